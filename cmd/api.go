@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/jumppad/pkg/api"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/spf13/cobra"
+)
+
+func newAPICmd(e jumppad.Engine, dc container.Docker, cc connector.Connector, l logger.Logger) *cobra.Command {
+	var bindAddr string
+
+	apiCmd := &cobra.Command{
+		Use:   "api",
+		Short: "Serve the jumppad engine as a local HTTP API",
+		Long: `Serve the jumppad engine as a local HTTP API.
+
+The API allows tools such as IDE extensions and workshop platforms to parse,
+create, and destroy environments, and to read the status, outputs, and logs
+without running the CLI. The Go client in the package
+github.com/jumppad-labs/jumppad/pkg/api can be used to call the API.
+
+Endpoints:
+  POST /v1/parse        parse a blueprint and return its resources
+  POST /v1/up           create the resources in a blueprint
+  POST /v1/down         destroy the resources in the environment
+  GET  /v1/status       return the resources in the state
+  GET  /v1/outputs      return the outputs, ?sensitive=true shows secrets
+  GET  /v1/logs/[id]    stream the container logs, ?follow=true follows
+
+Requests must send the token written to $HOME/.jumppad/api.token when the
+API starts as a bearer token, a new token is written each time the API starts.
+Requests with a body must set the Content-Type header to application/json.
+The API should only be bound to the loopback interface.`,
+		Example: `
+  # Serve the API on the default address
+  jumppad api
+
+  # Create the resources in a blueprint
+  curl -X POST localhost:9095/v1/up \
+    -H "Authorization: Bearer $(cat ~/.jumppad/api.token)" \
+    -H "Content-Type: application/json" \
+    -d '{"path": "./my-blueprint"}'
+	`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// ingress resources need the connector
+			err := startConnector(cc, l)
+			if err != nil {
+				return err
+			}
+
+			token, err := api.WriteToken()
+			if err != nil {
+				return err
+			}
+			defer api.RemoveToken()
+
+			s := api.New(bindAddr, token, e, dc, l)
+			s.SetOutputLister(func(cfg *hclconfig.Config, sensitive bool) map[string]any {
+				out, _ := outputValues(cfg, sensitive)
+				return out
+			})
+			s.SetContainerLister(getFQDNForResource)
+
+			errs := make(chan error, 1)
+			go func() {
+				errs <- s.Start()
+			}()
+
+			cmd.Printf("Serving the jumppad API on http://%s, press ctrl-c to stop\n", bindAddr)
+
+			sigs := make(chan os.Signal, 1)
+			signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+			select {
+			case err := <-errs:
+				return err
+			case <-sigs:
+				s.Stop()
+			}
+
+			return nil
+		},
+	}
+
+	apiCmd.Flags().StringVarP(&bindAddr, "bind", "", api.DefaultAddr, "Address the API listens on")
+
+	return apiCmd
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts"
+	"github.com/spf13/cobra"
+)
+
+func newCacheCmd(st artifacts.Store) *cobra.Command {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "List and remove downloaded blueprints, modules, Helm charts and files",
+		Long: `List and remove downloaded blueprints, modules, Helm charts and files.
+
+Remote content is downloaded once and stored in $HOME/.jumppad/artifacts by the
+hash of its content, environments that use the same source or content copy it
+from the store rather than downloading it again. Use --force-update with
+jumppad up to download the content again.`,
+	}
+
+	cacheCmd.AddCommand(newCacheListCmd(st))
+	cacheCmd.AddCommand(newCachePurgeCmd(st))
+
+	return cacheCmd
+}
+
+func newCacheListCmd(st artifacts.Store) *cobra.Command {
+	return &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "List the downloaded content in the cache",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			list, err := st.List()
+			if err != nil {
+				return err
+			}
+
+			if structuredOutput() {
+				return writeStructured(cmd.OutOrStdout(), cliFormat, list)
+			}
+
+			if len(list) == 0 {
+				cmd.Println("The cache is empty")
+				return nil
+			}
+
+			for _, a := range list {
+				cmd.Printf("%-50s %10s  %s  %s\n", a.Key, formatSize(a.Size), a.Used.Format("2006-01-02 15:04:05"), strings.Join(a.Sources, ", "))
+			}
+
+			return nil
+		},
+	}
+}
+
+func newCachePurgeCmd(st artifacts.Store) *cobra.Command {
+	var unused time.Duration
+
+	purgeCmd := &cobra.Command{
+		Use:   "purge [key]...",
+		Short: "Remove downloaded content from the cache",
+		Example: `
+  # Remove everything in the cache
+  jumppad cache purge
+
+  # Remove content that has not been used for 30 days
+  jumppad cache purge --unused 720h
+
+  # Remove a single entry
+  jumppad cache purge h1-2d3fcc1c4c4e5a6d0a7d44e3f2f0e0c9c8b3e7a1d2f4b5c6d7e8f9a0b1c2d3e4
+	`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				for _, k := range args {
+					err := st.Remove(k)
+					if err != nil {
+						return err
+					}
+
+					cmd.Printf("Removed %s\n", k)
+				}
+
+				return nil
+			}
+
+			removed, err := st.Purge(unused)
+			if err != nil {
+				return fmt.Errorf("unable to purge the cache: %s", err)
+			}
+
+			size := int64(0)
+			for _, a := range removed {
+				size += a.Size
+			}
+
+			cmd.Printf("Removed %d entries, %s\n", len(removed), formatSize(size))
+
+			return nil
+		},
+	}
+
+	purgeCmd.Flags().DurationVarP(&unused, "unused", "", 0, "Only remove content that has not been used for the given duration, e.g. 720h")
+
+	return purgeCmd
+}
+
+// formatSize returns the size in bytes as a human readable string
+func formatSize(b int64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%d B", b)
+	}
+
+	div, exp := int64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
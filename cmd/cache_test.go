@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts"
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts/mocks"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCache(t *testing.T, args ...string) (*cobra.Command, *bytes.Buffer, *mocks.Store) {
+	st := mocks.NewStore(t)
+
+	output := bytes.NewBuffer(nil)
+
+	c := newCacheCmd(st)
+	c.SetOut(output)
+	c.SetErr(output)
+	c.SetArgs(args)
+
+	return c, output, st
+}
+
+func TestCacheListShowsArtifacts(t *testing.T) {
+	c, output, st := setupCache(t, "ls")
+	st.On("List").Return([]artifacts.Artifact{
+		{
+			Key:     "h1-abc",
+			Size:    2048,
+			Used:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+			Sources: []string{"github.com/org/repo//consul", "https://example.com/consul.zip"},
+		},
+	}, nil)
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "h1-abc")
+	require.Contains(t, output.String(), "2.0 KiB")
+	require.Contains(t, output.String(), "2024-01-01 12:00:00")
+	require.Contains(t, output.String(), "github.com/org/repo//consul, https://example.com/consul.zip")
+}
+
+func TestCacheListWithNoArtifactsShowsEmpty(t *testing.T) {
+	c, output, st := setupCache(t, "ls")
+	st.On("List").Return([]artifacts.Artifact{}, nil)
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Equal(t, "The cache is empty\n", output.String())
+}
+
+func TestCachePurgeRemovesAll(t *testing.T) {
+	c, output, st := setupCache(t, "purge")
+	st.On("Purge", time.Duration(0)).Return([]artifacts.Artifact{{Key: "h1-abc", Size: 512}, {Key: "h1-def", Size: 512}}, nil)
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Equal(t, "Removed 2 entries, 1.0 KiB\n", output.String())
+}
+
+func TestCachePurgeWithUnusedRemovesUnusedArtifacts(t *testing.T) {
+	c, _, st := setupCache(t, "purge", "--unused", "720h")
+	st.On("Purge", 720*time.Hour).Return([]artifacts.Artifact{}, nil)
+
+	err := c.Execute()
+	require.NoError(t, err)
+}
+
+func TestCachePurgeWithKeyRemovesArtifact(t *testing.T) {
+	c, output, st := setupCache(t, "purge", "h1-abc")
+	st.On("Remove", "h1-abc").Return(nil)
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Equal(t, "Removed h1-abc\n", output.String())
+}
+
+func TestCachePurgeWithUnknownKeyReturnsError(t *testing.T) {
+	c, _, st := setupCache(t, "purge", "h1-abc")
+	st.On("Remove", "h1-abc").Return(fmt.Errorf("artifact h1-abc does not exist"))
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "does not exist")
+}
+
+func TestFormatSizeReturnsHumanReadableSize(t *testing.T) {
+	require.Equal(t, "512 B", formatSize(512))
+	require.Equal(t, "1.5 MiB", formatSize(1536*1024))
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig"
+	dtypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+)
+
+// default estimates for clusters that do not specify resource limits,
+// cpu is specified in the same units as the resources block, 1 CPU = 1000
+const (
+	defaultK8sClusterCPU       = 500
+	defaultK8sClusterMemory    = 2048
+	defaultNomadNodeCPU        = 500
+	defaultNomadNodeMemory     = 1024
+	capacityMemoryBytesPerUnit = 1000000 // docker uses 1MB = 1000000 bytes, see DockerTasks
+)
+
+// capacityRequirements is the estimated CPU and memory needed to create the
+// resources in a config
+type capacityRequirements struct {
+	// CPU is the sum of the CPU limits, 1 CPU = 1000
+	CPU int
+	// Memory is the sum of the memory limits in MB
+	Memory int
+
+	// Largest is the resource with the highest CPU limit and LargestCPU its limit,
+	// Docker refuses to create a container with more CPU than the host has
+	Largest    string
+	LargestCPU int
+}
+
+func (c *capacityRequirements) add(id string, cpu, memory int) {
+	c.CPU += cpu
+	c.Memory += memory
+
+	if cpu > c.LargestCPU {
+		c.Largest = id
+		c.LargestCPU = cpu
+	}
+}
+
+// estimateCapacity returns the CPU and memory needed by the resources in the
+// config, resources without limits are not counted except for clusters which
+// are estimated from the default size of a cluster node
+func estimateCapacity(cfg *hclconfig.Config) capacityRequirements {
+	req := capacityRequirements{}
+
+	for _, r := range cfg.Resources {
+		if r.GetDisabled() {
+			continue
+		}
+
+		id := r.Metadata().ID
+
+		switch v := r.(type) {
+		case *container.Container:
+			if v.Resources != nil {
+				req.add(id, v.Resources.CPU, v.Resources.Memory)
+			}
+		case *container.Sidecar:
+			if v.Resources != nil {
+				req.add(id, v.Resources.CPU, v.Resources.Memory)
+			}
+		case *k8s.Cluster:
+			if v.Resources != nil {
+				req.add(id, v.Resources.CPU, v.Resources.Memory)
+			} else {
+				req.add(id, defaultK8sClusterCPU, defaultK8sClusterMemory)
+			}
+		case *nomad.NomadCluster:
+			// the server runs workloads when there are no client nodes
+			for i := 0; i < v.ClientNodes+1; i++ {
+				if v.Resources != nil {
+					req.add(id, v.Resources.CPU, v.Resources.Memory)
+				} else {
+					req.add(id, defaultNomadNodeCPU, defaultNomadNodeMemory)
+				}
+			}
+		case *exec.Exec:
+			if v.Resources != nil {
+				req.add(id, v.Resources.CPU, v.Resources.Memory)
+			}
+		case *docs.Docs:
+			if v.Resources != nil {
+				req.add(id, v.Resources.CPU, v.Resources.Memory)
+			}
+		case *cache.ImageCache:
+			if v.Resources != nil {
+				req.add(id, v.Resources.CPU, v.Resources.Memory)
+			}
+		}
+	}
+
+	return req
+}
+
+// checkCapacity returns an error when the host does not have the memory
+// needed by the resources or a resource asks for more CPU than the host has,
+// the total CPU is not checked as containers share the CPU of the host
+func checkCapacity(req capacityRequirements, info *dtypes.EngineInfo) error {
+	// the engine could not report its capacity
+	if info == nil || info.CPU == 0 || info.Memory == 0 {
+		return nil
+	}
+
+	problems := []string{}
+
+	hostMemory := info.Memory / capacityMemoryBytesPerUnit
+	if req.Memory > hostMemory {
+		problems = append(problems, fmt.Sprintf("the resources need %dMB of memory, the Docker host has %dMB", req.Memory, hostMemory))
+	}
+
+	if req.LargestCPU > info.CPU*1000 {
+		problems = append(problems, fmt.Sprintf("%s needs %.1f CPUs, the Docker host has %d", req.Largest, float64(req.LargestCPU)/1000, info.CPU))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf(
+		"the Docker host does not have the capacity to create the resources, %s. Increase the resources available to Docker or use --force to create the resources anyway",
+		strings.Join(problems, ", "),
+	)
+}
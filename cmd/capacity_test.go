@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	dtypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/stretchr/testify/require"
+)
+
+func testCapacityConfig() *hclconfig.Config {
+	c := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.container.consul"}}}
+	c.Resources = &container.Resources{CPU: 2000, Memory: 512}
+
+	// resources without limits are not counted
+	c2 := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.container.vault"}}}
+
+	d := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.container.disabled"}, Disabled: true}}
+	d.Resources = &container.Resources{CPU: 8000, Memory: 8000}
+
+	k := &k8s.Cluster{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.k8s_cluster.k3s"}}}
+
+	n := &nomad.NomadCluster{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.nomad_cluster.dev"}}}
+	n.ClientNodes = 2
+
+	return &hclconfig.Config{Resources: []hcltypes.Resource{c, c2, d, k, n}}
+}
+
+func TestEstimateCapacitySumsResourceLimits(t *testing.T) {
+	req := estimateCapacity(testCapacityConfig())
+
+	require.Equal(t, 2000+defaultK8sClusterCPU+3*defaultNomadNodeCPU, req.CPU)
+	require.Equal(t, 512+defaultK8sClusterMemory+3*defaultNomadNodeMemory, req.Memory)
+	require.Equal(t, "resource.container.consul", req.Largest)
+	require.Equal(t, 2000, req.LargestCPU)
+}
+
+func TestCheckCapacityWithEnoughCapacityReturnsNil(t *testing.T) {
+	req := estimateCapacity(testCapacityConfig())
+
+	err := checkCapacity(req, &dtypes.EngineInfo{CPU: 4, Memory: 8000000000})
+	require.NoError(t, err)
+}
+
+func TestCheckCapacityWithoutEngineInfoReturnsNil(t *testing.T) {
+	req := estimateCapacity(testCapacityConfig())
+
+	err := checkCapacity(req, &dtypes.EngineInfo{})
+	require.NoError(t, err)
+}
+
+func TestCheckCapacityWithInsufficientMemoryReturnsError(t *testing.T) {
+	req := estimateCapacity(testCapacityConfig())
+
+	err := checkCapacity(req, &dtypes.EngineInfo{CPU: 4, Memory: 4000000000})
+	require.ErrorContains(t, err, "the resources need 5632MB of memory, the Docker host has 4000MB")
+	require.ErrorContains(t, err, "--force")
+}
+
+func TestCheckCapacityWithInsufficientCPUReturnsError(t *testing.T) {
+	req := estimateCapacity(testCapacityConfig())
+
+	err := checkCapacity(req, &dtypes.EngineInfo{CPU: 1, Memory: 8000000000})
+	require.ErrorContains(t, err, "resource.container.consul needs 2.0 CPUs, the Docker host has 1")
+}
+
+func TestEstimateCapacityUsesNomadNodeResources(t *testing.T) {
+	n := &nomad.NomadCluster{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.nomad_cluster.dev"}}}
+	n.ClientNodes = 2
+	n.Resources = &container.Resources{CPU: 1000, Memory: 512}
+
+	req := estimateCapacity(&hclconfig.Config{Resources: []hcltypes.Resource{n}})
+
+	require.Equal(t, 3000, req.CPU)
+	require.Equal(t, 1536, req.Memory)
+}
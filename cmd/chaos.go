@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"text/tabwriter"
+
+	"github.com/jumppad-labs/hclconfig"
+	chaosClient "github.com/jumppad-labs/jumppad/pkg/clients/chaos"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos"
+	"github.com/spf13/cobra"
+)
+
+// chaosStatus is the structured output for a chaos resource
+type chaosStatus struct {
+	ID       string   `json:"id" yaml:"id"`
+	Enabled  bool     `json:"enabled" yaml:"enabled"`
+	Mode     string   `json:"mode" yaml:"mode"`
+	Interval string   `json:"interval" yaml:"interval"`
+	Faults   []string `json:"faults" yaml:"faults"`
+	Log      string   `json:"log" yaml:"log"`
+}
+
+func newChaosCmd(dt container.ContainerTasks, dc container.Docker, l logger.Logger) *cobra.Command {
+	chaosCmd := &cobra.Command{
+		Use:   "chaos",
+		Short: "Enable, disable, and show the status of chaos resources",
+		Long: `Enable, disable, and show the status of chaos resources.
+
+Chaos resources inject faults into containers and pods on a schedule while the
+environment is running. Disabling a chaos resource stops new faults from being
+injected, faults that are in progress are recovered after their duration. The
+faults that have been injected are written to the log file for the resource.`,
+		Example: `
+  # Stop injecting faults into the environment
+  jumppad chaos disable
+
+  # Start injecting faults for a single chaos resource
+  jumppad chaos enable resource.chaos.api
+
+  # Show the chaos resources
+  jumppad chaos status
+	`,
+	}
+
+	chaosCmd.AddCommand(newChaosEnableCmd(true))
+	chaosCmd.AddCommand(newChaosEnableCmd(false))
+	chaosCmd.AddCommand(newChaosStatusCmd())
+	chaosCmd.AddCommand(newChaosRunCmd(dt, dc, l))
+
+	return chaosCmd
+}
+
+func newChaosEnableCmd(enabled bool) *cobra.Command {
+	use := "disable"
+	short := "Stop injecting faults for chaos resources"
+	done := "Disabled"
+	if enabled {
+		use = "enable"
+		short = "Start injecting faults for chaos resources"
+		done = "Enabled"
+	}
+
+	return &cobra.Command{
+		Use:          use + " [id...]",
+		Short:        short,
+		Long:         short + ", when no ids are given all chaos resources are changed",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadState()
+			if err != nil {
+				return fmt.Errorf("unable to load state, have you run jumppad up: %s", err)
+			}
+
+			res, err := chaosResources(cfg, args)
+			if err != nil {
+				return err
+			}
+
+			for _, r := range res {
+				err := chaosClient.SetEnabled(r.Meta.ID, enabled)
+				if err != nil {
+					return err
+				}
+
+				cmd.Printf("%s %s\n", done, r.Meta.ID)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newChaosStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:          "status",
+		Short:        "Show the chaos resources and whether they are enabled",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadState()
+			if err != nil {
+				return fmt.Errorf("unable to load state, have you run jumppad up: %s", err)
+			}
+
+			res, err := chaosResources(cfg, nil)
+			if err != nil {
+				return err
+			}
+
+			status := []chaosStatus{}
+			for _, r := range res {
+				status = append(status, newChaosStatus(r))
+			}
+
+			if structuredOutput() {
+				return writeStructured(cmd.OutOrStdout(), cliFormat, status)
+			}
+
+			writeChaosStatus(cmd.OutOrStdout(), status)
+
+			return nil
+		},
+	}
+}
+
+// newChaosRunCmd creates the command used by chaos resources to inject faults
+// in the background, it is not intended to be run by users
+func newChaosRunCmd(dt container.ContainerTasks, dc container.Docker, l logger.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:    "run [config]",
+		Short:  "Inject the faults for a chaos resource until stopped",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := chaosClient.ReadConfig(args[0])
+			if err != nil {
+				return err
+			}
+
+			// faults in progress are recovered when the injector is stopped
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			return chaosClient.NewInjector(c, dt, dc, l).Run(ctx)
+		},
+	}
+}
+
+// chaosResources returns the chaos resources in the state with the given ids,
+// all chaos resources are returned when no ids are given
+func chaosResources(cfg *hclconfig.Config, ids []string) ([]*chaos.Chaos, error) {
+	res := []*chaos.Chaos{}
+
+	if len(ids) == 0 {
+		rs, _ := cfg.FindResourcesByType(chaos.TypeChaos)
+		for _, r := range rs {
+			res = append(res, r.(*chaos.Chaos))
+		}
+
+		if len(res) == 0 {
+			return nil, fmt.Errorf("the environment does not have any chaos resources")
+		}
+
+		return res, nil
+	}
+
+	for _, id := range ids {
+		r, err := cfg.FindResource(id)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find resource %s: %s", id, err)
+		}
+
+		c, ok := r.(*chaos.Chaos)
+		if !ok {
+			return nil, fmt.Errorf("resource %s is not a chaos resource", id)
+		}
+
+		res = append(res, c)
+	}
+
+	return res, nil
+}
+
+func newChaosStatus(c *chaos.Chaos) chaosStatus {
+	faults := []string{}
+	for _, f := range c.Faults {
+		faults = append(faults, f.Type)
+	}
+
+	return chaosStatus{
+		ID:       c.Meta.ID,
+		Enabled:  chaosClient.Enabled(c.Meta.ID),
+		Mode:     c.Mode,
+		Interval: c.Interval,
+		Faults:   faults,
+		Log:      chaosClient.LogFile(c.Meta.ID),
+	}
+}
+
+func writeChaosStatus(w io.Writer, status []chaosStatus) {
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "ID\tSTATUS\tMODE\tINTERVAL\tFAULTS\tLOG")
+
+	for _, s := range status {
+		enabled := "disabled"
+		if s.Enabled {
+			enabled = "enabled"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", s.ID, enabled, s.Mode, s.Interval, strings.Join(s.Faults, ","), s.Log)
+	}
+
+	tw.Flush()
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	chaosClient "github.com/jumppad-labs/jumppad/pkg/clients/chaos"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func setupChaosConfig() *hclconfig.Config {
+	c := hclconfig.NewConfig()
+
+	ch := &chaos.Chaos{Mode: "all", Interval: "1m", Faults: []chaos.Fault{{Type: "kill"}, {Type: "pause"}}}
+	ch.Meta = hcltypes.Meta{ID: "resource.chaos.api", Name: "api", Type: chaos.TypeChaos, Properties: map[string]any{}}
+	c.AppendResource(ch)
+
+	n := &network.Network{}
+	n.Meta = hcltypes.Meta{ID: "resource.network.main", Name: "main", Type: network.TypeNetwork, Properties: map[string]any{}}
+	c.AppendResource(n)
+
+	return c
+}
+
+func TestChaosResourcesWithoutIDsReturnsAll(t *testing.T) {
+	res, err := chaosResources(setupChaosConfig(), nil)
+	require.NoError(t, err)
+
+	require.Len(t, res, 1)
+	require.Equal(t, "resource.chaos.api", res[0].Meta.ID)
+}
+
+func TestChaosResourcesWithIDReturnsResource(t *testing.T) {
+	res, err := chaosResources(setupChaosConfig(), []string{"resource.chaos.api"})
+	require.NoError(t, err)
+
+	require.Len(t, res, 1)
+}
+
+func TestChaosResourcesWithOtherTypeReturnsError(t *testing.T) {
+	_, err := chaosResources(setupChaosConfig(), []string{"resource.network.main"})
+	require.ErrorContains(t, err, "resource.network.main is not a chaos resource")
+}
+
+func TestChaosResourcesWithoutChaosReturnsError(t *testing.T) {
+	_, err := chaosResources(hclconfig.NewConfig(), nil)
+	require.ErrorContains(t, err, "does not have any chaos resources")
+}
+
+func TestWriteChaosStatusShowsDisabled(t *testing.T) {
+	t.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	err := chaosClient.SetEnabled("resource.chaos.api", false)
+	require.NoError(t, err)
+
+	res, err := chaosResources(setupChaosConfig(), nil)
+	require.NoError(t, err)
+
+	out := bytes.NewBufferString("")
+	writeChaosStatus(out, []chaosStatus{newChaosStatus(res[0])})
+
+	require.Contains(t, out.String(), "resource.chaos.api")
+	require.Contains(t, out.String(), "disabled")
+	require.Contains(t, out.String(), "kill,pause")
+}
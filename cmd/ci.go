@@ -0,0 +1,437 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jumppad-labs/gohup"
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
+	"github.com/jumppad-labs/jumppad/pkg/clients/getter"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// ciOptions are the flags for the ci command
+type ciOptions struct {
+	tests           []string
+	timeout         time.Duration
+	teardownTimeout time.Duration
+	junit           string
+	timing          string
+	variables       []string
+	variablesFile   string
+}
+
+func newCICmd(e jumppad.Engine, bp getter.Getter, cc connector.Connector, l logger.Logger) *cobra.Command {
+	o := &ciOptions{}
+
+	ciCmd := &cobra.Command{
+		Use:   "ci [file] | [directory]",
+		Short: "Create the resources, run the tests, and destroy the resources",
+		Long: `Create the resources, run the tests, and destroy the resources in a single invocation.
+
+The resources are always destroyed, even when creating the resources or the tests
+fail, the time budget is exceeded, or the command is interrupted. The output
+variables of the blueprint are set as environment variables for the tests.`,
+		Example: `
+  # Run the tests in a blueprint with a 20 minute budget and write a JUnit report
+  jumppad ci ./ --test "go test ./..." --timeout 20m --junit ./report.xml
+
+  # Run several test commands and write the time taken by each phase
+  jumppad ci ./ --test "./smoke.sh" --test "jumppad test --dont-destroy" --timing ./timing.json
+	`,
+		Args:         cobra.MaximumNArgs(1),
+		RunE:         newCICmdFunc(e, bp, cc, o, l),
+		SilenceUsage: true,
+	}
+
+	ciCmd.Flags().StringArrayVarP(&o.tests, "test", "", nil, "Command to run once the resources have been created, the command is run with the system shell and fails the run when it exits with a non zero code. Can be specified multiple times")
+	ciCmd.Flags().DurationVarP(&o.timeout, "timeout", "", 30*time.Minute, "Maximum time for creating the resources and running the tests, the resources are destroyed when the budget is exceeded")
+	ciCmd.Flags().DurationVarP(&o.teardownTimeout, "teardown-timeout", "", 10*time.Minute, "Maximum time for destroying the resources, this is not part of the time budget set by --timeout")
+	ciCmd.Flags().StringVarP(&o.junit, "junit", "", "", "Write a JUnit XML report containing a test case for each phase to the given file, e.g. --junit=./report.xml")
+	ciCmd.Flags().StringVarP(&o.timing, "timing", "", "", "Write a JSON report containing the time taken by each phase and resource to the given file, e.g. --timing=./timing.json")
+	ciCmd.Flags().StringSliceVarP(&o.variables, "var", "", nil, "Allows setting variables from the command line, variables are specified as a key and value, e.g --var key=value. Can be specified multiple times")
+	ciCmd.Flags().StringVarP(&o.variablesFile, "vars-file", "", "", "Load variables from a location other than *.vars files in the blueprint folder. E.g --vars-file=./file.vars")
+
+	return ciCmd
+}
+
+func newCICmdFunc(e jumppad.Engine, bp getter.Getter, cc connector.Connector, o *ciOptions, l logger.Logger) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		utils.CreateFolders()
+
+		dst := "./"
+		if len(args) == 1 && args[0] != "." {
+			dst = args[0]
+		}
+
+		vars := map[string]string{}
+		for _, v := range o.variables {
+			parts := strings.SplitN(strings.Trim(v, "'"), "=", 2)
+			if len(parts) == 2 {
+				vars[parts[0]] = parts[1]
+			}
+		}
+
+		if o.variablesFile != "" {
+			if _, err := os.Stat(o.variablesFile); err != nil {
+				return fmt.Errorf("variables file %s, does not exist", o.variablesFile)
+			}
+		}
+
+		// structured output only contains the report
+		out := cmd.OutOrStdout()
+		if structuredOutput() {
+			out = cmd.ErrOrStderr()
+		}
+
+		r := &ciRunner{
+			engine:    e,
+			getter:    bp,
+			connector: cc,
+			options:   o,
+			out:       out,
+			log:       l,
+		}
+
+		// interrupting the run cancels the current phase, the resources are
+		// still destroyed
+		done := make(chan os.Signal, 1)
+		signal.Notify(done, syscall.SIGINT, syscall.SIGTERM)
+		defer signal.Stop(done)
+
+		report := r.run(context.Background(), dst, vars, done)
+
+		if structuredOutput() {
+			err := writeStructured(cmd.OutOrStdout(), cliFormat, report)
+			if err != nil {
+				l.Error("Unable to write output", "error", err)
+			}
+		}
+
+		if !report.Success {
+			return errors.New(report.Error)
+		}
+
+		return nil
+	}
+}
+
+// ciPhase is the outcome of a single step of a ci run
+type ciPhase struct {
+	Name     string  `json:"name" yaml:"name"`
+	Success  bool    `json:"success" yaml:"success"`
+	Skipped  bool    `json:"skipped,omitempty" yaml:"skipped,omitempty"`
+	Error    string  `json:"error,omitempty" yaml:"error,omitempty"`
+	Duration float64 `json:"duration_seconds" yaml:"duration_seconds"`
+
+	// output of test commands, included in the JUnit report
+	output string
+}
+
+// ciReport is the timing report for a ci run
+type ciReport struct {
+	Success   bool             `json:"success" yaml:"success"`
+	Error     string           `json:"error,omitempty" yaml:"error,omitempty"`
+	Duration  float64          `json:"duration_seconds" yaml:"duration_seconds"`
+	Budget    float64          `json:"budget_seconds" yaml:"budget_seconds"`
+	Phases    []ciPhase        `json:"phases" yaml:"phases"`
+	Resources []resourceReport `json:"resources" yaml:"resources"`
+}
+
+// ciRunner creates the resources, runs the tests, and destroys the resources
+type ciRunner struct {
+	engine    jumppad.Engine
+	getter    getter.Getter
+	connector connector.Connector
+	options   *ciOptions
+	out       io.Writer
+	log       logger.Logger
+}
+
+// run executes the phases of the run, the resources are destroyed regardless
+// of the outcome of the earlier phases or a signal being received on done
+func (r *ciRunner) run(ctx context.Context, dst string, vars map[string]string, done <-chan os.Signal) ciReport {
+	start := time.Now()
+	report := ciReport{
+		Budget:    r.options.timeout.Seconds(),
+		Phases:    []ciPhase{},
+		Resources: []resourceReport{},
+	}
+
+	ctx, interrupt := context.WithCancelCause(ctx)
+	defer interrupt(nil)
+
+	budget, cancel := context.WithTimeoutCause(ctx, r.options.timeout, fmt.Errorf("time budget of %s exceeded", r.options.timeout))
+	defer cancel()
+
+	go func() {
+		select {
+		case <-done:
+			r.log.Info("Interrupted, cancelling run and destroying resources")
+			interrupt(errors.New("run was interrupted"))
+		case <-budget.Done():
+		}
+	}()
+
+	var failure error
+
+	// record the outcome of a phase, when the budget has been exceeded or the
+	// run was interrupted the cause is recorded instead of the phase error
+	record := func(p ciPhase, err error) {
+		if err != nil && budget.Err() != nil {
+			err = context.Cause(budget)
+		}
+
+		p.Success = err == nil
+		if err != nil {
+			p.Error = err.Error()
+
+			if failure == nil {
+				failure = fmt.Errorf("%s failed: %s", p.Name, err)
+			}
+		}
+
+		report.Phases = append(report.Phases, p)
+	}
+
+	cfg, err := r.apply(budget, dst, vars, &report)
+	record(ciPhase{Name: "up", Duration: time.Since(start).Seconds()}, err)
+
+	env := outputEnvironment(cfg)
+	for _, t := range r.options.tests {
+		if failure != nil {
+			report.Phases = append(report.Phases, ciPhase{Name: "test: " + t, Skipped: true})
+			continue
+		}
+
+		ts := time.Now()
+		out, err := r.runTest(budget, t, env)
+		record(ciPhase{Name: "test: " + t, Duration: time.Since(ts).Seconds(), output: out}, err)
+	}
+
+	// the teardown has its own timeout so the error is not replaced with the
+	// cause of the budget being cancelled
+	ds := time.Now()
+	err = r.teardown()
+
+	down := ciPhase{Name: "down", Success: err == nil, Duration: time.Since(ds).Seconds()}
+	if err != nil {
+		down.Error = err.Error()
+
+		if failure == nil {
+			failure = fmt.Errorf("down failed: %s", err)
+		}
+	}
+
+	report.Phases = append(report.Phases, down)
+
+	report.Duration = time.Since(start).Seconds()
+	report.Success = failure == nil
+	if failure != nil {
+		report.Error = failure.Error()
+	}
+
+	r.writeArtifacts(report)
+
+	return report
+}
+
+// apply creates the resources, the results for each resource are added to
+// the report
+func (r *ciRunner) apply(ctx context.Context, dst string, vars map[string]string, report *ciReport) (*hclconfig.Config, error) {
+	fmt.Fprintln(r.out, "Creating resources from", dst)
+
+	err := startConnector(r.connector, r.log)
+	if err != nil {
+		return nil, err
+	}
+
+	if !utils.IsLocalFolder(dst) && !utils.IsHCLFile(dst) {
+		err := r.getter.Get(dst, utils.BlueprintLocalFolder(dst))
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve blueprint: %s", err)
+		}
+
+		dst = utils.BlueprintLocalFolder(dst)
+	}
+
+	cfg, err := r.engine.ApplyWithVariables(ctx, dst, vars, r.options.variablesFile)
+	report.Resources = newCommandReport("up", r.engine.Results(), time.Now(), err).Resources
+
+	reportToGitHubActions(r.out, r.engine, cfg, err, r.log)
+
+	return cfg, err
+}
+
+// runTest runs the command with the system shell, the output is written to
+// the command output and returned for the JUnit report
+func (r *ciRunner) runTest(ctx context.Context, test string, env []string) (string, error) {
+	fmt.Fprintln(r.out, "Running test", test)
+
+	shell, flag := "sh", "-c"
+	if runtime.GOOS == "windows" {
+		shell, flag = "cmd", "/C"
+	}
+
+	out := bytes.NewBuffer(nil)
+
+	c := exec.CommandContext(ctx, shell, flag, test)
+	c.Env = append(os.Environ(), env...)
+	c.Stdout = io.MultiWriter(r.out, out)
+	c.Stderr = io.MultiWriter(r.out, out)
+
+	// kill the test and any processes it started when the budget is exceeded,
+	// processes can keep the output open after the test exits, do not wait
+	// for them
+	c.SysProcAttr = gohup.SetSysProcAttr()
+	c.Cancel = func() error {
+		gohup.Kill(c.Process)
+		return nil
+	}
+	c.WaitDelay = time.Second
+
+	err := c.Run()
+
+	return out.String(), err
+}
+
+// teardown destroys the resources, it is not part of the time budget so
+// that resources are removed when the budget has been exceeded
+func (r *ciRunner) teardown() error {
+	fmt.Fprintln(r.out, "Destroying resources")
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.options.teardownTimeout)
+	defer cancel()
+
+	err := r.engine.Destroy(ctx, true)
+	if err != nil {
+		return err
+	}
+
+	removeEnvironmentData(r.connector, r.log)
+
+	return nil
+}
+
+// writeArtifacts writes the JUnit and timing reports, errors are logged as
+// the reports must not change the outcome of the run
+func (r *ciRunner) writeArtifacts(report ciReport) {
+	if r.options.timing != "" {
+		d, _ := json.MarshalIndent(report, "", "  ")
+
+		err := os.WriteFile(r.options.timing, d, 0644)
+		if err != nil {
+			r.log.Error("Unable to write timing report", "file", r.options.timing, "error", err)
+		}
+	}
+
+	if r.options.junit != "" {
+		err := os.WriteFile(r.options.junit, junitReport(report), 0644)
+		if err != nil {
+			r.log.Error("Unable to write JUnit report", "file", r.options.junit, "error", err)
+		}
+	}
+}
+
+// outputEnvironment returns the root output variables as environment
+// variables, values that are not strings are JSON encoded
+func outputEnvironment(cfg *hclconfig.Config) []string {
+	env := []string{}
+	if cfg == nil {
+		return env
+	}
+
+	for _, r := range cfg.Resources {
+		if r.Metadata().Type != resources.TypeOutput || r.Metadata().Module != "" || r.GetDisabled() {
+			continue
+		}
+
+		v := r.(*resources.Output).Value
+		if s, ok := v.(string); ok {
+			env = append(env, fmt.Sprintf("%s=%s", r.Metadata().Name, s))
+			continue
+		}
+
+		d, _ := json.Marshal(v)
+		env = append(env, fmt.Sprintf("%s=%s", r.Metadata().Name, d))
+	}
+
+	return env
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+}
+
+// junitReport returns a JUnit XML report with a test case for each phase
+func junitReport(report ciReport) []byte {
+	suite := junitTestSuite{
+		Name:  "jumppad ci",
+		Tests: len(report.Phases),
+		Time:  fmt.Sprintf("%.3f", report.Duration),
+	}
+
+	for _, p := range report.Phases {
+		tc := junitTestCase{
+			Name:      p.Name,
+			ClassName: "jumppad.ci",
+			Time:      fmt.Sprintf("%.3f", p.Duration),
+			SystemOut: p.output,
+		}
+
+		switch {
+		case p.Skipped:
+			tc.Skipped = &struct{}{}
+			suite.Skipped++
+		case !p.Success:
+			tc.Failure = &junitFailure{Message: p.Error}
+			suite.Failures++
+		}
+
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	d, _ := xml.MarshalIndent(junitTestSuites{Suites: []junitTestSuite{suite}}, "", "  ")
+
+	return append([]byte(xml.Header), d...)
+}
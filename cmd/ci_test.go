@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/hclconfig/types"
+	conmock "github.com/jumppad-labs/jumppad/pkg/clients/connector/mocks"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+	gettermock "github.com/jumppad-labs/jumppad/pkg/clients/getter/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	enginemocks "github.com/jumppad-labs/jumppad/pkg/jumppad/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupCI(t *testing.T) (*cobra.Command, *enginemocks.Engine, *bytes.Buffer) {
+	t.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	// the annotations and outputs are only written in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	out := &resources.Output{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "ADDRESS", Type: resources.TypeOutput}}}
+	out.Value = "localhost:8080"
+
+	cfg := hclconfig.NewConfig()
+	cfg.AppendResource(out)
+
+	me := &enginemocks.Engine{}
+	me.On("ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(cfg, nil)
+	me.On("Destroy", mock.Anything, true).Return(nil)
+	me.On("Results").Return([]jumppad.ResourceResult{{ID: "resource.container.app", Type: "container", Status: "created", Duration: 2 * time.Second}})
+
+	mg := &gettermock.Getter{}
+	mg.On("Get", mock.Anything, mock.Anything).Return(nil)
+
+	mc := &conmock.Connector{}
+	mc.On("GetLocalCertBundle", mock.Anything).Return(&ctypes.CertBundle{}, nil)
+	mc.On("IsRunning").Return(true)
+	mc.On("Stop").Return(nil)
+
+	output := bytes.NewBuffer(nil)
+
+	c := newCICmd(me, mg, mc, logger.NewTestLogger(t))
+	c.SetOut(output)
+	c.SetErr(output)
+
+	return c, me, output
+}
+
+func TestCIAppliesRunsTestsAndDestroys(t *testing.T) {
+	c, me, output := setupCI(t)
+	c.SetArgs([]string{"/tmp", "--test", "echo running tests"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	me.AssertCalled(t, "ApplyWithVariables", mock.Anything, "/tmp", map[string]string{}, "")
+	me.AssertCalled(t, "Destroy", mock.Anything, true)
+	require.Contains(t, output.String(), "running tests")
+}
+
+func TestCISetsOutputsAsEnvironmentVariables(t *testing.T) {
+	c, _, output := setupCI(t)
+	c.SetArgs([]string{"/tmp", "--test", "echo address=$ADDRESS"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "address=localhost:8080")
+}
+
+func TestCIApplyFailsDestroysAndSkipsTests(t *testing.T) {
+	c, me, output := setupCI(t)
+	testutils.RemoveOn(&me.Mock, "ApplyWithVariables")
+	me.On("ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+	c.SetArgs([]string{"/tmp", "--test", "echo running tests"})
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "up failed: boom")
+
+	me.AssertCalled(t, "Destroy", mock.Anything, true)
+	require.NotContains(t, output.String(), "Running test")
+}
+
+func TestCITestFailsDestroysAndReturnsError(t *testing.T) {
+	c, me, _ := setupCI(t)
+	c.SetArgs([]string{"/tmp", "--test", "exit 3"})
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "test: exit 3 failed")
+
+	me.AssertCalled(t, "Destroy", mock.Anything, true)
+}
+
+func TestCIDestroyFailsReturnsError(t *testing.T) {
+	c, me, _ := setupCI(t)
+	testutils.RemoveOn(&me.Mock, "Destroy")
+	me.On("Destroy", mock.Anything, true).Return(fmt.Errorf("boom"))
+
+	c.SetArgs([]string{"/tmp"})
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "down failed: boom")
+}
+
+func TestCIExceedingBudgetStopsTestsAndDestroys(t *testing.T) {
+	c, me, _ := setupCI(t)
+	c.SetArgs([]string{"/tmp", "--test", "sleep 10", "--timeout", "200ms"})
+
+	start := time.Now()
+	err := c.Execute()
+	require.ErrorContains(t, err, "time budget of 200ms exceeded")
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	me.AssertCalled(t, "Destroy", mock.Anything, true)
+}
+
+func TestCIInterruptStopsTestsAndDestroys(t *testing.T) {
+	_, me, output := setupCI(t)
+
+	mc := &conmock.Connector{}
+	mc.On("GetLocalCertBundle", mock.Anything).Return(&ctypes.CertBundle{}, nil)
+	mc.On("IsRunning").Return(true)
+	mc.On("Stop").Return(nil)
+
+	r := &ciRunner{
+		engine:    me,
+		connector: mc,
+		options:   &ciOptions{tests: []string{"sleep 10"}, timeout: time.Minute, teardownTimeout: time.Minute},
+		out:       output,
+		log:       logger.NewTestLogger(t),
+	}
+
+	done := make(chan os.Signal, 1)
+	done <- syscall.SIGINT
+
+	report := r.run(context.Background(), "/tmp", map[string]string{}, done)
+	require.False(t, report.Success)
+	require.Contains(t, report.Error, "run was interrupted")
+
+	me.AssertCalled(t, "Destroy", mock.Anything, true)
+}
+
+func TestCIWritesTimingReport(t *testing.T) {
+	c, _, _ := setupCI(t)
+	timing := filepath.Join(t.TempDir(), "timing.json")
+	c.SetArgs([]string{"/tmp", "--test", "exit 0", "--timing", timing})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(timing)
+	require.NoError(t, err)
+
+	r := ciReport{}
+	err = json.Unmarshal(d, &r)
+	require.NoError(t, err)
+
+	require.True(t, r.Success)
+	require.Equal(t, float64(1800), r.Budget)
+	require.Len(t, r.Phases, 3)
+	require.Equal(t, "up", r.Phases[0].Name)
+	require.Equal(t, "test: exit 0", r.Phases[1].Name)
+	require.Equal(t, "down", r.Phases[2].Name)
+	require.Equal(t, "resource.container.app", r.Resources[0].ID)
+	require.Equal(t, float64(2), r.Resources[0].Duration)
+}
+
+func TestCIWritesJUnitReport(t *testing.T) {
+	c, _, _ := setupCI(t)
+	junit := filepath.Join(t.TempDir(), "report.xml")
+	c.SetArgs([]string{"/tmp", "--test", "echo failing && exit 1", "--test", "exit 0", "--junit", junit})
+
+	err := c.Execute()
+	require.Error(t, err)
+
+	d, err := os.ReadFile(junit)
+	require.NoError(t, err)
+
+	require.Contains(t, string(d), `<testsuite name="jumppad ci" tests="4" failures="1" skipped="1"`)
+	require.Contains(t, string(d), `<failure message="exit status 1"></failure>`)
+	require.Contains(t, string(d), `<system-out>failing`)
+	require.Contains(t, string(d), `<testcase name="test: exit 0" classname="jumppad.ci" time="0.000">`)
+}
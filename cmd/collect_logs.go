@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logcollector"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+	"github.com/spf13/cobra"
+)
+
+// newCollectLogsCmd creates the command used by cluster resources to write
+// the logs of their workloads to files, it is not intended to be run by users
+func newCollectLogsCmd(kc k8s.Kubernetes, nc nomad.Nomad, l logger.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:    "collect-logs [config]",
+		Short:  "Write the logs of all workloads in a cluster to files",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := logcollector.ReadConfig(args[0])
+			if err != nil {
+				return err
+			}
+
+			var source logcollector.Source
+
+			switch c.Type {
+			case logcollector.TypeKubernetes:
+				kc, err := kc.SetConfig(c.KubeConfig)
+				if err != nil {
+					return fmt.Errorf("unable to create Kubernetes client: %s", err)
+				}
+
+				source = logcollector.NewKubernetesSource(kc)
+			case logcollector.TypeNomad:
+				nc.SetConfig(c.NomadAddress, c.NomadPort, 0)
+				source = logcollector.NewNomadSource(nc)
+			default:
+				return fmt.Errorf("unknown cluster type %s", c.Type)
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			return logcollector.NewCollector(source, c.Directory, 5*time.Second, l).Run(ctx)
+		},
+	}
+}
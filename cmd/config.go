@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/settings"
+	"github.com/spf13/cobra"
+)
+
+func newConfigCmd(path string) *cobra.Command {
+	configCmd := &cobra.Command{
+		Use:   "config",
+		Short: "Read and change the jumppad settings",
+		Long: fmt.Sprintf(`Read and change the jumppad settings.
+
+Settings are stored in %s and provide the defaults for the
+container driver and socket, registry mirrors, proxy servers, telemetry, strict
+checking, and blueprint variables. Environment variables and command line flags take
+precedence over the settings.
+
+Valid settings are:
+  %s
+  variables.<name>`, path, strings.Join(settings.Keys, "\n  ")),
+	}
+
+	configCmd.AddCommand(newConfigSetCmd(path))
+	configCmd.AddCommand(newConfigGetCmd(path))
+	configCmd.AddCommand(newConfigListCmd(path))
+
+	return configCmd
+}
+
+func newConfigSetCmd(path string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "set [key] [value]",
+		Short: "Change a setting, an empty value removes the setting",
+		Example: `
+  # Use Podman to run resources
+  jumppad config set driver podman
+
+  # Use the Docker socket created by Colima
+  jumppad config set socket ~/.colima/default/docker.sock
+
+  # Set a default value for a blueprint variable
+  jumppad config set variables.consul_version 1.16.1
+
+  # Remove the proxy setting
+  jumppad config set proxy.http ""
+	`,
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := settings.Load(path)
+			if err != nil {
+				return err
+			}
+
+			err = s.Set(args[0], args[1])
+			if err != nil {
+				return err
+			}
+
+			err = s.Save(path)
+			if err != nil {
+				return fmt.Errorf("unable to save settings: %s", err)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newConfigGetCmd(path string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [key]",
+		Short: "Show the value of a setting",
+		Example: `
+  jumppad config get driver
+	`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := settings.Load(path)
+			if err != nil {
+				return err
+			}
+
+			v, err := s.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Println(v)
+
+			return nil
+		},
+	}
+}
+
+func newConfigListCmd(path string) *cobra.Command {
+	return &cobra.Command{
+		Use:          "list",
+		Short:        "List the settings that have been set",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := settings.Load(path)
+			if err != nil {
+				return err
+			}
+
+			for _, k := range s.List() {
+				v, _ := s.Get(k)
+				cmd.Printf("%s=%s\n", k, v)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func setupConfig(t *testing.T, args ...string) (*cobra.Command, *bytes.Buffer, string) {
+	path := filepath.Join(t.TempDir(), "config.hcl")
+
+	output := bytes.NewBuffer(nil)
+
+	c := newConfigCmd(path)
+	c.SetOut(output)
+	c.SetErr(output)
+	c.SetArgs(args)
+
+	return c, output, path
+}
+
+func TestConfigSetWritesSettings(t *testing.T) {
+	c, _, path := setupConfig(t, "set", "driver", "podman")
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Contains(t, string(d), `driver = "podman"`)
+}
+
+func TestConfigSetInvalidValueReturnsError(t *testing.T) {
+	c, _, path := setupConfig(t, "set", "driver", "containerd")
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "invalid driver containerd")
+	require.NoFileExists(t, path)
+}
+
+func TestConfigGetShowsValue(t *testing.T) {
+	c, output, path := setupConfig(t, "get", "variables.version")
+	os.WriteFile(path, []byte("variables = {\n  version = \"1.2.0\"\n}\n"), 0644)
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Equal(t, "1.2.0\n", output.String())
+}
+
+func TestConfigListShowsSettings(t *testing.T) {
+	c, output, path := setupConfig(t, "list")
+	os.WriteFile(path, []byte("driver = \"podman\"\ntelemetry = false\n"), 0644)
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Equal(t, "driver=podman\ntelemetry=false\n", output.String())
+}
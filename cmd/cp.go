@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/spf13/cobra"
+)
+
+// copyTimeout is the time in seconds that commands used to copy files
+// are allowed to run
+const copyTimeout = 300
+
+type copyOptions struct {
+	node  string
+	alloc string
+	task  string
+}
+
+func newCpCmd(ct container.ContainerTasks) *cobra.Command {
+	opts := copyOptions{}
+
+	cpCmd := &cobra.Command{
+		Use:   "cp [source] [destination]",
+		Short: "Copy files and directories to and from resources",
+		Long: `Copy files and directories to and from resources, the resource side of
+the copy is specified as resource:path.
+
+When copying to a resource the destination is the directory that the files are
+copied into. Files can be copied to and from any node in a cluster using the
+--node flag, and to and from Nomad allocations using the --alloc and --task flags.`,
+		Example: `
+  # Copy a file from a container
+  jumppad cp resource.container.app:/var/log/app.log ./app.log
+
+  # Copy a directory to a container
+  jumppad cp ./testdata resource.container.app:/tmp
+
+  # Copy a file to the first client node of a Nomad cluster
+  jumppad cp ./config.hcl resource.nomad_cluster.dev:/etc/nomad.d --node 1.client
+
+  # Copy a file from a task in a Nomad allocation
+  jumppad cp resource.nomad_cluster.dev:/local/config.json ./config.json --alloc 5a9a4c1e --task app
+	`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: getResources,
+		RunE:              newCpCmdFunc(ct, &opts),
+		SilenceUsage:      true,
+	}
+
+	cpCmd.Flags().StringVarP(&opts.node, "node", "", "", "Name of the cluster node to copy to or from, i.e. server, 1.client")
+	cpCmd.Flags().StringVarP(&opts.alloc, "alloc", "", "", "ID of the Nomad allocation to copy to or from, only valid for nomad_cluster resources")
+	cpCmd.Flags().StringVarP(&opts.task, "task", "", "", "Name of the task in the Nomad allocation")
+
+	return cpCmd
+}
+
+func newCpCmdFunc(ct container.ContainerTasks, opts *copyOptions) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		srcResource, srcPath := splitCopyPath(args[0])
+		dstResource, dstPath := splitCopyPath(args[1])
+
+		if srcResource != "" && dstResource != "" {
+			return errors.New("copying between resources is not supported, either the source or destination must be a local path")
+		}
+
+		if srcResource == "" && dstResource == "" {
+			return errors.New("either the source or destination must be a resource, i.e. resource.container.app:/path")
+		}
+
+		id := srcResource
+		if id == "" {
+			id = dstResource
+		}
+
+		cfg, err := config.LoadState()
+		if err != nil {
+			return errors.New("unable to read state file")
+		}
+
+		r, err := cfg.FindResource(id)
+		if err != nil {
+			return fmt.Errorf("%s not found: %s", id, err)
+		}
+
+		name, err := copyTarget(r, *opts)
+		if err != nil {
+			return err
+		}
+
+		ids, err := ct.FindContainerIDs(name)
+		if err != nil || len(ids) == 0 {
+			return fmt.Errorf("unable to find container %s for %s, is the resource running?", name, id)
+		}
+
+		switch {
+		case opts.alloc != "" && srcResource != "":
+			err = copyFromAllocation(ct, ids[0], *opts, srcPath, dstPath)
+		case opts.alloc != "":
+			err = copyToAllocation(ct, ids[0], *opts, srcPath, dstPath)
+		case srcResource != "":
+			err = ct.CopyFromContainer(ids[0], srcPath, dstPath)
+		default:
+			err = copyToContainer(ct, ids[0], srcPath, dstPath)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		cmd.Printf("Copied %s to %s\n", args[0], args[1])
+
+		return nil
+	}
+}
+
+// splitCopyPath splits a copy argument into the resource and path, when the
+// argument is a local path the returned resource is empty
+func splitCopyPath(arg string) (string, string) {
+	i := strings.Index(arg, ":")
+	if i < 0 {
+		return "", arg
+	}
+
+	if !strings.HasPrefix(arg, "resource.") && !strings.HasPrefix(arg, "module.") {
+		return "", arg
+	}
+
+	return arg[:i], arg[i+1:]
+}
+
+// copyTarget returns the name of the container that files are copied to or
+// from for the resource
+func copyTarget(r hcltypes.Resource, opts copyOptions) (string, error) {
+	if r.GetDisabled() {
+		return "", fmt.Errorf("%s is disabled", r.Metadata().ID)
+	}
+
+	names := getFQDNForResource(r)
+	if len(names) == 0 {
+		return "", fmt.Errorf("%s is a %s resource, only resources that run containers support copying files", r.Metadata().ID, r.Metadata().Type)
+	}
+
+	if opts.alloc != "" {
+		if r.Metadata().Type != nomad.TypeNomadCluster {
+			return "", fmt.Errorf("--alloc can only be used with %s resources", nomad.TypeNomadCluster)
+		}
+
+		if opts.task == "" {
+			return "", errors.New("--task must be specified when copying to or from an allocation")
+		}
+
+		// allocation commands are run using the nomad cli on the server
+		return names[0], nil
+	}
+
+	if opts.node == "" {
+		return names[0], nil
+	}
+
+	for _, n := range names {
+		if strings.HasPrefix(n, opts.node+".") {
+			return n, nil
+		}
+	}
+
+	return "", fmt.Errorf("node %s not found for %s", opts.node, r.Metadata().ID)
+}
+
+// copyToContainer copies the file or directory at src into the directory dst
+// in the container
+func copyToContainer(ct container.ContainerTasks, id, src, dst string) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %s", src, err)
+	}
+
+	if !fi.IsDir() {
+		return ct.CopyFileToContainer(id, src, dst)
+	}
+
+	// files can only be copied to directories that exist, create the
+	// directory tree before copying the files
+	return filepath.WalkDir(src, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(filepath.Dir(src), p)
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			_, err := ct.ExecuteCommand(id, []string{"mkdir", "-p", path.Join(dst, filepath.ToSlash(rel))}, nil, "", "", "", copyTimeout, nil)
+			if err != nil {
+				return fmt.Errorf("unable to create directory %s: %s", rel, err)
+			}
+
+			return nil
+		}
+
+		return ct.CopyFileToContainer(id, p, path.Join(dst, filepath.ToSlash(filepath.Dir(rel))))
+	})
+}
+
+// copyFromAllocation copies a file from a task in a Nomad allocation to dst
+func copyFromAllocation(ct container.ContainerTasks, id string, opts copyOptions, src, dst string) error {
+	f, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("unable to create %s: %s", dst, err)
+	}
+	defer f.Close()
+
+	_, err = ct.ExecuteCommand(id, allocReadCommand(opts, src), nil, "", "", "", copyTimeout, f)
+	if err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("unable to copy %s from allocation %s: %s", src, opts.alloc, err)
+	}
+
+	return nil
+}
+
+// copyToAllocation copies the file src into the directory dst in a task in a
+// Nomad allocation, the file is first copied to the server and then streamed
+// to the task
+func copyToAllocation(ct container.ContainerTasks, id string, opts copyOptions, src, dst string) error {
+	fi, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %s", src, err)
+	}
+
+	if fi.IsDir() {
+		return errors.New("only files can be copied to an allocation")
+	}
+
+	err = ct.CopyFileToContainer(id, src, "/tmp")
+	if err != nil {
+		return err
+	}
+
+	tmp := path.Join("/tmp", fi.Name())
+	defer ct.ExecuteCommand(id, []string{"rm", "-f", tmp}, nil, "", "", "", copyTimeout, nil)
+
+	_, err = ct.ExecuteCommand(id, allocWriteCommand(opts, tmp, path.Join(dst, fi.Name())), nil, "", "", "", copyTimeout, nil)
+	if err != nil {
+		return fmt.Errorf("unable to copy %s to allocation %s: %s", src, opts.alloc, err)
+	}
+
+	return nil
+}
+
+// allocReadCommand returns the command that writes the file at path in the
+// allocations task to stdout
+func allocReadCommand(opts copyOptions, file string) []string {
+	return []string{"nomad", "alloc", "exec", "-i=false", "-t=false", "-task", opts.task, opts.alloc, "cat", file}
+}
+
+// allocWriteCommand returns the command that writes the file src on the server
+// to dst in the allocations task
+func allocWriteCommand(opts copyOptions, src, dst string) []string {
+	return []string{
+		"sh", "-c",
+		fmt.Sprintf("nomad alloc exec -i=true -t=false -task %s %s sh -c 'cat > %s' < %s", opts.task, opts.alloc, dst, src),
+	}
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	ct "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitCopyPathWithResource(t *testing.T) {
+	r, p := splitCopyPath("resource.container.app:/var/log/app.log")
+	require.Equal(t, "resource.container.app", r)
+	require.Equal(t, "/var/log/app.log", p)
+}
+
+func TestSplitCopyPathWithModuleResource(t *testing.T) {
+	r, p := splitCopyPath("module.web.resource.container.app:/tmp")
+	require.Equal(t, "module.web.resource.container.app", r)
+	require.Equal(t, "/tmp", p)
+}
+
+func TestSplitCopyPathWithLocalPath(t *testing.T) {
+	r, p := splitCopyPath("./data:backup")
+	require.Empty(t, r)
+	require.Equal(t, "./data:backup", p)
+}
+
+func TestCopyTargetContainer(t *testing.T) {
+	c := &ct.Container{}
+	c.Meta = hcltypes.Meta{ID: "resource.container.app", Name: "app", Type: ct.TypeContainer}
+
+	name, err := copyTarget(c, copyOptions{})
+	require.NoError(t, err)
+	require.Equal(t, utils.FQDN("app", "", ct.TypeContainer), name)
+}
+
+func TestCopyTargetNomadClientNode(t *testing.T) {
+	n := &nomad.NomadCluster{ClientNodes: 2}
+	n.Meta = hcltypes.Meta{ID: "resource.nomad_cluster.dev", Name: "dev", Type: nomad.TypeNomadCluster}
+
+	name, err := copyTarget(n, copyOptions{node: "2.client"})
+	require.NoError(t, err)
+	require.Equal(t, "2.client."+utils.FQDN("dev", "", nomad.TypeNomadCluster), name)
+}
+
+func TestCopyTargetUnknownNodeReturnsError(t *testing.T) {
+	n := &nomad.NomadCluster{ClientNodes: 1}
+	n.Meta = hcltypes.Meta{ID: "resource.nomad_cluster.dev", Name: "dev", Type: nomad.TypeNomadCluster}
+
+	_, err := copyTarget(n, copyOptions{node: "3.client"})
+	require.ErrorContains(t, err, "node 3.client not found")
+}
+
+func TestCopyTargetAllocationUsesServer(t *testing.T) {
+	n := &nomad.NomadCluster{ClientNodes: 1}
+	n.Meta = hcltypes.Meta{ID: "resource.nomad_cluster.dev", Name: "dev", Type: nomad.TypeNomadCluster}
+
+	name, err := copyTarget(n, copyOptions{alloc: "5a9a4c1e", task: "app"})
+	require.NoError(t, err)
+	require.Equal(t, "server."+utils.FQDN("dev", "", nomad.TypeNomadCluster), name)
+}
+
+func TestCopyTargetAllocationWithContainerReturnsError(t *testing.T) {
+	c := &ct.Container{}
+	c.Meta = hcltypes.Meta{ID: "resource.container.app", Name: "app", Type: ct.TypeContainer}
+
+	_, err := copyTarget(c, copyOptions{alloc: "5a9a4c1e", task: "app"})
+	require.ErrorContains(t, err, "--alloc can only be used with nomad_cluster resources")
+}
+
+func TestCopyTargetAllocationWithoutTaskReturnsError(t *testing.T) {
+	n := &nomad.NomadCluster{}
+	n.Meta = hcltypes.Meta{ID: "resource.nomad_cluster.dev", Name: "dev", Type: nomad.TypeNomadCluster}
+
+	_, err := copyTarget(n, copyOptions{alloc: "5a9a4c1e"})
+	require.ErrorContains(t, err, "--task must be specified")
+}
+
+func TestCopyTargetWithoutContainerReturnsError(t *testing.T) {
+	n := &network.Network{}
+	n.Meta = hcltypes.Meta{ID: "resource.network.main", Name: "main", Type: network.TypeNetwork}
+
+	_, err := copyTarget(n, copyOptions{})
+	require.ErrorContains(t, err, "only resources that run containers support copying files")
+}
+
+func TestCopyToContainerCopiesDirectoryTree(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "data")
+
+	err := os.MkdirAll(filepath.Join(src, "sub"), 0755)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(src, "a.txt"), []byte("a"), 0644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(src, "sub", "b.txt"), []byte("b"), 0644)
+	require.NoError(t, err)
+
+	m := &mocks.ContainerTasks{}
+	m.On("ExecuteCommand", "abc", mock.Anything, mock.Anything, "", "", "", copyTimeout, mock.Anything).Return(0, nil)
+	m.On("CopyFileToContainer", "abc", mock.Anything, mock.Anything).Return(nil)
+
+	err = copyToContainer(m, "abc", src, "/tmp")
+	require.NoError(t, err)
+
+	m.AssertCalled(t, "ExecuteCommand", "abc", []string{"mkdir", "-p", "/tmp/data"}, mock.Anything, "", "", "", copyTimeout, mock.Anything)
+	m.AssertCalled(t, "ExecuteCommand", "abc", []string{"mkdir", "-p", "/tmp/data/sub"}, mock.Anything, "", "", "", copyTimeout, mock.Anything)
+	m.AssertCalled(t, "CopyFileToContainer", "abc", filepath.Join(src, "a.txt"), "/tmp/data")
+	m.AssertCalled(t, "CopyFileToContainer", "abc", filepath.Join(src, "sub", "b.txt"), "/tmp/data/sub")
+}
+
+func TestCopyToAllocationWithDirectoryReturnsError(t *testing.T) {
+	m := &mocks.ContainerTasks{}
+
+	err := copyToAllocation(m, "abc", copyOptions{alloc: "5a9a4c1e", task: "app"}, t.TempDir(), "/local")
+	require.ErrorContains(t, err, "only files can be copied to an allocation")
+}
@@ -7,9 +7,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/cmd/view"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/metrics"
 	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/sync"
 	"github.com/jumppad-labs/jumppad/pkg/jumppad"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/spf13/cobra"
@@ -20,28 +24,32 @@ func newDevCmd() *cobra.Command {
 	var variablesFile string
 	var interval string
 	var ttyFlag bool
+	var metricsBind string
 
 	devCmd := &cobra.Command{
 		Use:   "dev",
 		Short: "Watches config for changes and automatically runs `up` when a change is detected",
-		Long:  "Watches config for changes and automatically runs `up` when a change is detected",
+		Long: `Watches the blueprint directory, variables file, and build contexts for changes and automatically runs ` + "`up`" + ` when a change is detected.
+When a change is detected only the affected resources are applied, new resources are created, removed resources
+are destroyed and changed resources and the resources that depend on them are refreshed.`,
 		Example: `
 		jumppad dev ./
 `,
 		Args:         cobra.ArbitraryArgs,
-		RunE:         newDevCmdFunc(&variables, &variablesFile, &interval, &ttyFlag),
+		RunE:         newDevCmdFunc(&variables, &variablesFile, &interval, &ttyFlag, &metricsBind),
 		SilenceUsage: true,
 	}
 
 	devCmd.Flags().StringSliceVarP(&variables, "var", "", nil, "Allows setting variables from the command line, variables are specified as a key and value, e.g --var key=value. Can be specified multiple times")
 	devCmd.Flags().StringVarP(&variablesFile, "vars-file", "", "", "Load variables from a location other than *.vars files in the blueprint folder. E.g --vars-file=./file.vars")
-	devCmd.Flags().StringVarP(&interval, "interval", "", "5s", "Interval to check for changes. E.g. --interval=5s")
+	devCmd.Flags().StringVarP(&interval, "interval", "", "5s", "Interval to check for changes that are not detected by watching files. E.g. --interval=5s")
 	devCmd.Flags().BoolVarP(&ttyFlag, "disable-tty", "", false, "Enable/disable output to TTY")
+	devCmd.Flags().StringVarP(&metricsBind, "metrics-bind", "", "", "Bind address for a listener that serves Prometheus metrics at /metrics, disabled when empty. E.g. --metrics-bind=:9100")
 
 	return devCmd
 }
 
-func newDevCmdFunc(variables *[]string, variablesFile, interval *string, ttyFlag *bool) func(cmd *cobra.Command, args []string) error {
+func newDevCmdFunc(variables *[]string, variablesFile, interval *string, ttyFlag *bool, metricsBind *string) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		// create the output view
 		var v view.View
@@ -67,6 +75,10 @@ func newDevCmdFunc(variables *[]string, variablesFile, interval *string, ttyFlag
 		// create the shipyard and sub folders in the users home directory
 		utils.CreateFolders()
 
+		// providers use dev mode to push changes such as docs content
+		// without recreating resources
+		os.Setenv(utils.DevModeEnvVar, "true")
+
 		d, err := time.ParseDuration(*interval)
 		if err != nil {
 			return fmt.Errorf("invalid duration %s, please specify a duration using go syntax, e.g. 5s, 1m", *interval)
@@ -102,34 +114,31 @@ func newDevCmdFunc(variables *[]string, variablesFile, interval *string, ttyFlag
 			variablesFile = &vf
 		}
 
-		// create the certificates for the connector
-		if cb, err := engineClients.Connector.GetLocalCertBundle(utils.CertsDir("")); err != nil || cb == nil {
-			// generate certs
-			v.Logger().Debug("Generating TLS Certificates for Ingress", "path", utils.CertsDir(""))
-
-			_, err := engineClients.Connector.GenerateLocalCertBundle(utils.CertsDir(""))
-			if err != nil {
-				return fmt.Errorf("unable to generate connector certificates: %s", err)
-			}
+		// start the connector, doUpdates restarts it when it stops
+		err = startConnector(engineClients.Connector, v.Logger())
+		if err != nil {
+			return err
 		}
 
-		// start the connector
-		if !engineClients.Connector.IsRunning() {
-			cb, err := engineClients.Connector.GetLocalCertBundle(utils.CertsDir(""))
+		// serve metrics for the provider operations and connector tunnels
+		if *metricsBind != "" {
+			err := metrics.Default.RegisterServiceLister(engineClients.Connector.ListServices)
 			if err != nil {
-				return fmt.Errorf("unable to get certificates to secure ingress: %s", err)
+				return fmt.Errorf("unable to register connector metrics: %s", err)
 			}
 
-			v.Logger().Debug("Starting API server")
+			go func() {
+				v.Logger().Debug("Starting metrics listener", "bind_addr", *metricsBind)
 
-			err = engineClients.Connector.Start(cb)
-			if err != nil {
-				return fmt.Errorf("unable to start API server: %s", err)
-			}
+				err := metrics.Default.Serve(*metricsBind)
+				if err != nil {
+					v.Logger().Error("Unable to start metrics listener", "bind_addr", *metricsBind, "error", err)
+				}
+			}()
 		}
 
 		// start the
-		go doUpdates(v, engine, src, vars, *variablesFile, d)
+		go doUpdates(v, engine, engineClients, src, vars, *variablesFile, d)
 
 		// Show the view
 		err = v.Display()
@@ -141,7 +150,7 @@ func newDevCmdFunc(variables *[]string, variablesFile, interval *string, ttyFlag
 	}
 }
 
-func doUpdates(v view.View, e jumppad.Engine, source string, variables map[string]string, variableFile string, interval time.Duration) {
+func doUpdates(v view.View, e jumppad.Engine, cli *clients.Clients, source string, variables map[string]string, variableFile string, interval time.Duration) {
 	v.Logger().Debug("P_Init: Checking cmd-line parameters....................")
 	v.Logger().Debug("V_Init: Allocate screens................................")
 	v.Logger().Debug("M_LoadDefaults: Load system defaults....................")
@@ -161,15 +170,51 @@ func doUpdates(v view.View, e jumppad.Engine, source string, variables map[strin
 		if err != nil {
 			v.Logger().Error(err.Error())
 		}
+
+		observeResults(e.Results())
+	}
+
+	// keep the sync resources up to date while dev is running
+	stopSync := watchSyncResources(v, cli, nil)
+
+	// watch the files so that changes are applied immediately, the interval
+	// is used to pick up changes that can not be detected from the files
+	var changes <-chan string
+	w, err := newDevWatcher(v.Logger())
+	if err != nil {
+		v.Logger().Error("Unable to watch for file changes, falling back to interval", "error", err)
+	} else {
+		defer w.Close()
+		w.WatchConfig(source, variableFile)
+		changes = w.Changes
 	}
 
 	v.UpdateStatus("Checking for changes...", false)
 	for {
-		time.Sleep(interval)
+		select {
+		case f := <-changes:
+			v.Logger().Debug("File changed", "path", f)
+		case <-time.After(interval):
+		}
+
+		// when the connector has stopped the ingress services are lost,
+		// restart it so that the diff detects the inactive ingress
+		// resources and the apply exposes them again
+		if !cli.Connector.IsRunning() {
+			v.Logger().Info("Connector is not running, restarting")
+
+			err := startConnector(cli.Connector, v.Logger())
+			if err != nil {
+				v.Logger().Error("Unable to restart connector", "error", err)
+			}
+		}
 
-		new, changed, removed, _, err := e.Diff(source, variables, variableFile)
+		new, changed, removed, cfg, err := e.Diff(source, variables, variableFile)
 		if err != nil {
-			v.Logger().Error(err.Error())
+			// the config can not be parsed while it is being edited, the
+			// changes are applied once the config is valid again
+			v.Logger().Error("Unable to check for changes", "error", err)
+			continue
 		}
 
 		if len(new) > 0 || len(changed) > 0 || len(removed) > 0 {
@@ -185,12 +230,80 @@ func doUpdates(v view.View, e jumppad.Engine, source string, variables map[strin
 				v.Logger().Debug("Changed", "resource", n.Metadata().ID)
 			}
 
-			_, err := e.ApplyWithVariables(context.Background(), source, variables, variableFile)
+			_, err := e.ApplyTargets(context.Background(), source, variables, variableFile, changedTargets(cfg, changed))
 			if err != nil {
 				v.Logger().Error(err.Error())
 			}
 
+			observeResults(e.Results())
+
+			// containers may have been recreated, restart the watchers
+			stopSync = watchSyncResources(v, cli, stopSync)
+
+			// new build contexts may have been added
+			if w != nil {
+				w.WatchConfig(source, variableFile)
+			}
+
 			v.UpdateStatus("Checking for changes...", false)
 		}
 	}
 }
+
+// changedTargets returns the ids of the changed resources and the resources
+// that depend on them, only these resources are refreshed by dev
+func changedTargets(cfg *hclconfig.Config, changed []types.Resource) []string {
+	targets := []string{}
+	for _, r := range changed {
+		deps, err := jumppad.Dependents(cfg, r.Metadata().ID)
+		if err != nil {
+			targets = append(targets, r.Metadata().ID)
+			continue
+		}
+
+		targets = append(targets, deps...)
+	}
+
+	return targets
+}
+
+// observeResults records the provider operations from the last apply in the
+// metrics
+func observeResults(results []jumppad.ResourceResult) {
+	for _, r := range results {
+		metrics.Default.ObserveOperation(r.Type, r.Status, r.Duration)
+	}
+}
+
+// watchSyncResources starts a file watcher for each sync resource in the
+// state, the watchers for the previous run are stopped by calling stop.
+// Returns a function that stops the new watchers.
+func watchSyncResources(v view.View, cli *clients.Clients, stop context.CancelFunc) context.CancelFunc {
+	if stop != nil {
+		stop()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cfg, err := config.LoadState()
+	if err != nil {
+		return cancel
+	}
+
+	res, _ := cfg.FindResourcesByType(sync.TypeSync)
+	for _, r := range res {
+		if r.GetDisabled() {
+			continue
+		}
+
+		s := r.(*sync.Sync)
+		go func() {
+			err := sync.NewSyncer(s, cli.ContainerTasks, v.Logger()).Watch(ctx)
+			if err != nil {
+				v.Logger().Error("Unable to watch for changes", "ref", s.Meta.ID, "error", err)
+			}
+		}()
+	}
+
+	return cancel
+}
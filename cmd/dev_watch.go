@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build"
+)
+
+// devDebounce is the time to wait for further changes before notifying,
+// editors often write a file multiple times when saving
+const devDebounce = 500 * time.Millisecond
+
+// devIgnoredDirs are directories that are never watched
+var devIgnoredDirs = map[string]bool{".git": true, "node_modules": true, ".terraform": true}
+
+// devWatcher watches the blueprint directory and any build contexts for
+// changes, the Changes channel receives a value after a change has been
+// detected
+type devWatcher struct {
+	watcher *fsnotify.Watcher
+	log     logger.Logger
+	watched map[string]bool
+	mutex   sync.Mutex
+	Changes chan string
+}
+
+func newDevWatcher(l logger.Logger) (*devWatcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	dw := &devWatcher{
+		watcher: w,
+		log:     l,
+		watched: map[string]bool{},
+		Changes: make(chan string, 1),
+	}
+
+	go dw.run()
+
+	return dw, nil
+}
+
+// Close stops watching for changes
+func (d *devWatcher) Close() error {
+	return d.watcher.Close()
+}
+
+// WatchConfig adds the blueprint source, the variables file, and the build
+// contexts of any build resources in the state to the watcher
+func (d *devWatcher) WatchConfig(source, variablesFile string) {
+	paths := []string{source}
+
+	if variablesFile != "" {
+		paths = append(paths, variablesFile)
+	}
+
+	if cfg, err := config.LoadState(); err == nil {
+		res, _ := cfg.FindResourcesByType(build.TypeBuild)
+		for _, r := range res {
+			paths = append(paths, r.(*build.Build).Container.Context)
+		}
+	}
+
+	for _, p := range paths {
+		err := d.add(p)
+		if err != nil {
+			d.log.Debug("Unable to watch path", "path", p, "error", err)
+		}
+	}
+}
+
+// add watches the path, directories are watched recursively
+func (d *devWatcher) add(p string) error {
+	p, err := filepath.Abs(p)
+	if err != nil {
+		return err
+	}
+
+	fi, err := os.Stat(p)
+	if err != nil {
+		return err
+	}
+
+	if !fi.IsDir() {
+		return d.addPath(p)
+	}
+
+	return filepath.WalkDir(p, func(path string, e fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !e.IsDir() {
+			return nil
+		}
+
+		if devIgnoredDirs[e.Name()] {
+			return filepath.SkipDir
+		}
+
+		return d.addPath(path)
+	})
+}
+
+func (d *devWatcher) addPath(p string) error {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if d.watched[p] {
+		return nil
+	}
+
+	d.log.Debug("Watching path for changes", "path", p)
+
+	err := d.watcher.Add(p)
+	if err != nil {
+		return err
+	}
+
+	d.watched[p] = true
+	return nil
+}
+
+func (d *devWatcher) run() {
+	timer := time.NewTimer(devDebounce)
+	timer.Stop()
+
+	changed := ""
+
+	for {
+		select {
+		case e, ok := <-d.watcher.Events:
+			if !ok {
+				return
+			}
+
+			// fsnotify does not watch recursively, new directories need to be
+			// added to the watcher
+			if e.Has(fsnotify.Create) {
+				if fi, err := os.Stat(e.Name); err == nil && fi.IsDir() && !devIgnoredDirs[fi.Name()] {
+					d.add(e.Name)
+				}
+			}
+
+			changed = e.Name
+			timer.Reset(devDebounce)
+
+		case err, ok := <-d.watcher.Errors:
+			if !ok {
+				return
+			}
+
+			d.log.Debug("Error watching for changes", "error", err)
+
+		case <-timer.C:
+			// do not block when a change is already waiting to be processed
+			select {
+			case d.Changes <- changed:
+			default:
+			}
+		}
+	}
+}
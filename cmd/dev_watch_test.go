@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDevWatcher(t *testing.T) (*devWatcher, string) {
+	testutils.SetupState(t, "")
+
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "modules"), 0755)
+	os.MkdirAll(filepath.Join(dir, ".git"), 0755)
+
+	w, err := newDevWatcher(logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		w.Close()
+	})
+
+	w.WatchConfig(dir, "")
+
+	return w, dir
+}
+
+func TestDevWatcherNotifiesOnChange(t *testing.T) {
+	w, dir := setupDevWatcher(t)
+
+	f := filepath.Join(dir, "modules", "main.hcl")
+	os.WriteFile(f, []byte(`resource "network" "main" {}`), 0644)
+
+	select {
+	case c := <-w.Changes:
+		require.Equal(t, f, c)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timeout waiting for change")
+	}
+}
+
+func TestDevWatcherIgnoresGitDirectory(t *testing.T) {
+	w, dir := setupDevWatcher(t)
+
+	require.True(t, w.watched[filepath.Join(dir, "modules")])
+	require.False(t, w.watched[filepath.Join(dir, ".git")])
+}
@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/system"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// diagnoseTimeout is the time in seconds that commands run inside cluster
+// containers are allowed to run when gathering diagnostics
+const diagnoseTimeout = 30
+
+func newDiagnoseCmd(dc container.Docker, ct container.ContainerTasks, s system.System) *cobra.Command {
+	var output string
+
+	diagnoseCmd := &cobra.Command{
+		Use:   "diagnose",
+		Short: "Gather diagnostic information into a tarball that can be attached to bug reports",
+		Long: `Gather diagnostic information into a tarball that can be attached to bug reports.
+
+The tarball contains the jumppad and Docker versions, the output of the system
+checks, the state file, the jumppad logs, the Docker inspect output for all
+running resources and the status of any Kubernetes or Nomad clusters.
+
+Values marked as sensitive and any values whose names look like passwords,
+secrets or tokens are redacted, however you should review the contents of the
+tarball before sharing it.`,
+		Example: `
+  # Write diagnostics to jumppad-diagnostics-<timestamp>.tar.gz
+  jumppad diagnose
+
+  # Write diagnostics to a specific file
+  jumppad diagnose --output ./bug-report.tar.gz
+	`,
+		Args: cobra.NoArgs,
+		RunE: newDiagnoseCmdFunc(dc, ct, s, &output),
+	}
+
+	diagnoseCmd.Flags().StringVarP(&output, "output", "o", "", "File to write the diagnostics tarball to, defaults to jumppad-diagnostics-<timestamp>.tar.gz in the current directory")
+
+	return diagnoseCmd
+}
+
+func newDiagnoseCmdFunc(dc container.Docker, ct container.ContainerTasks, s system.System, output *string) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		out := *output
+		if out == "" {
+			out = fmt.Sprintf("jumppad-diagnostics-%s.tar.gz", time.Now().Format("20060102-150405"))
+		}
+
+		f, err := os.Create(out)
+		if err != nil {
+			return fmt.Errorf("unable to create diagnostics file %s: %s", out, err)
+		}
+		defer f.Close()
+
+		b := newDiagnosticsBundle(f)
+		collectDiagnostics(b, dc, ct, s)
+
+		err = b.Close()
+		if err != nil {
+			return fmt.Errorf("unable to write diagnostics file %s: %s", out, err)
+		}
+
+		cmd.Printf("Diagnostics written to %s\n", out)
+		cmd.Println("Sensitive values have been redacted, please review the contents before sharing")
+
+		return nil
+	}
+}
+
+func collectDiagnostics(b *diagnosticsBundle, dc container.Docker, ct container.ContainerTasks, s system.System) {
+	ctx := context.Background()
+
+	// versions
+	v := &bytes.Buffer{}
+	fmt.Fprintf(v, "jumppad: %s\ncommit: %s\ndate: %s\n", version, commit, date)
+	fmt.Fprintf(v, "go: %s\nplatform: %s/%s\n", runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	b.add("version.txt", v.Bytes())
+
+	// system checks, the output is useful even when a check fails
+	po, err := s.Preflight()
+	if err != nil {
+		b.addError("preflight.txt", err)
+	}
+	b.add("preflight.txt", []byte(po))
+
+	// docker
+	if sv, err := dc.ServerVersion(ctx); err == nil {
+		b.addJSON("docker/version.json", sv)
+	} else {
+		b.addError("docker/version.json", err)
+	}
+
+	if info, err := dc.Info(ctx); err == nil {
+		b.addJSON("docker/info.json", info)
+	} else {
+		b.addError("docker/info.json", err)
+	}
+
+	// jumppad logs
+	logs, err := os.ReadDir(utils.LogsDir())
+	if err != nil && !os.IsNotExist(err) {
+		b.addError("logs", err)
+	}
+
+	for _, l := range logs {
+		if l.IsDir() {
+			continue
+		}
+
+		d, err := os.ReadFile(filepath.Join(utils.LogsDir(), l.Name()))
+		if err != nil {
+			b.addError("logs/"+l.Name(), err)
+			continue
+		}
+
+		b.add("logs/"+l.Name(), d)
+	}
+
+	// state, loading the state registers the sensitive values of resources
+	// so it must happen before the state file is added
+	cfg, err := config.LoadState()
+	if err != nil {
+		b.addError("state.json", err)
+		return
+	}
+
+	sf, err := os.ReadFile(utils.StatePath())
+	if err != nil {
+		b.addError("state.json", err)
+	} else {
+		b.add("state.json", sf)
+	}
+
+	for _, r := range cfg.Resources {
+		collectResourceDiagnostics(ctx, b, dc, ct, r)
+	}
+}
+
+// containerDiagnosticsName returns the name of the file in the bundle for the
+// container, when several containers share the fqdn the short container id
+// is added so that the files do not overwrite each other
+func containerDiagnosticsName(fqdn, id string, multiple bool) string {
+	if !multiple {
+		return "docker/" + fqdn + ".json"
+	}
+
+	if len(id) > 12 {
+		id = id[:12]
+	}
+
+	return "docker/" + fqdn + "-" + id + ".json"
+}
+
+func collectResourceDiagnostics(ctx context.Context, b *diagnosticsBundle, dc container.Docker, ct container.ContainerTasks, r hcltypes.Resource) {
+	for _, fqdn := range getFQDNForResource(r) {
+		ids, err := ct.FindContainerIDs(fqdn)
+		if err != nil || len(ids) == 0 {
+			b.addError("docker/"+fqdn+".json", fmt.Errorf("unable to find container for %s", r.Metadata().ID))
+			continue
+		}
+
+		for _, id := range ids {
+			name := containerDiagnosticsName(fqdn, id, len(ids) > 1)
+
+			ci, err := dc.ContainerInspect(ctx, id)
+			if err != nil {
+				b.addError(name, err)
+				continue
+			}
+
+			b.addJSON(name, ci)
+		}
+	}
+
+	var name string
+	var commands [][]string
+
+	switch r.Metadata().Type {
+	case k8s.TypeK8sCluster:
+		name = "k8s/" + r.Metadata().ID + ".txt"
+		commands = [][]string{
+			{"kubectl", "get", "nodes", "-o", "wide"},
+			{"kubectl", "get", "all", "--all-namespaces", "-o", "wide"},
+			{"kubectl", "get", "events", "--all-namespaces"},
+		}
+	case nomad.TypeNomadCluster:
+		name = "nomad/" + r.Metadata().ID + ".txt"
+		commands = [][]string{
+			{"nomad", "node", "status"},
+			{"nomad", "job", "status"},
+		}
+	default:
+		return
+	}
+
+	// commands are run in the server container
+	server := getFQDNForResource(r)[0]
+	ids, err := ct.FindContainerIDs(server)
+	if err != nil || len(ids) == 0 {
+		b.addError(name, fmt.Errorf("unable to find server container for %s", r.Metadata().ID))
+		return
+	}
+
+	out := &bytes.Buffer{}
+	for _, c := range commands {
+		fmt.Fprintf(out, "$ %s\n", strings.Join(c, " "))
+
+		_, err := ct.ExecuteCommand(ids[0], c, nil, "/", "", "", diagnoseTimeout, out)
+		if err != nil {
+			fmt.Fprintf(out, "error: %s\n", err)
+		}
+
+		fmt.Fprintln(out)
+	}
+
+	b.add(name, out.Bytes())
+}
+
+// sensitiveKeyPattern matches the names of values that commonly hold secrets
+const sensitiveKeyPattern = `[A-Za-z0-9_.-]*(?i:password|passwd|secret|token|api_key|apikey|private_key)[A-Za-z0-9_.-]*`
+
+var sensitiveJSONValue = regexp.MustCompile(`("` + sensitiveKeyPattern + `"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+var sensitiveEnvValue = regexp.MustCompile(`(\b` + sensitiveKeyPattern + `=)[^"\s]+`)
+
+// redactDiagnostics removes any values that have been registered as sensitive
+// and the values of any JSON keys or environment variables whose names look
+// like they contain secrets
+func redactDiagnostics(d []byte) []byte {
+	s := utils.Redact(string(d))
+	s = sensitiveJSONValue.ReplaceAllString(s, `${1}"`+utils.Redacted+`"`)
+	s = sensitiveEnvValue.ReplaceAllString(s, "${1}"+utils.Redacted)
+
+	return []byte(s)
+}
+
+// diagnosticsBundle writes redacted files to a gzipped tarball, errors that
+// occur while gathering information are collected and written to errors.txt
+// so that a partial bundle is still produced
+type diagnosticsBundle struct {
+	gz     *gzip.Writer
+	tw     *tar.Writer
+	errors []string
+	err    error
+}
+
+func newDiagnosticsBundle(w io.Writer) *diagnosticsBundle {
+	gz := gzip.NewWriter(w)
+
+	return &diagnosticsBundle{
+		gz: gz,
+		tw: tar.NewWriter(gz),
+	}
+}
+
+// add writes a redacted file to the bundle
+func (b *diagnosticsBundle) add(name string, d []byte) {
+	if b.err != nil {
+		return
+	}
+
+	d = redactDiagnostics(d)
+
+	b.err = b.tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(d)),
+		ModTime: time.Now(),
+	})
+	if b.err != nil {
+		return
+	}
+
+	_, b.err = b.tw.Write(d)
+}
+
+// addJSON writes v to the bundle as indented JSON
+func (b *diagnosticsBundle) addJSON(name string, v any) {
+	d, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		b.addError(name, err)
+		return
+	}
+
+	b.add(name, d)
+}
+
+// addError records an error that occurred while gathering name
+func (b *diagnosticsBundle) addError(name string, err error) {
+	b.errors = append(b.errors, fmt.Sprintf("%s: %s", name, err))
+}
+
+// Close writes errors.txt and flushes the tarball, it returns the first error
+// that occurred while writing the bundle
+func (b *diagnosticsBundle) Close() error {
+	if len(b.errors) > 0 {
+		b.add("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n"))
+	}
+
+	if b.err != nil {
+		return b.err
+	}
+
+	err := b.tw.Close()
+	if err != nil {
+		return err
+	}
+
+	return b.gz.Close()
+}
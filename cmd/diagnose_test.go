@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func readDiagnosticsBundle(t *testing.T, d []byte) map[string]string {
+	gz, err := gzip.NewReader(bytes.NewReader(d))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		c, err := io.ReadAll(tr)
+		require.NoError(t, err)
+
+		files[h.Name] = string(c)
+	}
+
+	return files
+}
+
+func TestRedactDiagnosticsRedactsSensitiveJSONKeys(t *testing.T) {
+	out := redactDiagnostics([]byte(`{"name": "db", "root_password": "s3cr3t", "ApiToken":"abc\"123"}`))
+
+	require.Equal(t, `{"name": "db", "root_password": "(sensitive)", "ApiToken":"(sensitive)"}`, string(out))
+}
+
+func TestRedactDiagnosticsRedactsSensitiveEnvironmentVariables(t *testing.T) {
+	out := redactDiagnostics([]byte(`"Env": ["PATH=/usr/bin", "VAULT_TOKEN=root", "DB_PASSWORD=pa55"]`))
+
+	require.Equal(t, `"Env": ["PATH=/usr/bin", "VAULT_TOKEN=(sensitive)", "DB_PASSWORD=(sensitive)"]`, string(out))
+}
+
+func TestRedactDiagnosticsRedactsRegisteredValues(t *testing.T) {
+	utils.RegisterSensitive("diagnose-registered-value")
+
+	out := redactDiagnostics([]byte(`config: diagnose-registered-value`))
+
+	require.Equal(t, `config: (sensitive)`, string(out))
+}
+
+func TestRedactDiagnosticsWithoutSecretsDoesNotModify(t *testing.T) {
+	in := `{"name": "consul", "image": "consul:1.16", "Env": ["PATH=/usr/bin"]}`
+
+	out := redactDiagnostics([]byte(in))
+
+	require.Equal(t, in, string(out))
+}
+
+func TestDiagnosticsBundleWritesRedactedFiles(t *testing.T) {
+	buf := &bytes.Buffer{}
+	b := newDiagnosticsBundle(buf)
+
+	b.add("version.txt", []byte("jumppad: 0.1.0"))
+	b.addJSON("docker/app.json", map[string]string{"secret_id": "abc"})
+
+	err := b.Close()
+	require.NoError(t, err)
+
+	files := readDiagnosticsBundle(t, buf.Bytes())
+	require.Equal(t, "jumppad: 0.1.0", files["version.txt"])
+	require.Contains(t, files["docker/app.json"], `"secret_id": "(sensitive)"`)
+	require.NotContains(t, files, "errors.txt")
+}
+
+func TestDiagnosticsBundleWithErrorsWritesErrorsFile(t *testing.T) {
+	buf := &bytes.Buffer{}
+	b := newDiagnosticsBundle(buf)
+
+	b.addError("docker/info.json", fmt.Errorf("docker not running"))
+
+	err := b.Close()
+	require.NoError(t, err)
+
+	files := readDiagnosticsBundle(t, buf.Bytes())
+	require.Equal(t, "docker/info.json: docker not running\n", files["errors.txt"])
+}
+
+func TestContainerDiagnosticsNameUsesFQDN(t *testing.T) {
+	name := containerDiagnosticsName("app.container.local.jmpd.in", "4f1c2a3b5d6e7f8a9b0c", false)
+
+	require.Equal(t, "docker/app.container.local.jmpd.in.json", name)
+}
+
+func TestContainerDiagnosticsNameWithMultipleContainersAddsID(t *testing.T) {
+	name := containerDiagnosticsName("app.container.local.jmpd.in", "4f1c2a3b5d6e7f8a9b0c", true)
+
+	require.Equal(t, "docker/app.container.local.jmpd.in-4f1c2a3b5d6e.json", name)
+}
@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/network"
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+const (
+	orphanContainer = "container"
+	orphanNetwork   = "network"
+)
+
+// orphan is a Docker container or network created by jumppad for a resource
+// that is not in the state of the environment that created it. Orphans are
+// left behind when jumppad is interrupted and cause errors such as "port is
+// already allocated" when the blueprint is applied again
+type orphan struct {
+	Type       string `json:"type" yaml:"type"`
+	ID         string `json:"id" yaml:"id"`
+	Name       string `json:"name" yaml:"name"`
+	ResourceID string `json:"resource_id,omitempty" yaml:"resource_id,omitempty"`
+}
+
+// doctorOptions select how the orphans are fixed, when neither option is set
+// the user is asked what to do with each orphan
+type doctorOptions struct {
+	// Adopt adds the orphans that belong to a resource in the blueprint to
+	// the state
+	Adopt bool
+	// Remove deletes the orphans that are not adopted
+	Remove bool
+}
+
+func newDoctorCmd(e jumppad.Engine, dc container.Docker) *cobra.Command {
+	var opts doctorOptions
+	var variables []string
+	var variablesFile string
+
+	doctorCmd := &cobra.Command{
+		Use:   "doctor [blueprint]",
+		Short: "Find and fix containers and networks created by jumppad that are not in the state",
+		Long: `Find and fix containers and networks created by jumppad that are not in the state.
+
+When jumppad is interrupted the containers and networks it has created may not
+be recorded in the state, applying the blueprint again fails with errors such
+as "port is already allocated". Orphaned containers and networks can be removed
+or, when the blueprint that created them is given, adopted into the state so
+that jumppad manages them again.
+
+You are asked what to do with each orphan unless --adopt or --remove is set.`,
+		Example: `
+  # List the orphans and choose whether to remove them
+  jumppad doctor
+
+  # Remove all orphans without asking
+  jumppad doctor --remove
+
+  # Add the orphans created by the blueprint in the current folder to the state
+  jumppad doctor --adopt ./
+	`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			vars := map[string]string{}
+			for _, v := range variables {
+				parts := strings.SplitN(v, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid variable %s, variables must be specified as key=value", v)
+				}
+
+				vars[parts[0]] = parts[1]
+			}
+
+			var blueprint *hclconfig.Config
+			if len(args) == 1 {
+				if !utils.IsLocalFolder(args[0]) && !utils.IsHCLFile(args[0]) {
+					return fmt.Errorf("blueprint %s must be a local folder or file", args[0])
+				}
+
+				cfg, err := e.ParseConfigWithVariables(args[0], vars, variablesFile)
+				if err != nil {
+					return fmt.Errorf("unable to parse blueprint: %s", err)
+				}
+
+				blueprint = cfg
+			} else if opts.Adopt {
+				return fmt.Errorf("the blueprint that created the orphans must be specified to adopt them")
+			}
+
+			prompt := !opts.Adopt && !opts.Remove
+			if f, ok := cmd.InOrStdin().(*os.File); ok && !term.IsTerminal(int(f.Fd())) {
+				// there is nobody to answer the questions
+				prompt = false
+			}
+
+			return runDoctor(cmd, dc, blueprint, opts, prompt)
+		},
+	}
+
+	doctorCmd.Flags().BoolVarP(&opts.Adopt, "adopt", "", false, "Add the orphans created by resources in the blueprint to the state without asking")
+	doctorCmd.Flags().BoolVarP(&opts.Remove, "remove", "", false, "Remove the orphans without asking, used with --adopt only the orphans that can not be adopted are removed")
+	doctorCmd.Flags().StringSliceVarP(&variables, "var", "", nil, "Set the value of a variable used to parse the blueprint, e.g --var key=value. Can be specified multiple times")
+	doctorCmd.Flags().StringVarP(&variablesFile, "vars-file", "", "", "Load variables used to parse the blueprint from a file, e.g --vars-file=./file.vars")
+
+	return doctorCmd
+}
+
+// runDoctor finds the orphans and adopts or removes them, blueprint is the
+// parsed config used to adopt orphans and can be nil
+func runDoctor(cmd *cobra.Command, dc container.Docker, blueprint *hclconfig.Config, opts doctorOptions, prompt bool) error {
+	ctx := context.Background()
+
+	orphans, err := findOrphans(ctx, dc)
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		cmd.Println("No orphaned containers or networks found")
+		return nil
+	}
+
+	cmd.Println("Found containers and networks created by jumppad that are not in the state:")
+	cmd.Println("")
+	printOrphans(cmd, orphans)
+	cmd.Println("")
+
+	if !prompt && !opts.Adopt && !opts.Remove {
+		cmd.Println("Run 'jumppad doctor --remove' to remove them, or 'jumppad doctor --adopt [blueprint]' to add them to the state")
+		return nil
+	}
+
+	in := bufio.NewReader(cmd.InOrStdin())
+
+	adopt := []hcltypes.Resource{}
+	remove := []orphan{}
+
+	for _, o := range orphans {
+		var r hcltypes.Resource
+		if blueprint != nil && o.ResourceID != "" {
+			r, _ = blueprint.FindResource(o.ResourceID)
+		}
+
+		action := "skip"
+		switch {
+		case opts.Adopt && r != nil:
+			action = "adopt"
+		case opts.Remove:
+			action = "remove"
+		case prompt:
+			action, err = askOrphanAction(cmd, in, o, r != nil)
+			if err != nil {
+				return err
+			}
+		}
+
+		switch action {
+		case "adopt":
+			if !containsResource(adopt, r) {
+				adopt = append(adopt, r)
+			}
+		case "remove":
+			remove = append(remove, o)
+		}
+	}
+
+	if len(adopt) > 0 {
+		err := adoptResources(adopt)
+		if err != nil {
+			return err
+		}
+
+		for _, r := range adopt {
+			cmd.Printf("Adopted %s\n", r.Metadata().ID)
+		}
+	}
+
+	removed, err := removeOrphans(ctx, dc, remove)
+	for _, o := range removed {
+		cmd.Printf("Removed %s %s\n", o.Type, o.Name)
+	}
+
+	return err
+}
+
+// findOrphans returns the containers and networks labelled as created by
+// jumppad for the current environment whose resource is not in the state of
+// the environment, containers are returned before networks. Orphans of other
+// environments are found by running doctor with --env
+func findOrphans(ctx context.Context, dc container.Docker) ([]orphan, error) {
+	env := utils.Environment()
+
+	ids, err := stateResourceIDs(env)
+	if err != nil {
+		return nil, err
+	}
+
+	f := filters.NewArgs(filters.Arg("label", config.LabelCreatedBy+"="+config.CreatedByJumppad))
+
+	orphans := []orphan{}
+
+	cs, err := dc.ContainerList(ctx, dcontainer.ListOptions{All: true, Filters: f})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list containers: %s", err)
+	}
+
+	for _, c := range cs {
+		rid := c.Labels[config.LabelResourceID]
+		if objectEnvironment(c.Labels) != env || ids[rid] {
+			continue
+		}
+
+		name := c.ID
+		if len(c.Names) > 0 {
+			name = strings.TrimPrefix(c.Names[0], "/")
+		}
+
+		orphans = append(orphans, orphan{Type: orphanContainer, ID: c.ID, Name: name, ResourceID: rid})
+	}
+
+	ns, err := dc.NetworkList(ctx, network.ListOptions{Filters: f})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list networks: %s", err)
+	}
+
+	for _, n := range ns {
+		rid := n.Labels[config.LabelResourceID]
+		if objectEnvironment(n.Labels) != env || ids[rid] {
+			continue
+		}
+
+		orphans = append(orphans, orphan{Type: orphanNetwork, ID: n.ID, Name: n.Name, ResourceID: rid})
+	}
+
+	return orphans, nil
+}
+
+// objectEnvironment returns the environment that created a Docker container
+// or network, objects created before environments were added belong to the
+// default environment
+func objectEnvironment(labels map[string]string) string {
+	if e, ok := labels[utils.EnvironmentLabel]; ok {
+		return e
+	}
+
+	return utils.DefaultEnvironment
+}
+
+// stateResourceIDs returns the ids of the resources in the state of the
+// given environment
+func stateResourceIDs(env string) (map[string]bool, error) {
+	ids := map[string]bool{}
+
+	d, err := os.ReadFile(utils.EnvironmentStatePath(env))
+	if os.IsNotExist(err) {
+		return ids, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read state for environment %s: %s", env, err)
+	}
+
+	state := struct {
+		Resources []struct {
+			Meta struct {
+				ID string `json:"id"`
+			} `json:"meta"`
+		} `json:"resources"`
+	}{}
+
+	err = json.Unmarshal(d, &state)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read state for environment %s: %s", env, err)
+	}
+
+	for _, r := range state.Resources {
+		ids[r.Meta.ID] = true
+	}
+
+	return ids, nil
+}
+
+func printOrphans(cmd *cobra.Command, orphans []orphan) {
+	for _, o := range orphans {
+		rid := o.ResourceID
+		if rid == "" {
+			rid = "unknown resource"
+		}
+
+		cmd.Printf("  %-10s %s (%s)\n", o.Type, o.Name, rid)
+	}
+}
+
+// askOrphanAction asks the user whether to adopt, remove, or skip the orphan,
+// the default is to skip
+func askOrphanAction(cmd *cobra.Command, in *bufio.Reader, o orphan, adoptable bool) (string, error) {
+	for {
+		if adoptable {
+			cmd.Printf("Adopt, remove, or skip %s %s? [a/r/S]: ", o.Type, o.Name)
+		} else {
+			cmd.Printf("Remove or skip %s %s? [r/S]: ", o.Type, o.Name)
+		}
+
+		line, err := in.ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("unable to read answer: %s", err)
+		}
+
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "", "s", "skip":
+			return "skip", nil
+		case "r", "remove":
+			return "remove", nil
+		case "a", "adopt":
+			if adoptable {
+				return "adopt", nil
+			}
+		}
+	}
+}
+
+// adoptResources adds the resources to the state as created, the next time
+// the blueprint is applied the resources are refreshed instead of created
+func adoptResources(resources []hcltypes.Resource) error {
+	state := hclconfig.NewConfig()
+	if _, err := os.Stat(utils.StatePath()); err == nil {
+		state, err = config.LoadState()
+		if err != nil {
+			return fmt.Errorf("unable to load state: %s", err)
+		}
+	}
+
+	for _, r := range resources {
+		if r.Metadata().Properties == nil {
+			r.Metadata().Properties = map[string]any{}
+		}
+
+		r.Metadata().Properties[constants.PropertyStatus] = constants.StatusCreated
+
+		err := state.AppendResource(r)
+		if err != nil {
+			return fmt.Errorf("unable to add %s to the state: %s", r.Metadata().ID, err)
+		}
+	}
+
+	return config.SaveState(state)
+}
+
+// removeOrphans deletes the orphans and returns the orphans that were
+// removed, containers are removed before networks as a network can not be
+// removed while containers are attached
+func removeOrphans(ctx context.Context, dc container.Docker, orphans []orphan) ([]orphan, error) {
+	removed := []orphan{}
+
+	for _, t := range []string{orphanContainer, orphanNetwork} {
+		for _, o := range orphans {
+			if o.Type != t {
+				continue
+			}
+
+			var err error
+			if o.Type == orphanContainer {
+				err = dc.ContainerRemove(ctx, o.ID, dcontainer.RemoveOptions{Force: true})
+			} else {
+				err = dc.NetworkRemove(ctx, o.ID)
+			}
+
+			if err != nil {
+				return removed, fmt.Errorf("unable to remove %s %s: %s", o.Type, o.Name, err)
+			}
+
+			removed = append(removed, o)
+		}
+	}
+
+	return removed, nil
+}
+
+func containsResource(resources []hcltypes.Resource, r hcltypes.Resource) bool {
+	for _, res := range resources {
+		if res.Metadata().ID == r.Metadata().ID {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	cmock "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	enginemocks "github.com/jumppad-labs/jumppad/pkg/jumppad/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var doctorState = `
+{
+  "blueprint": null,
+  "resources": [
+	{
+		"meta": {
+			"id": "resource.container.web",
+			"name": "web",
+			"type": "container"
+		}
+	}
+  ]
+}`
+
+func jumppadLabels(id string) map[string]string {
+	return map[string]string{config.LabelCreatedBy: config.CreatedByJumppad, config.LabelResourceID: id}
+}
+
+func setupDoctor(t *testing.T, input string) (*cobra.Command, *cmock.Docker, *enginemocks.Engine, *bytes.Buffer) {
+	testutils.SetupState(t, doctorState)
+
+	md := &cmock.Docker{}
+	md.On("ContainerList", mock.Anything, mock.Anything).Return([]dcontainer.Summary{
+		{ID: "abc", Names: []string{"/web.container.local.jmpd.in"}, Labels: jumppadLabels("resource.container.web")},
+		{ID: "def", Names: []string{"/api.container.local.jmpd.in"}, Labels: jumppadLabels("resource.container.api")},
+	}, nil)
+	md.On("NetworkList", mock.Anything, mock.Anything).Return([]network.Inspect{
+		{ID: "123", Name: "cloud", Labels: jumppadLabels("resource.network.cloud")},
+	}, nil)
+	md.On("ContainerRemove", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	md.On("NetworkRemove", mock.Anything, mock.Anything).Return(nil)
+
+	api := &container.Container{}
+	api.Meta = hcltypes.Meta{ID: "resource.container.api", Name: "api", Type: container.TypeContainer, Properties: map[string]any{}}
+
+	bp := hclconfig.NewConfig()
+	bp.AppendResource(api)
+
+	me := &enginemocks.Engine{}
+	me.On("ParseConfigWithVariables", mock.Anything, mock.Anything, mock.Anything).Return(bp, nil)
+
+	output := bytes.NewBuffer(nil)
+
+	c := newDoctorCmd(me, md)
+	c.SetOut(output)
+	c.SetErr(output)
+	c.SetIn(strings.NewReader(input))
+
+	return c, md, me, output
+}
+
+func TestDoctorListsOrphans(t *testing.T) {
+	c, md, _, output := setupDoctor(t, "\n\n")
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "api.container.local.jmpd.in (resource.container.api)")
+	require.Contains(t, output.String(), "cloud (resource.network.cloud)")
+	require.NotContains(t, output.String(), "web.container.local.jmpd.in")
+
+	md.AssertNotCalled(t, "ContainerRemove", mock.Anything, mock.Anything, mock.Anything)
+	md.AssertNotCalled(t, "NetworkRemove", mock.Anything, mock.Anything)
+}
+
+func TestDoctorWithNoOrphansPrintsMessage(t *testing.T) {
+	c, md, _, output := setupDoctor(t, "")
+	testutils.RemoveOn(&md.Mock, "ContainerList")
+	testutils.RemoveOn(&md.Mock, "NetworkList")
+	md.On("ContainerList", mock.Anything, mock.Anything).Return([]dcontainer.Summary{}, nil)
+	md.On("NetworkList", mock.Anything, mock.Anything).Return([]network.Inspect{}, nil)
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "No orphaned containers or networks found")
+}
+
+func TestDoctorIgnoresObjectsInOtherEnvironments(t *testing.T) {
+	c, md, _, output := setupDoctor(t, "\n\n")
+
+	labels := jumppadLabels("resource.network.cloud")
+	labels[utils.EnvironmentLabel] = "dev"
+
+	testutils.RemoveOn(&md.Mock, "NetworkList")
+	md.On("NetworkList", mock.Anything, mock.Anything).Return([]network.Inspect{
+		{ID: "123", Name: "cloud.dev", Labels: labels},
+	}, nil)
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "api.container.local.jmpd.in")
+	require.NotContains(t, output.String(), "resource.network.cloud")
+}
+
+func TestDoctorDoesNotMatchResourcesInOtherEnvironmentsState(t *testing.T) {
+	c, _, _, output := setupDoctor(t, "\n\n")
+
+	os.MkdirAll(filepath.Dir(utils.EnvironmentStatePath("dev")), os.ModePerm)
+	os.WriteFile(utils.EnvironmentStatePath("dev"), []byte(`{"resources": [{"meta": {"id": "resource.network.cloud"}}]}`), 0644)
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "cloud (resource.network.cloud)")
+}
+
+func TestDoctorRemoveRemovesOrphans(t *testing.T) {
+	c, md, _, output := setupDoctor(t, "")
+	c.SetArgs([]string{"--remove"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	md.AssertCalled(t, "ContainerRemove", mock.Anything, "def", dcontainer.RemoveOptions{Force: true})
+	md.AssertNotCalled(t, "ContainerRemove", mock.Anything, "abc", mock.Anything)
+	md.AssertCalled(t, "NetworkRemove", mock.Anything, "123")
+
+	require.Contains(t, output.String(), "Removed container api.container.local.jmpd.in")
+	require.Contains(t, output.String(), "Removed network cloud")
+}
+
+func TestDoctorAdoptAddsResourcesToState(t *testing.T) {
+	c, md, me, output := setupDoctor(t, "")
+	c.SetArgs([]string{"--adopt", t.TempDir()})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	me.AssertCalled(t, "ParseConfigWithVariables", mock.Anything, map[string]string{}, "")
+
+	s, err := config.LoadState()
+	require.NoError(t, err)
+
+	r, err := s.FindResource("resource.container.api")
+	require.NoError(t, err)
+	require.Equal(t, constants.StatusCreated, r.Metadata().Properties[constants.PropertyStatus])
+
+	// the network is not in the blueprint so it can not be adopted
+	md.AssertNotCalled(t, "NetworkRemove", mock.Anything, mock.Anything)
+	require.Contains(t, output.String(), "Adopted resource.container.api")
+}
+
+func TestDoctorAdoptAndRemoveRemovesOrphansNotInBlueprint(t *testing.T) {
+	c, md, _, _ := setupDoctor(t, "")
+	c.SetArgs([]string{"--adopt", "--remove", t.TempDir()})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	md.AssertNotCalled(t, "ContainerRemove", mock.Anything, mock.Anything, mock.Anything)
+	md.AssertCalled(t, "NetworkRemove", mock.Anything, "123")
+}
+
+func TestDoctorPromptsForAction(t *testing.T) {
+	c, md, _, output := setupDoctor(t, "a\nr\n")
+	c.SetArgs([]string{t.TempDir()})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "Adopt, remove, or skip container api.container.local.jmpd.in? [a/r/S]: ")
+	require.Contains(t, output.String(), "Remove or skip network cloud? [r/S]: ")
+	require.Contains(t, output.String(), "Adopted resource.container.api")
+
+	md.AssertNotCalled(t, "ContainerRemove", mock.Anything, mock.Anything, mock.Anything)
+	md.AssertCalled(t, "NetworkRemove", mock.Anything, "123")
+}
+
+func TestDoctorAdoptWithoutBlueprintReturnsError(t *testing.T) {
+	c, _, _, _ := setupDoctor(t, "")
+	c.SetArgs([]string{"--adopt"})
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "blueprint that created the orphans must be specified")
+}
+
+func TestDoctorWithRemoteBlueprintReturnsError(t *testing.T) {
+	c, _, _, _ := setupDoctor(t, "")
+	c.SetArgs([]string{"github.com/jumppad-labs/blueprints//consul"})
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "must be a local folder or file")
+}
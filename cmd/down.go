@@ -5,6 +5,7 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/jumppad-labs/jumppad/pkg/clients"
 	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
@@ -51,24 +52,24 @@ func newDestroyCmd(cc connector.Connector, l logger.Logger) *cobra.Command {
 				cancel()
 			}()
 
+			start := time.Now()
 			err = engine.Destroy(ctx, force)
+
+			if structuredOutput() {
+				r := newCommandReport("down", engine.Results(), start, err)
+
+				werr := writeStructured(cmd.OutOrStdout(), cliFormat, r)
+				if werr != nil {
+					l.Error("Unable to write output", "error", werr)
+				}
+			}
+
 			if err != nil {
 				l.Error("Unable to destroy stack", "error", err)
 				return
 			}
 
-			// clean up the data folders
-			os.RemoveAll(utils.DataFolder("", os.ModePerm))
-			os.RemoveAll(utils.LibraryFolder("", os.ModePerm))
-			os.RemoveAll(utils.JumppadTemp())
-
-			// shutdown ingress when we destroy all resources
-			if cc.IsRunning() {
-				err = cc.Stop()
-				if err != nil {
-					logger.Error("Unable to destroy jumppad daemon", "error", err)
-				}
-			}
+			removeEnvironmentData(cc, logger)
 		},
 	}
 
@@ -76,3 +77,18 @@ func newDestroyCmd(cc connector.Connector, l logger.Logger) *cobra.Command {
 
 	return downCmd
 }
+
+// removeEnvironmentData removes the data folders once the resources have been
+// destroyed and stops the connector when no other environment is using it
+func removeEnvironmentData(cc connector.Connector, l logger.Logger) {
+	os.RemoveAll(utils.DataFolder("", os.ModePerm))
+	os.RemoveAll(utils.LibraryFolder("", os.ModePerm))
+	os.RemoveAll(utils.JumppadTemp())
+
+	if cc.IsRunning() && !otherEnvironmentsRunning() {
+		err := cc.Stop()
+		if err != nil {
+			l.Error("Unable to destroy jumppad daemon", "error", err)
+		}
+	}
+}
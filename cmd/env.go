@@ -92,5 +92,11 @@ func newEnvCmd() *cobra.Command {
 	}
 
 	envCmd.Flags().BoolVarP(&unset, "unset", "", false, "When set to true jumppad will print unset commands for environment variables defined by the blueprint")
+
+	// commands for managing the named environments
+	envCmd.AddCommand(newEnvListCmd())
+	envCmd.AddCommand(newEnvSelectCmd())
+	envCmd.AddCommand(newEnvDeleteCmd())
+
 	return envCmd
 }
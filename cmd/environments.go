@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// cliEnvironment is the environment set with the --env flag
+var cliEnvironment string
+
+// setEnvironment makes the environment set with the --env flag the current
+// environment for this command and any processes it starts
+func setEnvironment() error {
+	if cliEnvironment == "" {
+		return nil
+	}
+
+	err := utils.ValidateEnvironment(cliEnvironment)
+	if err != nil {
+		return err
+	}
+
+	return os.Setenv(utils.EnvironmentEnvVar, cliEnvironment)
+}
+
+// flagValue returns the value of the named flag from the command line
+// arguments, global flags that affect how the clients are created are read
+// before cobra parses the command line
+func flagValue(args []string, name string) string {
+	for i, a := range args {
+		if a == "--" {
+			break
+		}
+
+		if v, ok := strings.CutPrefix(a, "--"+name+"="); ok {
+			return v
+		}
+
+		if a == "--"+name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return ""
+}
+
+// otherEnvironmentsRunning returns true when an environment other than the
+// current environment has resources, the connector is shared by all
+// environments so must not be stopped while they are running
+func otherEnvironmentsRunning() bool {
+	envs, _ := utils.ListEnvironments()
+	for _, e := range envs {
+		if e != utils.Environment() && utils.EnvironmentHasState(e) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newEnvListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the environments, the current environment is marked with *",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			envs, err := utils.ListEnvironments()
+			if err != nil {
+				return err
+			}
+
+			current := utils.Environment()
+			for _, e := range envs {
+				marker := " "
+				if e == current {
+					marker = "*"
+				}
+
+				status := ""
+				if utils.EnvironmentHasState(e) {
+					status = " (running)"
+				}
+
+				cmd.Printf("%s %s%s\n", marker, e, status)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newEnvSelectCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "select [name]",
+		Short: "Select the environment used by future commands",
+		Long: `Select the environment used by future commands, the environment is created if
+it does not exist.
+
+Each environment has separate state, data and log folders, allowing multiple
+blueprints to be run at the same time. The names of the Docker containers,
+networks and volumes include the environment name so the same blueprint can be
+run in several environments, however network subnets must not overlap.`,
+		Example: `
+  # Select the environment staging-demo
+  jumppad env select staging-demo
+
+  # Select the default environment
+  jumppad env select default
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := utils.SelectEnvironment(args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Selected environment %s\n", args[0])
+
+			return nil
+		},
+	}
+}
+
+func newEnvDeleteCmd() *cobra.Command {
+	var force bool
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Delete the state, data and logs for an environment",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if utils.EnvironmentHasState(args[0]) && !force {
+				return fmt.Errorf("environment %s has running resources, run 'jumppad down --env %s' before deleting it or use --force", args[0], args[0])
+			}
+
+			err := utils.DeleteEnvironment(args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Deleted environment %s\n", args[0])
+
+			return nil
+		},
+	}
+
+	deleteCmd.Flags().BoolVarP(&force, "force", "", false, "Delete the environment even when it has running resources, the resources are not removed")
+
+	return deleteCmd
+}
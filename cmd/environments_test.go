@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetEnvironmentSetsEnvVar(t *testing.T) {
+	t.Setenv(utils.EnvironmentEnvVar, "")
+	cliEnvironment = "staging-demo"
+	t.Cleanup(func() { cliEnvironment = "" })
+
+	err := setEnvironment()
+	require.NoError(t, err)
+
+	require.Equal(t, "staging-demo", os.Getenv(utils.EnvironmentEnvVar))
+}
+
+func TestSetEnvironmentWithInvalidNameReturnsError(t *testing.T) {
+	t.Setenv(utils.EnvironmentEnvVar, "")
+	cliEnvironment = "../demo"
+	t.Cleanup(func() { cliEnvironment = "" })
+
+	err := setEnvironment()
+	require.ErrorContains(t, err, "invalid environment name")
+}
+
+func TestFlagValueReturnsValue(t *testing.T) {
+	require.Equal(t, "staging", flagValue([]string{"up", "--env", "staging", "./"}, "env"))
+	require.Equal(t, "staging", flagValue([]string{"status", "--env=staging"}, "env"))
+}
+
+func TestFlagValueReturnsEmptyWhenNotSet(t *testing.T) {
+	require.Equal(t, "", flagValue([]string{"up", "./"}, "env"))
+	require.Equal(t, "", flagValue([]string{"up", "--env"}, "env"))
+	require.Equal(t, "", flagValue([]string{"exec", "--", "--env", "staging"}, "env"))
+}
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/spf13/cobra"
+)
+
+func newExecCmd(ct container.ContainerTasks) *cobra.Command {
+	var pod string
+	var namespace string
+
+	execCmd := &cobra.Command{
+		Use:     "exec [resource] -- [command]",
+		Short:   "Run a command or open an interactive shell in a resource",
+		Long:    "Run a command or open an interactive shell in a resource, when no command is specified sh is started",
+		Aliases: []string{"shell"},
+		Example: `
+  # Open a shell in a container
+  jumppad exec resource.container.db
+
+  # Run a command in a container
+  jumppad exec resource.container.db -- psql -U postgres
+
+  # Open a shell in a pod running in a Kubernetes cluster
+  jumppad exec resource.k8s_cluster.dev --pod consul-server-0 --namespace consul
+	`,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: getResources,
+		RunE:              newExecCmdFunc(ct, &pod, &namespace),
+		SilenceUsage:      true,
+	}
+
+	execCmd.Flags().StringVarP(&pod, "pod", "", "", "Name of the pod to exec into, only valid for k8s_cluster resources")
+	execCmd.Flags().StringVarP(&namespace, "namespace", "", "default", "Namespace of the pod")
+
+	return execCmd
+}
+
+func newExecCmdFunc(ct container.ContainerTasks, pod, namespace *string) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// everything after -- is the command to run
+		command := []string{}
+		if d := cmd.ArgsLenAtDash(); d > -1 {
+			command = args[d:]
+			args = args[:d]
+		}
+
+		if len(args) != 1 {
+			return errors.New("the resource to exec into must be specified as an argument")
+		}
+
+		cfg, err := config.LoadState()
+		if err != nil {
+			return errors.New("unable to read state file")
+		}
+
+		r, err := cfg.FindResource(args[0])
+		if err != nil {
+			return fmt.Errorf("%s not found: %s", args[0], err)
+		}
+
+		name, command, err := execTarget(r, *pod, *namespace, command)
+		if err != nil {
+			return err
+		}
+
+		ids, err := ct.FindContainerIDs(name)
+		if err != nil || len(ids) == 0 {
+			return fmt.Errorf("unable to find container %s for %s, is the resource running?", name, args[0])
+		}
+
+		return ct.CreateShell(ids[0], command, os.Stdin, os.Stdout, os.Stderr)
+	}
+}
+
+// execTarget returns the name of the container and the command to run for
+// the resource. Commands for pods are run using kubectl in the cluster's
+// server container.
+func execTarget(r hcltypes.Resource, pod, namespace string, command []string) (string, []string, error) {
+	if r.GetDisabled() {
+		return "", nil, fmt.Errorf("%s is disabled", r.Metadata().ID)
+	}
+
+	names := getFQDNForResource(r)
+	if len(names) == 0 {
+		return "", nil, fmt.Errorf("%s is a %s resource, only resources that run containers support exec", r.Metadata().ID, r.Metadata().Type)
+	}
+
+	if len(command) == 0 {
+		command = []string{"sh"}
+	}
+
+	if pod == "" {
+		return names[0], command, nil
+	}
+
+	if r.Metadata().Type != k8s.TypeK8sCluster {
+		return "", nil, fmt.Errorf("--pod can only be used with %s resources", k8s.TypeK8sCluster)
+	}
+
+	kc := []string{"kubectl", "exec", "-it", "--namespace", namespace, pod, "--"}
+
+	return names[0], append(kc, command...), nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	ct "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecTargetContainerDefaultsToShell(t *testing.T) {
+	c := &ct.Container{}
+	c.Meta = hcltypes.Meta{ID: "resource.container.db", Name: "db", Type: ct.TypeContainer}
+
+	name, command, err := execTarget(c, "", "default", nil)
+	require.NoError(t, err)
+	require.Equal(t, utils.FQDN("db", "", ct.TypeContainer), name)
+	require.Equal(t, []string{"sh"}, command)
+}
+
+func TestExecTargetContainerWithCommand(t *testing.T) {
+	c := &ct.Container{}
+	c.Meta = hcltypes.Meta{ID: "resource.container.db", Name: "db", Type: ct.TypeContainer}
+
+	_, command, err := execTarget(c, "", "default", []string{"psql", "-U", "postgres"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"psql", "-U", "postgres"}, command)
+}
+
+func TestExecTargetPodRunsKubectlInServer(t *testing.T) {
+	k := &k8s.Cluster{}
+	k.Meta = hcltypes.Meta{ID: "resource.k8s_cluster.dev", Name: "dev", Type: k8s.TypeK8sCluster}
+
+	name, command, err := execTarget(k, "consul-0", "consul", nil)
+	require.NoError(t, err)
+	require.Equal(t, "server."+utils.FQDN("dev", "", k8s.TypeK8sCluster), name)
+	require.Equal(t, []string{"kubectl", "exec", "-it", "--namespace", "consul", "consul-0", "--", "sh"}, command)
+}
+
+func TestExecTargetPodWithContainerReturnsError(t *testing.T) {
+	c := &ct.Container{}
+	c.Meta = hcltypes.Meta{ID: "resource.container.db", Name: "db", Type: ct.TypeContainer}
+
+	_, _, err := execTarget(c, "consul-0", "default", nil)
+	require.ErrorContains(t, err, "--pod can only be used with k8s_cluster resources")
+}
+
+func TestExecTargetWithoutContainerReturnsError(t *testing.T) {
+	n := &network.Network{}
+	n.Meta = hcltypes.Meta{ID: "resource.network.main", Name: "main", Type: network.TypeNetwork}
+
+	_, _, err := execTarget(n, "", "default", nil)
+	require.ErrorContains(t, err, "only resources that run containers support exec")
+}
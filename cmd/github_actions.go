@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig"
+	hclerrors "github.com/jumppad-labs/hclconfig/errors"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+)
+
+// githubActions returns true when running in a GitHub Actions workflow
+func githubActions() bool {
+	return os.Getenv("GITHUB_ACTIONS") == "true"
+}
+
+// reportToGitHubActions writes error annotations for a failed apply, or the
+// outputs to the GITHUB_OUTPUT file when the apply succeeds. Nothing is
+// written when not running in GitHub Actions
+func reportToGitHubActions(w io.Writer, e jumppad.Engine, cfg *hclconfig.Config, err error, l logger.Logger) {
+	if !githubActions() {
+		return
+	}
+
+	if err != nil {
+		writeGitHubAnnotations(w, e.Config(), e.Results(), err)
+		return
+	}
+
+	if f := os.Getenv("GITHUB_OUTPUT"); f != "" {
+		werr := writeGitHubOutputs(f, cfg)
+		if werr != nil {
+			l.Error("Unable to write GitHub Actions outputs", "error", werr)
+		}
+	}
+}
+
+// writeGitHubAnnotations writes a workflow command that creates an error
+// annotation for each failed resource, the annotation references the file
+// and line where the resource is defined. Errors parsing the configuration
+// are annotated with the location of the error
+func writeGitHubAnnotations(w io.Writer, cfg *hclconfig.Config, results []jumppad.ResourceResult, err error) {
+	count := 0
+
+	var ce *hclerrors.ConfigError
+	if errors.As(err, &ce) {
+		for _, e := range ce.Errors {
+			var pe *hclerrors.ParserError
+			if !errors.As(e, &pe) {
+				continue
+			}
+
+			level := "error"
+			if pe.Level == hclerrors.ParserErrorLevelWarning {
+				level = "warning"
+			}
+
+			writeGitHubAnnotation(w, level, pe.Filename, pe.Line, pe.Column, "Invalid configuration", pe.Message)
+			count++
+		}
+	}
+
+	for _, r := range results {
+		if r.Status != constants.StatusFailed || r.Error == nil {
+			continue
+		}
+
+		file, line := "", 0
+		if cfg != nil {
+			if res, ferr := cfg.FindResource(r.ID); ferr == nil {
+				file, line = res.Metadata().File, res.Metadata().Line
+			}
+		}
+
+		writeGitHubAnnotation(w, "error", file, line, 0, fmt.Sprintf("%s failed", r.ID), r.Error.Error())
+		count++
+	}
+
+	// always annotate the failure even when it is not caused by a resource
+	if count == 0 {
+		writeGitHubAnnotation(w, "error", "", 0, 0, "Unable to create resources", err.Error())
+	}
+}
+
+// writeGitHubAnnotation writes a single workflow annotation command
+// e.g. ::error file=main.hcl,line=3,title=resource.container.app failed::message
+func writeGitHubAnnotation(w io.Writer, level, file string, line, col int, title, message string) {
+	props := []string{}
+
+	if file != "" {
+		props = append(props, "file="+escapeGitHubProperty(githubPath(file)))
+
+		if line > 0 {
+			props = append(props, fmt.Sprintf("line=%d", line))
+		}
+
+		if col > 0 {
+			props = append(props, fmt.Sprintf("col=%d", col))
+		}
+	}
+
+	props = append(props, "title="+escapeGitHubProperty(title))
+
+	fmt.Fprintf(w, "::%s %s::%s\n", level, strings.Join(props, ","), escapeGitHubData(message))
+}
+
+// writeGitHubOutputs appends the root outputs to the GITHUB_OUTPUT file so
+// that later steps in the job can use them, secrets are redacted
+func writeGitHubOutputs(path string, cfg *hclconfig.Config) error {
+	if cfg == nil {
+		return nil
+	}
+
+	_, names := outputValues(cfg, false)
+
+	keys := []string{}
+	for k := range names {
+		// module outputs are not valid output names
+		if strings.HasPrefix(k, "module.") {
+			continue
+		}
+
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	sb := strings.Builder{}
+	for _, k := range keys {
+		v := rawOutput(names[k])
+
+		// multiline values use the heredoc syntax with a delimiter that is
+		// not part of the value
+		if strings.ContainsAny(v, "\r\n") {
+			delim := "JUMPPAD_EOF"
+			for strings.Contains(v, delim) {
+				delim += "_"
+			}
+
+			fmt.Fprintf(&sb, "%s<<%s\n%s\n%s\n", k, delim, v, delim)
+			continue
+		}
+
+		fmt.Fprintf(&sb, "%s=%s\n", k, v)
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open GitHub output file: %s", err)
+	}
+	defer f.Close()
+
+	_, err = f.WriteString(sb.String())
+
+	return err
+}
+
+// githubPath returns the file path relative to the workspace so that the
+// annotation is shown on the file in the repository
+func githubPath(file string) string {
+	ws := os.Getenv("GITHUB_WORKSPACE")
+	if ws == "" {
+		return file
+	}
+
+	rel, err := filepath.Rel(ws, file)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return file
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
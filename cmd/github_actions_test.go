@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	hclerrors "github.com/jumppad-labs/hclconfig/errors"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	enginemocks "github.com/jumppad-labs/jumppad/pkg/jumppad/mocks"
+	"github.com/stretchr/testify/require"
+)
+
+func setupGitHubConfig(t *testing.T) *hclconfig.Config {
+	t.Setenv("GITHUB_ACTIONS", "true")
+	t.Setenv("GITHUB_WORKSPACE", "/home/runner/work/app")
+
+	cfg := hclconfig.NewConfig()
+	cfg.AppendResource(&container.Container{ResourceBase: types.ResourceBase{Meta: types.Meta{
+		ID:   "resource.container.app",
+		Name: "app",
+		Type: container.TypeContainer,
+		File: "/home/runner/work/app/env/main.hcl",
+		Line: 12,
+	}}})
+
+	addOutput(cfg, "ADDRESS", "localhost:8080")
+	addOutput(cfg, "PORTS", []any{8080, 9090})
+	addOutput(cfg, "CERT", "-----BEGIN-----\nabc\n-----END-----")
+
+	mod := &resources.Output{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "ADDR", Type: resources.TypeOutput, Module: "consul"}}}
+	mod.Value = "consul:8500"
+	cfg.AppendResource(mod)
+
+	return cfg
+}
+
+func addOutput(cfg *hclconfig.Config, name string, value any) {
+	o := &resources.Output{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: name, Type: resources.TypeOutput}}}
+	o.Value = value
+	cfg.AppendResource(o)
+}
+
+func TestGitHubAnnotationsForFailedResource(t *testing.T) {
+	cfg := setupGitHubConfig(t)
+	out := bytes.NewBuffer(nil)
+
+	results := []jumppad.ResourceResult{
+		{ID: "resource.container.app", Status: constants.StatusFailed, Error: fmt.Errorf("unable to pull image: not found")},
+		{ID: "resource.network.cloud", Status: constants.StatusCreated},
+	}
+
+	writeGitHubAnnotations(out, cfg, results, fmt.Errorf("failed"))
+
+	require.Equal(t, "::error file=env/main.hcl,line=12,title=resource.container.app failed::unable to pull image: not found\n", out.String())
+}
+
+func TestGitHubAnnotationsForParserErrors(t *testing.T) {
+	setupGitHubConfig(t)
+	out := bytes.NewBuffer(nil)
+
+	ce := hclerrors.NewConfigError()
+	ce.AppendError(&hclerrors.ParserError{Filename: "/home/runner/work/app/main.hcl", Line: 3, Column: 5, Message: "invalid block,\nexpected resource", Level: hclerrors.ParserErrorLevelError})
+	ce.AppendError(&hclerrors.ParserError{Filename: "/home/runner/work/app/main.hcl", Line: 9, Column: 1, Message: "deprecated", Level: hclerrors.ParserErrorLevelWarning})
+
+	writeGitHubAnnotations(out, nil, nil, ce)
+
+	require.Contains(t, out.String(), "::error file=main.hcl,line=3,col=5,title=Invalid configuration::invalid block,%0Aexpected resource\n")
+	require.Contains(t, out.String(), "::warning file=main.hcl,line=9,col=1,title=Invalid configuration::deprecated\n")
+}
+
+func TestGitHubAnnotationsWithoutResourceErrorAnnotatesFailure(t *testing.T) {
+	setupGitHubConfig(t)
+	out := bytes.NewBuffer(nil)
+
+	writeGitHubAnnotations(out, nil, nil, fmt.Errorf("unable to create image cache: 100%% broken"))
+
+	require.Equal(t, "::error title=Unable to create resources::unable to create image cache: 100%25 broken\n", out.String())
+}
+
+func TestGitHubAnnotationsUsesAbsolutePathOutsideWorkspace(t *testing.T) {
+	setupGitHubConfig(t)
+	out := bytes.NewBuffer(nil)
+
+	ce := hclerrors.NewConfigError()
+	ce.AppendError(&hclerrors.ParserError{Filename: "/tmp/blueprint/main.hcl", Line: 3, Message: "invalid", Level: hclerrors.ParserErrorLevelError})
+
+	writeGitHubAnnotations(out, nil, nil, ce)
+
+	require.Contains(t, out.String(), "file=/tmp/blueprint/main.hcl,line=3,")
+}
+
+func TestGitHubOutputsAppendsRootOutputs(t *testing.T) {
+	cfg := setupGitHubConfig(t)
+
+	f := filepath.Join(t.TempDir(), "output")
+	os.WriteFile(f, []byte("existing=value\n"), 0644)
+
+	err := writeGitHubOutputs(f, cfg)
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(f)
+	require.NoError(t, err)
+
+	require.Equal(t,
+		"existing=value\n"+
+			"ADDRESS=localhost:8080\n"+
+			"CERT<<JUMPPAD_EOF\n-----BEGIN-----\nabc\n-----END-----\nJUMPPAD_EOF\n"+
+			"PORTS=[8080,9090]\n",
+		string(d),
+	)
+}
+
+func TestReportToGitHubActionsDoesNothingOutsideActions(t *testing.T) {
+	cfg := setupGitHubConfig(t)
+	t.Setenv("GITHUB_ACTIONS", "")
+
+	f := filepath.Join(t.TempDir(), "output")
+	t.Setenv("GITHUB_OUTPUT", f)
+
+	out := bytes.NewBuffer(nil)
+	reportToGitHubActions(out, &enginemocks.Engine{}, cfg, nil, logger.NewTestLogger(t))
+
+	require.Empty(t, out.String())
+	require.NoFileExists(t, f)
+}
+
+func TestReportToGitHubActionsWritesOutputs(t *testing.T) {
+	cfg := setupGitHubConfig(t)
+
+	f := filepath.Join(t.TempDir(), "output")
+	t.Setenv("GITHUB_OUTPUT", f)
+
+	out := bytes.NewBuffer(nil)
+	reportToGitHubActions(out, &enginemocks.Engine{}, cfg, nil, logger.NewTestLogger(t))
+
+	require.Empty(t, out.String())
+	require.FileExists(t, f)
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/jumppad/pkg/clients/getter"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newGraphCmd(e jumppad.Engine, bp getter.Getter) *cobra.Command {
+	var variables []string
+	var variablesFile string
+	var format string
+	var status bool
+
+	graphCmd := &cobra.Command{
+		Use:   "graph [file] | [directory]",
+		Short: "Output the dependency graph for the configuration at the given path",
+		Long: `Output the dependency graph for the configuration at the given path.
+
+The graph can be rendered as DOT for use with Graphviz or as a Mermaid
+flowchart that can be embedded in markdown documentation.`,
+		Example: `
+  # Render the graph for the configuration in the current folder as a PNG
+  jumppad graph | dot -Tpng > graph.png
+
+  # Output the graph as a Mermaid flowchart including the status of created resources
+  jumppad graph --format mermaid --status ./my-stack
+	`,
+		Args:         cobra.ArbitraryArgs,
+		RunE:         newGraphCmdFunc(e, bp, &variables, &variablesFile, &format, &status),
+		SilenceUsage: true,
+	}
+
+	graphCmd.Flags().StringSliceVarP(&variables, "var", "", nil, "Allows setting variables from the command line, variables are specified as a key and value, e.g --var key=value. Can be specified multiple times")
+	graphCmd.Flags().StringVarP(&variablesFile, "vars-file", "", "", "Load variables from a location other than *.vars files in the blueprint folder. E.g --vars-file=./file.vars")
+	graphCmd.Flags().StringVarP(&format, "format", "", jumppad.GraphFormatDOT, "Format of the graph, either dot or mermaid")
+	graphCmd.Flags().BoolVarP(&status, "status", "", false, "Annotate the graph with the status of resources in the state")
+
+	return graphCmd
+}
+
+func newGraphCmdFunc(e jumppad.Engine, bp getter.Getter, variables *[]string, variablesFile, format *string, status *bool) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		// create the jumppad and sub folders in the users home directory
+		utils.CreateFolders()
+
+		// parse the vars into a map
+		vars := map[string]string{}
+		for _, v := range *variables {
+			// if the variable is wrapped in single quotes remove them
+			v = strings.TrimPrefix(v, "'")
+			v = strings.TrimSuffix(v, "'")
+
+			parts := strings.Split(v, "=")
+			if len(parts) >= 2 {
+				vars[parts[0]] = strings.Join(parts[1:], "=")
+			}
+		}
+
+		// check the variables file exists
+		if variablesFile != nil && *variablesFile != "" {
+			if _, err := os.Stat(*variablesFile); err != nil {
+				return fmt.Errorf("variables file %s, does not exist", *variablesFile)
+			}
+		} else {
+			vf := ""
+			variablesFile = &vf
+		}
+
+		dst := "./"
+		if len(args) == 1 {
+			dst = args[0]
+		}
+
+		if !utils.IsLocalFolder(dst) && !utils.IsHCLFile(dst) {
+			// fetch the remote server from github
+			bp.SetForce(true)
+			err := bp.Get(dst, utils.BlueprintLocalFolder(dst))
+			if err != nil {
+				return fmt.Errorf("unable to retrieve blueprint: %s", err)
+			}
+
+			dst = utils.BlueprintLocalFolder(dst)
+		}
+
+		c, err := e.ParseConfigWithVariables(dst, vars, *variablesFile)
+		if err != nil {
+			return err
+		}
+
+		// the state is only used to annotate the graph, when there is no
+		// state the graph is rendered without the status
+		var state *hclconfig.Config
+		if *status {
+			state, _ = config.LoadState()
+		}
+
+		g, err := jumppad.Graph(c, state, *format)
+		if err != nil {
+			return err
+		}
+
+		cmd.Print(g)
+
+		return nil
+	}
+}
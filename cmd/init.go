@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/jumppad-labs/jumppad/cmd/templates"
+	"github.com/jumppad-labs/jumppad/pkg/clients/getter"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+func newInitCmd(bp getter.Getter) *cobra.Command {
+	var variables []string
+	var nonInteractive bool
+
+	initCmd := &cobra.Command{
+		Use:   "init [template] [directory]",
+		Short: "Create a new blueprint from a template",
+		Long: `Create a new blueprint from a template.
+
+The built-in templates are listed when no template is given, a template can
+also be a local folder or a remote source such as a GitHub repository. Values
+for the template variables that are not set with --var are asked for, use
+--non-interactive to use the default values.
+
+Remote templates can describe their variables in a jumppad-template.hcl file,
+files ending in .tmpl are rendered using Go templates with [[ ]] delimiters.`,
+		Example: `
+  # List the built-in templates
+  jumppad init
+
+  # Create a Kubernetes blueprint in the folder ./my-stack
+  jumppad init kubernetes ./my-stack
+
+  # Create a container blueprint using the default values
+  jumppad init container --var name=web --non-interactive
+
+  # Create a blueprint from a template in a GitHub repository
+  jumppad init github.com/org/templates//consul ./consul
+	`,
+		Args:         cobra.MaximumNArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return listTemplates(cmd)
+			}
+
+			vars := map[string]string{}
+			for _, v := range variables {
+				parts := strings.SplitN(v, "=", 2)
+				if len(parts) != 2 {
+					return fmt.Errorf("invalid variable %s, variables must be specified as key=value", v)
+				}
+
+				vars[parts[0]] = parts[1]
+			}
+
+			dst := "./"
+			if len(args) == 2 {
+				dst = args[1]
+			}
+
+			prompt := !nonInteractive
+			if f, ok := cmd.InOrStdin().(*os.File); ok && !term.IsTerminal(int(f.Fd())) {
+				// there is nobody to answer the questions
+				prompt = false
+			}
+
+			return initBlueprint(cmd, bp, args[0], dst, vars, prompt)
+		},
+	}
+
+	initCmd.Flags().StringSliceVarP(&variables, "var", "", nil, "Set the value of a template variable, e.g --var name=web. Can be specified multiple times")
+	initCmd.Flags().BoolVarP(&nonInteractive, "non-interactive", "", false, "Do not ask for variable values, the default values are used")
+
+	return initCmd
+}
+
+func listTemplates(cmd *cobra.Command) error {
+	ts, err := templates.Builtin()
+	if err != nil {
+		return err
+	}
+
+	cmd.Println("Available templates:")
+	cmd.Println("")
+
+	for _, t := range ts {
+		cmd.Printf("  %-12s %s\n", t.Name, t.Description)
+	}
+
+	cmd.Println("")
+	cmd.Println("Create a blueprint with: jumppad init [template] [directory]")
+
+	return nil
+}
+
+// initBlueprint renders the template to dst, when prompt is true the values
+// for variables that are not in vars are asked for
+func initBlueprint(cmd *cobra.Command, bp getter.Getter, name, dst string, vars map[string]string, prompt bool) error {
+	t, err := loadTemplate(bp, name)
+	if err != nil {
+		return err
+	}
+
+	if prompt {
+		vs := []blueprintVariable{}
+		for _, v := range t.Variables {
+			if _, ok := vars[v.Name]; ok {
+				continue
+			}
+
+			vs = append(vs, blueprintVariable{
+				Name:        v.Name,
+				Description: v.Description,
+				Default:     strconv.Quote(v.Default),
+				Required:    v.Required,
+			})
+		}
+
+		if len(vs) > 0 {
+			cmd.Println("Enter the values for the template variables, press enter to use the default value")
+
+			answers, err := promptVariables(bufio.NewReader(cmd.InOrStdin()), cmd.OutOrStdout(), vs, nil)
+			if err != nil {
+				return err
+			}
+
+			for k, v := range answers {
+				vars[k] = v
+			}
+
+			cmd.Println("")
+		}
+	}
+
+	created, err := t.Render(dst, vars)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range created {
+		if rel, err := filepath.Rel(dst, f); err == nil {
+			f = rel
+		}
+
+		cmd.Printf("Created %s\n", filepath.ToSlash(f))
+	}
+
+	cmd.Println("")
+	cmd.Printf("To create the resources run: jumppad up %s\n", dst)
+
+	return nil
+}
+
+// loadTemplate returns the built-in template with the given name, otherwise
+// the template is read from the local folder or remote source
+func loadTemplate(bp getter.Getter, name string) (*templates.Template, error) {
+	t, ok, err := templates.Find(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		return t, nil
+	}
+
+	dir := name
+	if !utils.IsLocalFolder(dir) {
+		if !strings.ContainsAny(name, "./:") {
+			return nil, fmt.Errorf("unknown template %s, run jumppad init to list the built-in templates", name)
+		}
+
+		dir = utils.BlueprintLocalFolder(name)
+
+		bp.SetForce(true)
+		err := bp.Get(name, dir)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve template: %s", err)
+		}
+	}
+
+	return templates.Load(filepath.Base(name), os.DirFS(dir))
+}
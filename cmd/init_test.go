@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gettermock "github.com/jumppad-labs/jumppad/pkg/clients/getter/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupInit(t *testing.T, input string) (*cobra.Command, *gettermock.Getter, *bytes.Buffer) {
+	t.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	mg := &gettermock.Getter{}
+	mg.On("SetForce", true)
+	mg.On("Get", mock.Anything, mock.Anything).Return(func(uri, dst string) error {
+		os.MkdirAll(dst, os.ModePerm)
+		os.WriteFile(filepath.Join(dst, "main.hcl.tmpl"), []byte(`resource "network" "[[ .name ]]" {}`), 0644)
+
+		return os.WriteFile(filepath.Join(dst, "jumppad-template.hcl"), []byte(`variable "name" { default = "main" }`), 0644)
+	})
+
+	output := bytes.NewBuffer(nil)
+
+	c := newInitCmd(mg)
+	c.SetOut(output)
+	c.SetErr(output)
+	c.SetIn(strings.NewReader(input))
+
+	return c, mg, output
+}
+
+func TestInitWithNoArgsListsTemplates(t *testing.T) {
+	c, _, output := setupInit(t, "")
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "container")
+	require.Contains(t, output.String(), "kubernetes")
+	require.Contains(t, output.String(), "nomad")
+	require.Contains(t, output.String(), "docs")
+}
+
+func TestInitCreatesBlueprintFromBuiltinTemplate(t *testing.T) {
+	c, mg, output := setupInit(t, "")
+	dst := filepath.Join(t.TempDir(), "app")
+	c.SetArgs([]string{"container", dst, "--var", "name=web", "--non-interactive"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(dst, "main.hcl"))
+	require.NoError(t, err)
+	require.Contains(t, string(d), `resource "container" "web"`)
+
+	require.Contains(t, output.String(), "Created main.hcl")
+	mg.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+}
+
+func TestInitPromptsForVariables(t *testing.T) {
+	c, _, output := setupInit(t, "web\n\n8080\n")
+	dst := filepath.Join(t.TempDir(), "app")
+
+	err := initBlueprint(c, nil, "container", dst, map[string]string{}, true)
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(dst, "main.hcl"))
+	require.NoError(t, err)
+	require.Contains(t, string(d), `resource "container" "web"`)
+	require.Contains(t, string(d), `local  = 8080`)
+
+	require.Contains(t, output.String(), `name ["app"]: `)
+}
+
+func TestInitDoesNotPromptForSetVariables(t *testing.T) {
+	c, _, output := setupInit(t, "\n\n")
+	dst := filepath.Join(t.TempDir(), "app")
+
+	err := initBlueprint(c, nil, "container", dst, map[string]string{"name": "web"}, true)
+	require.NoError(t, err)
+
+	require.NotContains(t, output.String(), `name ["app"]: `)
+}
+
+func TestInitCreatesBlueprintFromRemoteTemplate(t *testing.T) {
+	c, mg, _ := setupInit(t, "")
+	dst := filepath.Join(t.TempDir(), "app")
+	c.SetArgs([]string{"github.com/org/templates//network", dst, "--non-interactive"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	mg.AssertCalled(t, "Get", "github.com/org/templates//network", mock.Anything)
+
+	d, err := os.ReadFile(filepath.Join(dst, "main.hcl"))
+	require.NoError(t, err)
+	require.Equal(t, `resource "network" "main" {}`, string(d))
+}
+
+func TestInitWithUnknownTemplateReturnsError(t *testing.T) {
+	c, _, _ := setupInit(t, "")
+	c.SetArgs([]string{"unknown", t.TempDir()})
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "unknown template unknown")
+}
+
+func TestInitWithInvalidVariableReturnsError(t *testing.T) {
+	c, _, _ := setupInit(t, "")
+	c.SetArgs([]string{"container", t.TempDir(), "--var", "name"})
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "invalid variable name")
+}
@@ -28,6 +28,8 @@ import (
 )
 
 func newLogCmd(dc container.Docker, stdout, stderr io.Writer) *cobra.Command {
+	var resource string
+
 	logCmd := &cobra.Command{
 		Use:     "logs [resource]",
 		Short:   "Tails logs for running jumppad resources",
@@ -39,12 +41,17 @@ func newLogCmd(dc container.Docker, stdout, stderr io.Writer) *cobra.Command {
 
 	# Tail logs for a specific resource
 	jumppad logs resource.container.nginx
+
+	# Show the logs written by jumppad while creating or destroying a resource
+	jumppad logs --resource resource.container.nginx
 	`,
 		Args:              cobra.ArbitraryArgs,
 		ValidArgsFunction: getResources,
-		RunE:              newLogCmdFunc(dc, stdout, stderr),
+		RunE:              newLogCmdFunc(dc, stdout, stderr, &resource),
 	}
 
+	logCmd.Flags().StringVarP(&resource, "resource", "", "", "Show the logs written by jumppad while creating or destroying the resource instead of the container logs")
+
 	return logCmd
 }
 
@@ -67,8 +74,12 @@ func getResources(cmd *cobra.Command, args []string, complete string) ([]string,
 	return loggable, cobra.ShellCompDirectiveNoFileComp
 }
 
-func newLogCmdFunc(dc container.Docker, stdout, stderr io.Writer) func(cmd *cobra.Command, args []string) error {
+func newLogCmdFunc(dc container.Docker, stdout, stderr io.Writer, resource *string) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
+		if *resource != "" {
+			return writeResourceLog(stdout, *resource)
+		}
+
 		log := createLogger()
 		sigs := make(chan os.Signal, 1)
 		signal.Notify(sigs, os.Interrupt)
@@ -135,6 +146,23 @@ func newLogCmdFunc(dc container.Docker, stdout, stderr io.Writer) func(cmd *cobr
 	}
 }
 
+// writeResourceLog writes the provider log file for the resource with the
+// given id to w
+func writeResourceLog(w io.Writer, id string) error {
+	f, err := os.Open(utils.ResourceLogFile(id))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no logs found for %s, logs are written when the resource is created or destroyed", id)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to open logs for %s: %s", id, err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(w, f)
+	return err
+}
+
 // if this methods returns and error, it will get returned as shell-completion data
 // otherwise fmt.println() gets lost
 func getLoggable() ([]string, error) {
@@ -1,22 +1,51 @@
 package cmd
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
+	"text/template"
 
+	"github.com/Masterminds/sprig/v3"
 	"github.com/hokaccha/go-prettyjson"
+	"github.com/jumppad-labs/hclconfig"
 	"github.com/jumppad-labs/hclconfig/resources"
 	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
+var showSensitive bool
+var outputJSON bool
+var outputRaw string
+var outputFormat string
+
 var outputCmd = &cobra.Command{
-	Use:   "output",
+	Use:   "output [name]",
 	Short: "Show the output variables",
-	Long:  `Show the output variables`,
-	Args:  cobra.MaximumNArgs(1),
+	Long: `Show the output variables for the blueprint and its modules.
+
+Outputs defined in modules are grouped under the key "module" and the name of
+the module, single module outputs can be referenced as module.<module>.<name>`,
+	Example: `
+  # show all outputs
+  jumppad output
+
+  # print the value of an output without quotes for use in scripts
+  jumppad output --raw consul_addr
+
+  # print the value of an output defined in a module
+  jumppad output --raw module.consul.addr
+
+  # show all outputs as YAML
+  jumppad output --format yaml
+
+  # format the outputs using a Go template
+  jumppad output --format '{{ .consul_addr }}:{{ .consul_port }}'
+	`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		// load the stack
 		cfg, err := config.LoadState()
@@ -25,30 +54,175 @@ var outputCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		out := map[string]interface{}{}
-		// get the output variables
-		for _, r := range cfg.Resources {
-			if r.Metadata().Type == resources.TypeOutput {
-				// don't output when disabled
-				if r.GetDisabled() {
-					continue
-				}
-
-				if r.Metadata().Module != "" {
-					continue
-				}
-
-				out[r.Metadata().Name] = r.(*resources.Output).Value
-
-				if len(args) > 0 && strings.EqualFold(args[0], r.Metadata().Name) {
-					d, _ := json.Marshal(r.(*resources.Output).Value)
-					fmt.Printf("%s", string(d))
-					return
-				}
+		out, names := outputValues(cfg, showSensitive)
+
+		if outputRaw != "" {
+			v, ok := findOutput(names, outputRaw)
+			if !ok {
+				cmd.Printf("Error: Output %s not found\n", outputRaw)
+				os.Exit(1)
+			}
+
+			fmt.Printf("%s", rawOutput(v))
+			return
+		}
+
+		if len(args) > 0 {
+			if v, ok := findOutput(names, args[0]); ok {
+				d, _ := json.Marshal(v)
+				fmt.Printf("%s", string(d))
+				return
+			}
+		}
+
+		switch outputFormat {
+		case "", formatText:
+			// pretty printed JSON
+		case formatJSON:
+			outputJSON = true
+		case formatYAML:
+			err := writeStructured(os.Stdout, formatYAML, out)
+			if err != nil {
+				cmd.Println("Error: Unable to format outputs, ", err)
+				os.Exit(1)
+			}
+
+			return
+		default:
+			s, err := formatOutputs(out, outputFormat)
+			if err != nil {
+				cmd.Println("Error: Unable to format outputs, ", err)
+				os.Exit(1)
 			}
+
+			fmt.Printf("%s", s)
+			return
+		}
+
+		if outputJSON {
+			d, _ := json.Marshal(out)
+			fmt.Printf("%s", string(d))
+			return
 		}
 
 		d, _ := prettyjson.Marshal(out)
 		fmt.Printf("%s", string(d))
 	},
 }
+
+func init() {
+	outputCmd.Flags().BoolVarP(&showSensitive, "show-sensitive", "", false, "Show the values of outputs that contain secrets")
+	outputCmd.Flags().BoolVarP(&outputJSON, "json", "", false, "Output the values as unformatted JSON")
+	outputCmd.Flags().StringVarP(&outputRaw, "raw", "", "", "Print the value of the named output, strings are printed without quotes")
+	outputCmd.Flags().StringVarP(&outputFormat, "format", "", "", "Format the outputs as text, json, yaml, or using a Go template, e.g. --format '{{ .addr }}'")
+}
+
+// outputValues returns the outputs in the state, outputs defined in modules
+// are nested under the key module. Also returns a flat map of the outputs
+// keyed by name, or module.<module>.<name> for module outputs
+func outputValues(cfg *hclconfig.Config, sensitive bool) (map[string]any, map[string]any) {
+	out := map[string]any{}
+	names := map[string]any{}
+	modules := map[string]any{}
+
+	// get the output variables
+	for _, r := range cfg.Resources {
+		if r.Metadata().Type != resources.TypeOutput {
+			continue
+		}
+
+		// don't output when disabled
+		if r.GetDisabled() {
+			continue
+		}
+
+		value := r.(*resources.Output).Value
+		if !sensitive {
+			value = redactValue(value)
+		}
+
+		if r.Metadata().Module == "" {
+			out[r.Metadata().Name] = value
+			names[r.Metadata().Name] = value
+			continue
+		}
+
+		m, ok := modules[r.Metadata().Module].(map[string]any)
+		if !ok {
+			m = map[string]any{}
+			modules[r.Metadata().Module] = m
+		}
+
+		m[r.Metadata().Name] = value
+		names[fmt.Sprintf("module.%s.%s", r.Metadata().Module, r.Metadata().Name)] = value
+	}
+
+	if len(modules) > 0 {
+		out["module"] = modules
+	}
+
+	return out, names
+}
+
+// findOutput returns the output with the given name, names are not case
+// sensitive
+func findOutput(names map[string]any, name string) (any, bool) {
+	for k, v := range names {
+		if strings.EqualFold(k, name) {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+// rawOutput returns strings as is and all other values as JSON
+func rawOutput(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	d, _ := json.Marshal(v)
+	return string(d)
+}
+
+// formatOutputs renders the outputs using the Go template format
+func formatOutputs(out map[string]any, format string) (string, error) {
+	tmpl, err := template.New("output").Funcs(sprig.TxtFuncMap()).Parse(format)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse template: %w", err)
+	}
+
+	bs := bytes.NewBufferString("")
+	err = tmpl.Execute(bs, out)
+	if err != nil {
+		return "", fmt.Errorf("unable to execute template: %w", err)
+	}
+
+	return bs.String(), nil
+}
+
+// redactValue replaces any secrets in the output value, secrets are
+// registered when the state is loaded
+func redactValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		return utils.Redact(val)
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, e := range val {
+			out[i] = redactValue(e)
+		}
+
+		return out
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for k, e := range val {
+			out[k] = redactValue(e)
+		}
+
+		return out
+	}
+
+	return v
+}
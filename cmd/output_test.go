@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOutputConfig() *hclconfig.Config {
+	c := hclconfig.NewConfig()
+
+	addr := &resources.Output{Value: "localhost"}
+	addr.Meta = hcltypes.Meta{Name: "addr", Type: resources.TypeOutput}
+
+	port := &resources.Output{Value: 8500}
+	port.Meta = hcltypes.Meta{Name: "port", Type: resources.TypeOutput}
+
+	module := &resources.Output{Value: "10.0.0.2"}
+	module.Meta = hcltypes.Meta{Name: "ip", Type: resources.TypeOutput, Module: "consul"}
+
+	disabled := &resources.Output{Value: "disabled"}
+	disabled.Meta = hcltypes.Meta{Name: "disabled", Type: resources.TypeOutput}
+	disabled.Disabled = true
+
+	c.Resources = append(c.Resources, addr, port, module, disabled)
+
+	return c
+}
+
+func TestOutputValuesNestsModuleOutputs(t *testing.T) {
+	out, names := outputValues(setupOutputConfig(), true)
+
+	require.Equal(t, "localhost", out["addr"])
+	require.Equal(t, map[string]any{"consul": map[string]any{"ip": "10.0.0.2"}}, out["module"])
+	require.Equal(t, "10.0.0.2", names["module.consul.ip"])
+	require.NotContains(t, out, "disabled")
+}
+
+func TestFindOutputIgnoresCase(t *testing.T) {
+	_, names := outputValues(setupOutputConfig(), true)
+
+	v, ok := findOutput(names, "Module.Consul.IP")
+	require.True(t, ok)
+	require.Equal(t, "10.0.0.2", v)
+}
+
+func TestRawOutputPrintsStringsWithoutQuotes(t *testing.T) {
+	require.Equal(t, "localhost", rawOutput("localhost"))
+	require.Equal(t, `["a","b"]`, rawOutput([]any{"a", "b"}))
+}
+
+func TestFormatOutputsRendersTemplate(t *testing.T) {
+	out, _ := outputValues(setupOutputConfig(), true)
+
+	s, err := formatOutputs(out, `{{ .addr }}:{{ .port }} {{ .module.consul.ip | upper }}`)
+	require.NoError(t, err)
+	require.Equal(t, "localhost:8500 10.0.0.2", s)
+}
+
+func TestFormatOutputsInvalidTemplateReturnsError(t *testing.T) {
+	_, err := formatOutputs(map[string]any{}, `{{ .addr `)
+	require.Error(t, err)
+}
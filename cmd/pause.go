@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newPauseCmd(dc container.Docker, cc connector.Connector, l logger.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause",
+		Short: "Stop the containers and the connector without removing them",
+		Long: `Stop the containers and the connector without removing them.
+
+Paused environments do not use any CPU or memory, the containers keep their
+networks, volumes and filesystems and can be started again with the resume
+command.`,
+		Example: `jumppad pause`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadState()
+			if err != nil {
+				return fmt.Errorf("unable to load state, have you run jumppad up: %s", err)
+			}
+
+			// containers are stopped in the reverse order they are started
+			names := pauseContainers(cfg)
+			for i := len(names) - 1; i >= 0; i-- {
+				l.Info("Stopping container", "name", names[i])
+
+				err := dc.ContainerStop(context.Background(), names[i], dcontainer.StopOptions{})
+				if err != nil {
+					return fmt.Errorf("unable to stop container %s: %s", names[i], err)
+				}
+			}
+
+			if cc.IsRunning() && !otherEnvironmentsRunning() {
+				l.Info("Stopping connector")
+
+				err := cc.Stop()
+				if err != nil {
+					return fmt.Errorf("unable to stop connector: %s", err)
+				}
+			}
+
+			cmd.Println("")
+			cmd.Println("Environment paused, run 'jumppad resume' to start it again")
+
+			return nil
+		},
+	}
+}
+
+func newResumeCmd(c *clients.Clients, l logger.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:     "resume",
+		Short:   "Start the containers and the connector of a paused environment",
+		Long:    "Start the containers and the connector of an environment paused with the pause command",
+		Example: `jumppad resume`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadState()
+			if err != nil {
+				return fmt.Errorf("unable to load state, have you run jumppad up: %s", err)
+			}
+
+			if !c.Connector.IsRunning() {
+				cb, err := c.Connector.GetLocalCertBundle(utils.CertsDir(""))
+				if err != nil {
+					return fmt.Errorf("unable to get certificates to secure ingress: %s", err)
+				}
+
+				l.Info("Starting connector")
+
+				err = c.Connector.Start(cb)
+				if err != nil {
+					return fmt.Errorf("unable to start connector: %s", err)
+				}
+			}
+
+			for _, n := range pauseContainers(cfg) {
+				l.Info("Starting container", "name", n)
+
+				err := c.Docker.ContainerStart(context.Background(), n, dcontainer.StartOptions{})
+				if err != nil {
+					return fmt.Errorf("unable to start container %s: %s", n, err)
+				}
+			}
+
+			// the connector has been restarted so the services for the
+			// ingress resources need to be exposed again
+			p := config.NewProviders(c)
+			for _, r := range cfg.Resources {
+				if r.GetDisabled() || r.Metadata().Type != ingress.TypeIngress {
+					continue
+				}
+
+				err := p.GetProvider(r).Refresh(context.Background())
+				if err != nil {
+					return fmt.Errorf("unable to expose ingress %s: %s", r.Metadata().ID, err)
+				}
+			}
+
+			cmd.Println("")
+			cmd.Println("Environment resumed")
+
+			return nil
+		},
+	}
+}
+
+// pauseContainers returns the names of the containers for the resources in
+// the state in the order they need to be started, the image cache is started
+// first as clusters pull images through it and sidecars last as they share
+// the network of another container
+func pauseContainers(cfg *hclconfig.Config) []string {
+	resources := []hcltypes.Resource{}
+	for _, r := range cfg.Resources {
+		if !r.GetDisabled() && pauseOrder(r) >= 0 {
+			resources = append(resources, r)
+		}
+	}
+
+	sort.SliceStable(resources, func(i, j int) bool {
+		return pauseOrder(resources[i]) < pauseOrder(resources[j])
+	})
+
+	names := []string{}
+	for _, r := range resources {
+		names = append(names, containerNames(r)...)
+	}
+
+	return names
+}
+
+// containerNames returns the names of the containers created by the
+// resource, resources that have not been created do not have any containers
+func containerNames(r hcltypes.Resource) []string {
+	names := []string{}
+	add := func(n ...string) {
+		for _, name := range n {
+			// containers that have not been created do not have a name
+			if name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	switch v := r.(type) {
+	case *cache.ImageCache:
+		add(utils.FQDN(v.Meta.Name, v.Meta.Module, v.Meta.Type))
+	case *k8s.Cluster:
+		add(v.ContainerName)
+	case *nomad.NomadCluster:
+		add(v.ServerContainerName)
+		add(v.ClientContainerName...)
+	case *ctypes.Container:
+		add(v.ContainerName)
+	case *docs.Docs:
+		add(v.ContainerName)
+	case *ctypes.Sidecar:
+		add(v.ContainerName)
+	}
+
+	return names
+}
+
+// pauseOrder returns the position that containers for the resource are
+// started in, -1 is returned for resources that do not create containers
+func pauseOrder(r hcltypes.Resource) int {
+	switch r.Metadata().Type {
+	case cache.TypeImageCache:
+		return 0
+	case k8s.TypeK8sCluster, nomad.TypeNomadCluster:
+		return 1
+	case ctypes.TypeContainer, docs.TypeDocs:
+		return 2
+	case ctypes.TypeSidecar:
+		return 3
+	default:
+		return -1
+	}
+}
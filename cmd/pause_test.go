@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseContainersReturnsContainersInStartOrder(t *testing.T) {
+	c := hclconfig.NewConfig()
+
+	sc := &container.Sidecar{ContainerName: "envoy.sidecar.jmpd.in"}
+	sc.Meta = hcltypes.Meta{ID: "resource.sidecar.envoy", Name: "envoy", Type: container.TypeSidecar, Properties: map[string]any{}}
+	c.AppendResource(sc)
+
+	ct := &container.Container{ContainerName: "consul.container.jmpd.in"}
+	ct.Meta = hcltypes.Meta{ID: "resource.container.consul", Name: "consul", Type: container.TypeContainer, Properties: map[string]any{}}
+	c.AppendResource(ct)
+
+	nc := &nomad.NomadCluster{ServerContainerName: "server.dev.nomad-cluster.jmpd.in", ClientContainerName: []string{"1.client.dev.nomad-cluster.jmpd.in"}}
+	nc.Meta = hcltypes.Meta{ID: "resource.nomad_cluster.dev", Name: "dev", Type: nomad.TypeNomadCluster, Properties: map[string]any{}}
+	c.AppendResource(nc)
+
+	n := &network.Network{}
+	n.Meta = hcltypes.Meta{ID: "resource.network.main", Name: "main", Type: network.TypeNetwork, Properties: map[string]any{}}
+	c.AppendResource(n)
+
+	names := pauseContainers(c)
+
+	require.Equal(t, []string{
+		"server.dev.nomad-cluster.jmpd.in",
+		"1.client.dev.nomad-cluster.jmpd.in",
+		"consul.container.jmpd.in",
+		"envoy.sidecar.jmpd.in",
+	}, names)
+}
+
+func TestPauseContainersIgnoresDisabledAndUncreatedResources(t *testing.T) {
+	c := hclconfig.NewConfig()
+
+	disabled := &container.Container{ContainerName: "vault.container.jmpd.in"}
+	disabled.Meta = hcltypes.Meta{ID: "resource.container.vault", Name: "vault", Type: container.TypeContainer, Properties: map[string]any{}}
+	disabled.Disabled = true
+	c.AppendResource(disabled)
+
+	pending := &container.Container{}
+	pending.Meta = hcltypes.Meta{ID: "resource.container.consul", Name: "consul", Type: container.TypeContainer, Properties: map[string]any{}}
+	c.AppendResource(pending)
+
+	require.Empty(t, pauseContainers(c))
+}
@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ct "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/spf13/cobra"
+)
+
+// localConnectorAddr is the address of the local connector, containers are
+// reached by the local connector forwarding to itself
+const localConnectorAddr = "localhost:30001"
+
+// portForwardOptions define the service in a cluster to forward to
+type portForwardOptions struct {
+	service   string
+	namespace string
+	job       string
+	group     string
+	task      string
+}
+
+func newPortForwardCmd(cc connector.Connector) *cobra.Command {
+	opts := portForwardOptions{}
+
+	portForwardCmd := &cobra.Command{
+		Use:   "port-forward [resource] [local_port:]remote_port",
+		Short: "Forward a local port to a port on a resource",
+		Long: `Forward a local port to a port on a resource using the connector, the
+port is forwarded until the command is stopped with Ctrl+C.
+
+Ports can be forwarded to containers, services running in Kubernetes clusters,
+and tasks running in Nomad clusters.`,
+		Example: `
+  # Forward local port 5432 to port 5432 on a container
+  jumppad port-forward resource.container.db 5432
+
+  # Forward local port 8500 to port 8500 on a Kubernetes service
+  jumppad port-forward resource.k8s_cluster.dev 8500 --service consul-server --namespace consul
+
+  # Forward local port 9090 to port 8080 on a Nomad task
+  jumppad port-forward resource.nomad_cluster.dev 9090:8080 --job example --group web --task app
+	`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: getResources,
+		RunE:              newPortForwardCmdFunc(cc, &opts),
+		SilenceUsage:      true,
+	}
+
+	portForwardCmd.Flags().StringVarP(&opts.service, "service", "", "", "Name of the Kubernetes service to forward to")
+	portForwardCmd.Flags().StringVarP(&opts.namespace, "namespace", "", "default", "Namespace of the Kubernetes service")
+	portForwardCmd.Flags().StringVarP(&opts.job, "job", "", "", "Name of the Nomad job to forward to")
+	portForwardCmd.Flags().StringVarP(&opts.group, "group", "", "", "Name of the Nomad task group")
+	portForwardCmd.Flags().StringVarP(&opts.task, "task", "", "", "Name of the Nomad task")
+
+	return portForwardCmd
+}
+
+func newPortForwardCmdFunc(cc connector.Connector, opts *portForwardOptions) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if !cc.IsRunning() {
+			return errors.New("the connector is not running, start an environment with jumppad up before forwarding ports")
+		}
+
+		localPort, remotePort, err := parsePortMapping(args[1])
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.LoadState()
+		if err != nil {
+			return errors.New("unable to read state file")
+		}
+
+		r, err := cfg.FindResource(args[0])
+		if err != nil {
+			return fmt.Errorf("%s not found: %s", args[0], err)
+		}
+
+		remoteAddr, destAddr, err := portForwardTarget(r, remotePort, *opts)
+		if err != nil {
+			return err
+		}
+
+		// check the port is free before asking the connector to listen
+		l, err := net.Listen("tcp", fmt.Sprintf(":%d", localPort))
+		if err != nil {
+			return fmt.Errorf("unable to forward local port %d, port in use", localPort)
+		}
+		l.Close()
+
+		id, err := cc.ExposeService(
+			fmt.Sprintf("port-forward-%d", localPort),
+			localPort,
+			remoteAddr,
+			destAddr,
+			"remote",
+		)
+
+		if err != nil {
+			return fmt.Errorf("unable to forward port: %s", err)
+		}
+
+		cmd.Printf("Forwarding localhost:%d -> %s, press Ctrl+C to stop\n", localPort, destAddr)
+
+		sigs := make(chan os.Signal, 1)
+		signal.Notify(sigs, os.Interrupt)
+		<-sigs
+
+		err = cc.RemoveService(id)
+		if err != nil {
+			return fmt.Errorf("unable to remove forwarded port: %s", err)
+		}
+
+		return nil
+	}
+}
+
+// parsePortMapping parses a port mapping in the format local:remote, when the
+// local port is not specified it is the same as the remote port
+func parsePortMapping(m string) (int, int, error) {
+	parts := strings.Split(m, ":")
+	if len(parts) > 2 {
+		return 0, 0, fmt.Errorf("invalid port mapping %s, must be specified as [local_port:]remote_port", m)
+	}
+
+	ports := []int{}
+	for _, p := range parts {
+		port, err := strconv.Atoi(p)
+		if err != nil || port < 1 || port > 65535 {
+			return 0, 0, fmt.Errorf("invalid port %s, ports must be a number between 1 and 65535", p)
+		}
+
+		ports = append(ports, port)
+	}
+
+	if len(ports) == 1 {
+		return ports[0], ports[0], nil
+	}
+
+	return ports[0], ports[1], nil
+}
+
+// portForwardTarget returns the address of the connector that will make the
+// connection to the destination and the address of the destination
+func portForwardTarget(r hcltypes.Resource, port int, opts portForwardOptions) (string, string, error) {
+	if r.GetDisabled() {
+		return "", "", fmt.Errorf("%s is disabled", r.Metadata().ID)
+	}
+
+	switch v := r.(type) {
+	case *ct.Container:
+		if len(v.Networks) == 0 || v.Networks[0].AssignedAddress == "" {
+			return "", "", fmt.Errorf("%s does not have an IP address, is the container running?", r.Metadata().ID)
+		}
+
+		return localConnectorAddr, fmt.Sprintf("%s:%d", v.Networks[0].AssignedAddress, port), nil
+
+	case *k8s.Cluster:
+		if opts.service == "" {
+			return "", "", errors.New("--service must be specified when forwarding to a Kubernetes cluster")
+		}
+
+		return fmt.Sprintf("%s:%d", v.ExternalIP, v.ConnectorPort), fmt.Sprintf("%s.%s.svc:%d", opts.service, opts.namespace, port), nil
+
+	case *nomad.NomadCluster:
+		if opts.job == "" || opts.group == "" || opts.task == "" {
+			return "", "", errors.New("--job, --group, and --task must be specified when forwarding to a Nomad cluster")
+		}
+
+		return fmt.Sprintf("%s:%d", v.ExternalIP, v.ConnectorPort), fmt.Sprintf("%s.%s.%s:%d", opts.job, opts.group, opts.task, port), nil
+	}
+
+	return "", "", fmt.Errorf("%s is a %s resource, ports can only be forwarded to %s, %s, and %s resources", r.Metadata().ID, r.Metadata().Type, ct.TypeContainer, k8s.TypeK8sCluster, nomad.TypeNomadCluster)
+}
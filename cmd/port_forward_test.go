@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"testing"
+
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	ct "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePortMappingWithRemotePortOnly(t *testing.T) {
+	local, remote, err := parsePortMapping("8080")
+	require.NoError(t, err)
+	require.Equal(t, 8080, local)
+	require.Equal(t, 8080, remote)
+}
+
+func TestParsePortMappingWithLocalAndRemotePort(t *testing.T) {
+	local, remote, err := parsePortMapping("9090:8080")
+	require.NoError(t, err)
+	require.Equal(t, 9090, local)
+	require.Equal(t, 8080, remote)
+}
+
+func TestParsePortMappingInvalidReturnsError(t *testing.T) {
+	_, _, err := parsePortMapping("abc:8080")
+	require.ErrorContains(t, err, "invalid port abc")
+
+	_, _, err = parsePortMapping("1:2:3")
+	require.ErrorContains(t, err, "invalid port mapping")
+}
+
+func TestPortForwardTargetContainerUsesLocalConnector(t *testing.T) {
+	c := &ct.Container{Networks: []ct.NetworkAttachment{{AssignedAddress: "10.10.0.2"}}}
+	c.Meta = hcltypes.Meta{ID: "resource.container.db", Name: "db", Type: ct.TypeContainer}
+
+	remote, dest, err := portForwardTarget(c, 5432, portForwardOptions{})
+	require.NoError(t, err)
+	require.Equal(t, localConnectorAddr, remote)
+	require.Equal(t, "10.10.0.2:5432", dest)
+}
+
+func TestPortForwardTargetContainerWithoutAddressReturnsError(t *testing.T) {
+	c := &ct.Container{}
+	c.Meta = hcltypes.Meta{ID: "resource.container.db", Name: "db", Type: ct.TypeContainer}
+
+	_, _, err := portForwardTarget(c, 5432, portForwardOptions{})
+	require.ErrorContains(t, err, "does not have an IP address")
+}
+
+func TestPortForwardTargetK8sServiceUsesClusterConnector(t *testing.T) {
+	k := &k8s.Cluster{ExternalIP: "10.10.0.3", ConnectorPort: 31000}
+	k.Meta = hcltypes.Meta{ID: "resource.k8s_cluster.dev", Name: "dev", Type: k8s.TypeK8sCluster}
+
+	remote, dest, err := portForwardTarget(k, 8500, portForwardOptions{service: "consul-server", namespace: "consul"})
+	require.NoError(t, err)
+	require.Equal(t, "10.10.0.3:31000", remote)
+	require.Equal(t, "consul-server.consul.svc:8500", dest)
+}
+
+func TestPortForwardTargetK8sWithoutServiceReturnsError(t *testing.T) {
+	k := &k8s.Cluster{ExternalIP: "10.10.0.3", ConnectorPort: 31000}
+	k.Meta = hcltypes.Meta{ID: "resource.k8s_cluster.dev", Name: "dev", Type: k8s.TypeK8sCluster}
+
+	_, _, err := portForwardTarget(k, 8500, portForwardOptions{namespace: "default"})
+	require.ErrorContains(t, err, "--service must be specified")
+}
+
+func TestPortForwardTargetNomadTaskUsesClusterConnector(t *testing.T) {
+	n := &nomad.NomadCluster{ExternalIP: "10.10.0.4", ConnectorPort: 31001}
+	n.Meta = hcltypes.Meta{ID: "resource.nomad_cluster.dev", Name: "dev", Type: nomad.TypeNomadCluster}
+
+	remote, dest, err := portForwardTarget(n, 8080, portForwardOptions{job: "example", group: "web", task: "app"})
+	require.NoError(t, err)
+	require.Equal(t, "10.10.0.4:31001", remote)
+	require.Equal(t, "example.web.app:8080", dest)
+}
+
+func TestPortForwardTargetNomadWithoutTaskReturnsError(t *testing.T) {
+	n := &nomad.NomadCluster{ExternalIP: "10.10.0.4", ConnectorPort: 31001}
+	n.Meta = hcltypes.Meta{ID: "resource.nomad_cluster.dev", Name: "dev", Type: nomad.TypeNomadCluster}
+
+	_, _, err := portForwardTarget(n, 8080, portForwardOptions{job: "example"})
+	require.ErrorContains(t, err, "--job, --group, and --task must be specified")
+}
+
+func TestPortForwardTargetUnsupportedResourceReturnsError(t *testing.T) {
+	n := &network.Network{}
+	n.Meta = hcltypes.Meta{ID: "resource.network.main", Name: "main", Type: network.TypeNetwork}
+
+	_, _, err := portForwardTarget(n, 8080, portForwardOptions{})
+	require.ErrorContains(t, err, "ports can only be forwarded to")
+}
+
+func TestPortForwardTargetDisabledResourceReturnsError(t *testing.T) {
+	c := &ct.Container{Networks: []ct.NetworkAttachment{{AssignedAddress: "10.10.0.2"}}}
+	c.Meta = hcltypes.Meta{ID: "resource.container.db", Name: "db", Type: ct.TypeContainer}
+	c.Disabled = true
+
+	_, _, err := portForwardTarget(c, 5432, portForwardOptions{})
+	require.ErrorContains(t, err, "is disabled")
+}
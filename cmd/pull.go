@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/jumppad/cmd/view"
+	cclients "github.com/jumppad-labs/jumppad/pkg/clients/container"
+	dtypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// defaultPullConcurrency is the number of images that are pulled at the same
+// time before the resources are created
+const defaultPullConcurrency = 4
+
+// imagesToPull returns the images used by the resources in the config,
+// images are returned once even when they are used by multiple resources.
+// Images built by jumppad and images whose name could not be resolved
+// when the config was parsed are not returned
+func imagesToPull(cfg *hclconfig.Config) []dtypes.Image {
+	images := []dtypes.Image{}
+	seen := map[string]bool{}
+
+	add := func(i dtypes.Image) {
+		if i.Name == "" || strings.HasPrefix(i.Name, utils.BuildImagePrefix) || seen[i.Name] {
+			return
+		}
+
+		seen[i.Name] = true
+		images = append(images, i)
+	}
+
+	for _, r := range cfg.Resources {
+		if r.GetDisabled() {
+			continue
+		}
+
+		switch v := r.(type) {
+		case *container.Container:
+			add(v.Image.ToClientImage())
+		case *container.Sidecar:
+			add(v.Image.ToClientImage())
+		case *k8s.Cluster:
+			if v.Image != nil {
+				add(v.Image.ToClientImage())
+			}
+
+			for _, i := range v.CopyImages {
+				add(i.ToClientImage())
+			}
+		case *nomad.NomadCluster:
+			if v.Image != nil {
+				add(v.Image.ToClientImage())
+			}
+
+			for _, i := range v.CopyImages {
+				add(i.ToClientImage())
+			}
+		case *exec.Exec:
+			if v.Image != nil {
+				add(v.Image.ToClientImage())
+			}
+		case *docs.Docs:
+			if v.Image != nil {
+				add(v.Image.ToClientImage())
+			}
+		}
+	}
+
+	return images
+}
+
+// pullImages pulls the images in parallel, at most concurrency images are
+// pulled at the same time. Errors are logged and not returned, the resource
+// that uses the image pulls it again when it is created and reports the error
+func pullImages(dt cclients.ContainerTasks, images []dtypes.Image, concurrency int, progress *view.Progress, l logger.Logger) {
+	if len(images) == 0 || concurrency < 1 {
+		return
+	}
+
+	l.Info("Pulling images", "count", len(images), "concurrency", concurrency)
+
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+
+	for _, i := range images {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i dtypes.Image) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			start := time.Now()
+			current, total := int64(0), int64(0)
+
+			err := dt.PullImageWithProgress(i, false, func(c, t int64) {
+				current, total = c, t
+
+				if progress != nil {
+					progress.UpdateImage(view.ImageProgress{Name: i.Name, Current: c, Total: t})
+				}
+			})
+
+			if progress != nil {
+				progress.UpdateImage(view.ImageProgress{Name: i.Name, Current: current, Total: total, Done: true})
+			}
+
+			if err != nil {
+				l.Warn("Unable to pull image", "image", i.Name, "error", err)
+				return
+			}
+
+			l.Debug("Pulled image", "image", i.Name, "duration", time.Since(start))
+		}(i)
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	dtypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testPullConfig() *hclconfig.Config {
+	c := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.container.consul"}}}
+	c.Image = container.Image{Name: "consul:1.16.1"}
+
+	c2 := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.container.consul2"}}}
+	c2.Image = container.Image{Name: "consul:1.16.1"}
+
+	b := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.container.app"}}}
+	b.Image = container.Image{Name: "jumppad.dev/localcache/app:latest"}
+
+	d := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.container.disabled"}, Disabled: true}}
+	d.Image = container.Image{Name: "redis:7"}
+
+	k := &k8s.Cluster{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.k8s_cluster.k3s"}}}
+	k.Image = &container.Image{Name: "shipyardrun/k3s:v1.27.4"}
+	k.CopyImages = container.Images{{Name: "consul:1.16.1"}, {Name: "vault:1.13.0", Username: "user", Password: "pass"}}
+
+	return &hclconfig.Config{Resources: []hcltypes.Resource{c, c2, b, d, k}}
+}
+
+func TestImagesToPullReturnsUniqueImages(t *testing.T) {
+	images := imagesToPull(testPullConfig())
+
+	require.Equal(t, []dtypes.Image{
+		{Name: "consul:1.16.1"},
+		{Name: "shipyardrun/k3s:v1.27.4"},
+		{Name: "vault:1.13.0", Username: "user", Password: "pass"},
+	}, images)
+}
+
+func TestPullImagesPullsEachImage(t *testing.T) {
+	dt := &mocks.ContainerTasks{}
+	dt.On("PullImageWithProgress", mock.Anything, false, mock.Anything).Return(nil)
+
+	pullImages(dt, imagesToPull(testPullConfig()), 2, nil, logger.NewTestLogger(t))
+
+	dt.AssertNumberOfCalls(t, "PullImageWithProgress", 3)
+	dt.AssertCalled(t, "PullImageWithProgress", dtypes.Image{Name: "consul:1.16.1"}, false, mock.Anything)
+}
+
+func TestPullImagesWithZeroConcurrencyDoesNotPull(t *testing.T) {
+	dt := &mocks.ContainerTasks{}
+
+	pullImages(dt, imagesToPull(testPullConfig()), 0, nil, logger.NewTestLogger(t))
+
+	dt.AssertNotCalled(t, "PullImageWithProgress", mock.Anything, mock.Anything, mock.Anything)
+}
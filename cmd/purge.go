@@ -5,129 +5,390 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
 
 	"github.com/docker/docker/api/types/filters"
 	dimage "github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/jumppad-labs/hclconfig"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container"
 	"github.com/jumppad-labs/jumppad/pkg/clients/images"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/progress"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
-func newPurgeCmd(dt container.Docker, il images.ImageLog, l logger.Logger) *cobra.Command {
+// purgeOptions selects the content removed by the purge command, when no
+// option is set everything is removed
+type purgeOptions struct {
+	Images  bool
+	Volumes bool
+	Cache   bool
+
+	// Keep removes only the images for blueprints other than the Keep most
+	// recently applied, -1 removes all images
+	Keep int
+}
+
+func (p purgeOptions) all() bool {
+	return !p.Images && !p.Volumes && !p.Cache
+}
+
+func newPurgeCmd(dt container.Docker, il images.ImageLog, is images.ImageSets, l logger.Logger) *cobra.Command {
+	opts := purgeOptions{}
+
 	purgeCmd := &cobra.Command{
 		Use:   "purge",
 		Short: "Purges Docker images, Helm charts, and Blueprints downloaded by jumppad",
-		Long:  "Purges Docker images, Helm charts, and Blueprints downloaded by jumppad",
+		Long: `Purges Docker images, Helm charts, and Blueprints downloaded by jumppad.
+
+When no flags are specified all images, volumes, caches and data created by
+jumppad are removed. Images and volumes that were not created by jumppad are
+never removed.
+
+Jumppad records the images used by each blueprint, setting the environment
+variable ` + imageGCEnvVar + ` to a number N removes the images for all but
+the N most recently applied blueprints every time jumppad up is run.`,
 		Example: `
+  # Remove everything downloaded or created by jumppad
   jumppad purge
+
+  # Remove the images for all but the last two blueprints that were applied
+  jumppad purge --images --keep 2
+
+  # Remove volumes that are no longer used by a container and the image cache
+  jumppad purge --volumes --cache
 	`,
 		Args:         cobra.ArbitraryArgs,
-		RunE:         newPurgeCmdFunc(dt, il, l),
+		RunE:         newPurgeCmdFunc(dt, il, is, &opts, l),
 		SilenceUsage: true,
 	}
 
+	purgeCmd.Flags().BoolVarP(&opts.Images, "images", "", false, "Remove the Docker images pulled or built by jumppad")
+	purgeCmd.Flags().BoolVarP(&opts.Volumes, "volumes", "", false, "Remove the Docker volumes created by jumppad that are not used by a container")
+	purgeCmd.Flags().BoolVarP(&opts.Cache, "cache", "", false, "Remove the image cache and the cached blueprints, Helm charts, downloads and releases")
+	purgeCmd.Flags().IntVarP(&opts.Keep, "keep", "", -1, "Used with --images, keep the images for the given number of most recently applied blueprints")
+
 	return purgeCmd
 }
 
-func newPurgeCmdFunc(dt container.Docker, il images.ImageLog, l logger.Logger) func(cmd *cobra.Command, args []string) error {
+func newPurgeCmdFunc(dt container.Docker, il images.ImageLog, is images.ImageSets, opts *purgeOptions, l logger.Logger) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
-		images, _ := il.Read(images.ImageTypeDocker)
+		if opts == nil {
+			opts = &purgeOptions{Keep: -1}
+		}
 
 		bHasError := false
 
-		for _, i := range images {
-			l.Info("Removing image", "image", i)
+		if opts.all() || opts.Images {
+			if opts.Keep >= 0 {
+				remove := func(i string) error {
+					_, err := dt.ImageRemove(context.Background(), i, dimage.RemoveOptions{PruneChildren: true})
+					return err
+				}
 
-			_, err := dt.ImageRemove(context.Background(), i, dimage.RemoveOptions{Force: true, PruneChildren: true})
-			if err != nil {
-				l.Error("Unable to delete", "image", i, "error", err)
+				bHasError = purgeExpiredImages(remove, is, opts.Keep, l) || bHasError
+			} else {
+				bHasError = purgeImages(dt, il, is, l) || bHasError
 			}
 		}
-		il.Clear()
-
-		// Remove any images which have been built
-		filter := filters.NewArgs()
-		filter.Add("reference", "jumppad.dev/localcache/*")
 
-		// check if the image already exists, if so do not rebuild unless force
-		sum, err := dt.ImageList(context.Background(), dimage.ListOptions{Filters: filter})
-		if err != nil {
-			l.Error("Unable to check image cache", "error", err)
-			bHasError = true
+		if opts.all() || opts.Volumes {
+			bHasError = purgeVolumes(dt, l) || bHasError
 		}
 
-		for _, i := range sum {
-			l.Info("Removing image", "image", i.ID)
+		if opts.all() || opts.Cache {
+			bHasError = purgeCache(dt, l) || bHasError
+		}
 
-			_, err := dt.ImageRemove(context.Background(), i.ID, dimage.RemoveOptions{Force: true, PruneChildren: true})
-			if err != nil {
-				l.Error("Unable to delete", "image", i.ID, "error", err)
-				bHasError = true
-			}
+		if opts.all() {
+			bHasError = purgeData(l) || bHasError
 		}
 
-		l.Info("Removing Docker image cache")
-		err = dt.VolumeRemove(context.Background(), utils.FQDNVolumeName("images"), true)
-		if err != nil {
-			l.Error("Unable to remove cached image volume", "error", err)
-			bHasError = true
+		if bHasError {
+			return fmt.Errorf("an error occurred when purging data")
 		}
 
-		hcp := utils.BlueprintLocalFolder("")
-		l.Info("Removing cached blueprints", "path", hcp)
-		err = os.RemoveAll(hcp)
+		return nil
+	}
+}
+
+// purgeImages removes every image pulled or built by jumppad, it returns
+// true when an image could not be removed
+func purgeImages(dt container.Docker, il images.ImageLog, is images.ImageSets, l logger.Logger) bool {
+	images, _ := il.Read(images.ImageTypeDocker)
+
+	bHasError := false
+
+	for _, i := range images {
+		l.Info("Removing image", "image", i)
+
+		_, err := dt.ImageRemove(context.Background(), i, dimage.RemoveOptions{Force: true, PruneChildren: true})
 		if err != nil {
-			l.Error("Unable to remove cached blueprints", "error", err)
-			bHasError = true
+			l.Error("Unable to delete", "image", i, "error", err)
 		}
+	}
+	il.Clear()
 
-		bcp := utils.HelmLocalFolder("")
-		l.Info("Removing cached Helm charts", "path", bcp)
-		err = os.RemoveAll(bcp)
+	// all the images have been removed so the sets are no longer needed
+	if is != nil {
+		is.Prune(0)
+	}
+
+	// Remove any images which have been built
+	filter := filters.NewArgs()
+	filter.Add("reference", "jumppad.dev/localcache/*")
+
+	// check if the image already exists, if so do not rebuild unless force
+	sum, err := dt.ImageList(context.Background(), dimage.ListOptions{Filters: filter})
+	if err != nil {
+		l.Error("Unable to check image cache", "error", err)
+		bHasError = true
+	}
+
+	for _, i := range sum {
+		l.Info("Removing image", "image", i.ID)
+
+		_, err := dt.ImageRemove(context.Background(), i.ID, dimage.RemoveOptions{Force: true, PruneChildren: true})
 		if err != nil {
-			l.Error("Unable to remove cached Helm charts", "error", err)
+			l.Error("Unable to delete", "image", i.ID, "error", err)
 			bHasError = true
 		}
+	}
+
+	return bHasError
+}
+
+// purgeExpiredImages removes the images that are only used by blueprints
+// other than the keep most recently applied
+func purgeExpiredImages(remove func(string) error, is images.ImageSets, keep int, l logger.Logger) bool {
+	expired, err := is.Prune(keep)
+	if err != nil {
+		l.Error("Unable to read the images used by blueprints", "error", err)
+		return true
+	}
+
+	for _, i := range expired {
+		l.Info("Removing image", "image", i)
 
-		// delete the releases
-		rcp := utils.ReleasesFolder()
-		l.Info("Removing cached releases", "path", rcp)
-		err = os.RemoveAll(rcp)
+		err := remove(i)
 		if err != nil {
-			l.Error("Unable to remove cached Releases", "error", err)
-			bHasError = true
+			// the image may have been removed or still be in use, this is
+			// not an error as the image is no longer recorded
+			l.Debug("Unable to delete", "image", i, "error", err)
 		}
+	}
 
-		dcp := utils.DataFolder("", os.ModePerm)
-		l.Info("Removing data folders", "path", dcp)
-		err = os.RemoveAll(dcp)
-		if err != nil {
-			l.Error("Unable to remove data folder", "error", err)
-			bHasError = true
+	return false
+}
+
+// purgeVolumes removes the volumes created by jumppad that are not used by
+// any container, the image cache is removed with the cache
+func purgeVolumes(dt container.Docker, l logger.Logger) bool {
+	filter := filters.NewArgs()
+	filter.Add("dangling", "true")
+	filter.Add("name", ".volume."+utils.LocalTLD)
+
+	vols, err := dt.VolumeList(context.Background(), volume.ListOptions{Filters: filter})
+	if err != nil {
+		l.Error("Unable to list volumes", "error", err)
+		return true
+	}
+
+	bHasError := false
+
+	for _, v := range vols.Volumes {
+		if !strings.HasSuffix(v.Name, ".volume."+utils.LocalTLD) || v.Name == utils.FQDNVolumeName(utils.ImageVolumeName) {
+			continue
 		}
 
-		ccp := utils.DataFolder("", os.ModePerm)
-		l.Info("Removing cache folders", "path", ccp)
-		err = os.RemoveAll(ccp)
+		l.Info("Removing volume", "volume", v.Name)
+
+		err := dt.VolumeRemove(context.Background(), v.Name, false)
 		if err != nil {
-			l.Error("Unable to remove cache folder", "error", err)
+			l.Error("Unable to remove volume", "volume", v.Name, "error", err)
 			bHasError = true
 		}
+	}
 
-		cp := path.Join(utils.JumppadHome(), "config")
-		l.Info("Removing config", "path", cp)
-		err = os.RemoveAll(cp)
-		if err != nil {
-			l.Error("Unable to remove config folder", "error", err)
-			bHasError = true
+	return bHasError
+}
+
+// purgeCache removes the image cache and the downloaded blueprints, Helm
+// charts and releases
+func purgeCache(dt container.Docker, l logger.Logger) bool {
+	bHasError := false
+
+	l.Info("Removing Docker image cache")
+	err := dt.VolumeRemove(context.Background(), utils.FQDNVolumeName(utils.ImageVolumeName), true)
+	if err != nil {
+		l.Error("Unable to remove cached image volume", "error", err)
+		bHasError = true
+	}
+
+	hcp := utils.BlueprintLocalFolder("")
+	l.Info("Removing cached blueprints", "path", hcp)
+	err = os.RemoveAll(hcp)
+	if err != nil {
+		l.Error("Unable to remove cached blueprints", "error", err)
+		bHasError = true
+	}
+
+	bcp := utils.HelmLocalFolder("")
+	l.Info("Removing cached Helm charts", "path", bcp)
+	err = os.RemoveAll(bcp)
+	if err != nil {
+		l.Error("Unable to remove cached Helm charts", "error", err)
+		bHasError = true
+	}
+
+	acp := utils.ArtifactsDir()
+	l.Info("Removing downloaded artifacts", "path", acp)
+	err = os.RemoveAll(acp)
+	if err != nil {
+		l.Error("Unable to remove downloaded artifacts", "error", err)
+		bHasError = true
+	}
+
+	// delete the releases
+	rcp := utils.ReleasesFolder()
+	l.Info("Removing cached releases", "path", rcp)
+	err = os.RemoveAll(rcp)
+	if err != nil {
+		l.Error("Unable to remove cached Releases", "error", err)
+		bHasError = true
+	}
+
+	return bHasError
+}
+
+// purgeData removes the data folders, workshop progress and config
+func purgeData(l logger.Logger) bool {
+	bHasError := false
+
+	dcp := utils.DataFolder("", os.ModePerm)
+	l.Info("Removing data folders", "path", dcp)
+	err := os.RemoveAll(dcp)
+	if err != nil {
+		l.Error("Unable to remove data folder", "error", err)
+		bHasError = true
+	}
+
+	ccp := utils.DataFolder("", os.ModePerm)
+	l.Info("Removing cache folders", "path", ccp)
+	err = os.RemoveAll(ccp)
+	if err != nil {
+		l.Error("Unable to remove cache folder", "error", err)
+		bHasError = true
+	}
+
+	pp := progress.DefaultPath()
+	l.Info("Removing workshop progress", "path", pp)
+	err = progress.NewFileStore(pp).Reset()
+	if err != nil {
+		l.Error("Unable to remove workshop progress", "error", err)
+		bHasError = true
+	}
+
+	cp := path.Join(utils.JumppadHome(), "config")
+	l.Info("Removing config", "path", cp)
+	err = os.RemoveAll(cp)
+	if err != nil {
+		l.Error("Unable to remove config folder", "error", err)
+		bHasError = true
+	}
+
+	return bHasError
+}
+
+// imageGCEnvVar sets the number of most recently applied blueprints whose
+// images are kept when jumppad up is run, images used only by older
+// blueprints are removed
+const imageGCEnvVar = "JUMPPAD_IMAGE_GC_KEEP"
+
+// collectImageGarbage records the images used by the blueprint and removes
+// the images for older blueprints when the garbage collection is enabled,
+// errors are logged as a failed collection should not fail up
+func collectImageGarbage(is images.ImageSets, dt container.ContainerTasks, blueprint string, cfg *hclconfig.Config, l logger.Logger) {
+	if is == nil || cfg == nil {
+		return
+	}
+
+	if abs, err := filepath.Abs(blueprint); err == nil {
+		blueprint = abs
+	}
+
+	err := is.Record(blueprint, blueprintImages(cfg))
+	if err != nil {
+		l.Error("Unable to record the images used by the blueprint", "error", err)
+		return
+	}
+
+	v := os.Getenv(imageGCEnvVar)
+	if v == "" {
+		return
+	}
+
+	// the current blueprint is always kept
+	keep, err := strconv.Atoi(v)
+	if err != nil || keep < 1 {
+		l.Error("Invalid value for "+imageGCEnvVar+", expected a number greater than 0", "value", v)
+		return
+	}
+
+	purgeExpiredImages(dt.RemoveImage, is, keep, l)
+}
+
+// blueprintImages returns the images pulled or built for the resources in
+// the config
+func blueprintImages(cfg *hclconfig.Config) []string {
+	imgs := []string{}
+	add := func(name string) {
+		if name != "" && !slices.Contains(imgs, name) {
+			imgs = append(imgs, name)
 		}
+	}
 
-		if bHasError {
-			return fmt.Errorf("an error occurred when purging data")
+	for _, r := range cfg.Resources {
+		if r.GetDisabled() {
+			continue
 		}
 
-		return nil
+		switch v := r.(type) {
+		case *ctypes.Container:
+			add(v.Image.Name)
+		case *ctypes.Sidecar:
+			add(v.Image.Name)
+		case *k8s.Cluster:
+			if v.Image != nil {
+				add(v.Image.Name)
+			}
+		case *nomad.NomadCluster:
+			if v.Image != nil {
+				add(v.Image.Name)
+			}
+		case *exec.Exec:
+			if v.Image != nil {
+				add(v.Image.Name)
+			}
+		case *docs.Docs:
+			if v.Image != nil {
+				add(v.Image.Name)
+			}
+		case *build.Build:
+			add(v.Image)
+		}
 	}
+
+	return imgs
 }
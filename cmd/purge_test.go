@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/volume"
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	cmock "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	imagemock "github.com/jumppad-labs/jumppad/pkg/clients/images/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupPurge(t *testing.T) (*cobra.Command, *cmock.Docker, *imagemock.ImageSets) {
+	md := &cmock.Docker{}
+	md.On("ImageRemove", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+	md.On("VolumeRemove", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	md.On("VolumeList", mock.Anything, mock.Anything).Return(volume.ListResponse{
+		Volumes: []*volume.Volume{
+			{Name: utils.FQDNVolumeName("data")},
+			{Name: utils.FQDNVolumeName(utils.ImageVolumeName)},
+			{Name: utils.FQDNVolumeName("data") + ".backup"},
+		},
+	}, nil)
+
+	is := &imagemock.ImageSets{}
+	is.On("Prune", mock.Anything).Return([]string{"vault:1.13"}, nil)
+
+	cmd := newPurgeCmd(md, nil, is, logger.NewTestLogger(t))
+
+	return cmd, md, is
+}
+
+func TestPurgeImagesWithKeepRemovesExpiredImages(t *testing.T) {
+	cmd, md, is := setupPurge(t)
+	cmd.SetArgs([]string{"--images", "--keep", "2"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	is.AssertCalled(t, "Prune", 2)
+	md.AssertCalled(t, "ImageRemove", mock.Anything, "vault:1.13", mock.Anything)
+	md.AssertNotCalled(t, "VolumeRemove", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPurgeVolumesRemovesOnlyUnusedJumppadVolumes(t *testing.T) {
+	cmd, md, _ := setupPurge(t)
+	cmd.SetArgs([]string{"--volumes"})
+
+	err := cmd.Execute()
+	require.NoError(t, err)
+
+	md.AssertCalled(t, "VolumeRemove", mock.Anything, utils.FQDNVolumeName("data"), false)
+	md.AssertNumberOfCalls(t, "VolumeRemove", 1)
+	md.AssertNotCalled(t, "ImageRemove", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestBlueprintImagesReturnsResourceImages(t *testing.T) {
+	c := &container.Container{}
+	c.Image = container.Image{Name: "consul:1.16"}
+
+	c2 := &container.Container{}
+	c2.Image = container.Image{Name: "consul:1.16"}
+
+	k := &k8s.Cluster{Image: &container.Image{Name: "ghcr.io/jumppad-labs/kubernetes:v1.29"}}
+
+	b := &build.Build{Image: "jumppad.dev/localcache/app:abc"}
+
+	d := &container.Container{ResourceBase: hcltypes.ResourceBase{Disabled: true}}
+	d.Image = container.Image{Name: "vault:1.13"}
+
+	imgs := blueprintImages(&hclconfig.Config{Resources: []hcltypes.Resource{c, c2, k, b, d}})
+
+	require.Equal(t, []string{"consul:1.16", "ghcr.io/jumppad-labs/kubernetes:v1.29", "jumppad.dev/localcache/app:abc"}, imgs)
+}
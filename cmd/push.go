@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"context"
+	"crypto"
 	"errors"
 	"fmt"
 	"os"
@@ -10,26 +12,40 @@ import (
 	"github.com/jumppad-labs/jumppad/pkg/clients/container"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/registry"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
-func newPushCmd(ct container.ContainerTasks, l logger.Logger) *cobra.Command {
+func newPushCmd(ct container.ContainerTasks, reg registry.Registry, l logger.Logger) *cobra.Command {
 	var force bool
+	var signKey string
+	var ignore []string
 
 	pushCmd := &cobra.Command{
-		Use:                   "push [image] [cluster]",
-		Short:                 "Push a local Docker image to a cluster",
-		Long:                  `Push a local Docker image to a cluster`,
-		Example:               `yard push nicholasjackson/fake-service:v0.1.3 k8s_cluster.k3s`,
+		Use:   "push [image] [cluster] | [directory] [registry]",
+		Short: "Push a local Docker image to a cluster or a blueprint to an OCI registry",
+		Long:  `Push a local Docker image to a cluster or a blueprint to an OCI registry`,
+		Example: `
+  # Push a local Docker image to a cluster
+  jumppad push nicholasjackson/fake-service:v0.1.3 k8s_cluster.k3s
+
+  # Publish the blueprint in the current folder to an OCI registry and sign it
+  jumppad push ./ oci://ghcr.io/jumppad-labs/kubernetes-vault:v1.2.0 --sign-key ./cosign.key
+	`,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.MaximumNArgs(3),
 		SilenceUsage:          true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) < 2 {
-				return errors.New("push requires two arguments [image] [cluster]")
+				return errors.New("push requires two arguments [image] [cluster] or [directory] [registry]")
+			}
+
+			if registry.IsReference(args[1]) {
+				return pushBlueprint(cmd, reg, args[0], args[1], signKey, ignore)
 			}
 
 			if force {
@@ -69,10 +85,43 @@ func newPushCmd(ct container.ContainerTasks, l logger.Logger) *cobra.Command {
 	}
 
 	pushCmd.Flags().BoolVarP(&force, "force-update", "", false, "When set to true jumppad will ignore cached images or files and will download all resources")
+	pushCmd.Flags().StringVarP(&signKey, "sign-key", "", "", "Path to a PEM encoded private key used to sign blueprints pushed to an OCI registry")
+	pushCmd.Flags().StringSliceVarP(&ignore, "ignore", "", []string{"*/.git", "*/.jumppad"}, "Glob patterns for files that are not included in blueprints pushed to an OCI registry, files matching the patterns in a .jumppadignore file in the blueprint folder are also not included")
 
 	return pushCmd
 }
 
+func pushBlueprint(cmd *cobra.Command, reg registry.Registry, dir, uri, signKey string, ignore []string) error {
+	if !utils.IsLocalFolder(dir) {
+		return fmt.Errorf("blueprint folder %s does not exist", dir)
+	}
+
+	var key crypto.Signer
+	if signKey != "" {
+		k, err := registry.ReadPrivateKey(signKey)
+		if err != nil {
+			return fmt.Errorf("unable to read sign key: %s", err)
+		}
+
+		key = k
+	}
+
+	cmd.Printf("Pushing blueprint %s to %s\n\n", dir, uri)
+
+	d, err := reg.Push(context.Background(), dir, uri, key, ignore...)
+	if err != nil {
+		return fmt.Errorf("unable to push blueprint: %s", err)
+	}
+
+	cmd.Printf("Pushed blueprint %s, digest: %s\n", uri, d)
+
+	if key != nil {
+		cmd.Println("Blueprint signed, verify with: jumppad up", uri, "--verify-key [public key]")
+	}
+
+	return nil
+}
+
 func pushK8sCluster(image string, c *k8s.Cluster, log logger.Logger, force bool) error {
 	cli, _ := clients.GenerateClients(log)
 	p := config.NewProviders(cli)
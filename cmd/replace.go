@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
+	cclients "github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/getter"
+	"github.com/jumppad-labs/jumppad/pkg/clients/http"
+	"github.com/jumppad-labs/jumppad/pkg/clients/images"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/system"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	"github.com/spf13/cobra"
+)
+
+// newReplaceCmd creates the replace command, replace taints the resource and
+// its dependents then runs up so it accepts the same flags as up
+func newReplaceCmd(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Getter, hc http.HTTP, bc system.System, cc connector.Connector, is images.ImageSets, l logger.Logger) *cobra.Command {
+	replaceCmd := newRunCmd(e, dt, bp, hc, bc, cc, is, l)
+	up := replaceCmd.RunE
+
+	replaceCmd.Use = "replace [resource] [file] | [directory]"
+	replaceCmd.Short = "Destroy and re-create a resource and the resources that depend on it"
+	replaceCmd.Long = `Destroy and re-create a resource and the resources that depend on it.
+
+Replace re-creates a single resource without rebuilding the whole environment,
+any resources that use the outputs of the resource are also re-created so that
+they use the new values. The configuration is applied in the same way as up.`
+	replaceCmd.Example = `
+  # Re-create the container app and the resources that depend on it
+  jumppad replace resource.container.app
+
+  # Re-create the container app using the configuration in my-stack
+  jumppad replace container.app ./my-stack
+	`
+	replaceCmd.Args = cobra.RangeArgs(1, 2)
+	replaceCmd.RunE = func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.LoadState()
+		if err != nil {
+			return fmt.Errorf("unable to load state, have you run jumppad up: %s", err)
+		}
+
+		ids, err := replaceResources(cfg, args[0])
+		if err != nil {
+			return err
+		}
+
+		err = config.SaveState(cfg)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			cmd.Printf("Replacing %s\n", id)
+		}
+
+		cmd.Println("")
+
+		return up(cmd, args[1:])
+	}
+
+	return replaceCmd
+}
+
+// replaceResources taints the resource with the given id and the resources in
+// the state that depend on it, it returns the ids of the tainted resources
+func replaceResources(cfg *hclconfig.Config, arg string) ([]string, error) {
+	id := arg
+	if !strings.HasPrefix(id, "resource.") && !strings.HasPrefix(id, "module.") {
+		id = "resource." + id
+	}
+
+	r, err := cfg.FindResource(id)
+	if err != nil || r == nil {
+		return nil, fmt.Errorf("unable to find resource %s in the state", arg)
+	}
+
+	if r.GetDisabled() {
+		return nil, fmt.Errorf("%s is disabled", id)
+	}
+
+	deps, err := jumppad.Dependents(cfg, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []string{}
+	for _, d := range deps {
+		dr, err := cfg.FindResource(d)
+		if err != nil || dr.GetDisabled() {
+			continue
+		}
+
+		// failed resources are always re-created and resources that have not
+		// been created do not need to be replaced
+		if dr.Metadata().Properties[constants.PropertyStatus] != constants.StatusCreated {
+			continue
+		}
+
+		dr.Metadata().Properties[constants.PropertyStatus] = constants.StatusTainted
+		ids = append(ids, d)
+	}
+
+	return ids, nil
+}
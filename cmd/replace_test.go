@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func setupReplaceConfig() *hclconfig.Config {
+	c := hclconfig.NewConfig()
+
+	n := &network.Network{}
+	n.Meta = hcltypes.Meta{Name: "main", Type: network.TypeNetwork, Properties: map[string]any{constants.PropertyStatus: constants.StatusCreated}}
+	c.AppendResource(n)
+
+	app := &container.Container{}
+	app.Meta = hcltypes.Meta{Name: "app", Type: container.TypeContainer, Links: []string{"resource.network.main.meta.id"}, Properties: map[string]any{constants.PropertyStatus: constants.StatusCreated}}
+	c.AppendResource(app)
+
+	web := &container.Container{}
+	web.Meta = hcltypes.Meta{Name: "web", Type: container.TypeContainer, Links: []string{"resource.container.app.container_name"}, Properties: map[string]any{constants.PropertyStatus: constants.StatusCreated}}
+	c.AppendResource(web)
+
+	db := &container.Container{}
+	db.Meta = hcltypes.Meta{Name: "db", Type: container.TypeContainer, Links: []string{"resource.network.main.meta.id"}, Properties: map[string]any{constants.PropertyStatus: constants.StatusCreated}}
+	c.AppendResource(db)
+
+	return c
+}
+
+func TestReplaceTaintsResourceAndDependents(t *testing.T) {
+	c := setupReplaceConfig()
+
+	ids, err := replaceResources(c, "container.app")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"resource.container.app", "resource.container.web"}, ids)
+
+	r, _ := c.FindResource("resource.container.web")
+	require.Equal(t, constants.StatusTainted, r.Metadata().Properties[constants.PropertyStatus])
+
+	r, _ = c.FindResource("resource.container.db")
+	require.Equal(t, constants.StatusCreated, r.Metadata().Properties[constants.PropertyStatus])
+
+	r, _ = c.FindResource("resource.network.main")
+	require.Equal(t, constants.StatusCreated, r.Metadata().Properties[constants.PropertyStatus])
+}
+
+func TestReplaceMissingResourceReturnsError(t *testing.T) {
+	_, err := replaceResources(setupReplaceConfig(), "resource.container.vault")
+	require.Error(t, err)
+}
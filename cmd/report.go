@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/jumppad/pkg/clients/command"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatText = "text"
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+// cliFormat is the value of the global --format flag
+var cliFormat = formatText
+
+// structuredOutput returns true when commands should write machine readable
+// output instead of text
+func structuredOutput() bool {
+	return cliFormat == formatJSON || cliFormat == formatYAML
+}
+
+// validateFormat checks the value of the global --format flag
+func validateFormat(f string) error {
+	switch f {
+	case formatText, formatJSON, formatYAML:
+		return nil
+	}
+
+	return fmt.Errorf("invalid format %s, must be one of %s, %s, or %s", f, formatText, formatJSON, formatYAML)
+}
+
+// resourceReport is the outcome or status of a single resource
+type resourceReport struct {
+	ID       string  `json:"id" yaml:"id"`
+	Type     string  `json:"type" yaml:"type"`
+	Status   string  `json:"status" yaml:"status"`
+	Duration float64 `json:"duration_seconds,omitempty" yaml:"duration_seconds,omitempty"`
+	Error    string  `json:"error,omitempty" yaml:"error,omitempty"`
+
+	// Labels are the labels set on the resource
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// Process is the status of the process for supervised exec resources
+	Process *command.SupervisorStatus `json:"process,omitempty" yaml:"process,omitempty"`
+}
+
+// commandReport is the structured output for commands that create or
+// destroy resources
+type commandReport struct {
+	Command   string           `json:"command" yaml:"command"`
+	Success   bool             `json:"success" yaml:"success"`
+	Error     string           `json:"error,omitempty" yaml:"error,omitempty"`
+	Duration  float64          `json:"duration_seconds" yaml:"duration_seconds"`
+	Resources []resourceReport `json:"resources" yaml:"resources"`
+	Outputs   map[string]any   `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+}
+
+// statusReport is the structured output for the status command
+type statusReport struct {
+	Resources []resourceReport `json:"resources" yaml:"resources"`
+	Pending   int              `json:"pending" yaml:"pending"`
+	Created   int              `json:"created" yaml:"created"`
+	Failed    int              `json:"failed" yaml:"failed"`
+	Disabled  int              `json:"disabled" yaml:"disabled"`
+}
+
+// orphanReport is the structured output for status --orphans
+type orphanReport struct {
+	Orphans []orphan `json:"orphans" yaml:"orphans"`
+}
+
+// newCommandReport creates a report from the results of an engine operation
+func newCommandReport(command string, results []jumppad.ResourceResult, start time.Time, err error) commandReport {
+	cr := commandReport{
+		Command:   command,
+		Success:   err == nil,
+		Duration:  time.Since(start).Seconds(),
+		Resources: []resourceReport{},
+	}
+
+	if err != nil {
+		cr.Error = err.Error()
+	}
+
+	for _, r := range results {
+		rr := resourceReport{
+			ID:       r.ID,
+			Type:     r.Type,
+			Status:   r.Status,
+			Duration: r.Duration.Seconds(),
+		}
+
+		if r.Error != nil {
+			rr.Error = r.Error.Error()
+		}
+
+		cr.Resources = append(cr.Resources, rr)
+	}
+
+	return cr
+}
+
+// newStatusReport creates a report containing the status of the resources
+// in the state, when resourceType is set only resources of that type are
+// included and when selectors are set only resources with matching labels
+// are included
+func newStatusReport(cfg *hclconfig.Config, resourceType string, selectors []config.LabelSelector) statusReport {
+	sr := statusReport{Resources: []resourceReport{}}
+
+	for _, r := range cfg.Resources {
+		if (resourceType != "" && r.Metadata().Type != resourceType) ||
+			r.Metadata().Type == resources.TypeModule ||
+			r.Metadata().Type == resources.TypeVariable ||
+			r.Metadata().Type == resources.TypeOutput ||
+			!matchesSelectors(r, selectors) {
+			continue
+		}
+
+		rr := resourceReport{
+			ID:     r.Metadata().ID,
+			Type:   r.Metadata().Type,
+			Status: "pending",
+			Labels: config.ResourceLabels(r),
+		}
+
+		if s, ok := r.Metadata().Properties[constants.PropertyStatus].(string); ok && s != "" {
+			rr.Status = s
+		}
+
+		if r.GetDisabled() {
+			rr.Status = constants.StatusDisabled
+		}
+
+		rr.Process = execProcessStatus(r)
+
+		switch rr.Status {
+		case constants.StatusCreated:
+			sr.Created++
+		case constants.StatusFailed:
+			sr.Failed++
+		case constants.StatusDisabled:
+			sr.Disabled++
+		default:
+			sr.Pending++
+		}
+
+		sr.Resources = append(sr.Resources, rr)
+	}
+
+	sort.Slice(sr.Resources, func(i, j int) bool {
+		return sr.Resources[i].ID < sr.Resources[j].ID
+	})
+
+	return sr
+}
+
+// writeStructured writes v to w in the given format
+func writeStructured(w io.Writer, format string, v any) error {
+	switch format {
+	case formatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		defer enc.Close()
+
+		return enc.Encode(v)
+	default:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	}
+}
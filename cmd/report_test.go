@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateFormatAcceptsKnownFormats(t *testing.T) {
+	require.NoError(t, validateFormat(formatText))
+	require.NoError(t, validateFormat(formatJSON))
+	require.NoError(t, validateFormat(formatYAML))
+}
+
+func TestValidateFormatWithUnknownFormatReturnsError(t *testing.T) {
+	err := validateFormat("xml")
+	require.ErrorContains(t, err, "invalid format xml")
+}
+
+func TestNewCommandReportIncludesResourceResults(t *testing.T) {
+	results := []jumppad.ResourceResult{
+		{ID: "resource.network.main", Type: "network", Status: constants.StatusCreated, Duration: 2 * time.Second},
+		{ID: "resource.container.consul", Type: container.TypeContainer, Status: constants.StatusFailed, Error: fmt.Errorf("boom")},
+	}
+
+	r := newCommandReport("up", results, time.Now(), fmt.Errorf("unable to create resources"))
+
+	require.Equal(t, "up", r.Command)
+	require.False(t, r.Success)
+	require.Equal(t, "unable to create resources", r.Error)
+	require.Len(t, r.Resources, 2)
+	require.Equal(t, 2.0, r.Resources[0].Duration)
+	require.Empty(t, r.Resources[0].Error)
+	require.Equal(t, constants.StatusFailed, r.Resources[1].Status)
+	require.Equal(t, "boom", r.Resources[1].Error)
+}
+
+func TestNewCommandReportWithoutErrorIsSuccessful(t *testing.T) {
+	r := newCommandReport("down", nil, time.Now(), nil)
+
+	require.True(t, r.Success)
+	require.Empty(t, r.Error)
+	require.NotNil(t, r.Resources)
+}
+
+func TestNewStatusReportCountsResources(t *testing.T) {
+	c := hclconfig.NewConfig()
+
+	created := &container.Container{}
+	created.Meta = hcltypes.Meta{ID: "resource.container.consul", Name: "consul", Type: container.TypeContainer, Properties: map[string]any{constants.PropertyStatus: constants.StatusCreated}}
+	c.AppendResource(created)
+
+	disabled := &container.Container{}
+	disabled.Meta = hcltypes.Meta{ID: "resource.container.vault", Name: "vault", Type: container.TypeContainer, Properties: map[string]any{}}
+	disabled.Disabled = true
+	c.AppendResource(disabled)
+
+	out := &resources.Output{}
+	out.Meta = hcltypes.Meta{ID: "output.addr", Name: "addr", Type: resources.TypeOutput, Properties: map[string]any{}}
+	c.AppendResource(out)
+
+	r := newStatusReport(c, "", nil)
+
+	require.Len(t, r.Resources, 2)
+	require.Equal(t, "resource.container.consul", r.Resources[0].ID)
+	require.Equal(t, constants.StatusCreated, r.Resources[0].Status)
+	require.Equal(t, constants.StatusDisabled, r.Resources[1].Status)
+	require.Equal(t, 1, r.Created)
+	require.Equal(t, 1, r.Disabled)
+	require.Equal(t, 0, r.Pending)
+}
+
+func TestNewStatusReportFiltersByType(t *testing.T) {
+	c := hclconfig.NewConfig()
+
+	ct := &container.Container{}
+	ct.Meta = hcltypes.Meta{ID: "resource.container.consul", Name: "consul", Type: container.TypeContainer, Properties: map[string]any{}}
+	c.AppendResource(ct)
+
+	r := newStatusReport(c, "network", nil)
+
+	require.Empty(t, r.Resources)
+}
+
+func TestNewStatusReportFiltersByLabel(t *testing.T) {
+	c := hclconfig.NewConfig()
+
+	platform := &container.Container{Labels: map[string]string{"team": "platform"}}
+	platform.Meta = hcltypes.Meta{ID: "resource.container.consul", Name: "consul", Type: container.TypeContainer, Properties: map[string]any{}}
+	c.AppendResource(platform)
+
+	web := &container.Container{Labels: map[string]string{"team": "web"}}
+	web.Meta = hcltypes.Meta{ID: "resource.container.app", Name: "app", Type: container.TypeContainer, Properties: map[string]any{}}
+	c.AppendResource(web)
+
+	r := newStatusReport(c, "", []config.LabelSelector{{Key: "team", Value: "platform"}})
+
+	require.Len(t, r.Resources, 1)
+	require.Equal(t, "resource.container.consul", r.Resources[0].ID)
+	require.Equal(t, map[string]string{"team": "platform"}, r.Resources[0].Labels)
+}
+
+func TestWriteStructuredWritesJSON(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := writeStructured(buf, formatJSON, statusReport{Resources: []resourceReport{{ID: "resource.network.main", Type: "network", Status: "created"}}, Created: 1})
+	require.NoError(t, err)
+
+	out := map[string]any{}
+	err = json.Unmarshal(buf.Bytes(), &out)
+	require.NoError(t, err)
+	require.Equal(t, float64(1), out["created"])
+}
+
+func TestWriteStructuredWritesYAML(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := writeStructured(buf, formatYAML, resourceReport{ID: "resource.network.main", Type: "network", Status: "created"})
+	require.NoError(t, err)
+	require.Equal(t, "id: resource.network.main\ntype: network\nstatus: created\n", buf.String())
+}
+
+func TestWriteTestReportConvertsToYAML(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := writeTestReport(buf, formatYAML, []byte(`[{"name":"feature"}]`))
+	require.NoError(t, err)
+	require.Equal(t, "- name: feature\n", buf.String())
+}
+
+func TestWriteTestReportWithInvalidJSONReturnsError(t *testing.T) {
+	buf := bytes.NewBuffer(nil)
+
+	err := writeTestReport(buf, formatYAML, []byte(`not json`))
+	require.ErrorContains(t, err, "unable to parse test report")
+}
@@ -1,15 +1,21 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/jumppad-labs/jumppad/cmd/changelog"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/events"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/settings"
+	"github.com/jumppad-labs/jumppad/pkg/clients/tracing"
 	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/requirements"
 	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 
 	"github.com/spf13/cobra"
 )
@@ -27,21 +33,54 @@ var commit string  //lint:ignore U1000 set at runtime
 func createEngine(l logger.Logger, c *clients.Clients) (jumppad.Engine, error) {
 	providers := config.NewProviders(c)
 
-	engine, err := jumppad.New(providers, l)
+	engine, err := jumppad.New(jumppad.WithProviders(providers), jumppad.WithLogger(l))
 	if err != nil {
 		return nil, err
 	}
 
+	// JUMPPAD_EVENT_SINKS is a comma separated list of sinks that engine
+	// events are sent to e.g. stdout,webhook:https://example.com/hook
+	// JUMPPAD_EVENT_TYPES optionally limits the events that are sent
+	if s := os.Getenv("JUMPPAD_EVENT_SINKS"); s != "" {
+		sinks, err := events.ParseSinks(s)
+		if err != nil {
+			l.Error("Unable to configure event sinks", "error", err)
+			return engine, nil
+		}
+
+		types := []string{}
+		for _, t := range strings.Split(os.Getenv("JUMPPAD_EVENT_TYPES"), ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				types = append(types, t)
+			}
+		}
+
+		engine.SetEventBus(events.NewBus(l, sinks, types))
+	}
+
 	return engine, nil
 }
 
 func createLogger() logger.Logger {
+	// structured output is written to stdout, write logs to stderr so they
+	// do not corrupt it
+	w := os.Stdout
+	if structuredOutput() {
+		w = os.Stderr
+	}
+
 	// set the log level
-	if lev := os.Getenv("LOG_LEVEL"); lev != "" {
-		return logger.NewLogger(os.Stdout, lev)
+	lev := logger.LogLevelInfo
+	if l := os.Getenv("LOG_LEVEL"); l != "" {
+		lev = l
+	}
+
+	// LOG_FORMAT=json writes each log message as a JSON object
+	if os.Getenv("LOG_FORMAT") == logger.FormatJSON {
+		return logger.NewJSONLogger(w, lev)
 	}
 
-	return logger.NewLogger(os.Stdout, logger.LogLevelInfo)
+	return logger.NewLogger(w, lev)
 }
 
 // Execute the root command
@@ -50,34 +89,98 @@ func Execute(v, c, d string) error {
 	commit = c
 	date = d
 
+	// blueprints can require a minimum version of jumppad
+	requirements.Version = v
+
+	// the environment changes where the clients and plugins read and write
+	// state, read the flag before the dependencies are created
+	cliEnvironment = flagValue(os.Args[1:], "env")
+
+	err := setEnvironment()
+	if err != nil {
+		showErr(err)
+		return err
+	}
+
 	// setup dependencies
 	l := createLogger()
 
+	// the user settings set the defaults for the environment, this must be
+	// done before the clients are created
+	userSettings, err := settings.Load(utils.SettingsPath())
+	if err != nil {
+		l.Error("Unable to load settings", "error", err)
+		userSettings = &settings.Settings{}
+	}
+
+	userSettings.Apply()
+
+	// spans are only exported when an OTLP endpoint is set in the environment
+	// and telemetry has not been disabled
+	if userSettings.TelemetryEnabled() {
+		shutdownTracing, err := tracing.Setup(context.Background(), version)
+		if err != nil {
+			l.Error("Unable to configure tracing", "error", err)
+		} else {
+			defer shutdownTracing(context.Background())
+		}
+	}
+
 	engineClients, _ := clients.GenerateClients(l)
 
+	// the resource types provided by external plugins are registered when
+	// configuration or state is first parsed
+	jumppad.LoadPluginsOnUse(utils.PluginsDir(), l)
+
 	engine, _ := createEngine(l, engineClients)
 
 	rootCmd.AddCommand(checkCmd)
 	rootCmd.AddCommand(outputCmd)
 	rootCmd.AddCommand(newDevCmd())
 	rootCmd.AddCommand(newEnvCmd())
-	rootCmd.AddCommand(newRunCmd(engine, engineClients.ContainerTasks, engineClients.Getter, engineClients.HTTP, engineClients.System, engineClients.Connector, l))
+	rootCmd.AddCommand(newRunCmd(engine, engineClients.ContainerTasks, engineClients.Getter, engineClients.HTTP, engineClients.System, engineClients.Connector, engineClients.ImageSets, l))
 	rootCmd.AddCommand(newTestCmd())
+	rootCmd.AddCommand(newCICmd(engine, engineClients.Getter, engineClients.Connector, l))
 	rootCmd.AddCommand(newDestroyCmd(engineClients.Connector, l))
 	rootCmd.AddCommand(statusCmd)
-	rootCmd.AddCommand(newPurgeCmd(engineClients.Docker, engineClients.ImageLog, l))
-	rootCmd.AddCommand(taintCmd)
-	rootCmd.AddCommand(newVersionCmd())
+	rootCmd.AddCommand(newPurgeCmd(engineClients.Docker, engineClients.ImageLog, engineClients.ImageSets, l))
+	rootCmd.AddCommand(newCacheCmd(engineClients.Artifacts))
+	rootCmd.AddCommand(newInitCmd(engineClients.Getter))
+	rootCmd.AddCommand(newTaintCmd())
+	rootCmd.AddCommand(newUntaintCmd())
+	rootCmd.AddCommand(newReplaceCmd(engine, engineClients.ContainerTasks, engineClients.Getter, engineClients.HTTP, engineClients.System, engineClients.Connector, engineClients.ImageSets, l))
+	rootCmd.AddCommand(newVersionCmd(engineClients.Updater))
 	rootCmd.AddCommand(uninstallCmd)
-	rootCmd.AddCommand(newPushCmd(engineClients.ContainerTasks, l))
+
+	// self-update replaces the running binary
+	binary, _ := os.Executable()
+	rootCmd.AddCommand(newSelfUpdateCmd(engineClients.Updater, binary))
+	rootCmd.AddCommand(newPushCmd(engineClients.ContainerTasks, engineClients.Registry, l))
 	rootCmd.AddCommand(newLogCmd(engineClients.Docker, os.Stdout, os.Stderr), completionCmd)
+	rootCmd.AddCommand(newExecCmd(engineClients.ContainerTasks))
+	rootCmd.AddCommand(newPortForwardCmd(engineClients.Connector))
+	rootCmd.AddCommand(newCpCmd(engineClients.ContainerTasks))
+	rootCmd.AddCommand(newDiagnoseCmd(engineClients.Docker, engineClients.ContainerTasks, engineClients.System))
+	rootCmd.AddCommand(newDoctorCmd(engine, engineClients.Docker))
+	rootCmd.AddCommand(newSnapshotCmd(engineClients.Snapshots))
+	rootCmd.AddCommand(newPauseCmd(engineClients.Docker, engineClients.Connector, l))
+	rootCmd.AddCommand(newResumeCmd(engineClients, l))
+	rootCmd.AddCommand(newStatsCmd(engineClients.ContainerTasks, l))
+	rootCmd.AddCommand(newChaosCmd(engineClients.ContainerTasks, engineClients.Docker, l))
 	rootCmd.AddCommand(changelogCmd)
+	rootCmd.AddCommand(newSuperviseCmd(l))
+	rootCmd.AddCommand(newCollectLogsCmd(engineClients.Kubernetes, engineClients.Nomad, l))
+	rootCmd.AddCommand(newConfigCmd(utils.SettingsPath()))
+	rootCmd.AddCommand(newAPICmd(engine, engineClients.Docker, engineClients.Connector, l))
+	rootCmd.AddCommand(newSchemaCmd())
 
 	// add the server commands
 	rootCmd.AddCommand(connectorCmd)
 	connectorCmd.AddCommand(newConnectorRunCommand())
 	connectorCmd.AddCommand(connectorStopCmd)
+	connectorCmd.AddCommand(newConnectorStatusCmd(engineClients.Connector))
 	connectorCmd.AddCommand(newConnectorCertCmd())
+	connectorCmd.AddCommand(newConnectorDNSCmd())
 
 	// add the generate command
 	rootCmd.AddCommand(generateCmd)
@@ -89,6 +192,9 @@ func Execute(v, c, d string) error {
 	// add the validate command
 	rootCmd.AddCommand(newValidateCmd(engine, engineClients.Getter))
 
+	// add the graph command
+	rootCmd.AddCommand(newGraphCmd(engine, engineClients.Getter))
+
 	// add the fmt command
 	rootCmd.AddCommand(newFormatCmd())
 
@@ -96,9 +202,28 @@ func Execute(v, c, d string) error {
 
 	// set a pre run function to show the changelog
 	rootCmd.PersistentFlags().Bool("non-interactive", false, "Run in non-interactive mode")
+	rootCmd.PersistentFlags().StringVarP(&cliFormat, "format", "", formatText, "Output format for commands that report results such as up, down, status, and test, one of text, json, or yaml")
+	rootCmd.PersistentFlags().StringVarP(&cliEnvironment, "env", "", "", "Name of the environment to use, defaults to the environment selected with 'jumppad env select'")
 	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		err := validateFormat(cliFormat)
+		if err != nil {
+			return err
+		}
+
+		// the logger is created before cobra parses the global --format
+		// flag, commands such as graph and output define their own --format
+		// flag so the arguments can not be scanned for it
+		if structuredOutput() {
+			l.SetOutput(os.Stderr)
+		}
+
+		err = setEnvironment()
+		if err != nil {
+			return err
+		}
+
 		ni, _ := cmd.Flags().GetBool("non-interactive")
-		if ni {
+		if ni || structuredOutput() {
 			return nil
 		}
 
@@ -107,7 +232,7 @@ func Execute(v, c, d string) error {
 		// replace """ with ``` in changelog
 		changes = strings.ReplaceAll(changes, `"""`, "```")
 
-		err := cl.Show(changes, changesVersion, false)
+		err = cl.Show(changes, changesVersion, false)
 		if err != nil {
 			showErr(err)
 			return err
@@ -125,7 +250,7 @@ func Execute(v, c, d string) error {
 		return nil
 	}
 
-	err := rootCmd.Execute()
+	err = rootCmd.Execute()
 
 	if err != nil {
 		showErr(err)
@@ -135,6 +260,12 @@ func Execute(v, c, d string) error {
 }
 
 func showErr(err error) {
+	// keep stdout parsable when using structured output
+	if structuredOutput() {
+		fmt.Fprintln(os.Stderr, err)
+		return
+	}
+
 	fmt.Println("")
 	fmt.Println(err)
 }
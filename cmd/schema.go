@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/schema"
+	"github.com/spf13/cobra"
+)
+
+const schemaFormatJSONSchema = "json-schema"
+
+func newSchemaCmd() *cobra.Command {
+	schemaCmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Export the schema for jumppad configuration",
+		Long:  `Export the schema for jumppad configuration`,
+		// the schema is used by editors that may not have a container
+		// runtime, skip the preflight checks run by the root command
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	schemaCmd.AddCommand(newSchemaExportCmd())
+
+	return schemaCmd
+}
+
+func newSchemaExportCmd() *cobra.Command {
+	var format string
+	var output string
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export a machine readable schema for every resource type",
+		Long: `Export a machine readable schema for every resource type.
+
+The schema describes the attributes, blocks, types, and documentation for each
+resource and the builtin variable, output, local, and module blocks. Editor
+extensions and language servers can use the schema to offer completion and
+validation. Each definition in $defs describes the body of a block, the x-hcl-
+extensions describe whether a property is an attribute or a nested block and
+the labels of the block.`,
+		Example: `
+  # Write the JSON Schema to stdout
+  jumppad schema export --format json-schema
+
+  # Write the JSON Schema to a file
+  jumppad schema export --format json-schema --output jumppad.schema.json
+	`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format != schemaFormatJSONSchema {
+				return fmt.Errorf("unsupported format %s, supported formats are: %s", format, schemaFormatJSONSchema)
+			}
+
+			d, err := json.MarshalIndent(schema.New(config.RegisteredResources()), "", "  ")
+			if err != nil {
+				return fmt.Errorf("unable to encode schema: %s", err)
+			}
+
+			if output == "" {
+				fmt.Fprintln(cmd.OutOrStdout(), string(d))
+				return nil
+			}
+
+			err = os.WriteFile(output, append(d, '\n'), 0644)
+			if err != nil {
+				return fmt.Errorf("unable to write schema to %s: %s", output, err)
+			}
+
+			return nil
+		},
+	}
+
+	exportCmd.Flags().StringVarP(&format, "format", "", schemaFormatJSONSchema, "Format of the schema, supported formats are: json-schema")
+	exportCmd.Flags().StringVarP(&output, "output", "o", "", "Write the schema to a file rather than stdout")
+
+	return exportCmd
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/config/schema"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSchemaCmd(t *testing.T, args ...string) (*cobra.Command, *bytes.Buffer) {
+	output := bytes.NewBuffer(nil)
+
+	c := newSchemaCmd()
+	c.SetOut(output)
+	c.SetErr(output)
+	c.SetArgs(args)
+
+	return c, output
+}
+
+func TestSchemaExportWritesJSONSchema(t *testing.T) {
+	c, output := setupSchemaCmd(t, "export", "--format", "json-schema")
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	s := &schema.Schema{}
+	err = json.Unmarshal(output.Bytes(), s)
+	require.NoError(t, err)
+
+	require.Contains(t, s.Defs, "container")
+	require.Contains(t, s.Defs["container"].Properties, "image")
+}
+
+func TestSchemaExportWritesToFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jumppad.schema.json")
+	c, output := setupSchemaCmd(t, "export", "--output", path)
+
+	err := c.Execute()
+	require.NoError(t, err)
+	require.Empty(t, output.String())
+
+	d, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.True(t, json.Valid(d))
+}
+
+func TestSchemaExportWithUnsupportedFormatReturnsError(t *testing.T) {
+	c, _ := setupSchemaCmd(t, "export", "--format", "yaml")
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "unsupported format yaml")
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/registry"
+	"github.com/jumppad-labs/jumppad/pkg/clients/updater"
+	"github.com/spf13/cobra"
+)
+
+func newSelfUpdateCmd(u updater.Updater, binary string) *cobra.Command {
+	var verifyKey string
+	var force bool
+	var insecure bool
+
+	selfUpdateCmd := &cobra.Command{
+		Use:   "self-update [version]",
+		Short: "Update jumppad to the latest or given version",
+		Long: `Update jumppad to the latest or given version.
+
+The signature of the checksums published with the release is verified using
+the release key built into jumppad, or the key set with --verify-key, then the
+release archive is verified using the checksums before the jumppad binary is
+replaced. Builds of jumppad without a release key can only update when
+--insecure is set, insecure updates only verify the checksums.`,
+		Example: `
+  # Update to the latest version
+  jumppad self-update
+
+  # Install a specific version
+  jumppad self-update 0.13.0
+
+  # Verify the signature of the release checksums using the given key
+  jumppad self-update --verify-key ./jumppad.pub
+
+  # Update a build without a release key using only the release checksums
+  jumppad self-update --insecure
+	`,
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			key, err := updater.EmbeddedKey()
+			if err != nil {
+				return err
+			}
+
+			if verifyKey != "" {
+				key, err = registry.ReadPublicKey(verifyKey)
+				if err != nil {
+					return fmt.Errorf("unable to read verify key: %s", err)
+				}
+			}
+
+			target := ""
+			if len(args) == 1 {
+				target = args[0]
+			} else {
+				latest, err := u.Latest(context.Background())
+				if err != nil {
+					return err
+				}
+
+				target = latest
+			}
+
+			if sameVersion(target, version) && !force {
+				cmd.Printf("jumppad is already at version %s\n", version)
+				return nil
+			}
+
+			cmd.Printf("Updating jumppad from %s to %s\n", version, target)
+
+			err = u.Update(context.Background(), target, binary, key, insecure)
+			if errors.Is(err, updater.ErrNoVerifyKey) {
+				return fmt.Errorf("unable to update jumppad: %s, use --verify-key to set the release key or --insecure to update without verifying the signature", err)
+			}
+
+			if err != nil {
+				return fmt.Errorf("unable to update jumppad: %s", err)
+			}
+
+			cmd.Printf("jumppad updated to version %s\n", target)
+
+			return nil
+		},
+	}
+
+	selfUpdateCmd.Flags().StringVarP(&verifyKey, "verify-key", "", "", "Path to a PEM encoded public key used to verify the signature of the release checksums")
+	selfUpdateCmd.Flags().BoolVarP(&insecure, "insecure", "", false, "When set to true jumppad is updated without verifying the signature of the release checksums")
+	selfUpdateCmd.Flags().BoolVarP(&force, "force", "", false, "When set to true jumppad is downloaded even when the current version matches the requested version")
+
+	return selfUpdateCmd
+}
+
+// sameVersion returns true when the versions are equal ignoring the v prefix
+func sameVersion(a, b string) bool {
+	return strings.TrimPrefix(a, "v") == strings.TrimPrefix(b, "v")
+}
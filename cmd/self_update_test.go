@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/updater"
+	updatermocks "github.com/jumppad-labs/jumppad/pkg/clients/updater/mocks"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSelfUpdate(t *testing.T) (*cobra.Command, *updatermocks.Updater, *bytes.Buffer) {
+	setVersion(t, "v0.12.0")
+
+	mu := &updatermocks.Updater{}
+	mu.On("Latest", mock.Anything).Return("0.13.0", nil)
+	mu.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	output := bytes.NewBuffer(nil)
+
+	c := newSelfUpdateCmd(mu, "/usr/local/bin/jumppad")
+	c.SetOut(output)
+	c.SetErr(output)
+
+	return c, mu, output
+}
+
+func TestSelfUpdateInstallsLatestVersion(t *testing.T) {
+	c, mu, output := setupSelfUpdate(t)
+	c.SetArgs([]string{})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	mu.AssertCalled(t, "Update", mock.Anything, "0.13.0", "/usr/local/bin/jumppad", nil, false)
+	require.Contains(t, output.String(), "jumppad updated to version 0.13.0")
+}
+
+func TestSelfUpdateInstallsGivenVersion(t *testing.T) {
+	c, mu, _ := setupSelfUpdate(t)
+	c.SetArgs([]string{"0.11.2"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	mu.AssertNotCalled(t, "Latest", mock.Anything)
+	mu.AssertCalled(t, "Update", mock.Anything, "0.11.2", "/usr/local/bin/jumppad", nil, false)
+}
+
+func TestSelfUpdateDoesNothingWhenVersionIsCurrent(t *testing.T) {
+	c, mu, output := setupSelfUpdate(t)
+	c.SetArgs([]string{"0.12.0"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	mu.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	require.Contains(t, output.String(), "jumppad is already at version v0.12.0")
+}
+
+func TestSelfUpdateWithForceUpdatesWhenVersionIsCurrent(t *testing.T) {
+	c, mu, _ := setupSelfUpdate(t)
+	c.SetArgs([]string{"0.12.0", "--force"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	mu.AssertCalled(t, "Update", mock.Anything, "0.12.0", "/usr/local/bin/jumppad", nil, false)
+}
+
+func TestSelfUpdateReturnsErrorWhenUpdateFails(t *testing.T) {
+	c, mu, _ := setupSelfUpdate(t)
+	testutils.RemoveOn(&mu.Mock, "Update")
+	mu.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("checksum does not match"))
+
+	c.SetArgs([]string{})
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "unable to update jumppad: checksum does not match")
+}
+
+func TestSelfUpdateWithInsecureUpdatesWithoutKey(t *testing.T) {
+	c, mu, _ := setupSelfUpdate(t)
+	c.SetArgs([]string{"--insecure"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	mu.AssertCalled(t, "Update", mock.Anything, "0.13.0", "/usr/local/bin/jumppad", nil, true)
+}
+
+func TestSelfUpdateWithoutKeyReturnsError(t *testing.T) {
+	c, mu, _ := setupSelfUpdate(t)
+	testutils.RemoveOn(&mu.Mock, "Update")
+	mu.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(updater.ErrNoVerifyKey)
+
+	c.SetArgs([]string{})
+
+	err := c.Execute()
+	require.ErrorContains(t, err, "use --verify-key to set the release key or --insecure")
+}
+
+func TestVersionCheckShowsLatestVersion(t *testing.T) {
+	_, mu, output := setupSelfUpdate(t)
+
+	c := newVersionCmd(mu)
+	c.SetOut(output)
+	c.SetArgs([]string{"--check"})
+
+	err := c.Execute()
+	require.NoError(t, err)
+
+	require.Contains(t, output.String(), "Latest Version: 0.13.0")
+	require.Contains(t, output.String(), "run 'jumppad self-update' to update")
+}
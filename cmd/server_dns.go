@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newConnectorDNSCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "dns",
+		Short: "Show how to configure the system resolver to use the connector DNS server",
+		Long: `The connector runs a DNS server that resolves the names of resources such as
+web.container.local.jmpd.in to their addresses. Containers resolve these names
+using the Docker network DNS, this command shows how to configure the system
+resolver so that the names also resolve on the local machine.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := dnsResolverConfig(runtime.GOOS, connector.DefaultConnectorOptions().DNSBind)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), cfg)
+			return nil
+		},
+	}
+}
+
+// dnsResolverConfig returns the instructions to forward queries for the
+// jumppad domain to the connector DNS server bound to addr
+func dnsResolverConfig(goos, addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid DNS bind address %s: %w", addr, err)
+	}
+
+	domain := fmt.Sprintf("local.%s", utils.LocalTLD)
+
+	switch goos {
+	case "darwin":
+		return fmt.Sprintf(`Create a resolver for %[1]s by running:
+
+  sudo mkdir -p /etc/resolver
+  printf 'nameserver %[2]s\nport %[3]s\n' | sudo tee /etc/resolver/%[1]s`, domain, host, port), nil
+	case "linux":
+		return fmt.Sprintf(`When using systemd-resolved, create the file /etc/systemd/resolved.conf.d/jumppad.conf:
+
+  [Resolve]
+  DNS=%[2]s:%[3]s
+  Domains=~%[1]s
+
+Then restart the resolver:
+
+  sudo systemctl restart systemd-resolved`, domain, host, port), nil
+	}
+
+	return "", fmt.Errorf("configuring the system resolver is not supported on %s, use the ingress addresses to access resources", goos)
+}
+
+// stateDNSRecords builds the DNS records for the resources in the
+// current jumppad state
+func stateDNSRecords() map[string]string {
+	records := map[string]string{}
+
+	c, err := config.LoadState()
+	if err != nil {
+		return records
+	}
+
+	for _, r := range c.Resources {
+		if r.GetDisabled() {
+			continue
+		}
+
+		fqdn := utils.FQDN(r.Metadata().Name, r.Metadata().Module, r.Metadata().Type)
+
+		switch v := r.(type) {
+		case *container.Container:
+			for _, n := range v.Networks {
+				if n.AssignedAddress != "" {
+					records[fqdn] = n.AssignedAddress
+					break
+				}
+			}
+		case *k8s.Cluster:
+			if v.ExternalIP != "" {
+				records[fqdn] = v.ExternalIP
+			}
+		case *nomad.NomadCluster:
+			if v.ExternalIP != "" {
+				records[fqdn] = v.ExternalIP
+			}
+		case *ingress.Ingress:
+			// ingress is always exposed on the local machine
+			records[fqdn] = "127.0.0.1"
+		}
+	}
+
+	return records
+}
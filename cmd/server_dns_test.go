@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDNSResolverConfigForDarwinCreatesResolverFile(t *testing.T) {
+	cfg, err := dnsResolverConfig("darwin", "127.0.0.1:30053")
+	require.NoError(t, err)
+
+	require.Contains(t, cfg, "/etc/resolver/local.jmpd.in")
+	require.Contains(t, cfg, `nameserver 127.0.0.1\nport 30053`)
+}
+
+func TestDNSResolverConfigForLinuxConfiguresSystemdResolved(t *testing.T) {
+	cfg, err := dnsResolverConfig("linux", "127.0.0.1:30053")
+	require.NoError(t, err)
+
+	require.Contains(t, cfg, "DNS=127.0.0.1:30053")
+	require.Contains(t, cfg, "Domains=~local.jmpd.in")
+}
+
+func TestDNSResolverConfigForWindowsReturnsError(t *testing.T) {
+	_, err := dnsResolverConfig("windows", "127.0.0.1:30053")
+	require.Error(t, err)
+}
+
+func TestDNSResolverConfigWithInvalidAddressReturnsError(t *testing.T) {
+	_, err := dnsResolverConfig("linux", "127.0.0.1")
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -14,6 +15,8 @@ import (
 	"github.com/jumppad-labs/connector/protos/shipyard"
 	"github.com/jumppad-labs/connector/remote"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/metrics"
+	"github.com/jumppad-labs/jumppad/pkg/clients/progress"
 	"github.com/jumppad-labs/jumppad/pkg/server"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/spf13/cobra"
@@ -25,6 +28,8 @@ func newConnectorRunCommand() *cobra.Command {
 	var grpcBindAddr string
 	var httpBindAddr string
 	var apiBindAddr string
+	var dnsBindAddr string
+	var metricsBindAddr string
 	var pathCertRoot string
 	var pathCertServer string
 	var pathKeyServer string
@@ -115,8 +120,50 @@ func newConnectorRunCommand() *cobra.Command {
 			// we should look at merging the connector server and the API server
 			l.Info("Starting API server", "bind_addr", apiBindAddr)
 			api := server.New(apiBindAddr, l)
+			api.SetProgressStore(progress.NewFileStore(progress.DefaultPath()))
+			listServices := func() ([]*shipyard.Service, error) {
+				resp, err := s.ListServices(context.Background(), &shipyard.NullMessage{})
+				if err != nil {
+					return nil, err
+				}
+
+				return resp.Services, nil
+			}
+
+			api.SetServiceLister(listServices)
 			go api.Start()
 
+			// start the metrics listener that exposes the tunnel stats
+			if metricsBindAddr != "" {
+				l.Info("Starting metrics listener", "bind_addr", metricsBindAddr)
+
+				err := metrics.Default.RegisterServiceLister(listServices)
+				if err != nil {
+					return fmt.Errorf("unable to register connector metrics: %s", err)
+				}
+
+				go func() {
+					err := metrics.Default.Serve(metricsBindAddr)
+					if err != nil {
+						l.Error("Unable to start metrics listener", "error", err)
+					}
+				}()
+			}
+
+			// start the DNS server that resolves resource names
+			var dns *server.DNS
+			if dnsBindAddr != "" {
+				l.Info("Starting DNS server", "bind_addr", dnsBindAddr)
+				dns = server.NewDNS(dnsBindAddr, stateDNSRecords, l)
+
+				go func() {
+					err := dns.Start()
+					if err != nil {
+						l.Error("Unable to start DNS server", "error", err)
+					}
+				}()
+			}
+
 			c := make(chan os.Signal, 1)
 			signal.Notify(c, os.Interrupt)
 			signal.Notify(c, syscall.SIGTERM)
@@ -127,6 +174,10 @@ func newConnectorRunCommand() *cobra.Command {
 
 			s.Shutdown()
 
+			if dns != nil {
+				dns.Stop()
+			}
+
 			return nil
 		},
 	}
@@ -134,6 +185,8 @@ func newConnectorRunCommand() *cobra.Command {
 	connectorRunCmd.Flags().StringVarP(&grpcBindAddr, "grpc-bind", "", ":9090", "Bind address for the gRPC API")
 	connectorRunCmd.Flags().StringVarP(&httpBindAddr, "http-bind", "", ":9091", "Bind address for the HTTP API")
 	connectorRunCmd.Flags().StringVarP(&apiBindAddr, "api-bind", "", ":9092", "Bind address for the API Server")
+	connectorRunCmd.Flags().StringVarP(&dnsBindAddr, "dns-bind", "", "", "Bind address for the DNS server that resolves resource names, disabled when empty")
+	connectorRunCmd.Flags().StringVarP(&metricsBindAddr, "metrics-bind", "", "", "Bind address for the Prometheus metrics listener, disabled when empty")
 	connectorRunCmd.Flags().StringVarP(&pathCertRoot, "root-cert-path", "", "", "Path for the PEM encoded TLS root certificate")
 	connectorRunCmd.Flags().StringVarP(&pathCertServer, "server-cert-path", "", "", "Path for the servers PEM encoded TLS certificate")
 	connectorRunCmd.Flags().StringVarP(&pathKeyServer, "server-key-path", "", "", "Path for the servers PEM encoded Private Key")
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hokaccha/go-prettyjson"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+	"github.com/spf13/cobra"
+)
+
+func newConnectorStatusCmd(cc connector.Connector) *cobra.Command {
+	var outputJSON bool
+
+	connectorStatusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the status of the connector",
+		Long:  `Show the services tunnelled by the connector, their health, and the traffic forwarded by TLS listeners`,
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cc.IsRunning() {
+				fmt.Println("The connector is not running")
+				return nil
+			}
+
+			s, err := cc.Status()
+			if err != nil {
+				return fmt.Errorf("unable to get connector status: %w", err)
+			}
+
+			if outputJSON {
+				d, err := prettyjson.Marshal(s)
+				if err != nil {
+					return fmt.Errorf("unable to output status as JSON: %w", err)
+				}
+
+				fmt.Println(string(d))
+				return nil
+			}
+
+			printConnectorStatus(os.Stdout, s)
+
+			return nil
+		},
+	}
+
+	connectorStatusCmd.Flags().BoolVarP(&outputJSON, "json", "", false, "Output the status as JSON")
+
+	return connectorStatusCmd
+}
+
+func printConnectorStatus(w io.Writer, s *types.Status) {
+	fmt.Fprintln(w, whiteText.Render("Services"))
+
+	if len(s.Services) == 0 {
+		fmt.Fprintf(w, "  %s\n", grayText.Render("no active services"))
+	}
+
+	for _, svc := range s.Services {
+		icon := redIcon.Render("✘")
+		if svc.Healthy {
+			icon = greenIcon.Render("✔")
+		}
+
+		fmt.Fprintf(w, "%s %s %s\n", icon, svc.Name, grayText.Render(fmt.Sprintf("(%s, %s)", svc.Type, svc.Status)))
+		fmt.Fprintf(w, "    %s %s\n", grayText.Render("└─"), fmt.Sprintf(":%d -> %s -> %s", svc.SourcePort, svc.RemoteConnectorAddr, svc.DestinationAddr))
+	}
+
+	if len(s.TLSTerminations) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, whiteText.Render("TLS Listeners"))
+
+	for _, t := range s.TLSTerminations {
+		fmt.Fprintf(w, "%s :%d -> %s\n", greenIcon.Render("✔"), t.Port, t.Destination)
+		fmt.Fprintf(w, "    %s %s\n", grayText.Render("└─"), grayText.Render(fmt.Sprintf("connections: %d, bytes in: %d, bytes out: %d", t.Connections, t.BytesIn, t.BytesOut)))
+	}
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/jumppad/pkg/clients/snapshot"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func newSnapshotCmd(sn snapshot.Snapshots) *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Create and restore snapshots of the running environment",
+		Long: `Create and restore snapshots of the running environment.
+
+A snapshot commits the filesystem of every container, including the containers
+for Kubernetes and Nomad clusters, and exports the contents of their volumes.
+Restoring a snapshot replaces the running containers with containers created
+from the snapshot, resetting the environment to the point the snapshot was
+taken.
+
+Snapshots are stored in $HOME/.jumppad/snapshots and the committed images in
+the local Docker engine.`,
+	}
+
+	snapshotCmd.AddCommand(newSnapshotCreateCmd(sn))
+	snapshotCmd.AddCommand(newSnapshotRestoreCmd(sn))
+	snapshotCmd.AddCommand(newSnapshotListCmd(sn))
+	snapshotCmd.AddCommand(newSnapshotDeleteCmd(sn))
+
+	return snapshotCmd
+}
+
+func newSnapshotCreateCmd(sn snapshot.Snapshots) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create [name]",
+		Short: "Create a snapshot of the running environment",
+		Example: `
+  # Snapshot the environment before a demo
+  jumppad snapshot create demo
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadState()
+			if err != nil {
+				return fmt.Errorf("unable to load state, have you run jumppad up: %s", err)
+			}
+
+			hash, err := utils.HashFile(utils.StatePath())
+			if err != nil {
+				return fmt.Errorf("unable to hash state: %s", err)
+			}
+
+			targets := snapshotTargets(cfg)
+			if len(targets) == 0 {
+				return fmt.Errorf("there are no running containers to snapshot")
+			}
+
+			s, err := sn.Create(context.Background(), args[0], hash, targets)
+			if err != nil {
+				return fmt.Errorf("unable to create snapshot: %s", err)
+			}
+
+			cmd.Printf("Created snapshot %s of %d containers\n", s.Name, len(s.Containers))
+
+			return nil
+		},
+	}
+}
+
+func newSnapshotRestoreCmd(sn snapshot.Snapshots) *cobra.Command {
+	var force bool
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore [name]",
+		Short: "Restore the environment to a snapshot",
+		Long: `Restore the environment to a snapshot.
+
+The containers in the snapshot are replaced with containers created from the
+snapshot images and volumes. The snapshot can only be restored when the state
+has not changed since the snapshot was created, as the environment may no
+longer contain the same containers.`,
+		Example: `
+  # Reset the environment after a demo
+  jumppad snapshot restore demo
+	`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			s, err := sn.Get(args[0])
+			if err != nil {
+				return err
+			}
+
+			hash, err := utils.HashFile(utils.StatePath())
+			if err != nil {
+				return fmt.Errorf("unable to hash state, have you run jumppad up: %s", err)
+			}
+
+			if hash != s.StateHash && !force {
+				return fmt.Errorf("the environment has changed since snapshot %s was created, use --force to restore it anyway", s.Name)
+			}
+
+			_, err = sn.Restore(context.Background(), s.Name)
+			if err != nil {
+				return fmt.Errorf("unable to restore snapshot: %s", err)
+			}
+
+			cmd.Printf("Restored snapshot %s\n", s.Name)
+
+			return nil
+		},
+	}
+
+	restoreCmd.Flags().BoolVarP(&force, "force", "", false, "Restore the snapshot even when the state has changed since it was created")
+
+	return restoreCmd
+}
+
+func newSnapshotListCmd(sn snapshot.Snapshots) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			snaps, err := sn.List()
+			if err != nil {
+				return err
+			}
+
+			if structuredOutput() {
+				return writeStructured(cmd.OutOrStdout(), cliFormat, snaps)
+			}
+
+			if len(snaps) == 0 {
+				cmd.Println("There are no snapshots")
+				return nil
+			}
+
+			for _, s := range snaps {
+				cmd.Printf("%-30s %s  %d containers\n", s.Name, s.Created.Format("2006-01-02 15:04:05"), len(s.Containers))
+			}
+
+			return nil
+		},
+	}
+}
+
+func newSnapshotDeleteCmd(sn snapshot.Snapshots) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Delete a snapshot and its images",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := sn.Delete(context.Background(), args[0])
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Deleted snapshot %s\n", args[0])
+
+			return nil
+		},
+	}
+}
+
+// snapshotTargets returns the containers for the resources in the state,
+// the image cache is shared between environments so is not included
+func snapshotTargets(cfg *hclconfig.Config) []snapshot.Target {
+	targets := []snapshot.Target{}
+
+	for _, r := range cfg.Resources {
+		if r.GetDisabled() || r.Metadata().Type == cache.TypeImageCache {
+			continue
+		}
+
+		for _, fqdn := range getFQDNForResource(r) {
+			targets = append(targets, snapshot.Target{Resource: r.Metadata().ID, Container: fqdn})
+		}
+	}
+
+	return targets
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotTargetsReturnsContainers(t *testing.T) {
+	c := hclconfig.NewConfig()
+
+	ct := &container.Container{}
+	ct.Meta = hcltypes.Meta{ID: "resource.container.consul", Name: "consul", Type: container.TypeContainer, Properties: map[string]any{}}
+	c.AppendResource(ct)
+
+	targets := snapshotTargets(c)
+
+	require.Len(t, targets, 1)
+	require.Equal(t, "resource.container.consul", targets[0].Resource)
+	require.Equal(t, utils.FQDN("consul", "", container.TypeContainer), targets[0].Container)
+}
+
+func TestSnapshotTargetsIgnoresDisabledResourcesAndImageCache(t *testing.T) {
+	c := hclconfig.NewConfig()
+
+	disabled := &container.Container{}
+	disabled.Meta = hcltypes.Meta{ID: "resource.container.vault", Name: "vault", Type: container.TypeContainer, Properties: map[string]any{}}
+	disabled.Disabled = true
+	c.AppendResource(disabled)
+
+	ic := &cache.ImageCache{}
+	ic.Meta = hcltypes.Meta{ID: "resource.image_cache.default", Name: "default", Type: cache.TypeImageCache, Properties: map[string]any{}}
+	c.AppendResource(ic)
+
+	require.Empty(t, snapshotTargets(c))
+}
@@ -0,0 +1,419 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// supported formats for the stats export file
+const (
+	statsExportCSV  = ".csv"
+	statsExportJSON = ".json"
+)
+
+// resourceStats is the resource usage of all the containers for a resource
+// at the time it was sampled
+type resourceStats struct {
+	Time       time.Time `json:"time" yaml:"time"`
+	ID         string    `json:"id" yaml:"id"`
+	Type       string    `json:"type" yaml:"type"`
+	Containers int       `json:"containers" yaml:"containers"`
+
+	CPUPercent  float64 `json:"cpu_percent" yaml:"cpu_percent"`
+	MemoryUsage uint64  `json:"memory_bytes" yaml:"memory_bytes"`
+	MemoryLimit uint64  `json:"memory_limit_bytes" yaml:"memory_limit_bytes"`
+	NetworkRx   uint64  `json:"network_rx_bytes" yaml:"network_rx_bytes"`
+	NetworkTx   uint64  `json:"network_tx_bytes" yaml:"network_tx_bytes"`
+	BlockRead   uint64  `json:"block_read_bytes" yaml:"block_read_bytes"`
+	BlockWrite  uint64  `json:"block_write_bytes" yaml:"block_write_bytes"`
+	DiskUsage   int64   `json:"disk_bytes" yaml:"disk_bytes"`
+}
+
+// statsReport is the structured output for a single sample of the stats
+// command
+type statsReport struct {
+	Time      time.Time       `json:"time" yaml:"time"`
+	Resources []resourceStats `json:"resources" yaml:"resources"`
+}
+
+// statsResource is a resource in the state and the names of its containers
+type statsResource struct {
+	ID         string
+	Type       string
+	Containers []string
+}
+
+func newStatsCmd(dt container.ContainerTasks, l logger.Logger) *cobra.Command {
+	var interval time.Duration
+	var samples int
+	var export string
+
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show the CPU, memory, disk, and network usage of the resources",
+		Long: `Show the CPU, memory, disk, and network usage of the resources.
+
+The usage of the containers for each resource is sampled from the container
+engine and added together. CPU is the percentage of a single core, 200% is two
+full cores. Network and block IO are the totals since the containers started,
+disk is the size of the files written to the containers.
+
+When more than one sample is taken the peak CPU and memory usage for each
+resource is shown when sampling finishes, this can be used to set the
+resource limits for a blueprint. Samples can be exported to a CSV or JSON file,
+JSON files contain an object for each resource per line.`,
+		Example: `
+  # Show the current usage
+  jumppad stats
+
+  # Sample every 10 seconds for 5 minutes and export the samples to CSV
+  jumppad stats --interval 10s --samples 30 --export usage.csv
+
+  # Sample until ctrl-c is pressed
+  jumppad stats --samples 0 --export usage.json
+	`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if samples < 0 {
+				return fmt.Errorf("samples must be 0 or greater")
+			}
+
+			if interval <= 0 {
+				return fmt.Errorf("interval must be greater than 0")
+			}
+
+			cfg, err := config.LoadState()
+			if err != nil {
+				return fmt.Errorf("unable to load state, have you run jumppad up: %s", err)
+			}
+
+			resources := statsResources(cfg)
+			if len(resources) == 0 {
+				return fmt.Errorf("the environment does not have any containers")
+			}
+
+			var exporter *statsExporter
+			if export != "" {
+				exporter, err = newStatsExporter(export)
+				if err != nil {
+					return err
+				}
+				defer exporter.Close()
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			peaks := map[string]resourceStats{}
+
+			for i := 0; samples == 0 || i < samples; i++ {
+				if i > 0 {
+					select {
+					case <-ctx.Done():
+						return writePeakStats(cmd.OutOrStdout(), peaks, i)
+					case <-ticker.C:
+					}
+				}
+
+				stats := sampleStats(dt, resources, time.Now(), l)
+				updatePeakStats(peaks, stats)
+
+				if exporter != nil {
+					err := exporter.Write(stats)
+					if err != nil {
+						return err
+					}
+				}
+
+				err := writeStats(cmd.OutOrStdout(), stats)
+				if err != nil {
+					return err
+				}
+			}
+
+			return writePeakStats(cmd.OutOrStdout(), peaks, samples)
+		},
+	}
+
+	statsCmd.Flags().DurationVarP(&interval, "interval", "", 5*time.Second, "Time between samples")
+	statsCmd.Flags().IntVarP(&samples, "samples", "", 1, "Number of samples to take, when set to 0 samples are taken until ctrl-c is pressed")
+	statsCmd.Flags().StringVarP(&export, "export", "", "", "Path of a .csv or .json file to write the samples to")
+
+	return statsCmd
+}
+
+// statsResources returns the resources in the state that have containers
+// sorted by id
+func statsResources(cfg *hclconfig.Config) []statsResource {
+	resources := []statsResource{}
+
+	for _, r := range cfg.Resources {
+		if r.GetDisabled() {
+			continue
+		}
+
+		names := containerNames(r)
+		if len(names) == 0 {
+			continue
+		}
+
+		resources = append(resources, statsResource{ID: r.Metadata().ID, Type: r.Metadata().Type, Containers: names})
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].ID < resources[j].ID
+	})
+
+	return resources
+}
+
+// sampleStats reads the usage of every container at the same time, the
+// engine takes around a second to sample each container. Containers that
+// are not running are not included.
+func sampleStats(dt container.ContainerTasks, resources []statsResource, now time.Time, l logger.Logger) []resourceStats {
+	stats := make([]resourceStats, len(resources))
+	mutex := sync.Mutex{}
+	wg := sync.WaitGroup{}
+
+	for i, r := range resources {
+		stats[i] = resourceStats{Time: now, ID: r.ID, Type: r.Type}
+
+		for _, n := range r.Containers {
+			wg.Add(1)
+
+			go func(i int, name string) {
+				defer wg.Done()
+
+				cs, err := dt.ContainerStats(name)
+				if err != nil {
+					l.Debug("Unable to read container stats", "container", name, "error", err)
+					return
+				}
+
+				mutex.Lock()
+				defer mutex.Unlock()
+
+				s := &stats[i]
+				s.Containers++
+				s.CPUPercent += cs.CPUPercent
+				s.MemoryUsage += cs.MemoryUsage
+				s.MemoryLimit += cs.MemoryLimit
+				s.NetworkRx += cs.NetworkRx
+				s.NetworkTx += cs.NetworkTx
+				s.BlockRead += cs.BlockRead
+				s.BlockWrite += cs.BlockWrite
+				s.DiskUsage += cs.DiskUsage
+			}(i, n)
+		}
+	}
+
+	wg.Wait()
+
+	return stats
+}
+
+// updatePeakStats sets the highest CPU and memory usage seen for each
+// resource
+func updatePeakStats(peaks map[string]resourceStats, stats []resourceStats) {
+	for _, s := range stats {
+		p, ok := peaks[s.ID]
+		if !ok {
+			peaks[s.ID] = s
+			continue
+		}
+
+		p.CPUPercent = max(p.CPUPercent, s.CPUPercent)
+		p.MemoryUsage = max(p.MemoryUsage, s.MemoryUsage)
+		peaks[s.ID] = p
+	}
+}
+
+func writeStats(w io.Writer, stats []resourceStats) error {
+	if structuredOutput() {
+		return writeStructured(w, cliFormat, statsReport{Time: stats[0].Time, Resources: stats})
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tCPU %\tMEMORY\tNET RX / TX\tBLOCK READ / WRITE\tDISK")
+
+	for _, s := range stats {
+		if s.Containers == 0 {
+			fmt.Fprintf(tw, "%s\t-\t-\t-\t-\t-\n", s.ID)
+			continue
+		}
+
+		fmt.Fprintf(tw, "%s\t%.2f%%\t%s / %s\t%s / %s\t%s / %s\t%s\n",
+			s.ID,
+			s.CPUPercent,
+			formatBytes(s.MemoryUsage), formatBytes(s.MemoryLimit),
+			formatBytes(s.NetworkRx), formatBytes(s.NetworkTx),
+			formatBytes(s.BlockRead), formatBytes(s.BlockWrite),
+			formatBytes(uint64(s.DiskUsage)),
+		)
+	}
+
+	fmt.Fprintln(tw)
+
+	return tw.Flush()
+}
+
+// writePeakStats writes the highest CPU and memory usage for each resource,
+// nothing is written for a single sample or structured output
+func writePeakStats(w io.Writer, peaks map[string]resourceStats, samples int) error {
+	if samples == 1 || len(peaks) == 0 || structuredOutput() {
+		return nil
+	}
+
+	ids := []string{}
+	for id := range peaks {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	fmt.Fprintf(w, "Peak usage over %d samples\n\n", samples)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tCPU %\tMEMORY")
+
+	for _, id := range ids {
+		p := peaks[id]
+		fmt.Fprintf(tw, "%s\t%.2f%%\t%s\n", id, p.CPUPercent, formatBytes(p.MemoryUsage))
+	}
+
+	return tw.Flush()
+}
+
+// formatBytes returns the size in the largest binary unit e.g. 1.5GiB
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+
+	div, exp := uint64(unit), 0
+	for n := b / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f%ciB", float64(b)/float64(div), "KMGTPE"[exp])
+}
+
+// statsExporter writes samples to a CSV file or a JSON file with an object
+// per line
+type statsExporter struct {
+	file *os.File
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+var statsCSVHeader = []string{
+	"time",
+	"id",
+	"type",
+	"containers",
+	"cpu_percent",
+	"memory_bytes",
+	"memory_limit_bytes",
+	"network_rx_bytes",
+	"network_tx_bytes",
+	"block_read_bytes",
+	"block_write_bytes",
+	"disk_bytes",
+}
+
+func newStatsExporter(path string) (*statsExporter, error) {
+	ext := filepath.Ext(path)
+	if ext != statsExportCSV && ext != statsExportJSON {
+		return nil, fmt.Errorf("unsupported export file %s, the file must have a %s or %s extension", path, statsExportCSV, statsExportJSON)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create export file %s: %s", path, err)
+	}
+
+	e := &statsExporter{file: f}
+
+	if ext == statsExportJSON {
+		e.json = json.NewEncoder(f)
+		return e, nil
+	}
+
+	e.csv = csv.NewWriter(f)
+	err = e.csv.Write(statsCSVHeader)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("unable to write export file %s: %s", path, err)
+	}
+
+	return e, nil
+}
+
+// Write appends the sample to the file, the file is flushed after every
+// sample so that it can be read while sampling
+func (e *statsExporter) Write(stats []resourceStats) error {
+	for _, s := range stats {
+		if e.json != nil {
+			err := e.json.Encode(s)
+			if err != nil {
+				return fmt.Errorf("unable to write export file: %s", err)
+			}
+
+			continue
+		}
+
+		err := e.csv.Write([]string{
+			s.Time.Format(time.RFC3339),
+			s.ID,
+			s.Type,
+			strconv.Itoa(s.Containers),
+			strconv.FormatFloat(s.CPUPercent, 'f', 2, 64),
+			strconv.FormatUint(s.MemoryUsage, 10),
+			strconv.FormatUint(s.MemoryLimit, 10),
+			strconv.FormatUint(s.NetworkRx, 10),
+			strconv.FormatUint(s.NetworkTx, 10),
+			strconv.FormatUint(s.BlockRead, 10),
+			strconv.FormatUint(s.BlockWrite, 10),
+			strconv.FormatInt(s.DiskUsage, 10),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to write export file: %s", err)
+		}
+	}
+
+	if e.csv != nil {
+		e.csv.Flush()
+		return e.csv.Error()
+	}
+
+	return nil
+}
+
+// Close the export file
+func (e *statsExporter) Close() error {
+	return e.file.Close()
+}
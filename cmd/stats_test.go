@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	cmock "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	dtypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/stretchr/testify/require"
+)
+
+var statsTime = time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+
+func setupStatsConfig() *hclconfig.Config {
+	c := hclconfig.NewConfig()
+
+	ct := &container.Container{ContainerName: "consul.container.jmpd.in"}
+	ct.Meta = hcltypes.Meta{ID: "resource.container.consul", Name: "consul", Type: container.TypeContainer, Properties: map[string]any{}}
+	c.AppendResource(ct)
+
+	nc := &nomad.NomadCluster{ServerContainerName: "server.dev.nomad-cluster.jmpd.in", ClientContainerName: []string{"1.client.dev.nomad-cluster.jmpd.in"}}
+	nc.Meta = hcltypes.Meta{ID: "resource.nomad_cluster.dev", Name: "dev", Type: nomad.TypeNomadCluster, Properties: map[string]any{}}
+	c.AppendResource(nc)
+
+	n := &network.Network{}
+	n.Meta = hcltypes.Meta{ID: "resource.network.main", Name: "main", Type: network.TypeNetwork, Properties: map[string]any{}}
+	c.AppendResource(n)
+
+	return c
+}
+
+func setupStatsTasks() *cmock.ContainerTasks {
+	mt := &cmock.ContainerTasks{}
+	mt.On("ContainerStats", "consul.container.jmpd.in").Return(&dtypes.ContainerStats{CPUPercent: 12.5, MemoryUsage: 64 * 1024 * 1024, MemoryLimit: 1024 * 1024 * 1024, NetworkRx: 2048, NetworkTx: 1024, DiskUsage: 4096}, nil)
+	mt.On("ContainerStats", "server.dev.nomad-cluster.jmpd.in").Return(&dtypes.ContainerStats{CPUPercent: 50, MemoryUsage: 256 * 1024 * 1024, BlockRead: 100, BlockWrite: 200}, nil)
+	mt.On("ContainerStats", "1.client.dev.nomad-cluster.jmpd.in").Return(&dtypes.ContainerStats{CPUPercent: 25, MemoryUsage: 512 * 1024 * 1024, BlockRead: 100, BlockWrite: 200}, nil)
+
+	return mt
+}
+
+func TestStatsResourcesReturnsResourcesWithContainers(t *testing.T) {
+	resources := statsResources(setupStatsConfig())
+
+	require.Equal(t, []statsResource{
+		{ID: "resource.container.consul", Type: container.TypeContainer, Containers: []string{"consul.container.jmpd.in"}},
+		{ID: "resource.nomad_cluster.dev", Type: nomad.TypeNomadCluster, Containers: []string{"server.dev.nomad-cluster.jmpd.in", "1.client.dev.nomad-cluster.jmpd.in"}},
+	}, resources)
+}
+
+func TestSampleStatsAddsUsageOfContainers(t *testing.T) {
+	stats := sampleStats(setupStatsTasks(), statsResources(setupStatsConfig()), statsTime, logger.NewTestLogger(t))
+
+	require.Len(t, stats, 2)
+	require.Equal(t, 1, stats[0].Containers)
+	require.Equal(t, 12.5, stats[0].CPUPercent)
+
+	require.Equal(t, "resource.nomad_cluster.dev", stats[1].ID)
+	require.Equal(t, statsTime, stats[1].Time)
+	require.Equal(t, 2, stats[1].Containers)
+	require.Equal(t, 75.0, stats[1].CPUPercent)
+	require.Equal(t, uint64(768*1024*1024), stats[1].MemoryUsage)
+	require.Equal(t, uint64(200), stats[1].BlockRead)
+	require.Equal(t, uint64(400), stats[1].BlockWrite)
+}
+
+func TestSampleStatsIgnoresStoppedContainers(t *testing.T) {
+	mt := &cmock.ContainerTasks{}
+	mt.On("ContainerStats", "consul.container.jmpd.in").Return(nil, fmt.Errorf("container is not running"))
+
+	resources := []statsResource{{ID: "resource.container.consul", Type: container.TypeContainer, Containers: []string{"consul.container.jmpd.in"}}}
+
+	stats := sampleStats(mt, resources, statsTime, logger.NewTestLogger(t))
+
+	require.Len(t, stats, 1)
+	require.Equal(t, 0, stats[0].Containers)
+}
+
+func TestUpdatePeakStatsKeepsHighestUsage(t *testing.T) {
+	peaks := map[string]resourceStats{}
+
+	updatePeakStats(peaks, []resourceStats{{ID: "resource.container.consul", CPUPercent: 80, MemoryUsage: 100}})
+	updatePeakStats(peaks, []resourceStats{{ID: "resource.container.consul", CPUPercent: 20, MemoryUsage: 300}})
+
+	require.Equal(t, 80.0, peaks["resource.container.consul"].CPUPercent)
+	require.Equal(t, uint64(300), peaks["resource.container.consul"].MemoryUsage)
+}
+
+func TestWriteStatsWritesTable(t *testing.T) {
+	stats := sampleStats(setupStatsTasks(), statsResources(setupStatsConfig()), statsTime, logger.NewTestLogger(t))
+	out := bytes.NewBufferString("")
+
+	err := writeStats(out, stats)
+	require.NoError(t, err)
+
+	require.Contains(t, out.String(), "RESOURCE")
+	require.Regexp(t, `resource.container.consul\s+12.50%\s+64.0MiB / 1.0GiB\s+2.0KiB / 1.0KiB`, out.String())
+}
+
+func TestFormatBytesReturnsBinaryUnits(t *testing.T) {
+	require.Equal(t, "512B", formatBytes(512))
+	require.Equal(t, "1.5KiB", formatBytes(1536))
+	require.Equal(t, "2.0GiB", formatBytes(2*1024*1024*1024))
+}
+
+func TestStatsExporterWritesCSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.csv")
+
+	e, err := newStatsExporter(path)
+	require.NoError(t, err)
+
+	err = e.Write([]resourceStats{{Time: statsTime, ID: "resource.container.consul", Type: container.TypeContainer, Containers: 1, CPUPercent: 12.5, MemoryUsage: 1024}})
+	require.NoError(t, err)
+	e.Close()
+
+	d, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(d)), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, strings.Join(statsCSVHeader, ","), lines[0])
+	require.Equal(t, "2026-10-16T09:30:00Z,resource.container.consul,container,1,12.50,1024,0,0,0,0,0,0", lines[1])
+}
+
+func TestStatsExporterWritesJSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "usage.json")
+
+	e, err := newStatsExporter(path)
+	require.NoError(t, err)
+
+	err = e.Write([]resourceStats{{Time: statsTime, ID: "resource.container.consul"}, {Time: statsTime, ID: "resource.container.vault"}})
+	require.NoError(t, err)
+	e.Close()
+
+	d, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(d)), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[1], `"id":"resource.container.vault"`)
+}
+
+func TestStatsExporterWithUnsupportedExtensionReturnsError(t *testing.T) {
+	_, err := newStatsExporter(filepath.Join(t.TempDir(), "usage.xml"))
+	require.ErrorContains(t, err, "unsupported export file")
+}
@@ -1,15 +1,23 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/hokaccha/go-prettyjson"
 	"github.com/jumppad-labs/hclconfig/resources"
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/cmd/view"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/command"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
 	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
@@ -36,6 +44,9 @@ const (
 
 var jsonFlag bool
 var resourceType string
+var tuiFlag bool
+var statusFilters []string
+var orphansFlag bool
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
@@ -43,6 +54,32 @@ var statusCmd = &cobra.Command{
 	Long:  `Show the status of the current resources`,
 	Args:  cobra.NoArgs,
 	Run: func(cmd *cobra.Command, args []string) {
+		if tuiFlag {
+			err := showDashboard()
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			return
+		}
+
+		if orphansFlag {
+			err := showOrphans(cmd)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+
+			return
+		}
+
+		selectors, err := parseStatusFilters(statusFilters)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
 		// load the resources from state
 
 		cfg, err := config.LoadState()
@@ -52,6 +89,16 @@ var statusCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
+		if structuredOutput() {
+			err := writeStructured(cmd.OutOrStdout(), cliFormat, newStatusReport(cfg, resourceType, selectors))
+			if err != nil {
+				fmt.Println("Unable to output status", err)
+				os.Exit(1)
+			}
+
+			return
+		}
+
 		if jsonFlag {
 			s, err := prettyjson.Marshal(cfg)
 			if err != nil {
@@ -85,7 +132,8 @@ var statusCmd = &cobra.Command{
 					if (resourceType != "" && r.Metadata().Type != resourceType) ||
 						r.Metadata().Type == resources.TypeModule ||
 						r.Metadata().Type == resources.TypeVariable ||
-						r.Metadata().Type == resources.TypeOutput {
+						r.Metadata().Type == resources.TypeOutput ||
+						!matchesSelectors(r, selectors) {
 						continue
 					}
 
@@ -126,6 +174,12 @@ var statusCmd = &cobra.Command{
 					case container.TypeSidecar:
 						fmt.Printf("%s %s\n", status, r.Metadata().ID)
 						fmt.Printf("    %s %s\n", grayText.Render("└─"), whiteText.Render(utils.FQDN(r.Metadata().Name, r.Metadata().Module, string(r.Metadata().Type))))
+					case exec.TypeExec:
+						fmt.Printf("%s %s\n", status, r.Metadata().ID)
+
+						if ps := execProcessStatus(r); ps != nil {
+							fmt.Printf("    %s %s\n", grayText.Render("└─"), whiteText.Render(fmt.Sprintf("%s, %d restarts", ps.State, ps.Restarts)))
+						}
 					case cache.TypeImageCache:
 						fmt.Printf("%s %s\n", status, r.Metadata().ID)
 					default:
@@ -155,4 +209,102 @@ var statusCmd = &cobra.Command{
 func init() {
 	statusCmd.Flags().BoolVarP(&jsonFlag, "json", "", false, "Output the status as JSON")
 	statusCmd.Flags().StringVarP(&resourceType, "type", "", "", "Resource type used to filter status list")
+	statusCmd.Flags().BoolVarP(&orphansFlag, "orphans", "", false, "List the containers and networks created by jumppad that are not in the state, use jumppad doctor to adopt or remove them")
+	statusCmd.Flags().StringSliceVarP(&statusFilters, "filter", "", nil, "Only show resources with matching labels, e.g. --filter label=team=platform. Can be specified multiple times, resources must match all filters")
+	statusCmd.Flags().BoolVarP(&tuiFlag, "tui", "", false, "Show an interactive dashboard with the status, health, ports, and logs of the resources")
+}
+
+// parseStatusFilters parses the values of the --filter flag, filters are in
+// the form label=key=value or label=key
+func parseStatusFilters(filters []string) ([]config.LabelSelector, error) {
+	selectors := []config.LabelSelector{}
+
+	for _, f := range filters {
+		kind, selector, ok := strings.Cut(f, "=")
+		if !ok || kind != "label" {
+			return nil, fmt.Errorf("invalid filter %q, filters must be in the form label=key=value or label=key", f)
+		}
+
+		ls, err := config.ParseLabelSelector(selector)
+		if err != nil {
+			return nil, err
+		}
+
+		selectors = append(selectors, ls)
+	}
+
+	return selectors, nil
+}
+
+// matchesSelectors returns true when the resource matches all the selectors
+func matchesSelectors(r types.Resource, selectors []config.LabelSelector) bool {
+	for _, s := range selectors {
+		if !s.Matches(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// execProcessStatus returns the status of the process for a supervised exec,
+// nil is returned for other resources or when the status can not be read
+func execProcessStatus(r types.Resource) *command.SupervisorStatus {
+	e, ok := r.(*exec.Exec)
+	if !ok || e.Restart == nil || !e.Daemon {
+		return nil
+	}
+
+	s, err := command.ReadSupervisorStatus(exec.SupervisorStatusPath(e))
+	if err != nil {
+		return nil
+	}
+
+	return s
+}
+
+// showDashboard displays the interactive status dashboard
+func showDashboard() error {
+	// log output would be drawn over the dashboard, discard it
+	l := logger.NewLogger(io.Discard, logger.LogLevelInfo)
+
+	c, err := clients.GenerateClients(l)
+	if err != nil {
+		return fmt.Errorf("unable to create clients: %s", err)
+	}
+
+	s := newDashboardSource(c.Docker, c.ContainerTasks, config.NewProviders(c))
+
+	return view.NewDashboard(s).Display()
+}
+
+// showOrphans lists the containers and networks created by jumppad that are
+// not in the state
+func showOrphans(cmd *cobra.Command) error {
+	l := logger.NewLogger(io.Discard, logger.LogLevelInfo)
+
+	c, err := clients.GenerateClients(l)
+	if err != nil {
+		return fmt.Errorf("unable to create clients: %s", err)
+	}
+
+	orphans, err := findOrphans(context.Background(), c.Docker)
+	if err != nil {
+		return err
+	}
+
+	if structuredOutput() {
+		return writeStructured(cmd.OutOrStdout(), cliFormat, orphanReport{Orphans: orphans})
+	}
+
+	if len(orphans) == 0 {
+		cmd.Println("No orphaned containers or networks found")
+		return nil
+	}
+
+	printOrphans(cmd, orphans)
+	cmd.Println("")
+	cmd.Println("Run 'jumppad doctor' to adopt or remove them")
+
+	return nil
 }
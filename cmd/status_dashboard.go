@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/jumppad-labs/hclconfig/resources"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/cmd/view"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ct "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+)
+
+// dashboardSource provides the resources in the state to the status dashboard
+type dashboardSource struct {
+	docker    container.Docker
+	tasks     container.ContainerTasks
+	providers config.Providers
+}
+
+func newDashboardSource(dc container.Docker, tasks container.ContainerTasks, p config.Providers) *dashboardSource {
+	return &dashboardSource{docker: dc, tasks: tasks, providers: p}
+}
+
+// Resources returns the resources in the state
+func (d *dashboardSource) Resources() ([]view.DashboardResource, error) {
+	cfg, err := config.LoadState()
+	if err != nil {
+		return nil, err
+	}
+
+	out := []view.DashboardResource{}
+
+	for _, r := range cfg.Resources {
+		if r.Metadata().Type == resources.TypeModule ||
+			r.Metadata().Type == resources.TypeVariable ||
+			r.Metadata().Type == resources.TypeOutput {
+			continue
+		}
+
+		dr := view.DashboardResource{
+			ID:     resources.FQRNFromResource(r).String(),
+			Status: "pending",
+			Health: "-",
+			Ports:  resourcePorts(r),
+		}
+
+		if s, ok := r.Metadata().Properties[constants.PropertyStatus].(string); ok {
+			dr.Status = s
+		}
+
+		if r.GetDisabled() {
+			dr.Status = constants.StatusDisabled
+		}
+
+		if names := getFQDNForResource(r); len(names) > 0 && !r.GetDisabled() {
+			dr.Container = names[0]
+			dr.Health = d.health(dr.Container)
+		}
+
+		out = append(out, dr)
+	}
+
+	return out, nil
+}
+
+// Logs returns the last log lines for the resources container
+func (d *dashboardSource) Logs(r view.DashboardResource, lines int) ([]string, error) {
+	rc, err := d.docker.ContainerLogs(
+		context.Background(),
+		r.Container,
+		dcontainer.LogsOptions{
+			ShowStdout: true,
+			ShowStderr: true,
+			Tail:       fmt.Sprintf("%d", lines),
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	// the log stream is multiplexed, stdout and stderr are shown together
+	buf := bytes.NewBuffer(nil)
+	_, err = stdcopy.StdCopy(buf, buf, rc)
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"), nil
+}
+
+// Shell returns a command that opens a shell in the resources container
+func (d *dashboardSource) Shell(r view.DashboardResource) (tea.ExecCommand, error) {
+	ids, err := d.tasks.FindContainerIDs(r.Container)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("unable to find container %s", r.Container)
+	}
+
+	return &shellCommand{tasks: d.tasks, id: ids[0]}, nil
+}
+
+// Destroy destroys the resource and removes it from the state, the resource
+// is created again on the next up
+func (d *dashboardSource) Destroy(r view.DashboardResource) error {
+	cfg, err := config.LoadState()
+	if err != nil {
+		return err
+	}
+
+	res, err := cfg.FindResource(r.ID)
+	if err != nil {
+		return err
+	}
+
+	p := d.providers.GetProvider(res)
+	if p == nil {
+		return fmt.Errorf("unable to create provider for resource %s", r.ID)
+	}
+
+	err = p.Destroy(context.Background(), false)
+	if err != nil {
+		return err
+	}
+
+	err = cfg.RemoveResource(res)
+	if err != nil {
+		return err
+	}
+
+	return config.SaveState(cfg)
+}
+
+// health returns the health check status of the container, or the container
+// state when the container does not define a health check
+func (d *dashboardSource) health(name string) string {
+	info, err := d.docker.ContainerInspect(context.Background(), name)
+	if err != nil || info.ContainerJSONBase == nil || info.State == nil {
+		return "unknown"
+	}
+
+	if info.State.Health != nil {
+		return info.State.Health.Status
+	}
+
+	return info.State.Status
+}
+
+// resourcePorts returns the ports exposed on the local machine by the resource
+func resourcePorts(r hcltypes.Resource) []string {
+	ports := []string{}
+
+	switch v := r.(type) {
+	case *ct.Container:
+		for _, p := range v.Ports {
+			if p.Host != "" {
+				ports = append(ports, fmt.Sprintf("%s:%s", p.Host, p.Local))
+			}
+		}
+
+		for _, pr := range v.PortRanges {
+			if pr.EnableHost {
+				ports = append(ports, pr.Range)
+			}
+		}
+	case *k8s.Cluster:
+		ports = append(ports, fmt.Sprintf("%d", v.APIPort))
+	case *nomad.NomadCluster:
+		ports = append(ports, fmt.Sprintf("%d", v.APIPort))
+	case *ingress.Ingress:
+		if len(v.Ports) > 0 {
+			ports = append(ports, v.Ports...)
+		} else {
+			ports = append(ports, fmt.Sprintf("%d", v.Port))
+		}
+	}
+
+	return ports
+}
+
+// shellCommand opens an interactive shell in a container, it implements
+// tea.ExecCommand so that the dashboard can hand over the terminal
+type shellCommand struct {
+	tasks  container.ContainerTasks
+	id     string
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func (s *shellCommand) Run() error {
+	// keep the underlying file so that the terminal can be set to raw mode
+	in, ok := s.stdin.(io.ReadCloser)
+	if !ok {
+		in = io.NopCloser(s.stdin)
+	}
+
+	return s.tasks.CreateShell(s.id, []string{"sh"}, in, s.stdout, s.stderr)
+}
+
+func (s *shellCommand) SetStdin(r io.Reader) {
+	s.stdin = r
+}
+
+func (s *shellCommand) SetStdout(w io.Writer) {
+	s.stdout = w
+}
+
+func (s *shellCommand) SetStderr(w io.Writer) {
+	s.stderr = w
+}
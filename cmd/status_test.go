@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatusFiltersReturnsSelectors(t *testing.T) {
+	s, err := parseStatusFilters([]string{"label=team=platform", "label=env"})
+	require.NoError(t, err)
+
+	require.Equal(t, []config.LabelSelector{
+		{Key: "team", Value: "platform"},
+		{Key: "env", Any: true},
+	}, s)
+}
+
+func TestParseStatusFiltersWithUnknownFilterReturnsError(t *testing.T) {
+	_, err := parseStatusFilters([]string{"type=container"})
+	require.ErrorContains(t, err, `invalid filter "type=container"`)
+}
+
+func TestParseStatusFiltersWithInvalidSelectorReturnsError(t *testing.T) {
+	_, err := parseStatusFilters([]string{"label="})
+	require.ErrorContains(t, err, "invalid label selector")
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/command"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/spf13/cobra"
+)
+
+// newSuperviseCmd creates the command used by daemonized exec resources to
+// run their script, it is not intended to be run by users
+func newSuperviseCmd(l logger.Logger) *cobra.Command {
+	return &cobra.Command{
+		Use:    "supervise [config]",
+		Short:  "Run a process and restart it according to its restart policy",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := command.ReadSupervisorConfig(args[0])
+			if err != nil {
+				return err
+			}
+
+			// the process is stopped when the supervisor is stopped
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			return command.NewSupervisor(*c, l).Run(ctx)
+		},
+	}
+}
@@ -2,48 +2,129 @@ package cmd
 
 import (
 	"fmt"
-	"os"
+	"strings"
 
+	"github.com/jumppad-labs/hclconfig"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
-	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
-var taintCmd = &cobra.Command{
-	Use:   "taint [resource]",
-	Short: "Taint a resource e.g. 'jumppad taint container.test'",
-	Long: `Taint a resource and mark is to be re-created on the next Apply
-	Example use to remove a container named test
-	jumppad taint resource.container.test
+func newTaintCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "taint [resource]...",
+		Short: "Mark resources to be re-created on the next up",
+		Long: `Mark resources to be re-created on the next up.
+
+Tainted resources are destroyed and created again the next time jumppad up is
+run, even when jumppad does not detect any changes. Use taint when a resource
+is broken but its configuration has not changed.`,
+		Example: `
+  # Re-create the container named test on the next up
+  jumppad taint resource.container.test
+
+  # The resource prefix is optional
+  jumppad taint container.test helm.vault
 	`,
-	Args: cobra.ArbitraryArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		if len(args) != 1 {
-			fmt.Println("The resource to taint must be specified as an argument")
-			os.Exit(1)
-		}
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadState()
+			if err != nil {
+				return fmt.Errorf("unable to load state, have you run jumppad up: %s", err)
+			}
+
+			ids, err := setTainted(cfg, args, true)
+			if err != nil {
+				return err
+			}
+
+			err = config.SaveState(cfg)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range ids {
+				cmd.Printf("Tainted %s, it will be re-created on the next up\n", id)
+			}
+
+			return nil
+		},
+	}
+}
+
+func newUntaintCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "untaint [resource]...",
+		Short: "Remove the taint from resources",
+		Long:  "Remove the taint from resources marked with the taint command so that they are not re-created on the next up",
+		Example: `
+  jumppad untaint resource.container.test
+	`,
+		Args: cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := config.LoadState()
+			if err != nil {
+				return fmt.Errorf("unable to load state, have you run jumppad up: %s", err)
+			}
 
-		cfg, err := config.LoadState()
-		if err != nil {
-			fmt.Println("Unable to load statefile, do you have a running blueprint?")
-			os.Exit(1)
+			ids, err := setTainted(cfg, args, false)
+			if err != nil {
+				return err
+			}
+
+			err = config.SaveState(cfg)
+			if err != nil {
+				return err
+			}
+
+			for _, id := range ids {
+				cmd.Printf("Removed taint from %s\n", id)
+			}
+
+			return nil
+		},
+	}
+}
+
+// setTainted taints or untaints the resources in the state, all resources are
+// checked before any are changed so that the state is not partially updated
+func setTainted(cfg *hclconfig.Config, args []string, taint bool) ([]string, error) {
+	ids := []string{}
+
+	for _, a := range args {
+		id := a
+		if !strings.HasPrefix(id, "resource.") && !strings.HasPrefix(id, "module.") {
+			id = "resource." + id
 		}
 
-		r, err := cfg.FindResource(args[0])
+		r, err := cfg.FindResource(id)
 		if err != nil || r == nil {
-			fmt.Println("Unable to locate resource in the state", args[0])
-			os.Exit(1)
+			return nil, fmt.Errorf("unable to find resource %s in the state", a)
 		}
 
-		r.Metadata().Properties[constants.PropertyStatus] = constants.StatusTainted
+		status, _ := r.Metadata().Properties[constants.PropertyStatus].(string)
 
-		d, err := cfg.ToJSON()
-		if err != nil {
-			fmt.Println("Unable to save state", err)
-			os.Exit(1)
+		switch {
+		case r.GetDisabled():
+			return nil, fmt.Errorf("%s is disabled", id)
+		case taint && status != constants.StatusCreated && status != constants.StatusTainted:
+			return nil, fmt.Errorf("%s has not been created and can not be tainted", id)
+		case !taint && status != constants.StatusTainted:
+			return nil, fmt.Errorf("%s is not tainted", id)
 		}
 
-		os.WriteFile(utils.StatePath(), d, os.ModePerm)
-	},
+		ids = append(ids, id)
+	}
+
+	status := constants.StatusCreated
+	if taint {
+		status = constants.StatusTainted
+	}
+
+	for _, id := range ids {
+		r, _ := cfg.FindResource(id)
+		r.Metadata().Properties[constants.PropertyStatus] = status
+	}
+
+	return ids, nil
 }
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	hcltypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTaintConfig(status string) *hclconfig.Config {
+	c := hclconfig.NewConfig()
+
+	ct := &container.Container{}
+	ct.Meta = hcltypes.Meta{
+		ID:         "resource.container.consul",
+		Name:       "consul",
+		Type:       container.TypeContainer,
+		Properties: map[string]any{constants.PropertyStatus: status},
+	}
+	c.AppendResource(ct)
+
+	return c
+}
+
+func taintStatus(t *testing.T, c *hclconfig.Config) string {
+	r, err := c.FindResource("resource.container.consul")
+	require.NoError(t, err)
+
+	return r.Metadata().Properties[constants.PropertyStatus].(string)
+}
+
+func TestTaintSetsStatusTainted(t *testing.T) {
+	c := setupTaintConfig(constants.StatusCreated)
+
+	ids, err := setTainted(c, []string{"container.consul"}, true)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"resource.container.consul"}, ids)
+	require.Equal(t, constants.StatusTainted, taintStatus(t, c))
+}
+
+func TestTaintMissingResourceReturnsError(t *testing.T) {
+	c := setupTaintConfig(constants.StatusCreated)
+
+	_, err := setTainted(c, []string{"resource.container.vault"}, true)
+	require.Error(t, err)
+}
+
+func TestTaintUncreatedResourceReturnsError(t *testing.T) {
+	c := setupTaintConfig(constants.StatusFailed)
+
+	_, err := setTainted(c, []string{"resource.container.consul"}, true)
+	require.Error(t, err)
+	require.Equal(t, constants.StatusFailed, taintStatus(t, c))
+}
+
+func TestUntaintSetsStatusCreated(t *testing.T) {
+	c := setupTaintConfig(constants.StatusTainted)
+
+	_, err := setTainted(c, []string{"resource.container.consul"}, false)
+	require.NoError(t, err)
+
+	require.Equal(t, constants.StatusCreated, taintStatus(t, c))
+}
+
+func TestUntaintResourceNotTaintedReturnsError(t *testing.T) {
+	c := setupTaintConfig(constants.StatusCreated)
+
+	_, err := setTainted(c, []string{"resource.container.consul"}, false)
+	require.Error(t, err)
+}
@@ -0,0 +1,16 @@
+description = "A single container application with a network and health check"
+
+variable "name" {
+  description = "Name of the application"
+  default     = "app"
+}
+
+variable "image" {
+  description = "Docker image for the application"
+  default     = "nicholasjackson/fake-service:v0.26.2"
+}
+
+variable "port" {
+  description = "Port the application listens on"
+  default     = "9090"
+}
@@ -0,0 +1,16 @@
+description = "A documentation workshop with a chapter and a task"
+
+variable "title" {
+  description = "Title of the workshop"
+  default     = "My Workshop"
+}
+
+variable "name" {
+  description = "Name of the workshop used for resources"
+  default     = "workshop"
+}
+
+variable "port" {
+  description = "Port the documentation is served on"
+  default     = "80"
+}
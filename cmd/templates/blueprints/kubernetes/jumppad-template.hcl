@@ -0,0 +1,21 @@
+description = "Microservices deployed to a Kubernetes cluster with an ingress"
+
+variable "name" {
+  description = "Name of the frontend service"
+  default     = "web"
+}
+
+variable "backend" {
+  description = "Name of the backend service called by the frontend"
+  default     = "api"
+}
+
+variable "image" {
+  description = "Docker image for the services"
+  default     = "nicholasjackson/fake-service:v0.26.2"
+}
+
+variable "port" {
+  description = "Local port for the frontend ingress"
+  default     = "19090"
+}
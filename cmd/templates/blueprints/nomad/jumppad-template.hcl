@@ -0,0 +1,21 @@
+description = "A Nomad cluster running a job with an ingress"
+
+variable "name" {
+  description = "Name of the Nomad job"
+  default     = "app"
+}
+
+variable "image" {
+  description = "Docker image for the job"
+  default     = "nicholasjackson/fake-service:v0.26.2"
+}
+
+variable "client_nodes" {
+  description = "Number of Nomad client nodes"
+  default     = "1"
+}
+
+variable "port" {
+  description = "Local port for the job ingress"
+  default     = "19090"
+}
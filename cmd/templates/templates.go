@@ -0,0 +1,278 @@
+// Package templates contains the blueprint templates used by jumppad init
+// to scaffold new blueprints
+package templates
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// ManifestFile is the file in the root of a template that describes the
+// template and its variables, it is not copied to the generated blueprint
+const ManifestFile = "jumppad-template.hcl"
+
+// templateExtension is the extension of files that are rendered, the
+// extension is removed from the generated file
+const templateExtension = ".tmpl"
+
+//go:embed all:blueprints
+var builtin embed.FS
+
+// Template is a blueprint that can be generated with jumppad init
+type Template struct {
+	Name        string
+	Description string
+	Variables   []Variable
+
+	files fs.FS
+}
+
+// Variable is a value used when rendering the template files
+type Variable struct {
+	Name        string
+	Description string
+	Default     string
+	// Required is true when the variable has no default
+	Required bool
+}
+
+type manifest struct {
+	Description string             `hcl:"description,optional"`
+	Variables   []manifestVariable `hcl:"variable,block"`
+}
+
+type manifestVariable struct {
+	Name        string  `hcl:"name,label"`
+	Description string  `hcl:"description,optional"`
+	Default     *string `hcl:"default,optional"`
+}
+
+// Builtin returns the templates that are included with jumppad sorted by
+// name
+func Builtin() ([]*Template, error) {
+	entries, err := fs.ReadDir(builtin, "blueprints")
+	if err != nil {
+		return nil, err
+	}
+
+	ts := []*Template{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		sub, err := fs.Sub(builtin, "blueprints/"+e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := Load(e.Name(), sub)
+		if err != nil {
+			return nil, err
+		}
+
+		ts = append(ts, t)
+	}
+
+	sort.Slice(ts, func(i, j int) bool { return ts[i].Name < ts[j].Name })
+
+	return ts, nil
+}
+
+// Find returns the builtin template with the given name
+func Find(name string) (*Template, bool, error) {
+	ts, err := Builtin()
+	if err != nil {
+		return nil, false, err
+	}
+
+	for _, t := range ts {
+		if t.Name == name {
+			return t, true, nil
+		}
+	}
+
+	return nil, false, nil
+}
+
+// Load reads the template manifest from files, templates without a manifest
+// have no variables and the files are copied as they are
+func Load(name string, files fs.FS) (*Template, error) {
+	t := &Template{Name: name, files: files}
+
+	d, err := fs.ReadFile(files, ManifestFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return t, nil
+		}
+
+		return nil, fmt.Errorf("unable to read %s for template %s: %s", ManifestFile, name, err)
+	}
+
+	f, diags := hclparse.NewParser().ParseHCL(d, ManifestFile)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("unable to parse %s for template %s: %s", ManifestFile, name, diags.Error())
+	}
+
+	m := manifest{}
+	diags = gohcl.DecodeBody(f.Body, nil, &m)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("unable to parse %s for template %s: %s", ManifestFile, name, diags.Error())
+	}
+
+	t.Description = m.Description
+
+	for _, v := range m.Variables {
+		tv := Variable{Name: v.Name, Description: v.Description}
+		if v.Default == nil {
+			tv.Required = true
+		} else {
+			tv.Default = *v.Default
+		}
+
+		t.Variables = append(t.Variables, tv)
+	}
+
+	return t, nil
+}
+
+// Values returns the values used to render the template, variables that are
+// not set in values use their default. An error is returned when a required
+// variable is not set
+func (t *Template) Values(values map[string]string) (map[string]string, error) {
+	vals := map[string]string{}
+	for _, v := range t.Variables {
+		val, ok := values[v.Name]
+		if !ok {
+			if v.Required {
+				return nil, fmt.Errorf("variable %s is required by template %s", v.Name, t.Name)
+			}
+
+			val = v.Default
+		}
+
+		vals[v.Name] = val
+	}
+
+	for k, v := range values {
+		if _, ok := vals[k]; !ok {
+			return nil, fmt.Errorf("variable %s is not defined by template %s", k, t.Name)
+		}
+
+		vals[k] = v
+	}
+
+	return vals, nil
+}
+
+// Render writes the template files to the directory dst and returns the
+// paths of the created files. Files with the .tmpl extension are rendered
+// using the values, the delimiters are [[ ]] so that HCL interpolation in
+// the blueprint is not changed. Existing files are never overwritten, an
+// error is returned before anything is written when a file exists
+func (t *Template) Render(dst string, values map[string]string) ([]string, error) {
+	vals, err := t.Values(values)
+	if err != nil {
+		return nil, err
+	}
+
+	type output struct {
+		path    string
+		mode    fs.FileMode
+		content []byte
+	}
+
+	outputs := []output{}
+
+	err = fs.WalkDir(t.files, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || path == ManifestFile {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		content, err := fs.ReadFile(t.files, path)
+		if err != nil {
+			return err
+		}
+
+		if strings.HasSuffix(path, templateExtension) {
+			content, err = render(path, content, vals)
+			if err != nil {
+				return err
+			}
+
+			path = strings.TrimSuffix(path, templateExtension)
+		}
+
+		outputs = append(outputs, output{
+			path:    filepath.Join(dst, filepath.FromSlash(path)),
+			mode:    info.Mode().Perm() | 0600,
+			content: content,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to render template %s: %s", t.Name, err)
+	}
+
+	for _, o := range outputs {
+		if _, err := os.Stat(o.path); err == nil {
+			return nil, fmt.Errorf("unable to render template %s, file %s already exists", t.Name, o.path)
+		}
+	}
+
+	created := []string{}
+	for _, o := range outputs {
+		err := os.MkdirAll(filepath.Dir(o.path), os.ModePerm)
+		if err != nil {
+			return created, fmt.Errorf("unable to create directory for %s: %s", o.path, err)
+		}
+
+		err = os.WriteFile(o.path, o.content, o.mode)
+		if err != nil {
+			return created, fmt.Errorf("unable to write %s: %s", o.path, err)
+		}
+
+		created = append(created, o.path)
+	}
+
+	return created, nil
+}
+
+func render(path string, content []byte, values map[string]string) ([]byte, error) {
+	tmpl, err := template.New(path).
+		Delims("[[", "]]").
+		Option("missingkey=error").
+		Parse(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %s", path, err)
+	}
+
+	out := bytes.NewBuffer(nil)
+
+	err = tmpl.Execute(out, values)
+	if err != nil {
+		return nil, fmt.Errorf("unable to render %s: %s", path, err)
+	}
+
+	return out.Bytes(), nil
+}
@@ -0,0 +1,179 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testManifest = `
+description = "Test template"
+
+variable "name" {
+  description = "Name of the application"
+  default     = "app"
+}
+
+variable "license" {}
+`
+
+func setupTemplate(t *testing.T) *Template {
+	files := fstest.MapFS{
+		ManifestFile:               {Data: []byte(testManifest)},
+		"main.hcl.tmpl":            {Data: []byte(`resource "container" "[[ .name ]]" { license = "${variable.license}" }`)},
+		"README.md":                {Data: []byte(`# [[ .name ]]`)},
+		"files/config.json.tmpl":   {Data: []byte(`{"license": "[[ .license ]]"}`)},
+		"files/scripts/install.sh": {Data: []byte(`echo install`), Mode: 0755},
+	}
+
+	tmpl, err := Load("test", files)
+	require.NoError(t, err)
+
+	return tmpl
+}
+
+func TestLoadReadsManifest(t *testing.T) {
+	tmpl := setupTemplate(t)
+
+	require.Equal(t, "test", tmpl.Name)
+	require.Equal(t, "Test template", tmpl.Description)
+	require.Equal(t, []Variable{
+		{Name: "name", Description: "Name of the application", Default: "app"},
+		{Name: "license", Required: true},
+	}, tmpl.Variables)
+}
+
+func TestLoadWithoutManifestReturnsTemplate(t *testing.T) {
+	tmpl, err := Load("test", fstest.MapFS{"main.hcl": {Data: []byte(``)}})
+	require.NoError(t, err)
+
+	require.Empty(t, tmpl.Variables)
+}
+
+func TestLoadWithInvalidManifestReturnsError(t *testing.T) {
+	_, err := Load("test", fstest.MapFS{ManifestFile: {Data: []byte(`variable "name" { required = true }`)}})
+	require.ErrorContains(t, err, "unable to parse jumppad-template.hcl for template test")
+}
+
+func TestRenderWritesFiles(t *testing.T) {
+	tmpl := setupTemplate(t)
+	dst := filepath.Join(t.TempDir(), "blueprint")
+
+	created, err := tmpl.Render(dst, map[string]string{"name": "web", "license": "abc"})
+	require.NoError(t, err)
+
+	require.Len(t, created, 4)
+	require.NoFileExists(t, filepath.Join(dst, ManifestFile))
+
+	d, err := os.ReadFile(filepath.Join(dst, "main.hcl"))
+	require.NoError(t, err)
+	require.Equal(t, `resource "container" "web" { license = "${variable.license}" }`, string(d))
+
+	d, err = os.ReadFile(filepath.Join(dst, "files", "config.json"))
+	require.NoError(t, err)
+	require.Equal(t, `{"license": "abc"}`, string(d))
+
+	// files without the template extension are copied as they are
+	d, err = os.ReadFile(filepath.Join(dst, "README.md"))
+	require.NoError(t, err)
+	require.Equal(t, `# [[ .name ]]`, string(d))
+
+	s, err := os.Stat(filepath.Join(dst, "files", "scripts", "install.sh"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0755), s.Mode().Perm())
+}
+
+func TestRenderUsesDefaults(t *testing.T) {
+	tmpl := setupTemplate(t)
+	dst := t.TempDir()
+
+	_, err := tmpl.Render(dst, map[string]string{"license": "abc"})
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(dst, "main.hcl"))
+	require.NoError(t, err)
+	require.Contains(t, string(d), `resource "container" "app"`)
+}
+
+func TestRenderWithMissingRequiredVariableReturnsError(t *testing.T) {
+	tmpl := setupTemplate(t)
+
+	_, err := tmpl.Render(t.TempDir(), map[string]string{})
+	require.ErrorContains(t, err, "variable license is required by template test")
+}
+
+func TestRenderWithUnknownVariableReturnsError(t *testing.T) {
+	tmpl := setupTemplate(t)
+
+	_, err := tmpl.Render(t.TempDir(), map[string]string{"license": "abc", "version": "1.0"})
+	require.ErrorContains(t, err, "variable version is not defined by template test")
+}
+
+func TestRenderWithExistingFileReturnsError(t *testing.T) {
+	tmpl := setupTemplate(t)
+	dst := t.TempDir()
+	os.WriteFile(filepath.Join(dst, "main.hcl"), []byte(`existing`), 0644)
+
+	_, err := tmpl.Render(dst, map[string]string{"license": "abc"})
+	require.ErrorContains(t, err, "already exists")
+
+	// nothing is written when a file exists
+	require.NoFileExists(t, filepath.Join(dst, "README.md"))
+
+	d, err := os.ReadFile(filepath.Join(dst, "main.hcl"))
+	require.NoError(t, err)
+	require.Equal(t, `existing`, string(d))
+}
+
+func TestRenderWithInvalidTemplateReturnsError(t *testing.T) {
+	tmpl, err := Load("test", fstest.MapFS{"main.hcl.tmpl": {Data: []byte(`[[ .name `)}})
+	require.NoError(t, err)
+
+	_, err = tmpl.Render(t.TempDir(), map[string]string{})
+	require.ErrorContains(t, err, "unable to parse main.hcl.tmpl")
+}
+
+func TestBuiltinReturnsTemplates(t *testing.T) {
+	ts, err := Builtin()
+	require.NoError(t, err)
+
+	names := []string{}
+	for _, tmpl := range ts {
+		names = append(names, tmpl.Name)
+		require.NotEmpty(t, tmpl.Description)
+	}
+
+	require.Equal(t, []string{"container", "docs", "kubernetes", "nomad"}, names)
+}
+
+func TestBuiltinTemplatesRenderWithDefaults(t *testing.T) {
+	ts, err := Builtin()
+	require.NoError(t, err)
+
+	for _, tmpl := range ts {
+		created, err := tmpl.Render(filepath.Join(t.TempDir(), tmpl.Name), map[string]string{})
+		require.NoError(t, err, tmpl.Name)
+		require.NotEmpty(t, created)
+
+		for _, f := range created {
+			require.NotContains(t, f, ".tmpl")
+		}
+	}
+}
+
+func TestFindReturnsBuiltinTemplate(t *testing.T) {
+	tmpl, ok, err := Find("nomad")
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	require.Equal(t, "nomad", tmpl.Name)
+}
+
+func TestFindWithUnknownTemplateReturnsFalse(t *testing.T) {
+	_, ok, err := Find("unknown")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
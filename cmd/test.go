@@ -61,6 +61,7 @@ func newTestCmd() *cobra.Command {
 	var variables []string
 	var variablesFile string
 	var tags string
+	var junit string
 
 	var testCmd = &cobra.Command{
 		Use:                   "test [blueprint]",
@@ -68,7 +69,7 @@ func newTestCmd() *cobra.Command {
 		Long:                  `Run functional tests for the blueprint, this command will start the jumppad blueprint `,
 		DisableFlagsInUseLine: true,
 		Args:                  cobra.ArbitraryArgs,
-		RunE:                  newTestCmdFunc(testFolder, &force, &purge, &variables, &variablesFile, &tags, &dontDestroy),
+		RunE:                  newTestCmdFunc(testFolder, &force, &purge, &variables, &variablesFile, &tags, &junit, &dontDestroy),
 	}
 
 	testCmd.Flags().StringVarP(&testFolder, "test-folder", "", "", "Specify the folder containing the functional tests.")
@@ -77,6 +78,7 @@ func newTestCmd() *cobra.Command {
 	testCmd.Flags().StringSliceVarP(&variables, "var", "", nil, "Allows setting variables from the command line, variables are specified as a key and value, e.g --var key=value. Can be specified multiple times")
 	testCmd.Flags().StringVarP(&variablesFile, "vars-file", "", "", "Load variables from a location other than *.vars files in the blueprint folder. E.g --vars-file=./file.vars")
 	testCmd.Flags().StringVarP(&tags, "tags", "", "", "Test tags to run e.g. @wip, @wip,@new, when not set all tests are run")
+	testCmd.Flags().StringVarP(&junit, "junit", "", "", "Write a JUnit XML report of the test results to the given file, e.g. --junit=./report.xml")
 	testCmd.Flags().BoolVarP(&dontDestroy, "dont-destroy", "", false, "When set to true, jumppad does not destroy the blueprint after executing the tests")
 
 	return testCmd
@@ -89,6 +91,7 @@ func newTestCmdFunc(
 	variables *[]string,
 	variablesFile *string,
 	tags *string,
+	junit *string,
 	dontDestroy *bool,
 ) func(cmd *cobra.Command, args []string) error {
 
@@ -102,6 +105,7 @@ func newTestCmdFunc(
 			baseVariables: *variables,
 			variablesFile: *variablesFile,
 			tags:          *tags,
+			junit:         *junit,
 			dontDestroy:   dontDestroy,
 		}
 
@@ -127,6 +131,7 @@ type CucumberRunner struct {
 	variables     []string
 	variablesFile string
 	tags          string
+	junit         string
 	dontDestroy   *bool
 }
 
@@ -156,15 +161,70 @@ func (cr *CucumberRunner) start() {
 	opts.Paths = []string{cr.testPath}
 	opts.Tags = cr.tags
 
+	// the cucumber formatter reports the duration and any error for each
+	// step as JSON
+	var report *bytes.Buffer
+	if structuredOutput() {
+		report = bytes.NewBufferString("")
+		opts.Format = "cucumber"
+		opts.Output = report
+	}
+
+	// godog writes a formatter to a file when the format is suffixed
+	// with the path
+	if cr.junit != "" {
+		opts.Format = withJUnitReport(opts.Format, cr.junit)
+	}
+
 	status := godog.TestSuite{
 		Name:                "Blueprint test",
 		ScenarioInitializer: cr.initializeSuite,
 		Options:             opts,
 	}.Run()
 
+	if report != nil {
+		err := writeTestReport(os.Stdout, cliFormat, report.Bytes())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Unable to write test report", err)
+			os.Exit(1)
+		}
+	}
+
 	os.Exit(status)
 }
 
+// writeTestReport writes the cucumber JSON report in the given format
+func writeTestReport(w io.Writer, format string, report []byte) error {
+	if format == formatJSON {
+		_, err := w.Write(report)
+		return err
+	}
+
+	var r any
+	err := json.Unmarshal(report, &r)
+	if err != nil {
+		return fmt.Errorf("unable to parse test report: %s", err)
+	}
+
+	return writeStructured(w, format, r)
+}
+
+// withJUnitReport adds the junit formatter writing to file to the godog
+// format
+func withJUnitReport(format, file string) string {
+	return format + ",junit:" + file
+}
+
+// diagnostics returns the writer used for diagnostic output, when using
+// structured output stdout only contains the test report
+func (cr *CucumberRunner) diagnostics() io.Writer {
+	if structuredOutput() {
+		return os.Stderr
+	}
+
+	return os.Stdout
+}
+
 func (cr *CucumberRunner) initializeSuite(ctx *godog.ScenarioContext) {
 	sb := &strings.Builder{}
 
@@ -179,7 +239,7 @@ func (cr *CucumberRunner) initializeSuite(ctx *godog.ScenarioContext) {
 		cli, _ := clients.GenerateClients(cl)
 		engine, err := createEngine(cl, cli)
 		if err != nil {
-			fmt.Printf("Unable to setup tests: %s\n", err)
+			fmt.Fprintf(cr.diagnostics(), "Unable to setup tests: %s\n", err)
 			return ctx, err
 		}
 
@@ -189,7 +249,7 @@ func (cr *CucumberRunner) initializeSuite(ctx *godog.ScenarioContext) {
 
 		// do we need to pure the cache
 		if *cr.purge {
-			pc := newPurgeCmdFunc(cr.cli.Docker, cr.cli.ImageLog, cr.cli.Logger)
+			pc := newPurgeCmdFunc(cr.cli.Docker, cr.cli.ImageLog, cr.cli.ImageSets, nil, cr.cli.Logger)
 			pc(cr.cmd, cr.args)
 		}
 
@@ -198,8 +258,8 @@ func (cr *CucumberRunner) initializeSuite(ctx *godog.ScenarioContext) {
 
 	ctx.After(func(ctx context.Context, sc *godog.Scenario, err error) (context.Context, error) {
 		if err != nil {
-			fmt.Println(sb.String())
-			fmt.Println(output.String())
+			fmt.Fprintln(cr.diagnostics(), sb.String())
+			fmt.Fprintln(cr.diagnostics(), output.String())
 			return ctx, err
 		}
 
@@ -214,7 +274,7 @@ func (cr *CucumberRunner) initializeSuite(ctx *godog.ScenarioContext) {
 
 		// only destroy when the dont-destroy flag is false
 		if *cr.dontDestroy {
-			fmt.Println("Not automatically destroying resources, run the command 'jumppad destroy' manually")
+			fmt.Fprintln(cr.diagnostics(), "Not automatically destroying resources, run the command 'jumppad destroy' manually")
 			return ctx, nil
 		}
 
@@ -223,9 +283,14 @@ func (cr *CucumberRunner) initializeSuite(ctx *godog.ScenarioContext) {
 		dest := newDestroyCmd(cr.cli.Connector, l)
 		dest.SetArgs([]string{"--force"})
 
+		// the report for down must not be mixed with the test report
+		if structuredOutput() {
+			dest.SetOut(&sb)
+		}
+
 		err = dest.Execute()
 		if err != nil {
-			fmt.Println(sb.String())
+			fmt.Fprintln(cr.diagnostics(), sb.String())
 			os.Exit(1)
 		}
 
@@ -251,6 +316,11 @@ func (cr *CucumberRunner) initializeSuite(ctx *godog.ScenarioContext) {
 	ctx.Step(`^I expect the response to contain "([^"]*)"$`, cr.iExpectTheResponseToContain)
 	ctx.Step(`^a TCP connection to "([^"]*)" should open$`, aTCPConnectionToShouldOpen)
 	ctx.Step(`^the following output variables should be set$`, cr.theFollowingOutputVaraiblesShouldBeSet)
+	ctx.Step(`^the container "([^"]*)" should exit with code (\d+)$`, cr.theContainerShouldExitWithCode)
+	ctx.Step(`^the file "([^"]*)" should contain "([^"]*)"$`, cr.theFileShouldContain)
+	ctx.Step(`^the file "([^"]*)" in the container "([^"]*)" should contain "([^"]*)"$`, cr.theFileInTheContainerShouldContain)
+	ctx.Step(`^the Kubernetes object "([^"]*)" in the cluster "([^"]*)" should have "([^"]*)" equal to "([^"]*)"$`, cr.theKubernetesObjectShouldEqual)
+	ctx.Step(`^the Kubernetes object "([^"]*)" in the cluster "([^"]*)" should have "([^"]*)" containing "([^"]*)"$`, cr.theKubernetesObjectShouldContain)
 }
 
 func (cr *CucumberRunner) iRunApply() error {
@@ -265,7 +335,14 @@ func (cr *CucumberRunner) iRunApplyAtPath(path string) error {
 
 	args := []string{absPath}
 
-	noOpen := true
+	o := &runOptions{
+		noOpen:          true,
+		force:           *cr.force,
+		variables:       cr.variables,
+		variablesFile:   cr.variablesFile,
+		plain:           true,
+		pullConcurrency: defaultPullConcurrency,
+	}
 
 	// re-use the run command
 	rc := newRunCmdFunc(
@@ -275,10 +352,8 @@ func (cr *CucumberRunner) iRunApplyAtPath(path string) error {
 		cr.cli.HTTP,
 		cr.cli.System,
 		cr.cli.Connector,
-		&noOpen,
-		cr.force,
-		&cr.variables,
-		&cr.variablesFile,
+		cr.cli.ImageSets,
+		o,
 		cr.l,
 	)
 
@@ -292,7 +367,7 @@ func (cr *CucumberRunner) iRunApplyAtPath(path string) error {
 
 	err := rc(cr.cmd, args)
 	if err != nil {
-		fmt.Println(output.String())
+		fmt.Fprintln(cr.diagnostics(), output.String())
 	}
 
 	return err
@@ -462,20 +537,13 @@ func (cr *CucumberRunner) aCallToShouldResultInStatus(arg1 string, arg2 int) err
 }
 
 func (cr *CucumberRunner) theResponseBodyShouldContain(value string) error {
-	if strings.HasPrefix(value, "`") && strings.HasSuffix(value, "`") {
-		r, err := regexp.Compile(strings.Replace(value, "`", "", -1))
-		if err != nil {
-			return err
-		}
+	ok, err := containsValue(respBody, value)
+	if err != nil {
+		return err
+	}
 
-		s := r.FindString(respBody)
-		if s == "" {
-			return fmt.Errorf("expected value %s to be found in response %s", value, respBody)
-		}
-	} else {
-		if !strings.Contains(respBody, value) {
-			return fmt.Errorf("expected value %s to be found in response %s", value, respBody)
-		}
+	if !ok {
+		return fmt.Errorf("expected value %s to be found in response %s", value, respBody)
 	}
 
 	return nil
@@ -618,20 +686,13 @@ func (cr *CucumberRunner) iExpectTheExitCodeToBe(arg1 int) error {
 }
 
 func (cr *CucumberRunner) iExpectTheResponseToContain(arg1 string) error {
-	if strings.HasPrefix(arg1, "`") && strings.HasSuffix(arg1, "`") {
-		r, err := regexp.Compile(strings.Replace(arg1, "`", "", -1))
-		if err != nil {
-			return err
-		}
+	ok, err := containsValue(commandOutput.String(), arg1)
+	if err != nil {
+		return err
+	}
 
-		s := r.FindString(commandOutput.String())
-		if s != "" {
-			return nil
-		}
-	} else {
-		if strings.Contains(commandOutput.String(), arg1) {
-			return nil
-		}
+	if ok {
+		return nil
 	}
 
 	return fmt.Errorf("expected command output to contain %s.\n Output:\n%s", arg1, commandOutput.String())
@@ -785,8 +846,17 @@ func (cr *CucumberRunner) getJSONPath(path, resource string) (string, error) {
 
 	// flatten
 	flat, _ := json.Marshal(ci)
+
+	return evaluateJSONPath(path, flat)
+}
+
+// evaluateJSONPath returns the value at path in the JSON document
+func evaluateJSONPath(path string, doc []byte) (string, error) {
 	var flatInt interface{}
-	json.Unmarshal(flat, &flatInt)
+	err := json.Unmarshal(doc, &flatInt)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse JSON: %s", err)
+	}
 
 	jp := jsonpath.New("test")
 	err = jp.Parse(path)
@@ -802,3 +872,187 @@ func (cr *CucumberRunner) getJSONPath(path, resource string) (string, error) {
 
 	return buf.String(), nil
 }
+
+// containsValue returns true when s contains value, values wrapped in
+// backticks are treated as regular expressions
+func containsValue(s, value string) (bool, error) {
+	if strings.HasPrefix(value, "`") && strings.HasSuffix(value, "`") {
+		r, err := regexp.Compile(strings.Replace(value, "`", "", -1))
+		if err != nil {
+			return false, err
+		}
+
+		return r.FindString(s) != "", nil
+	}
+
+	return strings.Contains(s, value), nil
+}
+
+// theContainerShouldExitWithCode waits for the container to exit and checks
+// the exit code, this is used to test jobs that run to completion
+func (cr *CucumberRunner) theContainerShouldExitWithCode(resource string, code int) error {
+	addr, _, _, err := getLookupAddress(resource)
+	if err != nil {
+		return fmt.Errorf("unable to find resource: %s", err)
+	}
+
+	for i := 0; i < 60; i++ {
+		ci, err := cr.cli.Docker.ContainerInspect(context.Background(), addr)
+		if err != nil {
+			return err
+		}
+
+		if ci.State != nil && ci.State.Status == "exited" {
+			if ci.State.ExitCode != code {
+				return fmt.Errorf("expected container %s to exit with code %d, got %d", resource, code, ci.State.ExitCode)
+			}
+
+			return nil
+		}
+
+		time.Sleep(2 * time.Second)
+	}
+
+	return fmt.Errorf("container %s did not exit", resource)
+}
+
+func (cr *CucumberRunner) theFileShouldContain(path, value string) error {
+	// relative paths are relative to the blueprint
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(cr.basePath, path)
+	}
+
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read file %s: %s", path, err)
+	}
+
+	return fileShouldContain(path, string(d), value)
+}
+
+func (cr *CucumberRunner) theFileInTheContainerShouldContain(path, resource, value string) error {
+	addr, _, _, err := getLookupAddress(resource)
+	if err != nil {
+		return fmt.Errorf("unable to find resource: %s", err)
+	}
+
+	dir, err := os.MkdirTemp(utils.JumppadTemp(), "test")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	dst := filepath.Join(dir, filepath.Base(path))
+	err = cr.cli.ContainerTasks.CopyFromContainer(addr, path, dst)
+	if err != nil {
+		return err
+	}
+
+	d, err := os.ReadFile(dst)
+	if err != nil {
+		return fmt.Errorf("unable to read file %s: %s", path, err)
+	}
+
+	return fileShouldContain(path, string(d), value)
+}
+
+func fileShouldContain(path, contents, value string) error {
+	ok, err := containsValue(contents, value)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("expected file %s to contain %s.\n Contents:\n%s", path, value, contents)
+	}
+
+	return nil
+}
+
+func (cr *CucumberRunner) theKubernetesObjectShouldEqual(object, cluster, path, value string) error {
+	return cr.checkKubernetesObject(object, cluster, path, func(s string) error {
+		if s != value {
+			return fmt.Errorf("string %s is not equal to %s", value, s)
+		}
+
+		return nil
+	})
+}
+
+func (cr *CucumberRunner) theKubernetesObjectShouldContain(object, cluster, path, value string) error {
+	return cr.checkKubernetesObject(object, cluster, path, func(s string) error {
+		if !strings.Contains(s, value) {
+			return fmt.Errorf("string %s is not found in value %s", value, s)
+		}
+
+		return nil
+	})
+}
+
+// checkKubernetesObject fetches the object from the cluster using kubectl in
+// the server container and checks the value at path, objects are often
+// updated by controllers after creation so the check is retried until it
+// passes
+func (cr *CucumberRunner) checkKubernetesObject(object, cluster, path string, check func(string) error) error {
+	namespace, name, err := parseKubernetesObject(object)
+	if err != nil {
+		return err
+	}
+
+	addr, typ, _, err := getLookupAddress(cluster)
+	if err != nil {
+		return fmt.Errorf("unable to find resource: %s", err)
+	}
+
+	if typ != k8s.TypeK8sCluster {
+		return fmt.Errorf("resource %s is not a Kubernetes cluster", cluster)
+	}
+
+	ids, err := cr.cli.ContainerTasks.FindContainerIDs(addr)
+	if err != nil || len(ids) == 0 {
+		return fmt.Errorf("unable to find server container for %s", cluster)
+	}
+
+	command := []string{"kubectl", "get", name, "--namespace", namespace, "-o", "json"}
+
+	for i := 0; i < 30; i++ {
+		out := bytes.NewBufferString("")
+
+		var code int
+		code, err = cr.cli.ContainerTasks.ExecuteCommand(ids[0], command, nil, "/", "", "", 30, out)
+		if err == nil && code != 0 {
+			err = fmt.Errorf("unable to get object %s: %s", object, out.String())
+		}
+
+		if err == nil {
+			var s string
+			s, err = evaluateJSONPath(path, out.Bytes())
+			if err == nil {
+				err = check(s)
+			}
+		}
+
+		if err == nil {
+			return nil
+		}
+
+		time.Sleep(2 * time.Second)
+	}
+
+	return err
+}
+
+// parseKubernetesObject splits an object reference in the form
+// [namespace/]kind/name, when the namespace is not set default is returned
+func parseKubernetesObject(object string) (string, string, error) {
+	parts := strings.Split(object, "/")
+
+	switch len(parts) {
+	case 2:
+		return "default", object, nil
+	case 3:
+		return parts[0], parts[1] + "/" + parts[2], nil
+	default:
+		return "", "", fmt.Errorf("invalid Kubernetes object %s, objects should be specified as [namespace/]kind/name, e.g. default/deployment/web", object)
+	}
+}
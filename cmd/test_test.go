@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithJUnitReportAddsFormatter(t *testing.T) {
+	require.Equal(t, "pretty,junit:./report.xml", withJUnitReport("pretty", "./report.xml"))
+}
+
+func TestContainsValueMatchesString(t *testing.T) {
+	ok, err := containsValue("hello world", "world")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestContainsValueMatchesRegex(t *testing.T) {
+	ok, err := containsValue("version 1.2.3", "`\\d+\\.\\d+\\.\\d+`")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestContainsValueWithMissingValueReturnsFalse(t *testing.T) {
+	ok, err := containsValue("hello world", "`^world`")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestContainsValueWithInvalidRegexReturnsError(t *testing.T) {
+	_, err := containsValue("hello world", "`(`")
+	require.Error(t, err)
+}
+
+func TestEvaluateJSONPathReturnsValue(t *testing.T) {
+	s, err := evaluateJSONPath("{.status.readyReplicas}", []byte(`{"status":{"readyReplicas":3}}`))
+	require.NoError(t, err)
+	require.Equal(t, "3", s)
+}
+
+func TestEvaluateJSONPathWithInvalidJSONReturnsError(t *testing.T) {
+	_, err := evaluateJSONPath("{.status}", []byte(`not json`))
+	require.ErrorContains(t, err, "unable to parse JSON")
+}
+
+func TestParseKubernetesObjectUsesDefaultNamespace(t *testing.T) {
+	ns, name, err := parseKubernetesObject("deployment/web")
+	require.NoError(t, err)
+	require.Equal(t, "default", ns)
+	require.Equal(t, "deployment/web", name)
+}
+
+func TestParseKubernetesObjectReturnsNamespace(t *testing.T) {
+	ns, name, err := parseKubernetesObject("kube-system/pod/coredns")
+	require.NoError(t, err)
+	require.Equal(t, "kube-system", ns)
+	require.Equal(t, "pod/coredns", name)
+}
+
+func TestParseKubernetesObjectWithInvalidObjectReturnsError(t *testing.T) {
+	_, _, err := parseKubernetesObject("web")
+	require.ErrorContains(t, err, "invalid Kubernetes object web")
+}
+
+func TestFileShouldContainWithMissingValueReturnsError(t *testing.T) {
+	err := fileShouldContain("/etc/config", "a=b", "c=d")
+	require.ErrorContains(t, err, "expected file /etc/config to contain c=d")
+}
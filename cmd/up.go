@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
@@ -13,31 +16,51 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/jumppad-labs/hclconfig"
 	"github.com/jumppad-labs/hclconfig/resources"
 
+	"github.com/jumppad-labs/jumppad/cmd/view"
 	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
 	cclients "github.com/jumppad-labs/jumppad/pkg/clients/container"
 	"github.com/jumppad-labs/jumppad/pkg/clients/getter"
 	"github.com/jumppad-labs/jumppad/pkg/clients/http"
+	"github.com/jumppad-labs/jumppad/pkg/clients/images"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/registry"
 	"github.com/jumppad-labs/jumppad/pkg/clients/system"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/blueprint"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/config/strict"
 	"github.com/jumppad-labs/jumppad/pkg/jumppad"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/mattn/go-isatty"
 	"github.com/spf13/cobra"
 
 	markdown "github.com/MichaelMure/go-term-markdown"
 )
 
-func newRunCmd(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Getter, hc http.HTTP, bc system.System, cc connector.Connector, l logger.Logger) *cobra.Command {
-	var noOpen bool
-	var force bool
-	var variables []string
-	var variablesFile string
+// runOptions are the flags for the up command
+type runOptions struct {
+	noOpen          bool
+	force           bool
+	variables       []string
+	variablesFile   string
+	verifyKey       string
+	update          bool
+	plain           bool
+	ignoreCapacity  bool
+	rollback        bool
+	pullConcurrency int
+	interactive     bool
+	strict          bool
+}
+
+func newRunCmd(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Getter, hc http.HTTP, bc system.System, cc connector.Connector, is images.ImageSets, l logger.Logger) *cobra.Command {
+	o := &runOptions{}
 
 	runCmd := &cobra.Command{
 		Use:   "up [file] | [directory]",
@@ -52,33 +75,59 @@ func newRunCmd(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Getter, h
 
   # Create resources from a blueprint in GitHub
   jumppad up github.com/jumppad-labs/blueprints/kubernetes-vault
+
+  # Create resources from a signed blueprint in an OCI registry
+  jumppad up oci://ghcr.io/jumppad-labs/kubernetes-vault:v1.2.0 --verify-key ./cosign.pub
+
+  # Ask for the values of variables that have not been set
+  jumppad up ./ --interactive
+
+  # Return an error for unknown attributes, unused variables, and unused outputs
+  jumppad up ./ --strict
 	`,
 		Args:         cobra.ArbitraryArgs,
-		RunE:         newRunCmdFunc(e, dt, bp, hc, bc, cc, &noOpen, &force, &variables, &variablesFile, l),
+		RunE:         newRunCmdFunc(e, dt, bp, hc, bc, cc, is, o, l),
 		SilenceUsage: true,
 	}
 
-	runCmd.Flags().BoolVarP(&noOpen, "no-browser", "", false, "When set to true Jumppad will not open the browser windows defined in the blueprint")
-	runCmd.Flags().BoolVarP(&force, "force-update", "", false, "When set to true Jumppad ignores cached images or files and will download all resources")
-	runCmd.Flags().StringSliceVarP(&variables, "var", "", nil, "Allows setting variables from the command line, variables are specified as a key and value, e.g --var key=value. Can be specified multiple times")
-	runCmd.Flags().StringVarP(&variablesFile, "vars-file", "", "", "Load variables from a location other than *.vars files in the blueprint folder. E.g --vars-file=./file.vars")
+	runCmd.Flags().BoolVarP(&o.noOpen, "no-browser", "", false, "When set to true Jumppad will not open the browser windows defined in the blueprint")
+	runCmd.Flags().BoolVarP(&o.force, "force-update", "", false, "When set to true Jumppad ignores cached images or files and will download all resources")
+	runCmd.Flags().StringSliceVarP(&o.variables, "var", "", nil, "Allows setting variables from the command line, variables are specified as a key and value, e.g --var key=value. Can be specified multiple times")
+	runCmd.Flags().StringVarP(&o.variablesFile, "vars-file", "", "", "Load variables from a location other than *.vars files in the blueprint folder. E.g --vars-file=./file.vars")
+	runCmd.Flags().BoolVarP(&o.update, "update", "", false, "When set to true Jumppad updates the hashes in the "+jumppad.LockFileName+" file for remote blueprints and modules that have changed instead of returning an error")
+	runCmd.Flags().StringVarP(&o.verifyKey, "verify-key", "", "", "Path to a PEM encoded public key used to verify the signature of blueprints fetched from an OCI registry")
+	runCmd.Flags().BoolVarP(&o.plain, "plain", "", false, "When set to true Jumppad shows the log stream instead of the progress of each resource, progress is only shown when the output is a terminal")
+	runCmd.Flags().BoolVarP(&o.ignoreCapacity, "force", "", false, "When set to true Jumppad creates the resources even when the Docker host does not have the CPU or memory they need")
+	runCmd.Flags().BoolVarP(&o.rollback, "rollback", "", false, "When set to true Jumppad destroys the resources created by this run when a resource fails, resources that existed before the run are left in place")
+	runCmd.Flags().BoolVarP(&o.interactive, "interactive", "", false, "When set to true Jumppad asks for the values of variables that have not been set, the answers can be saved to "+varsFileName+" in the blueprint folder")
+	runCmd.Flags().BoolVarP(&o.strict, "strict", "", false, "When set to true Jumppad returns an error for unknown attributes, unused variables, and unused module outputs instead of ignoring them, can be enabled by default with the strict setting")
+	runCmd.Flags().IntVarP(&o.pullConcurrency, "pull-concurrency", "", defaultPullConcurrency, "Number of images that are pulled at the same time before the resources are created, images are pulled by each resource when set to 0")
 
 	return runCmd
 }
 
-func newRunCmdFunc(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Getter, hc http.HTTP, bc system.System, cc connector.Connector, noOpen *bool, force *bool, variables *[]string, variablesFile *string, l logger.Logger) func(cmd *cobra.Command, args []string) error {
+func newRunCmdFunc(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Getter, hc http.HTTP, bc system.System, cc connector.Connector, is images.ImageSets, o *runOptions, l logger.Logger) func(cmd *cobra.Command, args []string) error {
 	return func(cmd *cobra.Command, args []string) error {
 		// create the shipyard and sub folders in the users home directory
 		utils.CreateFolders()
 
-		if *force {
+		if o.force {
 			bp.SetForce(true)
 			dt.SetForce(true)
 		}
 
+		if o.verifyKey != "" {
+			k, err := registry.ReadPublicKey(o.verifyKey)
+			if err != nil {
+				return fmt.Errorf("unable to read verify key: %s", err)
+			}
+
+			bp.SetVerifyKey(k)
+		}
+
 		// parse the vars into a map
 		vars := map[string]string{}
-		for _, v := range *variables {
+		for _, v := range o.variables {
 			// if the variable is wrapped in single quotes remove them
 			v = strings.TrimPrefix(v, "'")
 			v = strings.TrimSuffix(v, "'")
@@ -90,39 +139,21 @@ func newRunCmdFunc(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Gette
 		}
 
 		// check the variables file exists
-		if variablesFile != nil && *variablesFile != "" {
-			if _, err := os.Stat(*variablesFile); err != nil {
-				return fmt.Errorf("variables file %s, does not exist", *variablesFile)
+		variablesFile := o.variablesFile
+		if variablesFile != "" {
+			if _, err := os.Stat(variablesFile); err != nil {
+				return fmt.Errorf("variables file %s, does not exist", variablesFile)
 			}
-		} else {
-			vf := ""
-			variablesFile = &vf
 		}
 
-		// create the certificates for the connector
-		if cb, err := cc.GetLocalCertBundle(utils.CertsDir("")); err != nil || cb == nil {
-			// generate certs
-			l.Debug("Generating TLS Certificates for Ingress", "path", utils.CertsDir(""))
-			_, err := cc.GenerateLocalCertBundle(utils.CertsDir(""))
-			if err != nil {
-				return fmt.Errorf("unable to generate connector certificates: %s", err)
-			}
+		err := startConnector(cc, l)
+		if err != nil {
+			return err
 		}
 
-		// start the connector
-		if !cc.IsRunning() {
-			cb, err := cc.GetLocalCertBundle(utils.CertsDir(""))
-			if err != nil {
-				return fmt.Errorf("unable to get certificates to secure ingress: %s", err)
-			}
-
-			l.Debug("Starting API server")
-
-			err = cc.Start(cb)
-			if err != nil {
-				return fmt.Errorf("unable to start API server: %s", err)
-			}
-		}
+		// the parsed config is used to pull the images before the resources
+		// are created
+		var parsed *hclconfig.Config
 
 		dst := ""
 		if len(args) == 1 {
@@ -139,6 +170,7 @@ func newRunCmdFunc(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Gette
 			cmd.Println("Running configuration from ", dst, " -- press ctrl c to cancel")
 			cmd.Println("")
 
+			source := ""
 			if !utils.IsLocalFolder(dst) && !utils.IsHCLFile(dst) {
 				// fetch the remote server from github
 				err := bp.Get(dst, utils.BlueprintLocalFolder(dst))
@@ -146,8 +178,56 @@ func newRunCmdFunc(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Gette
 					return fmt.Errorf("unable to retrieve blueprint: %s", err)
 				}
 
+				source = dst
 				dst = utils.BlueprintLocalFolder(dst)
 			}
+
+			// answers saved next to a single file are not read by the
+			// parser, only .vars files in a blueprint folder are read
+			if variablesFile == "" && utils.IsHCLFile(dst) {
+				if _, err := os.Stat(varsFilePath(dst)); err == nil {
+					variablesFile = varsFilePath(dst)
+				}
+			}
+
+			if o.interactive {
+				err := askVariables(cmd, dst, vars, variablesFile)
+				if err != nil {
+					return err
+				}
+			}
+
+			// typos in a blueprint are silently ignored by the parser, strict
+			// mode reports them before anything is created
+			if o.strict || utils.StrictMode() {
+				err := checkStrict(dst)
+				if err != nil {
+					return err
+				}
+			}
+
+			// parsing the config fetches any remote modules, the parsed config
+			// is used to verify the lock file, check the capacity, and pull the
+			// images, errors parsing the config are returned by apply
+			cfg, perr := e.ParseConfigWithVariables(dst, vars, variablesFile)
+
+			err := verifyLockFile(source, dst, cfg, o.update, l)
+			if err != nil {
+				return err
+			}
+
+			// check the Docker host can run the resources before creating
+			// anything
+			if perr == nil && cfg != nil {
+				parsed = cfg
+
+				if !o.ignoreCapacity {
+					err := checkCapacity(estimateCapacity(cfg), dt.EngineInfo())
+					if err != nil {
+						return err
+					}
+				}
+			}
 		}
 
 		// update status every 30s to let people know we are still running
@@ -175,13 +255,85 @@ func newRunCmdFunc(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Gette
 			cancel()
 		}()
 
-		config, err := e.ApplyWithVariables(ctx, dst, vars, *variablesFile)
+		var progress *view.Progress
+		logs := bytes.NewBuffer(nil)
+
+		if useProgress(o.plain, l) {
+			// log lines would be drawn over the progress, keep them so that
+			// they can be shown when the apply fails
+			out := l.Output()
+			l.SetOutput(logs)
+			defer l.SetOutput(out)
+
+			progress = view.NewProgress(os.Stdout)
+			e.SetProgressHandler(progressHandler(progress))
+			progress.Start()
+		}
+
+		// pull the images up front in parallel, when force is set the
+		// resources pull the images again so there is no benefit
+		if parsed != nil && !o.force {
+			pullImages(dt, imagesToPull(parsed), o.pullConcurrency, progress, l)
+		}
+
+		// keep the state before the apply so that the resources created by
+		// a failed apply can be rolled back
+		previous, _ := config.LoadState()
+
+		config, err := e.ApplyWithVariables(ctx, dst, vars, variablesFile)
+		if err == nil {
+			collectImageGarbage(is, dt, dst, config, l)
+		}
+
+		if progress != nil {
+			progress.Stop()
+			e.SetProgressHandler(nil)
+
+			if err != nil {
+				cmd.PrintErrln(logs.String())
+			}
+		}
+
+		// annotations must not be mixed with the structured output
+		if structuredOutput() {
+			reportToGitHubActions(cmd.ErrOrStderr(), e, config, err, l)
+		} else {
+			reportToGitHubActions(cmd.OutOrStdout(), e, config, err, l)
+		}
+
+		if err != nil && o.rollback {
+			cmd.PrintErrln("Apply failed, rolling back resources created by this run")
+
+			// the apply context may have been cancelled with ctrl c
+			rerr := e.Rollback(context.Background(), previous)
+			if rerr != nil {
+				err = fmt.Errorf("%s, %s", err, rerr)
+			}
+		}
+
+		// structured output replaces the browser windows and blueprint header
+		if structuredOutput() {
+			statusUpdate.Stop()
+
+			r := newCommandReport("up", e.Results(), startTime, err)
+			if config != nil {
+				r.Outputs, _ = outputValues(config, false)
+			}
+
+			werr := writeStructured(cmd.OutOrStdout(), cliFormat, r)
+			if err != nil {
+				return err
+			}
+
+			return werr
+		}
+
 		if err != nil {
 			return err
 		}
 
 		// do not open the browser windows
-		if !*noOpen {
+		if !o.noOpen {
 
 			browserList := []string{}
 			checkDuration := 30 * time.Second
@@ -309,6 +461,141 @@ func newRunCmdFunc(e jumppad.Engine, dt cclients.ContainerTasks, bp getter.Gette
 	}
 }
 
+// checkStrict returns an error listing the unknown attributes, unused
+// variables, and unused outputs in the blueprint
+func checkStrict(path string) error {
+	err := strict.Check(path)
+	if err != nil {
+		return fmt.Errorf("strict checks failed for blueprint %s:\n%s", path, err)
+	}
+
+	return nil
+}
+
+// startConnector creates the certificates for the connector and starts it
+// when it is not running
+func startConnector(cc connector.Connector, l logger.Logger) error {
+	if cb, err := cc.GetLocalCertBundle(utils.CertsDir("")); err != nil || cb == nil {
+		// generate certs
+		l.Debug("Generating TLS Certificates for Ingress", "path", utils.CertsDir(""))
+		_, err := cc.GenerateLocalCertBundle(utils.CertsDir(""))
+		if err != nil {
+			return fmt.Errorf("unable to generate connector certificates: %s", err)
+		}
+	}
+
+	if !cc.IsRunning() {
+		cb, err := cc.GetLocalCertBundle(utils.CertsDir(""))
+		if err != nil {
+			return fmt.Errorf("unable to get certificates to secure ingress: %s", err)
+		}
+
+		l.Debug("Starting API server")
+
+		err = cc.Start(cb)
+		if err != nil {
+			return fmt.Errorf("unable to start API server: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// verifyLockFile checks the hashes of the remote blueprint and any remote modules
+// used by the parsed configuration cfg match the hashes in the lock file, new
+// sources are added to the lock file.
+//
+// The lock file for a local configuration is stored alongside the configuration,
+// for a remote blueprint it is stored in the current working directory
+func verifyLockFile(source, dst string, cfg *hclconfig.Config, update bool, l logger.Logger) error {
+	dst, err := filepath.Abs(dst)
+	if err != nil {
+		return err
+	}
+
+	dir := dst
+	if utils.IsHCLFile(dst) {
+		dir = filepath.Dir(dst)
+	}
+
+	if !utils.IsLocalFolder(dir) {
+		return nil
+	}
+
+	lockPath := filepath.Join(dir, jumppad.LockFileName)
+	if source != "" {
+		lockPath = jumppad.LockFileName
+	}
+
+	lf, err := jumppad.LoadLockFile(lockPath)
+	if err != nil {
+		return err
+	}
+
+	check := func(err error) error {
+		var me *jumppad.LockMismatchError
+		if !errors.As(err, &me) {
+			return err
+		}
+
+		if update {
+			l.Warn("Hash has changed, updating lock file", "type", me.Type, "source", me.Source, "expected", me.Expected, "got", me.Got)
+			return nil
+		}
+
+		return fmt.Errorf("%s, if this change is expected run again with --update", err)
+	}
+
+	if source != "" {
+		err := check(lf.Verify(jumppad.LockTypeBlueprint, source, dst, update))
+		if err != nil {
+			return err
+		}
+	}
+
+	for src, dir := range jumppad.RemoteModules(cfg, utils.ModulesDir()) {
+		err := check(lf.Verify(jumppad.LockTypeModule, src, dir, update))
+		if err != nil {
+			return err
+		}
+	}
+
+	return lf.Save()
+}
+
+// useProgress returns true when the progress of each resource should be shown
+// instead of the log stream
+func useProgress(plain bool, l logger.Logger) bool {
+	if plain || structuredOutput() {
+		return false
+	}
+
+	// the log stream is more useful when diagnosing problems
+	if l.IsDebug() || l.IsTrace() {
+		return false
+	}
+
+	return isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// progressHandler sends the progress of each resource to the renderer,
+// variables, outputs, and modules are not shown as nothing is created
+func progressHandler(p *view.Progress) jumppad.ProgressHandler {
+	return func(r jumppad.ResourceResult) {
+		if r.Type == resources.TypeVariable || r.Type == resources.TypeOutput || r.Type == resources.TypeModule {
+			return
+		}
+
+		p.Update(view.ResourceProgress{
+			ID:       r.ID,
+			Status:   r.Status,
+			Duration: r.Duration,
+			Error:    r.Error,
+			Done:     r.Status != jumppad.PhaseCreating && r.Status != jumppad.PhaseRefreshing && r.Status != jumppad.PhaseDestroying,
+		})
+	}
+}
+
 func buildBrowserPath(n, p string, resourceType string, path string) string {
 	// if the path starts with http or https then override the default behaviour
 	if strings.HasPrefix(path, "https://") || strings.HasPrefix(path, "http://") {
@@ -14,8 +14,10 @@ import (
 	conmock "github.com/jumppad-labs/jumppad/pkg/clients/connector/mocks"
 	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
 	cmock "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	dtypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
 	gettermock "github.com/jumppad-labs/jumppad/pkg/clients/getter/mocks"
 	httpmock "github.com/jumppad-labs/jumppad/pkg/clients/http/mocks"
+	imagemock "github.com/jumppad-labs/jumppad/pkg/clients/images/mocks"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	systemmock "github.com/jumppad-labs/jumppad/pkg/clients/system/mocks"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/blueprint"
@@ -38,11 +40,16 @@ type runMocks struct {
 	system    *systemmock.System
 	tasks     *cmock.ContainerTasks
 	connector *conmock.Connector
+	imageSets *imagemock.ImageSets
 }
 
 func setupRun(t *testing.T) (*cobra.Command, *runMocks) {
+	// the annotations and outputs are only written in GitHub Actions
+	t.Setenv("GITHUB_ACTIONS", "")
+
 	mockContainer := &cmock.ContainerTasks{}
 	mockContainer.On("SetForce", mock.Anything)
+	mockContainer.On("EngineInfo").Return(&dtypes.EngineInfo{CPU: 4, Memory: 8000000000})
 
 	mockHTTP := &httpmock.HTTP{}
 	mockHTTP.On("HealthCheckHTTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
@@ -57,6 +64,9 @@ func setupRun(t *testing.T) (*cobra.Command, *runMocks) {
 	mockSystem.On("PromptInput", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("")
 	mockSystem.On("CheckVersion", mock.Anything).Return("", false)
 
+	mockImageSets := &imagemock.ImageSets{}
+	mockImageSets.On("Record", mock.Anything, mock.Anything).Return(nil)
+
 	mockConnector := &conmock.Connector{}
 	mockConnector.On("GetLocalCertBundle", mock.Anything).Return(
 		&types.CertBundle{},
@@ -85,10 +95,11 @@ func setupRun(t *testing.T) (*cobra.Command, *runMocks) {
 	hclconfig := hclconfig.Config{}
 
 	mockEngine := &enginemocks.Engine{}
-	mockEngine.On("ParseConfigWithVariables", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockEngine.On("ParseConfigWithVariables", mock.Anything, mock.Anything, mock.Anything).Return(&hclconfig, nil)
 	mockEngine.On("ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&hclconfig, nil)
 	mockEngine.On("GetClients", mock.Anything).Return(clients)
 	mockEngine.On("ResourceCountForType", mock.Anything).Return(0)
+	mockEngine.On("Rollback", mock.Anything, mock.Anything).Return(nil)
 
 	bp := blueprint.Blueprint{}
 
@@ -101,9 +112,10 @@ func setupRun(t *testing.T) (*cobra.Command, *runMocks) {
 		system:    mockSystem,
 		connector: mockConnector,
 		tasks:     mockContainer,
+		imageSets: mockImageSets,
 	}
 
-	cmd := newRunCmd(mockEngine, mockContainer, mockGetter, mockHTTP, mockSystem, mockConnector, logger.NewTestLogger(t))
+	cmd := newRunCmd(mockEngine, mockContainer, mockGetter, mockHTTP, mockSystem, mockConnector, mockImageSets, logger.NewTestLogger(t))
 	cmd.SetOut(bytes.NewBuffer([]byte("")))
 
 	return cmd, rm
@@ -215,7 +227,7 @@ func TestRunSetsVariablesFromFlag(t *testing.T) {
 	err := rf.Execute()
 	require.NoError(t, err)
 
-	args := rm.engine.Calls[0].Arguments[2]
+	args := testutils.GetCalls(&rm.engine.Mock, "ApplyWithVariables")[0].Arguments[2]
 
 	require.Equal(t, map[string]string{
 		"abc":  "1234",
@@ -351,3 +363,142 @@ func TestRunDoesNotOpensBrowserWindowWhenCheckError(t *testing.T) {
 
 	rm.system.AssertNumberOfCalls(t, "OpenBrowser", 0)
 }
+
+func TestRunParsesConfigOnceBeforeApply(t *testing.T) {
+	rf, rm := setupRun(t)
+	rf.SetArgs([]string{"/tmp"})
+
+	err := rf.Execute()
+	require.NoError(t, err)
+
+	rm.engine.AssertNumberOfCalls(t, "ParseConfigWithVariables", 1)
+}
+
+func TestRunWithInsufficientCapacityReturnsError(t *testing.T) {
+	rf, rm := setupRun(t)
+	rf.SetArgs([]string{"/tmp"})
+
+	c := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.container.test", Name: "test", Type: "container"}}}
+	c.Resources = &container.Resources{Memory: 16000}
+
+	testutils.RemoveOn(&rm.engine.Mock, "ParseConfigWithVariables")
+	rm.engine.On("ParseConfigWithVariables", mock.Anything, mock.Anything, mock.Anything).Return(&hclconfig.Config{Resources: []hcltypes.Resource{c}}, nil)
+
+	err := rf.Execute()
+	require.ErrorContains(t, err, "the resources need 16000MB of memory, the Docker host has 8000MB")
+
+	rm.engine.AssertNotCalled(t, "ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRunWithForceIgnoresCapacity(t *testing.T) {
+	rf, rm := setupRun(t)
+	rf.SetArgs([]string{"/tmp"})
+	rf.Flags().Set("force", "true")
+
+	c := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{ID: "resource.container.test", Name: "test", Type: "container"}}}
+	c.Resources = &container.Resources{Memory: 16000}
+
+	testutils.RemoveOn(&rm.engine.Mock, "ParseConfigWithVariables")
+	rm.engine.On("ParseConfigWithVariables", mock.Anything, mock.Anything, mock.Anything).Return(&hclconfig.Config{Resources: []hcltypes.Resource{c}}, nil)
+
+	err := rf.Execute()
+	require.NoError(t, err)
+
+	rm.engine.AssertCalled(t, "ApplyWithVariables", mock.Anything, "/tmp", mock.Anything, mock.Anything)
+}
+
+func TestRunRecordsBlueprintImages(t *testing.T) {
+	rf, rm := setupRun(t)
+	rf.SetArgs([]string{"/tmp"})
+
+	testutils.RemoveOn(&rm.engine.Mock, "ApplyWithVariables")
+
+	c := &container.Container{ResourceBase: hcltypes.ResourceBase{Meta: hcltypes.Meta{Name: "test", Type: "container"}}}
+	c.Image = container.Image{Name: "consul:1.16"}
+
+	rm.engine.On("ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		&hclconfig.Config{Resources: []hcltypes.Resource{c}},
+		nil,
+	)
+
+	err := rf.Execute()
+	require.NoError(t, err)
+
+	rm.imageSets.AssertCalled(t, "Record", "/tmp", []string{"consul:1.16"})
+	rm.imageSets.AssertNotCalled(t, "Prune", mock.Anything)
+}
+
+func TestRunWithRollbackRollsBackWhenApplyFails(t *testing.T) {
+	rf, rm := setupRun(t)
+	rf.SetArgs([]string{"/tmp"})
+	rf.Flags().Set("rollback", "true")
+	rf.SetErr(bytes.NewBuffer([]byte("")))
+
+	testutils.RemoveOn(&rm.engine.Mock, "ApplyWithVariables")
+	rm.engine.On("ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+	err := rf.Execute()
+	require.ErrorContains(t, err, "boom")
+
+	rm.engine.AssertCalled(t, "Rollback", mock.Anything, mock.Anything)
+}
+
+func TestRunWithoutRollbackDoesNotRollBackWhenApplyFails(t *testing.T) {
+	rf, rm := setupRun(t)
+	rf.SetArgs([]string{"/tmp"})
+	rf.SetErr(bytes.NewBuffer([]byte("")))
+
+	testutils.RemoveOn(&rm.engine.Mock, "ApplyWithVariables")
+	rm.engine.On("ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+	err := rf.Execute()
+	require.Error(t, err)
+
+	rm.engine.AssertNotCalled(t, "Rollback", mock.Anything, mock.Anything)
+}
+
+func TestRunWithStrictReturnsErrorForUnknownAttribute(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(`
+resource "container" "consul" {
+  imge {
+    name = "consul:1.16.1"
+  }
+}
+`), 0644)
+
+	rf, rm := setupRun(t)
+	rf.SetArgs([]string{"--strict", dir})
+
+	err := rf.Execute()
+	require.ErrorContains(t, err, "strict checks failed")
+	require.ErrorContains(t, err, `did you mean "image"?`)
+
+	rm.engine.AssertNotCalled(t, "ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRunWithStrictSettingReturnsErrorForUnusedVariable(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(`
+variable "version" {
+  default = "1.16.1"
+}
+`), 0644)
+
+	t.Setenv(utils.StrictEnvVar, "true")
+
+	rf, rm := setupRun(t)
+	rf.SetArgs([]string{dir})
+
+	err := rf.Execute()
+	require.ErrorContains(t, err, `variable "version" is not used`)
+
+	rm.engine.AssertNotCalled(t, "ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func setVersion(t *testing.T, v string) {
+	current := version
+	version = v
+
+	t.Cleanup(func() { version = current })
+}
@@ -18,7 +18,11 @@ func newValidateCmd(e jumppad.Engine, bp getter.Getter) *cobra.Command {
 	validateCmd := &cobra.Command{
 		Use:   "validate [file] | [directory]",
 		Short: "Validate the configuration at the given path",
-		Long:  `Validate the configuration at the given path`,
+		Long: `Validate the configuration at the given path.
+
+The configuration is parsed without creating any resources or changing the
+state. As well as the checks performed by the parser, validate reports host
+ports that are used by more than one resource and invalid image names.`,
 		Example: `
   # Validate configuration from .hcl files in the current folder
   jumppad validate
@@ -93,7 +97,12 @@ func newValidateCmdFunc(e jumppad.Engine, bp getter.Getter, variables *[]string,
 			}
 		}
 
-		_, err := e.ParseConfigWithVariables(dst, vars, *variablesFile)
+		c, err := e.ParseConfigWithVariables(dst, vars, *variablesFile)
+		if err != nil {
+			return err
+		}
+
+		err = jumppad.Validate(c)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,377 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/spf13/cobra"
+	"github.com/zclconf/go-cty/cty"
+	"golang.org/x/term"
+)
+
+// varsFileName is the file in the blueprint folder that answers to variable
+// prompts are saved to, files with the .vars extension in the blueprint
+// folder are read when the blueprint is parsed
+const varsFileName = ".jumppad.vars"
+
+// variableEnvPrefix is the prefix for environment variables that set the
+// value of blueprint variables
+const variableEnvPrefix = "JUMPPAD_VAR_"
+
+// sensitiveNames are the parts of a variable name that indicate the value
+// is sensitive, e.g. admin_password or api_key. Sensitive values are not
+// shown when they are entered and are not saved
+var sensitiveNames = []string{"password", "secret", "token", "credential"}
+
+// blueprintVariable is a variable defined in a blueprint that can be set
+// using a prompt
+type blueprintVariable struct {
+	Name        string
+	Description string
+	// Default is the default value formatted as HCL, variables with a null
+	// default are required
+	Default   string
+	Required  bool
+	Sensitive bool
+}
+
+// askVariables prompts for the values of the variables in the blueprint at
+// path that have not been set, the answers are added to vars and can be saved
+// to the vars file so that they are used the next time the blueprint is run
+func askVariables(cmd *cobra.Command, path string, vars map[string]string, variablesFile string) error {
+	vs, err := unsetVariables(path, vars, variablesFile)
+	if err != nil {
+		return fmt.Errorf("unable to read blueprint variables: %s", err)
+	}
+
+	if len(vs) == 0 {
+		return nil
+	}
+
+	in := bufio.NewReader(cmd.InOrStdin())
+	out := cmd.OutOrStdout()
+
+	var readSecret func() (string, error)
+	if f, ok := cmd.InOrStdin().(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		readSecret = func() (string, error) {
+			d, err := term.ReadPassword(int(f.Fd()))
+			return strings.TrimSpace(string(d)), err
+		}
+	}
+
+	cmd.Println("Enter the values for the blueprint variables, press enter to use the default value")
+
+	answers, err := promptVariables(in, out, vs, readSecret)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range answers {
+		vars[k] = v
+	}
+
+	if len(answers) == 0 {
+		return nil
+	}
+
+	cmd.Println("")
+
+	vf := varsFilePath(path)
+	if !confirm(in, out, fmt.Sprintf("Save the answers to %s for next time?", vf)) {
+		return nil
+	}
+
+	err = saveVariables(vf, answers, vs)
+	if err != nil {
+		return fmt.Errorf("unable to save variables: %s", err)
+	}
+
+	cmd.Printf("Saved the answers to %s, sensitive values are not saved\n\n", vf)
+
+	return nil
+}
+
+// unsetVariables returns the variables defined in the blueprint at path that
+// have not been set with --var, a variables file, or an environment
+// variable. Variables with a list, map, or object default can not be entered
+// at a prompt so are not returned
+func unsetVariables(path string, vars map[string]string, variablesFile string) ([]blueprintVariable, error) {
+	files, varsFiles, err := blueprintFiles(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if variablesFile != "" {
+		varsFiles = append(varsFiles, variablesFile)
+	}
+
+	set := map[string]bool{}
+	for k := range vars {
+		set[k] = true
+	}
+
+	for _, vf := range varsFiles {
+		f, diags := hclparse.NewParser().ParseHCLFile(vf)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to parse variables file %s: %s", vf, diags.Error())
+		}
+
+		attrs, _ := f.Body.JustAttributes()
+		for k := range attrs {
+			set[k] = true
+		}
+	}
+
+	unset := []blueprintVariable{}
+
+	for _, file := range files {
+		f, diags := hclparse.NewParser().ParseHCLFile(file)
+		if diags.HasErrors() {
+			return nil, fmt.Errorf("unable to parse %s: %s", file, diags.Error())
+		}
+
+		content, _, _ := f.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: resources.TypeVariable, LabelNames: []string{"name"}}},
+		})
+
+		for _, b := range content.Blocks {
+			name := b.Labels[0]
+			if set[name] || os.Getenv(variableEnvPrefix+name) != "" {
+				continue
+			}
+
+			v, ok := readVariable(name, b.Body)
+			if ok {
+				unset = append(unset, v)
+			}
+		}
+	}
+
+	return unset, nil
+}
+
+// readVariable returns the variable defined by the block body, false is
+// returned when the default can not be entered at a prompt
+func readVariable(name string, body hcl.Body) (blueprintVariable, bool) {
+	v := blueprintVariable{Name: name, Sensitive: isSensitive(name)}
+
+	attrs, _ := body.JustAttributes()
+
+	if d, ok := attrs["description"]; ok {
+		if val, diags := d.Expr.Value(nil); !diags.HasErrors() && val.Type() == cty.String && !val.IsNull() {
+			v.Description = val.AsString()
+		}
+	}
+
+	d, ok := attrs["default"]
+	if !ok {
+		v.Required = true
+		return v, true
+	}
+
+	val, diags := d.Expr.Value(nil)
+	if diags.HasErrors() {
+		// defaults that reference functions or other values can not be
+		// shown
+		return v, false
+	}
+
+	if val.IsNull() {
+		v.Required = true
+		return v, true
+	}
+
+	if !val.Type().IsPrimitiveType() {
+		return v, false
+	}
+
+	v.Default = strings.TrimSpace(string(hclwrite.TokensForValue(val).Bytes()))
+
+	return v, true
+}
+
+// blueprintFiles returns the HCL and variables files read when parsing the
+// blueprint at path
+func blueprintFiles(path string) ([]string, []string, error) {
+	s, err := os.Stat(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !s.IsDir() {
+		return []string{path}, []string{}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	files := []string{}
+	varsFiles := []string{}
+
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		switch filepath.Ext(e.Name()) {
+		case ".hcl":
+			files = append(files, filepath.Join(path, e.Name()))
+		case ".vars":
+			varsFiles = append(varsFiles, filepath.Join(path, e.Name()))
+		}
+	}
+
+	sort.Strings(files)
+
+	return files, varsFiles, nil
+}
+
+// promptVariables asks for the value of each variable, an empty answer keeps
+// the default and required variables are asked for until a value is entered.
+// Sensitive values are read using readSecret when it is not nil so that they
+// are not shown
+func promptVariables(in *bufio.Reader, out io.Writer, vs []blueprintVariable, readSecret func() (string, error)) (map[string]string, error) {
+	answers := map[string]string{}
+
+	for _, v := range vs {
+		if v.Description != "" {
+			fmt.Fprintf(out, "\n%s\n", v.Description)
+		} else {
+			fmt.Fprintln(out, "")
+		}
+
+		for {
+			switch {
+			case v.Required:
+				fmt.Fprintf(out, "%s (required): ", v.Name)
+			default:
+				fmt.Fprintf(out, "%s [%s]: ", v.Name, v.Default)
+			}
+
+			var answer string
+			var err error
+
+			if v.Sensitive && readSecret != nil {
+				answer, err = readSecret()
+				fmt.Fprintln(out, "")
+			} else {
+				answer, err = readLine(in)
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to read value for variable %s: %s", v.Name, err)
+			}
+
+			if answer != "" {
+				answers[v.Name] = answer
+				break
+			}
+
+			if !v.Required {
+				break
+			}
+
+			fmt.Fprintf(out, "a value is required for %s\n", v.Name)
+		}
+	}
+
+	return answers, nil
+}
+
+// confirm asks a yes or no question, the answer is no unless y or yes is
+// entered
+func confirm(in *bufio.Reader, out io.Writer, question string) bool {
+	fmt.Fprintf(out, "%s [y/N]: ", question)
+
+	answer, err := readLine(in)
+	if err != nil {
+		return false
+	}
+
+	answer = strings.ToLower(answer)
+
+	return answer == "y" || answer == "yes"
+}
+
+// saveVariables writes the answers to the file at path, values that are
+// already in the file are replaced and sensitive values are not written
+func saveVariables(path string, answers map[string]string, vs []blueprintVariable) error {
+	f := hclwrite.NewEmptyFile()
+
+	if d, err := os.ReadFile(path); err == nil {
+		existing, diags := hclwrite.ParseConfig(d, path, hcl.InitialPos)
+		if diags.HasErrors() {
+			return fmt.Errorf("unable to parse variables file %s: %s", path, diags.Error())
+		}
+
+		f = existing
+	}
+
+	for _, v := range vs {
+		a, ok := answers[v.Name]
+		if !ok || v.Sensitive {
+			continue
+		}
+
+		f.Body().SetAttributeValue(v.Name, variableValue(a))
+	}
+
+	return os.WriteFile(path, f.Bytes(), 0644)
+}
+
+// varsFilePath returns the location of the file answers are saved to for
+// the blueprint at path
+func varsFilePath(path string) string {
+	if s, err := os.Stat(path); err == nil && !s.IsDir() {
+		return filepath.Join(filepath.Dir(path), varsFileName)
+	}
+
+	return filepath.Join(path, varsFileName)
+}
+
+// variableValue converts the answer to a number or bool when possible, this
+// matches the conversion of values set with --var
+func variableValue(v string) cty.Value {
+	if i, err := strconv.ParseInt(v, 10, 0); err == nil {
+		return cty.NumberIntVal(i)
+	}
+
+	if b, err := strconv.ParseBool(v); err == nil {
+		return cty.BoolVal(b)
+	}
+
+	return cty.StringVal(v)
+}
+
+func isSensitive(name string) bool {
+	n := strings.ToLower(name)
+	for _, s := range sensitiveNames {
+		if strings.Contains(n, s) {
+			return true
+		}
+	}
+
+	// key is a common word so only names ending in key are sensitive
+	return strings.HasSuffix(n, "key")
+}
+
+func readLine(in *bufio.Reader) (string, error) {
+	l, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || l == "") {
+		return "", err
+	}
+
+	return strings.TrimSpace(l), nil
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testVariablesBlueprint = `
+variable "consul_version" {
+  default     = "1.16.1"
+  description = "Version of Consul to install"
+}
+
+variable "replicas" {
+  default = 3
+}
+
+variable "license" {
+  default     = null
+  description = "Consul Enterprise license"
+}
+
+variable "admin_password" {
+  default = null
+}
+
+variable "tags" {
+  default = ["dev"]
+}
+
+resource "network" "main" {
+  subnet = "10.10.0.0/16"
+}
+`
+
+func setupVariablesBlueprint(t *testing.T) string {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(testVariablesBlueprint), 0644)
+
+	return dir
+}
+
+func TestUnsetVariablesReturnsVariables(t *testing.T) {
+	dir := setupVariablesBlueprint(t)
+
+	vs, err := unsetVariables(dir, map[string]string{}, "")
+	require.NoError(t, err)
+
+	require.Equal(t, []blueprintVariable{
+		{Name: "consul_version", Description: "Version of Consul to install", Default: `"1.16.1"`},
+		{Name: "replicas", Default: "3"},
+		{Name: "license", Description: "Consul Enterprise license", Required: true},
+		{Name: "admin_password", Required: true, Sensitive: true},
+	}, vs)
+}
+
+func TestUnsetVariablesIgnoresSetVariables(t *testing.T) {
+	dir := setupVariablesBlueprint(t)
+	os.WriteFile(filepath.Join(dir, "defaults.vars"), []byte(`replicas = 1`), 0644)
+
+	vf := filepath.Join(t.TempDir(), "file.vars")
+	os.WriteFile(vf, []byte(`license = "abc"`), 0644)
+
+	t.Setenv("JUMPPAD_VAR_admin_password", "secret")
+
+	vs, err := unsetVariables(dir, map[string]string{"consul_version": "1.17.0"}, vf)
+	require.NoError(t, err)
+
+	require.Empty(t, vs)
+}
+
+func TestUnsetVariablesWithInvalidFileReturnsError(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(`variable "version" {`), 0644)
+
+	_, err := unsetVariables(dir, map[string]string{}, "")
+	require.ErrorContains(t, err, "unable to parse")
+}
+
+func TestPromptVariablesReturnsAnswers(t *testing.T) {
+	vs := []blueprintVariable{
+		{Name: "consul_version", Description: "Version of Consul to install", Default: `"1.16.1"`},
+		{Name: "replicas", Default: "3"},
+		{Name: "license", Required: true},
+	}
+
+	in := bufio.NewReader(strings.NewReader("1.17.0\n\nabc\n"))
+	out := bytes.NewBuffer(nil)
+
+	answers, err := promptVariables(in, out, vs, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{"consul_version": "1.17.0", "license": "abc"}, answers)
+	require.Contains(t, out.String(), "Version of Consul to install")
+	require.Contains(t, out.String(), `consul_version ["1.16.1"]: `)
+	require.Contains(t, out.String(), "license (required): ")
+}
+
+func TestPromptVariablesAsksAgainForRequiredVariable(t *testing.T) {
+	vs := []blueprintVariable{{Name: "license", Required: true}}
+
+	in := bufio.NewReader(strings.NewReader("\nabc\n"))
+	out := bytes.NewBuffer(nil)
+
+	answers, err := promptVariables(in, out, vs, nil)
+	require.NoError(t, err)
+
+	require.Equal(t, "abc", answers["license"])
+	require.Contains(t, out.String(), "a value is required for license")
+}
+
+func TestPromptVariablesReadsSensitiveValuesWithReadSecret(t *testing.T) {
+	vs := []blueprintVariable{{Name: "admin_password", Required: true, Sensitive: true}}
+
+	in := bufio.NewReader(strings.NewReader(""))
+	out := bytes.NewBuffer(nil)
+
+	answers, err := promptVariables(in, out, vs, func() (string, error) { return "s3cr3t", nil })
+	require.NoError(t, err)
+
+	require.Equal(t, "s3cr3t", answers["admin_password"])
+	require.NotContains(t, out.String(), "s3cr3t")
+}
+
+func TestPromptVariablesWithNoInputReturnsError(t *testing.T) {
+	vs := []blueprintVariable{{Name: "license", Required: true}}
+
+	in := bufio.NewReader(strings.NewReader(""))
+
+	_, err := promptVariables(in, bytes.NewBuffer(nil), vs, nil)
+	require.ErrorContains(t, err, "unable to read value for variable license")
+}
+
+func TestSaveVariablesWritesAnswers(t *testing.T) {
+	path := filepath.Join(t.TempDir(), varsFileName)
+	os.WriteFile(path, []byte("# saved answers\nreplicas = 1\n"), 0644)
+
+	vs := []blueprintVariable{
+		{Name: "consul_version"},
+		{Name: "replicas"},
+		{Name: "admin_password", Sensitive: true},
+	}
+
+	err := saveVariables(path, map[string]string{"consul_version": "1.17.0", "replicas": "5", "admin_password": "s3cr3t"}, vs)
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	require.Contains(t, string(d), "# saved answers")
+	require.Contains(t, string(d), "replicas       = 5")
+	require.Contains(t, string(d), `consul_version = "1.17.0"`)
+	require.NotContains(t, string(d), "s3cr3t")
+}
+
+func TestVarsFilePathReturnsFileInBlueprintFolder(t *testing.T) {
+	dir := setupVariablesBlueprint(t)
+
+	require.Equal(t, filepath.Join(dir, varsFileName), varsFilePath(dir))
+	require.Equal(t, filepath.Join(dir, varsFileName), varsFilePath(filepath.Join(dir, "main.hcl")))
+}
+
+func TestIsSensitiveDetectsSensitiveNames(t *testing.T) {
+	require.True(t, isSensitive("admin_password"))
+	require.True(t, isSensitive("GITHUB_TOKEN"))
+	require.True(t, isSensitive("api_key"))
+	require.False(t, isSensitive("keycloak_version"))
+}
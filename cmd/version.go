@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"context"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/updater"
 	"github.com/spf13/cobra"
 )
 
-func newVersionCmd() *cobra.Command {
+func newVersionCmd(u updater.Updater) *cobra.Command {
+	var check bool
+
 	var versionCmd = &cobra.Command{
 		Use:           "version",
 		Short:         "jumppad version manager commands",
@@ -13,11 +18,28 @@ func newVersionCmd() *cobra.Command {
 		SilenceErrors: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			cmd.Println("Current Version:", version)
+
+			if check {
+				latest, err := u.Latest(context.Background())
+				if err != nil {
+					return err
+				}
+
+				cmd.Println("Latest Version:", latest)
+
+				if !sameVersion(latest, version) {
+					cmd.Println("")
+					cmd.Println("A new version of jumppad is available, run 'jumppad self-update' to update")
+				}
+			}
+
 			cmd.Println("")
 
 			return nil
 		},
 	}
 
+	versionCmd.Flags().BoolVarP(&check, "check", "", false, "Check if a newer version of jumppad is available")
+
 	return versionCmd
 }
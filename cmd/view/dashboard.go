@@ -0,0 +1,391 @@
+package view
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var dashboardRefresh = 2 * time.Second
+
+// dashboardLogLines is the number of log lines fetched for the selected resource
+var dashboardLogLines = 100
+
+// DashboardResource is a row in the status dashboard
+type DashboardResource struct {
+	ID     string
+	Status string
+	Health string
+	Ports  []string
+	// Container is the name of the container used for logs and shells, it is
+	// empty when the resource does not have a container
+	Container string
+}
+
+// DashboardSource provides the resources shown in the dashboard and the
+// actions that can be performed on them
+type DashboardSource interface {
+	// Resources returns the current resources in the state
+	Resources() ([]DashboardResource, error)
+	// Logs returns the most recent log lines for the resource
+	Logs(r DashboardResource, lines int) ([]string, error)
+	// Shell returns a command that opens an interactive shell in the resource
+	Shell(r DashboardResource) (tea.ExecCommand, error)
+	// Destroy destroys the resource and removes it from the state
+	Destroy(r DashboardResource) error
+}
+
+type dashboardResourcesMsg struct {
+	resources []DashboardResource
+	err       error
+}
+
+type dashboardLogsMsg struct {
+	id    string
+	lines []string
+	err   error
+}
+
+type dashboardActionMsg struct {
+	message string
+	err     error
+}
+
+type dashboardTickMsg time.Time
+
+type DashboardKeyMap struct {
+	Shell   key.Binding
+	Logs    key.Binding
+	Destroy key.Binding
+	Confirm key.Binding
+	Cancel  key.Binding
+	Quit    key.Binding
+}
+
+var DefaultDashboardKeyMap = DashboardKeyMap{
+	Shell: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "shell"),
+	),
+	Logs: key.NewBinding(
+		key.WithKeys("l"),
+		key.WithHelp("l", "tail logs"),
+	),
+	Destroy: key.NewBinding(
+		key.WithKeys("d"),
+		key.WithHelp("d", "destroy"),
+	),
+	Confirm: key.NewBinding(
+		key.WithKeys("y"),
+		key.WithHelp("y", "confirm"),
+	),
+	Cancel: key.NewBinding(
+		key.WithKeys("n", "esc"),
+		key.WithHelp("n", "cancel"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+type dashboardModel struct {
+	source    DashboardSource
+	resources []DashboardResource
+
+	table   table.Model
+	logs    viewport.Model
+	follow  bool
+	confirm bool
+	message string
+
+	height int
+	width  int
+}
+
+// Dashboard is an interactive view that shows the status of the resources
+// in the state
+type Dashboard struct {
+	program *tea.Program
+}
+
+// NewDashboard creates a dashboard for the resources returned by the source
+func NewDashboard(s DashboardSource) *Dashboard {
+	m := dashboardModel{
+		source: s,
+		table:  newDashboardTable(),
+		follow: true,
+	}
+
+	return &Dashboard{program: tea.NewProgram(m, tea.WithAltScreen())}
+}
+
+// Display starts the dashboard, this is a blocking function
+func (d *Dashboard) Display() error {
+	if _, err := d.program.Run(); err != nil {
+		return fmt.Errorf("unable to start bubbletea view: %s", err)
+	}
+
+	return nil
+}
+
+func (m dashboardModel) Init() tea.Cmd {
+	return tea.Batch(m.refresh(), m.tick())
+}
+
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case dashboardTickMsg:
+		cmds := []tea.Cmd{m.refresh(), m.tick()}
+		if m.follow {
+			cmds = append(cmds, m.tailLogs())
+		}
+
+		return m, tea.Batch(cmds...)
+
+	case dashboardResourcesMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("unable to load state: %s", msg.err)
+			return m, nil
+		}
+
+		m.resources = msg.resources
+		m.table.SetRows(dashboardRows(m.resources))
+
+		if m.table.Cursor() >= len(m.resources) && len(m.resources) > 0 {
+			m.table.SetCursor(len(m.resources) - 1)
+		}
+
+		return m, nil
+
+	case dashboardLogsMsg:
+		r, ok := m.selected()
+		if !ok || r.ID != msg.id {
+			return m, nil
+		}
+
+		if msg.err != nil {
+			m.logs.SetContent(fmt.Sprintf("unable to read logs: %s", msg.err))
+			return m, nil
+		}
+
+		m.logs.SetContent(strings.Join(msg.lines, "\n"))
+		m.logs.GotoBottom()
+
+		return m, nil
+
+	case dashboardActionMsg:
+		m.message = msg.message
+		if msg.err != nil {
+			m.message = msg.err.Error()
+		}
+
+		return m, m.refresh()
+
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+
+		// header and footer each use two lines, the table takes half of the
+		// remaining space and the logs the rest
+		available := m.height - 4
+		m.table.SetWidth(m.width)
+		m.table.SetHeight(available / 2)
+		m.table.SetColumns(dashboardColumns(m.width))
+
+		m.logs = viewport.New(m.width, available-available/2)
+
+		return m, m.tailLogs()
+
+	case tea.KeyMsg:
+		if m.confirm {
+			switch {
+			case key.Matches(msg, DefaultDashboardKeyMap.Confirm):
+				m.confirm = false
+				return m, m.destroy()
+			case key.Matches(msg, DefaultDashboardKeyMap.Cancel):
+				m.confirm = false
+				m.message = ""
+			}
+
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, DefaultDashboardKeyMap.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, DefaultDashboardKeyMap.Shell):
+			return m, m.shell()
+
+		case key.Matches(msg, DefaultDashboardKeyMap.Logs):
+			m.follow = !m.follow
+			if m.follow {
+				return m, m.tailLogs()
+			}
+
+			return m, nil
+
+		case key.Matches(msg, DefaultDashboardKeyMap.Destroy):
+			if r, ok := m.selected(); ok {
+				m.confirm = true
+				m.message = fmt.Sprintf("Destroy %s? [y/n]", r.ID)
+			}
+
+			return m, nil
+		}
+
+		// the table handles moving the selection
+		var cmd tea.Cmd
+		m.table, cmd = m.table.Update(msg)
+
+		m.logs.SetContent("")
+		return m, tea.Batch(cmd, m.tailLogs())
+	}
+
+	return m, nil
+}
+
+func (m dashboardModel) View() string {
+	if m.width < 1 {
+		return ""
+	}
+
+	header := lipgloss.JoinVertical(lipgloss.Top, "Jumppad Status", "")
+
+	follow := "tail logs"
+	if m.follow {
+		follow = "stop tailing logs"
+	}
+
+	keys := lipgloss.NewStyle().Foreground(lipgloss.Color("37")).Render(
+		fmt.Sprintf("[↑/↓] select, [s] shell, [l] %s, [d] destroy, [q] quit", follow),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Top,
+		header,
+		m.table.View(),
+		m.logs.View(),
+		keys,
+		m.message,
+	)
+}
+
+// selected returns the resource for the selected row in the table
+func (m dashboardModel) selected() (DashboardResource, bool) {
+	c := m.table.Cursor()
+	if c < 0 || c >= len(m.resources) {
+		return DashboardResource{}, false
+	}
+
+	return m.resources[c], true
+}
+
+func (m dashboardModel) tick() tea.Cmd {
+	return tea.Tick(dashboardRefresh, func(t time.Time) tea.Msg {
+		return dashboardTickMsg(t)
+	})
+}
+
+func (m dashboardModel) refresh() tea.Cmd {
+	return func() tea.Msg {
+		r, err := m.source.Resources()
+		return dashboardResourcesMsg{resources: r, err: err}
+	}
+}
+
+func (m dashboardModel) tailLogs() tea.Cmd {
+	r, ok := m.selected()
+	if !ok || r.Container == "" {
+		return nil
+	}
+
+	return func() tea.Msg {
+		lines, err := m.source.Logs(r, dashboardLogLines)
+		return dashboardLogsMsg{id: r.ID, lines: lines, err: err}
+	}
+}
+
+func (m dashboardModel) shell() tea.Cmd {
+	r, ok := m.selected()
+	if !ok {
+		return nil
+	}
+
+	if r.Container == "" {
+		return func() tea.Msg {
+			return dashboardActionMsg{err: fmt.Errorf("%s does not support shells", r.ID)}
+		}
+	}
+
+	c, err := m.source.Shell(r)
+	if err != nil {
+		return func() tea.Msg {
+			return dashboardActionMsg{err: err}
+		}
+	}
+
+	return tea.Exec(c, func(err error) tea.Msg {
+		return dashboardActionMsg{err: err}
+	})
+}
+
+func (m dashboardModel) destroy() tea.Cmd {
+	r, ok := m.selected()
+	if !ok {
+		return nil
+	}
+
+	return func() tea.Msg {
+		err := m.source.Destroy(r)
+		if err != nil {
+			return dashboardActionMsg{err: fmt.Errorf("unable to destroy %s: %s", r.ID, err)}
+		}
+
+		return dashboardActionMsg{message: fmt.Sprintf("Destroyed %s", r.ID)}
+	}
+}
+
+func newDashboardTable() table.Model {
+	t := table.New(
+		table.WithColumns(dashboardColumns(80)),
+		table.WithFocused(true),
+	)
+
+	ts := table.DefaultStyles()
+	ts.Selected = ts.Selected.Foreground(lipgloss.Color("229")).Background(lipgloss.Color("57"))
+	t.SetStyles(ts)
+
+	return t
+}
+
+func dashboardColumns(width int) []table.Column {
+	// status, health and ports have a fixed width, the resource column uses
+	// the remaining space
+	resource := width - 10 - 12 - 24 - 8
+	if resource < 20 {
+		resource = 20
+	}
+
+	return []table.Column{
+		{Title: "Status", Width: 10},
+		{Title: "Resource", Width: resource},
+		{Title: "Health", Width: 12},
+		{Title: "Ports", Width: 24},
+	}
+}
+
+func dashboardRows(resources []DashboardResource) []table.Row {
+	rows := []table.Row{}
+	for _, r := range resources {
+		rows = append(rows, table.Row{r.Status, r.ID, r.Health, strings.Join(r.Ports, ", ")})
+	}
+
+	return rows
+}
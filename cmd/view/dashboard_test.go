@@ -0,0 +1,105 @@
+package view
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+type testDashboardSource struct {
+	resources []DashboardResource
+	destroyed []string
+}
+
+func (s *testDashboardSource) Resources() ([]DashboardResource, error) {
+	return s.resources, nil
+}
+
+func (s *testDashboardSource) Logs(r DashboardResource, lines int) ([]string, error) {
+	return []string{"log from " + r.ID}, nil
+}
+
+func (s *testDashboardSource) Shell(r DashboardResource) (tea.ExecCommand, error) {
+	return nil, nil
+}
+
+func (s *testDashboardSource) Destroy(r DashboardResource) error {
+	s.destroyed = append(s.destroyed, r.ID)
+	return nil
+}
+
+func setupDashboard(t *testing.T) (dashboardModel, *testDashboardSource) {
+	s := &testDashboardSource{
+		resources: []DashboardResource{
+			{ID: "resource.network.main", Status: "created"},
+			{ID: "resource.container.consul", Status: "created", Container: "consul.container.jumppad.dev"},
+		},
+	}
+
+	dm := dashboardModel{source: s, follow: true}
+	dm.table = newDashboardTable()
+
+	nm, _ := dm.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	nm, _ = nm.Update(dm.refresh()())
+
+	return nm.(dashboardModel), s
+}
+
+func TestDashboardRefreshShowsResources(t *testing.T) {
+	m, _ := setupDashboard(t)
+
+	require.Len(t, m.table.Rows(), 2)
+	require.Equal(t, "resource.network.main", m.table.Rows()[0][1])
+}
+
+func TestDashboardDestroyRequiresConfirmation(t *testing.T) {
+	m, s := setupDashboard(t)
+
+	nm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = nm.(dashboardModel)
+
+	require.True(t, m.confirm)
+	require.Contains(t, m.message, "Destroy resource.network.main?")
+
+	nm, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = nm.(dashboardModel)
+	cmd()
+
+	require.False(t, m.confirm)
+	require.Equal(t, []string{"resource.network.main"}, s.destroyed)
+}
+
+func TestDashboardDestroyCancelDoesNotDestroy(t *testing.T) {
+	m, s := setupDashboard(t)
+
+	nm, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	nm, cmd := nm.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	m = nm.(dashboardModel)
+
+	require.Nil(t, cmd)
+	require.False(t, m.confirm)
+	require.Empty(t, s.destroyed)
+}
+
+func TestDashboardTailsLogsForSelectedResource(t *testing.T) {
+	m, _ := setupDashboard(t)
+
+	nm, _ := m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = nm.(dashboardModel)
+
+	cmd := m.tailLogs()
+	require.NotNil(t, cmd)
+
+	nm, _ = m.Update(cmd())
+	m = nm.(dashboardModel)
+
+	require.Contains(t, m.logs.View(), "log from resource.container.consul")
+}
+
+func TestDashboardShellWithoutContainerReturnsError(t *testing.T) {
+	m, _ := setupDashboard(t)
+
+	msg := m.shell()()
+	require.ErrorContains(t, msg.(dashboardActionMsg).err, "does not support shells")
+}
@@ -0,0 +1,257 @@
+package view
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+var progressTick = 100 * time.Millisecond
+
+var (
+	progressGreen = lipgloss.NewStyle().Foreground(lipgloss.Color("10"))
+	progressRed   = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	progressGray  = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+)
+
+// ResourceProgress is the progress of a single resource, Status is either the
+// phase the resource is in or the final status once Done is set
+type ResourceProgress struct {
+	ID       string
+	Status   string
+	Duration time.Duration
+	Error    error
+	Done     bool
+}
+
+// ImageProgress is the progress of an image pull, Current and Total are the
+// bytes downloaded and the size of the layers that are being downloaded
+type ImageProgress struct {
+	Name    string
+	Current int64
+	Total   int64
+	Done    bool
+}
+
+type progressDoneMsg struct{}
+
+type progressTickMsg time.Time
+
+type progressResource struct {
+	ResourceProgress
+	start time.Time
+}
+
+type progressImage struct {
+	ImageProgress
+	start time.Time
+}
+
+type progressModel struct {
+	spinner    spinner.Model
+	order      []string
+	resources  map[string]*progressResource
+	imageOrder []string
+	images     map[string]*progressImage
+	start      time.Time
+	done       bool
+}
+
+// Progress renders the progress of resources as they are created or
+// destroyed. Resources that are in progress are shown with a spinner and the
+// elapsed time, successful resources are collapsed into a count and failed
+// resources are shown with their error.
+type Progress struct {
+	program *tea.Program
+	done    chan struct{}
+}
+
+// NewProgress creates a progress renderer that writes to w
+func NewProgress(w io.Writer) *Progress {
+	// input is disabled so that ctrl+c is handled by the caller
+	p := tea.NewProgram(
+		newProgressModel(),
+		tea.WithOutput(w),
+		tea.WithInput(nil),
+		tea.WithoutSignalHandler(),
+	)
+
+	return &Progress{program: p, done: make(chan struct{})}
+}
+
+// Start renders the progress until Stop is called
+func (p *Progress) Start() {
+	go func() {
+		p.program.Run()
+		close(p.done)
+	}()
+}
+
+// Update sets the progress for a resource
+func (p *Progress) Update(r ResourceProgress) {
+	p.program.Send(r)
+}
+
+// UpdateImage sets the progress for an image pull
+func (p *Progress) UpdateImage(i ImageProgress) {
+	p.program.Send(i)
+}
+
+// Stop renders the summary and waits for the renderer to exit
+func (p *Progress) Stop() {
+	p.program.Send(progressDoneMsg{})
+	<-p.done
+}
+
+func newProgressModel() progressModel {
+	sp := spinner.New()
+	sp.Spinner = spinner.MiniDot
+	sp.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("36"))
+
+	return progressModel{
+		spinner:   sp,
+		resources: map[string]*progressResource{},
+		images:    map[string]*progressImage{},
+		start:     time.Now(),
+	}
+}
+
+func (m progressModel) Init() tea.Cmd {
+	return m.tick()
+}
+
+func (m progressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case progressTickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(m.spinner.Tick())
+
+		return m, tea.Batch(cmd, m.tick())
+
+	case ResourceProgress:
+		r, ok := m.resources[msg.ID]
+		if !ok {
+			r = &progressResource{start: time.Now()}
+			m.resources[msg.ID] = r
+			m.order = append(m.order, msg.ID)
+		}
+
+		r.ResourceProgress = msg
+
+		return m, nil
+
+	case ImageProgress:
+		i, ok := m.images[msg.Name]
+		if !ok {
+			i = &progressImage{start: time.Now()}
+			m.images[msg.Name] = i
+			m.imageOrder = append(m.imageOrder, msg.Name)
+		}
+
+		i.ImageProgress = msg
+
+		return m, nil
+
+	case progressDoneMsg:
+		m.done = true
+		return m, tea.Quit
+	}
+
+	return m, nil
+}
+
+func (m progressModel) View() string {
+	lines := []string{}
+
+	complete := 0
+	failedCount := 0
+	active := []string{}
+	failed := []string{}
+
+	for _, id := range m.order {
+		r := m.resources[id]
+
+		switch {
+		case r.Done && r.Error != nil:
+			failedCount++
+			failed = append(failed,
+				fmt.Sprintf("%s %s %s", progressRed.Render("✘"), id, progressGray.Render(elapsed(r.Duration))),
+				progressRed.Render(indent(r.Error.Error(), "    ")),
+			)
+		case r.Done:
+			complete++
+		default:
+			active = append(active, fmt.Sprintf("%s %s %s %s", m.spinner.View(), id, progressGray.Render(r.Status), progressGray.Render(elapsed(time.Since(r.start)))))
+		}
+	}
+
+	pulled := 0
+	pulling := []string{}
+
+	for _, name := range m.imageOrder {
+		i := m.images[name]
+
+		if i.Done {
+			pulled++
+			continue
+		}
+
+		pulling = append(pulling, fmt.Sprintf("%s %s %s %s", m.spinner.View(), name, progressBar(i.Current, i.Total), progressGray.Render(elapsed(time.Since(i.start)))))
+	}
+
+	if pulled > 0 {
+		lines = append(lines, fmt.Sprintf("%s %d images pulled", progressGreen.Render("✔"), pulled))
+	}
+
+	if complete > 0 {
+		lines = append(lines, fmt.Sprintf("%s %d resources complete", progressGreen.Render("✔"), complete))
+	}
+
+	lines = append(lines, failed...)
+
+	if m.done {
+		lines = append(lines, "", fmt.Sprintf("Finished in %s, %d complete, %d failed", elapsed(time.Since(m.start)), complete, failedCount))
+		return strings.Join(lines, "\n") + "\n"
+	}
+
+	lines = append(lines, pulling...)
+	lines = append(lines, active...)
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (m progressModel) tick() tea.Cmd {
+	return tea.Tick(progressTick, func(t time.Time) tea.Msg {
+		return progressTickMsg(t)
+	})
+}
+
+// progressBar renders the downloaded bytes as a bar with the percentage
+// complete, the bar is empty until the size of the download is known
+func progressBar(current, total int64) string {
+	width := 20
+
+	percent := 0
+	if total > 0 {
+		percent = int(min(current*100/total, 100))
+	}
+
+	filled := width * percent / 100
+
+	return fmt.Sprintf("[%s%s] %3d%%", strings.Repeat("=", filled), strings.Repeat(" ", width-filled), percent)
+}
+
+// elapsed formats a duration in whole seconds
+func elapsed(d time.Duration) string {
+	return fmt.Sprintf("%ds", int(math.Round(d.Seconds())))
+}
+
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n"+prefix)
+}
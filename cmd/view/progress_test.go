@@ -0,0 +1,82 @@
+package view
+
+import (
+	"fmt"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/require"
+)
+
+func updateProgress(m tea.Model, msgs ...tea.Msg) progressModel {
+	for _, msg := range msgs {
+		m, _ = m.Update(msg)
+	}
+
+	return m.(progressModel)
+}
+
+func TestProgressShowsResourcesInProgress(t *testing.T) {
+	m := updateProgress(newProgressModel(),
+		ResourceProgress{ID: "resource.container.consul", Status: "creating"},
+	)
+
+	require.Contains(t, m.View(), "resource.container.consul")
+	require.Contains(t, m.View(), "creating")
+}
+
+func TestProgressCollapsesCompletedResources(t *testing.T) {
+	m := updateProgress(newProgressModel(),
+		ResourceProgress{ID: "resource.network.main", Status: "creating"},
+		ResourceProgress{ID: "resource.network.main", Status: "created", Done: true},
+		ResourceProgress{ID: "resource.container.consul", Status: "creating"},
+		ResourceProgress{ID: "resource.container.consul", Status: "created", Done: true},
+	)
+
+	require.Contains(t, m.View(), "2 resources complete")
+	require.NotContains(t, m.View(), "resource.network.main")
+}
+
+func TestProgressShowsFailedResourcesWithError(t *testing.T) {
+	m := updateProgress(newProgressModel(),
+		ResourceProgress{ID: "resource.network.main", Status: "created", Done: true},
+		ResourceProgress{ID: "resource.container.consul", Status: "failed", Done: true, Error: fmt.Errorf("unable to pull image")},
+	)
+
+	require.Contains(t, m.View(), "1 resources complete")
+	require.Contains(t, m.View(), "resource.container.consul")
+	require.Contains(t, m.View(), "unable to pull image")
+}
+
+func TestProgressDoneShowsSummary(t *testing.T) {
+	m := updateProgress(newProgressModel(),
+		ResourceProgress{ID: "resource.network.main", Status: "created", Done: true},
+		ResourceProgress{ID: "resource.container.consul", Status: "creating"},
+		progressDoneMsg{},
+	)
+
+	require.True(t, m.done)
+	require.Contains(t, m.View(), "1 complete, 0 failed")
+
+	// resources that did not finish are not shown once done
+	require.NotContains(t, m.View(), "resource.container.consul")
+}
+
+func TestProgressShowsImagesBeingPulled(t *testing.T) {
+	m := updateProgress(newProgressModel(),
+		ImageProgress{Name: "consul:1.16.1", Current: 50, Total: 200},
+	)
+
+	require.Contains(t, m.View(), "consul:1.16.1")
+	require.Contains(t, m.View(), "[=====               ]  25%")
+}
+
+func TestProgressCollapsesPulledImages(t *testing.T) {
+	m := updateProgress(newProgressModel(),
+		ImageProgress{Name: "consul:1.16.1", Current: 200, Total: 200, Done: true},
+		ImageProgress{Name: "vault:1.13.0", Done: true},
+	)
+
+	require.Contains(t, m.View(), "2 images pulled")
+	require.NotContains(t, m.View(), "consul:1.16.1")
+}
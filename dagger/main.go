@@ -14,6 +14,10 @@ var arches = []string{"amd64", "arm64"}
 var owner = "jumppad-labs"
 var repo = "jumppad"
 
+// releaseKeyVar is the variable set with -X to embed the public release key
+// used by self-update to verify the signature of the release checksums
+var releaseKeyVar = "github.com/jumppad-labs/jumppad/pkg/clients/updater.ReleaseKey"
+
 func New() *JumppadCI {
 	return &JumppadCI{}
 }
@@ -45,6 +49,10 @@ func (d *JumppadCI) All(
 	notorizeId string,
 	// +optional
 	notorizeIssuer string,
+	// PEM encoded ECDSA or RSA private key used to sign the release checksums,
+	// the checksums are not signed when the key is not set or empty
+	// +optional
+	releaseKey *Secret,
 ) (*Directory, error) {
 	// if quick, only build for the current architecture
 	if quick {
@@ -72,8 +80,22 @@ func (d *JumppadCI) All(
 	// run the unit tests
 	d.UnitTest(ctx, src, !quick)
 
+	// builds without access to the release key secret, such as pull requests
+	// from forks, are not signed
+	if !secretSet(ctx, releaseKey) {
+		log.Info("Release key not set, checksums will not be signed")
+		releaseKey = nil
+	}
+
+	// when we have a release key, embed the public key so self-update can
+	// verify the signature of the release checksums
+	releasePublicKey := ""
+	if releaseKey != nil {
+		releasePublicKey, _ = d.ReleasePublicKey(ctx, releaseKey)
+	}
+
 	// build the applications
-	output, _ = d.Build(ctx, src, version, sha)
+	output, _ = d.Build(ctx, src, version, sha, releasePublicKey)
 
 	// package the build outputs
 	output, _ = d.Package(ctx, output, version)
@@ -89,6 +111,11 @@ func (d *JumppadCI) All(
 	// generate the checksums
 	output, _ = d.GenerateChecksums(ctx, output, version)
 
+	// sign the checksums, the signature is published with the release
+	if releaseKey != nil {
+		output, _ = d.SignChecksums(ctx, output, releaseKey)
+	}
+
 	return output, d.lastError
 }
 
@@ -119,6 +146,9 @@ func (d *JumppadCI) Build(
 	src *Directory,
 	version,
 	sha string,
+	// base64 encoded PKIX public key embedded as the release key
+	// +optional
+	releasePublicKey string,
 ) (*Directory, error) {
 	if d.hasError() {
 		return nil, d.lastError
@@ -126,6 +156,11 @@ func (d *JumppadCI) Build(
 
 	cli := dag.Pipeline("build")
 
+	ldflags := fmt.Sprintf("-X main.version=%s -X main.sha=%s", version, sha)
+	if releasePublicKey != "" {
+		ldflags = fmt.Sprintf("%s -X %s=%s", ldflags, releaseKeyVar, releasePublicKey)
+	}
+
 	// create empty directory to put build outputs
 	outputs := cli.Directory()
 
@@ -151,7 +186,7 @@ func (d *JumppadCI) Build(
 				WithExec([]string{
 					"go", "build",
 					"-o", path,
-					"-ldflags", fmt.Sprintf("-X main.version=%s -X main.sha=%s", version, sha),
+					"-ldflags", ldflags,
 				}).
 				Sync(ctx)
 
@@ -339,6 +374,63 @@ func (d JumppadCI) GenerateChecksums(
 	return files, nil
 }
 
+// ReleasePublicKey returns the base64 encoded PKIX public key for the release
+// key, this is the format expected by the jumppad release key
+func (d *JumppadCI) ReleasePublicKey(
+	ctx context.Context,
+	releaseKey *Secret,
+) (string, error) {
+	if d.hasError() {
+		return "", d.lastError
+	}
+
+	cli := dag.Pipeline("release-public-key")
+
+	key, err := cli.Container().
+		From("alpine:latest").
+		WithExec([]string{"apk", "add", "openssl"}).
+		WithMountedSecret("/release.pem", releaseKey).
+		WithExec([]string{"sh", "-c", "openssl pkey -in /release.pem -pubout -outform DER | base64 -w 0"}).
+		Stdout(ctx)
+
+	if err != nil {
+		d.lastError = fmt.Errorf("unable to read public key from release key: %w", err)
+		return "", d.lastError
+	}
+
+	return strings.TrimSpace(key), nil
+}
+
+// SignChecksums signs checksums.txt with the release key and adds the base64
+// encoded signature as checksums.txt.sig
+func (d *JumppadCI) SignChecksums(
+	ctx context.Context,
+	files *Directory,
+	releaseKey *Secret,
+) (*Directory, error) {
+	if d.hasError() {
+		return nil, d.lastError
+	}
+
+	cli := dag.Pipeline("sign-checksums")
+
+	sig, err := cli.Container().
+		From("alpine:latest").
+		WithExec([]string{"apk", "add", "openssl"}).
+		WithMountedSecret("/release.pem", releaseKey).
+		WithMountedFile("/checksums.txt", files.File("checksums.txt")).
+		WithExec([]string{"sh", "-c", "openssl dgst -sha256 -sign /release.pem /checksums.txt | base64 -w 0 > /checksums.txt.sig"}).
+		File("/checksums.txt.sig").
+		Sync(ctx)
+
+	if err != nil {
+		d.lastError = fmt.Errorf("unable to sign checksums: %w", err)
+		return nil, d.lastError
+	}
+
+	return files.WithFile("checksums.txt.sig", sig), nil
+}
+
 var notorize = []Archive{
 	{Path: "/jumppad_%%VERSION%%_darwin_x86_64.zip", Type: "zip", Output: "/jumppad_%%VERSION%%_darwin_x86_64.zip"},
 	{Path: "/jumppad_%%VERSION%%_darwin_arm64.zip", Type: "zip", Output: "/jumppad_%%VERSION%%_darwin_arm64.zip"},
@@ -413,6 +505,14 @@ func (d *JumppadCI) GithubRelease(
 		return "", d.lastError
 	}
 
+	// self-update refuses releases without a valid signature, the archives
+	// must have been built with the release key
+	_, err = archives.File("checksums.txt.sig").Sync(ctx)
+	if err != nil {
+		d.lastError = fmt.Errorf("checksums.txt.sig not found in the archives, the release key is required to build a release: %w", err)
+		return "", d.lastError
+	}
+
 	cli := dag.Pipeline("release")
 
 	_, err = cli.Github().
@@ -576,6 +676,16 @@ func (d *JumppadCI) goCache() *CacheVolume {
 	return d.goCacheVolume
 }
 
+// secretSet returns true when the secret has a non empty value
+func secretSet(ctx context.Context, s *Secret) bool {
+	if s == nil {
+		return false
+	}
+
+	v, err := s.Plaintext(ctx)
+	return err == nil && strings.TrimSpace(v) != ""
+}
+
 func (d *JumppadCI) hasError() bool {
 	return d.lastError != nil
 }
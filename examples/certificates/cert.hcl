@@ -2,6 +2,15 @@ resource "certificate_ca" "root" {
   output = data("certs")
 }
 
+resource "certificate_ca" "intermediate" {
+  ca_key  = resource.certificate_ca.root.private_key.path
+  ca_cert = resource.certificate_ca.root.bundle.path
+
+  key_type = "ecdsa"
+
+  output = data("certs")
+}
+
 resource "certificate_leaf" "nomad" {
   ca_key  = resource.certificate_ca.root.private_key.path
   ca_cert = resource.certificate_ca.root.certificate.path
@@ -17,4 +26,19 @@ resource "certificate_leaf" "nomad" {
   ]
 
   output = data("certs")
-}
\ No newline at end of file
+}
+
+resource "certificate_leaf" "web" {
+  ca_key  = resource.certificate_ca.intermediate.private_key.path
+  ca_cert = resource.certificate_ca.intermediate.bundle.path
+
+  key_type     = "ecdsa"
+  validity     = "720h"
+  renew_before = "168h"
+
+  dns_names = ["web.local.jmpd.in"]
+
+  pkcs12_password = "password"
+
+  output = data("certs")
+}
@@ -0,0 +1,33 @@
+resource "aws_credentials" "local" {
+  profile = "default"
+}
+
+resource "network" "main" {
+  subnet = "10.10.0.0/16"
+}
+
+resource "container" "aws_cli" {
+  image {
+    name = "amazon/aws-cli:latest"
+  }
+
+  entrypoint = ["sleep"]
+  command    = ["infinity"]
+
+  network {
+    id = resource.network.main.meta.id
+  }
+
+  // the credentials file is rewritten on every apply, mounting the file
+  // ensures the container always has the latest temporary credentials
+  volume {
+    source      = resource.aws_credentials.local.file
+    destination = resource.aws_credentials.local.container_path
+  }
+
+  environment = resource.aws_credentials.local.env
+}
+
+output "aws_source" {
+  value = resource.aws_credentials.local.source
+}
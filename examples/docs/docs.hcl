@@ -2,6 +2,11 @@ resource "docs" "docs" {
   content = [
     resource.book.terraform_basics
   ]
+
+  variant "os" {
+    title   = "Operating system"
+    options = ["linux", "macos", "windows"]
+  }
 }
 
 resource "book" "terraform_basics" {
@@ -17,7 +22,7 @@ resource "chapter" "introduction" {
   title = "Introduction"
 
   page "introduction" {
-    content = file("./docs/index.mdx")
+    source = "./docs/index.mdx"
   }
 }
 
@@ -50,4 +55,15 @@ resource "task" "manual_installation" {
       failure_message = "Terraform is not installed"
     }
   }
+
+  validation "docs_running" {
+    description = "The documentation is being served"
+
+    http {
+      url          = "http://localhost:80"
+      status_codes = [200]
+    }
+
+    failure_message = "The documentation is not reachable on port 80"
+  }
 }
\ No newline at end of file
@@ -0,0 +1,19 @@
+variable "monitoring" {
+  default = false
+}
+
+resource "network" "main" {
+  subnet = "10.16.0.0/16"
+}
+
+resource "container" "prometheus" {
+  enabled = variable.monitoring
+
+  image {
+    name = "prom/prometheus:v2.53.0"
+  }
+
+  network {
+    id = resource.network.main.meta.id
+  }
+}
@@ -40,6 +40,14 @@ resource "http" "post" {
   headers = {
     Accept = "application/json"
   }
+
+  retries         = 3
+  retry_delay     = "2s"
+  expected_status = [200]
+
+  json_outputs = {
+    foo = "json.foo"
+  }
 }
 
 output "get_body" {
@@ -56,4 +64,7 @@ output "post_body" {
 
 output "post_status" {
   value = resource.http.post.status
-}
\ No newline at end of file
+}
+output "post_foo" {
+  value = resource.http.post.output.foo
+}
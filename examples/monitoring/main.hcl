@@ -0,0 +1,33 @@
+resource "network" "cloud" {
+  subnet = "10.10.0.0/16"
+}
+
+resource "container" "app" {
+  image {
+    name = "prom/node-exporter:v1.8.2"
+  }
+
+  network {
+    id = resource.network.cloud.meta.id
+  }
+
+  // containers with these labels are scraped by Prometheus
+  labels = {
+    "prometheus.io/scrape" = "true"
+    "prometheus.io/port"   = "9100"
+  }
+}
+
+resource "monitoring" "stack" {
+  network {
+    id = resource.network.cloud.meta.id
+  }
+}
+
+output "GRAFANA" {
+  value = resource.monitoring.stack.grafana_address
+}
+
+output "PROMETHEUS" {
+  value = resource.monitoring.stack.prometheus_address
+}
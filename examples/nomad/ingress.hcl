@@ -15,4 +15,18 @@ resource "ingress" "fake_service_2" {
 
 output "fake_service_addr" {
   value = resource.ingress.fake_service_2.local_address
-}
\ No newline at end of file
+}
+
+resource "nomad_allocation" "fake_service_2" {
+  cluster = resource.nomad_cluster.dev
+
+  job   = "example_2"
+  group = "fake_service"
+  task  = "fake_service"
+
+  depends_on = ["resource.nomad_job.example_2"]
+}
+
+output "fake_service_alloc_addr" {
+  value = "${resource.nomad_allocation.fake_service_2.ip}:${resource.nomad_allocation.fake_service_2.ports.http}"
+}
@@ -0,0 +1,37 @@
+resource "jumppad" "requirements" {
+  required_version  = ">= 0.11"
+  required_features = ["service_mesh"]
+}
+
+resource "network" "cloud" {
+  subnet = "10.10.0.0/16"
+}
+
+resource "k8s_cluster" "k3s" {
+  network {
+    id = resource.network.cloud.meta.id
+  }
+}
+
+resource "service_mesh" "consul" {
+  cluster = resource.k8s_cluster.k3s
+  mesh    = "consul"
+
+  inject_namespaces = ["default", "payments"]
+  default_deny      = true
+
+  intention {
+    source      = "web"
+    destination = "api"
+  }
+
+  intention {
+    source      = "api"
+    destination = "payments"
+    namespace   = "payments"
+  }
+}
+
+output "KUBECONFIG" {
+  value = resource.k8s_cluster.k3s.kube_config.path
+}
@@ -41,4 +41,16 @@ resource "helm" "vault" {
     timeout = "240s"
     pods    = ["app.kubernetes.io/name=vault"]
   }
-}
\ No newline at end of file
+}
+
+resource "k8s_service" "consul" {
+  cluster   = resource.k8s_cluster.k3s
+  name      = "consul-consul-ui"
+  namespace = "default"
+
+  depends_on = ["resource.helm.consul"]
+}
+
+output "CONSUL_UI_CLUSTER_IP" {
+  value = resource.k8s_service.consul.cluster_ip
+}
@@ -1,4 +1,6 @@
 resource "k8s_cluster" "k3s" {
+  collect_logs = true
+
   network {
     id = resource.network.cloud.meta.id
   }
@@ -10,4 +12,8 @@ resource "k8s_cluster" "k3s" {
 
 output "KUBECONFIG" {
   value = resource.k8s_cluster.k3s.kube_config.path
+}
+
+output "CLUSTER_LOGS" {
+  value = resource.k8s_cluster.k3s.logs_directory
 }
\ No newline at end of file
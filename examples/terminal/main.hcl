@@ -0,0 +1,39 @@
+resource "network" "main" {
+  subnet = "10.0.20.0/16"
+}
+
+resource "container" "ubuntu" {
+  image {
+    name = "ubuntu:22.04"
+  }
+
+  command = ["tail", "-f", "/dev/null"]
+
+  network {
+    id = resource.network.main.meta.id
+  }
+}
+
+# browser based shell attached to the ubuntu container, the token is
+# generated and included in the url output
+resource "terminal" "ubuntu" {
+  target = resource.container.ubuntu
+
+  shell             = "/bin/bash"
+  working_directory = "/root"
+  title             = "Ubuntu"
+}
+
+# browser based shell on the local machine with a fixed port and token
+resource "terminal" "local" {
+  port  = 8090
+  token = "workshop"
+}
+
+output "ubuntu_terminal" {
+  value = resource.terminal.ubuntu.url
+}
+
+output "local_terminal" {
+  value = resource.terminal.local.url
+}
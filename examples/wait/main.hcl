@@ -0,0 +1,38 @@
+resource "container" "httpbin" {
+  image {
+    name = "kong/httpbin:0.1.0"
+  }
+
+  port {
+    local = 80
+    host  = 80
+  }
+}
+
+resource "wait" "httpbin" {
+  // give the container time to start before checking
+  duration = "5s"
+  timeout  = "60s"
+
+  tcp {
+    address = "localhost:80"
+  }
+
+  http {
+    address       = "http://localhost/get"
+    success_codes = [200]
+  }
+
+  command {
+    script = "curl -sf http://localhost/status/200"
+  }
+
+  depends_on = ["resource.container.httpbin"]
+}
+
+resource "http" "get" {
+  method = "GET"
+  url    = "http://localhost/get"
+
+  depends_on = ["resource.wait.httpbin"]
+}
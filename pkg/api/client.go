@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultAddr is the address the API server listens on by default
+const DefaultAddr = "localhost:9095"
+
+// Error is returned by the client when the server responds with an error
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("jumppad API returned status %d: %s", e.StatusCode, e.Message)
+}
+
+// Client calls the jumppad API server. Creating and destroying environments
+// can take several minutes, cancelling the context stops waiting for the
+// result but the server completes the operation.
+type Client struct {
+	addr  string
+	token string
+	http  *http.Client
+}
+
+// NewClient creates a client for the API server at addr, e.g. localhost:9095,
+// the token is written by the server when it starts and can be read with
+// ReadToken
+func NewClient(addr string, token string) *Client {
+	return &Client{addr: addr, token: token, http: &http.Client{}}
+}
+
+// Parse parses the blueprint and returns the resources it contains without
+// making any changes
+func (c *Client) Parse(ctx context.Context, req ApplyRequest) ([]Resource, error) {
+	resp := ParseResponse{}
+
+	err := c.do(ctx, http.MethodPost, "/v1/parse", req, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Resources, nil
+}
+
+// Up creates the resources in the blueprint, the results for each resource
+// are returned even when the blueprint fails to apply
+func (c *Client) Up(ctx context.Context, req ApplyRequest) ([]ResourceResult, error) {
+	return c.apply(ctx, "/v1/up", req)
+}
+
+// Down destroys the resources in the current environment
+func (c *Client) Down(ctx context.Context, force bool) ([]ResourceResult, error) {
+	return c.apply(ctx, "/v1/down", DestroyRequest{Force: force})
+}
+
+// Status returns the resources in the state of the current environment
+func (c *Client) Status(ctx context.Context) ([]Resource, error) {
+	resp := StatusResponse{}
+
+	err := c.do(ctx, http.MethodGet, "/v1/status", nil, &resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Resources, nil
+}
+
+// Outputs returns the outputs of the current environment, sensitive values
+// are redacted unless sensitive is true
+func (c *Client) Outputs(ctx context.Context, sensitive bool) (map[string]any, error) {
+	out := map[string]any{}
+
+	err := c.do(ctx, http.MethodGet, "/v1/outputs?sensitive="+strconv.FormatBool(sensitive), nil, &out)
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// Logs returns the logs of the containers for the resource with the given
+// id, when follow is true the logs are streamed until the context is
+// cancelled. The caller must close the returned reader.
+func (c *Client) Logs(ctx context.Context, id string, follow bool) (io.ReadCloser, error) {
+	path := fmt.Sprintf("/v1/logs/%s?follow=%t", url.PathEscape(id), follow)
+
+	resp, err := c.send(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+
+	return resp.Body, nil
+}
+
+func (c *Client) apply(ctx context.Context, path string, req any) ([]ResourceResult, error) {
+	resp, err := c.send(ctx, http.MethodPost, path, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// failed operations return the results along with the error
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusInternalServerError {
+		return nil, responseError(resp)
+	}
+
+	ar := ApplyResponse{}
+
+	err = json.NewDecoder(resp.Body).Decode(&ar)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read response from jumppad API: %w", err)
+	}
+
+	if ar.Error != "" {
+		return ar.Results, &Error{StatusCode: resp.StatusCode, Message: ar.Error}
+	}
+
+	return ar.Results, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, req, resp any) error {
+	r, err := c.send(ctx, method, path, req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		return responseError(r)
+	}
+
+	err = json.NewDecoder(r.Body).Decode(resp)
+	if err != nil {
+		return fmt.Errorf("unable to read response from jumppad API: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Client) send(ctx context.Context, method, path string, req any) (*http.Response, error) {
+	var body io.Reader
+	if req != nil {
+		d, err := json.Marshal(req)
+		if err != nil {
+			return nil, err
+		}
+
+		body = bytes.NewReader(d)
+	}
+
+	r, err := http.NewRequestWithContext(ctx, method, c.url(path), body)
+	if err != nil {
+		return nil, err
+	}
+
+	if body != nil {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	r.Header.Set("Authorization", "Bearer "+c.token)
+
+	resp, err := c.http.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to contact jumppad API: %w", err)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) url(path string) string {
+	if strings.HasPrefix(c.addr, "http://") || strings.HasPrefix(c.addr, "https://") {
+		return strings.TrimSuffix(c.addr, "/") + path
+	}
+
+	return "http://" + c.addr + path
+}
+
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(resp.Body)
+	return &Error{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(body))}
+}
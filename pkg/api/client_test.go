@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	enginemocks "github.com/jumppad-labs/jumppad/pkg/jumppad/mocks"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupClient(t *testing.T) (*Client, *enginemocks.Engine) {
+	s, me, _ := setupServer(t)
+
+	ts := httptest.NewServer(s.server.Handler)
+	t.Cleanup(ts.Close)
+
+	return NewClient(ts.URL, testToken), me
+}
+
+func TestClientParseReturnsResources(t *testing.T) {
+	c, _ := setupClient(t)
+
+	r, err := c.Parse(context.Background(), ApplyRequest{Path: blueprintDir(t)})
+	require.NoError(t, err)
+
+	require.Len(t, r, 1)
+	require.Equal(t, "resource.container.web", r[0].ID)
+}
+
+func TestClientParseWithServerErrorReturnsError(t *testing.T) {
+	c, _ := setupClient(t)
+
+	_, err := c.Parse(context.Background(), ApplyRequest{Path: "github.com/jumppad-labs/blueprints//consul"})
+
+	apiErr := &Error{}
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.Contains(t, apiErr.Message, "must be a local folder or file")
+}
+
+func TestClientUpReturnsResults(t *testing.T) {
+	c, _ := setupClient(t)
+
+	r, err := c.Up(context.Background(), ApplyRequest{Path: blueprintDir(t)})
+	require.NoError(t, err)
+
+	require.Len(t, r, 1)
+	require.Equal(t, "created", r[0].Status)
+}
+
+func TestClientUpWithErrorReturnsResultsAndError(t *testing.T) {
+	c, me := setupClient(t)
+	testutils.RemoveOn(&me.Mock, "ApplyWithVariables")
+	me.On("ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+	r, err := c.Up(context.Background(), ApplyRequest{Path: blueprintDir(t)})
+	require.ErrorContains(t, err, "boom")
+	require.Len(t, r, 1)
+}
+
+func TestClientDownReturnsResults(t *testing.T) {
+	c, _ := setupClient(t)
+
+	r, err := c.Down(context.Background(), true)
+	require.NoError(t, err)
+	require.Len(t, r, 1)
+}
+
+func TestClientStatusReturnsResources(t *testing.T) {
+	c, _ := setupClient(t)
+
+	r, err := c.Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, r, 1)
+}
+
+func TestClientOutputsReturnsOutputs(t *testing.T) {
+	c, _ := setupClient(t)
+
+	o, err := c.Outputs(context.Background(), false)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"sensitive": false}, o)
+}
+
+func TestClientLogsWithUnknownResourceReturnsError(t *testing.T) {
+	c, _ := setupClient(t)
+
+	_, err := c.Logs(context.Background(), "resource.container.api", false)
+	require.ErrorContains(t, err, "resource resource.container.api not found")
+}
+
+func TestClientWithServerNotRunningReturnsError(t *testing.T) {
+	c := NewClient("localhost:1", testToken)
+
+	_, err := c.Status(context.Background())
+	require.ErrorContains(t, err, "unable to contact jumppad API")
+}
+
+func TestClientWithInvalidTokenReturnsError(t *testing.T) {
+	s, _, _ := setupServer(t)
+
+	ts := httptest.NewServer(s.server.Handler)
+	t.Cleanup(ts.Close)
+
+	_, err := NewClient(ts.URL, "abc").Status(context.Background())
+
+	apiErr := &Error{}
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+}
@@ -0,0 +1,474 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// OutputLister returns the outputs in the state, sensitive values are
+// redacted unless sensitive is true
+type OutputLister func(cfg *hclconfig.Config, sensitive bool) map[string]any
+
+// ContainerLister returns the names of the containers for a resource
+type ContainerLister func(r types.Resource) []string
+
+// Server exposes the engine as a local HTTP API so that tools such as IDE
+// extensions can create and destroy environments without running the CLI,
+// requests must send the token for the server as a bearer token
+type Server struct {
+	server *http.Server
+	log    logger.Logger
+	engine jumppad.Engine
+	docker container.Docker
+	token  string
+
+	// only one operation can change the environment at a time
+	engineMutex sync.Mutex
+
+	outputs    OutputLister
+	containers ContainerLister
+}
+
+// New creates a new API server that listens on addr, requests are only
+// accepted when they send the given token
+func New(addr string, token string, e jumppad.Engine, dc container.Docker, l logger.Logger) *Server {
+	router := chi.NewRouter()
+	router.Use(middleware.RequestID)
+	router.Use(middleware.RequestLogger(&middleware.DefaultLogFormatter{Logger: log.New(l.StandardWriter(), "", log.Default().Flags()), NoColor: true}))
+	router.Use(middleware.Recoverer)
+
+	s := &Server{
+		server: &http.Server{
+			Addr:     addr,
+			Handler:  router,
+			ErrorLog: log.New(l.StandardWriter(), "", log.Default().Flags()),
+		},
+		log:    l,
+		engine: e,
+		docker: dc,
+		token:  token,
+	}
+
+	router.Use(s.checkHost)
+	router.Use(s.authenticate)
+
+	router.Post("/v1/parse", s.parse)
+	router.Post("/v1/up", s.up)
+	router.Post("/v1/down", s.down)
+	router.Get("/v1/status", s.status)
+	router.Get("/v1/outputs", s.listOutputs)
+	router.Get("/v1/logs/{id}", s.logs)
+
+	return s
+}
+
+// SetOutputLister sets the function used to list the outputs for the
+// outputs endpoint
+func (s *Server) SetOutputLister(ol OutputLister) {
+	s.outputs = ol
+}
+
+// SetContainerLister sets the function used to find the containers for the
+// logs endpoint
+func (s *Server) SetContainerLister(cl ContainerLister) {
+	s.containers = cl
+}
+
+// Serve handles requests on the listener until the server is stopped
+func (s *Server) Serve(l net.Listener) error {
+	s.log.Info("Starting API server", "addr", l.Addr().String())
+
+	err := s.server.Serve(l)
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
+}
+
+// Start listens on the address of the server and handles requests until the
+// server is stopped
+func (s *Server) Start() error {
+	l, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %s", s.server.Addr, err)
+	}
+
+	return s.Serve(l)
+}
+
+// Stop the API server, waiting for running requests to complete
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	s.log.Info("Shutdown API server")
+	s.server.Shutdown(ctx)
+}
+
+func (s *Server) parse(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeApplyRequest(w, r)
+	if !ok {
+		return
+	}
+
+	// parsing replaces the engine config so it can not run at the same time
+	// as up or down
+	if !s.engineMutex.TryLock() {
+		http.Error(w, "another operation is in progress", http.StatusConflict)
+		return
+	}
+	defer s.engineMutex.Unlock()
+
+	cfg, err := s.engine.ParseConfigWithVariables(req.Path, req.Variables, req.VariablesFile)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to parse blueprint: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, ParseResponse{Resources: resourceList(cfg)})
+}
+
+func (s *Server) up(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeApplyRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if !s.engineMutex.TryLock() {
+		http.Error(w, "another operation is in progress", http.StatusConflict)
+		return
+	}
+	defer s.engineMutex.Unlock()
+
+	// the apply continues when the client disconnects, cancelling it would
+	// leave the resources half created
+	_, err := s.engine.ApplyWithVariables(context.WithoutCancel(r.Context()), req.Path, req.Variables, req.VariablesFile)
+	s.writeResults(w, err)
+}
+
+func (s *Server) down(w http.ResponseWriter, r *http.Request) {
+	req := DestroyRequest{}
+
+	// the body is optional
+	if r.ContentLength != 0 {
+		if !requireJSON(w, r) {
+			return
+		}
+
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	if !s.engineMutex.TryLock() {
+		http.Error(w, "another operation is in progress", http.StatusConflict)
+		return
+	}
+	defer s.engineMutex.Unlock()
+
+	// like apply the destroy continues when the client disconnects
+	err := s.engine.Destroy(context.WithoutCancel(r.Context()), req.Force)
+	s.writeResults(w, err)
+}
+
+func (s *Server) status(w http.ResponseWriter, r *http.Request) {
+	cfg, ok := s.loadState(w)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, StatusResponse{Resources: resourceList(cfg)})
+}
+
+func (s *Server) listOutputs(w http.ResponseWriter, r *http.Request) {
+	if s.outputs == nil {
+		http.Error(w, "outputs are not supported by this server", http.StatusNotImplemented)
+		return
+	}
+
+	cfg, ok := s.loadState(w)
+	if !ok {
+		return
+	}
+
+	sensitive, _ := strconv.ParseBool(r.URL.Query().Get("sensitive"))
+
+	writeJSON(w, http.StatusOK, s.outputs(cfg, sensitive))
+}
+
+// logs streams the logs of the containers for a resource, the logs of all
+// containers are interleaved and each line is prefixed with the container
+// name
+func (s *Server) logs(w http.ResponseWriter, r *http.Request) {
+	if s.containers == nil || s.docker == nil {
+		http.Error(w, "logs are not supported by this server", http.StatusNotImplemented)
+		return
+	}
+
+	cfg, ok := s.loadState(w)
+	if !ok {
+		return
+	}
+
+	id := chi.URLParam(r, "id")
+
+	res, err := cfg.FindResource(id)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("resource %s not found", id), http.StatusNotFound)
+		return
+	}
+
+	names := s.containers(res)
+	if len(names) == 0 {
+		http.Error(w, fmt.Sprintf("resource %s does not have any containers", id), http.StatusBadRequest)
+		return
+	}
+
+	follow, _ := strconv.ParseBool(r.URL.Query().Get("follow"))
+
+	tail := r.URL.Query().Get("tail")
+	if tail == "" {
+		tail = "40"
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+
+	out := &streamWriter{w: w}
+	wg := sync.WaitGroup{}
+
+	for _, n := range names {
+		rc, err := s.docker.ContainerLogs(r.Context(), n, dcontainer.LogsOptions{ShowStdout: true, ShowStderr: true, Follow: follow, Tail: tail})
+		if err != nil {
+			s.log.Error("Unable to read container logs", "container", n, "error", err)
+			continue
+		}
+
+		wg.Add(1)
+		go func(name string, rc io.ReadCloser) {
+			defer wg.Done()
+			defer rc.Close()
+
+			pw := &prefixWriter{prefix: fmt.Sprintf("[%s] ", strings.TrimSuffix(name, "."+utils.LocalTLD)), w: out}
+			stdcopy.StdCopy(pw, pw, rc)
+			pw.Flush()
+		}(n, rc)
+	}
+
+	wg.Wait()
+}
+
+// checkHost rejects requests for any host other than the loopback interface
+// or the address of the server, this stops web pages in the browser calling
+// the API using a DNS name that resolves to the local machine
+func (s *Server) checkHost(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			host = r.Host
+		}
+
+		bind, _, _ := net.SplitHostPort(s.server.Addr)
+
+		ip := net.ParseIP(strings.Trim(host, "[]"))
+		if host != "localhost" && (ip == nil || !ip.IsLoopback()) && (bind == "" || host != bind) {
+			http.Error(w, fmt.Sprintf("host %s is not allowed", r.Host), http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// authenticate rejects requests that do not send the token for the server
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || s.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			http.Error(w, "invalid or missing API token", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (s *Server) loadState(w http.ResponseWriter) (*hclconfig.Config, bool) {
+	// there is no state until the first blueprint is applied
+	if _, err := os.Stat(utils.StatePath()); os.IsNotExist(err) {
+		return hclconfig.NewConfig(), true
+	}
+
+	cfg, err := config.LoadState()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to load state: %s", err), http.StatusInternalServerError)
+		return nil, false
+	}
+
+	return cfg, true
+}
+
+// writeResults writes the results of the last engine operation, the status
+// code is 500 when the operation failed
+func (s *Server) writeResults(w http.ResponseWriter, err error) {
+	resp := ApplyResponse{Results: []ResourceResult{}}
+
+	for _, res := range s.engine.Results() {
+		rr := ResourceResult{ID: res.ID, Type: res.Type, Status: res.Status, Duration: res.Duration}
+		if res.Error != nil {
+			rr.Error = res.Error.Error()
+		}
+
+		resp.Results = append(resp.Results, rr)
+	}
+
+	if err != nil {
+		resp.Error = err.Error()
+		writeJSON(w, http.StatusInternalServerError, resp)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func decodeApplyRequest(w http.ResponseWriter, r *http.Request) (ApplyRequest, bool) {
+	req := ApplyRequest{}
+
+	if !requireJSON(w, r) {
+		return req, false
+	}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid request: %s", err), http.StatusBadRequest)
+		return req, false
+	}
+
+	// remote blueprints are downloaded by the CLI, the API only works with
+	// blueprints on the local machine
+	if !utils.IsLocalFolder(req.Path) && !utils.IsHCLFile(req.Path) {
+		http.Error(w, fmt.Sprintf("blueprint %s must be a local folder or file", req.Path), http.StatusBadRequest)
+		return req, false
+	}
+
+	return req, true
+}
+
+// requireJSON returns false and writes an error when the body of the request
+// is not JSON, browsers can send form and text bodies to any site without a
+// preflight request
+func requireJSON(w http.ResponseWriter, r *http.Request) bool {
+	mt, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mt != "application/json" {
+		http.Error(w, "the request body must be JSON, set the Content-Type header to application/json", http.StatusUnsupportedMediaType)
+		return false
+	}
+
+	return true
+}
+
+func resourceList(cfg *hclconfig.Config) []Resource {
+	list := []Resource{}
+
+	for _, r := range cfg.Resources {
+		status, _ := r.Metadata().Properties[constants.PropertyStatus].(string)
+
+		list = append(list, Resource{
+			ID:       r.Metadata().ID,
+			Name:     r.Metadata().Name,
+			Type:     r.Metadata().Type,
+			Module:   r.Metadata().Module,
+			Status:   status,
+			Disabled: r.GetDisabled(),
+		})
+	}
+
+	return list
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// streamWriter serializes writes from multiple log streams and flushes each
+// write so that the client receives the logs as they are written
+type streamWriter struct {
+	sync.Mutex
+	w http.ResponseWriter
+}
+
+func (s *streamWriter) Write(p []byte) (int, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	n, err := s.w.Write(p)
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+
+	return n, err
+}
+
+// prefixWriter writes complete lines prefixed with the container name
+type prefixWriter struct {
+	prefix string
+	w      io.Writer
+	buf    bytes.Buffer
+}
+
+func (p *prefixWriter) Write(d []byte) (int, error) {
+	p.buf.Write(d)
+
+	for {
+		i := bytes.IndexByte(p.buf.Bytes(), '\n')
+		if i < 0 {
+			return len(d), nil
+		}
+
+		line := p.buf.Next(i + 1)
+
+		_, err := p.w.Write(append([]byte(p.prefix), line...))
+		if err != nil {
+			return len(d), err
+		}
+	}
+}
+
+// Flush writes any partial line that does not end with a new line
+func (p *prefixWriter) Flush() {
+	if p.buf.Len() > 0 {
+		p.w.Write(append([]byte(p.prefix), append(p.buf.Bytes(), '\n')...))
+		p.buf.Reset()
+	}
+}
@@ -0,0 +1,369 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/types"
+	cmocks "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	enginemocks "github.com/jumppad-labs/jumppad/pkg/jumppad/mocks"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "abc123"
+
+var apiState = `
+{
+  "blueprint": null,
+  "resources": [
+	{
+		"meta": {
+			"id": "resource.container.web",
+			"name": "web",
+			"type": "container",
+			"properties": {
+				"status": "created"
+			}
+		}
+	}
+  ]
+}`
+
+func setupServer(t *testing.T) (*Server, *enginemocks.Engine, *cmocks.Docker) {
+	testutils.SetupState(t, apiState)
+
+	web := &container.Container{}
+	web.Meta = types.Meta{ID: "resource.container.web", Name: "web", Type: container.TypeContainer}
+
+	cfg := hclconfig.NewConfig()
+	cfg.AppendResource(web)
+
+	me := &enginemocks.Engine{}
+	me.On("ParseConfigWithVariables", mock.Anything, mock.Anything, mock.Anything).Return(cfg, nil)
+	me.On("ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(cfg, nil)
+	me.On("Destroy", mock.Anything, mock.Anything).Return(nil)
+	me.On("Results").Return([]jumppad.ResourceResult{
+		{ID: "resource.container.web", Type: container.TypeContainer, Status: constants.StatusCreated},
+	})
+
+	md := &cmocks.Docker{}
+
+	s := New(":0", testToken, me, md, logger.NewTestLogger(t))
+	s.SetOutputLister(func(cfg *hclconfig.Config, sensitive bool) map[string]any {
+		return map[string]any{"sensitive": sensitive}
+	})
+	s.SetContainerLister(func(r types.Resource) []string {
+		return []string{r.Metadata().Name + ".container.local.jmpd.in"}
+	})
+
+	return s, me, md
+}
+
+func applyBody(t *testing.T, path string) io.Reader {
+	d, err := json.Marshal(ApplyRequest{Path: path, Variables: map[string]string{"version": "1.0"}})
+	require.NoError(t, err)
+
+	return bytes.NewReader(d)
+}
+
+func blueprintDir(t *testing.T) string {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(""), 0644)
+
+	return dir
+}
+
+// serve sends the request to the server with the headers sent by the client
+// unless the test has set them
+func serve(s *Server, r *http.Request) *httptest.ResponseRecorder {
+	r.Host = "localhost:9095"
+
+	if r.Header.Get("Authorization") == "" {
+		r.Header.Set("Authorization", "Bearer "+testToken)
+	}
+
+	if r.ContentLength != 0 && r.Header.Get("Content-Type") == "" {
+		r.Header.Set("Content-Type", "application/json")
+	}
+
+	rr := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rr, r)
+
+	return rr
+}
+
+func TestParseReturnsResources(t *testing.T) {
+	s, me, _ := setupServer(t)
+	dir := blueprintDir(t)
+
+	rr := serve(s, httptest.NewRequest(http.MethodPost, "/v1/parse", applyBody(t, dir)))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	resp := ParseResponse{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	require.Len(t, resp.Resources, 1)
+	require.Equal(t, "resource.container.web", resp.Resources[0].ID)
+
+	me.AssertCalled(t, "ParseConfigWithVariables", dir, map[string]string{"version": "1.0"}, "")
+}
+
+func TestParseWithRemoteBlueprintReturnsError(t *testing.T) {
+	s, me, _ := setupServer(t)
+
+	rr := serve(s, httptest.NewRequest(http.MethodPost, "/v1/parse", applyBody(t, "github.com/jumppad-labs/blueprints//consul")))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Contains(t, rr.Body.String(), "must be a local folder or file")
+
+	me.AssertNotCalled(t, "ParseConfigWithVariables", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParseWithInvalidBlueprintReturnsError(t *testing.T) {
+	s, me, _ := setupServer(t)
+	testutils.RemoveOn(&me.Mock, "ParseConfigWithVariables")
+	me.On("ParseConfigWithVariables", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+	rr := serve(s, httptest.NewRequest(http.MethodPost, "/v1/parse", applyBody(t, blueprintDir(t))))
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+	require.Contains(t, rr.Body.String(), "unable to parse blueprint: boom")
+}
+
+func TestParseWhileOperationRunningReturnsConflict(t *testing.T) {
+	s, me, _ := setupServer(t)
+	s.engineMutex.Lock()
+	defer s.engineMutex.Unlock()
+
+	rr := serve(s, httptest.NewRequest(http.MethodPost, "/v1/parse", applyBody(t, blueprintDir(t))))
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	me.AssertNotCalled(t, "ParseConfigWithVariables", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUpAppliesBlueprintAndReturnsResults(t *testing.T) {
+	s, me, _ := setupServer(t)
+	dir := blueprintDir(t)
+
+	rr := serve(s, httptest.NewRequest(http.MethodPost, "/v1/up", applyBody(t, dir)))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	resp := ApplyResponse{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	require.Len(t, resp.Results, 1)
+	require.Equal(t, constants.StatusCreated, resp.Results[0].Status)
+
+	me.AssertCalled(t, "ApplyWithVariables", mock.Anything, dir, map[string]string{"version": "1.0"}, "")
+}
+
+func TestUpWithErrorReturnsResultsAndError(t *testing.T) {
+	s, me, _ := setupServer(t)
+	testutils.RemoveOn(&me.Mock, "ApplyWithVariables")
+	me.On("ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("boom"))
+
+	rr := serve(s, httptest.NewRequest(http.MethodPost, "/v1/up", applyBody(t, blueprintDir(t))))
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+
+	resp := ApplyResponse{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	require.Equal(t, "boom", resp.Error)
+	require.Len(t, resp.Results, 1)
+}
+
+func TestUpWhileOperationRunningReturnsConflict(t *testing.T) {
+	s, me, _ := setupServer(t)
+	s.engineMutex.Lock()
+	defer s.engineMutex.Unlock()
+
+	rr := serve(s, httptest.NewRequest(http.MethodPost, "/v1/up", applyBody(t, blueprintDir(t))))
+	require.Equal(t, http.StatusConflict, rr.Code)
+
+	me.AssertNotCalled(t, "ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDownDestroysResources(t *testing.T) {
+	s, me, _ := setupServer(t)
+
+	rr := serve(s, httptest.NewRequest(http.MethodPost, "/v1/down", strings.NewReader(`{"force": true}`)))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	me.AssertCalled(t, "Destroy", mock.Anything, true)
+}
+
+func TestDownWithoutBodyDestroysResources(t *testing.T) {
+	s, me, _ := setupServer(t)
+
+	rr := serve(s, httptest.NewRequest(http.MethodPost, "/v1/down", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	me.AssertCalled(t, "Destroy", mock.Anything, false)
+}
+
+func TestStatusReturnsResourcesInState(t *testing.T) {
+	s, _, _ := setupServer(t)
+
+	rr := serve(s, httptest.NewRequest(http.MethodGet, "/v1/status", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	resp := StatusResponse{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	require.Len(t, resp.Resources, 1)
+	require.Equal(t, "resource.container.web", resp.Resources[0].ID)
+	require.Equal(t, constants.StatusCreated, resp.Resources[0].Status)
+}
+
+func TestStatusWithoutStateReturnsNoResources(t *testing.T) {
+	s, _, _ := setupServer(t)
+	testutils.SetupState(t, "")
+
+	rr := serve(s, httptest.NewRequest(http.MethodGet, "/v1/status", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"resources": []}`, rr.Body.String())
+}
+
+func TestOutputsReturnsOutputs(t *testing.T) {
+	s, _, _ := setupServer(t)
+
+	rr := serve(s, httptest.NewRequest(http.MethodGet, "/v1/outputs?sensitive=true", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.JSONEq(t, `{"sensitive": true}`, rr.Body.String())
+}
+
+func TestLogsStreamsContainerLogs(t *testing.T) {
+	s, _, md := setupServer(t)
+
+	logs := bytes.NewBuffer(nil)
+	stdcopy.NewStdWriter(logs, stdcopy.Stdout).Write([]byte("started\nlistening"))
+
+	md.On("ContainerLogs", mock.Anything, "web.container.local.jmpd.in", mock.Anything).Return(io.NopCloser(logs), nil)
+
+	rr := serve(s, httptest.NewRequest(http.MethodGet, "/v1/logs/resource.container.web", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "[web.container.local] started\n[web.container.local] listening\n", rr.Body.String())
+}
+
+func TestLogsWithUnknownResourceReturnsNotFound(t *testing.T) {
+	s, _, _ := setupServer(t)
+
+	rr := serve(s, httptest.NewRequest(http.MethodGet, "/v1/logs/resource.container.api", nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestServeHandlesRequests(t *testing.T) {
+	s, _, _ := setupServer(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	go s.Serve(l)
+	defer s.Stop()
+
+	resources, err := NewClient(l.Addr().String(), testToken).Status(context.Background())
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+}
+
+func TestRequestWithoutTokenReturnsUnauthorized(t *testing.T) {
+	s, _, _ := setupServer(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/status", nil)
+	r.Header.Set("Authorization", "none")
+
+	rr := serve(s, r)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+}
+
+func TestRequestWithInvalidTokenReturnsUnauthorized(t *testing.T) {
+	s, me, _ := setupServer(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/down", nil)
+	r.Header.Set("Authorization", "Bearer abc")
+
+	rr := serve(s, r)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	me.AssertNotCalled(t, "Destroy", mock.Anything, mock.Anything)
+}
+
+func TestRequestWithUnknownHostReturnsForbidden(t *testing.T) {
+	s, me, _ := setupServer(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/down", nil)
+	r.Header.Set("Authorization", "Bearer "+testToken)
+	r.Host = "attacker.example.com:9095"
+
+	rr := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rr, r)
+	require.Equal(t, http.StatusForbidden, rr.Code)
+
+	me.AssertNotCalled(t, "Destroy", mock.Anything, mock.Anything)
+}
+
+func TestRequestWithLoopbackHostIsAllowed(t *testing.T) {
+	s, _, _ := setupServer(t)
+
+	r := httptest.NewRequest(http.MethodGet, "/v1/status", nil)
+	r.Header.Set("Authorization", "Bearer "+testToken)
+	r.Host = "127.0.0.1:9095"
+
+	rr := httptest.NewRecorder()
+	s.server.Handler.ServeHTTP(rr, r)
+	require.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestUpWithNonJSONBodyReturnsUnsupportedMediaType(t *testing.T) {
+	s, me, _ := setupServer(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/up", applyBody(t, blueprintDir(t)))
+	r.Header.Set("Content-Type", "text/plain")
+
+	rr := serve(s, r)
+	require.Equal(t, http.StatusUnsupportedMediaType, rr.Code)
+
+	me.AssertNotCalled(t, "ApplyWithVariables", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDownWithNonJSONBodyReturnsUnsupportedMediaType(t *testing.T) {
+	s, me, _ := setupServer(t)
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/down", strings.NewReader(`{"force": true}`))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rr := serve(s, r)
+	require.Equal(t, http.StatusUnsupportedMediaType, rr.Code)
+
+	me.AssertNotCalled(t, "Destroy", mock.Anything, mock.Anything)
+}
+
+func TestUpContinuesWhenClientDisconnects(t *testing.T) {
+	s, me, _ := setupServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r := httptest.NewRequestWithContext(ctx, http.MethodPost, "/v1/up", applyBody(t, blueprintDir(t)))
+
+	rr := serve(s, r)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	me.AssertCalled(t, "ApplyWithVariables", mock.MatchedBy(func(ctx context.Context) bool { return ctx.Err() == nil }), mock.Anything, mock.Anything, mock.Anything)
+}
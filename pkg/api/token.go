@@ -0,0 +1,56 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// WriteToken generates a new token for the API server and writes it to the
+// token file so that only the current user can read it, the previous token
+// is replaced so that each server has its own token
+func WriteToken() (string, error) {
+	b := make([]byte, 32)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate API token: %s", err)
+	}
+
+	token := hex.EncodeToString(b)
+
+	err = os.MkdirAll(filepath.Dir(utils.APITokenPath()), os.ModePerm)
+	if err != nil {
+		return "", fmt.Errorf("unable to create directory for API token: %s", err)
+	}
+
+	// remove any previous token, WriteFile does not change the permissions
+	// of an existing file
+	os.Remove(utils.APITokenPath())
+
+	err = os.WriteFile(utils.APITokenPath(), []byte(token), 0600)
+	if err != nil {
+		return "", fmt.Errorf("unable to write API token %s: %s", utils.APITokenPath(), err)
+	}
+
+	return token, nil
+}
+
+// ReadToken returns the token written by the running API server
+func ReadToken() (string, error) {
+	d, err := os.ReadFile(utils.APITokenPath())
+	if err != nil {
+		return "", fmt.Errorf("unable to read API token %s, is the API server running: %s", utils.APITokenPath(), err)
+	}
+
+	return strings.TrimSpace(string(d)), nil
+}
+
+// RemoveToken removes the token file when the API server stops
+func RemoveToken() {
+	os.Remove(utils.APITokenPath())
+}
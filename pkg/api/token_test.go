@@ -0,0 +1,56 @@
+package api
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func setupToken(t *testing.T) {
+	home := os.Getenv(utils.HomeEnvName())
+	os.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	t.Cleanup(func() {
+		os.Setenv(utils.HomeEnvName(), home)
+	})
+}
+
+func TestWriteTokenWritesTokenReadableByUser(t *testing.T) {
+	setupToken(t)
+
+	token, err := WriteToken()
+	require.NoError(t, err)
+	require.Len(t, token, 64)
+
+	fi, err := os.Stat(utils.APITokenPath())
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	read, err := ReadToken()
+	require.NoError(t, err)
+	require.Equal(t, token, read)
+}
+
+func TestWriteTokenReplacesPreviousToken(t *testing.T) {
+	setupToken(t)
+
+	first, err := WriteToken()
+	require.NoError(t, err)
+
+	second, err := WriteToken()
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+
+	read, err := ReadToken()
+	require.NoError(t, err)
+	require.Equal(t, second, read)
+}
+
+func TestReadTokenWithoutServerReturnsError(t *testing.T) {
+	setupToken(t)
+
+	_, err := ReadToken()
+	require.ErrorContains(t, err, "is the API server running")
+}
@@ -0,0 +1,57 @@
+package api
+
+import "time"
+
+// ApplyRequest is the body for the parse and up endpoints
+type ApplyRequest struct {
+	// Path of the local folder or file containing the blueprint
+	Path string `json:"path"`
+
+	// Variables override the default values of the blueprint variables
+	Variables map[string]string `json:"variables,omitempty"`
+
+	// VariablesFile is the path of a file containing variables
+	VariablesFile string `json:"variables_file,omitempty"`
+}
+
+// DestroyRequest is the body for the down endpoint
+type DestroyRequest struct {
+	// Force destroys containers without waiting for a graceful shutdown
+	Force bool `json:"force,omitempty"`
+}
+
+// Resource describes a resource in a blueprint or in the state
+type Resource struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Module   string `json:"module,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+// ResourceResult is the outcome of creating or destroying a resource
+type ResourceResult struct {
+	ID       string        `json:"id"`
+	Type     string        `json:"type"`
+	Status   string        `json:"status"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// ParseResponse is returned by the parse endpoint
+type ParseResponse struct {
+	Resources []Resource `json:"resources"`
+}
+
+// ApplyResponse is returned by the up and down endpoints, the results are
+// returned when the operation fails so that the failed resources are known
+type ApplyResponse struct {
+	Results []ResourceResult `json:"results"`
+	Error   string           `json:"error,omitempty"`
+}
+
+// StatusResponse is returned by the status endpoint
+type StatusResponse struct {
+	Resources []Resource `json:"resources"`
+}
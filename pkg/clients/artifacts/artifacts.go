@@ -0,0 +1,370 @@
+package artifacts
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	cp "github.com/otiai10/copy"
+)
+
+// indexFile is the name of the file in the store directory that records the
+// sources and usage of each artifact
+const indexFile = "index.json"
+
+// tempFolder is the folder in the store directory where content is
+// downloaded before it is added to the store
+const tempFolder = "tmp"
+
+// Artifact is a downloaded directory in the store, artifacts are stored by
+// the hash of their content so content downloaded from different sources is
+// only stored once
+type Artifact struct {
+	// Key is the name of the directory in the store containing the artifact
+	Key string `json:"key"`
+	// Hash is the dirhash of the content
+	Hash string `json:"hash"`
+	// Sources are the URIs the content has been downloaded from
+	Sources []string  `json:"sources"`
+	Size    int64     `json:"size"`
+	Created time.Time `json:"created"`
+	// Used is the last time the artifact was added or read from the store
+	Used time.Time `json:"used"`
+}
+
+// Store is a content addressed cache for downloaded blueprints, modules,
+// Helm charts and files that is shared between environments
+//
+//go:generate mockery --name Store --filename store.go
+type Store interface {
+	// Lookup returns the location of the artifact downloaded from source, false
+	// is returned when the store does not contain the source or the content
+	// has been modified
+	Lookup(source string) (string, bool)
+	// Find returns the location of the artifact with the given hash, false is
+	// returned when the store does not contain the content or it has been
+	// modified
+	Find(hash string) (string, bool)
+	// Add moves the directory at path into the store and records that it was
+	// downloaded from source
+	Add(source, path string) (*Artifact, error)
+	// TempDir creates a directory to download content to before it is added,
+	// the directory is on the same filesystem as the store so content can be
+	// moved rather than copied
+	TempDir() (string, error)
+	// List returns the artifacts ordered by the time they were last used
+	List() ([]Artifact, error)
+	// Remove deletes the artifact with the given key
+	Remove(key string) error
+	// Purge removes the artifacts that have not been used for the given
+	// duration, a duration of 0 removes all artifacts
+	Purge(unused time.Duration) ([]Artifact, error)
+}
+
+// StoreImpl is a concrete implementation of the Store interface that stores
+// artifacts in a directory
+type StoreImpl struct {
+	dir string
+	mu  sync.Mutex
+	now func() time.Time
+}
+
+// NewStore creates a new Store that stores artifacts in dir
+func NewStore(dir string) *StoreImpl {
+	return &StoreImpl{dir: dir, now: time.Now}
+}
+
+// Key converts the dirhash into a string that can be used as a folder name
+func Key(h string) string {
+	return strings.NewReplacer(":", "-", "/", "_", "+", "-", "=", "").Replace(h)
+}
+
+func (s *StoreImpl) Lookup(source string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := s.readIndex()
+	for _, a := range idx {
+		if contains(a.Sources, source) {
+			return s.use(idx, a.Hash)
+		}
+	}
+
+	return "", false
+}
+
+func (s *StoreImpl) Find(hash string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.use(s.readIndex(), hash)
+}
+
+func (s *StoreImpl) Add(source, path string) (*Artifact, error) {
+	h, err := utils.HashDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to hash %s: %w", path, err)
+	}
+
+	size, err := dirSize(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := s.readIndex()
+	dst := filepath.Join(s.dir, Key(h))
+
+	a, ok := idx[Key(h)]
+	if !ok || !s.valid(h) {
+		os.RemoveAll(dst)
+
+		err := moveDir(path, dst)
+		if err != nil {
+			return nil, fmt.Errorf("unable to add %s to the store: %w", source, err)
+		}
+
+		a = Artifact{Key: Key(h), Hash: h, Sources: []string{}, Size: size, Created: s.now()}
+	}
+
+	// the same source can return different content, e.g. a git branch, the
+	// source only refers to the latest content
+	for k, o := range idx {
+		if k != a.Key {
+			o.Sources = remove(o.Sources, source)
+			idx[k] = o
+		}
+	}
+
+	if !contains(a.Sources, source) {
+		a.Sources = append(a.Sources, source)
+	}
+
+	a.Used = s.now()
+	idx[a.Key] = a
+
+	err = s.writeIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func (s *StoreImpl) TempDir() (string, error) {
+	tmp := filepath.Join(s.dir, tempFolder)
+
+	err := os.MkdirAll(tmp, os.ModePerm)
+	if err != nil {
+		return "", fmt.Errorf("unable to create store directory: %w", err)
+	}
+
+	return os.MkdirTemp(tmp, "download-")
+}
+
+func (s *StoreImpl) List() ([]Artifact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	list := []Artifact{}
+	for _, a := range s.readIndex() {
+		list = append(list, a)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Used.Equal(list[j].Used) {
+			return list[i].Key < list[j].Key
+		}
+
+		return list[i].Used.After(list[j].Used)
+	})
+
+	return list, nil
+}
+
+func (s *StoreImpl) Remove(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := s.readIndex()
+	if _, ok := idx[key]; !ok {
+		return fmt.Errorf("artifact %s does not exist", key)
+	}
+
+	err := os.RemoveAll(filepath.Join(s.dir, key))
+	if err != nil {
+		return fmt.Errorf("unable to remove artifact %s: %w", key, err)
+	}
+
+	delete(idx, key)
+
+	return s.writeIndex(idx)
+}
+
+func (s *StoreImpl) Purge(unused time.Duration) ([]Artifact, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idx := s.readIndex()
+	removed := []Artifact{}
+
+	for k, a := range idx {
+		if unused > 0 && s.now().Sub(a.Used) < unused {
+			continue
+		}
+
+		err := os.RemoveAll(filepath.Join(s.dir, k))
+		if err != nil {
+			return removed, fmt.Errorf("unable to remove artifact %s: %w", k, err)
+		}
+
+		delete(idx, k)
+		removed = append(removed, a)
+	}
+
+	// downloads that were interrupted before they were added are left in
+	// the temp folder
+	if unused == 0 {
+		os.RemoveAll(filepath.Join(s.dir, tempFolder))
+	}
+
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Key < removed[j].Key })
+
+	return removed, s.writeIndex(idx)
+}
+
+// use returns the location of the content with the given hash and records
+// that it has been used, the lock must be held by the caller
+func (s *StoreImpl) use(idx map[string]Artifact, hash string) (string, bool) {
+	a, ok := idx[Key(hash)]
+	if !ok || !s.valid(hash) {
+		return "", false
+	}
+
+	a.Used = s.now()
+	idx[a.Key] = a
+
+	// failing to record the usage only affects purging
+	s.writeIndex(idx)
+
+	return filepath.Join(s.dir, a.Key), true
+}
+
+// valid returns true when the content in the store matches the hash
+func (s *StoreImpl) valid(hash string) bool {
+	h, err := utils.HashDir(filepath.Join(s.dir, Key(hash)))
+	return err == nil && h == hash
+}
+
+func (s *StoreImpl) readIndex() map[string]Artifact {
+	idx := map[string]Artifact{}
+
+	d, err := os.ReadFile(filepath.Join(s.dir, indexFile))
+	if err != nil {
+		return idx
+	}
+
+	// a corrupt index is replaced, the content is added again when it is
+	// next downloaded
+	json.Unmarshal(d, &idx)
+
+	return idx
+}
+
+// writeIndex replaces the index, the index is written to a temporary file and
+// renamed so that other processes never read a partially written index
+func (s *StoreImpl) writeIndex(idx map[string]Artifact) error {
+	err := os.MkdirAll(s.dir, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("unable to create store directory: %w", err)
+	}
+
+	d, _ := json.MarshalIndent(idx, "", "  ")
+
+	tmp, err := os.CreateTemp(s.dir, ".index-*")
+	if err != nil {
+		return fmt.Errorf("unable to write store index: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(d)
+	tmp.Close()
+	if err != nil {
+		return fmt.Errorf("unable to write store index: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), filepath.Join(s.dir, indexFile))
+	if err != nil {
+		return fmt.Errorf("unable to write store index: %w", err)
+	}
+
+	return nil
+}
+
+// moveDir moves src to dst, when src is on a different filesystem the
+// directory is copied
+func moveDir(src, dst string) error {
+	err := os.MkdirAll(filepath.Dir(dst), os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	if os.Rename(src, dst) == nil {
+		return nil
+	}
+
+	return cp.Copy(src, dst)
+}
+
+func dirSize(dir string) (int64, error) {
+	size := int64(0)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Type().IsRegular() {
+			i, err := d.Info()
+			if err != nil {
+				return err
+			}
+
+			size += i.Size()
+		}
+
+		return nil
+	})
+
+	return size, err
+}
+
+func contains(l []string, s string) bool {
+	for _, i := range l {
+		if i == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func remove(l []string, s string) []string {
+	n := []string{}
+	for _, i := range l {
+		if i != s {
+			n = append(n, i)
+		}
+	}
+
+	return n
+}
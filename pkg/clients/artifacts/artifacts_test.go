@@ -0,0 +1,224 @@
+package artifacts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func setupStore(t *testing.T) *StoreImpl {
+	return NewStore(t.TempDir())
+}
+
+func createContent(t *testing.T, s *StoreImpl, content string) string {
+	dir, err := s.TempDir()
+	require.NoError(t, err)
+
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(content), 0644)
+
+	return dir
+}
+
+func TestAddMovesContentToStore(t *testing.T) {
+	s := setupStore(t)
+	dir := createContent(t, s, `resource "network" "main" {}`)
+
+	h, err := utils.HashDir(dir)
+	require.NoError(t, err)
+
+	a, err := s.Add("github.com/org/repo//consul", dir)
+	require.NoError(t, err)
+
+	require.Equal(t, h, a.Hash)
+	require.Equal(t, Key(h), a.Key)
+	require.Equal(t, []string{"github.com/org/repo//consul"}, a.Sources)
+	require.Equal(t, int64(28), a.Size)
+	require.FileExists(t, filepath.Join(s.dir, a.Key, "main.hcl"))
+	require.NoDirExists(t, dir)
+}
+
+func TestAddWithSameContentStoresOnce(t *testing.T) {
+	s := setupStore(t)
+
+	_, err := s.Add("https://example.com/one.zip", createContent(t, s, `one`))
+	require.NoError(t, err)
+
+	a, err := s.Add("https://example.com/two.zip", createContent(t, s, `one`))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"https://example.com/one.zip", "https://example.com/two.zip"}, a.Sources)
+
+	l, err := s.List()
+	require.NoError(t, err)
+	require.Len(t, l, 1)
+}
+
+func TestAddWithChangedContentMovesSource(t *testing.T) {
+	s := setupStore(t)
+
+	old, err := s.Add("github.com/org/repo?ref=main", createContent(t, s, `one`))
+	require.NoError(t, err)
+
+	_, err = s.Add("github.com/org/repo?ref=main", createContent(t, s, `two`))
+	require.NoError(t, err)
+
+	p, ok := s.Lookup("github.com/org/repo?ref=main")
+	require.True(t, ok)
+
+	d, _ := os.ReadFile(filepath.Join(p, "main.hcl"))
+	require.Equal(t, "two", string(d))
+
+	l, err := s.List()
+	require.NoError(t, err)
+
+	for _, a := range l {
+		if a.Key == old.Key {
+			require.Empty(t, a.Sources)
+		}
+	}
+}
+
+func TestLookupReturnsStoredContent(t *testing.T) {
+	s := setupStore(t)
+
+	a, err := s.Add("https://example.com/chart.tgz", createContent(t, s, `chart`))
+	require.NoError(t, err)
+
+	p, ok := s.Lookup("https://example.com/chart.tgz")
+	require.True(t, ok)
+	require.Equal(t, filepath.Join(s.dir, a.Key), p)
+}
+
+func TestLookupWithUnknownSourceReturnsFalse(t *testing.T) {
+	s := setupStore(t)
+
+	_, ok := s.Lookup("https://example.com/chart.tgz")
+	require.False(t, ok)
+}
+
+func TestLookupWithModifiedContentReturnsFalse(t *testing.T) {
+	s := setupStore(t)
+
+	a, err := s.Add("https://example.com/chart.tgz", createContent(t, s, `chart`))
+	require.NoError(t, err)
+
+	os.WriteFile(filepath.Join(s.dir, a.Key, "main.hcl"), []byte(`modified`), 0644)
+
+	_, ok := s.Lookup("https://example.com/chart.tgz")
+	require.False(t, ok)
+}
+
+func TestFindReturnsContentForHash(t *testing.T) {
+	s := setupStore(t)
+
+	a, err := s.Add("oci://ghcr.io/org/modules:v1.0.0", createContent(t, s, `module`))
+	require.NoError(t, err)
+
+	p, ok := s.Find(a.Hash)
+	require.True(t, ok)
+	require.Equal(t, filepath.Join(s.dir, a.Key), p)
+}
+
+func TestFindUpdatesLastUsed(t *testing.T) {
+	s := setupStore(t)
+	s.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	a, err := s.Add("oci://ghcr.io/org/modules:v1.0.0", createContent(t, s, `module`))
+	require.NoError(t, err)
+
+	used := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	s.now = func() time.Time { return used }
+
+	_, ok := s.Find(a.Hash)
+	require.True(t, ok)
+
+	l, err := s.List()
+	require.NoError(t, err)
+	require.True(t, used.Equal(l[0].Used))
+}
+
+func TestListOrdersByLastUsed(t *testing.T) {
+	s := setupStore(t)
+
+	s.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	one, err := s.Add("https://example.com/one.zip", createContent(t, s, `one`))
+	require.NoError(t, err)
+
+	s.now = func() time.Time { return time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC) }
+	two, err := s.Add("https://example.com/two.zip", createContent(t, s, `two`))
+	require.NoError(t, err)
+
+	l, err := s.List()
+	require.NoError(t, err)
+	require.Equal(t, two.Key, l[0].Key)
+	require.Equal(t, one.Key, l[1].Key)
+}
+
+func TestRemoveDeletesArtifact(t *testing.T) {
+	s := setupStore(t)
+
+	a, err := s.Add("https://example.com/one.zip", createContent(t, s, `one`))
+	require.NoError(t, err)
+
+	err = s.Remove(a.Key)
+	require.NoError(t, err)
+
+	require.NoDirExists(t, filepath.Join(s.dir, a.Key))
+
+	l, err := s.List()
+	require.NoError(t, err)
+	require.Empty(t, l)
+}
+
+func TestRemoveWithUnknownKeyReturnsError(t *testing.T) {
+	s := setupStore(t)
+
+	err := s.Remove("h1-unknown")
+	require.Error(t, err)
+}
+
+func TestPurgeRemovesUnusedArtifacts(t *testing.T) {
+	s := setupStore(t)
+
+	s.now = func() time.Time { return time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC) }
+	old, err := s.Add("https://example.com/one.zip", createContent(t, s, `one`))
+	require.NoError(t, err)
+
+	s.now = func() time.Time { return time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC) }
+	recent, err := s.Add("https://example.com/two.zip", createContent(t, s, `two`))
+	require.NoError(t, err)
+
+	removed, err := s.Purge(7 * 24 * time.Hour)
+	require.NoError(t, err)
+
+	require.Len(t, removed, 1)
+	require.Equal(t, old.Key, removed[0].Key)
+	require.NoDirExists(t, filepath.Join(s.dir, old.Key))
+	require.DirExists(t, filepath.Join(s.dir, recent.Key))
+}
+
+func TestPurgeWithZeroDurationRemovesAllArtifacts(t *testing.T) {
+	s := setupStore(t)
+
+	_, err := s.Add("https://example.com/one.zip", createContent(t, s, `one`))
+	require.NoError(t, err)
+
+	_, err = s.Add("https://example.com/two.zip", createContent(t, s, `two`))
+	require.NoError(t, err)
+
+	createContent(t, s, `interrupted`)
+
+	removed, err := s.Purge(0)
+	require.NoError(t, err)
+
+	require.Len(t, removed, 2)
+	require.NoDirExists(t, filepath.Join(s.dir, tempFolder))
+}
+
+func TestKeyIsValidFolderName(t *testing.T) {
+	require.Equal(t, "h1-ab_c-d", Key("h1:ab/c+d="))
+}
@@ -0,0 +1,107 @@
+package chaos
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+)
+
+// fault types that can be injected
+const (
+	// FaultKill kills the target, containers are started again after the
+	// duration and pods are recreated by Kubernetes
+	FaultKill = "kill"
+	// FaultPause freezes the processes in a container for the duration
+	FaultPause = "pause"
+	// FaultCPUStress runs busy loops in the target for the duration
+	FaultCPUStress = "cpu_stress"
+	// FaultDiskFill writes a file to the target that is removed after the
+	// duration
+	FaultDiskFill = "disk_fill"
+)
+
+// Faults are the types of fault that can be injected
+var Faults = []string{FaultKill, FaultPause, FaultCPUStress, FaultDiskFill}
+
+// modes for selecting the fault and the targets
+const (
+	// ModeAll injects the faults in order, each fault is injected into every
+	// target
+	ModeAll = "all"
+	// ModeRandom injects a random fault into a random target
+	ModeRandom = "random"
+)
+
+// Config is the schedule and the faults for an Injector
+type Config struct {
+	// Name of the chaos resource, used to enable and disable the injector
+	Name string `json:"name"`
+
+	// Interval is the time between faults, a random delay up to Jitter is
+	// added to the interval
+	Interval time.Duration `json:"interval"`
+	Jitter   time.Duration `json:"jitter,omitempty"`
+
+	// Mode is ModeAll or ModeRandom
+	Mode string `json:"mode"`
+
+	Faults []Fault `json:"faults"`
+
+	// Containers are the names of the target containers
+	Containers []string `json:"containers,omitempty"`
+
+	// Pods select the target pods in Kubernetes clusters
+	Pods []PodTarget `json:"pods,omitempty"`
+}
+
+// Fault is a fault that is injected into the targets
+type Fault struct {
+	// Type is one of the fault types e.g. FaultKill
+	Type string `json:"type"`
+
+	// Duration is how long the fault lasts before the target is recovered
+	Duration time.Duration `json:"duration"`
+
+	// Workers is the number of busy loops for FaultCPUStress
+	Workers int `json:"workers,omitempty"`
+
+	// SizeMB and Path are the size and the folder of the file written by
+	// FaultDiskFill
+	SizeMB int    `json:"size_mb,omitempty"`
+	Path   string `json:"path,omitempty"`
+}
+
+// PodTarget selects pods in a Kubernetes cluster, kubectl is run in the
+// server container of the cluster
+type PodTarget struct {
+	ClusterContainer string `json:"cluster_container"`
+	Namespace        string `json:"namespace"`
+	Selector         string `json:"selector"`
+}
+
+// ReadConfig reads the config for an Injector from a file
+func ReadConfig(path string) (*Config, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read chaos config: %s", err)
+	}
+
+	c := &Config{}
+	err = json.Unmarshal(d, c)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse chaos config: %s", err)
+	}
+
+	return c, nil
+}
+
+// WriteConfig writes the config for an Injector to a file
+func WriteConfig(path string, c *Config) error {
+	d, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("unable to serialize chaos config: %s", err)
+	}
+
+	return os.WriteFile(path, d, 0600)
+}
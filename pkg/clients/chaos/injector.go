@@ -0,0 +1,245 @@
+package chaos
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+)
+
+// fillFile is the name of the file written by FaultDiskFill
+const fillFile = "jumppad-chaos.fill"
+
+// Injector injects faults into the target containers and pods on a schedule
+// until it is stopped
+type Injector struct {
+	config *Config
+	tasks  container.ContainerTasks
+	docker container.Docker
+	log    logger.Logger
+
+	// next is the position of the next fault for ModeAll
+	next int
+}
+
+// target is a container or a pod that faults are injected into
+type target struct {
+	// container is the name of the target container, or the server
+	// container of the cluster for pods
+	container string
+
+	// pod and namespace are set for pods
+	pod       string
+	namespace string
+}
+
+func (t target) String() string {
+	if t.pod != "" {
+		return fmt.Sprintf("%s/%s", t.namespace, t.pod)
+	}
+
+	return t.container
+}
+
+// NewInjector creates an Injector for the config
+func NewInjector(c *Config, ct container.ContainerTasks, dc container.Docker, l logger.Logger) *Injector {
+	return &Injector{config: c, tasks: ct, docker: dc, log: l}
+}
+
+// Run injects a fault every interval until the context is cancelled, no
+// faults are injected while the chaos is disabled
+func (i *Injector) Run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(i.wait()):
+		}
+
+		if !Enabled(i.config.Name) {
+			i.log.Debug("Chaos is disabled, skipping fault", "name", i.config.Name)
+			continue
+		}
+
+		i.InjectNext(ctx)
+	}
+}
+
+// InjectNext selects the next fault and the targets using the mode and
+// injects the fault, errors are logged as the targets may be restarting
+func (i *Injector) InjectNext(ctx context.Context) {
+	targets, err := i.targets()
+	if err != nil {
+		i.log.Error("Unable to find chaos targets", "name", i.config.Name, "error", err)
+		return
+	}
+
+	if len(targets) == 0 || len(i.config.Faults) == 0 {
+		i.log.Warn("No chaos targets found", "name", i.config.Name)
+		return
+	}
+
+	var f Fault
+	if i.config.Mode == ModeRandom {
+		f = i.config.Faults[rand.Intn(len(i.config.Faults))]
+		targets = []target{targets[rand.Intn(len(targets))]}
+	} else {
+		f = i.config.Faults[i.next%len(i.config.Faults)]
+		i.next++
+	}
+
+	wg := sync.WaitGroup{}
+	for _, t := range targets {
+		wg.Add(1)
+
+		go func(t target) {
+			defer wg.Done()
+
+			i.log.Info("Injecting fault", "name", i.config.Name, "fault", f.Type, "target", t.String(), "duration", f.Duration)
+
+			err := i.inject(ctx, f, t)
+			if err != nil {
+				i.log.Error("Unable to inject fault", "name", i.config.Name, "fault", f.Type, "target", t.String(), "error", err)
+				return
+			}
+
+			i.log.Info("Fault recovered", "name", i.config.Name, "fault", f.Type, "target", t.String())
+		}(t)
+	}
+
+	wg.Wait()
+}
+
+func (i *Injector) wait() time.Duration {
+	if i.config.Jitter <= 0 {
+		return i.config.Interval
+	}
+
+	return i.config.Interval + time.Duration(rand.Int63n(int64(i.config.Jitter)))
+}
+
+// targets returns the containers and the pods that match the selectors
+func (i *Injector) targets() ([]target, error) {
+	targets := []target{}
+
+	for _, c := range i.config.Containers {
+		targets = append(targets, target{container: c})
+	}
+
+	for _, p := range i.config.Pods {
+		out := bytes.NewBufferString("")
+
+		_, err := i.tasks.ExecuteCommand(p.ClusterContainer, []string{"kubectl", "get", "pods", "-n", p.Namespace, "-l", p.Selector, "-o", "name"}, nil, "", "", "", 30, out)
+		if err != nil {
+			return nil, fmt.Errorf("unable to list pods for selector %s: %w", p.Selector, err)
+		}
+
+		for _, name := range strings.Fields(out.String()) {
+			targets = append(targets, target{container: p.ClusterContainer, pod: name, namespace: p.Namespace})
+		}
+	}
+
+	return targets, nil
+}
+
+// inject applies the fault to the target and recovers the target after the
+// duration, the target is recovered when the context is cancelled so that
+// stopping the injector does not leave targets broken
+func (i *Injector) inject(ctx context.Context, f Fault, t target) error {
+	switch f.Type {
+	case FaultKill:
+		return i.kill(ctx, f, t)
+	case FaultPause:
+		if t.pod != "" {
+			return fmt.Errorf("pause is not supported for pods")
+		}
+
+		err := i.docker.ContainerPause(context.Background(), t.container)
+		if err != nil {
+			return err
+		}
+
+		sleep(ctx, f.Duration)
+
+		return i.docker.ContainerUnpause(context.Background(), t.container)
+	case FaultCPUStress:
+		return i.exec(t, cpuStressScript(f.Workers, f.Duration), f.Duration)
+	case FaultDiskFill:
+		path := fmt.Sprintf("%s/%s", strings.TrimSuffix(f.Path, "/"), fillFile)
+
+		err := i.exec(t, fmt.Sprintf("dd if=/dev/zero of=%s bs=1048576 count=%d 2>/dev/null", path, f.SizeMB), 0)
+		if err != nil {
+			// remove the partially written file
+			i.exec(t, "rm -f "+path, 0)
+			return err
+		}
+
+		sleep(ctx, f.Duration)
+
+		return i.exec(t, "rm -f "+path, 0)
+	}
+
+	return fmt.Errorf("unknown fault %s", f.Type)
+}
+
+// kill stops a container without waiting for it to shutdown and starts it
+// again after the duration, pods are deleted and recreated by Kubernetes
+func (i *Injector) kill(ctx context.Context, f Fault, t target) error {
+	if t.pod != "" {
+		_, err := i.tasks.ExecuteCommand(t.container, []string{"kubectl", "delete", "-n", t.namespace, t.pod, "--grace-period=0", "--force", "--wait=false"}, nil, "", "", "", 30, nil)
+		return err
+	}
+
+	timeout := 0
+	err := i.docker.ContainerStop(context.Background(), t.container, dcontainer.StopOptions{Signal: "SIGKILL", Timeout: &timeout})
+	if err != nil {
+		return err
+	}
+
+	sleep(ctx, f.Duration)
+
+	return i.docker.ContainerStart(context.Background(), t.container, dcontainer.StartOptions{})
+}
+
+// exec runs the shell script in the target container or pod
+func (i *Injector) exec(t target, script string, d time.Duration) error {
+	cmd := []string{"sh", "-c", script}
+	if t.pod != "" {
+		cmd = append([]string{"kubectl", "exec", "-n", t.namespace, t.pod, "--"}, cmd...)
+	}
+
+	out := bytes.NewBufferString("")
+
+	_, err := i.tasks.ExecuteCommand(t.container, cmd, nil, "", "", "", int(d.Seconds())+60, out)
+	if err != nil {
+		return fmt.Errorf("%w, output: %s", err, strings.TrimSpace(out.String()))
+	}
+
+	return nil
+}
+
+// cpuStressScript returns a script that runs busy loops for the duration,
+// only shell builtins and date are used so that the script works in minimal
+// images
+func cpuStressScript(workers int, d time.Duration) string {
+	return fmt.Sprintf(
+		`end=$(($(date +%%s)+%d)); i=0; while [ $i -lt %d ]; do (while [ $(date +%%s) -lt $end ]; do :; done) & i=$((i+1)); done; wait`,
+		int(d.Seconds()),
+		workers,
+	)
+}
+
+// sleep waits for the duration or until the context is cancelled
+func sleep(ctx context.Context, d time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-time.After(d):
+	}
+}
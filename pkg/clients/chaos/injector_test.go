@@ -0,0 +1,148 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupInjector(t *testing.T, faults ...Fault) (*Injector, *mocks.ContainerTasks, *mocks.Docker) {
+	t.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	c := &Config{
+		Name:       "test",
+		Interval:   10 * time.Millisecond,
+		Mode:       ModeAll,
+		Faults:     faults,
+		Containers: []string{"web.container.local.jmpd.in", "db.container.local.jmpd.in"},
+	}
+
+	mt := &mocks.ContainerTasks{}
+	mt.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+
+	md := &mocks.Docker{}
+	md.On("ContainerStop", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	md.On("ContainerStart", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	md.On("ContainerPause", mock.Anything, mock.Anything).Return(nil)
+	md.On("ContainerUnpause", mock.Anything, mock.Anything).Return(nil)
+
+	return NewInjector(c, mt, md, logger.NewTestLogger(t)), mt, md
+}
+
+func TestInjectNextKillsAndStartsEveryContainer(t *testing.T) {
+	i, _, md := setupInjector(t, Fault{Type: FaultKill, Duration: time.Millisecond})
+
+	i.InjectNext(context.Background())
+
+	md.AssertNumberOfCalls(t, "ContainerStop", 2)
+	md.AssertCalled(t, "ContainerStart", mock.Anything, "web.container.local.jmpd.in", mock.Anything)
+	md.AssertCalled(t, "ContainerStart", mock.Anything, "db.container.local.jmpd.in", mock.Anything)
+}
+
+func TestInjectNextPausesAndUnpausesContainer(t *testing.T) {
+	i, _, md := setupInjector(t, Fault{Type: FaultPause, Duration: time.Millisecond})
+	i.config.Containers = i.config.Containers[:1]
+
+	i.InjectNext(context.Background())
+
+	md.AssertCalled(t, "ContainerPause", mock.Anything, "web.container.local.jmpd.in")
+	md.AssertCalled(t, "ContainerUnpause", mock.Anything, "web.container.local.jmpd.in")
+}
+
+func TestInjectNextCyclesThroughFaults(t *testing.T) {
+	i, mt, md := setupInjector(t, Fault{Type: FaultPause, Duration: time.Millisecond}, Fault{Type: FaultCPUStress, Duration: time.Second, Workers: 2})
+	i.config.Containers = i.config.Containers[:1]
+
+	i.InjectNext(context.Background())
+	i.InjectNext(context.Background())
+
+	md.AssertNumberOfCalls(t, "ContainerPause", 1)
+
+	cmd := testutils.GetCalls(&mt.Mock, "ExecuteCommand")[0].Arguments[1].([]string)
+	require.Equal(t, []string{"sh", "-c", cpuStressScript(2, time.Second)}, cmd)
+}
+
+func TestInjectNextFillsAndRemovesFile(t *testing.T) {
+	i, mt, _ := setupInjector(t, Fault{Type: FaultDiskFill, Duration: time.Millisecond, SizeMB: 100, Path: "/tmp/"})
+	i.config.Containers = i.config.Containers[:1]
+
+	i.InjectNext(context.Background())
+
+	calls := testutils.GetCalls(&mt.Mock, "ExecuteCommand")
+	require.Len(t, calls, 2)
+	require.Equal(t, []string{"sh", "-c", "dd if=/dev/zero of=/tmp/jumppad-chaos.fill bs=1048576 count=100 2>/dev/null"}, calls[0].Arguments[1])
+	require.Equal(t, []string{"sh", "-c", "rm -f /tmp/jumppad-chaos.fill"}, calls[1].Arguments[1])
+}
+
+func TestInjectNextWithRandomModeInjectsOneTarget(t *testing.T) {
+	i, _, md := setupInjector(t, Fault{Type: FaultPause, Duration: time.Millisecond})
+	i.config.Mode = ModeRandom
+
+	i.InjectNext(context.Background())
+
+	md.AssertNumberOfCalls(t, "ContainerPause", 1)
+}
+
+func TestInjectNextKillsPodsWithKubectl(t *testing.T) {
+	i, mt, _ := setupInjector(t, Fault{Type: FaultKill})
+	i.config.Containers = nil
+	i.config.Pods = []PodTarget{{ClusterContainer: "server.dev.k8s-cluster.jmpd.in", Namespace: "default", Selector: "app=web"}}
+
+	testutils.RemoveOn(&mt.Mock, "ExecuteCommand")
+	mt.On("ExecuteCommand", "server.dev.k8s-cluster.jmpd.in", []string{"kubectl", "get", "pods", "-n", "default", "-l", "app=web", "-o", "name"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(7).(io.Writer).Write([]byte("pod/web-1\npod/web-2\n"))
+		}).
+		Return(0, nil)
+	mt.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+
+	i.InjectNext(context.Background())
+
+	mt.AssertCalled(t, "ExecuteCommand", "server.dev.k8s-cluster.jmpd.in", []string{"kubectl", "delete", "-n", "default", "pod/web-1", "--grace-period=0", "--force", "--wait=false"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mt.AssertCalled(t, "ExecuteCommand", "server.dev.k8s-cluster.jmpd.in", []string{"kubectl", "delete", "-n", "default", "pod/web-2", "--grace-period=0", "--force", "--wait=false"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInjectNextWhenPauseFailsDoesNotUnpause(t *testing.T) {
+	i, _, md := setupInjector(t, Fault{Type: FaultPause, Duration: time.Millisecond})
+
+	testutils.RemoveOn(&md.Mock, "ContainerPause")
+	md.On("ContainerPause", mock.Anything, mock.Anything).Return(fmt.Errorf("boom"))
+
+	i.InjectNext(context.Background())
+
+	md.AssertNotCalled(t, "ContainerUnpause", mock.Anything, mock.Anything)
+}
+
+func TestRunDoesNotInjectWhenDisabled(t *testing.T) {
+	i, _, md := setupInjector(t, Fault{Type: FaultPause, Duration: time.Millisecond})
+	require.NoError(t, SetEnabled("test", false))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := i.Run(ctx)
+	require.NoError(t, err)
+
+	md.AssertNotCalled(t, "ContainerPause", mock.Anything, mock.Anything)
+}
+
+func TestRunInjectsFaultsUntilCancelled(t *testing.T) {
+	i, _, md := setupInjector(t, Fault{Type: FaultPause, Duration: time.Millisecond})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := i.Run(ctx)
+	require.NoError(t, err)
+
+	md.AssertCalled(t, "ContainerPause", mock.Anything, "web.container.local.jmpd.in")
+}
@@ -0,0 +1,92 @@
+package chaos
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/command"
+	"github.com/jumppad-labs/jumppad/pkg/clients/command/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// Start runs an Injector in a background jumppad process, it returns the pid
+// of the process
+func Start(c command.Command, conf *Config) (int, error) {
+	configPath := configPath(conf.Name)
+
+	err := WriteConfig(configPath, conf)
+	if err != nil {
+		return 0, err
+	}
+
+	bin, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("unable to find jumppad executable: %w", err)
+	}
+
+	pid, _, err := c.Execute(types.CommandConfig{
+		Command:         bin,
+		Args:            []string{"chaos", "run", configPath},
+		InheritEnv:      true,
+		RunInBackground: true,
+		LogFilePath:     LogFile(conf.Name),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to start chaos injector: %w", err)
+	}
+
+	return pid, nil
+}
+
+// Stop kills the background process started by Start and removes the
+// enabled setting
+func Stop(c command.Command, name string, pid int) error {
+	os.Remove(configPath(name))
+	os.Remove(disabledPath(name))
+
+	if pid < 1 {
+		return nil
+	}
+
+	return c.Kill(pid)
+}
+
+// SetEnabled enables or disables the injection of faults, the injector keeps
+// running when disabled so that it can be enabled again
+func SetEnabled(name string, enabled bool) error {
+	if enabled {
+		err := os.Remove(disabledPath(name))
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to enable chaos %s: %s", name, err)
+		}
+
+		return nil
+	}
+
+	err := os.WriteFile(disabledPath(name), []byte{}, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to disable chaos %s: %s", name, err)
+	}
+
+	return nil
+}
+
+// Enabled returns false when the injection of faults has been disabled
+func Enabled(name string) bool {
+	_, err := os.Stat(disabledPath(name))
+	return os.IsNotExist(err)
+}
+
+// LogFile returns the file the injector writes the faults it injects to
+func LogFile(name string) string {
+	return filepath.Join(utils.LogsDir(), fmt.Sprintf("chaos_%s.log", name))
+}
+
+func configPath(name string) string {
+	return filepath.Join(utils.JumppadTemp(), fmt.Sprintf("chaos_%s.json", name))
+}
+
+func disabledPath(name string) string {
+	return filepath.Join(utils.JumppadTemp(), fmt.Sprintf("chaos_%s.disabled", name))
+}
@@ -0,0 +1,67 @@
+package chaos
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/command/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/command/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartRunsInjectorInBackground(t *testing.T) {
+	t.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	mc := &mocks.Command{}
+	mc.On("Execute", mock.Anything).Return(42, 0, nil)
+
+	pid, err := Start(mc, &Config{Name: "test", Interval: time.Minute})
+	require.NoError(t, err)
+	require.Equal(t, 42, pid)
+
+	cc := testutils.GetCalls(&mc.Mock, "Execute")[0].Arguments[0].(types.CommandConfig)
+	require.True(t, cc.RunInBackground)
+	require.Equal(t, []string{"chaos", "run", configPath("test")}, cc.Args)
+
+	c, err := ReadConfig(configPath("test"))
+	require.NoError(t, err)
+	require.Equal(t, time.Minute, c.Interval)
+}
+
+func TestStopKillsInjectorAndRemovesFiles(t *testing.T) {
+	t.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	mc := &mocks.Command{}
+	mc.On("Kill", 42).Return(nil)
+
+	WriteConfig(configPath("test"), &Config{Name: "test"})
+	SetEnabled("test", false)
+
+	err := Stop(mc, "test", 42)
+	require.NoError(t, err)
+
+	mc.AssertCalled(t, "Kill", 42)
+	require.NoFileExists(t, configPath("test"))
+	require.True(t, Enabled("test"))
+}
+
+func TestSetEnabledTogglesEnabled(t *testing.T) {
+	t.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	require.True(t, Enabled("test"))
+
+	require.NoError(t, SetEnabled("test", false))
+	require.False(t, Enabled("test"))
+
+	require.NoError(t, SetEnabled("test", true))
+	require.True(t, Enabled("test"))
+}
+
+func TestReadConfigWithMissingFileReturnsError(t *testing.T) {
+	_, err := ReadConfig(os.DevNull + "/missing.json")
+	require.ErrorContains(t, err, "unable to read chaos config")
+}
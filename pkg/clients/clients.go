@@ -3,6 +3,7 @@ package clients
 import (
 	"time"
 
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts"
 	"github.com/jumppad-labs/jumppad/pkg/clients/command"
 	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container"
@@ -13,8 +14,13 @@ import (
 	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/clients/registry"
+	"github.com/jumppad-labs/jumppad/pkg/clients/snapshot"
+	"github.com/jumppad-labs/jumppad/pkg/clients/ssh"
 	"github.com/jumppad-labs/jumppad/pkg/clients/system"
 	"github.com/jumppad-labs/jumppad/pkg/clients/tar"
+	"github.com/jumppad-labs/jumppad/pkg/clients/updater"
+	"github.com/jumppad-labs/jumppad/pkg/clients/wasm"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 )
 
@@ -28,10 +34,17 @@ type Clients struct {
 	Command        command.Command
 	Logger         logger.Logger
 	Getter         getter.Getter
+	Registry       registry.Registry
 	System         system.System
 	ImageLog       images.ImageLog
+	ImageSets      images.ImageSets
 	Connector      connector.Connector
 	TarGz          *tar.TarGz
+	WASM           wasm.WASM
+	Snapshots      snapshot.Snapshots
+	SSH            ssh.SSH
+	Updater        updater.Updater
+	Artifacts      artifacts.Store
 }
 
 // GenerateClients creates the various clients for creating and destroying resources
@@ -48,12 +61,16 @@ func GenerateClients(l logger.Logger) (*Clients, error) {
 
 	nc := nomad.NewNomad(hc, 1*time.Second, l)
 
-	bp := getter.NewGetter(false)
+	as := artifacts.NewStore(utils.ArtifactsDir())
+
+	bp := getter.NewGetter(false, as)
 
 	bc := &system.SystemImpl{}
 
 	il := images.NewImageFileLog(utils.ImageCacheLog())
 
+	is := images.NewImageSetFileLog(utils.ImageSetsLog())
+
 	tgz := &tar.TarGz{}
 
 	ct, _ := container.NewDockerTasks(dc, il, tgz, l)
@@ -61,6 +78,10 @@ func GenerateClients(l logger.Logger) (*Clients, error) {
 	co := connector.DefaultConnectorOptions()
 	cc := connector.NewConnector(co)
 
+	wc := wasm.NewWASM(l)
+
+	sn := snapshot.NewSnapshots(dc, l, utils.SnapshotsDir())
+
 	return &Clients{
 		ContainerTasks: ct,
 		Docker:         dc,
@@ -71,9 +92,16 @@ func GenerateClients(l logger.Logger) (*Clients, error) {
 		Nomad:          nc,
 		Logger:         l,
 		Getter:         bp,
+		Registry:       registry.NewRegistry(),
 		System:         bc,
 		ImageLog:       il,
+		ImageSets:      is,
 		Connector:      cc,
 		TarGz:          tgz,
+		WASM:           wc,
+		Snapshots:      sn,
+		SSH:            ssh.NewSSH(l),
+		Updater:        updater.NewUpdater(updater.DefaultReleasesURL, updater.DefaultLatestURL),
+		Artifacts:      as,
 	}, nil
 }
@@ -1,23 +1,34 @@
 package command
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/jumppad-labs/gohup"
 	"github.com/jumppad-labs/jumppad/pkg/clients/command/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
-	"github.com/jumppad-labs/jumppad/pkg/utils"
 )
 
 var ErrorCommandTimeout = fmt.Errorf("Command timed out before completing")
 
 //go:generate mockery --name Command --filename command.go
 type Command interface {
-	Execute(config types.CommandConfig) (int, error)
+	// Execute runs the command and returns the process id and the exit code,
+	// the exit code is -1 for commands that run in the background or that
+	// did not complete
+	Execute(config types.CommandConfig) (pid int, exitCode int, err error)
 	Kill(pid int) error
+	// Running returns true when the background process with the given pid
+	// is running
+	Running(pid int) bool
 }
 
 // Command executes local commands
@@ -36,8 +47,13 @@ type done struct {
 	err error
 }
 
-// Execute the given command
-func (c *CommandImpl) Execute(config types.CommandConfig) (int, error) {
+// Execute the given command, commands that do not run in the background are
+// waited for so that their exit code can be returned
+func (c *CommandImpl) Execute(config types.CommandConfig) (int, int, error) {
+	if !config.RunInBackground {
+		return c.executeForeground(config)
+	}
+
 	mutex := sync.Mutex{}
 
 	lp := &gohup.LocalProcess{}
@@ -47,8 +63,7 @@ func (c *CommandImpl) Execute(config types.CommandConfig) (int, error) {
 		Logfile: config.LogFilePath,
 	}
 
-	// add the default environment variables
-	o.Env = config.Env
+	o.Env = environment(config)
 
 	if config.WorkingDirectory != "" {
 		o.Dir = config.WorkingDirectory
@@ -57,13 +72,8 @@ func (c *CommandImpl) Execute(config types.CommandConfig) (int, error) {
 	// done chan
 	doneCh := make(chan done)
 
-	timeout := c.timeout
-	if config.Timeout != (0 * time.Millisecond) {
-		timeout = config.Timeout
-	}
-
 	// wait for timeout
-	t := time.After(timeout)
+	t := time.After(c.commandTimeout(config))
 	var pidfile string
 	var pid int
 	var err error
@@ -87,23 +97,6 @@ func (c *CommandImpl) Execute(config types.CommandConfig) (int, error) {
 		}
 		mutex.Unlock()
 
-		// if not background wait for complete
-		if !config.RunInBackground {
-			for {
-				s, err := lp.QueryStatus(pidfile)
-				c.log.Debug("Checking status", "status", s, "pid", pidfile, "err", err)
-				if err != nil {
-					doneCh <- done{err: err, pid: pid}
-				}
-
-				if s == gohup.StatusStopped {
-					break
-				}
-
-				time.Sleep(200 * time.Millisecond)
-			}
-		}
-
 		doneCh <- done{err: err, pid: pid}
 	}()
 
@@ -113,16 +106,142 @@ func (c *CommandImpl) Execute(config types.CommandConfig) (int, error) {
 		mutex.Lock()
 		lp.Stop(pidfile)
 		mutex.Unlock()
-		return pid, ErrorCommandTimeout
+		return pid, -1, ErrorCommandTimeout
 	case d := <-doneCh:
-		return d.pid, d.err
+		return d.pid, -1, d.err
+	}
+}
+
+// executeForeground runs the command and waits for it to complete so that
+// the exit code can be returned
+func (c *CommandImpl) executeForeground(config types.CommandConfig) (int, int, error) {
+	cmd := exec.Command(config.Command, config.Args...)
+	cmd.Env = environment(config)
+	cmd.Dir = config.WorkingDirectory
+	cmd.SysProcAttr = gohup.SetSysProcAttr()
+
+	// processes started by the command can keep the output open after the
+	// command exits, do not wait for them
+	cmd.WaitDelay = time.Second
+
+	stdout := []io.Writer{}
+	stderr := []io.Writer{}
+
+	if config.LogFilePath != "" {
+		f, err := os.Create(config.LogFilePath)
+		if err != nil {
+			return -1, -1, fmt.Errorf("unable to open log file: %s", err)
+		}
+		defer f.Close()
+
+		stdout = append(stdout, f)
+		stderr = append(stderr, f)
+	}
+
+	if config.Stdout != nil {
+		stdout = append(stdout, config.Stdout)
+	}
+
+	if config.Stderr != nil {
+		stderr = append(stderr, config.Stderr)
+	}
+
+	if len(stdout) > 0 {
+		cmd.Stdout = io.MultiWriter(stdout...)
+	}
+
+	if len(stderr) > 0 {
+		cmd.Stderr = io.MultiWriter(stderr...)
+	}
+
+	c.log.Debug(
+		"Running command",
+		"cmd", config.Command,
+		"args", config.Args,
+		"dir", config.WorkingDirectory,
+		"env", config.Env,
+		"log_file", config.LogFilePath,
+	)
+
+	err := cmd.Start()
+	if err != nil {
+		return -1, -1, err
+	}
+
+	pid := cmd.Process.Pid
+
+	doneCh := make(chan error, 1)
+	go func() {
+		doneCh <- cmd.Wait()
+	}()
+
+	select {
+	case <-time.After(c.commandTimeout(config)):
+		// kill the process and any processes it started
+		gohup.Kill(cmd.Process)
+		<-doneCh
+
+		return pid, -1, ErrorCommandTimeout
+	case err := <-doneCh:
+		// a non zero exit code is not an error, the caller decides if the
+		// command failed
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) && !errors.Is(err, exec.ErrWaitDelay) {
+			return pid, -1, err
+		}
+
+		return pid, cmd.ProcessState.ExitCode(), nil
+	}
+}
+
+// commandTimeout returns the timeout for the command, the maximum command
+// time is used when the command does not set a timeout
+func (c *CommandImpl) commandTimeout(config types.CommandConfig) time.Duration {
+	if config.Timeout > 0 {
+		return config.Timeout
+	}
+
+	return c.timeout
+}
+
+// environment returns the environment for the command, an empty environment
+// is returned rather than nil as nil causes the process environment to be
+// inherited
+func environment(config types.CommandConfig) []string {
+	env := []string{}
+	if config.InheritEnv {
+		env = append(env, os.Environ()...)
+	}
+
+	env = append(env, config.Env...)
+
+	// remove duplicate keys keeping the last value so that the environment
+	// is the same on all platforms
+	index := map[string]int{}
+	merged := []string{}
+
+	for _, e := range env {
+		k, _, _ := strings.Cut(e, "=")
+		if runtime.GOOS == "windows" {
+			k = strings.ToUpper(k)
+		}
+
+		if i, ok := index[k]; ok {
+			merged[i] = e
+			continue
+		}
+
+		index[k] = len(merged)
+		merged = append(merged, e)
 	}
+
+	return merged
 }
 
 // Kill a process with the given pid
 func (c *CommandImpl) Kill(pid int) error {
 	lp := gohup.LocalProcess{}
-	pidPath := filepath.Join(utils.JumppadTemp(), fmt.Sprintf("%d.pid", pid))
+	pidPath := pidPath(pid)
 
 	if s, _ := lp.QueryStatus(pidPath); s == gohup.StatusRunning {
 		return lp.Stop(pidPath)
@@ -130,3 +249,23 @@ func (c *CommandImpl) Kill(pid int) error {
 
 	return nil
 }
+
+// Running returns true when the background process with the given pid is
+// running
+func (c *CommandImpl) Running(pid int) bool {
+	if pid < 1 {
+		return false
+	}
+
+	lp := gohup.LocalProcess{}
+	s, _ := lp.QueryStatus(pidPath(pid))
+
+	return s == gohup.StatusRunning
+}
+
+// pidPath returns the file gohup writes the pid of a background process to,
+// no pid file is set when the process is started so gohup uses the default
+// temporary directory
+func pidPath(pid int) string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("%d.pid", pid))
+}
@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bytes"
 	"runtime"
 	"testing"
 	"time"
@@ -25,7 +26,7 @@ func TestExecuteForgroundWithBasicParams(t *testing.T) {
 
 	e := setupExecute(t)
 
-	p, err := e.Execute(types.CommandConfig{
+	p, _, err := e.Execute(types.CommandConfig{
 		Command: command,
 		Args:    args,
 	})
@@ -49,7 +50,7 @@ func TestExecuteForgroundLongRunningTimesOut(t *testing.T) {
 
 	e := setupExecute(t)
 
-	p, err := e.Execute(types.CommandConfig{
+	p, _, err := e.Execute(types.CommandConfig{
 		Command: command,
 		Args:    args,
 	})
@@ -59,10 +60,126 @@ func TestExecuteForgroundLongRunningTimesOut(t *testing.T) {
 	assert.Greater(t, p, 1)
 }
 
+func TestExecuteForgroundReturnsExitCode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires sh")
+	}
+
+	e := setupExecute(t)
+
+	_, code, err := e.Execute(types.CommandConfig{
+		Command: "sh",
+		Args:    []string{"-c", "exit 3"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, code)
+}
+
+func TestExecuteForgroundWritesStdoutAndStderr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires sh")
+	}
+
+	stdout := bytes.NewBufferString("")
+	stderr := bytes.NewBufferString("")
+
+	e := setupExecute(t)
+
+	_, code, err := e.Execute(types.CommandConfig{
+		Command: "sh",
+		Args:    []string{"-c", "echo out; echo err >&2"},
+		Stdout:  stdout,
+		Stderr:  stderr,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "out\n", stdout.String())
+	assert.Equal(t, "err\n", stderr.String())
+}
+
+func TestExecuteForgroundUsesCommandTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires sh")
+	}
+
+	e := setupExecute(t)
+
+	start := time.Now()
+	_, _, err := e.Execute(types.CommandConfig{
+		Command: "sh",
+		Args:    []string{"-c", "sleep 10"},
+		Timeout: 500 * time.Millisecond,
+	})
+
+	assert.Equal(t, ErrorCommandTimeout, err)
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+func TestExecuteForgroundPassesEnvironment(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires sh")
+	}
+
+	stdout := bytes.NewBufferString("")
+
+	e := setupExecute(t)
+
+	_, _, err := e.Execute(types.CommandConfig{
+		Command: "sh",
+		Args:    []string{"-c", "echo $FOO"},
+		Env:     []string{"FOO=bar"},
+		Stdout:  stdout,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "bar\n", stdout.String())
+}
+
+func TestEnvironmentWithoutInheritOnlyContainsEnv(t *testing.T) {
+	t.Setenv("JUMPPAD_COMMAND_TEST", "parent")
+
+	env := environment(types.CommandConfig{Env: []string{"FOO=bar"}})
+
+	assert.Equal(t, []string{"FOO=bar"}, env)
+}
+
+func TestEnvironmentWithoutEnvIsEmpty(t *testing.T) {
+	env := environment(types.CommandConfig{})
+
+	assert.NotNil(t, env)
+	assert.Empty(t, env)
+}
+
+func TestEnvironmentWithInheritContainsProcessEnvironment(t *testing.T) {
+	t.Setenv("JUMPPAD_COMMAND_TEST", "parent")
+
+	env := environment(types.CommandConfig{Env: []string{"FOO=bar"}, InheritEnv: true})
+
+	assert.Contains(t, env, "JUMPPAD_COMMAND_TEST=parent")
+	assert.Contains(t, env, "FOO=bar")
+}
+
+func TestEnvironmentEnvOverridesProcessEnvironment(t *testing.T) {
+	t.Setenv("JUMPPAD_COMMAND_TEST", "parent")
+
+	env := environment(types.CommandConfig{Env: []string{"JUMPPAD_COMMAND_TEST=child"}, InheritEnv: true})
+
+	assert.Contains(t, env, "JUMPPAD_COMMAND_TEST=child")
+	assert.NotContains(t, env, "JUMPPAD_COMMAND_TEST=parent")
+}
+
+func TestEnvironmentLastValueTakesPrecedence(t *testing.T) {
+	env := environment(types.CommandConfig{Env: []string{"FOO=one", "BAR=two", "FOO=three"}})
+
+	assert.Equal(t, []string{"FOO=three", "BAR=two"}, env)
+}
+
 func TestExecuteInvalidCommandReturnsError(t *testing.T) {
 	e := setupExecute(t)
 
-	_, err := e.Execute(types.CommandConfig{Command: "nocommand"})
+	_, _, err := e.Execute(types.CommandConfig{Command: "nocommand"})
 	assert.Error(t, err)
 }
 
@@ -81,7 +198,7 @@ func TestExecuteBackgroundWithBasicParams(t *testing.T) {
 	doneCh := make(chan done)
 
 	go func() {
-		p, err := e.Execute(types.CommandConfig{
+		p, _, err := e.Execute(types.CommandConfig{
 			Command:         command,
 			Args:            args,
 			RunInBackground: true,
@@ -114,7 +231,7 @@ func TestKillRemovesProcessWhenRunning(t *testing.T) {
 	doneCh := make(chan done)
 
 	go func() {
-		p, err := e.Execute(types.CommandConfig{
+		p, _, err := e.Execute(types.CommandConfig{
 			Command:         command,
 			Args:            args,
 			RunInBackground: true,
@@ -134,3 +251,35 @@ func TestKillRemovesProcessWhenRunning(t *testing.T) {
 		assert.NoError(t, err)
 	}
 }
+
+func TestRunningReturnsTrueWhenRunning(t *testing.T) {
+	command := "sh"
+	args := []string{"-c", "sleep 10s"}
+
+	if runtime.GOOS == "windows" {
+		command = "cmd.exe"
+		args = []string{"/c", "ping", "192.0.2.1", "-n", "1", "-w", "100000", ">NUL"}
+	}
+
+	e := setupExecute(t)
+
+	p, _, err := e.Execute(types.CommandConfig{
+		Command:         command,
+		Args:            args,
+		RunInBackground: true,
+	})
+	assert.NoError(t, err)
+
+	assert.True(t, e.Running(p))
+
+	err = e.Kill(p)
+	assert.NoError(t, err)
+
+	assert.False(t, e.Running(p))
+}
+
+func TestRunningReturnsFalseWithoutPID(t *testing.T) {
+	e := setupExecute(t)
+
+	assert.False(t, e.Running(0))
+}
@@ -13,12 +13,13 @@ type Command struct {
 }
 
 // Execute provides a mock function with given fields: config
-func (_m *Command) Execute(config types.CommandConfig) (int, error) {
+func (_m *Command) Execute(config types.CommandConfig) (int, int, error) {
 	ret := _m.Called(config)
 
 	var r0 int
-	var r1 error
-	if rf, ok := ret.Get(0).(func(types.CommandConfig) (int, error)); ok {
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(types.CommandConfig) (int, int, error)); ok {
 		return rf(config)
 	}
 	if rf, ok := ret.Get(0).(func(types.CommandConfig) int); ok {
@@ -27,13 +28,19 @@ func (_m *Command) Execute(config types.CommandConfig) (int, error) {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(types.CommandConfig) error); ok {
+	if rf, ok := ret.Get(1).(func(types.CommandConfig) int); ok {
 		r1 = rf(config)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(types.CommandConfig) error); ok {
+		r2 = rf(config)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // Kill provides a mock function with given fields: pid
@@ -50,6 +57,20 @@ func (_m *Command) Kill(pid int) error {
 	return r0
 }
 
+// Running provides a mock function with given fields: pid
+func (_m *Command) Running(pid int) bool {
+	ret := _m.Called(pid)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(int) bool); ok {
+		r0 = rf(pid)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewCommand interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,324 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+)
+
+const (
+	// RestartNever does not restart the process when it exits
+	RestartNever = "no"
+	// RestartOnFailure restarts the process when it exits with a non zero
+	// exit code
+	RestartOnFailure = "on-failure"
+	// RestartAlways restarts the process whenever it exits
+	RestartAlways = "always"
+)
+
+const (
+	// SupervisorRunning is reported while the process is running
+	SupervisorRunning = "running"
+	// SupervisorRestarting is reported while waiting to restart the process
+	SupervisorRestarting = "restarting"
+	// SupervisorExited is reported when the process has exited and will not
+	// be restarted
+	SupervisorExited = "exited"
+	// SupervisorStopped is reported when the supervisor has been stopped
+	SupervisorStopped = "stopped"
+)
+
+// default log rotation, the log file is rotated when it is larger than
+// MaxLogSize and MaxLogFiles rotated files are kept
+const (
+	DefaultMaxLogSize  = 10 * 1024 * 1024
+	DefaultMaxLogFiles = 3
+)
+
+// SupervisorConfig is the process run by a Supervisor
+type SupervisorConfig struct {
+	Command          string   `json:"command"`
+	Args             []string `json:"args,omitempty"`
+	Env              []string `json:"env,omitempty"`
+	WorkingDirectory string   `json:"working_directory,omitempty"`
+
+	// Policy is one of RestartNever, RestartOnFailure or RestartAlways
+	Policy string `json:"policy"`
+	// MaxRestarts is the number of times the process is restarted, 0 does
+	// not limit the restarts
+	MaxRestarts int `json:"max_restarts,omitempty"`
+	// Delay is the time to wait before restarting the process
+	Delay time.Duration `json:"delay,omitempty"`
+
+	LogFilePath string `json:"log_file"`
+	MaxLogSize  int64  `json:"max_log_size,omitempty"`
+	MaxLogFiles int    `json:"max_log_files,omitempty"`
+
+	// StatusPath is the file the status of the process is written to
+	StatusPath string `json:"status_path"`
+}
+
+// SupervisorStatus is the status of a supervised process
+type SupervisorStatus struct {
+	State    string    `json:"state" yaml:"state"`
+	PID      int       `json:"pid,omitempty" yaml:"pid,omitempty"`
+	Restarts int       `json:"restarts" yaml:"restarts"`
+	ExitCode int       `json:"exit_code,omitempty" yaml:"exit_code,omitempty"`
+	Updated  time.Time `json:"updated" yaml:"updated"`
+}
+
+// ReadSupervisorConfig reads the config for a Supervisor from a file
+func ReadSupervisorConfig(path string) (*SupervisorConfig, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read supervisor config: %s", err)
+	}
+
+	c := &SupervisorConfig{}
+	err = json.Unmarshal(d, c)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse supervisor config: %s", err)
+	}
+
+	return c, nil
+}
+
+// WriteSupervisorConfig writes the config for a Supervisor to a file
+func WriteSupervisorConfig(path string, c *SupervisorConfig) error {
+	d, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("unable to serialize supervisor config: %s", err)
+	}
+
+	return os.WriteFile(path, d, 0600)
+}
+
+// ReadSupervisorStatus reads the status written by a Supervisor
+func ReadSupervisorStatus(path string) (*SupervisorStatus, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read supervisor status: %s", err)
+	}
+
+	s := &SupervisorStatus{}
+	err = json.Unmarshal(d, s)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse supervisor status: %s", err)
+	}
+
+	return s, nil
+}
+
+// Supervisor runs a process, restarting it according to the restart policy
+// and rotating its log file
+type Supervisor struct {
+	config SupervisorConfig
+	log    logger.Logger
+	status SupervisorStatus
+}
+
+// NewSupervisor creates a Supervisor for the given config
+func NewSupervisor(c SupervisorConfig, l logger.Logger) *Supervisor {
+	if c.MaxLogSize <= 0 {
+		c.MaxLogSize = DefaultMaxLogSize
+	}
+
+	if c.MaxLogFiles <= 0 {
+		c.MaxLogFiles = DefaultMaxLogFiles
+	}
+
+	if c.Policy == "" {
+		c.Policy = RestartNever
+	}
+
+	return &Supervisor{config: c, log: l}
+}
+
+// Run starts the process and blocks until it exits and is not restarted or
+// the context is cancelled, cancelling the context kills the process
+func (s *Supervisor) Run(ctx context.Context) error {
+	w, err := newRotatingWriter(s.config.LogFilePath, s.config.MaxLogSize, s.config.MaxLogFiles)
+	if err != nil {
+		return err
+	}
+	defer w.Close()
+
+	for {
+		code, err := s.runOnce(ctx, w)
+		if ctx.Err() != nil {
+			s.setStatus(SupervisorStopped, 0, code)
+			return nil
+		}
+
+		if err != nil {
+			s.setStatus(SupervisorExited, 0, -1)
+			return err
+		}
+
+		if !s.shouldRestart(code) {
+			s.setStatus(SupervisorExited, 0, code)
+			return nil
+		}
+
+		s.status.Restarts++
+		s.setStatus(SupervisorRestarting, 0, code)
+		s.log.Info("Restarting process", "command", s.config.Command, "exit_code", code, "restarts", s.status.Restarts)
+
+		select {
+		case <-ctx.Done():
+			s.setStatus(SupervisorStopped, 0, code)
+			return nil
+		case <-time.After(s.config.Delay):
+		}
+	}
+}
+
+// runOnce runs the process and returns its exit code
+func (s *Supervisor) runOnce(ctx context.Context, w *rotatingWriter) (int, error) {
+	cmd := exec.CommandContext(ctx, s.config.Command, s.config.Args...)
+	cmd.Env = s.config.Env
+	cmd.Dir = s.config.WorkingDirectory
+	cmd.Stdout = w
+	cmd.Stderr = w
+
+	// processes started by the command can keep the output open after the
+	// command exits, do not wait for them
+	cmd.WaitDelay = time.Second
+
+	err := cmd.Start()
+	if err != nil {
+		return -1, fmt.Errorf("unable to start process: %s", err)
+	}
+
+	s.setStatus(SupervisorRunning, cmd.Process.Pid, 0)
+
+	err = cmd.Wait()
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) && !errors.Is(err, exec.ErrWaitDelay) {
+		return -1, err
+	}
+
+	return cmd.ProcessState.ExitCode(), nil
+}
+
+func (s *Supervisor) shouldRestart(exitCode int) bool {
+	if s.config.MaxRestarts > 0 && s.status.Restarts >= s.config.MaxRestarts {
+		return false
+	}
+
+	switch s.config.Policy {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return exitCode != 0
+	}
+
+	return false
+}
+
+func (s *Supervisor) setStatus(state string, pid int, exitCode int) {
+	s.status.State = state
+	s.status.PID = pid
+	s.status.ExitCode = exitCode
+	s.status.Updated = time.Now()
+
+	if s.config.StatusPath == "" {
+		return
+	}
+
+	d, _ := json.Marshal(s.status)
+
+	err := os.WriteFile(s.config.StatusPath, d, 0644)
+	if err != nil {
+		s.log.Error("Unable to write process status", "path", s.config.StatusPath, "error", err)
+	}
+}
+
+// rotatingWriter writes to a file, when the file is larger than maxSize it
+// is renamed to file.1, file.1 to file.2, etc. keeping maxFiles files
+type rotatingWriter struct {
+	path     string
+	maxSize  int64
+	maxFiles int
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+}
+
+func newRotatingWriter(path string, maxSize int64, maxFiles int) (*rotatingWriter, error) {
+	w := &rotatingWriter{path: path, maxSize: maxSize, maxFiles: maxFiles}
+
+	err := w.open()
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+func (w *rotatingWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.size+int64(len(p)) > w.maxSize && w.size > 0 {
+		err := w.rotate()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+
+	return n, err
+}
+
+func (w *rotatingWriter) Close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.file.Close()
+}
+
+func (w *rotatingWriter) open() error {
+	f, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to open log file: %s", err)
+	}
+
+	i, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("unable to open log file: %s", err)
+	}
+
+	w.file = f
+	w.size = i.Size()
+
+	return nil
+}
+
+func (w *rotatingWriter) rotate() error {
+	w.file.Close()
+
+	// the oldest file is overwritten by the rename
+	for i := w.maxFiles - 1; i > 0; i-- {
+		os.Rename(fmt.Sprintf("%s.%d", w.path, i), fmt.Sprintf("%s.%d", w.path, i+1))
+	}
+
+	err := os.Rename(w.path, w.path+".1")
+	if err != nil {
+		return fmt.Errorf("unable to rotate log file: %s", err)
+	}
+
+	return w.open()
+}
@@ -0,0 +1,125 @@
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSupervisor(t *testing.T, policy string, maxRestarts int, script string) (*Supervisor, SupervisorConfig) {
+	if runtime.GOOS == "windows" {
+		t.Skip("test requires sh")
+	}
+
+	dir := t.TempDir()
+
+	c := SupervisorConfig{
+		Command:     "sh",
+		Args:        []string{"-c", script},
+		Policy:      policy,
+		MaxRestarts: maxRestarts,
+		LogFilePath: filepath.Join(dir, "exec.log"),
+		StatusPath:  filepath.Join(dir, "exec.status"),
+	}
+
+	return NewSupervisor(c, logger.NewTestLogger(t)), c
+}
+
+func TestSupervisorRestartsOnFailure(t *testing.T) {
+	s, c := setupSupervisor(t, RestartOnFailure, 2, "echo run; exit 1")
+
+	err := s.Run(context.Background())
+	require.NoError(t, err)
+
+	st, err := ReadSupervisorStatus(c.StatusPath)
+	require.NoError(t, err)
+	require.Equal(t, SupervisorExited, st.State)
+	require.Equal(t, 2, st.Restarts)
+	require.Equal(t, 1, st.ExitCode)
+
+	d, err := os.ReadFile(c.LogFilePath)
+	require.NoError(t, err)
+	require.Equal(t, 3, strings.Count(string(d), "run"))
+}
+
+func TestSupervisorOnFailureDoesNotRestartOnSuccess(t *testing.T) {
+	s, c := setupSupervisor(t, RestartOnFailure, 2, "exit 0")
+
+	err := s.Run(context.Background())
+	require.NoError(t, err)
+
+	st, err := ReadSupervisorStatus(c.StatusPath)
+	require.NoError(t, err)
+	require.Equal(t, 0, st.Restarts)
+}
+
+func TestSupervisorAlwaysRestartsOnSuccess(t *testing.T) {
+	s, c := setupSupervisor(t, RestartAlways, 1, "exit 0")
+
+	err := s.Run(context.Background())
+	require.NoError(t, err)
+
+	st, err := ReadSupervisorStatus(c.StatusPath)
+	require.NoError(t, err)
+	require.Equal(t, 1, st.Restarts)
+}
+
+func TestSupervisorNeverDoesNotRestart(t *testing.T) {
+	s, c := setupSupervisor(t, RestartNever, 0, "exit 1")
+
+	err := s.Run(context.Background())
+	require.NoError(t, err)
+
+	st, err := ReadSupervisorStatus(c.StatusPath)
+	require.NoError(t, err)
+	require.Equal(t, SupervisorExited, st.State)
+	require.Equal(t, 0, st.Restarts)
+}
+
+func TestSupervisorCancelStopsProcess(t *testing.T) {
+	s, c := setupSupervisor(t, RestartAlways, 0, "sleep 10")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(500*time.Millisecond, cancel)
+
+	start := time.Now()
+	err := s.Run(ctx)
+	require.NoError(t, err)
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	st, err := ReadSupervisorStatus(c.StatusPath)
+	require.NoError(t, err)
+	require.Equal(t, SupervisorStopped, st.State)
+}
+
+func TestRotatingWriterRotatesLargeFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exec.log")
+
+	w, err := newRotatingWriter(path, 10, 2)
+	require.NoError(t, err)
+
+	for _, l := range []string{"11111111\n", "22222222\n", "33333333\n", "44444444\n"} {
+		_, err := w.Write([]byte(l))
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, w.Close())
+
+	d, _ := os.ReadFile(path)
+	require.Equal(t, "44444444\n", string(d))
+
+	d, _ = os.ReadFile(path + ".1")
+	require.Equal(t, "33333333\n", string(d))
+
+	d, _ = os.ReadFile(path + ".2")
+	require.Equal(t, "22222222\n", string(d))
+
+	require.NoFileExists(t, path+".3")
+}
@@ -1,13 +1,32 @@
 package types
 
-import "time"
+import (
+	"io"
+	"time"
+)
 
 type CommandConfig struct {
-	Command          string
-	Args             []string
-	Env              []string
+	Command string
+	Args    []string
+
+	// Env is the environment for the command in the form key=value, when a
+	// key is set more than once the last value is used
+	Env []string
+	// InheritEnv adds the environment of the current process to the
+	// environment of the command, values in Env take precedence. When false
+	// the command only receives the values in Env
+	InheritEnv bool
+
 	WorkingDirectory string
 	RunInBackground  bool
 	LogFilePath      string
-	Timeout          time.Duration
+
+	// Timeout is the maximum time the command can run for, when not set the
+	// maximum command time of the client is used
+	Timeout time.Duration
+
+	// Stdout and Stderr receive the output of commands that do not run in
+	// the background, the output is also written to LogFilePath when set
+	Stdout io.Writer
+	Stderr io.Writer
 }
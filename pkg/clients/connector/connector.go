@@ -1,10 +1,14 @@
 package connector
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -64,6 +68,27 @@ type Connector interface {
 
 	// ListServices returns a slice of active services
 	ListServices() ([]*shipyard.Service, error)
+
+	// AddTLSTermination creates a TLS listener on the given local port
+	// that forwards decrypted traffic to the destination address
+	AddTLSTermination(port int, destAddr, certPath, keyPath string) error
+
+	// RemoveTLSTermination removes a TLS listener for the given port
+	RemoveTLSTermination(port int) error
+
+	// Status returns the active services and TLS listeners along with
+	// their health and traffic
+	Status() (*types.Status, error)
+
+	// ReloadDocs notifies any connected docs UI that the content for a docs
+	// resource has changed
+	ReloadDocs(types.DocsReload) error
+
+	// AddTerminal serves a browser based terminal on the given local port
+	AddTerminal(types.Terminal) error
+
+	// RemoveTerminal stops the terminal for the given port
+	RemoveTerminal(port int) error
 }
 
 // ConnectorImpl is a concrete implementation of the Connector interface
@@ -77,8 +102,12 @@ type ConnectorOptions struct {
 	GrpcBind     string
 	HTTPBind     string
 	APIBind      string
+	DNSBind      string
 	LogLevel     string
 	PidFile      string
+	// MetricsBind is the address of the Prometheus metrics listener,
+	// disabled when empty
+	MetricsBind string
 }
 
 func DefaultConnectorOptions() ConnectorOptions {
@@ -88,9 +117,12 @@ func DefaultConnectorOptions() ConnectorOptions {
 	co.GrpcBind = ":30001"
 	co.HTTPBind = ":30002"
 	co.APIBind = ":30003"
+	co.DNSBind = "127.0.0.1:30053"
 	co.LogLevel = "info"
 	co.PidFile = utils.GetConnectorPIDFile()
 
+	co.MetricsBind = os.Getenv("JUMPPAD_CONNECTOR_METRICS_BIND")
+
 	return co
 }
 
@@ -114,6 +146,8 @@ func (c *ConnectorImpl) Start(cb *types.CertBundle) error {
 		"--grpc-bind", c.options.GrpcBind,
 		"--http-bind", c.options.HTTPBind,
 		"--api-bind", c.options.APIBind,
+		"--dns-bind", c.options.DNSBind,
+		"--metrics-bind", c.options.MetricsBind,
 		"--root-cert-path", cb.RootCertPath,
 		"--server-cert-path", cb.LeafCertPath,
 		"--server-key-path", cb.LeafKeyPath,
@@ -392,6 +426,149 @@ func (c *ConnectorImpl) ListServices() ([]*shipyard.Service, error) {
 	return lr.Services, nil
 }
 
+// AddTLSTermination creates a TLS listener on the given local port
+// that forwards decrypted traffic to the destination address
+func (c *ConnectorImpl) AddTLSTermination(port int, destAddr, certPath, keyPath string) error {
+	d, err := json.Marshal(types.TLSTermination{
+		Port:        port,
+		Destination: destAddr,
+		CertPath:    certPath,
+		KeyPath:     keyPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(c.apiURL("/tls"), "application/json", bytes.NewReader(d))
+	if err != nil {
+		return fmt.Errorf("unable to contact connector API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unable to create TLS termination for port %d, status: %d, error: %s", port, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// RemoveTLSTermination removes a TLS listener for the given port
+func (c *ConnectorImpl) RemoveTLSTermination(port int) error {
+	req, err := http.NewRequest(http.MethodDelete, c.apiURL(fmt.Sprintf("/tls/%d", port)), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to contact connector API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to remove TLS termination for port %d, status: %d", port, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ReloadDocs notifies any connected docs UI that the content for a docs
+// resource has changed
+func (c *ConnectorImpl) ReloadDocs(r types.DocsReload) error {
+	d, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(c.apiURL("/docs/reload"), "application/json", bytes.NewReader(d))
+	if err != nil {
+		return fmt.Errorf("unable to contact connector API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unable to reload docs %s, status: %d, error: %s", r.ID, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// AddTerminal serves a browser based terminal on the given local port
+func (c *ConnectorImpl) AddTerminal(t types.Terminal) error {
+	d, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(c.apiURL("/terminals"), "application/json", bytes.NewReader(d))
+	if err != nil {
+		return fmt.Errorf("unable to contact connector API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("unable to create terminal for port %d, status: %d, error: %s", t.Port, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+// RemoveTerminal stops the terminal for the given port
+func (c *ConnectorImpl) RemoveTerminal(port int) error {
+	req, err := http.NewRequest(http.MethodDelete, c.apiURL(fmt.Sprintf("/terminals/%d", port)), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to contact connector API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("unable to remove terminal for port %d, status: %d", port, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Status returns the active services and TLS listeners along with
+// their health and traffic
+func (c *ConnectorImpl) Status() (*types.Status, error) {
+	resp, err := http.Get(c.apiURL("/status"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to contact connector API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("unable to get connector status, status: %d, error: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	status := &types.Status{}
+	err = json.NewDecoder(resp.Body).Decode(status)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode connector status: %w", err)
+	}
+
+	return status, nil
+}
+
+// apiURL returns the url for the connectors local API server
+func (c *ConnectorImpl) apiURL(path string) string {
+	host := c.options.APIBind
+	if strings.HasPrefix(host, ":") {
+		host = "localhost" + host
+	}
+
+	return fmt.Sprintf("http://%s%s", host, path)
+}
+
 func getClient(cert *types.CertBundle, uri string) (shipyard.RemoteConnectionClient, error) {
 	// if we are using TLS create a TLS client
 	certificate, err := tls.LoadX509KeyPair(cert.LeafCertPath, cert.LeafKeyPath)
@@ -2,13 +2,17 @@ package connector
 
 import (
 	"fmt"
+	"io"
 	"math/rand"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"path"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 	"time"
 
@@ -342,3 +346,70 @@ func testConnectorStartPathWithSpaces(t *testing.T) {
 		return c.IsRunning()
 	}, 5*time.Second, 100*time.Millisecond, "Connector should be running after start")
 }
+
+func TestAddTLSTerminationCallsAPI(t *testing.T) {
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(ts.Close)
+
+	opts := DefaultConnectorOptions()
+	opts.APIBind = strings.TrimPrefix(ts.URL, "http://")
+
+	c := NewConnector(opts)
+	err := c.AddTLSTermination(8443, "localhost:31001", "/tmp/cert", "/tmp/key")
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(body), `"port":8443`)
+	assert.Contains(t, string(body), `"destination":"localhost:31001"`)
+}
+
+func TestAddTLSTerminationWithAPIErrorReturnsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(ts.Close)
+
+	opts := DefaultConnectorOptions()
+	opts.APIBind = strings.TrimPrefix(ts.URL, "http://")
+
+	c := NewConnector(opts)
+	err := c.AddTLSTermination(8443, "localhost:31001", "/tmp/cert", "/tmp/key")
+	assert.Error(t, err)
+}
+
+func TestStatusReturnsStatusFromAPI(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/status", r.URL.Path)
+		w.Write([]byte(`{"services":[{"id":"123","name":"web","healthy":true}],"tls_terminations":[{"port":8443,"bytes_in":42}]}`))
+	}))
+	t.Cleanup(ts.Close)
+
+	opts := DefaultConnectorOptions()
+	opts.APIBind = strings.TrimPrefix(ts.URL, "http://")
+
+	c := NewConnector(opts)
+	s, err := c.Status()
+	assert.NoError(t, err)
+
+	assert.Len(t, s.Services, 1)
+	assert.True(t, s.Services[0].Healthy)
+	assert.Equal(t, 8443, s.TLSTerminations[0].Port)
+	assert.Equal(t, int64(42), s.TLSTerminations[0].BytesIn)
+}
+
+func TestStatusWithAPIErrorReturnsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(ts.Close)
+
+	opts := DefaultConnectorOptions()
+	opts.APIBind = strings.TrimPrefix(ts.URL, "http://")
+
+	c := NewConnector(opts)
+	_, err := c.Status()
+	assert.Error(t, err)
+}
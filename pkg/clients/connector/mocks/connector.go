@@ -13,6 +13,42 @@ type Connector struct {
 	mock.Mock
 }
 
+// AddTLSTermination provides a mock function with given fields: port, destAddr, certPath, keyPath
+func (_m *Connector) AddTLSTermination(port int, destAddr string, certPath string, keyPath string) error {
+	ret := _m.Called(port, destAddr, certPath, keyPath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTLSTermination")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int, string, string, string) error); ok {
+		r0 = rf(port, destAddr, certPath, keyPath)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddTerminal provides a mock function with given fields: _a0
+func (_m *Connector) AddTerminal(_a0 types.Terminal) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTerminal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.Terminal) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ExposeService provides a mock function with given fields: name, port, remoteAddr, destAddr, direction
 func (_m *Connector) ExposeService(name string, port int, remoteAddr string, destAddr string, direction string) (string, error) {
 	ret := _m.Called(name, port, remoteAddr, destAddr, direction)
@@ -197,6 +233,60 @@ func (_m *Connector) RemoveService(id string) error {
 	return r0
 }
 
+// ReloadDocs provides a mock function with given fields: _a0
+func (_m *Connector) ReloadDocs(_a0 types.DocsReload) error {
+	ret := _m.Called(_a0)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReloadDocs")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.DocsReload) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveTLSTermination provides a mock function with given fields: port
+func (_m *Connector) RemoveTLSTermination(port int) error {
+	ret := _m.Called(port)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTLSTermination")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(port)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveTerminal provides a mock function with given fields: port
+func (_m *Connector) RemoveTerminal(port int) error {
+	ret := _m.Called(port)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveTerminal")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int) error); ok {
+		r0 = rf(port)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Start provides a mock function with given fields: _a0
 func (_m *Connector) Start(_a0 *types.CertBundle) error {
 	ret := _m.Called(_a0)
@@ -215,6 +305,36 @@ func (_m *Connector) Start(_a0 *types.CertBundle) error {
 	return r0
 }
 
+// Status provides a mock function with no fields
+func (_m *Connector) Status() (*types.Status, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Status")
+	}
+
+	var r0 *types.Status
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*types.Status, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *types.Status); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Status)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Stop provides a mock function with no fields
 func (_m *Connector) Stop() error {
 	ret := _m.Called()
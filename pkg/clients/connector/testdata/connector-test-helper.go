@@ -15,6 +15,7 @@ func main() {
 	flag.String("grpc-bind", "", "grpc bind address")
 	flag.String("http-bind", "", "http bind address")
 	flag.String("api-bind", "", "api bind address")
+	flag.String("dns-bind", "", "dns bind address")
 	flag.String("root-cert-path", "", "root cert path")
 	flag.String("server-cert-path", "", "server cert path")
 	flag.String("server-key-path", "", "server key path")
@@ -0,0 +1,12 @@
+package types
+
+// DocsReload defines an event sent to the docs UI when the content for a
+// docs resource has been regenerated
+type DocsReload struct {
+	// ID of the docs resource
+	ID string `json:"id"`
+	// Checksum of the regenerated content
+	Checksum string `json:"checksum"`
+	// Pages contains the URIs of the pages that have changed
+	Pages []string `json:"pages"`
+}
@@ -0,0 +1,30 @@
+package types
+
+// Status is returned by the connector API and describes the active
+// tunnels and TLS listeners
+type Status struct {
+	Services        []ServiceStatus        `json:"services"`
+	TLSTerminations []TLSTerminationStatus `json:"tls_terminations"`
+}
+
+// ServiceStatus describes a service tunnelled by the connector
+type ServiceStatus struct {
+	ID                  string `json:"id"`
+	Name                string `json:"name"`
+	Type                string `json:"type"` // local or remote
+	SourcePort          int    `json:"source_port"`
+	RemoteConnectorAddr string `json:"remote_connector_addr"`
+	DestinationAddr     string `json:"destination_addr"`
+	Status              string `json:"status"`
+	Healthy             bool   `json:"healthy"`
+}
+
+// TLSTerminationStatus describes a TLS listener and the traffic it has
+// forwarded since it was created
+type TLSTerminationStatus struct {
+	TLSTermination
+
+	Connections int64 `json:"connections"`
+	BytesIn     int64 `json:"bytes_in"`
+	BytesOut    int64 `json:"bytes_out"`
+}
@@ -0,0 +1,19 @@
+package types
+
+// TerminalLocal is the target for a terminal that runs a shell on the local
+// machine
+const TerminalLocal = "local"
+
+// Terminal defines a browser based terminal served by the connector on the
+// given local port
+type Terminal struct {
+	Port int `json:"port"`
+	// Target is the name of the container to attach to or TerminalLocal
+	Target           string `json:"target"`
+	Shell            string `json:"shell"`
+	User             string `json:"user"`
+	WorkingDirectory string `json:"working_directory"`
+	// Token must be presented by the browser to access the terminal
+	Token string `json:"token"`
+	Title string `json:"title,omitempty"`
+}
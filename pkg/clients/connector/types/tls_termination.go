@@ -0,0 +1,10 @@
+package types
+
+// TLSTermination defines the details for a TLS listener in the connector
+// that forwards decrypted traffic to a local destination
+type TLSTermination struct {
+	Port        int    `json:"port"`
+	Destination string `json:"destination"`
+	CertPath    string `json:"cert_path"`
+	KeyPath     string `json:"key_path"`
+}
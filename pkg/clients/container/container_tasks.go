@@ -27,6 +27,8 @@ type ContainerTasks interface {
 	ContainerInfo(id string) (interface{}, error)
 	// RemoveContainer stops and removes a running container
 	RemoveContainer(id string, force bool) error
+	// RemoveContainers stops and removes the containers concurrently
+	RemoveContainers(ids []string, force bool) error
 	// BuildContainer builds a container based on the given configuration
 	// If a cached image already exists Build will noop
 	// When force is specified BuildContainer will rebuild the container regardless of cached images
@@ -56,6 +58,10 @@ type ContainerTasks interface {
 	// If the force parameter is set then PullImage will pull regardless of the image already
 	// being cached locally.
 	PullImage(image types.Image, force bool) error
+	// PullImageWithProgress pulls a Docker image in the same way as PullImage,
+	// progress is called with the bytes downloaded and the total bytes of the
+	// layers as the pull progresses
+	PullImageWithProgress(image types.Image, force bool, progress func(current, total int64)) error
 	// PushImage pushes an image to the registry
 	PushImage(image types.Image) error
 	// FindContainerIDs returns the Container IDs for the given container name
@@ -66,6 +72,14 @@ type ContainerTasks interface {
 	// io.ReadCloser.
 	// Returns an error if the container is not running
 	ContainerLogs(id string, stdOut, stdErr bool) (io.ReadCloser, error)
+	// ContainerStats returns the CPU, memory, network, and disk usage of the
+	// container with the given id or name, the engine samples the container
+	// twice to calculate the CPU usage so the call takes around a second.
+	// Returns an error if the container is not running
+	ContainerStats(id string) (*types.ContainerStats, error)
+	// FileExists returns true when the file at the given path exists in the
+	// container, symbolic links are followed
+	FileExists(id, path string) (bool, error)
 	// CopyFromContainer allows the copying of a file from a container
 	CopyFromContainer(id, src, dst string) error
 	// CopyToContainer allows a file to be copied into a container
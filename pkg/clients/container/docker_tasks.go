@@ -4,14 +4,18 @@ import (
 	"archive/tar"
 	"bytes"
 	"context"
-	"encoding/base64"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	gonet "net"
 	"os"
 	gosignal "os/signal"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -26,6 +30,7 @@ import (
 	"github.com/docker/docker/api/types/network"
 	registrytypes "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/api/types/volume"
+	"github.com/docker/docker/errdefs"
 	"github.com/docker/docker/pkg/jsonmessage"
 	"github.com/docker/go-connections/nat"
 	dtypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
@@ -51,6 +56,14 @@ const (
 
 const defaultExitCode = 254
 
+// defaultUnprivilegedPortStart is the lowest port an unprivileged process
+// can bind when net.ipv4.ip_unprivileged_port_start can not be read
+const defaultUnprivilegedPortStart = 1024
+
+// unprivilegedPortStartPath is the sysctl that sets the lowest port an
+// unprivileged process can bind
+var unprivilegedPortStartPath = "/proc/sys/net/ipv4/ip_unprivileged_port_start"
+
 // DockerTasks is a concrete implementation of ContainerTasks which uses the Docker SDK
 type DockerTasks struct {
 	engineType    string
@@ -63,6 +76,12 @@ type DockerTasks struct {
 	tg            *ctar.TarGz
 	force         bool
 	defaultWait   time.Duration
+
+	// rootless engines run in a user namespace and can not bind privileged
+	// ports or share the host cgroup namespace
+	rootless              bool
+	cgroupVersion         string
+	unprivilegedPortStart int
 }
 
 // NewDockerTasks creates a DockerTasks with the given Docker client
@@ -91,11 +110,46 @@ func NewDockerTasks(c Docker, il images.ImageLog, tg *ctar.TarGz, l logger.Logge
 		return nil, fmt.Errorf("error checking server storage driver, error: %s", err)
 	}
 
-	return &DockerTasks{engineType: t, storageDriver: info.Driver, c: c, il: il, tg: tg, l: l, defaultWait: 1 * time.Second, cpu: info.NCPU, memory: int(info.MemTotal)}, nil
+	dt := &DockerTasks{engineType: t, storageDriver: info.Driver, c: c, il: il, tg: tg, l: l, defaultWait: 1 * time.Second, cpu: info.NCPU, memory: int(info.MemTotal), cgroupVersion: info.CgroupVersion}
+
+	// rootless Docker and Podman report the rootless security option
+	for _, o := range info.SecurityOptions {
+		if strings.Contains(o, "name=rootless") {
+			dt.rootless = true
+			dt.unprivilegedPortStart = unprivilegedPortStart()
+		}
+	}
+
+	return dt, nil
 }
 
 func (d *DockerTasks) EngineInfo() *dtypes.EngineInfo {
-	return &dtypes.EngineInfo{StorageDriver: d.storageDriver, EngineType: d.engineType, CPU: d.cpu, Memory: d.memory}
+	return &dtypes.EngineInfo{
+		StorageDriver:         d.storageDriver,
+		EngineType:            d.engineType,
+		CPU:                   d.cpu,
+		Memory:                d.memory,
+		Rootless:              d.rootless,
+		CgroupVersion:         d.cgroupVersion,
+		UnprivilegedPortStart: d.unprivilegedPortStart,
+	}
+}
+
+// unprivilegedPortStart returns the lowest port that a rootless engine can
+// bind, rootless engines always run on the local machine so the sysctl of the
+// host is used
+func unprivilegedPortStart() int {
+	d, err := os.ReadFile(unprivilegedPortStartPath)
+	if err != nil {
+		return defaultUnprivilegedPortStart
+	}
+
+	p, err := strconv.Atoi(strings.TrimSpace(string(d)))
+	if err != nil {
+		return defaultUnprivilegedPortStart
+	}
+
+	return p
 }
 
 // SetForce sets a global override for the DockerTasks, when set to true
@@ -311,10 +365,25 @@ func (d *DockerTasks) CreateContainer(c *dtypes.Container) (string, error) {
 		}
 	}
 
+	if d.rootless {
+		err := d.checkRootlessPorts(hc.PortBindings)
+		if err != nil {
+			return "", fmt.Errorf("unable to create container %s: %w", c.Name, err)
+		}
+	}
+
 	// is this a priviledged container
 	hc.Privileged = c.Privileged
 	if c.Privileged {
 		hc.CgroupnsMode = "host"
+
+		// a rootless engine can not give the container write access to
+		// the cgroups of the host, use a private namespace with the
+		// cgroups delegated to the user
+		if d.rootless {
+			d.l.Debug("Using private cgroup namespace for rootless engine", "ref", c.Name)
+			hc.CgroupnsMode = "private"
+		}
 	}
 
 	// are we attaching the container to a sidecar network?
@@ -337,6 +406,23 @@ func (d *DockerTasks) CreateContainer(c *dtypes.Container) (string, error) {
 			if net.IPv6Enabled {
 				ipv6Enabled = true
 			}
+
+			// validate any static ip address against the network subnet
+			if n.IPAddress != "" {
+				subnet := net.Subnet
+				if ip := gonet.ParseIP(n.IPAddress); ip != nil && ip.To4() == nil {
+					subnet = net.SubnetIPv6
+				}
+
+				if subnet == "" {
+					return "", fmt.Errorf("unable to create container, network %s does not have an IPv6 subnet for the ip address %s", n.ID, n.IPAddress)
+				}
+
+				err := utils.ValidateSubnetIP(n.IPAddress, subnet)
+				if err != nil {
+					return "", fmt.Errorf("unable to create container, invalid ip address for network %s: %w", n.ID, err)
+				}
+			}
 		}
 	}
 
@@ -475,6 +561,13 @@ func (d *DockerTasks) FindImagesInLocalRegistry(filter string) ([]string, error)
 
 // PullImage pulls a Docker image from a remote repo
 func (d *DockerTasks) PullImage(img dtypes.Image, force bool) error {
+	return d.PullImageWithProgress(img, force, nil)
+}
+
+// PullImageWithProgress pulls a Docker image from a remote repo, progress is
+// called with the bytes downloaded and the total bytes of the layers that are
+// being downloaded
+func (d *DockerTasks) PullImageWithProgress(img dtypes.Image, force bool, progress func(current, total int64)) error {
 	// if image is local, do not try to pull jumppad.dev/localcache
 	if strings.HasPrefix(img.Name, utils.BuildImagePrefix) {
 		return nil
@@ -510,6 +603,7 @@ func (d *DockerTasks) PullImage(img dtypes.Image, force bool) error {
 	if err != nil {
 		return fmt.Errorf("error pulling image: %w", err)
 	}
+	defer out.Close()
 
 	// update the image log
 	err = d.il.Log(in, images.ImageTypeDocker)
@@ -518,7 +612,10 @@ func (d *DockerTasks) PullImage(img dtypes.Image, force bool) error {
 	}
 
 	// write the output to the debug log
-	io.Copy(d.l.StandardWriter(), out)
+	err = readPullProgress(out, d.l.StandardWriter(), progress)
+	if err != nil {
+		return fmt.Errorf("error pulling image: %w", err)
+	}
 
 	return nil
 }
@@ -619,6 +716,28 @@ func (d *DockerTasks) RemoveContainer(id string, force bool) error {
 	return d.c.ContainerRemove(context.Background(), id, container.RemoveOptions{Force: true, RemoveVolumes: true})
 }
 
+// RemoveContainers stops and removes the containers concurrently, the
+// graceful stop of each container runs at the same time so removing several
+// containers takes no longer than removing the slowest
+func (d *DockerTasks) RemoveContainers(ids []string, force bool) error {
+	errs := make([]error, len(ids))
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(ids))
+
+	for i, id := range ids {
+		go func(i int, id string) {
+			defer wg.Done()
+
+			errs[i] = d.RemoveContainer(id, force)
+		}(i, id)
+	}
+
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
 func (d *DockerTasks) RemoveImage(id string) error {
 	_, err := d.c.ImageRemove(context.Background(), id, image.RemoveOptions{Force: true})
 
@@ -748,6 +867,89 @@ func (d *DockerTasks) ContainerLogs(id string, stdOut, stdErr bool) (io.ReadClos
 	return d.c.ContainerLogs(context.Background(), id, container.LogsOptions{ShowStderr: stdErr, ShowStdout: stdOut})
 }
 
+// ContainerStats returns the resource usage for the container
+func (d *DockerTasks) ContainerStats(id string) (*dtypes.ContainerStats, error) {
+	// when stream is false the engine waits for a second sample so that the
+	// previous CPU stats are set
+	sr, err := d.c.ContainerStats(context.Background(), id, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read stats for container %s: %s", id, err)
+	}
+	defer sr.Body.Close()
+
+	st := container.StatsResponse{}
+	err = json.NewDecoder(sr.Body).Decode(&st)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode stats for container %s: %s", id, err)
+	}
+
+	cs := &dtypes.ContainerStats{
+		CPUPercent:  cpuPercent(st),
+		MemoryUsage: memoryUsage(st.MemoryStats),
+		MemoryLimit: st.MemoryStats.Limit,
+	}
+
+	for _, n := range st.Networks {
+		cs.NetworkRx += n.RxBytes
+		cs.NetworkTx += n.TxBytes
+	}
+
+	for _, b := range st.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(b.Op) {
+		case "read":
+			cs.BlockRead += b.Value
+		case "write":
+			cs.BlockWrite += b.Value
+		}
+	}
+
+	// the size of the writable layer is only returned when listing
+	// containers
+	args := filters.NewArgs()
+	args.Add("name", fmt.Sprintf("^%s$", id))
+
+	cl, err := d.c.ContainerList(context.Background(), container.ListOptions{Filters: args, Size: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read disk usage for container %s: %s", id, err)
+	}
+
+	if len(cl) > 0 {
+		cs.DiskUsage = cl[0].SizeRw
+	}
+
+	return cs, nil
+}
+
+// cpuPercent calculates the CPU usage between the two samples in the stats
+// in the same way as the docker stats command
+func cpuPercent(st container.StatsResponse) float64 {
+	cpuDelta := float64(st.CPUStats.CPUUsage.TotalUsage) - float64(st.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(st.CPUStats.SystemUsage) - float64(st.PreCPUStats.SystemUsage)
+
+	cpus := float64(st.CPUStats.OnlineCPUs)
+	if cpus == 0 {
+		cpus = float64(len(st.CPUStats.CPUUsage.PercpuUsage))
+	}
+
+	if cpuDelta <= 0 || systemDelta <= 0 {
+		return 0
+	}
+
+	return cpuDelta / systemDelta * cpus * 100
+}
+
+// memoryUsage returns the memory used without the inactive page cache, the
+// name of the stat depends on the cgroup version
+func memoryUsage(ms container.MemoryStats) uint64 {
+	for _, k := range []string{"total_inactive_file", "inactive_file"} {
+		if v, ok := ms.Stats[k]; ok && v < ms.Usage {
+			return ms.Usage - v
+		}
+	}
+
+	return ms.Usage
+}
+
 // CopyFromContainer copies a file from a container
 func (d *DockerTasks) CopyFromContainer(id, src, dst string) error {
 	d.l.Debug("Copying file from", "id", id, "src", src, "dst", dst)
@@ -809,10 +1011,46 @@ func (d *DockerTasks) CopyFromContainer(id, src, dst string) error {
 	return nil
 }
 
+// FileExists returns true when the file at the given path exists in the
+// container, the container does not need to be running
+func (d *DockerTasks) FileExists(id, p string) (bool, error) {
+	d.l.Debug("Checking file exists in container", "id", id, "path", p)
+
+	// follow a limited number of symbolic links, shared libraries are often
+	// links to a versioned file
+	for i := 0; i < 10; i++ {
+		st, err := d.c.ContainerStatPath(context.Background(), id, p)
+		if err != nil {
+			if errdefs.IsNotFound(err) {
+				return false, nil
+			}
+
+			return false, fmt.Errorf("unable to check '%s' in container '%s': %w", p, id, err)
+		}
+
+		if st.Mode&os.ModeSymlink == 0 || st.LinkTarget == "" {
+			return !st.Mode.IsDir(), nil
+		}
+
+		if path.IsAbs(st.LinkTarget) {
+			p = st.LinkTarget
+		} else {
+			p = path.Join(path.Dir(p), st.LinkTarget)
+		}
+	}
+
+	return false, fmt.Errorf("too many links resolving '%s' in container '%s'", p, id)
+}
+
 var importMutex = sync.Mutex{}
 
 // CopyLocalDockerImagesToVolume writes multiple Docker images to a Docker container as a compressed archive
 // returns the filename of the archive and an error if one occurred
+//
+// Archives are content addressed, the name of the archive is derived from the
+// image ID and the image names. Images that share an ID are saved to a single
+// archive and images that already exist in the volume are not saved or copied
+// again, unless force is set.
 func (d *DockerTasks) CopyLocalDockerImagesToVolume(images []string, volume string, force bool) ([]string, error) {
 	d.l.Debug("Writing docker images to volume", "images", images, "volume", volume)
 
@@ -821,69 +1059,148 @@ func (d *DockerTasks) CopyLocalDockerImagesToVolume(images []string, volume stri
 	importMutex.Lock()
 	defer importMutex.Unlock()
 
-	savedImages := []string{}
+	// group the images by id so that each image is only saved once
+	ids := []string{}
+	names := map[string][]string{}
 
 	// first check that the images are in the local cache
-	for n, i := range images {
-		// first check the short tag like envoy-proxy/envoy:latest
-		args := filters.NewArgs()
-		args.Add("reference", i)
-
-		sum, err := d.c.ImageList(context.Background(), image.ListOptions{Filters: args})
+	for _, i := range images {
+		id, name, err := d.findLocalImage(i)
 		if err != nil {
-			return nil, fmt.Errorf("unable to list images in local Docker cache: %w", err)
+			return nil, err
 		}
 
-		// we have image
-		if len(sum) > 0 {
-			continue
+		if _, ok := names[id]; !ok {
+			ids = append(ids, id)
 		}
 
-		// check the canonical name like docker.io/library/envoy-proxy/envoy:latest as this might be a podman server
-		in := makeImageCanonical(i)
+		names[id] = append(names[id], name)
+	}
 
-		args = filters.NewArgs()
-		args.Add("reference", in)
+	tmpID, err := d.createImportContainer(volume)
+	if err != nil {
+		return nil, err
+	}
+	defer d.RemoveContainer(tmpID, true)
 
-		sum, err = d.c.ImageList(context.Background(), image.ListOptions{Filters: args})
-		if err != nil {
-			return nil, fmt.Errorf("unable to list images in local Docker cache: %w", err)
-		}
+	destPath, err := d.createImportPath(tmpID, "/images")
+	if err != nil {
+		return nil, err
+	}
 
-		if len(sum) > 0 {
-			// update the image name in the collection to the canonical name
-			images[n] = in
+	imported := []string{}
+	for _, id := range ids {
+		archive := imageArchiveName(id, names[id])
+		destFile := fmt.Sprintf("%s/%s", destPath, archive)
+
+		if !d.force && !force && d.fileExists(tmpID, destFile) {
+			d.l.Debug("Images already cached", "images", names[id], "archive", archive)
+			imported = append(imported, destFile)
 			continue
 		}
 
-		return nil, fmt.Errorf("unable to find image '%s' in the local Docker cache, please pull the image before attempting to copy to a volume", i)
-	}
-
-	for _, i := range images {
-		compressedImageName := base64.StdEncoding.EncodeToString([]byte(i))
-
-		d.l.Debug("Copying image to container", "image", i)
-		imageFile, err := d.saveImageToTempFile(i, compressedImageName)
+		d.l.Debug("Copying images to container", "images", names[id], "archive", archive)
+		imageFile, err := d.saveImageToTempFile(names[id], archive)
 		if err != nil {
 			return nil, err
 		}
 
-		// clean up
-		defer os.Remove(imageFile)
-		savedImages = append(savedImages, imageFile)
+		err = d.CopyFileToContainer(tmpID, imageFile, destPath)
+		os.RemoveAll(filepath.Dir(imageFile))
+		if err != nil {
+			return nil, fmt.Errorf("unable to copy images %s to container: %w", strings.Join(names[id], ", "), err)
+		}
+
+		imported = append(imported, destFile)
 	}
 
-	// copy the images to a volume
-	return d.CopyFilesToVolume(volume, savedImages, "/images", force)
+	return imported, nil
 }
 
 // CopyFileToVolume copies a file to a Docker volume
 // returns the names of the stored files
 func (d *DockerTasks) CopyFilesToVolume(volumeID string, filenames []string, path string, force bool) ([]string, error) {
+	tmpID, err := d.createImportContainer(volumeID)
+	if err != nil {
+		return nil, err
+	}
+	defer d.RemoveContainer(tmpID, true)
+
+	// container is running copy the files
+	destPath, err := d.createImportPath(tmpID, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// add each file individually
+	imported := []string{}
+	for _, f := range filenames {
+		// get the filename part
+		name := filepath.Base(f)
+		destFile := fmt.Sprintf("%s/%s", destPath, name)
+
+		// check if the image exists if we are not doing a forced update
+		if !d.force && !force && d.fileExists(tmpID, destFile) {
+			// we have the image already
+			d.l.Debug("File already cached", "name", name, "path", path)
+			imported = append(imported, destFile)
+			continue
+		}
+
+		err = d.CopyFileToContainer(tmpID, f, destPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to copy file %s to container: %w", f, err)
+		}
+
+		imported = append(imported, destFile)
+	}
+
+	return imported, nil
+}
+
+// findLocalImage returns the id and name of the image in the local cache,
+// the canonical name is returned when the image is only found by its
+// canonical name
+func (d *DockerTasks) findLocalImage(i string) (string, string, error) {
+	// first check the short tag like envoy-proxy/envoy:latest
+	args := filters.NewArgs()
+	args.Add("reference", i)
+
+	sum, err := d.c.ImageList(context.Background(), image.ListOptions{Filters: args})
+	if err != nil {
+		return "", "", fmt.Errorf("unable to list images in local Docker cache: %w", err)
+	}
+
+	// we have image
+	if len(sum) > 0 {
+		return sum[0].ID, i, nil
+	}
+
+	// check the canonical name like docker.io/library/envoy-proxy/envoy:latest as this might be a podman server
+	in := makeImageCanonical(i)
+
+	args = filters.NewArgs()
+	args.Add("reference", in)
+
+	sum, err = d.c.ImageList(context.Background(), image.ListOptions{Filters: args})
+	if err != nil {
+		return "", "", fmt.Errorf("unable to list images in local Docker cache: %w", err)
+	}
+
+	if len(sum) > 0 {
+		return sum[0].ID, in, nil
+	}
+
+	return "", "", fmt.Errorf("unable to find image '%s' in the local Docker cache, please pull the image before attempting to copy to a volume", i)
+}
+
+// createImportContainer creates a temporary container that mounts the volume
+// at /cache and waits for it to start, returns the id of the container
+func (d *DockerTasks) createImportContainer(volumeID string) (string, error) {
 	// make sure we have the alpine image needed to copy
 	err := d.PullImage(dtypes.Image{Name: "alpine:latest"}, false)
 	if err != nil {
-		return nil, fmt.Errorf("unable pull 'alpine:latest' needed to copy files to volume: %w", err)
+		return "", fmt.Errorf("unable pull 'alpine:latest' needed to copy files to volume: %w", err)
 	}
 
 	// create a dummy container to import to volume
@@ -904,9 +1221,8 @@ func (d *DockerTasks) CopyFilesToVolume(volumeID string, filenames []string, pat
 
 	tmpID, err := d.CreateContainer(cc)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create dummy container for importing files: %w", err)
+		return "", fmt.Errorf("unable to create dummy container for importing files: %w", err)
 	}
-	defer d.RemoveContainer(tmpID, true)
 
 	// wait for container to start
 	successCount := 0
@@ -938,49 +1254,34 @@ func (d *DockerTasks) CopyFilesToVolume(volumeID string, filenames []string, pat
 			d.l.Error("Timeout waiting for container to start", "ref", tmpID, "error", err)
 			startError = fmt.Errorf("timeout waiting for container to start: %w", startError)
 
-			return nil, startError
+			d.RemoveContainer(tmpID, true)
+			return "", startError
 		}
 
 		// still waiting for success wait
 		time.Sleep(d.defaultWait)
 	}
 
-	// container is running copy the files
+	return tmpID, nil
+}
 
+// createImportPath creates the directory path in the volume mounted by the
+// import container, returns the path in the container
+func (d *DockerTasks) createImportPath(tmpID, path string) (string, error) {
 	// create the directory paths ensure unix paths for containers
 	destPath := filepath.ToSlash(filepath.Join("/cache", path))
-	_, err = d.ExecuteCommand(tmpID, []string{"mkdir", "-p", destPath}, nil, "/", "", "", 300, nil)
+	_, err := d.ExecuteCommand(tmpID, []string{"mkdir", "-p", destPath}, nil, "/", "", "", 300, nil)
 	if err != nil {
-		return nil, fmt.Errorf("unable to create destination path '%s' in volume: %w", destPath, err)
+		return "", fmt.Errorf("unable to create destination path '%s' in volume: %w", destPath, err)
 	}
 
-	// add each file individually
-	imported := []string{}
-	for _, f := range filenames {
-		// get the filename part
-		name := filepath.Base(f)
-		destFile := fmt.Sprintf("%s/%s", destPath, name)
-
-		// check if the image exists if we are not doing a forced update
-		if !d.force && !force {
-			_, err := d.ExecuteCommand(tmpID, []string{"find", destFile}, nil, "/", "", "", 300, nil)
-			if err == nil {
-				// we have the image already
-				d.l.Debug("File already cached", "name", name, "path", path)
-				imported = append(imported, destFile)
-				continue
-			}
-		}
-
-		err = d.CopyFileToContainer(cc.Name, f, destPath)
-		if err != nil {
-			return nil, fmt.Errorf("unable to copy file %s to container: %w", f, err)
-		}
-
-		imported = append(imported, destFile)
-	}
+	return destPath, nil
+}
 
-	return imported, nil
+// fileExists returns true when the file exists in the container
+func (d *DockerTasks) fileExists(id, file string) bool {
+	_, err := d.ExecuteCommand(id, []string{"find", file}, nil, "/", "", "", 300, nil)
+	return err == nil
 }
 
 // CreateFileInContainer creates a file with the given contents and name in the container containerID and
@@ -1304,6 +1605,10 @@ func (d *DockerTasks) AttachNetwork(net, containerID string, aliases []string, i
 	if ipAddress != "" {
 		d.l.Debug("Assigning static ip address", "id", containerID, "network", net, "ip_address", ipAddress)
 		es.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: ipAddress}
+
+		if ip := gonet.ParseIP(ipAddress); ip != nil && ip.To4() == nil {
+			es.IPAMConfig = &network.EndpointIPAMConfig{IPv6Address: ipAddress}
+		}
 	}
 
 	return d.c.NetworkConnect(context.Background(), net, containerID, es)
@@ -1351,7 +1656,8 @@ func (d *DockerTasks) DetachNetwork(network, containerID string) error {
 	return err
 }
 
-// FindNetwork returns a network using the unique resource id
+// FindNetwork returns a network using the unique resource id, only networks
+// created by the current environment are returned
 func (d *DockerTasks) FindNetwork(id string) (dtypes.NetworkAttachment, error) {
 	nets, err := d.c.NetworkList(context.Background(), network.ListOptions{})
 	if err != nil {
@@ -1359,19 +1665,39 @@ func (d *DockerTasks) FindNetwork(id string) (dtypes.NetworkAttachment, error) {
 	}
 
 	for _, n := range nets {
-		if n.Labels["id"] == id {
-			return dtypes.NetworkAttachment{
+		if n.Labels["id"] == id && networkEnvironment(n.Labels) == utils.Environment() {
+			na := dtypes.NetworkAttachment{
 				ID:          n.ID,
 				Name:        n.Name,
 				Subnet:      n.IPAM.Config[0].Subnet,
 				IPv6Enabled: n.EnableIPv6,
-			}, nil
+			}
+
+			// find the IPv6 subnet if one has been configured
+			for _, c := range n.IPAM.Config {
+				if ip, _, err := gonet.ParseCIDR(c.Subnet); err == nil && ip.To4() == nil {
+					na.SubnetIPv6 = c.Subnet
+				}
+			}
+
+			return na, nil
 		}
 	}
 
 	return dtypes.NetworkAttachment{}, fmt.Errorf("a network with the label id: %s, was not found", id)
 }
 
+// networkEnvironment returns the environment that created a network,
+// networks created before environments were added belong to the default
+// environment
+func networkEnvironment(labels map[string]string) string {
+	if e, ok := labels[utils.EnvironmentLabel]; ok {
+		return e
+	}
+
+	return utils.DefaultEnvironment
+}
+
 func (d *DockerTasks) TagImage(source, destination string) error {
 	return d.c.ImageTag(context.Background(), source, destination)
 }
@@ -1382,6 +1708,26 @@ type publishedPorts struct {
 	PortBindings map[nat.Port][]nat.PortBinding
 }
 
+// checkRootlessPorts returns an error when a port binding uses a host port
+// that a rootless engine is not allowed to bind
+func (d *DockerTasks) checkRootlessPorts(bindings nat.PortMap) error {
+	for _, pbs := range bindings {
+		for _, pb := range pbs {
+			p, err := strconv.Atoi(pb.HostPort)
+			if err != nil || p == 0 || p >= d.unprivilegedPortStart {
+				continue
+			}
+
+			return fmt.Errorf(
+				"rootless %s can not publish host port %d, use a host port of %d or above or allow the port with 'sysctl net.ipv4.ip_unprivileged_port_start=%d'",
+				d.engineType, p, d.unprivilegedPortStart, p,
+			)
+		}
+	}
+
+	return nil
+}
+
 // createPublishedPorts converts a list of config.Port to Docker publishedPorts type
 func createPublishedPorts(ps []dtypes.Port) publishedPorts {
 	pp := publishedPorts{
@@ -1483,9 +1829,9 @@ func makeImageCanonical(image string) string {
 
 // saveImageToTempFile saves a Docker image to a temporary tar file
 // it is the responsibility of the caller to remove the temporary file
-func (d *DockerTasks) saveImageToTempFile(image, filename string) (string, error) {
-	// save the image to a local temp file
-	ir, err := d.c.ImageSave(context.Background(), []string{image})
+func (d *DockerTasks) saveImageToTempFile(images []string, filename string) (string, error) {
+	// save the images to a local temp file
+	ir, err := d.c.ImageSave(context.Background(), images)
 	if err != nil {
 		return "", fmt.Errorf("unable to save images: %w", err)
 	}
@@ -1513,6 +1859,97 @@ func (d *DockerTasks) saveImageToTempFile(image, filename string) (string, error
 	return tmpFileName, nil
 }
 
+// readPullProgress reads the output of an image pull writing it to the log,
+// the pull is complete when the output has been read. Errors reported by the
+// registry during the pull are returned
+func readPullProgress(r io.Reader, log io.Writer, progress func(current, total int64)) error {
+	type layer struct {
+		current int64
+		total   int64
+	}
+
+	layers := map[string]*layer{}
+	order := []string{}
+
+	tr := io.TeeReader(r, log)
+	dec := json.NewDecoder(tr)
+
+	for {
+		m := jsonmessage.JSONMessage{}
+		err := dec.Decode(&m)
+		if err == io.EOF {
+			return nil
+		}
+
+		// the output is not a progress stream, read the rest so that the
+		// pull completes
+		if err != nil {
+			io.Copy(io.Discard, tr)
+			return nil
+		}
+
+		if m.Error != nil {
+			io.Copy(io.Discard, tr)
+			return m.Error
+		}
+
+		if progress == nil || m.ID == "" {
+			continue
+		}
+
+		l, ok := layers[m.ID]
+
+		switch m.Status {
+		case "Downloading":
+			if m.Progress == nil || m.Progress.Total <= 0 {
+				continue
+			}
+
+			if !ok {
+				l = &layer{}
+				layers[m.ID] = l
+				order = append(order, m.ID)
+			}
+
+			l.current = m.Progress.Current
+			l.total = m.Progress.Total
+		case "Download complete", "Pull complete":
+			if !ok {
+				continue
+			}
+
+			l.current = l.total
+		default:
+			continue
+		}
+
+		current, total := int64(0), int64(0)
+		for _, id := range order {
+			current += layers[id].current
+			total += layers[id].total
+		}
+
+		progress(current, total)
+	}
+}
+
+// imageArchiveName returns the content addressed name of the archive for
+// the images with the given id, the names are included as the tags are
+// stored in the archive
+func imageArchiveName(id string, images []string) string {
+	names := slices.Clone(images)
+	slices.Sort(names)
+
+	h := sha256.New()
+	h.Write([]byte(id))
+
+	for _, n := range names {
+		h.Write([]byte("\n" + n))
+	}
+
+	return hex.EncodeToString(h.Sum(nil)) + ".tar"
+}
+
 func copyDir(src string, dest string) error {
 
 	if dest == src {
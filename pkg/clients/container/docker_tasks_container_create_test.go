@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -280,7 +281,7 @@ func TestContainerDoesNOTAttachesToUserNetworkWhenNil(t *testing.T) {
 
 func TestContainerAssignsIPToUserNetwork(t *testing.T) {
 	cc, md, mic := createContainerConfig()
-	cc.Networks[0].IPAddress = "192.168.1.123"
+	cc.Networks[0].IPAddress = "10.0.0.123"
 
 	err := setupContainer(t, cc, md, mic)
 	assert.NoError(t, err)
@@ -291,6 +292,44 @@ func TestContainerAssignsIPToUserNetwork(t *testing.T) {
 	assert.Equal(t, cc.Networks[0].IPAddress, nc.IPAMConfig.IPv4Address)
 }
 
+func TestContainerAssignsIPv6ToUserNetwork(t *testing.T) {
+	cc, md, mic := createContainerConfig()
+	cc.Networks[0].IPAddress = "fd00::10"
+
+	testutils.RemoveOn(&md.Mock, "NetworkList")
+	md.On("NetworkList", mock.Anything, mock.Anything).Return(
+		[]network.Summary{
+			{ID: "abc", Labels: map[string]string{"id": "network.testnet"}, EnableIPv6: true, IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "10.0.0.0/24"}, {Subnet: "fd00::/64"}}}},
+			{ID: "123", Labels: map[string]string{"id": "network.wan"}, IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "10.2.0.0/24"}}}},
+		}, nil)
+
+	err := setupContainer(t, cc, md, mic)
+	assert.NoError(t, err)
+
+	params := testutils.GetCalls(&md.Mock, "NetworkConnect")[0].Arguments
+	nc := params[3].(*network.EndpointSettings)
+
+	assert.Equal(t, cc.Networks[0].IPAddress, nc.IPAMConfig.IPv6Address)
+}
+
+func TestContainerWithIPOutsideSubnetReturnsError(t *testing.T) {
+	cc, md, mic := createContainerConfig()
+	cc.Networks[0].IPAddress = "192.168.1.123"
+
+	err := setupContainer(t, cc, md, mic)
+	assert.Error(t, err)
+
+	md.AssertNotCalled(t, "ContainerCreate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestContainerWithIPv6AndNoIPv6SubnetReturnsError(t *testing.T) {
+	cc, md, mic := createContainerConfig()
+	cc.Networks[0].IPAddress = "fd00::10"
+
+	err := setupContainer(t, cc, md, mic)
+	assert.Error(t, err)
+}
+
 func TestContainerAssignsAliasesToUserNetwork(t *testing.T) {
 	cc, md, mic := createContainerConfig()
 	cc.Networks[0].Aliases = []string{"abc", "123"}
@@ -606,3 +645,63 @@ func TestContainerLabels(t *testing.T) {
 	assert.Contains(t, dc.Labels, "com.example.foo")
 	assert.Equal(t, "bar", dc.Labels["com.example.foo"])
 }
+
+func setupRootlessMocks(t *testing.T, portStart string) (*dtypes.Container, *mocks.Docker, *imocks.ImageLog) {
+	cc, md, mic := createContainerConfig()
+
+	testutils.RemoveOn(&md.Mock, "Info")
+	md.On("Info", mock.Anything).Return(system.Info{Driver: StorageDriverOverlay2, CgroupVersion: "2", SecurityOptions: []string{"name=seccomp,profile=builtin", "name=rootless"}}, nil)
+
+	f := filepath.Join(t.TempDir(), "ip_unprivileged_port_start")
+	os.WriteFile(f, []byte(portStart+"\n"), 0644)
+
+	old := unprivilegedPortStartPath
+	unprivilegedPortStartPath = f
+	t.Cleanup(func() { unprivilegedPortStartPath = old })
+
+	return cc, md, mic
+}
+
+func TestNewDockerTasksDetectsRootlessEngine(t *testing.T) {
+	_, md, mic := setupRootlessMocks(t, "80")
+
+	p, err := NewDockerTasks(md, mic, &tar.TarGz{}, logger.NewTestLogger(t))
+	assert.NoError(t, err)
+
+	info := p.EngineInfo()
+	assert.True(t, info.Rootless)
+	assert.Equal(t, "2", info.CgroupVersion)
+	assert.Equal(t, 80, info.UnprivilegedPortStart)
+}
+
+func TestContainerRootlessWithPrivilegedPortReturnsError(t *testing.T) {
+	cc, md, mic := setupRootlessMocks(t, "1024")
+	cc.Ports[0].Host = "80"
+
+	err := setupContainer(t, cc, md, mic)
+	assert.ErrorContains(t, err, "can not publish host port 80")
+
+	md.AssertNotCalled(t, "ContainerCreate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestContainerRootlessWithAllowedPortPublishesPort(t *testing.T) {
+	cc, md, mic := setupRootlessMocks(t, "80")
+	cc.Ports[0].Host = "80"
+
+	err := setupContainer(t, cc, md, mic)
+	assert.NoError(t, err)
+}
+
+func TestContainerRootlessPrivilegedUsesPrivateCgroupNamespace(t *testing.T) {
+	cc, md, mic := setupRootlessMocks(t, "1024")
+	cc.Privileged = true
+
+	err := setupContainer(t, cc, md, mic)
+	assert.NoError(t, err)
+
+	params := testutils.GetCalls(&md.Mock, "ContainerCreate")[0].Arguments
+	hc := params[2].(*container.HostConfig)
+
+	assert.True(t, hc.Privileged)
+	assert.Equal(t, container.CgroupnsMode("private"), hc.CgroupnsMode)
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	"github.com/jumppad-labs/jumppad/pkg/clients/tar"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func setupRemoveTests(t *testing.T) (*DockerTasks, *mocks.Docker) {
@@ -69,3 +70,26 @@ func TestContainerRemoveFailsCallsRemoveForcefully(t *testing.T) {
 
 	md.AssertNumberOfCalls(t, "ContainerRemove", 1)
 }
+
+func TestContainersRemoveRemovesAllContainers(t *testing.T) {
+	dt, md := setupRemoveTests(t)
+	md.On("ContainerStop", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	md.On("ContainerRemove", mock.Anything, mock.Anything, container.RemoveOptions{Force: false, RemoveVolumes: true}).Return(nil)
+
+	err := dt.RemoveContainers([]string{"one", "two", "three"}, false)
+	require.NoError(t, err)
+
+	md.AssertNumberOfCalls(t, "ContainerStop", 3)
+	md.AssertNumberOfCalls(t, "ContainerRemove", 3)
+}
+
+func TestContainersRemoveReturnsErrorWhenRemoveFails(t *testing.T) {
+	dt, md := setupRemoveTests(t)
+	md.On("ContainerRemove", mock.Anything, "one", mock.Anything).Return(nil)
+	md.On("ContainerRemove", mock.Anything, "two", mock.Anything).Return(fmt.Errorf("boom"))
+
+	err := dt.RemoveContainers([]string{"one", "two"}, true)
+	require.ErrorContains(t, err, "boom")
+
+	md.AssertNumberOfCalls(t, "ContainerRemove", 2)
+}
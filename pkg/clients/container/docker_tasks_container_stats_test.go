@@ -0,0 +1,101 @@
+package container
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/system"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/tar"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testStats = `
+{
+  "cpu_stats": {
+    "cpu_usage": {"total_usage": 300000000},
+    "system_cpu_usage": 2000000000,
+    "online_cpus": 2
+  },
+  "precpu_stats": {
+    "cpu_usage": {"total_usage": 100000000},
+    "system_cpu_usage": 1000000000
+  },
+  "memory_stats": {
+    "usage": 52428800,
+    "limit": 2147483648,
+    "stats": {"inactive_file": 10485760}
+  },
+  "networks": {
+    "eth0": {"rx_bytes": 1000, "tx_bytes": 500},
+    "eth1": {"rx_bytes": 24, "tx_bytes": 12}
+  },
+  "blkio_stats": {
+    "io_service_bytes_recursive": [
+      {"op": "read", "value": 4096},
+      {"op": "write", "value": 8192},
+      {"op": "Read", "value": 4096}
+    ]
+  }
+}
+`
+
+func setupContainerStats(t *testing.T) (*DockerTasks, *mocks.Docker) {
+	md := &mocks.Docker{}
+	md.On("ServerVersion", mock.Anything).Return(types.Version{}, nil)
+	md.On("Info", mock.Anything).Return(system.Info{Driver: StorageDriverOverlay2}, nil)
+	md.On("ContainerStats", mock.Anything, mock.Anything, false).Return(
+		container.StatsResponseReader{Body: io.NopCloser(strings.NewReader(testStats))},
+		nil,
+	)
+	md.On("ContainerList", mock.Anything, mock.Anything).Return(
+		[]container.Summary{{ID: "abc", SizeRw: 2048}},
+		nil,
+	)
+
+	dt, _ := NewDockerTasks(md, nil, &tar.TarGz{}, logger.NewTestLogger(t))
+
+	return dt, md
+}
+
+func TestContainerStatsReturnsUsage(t *testing.T) {
+	dt, _ := setupContainerStats(t)
+
+	st, err := dt.ContainerStats("consul.container.jumppad.dev")
+	require.NoError(t, err)
+
+	require.InDelta(t, 40.0, st.CPUPercent, 0.001)
+	require.Equal(t, uint64(41943040), st.MemoryUsage)
+	require.Equal(t, uint64(2147483648), st.MemoryLimit)
+	require.Equal(t, uint64(1024), st.NetworkRx)
+	require.Equal(t, uint64(512), st.NetworkTx)
+	require.Equal(t, uint64(8192), st.BlockRead)
+	require.Equal(t, uint64(8192), st.BlockWrite)
+	require.Equal(t, int64(2048), st.DiskUsage)
+}
+
+func TestContainerStatsListsContainerWithSize(t *testing.T) {
+	dt, md := setupContainerStats(t)
+
+	_, err := dt.ContainerStats("consul.container.jumppad.dev")
+	require.NoError(t, err)
+
+	md.AssertCalled(t, "ContainerList", mock.Anything, mock.MatchedBy(func(opts container.ListOptions) bool {
+		return opts.Size && opts.Filters.Get("name")[0] == "^consul.container.jumppad.dev$"
+	}))
+}
+
+func TestContainerStatsReturnsErrorWhenDockerFails(t *testing.T) {
+	dt, md := setupContainerStats(t)
+	md.ExpectedCalls = nil
+	md.On("ContainerStats", mock.Anything, mock.Anything, false).Return(container.StatsResponseReader{}, fmt.Errorf("boom"))
+
+	_, err := dt.ContainerStats("consul.container.jumppad.dev")
+	require.ErrorContains(t, err, "unable to read stats for container consul.container.jumppad.dev")
+}
@@ -3,7 +3,6 @@ package container
 import (
 	"bufio"
 	"bytes"
-	"encoding/base64"
 	"fmt"
 	"io"
 	"net"
@@ -114,8 +113,7 @@ func TestCopyToVolumeDoesNothingWhenCached(t *testing.T) {
 	args := container.ExecOptions{
 		Cmd: []string{
 			"find",
-			"/cache/images/" +
-				base64.StdEncoding.EncodeToString([]byte(testCopyLocalImages[0])),
+			"/cache/images/" + imageArchiveName("", testCopyLocalImages),
 		},
 		WorkingDir:   "/",
 		AttachStdout: true,
@@ -123,7 +121,7 @@ func TestCopyToVolumeDoesNothingWhenCached(t *testing.T) {
 	}
 
 	mk.AssertCalled(t, "ContainerExecCreate", mock.Anything, "myid", args)
-	mk.AssertNotCalled(t, "ImageSave")
+	mk.AssertNotCalled(t, "ImageSave", mock.Anything, mock.Anything)
 }
 
 func TestCopyToVolumeDoesNotChecksVolumeCacheWhenGlobalForce(t *testing.T) {
@@ -157,6 +155,49 @@ func TestCopyToVolumeSavesImages(t *testing.T) {
 	mk.AssertCalled(t, "ImageSave", mock.Anything, testCopyLocalImages)
 }
 
+func TestCopyToVolumeSavesImagesWithTheSameIDOnce(t *testing.T) {
+	dt, mk := testSetupCopyLocal(t)
+	dt.SetForce(true) // set force pull to avoid execute command block
+
+	files, err := dt.CopyLocalDockerImagesToVolume([]string{"consul:1.6.1", "consul:latest"}, testCopyLocalVolume, false)
+	assert.NoError(t, err)
+
+	mk.AssertNumberOfCalls(t, "ImageSave", 1)
+	mk.AssertCalled(t, "ImageSave", mock.Anything, []string{"consul:1.6.1", "consul:latest"})
+	assert.Len(t, files, 1)
+}
+
+func TestCopyToVolumeSavesImagesWithDifferentIDs(t *testing.T) {
+	dt, mk := testSetupCopyLocal(t)
+	testutils.RemoveOn(&mk.Mock, "ImageList")
+	mk.On("ImageList", mock.Anything, mock.Anything, mock.Anything).Return([]image.Summary{{ID: "sha256:abc"}}, nil).Once()
+	mk.On("ImageList", mock.Anything, mock.Anything, mock.Anything).Return([]image.Summary{{ID: "sha256:def"}}, nil)
+	dt.SetForce(true) // set force pull to avoid execute command block
+
+	files, err := dt.CopyLocalDockerImagesToVolume([]string{"consul:1.6.1", "vault:1.13.0"}, testCopyLocalVolume, false)
+	assert.NoError(t, err)
+
+	mk.AssertNumberOfCalls(t, "ImageSave", 2)
+	assert.Equal(t, []string{
+		"/cache/images/" + imageArchiveName("sha256:abc", []string{"consul:1.6.1"}),
+		"/cache/images/" + imageArchiveName("sha256:def", []string{"vault:1.13.0"}),
+	}, files)
+}
+
+func TestImageArchiveNameChangesWithImageID(t *testing.T) {
+	assert.NotEqual(t,
+		imageArchiveName("sha256:abc", []string{"consul:1.6.1"}),
+		imageArchiveName("sha256:def", []string{"consul:1.6.1"}),
+	)
+}
+
+func TestImageArchiveNameDoesNotDependOnImageOrder(t *testing.T) {
+	assert.Equal(t,
+		imageArchiveName("sha256:abc", []string{"consul:1.6.1", "consul:latest"}),
+		imageArchiveName("sha256:abc", []string{"consul:latest", "consul:1.6.1"}),
+	)
+}
+
 func TestCopyToVolumeSavesImageFailReturnsError(t *testing.T) {
 	dt, mk := testSetupCopyLocal(t)
 	testutils.RemoveOn(&mk.Mock, "ImageSave")
@@ -0,0 +1,72 @@
+package container
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/system"
+	"github.com/docker/docker/errdefs"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/tar"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFileExists(t *testing.T) (*DockerTasks, *mocks.Docker) {
+	md := &mocks.Docker{}
+	md.On("ServerVersion", mock.Anything).Return(types.Version{}, nil)
+	md.On("Info", mock.Anything).Return(system.Info{Driver: StorageDriverOverlay2}, nil)
+
+	dt, _ := NewDockerTasks(md, nil, &tar.TarGz{}, logger.NewTestLogger(t))
+
+	return dt, md
+}
+
+func TestFileExistsReturnsTrueForFile(t *testing.T) {
+	dt, md := setupFileExists(t)
+	md.On("ContainerStatPath", mock.Anything, "abc", "/usr/lib/lib.so").Return(container.PathStat{Mode: 0644}, nil)
+
+	ok, err := dt.FileExists("abc", "/usr/lib/lib.so")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestFileExistsFollowsLinks(t *testing.T) {
+	dt, md := setupFileExists(t)
+	md.On("ContainerStatPath", mock.Anything, "abc", "/usr/lib/lib.so").Return(container.PathStat{Mode: os.ModeSymlink, LinkTarget: "lib.so.1"}, nil)
+	md.On("ContainerStatPath", mock.Anything, "abc", "/usr/lib/lib.so.1").Return(container.PathStat{Mode: 0644}, nil)
+
+	ok, err := dt.FileExists("abc", "/usr/lib/lib.so")
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestFileExistsReturnsFalseWhenNotFound(t *testing.T) {
+	dt, md := setupFileExists(t)
+	md.On("ContainerStatPath", mock.Anything, "abc", "/usr/lib/lib.so").Return(container.PathStat{}, errdefs.NotFound(fmt.Errorf("not found")))
+
+	ok, err := dt.FileExists("abc", "/usr/lib/lib.so")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestFileExistsReturnsFalseForDirectory(t *testing.T) {
+	dt, md := setupFileExists(t)
+	md.On("ContainerStatPath", mock.Anything, "abc", "/usr/lib").Return(container.PathStat{Mode: os.ModeDir}, nil)
+
+	ok, err := dt.FileExists("abc", "/usr/lib")
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestFileExistsReturnsErrorWhenStatFails(t *testing.T) {
+	dt, md := setupFileExists(t)
+	md.On("ContainerStatPath", mock.Anything, "abc", "/usr/lib/lib.so").Return(container.PathStat{}, fmt.Errorf("boom"))
+
+	_, err := dt.FileExists("abc", "/usr/lib/lib.so")
+	require.Error(t, err)
+}
@@ -0,0 +1,58 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/system"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/tar"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFindNetwork(t *testing.T) *DockerTasks {
+	md := &mocks.Docker{}
+	md.On("ServerVersion", mock.Anything).Return(types.Version{}, nil)
+	md.On("Info", mock.Anything).Return(system.Info{Driver: StorageDriverOverlay2}, nil)
+	md.On("NetworkList", mock.Anything, mock.Anything).Return(
+		[]network.Summary{
+			{ID: "abc", Name: "main", Labels: map[string]string{"id": "resource.network.main"}, IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "10.0.0.0/24"}}}},
+			{ID: "123", Name: "main.staging", Labels: map[string]string{"id": "resource.network.main", utils.EnvironmentLabel: "staging"}, IPAM: network.IPAM{Config: []network.IPAMConfig{{Subnet: "10.2.0.0/24"}}}},
+		}, nil)
+
+	dt, err := NewDockerTasks(md, nil, &tar.TarGz{}, logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	return dt
+}
+
+func TestFindNetworkReturnsNetworkWithoutEnvironmentForDefault(t *testing.T) {
+	t.Setenv(utils.EnvironmentEnvVar, utils.DefaultEnvironment)
+	dt := setupFindNetwork(t)
+
+	n, err := dt.FindNetwork("resource.network.main")
+	require.NoError(t, err)
+	require.Equal(t, "main", n.Name)
+}
+
+func TestFindNetworkReturnsNetworkForEnvironment(t *testing.T) {
+	t.Setenv(utils.EnvironmentEnvVar, "staging")
+	dt := setupFindNetwork(t)
+
+	n, err := dt.FindNetwork("resource.network.main")
+	require.NoError(t, err)
+	require.Equal(t, "main.staging", n.Name)
+	require.Equal(t, "10.2.0.0/24", n.Subnet)
+}
+
+func TestFindNetworkDoesNotReturnNetworksFromOtherEnvironments(t *testing.T) {
+	t.Setenv(utils.EnvironmentEnvVar, "dev")
+	dt := setupFindNetwork(t)
+
+	_, err := dt.FindNetwork("resource.network.main")
+	require.Error(t, err)
+}
@@ -132,3 +132,44 @@ func TestPullImageAlwaysWhenForce(t *testing.T) {
 	md.AssertCalled(t, "ImagePull", mock.Anything, mock.Anything, mock.Anything)
 	mic.AssertCalled(t, "Log", mock.Anything, mock.Anything)
 }
+
+var testPullOutput = `{"status":"Pulling from library/consul","id":"1.6.1"}
+{"status":"Downloading","progressDetail":{"current":50,"total":100},"id":"a1"}
+{"status":"Downloading","progressDetail":{"current":100,"total":300},"id":"b2"}
+{"status":"Download complete","progressDetail":{},"id":"a1"}
+{"status":"Pull complete","progressDetail":{},"id":"b2"}
+{"status":"Status: Downloaded newer image for consul:1.6.1"}
+`
+
+func TestPullImageWithProgressReportsDownloadedBytes(t *testing.T) {
+	cc, md, mic := createImagePullConfig()
+	testutils.RemoveOn(&md.Mock, "ImagePull")
+	md.On("ImagePull", mock.Anything, mock.Anything, mock.Anything).Return(
+		io.NopCloser(strings.NewReader(testPullOutput)),
+		nil,
+	)
+
+	p, _ := NewDockerTasks(md, mic, &tar.TarGz{}, logger.NewTestLogger(t))
+
+	calls := [][]int64{}
+	err := p.PullImageWithProgress(cc, false, func(current, total int64) {
+		calls = append(calls, []int64{current, total})
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, [][]int64{{50, 100}, {150, 400}, {200, 400}, {400, 400}}, calls)
+}
+
+func TestPullImageReturnsErrorFromPullOutput(t *testing.T) {
+	cc, md, mic := createImagePullConfig()
+	testutils.RemoveOn(&md.Mock, "ImagePull")
+	md.On("ImagePull", mock.Anything, mock.Anything, mock.Anything).Return(
+		io.NopCloser(strings.NewReader(`{"errorDetail":{"message":"toomanyrequests: rate limit"},"error":"toomanyrequests: rate limit"}`)),
+		nil,
+	)
+
+	p, _ := NewDockerTasks(md, mic, &tar.TarGz{}, logger.NewTestLogger(t))
+
+	err := p.PullImage(cc, false)
+	assert.ErrorContains(t, err, "toomanyrequests: rate limit")
+}
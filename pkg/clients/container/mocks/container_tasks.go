@@ -120,6 +120,36 @@ func (_m *ContainerTasks) ContainerLogs(id string, stdOut bool, stdErr bool) (io
 	return r0, r1
 }
 
+// ContainerStats provides a mock function with given fields: id
+func (_m *ContainerTasks) ContainerStats(id string) (*types.ContainerStats, error) {
+	ret := _m.Called(id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerStats")
+	}
+
+	var r0 *types.ContainerStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*types.ContainerStats, error)); ok {
+		return rf(id)
+	}
+	if rf, ok := ret.Get(0).(func(string) *types.ContainerStats); ok {
+		r0 = rf(id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.ContainerStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CopyFileToContainer provides a mock function with given fields: id, src, dst
 func (_m *ContainerTasks) CopyFileToContainer(id string, src string, dst string) error {
 	ret := _m.Called(id, src, dst)
@@ -402,6 +432,34 @@ func (_m *ContainerTasks) ExecuteScript(id string, contents string, env []string
 	return r0, r1
 }
 
+// FileExists provides a mock function with given fields: id, path
+func (_m *ContainerTasks) FileExists(id string, path string) (bool, error) {
+	ret := _m.Called(id, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileExists")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) (bool, error)); ok {
+		return rf(id, path)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) bool); ok {
+		r0 = rf(id, path)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(id, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // FindContainerIDs provides a mock function with given fields: containerName
 func (_m *ContainerTasks) FindContainerIDs(containerName string) ([]string, error) {
 	ret := _m.Called(containerName)
@@ -556,6 +614,24 @@ func (_m *ContainerTasks) PullImage(image types.Image, force bool) error {
 	return r0
 }
 
+// PullImageWithProgress provides a mock function with given fields: image, force, progress
+func (_m *ContainerTasks) PullImageWithProgress(image types.Image, force bool, progress func(int64, int64)) error {
+	ret := _m.Called(image, force, progress)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PullImageWithProgress")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(types.Image, bool, func(int64, int64)) error); ok {
+		r0 = rf(image, force, progress)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // PushImage provides a mock function with given fields: image
 func (_m *ContainerTasks) PushImage(image types.Image) error {
 	ret := _m.Called(image)
@@ -592,6 +668,24 @@ func (_m *ContainerTasks) RemoveContainer(id string, force bool) error {
 	return r0
 }
 
+// RemoveContainers provides a mock function with given fields: ids, force
+func (_m *ContainerTasks) RemoveContainers(ids []string, force bool) error {
+	ret := _m.Called(ids, force)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveContainers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]string, bool) error); ok {
+		r0 = rf(ids, force)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RemoveImage provides a mock function with given fields: id
 func (_m *ContainerTasks) RemoveImage(id string) error {
 	ret := _m.Called(id)
@@ -82,6 +82,34 @@ func (_m *Docker) CheckpointList(ctx context.Context, _a1 string, options checkp
 	return r0, r1
 }
 
+// ContainerCommit provides a mock function with given fields: ctx, containerID, options
+func (_m *Docker) ContainerCommit(ctx context.Context, containerID string, options typescontainer.CommitOptions) (common.IDResponse, error) {
+	ret := _m.Called(ctx, containerID, options)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerCommit")
+	}
+
+	var r0 common.IDResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, typescontainer.CommitOptions) (common.IDResponse, error)); ok {
+		return rf(ctx, containerID, options)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, typescontainer.CommitOptions) common.IDResponse); ok {
+		r0 = rf(ctx, containerID, options)
+	} else {
+		r0 = ret.Get(0).(common.IDResponse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, typescontainer.CommitOptions) error); ok {
+		r1 = rf(ctx, containerID, options)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ContainerCreate provides a mock function with given fields: ctx, config, hostConfig, networkingConfig, platform, containerName
 func (_m *Docker) ContainerCreate(ctx context.Context, config *typescontainer.Config, hostConfig *typescontainer.HostConfig, networkingConfig *network.NetworkingConfig, platform *v1.Platform, containerName string) (typescontainer.CreateResponse, error) {
 	ret := _m.Called(ctx, config, hostConfig, networkingConfig, platform, containerName)
@@ -258,6 +286,34 @@ func (_m *Docker) ContainerInspect(ctx context.Context, containerID string) (typ
 	return r0, r1
 }
 
+// ContainerStatPath provides a mock function with given fields: ctx, containerID, path
+func (_m *Docker) ContainerStatPath(ctx context.Context, containerID string, path string) (typescontainer.PathStat, error) {
+	ret := _m.Called(ctx, containerID, path)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerStatPath")
+	}
+
+	var r0 typescontainer.PathStat
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (typescontainer.PathStat, error)); ok {
+		return rf(ctx, containerID, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) typescontainer.PathStat); ok {
+		r0 = rf(ctx, containerID, path)
+	} else {
+		r0 = ret.Get(0).(typescontainer.PathStat)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, containerID, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ContainerList provides a mock function with given fields: ctx, options
 func (_m *Docker) ContainerList(ctx context.Context, options typescontainer.ListOptions) ([]typescontainer.Summary, error) {
 	ret := _m.Called(ctx, options)
@@ -318,6 +374,24 @@ func (_m *Docker) ContainerLogs(ctx context.Context, _a1 string, options typesco
 	return r0, r1
 }
 
+// ContainerPause provides a mock function with given fields: ctx, containerID
+func (_m *Docker) ContainerPause(ctx context.Context, containerID string) error {
+	ret := _m.Called(ctx, containerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerPause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, containerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ContainerRemove provides a mock function with given fields: ctx, containerID, options
 func (_m *Docker) ContainerRemove(ctx context.Context, containerID string, options typescontainer.RemoveOptions) error {
 	ret := _m.Called(ctx, containerID, options)
@@ -354,6 +428,34 @@ func (_m *Docker) ContainerStart(_a0 context.Context, _a1 string, _a2 typesconta
 	return r0
 }
 
+// ContainerStats provides a mock function with given fields: ctx, containerID, stream
+func (_m *Docker) ContainerStats(ctx context.Context, containerID string, stream bool) (typescontainer.StatsResponseReader, error) {
+	ret := _m.Called(ctx, containerID, stream)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerStats")
+	}
+
+	var r0 typescontainer.StatsResponseReader
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (typescontainer.StatsResponseReader, error)); ok {
+		return rf(ctx, containerID, stream)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) typescontainer.StatsResponseReader); ok {
+		r0 = rf(ctx, containerID, stream)
+	} else {
+		r0 = ret.Get(0).(typescontainer.StatsResponseReader)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, containerID, stream)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ContainerStop provides a mock function with given fields: ctx, containerID, options
 func (_m *Docker) ContainerStop(ctx context.Context, containerID string, options typescontainer.StopOptions) error {
 	ret := _m.Called(ctx, containerID, options)
@@ -372,6 +474,24 @@ func (_m *Docker) ContainerStop(ctx context.Context, containerID string, options
 	return r0
 }
 
+// ContainerUnpause provides a mock function with given fields: ctx, containerID
+func (_m *Docker) ContainerUnpause(ctx context.Context, containerID string) error {
+	ret := _m.Called(ctx, containerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerUnpause")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, containerID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CopyFromContainer provides a mock function with given fields: ctx, containerID, srcPath
 func (_m *Docker) CopyFromContainer(ctx context.Context, containerID string, srcPath string) (io.ReadCloser, typescontainer.PathStat, error) {
 	ret := _m.Called(ctx, containerID, srcPath)
@@ -0,0 +1,29 @@
+package types
+
+// ContainerStats is the resource usage of a container at the time it was
+// sampled, network and block IO are totals since the container started
+type ContainerStats struct {
+	// CPUPercent is the CPU used since the previous sample by the engine,
+	// 100 is one full CPU core
+	CPUPercent float64
+
+	// MemoryUsage is the memory used in bytes excluding the page cache
+	MemoryUsage uint64
+
+	// MemoryLimit is the maximum memory the container can use in bytes
+	MemoryLimit uint64
+
+	// NetworkRx and NetworkTx are the bytes received and sent on all
+	// networks
+	NetworkRx uint64
+	NetworkTx uint64
+
+	// BlockRead and BlockWrite are the bytes read and written to block
+	// devices
+	BlockRead  uint64
+	BlockWrite uint64
+
+	// DiskUsage is the size in bytes of the files written to the writable
+	// layer of the container
+	DiskUsage int64
+}
@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 type EngineInfo struct {
 	// StorageDriver used by the engine, overlay, devicemapper, etc
 	StorageDriver string
@@ -10,6 +12,28 @@ type EngineInfo struct {
 	// EngineType, docker, podman, not found
 	CPU    int
 	Memory int
+
+	// Rootless is true when the engine runs as an unprivileged user in a
+	// user namespace, e.g. rootless Docker or Podman
+	Rootless bool
+
+	// CgroupVersion used by the engine, 1 or 2
+	CgroupVersion string
+
+	// UnprivilegedPortStart is the lowest host port a rootless engine can
+	// publish, it is 0 when the engine is not rootless
+	UnprivilegedPortStart int
+}
+
+// CheckClusterSupport returns an error when the engine can not run the
+// privileged containers used by Kubernetes and Nomad clusters. Rootless
+// engines can only delegate cgroups to the cluster with cgroup v2.
+func (e *EngineInfo) CheckClusterSupport() error {
+	if e.Rootless && e.CgroupVersion == "1" {
+		return fmt.Errorf("rootless %s uses cgroup v1, clusters require cgroup v2 with cgroup delegation enabled for the user running %s", e.EngineType, e.EngineType)
+	}
+
+	return nil
 }
 
 const (
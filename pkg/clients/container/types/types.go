@@ -36,6 +36,7 @@ type NetworkAttachment struct {
 	IPAddress   string
 	Aliases     []string
 	Subnet      string
+	SubnetIPv6  string
 	IsContainer bool // is the network attachment a container or normal network
 	IPv6Enabled bool
 }
@@ -0,0 +1,120 @@
+package events
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+)
+
+const (
+	// TypeResourceCreateStart is emitted when the engine starts creating or
+	// refreshing a resource
+	TypeResourceCreateStart = "resource.create.start"
+	// TypeResourceCreateSuccess is emitted when a resource has been created
+	TypeResourceCreateSuccess = "resource.create.success"
+	// TypeResourceCreateFail is emitted when a resource could not be created
+	TypeResourceCreateFail = "resource.create.fail"
+	// TypeResourceDestroyStart is emitted when the engine starts destroying a
+	// resource
+	TypeResourceDestroyStart = "resource.destroy.start"
+	// TypeResourceDestroySuccess is emitted when a resource has been destroyed
+	TypeResourceDestroySuccess = "resource.destroy.success"
+	// TypeResourceDestroyFail is emitted when a resource could not be destroyed
+	TypeResourceDestroyFail = "resource.destroy.fail"
+	// TypeEngineUpStart is emitted when the engine starts applying configuration
+	TypeEngineUpStart = "engine.up.start"
+	// TypeEngineUpComplete is emitted when all resources have been applied
+	TypeEngineUpComplete = "engine.up.complete"
+	// TypeEngineUpFail is emitted when applying the configuration failed
+	TypeEngineUpFail = "engine.up.fail"
+	// TypeEngineDownStart is emitted when the engine starts destroying resources
+	TypeEngineDownStart = "engine.down.start"
+	// TypeEngineDownComplete is emitted when all resources have been destroyed
+	TypeEngineDownComplete = "engine.down.complete"
+	// TypeEngineDownFail is emitted when destroying the resources failed
+	TypeEngineDownFail = "engine.down.fail"
+)
+
+// Event is a structured notification emitted by the engine
+type Event struct {
+	Type string    `json:"type"`
+	Time time.Time `json:"time"`
+	// Text is a human readable summary of the event, the field is named text
+	// so that the event can be sent directly to Slack incoming webhooks
+	Text         string  `json:"text"`
+	Path         string  `json:"path,omitempty"`
+	ResourceID   string  `json:"resource_id,omitempty"`
+	ResourceType string  `json:"resource_type,omitempty"`
+	Status       string  `json:"status,omitempty"`
+	Duration     float64 `json:"duration_seconds,omitempty"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// Sink receives events published to a Bus
+type Sink interface {
+	// Send delivers the event, an error does not stop the event being
+	// sent to other sinks
+	Send(e Event) error
+}
+
+// Bus publishes events to a set of sinks, a nil Bus discards all events
+type Bus struct {
+	sinks []Sink
+	types []string
+	log   logger.Logger
+	mutex sync.Mutex
+}
+
+// NewBus creates a Bus that publishes events to the given sinks. When types
+// is not empty only events matching one of the types are published, a type
+// ending in * matches any event with that prefix e.g. resource.create.*
+func NewBus(l logger.Logger, sinks []Sink, types []string) *Bus {
+	return &Bus{
+		sinks: sinks,
+		types: types,
+		log:   l,
+	}
+}
+
+// Publish sends the event to every sink, events are delivered in order and
+// errors from sinks are logged rather than returned so that a failing sink
+// does not affect the engine
+func (b *Bus) Publish(e Event) {
+	if b == nil || len(b.sinks) == 0 || !b.matches(e.Type) {
+		return
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, s := range b.sinks {
+		err := s.Send(e)
+		if err != nil {
+			b.log.Warn("Unable to send event", "type", e.Type, "error", err)
+		}
+	}
+}
+
+func (b *Bus) matches(t string) bool {
+	if len(b.types) == 0 {
+		return true
+	}
+
+	for _, m := range b.types {
+		if m == t {
+			return true
+		}
+
+		if strings.HasSuffix(m, "*") && strings.HasPrefix(t, strings.TrimSuffix(m, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,153 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSink struct {
+	events []Event
+	err    error
+}
+
+func (r *recordingSink) Send(e Event) error {
+	r.events = append(r.events, e)
+	return r.err
+}
+
+func TestPublishSendsToAllSinks(t *testing.T) {
+	s1 := &recordingSink{}
+	s2 := &recordingSink{}
+	b := NewBus(logger.NewTestLogger(t), []Sink{s1, s2}, nil)
+
+	b.Publish(Event{Type: TypeEngineUpComplete})
+
+	require.Len(t, s1.events, 1)
+	require.Len(t, s2.events, 1)
+	require.Equal(t, TypeEngineUpComplete, s1.events[0].Type)
+	require.False(t, s1.events[0].Time.IsZero())
+}
+
+func TestPublishWithSinkErrorSendsToOtherSinks(t *testing.T) {
+	s1 := &recordingSink{err: fmt.Errorf("boom")}
+	s2 := &recordingSink{}
+	b := NewBus(logger.NewTestLogger(t), []Sink{s1, s2}, nil)
+
+	b.Publish(Event{Type: TypeEngineUpComplete})
+
+	require.Len(t, s2.events, 1)
+}
+
+func TestPublishWithTypesFiltersEvents(t *testing.T) {
+	s := &recordingSink{}
+	b := NewBus(logger.NewTestLogger(t), []Sink{s}, []string{TypeEngineUpComplete, "resource.destroy.*"})
+
+	b.Publish(Event{Type: TypeEngineUpStart})
+	b.Publish(Event{Type: TypeResourceCreateStart})
+	b.Publish(Event{Type: TypeResourceDestroyFail})
+	b.Publish(Event{Type: TypeEngineUpComplete})
+
+	require.Len(t, s.events, 2)
+	require.Equal(t, TypeResourceDestroyFail, s.events[0].Type)
+	require.Equal(t, TypeEngineUpComplete, s.events[1].Type)
+}
+
+func TestPublishWithNilBusDoesNothing(t *testing.T) {
+	var b *Bus
+
+	require.NotPanics(t, func() {
+		b.Publish(Event{Type: TypeEngineUpStart})
+	})
+}
+
+func TestParseSinksReturnsSinks(t *testing.T) {
+	s, err := ParseSinks("stdout, webhook:https://example.com/hook,script:./notify.sh")
+	require.NoError(t, err)
+
+	require.Len(t, s, 3)
+	require.IsType(t, &WriterSink{}, s[0])
+	require.Equal(t, "https://example.com/hook", s[1].(*WebhookSink).url)
+	require.Equal(t, "./notify.sh", s[2].(*ScriptSink).path)
+}
+
+func TestParseSinksWithUnknownSinkReturnsError(t *testing.T) {
+	_, err := ParseSinks("stdout,email:me@example.com")
+	require.ErrorContains(t, err, "unknown event sink")
+}
+
+func TestParseSinksWithMissingURLReturnsError(t *testing.T) {
+	_, err := ParseSinks("webhook")
+	require.ErrorContains(t, err, "requires a URL")
+}
+
+func TestWriterSinkWritesJSONLines(t *testing.T) {
+	buf := &bytes.Buffer{}
+	s := NewWriterSink(buf)
+
+	require.NoError(t, s.Send(Event{Type: TypeResourceCreateStart, ResourceID: "resource.container.app"}))
+	require.NoError(t, s.Send(Event{Type: TypeResourceCreateSuccess, ResourceID: "resource.container.app"}))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	e := Event{}
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &e))
+	require.Equal(t, TypeResourceCreateSuccess, e.Type)
+	require.Equal(t, "resource.container.app", e.ResourceID)
+}
+
+func TestWebhookSinkPostsEvent(t *testing.T) {
+	var body []byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer ts.Close()
+
+	err := NewWebhookSink(ts.URL).Send(Event{Type: TypeEngineUpComplete, Text: "Environment is ready"})
+	require.NoError(t, err)
+
+	require.Contains(t, string(body), `"type":"engine.up.complete"`)
+	require.Contains(t, string(body), `"text":"Environment is ready"`)
+}
+
+func TestWebhookSinkWithErrorStatusReturnsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	err := NewWebhookSink(ts.URL).Send(Event{Type: TypeEngineUpComplete})
+	require.ErrorContains(t, err, "status 500")
+}
+
+func TestScriptSinkRunsScriptWithEvent(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("script sink test requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	script := filepath.Join(dir, "notify.sh")
+
+	err := os.WriteFile(script, []byte("#!/bin/sh\necho $JUMPPAD_EVENT_TYPE > "+out+"\ncat >> "+out+"\n"), 0755)
+	require.NoError(t, err)
+
+	err = NewScriptSink(script).Send(Event{Type: TypeEngineDownComplete})
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(out)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(string(d), "engine.down.complete\n{"))
+}
@@ -0,0 +1,151 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SinkStdout writes events to stdout as JSON, one event per line
+	SinkStdout = "stdout"
+	// SinkWebhook posts events as JSON to a URL, e.g. webhook:https://example.com
+	SinkWebhook = "webhook"
+	// SinkScript runs a local script for every event, e.g. script:./notify.sh
+	SinkScript = "script"
+)
+
+// sinkTimeout is the maximum time a webhook or script can take to handle an
+// event
+const sinkTimeout = 10 * time.Second
+
+// ParseSinks creates sinks from a comma separated list of sink definitions.
+// Each definition is either stdout, webhook:<url> or script:<path>.
+func ParseSinks(spec string) ([]Sink, error) {
+	sinks := []Sink{}
+
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		kind, value, _ := strings.Cut(s, ":")
+
+		switch kind {
+		case SinkStdout:
+			sinks = append(sinks, NewWriterSink(os.Stdout))
+		case SinkWebhook:
+			if value == "" {
+				return nil, fmt.Errorf("webhook event sink requires a URL, e.g. webhook:https://example.com/hook")
+			}
+
+			sinks = append(sinks, NewWebhookSink(value))
+		case SinkScript:
+			if value == "" {
+				return nil, fmt.Errorf("script event sink requires a path, e.g. script:./notify.sh")
+			}
+
+			sinks = append(sinks, NewScriptSink(value))
+		default:
+			return nil, fmt.Errorf("unknown event sink %q, valid sinks are stdout, webhook:<url> and script:<path>", s)
+		}
+	}
+
+	return sinks, nil
+}
+
+// WriterSink writes events to an io.Writer as JSON, one event per line
+type WriterSink struct {
+	w     io.Writer
+	mutex sync.Mutex
+}
+
+// NewWriterSink creates a sink that writes to w
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{w: w}
+}
+
+func (s *WriterSink) Send(e Event) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return json.NewEncoder(s.w).Encode(e)
+}
+
+// WebhookSink posts each event as JSON to a URL
+type WebhookSink struct {
+	url   string
+	httpc *http.Client
+}
+
+// NewWebhookSink creates a sink that posts events to url
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		url:   url,
+		httpc: &http.Client{Timeout: sinkTimeout},
+	}
+}
+
+func (s *WebhookSink) Send(e Event) error {
+	d, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.httpc.Post(s.url, "application/json", bytes.NewReader(d))
+	if err != nil {
+		return fmt.Errorf("unable to post event to webhook: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ScriptSink runs a local script for each event, the event is written to the
+// script's stdin as JSON and the type and resource are set in the
+// environment variables JUMPPAD_EVENT_TYPE and JUMPPAD_EVENT_RESOURCE
+type ScriptSink struct {
+	path string
+}
+
+// NewScriptSink creates a sink that runs the script at path
+func NewScriptSink(path string) *ScriptSink {
+	return &ScriptSink{path: path}
+}
+
+func (s *ScriptSink) Send(e Event) error {
+	d, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), sinkTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, s.path)
+	cmd.Stdin = bytes.NewReader(d)
+	cmd.Env = append(os.Environ(),
+		"JUMPPAD_EVENT_TYPE="+e.Type,
+		"JUMPPAD_EVENT_RESOURCE="+e.ResourceID,
+	)
+
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("event script %s failed: %s, output: %s", s.path, err, strings.TrimSpace(string(out)))
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package getter
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+)
+
+// archiveTypes are the archive formats that can be unpacked into a directory
+var archiveTypes = []string{"tar", "tar.bz2", "tar.gz", "tar.xz", "tar.zst", "tbz2", "tgz", "txz", "tzst", "zip"}
+
+// checksumTypes are the supported checksum algorithms
+var checksumTypes = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha512": sha512.New,
+}
+
+// ArchiveType returns the archive format for the given path based on its
+// extension, if the path is not a supported archive an empty string is returned
+func ArchiveType(path string) string {
+	for _, t := range archiveTypes {
+		if strings.HasSuffix(strings.ToLower(path), "."+t) {
+			return t
+		}
+	}
+
+	return ""
+}
+
+// Unpack extracts the archive at src into the directory dst
+func Unpack(src, dst string) error {
+	t := ArchiveType(src)
+	if t == "" {
+		return fmt.Errorf("%s is not a supported archive, supported types are %s", src, strings.Join(archiveTypes, ", "))
+	}
+
+	d := getter.Decompressors[t]
+
+	err := d.Decompress(dst, src, true, 0)
+	if err != nil {
+		return fmt.Errorf("unable to unpack %s: %w", src, err)
+	}
+
+	return nil
+}
+
+// ParseChecksum validates a checksum in the format type:value and returns the
+// type and value, supported types are md5, sha1, sha256, and sha512
+func ParseChecksum(checksum string) (string, string, error) {
+	t, v, ok := strings.Cut(checksum, ":")
+	if !ok || v == "" {
+		return "", "", fmt.Errorf("invalid checksum %s, checksum must be in the format type:value", checksum)
+	}
+
+	h, ok := checksumTypes[t]
+	if !ok {
+		return "", "", fmt.Errorf("invalid checksum type %s, supported types are md5, sha1, sha256, and sha512", t)
+	}
+
+	b, err := hex.DecodeString(v)
+	if err != nil || len(b) != h().Size() {
+		return "", "", fmt.Errorf("invalid checksum value %s for type %s", v, t)
+	}
+
+	return t, strings.ToLower(v), nil
+}
+
+// VerifyChecksum checks that the file at path matches the checksum, the
+// checksum must be in the format type:value
+func VerifyChecksum(path, checksum string) error {
+	t, v, err := ParseChecksum(checksum)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := checksumTypes[t]()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	actual := hex.EncodeToString(h.Sum(nil))
+	if actual != v {
+		return fmt.Errorf("checksum mismatch for %s, expected %s got %s", path, v, actual)
+	}
+
+	return nil
+}
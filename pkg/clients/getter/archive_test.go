@@ -0,0 +1,96 @@
+package getter
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sha256 of the string "hello"
+const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func createZip(t *testing.T) string {
+	p := filepath.Join(t.TempDir(), "files.zip")
+
+	f, err := os.Create(p)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	fw, err := w.Create("sub/file.txt")
+	require.NoError(t, err)
+
+	_, err = fw.Write([]byte("hello"))
+	require.NoError(t, err)
+
+	require.NoError(t, w.Close())
+
+	return p
+}
+
+func TestArchiveTypeReturnsType(t *testing.T) {
+	require.Equal(t, "tar.gz", ArchiveType("/tmp/files.tar.gz"))
+	require.Equal(t, "tgz", ArchiveType("/tmp/files.tgz"))
+	require.Equal(t, "zip", ArchiveType("/tmp/FILES.ZIP"))
+	require.Equal(t, "tar", ArchiveType("/tmp/files.tar"))
+}
+
+func TestArchiveTypeReturnsEmptyWhenNotArchive(t *testing.T) {
+	require.Equal(t, "", ArchiveType("/tmp/files.txt"))
+	require.Equal(t, "", ArchiveType("/tmp/file.gz"))
+}
+
+func TestUnpackExtractsArchive(t *testing.T) {
+	src := createZip(t)
+	dst := filepath.Join(t.TempDir(), "out")
+
+	err := Unpack(src, dst)
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(dst, "sub", "file.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "hello", string(d))
+}
+
+func TestUnpackReturnsErrorWhenNotArchive(t *testing.T) {
+	err := Unpack("/tmp/file.txt", t.TempDir())
+	require.Error(t, err)
+}
+
+func TestParseChecksumReturnsTypeAndValue(t *testing.T) {
+	ct, v, err := ParseChecksum("sha256:" + helloSHA256)
+	require.NoError(t, err)
+
+	require.Equal(t, "sha256", ct)
+	require.Equal(t, helloSHA256, v)
+}
+
+func TestParseChecksumReturnsErrorWhenInvalid(t *testing.T) {
+	_, _, err := ParseChecksum(helloSHA256)
+	require.Error(t, err)
+
+	_, _, err = ParseChecksum("crc32:abcd")
+	require.Error(t, err)
+
+	_, _, err = ParseChecksum("sha256:abcd")
+	require.Error(t, err)
+}
+
+func TestVerifyChecksumValidatesFile(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "file.txt")
+	os.WriteFile(p, []byte("hello"), 0644)
+
+	err := VerifyChecksum(p, "sha256:"+helloSHA256)
+	require.NoError(t, err)
+}
+
+func TestVerifyChecksumReturnsErrorOnMismatch(t *testing.T) {
+	p := filepath.Join(t.TempDir(), "file.txt")
+	os.WriteFile(p, []byte("goodbye"), 0644)
+
+	err := VerifyChecksum(p, "sha256:"+helloSHA256)
+	require.ErrorContains(t, err, "checksum mismatch")
+}
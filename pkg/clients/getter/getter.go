@@ -2,10 +2,18 @@ package getter
 
 import (
 	"context"
+	"crypto"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/go-getter"
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts"
+	"github.com/jumppad-labs/jumppad/pkg/clients/registry"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	cp "github.com/otiai10/copy"
 )
 
 // Getter is an interface which defines interations for
@@ -15,6 +23,7 @@ import (
 type Getter interface {
 	Get(uri, dst string) error
 	SetForce(force bool)
+	SetVerifyKey(key crypto.PublicKey)
 }
 
 // GetterImpl is a concrete implementation of the Getter interface
@@ -22,13 +31,22 @@ type GetterImpl struct {
 	//
 	force bool
 	get   func(uri, dst, pwd string) error
+	// oci fetches blueprints referenced with an oci:// uri
+	oci       registry.Registry
+	verifyKey crypto.PublicKey
+	// store caches remote downloads so that they are shared between
+	// environments, when nil every Get downloads the files
+	store artifacts.Store
 }
 
-// NewGetter creates a new Getter
-func NewGetter(force bool) *GetterImpl {
+// NewGetter creates a new Getter, remote files are cached in the store when
+// it is not nil
+func NewGetter(force bool, store artifacts.Store) *GetterImpl {
 	gi := &GetterImpl{
-		force,
-		func(uri, dst, pwd string) error {
+		force: force,
+		oci:   registry.NewRegistry(),
+		store: store,
+		get: func(uri, dst, pwd string) error {
 			// if the argument is a url fetch it first
 			c := &getter.Client{
 				Ctx:     context.Background(),
@@ -36,6 +54,7 @@ func NewGetter(force bool) *GetterImpl {
 				Dst:     dst,
 				Pwd:     pwd,
 				Mode:    getter.ClientModeAny,
+				Getters: getters(store),
 				Options: []getter.ClientOption{},
 			}
 
@@ -46,11 +65,41 @@ func NewGetter(force bool) *GetterImpl {
 	return gi
 }
 
+// getters returns the default getters with the http getters replaced by
+// getters that use the proxy and CA bundle from the environment, git
+// repositories are cached in the store when it is not nil
+func getters(store artifacts.Store) map[string]getter.Getter {
+	gs := map[string]getter.Getter{}
+	for k, v := range getter.Getters {
+		gs[k] = v
+	}
+
+	hg := &getter.HttpGetter{
+		Netrc:  true,
+		Client: &http.Client{Transport: utils.HTTPTransport()},
+	}
+
+	gs["http"] = hg
+	gs["https"] = hg
+
+	if store != nil {
+		gs["git"] = &GitGetter{store: store}
+	}
+
+	return gs
+}
+
 // SetForce sets the force flag causing all downloads to overwrite the destination
 func (g *GetterImpl) SetForce(force bool) {
 	g.force = force
 }
 
+// SetVerifyKey sets the public key used to verify the signature of
+// blueprints fetched from an OCI registry, when nil signatures are not checked
+func (g *GetterImpl) SetVerifyKey(key crypto.PublicKey) {
+	g.verifyKey = key
+}
+
 // Get attempts to retrieve a folder
 // from a remote location and stores it at the destination.
 //
@@ -74,11 +123,25 @@ func (g *GetterImpl) Get(uri, dst string) error {
 		}
 	}
 
+	// blueprints stored in an OCI registry are not supported by go-getter
+	if registry.IsReference(uri) {
+		err := g.oci.Pull(context.Background(), uri, dst, g.verifyKey)
+		if err != nil {
+			return fmt.Errorf("unable to fetch blueprint from %s: %w", uri, err)
+		}
+
+		return nil
+	}
+
 	pwd, err := os.Getwd()
 	if err != nil {
 		return err
 	}
 
+	if g.store != nil && storeSource(uri, pwd) {
+		return g.getArtifact(uri, dst, pwd)
+	}
+
 	err = g.get(uri, dst, pwd)
 	if err != nil {
 		return fmt.Errorf("unable to fetch files from %s: %w", uri, err)
@@ -86,3 +149,62 @@ func (g *GetterImpl) Get(uri, dst string) error {
 
 	return nil
 }
+
+// getArtifact copies the files for the uri from the store, the files are
+// downloaded and added to the store when they have not been downloaded
+// before or force is set
+func (g *GetterImpl) getArtifact(uri, dst, pwd string) error {
+	if !g.force {
+		if p, ok := g.store.Lookup(uri); ok {
+			return copyArtifact(uri, p, dst)
+		}
+	}
+
+	tmp, err := g.store.TempDir()
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	// go-getter creates the destination folder
+	download := filepath.Join(tmp, "content")
+
+	err = g.get(uri, download, pwd)
+	if err != nil {
+		return fmt.Errorf("unable to fetch files from %s: %w", uri, err)
+	}
+
+	a, err := g.store.Add(uri, download)
+	if err != nil {
+		return err
+	}
+
+	p, ok := g.store.Find(a.Hash)
+	if !ok {
+		return fmt.Errorf("unable to find files for %s in the store", uri)
+	}
+
+	return copyArtifact(uri, p, dst)
+}
+
+func copyArtifact(uri, src, dst string) error {
+	err := cp.Copy(src, dst)
+	if err != nil {
+		return fmt.Errorf("unable to copy %s to %s: %w", uri, dst, err)
+	}
+
+	return nil
+}
+
+// storeSource returns true when the files for the uri should be added to the
+// store. Local files can change at any time so are never stored, git
+// repositories are stored by the git getter using the commit for the ref so
+// that moved branches are fetched again
+func storeSource(uri, pwd string) bool {
+	src, err := getter.Detect(uri, pwd, getter.Detectors)
+	if err != nil {
+		return false
+	}
+
+	return !strings.HasPrefix(src, "file:") && !strings.HasPrefix(src, "git::")
+}
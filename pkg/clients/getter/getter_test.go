@@ -8,7 +8,10 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts"
+	"github.com/jumppad-labs/jumppad/pkg/clients/registry/mocks"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func setupGetter(t *testing.T, force bool, err error) (string, Getter, *string, *string) {
@@ -73,8 +76,89 @@ func TestGetsFolderWhenExistsAndForceTrue(t *testing.T) {
 	assert.Equal(t, outDir, *gd)
 }
 
+func TestGetsBlueprintFromRegistry(t *testing.T) {
+	tmpDir, g, gs, _ := setupGetter(t, false, nil)
+	outDir := filepath.Join(tmpDir, "blueprint")
+	url := "oci://ghcr.io/jumppad-labs/blueprint:v1.2.0"
+
+	r := mocks.NewRegistry(t)
+	r.On("Pull", mock.Anything, url, outDir, "key").Return(nil)
+
+	gi := g.(*GetterImpl)
+	gi.oci = r
+	gi.SetVerifyKey("key")
+
+	err := g.Get(url, outDir)
+	assert.NoError(t, err)
+
+	// go-getter is not used for registry blueprints
+	assert.Equal(t, "", *gs)
+}
+
+func setupStoreGetter(t *testing.T, force bool) (*GetterImpl, *int) {
+	calls := 0
+
+	g := &GetterImpl{
+		force: force,
+		store: artifacts.NewStore(t.TempDir()),
+		get: func(uri, dst, pwd string) error {
+			calls++
+
+			os.MkdirAll(dst, os.ModePerm)
+			return os.WriteFile(filepath.Join(dst, "Chart.yaml"), []byte(`name: consul`), 0644)
+		},
+	}
+
+	return g, &calls
+}
+
+func TestGetCopiesRemoteFilesFromStore(t *testing.T) {
+	g, calls := setupStoreGetter(t, false)
+	url := "https://github.com/hashicorp/consul-k8s/archive/refs/tags/v1.2.0.zip"
+
+	err := g.Get(url, filepath.Join(t.TempDir(), "one"))
+	assert.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "two")
+	err = g.Get(url, dst)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, *calls)
+	assert.FileExists(t, filepath.Join(dst, "Chart.yaml"))
+}
+
+func TestGetWithForceDownloadsRemoteFilesAgain(t *testing.T) {
+	g, calls := setupStoreGetter(t, true)
+	url := "https://github.com/hashicorp/consul-k8s/archive/refs/tags/v1.2.0.zip"
+
+	err := g.Get(url, filepath.Join(t.TempDir(), "one"))
+	assert.NoError(t, err)
+
+	err = g.Get(url, filepath.Join(t.TempDir(), "two"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, *calls)
+}
+
+func TestGetDoesNotStoreLocalFiles(t *testing.T) {
+	g, calls := setupStoreGetter(t, false)
+	src := t.TempDir()
+
+	err := g.Get(src, filepath.Join(t.TempDir(), "one"))
+	assert.NoError(t, err)
+
+	err = g.Get(src, filepath.Join(t.TempDir(), "two"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 2, *calls)
+
+	l, err := g.store.List()
+	assert.NoError(t, err)
+	assert.Empty(t, l)
+}
+
 func TestGetFunctional(t *testing.T) {
-	g := NewGetter(true)
+	g := NewGetter(true, nil)
 	url := "github.com/shipyard-run/blueprints//consul-nomad?ref=v0.0.1"
 	dir := t.TempDir()
 
@@ -0,0 +1,449 @@
+package getter
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	cp "github.com/otiai10/copy"
+)
+
+// GitTokenEnvVar is the environment variable containing a token used to
+// authenticate when fetching git repositories over https, e.g. a GitHub
+// personal access token. Repositories fetched over ssh use the keys in the
+// ssh agent
+const GitTokenEnvVar = "JUMPPAD_GIT_TOKEN"
+
+// GitTokenHostsEnvVar is the environment variable containing a comma
+// separated list of the hosts the git token is sent to, defaults to
+// github.com
+const GitTokenHostsEnvVar = "JUMPPAD_GIT_TOKEN_HOSTS"
+
+// defaultGitDepth is the depth of the clone when the source does not set the
+// depth parameter, only the files for the ref are needed so a shallow clone
+// avoids downloading the history of large repositories
+const defaultGitDepth = 1
+
+var (
+	commitRegex      = regexp.MustCompile("^[0-9a-fA-F]{40}$")
+	shortCommitRegex = regexp.MustCompile("^[0-9a-fA-F]{7,39}$")
+)
+
+func init() {
+	// replace the go-getter git getter so that modules are also fetched
+	// using shallow clones and the artifact store
+	getter.Getters["git"] = &GitGetter{}
+}
+
+// GitGetter is a go-getter Getter that fetches git repositories, e.g.
+// git::https://github.com/org/repo//blueprints/consul?ref=v1.2.3
+//
+// The ref is resolved to a commit before fetching and the files for the
+// commit are cached in the artifact store, sources that use different sub
+// directories of the same repository and ref only fetch the repository once.
+// The ref can be a branch, tag, or commit, when a branch is moved the new
+// commit is fetched. Only the commit is fetched unless the depth parameter
+// is set, depth=0 fetches the full history.
+type GitGetter struct {
+	client *getter.Client
+	// store is where the files are cached, defaults to the store in
+	// $HOME/.jumppad/artifacts
+	store artifacts.Store
+	// git runs a git command and returns the output
+	git func(ctx context.Context, dir string, env []string, args ...string) (string, error)
+}
+
+// ClientMode returns the mode for the url, git sources are always directories
+func (g *GitGetter) ClientMode(u *url.URL) (getter.ClientMode, error) {
+	return getter.ClientModeDir, nil
+}
+
+// SetClient sets the go-getter client
+func (g *GitGetter) SetClient(c *getter.Client) {
+	g.client = c
+}
+
+// GetFile fetches the repository and copies the file at the path in the url
+func (g *GitGetter) GetFile(dst string, u *url.URL) error {
+	file := filepath.Base(u.Path)
+
+	repo := *u
+	repo.Path = filepath.Dir(u.Path)
+
+	tmp, err := os.MkdirTemp("", "git-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	err = g.Get(filepath.Join(tmp, "repo"), &repo)
+	if err != nil {
+		return err
+	}
+
+	return cp.Copy(filepath.Join(tmp, "repo", file), dst)
+}
+
+// Get fetches the files for the ref in the url and copies them to dst
+func (g *GitGetter) Get(dst string, u *url.URL) error {
+	ctx := context.Background()
+	if g.client != nil && g.client.Ctx != nil {
+		ctx = g.client.Ctx
+	}
+
+	if g.git == nil {
+		if _, err := exec.LookPath("git"); err != nil {
+			return fmt.Errorf("git must be installed to fetch %s", u.Redacted())
+		}
+	}
+
+	repo := *u
+	q := repo.Query()
+
+	ref := q.Get("ref")
+	depth := defaultGitDepth
+	if d := q.Get("depth"); d != "" {
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid depth %s for %s, depth must be a positive number", d, u.Redacted())
+		}
+
+		depth = n
+	}
+
+	sshKey := q.Get("sshkey")
+
+	q.Del("ref")
+	q.Del("depth")
+	q.Del("sshkey")
+	repo.RawQuery = q.Encode()
+
+	env, cleanup, err := gitEnv(&repo, sshKey)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	commit, err := g.resolve(ctx, env, repo.String(), ref)
+	if err != nil {
+		return err
+	}
+
+	st := g.artifacts()
+	source := fmt.Sprintf("git::%s?ref=%s", repo.Redacted(), commit)
+
+	dir, ok := st.Lookup(source)
+	if !ok {
+		dir, err = g.fetch(ctx, st, env, source, repo.String(), commit, depth)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cp.Copy(dir, dst)
+	if err != nil {
+		return fmt.Errorf("unable to copy %s to %s: %w", u.Redacted(), dst, err)
+	}
+
+	return nil
+}
+
+// resolve returns the commit for the ref, when the ref is empty the commit
+// for the default branch is returned
+func (g *GitGetter) resolve(ctx context.Context, env []string, repo, ref string) (string, error) {
+	if commitRegex.MatchString(ref) {
+		return strings.ToLower(ref), nil
+	}
+
+	// refs are matched by their full name so that a ref such as main does
+	// not match a branch ending with the same name, e.g. feature/main. Tags
+	// take precedence over branches with the same name like git rev-parse,
+	// the peeled tag is the commit for annotated tags
+	names := []string{"HEAD"}
+	switch {
+	case strings.HasPrefix(ref, "refs/"):
+		names = []string{ref + "^{}", ref}
+	case ref != "":
+		names = []string{"refs/tags/" + ref + "^{}", "refs/tags/" + ref, "refs/heads/" + ref}
+	}
+
+	out, err := g.run(ctx, "", env, append([]string{"ls-remote", "--", repo}, names...)...)
+	if err != nil {
+		return "", fmt.Errorf("unable to read refs from %s: %w", redact(repo), err)
+	}
+
+	refs := map[string]string{}
+	for _, l := range strings.Split(strings.TrimSpace(out), "\n") {
+		f := strings.Fields(l)
+		if len(f) != 2 {
+			continue
+		}
+
+		refs[f[1]] = f[0]
+	}
+
+	commit := ""
+	for _, n := range names {
+		if c, ok := refs[n]; ok {
+			commit = c
+			break
+		}
+	}
+
+	if commit != "" {
+		return commit, nil
+	}
+
+	// abbreviated commits can not be resolved on the remote
+	if shortCommitRegex.MatchString(ref) {
+		return strings.ToLower(ref), nil
+	}
+
+	return "", fmt.Errorf("unable to find ref %s in %s", ref, redact(repo))
+}
+
+// fetch downloads the files for the commit and adds them to the store
+func (g *GitGetter) fetch(ctx context.Context, st artifacts.Store, env []string, source, repo, commit string, depth int) (string, error) {
+	tmp, err := st.TempDir()
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	dir := filepath.Join(tmp, "content")
+
+	err = os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return "", err
+	}
+
+	// submodules with relative urls are resolved using the origin remote
+	cmds := [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", "--", repo},
+	}
+
+	if commitRegex.MatchString(commit) {
+		fetch := []string{"fetch", "--quiet"}
+		if depth > 0 {
+			fetch = append(fetch, "--depth", strconv.Itoa(depth))
+		}
+
+		cmds = append(cmds,
+			append(fetch, "origin", commit),
+			[]string{"checkout", "--quiet", "FETCH_HEAD"},
+		)
+	} else {
+		// an abbreviated commit can only be checked out from the full
+		// history
+		cmds = append(cmds,
+			[]string{"fetch", "--quiet", "--tags", "origin", "+refs/heads/*:refs/remotes/origin/*"},
+			[]string{"checkout", "--quiet", commit},
+		)
+	}
+
+	submodules := []string{"submodule", "update", "--init", "--recursive"}
+	if depth > 0 {
+		submodules = append(submodules, "--depth", strconv.Itoa(depth))
+	}
+
+	cmds = append(cmds, submodules)
+
+	for _, c := range cmds {
+		_, err := g.run(ctx, dir, env, c...)
+		if err != nil {
+			return "", fmt.Errorf("unable to fetch %s from %s: %w", commit, redact(repo), err)
+		}
+	}
+
+	// the git metadata is not needed and would change the hash of the files
+	err = removeGitDirs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	a, err := st.Add(source, dir)
+	if err != nil {
+		return "", err
+	}
+
+	p, ok := st.Find(a.Hash)
+	if !ok {
+		return "", fmt.Errorf("unable to find %s in the store", source)
+	}
+
+	return p, nil
+}
+
+func (g *GitGetter) run(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	if g.git != nil {
+		return g.git(ctx, dir, env, args...)
+	}
+
+	return runGit(ctx, dir, env, args...)
+}
+
+func (g *GitGetter) artifacts() artifacts.Store {
+	if g.store != nil {
+		return g.store
+	}
+
+	return artifacts.NewStore(utils.ArtifactsDir())
+}
+
+func runGit(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// gitEnv returns the environment for git commands, the git token is added
+// as an authorization header for https repositories hosted on the token
+// hosts, only requests to the host of the repository use the header. The header is passed using the environment so that the token is not
+// written to the git config or shown in the process list. When sshKey is set
+// it is decoded and used as the ssh identity, otherwise ssh uses the agent
+func gitEnv(u *url.URL, sshKey string) ([]string, func(), error) {
+	// never prompt for credentials, jumppad is often run non interactively
+	env := append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	cleanup := func() {}
+
+	token := os.Getenv(GitTokenEnvVar)
+	if token != "" && u.Scheme == "https" && u.User == nil && tokenHost(u.Hostname()) {
+		auth := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + token))
+
+		// config set by the user in the environment is kept, the header is
+		// added after it
+		n, _ := strconv.Atoi(os.Getenv("GIT_CONFIG_COUNT"))
+		if n < 0 {
+			n = 0
+		}
+
+		filtered := []string{}
+		for _, e := range env {
+			if !strings.HasPrefix(e, "GIT_CONFIG_COUNT=") {
+				filtered = append(filtered, e)
+			}
+		}
+
+		// the header is scoped to the host so that it is not sent to
+		// submodules hosted elsewhere
+		env = append(filtered,
+			fmt.Sprintf("GIT_CONFIG_COUNT=%d", n+1),
+			fmt.Sprintf("GIT_CONFIG_KEY_%d=http.https://%s/.extraHeader", n, u.Host),
+			fmt.Sprintf("GIT_CONFIG_VALUE_%d=Authorization: Basic %s", n, auth),
+		)
+	}
+
+	if sshKey == "" {
+		return env, cleanup, nil
+	}
+
+	key, err := base64.StdEncoding.DecodeString(sshKey)
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("unable to decode sshkey, the key must be base64 encoded: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "jumppad-ssh-key-*")
+	if err != nil {
+		return nil, cleanup, err
+	}
+
+	cleanup = func() { os.Remove(f.Name()) }
+
+	// the key must only be readable by the user or ssh refuses to use it
+	f.Chmod(0600)
+	_, err = f.Write(key)
+	f.Close()
+	if err != nil {
+		cleanup()
+		return nil, func() {}, fmt.Errorf("unable to write sshkey: %w", err)
+	}
+
+	env = append(env, fmt.Sprintf("GIT_SSH_COMMAND=ssh -i %s -o IdentitiesOnly=yes", filepath.ToSlash(f.Name())))
+
+	return env, cleanup, nil
+}
+
+// tokenHost returns true when the git token should be sent to the host
+func tokenHost(host string) bool {
+	hosts := os.Getenv(GitTokenHostsEnvVar)
+	if hosts == "" {
+		hosts = "github.com"
+	}
+
+	for _, h := range strings.Split(hosts, ",") {
+		if strings.EqualFold(strings.TrimSpace(h), host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// removeGitDirs removes the .git directories and files from the repository
+// and its submodules
+func removeGitDirs(dir string) error {
+	gitDirs := []string{}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.Name() == ".git" {
+			gitDirs = append(gitDirs, path)
+
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, d := range gitDirs {
+		err := os.RemoveAll(d)
+		if err != nil {
+			return fmt.Errorf("unable to remove %s: %w", d, err)
+		}
+	}
+
+	return nil
+}
+
+// redact removes the password from the repository url
+func redact(repo string) string {
+	u, err := url.Parse(repo)
+	if err != nil {
+		return repo
+	}
+
+	return u.Redacted()
+}
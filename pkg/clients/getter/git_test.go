@@ -0,0 +1,333 @@
+package getter
+
+import (
+	"context"
+	"encoding/base64"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts"
+	"github.com/stretchr/testify/require"
+)
+
+func runTestGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@jumppad.dev", "-c", "commit.gpgsign=false"}, args...)...)
+	cmd.Dir = dir
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return strings.TrimSpace(string(out))
+}
+
+// setupGitRepo creates a repository with a blueprint in a sub directory and
+// a tag v1.0.0
+func setupGitRepo(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	runTestGit(t, repo, "init", "--quiet", "--initial-branch", "main")
+
+	os.MkdirAll(filepath.Join(repo, "blueprints", "consul"), os.ModePerm)
+	os.WriteFile(filepath.Join(repo, "blueprints", "consul", "main.hcl"), []byte(`resource "network" "main" {}`), 0644)
+
+	runTestGit(t, repo, "add", "-A")
+	runTestGit(t, repo, "commit", "--quiet", "-m", "initial")
+	runTestGit(t, repo, "tag", "-a", "v1.0.0", "-m", "v1.0.0")
+
+	return repo
+}
+
+func commitTestFile(t *testing.T, repo, content string) string {
+	os.WriteFile(filepath.Join(repo, "blueprints", "consul", "main.hcl"), []byte(content), 0644)
+
+	runTestGit(t, repo, "commit", "--quiet", "-am", "update")
+
+	return runTestGit(t, repo, "rev-parse", "HEAD")
+}
+
+func setupGitGetter(t *testing.T) (*GitGetter, *[][]string) {
+	calls := [][]string{}
+
+	g := &GitGetter{
+		store: artifacts.NewStore(t.TempDir()),
+		git: func(ctx context.Context, dir string, env []string, args ...string) (string, error) {
+			calls = append(calls, args)
+			return runGit(ctx, dir, env, args...)
+		},
+	}
+
+	return g, &calls
+}
+
+func gitURL(t *testing.T, repo, query string) *url.URL {
+	u, err := url.Parse("file://" + filepath.ToSlash(repo) + query)
+	require.NoError(t, err)
+
+	return u
+}
+
+func fetchCount(calls [][]string) int {
+	n := 0
+	for _, c := range calls {
+		if c[0] == "fetch" {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestGitGetterFetchesTag(t *testing.T) {
+	repo := setupGitRepo(t)
+	commitTestFile(t, repo, `updated`)
+
+	g, _ := setupGitGetter(t)
+	dst := filepath.Join(t.TempDir(), "repo")
+
+	err := g.Get(dst, gitURL(t, repo, "?ref=v1.0.0"))
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(dst, "blueprints", "consul", "main.hcl"))
+	require.NoError(t, err)
+	require.Equal(t, `resource "network" "main" {}`, string(d))
+	require.NoDirExists(t, filepath.Join(dst, ".git"))
+}
+
+func TestGitGetterFetchesCommit(t *testing.T) {
+	repo := setupGitRepo(t)
+	commit := commitTestFile(t, repo, `pinned`)
+	commitTestFile(t, repo, `updated`)
+
+	g, _ := setupGitGetter(t)
+	dst := filepath.Join(t.TempDir(), "repo")
+
+	err := g.Get(dst, gitURL(t, repo, "?ref="+commit))
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(dst, "blueprints", "consul", "main.hcl"))
+	require.NoError(t, err)
+	require.Equal(t, `pinned`, string(d))
+}
+
+func TestGitGetterFetchesDefaultBranch(t *testing.T) {
+	repo := setupGitRepo(t)
+	commitTestFile(t, repo, `updated`)
+
+	g, _ := setupGitGetter(t)
+	dst := filepath.Join(t.TempDir(), "repo")
+
+	err := g.Get(dst, gitURL(t, repo, ""))
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(dst, "blueprints", "consul", "main.hcl"))
+	require.NoError(t, err)
+	require.Equal(t, `updated`, string(d))
+}
+
+func TestGitGetterUsesShallowFetch(t *testing.T) {
+	repo := setupGitRepo(t)
+
+	g, calls := setupGitGetter(t)
+
+	err := g.Get(filepath.Join(t.TempDir(), "repo"), gitURL(t, repo, "?ref=v1.0.0"))
+	require.NoError(t, err)
+
+	for _, c := range *calls {
+		if c[0] == "fetch" {
+			require.Contains(t, c, "--depth")
+		}
+	}
+}
+
+func TestGitGetterUsesStoreForSameCommit(t *testing.T) {
+	repo := setupGitRepo(t)
+
+	g, calls := setupGitGetter(t)
+
+	err := g.Get(filepath.Join(t.TempDir(), "one"), gitURL(t, repo, "?ref=v1.0.0"))
+	require.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "two")
+	err = g.Get(dst, gitURL(t, repo, "?ref=main"))
+	require.NoError(t, err)
+
+	require.Equal(t, 1, fetchCount(*calls))
+	require.FileExists(t, filepath.Join(dst, "blueprints", "consul", "main.hcl"))
+}
+
+func TestGitGetterFetchesMovedBranch(t *testing.T) {
+	repo := setupGitRepo(t)
+
+	g, calls := setupGitGetter(t)
+
+	err := g.Get(filepath.Join(t.TempDir(), "one"), gitURL(t, repo, "?ref=main"))
+	require.NoError(t, err)
+
+	commitTestFile(t, repo, `updated`)
+
+	dst := filepath.Join(t.TempDir(), "two")
+	err = g.Get(dst, gitURL(t, repo, "?ref=main"))
+	require.NoError(t, err)
+
+	require.Equal(t, 2, fetchCount(*calls))
+
+	d, err := os.ReadFile(filepath.Join(dst, "blueprints", "consul", "main.hcl"))
+	require.NoError(t, err)
+	require.Equal(t, `updated`, string(d))
+}
+
+func TestGitGetterDoesNotMatchBranchWithSameSuffix(t *testing.T) {
+	repo := setupGitRepo(t)
+	runTestGit(t, repo, "checkout", "--quiet", "-b", "feature/main")
+	commitTestFile(t, repo, `feature`)
+	runTestGit(t, repo, "checkout", "--quiet", "main")
+
+	g, _ := setupGitGetter(t)
+	dst := filepath.Join(t.TempDir(), "repo")
+
+	err := g.Get(dst, gitURL(t, repo, "?ref=main"))
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(dst, "blueprints", "consul", "main.hcl"))
+	require.NoError(t, err)
+	require.Equal(t, `resource "network" "main" {}`, string(d))
+}
+
+func TestGitGetterWithUnknownRefReturnsError(t *testing.T) {
+	repo := setupGitRepo(t)
+
+	g, _ := setupGitGetter(t)
+
+	err := g.Get(filepath.Join(t.TempDir(), "repo"), gitURL(t, repo, "?ref=v9.9.9"))
+	require.ErrorContains(t, err, "unable to find ref v9.9.9")
+}
+
+func TestGitGetterWithInvalidDepthReturnsError(t *testing.T) {
+	g, _ := setupGitGetter(t)
+
+	err := g.Get(filepath.Join(t.TempDir(), "repo"), gitURL(t, t.TempDir(), "?depth=abc"))
+	require.ErrorContains(t, err, "invalid depth abc")
+}
+
+func TestGetWithGitSubdirCopiesSubdir(t *testing.T) {
+	repo := setupGitRepo(t)
+
+	g := NewGetter(false, artifacts.NewStore(t.TempDir()))
+	dst := filepath.Join(t.TempDir(), "consul")
+
+	err := g.Get("git::file://"+filepath.ToSlash(repo)+"//blueprints/consul?ref=v1.0.0", dst)
+	require.NoError(t, err)
+
+	require.FileExists(t, filepath.Join(dst, "main.hcl"))
+}
+
+func TestGitEnvAddsTokenForGitHub(t *testing.T) {
+	t.Setenv(GitTokenEnvVar, "abc123")
+	t.Setenv(GitTokenHostsEnvVar, "")
+
+	u, _ := url.Parse("https://github.com/jumppad-labs/blueprints.git")
+
+	env, cleanup, err := gitEnv(u, "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	auth := base64.StdEncoding.EncodeToString([]byte("x-access-token:abc123"))
+	require.Contains(t, env, "GIT_CONFIG_COUNT=1")
+	require.Contains(t, env, "GIT_CONFIG_KEY_0=http.https://github.com/.extraHeader")
+	require.Contains(t, env, "GIT_CONFIG_VALUE_0=Authorization: Basic "+auth)
+}
+
+func TestGitEnvAddsTokenAfterExistingConfig(t *testing.T) {
+	t.Setenv(GitTokenEnvVar, "abc123")
+	t.Setenv(GitTokenHostsEnvVar, "")
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "core.autocrlf")
+	t.Setenv("GIT_CONFIG_VALUE_0", "false")
+
+	u, _ := url.Parse("https://github.com/jumppad-labs/blueprints.git")
+
+	env, cleanup, err := gitEnv(u, "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.Contains(t, env, "GIT_CONFIG_KEY_0=core.autocrlf")
+	require.Contains(t, env, "GIT_CONFIG_COUNT=2")
+	require.NotContains(t, env, "GIT_CONFIG_COUNT=1")
+	require.Contains(t, env, "GIT_CONFIG_KEY_1=http.https://github.com/.extraHeader")
+}
+
+func TestGitEnvAddsTokenForConfiguredHosts(t *testing.T) {
+	t.Setenv(GitTokenEnvVar, "abc123")
+	t.Setenv(GitTokenHostsEnvVar, "github.com, gitlab.corp.com")
+
+	u, _ := url.Parse("https://gitlab.corp.com/platform/blueprints.git")
+
+	env, cleanup, err := gitEnv(u, "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.Contains(t, env, "GIT_CONFIG_KEY_0=http.https://gitlab.corp.com/.extraHeader")
+}
+
+func TestGitEnvDoesNotAddTokenForOtherHosts(t *testing.T) {
+	t.Setenv(GitTokenEnvVar, "abc123")
+	t.Setenv(GitTokenHostsEnvVar, "")
+
+	u, _ := url.Parse("https://example.com/blueprints.git")
+
+	env, cleanup, err := gitEnv(u, "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.NotContains(t, env, "GIT_CONFIG_COUNT=1")
+}
+
+func TestGitEnvDoesNotAddTokenForSSH(t *testing.T) {
+	t.Setenv(GitTokenEnvVar, "abc123")
+	t.Setenv(GitTokenHostsEnvVar, "")
+
+	u, _ := url.Parse("ssh://git@github.com/jumppad-labs/blueprints.git")
+
+	env, cleanup, err := gitEnv(u, "")
+	require.NoError(t, err)
+	defer cleanup()
+
+	require.NotContains(t, env, "GIT_CONFIG_COUNT=1")
+}
+
+func TestGitEnvWritesSSHKey(t *testing.T) {
+	u, _ := url.Parse("ssh://git@github.com/jumppad-labs/blueprints.git")
+
+	env, cleanup, err := gitEnv(u, base64.StdEncoding.EncodeToString([]byte("private key")))
+	require.NoError(t, err)
+
+	cmd := ""
+	for _, e := range env {
+		if strings.HasPrefix(e, "GIT_SSH_COMMAND=") {
+			cmd = e
+		}
+	}
+
+	key := strings.Fields(cmd)[2]
+
+	d, err := os.ReadFile(key)
+	require.NoError(t, err)
+	require.Equal(t, "private key", string(d))
+
+	cleanup()
+	require.NoFileExists(t, key)
+}
+
+func TestGitEnvWithInvalidSSHKeyReturnsError(t *testing.T) {
+	u, _ := url.Parse("ssh://git@github.com/jumppad-labs/blueprints.git")
+
+	_, _, err := gitEnv(u, "not base64!")
+	require.ErrorContains(t, err, "unable to decode sshkey")
+}
@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	crypto "crypto"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Getter is an autogenerated mock type for the Getter type
 type Getter struct {
@@ -32,6 +36,11 @@ func (_m *Getter) SetForce(force bool) {
 	_m.Called(force)
 }
 
+// SetVerifyKey provides a mock function with given fields: key
+func (_m *Getter) SetVerifyKey(key crypto.PublicKey) {
+	_m.Called(key)
+}
+
 // NewGetter creates a new instance of Getter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewGetter(t interface {
@@ -0,0 +1,136 @@
+package getter
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts"
+	"github.com/jumppad-labs/jumppad/pkg/clients/registry"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	cp "github.com/otiai10/copy"
+)
+
+func init() {
+	// register the OCI getter with go-getter so that modules can be sourced
+	// from an OCI registry, e.g. oci://ghcr.io/org/modules:v1.0.0//consul
+	og := &OCIGetter{registry: registry.NewRegistry()}
+
+	for _, s := range registry.Schemes {
+		getter.Getters[strings.TrimSuffix(s, "://")] = og
+	}
+}
+
+// OCIGetter is a go-getter Getter that fetches blueprints and modules from an
+// OCI registry. Files are cached in the artifact store keyed by their dirhash
+// so that the same content is only downloaded once regardless of the tag.
+type OCIGetter struct {
+	registry registry.Registry
+	client   *getter.Client
+	// store is where the content is cached, defaults to the store in
+	// $HOME/.jumppad/artifacts
+	store artifacts.Store
+}
+
+// ClientMode returns the mode for the url, OCI sources are always directories
+func (g *OCIGetter) ClientMode(u *url.URL) (getter.ClientMode, error) {
+	return getter.ClientModeDir, nil
+}
+
+// SetClient sets the go-getter client
+func (g *OCIGetter) SetClient(c *getter.Client) {
+	g.client = c
+}
+
+// GetFile is not supported for OCI sources
+func (g *OCIGetter) GetFile(dst string, u *url.URL) error {
+	return fmt.Errorf("unable to fetch %s, OCI sources can only be fetched as a directory", u)
+}
+
+// Get fetches the blueprint at the url and copies it to dst
+func (g *OCIGetter) Get(dst string, u *url.URL) error {
+	ctx := context.Background()
+	if g.client != nil && g.client.Ctx != nil {
+		ctx = g.client.Ctx
+	}
+
+	uri := fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, u.Path)
+
+	ref, err := registry.ParseReference(uri)
+	if err != nil {
+		return err
+	}
+
+	d, h, err := g.registry.Resolve(ctx, uri)
+	if err != nil {
+		return err
+	}
+
+	if h == "" {
+		return fmt.Errorf("blueprint %s does not contain a hash", uri)
+	}
+
+	st := g.artifacts()
+
+	// only pull the content when the store does not contain it or it has
+	// been modified
+	dir, ok := st.Find(h)
+	if !ok {
+		dir, err = g.pull(ctx, st, uri, ref, d)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = cp.Copy(dir, dst)
+	if err != nil {
+		return fmt.Errorf("unable to copy %s to %s: %w", uri, dst, err)
+	}
+
+	return nil
+}
+
+// pull fetches the content by digest and adds it to the store, pulling by
+// digest ensures the content matches the resolved hash even when the tag is
+// moved
+func (g *OCIGetter) pull(ctx context.Context, st artifacts.Store, uri string, ref *registry.Reference, digest string) (string, error) {
+	tmp, err := st.TempDir()
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmp)
+
+	ref.Tag = ""
+	ref.Digest = digest
+
+	dir := filepath.Join(tmp, "content")
+
+	err = g.registry.Pull(ctx, ref.String(), dir, nil)
+	if err != nil {
+		return "", err
+	}
+
+	a, err := st.Add(uri, dir)
+	if err != nil {
+		return "", err
+	}
+
+	p, ok := st.Find(a.Hash)
+	if !ok {
+		return "", fmt.Errorf("unable to find %s in the store", uri)
+	}
+
+	return p, nil
+}
+
+func (g *OCIGetter) artifacts() artifacts.Store {
+	if g.store != nil {
+		return g.store
+	}
+
+	return artifacts.NewStore(utils.ArtifactsDir())
+}
@@ -0,0 +1,77 @@
+package getter
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/artifacts"
+	"github.com/jumppad-labs/jumppad/pkg/clients/registry/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	cp "github.com/otiai10/copy"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testDigest = "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+
+func setupOCIGetter(t *testing.T) (*OCIGetter, *mocks.Registry, string) {
+	src := t.TempDir()
+	os.WriteFile(filepath.Join(src, "main.hcl"), []byte(`resource "network" "main" {}`), 0644)
+
+	h, err := utils.HashDir(src)
+	require.NoError(t, err)
+
+	r := mocks.NewRegistry(t)
+	r.On("Resolve", mock.Anything, "oci://ghcr.io/org/modules:v1.0.0").Return(testDigest, h, nil)
+	r.On("Pull", mock.Anything, "oci://ghcr.io/org/modules@"+testDigest, mock.Anything, nil).
+		Run(func(args mock.Arguments) {
+			cp.Copy(src, args.String(2))
+		}).
+		Return(nil)
+
+	return &OCIGetter{registry: r, store: artifacts.NewStore(t.TempDir())}, r, h
+}
+
+func TestOCIGetterPullsByDigestAndCopies(t *testing.T) {
+	g, _, h := setupOCIGetter(t)
+	u, _ := url.Parse("oci://ghcr.io/org/modules:v1.0.0")
+	dst := filepath.Join(t.TempDir(), "module")
+
+	err := g.Get(dst, u)
+	require.NoError(t, err)
+
+	require.FileExists(t, filepath.Join(dst, "main.hcl"))
+	_, ok := g.store.Find(h)
+	require.True(t, ok)
+}
+
+func TestOCIGetterUsesCache(t *testing.T) {
+	g, r, _ := setupOCIGetter(t)
+	u, _ := url.Parse("oci://ghcr.io/org/modules:v1.0.0")
+
+	err := g.Get(filepath.Join(t.TempDir(), "one"), u)
+	require.NoError(t, err)
+
+	err = g.Get(filepath.Join(t.TempDir(), "two"), u)
+	require.NoError(t, err)
+
+	r.AssertNumberOfCalls(t, "Pull", 1)
+}
+
+func TestOCIGetterRefreshesModifiedCache(t *testing.T) {
+	g, r, h := setupOCIGetter(t)
+	u, _ := url.Parse("oci://ghcr.io/org/modules:v1.0.0")
+
+	err := g.Get(filepath.Join(t.TempDir(), "one"), u)
+	require.NoError(t, err)
+
+	dir, _ := g.store.Find(h)
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(`modified`), 0644)
+
+	err = g.Get(filepath.Join(t.TempDir(), "two"), u)
+	require.NoError(t, err)
+
+	r.AssertNumberOfCalls(t, "Pull", 2)
+}
@@ -1,13 +1,16 @@
 package helm
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path"
 	"sync"
 
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"gopkg.in/yaml.v3"
 	"helm.sh/helm/v3/pkg/action"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
@@ -28,9 +31,12 @@ func init() {
 }
 
 // Helm defines an interface for a client which can manage Helm charts
+//
+//go:generate mockery --name Helm --filename helm.go
 type Helm interface {
-	// CreateFromRepository creates a Helm install from a repository
-	Create(kubeConfig, name, namespace string, createNamespace bool, skipCRDs bool, chart, version, valuesPath string, valuesString map[string]string) error
+	// CreateFromRepository creates a Helm install from a repository, the
+	// labels are added to the release and each object created by the chart
+	Create(kubeConfig, name, namespace string, createNamespace bool, skipCRDs bool, chart, version, valuesPath string, valuesString map[string]string, labels map[string]string) error
 
 	// Destroy the given chart
 	Destroy(kubeConfig, name, namespace string) error
@@ -75,7 +81,7 @@ func NewHelm(l logger.Logger) Helm {
 	return &HelmImpl{l, helmRepoConfig, helmCachePath, helmDataPath, helmConfigPath}
 }
 
-func (h *HelmImpl) Create(kubeConfig, name, namespace string, createNamespace bool, skipCRDs bool, chart, version, valuesPath string, valuesString map[string]string) error {
+func (h *HelmImpl) Create(kubeConfig, name, namespace string, createNamespace bool, skipCRDs bool, chart, version, valuesPath string, valuesString map[string]string, labels map[string]string) error {
 	// set the kube client for Helm
 	s := kube.GetConfig(kubeConfig, "default", namespace)
 	cfg := &action.Configuration{}
@@ -93,6 +99,11 @@ func (h *HelmImpl) Create(kubeConfig, name, namespace string, createNamespace bo
 	client.CreateNamespace = createNamespace
 	client.SkipCRDs = skipCRDs
 
+	if len(labels) > 0 {
+		client.Labels = labels
+		client.PostRenderer = &labelRenderer{labels}
+	}
+
 	settings := h.getSettings()
 	settings.Debug = true
 
@@ -100,6 +111,10 @@ func (h *HelmImpl) Create(kubeConfig, name, namespace string, createNamespace bo
 	cpa := client.ChartPathOptions
 	cpa.Version = version
 
+	// Helm trusts only the certificates in the CA file when it is set, the
+	// bundle must contain the CA for the proxy or the chart repository
+	cpa.CaFile = utils.CABundle()
+
 	cp, err := cpa.LocateChart(chart, &settings)
 	if err != nil {
 		return fmt.Errorf("error locating chart: %w", err)
@@ -179,6 +194,66 @@ func (h *HelmImpl) Create(kubeConfig, name, namespace string, createNamespace bo
 	return nil
 }
 
+// labelRenderer is a Helm post renderer that adds labels to each of the
+// objects rendered by a chart
+type labelRenderer struct {
+	labels map[string]string
+}
+
+// Run adds the labels to the metadata of each object in the manifests
+func (l *labelRenderer) Run(manifests *bytes.Buffer) (*bytes.Buffer, error) {
+	out := bytes.NewBuffer(nil)
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2)
+
+	dec := yaml.NewDecoder(manifests)
+	for {
+		obj := map[string]any{}
+		err := dec.Decode(&obj)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode manifest: %w", err)
+		}
+
+		// empty documents are removed
+		if len(obj) == 0 {
+			continue
+		}
+
+		md, _ := obj["metadata"].(map[string]any)
+		if md == nil {
+			md = map[string]any{}
+		}
+
+		ls, _ := md["labels"].(map[string]any)
+		if ls == nil {
+			ls = map[string]any{}
+		}
+
+		for k, v := range l.labels {
+			ls[k] = v
+		}
+
+		md["labels"] = ls
+		obj["metadata"] = md
+
+		err = enc.Encode(obj)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode manifest: %w", err)
+		}
+	}
+
+	err := enc.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func checkIfInstallable(ch *chart.Chart) error {
 	switch ch.Metadata.Type {
 	case "", "application":
@@ -216,6 +291,7 @@ func (h *HelmImpl) UpsertChartRepository(name, url string) error {
 		Name:                  name,
 		URL:                   url,
 		InsecureSkipTLSverify: true,
+		CAFile:                utils.CABundle(),
 	}
 
 	// ensure only a single client can operate at one time
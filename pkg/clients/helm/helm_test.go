@@ -1,11 +1,13 @@
 package helm
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 func TestUpsertChartRepository(t *testing.T) {
@@ -20,3 +22,50 @@ func TestUpsertChartRepository(t *testing.T) {
 	err := hc.UpsertChartRepository("hashicorp", "https://helm.releases.hashicorp.com")
 	require.NoError(t, err)
 }
+
+var testManifests = `---
+# Source: app/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+  labels:
+    app: app
+spec:
+  ports:
+    - port: 80
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: value
+`
+
+func TestLabelRendererAddsLabels(t *testing.T) {
+	lr := &labelRenderer{map[string]string{"team": "platform"}}
+
+	out, err := lr.Run(bytes.NewBufferString(testManifests))
+	require.NoError(t, err)
+
+	dec := yaml.NewDecoder(out)
+
+	svc := map[string]any{}
+	err = dec.Decode(&svc)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"app": "app", "team": "platform"}, svc["metadata"].(map[string]any)["labels"])
+
+	cm := map[string]any{}
+	err = dec.Decode(&cm)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"team": "platform"}, cm["metadata"].(map[string]any)["labels"])
+	require.Equal(t, map[string]any{"key": "value"}, cm["data"])
+}
+
+func TestLabelRendererWithInvalidManifestReturnsError(t *testing.T) {
+	lr := &labelRenderer{map[string]string{"team": "platform"}}
+
+	_, err := lr.Run(bytes.NewBufferString("metadata: [\n"))
+	require.ErrorContains(t, err, "unable to decode manifest")
+}
@@ -0,0 +1,141 @@
+package images
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// ImageSet is the list of images used by a blueprint the last time it was
+// applied
+type ImageSet struct {
+	Blueprint string    `json:"blueprint"`
+	Images    []string  `json:"images"`
+	Used      time.Time `json:"used"`
+}
+
+// ImageSets records the images used by each blueprint so that images which
+// are no longer used by recent blueprints can be removed
+//
+//go:generate mockery --name ImageSets --filename imagesets.go
+type ImageSets interface {
+	// Record replaces the image set for the blueprint and marks it as the most
+	// recently used
+	Record(blueprint string, images []string) error
+	// Read returns the image sets, most recently used first
+	Read() ([]ImageSet, error)
+	// Prune removes all but the keep most recently used image sets, it returns
+	// the images that are not used by any of the remaining sets
+	Prune(keep int) ([]string, error)
+}
+
+type ImageSetFileLog struct {
+	f string
+}
+
+// NewImageSetFileLog creates an ImageSets which uses a file as the
+// underlying Datastore
+func NewImageSetFileLog(file string) *ImageSetFileLog {
+	return &ImageSetFileLog{file}
+}
+
+// Record the images used by a blueprint
+func (i *ImageSetFileLog) Record(blueprint string, images []string) error {
+	sets, err := i.Read()
+	if err != nil {
+		return err
+	}
+
+	updated := []ImageSet{{Blueprint: blueprint, Images: images, Used: time.Now()}}
+	for _, s := range sets {
+		if s.Blueprint != blueprint {
+			updated = append(updated, s)
+		}
+	}
+
+	return i.write(updated)
+}
+
+// Read the image sets, a missing file returns an empty list
+func (i *ImageSetFileLog) Read() ([]ImageSet, error) {
+	d, err := os.ReadFile(i.f)
+	if os.IsNotExist(err) {
+		return []ImageSet{}, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read image sets: %s", err)
+	}
+
+	sets := []ImageSet{}
+	err = json.Unmarshal(d, &sets)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse image sets: %s", err)
+	}
+
+	sort.SliceStable(sets, func(a, b int) bool {
+		return sets[a].Used.After(sets[b].Used)
+	})
+
+	return sets, nil
+}
+
+// Prune the image sets keeping the most recently used
+func (i *ImageSetFileLog) Prune(keep int) ([]string, error) {
+	sets, err := i.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	if keep < 0 {
+		keep = 0
+	}
+
+	if len(sets) <= keep {
+		return []string{}, nil
+	}
+
+	kept := sets[:keep]
+	expired := sets[keep:]
+
+	used := map[string]bool{}
+	for _, s := range kept {
+		for _, img := range s.Images {
+			used[img] = true
+		}
+	}
+
+	// an image can be in more than one expired set
+	remove := []string{}
+	for _, s := range expired {
+		for _, img := range s.Images {
+			if !used[img] {
+				remove = append(remove, img)
+				used[img] = true
+			}
+		}
+	}
+
+	err = i.write(kept)
+	if err != nil {
+		return nil, err
+	}
+
+	return remove, nil
+}
+
+func (i *ImageSetFileLog) write(sets []ImageSet) error {
+	d, err := json.MarshalIndent(sets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to serialize image sets: %s", err)
+	}
+
+	err = os.WriteFile(i.f, d, 0666)
+	if err != nil {
+		return fmt.Errorf("unable to write image sets: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package images
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupImageSetsTests(t *testing.T) *ImageSetFileLog {
+	return NewImageSetFileLog(filepath.Join(t.TempDir(), "image_sets.json"))
+}
+
+func TestImageSetsReadWithMissingFileReturnsEmpty(t *testing.T) {
+	i := setupImageSetsTests(t)
+
+	sets, err := i.Read()
+	require.NoError(t, err)
+	require.Empty(t, sets)
+}
+
+func TestImageSetsReadWithInvalidFileReturnsError(t *testing.T) {
+	i := setupImageSetsTests(t)
+	os.WriteFile(i.f, []byte("nope"), 0666)
+
+	_, err := i.Read()
+	require.ErrorContains(t, err, "unable to parse image sets")
+}
+
+func TestImageSetsRecordReplacesBlueprintSet(t *testing.T) {
+	i := setupImageSetsTests(t)
+
+	err := i.Record("./consul", []string{"consul:1.15"})
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	err = i.Record("./vault", []string{"vault:1.13"})
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	err = i.Record("./consul", []string{"consul:1.16"})
+	require.NoError(t, err)
+
+	sets, err := i.Read()
+	require.NoError(t, err)
+
+	require.Len(t, sets, 2)
+	require.Equal(t, "./consul", sets[0].Blueprint)
+	require.Equal(t, []string{"consul:1.16"}, sets[0].Images)
+	require.Equal(t, "./vault", sets[1].Blueprint)
+}
+
+func TestImageSetsPruneReturnsImagesOnlyUsedByExpiredSets(t *testing.T) {
+	i := setupImageSetsTests(t)
+
+	i.Record("./nomad", []string{"nomad:1.6", "consul:1.16"})
+	time.Sleep(10 * time.Millisecond)
+	i.Record("./vault", []string{"vault:1.13", "nomad:1.6"})
+	time.Sleep(10 * time.Millisecond)
+	i.Record("./consul", []string{"consul:1.16"})
+
+	remove, err := i.Prune(1)
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []string{"vault:1.13", "nomad:1.6"}, remove)
+
+	sets, err := i.Read()
+	require.NoError(t, err)
+	require.Len(t, sets, 1)
+	require.Equal(t, "./consul", sets[0].Blueprint)
+}
+
+func TestImageSetsPruneWithFewerSetsDoesNothing(t *testing.T) {
+	i := setupImageSetsTests(t)
+
+	i.Record("./consul", []string{"consul:1.16"})
+
+	remove, err := i.Prune(2)
+	require.NoError(t, err)
+	require.Empty(t, remove)
+
+	sets, err := i.Read()
+	require.NoError(t, err)
+	require.Len(t, sets, 1)
+}
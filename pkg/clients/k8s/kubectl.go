@@ -12,9 +12,17 @@ import (
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	"helm.sh/helm/v3/pkg/kube"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -23,15 +31,28 @@ type Kubernetes interface {
 	SetConfig(string) (Kubernetes, error)
 	GetPods(string) (*v1.PodList, error)
 	HealthCheckPods(ctx context.Context, selectors []string, timeout time.Duration) error
-	Apply(files []string, waitUntilReady bool) error
+	// Apply creates the objects in the files, the labels are added to each
+	// object
+	Apply(files []string, waitUntilReady bool, labels map[string]string) error
 	Delete(files []string) error
 	GetPodLogs(ctx context.Context, podName, nameSpace string) (io.ReadCloser, error)
+	// StreamPodLogs follows the logs of a container in a pod, when since is
+	// not zero only the logs written after since are returned
+	StreamPodLogs(ctx context.Context, podName, nameSpace, container string, since time.Time) (io.ReadCloser, error)
+	// GetObject returns the object with the given kind, name, and namespace,
+	// namespace is ignored for cluster scoped objects
+	GetObject(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error)
+	// LabelNamespace sets the labels on a namespace, the namespace is created
+	// when it does not exist, labels with an empty value are removed
+	LabelNamespace(ctx context.Context, name string, labels map[string]string) error
 }
 
 // KubernetesImpl is a concrete implementation of a Kubernetes client
 type KubernetesImpl struct {
 	clientset  *kubernetes.Clientset
 	client     corev1.CoreV1Interface
+	dynamic    dynamic.Interface
+	mapper     *restmapper.DeferredDiscoveryRESTMapper
 	configPath string
 	timeout    time.Duration
 	l          logger.Logger
@@ -85,8 +106,15 @@ func (k *KubernetesImpl) setConfig() error {
 		return err
 	}
 
+	dc, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return err
+	}
+
 	k.clientset = clientset
 	k.client = clientset.CoreV1()
+	k.dynamic = dc
+	k.mapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(clientset.Discovery()))
 
 	return nil
 }
@@ -97,6 +125,80 @@ func (k *KubernetesImpl) GetPodLogs(ctx context.Context, podName, nameSpace stri
 	return k.clientset.CoreV1().Pods(nameSpace).GetLogs(podName, &plOpts).Stream(ctx)
 }
 
+// StreamPodLogs returns a io.ReadCloser that follows the logs of a container
+func (k *KubernetesImpl) StreamPodLogs(ctx context.Context, podName, nameSpace, container string, since time.Time) (io.ReadCloser, error) {
+	plOpts := v1.PodLogOptions{Container: container, Follow: true}
+	if !since.IsZero() {
+		st := metav1.NewTime(since)
+		plOpts.SinceTime = &st
+	}
+
+	return k.clientset.CoreV1().Pods(nameSpace).GetLogs(podName, &plOpts).Stream(ctx)
+}
+
+// GetObject returns the object with the given kind, name, and namespace
+func (k *KubernetesImpl) GetObject(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api version %s: %w", apiVersion, err)
+	}
+
+	m, err := k.mapper.RESTMapping(gv.WithKind(kind).GroupKind(), gv.Version)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find resource for kind %s: %w", kind, err)
+	}
+
+	if m.Scope.Name() == meta.RESTScopeNameNamespace {
+		return k.dynamic.Resource(m.Resource).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
+
+	return k.dynamic.Resource(m.Resource).Get(ctx, name, metav1.GetOptions{})
+}
+
+// LabelNamespace sets the labels on the namespace
+func (k *KubernetesImpl) LabelNamespace(ctx context.Context, name string, labels map[string]string) error {
+	ns, err := k.client.Namespaces().Get(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		ns = &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}}}
+		setLabels(ns, labels)
+
+		_, err = k.client.Namespaces().Create(ctx, ns, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("unable to create namespace %s: %w", name, err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to get namespace %s: %w", name, err)
+	}
+
+	if ns.Labels == nil {
+		ns.Labels = map[string]string{}
+	}
+
+	setLabels(ns, labels)
+
+	_, err = k.client.Namespaces().Update(ctx, ns, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to update namespace %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func setLabels(ns *v1.Namespace, labels map[string]string) {
+	for k, v := range labels {
+		if v == "" {
+			delete(ns.Labels, k)
+			continue
+		}
+
+		ns.Labels[k] = v
+	}
+}
+
 // GetPods returns the Kubernetes pods based on the label selector
 func (k *KubernetesImpl) GetPods(selector string) (*v1.PodList, error) {
 	lo := metav1.ListOptions{
@@ -112,7 +214,7 @@ func (k *KubernetesImpl) GetPods(selector string) (*v1.PodList, error) {
 
 // Apply Kubernetes YAML files at path
 // if waitUntilReady is true then the client will block until all resources have been created
-func (k *KubernetesImpl) Apply(files []string, waitUntilReady bool) error {
+func (k *KubernetesImpl) Apply(files []string, waitUntilReady bool, labels map[string]string) error {
 	allFiles, err := buildFileList(files)
 	if err != nil {
 		return err
@@ -124,7 +226,7 @@ func (k *KubernetesImpl) Apply(files []string, waitUntilReady bool) error {
 	// process the files
 	for _, f := range allFiles {
 		k.l.Debug("Applying Kubernetes config", "file", f)
-		err := applyFile(f, waitUntilReady, kc)
+		err := applyFile(f, waitUntilReady, labels, kc)
 		if err != nil {
 			return err
 		}
@@ -263,7 +365,7 @@ func buildFileList(files []string) ([]string, error) {
 	return allFiles, nil
 }
 
-func applyFile(path string, waitUntilReady bool, kc *kube.Client) error {
+func applyFile(path string, waitUntilReady bool, labels map[string]string, kc *kube.Client) error {
 	f, err := os.Open(path)
 	if err != nil {
 		return fmt.Errorf("unable to open file: %w", err)
@@ -275,6 +377,11 @@ func applyFile(path string, waitUntilReady bool, kc *kube.Client) error {
 		return fmt.Errorf("unable to build resources for file %s: %w", path, err)
 	}
 
+	err = addLabels(r, labels)
+	if err != nil {
+		return fmt.Errorf("unable to add labels to resources for file %s: %w", path, err)
+	}
+
 	_, err = kc.Create(r)
 	if err != nil {
 		return fmt.Errorf("unable to create resources for file %s: %w", path, err)
@@ -287,6 +394,38 @@ func applyFile(path string, waitUntilReady bool, kc *kube.Client) error {
 	return nil
 }
 
+// addLabels sets the labels on each of the objects, existing labels with the
+// same key are replaced
+func addLabels(r kube.ResourceList, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	return r.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+
+		a, err := meta.Accessor(info.Object)
+		if err != nil {
+			return err
+		}
+
+		l := a.GetLabels()
+		if l == nil {
+			l = map[string]string{}
+		}
+
+		for k, v := range labels {
+			l[k] = v
+		}
+
+		a.SetLabels(l)
+
+		return nil
+	})
+}
+
 func deleteFile(path string, kc *kube.Client) error {
 	f, err := os.Open(path)
 	if err != nil {
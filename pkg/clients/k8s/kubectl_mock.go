@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/mock"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 type MockKubernetes struct {
@@ -36,8 +37,34 @@ func (m *MockKubernetes) GetPodLogs(ctx context.Context, podName, nameSpace stri
 	return ior, args.Error(1)
 }
 
-func (m *MockKubernetes) Apply(files []string, waitUntilReady bool) error {
-	args := m.Called(files, waitUntilReady)
+func (m *MockKubernetes) StreamPodLogs(ctx context.Context, podName, nameSpace, container string, since time.Time) (io.ReadCloser, error) {
+	args := m.Called(ctx, podName, nameSpace, container, since)
+
+	if rc, ok := args.Get(0).(io.ReadCloser); ok {
+		return rc, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockKubernetes) GetObject(ctx context.Context, apiVersion, kind, namespace, name string) (*unstructured.Unstructured, error) {
+	args := m.Called(ctx, apiVersion, kind, namespace, name)
+
+	if o, ok := args.Get(0).(*unstructured.Unstructured); ok {
+		return o, args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MockKubernetes) LabelNamespace(ctx context.Context, name string, labels map[string]string) error {
+	args := m.Called(ctx, name, labels)
+
+	return args.Error(0)
+}
+
+func (m *MockKubernetes) Apply(files []string, waitUntilReady bool, labels map[string]string) error {
+	args := m.Called(files, waitUntilReady, labels)
 
 	return args.Error(0)
 }
@@ -2,6 +2,11 @@ package k8s
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/require"
+	"helm.sh/helm/v3/pkg/kube"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
 )
 
 // TODO: implement these tests
@@ -13,3 +18,33 @@ func TestKubernetesSetConfigCompletesWithoutError(t *testing.T) {
 func TestApply(t *testing.T) {
 	t.Skip()
 }
+
+func setupResourceList() kube.ResourceList {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion("v1")
+	obj.SetKind("ConfigMap")
+	obj.SetName("test")
+	obj.SetLabels(map[string]string{"app": "test", "team": "web"})
+
+	return kube.ResourceList{&resource.Info{Name: "test", Object: obj}}
+}
+
+func TestAddLabelsSetsLabels(t *testing.T) {
+	r := setupResourceList()
+
+	err := addLabels(r, map[string]string{"team": "platform", "env": "dev"})
+	require.NoError(t, err)
+
+	obj := r[0].Object.(*unstructured.Unstructured)
+	require.Equal(t, map[string]string{"app": "test", "team": "platform", "env": "dev"}, obj.GetLabels())
+}
+
+func TestAddLabelsWithNoLabelsDoesNotChangeObjects(t *testing.T) {
+	r := setupResourceList()
+
+	err := addLabels(r, nil)
+	require.NoError(t, err)
+
+	obj := r[0].Object.(*unstructured.Unstructured)
+	require.Equal(t, map[string]string{"app": "test", "team": "web"}, obj.GetLabels())
+}
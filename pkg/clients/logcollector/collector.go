@@ -0,0 +1,189 @@
+package logcollector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+)
+
+const (
+	// TypeKubernetes collects the logs of all pods in a Kubernetes cluster
+	TypeKubernetes = "kubernetes"
+	// TypeNomad collects the logs of all allocations in a Nomad cluster
+	TypeNomad = "nomad"
+)
+
+// Config is the cluster that a Collector reads logs from
+type Config struct {
+	// Type is one of TypeKubernetes or TypeNomad
+	Type string `json:"type"`
+	// Directory the log files are written to
+	Directory string `json:"directory"`
+
+	KubeConfig string `json:"kube_config,omitempty"`
+
+	NomadAddress string `json:"nomad_address,omitempty"`
+	NomadPort    int    `json:"nomad_port,omitempty"`
+}
+
+// ReadConfig reads the config for a Collector from a file
+func ReadConfig(path string) (*Config, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read log collector config: %s", err)
+	}
+
+	c := &Config{}
+	err = json.Unmarshal(d, c)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse log collector config: %s", err)
+	}
+
+	return c, nil
+}
+
+// WriteConfig writes the config for a Collector to a file
+func WriteConfig(path string, c *Config) error {
+	d, err := json.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("unable to serialize log collector config: %s", err)
+	}
+
+	return os.WriteFile(path, d, 0600)
+}
+
+// Stream is a log that can be followed, Name is unique for the source and is
+// used as the name of the log file
+type Stream struct {
+	Name string
+	// Open follows the log, when since is not zero only the logs written
+	// after since are returned
+	Open func(ctx context.Context, since time.Time) (io.ReadCloser, error)
+}
+
+// Source lists the logs in a cluster
+type Source interface {
+	Streams(ctx context.Context) ([]Stream, error)
+}
+
+// Collector follows the logs from a source writing each to a file, new
+// logs are discovered every interval
+type Collector struct {
+	source   Source
+	dir      string
+	interval time.Duration
+	log      logger.Logger
+
+	mutex  sync.Mutex
+	active map[string]bool
+	// ended records when a stream was closed so that it can be reopened
+	// without duplicating the logs
+	ended map[string]time.Time
+}
+
+// NewCollector creates a Collector that writes the logs to dir
+func NewCollector(s Source, dir string, interval time.Duration, l logger.Logger) *Collector {
+	return &Collector{
+		source:   s,
+		dir:      dir,
+		interval: interval,
+		log:      l,
+		active:   map[string]bool{},
+		ended:    map[string]time.Time{},
+	}
+}
+
+// Run collects the logs until the context is cancelled
+func (c *Collector) Run(ctx context.Context) error {
+	err := os.MkdirAll(c.dir, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("unable to create log directory: %s", err)
+	}
+
+	wg := sync.WaitGroup{}
+	defer wg.Wait()
+
+	for {
+		streams, err := c.source.Streams(ctx)
+		if err != nil {
+			c.log.Debug("Unable to list logs", "error", err)
+		}
+
+		for _, s := range streams {
+			if !c.start(s.Name) {
+				continue
+			}
+
+			wg.Add(1)
+			go func(s Stream) {
+				defer wg.Done()
+				c.follow(ctx, s)
+			}(s)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(c.interval):
+		}
+	}
+}
+
+// start marks the stream as active, it returns false when the stream is
+// already being followed
+func (c *Collector) start(name string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.active[name] {
+		return false
+	}
+
+	c.active[name] = true
+
+	return true
+}
+
+func (c *Collector) follow(ctx context.Context, s Stream) {
+	c.mutex.Lock()
+	since := c.ended[s.Name]
+	c.mutex.Unlock()
+
+	defer func() {
+		c.mutex.Lock()
+		delete(c.active, s.Name)
+		c.ended[s.Name] = time.Now()
+		c.mutex.Unlock()
+	}()
+
+	r, err := s.Open(ctx, since)
+	if err != nil {
+		c.log.Debug("Unable to open log", "name", s.Name, "error", err)
+		return
+	}
+	defer r.Close()
+
+	f, err := os.OpenFile(filepath.Join(c.dir, fileName(s.Name)), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		c.log.Error("Unable to open log file", "name", s.Name, "error", err)
+		return
+	}
+	defer f.Close()
+
+	c.log.Debug("Collecting log", "name", s.Name)
+
+	io.Copy(f, r)
+}
+
+// fileName returns a file name for the stream that is safe on all platforms
+func fileName(name string) string {
+	return strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(name) + ".log"
+}
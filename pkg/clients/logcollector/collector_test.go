@@ -0,0 +1,76 @@
+package logcollector
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+type testSource struct {
+	streams []Stream
+}
+
+func (t *testSource) Streams(ctx context.Context) ([]Stream, error) {
+	return t.streams, nil
+}
+
+func testStream(name, log string, opened *[]time.Time) Stream {
+	return Stream{
+		Name: name,
+		Open: func(ctx context.Context, since time.Time) (io.ReadCloser, error) {
+			*opened = append(*opened, since)
+			return io.NopCloser(bytes.NewBufferString(log)), nil
+		},
+	}
+}
+
+func runCollector(t *testing.T, s Source, d time.Duration) string {
+	dir := filepath.Join(t.TempDir(), "logs")
+
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	defer cancel()
+
+	c := NewCollector(s, dir, 10*time.Millisecond, logger.NewTestLogger(t))
+
+	err := c.Run(ctx)
+	require.NoError(t, err)
+
+	return dir
+}
+
+func TestCollectorWritesLogsToFiles(t *testing.T) {
+	opened := []time.Time{}
+	s := &testSource{streams: []Stream{
+		testStream("default_api_web", "hello\n", &opened),
+	}}
+
+	dir := runCollector(t, s, 5*time.Millisecond)
+
+	d, err := os.ReadFile(filepath.Join(dir, "default_api_web.log"))
+	require.NoError(t, err)
+	require.Equal(t, "hello\n", string(d))
+}
+
+func TestCollectorReopensEndedStreamsFromEnd(t *testing.T) {
+	opened := []time.Time{}
+	s := &testSource{streams: []Stream{
+		testStream("default_api_web", "hello\n", &opened),
+	}}
+
+	runCollector(t, s, 55*time.Millisecond)
+
+	require.Greater(t, len(opened), 1)
+	require.True(t, opened[0].IsZero())
+	require.False(t, opened[1].IsZero())
+}
+
+func TestFileNameReplacesSeparators(t *testing.T) {
+	require.Equal(t, "default_api_web.log", fileName("default/api:web"))
+}
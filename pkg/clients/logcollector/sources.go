@@ -0,0 +1,87 @@
+package logcollector
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+)
+
+// KubernetesSource lists the logs of the containers in all pods
+type KubernetesSource struct {
+	client k8s.Kubernetes
+}
+
+// NewKubernetesSource creates a source for a configured Kubernetes client
+func NewKubernetesSource(c k8s.Kubernetes) *KubernetesSource {
+	return &KubernetesSource{client: c}
+}
+
+// Streams returns a stream for each container named namespace_pod_container
+func (k *KubernetesSource) Streams(ctx context.Context) ([]Stream, error) {
+	pl, err := k.client.GetPods("")
+	if err != nil {
+		return nil, err
+	}
+
+	streams := []Stream{}
+	for _, p := range pl.Items {
+		for _, c := range p.Spec.Containers {
+			pod, ns, container := p.Name, p.Namespace, c.Name
+
+			streams = append(streams, Stream{
+				Name: fmt.Sprintf("%s_%s_%s", ns, pod, container),
+				Open: func(ctx context.Context, since time.Time) (io.ReadCloser, error) {
+					return k.client.StreamPodLogs(ctx, pod, ns, container, since)
+				},
+			})
+		}
+	}
+
+	return streams, nil
+}
+
+// NomadSource lists the stdout and stderr logs of the tasks in all running
+// allocations
+type NomadSource struct {
+	client nomad.Nomad
+}
+
+// NewNomadSource creates a source for a configured Nomad client
+func NewNomadSource(c nomad.Nomad) *NomadSource {
+	return &NomadSource{client: c}
+}
+
+// Streams returns a stream for each task named job_group_task_alloc.stdout
+// and job_group_task_alloc.stderr
+func (n *NomadSource) Streams(ctx context.Context) ([]Stream, error) {
+	tasks, err := n.client.RunningTasks()
+	if err != nil {
+		return nil, err
+	}
+
+	streams := []Stream{}
+	for _, t := range tasks {
+		for _, logType := range []string{"stdout", "stderr"} {
+			t, logType := t, logType
+
+			alloc := t.AllocationID
+			if len(alloc) > 8 {
+				alloc = alloc[:8]
+			}
+
+			streams = append(streams, Stream{
+				Name: fmt.Sprintf("%s_%s_%s_%s.%s", t.Job, t.Group, t.Task, alloc, logType),
+				Open: func(ctx context.Context, since time.Time) (io.ReadCloser, error) {
+					// Nomad can only return logs from the start or the end
+					return n.client.StreamTaskLogs(ctx, t.AllocationID, t.Task, logType, !since.IsZero())
+				},
+			})
+		}
+	}
+
+	return streams, nil
+}
@@ -0,0 +1,52 @@
+package logcollector
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestKubernetesSourceReturnsStreamForEachContainer(t *testing.T) {
+	mk := &k8s.MockKubernetes{}
+	mk.On("GetPods", "").Return(&v1.PodList{Items: []v1.Pod{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-123", Namespace: "default"},
+			Spec:       v1.PodSpec{Containers: []v1.Container{{Name: "web"}, {Name: "envoy"}}},
+		},
+	}}, nil)
+	mk.On("StreamPodLogs", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+	s, err := NewKubernetesSource(mk).Streams(context.Background())
+	require.NoError(t, err)
+	require.Len(t, s, 2)
+	require.Equal(t, "default_api-123_web", s[0].Name)
+
+	since := time.Now()
+	s[1].Open(context.Background(), since)
+	mk.AssertCalled(t, "StreamPodLogs", mock.Anything, "api-123", "default", "envoy", since)
+}
+
+func TestNomadSourceReturnsStdoutAndStderrForEachTask(t *testing.T) {
+	mn := &mocks.Nomad{}
+	mn.On("RunningTasks").Return([]nomad.TaskAllocation{
+		{AllocationID: "da975cd1-8b04-6bce-9d5c-03e47353768c", Job: "example", Group: "api", Task: "web"},
+	}, nil)
+	mn.On("StreamTaskLogs", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+	s, err := NewNomadSource(mn).Streams(context.Background())
+	require.NoError(t, err)
+	require.Len(t, s, 2)
+	require.Equal(t, "example_api_web_da975cd1.stdout", s[0].Name)
+	require.Equal(t, "example_api_web_da975cd1.stderr", s[1].Name)
+
+	s[1].Open(context.Background(), time.Now())
+	mn.AssertCalled(t, "StreamTaskLogs", mock.Anything, "da975cd1-8b04-6bce-9d5c-03e47353768c", "web", "stderr", true)
+}
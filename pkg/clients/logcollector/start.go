@@ -0,0 +1,62 @@
+package logcollector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/command"
+	"github.com/jumppad-labs/jumppad/pkg/clients/command/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// Directory returns the directory the logs for a cluster are written to,
+// usually $HOME/.jumppad/logs/[name]
+func Directory(name string) string {
+	return filepath.Join(utils.LogsDir(), name)
+}
+
+// Start runs a Collector in a background jumppad process, it returns the pid
+// of the process
+func Start(c command.Command, name string, conf *Config) (int, error) {
+	configPath := configPath(name)
+
+	err := WriteConfig(configPath, conf)
+	if err != nil {
+		return 0, err
+	}
+
+	bin, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("unable to find jumppad executable: %w", err)
+	}
+
+	pid, _, err := c.Execute(types.CommandConfig{
+		Command:         bin,
+		Args:            []string{"collect-logs", configPath},
+		InheritEnv:      true,
+		RunInBackground: true,
+		LogFilePath:     filepath.Join(utils.LogsDir(), fmt.Sprintf("%s.collector.log", name)),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("unable to start log collector: %w", err)
+	}
+
+	return pid, nil
+}
+
+// Stop kills the background process started by Start, the collected logs
+// are not removed
+func Stop(c command.Command, name string, pid int) error {
+	os.Remove(configPath(name))
+
+	if pid < 1 {
+		return nil
+	}
+
+	return c.Kill(pid)
+}
+
+func configPath(name string) string {
+	return filepath.Join(utils.JumppadTemp(), fmt.Sprintf("%s.collector.json", name))
+}
@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"testing"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 	"github.com/hashicorp/go-hclog"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/muesli/termenv"
 )
 
@@ -21,6 +23,11 @@ const (
 	LogLevelError = "error"
 )
 
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
 // Logger defines a abstract logger that can be used to log to the output
 type Logger interface {
 	// Set the logger level
@@ -70,6 +77,36 @@ func NewLogger(w io.Writer, level string) Logger {
 	return &CharmLogger{l, w, level}
 }
 
+// NewJSONLogger creates a new logger that writes each message as a JSON
+// object with a timestamp
+func NewJSONLogger(w io.Writer, level string) Logger {
+	return newLoggerWithOptions(w, level, log.Options{
+		Formatter:       log.JSONFormatter,
+		ReportTimestamp: true,
+		TimeFormat:      time.RFC3339,
+	})
+}
+
+// NewFileLogger creates a new logger for writing to log files, messages are
+// written as text with a timestamp
+func NewFileLogger(w io.Writer, level string) Logger {
+	return newLoggerWithOptions(w, level, log.Options{
+		ReportTimestamp: true,
+		TimeFormat:      time.RFC3339,
+	})
+}
+
+func newLoggerWithOptions(w io.Writer, level string, o log.Options) Logger {
+	ll, err := log.ParseLevel(level)
+	if err != nil {
+		ll = log.InfoLevel
+	}
+
+	o.Level = ll
+
+	return &CharmLogger{log.NewWithOptions(w, o), w, level}
+}
+
 // NewTTYLogger creates a new logger with full TTY colors
 func NewTTYLogger(w io.Writer, level string) Logger {
 	r := lipgloss.NewRenderer(w, termenv.WithColorCache(true), termenv.WithTTY(true))
@@ -129,25 +166,55 @@ func (l *CharmLogger) StandardWriter() io.Writer {
 }
 
 func (l *CharmLogger) Info(message string, keyvals ...interface{}) {
+	message, keyvals = redact(message, keyvals)
 	l.internal.Info(message, keyvals...)
 }
 
 func (l *CharmLogger) Debug(message string, keyvals ...interface{}) {
+	message, keyvals = redact(message, keyvals)
 	l.internal.Debug(message, keyvals...)
 }
 
 func (l *CharmLogger) Error(message string, keyvals ...interface{}) {
+	message, keyvals = redact(message, keyvals)
 	l.internal.Error(message, keyvals...)
 }
 
 func (l *CharmLogger) Warn(message string, keyvals ...interface{}) {
+	message, keyvals = redact(message, keyvals)
 	l.internal.Warn(message, keyvals...)
 }
 
 func (l *CharmLogger) Trace(message string, keyvals ...interface{}) {
+	message, keyvals = redact(message, keyvals)
 	l.internal.Debug(message, keyvals...)
 }
 
+// redact replaces any sensitive values such as secrets in the message and
+// the key values
+func redact(message string, keyvals []interface{}) (string, []interface{}) {
+	message = utils.Redact(message)
+
+	out := make([]interface{}, len(keyvals))
+	for i, kv := range keyvals {
+		switch v := kv.(type) {
+		case string:
+			out[i] = utils.Redact(v)
+		case error:
+			if utils.ContainsSensitive(v.Error()) {
+				out[i] = utils.Redact(v.Error())
+				continue
+			}
+
+			out[i] = v
+		default:
+			out[i] = kv
+		}
+	}
+
+	return message, out
+}
+
 func LoggerAsHCLogger(l Logger) hclog.Logger {
 	lo := hclog.LoggerOptions{}
 	lo.Level = hclog.LevelFromString(l.Level())
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerRedactsSensitiveValues(t *testing.T) {
+	utils.RegisterSensitive("l0gg3r-s3cr3t")
+
+	sb := &strings.Builder{}
+	l := NewLogger(sb, LogLevelDebug)
+
+	l.Info("token is l0gg3r-s3cr3t", "token", "l0gg3r-s3cr3t", "error", fmt.Errorf("invalid token l0gg3r-s3cr3t"))
+
+	require.NotContains(t, sb.String(), "l0gg3r-s3cr3t")
+	require.Contains(t, sb.String(), utils.Redacted)
+}
+
+func TestJSONLoggerWritesJSON(t *testing.T) {
+	sb := &strings.Builder{}
+	l := NewJSONLogger(sb, LogLevelInfo)
+
+	l.Info("creating container", "ref", "resource.container.consul")
+
+	out := map[string]any{}
+	err := json.Unmarshal([]byte(sb.String()), &out)
+	require.NoError(t, err)
+
+	require.Equal(t, "creating container", out["msg"])
+	require.Equal(t, "info", out["level"])
+	require.Equal(t, "resource.container.consul", out["ref"])
+	require.NotEmpty(t, out["time"])
+}
+
+func TestTeeLoggerWritesToBothLoggers(t *testing.T) {
+	main := &strings.Builder{}
+	file := &strings.Builder{}
+
+	l := NewTeeLogger(NewLogger(main, LogLevelInfo), NewFileLogger(file, LogLevelDebug))
+
+	l.Info("creating container")
+	l.Debug("pulling image")
+
+	require.Contains(t, main.String(), "creating container")
+	require.NotContains(t, main.String(), "pulling image")
+	require.Contains(t, file.String(), "creating container")
+	require.Contains(t, file.String(), "pulling image")
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"io"
+)
+
+// TeeLogger writes every message to a Logger and a second Logger, the
+// settings such as the level and output are those of the first Logger.
+// This is used to capture the logs of a single resource to a file while
+// still writing them to the main output.
+type TeeLogger struct {
+	Logger
+	tee Logger
+}
+
+// NewTeeLogger creates a logger that writes to l and tee
+func NewTeeLogger(l Logger, tee Logger) Logger {
+	return &TeeLogger{l, tee}
+}
+
+func (t *TeeLogger) StandardWriter() io.Writer {
+	return io.MultiWriter(t.Logger.StandardWriter(), t.tee.StandardWriter())
+}
+
+func (t *TeeLogger) Info(message string, keyvals ...interface{}) {
+	t.Logger.Info(message, keyvals...)
+	t.tee.Info(message, keyvals...)
+}
+
+func (t *TeeLogger) Debug(message string, keyvals ...interface{}) {
+	t.Logger.Debug(message, keyvals...)
+	t.tee.Debug(message, keyvals...)
+}
+
+func (t *TeeLogger) Error(message string, keyvals ...interface{}) {
+	t.Logger.Error(message, keyvals...)
+	t.tee.Error(message, keyvals...)
+}
+
+func (t *TeeLogger) Warn(message string, keyvals ...interface{}) {
+	t.Logger.Warn(message, keyvals...)
+	t.tee.Warn(message, keyvals...)
+}
+
+func (t *TeeLogger) Trace(message string, keyvals ...interface{}) {
+	t.Logger.Trace(message, keyvals...)
+	t.tee.Trace(message, keyvals...)
+}
@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jumppad-labs/connector/protos/shipyard"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	// HealthCheckHTTP is the kind of a HTTP health check
+	HealthCheckHTTP = "http"
+	// HealthCheckTCP is the kind of a TCP health check
+	HealthCheckTCP = "tcp"
+	// HealthCheckExec is the kind of a health check that runs a command
+	HealthCheckExec = "exec"
+	// HealthCheckPods is the kind of a health check for Kubernetes pods
+	HealthCheckPods = "pods"
+	// HealthCheckJobs is the kind of a health check for Nomad jobs
+	HealthCheckJobs = "jobs"
+)
+
+// ServiceLister returns the services that are exposed by the connector
+type ServiceLister func() ([]*shipyard.Service, error)
+
+// Metrics collects Prometheus metrics for provider operations, health checks
+// and connector tunnels
+type Metrics struct {
+	registry            *prometheus.Registry
+	operations          *prometheus.CounterVec
+	operationDuration   *prometheus.HistogramVec
+	healthChecks        *prometheus.CounterVec
+	healthCheckDuration *prometheus.HistogramVec
+}
+
+// Default is the Metrics used by the providers and commands, values are only
+// exposed when a listener is started with Serve
+var Default = New()
+
+// New creates a Metrics with its own registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "jumppad_provider_operations_total",
+			Help: "Number of provider operations by resource type and resulting status",
+		}, []string{"type", "status"}),
+		operationDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "jumppad_provider_operation_duration_seconds",
+			Help:    "Duration of provider operations by resource type and resulting status",
+			Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600},
+		}, []string{"type", "status"}),
+		healthChecks: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "jumppad_health_checks_total",
+			Help: "Number of health checks by resource, kind and result",
+		}, []string{"resource", "kind", "result"}),
+		healthCheckDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "jumppad_health_check_duration_seconds",
+			Help:    "Time taken for health checks to pass or time out",
+			Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+		}, []string{"resource", "kind"}),
+	}
+
+	m.registry.MustRegister(
+		m.operations,
+		m.operationDuration,
+		m.healthChecks,
+		m.healthCheckDuration,
+	)
+
+	return m
+}
+
+// ObserveOperation records a provider operation for a resource, status is the
+// status of the resource once the operation has completed
+func (m *Metrics) ObserveOperation(resourceType, status string, d time.Duration) {
+	m.operations.WithLabelValues(resourceType, status).Inc()
+	m.operationDuration.WithLabelValues(resourceType, status).Observe(d.Seconds())
+}
+
+// ObserveHealthCheck records the result of a health check for a resource
+func (m *Metrics) ObserveHealthCheck(resourceID, kind string, d time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+
+	m.healthChecks.WithLabelValues(resourceID, kind, result).Inc()
+	m.healthCheckDuration.WithLabelValues(resourceID, kind).Observe(d.Seconds())
+}
+
+// RegisterServiceLister exposes the number of connector tunnels by type and
+// status, the services are listed each time the metrics are scraped
+func (m *Metrics) RegisterServiceLister(sl ServiceLister) error {
+	return m.registry.Register(&tunnelCollector{list: sl})
+}
+
+// Handler returns a http.Handler that serves the metrics in the Prometheus
+// text format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Serve starts a listener at the given address that serves the metrics at
+// /metrics, the function blocks until the listener errors
+func (m *Metrics) Serve(addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+
+	return http.ListenAndServe(addr, mux)
+}
+
+var tunnelsDesc = prometheus.NewDesc(
+	"jumppad_connector_tunnels",
+	"Number of services exposed through the connector by type and status",
+	[]string{"type", "status"},
+	nil,
+)
+
+var tunnelErrorsDesc = prometheus.NewDesc(
+	"jumppad_connector_list_errors",
+	"Set to 1 when the services could not be listed from the connector",
+	nil,
+	nil,
+)
+
+// tunnelCollector collects the connector tunnels when metrics are scraped
+type tunnelCollector struct {
+	list ServiceLister
+}
+
+func (t *tunnelCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tunnelsDesc
+	ch <- tunnelErrorsDesc
+}
+
+func (t *tunnelCollector) Collect(ch chan<- prometheus.Metric) {
+	svcs, err := t.list()
+	if err != nil {
+		ch <- prometheus.MustNewConstMetric(tunnelErrorsDesc, prometheus.GaugeValue, 1)
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(tunnelErrorsDesc, prometheus.GaugeValue, 0)
+
+	// always report every type and status so that a count returning to zero
+	// is visible
+	counts := map[[2]string]int{}
+	for _, ty := range shipyard.ServiceType_name {
+		for _, st := range shipyard.ServiceStatus_name {
+			counts[[2]string{strings.ToLower(ty), strings.ToLower(st)}] = 0
+		}
+	}
+
+	for _, s := range svcs {
+		counts[[2]string{strings.ToLower(s.Type.String()), strings.ToLower(s.Status.String())}]++
+	}
+
+	for k, v := range counts {
+		ch <- prometheus.MustNewConstMetric(tunnelsDesc, prometheus.GaugeValue, float64(v), k[0], k[1])
+	}
+}
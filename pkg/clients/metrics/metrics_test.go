@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/connector/protos/shipyard"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest("GET", "/metrics", nil))
+
+	require.Equal(t, 200, rr.Code)
+
+	d, err := io.ReadAll(rr.Body)
+	require.NoError(t, err)
+
+	return string(d)
+}
+
+func TestObserveOperationRecordsCountAndDuration(t *testing.T) {
+	m := New()
+
+	m.ObserveOperation("container", "created", 2*time.Second)
+	m.ObserveOperation("container", "created", 3*time.Second)
+
+	out := scrape(t, m)
+	require.Contains(t, out, `jumppad_provider_operations_total{status="created",type="container"} 2`)
+	require.Contains(t, out, `jumppad_provider_operation_duration_seconds_sum{status="created",type="container"} 5`)
+}
+
+func TestObserveHealthCheckWithErrorRecordsFailure(t *testing.T) {
+	m := New()
+
+	m.ObserveHealthCheck("resource.container.consul", HealthCheckHTTP, time.Second, fmt.Errorf("timeout"))
+
+	out := scrape(t, m)
+	require.Contains(t, out, `jumppad_health_checks_total{kind="http",resource="resource.container.consul",result="failure"} 1`)
+}
+
+func TestRegisterServiceListerCountsTunnels(t *testing.T) {
+	m := New()
+
+	err := m.RegisterServiceLister(func() ([]*shipyard.Service, error) {
+		return []*shipyard.Service{
+			{Type: shipyard.ServiceType_LOCAL, Status: shipyard.ServiceStatus_COMPLETE},
+			{Type: shipyard.ServiceType_LOCAL, Status: shipyard.ServiceStatus_COMPLETE},
+			{Type: shipyard.ServiceType_REMOTE, Status: shipyard.ServiceStatus_ERROR},
+		}, nil
+	})
+	require.NoError(t, err)
+
+	out := scrape(t, m)
+	require.Contains(t, out, `jumppad_connector_tunnels{status="complete",type="local"} 2`)
+	require.Contains(t, out, `jumppad_connector_tunnels{status="error",type="remote"} 1`)
+	require.Contains(t, out, `jumppad_connector_tunnels{status="pending",type="local"} 0`)
+	require.Contains(t, out, `jumppad_connector_list_errors 0`)
+}
+
+func TestRegisterServiceListerWithErrorReportsListError(t *testing.T) {
+	m := New()
+
+	err := m.RegisterServiceLister(func() ([]*shipyard.Service, error) {
+		return nil, fmt.Errorf("connection refused")
+	})
+	require.NoError(t, err)
+
+	out := scrape(t, m)
+	require.Contains(t, out, `jumppad_connector_list_errors 1`)
+	require.NotContains(t, out, `jumppad_connector_tunnels{`)
+}
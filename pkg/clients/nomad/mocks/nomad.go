@@ -4,9 +4,12 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 
 	mock "github.com/stretchr/testify/mock"
 
+	nomad "github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+
 	time "time"
 )
 
@@ -15,13 +18,51 @@ type Nomad struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: files
-func (_m *Nomad) Create(files []string) error {
-	ret := _m.Called(files)
+// CSIPluginHealthy provides a mock function with given fields: plugin
+func (_m *Nomad) CSIPluginHealthy(plugin string) (bool, error) {
+	ret := _m.Called(plugin)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
+		return rf(plugin)
+	}
+	if rf, ok := ret.Get(0).(func(string) bool); ok {
+		r0 = rf(plugin)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(plugin)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: files, meta
+func (_m *Nomad) Create(files []string, meta map[string]string) error {
+	ret := _m.Called(files, meta)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func([]string) error); ok {
-		r0 = rf(files)
+	if rf, ok := ret.Get(0).(func([]string, map[string]string) error); ok {
+		r0 = rf(files, meta)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeregisterCSIVolume provides a mock function with given fields: id, del
+func (_m *Nomad) DeregisterCSIVolume(id string, del bool) error {
+	ret := _m.Called(id, del)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, bool) error); ok {
+		r0 = rf(id, del)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -55,6 +96,30 @@ func (_m *Nomad) Endpoints(job string, group string, task string) ([]map[string]
 	return r0, r1
 }
 
+// ExecuteCommand provides a mock function with given fields: job, group, task, command, writer, timeout
+func (_m *Nomad) ExecuteCommand(job string, group string, task string, command []string, writer io.Writer, timeout time.Duration) (int, error) {
+	ret := _m.Called(job, group, task, command, writer, timeout)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, string, []string, io.Writer, time.Duration) (int, error)); ok {
+		return rf(job, group, task, command, writer, timeout)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, string, []string, io.Writer, time.Duration) int); ok {
+		r0 = rf(job, group, task, command, writer, timeout)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, string, []string, io.Writer, time.Duration) error); ok {
+		r1 = rf(job, group, task, command, writer, timeout)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HealthCheckAPI provides a mock function with given fields: _a0, _a1
 func (_m *Nomad) HealthCheckAPI(_a0 context.Context, _a1 time.Duration) error {
 	ret := _m.Called(_a0, _a1)
@@ -69,6 +134,30 @@ func (_m *Nomad) HealthCheckAPI(_a0 context.Context, _a1 time.Duration) error {
 	return r0
 }
 
+// HostVolumeNodes provides a mock function with given fields: volume
+func (_m *Nomad) HostVolumeNodes(volume string) (int, error) {
+	ret := _m.Called(volume)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (int, error)); ok {
+		return rf(volume)
+	}
+	if rf, ok := ret.Get(0).(func(string) int); ok {
+		r0 = rf(volume)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(volume)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // JobRunning provides a mock function with given fields: job
 func (_m *Nomad) JobRunning(job string) (bool, error) {
 	ret := _m.Called(job)
@@ -119,6 +208,72 @@ func (_m *Nomad) ParseJob(file string) ([]byte, error) {
 	return r0, r1
 }
 
+// RegisterCSIVolume provides a mock function with given fields: v
+func (_m *Nomad) RegisterCSIVolume(v nomad.CSIVolume) error {
+	ret := _m.Called(v)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(nomad.CSIVolume) error); ok {
+		r0 = rf(v)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RunningTasks provides a mock function with given fields:
+func (_m *Nomad) RunningTasks() ([]nomad.TaskAllocation, error) {
+	ret := _m.Called()
+
+	var r0 []nomad.TaskAllocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]nomad.TaskAllocation, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []nomad.TaskAllocation); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]nomad.TaskAllocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ServiceAddresses provides a mock function with given fields: service
+func (_m *Nomad) ServiceAddresses(service string) ([]string, error) {
+	ret := _m.Called(service)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(service)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(service)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(service)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetConfig provides a mock function with given fields: address, port, nodes
 func (_m *Nomad) SetConfig(address string, port int, nodes int) error {
 	ret := _m.Called(address, port, nodes)
@@ -133,6 +288,32 @@ func (_m *Nomad) SetConfig(address string, port int, nodes int) error {
 	return r0
 }
 
+// StreamTaskLogs provides a mock function with given fields: ctx, allocID, task, logType, fromEnd
+func (_m *Nomad) StreamTaskLogs(ctx context.Context, allocID string, task string, logType string, fromEnd bool) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, allocID, task, logType, fromEnd)
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) (io.ReadCloser, error)); ok {
+		return rf(ctx, allocID, task, logType, fromEnd)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, bool) io.ReadCloser); ok {
+		r0 = rf(ctx, allocID, task, logType, fromEnd)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, bool) error); ok {
+		r1 = rf(ctx, allocID, task, logType, fromEnd)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Stop provides a mock function with given fields: files
 func (_m *Nomad) Stop(files []string) error {
 	ret := _m.Called(files)
@@ -4,12 +4,19 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/gorilla/websocket"
+
 	chttp "github.com/jumppad-labs/jumppad/pkg/clients/http"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 )
@@ -20,8 +27,9 @@ import (
 type Nomad interface {
 	// SetConfig for the client, path is a valid Nomad JSON config file
 	SetConfig(address string, port, nodes int) error
-	// Create jobs in the provided files
-	Create(files []string) error
+	// Create jobs in the provided files, the meta is added to the meta of
+	// each job
+	Create(files []string, meta map[string]string) error
 	// Stop jobs in the provided files
 	Stop(files []string) error
 	// ParseJob in the given file and return a JSON blob representing the HCL job
@@ -34,6 +42,59 @@ type Nomad interface {
 	HealthCheckAPI(context.Context, time.Duration) error
 	// Endpoints returns a list of endpoints for a cluster
 	Endpoints(job, group, task string) ([]map[string]string, error)
+	// ServiceAddresses returns the address and port of each registration of
+	// a Nomad native service in the form ip:port
+	ServiceAddresses(service string) ([]string, error)
+	// RunningTasks returns the tasks of all running allocations in the cluster
+	RunningTasks() ([]TaskAllocation, error)
+	// StreamTaskLogs follows the stdout or stderr logs of a task, when
+	// fromEnd is true only logs written after the call are returned
+	StreamTaskLogs(ctx context.Context, allocID, task, logType string, fromEnd bool) (io.ReadCloser, error)
+	// ExecuteCommand runs a command in a task of a running allocation for the
+	// job, output is written to writer and the exit code of the command is
+	// returned
+	ExecuteCommand(job, group, task string, command []string, writer io.Writer, timeout time.Duration) (int, error)
+	// HostVolumeNodes returns the number of ready nodes that have the host
+	// volume in their client config
+	HostVolumeNodes(volume string) (int, error)
+	// CSIPluginHealthy returns true when the nodes and the controllers of a
+	// CSI plugin are healthy, false is returned when the plugin is not
+	// registered
+	CSIPluginHealthy(plugin string) (bool, error)
+	// RegisterCSIVolume registers a volume with a CSI plugin, when the volume
+	// does not have an ExternalID the plugin creates the volume
+	RegisterCSIVolume(v CSIVolume) error
+	// DeregisterCSIVolume removes a CSI volume from the cluster, when del is
+	// true the plugin also deletes the volume
+	DeregisterCSIVolume(id string, del bool) error
+}
+
+// CSIVolume is a volume managed by a CSI plugin
+type CSIVolume struct {
+	ID                    string
+	Name                  string
+	PluginID              string
+	ExternalID            string                `json:",omitempty"`
+	RequestedCapabilities []CSIVolumeCapability `json:",omitempty"`
+	RequestedCapacityMin  int64                 `json:",omitempty"`
+	RequestedCapacityMax  int64                 `json:",omitempty"`
+	Parameters            map[string]string     `json:",omitempty"`
+	Secrets               map[string]string     `json:",omitempty"`
+}
+
+// CSIVolumeCapability is an access and attachment mode requested for a CSI
+// volume e.g. single-node-writer and file-system
+type CSIVolumeCapability struct {
+	AccessMode     string
+	AttachmentMode string
+}
+
+// TaskAllocation is a task running in an allocation
+type TaskAllocation struct {
+	AllocationID string
+	Job          string
+	Group        string
+	Task         string
 }
 
 // NomadImpl is an implementation of the Nomad interface
@@ -157,7 +218,7 @@ func (n *NomadImpl) HealthCheckAPI(ctx context.Context, timeout time.Duration) e
 }
 
 // Create jobs in the Nomad cluster for the given files and wait until all jobs are running
-func (n *NomadImpl) Create(files []string) error {
+func (n *NomadImpl) Create(files []string, meta map[string]string) error {
 	for _, f := range files {
 		// parse the job
 		jsonJob, err := n.ParseJob(f)
@@ -165,6 +226,11 @@ func (n *NomadImpl) Create(files []string) error {
 			return err
 		}
 
+		jsonJob, err = addJobMeta(jsonJob, meta)
+		if err != nil {
+			return fmt.Errorf("unable to add meta to job %s: %w", f, err)
+		}
+
 		addr := fmt.Sprintf("%s:%d/v1/jobs", n.address, n.port)
 		n.l.Debug("Submitting job to Nomad", "file", f, "address", addr)
 
@@ -192,6 +258,33 @@ func (n *NomadImpl) Create(files []string) error {
 	return nil
 }
 
+// addJobMeta adds the meta to the JSON job, existing meta with the same key
+// is replaced
+func addJobMeta(jsonJob []byte, meta map[string]string) ([]byte, error) {
+	if len(meta) == 0 {
+		return jsonJob, nil
+	}
+
+	job := map[string]any{}
+	err := json.Unmarshal(jsonJob, &job)
+	if err != nil {
+		return nil, err
+	}
+
+	m, _ := job["Meta"].(map[string]any)
+	if m == nil {
+		m = map[string]any{}
+	}
+
+	for k, v := range meta {
+		m[k] = v
+	}
+
+	job["Meta"] = m
+
+	return json.Marshal(job)
+}
+
 // Stop the jobs defined in the files for the referenced Nomad cluster
 func (n *NomadImpl) Stop(files []string) error {
 	for _, f := range files {
@@ -391,6 +484,387 @@ func (n *NomadImpl) Endpoints(job, group, task string) ([]map[string]string, err
 	return endpoints, nil
 }
 
+// RunningTasks returns the tasks of the running allocations
+func (n *NomadImpl) RunningTasks() ([]TaskAllocation, error) {
+	r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s:%d/v1/allocations", n.address, n.port), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create http request: %w", err)
+	}
+
+	resp, err := n.httpClient.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query allocations: %w", err)
+	}
+
+	if resp.Body == nil {
+		return nil, fmt.Errorf("no body returned from Nomad API")
+	}
+
+	defer resp.Body.Close()
+
+	allocs := []allocationStub{}
+	err = json.NewDecoder(resp.Body).Decode(&allocs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query allocations in Nomad server: %s: %s", n.address, err)
+	}
+
+	tasks := []TaskAllocation{}
+	for _, a := range allocs {
+		if a.ClientStatus != "running" {
+			continue
+		}
+
+		for t := range a.TaskStates {
+			tasks = append(tasks, TaskAllocation{AllocationID: a.ID, Job: a.JobID, Group: a.TaskGroup, Task: t})
+		}
+	}
+
+	return tasks, nil
+}
+
+// StreamTaskLogs returns a io.ReadCloser that follows the logs of a task
+func (n *NomadImpl) StreamTaskLogs(ctx context.Context, allocID, task, logType string, fromEnd bool) (io.ReadCloser, error) {
+	origin := "start"
+	if fromEnd {
+		origin = "end"
+	}
+
+	r, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf(
+			"%s:%d/v1/client/fs/logs/%s?task=%s&type=%s&follow=true&plain=true&origin=%s&offset=0",
+			n.address, n.port, allocID, url.QueryEscape(task), logType, origin,
+		),
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create http request: %w", err)
+	}
+
+	resp, err := n.httpClient.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get logs: %w", err)
+	}
+
+	if resp.Body == nil {
+		return nil, fmt.Errorf("no body returned from Nomad API")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unable to get logs for task %s, Nomad API returned status %d", task, resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+// ServiceAddresses returns the addresses of a service registered with the
+// Nomad service discovery
+func (n *NomadImpl) ServiceAddresses(service string) ([]string, error) {
+	r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s:%d/v1/service/%s", n.address, n.port, url.PathEscape(service)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create http request: %w", err)
+	}
+
+	resp, err := n.httpClient.Do(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query service: %w", err)
+	}
+
+	if resp.Body == nil {
+		return nil, fmt.Errorf("no body returned from Nomad API")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to query service %s, Nomad API returned status %d", service, resp.StatusCode)
+	}
+
+	regs := []serviceRegistration{}
+	err = json.NewDecoder(resp.Body).Decode(&regs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query service in Nomad server: %s: %s", n.address, err)
+	}
+
+	addresses := []string{}
+	for _, r := range regs {
+		addresses = append(addresses, net.JoinHostPort(r.Address, strconv.Itoa(r.Port)))
+	}
+
+	return addresses, nil
+}
+
+// HostVolumeNodes returns the number of ready nodes that have fingerprinted
+// the host volume, nodes fingerprint host volumes when the agent starts
+func (n *NomadImpl) HostVolumeNodes(volume string) (int, error) {
+	nodes := []nodeStub{}
+	err := n.getJSON(fmt.Sprintf("%s:%d/v1/nodes", n.address, n.port), &nodes)
+	if err != nil {
+		return 0, fmt.Errorf("unable to list nodes: %w", err)
+	}
+
+	count := 0
+	for _, ns := range nodes {
+		if ns.Status != "ready" {
+			continue
+		}
+
+		nd := node{}
+		err := n.getJSON(fmt.Sprintf("%s:%d/v1/node/%s", n.address, n.port, url.PathEscape(ns.ID)), &nd)
+		if err != nil {
+			return 0, fmt.Errorf("unable to read node %s: %w", ns.ID, err)
+		}
+
+		if _, ok := nd.HostVolumes[volume]; ok {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// getJSON decodes the response from a GET request to the Nomad API into v
+func (n *NomadImpl) getJSON(addr string, v any) error {
+	r, err := http.NewRequest(http.MethodGet, addr, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create http request: %w", err)
+	}
+
+	resp, err := n.httpClient.Do(r)
+	if err != nil {
+		return err
+	}
+
+	if resp.Body == nil {
+		return fmt.Errorf("no body returned from Nomad API")
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Nomad API returned status %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// CSIPluginHealthy returns true when the CSI plugin has healthy nodes and,
+// if required, healthy controllers
+func (n *NomadImpl) CSIPluginHealthy(plugin string) (bool, error) {
+	r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s:%d/v1/plugin/csi/%s", n.address, n.port, url.PathEscape(plugin)), nil)
+	if err != nil {
+		return false, fmt.Errorf("unable to create http request: %w", err)
+	}
+
+	resp, err := n.httpClient.Do(r)
+	if err != nil {
+		return false, fmt.Errorf("unable to query plugin: %w", err)
+	}
+
+	if resp.Body == nil {
+		return false, fmt.Errorf("no body returned from Nomad API")
+	}
+
+	defer resp.Body.Close()
+
+	// the plugin is registered when the first allocation of the plugin job
+	// starts
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("unable to query plugin %s, Nomad API returned status %d", plugin, resp.StatusCode)
+	}
+
+	p := csiPlugin{}
+	err = json.NewDecoder(resp.Body).Decode(&p)
+	if err != nil {
+		return false, fmt.Errorf("unable to query plugin in Nomad server: %s: %s", n.address, err)
+	}
+
+	if p.NodesHealthy < 1 || p.NodesHealthy < p.NodesExpected {
+		return false, nil
+	}
+
+	if p.ControllerRequired && (p.ControllersHealthy < 1 || p.ControllersHealthy < p.ControllersExpected) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// RegisterCSIVolume registers or creates a volume with the CSI volume API
+func (n *NomadImpl) RegisterCSIVolume(v CSIVolume) error {
+	addr := fmt.Sprintf("%s:%d/v1/volume/csi/%s", n.address, n.port, url.PathEscape(v.ID))
+	if v.ExternalID == "" {
+		addr += "/create"
+	}
+
+	d, err := json.Marshal(map[string][]CSIVolume{"Volumes": {v}})
+	if err != nil {
+		return fmt.Errorf("unable to serialize volume: %w", err)
+	}
+
+	r, err := http.NewRequest(http.MethodPut, addr, bytes.NewReader(d))
+	if err != nil {
+		return fmt.Errorf("unable to create http request: %w", err)
+	}
+
+	resp, err := n.httpClient.Do(r)
+	if err != nil {
+		return fmt.Errorf("unable to register volume: %w", err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		// try to read the body for the error
+		var d []byte
+		if resp.Body != nil {
+			d, _ = io.ReadAll(resp.Body)
+		}
+
+		return fmt.Errorf("error registering volume %s, got status code %d, error: %s", v.ID, resp.StatusCode, string(d))
+	}
+
+	return nil
+}
+
+// DeregisterCSIVolume deregisters or deletes a volume with the CSI volume API
+func (n *NomadImpl) DeregisterCSIVolume(id string, del bool) error {
+	addr := fmt.Sprintf("%s:%d/v1/volume/csi/%s?force=true", n.address, n.port, url.PathEscape(id))
+	if del {
+		addr = fmt.Sprintf("%s:%d/v1/volume/csi/%s/delete", n.address, n.port, url.PathEscape(id))
+	}
+
+	r, err := http.NewRequest(http.MethodDelete, addr, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create http request: %w", err)
+	}
+
+	resp, err := n.httpClient.Do(r)
+	if err != nil {
+		return fmt.Errorf("unable to deregister volume: %w", err)
+	}
+
+	if resp.Body != nil {
+		defer resp.Body.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error deregistering volume %s, got status code %d", id, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// ExecuteCommand runs a command in a task using the Nomad exec API
+func (n *NomadImpl) ExecuteCommand(job, group, task string, command []string, writer io.Writer, timeout time.Duration) (int, error) {
+	allocID, err := n.findTaskAllocation(job, group, task)
+	if err != nil {
+		return -1, err
+	}
+
+	cmd, err := json.Marshal(command)
+	if err != nil {
+		return -1, fmt.Errorf("unable to serialize command: %w", err)
+	}
+
+	// the exec API is a websocket on the same address as the HTTP API
+	addr := strings.Replace(n.address, "http", "ws", 1)
+	u := fmt.Sprintf(
+		"%s:%d/v1/client/allocation/%s/exec?task=%s&tty=false&command=%s",
+		addr, n.port, allocID, url.QueryEscape(task), url.QueryEscape(string(cmd)),
+	)
+
+	n.l.Debug("Executing command in Nomad task", "job", job, "group", group, "task", task, "allocation", allocID)
+
+	conn, _, err := websocket.DefaultDialer.Dial(u, nil)
+	if err != nil {
+		return -1, fmt.Errorf("unable to connect to exec API: %w", err)
+	}
+	defer conn.Close()
+
+	if timeout <= 0 {
+		timeout = 300 * time.Second
+	}
+
+	conn.SetReadDeadline(time.Now().Add(timeout))
+
+	// commands are not interactive, close stdin so that commands reading
+	// from it do not block
+	err = conn.WriteJSON(execFrame{Stdin: &execData{Close: true}})
+	if err != nil {
+		return -1, fmt.Errorf("unable to write to exec API: %w", err)
+	}
+
+	for {
+		f := execFrame{}
+		err := conn.ReadJSON(&f)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				return -1, fmt.Errorf("command timed out before completing")
+			}
+
+			return -1, fmt.Errorf("unable to read from exec API: %w", err)
+		}
+
+		if writer != nil {
+			if f.Stdout != nil {
+				writer.Write(f.Stdout.Data)
+			}
+
+			if f.Stderr != nil {
+				writer.Write(f.Stderr.Data)
+			}
+		}
+
+		if f.Exited {
+			if f.Result == nil {
+				return 0, nil
+			}
+
+			return f.Result.ExitCode, nil
+		}
+	}
+}
+
+// findTaskAllocation returns the ID of a running allocation for the job
+// which contains the task
+func (n *NomadImpl) findTaskAllocation(job, group, task string) (string, error) {
+	allocs, err := n.getJobAllocations(job)
+	if err != nil {
+		return "", err
+	}
+
+	for _, a := range allocs {
+		if status, _ := a["ClientStatus"].(string); status != "running" {
+			continue
+		}
+
+		if tg, _ := a["TaskGroup"].(string); group != "" && tg != group {
+			continue
+		}
+
+		if states, ok := a["TaskStates"].(map[string]interface{}); ok {
+			if _, ok := states[task]; !ok {
+				continue
+			}
+		}
+
+		id, _ := a["ID"].(string)
+		return id, nil
+	}
+
+	return "", fmt.Errorf("unable to find a running allocation for job %s with task %s", job, task)
+}
+
 func (n *NomadImpl) getJobAllocations(job string) ([]map[string]interface{}, error) {
 	// get the allocations for the job
 	r, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s:%d/v1/job/%s/allocations", n.address, n.port, job), nil)
@@ -435,6 +909,63 @@ func (n *NomadImpl) getJobID(file string) (string, error) {
 	return jobMap["ID"].(string), nil
 }
 
+type allocationStub struct {
+	ID           string
+	JobID        string
+	TaskGroup    string
+	ClientStatus string
+	TaskStates   map[string]interface{}
+}
+
+type nodeStub struct {
+	ID     string
+	Status string
+}
+
+type node struct {
+	ID          string
+	HostVolumes map[string]hostVolume
+}
+
+type hostVolume struct {
+	Path     string
+	ReadOnly bool
+}
+
+type csiPlugin struct {
+	ControllerRequired  bool
+	ControllersExpected int
+	ControllersHealthy  int
+	NodesExpected       int
+	NodesHealthy        int
+}
+
+type serviceRegistration struct {
+	ServiceName string
+	Address     string
+	Port        int
+	AllocID     string
+}
+
+// execFrame is a message sent to or received from the exec API, the data
+// is base64 encoded which is handled by the []byte type
+type execFrame struct {
+	Stdin  *execData   `json:"stdin,omitempty"`
+	Stdout *execData   `json:"stdout,omitempty"`
+	Stderr *execData   `json:"stderr,omitempty"`
+	Exited bool        `json:"exited,omitempty"`
+	Result *execResult `json:"result,omitempty"`
+}
+
+type execData struct {
+	Data  []byte `json:"data,omitempty"`
+	Close bool   `json:"close,omitempty"`
+}
+
+type execResult struct {
+	ExitCode int `json:"exit_code"`
+}
+
 type allocation struct {
 	ID        string
 	Job       job
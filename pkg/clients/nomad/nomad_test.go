@@ -3,13 +3,20 @@ package nomad
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
+
 	"github.com/jumppad-labs/jumppad/pkg/clients/http/mocks"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
@@ -45,14 +52,14 @@ func setupNomadTests(t *testing.T) (Nomad, string, *mocks.HTTP) {
 func TestNomadCreateReturnsErrorWhenFileNotExist(t *testing.T) {
 	c, _, _ := setupNomadTests(t)
 
-	err := c.Create([]string{"../../../examples/nomad/example.nomad"})
+	err := c.Create([]string{"../../../examples/nomad/example.nomad"}, nil)
 	assert.Error(t, err)
 }
 
 func TestNomadCreateValidatesConfig(t *testing.T) {
 	c, _, mh := setupNomadTests(t)
 
-	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"})
+	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"}, nil)
 	assert.NoError(t, err)
 
 	mh.AssertCalled(t, "Do", mock.Anything)
@@ -64,7 +71,7 @@ func TestNomadCreateValidateErrorReturnsError(t *testing.T) {
 	testutils.RemoveOn(&mh.Mock, "Do")
 	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("Boom"))
 
-	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"})
+	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"}, nil)
 	assert.Error(t, err)
 }
 
@@ -74,7 +81,7 @@ func TestNomadCreateValidateNot200ReturnsError(t *testing.T) {
 	testutils.RemoveOn(&mh.Mock, "Do")
 	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(&http.Response{StatusCode: http.StatusInternalServerError}, nil)
 
-	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"})
+	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"}, nil)
 	assert.Error(t, err)
 }
 
@@ -88,7 +95,7 @@ func TestNomadCreateValidateInvalidReturnsError(t *testing.T) {
 			Body:       io.NopCloser(bytes.NewBufferString("oops")),
 		}, nil)
 
-	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"})
+	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"}, nil)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "oops")
 }
@@ -96,12 +103,28 @@ func TestNomadCreateValidateInvalidReturnsError(t *testing.T) {
 func TestNomadCreateSubmitsJob(t *testing.T) {
 	c, _, mh := setupNomadTests(t)
 
-	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"})
+	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"}, nil)
 	assert.NoError(t, err)
 
 	mh.AssertNumberOfCalls(t, "Do", 2)
 }
 
+func TestNomadCreateAddsMetaToJob(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"}, map[string]string{"team": "platform"})
+	assert.NoError(t, err)
+
+	r := mh.Calls[1].Arguments[0].(*http.Request)
+	body, _ := io.ReadAll(r.Body)
+
+	req := map[string]map[string]any{}
+	err = json.Unmarshal(body, &req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, map[string]any{"team": "platform"}, req["Job"]["Meta"])
+}
+
 func TestNomadCreateSubmitErrorReturnsError(t *testing.T) {
 	c, _, mh := setupNomadTests(t)
 
@@ -116,7 +139,7 @@ func TestNomadCreateSubmitErrorReturnsError(t *testing.T) {
 
 	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("Boom")).Once()
 
-	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"})
+	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"}, nil)
 	assert.Error(t, err)
 }
 
@@ -140,7 +163,7 @@ func TestNomadCreateSubmitNot200ReturnsError(t *testing.T) {
 		nil,
 	)
 
-	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"})
+	err := c.Create([]string{"../../../examples/nomad/app_config/example.nomad"}, nil)
 	assert.Error(t, err)
 }
 
@@ -432,6 +455,287 @@ func TestNomadEndpointsReturnsConnectEndpoints(t *testing.T) {
 	assert.Equal(t, "10.5.0.4:9090", e[0]["http"])
 }
 
+func TestNomadServiceAddressesReturnsAddresses(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(serviceResponse))),
+		},
+		nil,
+	)
+
+	a, err := c.ServiceAddresses("api")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"10.5.0.2:28862", "10.5.0.3:21337"}, a)
+
+	r := testutils.GetCalls(&mh.Mock, "Do")[0].Arguments[0].(*http.Request)
+	assert.Contains(t, r.URL.String(), "/v1/service/api")
+}
+
+func TestNomadServiceAddressesNot200ReturnsError(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewReader([]byte(""))),
+		},
+		nil,
+	)
+
+	_, err := c.ServiceAddresses("api")
+	assert.Error(t, err)
+}
+
+func TestNomadHostVolumeNodesCountsReadyNodesWithVolume(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.MatchedBy(func(r *http.Request) bool { return strings.HasSuffix(r.URL.String(), "/v1/nodes") })).Return(
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`[{"ID": "a", "Status": "ready"}, {"ID": "b", "Status": "ready"}, {"ID": "c", "Status": "down"}]`))),
+		},
+		nil,
+	)
+	mh.On("Do", mock.MatchedBy(func(r *http.Request) bool { return strings.HasSuffix(r.URL.String(), "/v1/node/a") })).Return(
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"ID": "a", "HostVolumes": {"postgres": {"Path": "/opt/postgres"}}}`))),
+		},
+		nil,
+	)
+	mh.On("Do", mock.MatchedBy(func(r *http.Request) bool { return strings.HasSuffix(r.URL.String(), "/v1/node/b") })).Return(
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"ID": "b", "HostVolumes": {}}`))),
+		},
+		nil,
+	)
+
+	count, err := c.HostVolumeNodes("postgres")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestNomadHostVolumeNodesNot200ReturnsError(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       io.NopCloser(bytes.NewReader([]byte(""))),
+		},
+		nil,
+	)
+
+	_, err := c.HostVolumeNodes("postgres")
+	assert.Error(t, err)
+}
+
+func TestNomadCSIPluginHealthyReturnsTrueWhenHealthy(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"ControllerRequired": true, "ControllersExpected": 1, "ControllersHealthy": 1, "NodesExpected": 2, "NodesHealthy": 2}`))),
+		},
+		nil,
+	)
+
+	ok, err := c.CSIPluginHealthy("hostpath")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	r := testutils.GetCalls(&mh.Mock, "Do")[0].Arguments[0].(*http.Request)
+	assert.Contains(t, r.URL.String(), "/v1/plugin/csi/hostpath")
+}
+
+func TestNomadCSIPluginHealthyReturnsFalseWhenControllerNotHealthy(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(`{"ControllerRequired": true, "ControllersExpected": 1, "ControllersHealthy": 0, "NodesExpected": 1, "NodesHealthy": 1}`))),
+		},
+		nil,
+	)
+
+	ok, err := c.CSIPluginHealthy("hostpath")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestNomadCSIPluginHealthyReturnsFalseWhenNotRegistered(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusNotFound,
+			Body:       io.NopCloser(bytes.NewReader([]byte(""))),
+		},
+		nil,
+	)
+
+	ok, err := c.CSIPluginHealthy("hostpath")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestNomadRegisterCSIVolumeWithExternalIDRegistersVolume(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	err := c.RegisterCSIVolume(CSIVolume{ID: "data", Name: "data", PluginID: "hostpath", ExternalID: "vol-123"})
+	assert.NoError(t, err)
+
+	r := testutils.GetCalls(&mh.Mock, "Do")[0].Arguments[0].(*http.Request)
+	assert.Equal(t, http.MethodPut, r.Method)
+	assert.Contains(t, r.URL.String(), "/v1/volume/csi/data")
+	assert.NotContains(t, r.URL.String(), "/create")
+
+	body := map[string][]CSIVolume{}
+	json.NewDecoder(r.Body).Decode(&body)
+	assert.Equal(t, "vol-123", body["Volumes"][0].ExternalID)
+}
+
+func TestNomadRegisterCSIVolumeWithoutExternalIDCreatesVolume(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	err := c.RegisterCSIVolume(CSIVolume{ID: "data", Name: "data", PluginID: "hostpath"})
+	assert.NoError(t, err)
+
+	r := testutils.GetCalls(&mh.Mock, "Do")[0].Arguments[0].(*http.Request)
+	assert.Contains(t, r.URL.String(), "/v1/volume/csi/data/create")
+}
+
+func TestNomadRegisterCSIVolumeNot200ReturnsError(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       io.NopCloser(bytes.NewReader([]byte("plugin not found"))),
+		},
+		nil,
+	)
+
+	err := c.RegisterCSIVolume(CSIVolume{ID: "data", Name: "data", PluginID: "hostpath"})
+	assert.ErrorContains(t, err, "plugin not found")
+}
+
+func TestNomadDeregisterCSIVolumeDeletesVolume(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	err := c.DeregisterCSIVolume("data", true)
+	assert.NoError(t, err)
+
+	r := testutils.GetCalls(&mh.Mock, "Do")[0].Arguments[0].(*http.Request)
+	assert.Equal(t, http.MethodDelete, r.Method)
+	assert.Contains(t, r.URL.String(), "/v1/volume/csi/data/delete")
+}
+
+func TestNomadRunningTasksReturnsRunningTasks(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(allocationsListResponse))),
+		},
+		nil,
+	)
+
+	tasks, err := c.RunningTasks()
+	assert.NoError(t, err)
+	assert.Equal(t, []TaskAllocation{{AllocationID: "da975cd1", Job: "example_1", Group: "fake_service", Task: "fake_service"}}, tasks)
+}
+
+func setupNomadExecServer(t *testing.T, c Nomad, exitCode int) *[]string {
+	requests := []string{}
+
+	up := websocket.Upgrader{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.String())
+
+		conn, err := up.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// wait for stdin to be closed
+		conn.ReadJSON(&execFrame{})
+
+		conn.WriteJSON(execFrame{Stdout: &execData{Data: []byte("hello ")}})
+		conn.WriteJSON(execFrame{Stderr: &execData{Data: []byte("world")}})
+		conn.WriteJSON(execFrame{Exited: true, Result: &execResult{ExitCode: exitCode}})
+	}))
+	t.Cleanup(ts.Close)
+
+	u, _ := url.Parse(ts.URL)
+	port, _ := strconv.Atoi(u.Port())
+	c.SetConfig("http://"+u.Hostname(), port, 1)
+
+	return &requests
+}
+
+func TestNomadExecuteCommandRunsInRunningAllocation(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(jobAllocationsResponse))),
+		},
+		nil,
+	)
+
+	requests := setupNomadExecServer(t, c, 3)
+	out := bytes.NewBuffer(nil)
+
+	code, err := c.ExecuteCommand("example_1", "fake_service", "fake_service", []string{"sh", "-c", "echo hello"}, out, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, code)
+	assert.Equal(t, "hello world", out.String())
+
+	assert.Len(t, *requests, 1)
+	assert.Contains(t, (*requests)[0], "/v1/client/allocation/da975cd1-8b04-6bce-9d5c-03e47353768c/exec")
+	assert.Contains(t, (*requests)[0], "task=fake_service")
+	assert.Contains(t, (*requests)[0], url.QueryEscape(`["sh","-c","echo hello"]`))
+}
+
+func TestNomadExecuteCommandErrorsWhenNoAllocationForGroup(t *testing.T) {
+	c, _, mh := setupNomadTests(t)
+
+	testutils.RemoveOn(&mh.Mock, "Do")
+	mh.On("Do", mock.Anything, mock.Anything, mock.Anything).Return(
+		&http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(jobAllocationsResponse))),
+		},
+		nil,
+	)
+
+	requests := setupNomadExecServer(t, c, 0)
+
+	_, err := c.ExecuteCommand("example_1", "missing", "fake_service", []string{"ls"}, nil, time.Second)
+	assert.Error(t, err)
+	assert.Len(t, *requests, 0)
+}
+
 var aliveResponse = `
 [
 	{
@@ -996,3 +1300,51 @@ var allocationsConnectResponse = `
   "ModifyTime": 1616397645647263000
 }
 `
+
+var serviceResponse = `
+[
+  {
+    "ID": "_nomad-task-da975cd1-api-http",
+    "ServiceName": "api",
+    "Namespace": "default",
+    "NodeID": "e92cfe74-1ba3-2248-cf89-18760af8c278",
+    "JobID": "example_1",
+    "AllocID": "da975cd1-8b04-6bce-9d5c-03e47353768c",
+    "Address": "10.5.0.2",
+    "Port": 28862
+  },
+  {
+    "ID": "_nomad-task-ab12cd34-api-http",
+    "ServiceName": "api",
+    "Namespace": "default",
+    "NodeID": "e92cfe74-1ba3-2248-cf89-18760af8c278",
+    "JobID": "example_1",
+    "AllocID": "ab12cd34-8b04-6bce-9d5c-03e47353768c",
+    "Address": "10.5.0.3",
+    "Port": 21337
+  }
+]
+`
+
+var allocationsListResponse = `
+[
+  {
+    "ID": "da975cd1",
+    "JobID": "example_1",
+    "TaskGroup": "fake_service",
+    "ClientStatus": "running",
+    "TaskStates": {
+      "fake_service": {"State": "running"}
+    }
+  },
+  {
+    "ID": "ab12cd34",
+    "JobID": "example_1",
+    "TaskGroup": "fake_service",
+    "ClientStatus": "complete",
+    "TaskStates": {
+      "fake_service": {"State": "dead"}
+    }
+  }
+]
+`
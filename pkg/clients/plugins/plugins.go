@@ -0,0 +1,265 @@
+// Package plugins runs executables that provide resource types which are not
+// built into jumppad.
+//
+// Every call to a plugin starts the executable with the name of the operation
+// as the only argument. Requests are written to stdin and responses read from
+// stdout as JSON, anything the plugin writes to stderr is written to the
+// jumppad log. The operations are:
+//
+//	schema  - returns the resource types and attributes the plugin provides
+//	create  - creates the resource, returns the output values
+//	destroy - destroys the resource
+//	refresh - refreshes a created resource, returns the output values
+//	changed - returns true if the resource has changed outside of jumppad
+//	lookup  - returns the ids of any objects created for the resource
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Operations that can be called on a plugin
+const (
+	OperationSchema  = "schema"
+	OperationCreate  = "create"
+	OperationDestroy = "destroy"
+	OperationRefresh = "refresh"
+	OperationChanged = "changed"
+	OperationLookup  = "lookup"
+)
+
+// Prefix is the file name prefix of plugin executables in the plugins
+// directory, e.g. jumppad-plugin-kafka
+const Prefix = "jumppad-plugin-"
+
+// Attribute types that can be declared in a plugin schema
+const (
+	AttributeString = "string"
+	AttributeNumber = "number"
+	AttributeBool   = "bool"
+	AttributeList   = "list"
+	AttributeMap    = "map"
+	AttributeAny    = "any"
+)
+
+// Attribute describes an attribute of the config for a plugin resource
+type Attribute struct {
+	Type        string `json:"type"`
+	Required    bool   `json:"required,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Schema describes a resource type provided by a plugin
+type Schema struct {
+	Description string               `json:"description,omitempty"`
+	Attributes  map[string]Attribute `json:"attributes"`
+}
+
+// SchemaResponse is returned by the schema operation
+type SchemaResponse struct {
+	Types map[string]Schema `json:"types"`
+}
+
+// Request is sent to a plugin for every resource operation
+type Request struct {
+	Type   string                 `json:"type"`
+	ID     string                 `json:"id"`
+	Name   string                 `json:"name"`
+	Config map[string]interface{} `json:"config,omitempty"`
+	Output map[string]interface{} `json:"output,omitempty"`
+	Force  bool                   `json:"force,omitempty"`
+}
+
+// Response is returned by a plugin for every resource operation, when Error
+// is set the operation has failed
+type Response struct {
+	Output  map[string]interface{} `json:"output,omitempty"`
+	Changed bool                   `json:"changed,omitempty"`
+	IDs     []string               `json:"ids,omitempty"`
+	Error   string                 `json:"error,omitempty"`
+}
+
+// Plugin is an executable that provides one or more resource types
+type Plugin struct {
+	Name  string
+	Path  string
+	Types map[string]Schema
+}
+
+var registry = map[string]*Plugin{}
+var registryMutex = sync.RWMutex{}
+
+// Register makes the types provided by the plugin available to Lookup
+func Register(p *Plugin) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	for t := range p.Types {
+		registry[t] = p
+	}
+}
+
+// Lookup returns the plugin that provides the resource type t
+func Lookup(t string) (*Plugin, bool) {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	p, ok := registry[t]
+	return p, ok
+}
+
+// Discover finds the executables in dir whose names start with Prefix and
+// loads their schemas, a missing directory is not an error
+func Discover(ctx context.Context, dir string, stderr io.Writer) ([]*Plugin, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read plugins directory %s: %s", dir, err)
+	}
+
+	plugins := []*Plugin{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), Prefix) {
+			continue
+		}
+
+		p, err := Load(ctx, filepath.Join(dir, e.Name()), stderr)
+		if err != nil {
+			return nil, err
+		}
+
+		plugins = append(plugins, p)
+	}
+
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+
+	return plugins, nil
+}
+
+// Load runs the schema operation for the plugin executable at path
+func Load(ctx context.Context, path string, stderr io.Writer) (*Plugin, error) {
+	name := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), Prefix), filepath.Ext(path))
+	p := &Plugin{Name: name, Path: path}
+
+	sr := &SchemaResponse{}
+	err := p.run(ctx, OperationSchema, nil, sr, stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sr.Types) == 0 {
+		return nil, fmt.Errorf("plugin %s does not provide any resource types", name)
+	}
+
+	p.Types = sr.Types
+
+	return p, nil
+}
+
+// Call runs a resource operation, an error is returned if the plugin exits
+// with a non zero code or sets the error in the response
+func (p *Plugin) Call(ctx context.Context, operation string, req Request, stderr io.Writer) (*Response, error) {
+	resp := &Response{}
+
+	err := p.run(ctx, operation, req, resp, stderr)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("plugin %s returned an error for %s: %s", p.Name, operation, resp.Error)
+	}
+
+	return resp, nil
+}
+
+func (p *Plugin) run(ctx context.Context, operation string, req interface{}, resp interface{}, stderr io.Writer) error {
+	in := []byte{}
+	if req != nil {
+		var err error
+		in, err = json.Marshal(req)
+		if err != nil {
+			return fmt.Errorf("unable to encode request for plugin %s: %s", p.Name, err)
+		}
+	}
+
+	out := &bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, p.Path, operation)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = out
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return fmt.Errorf("plugin %s failed to run %s: %s", p.Name, operation, err)
+	}
+
+	err = json.Unmarshal(out.Bytes(), resp)
+	if err != nil {
+		return fmt.Errorf("unable to decode response from plugin %s for %s: %s", p.Name, operation, err)
+	}
+
+	return nil
+}
+
+// Validate checks that config contains the required attributes of the
+// schema, that there are no unknown attributes and that the values have the
+// declared types
+func (s Schema) Validate(config map[string]interface{}) error {
+	names := []string{}
+	for n := range s.Attributes {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		if _, ok := config[n]; !ok && s.Attributes[n].Required {
+			return fmt.Errorf("attribute %s is required", n)
+		}
+	}
+
+	for k, v := range config {
+		a, ok := s.Attributes[k]
+		if !ok {
+			return fmt.Errorf("attribute %s is not supported", k)
+		}
+
+		if v == nil {
+			continue
+		}
+
+		valid := true
+		switch a.Type {
+		case AttributeString:
+			_, valid = v.(string)
+		case AttributeNumber:
+			_, valid = v.(float64)
+		case AttributeBool:
+			_, valid = v.(bool)
+		case AttributeList:
+			_, valid = v.([]interface{})
+		case AttributeMap:
+			_, valid = v.(map[string]interface{})
+		}
+
+		if !valid {
+			return fmt.Errorf("attribute %s must be of type %s", k, a.Type)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testPlugin = `#!/bin/sh
+echo "running $1" >&2
+
+case "$1" in
+schema)
+  echo '{"types":{"kafka_cluster":{"attributes":{"brokers":{"type":"number","required":true},"version":{"type":"string"}}}}}'
+  ;;
+create)
+  cat > /dev/null
+  echo '{"output":{"bootstrap":"localhost:9092"}}'
+  ;;
+destroy)
+  echo '{"error":"cluster is locked"}'
+  ;;
+*)
+  exit 1
+  ;;
+esac
+`
+
+func setupPlugin(t *testing.T, name, script string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin tests require a POSIX shell")
+	}
+
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0755)
+	require.NoError(t, err)
+
+	return dir
+}
+
+func TestDiscoverLoadsPluginSchemas(t *testing.T) {
+	dir := setupPlugin(t, Prefix+"kafka", testPlugin)
+	os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a plugin"), 0644)
+
+	stderr := &bytes.Buffer{}
+	p, err := Discover(context.Background(), dir, stderr)
+	require.NoError(t, err)
+
+	require.Len(t, p, 1)
+	require.Equal(t, "kafka", p[0].Name)
+	require.Contains(t, p[0].Types, "kafka_cluster")
+	require.True(t, p[0].Types["kafka_cluster"].Attributes["brokers"].Required)
+	require.Contains(t, stderr.String(), "running schema")
+}
+
+func TestDiscoverWithMissingDirectoryReturnsNoPlugins(t *testing.T) {
+	p, err := Discover(context.Background(), filepath.Join(t.TempDir(), "missing"), nil)
+	require.NoError(t, err)
+	require.Empty(t, p)
+}
+
+func TestLoadWithInvalidSchemaReturnsError(t *testing.T) {
+	dir := setupPlugin(t, Prefix+"broken", "#!/bin/sh\necho 'not json'\n")
+
+	_, err := Load(context.Background(), filepath.Join(dir, Prefix+"broken"), nil)
+	require.ErrorContains(t, err, "unable to decode response from plugin broken")
+}
+
+func TestCallReturnsOutput(t *testing.T) {
+	dir := setupPlugin(t, Prefix+"kafka", testPlugin)
+
+	p, err := Load(context.Background(), filepath.Join(dir, Prefix+"kafka"), nil)
+	require.NoError(t, err)
+
+	resp, err := p.Call(context.Background(), OperationCreate, Request{Type: "kafka_cluster", ID: "resource.kafka_cluster.main"}, nil)
+	require.NoError(t, err)
+	require.Equal(t, "localhost:9092", resp.Output["bootstrap"])
+}
+
+func TestCallWithErrorResponseReturnsError(t *testing.T) {
+	dir := setupPlugin(t, Prefix+"kafka", testPlugin)
+
+	p, err := Load(context.Background(), filepath.Join(dir, Prefix+"kafka"), nil)
+	require.NoError(t, err)
+
+	_, err = p.Call(context.Background(), OperationDestroy, Request{Type: "kafka_cluster"}, nil)
+	require.ErrorContains(t, err, "cluster is locked")
+}
+
+func TestCallWithNonZeroExitReturnsError(t *testing.T) {
+	dir := setupPlugin(t, Prefix+"kafka", testPlugin)
+
+	p, err := Load(context.Background(), filepath.Join(dir, Prefix+"kafka"), nil)
+	require.NoError(t, err)
+
+	_, err = p.Call(context.Background(), OperationLookup, Request{Type: "kafka_cluster"}, nil)
+	require.ErrorContains(t, err, "failed to run lookup")
+}
+
+func TestRegisterMakesTypesAvailableToLookup(t *testing.T) {
+	Register(&Plugin{Name: "test", Types: map[string]Schema{"test_type": {}}})
+
+	p, ok := Lookup("test_type")
+	require.True(t, ok)
+	require.Equal(t, "test", p.Name)
+}
+
+func TestValidateWithValidConfigReturnsNoError(t *testing.T) {
+	s := Schema{Attributes: map[string]Attribute{
+		"brokers": {Type: AttributeNumber, Required: true},
+		"tags":    {Type: AttributeList},
+		"extra":   {Type: AttributeAny},
+	}}
+
+	err := s.Validate(map[string]interface{}{"brokers": float64(3), "tags": []interface{}{"a"}, "extra": "x"})
+	require.NoError(t, err)
+}
+
+func TestValidateWithMissingRequiredAttributeReturnsError(t *testing.T) {
+	s := Schema{Attributes: map[string]Attribute{"brokers": {Type: AttributeNumber, Required: true}}}
+
+	err := s.Validate(map[string]interface{}{})
+	require.ErrorContains(t, err, "attribute brokers is required")
+}
+
+func TestValidateWithUnknownAttributeReturnsError(t *testing.T) {
+	s := Schema{Attributes: map[string]Attribute{"brokers": {Type: AttributeNumber}}}
+
+	err := s.Validate(map[string]interface{}{"replicas": float64(1)})
+	require.ErrorContains(t, err, "attribute replicas is not supported")
+}
+
+func TestValidateWithWrongTypeReturnsError(t *testing.T) {
+	s := Schema{Attributes: map[string]Attribute{"brokers": {Type: AttributeNumber}}}
+
+	err := s.Validate(map[string]interface{}{"brokers": "three"})
+	require.ErrorContains(t, err, "attribute brokers must be of type number")
+}
@@ -0,0 +1,127 @@
+package progress
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileStore stores progress as files in a directory
+type FileStore struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// NewFileStore creates a store that writes progress to the given directory
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Tasks returns the progress for all tasks keyed by task ID
+func (f *FileStore) Tasks() (map[string]Task, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.readTasks()
+}
+
+// SetTask stores the progress for a task
+func (f *FileStore) SetTask(t Task) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	tasks, err := f.readTasks()
+	if err != nil {
+		return err
+	}
+
+	tasks[t.ID] = t
+
+	d, err := json.MarshalIndent(tasks, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return f.write(f.tasksPath(), d)
+}
+
+// History returns the terminal output for the given terminal
+func (f *FileStore) History(terminal string) ([]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	d, err := os.ReadFile(f.historyPath(terminal))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	return d, err
+}
+
+// SetHistory stores the terminal output for the given terminal
+func (f *FileStore) SetHistory(terminal string, data []byte) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return f.write(f.historyPath(terminal), trimHistory(data))
+}
+
+// Reset removes all progress
+func (f *FileStore) Reset() error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return os.RemoveAll(f.path)
+}
+
+func (f *FileStore) readTasks() (map[string]Task, error) {
+	tasks := map[string]Task{}
+
+	d, err := os.ReadFile(f.tasksPath())
+	if os.IsNotExist(err) {
+		return tasks, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(d, &tasks)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read progress from %s: %w", f.tasksPath(), err)
+	}
+
+	return tasks, nil
+}
+
+// write replaces the file by writing to a temporary file and renaming so
+// that progress is not lost if the process is stopped part way through
+func (f *FileStore) write(path string, d []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp := path + ".tmp"
+	err = os.WriteFile(tmp, d, 0644)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+func (f *FileStore) tasksPath() string {
+	return filepath.Join(f.path, "tasks.json")
+}
+
+// historyPath returns a file name for the terminal, the terminal key can
+// contain characters that are not valid in file names
+func (f *FileStore) historyPath(terminal string) string {
+	h := sha256.Sum256([]byte(terminal))
+	return filepath.Join(f.path, "history", hex.EncodeToString(h[:8])+".log")
+}
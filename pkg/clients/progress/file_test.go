@@ -0,0 +1,63 @@
+package progress
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileStoreSetTaskPersistsProgress(t *testing.T) {
+	dir := t.TempDir()
+
+	err := NewFileStore(dir).SetTask(Task{ID: "resource.task.a", Status: "completed"})
+	require.NoError(t, err)
+
+	err = NewFileStore(dir).SetTask(Task{ID: "resource.task.b", Status: "failed"})
+	require.NoError(t, err)
+
+	tasks, err := NewFileStore(dir).Tasks()
+	require.NoError(t, err)
+
+	require.Len(t, tasks, 2)
+	require.Equal(t, "completed", tasks["resource.task.a"].Status)
+}
+
+func TestFileStoreTasksReturnsEmptyWhenNoProgress(t *testing.T) {
+	tasks, err := NewFileStore(t.TempDir()).Tasks()
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+}
+
+func TestFileStoreHistoryKeepsLastBytes(t *testing.T) {
+	s := NewFileStore(t.TempDir())
+
+	h, err := s.History("local")
+	require.NoError(t, err)
+	require.Nil(t, h)
+
+	data := append(bytes.Repeat([]byte("a"), MaxHistory), []byte("end")...)
+	err = s.SetHistory("local", data)
+	require.NoError(t, err)
+
+	h, err = s.History("local")
+	require.NoError(t, err)
+	require.Len(t, h, MaxHistory)
+	require.True(t, bytes.HasSuffix(h, []byte("end")))
+}
+
+func TestFileStoreResetRemovesProgress(t *testing.T) {
+	s := NewFileStore(t.TempDir())
+
+	require.NoError(t, s.SetTask(Task{ID: "resource.task.a"}))
+	require.NoError(t, s.SetHistory("local", []byte("ls")))
+	require.NoError(t, s.Reset())
+
+	tasks, err := s.Tasks()
+	require.NoError(t, err)
+	require.Empty(t, tasks)
+
+	h, err := s.History("local")
+	require.NoError(t, err)
+	require.Nil(t, h)
+}
@@ -0,0 +1,67 @@
+package progress
+
+import (
+	"maps"
+	"slices"
+	"sync"
+)
+
+// MemoryStore stores progress in memory, progress is lost when the process
+// exits
+type MemoryStore struct {
+	tasks   map[string]Task
+	history map[string][]byte
+	mutex   sync.Mutex
+}
+
+// NewMemoryStore creates a store that keeps progress in memory
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tasks: map[string]Task{}, history: map[string][]byte{}}
+}
+
+// Tasks returns the progress for all tasks keyed by task ID
+func (m *MemoryStore) Tasks() (map[string]Task, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return maps.Clone(m.tasks), nil
+}
+
+// SetTask stores the progress for a task
+func (m *MemoryStore) SetTask(t Task) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.tasks[t.ID] = t
+
+	return nil
+}
+
+// History returns the terminal output for the given terminal
+func (m *MemoryStore) History(terminal string) ([]byte, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return slices.Clone(m.history[terminal]), nil
+}
+
+// SetHistory stores the terminal output for the given terminal
+func (m *MemoryStore) SetHistory(terminal string, data []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.history[terminal] = slices.Clone(trimHistory(data))
+
+	return nil
+}
+
+// Reset removes all progress
+func (m *MemoryStore) Reset() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.tasks = map[string]Task{}
+	m.history = map[string][]byte{}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package progress
+
+import (
+	"path/filepath"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// MaxHistory is the maximum number of bytes of terminal output kept for
+// each terminal
+const MaxHistory = 64 * 1024
+
+// Task is the result of the most recent validation for a docs task
+type Task struct {
+	ID         string      `json:"id"`
+	Conditions []Condition `json:"conditions"`
+	Status     string      `json:"status"`
+}
+
+// Condition is the result of a condition or validation for a task
+type Condition struct {
+	ID       string   `json:"id"`
+	Messages []string `json:"messages"`
+	Status   string   `json:"status"`
+}
+
+// Store persists the progress of a learner so that a workshop can be
+// resumed after the resources have been recreated
+type Store interface {
+	// Tasks returns the progress for all tasks keyed by task ID
+	Tasks() (map[string]Task, error)
+	// SetTask stores the progress for a task
+	SetTask(Task) error
+
+	// History returns the terminal output for the given terminal
+	History(terminal string) ([]byte, error)
+	// SetHistory stores the terminal output for the given terminal, only the
+	// last MaxHistory bytes are kept
+	SetHistory(terminal string, data []byte) error
+
+	// Reset removes all progress
+	Reset() error
+}
+
+// DefaultPath returns the location that progress is stored, this is in the
+// state directory so that it is not removed by jumppad down
+func DefaultPath() string {
+	return filepath.Join(utils.StateDir(), "progress")
+}
+
+// trimHistory returns the last MaxHistory bytes of data
+func trimHistory(data []byte) []byte {
+	if len(data) > MaxHistory {
+		return data[len(data)-MaxHistory:]
+	}
+
+	return data
+}
@@ -0,0 +1,153 @@
+package registry
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// UsernameEnvVar and PasswordEnvVar allow the registry credentials to be set
+// from the environment, when not set the credentials are read from the
+// Docker config file
+const (
+	UsernameEnvVar = "JUMPPAD_REGISTRY_USERNAME"
+	PasswordEnvVar = "JUMPPAD_REGISTRY_PASSWORD"
+)
+
+var challengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// credentials returns the username and password for the registry, if no
+// credentials are found empty strings are returned
+func credentials(registry string) (string, string) {
+	if u := os.Getenv(UsernameEnvVar); u != "" {
+		return u, os.Getenv(PasswordEnvVar)
+	}
+
+	dir := os.Getenv("DOCKER_CONFIG")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", ""
+		}
+
+		dir = filepath.Join(home, ".docker")
+	}
+
+	d, err := os.ReadFile(filepath.Join(dir, "config.json"))
+	if err != nil {
+		return "", ""
+	}
+
+	cfg := struct {
+		Auths map[string]struct {
+			Auth string `json:"auth"`
+		} `json:"auths"`
+	}{}
+
+	if err := json.Unmarshal(d, &cfg); err != nil {
+		return "", ""
+	}
+
+	keys := []string{registry, "https://" + registry}
+	if registry == DefaultRegistry {
+		keys = append(keys, "https://index.docker.io/v1/", "index.docker.io")
+	}
+
+	for _, k := range keys {
+		a, ok := cfg.Auths[k]
+		if !ok {
+			continue
+		}
+
+		b, err := base64.StdEncoding.DecodeString(a.Auth)
+		if err != nil {
+			continue
+		}
+
+		if u, p, ok := strings.Cut(string(b), ":"); ok {
+			return u, p
+		}
+	}
+
+	return "", ""
+}
+
+// authorize returns the Authorization header that satisfies the
+// WWW-Authenticate challenge returned by the registry
+func (r *RegistryImpl) authorize(ctx context.Context, ref *Reference, challenge string) (string, error) {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	user, pass := credentials(ref.Registry)
+
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if user == "" {
+			return "", fmt.Errorf("registry %s requires authentication, set %s and %s or login with docker login", ref.Registry, UsernameEnvVar, PasswordEnvVar)
+		}
+
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(user+":"+pass)), nil
+	case "bearer":
+	default:
+		return "", fmt.Errorf("registry %s returned an unsupported authentication challenge %q", ref.Registry, challenge)
+	}
+
+	p := map[string]string{}
+	for _, m := range challengeParamRegexp.FindAllStringSubmatch(params, -1) {
+		p[m[1]] = m[2]
+	}
+
+	if p["realm"] == "" {
+		return "", fmt.Errorf("registry %s returned an authentication challenge without a realm", ref.Registry)
+	}
+
+	q := url.Values{}
+	if p["service"] != "" {
+		q.Set("service", p["service"])
+	}
+
+	if p["scope"] != "" {
+		q.Set("scope", p["scope"])
+	} else {
+		q.Set("scope", fmt.Sprintf("repository:%s:pull", ref.Repository))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p["realm"]+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	if user != "" {
+		req.SetBasicAuth(user, pass)
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to fetch token for registry %s: %w", ref.Registry, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to fetch token for registry %s, expected status 200, got %d", ref.Registry, resp.StatusCode)
+	}
+
+	t := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return "", fmt.Errorf("unable to decode token for registry %s: %w", ref.Registry, err)
+	}
+
+	if t.Token == "" {
+		t.Token = t.AccessToken
+	}
+
+	return "Bearer " + t.Token, nil
+}
@@ -0,0 +1,136 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Schemes are the URI schemes used to reference a blueprint stored in an
+// OCI registry, e.g. oci://ghcr.io/org/blueprint:v1.2.0
+var Schemes = []string{"oci://", "registry://"}
+
+// DefaultRegistry is used when a reference does not contain a registry host
+const DefaultRegistry = "docker.io"
+
+// DefaultTag is used when a reference does not contain a tag or digest
+const DefaultTag = "latest"
+
+var (
+	repositoryRegexp = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*$`)
+	tagRegexp        = regexp.MustCompile(`^[\w][\w.-]{0,127}$`)
+	digestRegexp     = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+)
+
+// Reference is a parsed reference to a blueprint in an OCI registry
+type Reference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// IsReference returns true when the uri references a blueprint stored in an
+// OCI registry
+func IsReference(uri string) bool {
+	for _, s := range Schemes {
+		if strings.HasPrefix(uri, s) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseReference parses a blueprint uri in the format
+// oci://[registry/]repository[:tag|@digest]
+//
+// When the registry is omitted the DefaultRegistry is used, when both the
+// tag and digest are omitted the DefaultTag is used
+func ParseReference(uri string) (*Reference, error) {
+	if !IsReference(uri) {
+		return nil, fmt.Errorf("invalid reference %s, must start with one of %s", uri, strings.Join(Schemes, ", "))
+	}
+
+	_, name, _ := strings.Cut(uri, "://")
+	ref := &Reference{Registry: DefaultRegistry}
+
+	// split the digest
+	if n, d, ok := strings.Cut(name, "@"); ok {
+		if !digestRegexp.MatchString(d) {
+			return nil, fmt.Errorf("invalid reference %s, digest %s is not a valid sha256 digest", uri, d)
+		}
+
+		name = n
+		ref.Digest = d
+	}
+
+	// the first part is the registry when it looks like a host name
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry = parts[0]
+		name = parts[1]
+	}
+
+	// split the tag, a colon after the last slash
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+
+		if !tagRegexp.MatchString(ref.Tag) {
+			return nil, fmt.Errorf("invalid reference %s, tag %s is not valid", uri, ref.Tag)
+		}
+	}
+
+	if !repositoryRegexp.MatchString(name) {
+		return nil, fmt.Errorf("invalid reference %s, repository %s is not valid", uri, name)
+	}
+
+	ref.Repository = name
+
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = DefaultTag
+	}
+
+	return ref, nil
+}
+
+// String returns the uri for the reference
+func (r *Reference) String() string {
+	s := fmt.Sprintf("%s%s/%s", Schemes[0], r.Registry, r.Repository)
+
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+
+	return s
+}
+
+// reference returns the digest or tag used to fetch the manifest
+func (r *Reference) reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+
+	return r.Tag
+}
+
+// endpoint returns the base url for the registry API, registries running on
+// the local machine are accessed over plain http
+func (r *Reference) endpoint() string {
+	host := r.Registry
+	if host == DefaultRegistry {
+		host = "registry-1.docker.io"
+	}
+
+	h := strings.Split(host, ":")[0]
+	if h == "localhost" || h == "127.0.0.1" {
+		return "http://" + host
+	}
+
+	return "https://" + host
+}
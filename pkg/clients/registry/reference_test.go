@@ -0,0 +1,68 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsReference(t *testing.T) {
+	require.True(t, IsReference("oci://org/blueprint:v1.2.0"))
+	require.True(t, IsReference("registry://org/blueprint:v1.2.0"))
+	require.False(t, IsReference("github.com/jumppad-labs/blueprints//consul"))
+	require.False(t, IsReference("./blueprint"))
+}
+
+func TestParseReferenceWithoutRegistryUsesDefault(t *testing.T) {
+	r, err := ParseReference("oci://org/blueprint:v1.2.0")
+	require.NoError(t, err)
+
+	require.Equal(t, DefaultRegistry, r.Registry)
+	require.Equal(t, "org/blueprint", r.Repository)
+	require.Equal(t, "v1.2.0", r.Tag)
+	require.Equal(t, "https://registry-1.docker.io", r.endpoint())
+}
+
+func TestParseReferenceWithRegistry(t *testing.T) {
+	r, err := ParseReference("registry://ghcr.io/org/team/blueprint:v1")
+	require.NoError(t, err)
+
+	require.Equal(t, "ghcr.io", r.Registry)
+	require.Equal(t, "org/team/blueprint", r.Repository)
+	require.Equal(t, "v1", r.Tag)
+	require.Equal(t, "https://ghcr.io", r.endpoint())
+	require.Equal(t, "oci://ghcr.io/org/team/blueprint:v1", r.String())
+}
+
+func TestParseReferenceWithLocalRegistryUsesHTTP(t *testing.T) {
+	r, err := ParseReference("oci://localhost:5000/blueprint")
+	require.NoError(t, err)
+
+	require.Equal(t, "localhost:5000", r.Registry)
+	require.Equal(t, "blueprint", r.Repository)
+	require.Equal(t, DefaultTag, r.Tag)
+	require.Equal(t, "http://localhost:5000", r.endpoint())
+}
+
+func TestParseReferenceWithDigest(t *testing.T) {
+	d := "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+
+	r, err := ParseReference("oci://ghcr.io/org/blueprint@" + d)
+	require.NoError(t, err)
+
+	require.Equal(t, "org/blueprint", r.Repository)
+	require.Equal(t, "", r.Tag)
+	require.Equal(t, d, r.reference())
+}
+
+func TestParseReferenceInvalidReturnsError(t *testing.T) {
+	for _, uri := range []string{
+		"github.com/org/blueprint",
+		"oci://org/Blueprint:v1",
+		"oci://org/blueprint:v1!",
+		"oci://org/blueprint@sha256:abc",
+	} {
+		_, err := ParseReference(uri)
+		require.Error(t, err, uri)
+	}
+}
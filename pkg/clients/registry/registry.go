@@ -0,0 +1,559 @@
+package registry
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	ctar "github.com/jumppad-labs/jumppad/pkg/clients/tar"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/pkg/utils/dirhash"
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const (
+	// ArtifactTypeBlueprint is the artifact type of a blueprint manifest
+	ArtifactTypeBlueprint = "application/vnd.jumppad.blueprint.v1"
+	// ArtifactTypeSignature is the artifact type of a blueprint signature manifest
+	ArtifactTypeSignature = "application/vnd.jumppad.blueprint.signature.v1"
+	// MediaTypeBlueprintLayer is the media type of the layer containing the blueprint files
+	MediaTypeBlueprintLayer = "application/vnd.jumppad.blueprint.layer.v1.tar+gzip"
+
+	// AnnotationHash is the annotation containing the dirhash of the blueprint files
+	AnnotationHash = "dev.jumppad.blueprint.hash"
+	// AnnotationSignature is the annotation containing the base64 encoded signature
+	// of the blueprint manifest digest
+	AnnotationSignature = "dev.jumppad.blueprint.signature"
+)
+
+// ErrNotFound is returned when a manifest does not exist in the registry
+var ErrNotFound = errors.New("not found")
+
+// Registry defines an interface for publishing blueprints to and fetching
+// blueprints from an OCI registry
+//
+//go:generate mockery --name Registry --filename registry.go
+type Registry interface {
+	// Push publishes the blueprint in dir to the registry uri, when key is not
+	// nil a signature for the blueprint is also pushed.
+	// Returns the digest of the blueprint manifest.
+	Push(ctx context.Context, dir, uri string, key crypto.Signer, ignore ...string) (string, error)
+
+	// Pull fetches the blueprint at uri and unpacks it into dst, when key is
+	// not nil the blueprint signature is verified before it is unpacked
+	Pull(ctx context.Context, uri, dst string, key crypto.PublicKey) error
+
+	// Resolve returns the manifest digest and the dirhash of the files for the
+	// blueprint at uri without downloading the files
+	Resolve(ctx context.Context, uri string) (string, string, error)
+}
+
+// RegistryImpl is a concrete implementation of the Registry interface using
+// the OCI distribution API
+type RegistryImpl struct {
+	client *http.Client
+	mutex  sync.Mutex
+	// auth caches the authorization header for each registry
+	auth map[string]string
+}
+
+// NewRegistry creates a new Registry
+func NewRegistry() *RegistryImpl {
+	return &RegistryImpl{
+		client: &http.Client{Transport: utils.HTTPTransport()},
+		auth:   map[string]string{},
+	}
+}
+
+// Push publishes the blueprint in dir to the registry uri
+func (r *RegistryImpl) Push(ctx context.Context, dir, uri string, key crypto.Signer, ignore ...string) (string, error) {
+	ref, err := ParseReference(uri)
+	if err != nil {
+		return "", err
+	}
+
+	if ref.Digest != "" {
+		return "", fmt.Errorf("unable to push to %s, blueprints must be pushed to a tag", uri)
+	}
+
+	files, err := dirhash.DirFilesWithIgnoreFile(dir, "", ignore...)
+	if err != nil {
+		return "", fmt.Errorf("unable to list blueprint files in %s: %w", dir, err)
+	}
+
+	if len(files) == 0 {
+		return "", fmt.Errorf("no blueprint files found in %s", dir)
+	}
+
+	hash, err := dirhash.Hash1(files, func(name string) (io.ReadCloser, error) {
+		return os.Open(filepath.Join(dir, name))
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to hash blueprint files: %w", err)
+	}
+
+	layer, err := archive(dir, files)
+	if err != nil {
+		return "", fmt.Errorf("unable to archive blueprint files: %w", err)
+	}
+
+	cfg, err := r.uploadBlob(ctx, ref, v1.MediaTypeEmptyJSON, v1.DescriptorEmptyJSON.Data)
+	if err != nil {
+		return "", err
+	}
+
+	ld, err := r.uploadBlob(ctx, ref, MediaTypeBlueprintLayer, layer)
+	if err != nil {
+		return "", err
+	}
+
+	abs, _ := filepath.Abs(dir)
+	ld.Annotations = map[string]string{v1.AnnotationTitle: filepath.Base(abs) + ".tar.gz"}
+
+	m := v1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    v1.MediaTypeImageManifest,
+		ArtifactType: ArtifactTypeBlueprint,
+		Config:       cfg,
+		Layers:       []v1.Descriptor{ld},
+		Annotations: map[string]string{
+			AnnotationHash:       hash,
+			v1.AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+			v1.AnnotationVersion: ref.Tag,
+		},
+	}
+
+	md, err := r.putManifest(ctx, ref, ref.Tag, m)
+	if err != nil {
+		return "", err
+	}
+
+	if key != nil {
+		err := r.pushSignature(ctx, ref, md, cfg, key)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return md.Digest.String(), nil
+}
+
+// Pull fetches the blueprint at uri and unpacks it into dst
+func (r *RegistryImpl) Pull(ctx context.Context, uri, dst string, key crypto.PublicKey) (err error) {
+	ref, err := ParseReference(uri)
+	if err != nil {
+		return err
+	}
+
+	m, md, err := r.getManifest(ctx, ref, ref.reference())
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("blueprint %s does not exist", uri)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if ref.Digest != "" && md.String() != ref.Digest {
+		return fmt.Errorf("digest for blueprint %s does not match, got %s", uri, md)
+	}
+
+	if m.ArtifactType != ArtifactTypeBlueprint || len(m.Layers) != 1 || m.Layers[0].MediaType != MediaTypeBlueprintLayer {
+		return fmt.Errorf("%s is not a jumppad blueprint", uri)
+	}
+
+	if key != nil {
+		err := r.verifySignature(ctx, ref, md, key)
+		if err != nil {
+			return fmt.Errorf("unable to verify signature for blueprint %s: %w", uri, err)
+		}
+	}
+
+	layer, err := r.getBlob(ctx, ref, m.Layers[0])
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(dst, os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("unable to create destination folder %s: %w", dst, err)
+	}
+
+	// do not leave a partial blueprint behind as it would be used by later runs
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dst)
+		}
+	}()
+
+	tg := &ctar.TarGz{}
+	err = tg.Extract(bytes.NewReader(layer), true, dst)
+	if err != nil {
+		return fmt.Errorf("unable to unpack blueprint %s: %w", uri, err)
+	}
+
+	hash, err := dirhash.HashDir(dst, "", dirhash.Hash1)
+	if err != nil {
+		return fmt.Errorf("unable to hash blueprint files: %w", err)
+	}
+
+	if hash != m.Annotations[AnnotationHash] {
+		return fmt.Errorf("hash for blueprint %s does not match, expected %s, got %s", uri, m.Annotations[AnnotationHash], hash)
+	}
+
+	return nil
+}
+
+// Resolve returns the manifest digest and dirhash of the blueprint at uri
+func (r *RegistryImpl) Resolve(ctx context.Context, uri string) (string, string, error) {
+	ref, err := ParseReference(uri)
+	if err != nil {
+		return "", "", err
+	}
+
+	m, md, err := r.getManifest(ctx, ref, ref.reference())
+	if errors.Is(err, ErrNotFound) {
+		return "", "", fmt.Errorf("blueprint %s does not exist", uri)
+	}
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if m.ArtifactType != ArtifactTypeBlueprint {
+		return "", "", fmt.Errorf("%s is not a jumppad blueprint", uri)
+	}
+
+	return md.String(), m.Annotations[AnnotationHash], nil
+}
+
+// pushSignature signs the manifest digest and pushes the signature as an
+// artifact referring to the blueprint manifest, the signature is tagged
+// sha256-<digest>.sig so that it can be found by registries that do not
+// support the referrers API
+func (r *RegistryImpl) pushSignature(ctx context.Context, ref *Reference, md, cfg v1.Descriptor, key crypto.Signer) error {
+	sig, err := sign(key, []byte(md.Digest.String()))
+	if err != nil {
+		return fmt.Errorf("unable to sign blueprint: %w", err)
+	}
+
+	m := v1.Manifest{
+		Versioned:    specs.Versioned{SchemaVersion: 2},
+		MediaType:    v1.MediaTypeImageManifest,
+		ArtifactType: ArtifactTypeSignature,
+		Config:       cfg,
+		Layers:       []v1.Descriptor{cfg},
+		Subject:      &md,
+		Annotations: map[string]string{
+			AnnotationSignature:  base64.StdEncoding.EncodeToString(sig),
+			v1.AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+
+	_, err = r.putManifest(ctx, ref, signatureTag(md.Digest), m)
+
+	return err
+}
+
+// verifySignature fetches the signature for the manifest digest and checks
+// it was signed by the private key for the public key
+func (r *RegistryImpl) verifySignature(ctx context.Context, ref *Reference, md digest.Digest, key crypto.PublicKey) error {
+	m, _, err := r.getManifest(ctx, ref, signatureTag(md))
+	if errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("blueprint is not signed")
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if m.ArtifactType != ArtifactTypeSignature || m.Subject == nil || m.Subject.Digest != md {
+		return fmt.Errorf("signature does not refer to blueprint %s", md)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(m.Annotations[AnnotationSignature])
+	if err != nil {
+		return fmt.Errorf("unable to decode signature: %w", err)
+	}
+
+	return Verify(key, []byte(md.String()), sig)
+}
+
+// signatureTag returns the tag for the signature of the given manifest digest
+func signatureTag(d digest.Digest) string {
+	return fmt.Sprintf("%s-%s.sig", d.Algorithm(), d.Encoded())
+}
+
+func (r *RegistryImpl) uploadBlob(ctx context.Context, ref *Reference, mediaType string, data []byte) (v1.Descriptor, error) {
+	d := v1.Descriptor{
+		MediaType: mediaType,
+		Digest:    digest.FromBytes(data),
+		Size:      int64(len(data)),
+	}
+
+	// skip the upload when the registry already has the blob
+	resp, err := r.do(ctx, ref, http.MethodHead, fmt.Sprintf("/v2/%s/blobs/%s", ref.Repository, d.Digest), nil, nil)
+	if err != nil {
+		return d, fmt.Errorf("unable to check blob %s: %w", d.Digest, err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return d, nil
+	}
+
+	resp, err = r.do(ctx, ref, http.MethodPost, fmt.Sprintf("/v2/%s/blobs/uploads/", ref.Repository), nil, nil)
+	if err != nil {
+		return d, fmt.Errorf("unable to start upload for blob %s: %w", d.Digest, err)
+	}
+
+	err = checkStatus(resp, http.StatusAccepted)
+	if err != nil {
+		return d, fmt.Errorf("unable to start upload for blob %s: %w", d.Digest, err)
+	}
+
+	loc := resp.Header.Get("Location")
+	if loc == "" {
+		return d, fmt.Errorf("unable to start upload for blob %s, registry did not return an upload location", d.Digest)
+	}
+
+	sep := "?"
+	if strings.Contains(loc, "?") {
+		sep = "&"
+	}
+
+	h := http.Header{}
+	h.Set("Content-Type", "application/octet-stream")
+
+	resp, err = r.do(ctx, ref, http.MethodPut, loc+sep+"digest="+url.QueryEscape(d.Digest.String()), h, data)
+	if err != nil {
+		return d, fmt.Errorf("unable to upload blob %s: %w", d.Digest, err)
+	}
+
+	err = checkStatus(resp, http.StatusCreated)
+	if err != nil {
+		return d, fmt.Errorf("unable to upload blob %s: %w", d.Digest, err)
+	}
+
+	return d, nil
+}
+
+func (r *RegistryImpl) getBlob(ctx context.Context, ref *Reference, d v1.Descriptor) ([]byte, error) {
+	resp, err := r.do(ctx, ref, http.MethodGet, fmt.Sprintf("/v2/%s/blobs/%s", ref.Repository, d.Digest), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch blob %s: %w", d.Digest, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch blob %s: %w", d.Digest, checkStatus(resp, http.StatusOK))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, d.Size+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read blob %s: %w", d.Digest, err)
+	}
+
+	if int64(len(data)) != d.Size || digest.FromBytes(data) != d.Digest {
+		return nil, fmt.Errorf("content of blob %s does not match its digest", d.Digest)
+	}
+
+	return data, nil
+}
+
+func (r *RegistryImpl) putManifest(ctx context.Context, ref *Reference, tag string, m v1.Manifest) (v1.Descriptor, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return v1.Descriptor{}, fmt.Errorf("unable to encode manifest: %w", err)
+	}
+
+	d := v1.Descriptor{
+		MediaType:    v1.MediaTypeImageManifest,
+		ArtifactType: m.ArtifactType,
+		Digest:       digest.FromBytes(data),
+		Size:         int64(len(data)),
+	}
+
+	h := http.Header{}
+	h.Set("Content-Type", v1.MediaTypeImageManifest)
+
+	resp, err := r.do(ctx, ref, http.MethodPut, fmt.Sprintf("/v2/%s/manifests/%s", ref.Repository, tag), h, data)
+	if err != nil {
+		return d, fmt.Errorf("unable to push manifest %s: %w", tag, err)
+	}
+
+	err = checkStatus(resp, http.StatusCreated)
+	if err != nil {
+		return d, fmt.Errorf("unable to push manifest %s: %w", tag, err)
+	}
+
+	return d, nil
+}
+
+func (r *RegistryImpl) getManifest(ctx context.Context, ref *Reference, reference string) (*v1.Manifest, digest.Digest, error) {
+	h := http.Header{}
+	h.Set("Accept", v1.MediaTypeImageManifest)
+
+	resp, err := r.do(ctx, ref, http.MethodGet, fmt.Sprintf("/v2/%s/manifests/%s", ref.Repository, reference), h, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to fetch manifest %s: %w", reference, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", ErrNotFound
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("unable to fetch manifest %s: %w", reference, checkStatus(resp, http.StatusOK))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read manifest %s: %w", reference, err)
+	}
+
+	m := &v1.Manifest{}
+	err = json.Unmarshal(data, m)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to decode manifest %s: %w", reference, err)
+	}
+
+	return m, digest.FromBytes(data), nil
+}
+
+// do sends a request to the registry, when the registry responds with an
+// authentication challenge the request is retried with the credentials
+func (r *RegistryImpl) do(ctx context.Context, ref *Reference, method, p string, header http.Header, body []byte) (*http.Response, error) {
+	u := p
+	if !strings.HasPrefix(p, "http://") && !strings.HasPrefix(p, "https://") {
+		u = ref.endpoint() + p
+	}
+
+	send := func(auth string) (*http.Response, error) {
+		req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+
+		for k, v := range header {
+			req.Header[k] = v
+		}
+
+		if auth != "" {
+			req.Header.Set("Authorization", auth)
+		}
+
+		return r.client.Do(req)
+	}
+
+	r.mutex.Lock()
+	auth := r.auth[ref.Registry]
+	r.mutex.Unlock()
+
+	resp, err := send(auth)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	auth, err = r.authorize(ctx, ref, resp.Header.Get("WWW-Authenticate"))
+	if err != nil {
+		return nil, err
+	}
+
+	r.mutex.Lock()
+	r.auth[ref.Registry] = auth
+	r.mutex.Unlock()
+
+	return send(auth)
+}
+
+// checkStatus closes the response body and returns an error containing the
+// registry error message when the status code is not the expected code
+func checkStatus(resp *http.Response, expected int) error {
+	defer resp.Body.Close()
+
+	if resp.StatusCode == expected {
+		return nil
+	}
+
+	b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+	return fmt.Errorf("expected status %d, got %d: %s", expected, resp.StatusCode, strings.TrimSpace(string(b)))
+}
+
+// archive creates a reproducible tar.gz archive of the files in dir, file
+// modification times and ownership are not recorded so that the same files
+// always produce the same digest
+func archive(dir string, files []string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+
+	dirs := map[string]bool{}
+
+	for _, f := range files {
+		// write the parent directories before the file
+		parents := []string{}
+		for d := path.Dir(f); d != "." && !dirs[d]; d = path.Dir(d) {
+			parents = append([]string{d}, parents...)
+			dirs[d] = true
+		}
+
+		for _, d := range parents {
+			err := tw.WriteHeader(&tar.Header{Typeflag: tar.TypeDir, Name: d + "/", Mode: 0755})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		fi, err := os.Stat(filepath.Join(dir, f))
+		if err != nil {
+			return nil, err
+		}
+
+		err = tw.WriteHeader(&tar.Header{Typeflag: tar.TypeReg, Name: f, Mode: int64(fi.Mode().Perm()), Size: fi.Size()})
+		if err != nil {
+			return nil, err
+		}
+
+		fd, err := os.Open(filepath.Join(dir, f))
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = io.Copy(tw, fd)
+		fd.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,293 @@
+package registry
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/require"
+)
+
+// testRegistry is a minimal in memory implementation of the OCI distribution API
+type testRegistry struct {
+	sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   int
+	password  string
+}
+
+func (tr *testRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	tr.Lock()
+	defer tr.Unlock()
+
+	if tr.password != "" {
+		if _, p, ok := r.BasicAuth(); !ok || p != tr.password {
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	p := strings.TrimPrefix(r.URL.Path, "/v2/")
+
+	switch {
+	case strings.Contains(p, "/blobs/uploads/") && r.Method == http.MethodPost:
+		tr.uploads++
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s%d", p, tr.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case strings.Contains(p, "/blobs/uploads/") && r.Method == http.MethodPut:
+		d, _ := io.ReadAll(r.Body)
+		if digest.FromBytes(d).String() != r.URL.Query().Get("digest") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		tr.blobs[r.URL.Query().Get("digest")] = d
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(p, "/blobs/"):
+		d, ok := tr.blobs[p[strings.LastIndex(p, "/")+1:]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write(d)
+	case strings.Contains(p, "/manifests/") && r.Method == http.MethodPut:
+		d, _ := io.ReadAll(r.Body)
+		tr.manifests[p] = d
+		tr.manifests[p[:strings.LastIndex(p, "/")+1]+digest.FromBytes(d).String()] = d
+		w.WriteHeader(http.StatusCreated)
+	case strings.Contains(p, "/manifests/"):
+		d, ok := tr.manifests[p]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write(d)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func setupRegistry(t *testing.T) (*RegistryImpl, *testRegistry, string, string) {
+	tr := &testRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+
+	s := httptest.NewServer(tr)
+	t.Cleanup(s.Close)
+
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "modules"), os.ModePerm)
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(`resource "network" "main" {}`), 0644)
+	os.WriteFile(filepath.Join(dir, "modules", "consul.hcl"), []byte(`resource "container" "consul" {}`), 0644)
+	os.MkdirAll(filepath.Join(dir, ".git"), os.ModePerm)
+	os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte(`ref: refs/heads/main`), 0644)
+
+	uri := fmt.Sprintf("oci://%s/org/blueprint:v1.2.0", strings.TrimPrefix(s.URL, "http://"))
+
+	return NewRegistry(), tr, dir, uri
+}
+
+func TestPushAndPullBlueprint(t *testing.T) {
+	r, _, dir, uri := setupRegistry(t)
+
+	d, err := r.Push(context.Background(), dir, uri, nil, "*/.git")
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(d, "sha256:"))
+
+	dst := filepath.Join(t.TempDir(), "blueprint")
+	err = r.Pull(context.Background(), uri, dst, nil)
+	require.NoError(t, err)
+
+	require.FileExists(t, filepath.Join(dst, "main.hcl"))
+	require.FileExists(t, filepath.Join(dst, "modules", "consul.hcl"))
+	require.NoDirExists(t, filepath.Join(dst, ".git"))
+}
+
+func TestPushDoesNotIncludeFilesInIgnoreFile(t *testing.T) {
+	r, _, dir, uri := setupRegistry(t)
+	os.WriteFile(filepath.Join(dir, ".jumppadignore"), []byte(".git/\nnode_modules/\n"), 0644)
+	os.MkdirAll(filepath.Join(dir, "node_modules", "module"), os.ModePerm)
+	os.WriteFile(filepath.Join(dir, "node_modules", "module", "index.js"), []byte(`module.exports = {}`), 0644)
+
+	_, err := r.Push(context.Background(), dir, uri, nil)
+	require.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "blueprint")
+	err = r.Pull(context.Background(), uri, dst, nil)
+	require.NoError(t, err)
+
+	require.FileExists(t, filepath.Join(dst, "main.hcl"))
+	require.NoDirExists(t, filepath.Join(dst, "node_modules"))
+	require.NoDirExists(t, filepath.Join(dst, ".git"))
+}
+
+func TestPushIsReproducible(t *testing.T) {
+	r, tr, dir, uri := setupRegistry(t)
+
+	_, err := r.Push(context.Background(), dir, uri, nil)
+	require.NoError(t, err)
+
+	blobs := len(tr.blobs)
+
+	_, err = r.Push(context.Background(), dir, uri, nil)
+	require.NoError(t, err)
+
+	require.Len(t, tr.blobs, blobs)
+}
+
+func TestPullByDigest(t *testing.T) {
+	r, _, dir, uri := setupRegistry(t)
+
+	d, err := r.Push(context.Background(), dir, uri, nil)
+	require.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "blueprint")
+	err = r.Pull(context.Background(), strings.TrimSuffix(uri, ":v1.2.0")+"@"+d, dst, nil)
+	require.NoError(t, err)
+
+	require.FileExists(t, filepath.Join(dst, "main.hcl"))
+}
+
+func TestResolveReturnsDigestAndHash(t *testing.T) {
+	r, _, dir, uri := setupRegistry(t)
+
+	d, err := r.Push(context.Background(), dir, uri, nil)
+	require.NoError(t, err)
+
+	rd, h, err := r.Resolve(context.Background(), uri)
+	require.NoError(t, err)
+	require.Equal(t, d, rd)
+	require.True(t, strings.HasPrefix(h, "h1:"))
+}
+
+func TestPullMissingBlueprintReturnsError(t *testing.T) {
+	r, _, _, uri := setupRegistry(t)
+
+	err := r.Pull(context.Background(), uri, filepath.Join(t.TempDir(), "blueprint"), nil)
+	require.ErrorContains(t, err, "does not exist")
+}
+
+func TestPullWithModifiedHashReturnsErrorAndRemovesFiles(t *testing.T) {
+	r, tr, dir, uri := setupRegistry(t)
+
+	_, err := r.Push(context.Background(), dir, uri, nil)
+	require.NoError(t, err)
+
+	// replace the hash in the tagged manifest
+	for k, m := range tr.manifests {
+		if strings.HasSuffix(k, "v1.2.0") {
+			tr.manifests[k] = []byte(strings.Replace(string(m), `"h1:`, `"h1:x`, 1))
+		}
+	}
+
+	dst := filepath.Join(t.TempDir(), "blueprint")
+	err = r.Pull(context.Background(), uri, dst, nil)
+	require.ErrorContains(t, err, "does not match")
+	require.NoDirExists(t, dst)
+}
+
+func TestPullVerifiesSignature(t *testing.T) {
+	r, _, dir, uri := setupRegistry(t)
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	_, err = r.Push(context.Background(), dir, uri, priv)
+	require.NoError(t, err)
+
+	err = r.Pull(context.Background(), uri, filepath.Join(t.TempDir(), "blueprint"), pub)
+	require.NoError(t, err)
+}
+
+func TestPullWithECDSASignature(t *testing.T) {
+	r, _, dir, uri := setupRegistry(t)
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = r.Push(context.Background(), dir, uri, priv)
+	require.NoError(t, err)
+
+	err = r.Pull(context.Background(), uri, filepath.Join(t.TempDir(), "blueprint"), priv.Public())
+	require.NoError(t, err)
+}
+
+func TestPullWithWrongKeyReturnsError(t *testing.T) {
+	r, _, dir, uri := setupRegistry(t)
+
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	other, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	_, err := r.Push(context.Background(), dir, uri, priv)
+	require.NoError(t, err)
+
+	dst := filepath.Join(t.TempDir(), "blueprint")
+	err = r.Pull(context.Background(), uri, dst, other)
+	require.ErrorContains(t, err, "invalid signature")
+	require.NoDirExists(t, dst)
+}
+
+func TestPullUnsignedWithKeyReturnsError(t *testing.T) {
+	r, _, dir, uri := setupRegistry(t)
+
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	_, err := r.Push(context.Background(), dir, uri, nil)
+	require.NoError(t, err)
+
+	err = r.Pull(context.Background(), uri, filepath.Join(t.TempDir(), "blueprint"), pub)
+	require.ErrorContains(t, err, "not signed")
+}
+
+func TestPushWithBasicAuth(t *testing.T) {
+	r, tr, dir, uri := setupRegistry(t)
+	tr.password = "secret"
+
+	_, err := r.Push(context.Background(), dir, uri, nil)
+	require.ErrorContains(t, err, "requires authentication")
+
+	t.Setenv(UsernameEnvVar, "admin")
+	t.Setenv(PasswordEnvVar, "secret")
+
+	_, err = r.Push(context.Background(), dir, uri, nil)
+	require.NoError(t, err)
+}
+
+func TestReadKeys(t *testing.T) {
+	dir := t.TempDir()
+
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	d, _ := x509.MarshalPKCS8PrivateKey(priv)
+	os.WriteFile(filepath.Join(dir, "key.pem"), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: d}), 0600)
+
+	d, _ = x509.MarshalPKIXPublicKey(priv.Public())
+	os.WriteFile(filepath.Join(dir, "key.pub"), pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: d}), 0644)
+
+	k, err := ReadPrivateKey(filepath.Join(dir, "key.pem"))
+	require.NoError(t, err)
+
+	pub, err := ReadPublicKey(filepath.Join(dir, "key.pub"))
+	require.NoError(t, err)
+
+	sig, err := sign(k, []byte("blueprint"))
+	require.NoError(t, err)
+	require.NoError(t, Verify(pub, []byte("blueprint"), sig))
+}
@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+)
+
+// ReadPrivateKey reads a PEM encoded ECDSA, Ed25519, or RSA private key used
+// to sign blueprints
+func ReadPrivateKey(path string) (crypto.Signer, error) {
+	b, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch b.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(b.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(b.Bytes)
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(b.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key %s: %w", path, err)
+	}
+
+	s, ok := k.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type in %s", path)
+	}
+
+	return s, nil
+}
+
+// ReadPublicKey reads a PEM encoded ECDSA, Ed25519, or RSA public key used
+// to verify blueprints
+func ReadPublicKey(path string) (crypto.PublicKey, error) {
+	b, err := readPEM(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(b.Bytes)
+	}
+
+	k, err := x509.ParsePKIXPublicKey(b.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse public key %s: %w", path, err)
+	}
+
+	return k, nil
+}
+
+func readPEM(path string) (*pem.Block, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ := pem.Decode(d)
+	if b == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+
+	return b, nil
+}
+
+// sign signs the data with the key, Ed25519 keys sign the data directly all
+// other keys sign the SHA-256 hash of the data
+func sign(key crypto.Signer, data []byte) ([]byte, error) {
+	if _, ok := key.(ed25519.PrivateKey); ok {
+		return key.Sign(rand.Reader, data, crypto.Hash(0))
+	}
+
+	h := sha256.Sum256(data)
+
+	return key.Sign(rand.Reader, h[:], crypto.SHA256)
+}
+
+// Verify checks the signature for the data was created by the private key
+// for the public key
+func Verify(key crypto.PublicKey, data, sig []byte) error {
+	h := sha256.Sum256(data)
+
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, data, sig) {
+			return fmt.Errorf("invalid signature")
+		}
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, h[:], sig) {
+			return fmt.Errorf("invalid signature")
+		}
+	case *rsa.PublicKey:
+		if err := rsa.VerifyPKCS1v15(k, crypto.SHA256, h[:], sig); err != nil {
+			return fmt.Errorf("invalid signature")
+		}
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+
+	return nil
+}
@@ -0,0 +1,447 @@
+package settings
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// supported container drivers
+const (
+	DriverDocker = "docker"
+	DriverPodman = "podman"
+)
+
+// variablesPrefix is the prefix for keys that set default variables
+// e.g. variables.version
+const variablesPrefix = "variables."
+
+// Keys are the settings that can be read and changed, default variables are
+// set using the variables. prefix followed by the name of the variable
+var Keys = []string{
+	"driver",
+	"socket",
+	"registry_mirrors",
+	"proxy.http",
+	"proxy.https",
+	"proxy.no_proxy",
+	"ca_bundle",
+	"telemetry",
+	"strict",
+}
+
+// Settings are the per user defaults for jumppad, they are read from
+// $HOME/.jumppad/config.hcl
+//
+//	driver           = "podman"
+//	socket           = "unix:///Users/nic/.colima/default/docker.sock"
+//	registry_mirrors = ["https://mirror.gcr.io"]
+//	ca_bundle        = "/etc/pki/corp-ca.pem"
+//	telemetry        = false
+//	strict           = true
+//
+//	proxy {
+//	  http     = "http://proxy.corp.com:3128"
+//	  https    = "http://proxy.corp.com:3128"
+//	  no_proxy = "localhost,.corp.com"
+//	}
+//
+//	variables = {
+//	  consul_version = "1.16.1"
+//	}
+type Settings struct {
+	// Driver is the container engine used to create resources, docker or
+	// podman, DOCKER_HOST takes precedence when set
+	Driver string `hcl:"driver,optional"`
+
+	// Socket is the address of the container engine API, DOCKER_HOST takes
+	// precedence when set. When neither is set the sockets of common
+	// runtimes such as Colima and Rancher Desktop are probed.
+	Socket string `hcl:"socket,optional"`
+
+	// RegistryMirrors are the registries that Kubernetes and Nomad clusters
+	// use to pull images instead of Docker Hub
+	RegistryMirrors []string `hcl:"registry_mirrors,optional"`
+
+	// CABundle is the path of a PEM encoded bundle of CA certificates that
+	// are trusted in addition to the system roots, e.g. the CA of a
+	// corporate proxy
+	CABundle string `hcl:"ca_bundle,optional"`
+
+	// Telemetry can be set to false to disable exporting traces
+	Telemetry *bool `hcl:"telemetry,optional"`
+
+	// Strict enables the strict checks of jumppad up by default, unknown
+	// attributes, unused variables, and unused outputs are errors
+	Strict bool `hcl:"strict,optional"`
+
+	// Variables are the default values for blueprint variables, variables
+	// set with --var or JUMPPAD_VAR_ environment variables take precedence
+	Variables map[string]string `hcl:"variables,optional"`
+
+	// Proxy is used for outbound connections, the standard proxy environment
+	// variables take precedence when set
+	Proxy *Proxy `hcl:"proxy,block"`
+}
+
+// Proxy defines the proxy servers used for outbound connections
+type Proxy struct {
+	HTTP    string `hcl:"http,optional"`
+	HTTPS   string `hcl:"https,optional"`
+	NoProxy string `hcl:"no_proxy,optional"`
+}
+
+// Load reads the settings from the file at path, empty settings are returned
+// when the file does not exist
+func Load(path string) (*Settings, error) {
+	s := &Settings{}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return s, nil
+	}
+
+	f, diags := hclparse.NewParser().ParseHCLFile(path)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("unable to parse settings file %s: %s", path, diags.Error())
+	}
+
+	diags = gohcl.DecodeBody(f.Body, &hcl.EvalContext{}, s)
+	if diags.HasErrors() {
+		return nil, fmt.Errorf("unable to parse settings file %s: %s", path, diags.Error())
+	}
+
+	return s, nil
+}
+
+// Save writes the settings to the file at path, settings that are not set
+// are not written
+func (s *Settings) Save(path string) error {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+
+	if s.Driver != "" {
+		body.SetAttributeValue("driver", cty.StringVal(s.Driver))
+	}
+
+	if s.Socket != "" {
+		body.SetAttributeValue("socket", cty.StringVal(s.Socket))
+	}
+
+	if len(s.RegistryMirrors) > 0 {
+		mirrors := []cty.Value{}
+		for _, m := range s.RegistryMirrors {
+			mirrors = append(mirrors, cty.StringVal(m))
+		}
+
+		body.SetAttributeValue("registry_mirrors", cty.ListVal(mirrors))
+	}
+
+	if s.CABundle != "" {
+		body.SetAttributeValue("ca_bundle", cty.StringVal(s.CABundle))
+	}
+
+	if s.Telemetry != nil {
+		body.SetAttributeValue("telemetry", cty.BoolVal(*s.Telemetry))
+	}
+
+	if s.Strict {
+		body.SetAttributeValue("strict", cty.True)
+	}
+
+	if len(s.Variables) > 0 {
+		vars := map[string]cty.Value{}
+		for k, v := range s.Variables {
+			vars[k] = cty.StringVal(v)
+		}
+
+		body.SetAttributeValue("variables", cty.ObjectVal(vars))
+	}
+
+	if s.Proxy != nil && *s.Proxy != (Proxy{}) {
+		body.AppendNewline()
+		pb := body.AppendNewBlock("proxy", nil).Body()
+
+		if s.Proxy.HTTP != "" {
+			pb.SetAttributeValue("http", cty.StringVal(s.Proxy.HTTP))
+		}
+
+		if s.Proxy.HTTPS != "" {
+			pb.SetAttributeValue("https", cty.StringVal(s.Proxy.HTTPS))
+		}
+
+		if s.Proxy.NoProxy != "" {
+			pb.SetAttributeValue("no_proxy", cty.StringVal(s.Proxy.NoProxy))
+		}
+	}
+
+	err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("unable to create settings folder: %s", err)
+	}
+
+	return os.WriteFile(path, f.Bytes(), 0644)
+}
+
+// Get returns the value of the setting with the given key, an empty string is
+// returned when the setting is not set
+func (s *Settings) Get(key string) (string, error) {
+	if name, ok := strings.CutPrefix(key, variablesPrefix); ok {
+		return s.Variables[name], nil
+	}
+
+	p := Proxy{}
+	if s.Proxy != nil {
+		p = *s.Proxy
+	}
+
+	switch key {
+	case "driver":
+		return s.Driver, nil
+	case "socket":
+		return s.Socket, nil
+	case "registry_mirrors":
+		return strings.Join(s.RegistryMirrors, ","), nil
+	case "ca_bundle":
+		return s.CABundle, nil
+	case "telemetry":
+		return strconv.FormatBool(s.TelemetryEnabled()), nil
+	case "strict":
+		return strconv.FormatBool(s.Strict), nil
+	case "proxy.http":
+		return p.HTTP, nil
+	case "proxy.https":
+		return p.HTTPS, nil
+	case "proxy.no_proxy":
+		return p.NoProxy, nil
+	}
+
+	return "", unknownKeyError(key)
+}
+
+// Set validates and sets the value of the setting with the given key, an
+// empty value removes the setting. Registry mirrors are set as a comma
+// separated list
+func (s *Settings) Set(key, value string) error {
+	if name, ok := strings.CutPrefix(key, variablesPrefix); ok && name != "" {
+		if s.Variables == nil {
+			s.Variables = map[string]string{}
+		}
+
+		if value == "" {
+			delete(s.Variables, name)
+			return nil
+		}
+
+		s.Variables[name] = value
+		return nil
+	}
+
+	switch key {
+	case "driver":
+		if value != "" && value != DriverDocker && value != DriverPodman {
+			return fmt.Errorf("invalid driver %s, valid drivers are %s and %s", value, DriverDocker, DriverPodman)
+		}
+
+		s.Driver = value
+	case "socket":
+		if value == "" {
+			s.Socket = ""
+			return nil
+		}
+
+		// a path is a unix socket on the local machine
+		if !strings.Contains(value, "://") {
+			path, err := filepath.Abs(value)
+			if err != nil {
+				return fmt.Errorf("invalid path %s for socket: %s", value, err)
+			}
+
+			if _, err := os.Stat(path); err != nil {
+				return fmt.Errorf("socket %s does not exist", path)
+			}
+
+			value = "unix://" + path
+		}
+
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "unix" && u.Scheme != "npipe" && u.Scheme != "tcp" && u.Scheme != "ssh") {
+			return fmt.Errorf("invalid socket %s, the socket must be a path or an address e.g. unix:///var/run/docker.sock or tcp://localhost:2375", value)
+		}
+
+		s.Socket = value
+	case "registry_mirrors":
+		mirrors := []string{}
+		for _, m := range strings.Split(value, ",") {
+			if m = strings.TrimSpace(m); m == "" {
+				continue
+			}
+
+			if u, err := url.Parse(m); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return fmt.Errorf("invalid registry mirror %s, the mirror must be a URL e.g. https://mirror.gcr.io", m)
+			}
+
+			mirrors = append(mirrors, m)
+		}
+
+		s.RegistryMirrors = mirrors
+	case "ca_bundle":
+		if value == "" {
+			s.CABundle = ""
+			return nil
+		}
+
+		path, err := filepath.Abs(value)
+		if err != nil {
+			return fmt.Errorf("invalid path %s for ca_bundle: %s", value, err)
+		}
+
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("CA bundle %s does not exist", path)
+		}
+
+		s.CABundle = path
+	case "telemetry":
+		if value == "" {
+			s.Telemetry = nil
+			return nil
+		}
+
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %s for telemetry, must be true or false", value)
+		}
+
+		s.Telemetry = &b
+	case "strict":
+		if value == "" {
+			s.Strict = false
+			return nil
+		}
+
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %s for strict, must be true or false", value)
+		}
+
+		s.Strict = b
+	case "proxy.http", "proxy.https":
+		if value != "" {
+			if u, err := url.Parse(value); err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("invalid proxy address %s, the address must be a URL e.g. http://proxy:3128", value)
+			}
+		}
+
+		if key == "proxy.http" {
+			s.proxy().HTTP = value
+		} else {
+			s.proxy().HTTPS = value
+		}
+	case "proxy.no_proxy":
+		s.proxy().NoProxy = value
+	default:
+		return unknownKeyError(key)
+	}
+
+	return nil
+}
+
+// List returns the keys of the settings that have been set in alphabetical
+// order
+func (s *Settings) List() []string {
+	keys := []string{}
+	for _, k := range Keys {
+		// telemetry is enabled unless it has been set
+		if k == "telemetry" && s.Telemetry == nil {
+			continue
+		}
+
+		// strict is disabled unless it has been set
+		if k == "strict" && !s.Strict {
+			continue
+		}
+
+		if v, _ := s.Get(k); v != "" {
+			keys = append(keys, k)
+		}
+	}
+
+	for k := range s.Variables {
+		keys = append(keys, variablesPrefix+k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// TelemetryEnabled returns false when telemetry has been disabled
+func (s *Settings) TelemetryEnabled() bool {
+	return s.Telemetry == nil || *s.Telemetry
+}
+
+// Apply sets the environment variables for the settings, environment
+// variables that are already set are not changed so that they take
+// precedence over the settings
+func (s *Settings) Apply() {
+	switch {
+	case s.Socket != "":
+		setDefaultEnv("DOCKER_HOST", s.Socket)
+	case s.Driver == DriverPodman:
+		setDefaultEnv("DOCKER_HOST", podmanSocket())
+	case os.Getenv("DOCKER_HOST") == "":
+		// the Docker client only tries the default socket, runtimes such as
+		// Colima, Lima, and Rancher Desktop create their socket in the home
+		// folder
+		if sock, ok := DiscoverSocket(s.Driver); ok && sock.Path != defaultDockerSocket {
+			os.Setenv("DOCKER_HOST", sock.Host())
+		}
+	}
+
+	if s.Proxy != nil {
+		setDefaultEnv("HTTP_PROXY", s.Proxy.HTTP)
+		setDefaultEnv("HTTPS_PROXY", s.Proxy.HTTPS)
+		setDefaultEnv("NO_PROXY", s.Proxy.NoProxy)
+	}
+
+	setDefaultEnv(utils.CABundleEnvVar, s.CABundle)
+	setDefaultEnv(utils.RegistryMirrorsEnvVar, strings.Join(s.RegistryMirrors, ","))
+
+	if s.Strict {
+		setDefaultEnv(utils.StrictEnvVar, "true")
+	}
+
+	for k, v := range s.Variables {
+		setDefaultEnv("JUMPPAD_VAR_"+k, v)
+	}
+}
+
+func (s *Settings) proxy() *Proxy {
+	if s.Proxy == nil {
+		s.Proxy = &Proxy{}
+	}
+
+	return s.Proxy
+}
+
+func setDefaultEnv(key, value string) {
+	if value == "" || os.Getenv(key) != "" {
+		return
+	}
+
+	os.Setenv(key, value)
+}
+
+func unknownKeyError(key string) error {
+	return fmt.Errorf("unknown setting %s, valid settings are %s, and variables.<name>", key, strings.Join(Keys, ", "))
+}
@@ -0,0 +1,315 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+var testSettings = `
+driver           = "podman"
+registry_mirrors = ["https://mirror.gcr.io"]
+telemetry        = false
+
+variables = {
+  consul_version = "1.16.1"
+}
+
+proxy {
+  http     = "http://proxy.corp.com:3128"
+  no_proxy = "localhost"
+}
+`
+
+func setupSettings(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "config.hcl")
+	os.WriteFile(path, []byte(testSettings), 0644)
+
+	return path
+}
+
+func TestLoadReadsSettings(t *testing.T) {
+	s, err := Load(setupSettings(t))
+	require.NoError(t, err)
+
+	require.Equal(t, DriverPodman, s.Driver)
+	require.Equal(t, []string{"https://mirror.gcr.io"}, s.RegistryMirrors)
+	require.False(t, s.TelemetryEnabled())
+	require.Equal(t, "1.16.1", s.Variables["consul_version"])
+	require.Equal(t, "http://proxy.corp.com:3128", s.Proxy.HTTP)
+	require.Equal(t, "localhost", s.Proxy.NoProxy)
+}
+
+func TestLoadMissingFileReturnsEmptySettings(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "config.hcl"))
+	require.NoError(t, err)
+
+	require.Empty(t, s.Driver)
+	require.True(t, s.TelemetryEnabled())
+}
+
+func TestLoadInvalidFileReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.hcl")
+	os.WriteFile(path, []byte(`driver = `), 0644)
+
+	_, err := Load(path)
+	require.ErrorContains(t, err, "unable to parse settings file")
+}
+
+func TestSaveWritesSettingsThatCanBeLoaded(t *testing.T) {
+	s, _ := Load(setupSettings(t))
+	s.Set("proxy.https", "https://proxy.corp.com:3129")
+
+	path := filepath.Join(t.TempDir(), "jumppad", "config.hcl")
+	err := s.Save(path)
+	require.NoError(t, err)
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+
+	require.Equal(t, s, loaded)
+}
+
+func TestSaveDoesNotWriteEmptySettings(t *testing.T) {
+	s := &Settings{Proxy: &Proxy{}}
+
+	path := filepath.Join(t.TempDir(), "config.hcl")
+	err := s.Save(path)
+	require.NoError(t, err)
+
+	d, _ := os.ReadFile(path)
+	require.Empty(t, string(d))
+}
+
+func TestGetReturnsValues(t *testing.T) {
+	s, _ := Load(setupSettings(t))
+
+	v, err := s.Get("proxy.http")
+	require.NoError(t, err)
+	require.Equal(t, "http://proxy.corp.com:3128", v)
+
+	v, err = s.Get("variables.consul_version")
+	require.NoError(t, err)
+	require.Equal(t, "1.16.1", v)
+
+	v, err = s.Get("telemetry")
+	require.NoError(t, err)
+	require.Equal(t, "false", v)
+}
+
+func TestGetUnknownKeyReturnsError(t *testing.T) {
+	s := &Settings{}
+
+	_, err := s.Get("dirver")
+	require.ErrorContains(t, err, "unknown setting dirver")
+}
+
+func TestSetUpdatesValues(t *testing.T) {
+	s := &Settings{}
+
+	require.NoError(t, s.Set("driver", "docker"))
+	require.NoError(t, s.Set("registry_mirrors", "https://one.io, https://two.io"))
+	require.NoError(t, s.Set("telemetry", "false"))
+	require.NoError(t, s.Set("proxy.no_proxy", "localhost"))
+	require.NoError(t, s.Set("variables.version", "1.2.0"))
+
+	require.Equal(t, DriverDocker, s.Driver)
+	require.Equal(t, []string{"https://one.io", "https://two.io"}, s.RegistryMirrors)
+	require.False(t, s.TelemetryEnabled())
+	require.Equal(t, "localhost", s.Proxy.NoProxy)
+	require.Equal(t, "1.2.0", s.Variables["version"])
+}
+
+func TestSetEmptyValueRemovesSetting(t *testing.T) {
+	s, _ := Load(setupSettings(t))
+
+	require.NoError(t, s.Set("telemetry", ""))
+	require.NoError(t, s.Set("variables.consul_version", ""))
+
+	require.True(t, s.TelemetryEnabled())
+	require.NotContains(t, s.Variables, "consul_version")
+}
+
+func TestSetInvalidDriverReturnsError(t *testing.T) {
+	s := &Settings{}
+
+	err := s.Set("driver", "containerd")
+	require.ErrorContains(t, err, "invalid driver containerd")
+}
+
+func TestSetInvalidProxyReturnsError(t *testing.T) {
+	s := &Settings{}
+
+	err := s.Set("proxy.http", "proxy.corp.com")
+	require.ErrorContains(t, err, "invalid proxy address proxy.corp.com")
+}
+
+func TestSetInvalidRegistryMirrorReturnsError(t *testing.T) {
+	s := &Settings{}
+
+	err := s.Set("registry_mirrors", "https://one.io,mirror.gcr.io")
+	require.ErrorContains(t, err, "invalid registry mirror mirror.gcr.io")
+}
+
+func TestSetCABundleStoresAbsolutePath(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "ca.pem"), []byte(""), 0644)
+	t.Chdir(dir)
+
+	s := &Settings{}
+
+	err := s.Set("ca_bundle", "ca.pem")
+	require.NoError(t, err)
+
+	require.Equal(t, filepath.Join(dir, "ca.pem"), s.CABundle)
+}
+
+func TestSetMissingCABundleReturnsError(t *testing.T) {
+	s := &Settings{}
+
+	err := s.Set("ca_bundle", "/missing/ca.pem")
+	require.ErrorContains(t, err, "CA bundle /missing/ca.pem does not exist")
+}
+
+func TestSetSocketPathStoresAddress(t *testing.T) {
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "docker.sock"), []byte(""), 0644)
+	t.Chdir(dir)
+
+	s := &Settings{}
+
+	err := s.Set("socket", "docker.sock")
+	require.NoError(t, err)
+
+	require.Equal(t, "unix://"+filepath.Join(dir, "docker.sock"), s.Socket)
+}
+
+func TestSetSocketAddressStoresAddress(t *testing.T) {
+	s := &Settings{}
+
+	err := s.Set("socket", "tcp://localhost:2375")
+	require.NoError(t, err)
+
+	require.Equal(t, "tcp://localhost:2375", s.Socket)
+}
+
+func TestSetMissingSocketReturnsError(t *testing.T) {
+	s := &Settings{}
+
+	err := s.Set("socket", "/missing/docker.sock")
+	require.ErrorContains(t, err, "socket /missing/docker.sock does not exist")
+}
+
+func TestSetInvalidSocketSchemeReturnsError(t *testing.T) {
+	s := &Settings{}
+
+	err := s.Set("socket", "http://localhost:2375")
+	require.ErrorContains(t, err, "invalid socket http://localhost:2375")
+}
+
+func TestSetInvalidTelemetryReturnsError(t *testing.T) {
+	s := &Settings{}
+
+	err := s.Set("telemetry", "off")
+	require.ErrorContains(t, err, "invalid value off for telemetry")
+}
+
+func TestSetStrictEnablesStrict(t *testing.T) {
+	s := &Settings{}
+
+	require.NoError(t, s.Set("strict", "true"))
+	require.True(t, s.Strict)
+
+	v, err := s.Get("strict")
+	require.NoError(t, err)
+	require.Equal(t, "true", v)
+	require.Contains(t, s.List(), "strict")
+
+	require.NoError(t, s.Set("strict", ""))
+	require.False(t, s.Strict)
+	require.NotContains(t, s.List(), "strict")
+}
+
+func TestSetInvalidStrictReturnsError(t *testing.T) {
+	s := &Settings{}
+
+	err := s.Set("strict", "yes please")
+	require.ErrorContains(t, err, "invalid value yes please for strict")
+}
+
+func TestApplySetsStrict(t *testing.T) {
+	t.Setenv(utils.StrictEnvVar, "")
+
+	s := &Settings{Strict: true}
+	s.Apply()
+
+	require.True(t, utils.StrictMode())
+}
+
+func TestListReturnsSetKeys(t *testing.T) {
+	s, _ := Load(setupSettings(t))
+
+	require.Equal(t, []string{"driver", "proxy.http", "proxy.no_proxy", "registry_mirrors", "telemetry", "variables.consul_version"}, s.List())
+}
+
+func TestApplySetsEnvironment(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("JUMPPAD_VAR_consul_version", "")
+	t.Setenv(utils.CABundleEnvVar, "")
+	t.Setenv(utils.RegistryMirrorsEnvVar, "")
+
+	s, _ := Load(setupSettings(t))
+	s.CABundle = "/etc/pki/corp-ca.pem"
+	s.Apply()
+
+	require.Equal(t, "unix:///run/podman/podman.sock", os.Getenv("DOCKER_HOST"))
+	require.Equal(t, "http://proxy.corp.com:3128", os.Getenv("HTTP_PROXY"))
+	require.Equal(t, "", os.Getenv("HTTPS_PROXY"))
+	require.Equal(t, "localhost", os.Getenv("NO_PROXY"))
+	require.Equal(t, "1.16.1", os.Getenv("JUMPPAD_VAR_consul_version"))
+	require.Equal(t, "/etc/pki/corp-ca.pem", os.Getenv(utils.CABundleEnvVar))
+	require.Equal(t, "https://mirror.gcr.io", os.Getenv(utils.RegistryMirrorsEnvVar))
+}
+
+func TestApplyDoesNotOverrideEnvironment(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "tcp://remote:2375")
+	t.Setenv("HTTP_PROXY", "http://other:3128")
+	t.Setenv("NO_PROXY", "")
+	t.Setenv("JUMPPAD_VAR_consul_version", "1.17.0")
+
+	s, _ := Load(setupSettings(t))
+	s.Apply()
+
+	require.Equal(t, "tcp://remote:2375", os.Getenv("DOCKER_HOST"))
+	require.Equal(t, "http://other:3128", os.Getenv("HTTP_PROXY"))
+	require.Equal(t, "1.17.0", os.Getenv("JUMPPAD_VAR_consul_version"))
+}
+
+func TestApplySetsSocket(t *testing.T) {
+	t.Setenv("DOCKER_HOST", "")
+
+	s, _ := Load(setupSettings(t))
+	s.Socket = "unix:///home/nic/.colima/default/docker.sock"
+	s.Apply()
+
+	require.Equal(t, "unix:///home/nic/.colima/default/docker.sock", os.Getenv("DOCKER_HOST"))
+}
+
+func TestApplySetsDiscoveredSocket(t *testing.T) {
+	home := setupSocketHome(t)
+	path := listenSocket(t, filepath.Join(home, ".rd", "docker.sock"))
+	skipWhenDockerListening(t)
+	t.Setenv("DOCKER_HOST", "")
+
+	s := &Settings{}
+	s.Apply()
+
+	require.Equal(t, "unix://"+path, os.Getenv("DOCKER_HOST"))
+}
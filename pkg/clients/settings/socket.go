@@ -0,0 +1,138 @@
+package settings
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// defaultDockerSocket is the socket the Docker client uses when DOCKER_HOST
+// is not set
+const defaultDockerSocket = "/var/run/docker.sock"
+
+// defaultPodmanSocket is the socket for Podman running as root
+const defaultPodmanSocket = "/run/podman/podman.sock"
+
+// socketDialTimeout is how long to wait for a socket to accept a connection,
+// sockets are local so a short timeout is used to keep startup fast
+var socketDialTimeout = 250 * time.Millisecond
+
+// Socket is the API socket of a container runtime
+type Socket struct {
+	// Runtime is the name of the runtime that created the socket, e.g.
+	// Colima or Docker Desktop
+	Runtime string
+
+	// Path of the unix socket
+	Path string
+
+	// Driver is the API served by the socket, docker or podman
+	Driver string
+}
+
+// Host returns the address of the socket in the format used by DOCKER_HOST
+func (s Socket) Host() string {
+	return "unix://" + s.Path
+}
+
+// Sockets returns the locations of the sockets created by common container
+// runtimes in the order they are probed. Docker sockets are returned before
+// Podman sockets, only sockets for the given driver are returned when driver
+// is set.
+func Sockets(driver string) []Socket {
+	home := utils.HomeFolder()
+
+	sockets := []Socket{}
+
+	if driver != DriverPodman {
+		sockets = append(sockets,
+			Socket{Runtime: "Docker", Path: defaultDockerSocket},
+		)
+
+		if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+			sockets = append(sockets, Socket{Runtime: "Rootless Docker", Path: filepath.Join(dir, "docker.sock")})
+		}
+
+		colimaHome := filepath.Join(home, ".colima")
+		if dir := os.Getenv("COLIMA_HOME"); dir != "" {
+			colimaHome = dir
+		}
+
+		sockets = append(sockets,
+			Socket{Runtime: "Docker Desktop", Path: filepath.Join(home, ".docker", "run", "docker.sock")},
+			Socket{Runtime: "Docker Desktop", Path: filepath.Join(home, ".docker", "desktop", "docker.sock")},
+			Socket{Runtime: "Colima", Path: filepath.Join(colimaHome, "default", "docker.sock")},
+			Socket{Runtime: "Colima", Path: filepath.Join(home, ".config", "colima", "default", "docker.sock")},
+			Socket{Runtime: "Rancher Desktop", Path: filepath.Join(home, ".rd", "docker.sock")},
+			Socket{Runtime: "Lima", Path: filepath.Join(home, ".lima", "docker", "sock", "docker.sock")},
+			Socket{Runtime: "Lima", Path: filepath.Join(home, ".lima", "default", "sock", "docker.sock")},
+		)
+
+		for i := range sockets {
+			sockets[i].Driver = DriverDocker
+		}
+	}
+
+	if driver != DriverDocker {
+		if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+			sockets = append(sockets, Socket{Runtime: "Rootless Podman", Path: filepath.Join(dir, "podman", "podman.sock"), Driver: DriverPodman})
+		}
+
+		machine := filepath.Join(home, ".local", "share", "containers", "podman", "machine")
+
+		sockets = append(sockets,
+			Socket{Runtime: "Podman", Path: defaultPodmanSocket, Driver: DriverPodman},
+			Socket{Runtime: "Podman machine", Path: filepath.Join(machine, "podman.sock"), Driver: DriverPodman},
+			Socket{Runtime: "Podman machine", Path: filepath.Join(machine, "qemu", "podman.sock"), Driver: DriverPodman},
+		)
+	}
+
+	return sockets
+}
+
+// DiscoverSocket returns the first socket from Sockets that accepts a
+// connection. Runtimes such as Colima leave the socket behind when they are
+// stopped, so the socket must be listening not just exist. On Windows the
+// runtimes use named pipes and false is always returned.
+func DiscoverSocket(driver string) (Socket, bool) {
+	if runtime.GOOS == "windows" {
+		return Socket{}, false
+	}
+
+	for _, s := range Sockets(driver) {
+		if socketListening(s.Path) {
+			return s, true
+		}
+	}
+
+	return Socket{}, false
+}
+
+func socketListening(path string) bool {
+	if _, err := os.Stat(path); err != nil {
+		return false
+	}
+
+	c, err := net.DialTimeout("unix", path, socketDialTimeout)
+	if err != nil {
+		return false
+	}
+
+	c.Close()
+
+	return true
+}
+
+// podmanSocket returns the address of the Podman API socket, the socket for
+// rootless Podman is used when it is listening
+func podmanSocket() string {
+	if s, ok := DiscoverSocket(DriverPodman); ok {
+		return s.Host()
+	}
+
+	return "unix://" + defaultPodmanSocket
+}
@@ -0,0 +1,96 @@
+package settings
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSocketHome sets the home folder to a temp folder so that only the
+// sockets created by the test are found, the folder is created in /tmp as
+// the path of a unix socket is limited to around 100 characters
+func setupSocketHome(t *testing.T) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("container runtimes use named pipes on Windows")
+	}
+
+	home, err := os.MkdirTemp("/tmp", "jp")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(home) })
+
+	t.Setenv(utils.HomeEnvName(), home)
+	t.Setenv("XDG_RUNTIME_DIR", "")
+	t.Setenv("COLIMA_HOME", "")
+
+	return home
+}
+
+func listenSocket(t *testing.T, path string) string {
+	os.MkdirAll(filepath.Dir(path), os.ModePerm)
+
+	l, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	return path
+}
+
+func skipWhenDockerListening(t *testing.T) {
+	if socketListening(defaultDockerSocket) {
+		t.Skip("the default Docker socket is listening on this machine")
+	}
+}
+
+func TestSocketsReturnsDockerSocketsBeforePodman(t *testing.T) {
+	home := setupSocketHome(t)
+
+	s := Sockets("")
+
+	require.Equal(t, Socket{Runtime: "Docker", Path: defaultDockerSocket, Driver: DriverDocker}, s[0])
+	require.Contains(t, s, Socket{Runtime: "Colima", Path: filepath.Join(home, ".colima", "default", "docker.sock"), Driver: DriverDocker})
+	require.Equal(t, DriverPodman, s[len(s)-1].Driver)
+}
+
+func TestSocketsForPodmanReturnsOnlyPodmanSockets(t *testing.T) {
+	setupSocketHome(t)
+
+	for _, s := range Sockets(DriverPodman) {
+		require.Equal(t, DriverPodman, s.Driver)
+	}
+}
+
+func TestSocketsUsesColimaHome(t *testing.T) {
+	setupSocketHome(t)
+	t.Setenv("COLIMA_HOME", "/opt/colima")
+
+	require.Contains(t, Sockets(DriverDocker), Socket{Runtime: "Colima", Path: "/opt/colima/default/docker.sock", Driver: DriverDocker})
+}
+
+func TestDiscoverSocketReturnsListeningSocket(t *testing.T) {
+	home := setupSocketHome(t)
+	path := listenSocket(t, filepath.Join(home, ".colima", "default", "docker.sock"))
+
+	skipWhenDockerListening(t)
+
+	s, ok := DiscoverSocket(DriverDocker)
+	require.True(t, ok)
+	require.Equal(t, "Colima", s.Runtime)
+	require.Equal(t, "unix://"+path, s.Host())
+}
+
+func TestDiscoverSocketIgnoresStaleSocket(t *testing.T) {
+	home := setupSocketHome(t)
+
+	// a socket file that nothing is listening on
+	path := filepath.Join(home, ".colima", "default", "docker.sock")
+	os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	os.WriteFile(path, []byte(""), 0644)
+
+	s, _ := DiscoverSocket(DriverDocker)
+	require.NotEqual(t, path, s.Path)
+}
@@ -0,0 +1,439 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	dclient "github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// ImageRepository is the repository used for the images committed from
+// containers when creating a snapshot
+const ImageRepository = "snapshot.jumppad.dev"
+
+// manifestFile is the name of the file in the snapshot directory that
+// describes the snapshot
+const manifestFile = "snapshot.json"
+
+var validName = regexp.MustCompile(`^[a-z0-9][a-z0-9_.-]*$`)
+
+// Target is a container that is included in a snapshot
+type Target struct {
+	// Resource is the id of the resource that created the container
+	Resource string
+	// Container is the name of the container
+	Container string
+}
+
+// Snapshot describes a saved point in time of the containers in an
+// environment
+type Snapshot struct {
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+	// StateHash is the hash of the state file when the snapshot was created,
+	// restoring a snapshot for a different state can fail as the containers
+	// in the environment may have changed
+	StateHash  string      `json:"state_hash"`
+	Containers []Container `json:"containers"`
+}
+
+// Container is the snapshot of a single container
+type Container struct {
+	Resource string `json:"resource"`
+	Name     string `json:"name"`
+	// Image is the image committed from the container filesystem
+	Image   string   `json:"image"`
+	Volumes []Volume `json:"volumes,omitempty"`
+}
+
+// Volume is a volume mounted in a container that has been exported to the
+// snapshot directory as a tar archive
+type Volume struct {
+	Name        string `json:"name"`
+	Destination string `json:"destination"`
+	Archive     string `json:"archive"`
+}
+
+// Snapshots creates and restores snapshots of running containers
+//
+//go:generate mockery --name Snapshots --filename snapshots.go
+type Snapshots interface {
+	// Create commits the filesystem of each target container and exports
+	// its volumes
+	Create(ctx context.Context, name, stateHash string, targets []Target) (*Snapshot, error)
+	// Restore replaces the containers in the snapshot with containers
+	// created from the snapshot images and volumes
+	Restore(ctx context.Context, name string) (*Snapshot, error)
+	// Get returns the snapshot with the given name
+	Get(name string) (*Snapshot, error)
+	// List returns all snapshots ordered by the time they were created
+	List() ([]*Snapshot, error)
+	// Delete removes the snapshot images and volume archives
+	Delete(ctx context.Context, name string) error
+}
+
+// SnapshotsImpl is a concrete implementation of the Snapshots interface
+// that stores snapshot images in the local Docker engine and volume archives
+// in a directory
+type SnapshotsImpl struct {
+	docker dclient.Docker
+	log    logger.Logger
+	dir    string
+}
+
+// NewSnapshots creates a new Snapshots that stores snapshots in dir
+func NewSnapshots(d dclient.Docker, l logger.Logger, dir string) *SnapshotsImpl {
+	return &SnapshotsImpl{docker: d, log: l, dir: dir}
+}
+
+func (s *SnapshotsImpl) Create(ctx context.Context, name, stateHash string, targets []Target) (*Snapshot, error) {
+	if !validName.MatchString(name) {
+		return nil, fmt.Errorf("invalid snapshot name %s, names can only contain lower case letters, numbers, '.', '_' and '-'", name)
+	}
+
+	dir := filepath.Join(s.dir, name)
+	if _, err := os.Stat(dir); err == nil {
+		return nil, fmt.Errorf("snapshot %s already exists", name)
+	}
+
+	err := os.MkdirAll(dir, os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create snapshot directory: %s", err)
+	}
+
+	snap := &Snapshot{
+		Name:       name,
+		Created:    time.Now(),
+		StateHash:  stateHash,
+		Containers: []Container{},
+	}
+
+	for _, t := range targets {
+		c, err := s.createContainer(ctx, dir, name, t)
+		if c != nil {
+			snap.Containers = append(snap.Containers, *c)
+		}
+
+		if err != nil {
+			// remove anything that has already been created
+			s.remove(ctx, snap)
+			return nil, err
+		}
+	}
+
+	d, _ := json.MarshalIndent(snap, "", "  ")
+	err = os.WriteFile(filepath.Join(dir, manifestFile), d, 0644)
+	if err != nil {
+		s.remove(ctx, snap)
+		return nil, fmt.Errorf("unable to write snapshot: %s", err)
+	}
+
+	return snap, nil
+}
+
+func (s *SnapshotsImpl) createContainer(ctx context.Context, dir, name string, t Target) (*Container, error) {
+	s.log.Info("Creating snapshot", "ref", t.Resource, "container", t.Container)
+
+	ci, err := s.docker.ContainerInspect(ctx, t.Container)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find container %s: %s", t.Container, err)
+	}
+
+	c := &Container{
+		Resource: t.Resource,
+		Name:     t.Container,
+		Image:    imageReference(name, t.Container),
+		Volumes:  []Volume{},
+	}
+
+	// the container is paused while the commit runs so that the
+	// filesystem is consistent
+	_, err = s.docker.ContainerCommit(ctx, ci.ID, container.CommitOptions{
+		Reference: c.Image,
+		Comment:   fmt.Sprintf("jumppad snapshot %s of %s", name, t.Resource),
+		Pause:     true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to commit container %s: %s", t.Container, err)
+	}
+
+	// committing a container does not include the contents of volumes,
+	// these are exported separately
+	for i, m := range ci.Mounts {
+		if m.Type != mount.TypeVolume || m.Name == utils.FQDNVolumeName(utils.ImageVolumeName) {
+			continue
+		}
+
+		v := Volume{
+			Name:        m.Name,
+			Destination: m.Destination,
+			Archive:     fmt.Sprintf("%s.%d.tar", t.Container, i),
+		}
+
+		s.log.Debug("Exporting volume", "container", t.Container, "volume", m.Name, "destination", m.Destination)
+
+		err := s.exportVolume(ctx, ci.ID, m.Destination, filepath.Join(dir, v.Archive))
+		if err != nil {
+			return c, err
+		}
+
+		c.Volumes = append(c.Volumes, v)
+	}
+
+	return c, nil
+}
+
+func (s *SnapshotsImpl) exportVolume(ctx context.Context, id, src, dst string) error {
+	rc, _, err := s.docker.CopyFromContainer(ctx, id, src)
+	if err != nil {
+		return fmt.Errorf("unable to export volume %s: %s", src, err)
+	}
+	defer rc.Close()
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return fmt.Errorf("unable to create volume archive: %s", err)
+	}
+	defer f.Close()
+
+	_, err = io.Copy(f, rc)
+	if err != nil {
+		return fmt.Errorf("unable to export volume %s: %s", src, err)
+	}
+
+	return nil
+}
+
+func (s *SnapshotsImpl) Restore(ctx context.Context, name string) (*Snapshot, error) {
+	snap, err := s.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	// containers that share the network of another container must be
+	// created after that container, the old id of the container is replaced
+	// with the id of the restored container
+	containers := make([]Container, len(snap.Containers))
+	copy(containers, snap.Containers)
+
+	ids := map[string]string{}
+	infos := map[string]container.InspectResponse{}
+
+	for _, c := range containers {
+		ci, err := s.docker.ContainerInspect(ctx, c.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find container %s, run up to create the environment before restoring the snapshot: %s", c.Name, err)
+		}
+
+		infos[c.Name] = ci
+	}
+
+	sort.SliceStable(containers, func(i, j int) bool {
+		return !infos[containers[i].Name].HostConfig.NetworkMode.IsContainer() && infos[containers[j].Name].HostConfig.NetworkMode.IsContainer()
+	})
+
+	for _, c := range containers {
+		ci := infos[c.Name]
+
+		id, err := s.restoreContainer(ctx, filepath.Join(s.dir, name), c, ci, ids)
+		if err != nil {
+			return nil, err
+		}
+
+		ids[ci.ID] = id
+	}
+
+	return snap, nil
+}
+
+func (s *SnapshotsImpl) restoreContainer(ctx context.Context, dir string, c Container, ci container.InspectResponse, ids map[string]string) (string, error) {
+	s.log.Info("Restoring snapshot", "ref", c.Resource, "container", c.Name)
+
+	err := s.docker.ContainerRemove(ctx, ci.ID, container.RemoveOptions{Force: true, RemoveVolumes: true})
+	if err != nil {
+		return "", fmt.Errorf("unable to remove container %s: %s", c.Name, err)
+	}
+
+	// named volumes are not removed with the container, remove them so that
+	// the restored volume does not contain files created after the snapshot,
+	// volumes that are used by other containers can not be removed and are
+	// restored over the existing contents
+	for _, v := range c.Volumes {
+		err := s.docker.VolumeRemove(ctx, v.Name, true)
+		if err != nil {
+			s.log.Debug("Unable to remove volume", "volume", v.Name, "error", err)
+		}
+	}
+
+	cfg := ci.Config
+	cfg.Image = c.Image
+
+	hc := ci.HostConfig
+	nc := &network.NetworkingConfig{EndpointsConfig: map[string]*network.EndpointSettings{}}
+
+	if hc.NetworkMode.IsContainer() {
+		// the container shares the network of a container that has been
+		// restored and has a new id
+		if id, ok := ids[hc.NetworkMode.ConnectedContainer()]; ok {
+			hc.NetworkMode = container.NetworkMode("container:" + id)
+		}
+	} else if ci.NetworkSettings != nil {
+		nc.EndpointsConfig = endpoints(ci.NetworkSettings.Networks)
+	}
+
+	resp, err := s.docker.ContainerCreate(ctx, cfg, hc, nc, nil, strings.TrimPrefix(ci.Name, "/"))
+	if err != nil {
+		return "", fmt.Errorf("unable to create container %s: %s", c.Name, err)
+	}
+
+	for _, v := range c.Volumes {
+		err := s.importVolume(ctx, resp.ID, filepath.Join(dir, v.Archive), v.Destination)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = s.docker.ContainerStart(ctx, resp.ID, container.StartOptions{})
+	if err != nil {
+		return "", fmt.Errorf("unable to start container %s: %s", c.Name, err)
+	}
+
+	return resp.ID, nil
+}
+
+func (s *SnapshotsImpl) importVolume(ctx context.Context, id, src, dst string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("unable to open volume archive: %s", err)
+	}
+	defer f.Close()
+
+	// the archive contains the destination folder so it is extracted into
+	// the parent folder
+	err = s.docker.CopyToContainer(ctx, id, path.Dir(dst), f, container.CopyToContainerOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to restore volume %s: %s", dst, err)
+	}
+
+	return nil
+}
+
+func (s *SnapshotsImpl) Get(name string) (*Snapshot, error) {
+	d, err := os.ReadFile(filepath.Join(s.dir, name, manifestFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot %s does not exist", name)
+		}
+
+		return nil, fmt.Errorf("unable to read snapshot %s: %s", name, err)
+	}
+
+	snap := &Snapshot{}
+	err = json.Unmarshal(d, snap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse snapshot %s: %s", name, err)
+	}
+
+	return snap, nil
+}
+
+func (s *SnapshotsImpl) List() ([]*Snapshot, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read snapshots directory: %s", err)
+	}
+
+	snaps := []*Snapshot{}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+
+		snap, err := s.Get(e.Name())
+		if err != nil {
+			s.log.Debug("Ignoring invalid snapshot", "name", e.Name(), "error", err)
+			continue
+		}
+
+		snaps = append(snaps, snap)
+	}
+
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Created.Before(snaps[j].Created) })
+
+	return snaps, nil
+}
+
+func (s *SnapshotsImpl) Delete(ctx context.Context, name string) error {
+	snap, err := s.Get(name)
+	if err != nil {
+		return err
+	}
+
+	return s.remove(ctx, snap)
+}
+
+// remove deletes the images and the directory for the snapshot
+func (s *SnapshotsImpl) remove(ctx context.Context, snap *Snapshot) error {
+	for _, c := range snap.Containers {
+		_, err := s.docker.ImageRemove(ctx, c.Image, image.RemoveOptions{Force: true, PruneChildren: true})
+		if err != nil {
+			s.log.Debug("Unable to remove snapshot image", "image", c.Image, "error", err)
+		}
+	}
+
+	err := os.RemoveAll(filepath.Join(s.dir, snap.Name))
+	if err != nil {
+		return fmt.Errorf("unable to remove snapshot %s: %s", snap.Name, err)
+	}
+
+	return nil
+}
+
+// imageReference returns the image that the container filesystem is
+// committed to
+func imageReference(name, container string) string {
+	return fmt.Sprintf("%s/%s:%s", ImageRepository, name, container)
+}
+
+// endpoints returns the settings needed to connect a new container to the
+// same networks with the same addresses and aliases, runtime details such as
+// the endpoint id are not copied
+func endpoints(networks map[string]*network.EndpointSettings) map[string]*network.EndpointSettings {
+	eps := map[string]*network.EndpointSettings{}
+
+	for n, e := range networks {
+		if e == nil {
+			continue
+		}
+
+		eps[n] = &network.EndpointSettings{
+			IPAMConfig: e.IPAMConfig,
+			Links:      e.Links,
+			Aliases:    e.Aliases,
+			NetworkID:  e.NetworkID,
+			DriverOpts: e.DriverOpts,
+			MacAddress: e.MacAddress,
+		}
+	}
+
+	return eps
+}
@@ -0,0 +1,183 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func testContainer(id, name string) container.InspectResponse {
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:         id,
+			Name:       "/" + name,
+			HostConfig: &container.HostConfig{NetworkMode: "default"},
+		},
+		Config: &container.Config{Image: "consul:1.16"},
+		Mounts: []container.MountPoint{
+			{Type: mount.TypeVolume, Name: "data", Destination: "/consul/data"},
+			{Type: mount.TypeVolume, Name: utils.FQDNVolumeName(utils.ImageVolumeName), Destination: "/cache"},
+			{Type: mount.TypeBind, Source: "/tmp", Destination: "/files"},
+		},
+		NetworkSettings: &container.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"cloud": {
+					NetworkID:  "abc",
+					EndpointID: "123",
+					Aliases:    []string{"consul"},
+					IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "10.0.0.2"},
+				},
+			},
+		},
+	}
+}
+
+func setupSnapshots(t *testing.T) (*SnapshotsImpl, *mocks.Docker) {
+	md := &mocks.Docker{}
+	md.On("ContainerInspect", mock.Anything, "consul.container.jumppad.dev").Return(testContainer("1234", "consul.container.jumppad.dev"), nil)
+	md.On("ContainerCommit", mock.Anything, mock.Anything, mock.Anything).Return(container.CommitResponse{ID: "sha256:abc"}, nil)
+	md.On("CopyFromContainer", mock.Anything, mock.Anything, mock.Anything).Return(io.NopCloser(bytes.NewBufferString("volume")), container.PathStat{}, nil)
+	md.On("ImageRemove", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
+
+	return NewSnapshots(md, logger.NewTestLogger(t), t.TempDir()), md
+}
+
+var targets = []Target{{Resource: "resource.container.consul", Container: "consul.container.jumppad.dev"}}
+
+func TestCreateCommitsContainersAndExportsVolumes(t *testing.T) {
+	s, md := setupSnapshots(t)
+
+	snap, err := s.Create(context.Background(), "demo", "hash", targets)
+	require.NoError(t, err)
+
+	require.Equal(t, "hash", snap.StateHash)
+	require.Len(t, snap.Containers, 1)
+	require.Equal(t, "snapshot.jumppad.dev/demo:consul.container.jumppad.dev", snap.Containers[0].Image)
+
+	// only the data volume is exported, the image cache is shared
+	require.Len(t, snap.Containers[0].Volumes, 1)
+	require.Equal(t, "/consul/data", snap.Containers[0].Volumes[0].Destination)
+
+	md.AssertCalled(t, "ContainerCommit", mock.Anything, "1234", container.CommitOptions{
+		Reference: "snapshot.jumppad.dev/demo:consul.container.jumppad.dev",
+		Comment:   "jumppad snapshot demo of resource.container.consul",
+		Pause:     true,
+	})
+
+	d, err := os.ReadFile(filepath.Join(s.dir, "demo", snap.Containers[0].Volumes[0].Archive))
+	require.NoError(t, err)
+	require.Equal(t, "volume", string(d))
+
+	require.FileExists(t, filepath.Join(s.dir, "demo", manifestFile))
+}
+
+func TestCreateWithInvalidNameReturnsError(t *testing.T) {
+	s, _ := setupSnapshots(t)
+
+	_, err := s.Create(context.Background(), "My Demo", "hash", targets)
+	require.ErrorContains(t, err, "invalid snapshot name")
+}
+
+func TestCreateWithExistingSnapshotReturnsError(t *testing.T) {
+	s, _ := setupSnapshots(t)
+
+	_, err := s.Create(context.Background(), "demo", "hash", targets)
+	require.NoError(t, err)
+
+	_, err = s.Create(context.Background(), "demo", "hash", targets)
+	require.ErrorContains(t, err, "snapshot demo already exists")
+}
+
+func TestCreateWithExportErrorRemovesSnapshot(t *testing.T) {
+	s, md := setupSnapshots(t)
+	testutils.RemoveOn(&md.Mock, "CopyFromContainer")
+	md.On("CopyFromContainer", mock.Anything, mock.Anything, mock.Anything).Return(nil, container.PathStat{}, os.ErrPermission)
+
+	_, err := s.Create(context.Background(), "demo", "hash", targets)
+	require.ErrorContains(t, err, "unable to export volume")
+
+	require.NoDirExists(t, filepath.Join(s.dir, "demo"))
+	md.AssertCalled(t, "ImageRemove", mock.Anything, "snapshot.jumppad.dev/demo:consul.container.jumppad.dev", mock.Anything)
+}
+
+func TestRestoreRecreatesContainersFromSnapshot(t *testing.T) {
+	s, md := setupSnapshots(t)
+	md.On("ContainerRemove", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	md.On("VolumeRemove", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	md.On("ContainerCreate", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(container.CreateResponse{ID: "5678"}, nil)
+	md.On("CopyToContainer", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	md.On("ContainerStart", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	_, err := s.Create(context.Background(), "demo", "hash", targets)
+	require.NoError(t, err)
+
+	_, err = s.Restore(context.Background(), "demo")
+	require.NoError(t, err)
+
+	md.AssertCalled(t, "ContainerRemove", mock.Anything, "1234", container.RemoveOptions{Force: true, RemoveVolumes: true})
+	md.AssertCalled(t, "VolumeRemove", mock.Anything, "data", true)
+
+	cfg := testutils.GetCalls(&md.Mock, "ContainerCreate")[0].Arguments
+	require.Equal(t, "snapshot.jumppad.dev/demo:consul.container.jumppad.dev", cfg.Get(1).(*container.Config).Image)
+	require.Equal(t, "consul.container.jumppad.dev", cfg.Get(5))
+
+	ep := cfg.Get(3).(*network.NetworkingConfig).EndpointsConfig["cloud"]
+	require.Equal(t, "10.0.0.2", ep.IPAMConfig.IPv4Address)
+	require.Empty(t, ep.EndpointID)
+
+	md.AssertCalled(t, "CopyToContainer", mock.Anything, "5678", "/consul", mock.Anything, mock.Anything)
+	md.AssertCalled(t, "ContainerStart", mock.Anything, "5678", mock.Anything)
+}
+
+func TestRestoreWithMissingSnapshotReturnsError(t *testing.T) {
+	s, _ := setupSnapshots(t)
+
+	_, err := s.Restore(context.Background(), "demo")
+	require.ErrorContains(t, err, "snapshot demo does not exist")
+}
+
+func TestListReturnsSnapshotsInOrder(t *testing.T) {
+	s, _ := setupSnapshots(t)
+
+	_, err := s.Create(context.Background(), "second", "hash", targets)
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	_, err = s.Create(context.Background(), "first", "hash", targets)
+	require.NoError(t, err)
+
+	snaps, err := s.List()
+	require.NoError(t, err)
+
+	require.Len(t, snaps, 2)
+	require.Equal(t, "second", snaps[0].Name)
+	require.Equal(t, "first", snaps[1].Name)
+}
+
+func TestDeleteRemovesImagesAndDirectory(t *testing.T) {
+	s, md := setupSnapshots(t)
+
+	_, err := s.Create(context.Background(), "demo", "hash", targets)
+	require.NoError(t, err)
+
+	err = s.Delete(context.Background(), "demo")
+	require.NoError(t, err)
+
+	require.NoDirExists(t, filepath.Join(s.dir, "demo"))
+	md.AssertCalled(t, "ImageRemove", mock.Anything, "snapshot.jumppad.dev/demo:consul.container.jumppad.dev", mock.Anything)
+}
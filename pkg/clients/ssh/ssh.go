@@ -0,0 +1,292 @@
+package ssh
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"golang.org/x/crypto/ssh"
+)
+
+// ErrorCommandTimeout is returned when a script does not complete before the
+// timeout
+var ErrorCommandTimeout = fmt.Errorf("Command timed out before completing")
+
+// Target is the remote machine that commands are run on
+type Target struct {
+	Host string
+	// Port defaults to 22
+	Port int
+	User string
+
+	// PrivateKey is a PEM encoded private key, either PrivateKey or Password
+	// must be set
+	PrivateKey []byte
+	Password   string
+
+	// HostKey is the public key of the host in authorized_keys format, when
+	// not set the host key is not verified
+	HostKey string
+}
+
+// SSH runs scripts and copies files to remote machines
+//
+//go:generate mockery --name SSH --filename ssh.go
+type SSH interface {
+	// ExecuteScript uploads the script to the target and runs it with the
+	// given environment, it returns the exit code of the script
+	ExecuteScript(t Target, script string, env []string, workingDirectory string, timeout time.Duration, writer io.Writer) (int, error)
+
+	// Upload copies the local file or directory src to dst on the target
+	Upload(t Target, src, dst string) error
+
+	// Download copies the file src on the target to the local file dst
+	Download(t Target, src, dst string) error
+}
+
+// SSHImpl is the SSH client that uses golang.org/x/crypto/ssh
+type SSHImpl struct {
+	log logger.Logger
+}
+
+// NewSSH creates a new SSH client
+func NewSSH(l logger.Logger) SSH {
+	return &SSHImpl{log: l}
+}
+
+// ExecuteScript runs the script on the target
+func (s *SSHImpl) ExecuteScript(t Target, script string, env []string, workingDirectory string, timeout time.Duration, writer io.Writer) (int, error) {
+	c, err := s.connect(t)
+	if err != nil {
+		return -1, err
+	}
+	defer c.Close()
+
+	scriptPath := fmt.Sprintf("/tmp/jumppad_exec_%d.sh", time.Now().UnixNano())
+
+	err = write(c, bytes.NewBufferString(strings.ReplaceAll(script, "\r\n", "\n")), scriptPath, 0700)
+	if err != nil {
+		return -1, fmt.Errorf("unable to upload script: %s", err)
+	}
+
+	sess, err := c.NewSession()
+	if err != nil {
+		return -1, fmt.Errorf("unable to create session: %s", err)
+	}
+	defer sess.Close()
+
+	if writer != nil {
+		sess.Stdout = writer
+		sess.Stderr = writer
+	}
+
+	s.log.Debug("Running script on remote host", "host", t.Host, "user", t.User, "dir", workingDirectory)
+
+	doneCh := make(chan error, 1)
+	go func() {
+		doneCh <- sess.Run(scriptCommand(scriptPath, env, workingDirectory))
+	}()
+
+	if timeout <= 0 {
+		timeout = 300 * time.Second
+	}
+
+	select {
+	case <-time.After(timeout):
+		// closing the connection ends the session
+		c.Close()
+		return -1, ErrorCommandTimeout
+	case err := <-doneCh:
+		run(c, "rm -f "+quote(scriptPath))
+
+		var exitErr *ssh.ExitError
+		if errors.As(err, &exitErr) {
+			return exitErr.ExitStatus(), nil
+		}
+
+		if err != nil {
+			return -1, fmt.Errorf("unable to run script: %s", err)
+		}
+
+		return 0, nil
+	}
+}
+
+// Upload copies the local file or directory to the target, directories are
+// copied recursively
+func (s *SSHImpl) Upload(t Target, src, dst string) error {
+	c, err := s.connect(t)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	return filepath.Walk(src, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, p)
+		if err != nil {
+			return err
+		}
+
+		remote := dst
+		if rel != "." {
+			remote = path.Join(dst, filepath.ToSlash(rel))
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return fmt.Errorf("unable to open %s: %s", p, err)
+		}
+		defer f.Close()
+
+		s.log.Debug("Uploading file to remote host", "host", t.Host, "src", p, "dst", remote)
+
+		err = write(c, f, remote, info.Mode().Perm())
+		if err != nil {
+			return fmt.Errorf("unable to upload %s: %s", p, err)
+		}
+
+		return nil
+	})
+}
+
+// Download copies the file from the target
+func (s *SSHImpl) Download(t Target, src, dst string) error {
+	c, err := s.connect(t)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	sess, err := c.NewSession()
+	if err != nil {
+		return fmt.Errorf("unable to create session: %s", err)
+	}
+	defer sess.Close()
+
+	out := bytes.NewBuffer(nil)
+	sess.Stdout = out
+
+	err = sess.Run("cat " + quote(src))
+	if err != nil {
+		return fmt.Errorf("unable to read %s: %s", src, err)
+	}
+
+	return os.WriteFile(dst, out.Bytes(), 0644)
+}
+
+func (s *SSHImpl) connect(t Target) (*ssh.Client, error) {
+	auth := []ssh.AuthMethod{}
+
+	if len(t.PrivateKey) > 0 {
+		signer, err := ssh.ParsePrivateKey(t.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse private key: %s", err)
+		}
+
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+
+	if t.Password != "" {
+		auth = append(auth, ssh.Password(t.Password))
+	}
+
+	hostKey := ssh.InsecureIgnoreHostKey()
+	if t.HostKey != "" {
+		pk, _, _, _, err := ssh.ParseAuthorizedKey([]byte(t.HostKey))
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse host key: %s", err)
+		}
+
+		hostKey = ssh.FixedHostKey(pk)
+	}
+
+	port := t.Port
+	if port == 0 {
+		port = 22
+	}
+
+	addr := net.JoinHostPort(t.Host, strconv.Itoa(port))
+
+	c, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            t.User,
+		Auth:            auth,
+		HostKeyCallback: hostKey,
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %s", addr, err)
+	}
+
+	return c, nil
+}
+
+// write copies the contents of r to the file dst on the target, the parent
+// directories are created
+func write(c *ssh.Client, r io.Reader, dst string, mode os.FileMode) error {
+	sess, err := c.NewSession()
+	if err != nil {
+		return err
+	}
+	defer sess.Close()
+
+	sess.Stdin = r
+
+	return sess.Run(fmt.Sprintf("mkdir -p %s && cat > %s && chmod %o %s", quote(path.Dir(dst)), quote(dst), mode, quote(dst)))
+}
+
+func run(c *ssh.Client, cmd string) error {
+	sess, err := c.NewSession()
+	if err != nil {
+		return err
+	}
+	defer sess.Close()
+
+	return sess.Run(cmd)
+}
+
+// scriptCommand returns the shell command that runs the script with sh in
+// the same way as scripts in containers, the environment is set in the
+// command as sshd only accepts the variables allowed by AcceptEnv
+func scriptCommand(script string, env []string, workingDirectory string) string {
+	vars := []string{}
+	for _, e := range env {
+		k, v, _ := strings.Cut(e, "=")
+		vars = append(vars, fmt.Sprintf("%s=%s", k, quote(v)))
+	}
+
+	sort.Strings(vars)
+
+	cmd := "sh " + quote(script)
+	if len(vars) > 0 {
+		cmd = "env " + strings.Join(vars, " ") + " " + cmd
+	}
+
+	if workingDirectory != "" {
+		cmd = fmt.Sprintf("cd %s && %s", quote(workingDirectory), cmd)
+	}
+
+	return cmd
+}
+
+// quote returns s as a single quoted shell string
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
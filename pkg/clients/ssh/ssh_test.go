@@ -0,0 +1,23 @@
+package ssh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScriptCommandRunsScriptWithSh(t *testing.T) {
+	cmd := scriptCommand("/tmp/script.sh", nil, "")
+
+	require.Equal(t, "sh '/tmp/script.sh'", cmd)
+}
+
+func TestScriptCommandSetsEnvironmentAndWorkingDirectory(t *testing.T) {
+	cmd := scriptCommand("/tmp/script.sh", []string{"FOO=bar baz", "EXEC_OUTPUT=/tmp/out"}, "/opt/app")
+
+	require.Equal(t, "cd '/opt/app' && env EXEC_OUTPUT='/tmp/out' FOO='bar baz' sh '/tmp/script.sh'", cmd)
+}
+
+func TestQuoteEscapesSingleQuotes(t *testing.T) {
+	require.Equal(t, `'it'\''s'`, quote("it's"))
+}
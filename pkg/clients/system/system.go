@@ -2,10 +2,15 @@ package system
 
 import (
 	"bufio"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/pem"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"time"
@@ -29,6 +34,8 @@ type System interface {
 	Preflight() (string, error)
 	CheckVersion(string) (string, bool)
 	PromptInput(in io.Reader, out io.Writer, message string) string
+	TrustCertificate(string) error
+	UntrustCertificate(string) error
 }
 
 // SystemImpl is a concrete implementation of the System interface
@@ -106,11 +113,15 @@ func (b *SystemImpl) Preflight() (string, error) {
 
 	if !dockerPass && !podmanPass {
 		output += fmt.Sprintf(" [ %s ] Docker\n", fmt.Sprintf(Red, " ERROR "))
-		errors += "* Unable to connect to Docker, ensure Docker is installed and running.\n"
+		errors += "* Unable to connect to Docker, ensure Docker is installed and running. If the socket is not found automatically set it with 'jumppad config set socket [path]'.\n"
 		output += fmt.Sprintf(" [ %s ] Podman\n", fmt.Sprintf(Red, " ERROR "))
 		errors += "* Unable to connect to Podman, ensure Podman is installed and running.\n"
 	}
 
+	if dockerPass || podmanPass {
+		output += b.checkRootless()
+	}
+
 	if b.checkGit() != nil {
 		output += fmt.Sprintf(" [ %s ] Git\n", fmt.Sprintf(Red, " ERROR "))
 		errors += "* Unable to find 'git' command, ensure Git is installed. Shipyard uses the git CLI to download blueprints.\n"
@@ -179,6 +190,151 @@ func (b *SystemImpl) PromptInput(in io.Reader, out io.Writer, message string) st
 	return scanner.Text()
 }
 
+// TrustCertificate adds the PEM encoded CA certificate at the given path
+// to the operating systems trust store, this operation requires elevated
+// permissions and may prompt the user for their password
+func (b *SystemImpl) TrustCertificate(path string) error {
+	der, err := readCertificate(path)
+	if err != nil {
+		return err
+	}
+
+	var cmds [][]string
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmds = [][]string{{"sudo", "security", "add-trusted-cert", "-d", "-r", "trustRoot", "-k", macKeychain, path}}
+	case "linux":
+		ts, err := linuxTrustStore()
+		if err != nil {
+			return err
+		}
+
+		cmds = [][]string{
+			{"sudo", "cp", path, ts.certificatePath(der)},
+			ts.update,
+		}
+	case "windows":
+		cmds = [][]string{{"certutil", "-addstore", "-f", "ROOT", path}}
+	default:
+		return fmt.Errorf("trusting certificates is not supported on %s", runtime.GOOS)
+	}
+
+	err = runCommands(cmds)
+	if err != nil {
+		return fmt.Errorf("unable to add certificate %s to the system trust store: %w", path, err)
+	}
+
+	return nil
+}
+
+// UntrustCertificate removes the PEM encoded CA certificate at the given
+// path from the operating systems trust store, the certificate must have
+// been added with TrustCertificate
+func (b *SystemImpl) UntrustCertificate(path string) error {
+	der, err := readCertificate(path)
+	if err != nil {
+		return err
+	}
+
+	var cmds [][]string
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmds = [][]string{{"sudo", "security", "delete-certificate", "-Z", fmt.Sprintf("%X", sha1.Sum(der)), macKeychain}}
+	case "linux":
+		ts, err := linuxTrustStore()
+		if err != nil {
+			return err
+		}
+
+		cmds = [][]string{
+			{"sudo", "rm", "-f", ts.certificatePath(der)},
+			ts.update,
+		}
+	case "windows":
+		cmds = [][]string{{"certutil", "-delstore", "ROOT", fmt.Sprintf("%x", sha1.Sum(der))}}
+	default:
+		return fmt.Errorf("trusting certificates is not supported on %s", runtime.GOOS)
+	}
+
+	err = runCommands(cmds)
+	if err != nil {
+		return fmt.Errorf("unable to remove certificate %s from the system trust store: %w", path, err)
+	}
+
+	return nil
+}
+
+const macKeychain = "/Library/Keychains/System.keychain"
+
+// trustStore is a directory of CA certificates and the command that updates
+// the system trust store from it
+type trustStore struct {
+	dir    string
+	update []string
+}
+
+// certificatePath returns the path the certificate is copied to, the name is
+// derived from the certificate so it can be removed from the trust store
+func (t trustStore) certificatePath(der []byte) string {
+	return filepath.Join(t.dir, fmt.Sprintf("jumppad-%x.crt", sha256.Sum256(der)))
+}
+
+// linuxTrustStore returns the trust store for the linux distribution,
+// update-ca-certificates is used by Debian based distributions and
+// update-ca-trust by Red Hat based distributions
+func linuxTrustStore() (trustStore, error) {
+	if _, err := exec.LookPath("update-ca-certificates"); err == nil {
+		return trustStore{
+			dir:    "/usr/local/share/ca-certificates",
+			update: []string{"sudo", "update-ca-certificates"},
+		}, nil
+	}
+
+	if _, err := exec.LookPath("update-ca-trust"); err == nil {
+		return trustStore{
+			dir:    "/etc/pki/ca-trust/source/anchors",
+			update: []string{"sudo", "update-ca-trust", "extract"},
+		}, nil
+	}
+
+	return trustStore{}, fmt.Errorf("unable to find update-ca-certificates or update-ca-trust to update the system trust store")
+}
+
+// readCertificate returns the DER bytes of the PEM encoded certificate
+func readCertificate(path string) ([]byte, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read certificate %s: %w", path, err)
+	}
+
+	b, _ := pem.Decode(d)
+	if b == nil || b.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%s does not contain a PEM encoded certificate", path)
+	}
+
+	return b.Bytes, nil
+}
+
+// runCommands runs each command in order connecting the terminal so that
+// sudo can prompt for a password
+func runCommands(cmds [][]string) error {
+	for _, c := range cmds {
+		cmd := exec.Command(c[0], c[1:]...)
+		cmd.Stdin = os.Stdin
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		err := cmd.Run()
+		if err != nil {
+			return fmt.Errorf("%s: %w", strings.Join(c, " "), err)
+		}
+	}
+
+	return nil
+}
+
 var updateText = `
 ########################################################
                    JUMPPAD UPDATE
@@ -233,6 +389,30 @@ func (b *SystemImpl) checkPodman() error {
 	return nil
 }
 
+// checkRootless returns warnings for the features that are limited when the
+// engine runs rootless, an empty string is returned for a rootful engine
+func (b *SystemImpl) checkRootless() string {
+	d, err := container.NewDocker()
+	if err != nil {
+		return ""
+	}
+
+	dt, _ := container.NewDockerTasks(d, nil, nil, b.logger)
+	if dt == nil || !dt.EngineInfo().Rootless {
+		return ""
+	}
+
+	info := dt.EngineInfo()
+	output := fmt.Sprintf(" [ %s ] Rootless %s\n", fmt.Sprintf(Yellow, "WARNING"), info.EngineType)
+	output += fmt.Sprintf("             Host ports below %d can not be published, set 'sysctl net.ipv4.ip_unprivileged_port_start' to allow them\n", info.UnprivilegedPortStart)
+
+	if err := info.CheckClusterSupport(); err != nil {
+		output += fmt.Sprintf("             Kubernetes and Nomad clusters can not be created, %s\n", err)
+	}
+
+	return output
+}
+
 func (b *SystemImpl) checkGit() error {
 	_, err := exec.LookPath("git")
 	return err
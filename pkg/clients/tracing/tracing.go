@@ -0,0 +1,89 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation name used for all jumppad spans
+const TracerName = "github.com/jumppad-labs/jumppad"
+
+const (
+	// AttributeResourceID is the fully qualified id of a resource
+	AttributeResourceID = attribute.Key("jumppad.resource.id")
+	// AttributeResourceType is the type of a resource
+	AttributeResourceType = attribute.Key("jumppad.resource.type")
+	// AttributeResourceStatus is the status of a resource once processed
+	AttributeResourceStatus = attribute.Key("jumppad.resource.status")
+	// AttributePath is the path of the configuration being processed
+	AttributePath = attribute.Key("jumppad.path")
+	// AttributeImage is the name of a container image
+	AttributeImage = attribute.Key("jumppad.image")
+)
+
+// Enabled returns true when an OTLP endpoint has been configured using the
+// standard OpenTelemetry environment variables
+func Enabled() bool {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" ||
+		os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+}
+
+// Setup configures the global tracer provider to export spans using OTLP
+// over HTTP. The exporter is configured with the standard environment
+// variables, e.g. OTEL_EXPORTER_OTLP_ENDPOINT, when no endpoint is set
+// tracing is disabled and spans are discarded.
+//
+// The returned function flushes any buffered spans and must be called before
+// the process exits.
+func Setup(ctx context.Context, version string) (func(context.Context) error, error) {
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exp, err := otlptracehttp.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create OTLP exporter: %s", err)
+	}
+
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName("jumppad"),
+		semconv.ServiceVersion(version),
+	)
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+	)
+
+	otel.SetTracerProvider(tp)
+
+	return tp.Shutdown, nil
+}
+
+// Start creates a span with the given attributes as a child of any span in
+// ctx, the returned context should be passed to any operations that are
+// performed as part of the span
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records err on the span when it is not nil and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
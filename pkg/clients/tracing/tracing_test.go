@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func setupRecorder(t *testing.T) *tracetest.SpanRecorder {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+	old := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(old)
+	})
+
+	return sr
+}
+
+func TestEnabledWithEndpointReturnsTrue(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318")
+
+	require.True(t, Enabled())
+}
+
+func TestEnabledWithoutEndpointReturnsFalse(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	require.False(t, Enabled())
+}
+
+func TestSetupWithoutEndpointReturnsNoopShutdown(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+
+	shutdown, err := Setup(context.Background(), "v0.0.0")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestStartCreatesChildSpanWithAttributes(t *testing.T) {
+	sr := setupRecorder(t)
+
+	ctx, parent := Start(context.Background(), "apply")
+	_, child := Start(ctx, "create", AttributeResourceID.String("resource.container.consul"))
+	End(child, nil)
+	End(parent, nil)
+
+	spans := sr.Ended()
+	require.Len(t, spans, 2)
+	require.Equal(t, "create", spans[0].Name())
+	require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	require.Contains(t, spans[0].Attributes(), AttributeResourceID.String("resource.container.consul"))
+}
+
+func TestEndWithErrorSetsErrorStatus(t *testing.T) {
+	sr := setupRecorder(t)
+
+	_, span := Start(context.Background(), "pull")
+	End(span, fmt.Errorf("unable to pull image"))
+
+	spans := sr.Ended()
+	require.Len(t, spans, 1)
+	require.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Equal(t, "unable to pull image", spans[0].Status().Description)
+	require.Len(t, spans[0].Events(), 1)
+}
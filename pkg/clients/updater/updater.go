@@ -0,0 +1,337 @@
+package updater
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/registry"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// DefaultReleasesURL is the location of the jumppad release assets
+const DefaultReleasesURL = "https://github.com/jumppad-labs/jumppad/releases/download"
+
+// DefaultLatestURL returns the details of the latest jumppad release
+const DefaultLatestURL = "https://api.github.com/repos/jumppad-labs/jumppad/releases/latest"
+
+// checksumsFile is the release asset containing the SHA-256 checksums of the
+// archives, signatureFile contains the signature for the checksums
+const (
+	checksumsFile = "checksums.txt"
+	signatureFile = "checksums.txt.sig"
+)
+
+// ReleaseKey is the base64 encoded PKIX public key used to verify the
+// signature of the release checksums, it is set when jumppad is released with
+// -X github.com/jumppad-labs/jumppad/pkg/clients/updater.ReleaseKey=<key>
+var ReleaseKey string
+
+// ErrNoVerifyKey is returned when an update is not insecure and there is no
+// key to verify the signature of the release
+var ErrNoVerifyKey = fmt.Errorf("no key is available to verify the release signature")
+
+// EmbeddedKey returns the key set in ReleaseKey, nil is returned when jumppad
+// was built without a release key
+func EmbeddedKey() (crypto.PublicKey, error) {
+	if ReleaseKey == "" {
+		return nil, nil
+	}
+
+	d, err := base64.StdEncoding.DecodeString(ReleaseKey)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode release key: %w", err)
+	}
+
+	k, err := x509.ParsePKIXPublicKey(d)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse release key: %w", err)
+	}
+
+	return k, nil
+}
+
+// Updater downloads jumppad releases and replaces the jumppad binary
+//
+//go:generate mockery --name Updater --filename updater.go
+type Updater interface {
+	// Latest returns the version of the latest release
+	Latest(ctx context.Context) (string, error)
+
+	// Update downloads the release for the current operating system and
+	// architecture, verifies the signature of the checksums with key and
+	// the checksum of the archive, and replaces the binary at dst. When key
+	// is nil the update is refused unless insecure is set, insecure updates
+	// only verify the checksum of the archive
+	Update(ctx context.Context, version, dst string, key crypto.PublicKey, insecure bool) error
+}
+
+// UpdaterImpl is a concrete implementation of the Updater interface that
+// downloads releases from GitHub
+type UpdaterImpl struct {
+	releasesURL string
+	latestURL   string
+	goos        string
+	goarch      string
+	client      *http.Client
+}
+
+// NewUpdater creates a new Updater for the current operating system and
+// architecture
+func NewUpdater(releasesURL, latestURL string) *UpdaterImpl {
+	return &UpdaterImpl{
+		releasesURL: releasesURL,
+		latestURL:   latestURL,
+		goos:        runtime.GOOS,
+		goarch:      runtime.GOARCH,
+		client:      &http.Client{Transport: utils.HTTPTransport()},
+	}
+}
+
+// Latest returns the tag of the latest release
+func (u *UpdaterImpl) Latest(ctx context.Context) (string, error) {
+	d, err := u.get(ctx, u.latestURL)
+	if err != nil {
+		return "", fmt.Errorf("unable to get latest release: %w", err)
+	}
+
+	rel := struct {
+		TagName string `json:"tag_name"`
+	}{}
+
+	err = json.Unmarshal(d, &rel)
+	if err != nil || rel.TagName == "" {
+		return "", fmt.Errorf("unable to parse latest release")
+	}
+
+	return rel.TagName, nil
+}
+
+// Update downloads and verifies the release then replaces the binary at dst
+func (u *UpdaterImpl) Update(ctx context.Context, version, dst string, key crypto.PublicKey, insecure bool) error {
+	if key == nil && !insecure {
+		return ErrNoVerifyKey
+	}
+
+	archive := ArchiveName(version, u.goos, u.goarch)
+
+	checksums, err := u.get(ctx, u.assetURL(version, checksumsFile))
+	if err != nil {
+		return fmt.Errorf("unable to download checksums for release %s: %w", version, err)
+	}
+
+	if key != nil {
+		sig, err := u.get(ctx, u.assetURL(version, signatureFile))
+		if err != nil {
+			return fmt.Errorf("unable to download signature for release %s: %w", version, err)
+		}
+
+		err = registry.Verify(key, checksums, decodeSignature(sig))
+		if err != nil {
+			return fmt.Errorf("unable to verify signature for release %s: %w", version, err)
+		}
+	}
+
+	expected, err := findChecksum(checksums, archive)
+	if err != nil {
+		return err
+	}
+
+	d, err := u.get(ctx, u.assetURL(version, archive))
+	if err != nil {
+		return fmt.Errorf("unable to download %s: %w", archive, err)
+	}
+
+	sum := sha256.Sum256(d)
+	if hex.EncodeToString(sum[:]) != expected {
+		return fmt.Errorf("checksum for %s does not match the release checksum", archive)
+	}
+
+	bin, err := extractBinary(d, archive)
+	if err != nil {
+		return err
+	}
+
+	return Replace(dst, bin)
+}
+
+// ArchiveName returns the name of the release archive for the operating
+// system and architecture, e.g. jumppad_0.12.0_linux_x86_64.tar.gz
+func ArchiveName(version, goos, goarch string) string {
+	arch := goarch
+	if arch == "amd64" {
+		arch = "x86_64"
+	}
+
+	ext := "zip"
+	if goos == "linux" {
+		ext = "tar.gz"
+	}
+
+	return fmt.Sprintf("jumppad_%s_%s_%s.%s", version, goos, arch, ext)
+}
+
+// Replace atomically replaces the file at dst with the binary, the new
+// binary is written alongside the existing file and renamed so that the
+// existing binary is never partially overwritten
+func Replace(dst string, bin []byte) error {
+	dst, err := filepath.EvalSymlinks(dst)
+	if err != nil {
+		return fmt.Errorf("unable to resolve binary path: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".jumppad-update-*")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary file, check you have permission to write to %s: %w", filepath.Dir(dst), err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(bin)
+	tmp.Close()
+	if err != nil {
+		return fmt.Errorf("unable to write binary: %w", err)
+	}
+
+	err = os.Chmod(tmp.Name(), 0755)
+	if err != nil {
+		return fmt.Errorf("unable to set binary permissions: %w", err)
+	}
+
+	// a running executable can not be replaced on Windows but it can be
+	// renamed
+	if runtime.GOOS == "windows" {
+		old := dst + ".old"
+		os.Remove(old)
+
+		err := os.Rename(dst, old)
+		if err != nil {
+			return fmt.Errorf("unable to move existing binary: %w", err)
+		}
+	}
+
+	err = os.Rename(tmp.Name(), dst)
+	if err != nil {
+		return fmt.Errorf("unable to replace binary: %w", err)
+	}
+
+	return nil
+}
+
+func (u *UpdaterImpl) assetURL(version, name string) string {
+	return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(u.releasesURL, "/"), version, name)
+}
+
+func (u *UpdaterImpl) get(ctx context.Context, uri string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, uri)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// findChecksum returns the checksum for the file from the checksums file,
+// each line contains the hex encoded SHA-256 checksum and the file name
+func findChecksum(checksums []byte, file string) (string, error) {
+	s := bufio.NewScanner(bytes.NewReader(checksums))
+	for s.Scan() {
+		parts := strings.Fields(s.Text())
+		if len(parts) == 2 && path.Base(parts[1]) == file {
+			return strings.ToLower(parts[0]), nil
+		}
+	}
+
+	return "", fmt.Errorf("no checksum found for %s, the release may not support this platform", file)
+}
+
+// decodeSignature returns the raw signature, signatures may be base64
+// encoded
+func decodeSignature(sig []byte) []byte {
+	d, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		return sig
+	}
+
+	return d
+}
+
+// extractBinary returns the jumppad binary from the release archive
+func extractBinary(d []byte, archive string) ([]byte, error) {
+	if strings.HasSuffix(archive, ".tar.gz") {
+		gz, err := gzip.NewReader(bytes.NewReader(d))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", archive, err)
+		}
+
+		tr := tar.NewReader(gz)
+		for {
+			h, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to read %s: %w", archive, err)
+			}
+
+			if h.Typeflag == tar.TypeReg && isBinary(h.Name) {
+				return io.ReadAll(tr)
+			}
+		}
+
+		return nil, fmt.Errorf("jumppad binary not found in %s", archive)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(d), int64(len(d)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", archive, err)
+	}
+
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || !isBinary(f.Name) {
+			continue
+		}
+
+		r, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %s: %w", archive, err)
+		}
+		defer r.Close()
+
+		return io.ReadAll(r)
+	}
+
+	return nil, fmt.Errorf("jumppad binary not found in %s", archive)
+}
+
+func isBinary(name string) bool {
+	b := path.Base(name)
+	return b == "jumppad" || b == "jumppad.exe"
+}
@@ -0,0 +1,255 @@
+package updater
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type release struct {
+	assets map[string][]byte
+}
+
+func setupUpdater(t *testing.T, goos, goarch string) (*UpdaterImpl, *release, string) {
+	rel := &release{assets: map[string][]byte{}}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/latest" {
+			fmt.Fprint(w, `{"tag_name": "0.13.0", "name": "v0.13.0"}`)
+			return
+		}
+
+		d, ok := rel.assets[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write(d)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	bin := filepath.Join(t.TempDir(), "jumppad")
+	os.WriteFile(bin, []byte("old"), 0755)
+
+	u := NewUpdater(srv.URL+"/download", srv.URL+"/latest")
+	u.goos = goos
+	u.goarch = goarch
+
+	return u, rel, bin
+}
+
+func (r *release) addArchive(version, name string, archive []byte) {
+	sum := sha256.Sum256(archive)
+
+	r.assets["/download/"+version+"/"+name] = archive
+	r.assets["/download/"+version+"/checksums.txt"] = []byte(
+		fmt.Sprintf("%s  jumppad_%s_windows_x86_64.zip\n%s  %s\n", hex.EncodeToString(make([]byte, 32)), version, hex.EncodeToString(sum[:]), name),
+	)
+}
+
+func tarGz(t *testing.T, name string, contents []byte) []byte {
+	buf := bytes.NewBuffer(nil)
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+
+	err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0755, Size: int64(len(contents)), Typeflag: tar.TypeReg})
+	require.NoError(t, err)
+
+	tw.Write(contents)
+	tw.Close()
+	gz.Close()
+
+	return buf.Bytes()
+}
+
+func zipFile(t *testing.T, name string, contents []byte) []byte {
+	buf := bytes.NewBuffer(nil)
+	zw := zip.NewWriter(buf)
+
+	f, err := zw.Create(name)
+	require.NoError(t, err)
+
+	f.Write(contents)
+	zw.Close()
+
+	return buf.Bytes()
+}
+
+func TestArchiveNameForLinuxIsTarGz(t *testing.T) {
+	require.Equal(t, "jumppad_0.13.0_linux_x86_64.tar.gz", ArchiveName("0.13.0", "linux", "amd64"))
+	require.Equal(t, "jumppad_0.13.0_linux_arm64.tar.gz", ArchiveName("0.13.0", "linux", "arm64"))
+}
+
+func TestArchiveNameForDarwinIsZip(t *testing.T) {
+	require.Equal(t, "jumppad_0.13.0_darwin_arm64.zip", ArchiveName("0.13.0", "darwin", "arm64"))
+}
+
+func TestLatestReturnsTagName(t *testing.T) {
+	u, _, _ := setupUpdater(t, "linux", "amd64")
+
+	v, err := u.Latest(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "0.13.0", v)
+}
+
+func TestLatestReturnsErrorWhenRequestFails(t *testing.T) {
+	u, _, _ := setupUpdater(t, "linux", "amd64")
+	u.latestURL = u.releasesURL + "/missing"
+
+	_, err := u.Latest(context.Background())
+	require.Error(t, err)
+}
+
+func TestUpdateReplacesBinaryFromTarGz(t *testing.T) {
+	u, rel, bin := setupUpdater(t, "linux", "amd64")
+	rel.addArchive("0.13.0", "jumppad_0.13.0_linux_x86_64.tar.gz", tarGz(t, "/jumppad", []byte("new")))
+
+	err := u.Update(context.Background(), "0.13.0", bin, nil, true)
+	require.NoError(t, err)
+
+	d, _ := os.ReadFile(bin)
+	require.Equal(t, "new", string(d))
+
+	fi, _ := os.Stat(bin)
+	require.Equal(t, os.FileMode(0755), fi.Mode().Perm())
+}
+
+func TestUpdateReplacesBinaryFromZip(t *testing.T) {
+	u, rel, bin := setupUpdater(t, "darwin", "arm64")
+	rel.addArchive("0.13.0", "jumppad_0.13.0_darwin_arm64.zip", zipFile(t, "jumppad", []byte("new")))
+
+	err := u.Update(context.Background(), "0.13.0", bin, nil, true)
+	require.NoError(t, err)
+
+	d, _ := os.ReadFile(bin)
+	require.Equal(t, "new", string(d))
+}
+
+func TestUpdateReplacesSymlinkTarget(t *testing.T) {
+	u, rel, bin := setupUpdater(t, "linux", "amd64")
+	rel.addArchive("0.13.0", "jumppad_0.13.0_linux_x86_64.tar.gz", tarGz(t, "jumppad", []byte("new")))
+
+	link := filepath.Join(t.TempDir(), "jumppad")
+	os.Symlink(bin, link)
+
+	err := u.Update(context.Background(), "0.13.0", link, nil, true)
+	require.NoError(t, err)
+
+	d, _ := os.ReadFile(bin)
+	require.Equal(t, "new", string(d))
+}
+
+func TestUpdateWithInvalidChecksumReturnsError(t *testing.T) {
+	u, rel, bin := setupUpdater(t, "linux", "amd64")
+	rel.addArchive("0.13.0", "jumppad_0.13.0_linux_x86_64.tar.gz", tarGz(t, "jumppad", []byte("new")))
+	rel.assets["/download/0.13.0/jumppad_0.13.0_linux_x86_64.tar.gz"] = tarGz(t, "jumppad", []byte("tampered"))
+
+	err := u.Update(context.Background(), "0.13.0", bin, nil, true)
+	require.ErrorContains(t, err, "does not match")
+
+	d, _ := os.ReadFile(bin)
+	require.Equal(t, "old", string(d))
+}
+
+func TestUpdateWithoutPlatformArchiveReturnsError(t *testing.T) {
+	u, rel, bin := setupUpdater(t, "freebsd", "amd64")
+	rel.addArchive("0.13.0", "jumppad_0.13.0_linux_x86_64.tar.gz", tarGz(t, "jumppad", []byte("new")))
+
+	err := u.Update(context.Background(), "0.13.0", bin, nil, true)
+	require.ErrorContains(t, err, "no checksum found for jumppad_0.13.0_freebsd_x86_64.zip")
+}
+
+func TestUpdateWithMissingReleaseReturnsError(t *testing.T) {
+	u, _, bin := setupUpdater(t, "linux", "amd64")
+
+	err := u.Update(context.Background(), "9.9.9", bin, nil, true)
+	require.ErrorContains(t, err, "unable to download checksums for release 9.9.9")
+}
+
+func TestUpdateVerifiesSignature(t *testing.T) {
+	u, rel, bin := setupUpdater(t, "linux", "amd64")
+	rel.addArchive("0.13.0", "jumppad_0.13.0_linux_x86_64.tar.gz", tarGz(t, "jumppad", []byte("new")))
+
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	sig := ed25519.Sign(priv, rel.assets["/download/0.13.0/checksums.txt"])
+	rel.assets["/download/0.13.0/checksums.txt.sig"] = []byte(base64.StdEncoding.EncodeToString(sig))
+
+	err := u.Update(context.Background(), "0.13.0", bin, pub, false)
+	require.NoError(t, err)
+
+	d, _ := os.ReadFile(bin)
+	require.Equal(t, "new", string(d))
+}
+
+func TestUpdateWithInvalidSignatureReturnsError(t *testing.T) {
+	u, rel, bin := setupUpdater(t, "linux", "amd64")
+	rel.addArchive("0.13.0", "jumppad_0.13.0_linux_x86_64.tar.gz", tarGz(t, "jumppad", []byte("new")))
+
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	_, other, _ := ed25519.GenerateKey(rand.Reader)
+	rel.assets["/download/0.13.0/checksums.txt.sig"] = ed25519.Sign(other, rel.assets["/download/0.13.0/checksums.txt"])
+
+	err := u.Update(context.Background(), "0.13.0", bin, pub, false)
+	require.ErrorContains(t, err, "unable to verify signature")
+
+	d, _ := os.ReadFile(bin)
+	require.Equal(t, "old", string(d))
+}
+
+func TestUpdateWithoutSignatureReturnsErrorWhenKeySet(t *testing.T) {
+	u, rel, bin := setupUpdater(t, "linux", "amd64")
+	rel.addArchive("0.13.0", "jumppad_0.13.0_linux_x86_64.tar.gz", tarGz(t, "jumppad", []byte("new")))
+
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+
+	err := u.Update(context.Background(), "0.13.0", bin, pub, false)
+	require.ErrorContains(t, err, "unable to download signature")
+}
+
+func TestUpdateWithoutKeyReturnsErrorWhenNotInsecure(t *testing.T) {
+	u, rel, bin := setupUpdater(t, "linux", "amd64")
+	rel.addArchive("0.13.0", "jumppad_0.13.0_linux_x86_64.tar.gz", tarGz(t, "jumppad", []byte("new")))
+
+	err := u.Update(context.Background(), "0.13.0", bin, nil, false)
+	require.ErrorIs(t, err, ErrNoVerifyKey)
+
+	d, _ := os.ReadFile(bin)
+	require.Equal(t, "old", string(d))
+}
+
+func TestEmbeddedKeyReturnsNilWithoutReleaseKey(t *testing.T) {
+	k, err := EmbeddedKey()
+	require.NoError(t, err)
+	require.Nil(t, k)
+}
+
+func TestEmbeddedKeyReturnsReleaseKey(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(rand.Reader)
+	d, _ := x509.MarshalPKIXPublicKey(pub)
+
+	ReleaseKey = base64.StdEncoding.EncodeToString(d)
+	t.Cleanup(func() { ReleaseKey = "" })
+
+	k, err := EmbeddedKey()
+	require.NoError(t, err)
+	require.Equal(t, pub, k)
+}
@@ -0,0 +1,32 @@
+// This is a small WASI command used by the WASM client tests, it is built
+// with GOOS=wasip1 GOARCH=wasm when the tests run.
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		return
+	}
+
+	switch os.Args[1] {
+	case "exit":
+		code, _ := strconv.Atoi(os.Args[2])
+		os.Exit(code)
+	case "loop":
+		for {
+		}
+	case "cat":
+		d, err := os.ReadFile(os.Args[2])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		fmt.Print(string(d))
+	}
+}
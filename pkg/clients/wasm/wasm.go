@@ -0,0 +1,131 @@
+package wasm
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+)
+
+// WASM runs WebAssembly modules that implement the WASI command interface
+//
+//go:generate mockery --name WASM --filename wasm.go
+type WASM interface {
+	// Run executes the _start function of the WASI module at path, the
+	// module can only access the directories in the RunConfig mounts
+	Run(ctx context.Context, path string, config RunConfig) error
+}
+
+// Mount makes a directory on the host available to a module
+type Mount struct {
+	Source      string
+	Destination string
+	ReadOnly    bool
+}
+
+// RunConfig defines the arguments, environment and files available to a
+// module
+type RunConfig struct {
+	Args        []string
+	Environment map[string]string
+	Mounts      []Mount
+	Timeout     time.Duration
+
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+}
+
+// WASMImpl runs modules using the wazero runtime
+type WASMImpl struct {
+	log logger.Logger
+}
+
+// NewWASM creates a new WASM client
+func NewWASM(l logger.Logger) WASM {
+	return &WASMImpl{log: l}
+}
+
+func (w *WASMImpl) Run(ctx context.Context, path string, config RunConfig) error {
+	bin, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("unable to read module %s: %s", path, err)
+	}
+
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	// close the module when the context is cancelled so that a module stuck
+	// in a loop can not block jumppad
+	r := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCloseOnContextDone(true))
+	defer r.Close(ctx)
+
+	wasi_snapshot_preview1.MustInstantiate(ctx, r)
+
+	fs := wazero.NewFSConfig()
+	for _, m := range config.Mounts {
+		if m.ReadOnly {
+			fs = fs.WithReadOnlyDirMount(m.Source, m.Destination)
+		} else {
+			fs = fs.WithDirMount(m.Source, m.Destination)
+		}
+	}
+
+	mc := wazero.NewModuleConfig().
+		WithArgs(append([]string{filepath.Base(path)}, config.Args...)...).
+		WithFSConfig(fs).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+
+	for k, v := range config.Environment {
+		mc = mc.WithEnv(k, v)
+	}
+
+	if config.Stdin != nil {
+		mc = mc.WithStdin(config.Stdin)
+	}
+
+	if config.Stdout != nil {
+		mc = mc.WithStdout(config.Stdout)
+	}
+
+	if config.Stderr != nil {
+		mc = mc.WithStderr(config.Stderr)
+	}
+
+	w.log.Debug("Running WASM module", "path", path, "args", config.Args)
+
+	_, err = r.InstantiateWithConfig(ctx, bin, mc)
+	if err != nil {
+		var ee *sys.ExitError
+		if errors.As(err, &ee) {
+			switch {
+			case ee.ExitCode() == 0:
+				return nil
+			case ee.ExitCode() == sys.ExitCodeDeadlineExceeded:
+				return fmt.Errorf("module %s timed out after %s", path, config.Timeout)
+			case ee.ExitCode() == sys.ExitCodeContextCanceled:
+				return fmt.Errorf("module %s was cancelled", path)
+			}
+
+			return fmt.Errorf("module %s exited with code %d", path, ee.ExitCode())
+		}
+
+		return fmt.Errorf("unable to run module %s: %s", path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+// buildModule compiles the test module in testdata to a WASI module
+func buildModule(t *testing.T) string {
+	out := filepath.Join(t.TempDir(), "module.wasm")
+
+	cmd := exec.Command("go", "build", "-o", out, "./testdata/module")
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+
+	o, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(o))
+
+	return out
+}
+
+func setupWASM(t *testing.T) (WASM, string) {
+	return NewWASM(logger.NewTestLogger(t)), buildModule(t)
+}
+
+func TestRunWithSuccessfulExitReturnsNoError(t *testing.T) {
+	w, m := setupWASM(t)
+
+	err := w.Run(context.Background(), m, RunConfig{Args: []string{"exit", "0"}})
+	require.NoError(t, err)
+}
+
+func TestRunWithNonZeroExitReturnsError(t *testing.T) {
+	w, m := setupWASM(t)
+
+	err := w.Run(context.Background(), m, RunConfig{Args: []string{"exit", "3"}})
+	require.ErrorContains(t, err, "exited with code 3")
+}
+
+func TestRunWithTimeoutReturnsError(t *testing.T) {
+	w, m := setupWASM(t)
+
+	err := w.Run(context.Background(), m, RunConfig{Args: []string{"loop"}, Timeout: 100 * time.Millisecond})
+	require.ErrorContains(t, err, "timed out")
+}
+
+func TestRunWithMountCanReadFiles(t *testing.T) {
+	w, m := setupWASM(t)
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello world"), 0644)
+	require.NoError(t, err)
+
+	out := &bytes.Buffer{}
+	err = w.Run(context.Background(), m, RunConfig{
+		Args:   []string{"cat", "/data/hello.txt"},
+		Mounts: []Mount{{Source: dir, Destination: "/data", ReadOnly: true}},
+		Stdout: out,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "hello world", out.String())
+}
+
+func TestRunWithoutMountCanNotReadFiles(t *testing.T) {
+	w, m := setupWASM(t)
+
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello world"), 0644)
+	require.NoError(t, err)
+
+	err = w.Run(context.Background(), m, RunConfig{Args: []string{"cat", filepath.Join(dir, "hello.txt")}})
+	require.ErrorContains(t, err, "exited with code 1")
+}
+
+func TestRunWithMissingModuleReturnsError(t *testing.T) {
+	w := NewWASM(logger.NewTestLogger(t))
+
+	err := w.Run(context.Background(), filepath.Join(t.TempDir(), "missing.wasm"), RunConfig{})
+	require.ErrorContains(t, err, "unable to read module")
+}
@@ -0,0 +1,24 @@
+package config
+
+import (
+	"github.com/jumppad-labs/hclconfig/types"
+)
+
+// InCluster is implemented by resources that are created inside a Kubernetes
+// or Nomad cluster such as Helm charts and jobs, the resources are removed
+// with the cluster so they do not need to be destroyed when the cluster is
+// also destroyed
+type InCluster interface {
+	GetCluster() types.Resource
+}
+
+// ResourceCluster returns the cluster that the resource is created in, nil is
+// returned for resources that are not created in a cluster
+func ResourceCluster(r types.Resource) types.Resource {
+	c, ok := r.(InCluster)
+	if !ok {
+		return nil
+	}
+
+	return c.GetCluster()
+}
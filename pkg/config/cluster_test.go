@@ -0,0 +1,29 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/stretchr/testify/require"
+)
+
+type inClusterResource struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Cluster types.ResourceBase
+}
+
+func (c *inClusterResource) GetCluster() types.Resource {
+	return &c.Cluster
+}
+
+func TestResourceClusterReturnsCluster(t *testing.T) {
+	r := &inClusterResource{}
+	r.Cluster.Meta.ID = "resource.k8s_cluster.dev"
+
+	require.Equal(t, "resource.k8s_cluster.dev", ResourceCluster(r).Metadata().ID)
+}
+
+func TestResourceClusterWithResourceNotInClusterReturnsNil(t *testing.T) {
+	require.Nil(t, ResourceCluster(&types.ResourceBase{}))
+}
@@ -0,0 +1,188 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig/types"
+)
+
+// deprecatedTypes maps deprecated resource type names to their replacement
+var deprecatedTypes = map[string]string{}
+
+// attributeAliases maps a resource type to its deprecated attribute names
+// and the attributes that replace them
+var attributeAliases = map[string]map[string]string{}
+
+// Deprecation describes the use of a deprecated resource type or attribute
+// in a blueprint
+type Deprecation struct {
+	ResourceID string
+	File       string
+	Line       int
+	Message    string
+}
+
+func (d Deprecation) String() string {
+	if d.File == "" {
+		return fmt.Sprintf("%s: %s", d.ResourceID, d.Message)
+	}
+
+	return fmt.Sprintf("%s:%d: %s: %s", d.File, d.Line, d.ResourceID, d.Message)
+}
+
+// RegisterResourceAlias registers alias as another name for the resource
+// type name, blueprints using the alias are decoded into the same resource
+// and created by the same provider. The resource type must be registered
+// before the alias.
+func RegisterResourceAlias(alias, name string) {
+	RegisterResource(alias, registeredTypes[name], registeredProviders[name])
+}
+
+// RegisterDeprecatedResource registers a previous name for the resource type
+// name, blueprints using the previous name continue to work and a warning
+// is reported when they are parsed.
+func RegisterDeprecatedResource(previous, name string) {
+	RegisterResourceAlias(previous, name)
+	deprecatedTypes[previous] = name
+}
+
+// RegisterAttributeAlias registers a previous name for an attribute of a
+// resource type. The resource must keep a field with the previous name in
+// its hcl tag so that blueprints using it can be decoded, the value is
+// copied to the field for attribute when the attribute is not set and a
+// warning is reported when the blueprint is parsed.
+func RegisterAttributeAlias(resourceType, previous, attribute string) {
+	r, ok := registeredTypes[resourceType]
+	if !ok {
+		panic(fmt.Sprintf("unable to register alias for attribute %s, resource type %s is not registered", attribute, resourceType))
+	}
+
+	v := reflect.ValueOf(r)
+	if !hclField(v, previous).IsValid() || !hclField(v, attribute).IsValid() {
+		panic(fmt.Sprintf("unable to register alias %s for attribute %s, resource type %s must have fields for both attributes", previous, attribute, resourceType))
+	}
+
+	if attributeAliases[resourceType] == nil {
+		attributeAliases[resourceType] = map[string]string{}
+	}
+
+	attributeAliases[resourceType][previous] = attribute
+}
+
+// DeprecatedResources returns the deprecated resource type names and the
+// types that replace them
+func DeprecatedResources() map[string]string {
+	d := map[string]string{}
+	for k, v := range deprecatedTypes {
+		d[k] = v
+	}
+
+	return d
+}
+
+// AttributeAliases returns the deprecated attribute names for the resource
+// type and the attributes that replace them
+func AttributeAliases(resourceType string) map[string]string {
+	a := map[string]string{}
+	for k, v := range attributeAliases[resourceTypeFor(resourceType)] {
+		a[k] = v
+	}
+
+	return a
+}
+
+// Deprecations returns the deprecated resource types and attributes used by
+// the resource
+func Deprecations(r types.Resource) []Deprecation {
+	d := []Deprecation{}
+	meta := r.Metadata()
+
+	if name, ok := deprecatedTypes[meta.Type]; ok {
+		d = append(d, Deprecation{
+			ResourceID: meta.ID,
+			File:       meta.File,
+			Line:       meta.Line,
+			Message:    fmt.Sprintf(`resource type "%s" is deprecated, use "%s"`, meta.Type, name),
+		})
+	}
+
+	v := reflect.ValueOf(r)
+	for previous, attribute := range attributeAliases[resourceTypeFor(meta.Type)] {
+		f := hclField(v, previous)
+		if f.IsValid() && !f.IsZero() {
+			d = append(d, Deprecation{
+				ResourceID: meta.ID,
+				File:       meta.File,
+				Line:       meta.Line,
+				Message:    fmt.Sprintf(`attribute "%s" is deprecated, use "%s"`, previous, attribute),
+			})
+		}
+	}
+
+	return d
+}
+
+// resolveAttributeAliases copies the values of deprecated attributes to the
+// attributes that replace them, values set with the new attribute take
+// precedence
+func resolveAttributeAliases(r types.Resource) {
+	v := reflect.ValueOf(r)
+
+	for previous, attribute := range attributeAliases[resourceTypeFor(r.Metadata().Type)] {
+		from := hclField(v, previous)
+		to := hclField(v, attribute)
+
+		if !from.IsValid() || !to.IsValid() || from.IsZero() || !to.IsZero() || !to.CanSet() {
+			continue
+		}
+
+		if from.Type().AssignableTo(to.Type()) {
+			to.Set(from)
+		}
+	}
+}
+
+// resourceTypeFor returns the type that attribute aliases are registered
+// for, deprecated type names share the aliases of their replacement
+func resourceTypeFor(t string) string {
+	if name, ok := deprecatedTypes[t]; ok {
+		return name
+	}
+
+	return t
+}
+
+// hclField returns the field of the struct with the given name in its hcl
+// tag, fields of embedded structs are included
+func hclField(v reflect.Value, name string) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		tag := strings.Split(t.Field(i).Tag.Get("hcl"), ",")
+
+		if tag[0] == name {
+			return v.Field(i)
+		}
+
+		if t.Field(i).Anonymous && len(tag) > 1 && tag[1] == "remain" {
+			if f := hclField(v.Field(i), name); f.IsValid() {
+				return f
+			}
+		}
+	}
+
+	return reflect.Value{}
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/stretchr/testify/require"
+)
+
+type renamedResource struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Image     string `hcl:"image,optional"`
+	ImageName string `hcl:"image_name,optional" json:"-"`
+}
+
+func setupDeprecations(t *testing.T) {
+	RegisterResource("renamed", &renamedResource{}, nil)
+	RegisterDeprecatedResource("old_renamed", "renamed")
+	RegisterAttributeAlias("renamed", "image_name", "image")
+
+	t.Cleanup(func() {
+		delete(registeredTypes, "renamed")
+		delete(registeredTypes, "old_renamed")
+		delete(deprecatedTypes, "old_renamed")
+		delete(attributeAliases, "renamed")
+	})
+}
+
+func newRenamedResource(resourceType string) *renamedResource {
+	r := &renamedResource{}
+	r.Meta.ID = "resource." + resourceType + ".test"
+	r.Meta.Type = resourceType
+	r.Meta.File = "main.hcl"
+	r.Meta.Line = 3
+
+	return r
+}
+
+func TestRegisterDeprecatedResourceRegistersAlias(t *testing.T) {
+	setupDeprecations(t)
+
+	require.True(t, ResourceRegistered("old_renamed"))
+	require.Equal(t, map[string]string{"old_renamed": "renamed"}, DeprecatedResources())
+	require.Equal(t, map[string]string{"image_name": "image"}, AttributeAliases("old_renamed"))
+}
+
+func TestRegisterAttributeAliasWithMissingFieldPanics(t *testing.T) {
+	setupDeprecations(t)
+
+	require.Panics(t, func() {
+		RegisterAttributeAlias("renamed", "tag", "image")
+	})
+}
+
+func TestDeprecationsReturnsDeprecatedType(t *testing.T) {
+	setupDeprecations(t)
+
+	d := Deprecations(newRenamedResource("old_renamed"))
+
+	require.Len(t, d, 1)
+	require.Equal(t, "resource.old_renamed.test", d[0].ResourceID)
+	require.Equal(t, `main.hcl:3: resource.old_renamed.test: resource type "old_renamed" is deprecated, use "renamed"`, d[0].String())
+}
+
+func TestDeprecationsReturnsDeprecatedAttribute(t *testing.T) {
+	setupDeprecations(t)
+
+	r := newRenamedResource("renamed")
+	r.ImageName = "consul:1.16"
+
+	d := Deprecations(r)
+
+	require.Len(t, d, 1)
+	require.Equal(t, `attribute "image_name" is deprecated, use "image"`, d[0].Message)
+}
+
+func TestDeprecationsWithCurrentConfigReturnsEmpty(t *testing.T) {
+	setupDeprecations(t)
+
+	r := newRenamedResource("renamed")
+	r.Image = "consul:1.16"
+
+	require.Empty(t, Deprecations(r))
+}
+
+func TestResolveAttributeAliasesCopiesValue(t *testing.T) {
+	setupDeprecations(t)
+
+	r := newRenamedResource("old_renamed")
+	r.ImageName = "consul:1.16"
+
+	resolveAttributeAliases(r)
+
+	require.Equal(t, "consul:1.16", r.Image)
+}
+
+func TestResolveAttributeAliasesDoesNotReplaceSetValue(t *testing.T) {
+	setupDeprecations(t)
+
+	r := newRenamedResource("renamed")
+	r.Image = "consul:1.17"
+	r.ImageName = "consul:1.16"
+
+	resolveAttributeAliases(r)
+
+	require.Equal(t, "consul:1.17", r.Image)
+}
+
+func TestParserResolvesDeprecatedConfig(t *testing.T) {
+	setupDeprecations(t)
+
+	path := filepath.Join(t.TempDir(), "main.hcl")
+	err := os.WriteFile(path, []byte(`
+resource "old_renamed" "test" {
+  image_name = "consul:1.16"
+}
+`), 0644)
+	require.NoError(t, err)
+
+	var found *renamedResource
+	p := NewParser(func(r types.Resource) error {
+		if rr, ok := r.(*renamedResource); ok {
+			found = rr
+		}
+
+		return nil
+	}, nil, nil)
+
+	_, err = p.ParseFile(path)
+	require.NoError(t, err)
+
+	require.NotNil(t, found)
+	require.Equal(t, "consul:1.16", found.Image)
+	require.Len(t, Deprecations(found), 2)
+}
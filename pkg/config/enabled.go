@@ -0,0 +1,41 @@
+package config
+
+import (
+	"reflect"
+
+	"github.com/jumppad-labs/hclconfig/types"
+)
+
+// ResourceEnabled returns false when the enabled meta-argument of the
+// resource is set to false. The argument is evaluated when the blueprint is
+// parsed so it can reference variables to make a resource optional:
+//
+//	resource "container" "prometheus" {
+//	  enabled = variable.monitoring
+//	  ...
+//	}
+//
+// Resources without the argument, or where it is not set, are enabled.
+func ResourceEnabled(r types.Resource) bool {
+	v := reflect.ValueOf(r)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return true
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return true
+	}
+
+	f := v.FieldByName("Enabled")
+	if !f.IsValid() {
+		return true
+	}
+
+	enabled, ok := f.Interface().(*bool)
+
+	return !ok || enabled == nil || *enabled
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/stretchr/testify/require"
+)
+
+type enabledResource struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+}
+
+func TestResourceEnabledWithoutValueReturnsTrue(t *testing.T) {
+	require.True(t, ResourceEnabled(&enabledResource{}))
+}
+
+func TestResourceEnabledWithTrueReturnsTrue(t *testing.T) {
+	enabled := true
+	require.True(t, ResourceEnabled(&enabledResource{Enabled: &enabled}))
+}
+
+func TestResourceEnabledWithFalseReturnsFalse(t *testing.T) {
+	enabled := false
+	require.False(t, ResourceEnabled(&enabledResource{Enabled: &enabled}))
+}
+
+func TestResourceEnabledWithoutArgumentReturnsTrue(t *testing.T) {
+	require.True(t, ResourceEnabled(&types.ResourceBase{}))
+}
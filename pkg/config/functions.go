@@ -58,3 +58,11 @@ func customHCLFuncExists(path string) (bool, error) {
 
 	return true, nil
 }
+
+// customHCLFuncSensitive marks the value as sensitive so that it is redacted
+// from logs and output and encrypted in the state, e.g. variables and outputs
+// can be marked with value = sensitive(variable.token)
+func customHCLFuncSensitive(value string) (string, error) {
+	utils.RegisterSensitive(value)
+	return value, nil
+}
@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,3 +24,38 @@ func TestExistsTrue(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, true, exists)
 }
+
+func TestSensitiveRegistersValue(t *testing.T) {
+	v, err := customHCLFuncSensitive("s3nsitive-value")
+	require.NoError(t, err)
+	require.Equal(t, "s3nsitive-value", v)
+
+	require.True(t, utils.ContainsSensitive("token=s3nsitive-value"))
+}
+
+func TestSensitiveInBlueprintEncryptsOutput(t *testing.T) {
+	testutils.SetupState(t, "")
+	t.Setenv(utils.SecretsKeyEnvVar, "")
+
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(`
+variable "token" {
+  default = sensitive("bl0eprint-t0ken")
+}
+
+output "token" {
+  value = variable.token
+}
+`), 0644)
+
+	c, err := NewParser(nil, nil, nil).ParseDirectory(dir)
+	require.NoError(t, err)
+
+	err = SaveState(c)
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(utils.StatePath())
+	require.NoError(t, err)
+	require.NotContains(t, string(d), "bl0eprint-t0ken")
+	require.Contains(t, string(d), "jumppad:enc:v1:")
+}
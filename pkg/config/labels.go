@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+const (
+	// LabelCreatedBy is added to the Docker containers and networks created
+	// by jumppad
+	LabelCreatedBy = "created_by"
+	// LabelResourceID contains the id of the resource that created a Docker
+	// container or network
+	LabelResourceID = "id"
+	// CreatedByJumppad is the value of the created_by label
+	CreatedByJumppad = "jumppad"
+)
+
+// Labelled is implemented by resources that support the labels attribute,
+// the labels are added to the objects created for the resource: Docker
+// labels for containers and networks, Kubernetes labels for objects applied
+// to a cluster, and Nomad meta for jobs and client nodes
+type Labelled interface {
+	GetLabels() map[string]string
+}
+
+// ResourceLabels returns the labels for the resource, nil is returned when
+// the resource does not support labels
+func ResourceLabels(r types.Resource) map[string]string {
+	l, ok := r.(Labelled)
+	if !ok {
+		return nil
+	}
+
+	return l.GetLabels()
+}
+
+// MergeLabels returns a new map containing the labels from each of the maps,
+// later maps replace the values of earlier maps
+func MergeLabels(labels ...map[string]string) map[string]string {
+	m := map[string]string{}
+	for _, l := range labels {
+		for k, v := range l {
+			m[k] = v
+		}
+	}
+
+	return m
+}
+
+// DockerLabels returns the labels for a Docker container or network created
+// for the resource, the labels identifying jumppad, the resource and the
+// environment can not be replaced by the resource labels
+func DockerLabels(r types.Resource) map[string]string {
+	return MergeLabels(ResourceLabels(r), map[string]string{
+		LabelCreatedBy:         CreatedByJumppad,
+		LabelResourceID:        r.Metadata().ID,
+		utils.EnvironmentLabel: utils.Environment(),
+	})
+}
+
+// LabelSelector matches resources by their labels, a selector without a
+// value matches resources that have the label set to any value
+type LabelSelector struct {
+	Key   string
+	Value string
+	Any   bool
+}
+
+// ParseLabelSelector parses a selector in the form key=value or key
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	key, value, ok := strings.Cut(s, "=")
+	if key == "" {
+		return LabelSelector{}, fmt.Errorf("invalid label selector %q, selectors must be in the form key=value or key", s)
+	}
+
+	return LabelSelector{Key: key, Value: value, Any: !ok}, nil
+}
+
+// Matches returns true when the resource has a label matching the selector
+func (l LabelSelector) Matches(r types.Resource) bool {
+	v, ok := ResourceLabels(r)[l.Key]
+	if !ok {
+		return false
+	}
+
+	return l.Any || v == l.Value
+}
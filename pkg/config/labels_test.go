@@ -0,0 +1,84 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+type labelledResource struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Labels map[string]string
+}
+
+func (l *labelledResource) GetLabels() map[string]string {
+	return l.Labels
+}
+
+func TestResourceLabelsReturnsLabels(t *testing.T) {
+	r := &labelledResource{Labels: map[string]string{"team": "platform"}}
+
+	require.Equal(t, map[string]string{"team": "platform"}, ResourceLabels(r))
+}
+
+func TestResourceLabelsWithUnlabelledResourceReturnsNil(t *testing.T) {
+	require.Nil(t, ResourceLabels(&types.ResourceBase{}))
+}
+
+func TestMergeLabelsReplacesEarlierValues(t *testing.T) {
+	m := MergeLabels(map[string]string{"team": "web", "env": "dev"}, nil, map[string]string{"team": "platform"})
+
+	require.Equal(t, map[string]string{"team": "platform", "env": "dev"}, m)
+}
+
+func TestParseLabelSelectorWithValue(t *testing.T) {
+	ls, err := ParseLabelSelector("team=platform")
+	require.NoError(t, err)
+
+	require.Equal(t, LabelSelector{Key: "team", Value: "platform"}, ls)
+}
+
+func TestParseLabelSelectorWithoutValueMatchesAny(t *testing.T) {
+	ls, err := ParseLabelSelector("team")
+	require.NoError(t, err)
+
+	require.Equal(t, LabelSelector{Key: "team", Any: true}, ls)
+}
+
+func TestParseLabelSelectorWithEmptyKeyReturnsError(t *testing.T) {
+	_, err := ParseLabelSelector("=platform")
+	require.ErrorContains(t, err, "invalid label selector")
+}
+
+func TestLabelSelectorMatchesResources(t *testing.T) {
+	r := &labelledResource{Labels: map[string]string{"team": "platform"}}
+
+	require.True(t, LabelSelector{Key: "team", Value: "platform"}.Matches(r))
+	require.True(t, LabelSelector{Key: "team", Any: true}.Matches(r))
+}
+
+func TestLabelSelectorDoesNotMatchOtherResources(t *testing.T) {
+	r := &labelledResource{Labels: map[string]string{"team": "platform"}}
+
+	require.False(t, LabelSelector{Key: "team", Value: "web"}.Matches(r))
+	require.False(t, LabelSelector{Key: "env", Any: true}.Matches(r))
+	require.False(t, LabelSelector{Key: "team", Any: true}.Matches(&types.ResourceBase{}))
+}
+
+func TestDockerLabelsAddsJumppadLabels(t *testing.T) {
+	t.Setenv(utils.EnvironmentEnvVar, "staging")
+
+	r := &labelledResource{Labels: map[string]string{"team": "platform", "id": "custom", "environment": "dev", "dev.jumppad.environment": "custom"}}
+	r.Meta.ID = "resource.container.app"
+
+	require.Equal(t, map[string]string{
+		"team":                    "platform",
+		"created_by":              "jumppad",
+		"id":                      "resource.container.app",
+		"environment":             "dev",
+		"dev.jumppad.environment": "staging",
+	}, DockerLabels(r))
+}
@@ -1,10 +1,14 @@
 package config
 
 import (
+	"os"
 	"reflect"
+	"sync"
 
 	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
 
@@ -27,10 +31,14 @@ type Providers interface {
 
 type ProvidersImpl struct {
 	clients *clients.Clients
+
+	// loggers that write to the log file for each resource keyed by id
+	logs      map[string]logger.Logger
+	logsMutex sync.Mutex
 }
 
 func NewProviders(c *clients.Clients) Providers {
-	return &ProvidersImpl{c}
+	return &ProvidersImpl{clients: c, logs: map[string]logger.Logger{}}
 }
 
 func (p *ProvidersImpl) GetProvider(r types.Resource) sdk.Provider {
@@ -39,10 +47,35 @@ func (p *ProvidersImpl) GetProvider(r types.Resource) sdk.Provider {
 		ptr := reflect.New(reflect.TypeOf(t).Elem())
 
 		prov := ptr.Interface().(Provider)
-		prov.Init(r, p.clients.Logger)
+		prov.Init(r, p.resourceLogger(r))
 
 		return prov
 	}
 
 	return nil
 }
+
+// resourceLogger returns a logger that writes to the main logger and to the
+// log file for the resource, all messages are written to the file regardless
+// of the log level so that failures can be investigated after the fact
+func (p *ProvidersImpl) resourceLogger(r types.Resource) logger.Logger {
+	p.logsMutex.Lock()
+	defer p.logsMutex.Unlock()
+
+	id := r.Metadata().ID
+
+	// providers are created many times for a resource, open the file once
+	fl, ok := p.logs[id]
+	if !ok {
+		f, err := os.OpenFile(utils.ResourceLogFile(id), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			p.clients.Logger.Debug("Unable to open log file for resource", "ref", id, "error", err)
+			return p.clients.Logger
+		}
+
+		fl = logger.NewFileLogger(f, logger.LogLevelDebug)
+		p.logs[id] = fl
+	}
+
+	return logger.NewTeeLogger(p.clients.Logger, fl)
+}
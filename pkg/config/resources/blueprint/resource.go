@@ -9,6 +9,8 @@ const TypeBlueprint string = "blueprint"
 type Blueprint struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Title        string   `hcl:"title,optional" json:"title,omitempty"`
 	Organization string   `hcl:"organization,optional" json:"organization,omitempty"`
 	Author       string   `hcl:"author,optional" json:"author,omitempty"`
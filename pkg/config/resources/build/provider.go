@@ -8,6 +8,7 @@ import (
 	"github.com/jumppad-labs/jumppad/pkg/clients"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
@@ -195,6 +196,7 @@ func (b *Provider) copyOutputs() error {
 		},
 		Entrypoint: []string{},
 		Command:    []string{"tail", "-f", "/dev/null"},
+		Labels:     config.DockerLabels(b.config),
 	}
 
 	b.log.Debug("Creating container to copy files", "ref", b.config.Meta.ID, "name", b.config.Image)
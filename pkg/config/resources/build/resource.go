@@ -18,6 +18,8 @@ type Build struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Container BuildContainer `hcl:"container,block" json:"container"`
 
 	// Outputs allow files or directories to be copied from the container
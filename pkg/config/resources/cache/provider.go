@@ -14,6 +14,7 @@ import (
 	"github.com/jumppad-labs/jumppad/pkg/clients/container"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
@@ -171,6 +172,8 @@ func (p *Provider) createImageCache(registries []string, authRegistries []string
 	cc := &types.Container{}
 	cc.Name = fqdn
 	cc.Image = &types.Image{Name: cacheImage}
+	cc.Resources = p.config.Resources.ToClientResources()
+	cc.Labels = config.DockerLabels(p.config)
 
 	cc.Volumes = []types.Volume{
 		{
@@ -195,6 +198,11 @@ func (p *Provider) createImageCache(registries []string, authRegistries []string
 		"VERIFY_SSL":              "false",
 	}
 
+	// the cache pulls the images for clusters, use the proxy from the host
+	for k, v := range utils.ProxyEnvironment() {
+		cc.Environment[k] = v
+	}
+
 	// expose the docker proxy port on a random port num
 	p1, err1 := utils.RandomAvailablePort(31000, 34000)
 	p2, err2 := utils.RandomAvailablePort(31000, 34000)
@@ -11,6 +11,7 @@ import (
 	cmocks "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
 	ctypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	ct "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/jumppad-labs/jumppad/testutils"
 	"github.com/stretchr/testify/mock"
@@ -86,6 +87,21 @@ func TestImageCacheCreateAddsVolumes(t *testing.T) {
 	require.Equal(t, "volume", conf.Volumes[0].Type)
 }
 
+func TestImageCacheCreateAddsResources(t *testing.T) {
+	cc, md := setupImageCacheTests()
+	cc.Resources = &ct.Resources{CPU: 500, Memory: 256}
+
+	c := Provider{cc, md, logger.NewTestLogger(t)}
+	err := c.Create(context.Background())
+	require.NoError(t, err)
+
+	params := testutils.GetCalls(&md.Mock, "CreateContainer")[0]
+	conf := params.Arguments[0].(*ctypes.Container)
+
+	require.Equal(t, 500, conf.Resources.CPU)
+	require.Equal(t, 256, conf.Resources.Memory)
+}
+
 func TestImageCacheCreateAddsEnvironmentVariables(t *testing.T) {
 	cc, md := setupImageCacheTests()
 
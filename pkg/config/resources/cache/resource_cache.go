@@ -13,7 +13,11 @@ type ImageCache struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Registries []Registry `hcl:"registry,block" json:"registries,omitempty"`
 
 	Networks ctypes.NetworkAttachments `hcl:"network,block" json:"networks,omitempty"` // Attach to the correct network // only when Image is specified
+
+	Resources *ctypes.Resources `hcl:"resources,block" json:"resources,omitempty"` // resource constraints for the cache container
 }
@@ -9,6 +9,8 @@ type Registry struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Hostname string        `hcl:"hostname" json:"hostname"`         // Hostname of the registry
 	Auth     *RegistryAuth `hcl:"auth,block" json:"auth,omitempty"` // auth to authenticate against registry
 }
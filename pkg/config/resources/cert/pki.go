@@ -0,0 +1,366 @@
+package cert
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"slices"
+	"time"
+	"unicode"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+const (
+	KeyTypeRSA   = "rsa"
+	KeyTypeECDSA = "ecdsa"
+)
+
+const defaultRSAKeySize = 4096
+const defaultECDSAKeySize = 256
+
+// CA certificates are valid for 10 years and leaf certificates for 1 year
+const defaultCAValidity = "87600h"
+const defaultLeafValidity = "8760h"
+
+// keyOptions defines the type of key and validity of a certificate
+type keyOptions struct {
+	keyType     string
+	keySize     int
+	validity    time.Duration
+	renewBefore time.Duration
+}
+
+// validateKeyOptions checks the key type, key size and durations and returns
+// the parsed options
+func validateKeyOptions(keyType string, keySize int, validity, renewBefore string) (*keyOptions, error) {
+	ko := &keyOptions{keyType: keyType, keySize: keySize}
+
+	switch keyType {
+	case KeyTypeRSA:
+		if keySize < 2048 {
+			return nil, fmt.Errorf("invalid key_size %d, RSA keys must be at least 2048 bits", keySize)
+		}
+	case KeyTypeECDSA:
+		if _, err := curve(keySize); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid key_type %q, must be one of %q or %q", keyType, KeyTypeRSA, KeyTypeECDSA)
+	}
+
+	var err error
+	ko.validity, err = time.ParseDuration(validity)
+	if err != nil || ko.validity <= 0 {
+		return nil, fmt.Errorf("invalid validity %q, must be a positive duration e.g. 8760h", validity)
+	}
+
+	if renewBefore != "" {
+		ko.renewBefore, err = time.ParseDuration(renewBefore)
+		if err != nil || ko.renewBefore < 0 {
+			return nil, fmt.Errorf("invalid renew_before %q, must be a duration e.g. 720h", renewBefore)
+		}
+	}
+
+	return ko, nil
+}
+
+func curve(size int) (elliptic.Curve, error) {
+	switch size {
+	case 256:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	case 521:
+		return elliptic.P521(), nil
+	}
+
+	return nil, fmt.Errorf("invalid key_size %d, ECDSA keys must be one of 256, 384 or 521", size)
+}
+
+// generateKey creates a new private key for the options
+func generateKey(ko *keyOptions) (crypto.Signer, error) {
+	if ko.keyType == KeyTypeECDSA {
+		c, err := curve(ko.keySize)
+		if err != nil {
+			return nil, err
+		}
+
+		return ecdsa.GenerateKey(c, rand.Reader)
+	}
+
+	return rsa.GenerateKey(rand.Reader, ko.keySize)
+}
+
+// keyType returns the key type for the public key algorithm of a certificate
+func keyType(c *x509.Certificate) string {
+	switch c.PublicKeyAlgorithm {
+	case x509.RSA:
+		return KeyTypeRSA
+	case x509.ECDSA:
+		return KeyTypeECDSA
+	}
+
+	return c.PublicKeyAlgorithm.String()
+}
+
+// privateKeyPEM encodes the private key, RSA keys are encoded as PKCS#1 for
+// compatibility with certificates generated by earlier versions
+func privateKeyPEM(k crypto.Signer) ([]byte, error) {
+	if rk, ok := k.(*rsa.PrivateKey); ok {
+		return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rk)}), nil
+	}
+
+	d, err := x509.MarshalPKCS8PrivateKey(k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: d}), nil
+}
+
+// publicKeyPEM encodes the public key, RSA keys are encoded as PKCS#1 for
+// compatibility with certificates generated by earlier versions
+func publicKeyPEM(k crypto.PublicKey) ([]byte, error) {
+	if rk, ok := k.(*rsa.PublicKey); ok {
+		return pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(rk)}), nil
+	}
+
+	d, err := x509.MarshalPKIXPublicKey(k)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode public key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: d}), nil
+}
+
+// publicKeyOpenSSH returns the base64 encoded OpenSSH public key
+func publicKeyOpenSSH(k crypto.PublicKey) (string, error) {
+	pub, err := ssh.NewPublicKey(k)
+	if err != nil {
+		return "", fmt.Errorf("unable to convert public key to ssh: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(pub.Marshal()), nil
+}
+
+// certificatePEM encodes the certificate
+func certificatePEM(c *x509.Certificate) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})
+}
+
+// readPrivateKey reads a PEM encoded RSA or ECDSA private key
+func readPrivateKey(path string) (crypto.Signer, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	b, _ := pem.Decode(d)
+	if b == nil {
+		return nil, fmt.Errorf("no PEM data found in %s", path)
+	}
+
+	switch b.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(b.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(b.Bytes)
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(b.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	s, ok := k.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type in %s", path)
+	}
+
+	return s, nil
+}
+
+// readCertificates reads all the PEM encoded certificates in a file, the
+// first certificate is the issuer and any following certificates its chain
+func readCertificates(path string) ([]*x509.Certificate, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseCertificates(d)
+}
+
+func parseCertificates(d []byte) ([]*x509.Certificate, error) {
+	certs := []*x509.Certificate{}
+
+	for {
+		var b *pem.Block
+		b, d = pem.Decode(d)
+		if b == nil {
+			break
+		}
+
+		if b.Type != "CERTIFICATE" {
+			continue
+		}
+
+		c, err := x509.ParseCertificate(b.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		certs = append(certs, c)
+	}
+
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificates found")
+	}
+
+	return certs, nil
+}
+
+// certTemplate returns a template for a certificate valid from now
+func certTemplate(name string, validity time.Duration) (*x509.Certificate, error) {
+	serialNumberLimit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serialNumber, err := rand.Int(rand.Reader, serialNumberLimit)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate serial number: %w", err)
+	}
+
+	now := time.Now()
+
+	return &x509.Certificate{
+		SerialNumber:          serialNumber,
+		Subject:               pkix.Name{Organization: []string{"Jumppad"}, CommonName: name},
+		NotBefore:             now.Add(-1 * time.Minute),
+		NotAfter:              now.Add(validity),
+		BasicConstraintsValid: true,
+	}, nil
+}
+
+// caTemplate returns a template for a root or intermediate CA
+func caTemplate(name string, validity time.Duration) (*x509.Certificate, error) {
+	tmpl, err := certTemplate(name, validity)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.IsCA = true
+	tmpl.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+
+	return tmpl, nil
+}
+
+// leafTemplate returns a template for a leaf certificate with the given SANs
+func leafTemplate(name string, ipAddresses, dnsNames []string, validity time.Duration) (*x509.Certificate, error) {
+	tmpl, err := certTemplate(name, validity)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment
+	tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}
+
+	for _, i := range ipAddresses {
+		ip := net.ParseIP(i)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid ip address %q", i)
+		}
+
+		tmpl.IPAddresses = append(tmpl.IPAddresses, ip)
+	}
+
+	tmpl.DNSNames = sanitizeDNSNames(dnsNames)
+
+	// generate a random spiffe id
+	spiffe, _ := url.Parse(fmt.Sprintf("spiffe://jumppad.dev/private/%d", time.Now().UnixNano()))
+	tmpl.URIs = []*url.URL{spiffe}
+
+	return tmpl, nil
+}
+
+// signCertificate creates the certificate from the template, when parent is nil
+// the certificate is self signed
+func signCertificate(tmpl, parent *x509.Certificate, pub crypto.PublicKey, signer crypto.Signer) (*x509.Certificate, error) {
+	if parent == nil {
+		parent = tmpl
+	}
+
+	d, err := x509.CreateCertificate(rand.Reader, tmpl, parent, pub, signer)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create certificate: %w", err)
+	}
+
+	return x509.ParseCertificate(d)
+}
+
+// pkcs12Bundle encodes the key, certificate and chain as a PKCS#12 archive
+func pkcs12Bundle(k crypto.Signer, c *x509.Certificate, chain []*x509.Certificate, password string) ([]byte, error) {
+	d, err := pkcs12.Modern.Encode(k, c, chain, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create PKCS#12 archive: %w", err)
+	}
+
+	return d, nil
+}
+
+// needsRenewal returns true when the certificate has expired or will expire
+// within the renewBefore duration
+func needsRenewal(c *x509.Certificate, renewBefore time.Duration) bool {
+	return time.Now().Add(renewBefore).After(c.NotAfter)
+}
+
+// sansChanged returns true when the SANs of the certificate do not match the
+// given ip addresses and dns names
+func sansChanged(c *x509.Certificate, ipAddresses, dnsNames []string) bool {
+	current := []string{}
+	for _, ip := range c.IPAddresses {
+		current = append(current, ip.String())
+	}
+
+	ips := []string{}
+	for _, i := range ipAddresses {
+		if ip := net.ParseIP(i); ip != nil {
+			ips = append(ips, ip.String())
+		}
+	}
+
+	return !equalSet(current, ips) || !equalSet(c.DNSNames, sanitizeDNSNames(dnsNames))
+}
+
+func equalSet(a, b []string) bool {
+	a = slices.Compact(slices.Sorted(slices.Values(a)))
+	b = slices.Compact(slices.Sorted(slices.Values(b)))
+
+	return slices.Equal(a, b)
+}
+
+// sanitizeDNSNames removes unicode characters from DNS names
+func sanitizeDNSNames(dnsNames []string) []string {
+	names := []string{}
+
+	for _, name := range dnsNames {
+		t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+		result, _, _ := transform.String(t, name)
+		names = append(names, result)
+	}
+
+	return names
+}
@@ -2,22 +2,20 @@ package cert
 
 import (
 	"context"
+	"crypto"
 	"crypto/x509"
 	"encoding/base64"
-	"encoding/pem"
-	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path"
 	"strings"
+	"time"
 
-	"github.com/jumppad-labs/connector/crypto"
 	htypes "github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 	"github.com/sethvargo/go-retry"
-	"golang.org/x/crypto/ssh"
 )
 
 type CAProvider struct {
@@ -60,79 +58,54 @@ func (p *CAProvider) Create(ctx context.Context) error {
 
 	p.log.Info("Creating CA Certificate", "ref", p.config.Meta.ID)
 
-	directory := strings.Replace(p.config.Meta.Module, ".", "_", -1)
-	directory = path.Join(p.config.Output, directory)
-	os.MkdirAll(directory, os.ModePerm)
-
-	keyFile := path.Join(directory, fmt.Sprintf("%s.key", p.config.Meta.Name))
-	publicKeyFile := path.Join(directory, fmt.Sprintf("%s.pub", p.config.Meta.Name))
-	publicSSHFile := path.Join(directory, fmt.Sprintf("%s.ssh", p.config.Meta.Name))
-	certificateFile := path.Join(directory, fmt.Sprintf("%s.cert", p.config.Meta.Name))
+	setKeyDefaults(&p.config.KeyType, &p.config.KeySize, &p.config.Validity, defaultCAValidity)
 
-	k, err := crypto.GenerateKeyPair()
+	ko, err := validateKeyOptions(p.config.KeyType, p.config.KeySize, p.config.Validity, p.config.RenewBefore)
 	if err != nil {
 		return err
 	}
 
-	ca, err := crypto.GenerateCA(p.config.Meta.Name, k.Private)
+	k, err := generateKey(ko)
 	if err != nil {
-		return err
+		return fmt.Errorf("unable to generate key: %w", err)
 	}
 
-	err = k.Private.WriteFile(keyFile)
+	tmpl, err := caTemplate(p.config.Meta.Name, ko.validity)
 	if err != nil {
 		return err
 	}
 
-	err = k.Public.WriteFile(publicKeyFile)
-	if err != nil {
-		return err
-	}
+	// a CA without an issuer is a self signed root CA
+	var parent *x509.Certificate
+	var chain []*x509.Certificate
+	signer := k
 
-	err = ca.WriteFile(certificateFile)
-	if err != nil {
-		return err
+	if p.config.CACert != "" {
+		chain, signer, err = readIssuer(p.config.CACert, p.config.CAKey)
+		if err != nil {
+			return err
+		}
+
+		parent = chain[0]
 	}
 
-	// output the public ssh key
-	ssh, err := publicPEMtoOpenSSH(k.Public.PEMBlock())
+	ca, err := signCertificate(tmpl, parent, k.Public(), signer)
 	if err != nil {
 		return err
 	}
 
-	err = os.WriteFile(publicSSHFile, []byte(ssh), os.ModePerm)
+	f, err := writeFiles(p.config.Output, p.config.Meta.Module, p.config.Meta.Name, k, ca, chain, p.config.PKCS12Password)
 	if err != nil {
 		return err
 	}
 
 	// set the outputs
-	p.config.Cert = File{
-		Path:      certificateFile,
-		Directory: directory,
-		Filename:  fmt.Sprintf("%s.cert", p.config.Meta.Name),
-		Contents:  ca.String(),
-	}
-
-	p.config.PrivateKey = File{
-		Path:      keyFile,
-		Directory: directory,
-		Filename:  fmt.Sprintf("%s.key", p.config.Meta.Name),
-		Contents:  k.Private.String(),
-	}
-
-	p.config.PublicKeyPEM = File{
-		Path:      publicKeyFile,
-		Directory: directory,
-		Filename:  fmt.Sprintf("%s.pub", p.config.Meta.Name),
-		Contents:  k.Public.String(),
-	}
-
-	p.config.PublicKeySSH = File{
-		Path:      publicSSHFile,
-		Directory: directory,
-		Filename:  fmt.Sprintf("%s.ssh", p.config.Meta.Name),
-		Contents:  ssh,
-	}
+	p.config.Cert = f.cert
+	p.config.PrivateKey = f.privateKey
+	p.config.PublicKeyPEM = f.publicKeyPEM
+	p.config.PublicKeySSH = f.publicKeySSH
+	p.config.Bundle = f.bundle
+	p.config.PKCS12 = f.pkcs12
 
 	return nil
 }
@@ -162,10 +135,14 @@ func (p *CAProvider) Refresh(ctx context.Context) error {
 	return nil
 }
 
+// Changed returns true when the certificate needs to be renewed because it
+// has expired, the key type has changed, or the issuing CA has changed
 func (p *CAProvider) Changed() (bool, error) {
-	p.log.Debug("Checking changes Leaf Certificate", "ref", p.config.Meta.ID)
+	p.log.Debug("Checking changes CA Certificate", "ref", p.config.Meta.ID)
 
-	return false, nil
+	_, changed := certificateChanged(p.log, p.config.Meta.ID, p.config.Cert.Contents, p.config.KeyType, p.config.CACert, p.config.RenewBefore)
+
+	return changed, nil
 }
 
 func (p *LeafProvider) Create(ctx context.Context) error {
@@ -176,95 +153,47 @@ func (p *LeafProvider) Create(ctx context.Context) error {
 
 	p.log.Info("Creating Leaf Certificate", "ref", p.config.Meta.ID)
 
-	directory := strings.Replace(p.config.Meta.Module, ".", "_", -1)
-	directory = path.Join(p.config.Output, directory)
-	os.MkdirAll(directory, os.ModePerm)
-
-	keyFile := path.Join(directory, fmt.Sprintf("%s-leaf.key", p.config.Meta.Name))
-	pubkeyFile := path.Join(directory, fmt.Sprintf("%s-leaf.pub", p.config.Meta.Name))
-	pubsshFile := path.Join(directory, fmt.Sprintf("%s-leaf.ssh", p.config.Meta.Name))
-	certFile := path.Join(directory, fmt.Sprintf("%s-leaf.cert", p.config.Meta.Name))
+	setKeyDefaults(&p.config.KeyType, &p.config.KeySize, &p.config.Validity, defaultLeafValidity)
 
-	ca := &crypto.X509{}
-	err := ca.ReadFile(p.config.CACert)
-	if err != nil {
-		return retry.RetryableError(fmt.Errorf("unable to read root certificate %s: %w", p.config.CACert, err))
-	}
-
-	rk := crypto.NewKeyPair()
-	err = rk.Private.ReadFile(p.config.CAKey)
-	if err != nil {
-		return retry.RetryableError(fmt.Errorf("unable to read root key %s: %w", p.config.CAKey, err))
-	}
-
-	k, err := crypto.GenerateKeyPair()
+	ko, err := validateKeyOptions(p.config.KeyType, p.config.KeySize, p.config.Validity, p.config.RenewBefore)
 	if err != nil {
 		return err
 	}
 
-	lc, err := crypto.GenerateLeaf(p.config.Meta.Name, p.config.IPAddresses, p.config.DNSNames, ca, rk.Private, k.Private)
+	chain, signer, err := readIssuer(p.config.CACert, p.config.CAKey)
 	if err != nil {
 		return err
 	}
 
-	// output the public ssh key
-	ssh, err := publicPEMtoOpenSSH(k.Public.PEMBlock())
+	k, err := generateKey(ko)
 	if err != nil {
-		return err
+		return fmt.Errorf("unable to generate key: %w", err)
 	}
 
-	// Save the certificate
-	err = lc.WriteFile(certFile)
+	tmpl, err := leafTemplate(p.config.Meta.Name, p.config.IPAddresses, p.config.DNSNames, ko.validity)
 	if err != nil {
 		return err
 	}
 
-	// Save the keys
-	err = k.Private.WriteFile(keyFile)
+	lc, err := signCertificate(tmpl, chain[0], k.Public(), signer)
 	if err != nil {
 		return err
 	}
 
-	err = k.Public.WriteFile(pubkeyFile)
-	if err != nil {
-		return err
-	}
-
-	err = os.WriteFile(pubsshFile, []byte(ssh), os.ModePerm)
+	f, err := writeFiles(p.config.Output, p.config.Meta.Module, fmt.Sprintf("%s-leaf", p.config.Meta.Name), k, lc, chain, p.config.PKCS12Password)
 	if err != nil {
 		return err
 	}
 
 	// set the outputs
-	p.config.PublicKeySSH = File{
-		Path:      pubsshFile,
-		Directory: directory,
-		Filename:  fmt.Sprintf("%s-leaf.ssh", p.config.Meta.Name),
-		Contents:  ssh,
-	}
-
-	p.config.PublicKeyPEM = File{
-		Path:      pubkeyFile,
-		Directory: directory,
-		Filename:  fmt.Sprintf("%s-leaf.pub", p.config.Meta.Name),
-		Contents:  k.Public.String(),
-	}
+	p.config.Cert = f.cert
+	p.config.PrivateKey = f.privateKey
+	p.config.PublicKeyPEM = f.publicKeyPEM
+	p.config.PublicKeySSH = f.publicKeySSH
+	p.config.Bundle = f.bundle
+	p.config.PKCS12 = f.pkcs12
 
-	p.config.Cert = File{
-		Path:      certFile,
-		Directory: directory,
-		Filename:  fmt.Sprintf("%s-leaf.cert", p.config.Meta.Name),
-		Contents:  lc.String(),
-	}
-
-	p.config.PrivateKey = File{
-		Path:      keyFile,
-		Directory: directory,
-		Filename:  fmt.Sprintf("%s-leaf.key", p.config.Meta.Name),
-		Contents:  k.Private.String(),
-	}
-
-	return err
+	return nil
 }
 
 func (p *LeafProvider) Destroy(ctx context.Context, force bool) error {
@@ -293,17 +222,166 @@ func (p *LeafProvider) Lookup() ([]string, error) {
 	return nil, nil
 }
 
+// Changed returns true when the certificate needs to be renewed because it
+// has expired, the key type or SANs have changed, or the issuing CA has changed
 func (p *LeafProvider) Changed() (bool, error) {
 	p.log.Debug("Checking changes Leaf Certificate", "ref", p.config.Meta.Name)
 
+	c, changed := certificateChanged(p.log, p.config.Meta.ID, p.config.Cert.Contents, p.config.KeyType, p.config.CACert, p.config.RenewBefore)
+	if changed {
+		return true, nil
+	}
+
+	if c != nil && sansChanged(c, p.config.IPAddresses, p.config.DNSNames) {
+		p.log.Debug("Certificate SANs have changed", "ref", p.config.Meta.ID)
+		return true, nil
+	}
+
 	return false, nil
 }
 
+// certificateChanged parses the existing certificate and checks if it needs to
+// be renewed. When the certificate has not been created nil and false are
+// returned.
+func certificateChanged(l sdk.Logger, id, contents, keyTyp, caCert, renewBefore string) (*x509.Certificate, bool) {
+	if contents == "" {
+		return nil, false
+	}
+
+	certs, err := parseCertificates([]byte(contents))
+	if err != nil {
+		l.Debug("Unable to parse existing certificate", "ref", id, "error", err)
+		return nil, true
+	}
+
+	c := certs[0]
+
+	rb, _ := time.ParseDuration(renewBefore)
+	if needsRenewal(c, rb) {
+		l.Debug("Certificate has expired", "ref", id, "not_after", c.NotAfter)
+		return c, true
+	}
+
+	if keyTyp != "" && keyType(c) != keyTyp {
+		l.Debug("Certificate key type has changed", "ref", id, "key_type", keyTyp)
+		return c, true
+	}
+
+	// if the issuer has been regenerated the certificate needs to be signed again
+	if caCert != "" {
+		issuer, err := readCertificates(caCert)
+		if err == nil && c.CheckSignatureFrom(issuer[0]) != nil {
+			l.Debug("Certificate issuer has changed", "ref", id)
+			return c, true
+		}
+	}
+
+	return c, false
+}
+
+// readIssuer reads the certificate chain and key of the issuing CA
+func readIssuer(caCert, caKey string) ([]*x509.Certificate, crypto.Signer, error) {
+	chain, err := readCertificates(caCert)
+	if err != nil {
+		return nil, nil, retry.RetryableError(fmt.Errorf("unable to read root certificate %s: %w", caCert, err))
+	}
+
+	k, err := readPrivateKey(caKey)
+	if err != nil {
+		return nil, nil, retry.RetryableError(fmt.Errorf("unable to read root key %s: %w", caKey, err))
+	}
+
+	return chain, k, nil
+}
+
+type certFiles struct {
+	privateKey   File
+	publicKeyPEM File
+	publicKeySSH File
+	cert         File
+	bundle       File
+	pkcs12       File
+}
+
+// writeFiles writes the key, certificate, bundle and PKCS#12 archive to the
+// output directory
+func writeFiles(output, module, name string, k crypto.Signer, c *x509.Certificate, chain []*x509.Certificate, password string) (*certFiles, error) {
+	directory := strings.Replace(module, ".", "_", -1)
+	directory = path.Join(output, directory)
+	os.MkdirAll(directory, os.ModePerm)
+
+	privateKey, err := privateKeyPEM(k)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := publicKeyPEM(k.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	// output the public ssh key
+	sshKey, err := publicKeyOpenSSH(k.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	cert := certificatePEM(c)
+
+	bundle := cert
+	for _, ca := range chain {
+		bundle = append(bundle, certificatePEM(ca)...)
+	}
+
+	p12, err := pkcs12Bundle(k, c, chain, password)
+	if err != nil {
+		return nil, err
+	}
+
+	files := &certFiles{}
+
+	for _, f := range []struct {
+		ext      string
+		contents []byte
+		perm     os.FileMode
+		output   *File
+	}{
+		{"key", privateKey, 0600, &files.privateKey},
+		{"pub", publicKey, os.ModePerm, &files.publicKeyPEM},
+		{"ssh", []byte(sshKey), os.ModePerm, &files.publicKeySSH},
+		{"cert", cert, os.ModePerm, &files.cert},
+		{"bundle", bundle, os.ModePerm, &files.bundle},
+		{"p12", p12, 0600, &files.pkcs12},
+	} {
+		filename := fmt.Sprintf("%s.%s", name, f.ext)
+		fp := path.Join(directory, filename)
+
+		err := os.WriteFile(fp, f.contents, f.perm)
+		if err != nil {
+			return nil, fmt.Errorf("unable to write %s: %w", fp, err)
+		}
+
+		*f.output = File{
+			Path:      fp,
+			Directory: directory,
+			Filename:  filename,
+			Contents:  string(f.contents),
+		}
+	}
+
+	// the PKCS#12 archive is binary, output the contents as base64
+	files.pkcs12.Contents = base64.StdEncoding.EncodeToString(p12)
+
+	return files, nil
+}
+
 func destroy(module, name, output string, log logger.Logger) error {
 	keyFile := path.Join(output, fmt.Sprintf("%s.key", name))
 	pubkeyFile := path.Join(output, fmt.Sprintf("%s.pub", name))
 	pubsshFile := path.Join(output, fmt.Sprintf("%s.ssh", name))
 	certFile := path.Join(output, fmt.Sprintf("%s.cert", name))
+	bundleFile := path.Join(output, fmt.Sprintf("%s.bundle", name))
+	pkcs12File := path.Join(output, fmt.Sprintf("%s.p12", name))
 
 	err := os.Remove(keyFile)
 	if err != nil {
@@ -325,6 +403,16 @@ func destroy(module, name, output string, log logger.Logger) error {
 		log.Debug("Unable to remove certificate", "ref", name, "error", err)
 	}
 
+	err = os.Remove(bundleFile)
+	if err != nil {
+		log.Debug("Unable to remove bundle", "ref", name, "error", err)
+	}
+
+	err = os.Remove(pkcs12File)
+	if err != nil {
+		log.Debug("Unable to remove PKCS#12 archive", "ref", name, "error", err)
+	}
+
 	// if there is a module directory and it is empty, remove it
 	if module != "" {
 		directory := strings.Replace(module, ".", "_", -1)
@@ -351,38 +439,3 @@ func isEmpty(name string) (bool, error) {
 	}
 	return false, err // Either not empty or error, suits both cases
 }
-
-// thanks to https://gist.github.com/sriramsa/68d150ad50db4828f139e60a0efbde5a
-func publicPEMtoOpenSSH(pemBytes []byte) (string, error) {
-	// Decode and get the first block in the PEM file.
-	// In our case it should be the Public key block.
-	pemBlock, rest := pem.Decode(pemBytes)
-	if pemBlock == nil {
-		return "", errors.New("invalid PEM public key passed, pem.Decode() did not find a public key")
-	}
-	if len(rest) > 0 {
-		return "", errors.New("PEM block contains more than just public key")
-	}
-
-	// Confirm we got the PUBLIC KEY block type
-	if pemBlock.Type != "RSA PUBLIC KEY" {
-		return "", fmt.Errorf("ssh: unsupported key type %q", pemBlock.Type)
-	}
-
-	// Convert to rsa
-	rsaPubKey, err := x509.ParsePKCS1PublicKey(pemBlock.Bytes)
-	if err != nil {
-		return "", fmt.Errorf("x509.parse pki public key: %w", err)
-	}
-
-	// Generate the ssh public key
-	pub, err := ssh.NewPublicKey(rsaPubKey)
-	if err != nil {
-		return "", fmt.Errorf("new ssh public key from pem converted to rsa: %w", err)
-	}
-
-	// Encode to store to file
-	sshPubKey := base64.StdEncoding.EncodeToString(pub.Marshal())
-
-	return sshPubKey, nil
-}
@@ -2,6 +2,7 @@ package cert
 
 import (
 	"context"
+	"crypto/x509"
 	"fmt"
 	"path"
 	"testing"
@@ -94,3 +95,115 @@ func TestDestroyCleansUpLeaf(t *testing.T) {
 	require.NoFileExists(t, path.Join(c.Output, fmt.Sprintf("%s-leaf.pub", c.Meta.Name)))
 	require.NoFileExists(t, path.Join(c.Output, fmt.Sprintf("%s-leaf.ssh", c.Meta.Name)))
 }
+
+func TestGeneratesValidECDSALeaf(t *testing.T) {
+	c, p := setupLeafCert(t)
+	c.KeyType = KeyTypeECDSA
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	certs, err := parseCertificates([]byte(c.Cert.Contents))
+	require.NoError(t, err)
+
+	require.Equal(t, KeyTypeECDSA, keyType(certs[0]))
+	require.FileExists(t, path.Join(c.Output, fmt.Sprintf("%s-leaf.p12", c.Meta.Name)))
+}
+
+func TestGeneratesIntermediateChain(t *testing.T) {
+	root, rp := setupCACert(t)
+
+	err := rp.Create(context.Background())
+	require.NoError(t, err)
+
+	inter := &CertificateCA{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "intermediate"}}}
+	inter.Output = t.TempDir()
+	inter.CACert = root.Bundle.Path
+	inter.CAKey = root.PrivateKey.Path
+	inter.KeyType = KeyTypeECDSA
+
+	ip := &CAProvider{inter, logger.NewTestLogger(t)}
+
+	err = ip.Create(context.Background())
+	require.NoError(t, err)
+
+	cl := &CertificateLeaf{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "test"}}}
+	cl.Output = t.TempDir()
+	cl.DNSNames = []string{"localhost"}
+	cl.CACert = inter.Bundle.Path
+	cl.CAKey = inter.PrivateKey.Path
+
+	lp := &LeafProvider{cl, logger.NewTestLogger(t)}
+
+	err = lp.Create(context.Background())
+	require.NoError(t, err)
+
+	// bundle should contain leaf, intermediate and root
+	bundle, err := readCertificates(cl.Bundle.Path)
+	require.NoError(t, err)
+	require.Len(t, bundle, 3)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(bundle[2])
+
+	intermediates := x509.NewCertPool()
+	intermediates.AddCert(bundle[1])
+
+	_, err = bundle[0].Verify(x509.VerifyOptions{DNSName: "localhost", Roots: pool, Intermediates: intermediates})
+	require.NoError(t, err)
+}
+
+func TestLeafChangedReturnsFalseWhenNotModified(t *testing.T) {
+	_, p := setupLeafCert(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.False(t, c)
+}
+
+func TestLeafChangedReturnsTrueWhenSANsChange(t *testing.T) {
+	cl, p := setupLeafCert(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	cl.DNSNames = append(cl.DNSNames, "jumppad.local")
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, c)
+}
+
+func TestLeafChangedReturnsTrueWhenExpiring(t *testing.T) {
+	cl, p := setupLeafCert(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	cl.RenewBefore = "87600h"
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, c)
+}
+
+func TestLeafChangedReturnsTrueWhenCAChanges(t *testing.T) {
+	cl, p := setupLeafCert(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	// generate a new CA
+	ca, cp := setupCACert(t)
+	err = cp.Create(context.Background())
+	require.NoError(t, err)
+
+	cl.CACert = ca.Cert.Path
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, c)
+}
@@ -1,6 +1,8 @@
 package cert
 
 import (
+	"fmt"
+
 	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
@@ -13,9 +15,29 @@ const TypeCertificateCA string = "certificate_ca"
 type CertificateCA struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	// Output directory to write the certificate and key too
 	Output string `hcl:"output" json:"output"`
 
+	// CAKey and CACert are optional, when set an intermediate CA signed by the
+	// given CA is created. To include the full chain in the bundle set CACert
+	// to the bundle of the issuing CA.
+	CAKey  string `hcl:"ca_key,optional" json:"ca_key,omitempty"`
+	CACert string `hcl:"ca_cert,optional" json:"ca_cert,omitempty"`
+
+	// KeyType is the type of key to generate, rsa or ecdsa, defaults to rsa
+	KeyType string `hcl:"key_type,optional" json:"key_type,omitempty"`
+	// KeySize is the size of the key in bits, defaults to 4096 for rsa and 256 for ecdsa
+	KeySize int `hcl:"key_size,optional" json:"key_size,omitempty"`
+	// Validity is the duration the certificate is valid for, defaults to 87600h
+	Validity string `hcl:"validity,optional" json:"validity,omitempty"`
+	// RenewBefore renews the certificate when it expires within the given duration,
+	// by default the certificate is only renewed once it has expired
+	RenewBefore string `hcl:"renew_before,optional" json:"renew_before,omitempty"`
+	// PKCS12Password is the password used to encrypt the PKCS#12 file
+	PKCS12Password string `hcl:"pkcs12_password,optional" json:"pkcs12_password,omitempty"`
+
 	// output parameters
 
 	// Key is the value related to the certificate key
@@ -27,14 +49,39 @@ type CertificateCA struct {
 
 	// Cert is the value related to the certificate
 	Cert File `hcl:"certificate,optional" json:"certificate"`
+
+	// Bundle contains the certificate followed by the certificates of the issuing CAs
+	Bundle File `hcl:"bundle,optional" json:"bundle"`
+
+	// PKCS12 contains the private key, certificate and chain as a PKCS#12 archive
+	PKCS12 File `hcl:"pkcs12,optional" json:"pkcs12"`
 }
 
 func (c *CertificateCA) Process() error {
 	c.Output = utils.EnsureAbsolute(c.Output, c.Meta.File)
+
+	if (c.CAKey == "") != (c.CACert == "") {
+		return fmt.Errorf("ca_key and ca_cert must both be specified to create an intermediate CA")
+	}
+
+	if c.CAKey != "" {
+		c.CAKey = utils.EnsureAbsolute(c.CAKey, c.Meta.File)
+		c.CACert = utils.EnsureAbsolute(c.CACert, c.Meta.File)
+	}
+
+	setKeyDefaults(&c.KeyType, &c.KeySize, &c.Validity, defaultCAValidity)
+
+	_, err := validateKeyOptions(c.KeyType, c.KeySize, c.Validity, c.RenewBefore)
+	if err != nil {
+		return err
+	}
+
 	c.PrivateKey = File{}
 	c.PublicKeySSH = File{}
 	c.PublicKeyPEM = File{}
 	c.Cert = File{}
+	c.Bundle = File{}
+	c.PKCS12 = File{}
 
 	// do we have an existing resource in the state?
 	// if so we need to set any computed resources for dependents
@@ -48,6 +95,8 @@ func (c *CertificateCA) Process() error {
 			c.PublicKeySSH = kstate.PublicKeySSH
 			c.PublicKeyPEM = kstate.PublicKeyPEM
 			c.Cert = kstate.Cert
+			c.Bundle = kstate.Bundle
+			c.PKCS12 = kstate.PKCS12
 		}
 	}
 
@@ -61,6 +110,8 @@ const TypeCertificateLeaf string = "certificate_leaf"
 type CertificateLeaf struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	CAKey  string `hcl:"ca_key" json:"ca_key"`   // Path to the primary key for the root CA
 	CACert string `hcl:"ca_cert" json:"ca_cert"` // Path to the root CA
 
@@ -69,6 +120,18 @@ type CertificateLeaf struct {
 
 	Output string `hcl:"output" json:"output"` // output location for the certificate
 
+	// KeyType is the type of key to generate, rsa or ecdsa, defaults to rsa
+	KeyType string `hcl:"key_type,optional" json:"key_type,omitempty"`
+	// KeySize is the size of the key in bits, defaults to 4096 for rsa and 256 for ecdsa
+	KeySize int `hcl:"key_size,optional" json:"key_size,omitempty"`
+	// Validity is the duration the certificate is valid for, defaults to 8760h
+	Validity string `hcl:"validity,optional" json:"validity,omitempty"`
+	// RenewBefore renews the certificate when it expires within the given duration,
+	// by default the certificate is only renewed once it has expired
+	RenewBefore string `hcl:"renew_before,optional" json:"renew_before,omitempty"`
+	// PKCS12Password is the password used to encrypt the PKCS#12 file
+	PKCS12Password string `hcl:"pkcs12_password,optional" json:"pkcs12_password,omitempty"`
+
 	// output parameters
 
 	// Key is the value related to the certificate key
@@ -80,16 +143,32 @@ type CertificateLeaf struct {
 
 	// Cert is the value related to the certificate
 	Cert File `hcl:"certificate,optional" json:"certificate"`
+
+	// Bundle contains the certificate followed by the certificates of the issuing CAs
+	Bundle File `hcl:"bundle,optional" json:"bundle"`
+
+	// PKCS12 contains the private key, certificate and chain as a PKCS#12 archive
+	PKCS12 File `hcl:"pkcs12,optional" json:"pkcs12"`
 }
 
 func (c *CertificateLeaf) Process() error {
 	c.CACert = utils.EnsureAbsolute(c.CACert, c.Meta.File)
 	c.CAKey = utils.EnsureAbsolute(c.CAKey, c.Meta.File)
 	c.Output = utils.EnsureAbsolute(c.Output, c.Meta.File)
+
+	setKeyDefaults(&c.KeyType, &c.KeySize, &c.Validity, defaultLeafValidity)
+
+	_, err := validateKeyOptions(c.KeyType, c.KeySize, c.Validity, c.RenewBefore)
+	if err != nil {
+		return err
+	}
+
 	c.PrivateKey = File{}
 	c.PublicKeySSH = File{}
 	c.PublicKeyPEM = File{}
 	c.Cert = File{}
+	c.Bundle = File{}
+	c.PKCS12 = File{}
 
 	// do we have an existing resource in the state?
 	// if so we need to set any computed resources for dependents
@@ -103,12 +182,32 @@ func (c *CertificateLeaf) Process() error {
 			c.PublicKeySSH = kstate.PublicKeySSH
 			c.PublicKeyPEM = kstate.PublicKeyPEM
 			c.Cert = kstate.Cert
+			c.Bundle = kstate.Bundle
+			c.PKCS12 = kstate.PKCS12
 		}
 	}
 
 	return nil
 }
 
+// setKeyDefaults sets the default key type, size and validity
+func setKeyDefaults(keyType *string, keySize *int, validity *string, defaultValidity string) {
+	if *keyType == "" {
+		*keyType = KeyTypeRSA
+	}
+
+	if *keySize == 0 {
+		*keySize = defaultRSAKeySize
+		if *keyType == KeyTypeECDSA {
+			*keySize = defaultECDSAKeySize
+		}
+	}
+
+	if *validity == "" {
+		*validity = defaultValidity
+	}
+}
+
 type File struct {
 	Filename  string `hcl:"filename,optional" json:"filename"`
 	Directory string `hcl:"directory,optional" json:"directory"`
@@ -139,3 +139,51 @@ func TestCertLeafLoadsValuesFromState(t *testing.T) {
 	require.Equal(t, "private.key", ca.PrivateKey.Filename)
 	require.Equal(t, "cert.pem", ca.Cert.Filename)
 }
+
+func TestCertCAProcessSetsDefaults(t *testing.T) {
+	ca := &CertificateCA{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Output:       "./output",
+	}
+
+	err := ca.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, KeyTypeRSA, ca.KeyType)
+	require.Equal(t, defaultRSAKeySize, ca.KeySize)
+	require.Equal(t, defaultCAValidity, ca.Validity)
+}
+
+func TestCertCAProcessReturnsErrorWhenOnlyCAKeySet(t *testing.T) {
+	ca := &CertificateCA{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Output:       "./output",
+		CAKey:        "./root.key",
+	}
+
+	err := ca.Process()
+	require.Error(t, err)
+}
+
+func TestCertLeafProcessReturnsErrorWhenKeyTypeInvalid(t *testing.T) {
+	cl := &CertificateLeaf{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Output:       "./output",
+		KeyType:      "dsa",
+	}
+
+	err := cl.Process()
+	require.Error(t, err)
+}
+
+func TestCertLeafProcessReturnsErrorWhenECDSASizeInvalid(t *testing.T) {
+	cl := &CertificateLeaf{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Output:       "./output",
+		KeyType:      KeyTypeECDSA,
+		KeySize:      2048,
+	}
+
+	err := cl.Process()
+	require.Error(t, err)
+}
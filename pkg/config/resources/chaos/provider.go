@@ -0,0 +1,172 @@
+package chaos
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	chaosClient "github.com/jumppad-labs/jumppad/pkg/clients/chaos"
+	cmdClient "github.com/jumppad-labs/jumppad/pkg/clients/command"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+// checks Provider implements the sdk.Provider interface
+var _ sdk.Provider = &Provider{}
+
+// Provider starts a background process that injects the faults defined in a
+// Chaos resource
+type Provider struct {
+	config  *Chaos
+	command cmdClient.Command
+	log     logger.Logger
+}
+
+func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Chaos)
+	if !ok {
+		return fmt.Errorf("unable to initialize provider, resource is not of type Chaos")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.command = cli.Command
+	p.log = l
+
+	return nil
+}
+
+func (p *Provider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Starting chaos injector", "ref", p.config.Meta.ID, "interval", p.config.Interval, "mode", p.config.Mode)
+
+	conf, err := p.injectorConfig()
+	if err != nil {
+		return err
+	}
+
+	// set the enabled state before starting so the first fault respects it
+	err = chaosClient.SetEnabled(conf.Name, !p.config.Paused)
+	if err != nil {
+		return err
+	}
+
+	pid, err := chaosClient.Start(p.command, conf)
+	if err != nil {
+		return err
+	}
+
+	p.config.PID = pid
+
+	return nil
+}
+
+func (p *Provider) Destroy(ctx context.Context, force bool) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping destroy, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Stopping chaos injector", "ref", p.config.Meta.ID)
+
+	err := chaosClient.Stop(p.command, p.config.Meta.ID, p.config.PID)
+	if err != nil {
+		p.log.Warn("Unable to stop chaos injector", "ref", p.config.Meta.ID, "error", err)
+	}
+
+	return nil
+}
+
+func (p *Provider) Lookup() ([]string, error) {
+	return []string{}, nil
+}
+
+func (p *Provider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Chaos", "ref", p.config.Meta.ID)
+
+	changed, err := p.Changed()
+	if err != nil {
+		return err
+	}
+
+	// the injector is not restarted when the machine restarts or when the
+	// process is killed, start it again
+	if changed {
+		p.log.Info("Chaos injector is not running, restarting", "ref", p.config.Meta.ID, "pid", p.config.PID)
+		return p.Create(ctx)
+	}
+
+	return nil
+}
+
+func (p *Provider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return !p.command.Running(p.config.PID), nil
+}
+
+// injectorConfig converts the resource into the config for the injector,
+// the resource has been validated by Process
+func (p *Provider) injectorConfig() (*chaosClient.Config, error) {
+	conf := &chaosClient.Config{
+		Name: p.config.Meta.ID,
+		Mode: p.config.Mode,
+	}
+
+	conf.Interval, _ = time.ParseDuration(p.config.Interval)
+
+	if p.config.Jitter != "" {
+		conf.Jitter, _ = time.ParseDuration(p.config.Jitter)
+	}
+
+	for _, t := range p.config.Targets {
+		conf.Containers = append(conf.Containers, t.ContainerName)
+	}
+
+	for _, s := range p.config.Pods {
+		conf.Pods = append(conf.Pods, chaosClient.PodTarget{
+			ClusterContainer: s.Cluster.ContainerName,
+			Namespace:        s.Namespace,
+			Selector:         s.Selector,
+		})
+	}
+
+	for _, f := range p.config.Faults {
+		d, _ := time.ParseDuration(f.Duration)
+
+		cf := chaosClient.Fault{
+			Type:     f.Type,
+			Duration: d,
+			Workers:  f.Workers,
+		}
+
+		if f.Type == chaosClient.FaultDiskFill {
+			size, err := f.sizeMB()
+			if err != nil {
+				return nil, err
+			}
+
+			cf.SizeMB = size
+			cf.Path = f.Path
+		}
+
+		conf.Faults = append(conf.Faults, cf)
+	}
+
+	return conf, nil
+}
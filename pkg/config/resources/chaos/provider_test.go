@@ -0,0 +1,129 @@
+package chaos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	chaosClient "github.com/jumppad-labs/jumppad/pkg/clients/chaos"
+	commandMocks "github.com/jumppad-labs/jumppad/pkg/clients/command/mocks"
+	cmdTypes "github.com/jumppad-labs/jumppad/pkg/clients/command/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProvider(t *testing.T) (*Chaos, *Provider, *commandMocks.Command) {
+	t.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	cm := &commandMocks.Command{}
+	cm.On("Execute", mock.Anything).Return(42, 0, nil)
+	cm.On("Kill", mock.Anything).Return(nil)
+
+	c := testChaos()
+	p := &Provider{config: c, command: cm, log: logger.NewTestLogger(t)}
+
+	return c, p, cm
+}
+
+func TestChaosCreateStartsInjector(t *testing.T) {
+	c, p, cm := setupProvider(t)
+	c.Jitter = "30s"
+	c.Pods = []PodSelector{{Cluster: k8s.Cluster{ContainerName: "server.k8s.local.jmpd.in"}, Selector: "app=api"}}
+	c.Faults = []Fault{{Type: "kill"}, {Type: "disk_fill", Size: "1GB"}}
+
+	err := c.Process()
+	require.NoError(t, err)
+
+	err = p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 42, c.PID)
+	require.True(t, chaosClient.Enabled("resource.chaos.test"))
+
+	ac := testutils.GetCalls(&cm.Mock, "Execute")[0].Arguments[0].(cmdTypes.CommandConfig)
+	require.Equal(t, "chaos", ac.Args[0])
+	require.True(t, ac.RunInBackground)
+
+	conf, err := chaosClient.ReadConfig(ac.Args[2])
+	require.NoError(t, err)
+
+	require.Equal(t, "resource.chaos.test", conf.Name)
+	require.Equal(t, time.Minute, conf.Interval)
+	require.Equal(t, 30*time.Second, conf.Jitter)
+	require.Equal(t, []string{"api.container.local.jmpd.in"}, conf.Containers)
+	require.Equal(t, "server.k8s.local.jmpd.in", conf.Pods[0].ClusterContainer)
+	require.Equal(t, 10*time.Second, conf.Faults[0].Duration)
+	require.Equal(t, 1024, conf.Faults[1].SizeMB)
+}
+
+func TestChaosCreatePausedDisablesInjector(t *testing.T) {
+	c, p, _ := setupProvider(t)
+	c.Paused = true
+
+	err := c.Process()
+	require.NoError(t, err)
+
+	err = p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.False(t, chaosClient.Enabled("resource.chaos.test"))
+}
+
+func TestChaosDestroyKillsInjector(t *testing.T) {
+	c, p, cm := setupProvider(t)
+	c.PID = 42
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	cm.AssertCalled(t, "Kill", 42)
+}
+
+func TestChaosDestroyWithoutPIDDoesNothing(t *testing.T) {
+	_, p, cm := setupProvider(t)
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	cm.AssertNotCalled(t, "Kill", mock.Anything)
+}
+
+func TestChaosRefreshRestartsInjectorWhenNotRunning(t *testing.T) {
+	c, p, cm := setupProvider(t)
+	c.PID = 12
+	cm.On("Running", 12).Return(false)
+
+	err := c.Process()
+	require.NoError(t, err)
+
+	err = p.Refresh(context.Background())
+	require.NoError(t, err)
+
+	cm.AssertCalled(t, "Execute", mock.Anything)
+	require.Equal(t, 42, c.PID)
+}
+
+func TestChaosRefreshDoesNothingWhenRunning(t *testing.T) {
+	c, p, cm := setupProvider(t)
+	c.PID = 42
+	cm.On("Running", 42).Return(true)
+
+	err := p.Refresh(context.Background())
+	require.NoError(t, err)
+
+	cm.AssertNotCalled(t, "Execute", mock.Anything)
+}
+
+func TestChaosChangedWhenInjectorNotRunning(t *testing.T) {
+	c, p, cm := setupProvider(t)
+	c.PID = 42
+	cm.On("Running", 42).Return(false)
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, changed)
+}
@@ -0,0 +1,229 @@
+package chaos
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	chaosClient "github.com/jumppad-labs/jumppad/pkg/clients/chaos"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+)
+
+// TypeChaos is the string resource type for Chaos resources
+const TypeChaos string = "chaos"
+
+const (
+	defaultDuration  = "10s"
+	defaultNamespace = "default"
+	defaultFillSize  = "512MB"
+	defaultFillPath  = "/tmp"
+)
+
+var sizeRegex = regexp.MustCompile(`^([0-9]+)(MB|GB)$`)
+
+/*
+Chaos injects faults into containers and pods on a schedule for the lifetime
+of the environment, faults can be disabled and enabled again with the
+jumppad chaos command.
+
+```hcl
+
+	resource "chaos" "api" {
+	  targets  = [resource.container.api]
+	  interval = "1m"
+	  jitter   = "30s"
+	  mode     = "random"
+
+	  fault "kill" {
+	    duration = "20s"
+	  }
+
+	  fault "cpu_stress" {
+	    duration = "30s"
+	    workers  = 2
+	  }
+	}
+
+```
+*/
+type Chaos struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Targets are the containers to inject faults into
+	Targets []ctypes.Container `hcl:"targets,optional" json:"targets,omitempty"`
+
+	// Pods select the pods in Kubernetes clusters to inject faults into
+	Pods []PodSelector `hcl:"pod,block" json:"pods,omitempty"`
+
+	// Interval is the time between faults e.g. 1m
+	Interval string `hcl:"interval" json:"interval"`
+
+	// Jitter is the maximum random delay added to the interval
+	Jitter string `hcl:"jitter,optional" json:"jitter,omitempty"`
+
+	// Mode is all to inject the faults in order into every target, or random
+	// to inject a random fault into a random target, defaults to all
+	Mode string `hcl:"mode,optional" json:"mode,omitempty"`
+
+	// Paused can be set to true to create the resource without injecting
+	// faults until it is enabled with jumppad chaos enable
+	Paused bool `hcl:"paused,optional" json:"paused,omitempty"`
+
+	// Faults to inject into the targets
+	Faults []Fault `hcl:"fault,block" json:"faults"`
+
+	// output
+
+	// PID is the process id of the fault injector
+	PID int `hcl:"pid,optional" json:"pid,omitempty"`
+}
+
+// PodSelector selects pods in a Kubernetes cluster using a label selector
+type PodSelector struct {
+	Cluster k8s.Cluster `hcl:"cluster" json:"cluster"`
+
+	// Namespace of the pods, defaults to default
+	Namespace string `hcl:"namespace,optional" json:"namespace,omitempty"`
+
+	// Selector is a label selector e.g. app=api
+	Selector string `hcl:"selector" json:"selector"`
+}
+
+// Fault is a kill, pause, cpu_stress, or disk_fill fault
+type Fault struct {
+	Type string `hcl:"type,label" json:"type"`
+
+	// Duration the fault lasts before the target is recovered, killed
+	// containers are started after the duration, defaults to 10s
+	Duration string `hcl:"duration,optional" json:"duration,omitempty"`
+
+	// Workers is the number of CPU cores cpu_stress keeps busy, defaults to 1
+	Workers int `hcl:"workers,optional" json:"workers,omitempty"`
+
+	// Size of the file written by disk_fill e.g. 1GB, defaults to 512MB
+	Size string `hcl:"size,optional" json:"size,omitempty"`
+
+	// Path of the folder disk_fill writes to, defaults to /tmp
+	Path string `hcl:"path,optional" json:"path,omitempty"`
+}
+
+func (c *Chaos) Process() error {
+	if len(c.Targets) == 0 && len(c.Pods) == 0 {
+		return fmt.Errorf("at least one target or pods block must be specified")
+	}
+
+	if _, err := time.ParseDuration(c.Interval); err != nil {
+		return fmt.Errorf("invalid interval %s: %s", c.Interval, err)
+	}
+
+	if c.Jitter != "" {
+		if _, err := time.ParseDuration(c.Jitter); err != nil {
+			return fmt.Errorf("invalid jitter %s: %s", c.Jitter, err)
+		}
+	}
+
+	if c.Mode == "" {
+		c.Mode = chaosClient.ModeAll
+	}
+
+	if c.Mode != chaosClient.ModeAll && c.Mode != chaosClient.ModeRandom {
+		return fmt.Errorf("invalid mode %s, mode must be %s or %s", c.Mode, chaosClient.ModeAll, chaosClient.ModeRandom)
+	}
+
+	if len(c.Faults) == 0 {
+		return fmt.Errorf("at least one fault must be specified")
+	}
+
+	for i := range c.Pods {
+		if c.Pods[i].Namespace == "" {
+			c.Pods[i].Namespace = defaultNamespace
+		}
+	}
+
+	for i := range c.Faults {
+		err := c.Faults[i].process(len(c.Pods) > 0)
+		if err != nil {
+			return err
+		}
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(c.Meta.ID)
+		if r != nil {
+			kstate := r.(*Chaos)
+			c.PID = kstate.PID
+		}
+	}
+
+	return nil
+}
+
+func (f *Fault) process(pods bool) error {
+	if !slices.Contains(chaosClient.Faults, f.Type) {
+		return fmt.Errorf("invalid fault %s, fault must be one of %s", f.Type, strings.Join(chaosClient.Faults, ", "))
+	}
+
+	if f.Type == chaosClient.FaultPause && pods {
+		return fmt.Errorf("the pause fault can not be used with pods")
+	}
+
+	if f.Duration == "" {
+		f.Duration = defaultDuration
+	}
+
+	if _, err := time.ParseDuration(f.Duration); err != nil {
+		return fmt.Errorf("invalid duration %s for fault %s: %s", f.Duration, f.Type, err)
+	}
+
+	if f.Workers < 0 {
+		return fmt.Errorf("invalid workers %d for fault %s, workers must be greater than 0", f.Workers, f.Type)
+	}
+
+	if f.Type == chaosClient.FaultCPUStress && f.Workers == 0 {
+		f.Workers = 1
+	}
+
+	if f.Type == chaosClient.FaultDiskFill {
+		if f.Size == "" {
+			f.Size = defaultFillSize
+		}
+
+		if f.Path == "" {
+			f.Path = defaultFillPath
+		}
+
+		if _, err := f.sizeMB(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sizeMB returns the size of the disk_fill file in megabytes
+func (f *Fault) sizeMB() (int, error) {
+	m := sizeRegex.FindStringSubmatch(f.Size)
+	if m == nil {
+		return 0, fmt.Errorf("invalid size %s for fault %s, size must be a number followed by MB or GB e.g. 512MB", f.Size, f.Type)
+	}
+
+	n, _ := strconv.Atoi(m[1])
+	if m[2] == "GB" {
+		n *= 1024
+	}
+
+	return n, nil
+}
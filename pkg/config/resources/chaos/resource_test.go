@@ -0,0 +1,114 @@
+package chaos
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	config.RegisterResource(TypeChaos, &Chaos{}, &Provider{})
+}
+
+func testChaos() *Chaos {
+	return &Chaos{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.chaos.test", Name: "test"}},
+		Targets:      []ctypes.Container{{ContainerName: "api.container.local.jmpd.in"}},
+		Interval:     "1m",
+		Faults:       []Fault{{Type: "kill"}},
+	}
+}
+
+func TestChaosSetsOutputsFromState(t *testing.T) {
+	testutils.SetupState(t, `
+{
+  "blueprint": null,
+  "resources": [
+	{
+			"meta": {
+      	"id": "resource.chaos.test",
+      	"name": "test",
+      	"type": "chaos"
+			},
+			"pid": 42
+	}
+	]
+}`)
+
+	c := testChaos()
+
+	err := c.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, 42, c.PID)
+}
+
+func TestChaosProcessSetsDefaults(t *testing.T) {
+	c := testChaos()
+	c.Pods = []PodSelector{{Cluster: k8s.Cluster{}, Selector: "app=api"}}
+	c.Faults = []Fault{{Type: "cpu_stress"}, {Type: "disk_fill"}}
+
+	err := c.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "all", c.Mode)
+	require.Equal(t, "default", c.Pods[0].Namespace)
+	require.Equal(t, "10s", c.Faults[0].Duration)
+	require.Equal(t, 1, c.Faults[0].Workers)
+	require.Equal(t, "512MB", c.Faults[1].Size)
+	require.Equal(t, "/tmp", c.Faults[1].Path)
+}
+
+func TestChaosProcessWithoutTargetsReturnsError(t *testing.T) {
+	c := testChaos()
+	c.Targets = nil
+
+	err := c.Process()
+	require.ErrorContains(t, err, "at least one target")
+}
+
+func TestChaosProcessInvalidIntervalReturnsError(t *testing.T) {
+	c := testChaos()
+	c.Interval = "often"
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid interval often")
+}
+
+func TestChaosProcessInvalidModeReturnsError(t *testing.T) {
+	c := testChaos()
+	c.Mode = "sometimes"
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid mode sometimes")
+}
+
+func TestChaosProcessInvalidFaultReturnsError(t *testing.T) {
+	c := testChaos()
+	c.Faults = []Fault{{Type: "explode"}}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid fault explode")
+}
+
+func TestChaosProcessPauseWithPodsReturnsError(t *testing.T) {
+	c := testChaos()
+	c.Pods = []PodSelector{{Selector: "app=api"}}
+	c.Faults = []Fault{{Type: "pause"}}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "pause fault can not be used with pods")
+}
+
+func TestChaosProcessInvalidSizeReturnsError(t *testing.T) {
+	c := testChaos()
+	c.Faults = []Fault{{Type: "disk_fill", Size: "1TB"}}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid size 1TB")
+}
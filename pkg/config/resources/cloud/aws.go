@@ -0,0 +1,244 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+const (
+	SourceEnvironment = "env"
+	SourceProfile     = "profile"
+	SourceMetadata    = "metadata"
+)
+
+// endpoints for the EC2 instance metadata service and ECS container
+// credentials, replaced in tests
+var awsMetadataEndpoint = "http://169.254.169.254"
+var awsContainerEndpoint = "http://169.254.170.2"
+
+// awsCredentials are the credentials resolved from the local machine
+type awsCredentials struct {
+	Source          string
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+	Expiration      string
+	Region          string
+}
+
+// resolveAWSCredentials finds the AWS credentials on the local machine, the
+// environment is checked first, then the shared credentials file and finally
+// the container or instance metadata service. When a profile is specified
+// the environment is ignored.
+func resolveAWSCredentials(ctx context.Context, profile, region string) (*awsCredentials, error) {
+	var creds *awsCredentials
+
+	if profile == "" && os.Getenv("AWS_ACCESS_KEY_ID") != "" {
+		creds = &awsCredentials{
+			Source:          SourceEnvironment,
+			AccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+			Expiration:      os.Getenv("AWS_CREDENTIAL_EXPIRATION"),
+		}
+	}
+
+	if profile == "" {
+		profile = os.Getenv("AWS_PROFILE")
+	}
+
+	if profile == "" {
+		profile = "default"
+	}
+
+	if creds == nil {
+		creds = awsProfileCredentials(profile)
+	}
+
+	if creds == nil {
+		var err error
+		creds, err = awsMetadataCredentials(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find AWS credentials in the environment, profile %q, or metadata service: %w", profile, err)
+		}
+	}
+
+	creds.Region = region
+	if creds.Region == "" {
+		creds.Region = awsRegion(profile)
+	}
+
+	return creds, nil
+}
+
+// awsRegion returns the region from the environment or the shared config file
+func awsRegion(profile string) string {
+	if r := os.Getenv("AWS_REGION"); r != "" {
+		return r
+	}
+
+	if r := os.Getenv("AWS_DEFAULT_REGION"); r != "" {
+		return r
+	}
+
+	configFile := os.Getenv("AWS_CONFIG_FILE")
+	if configFile == "" {
+		configFile = filepath.Join(utils.HomeFolder(), ".aws", "config")
+	}
+
+	d, err := os.ReadFile(configFile)
+	if err != nil {
+		return ""
+	}
+
+	// sections in the config file are prefixed with profile except default
+	section := "profile " + profile
+	if profile == "default" {
+		section = "default"
+	}
+
+	return parseINI(string(d))[section]["region"]
+}
+
+// awsProfileCredentials reads the static credentials for the profile from
+// the shared credentials file
+func awsProfileCredentials(profile string) *awsCredentials {
+	credentialsFile := os.Getenv("AWS_SHARED_CREDENTIALS_FILE")
+	if credentialsFile == "" {
+		credentialsFile = filepath.Join(utils.HomeFolder(), ".aws", "credentials")
+	}
+
+	d, err := os.ReadFile(credentialsFile)
+	if err != nil {
+		return nil
+	}
+
+	s, ok := parseINI(string(d))[profile]
+	if !ok || s["aws_access_key_id"] == "" {
+		return nil
+	}
+
+	return &awsCredentials{
+		Source:          SourceProfile,
+		AccessKeyID:     s["aws_access_key_id"],
+		SecretAccessKey: s["aws_secret_access_key"],
+		SessionToken:    s["aws_session_token"],
+	}
+}
+
+type awsMetadataResponse struct {
+	AccessKeyID     string `json:"AccessKeyId"`
+	SecretAccessKey string `json:"SecretAccessKey"`
+	Token           string `json:"Token"`
+	Expiration      string `json:"Expiration"`
+}
+
+// awsMetadataCredentials fetches temporary credentials from the ECS container
+// credentials endpoint or the EC2 instance metadata service
+func awsMetadataCredentials(ctx context.Context) (*awsCredentials, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	headers := map[string]string{}
+	uri := os.Getenv("AWS_CONTAINER_CREDENTIALS_FULL_URI")
+	if rel := os.Getenv("AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"); rel != "" {
+		uri = awsContainerEndpoint + rel
+	}
+
+	if uri != "" {
+		if t := os.Getenv("AWS_CONTAINER_AUTHORIZATION_TOKEN"); t != "" {
+			headers["Authorization"] = t
+		}
+	} else {
+		// IMDSv2 requires a session token
+		token, err := metadataRequest(ctx, http.MethodPut, awsMetadataEndpoint+"/latest/api/token", map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": "21600"})
+		if err != nil {
+			return nil, err
+		}
+
+		headers["X-aws-ec2-metadata-token"] = token
+
+		role, err := metadataRequest(ctx, http.MethodGet, awsMetadataEndpoint+"/latest/meta-data/iam/security-credentials/", headers)
+		if err != nil {
+			return nil, err
+		}
+
+		uri = awsMetadataEndpoint + "/latest/meta-data/iam/security-credentials/" + strings.TrimSpace(strings.Split(role, "\n")[0])
+	}
+
+	d, err := metadataRequest(ctx, http.MethodGet, uri, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	mr := awsMetadataResponse{}
+	err = json.Unmarshal([]byte(d), &mr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse credentials from metadata service: %w", err)
+	}
+
+	return &awsCredentials{
+		Source:          SourceMetadata,
+		AccessKeyID:     mr.AccessKeyID,
+		SecretAccessKey: mr.SecretAccessKey,
+		SessionToken:    mr.Token,
+		Expiration:      mr.Expiration,
+	}, nil
+}
+
+// metadataRequest makes a request to a metadata service and returns the body
+func metadataRequest(ctx context.Context, method, uri string, headers map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, uri, nil)
+	if err != nil {
+		return "", err
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	d, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("metadata service %s returned status %d", uri, resp.StatusCode)
+	}
+
+	return string(d), nil
+}
+
+// sharedCredentials returns the contents of a shared credentials file for the
+// credentials
+func (c *awsCredentials) sharedCredentials() []byte {
+	sb := strings.Builder{}
+	sb.WriteString("[default]\n")
+	sb.WriteString(fmt.Sprintf("aws_access_key_id = %s\n", c.AccessKeyID))
+	sb.WriteString(fmt.Sprintf("aws_secret_access_key = %s\n", c.SecretAccessKey))
+
+	if c.SessionToken != "" {
+		sb.WriteString(fmt.Sprintf("aws_session_token = %s\n", c.SessionToken))
+	}
+
+	if c.Region != "" {
+		sb.WriteString(fmt.Sprintf("region = %s\n", c.Region))
+	}
+
+	return []byte(sb.String())
+}
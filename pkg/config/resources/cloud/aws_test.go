@@ -0,0 +1,124 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupAWSEnv(t *testing.T) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	for _, e := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN", "AWS_PROFILE", "AWS_REGION",
+		"AWS_DEFAULT_REGION", "AWS_SHARED_CREDENTIALS_FILE", "AWS_CONFIG_FILE", "AWS_CREDENTIAL_EXPIRATION",
+		"AWS_CONTAINER_CREDENTIALS_FULL_URI", "AWS_CONTAINER_CREDENTIALS_RELATIVE_URI"} {
+		t.Setenv(e, "")
+	}
+
+	return home
+}
+
+func writeAWSFiles(t *testing.T, home string) {
+	os.MkdirAll(filepath.Join(home, ".aws"), os.ModePerm)
+
+	err := os.WriteFile(filepath.Join(home, ".aws", "credentials"), []byte(`
+[default]
+aws_access_key_id = DEFAULTKEY
+aws_secret_access_key = defaultsecret
+
+[dev]
+aws_access_key_id = DEVKEY
+aws_secret_access_key = devsecret
+aws_session_token = devtoken
+`), 0600)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(home, ".aws", "config"), []byte(`
+[default]
+region = us-east-1
+
+[profile dev]
+region = eu-west-2
+`), 0600)
+	require.NoError(t, err)
+}
+
+func TestResolveAWSCredentialsFromEnvironment(t *testing.T) {
+	home := setupAWSEnv(t)
+	writeAWSFiles(t, home)
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "ENVKEY")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "envsecret")
+	t.Setenv("AWS_REGION", "ap-south-1")
+
+	c, err := resolveAWSCredentials(context.Background(), "", "")
+	require.NoError(t, err)
+
+	require.Equal(t, SourceEnvironment, c.Source)
+	require.Equal(t, "ENVKEY", c.AccessKeyID)
+	require.Equal(t, "envsecret", c.SecretAccessKey)
+	require.Equal(t, "ap-south-1", c.Region)
+}
+
+func TestResolveAWSCredentialsFromProfile(t *testing.T) {
+	home := setupAWSEnv(t)
+	writeAWSFiles(t, home)
+
+	// environment is ignored when a profile is set
+	t.Setenv("AWS_ACCESS_KEY_ID", "ENVKEY")
+
+	c, err := resolveAWSCredentials(context.Background(), "dev", "")
+	require.NoError(t, err)
+
+	require.Equal(t, SourceProfile, c.Source)
+	require.Equal(t, "DEVKEY", c.AccessKeyID)
+	require.Equal(t, "devtoken", c.SessionToken)
+	require.Equal(t, "eu-west-2", c.Region)
+	require.Contains(t, string(c.sharedCredentials()), "aws_session_token = devtoken")
+}
+
+func TestResolveAWSCredentialsFromMetadata(t *testing.T) {
+	setupAWSEnv(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/latest/api/token":
+			rw.Write([]byte("imdstoken"))
+		case "/latest/meta-data/iam/security-credentials/":
+			rw.Write([]byte("my-role"))
+		case "/latest/meta-data/iam/security-credentials/my-role":
+			if r.Header.Get("X-aws-ec2-metadata-token") != "imdstoken" {
+				rw.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			rw.Write([]byte(`{"AccessKeyId": "IMDSKEY", "SecretAccessKey": "imdssecret", "Token": "imdstoken", "Expiration": "2000-01-01T00:00:00Z"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	old := awsMetadataEndpoint
+	awsMetadataEndpoint = s.URL
+	t.Cleanup(func() { awsMetadataEndpoint = old })
+
+	c, err := resolveAWSCredentials(context.Background(), "", "us-west-1")
+	require.NoError(t, err)
+
+	require.Equal(t, SourceMetadata, c.Source)
+	require.Equal(t, "IMDSKEY", c.AccessKeyID)
+	require.Equal(t, "us-west-1", c.Region)
+	require.True(t, expiring(c.Expiration))
+}
+
+func TestExpiringReturnsFalseWhenNoExpiration(t *testing.T) {
+	require.False(t, expiring(""))
+}
@@ -0,0 +1,168 @@
+package cloud
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+// credentials are renewed when they expire within this duration
+const expiryWindow = 5 * time.Minute
+
+// credentialsPath returns the path on the host where the materialized
+// credentials for the resource are written
+func credentialsPath(id, name string) string {
+	dir := utils.DataFolder(filepath.Join("credentials", strings.ReplaceAll(id, ".", "_")), 0700)
+	return filepath.Join(dir, name)
+}
+
+// writeCredentials writes the credentials file, the file is truncated and
+// written in place so that containers mounting the file see the new contents
+func writeCredentials(path string, contents []byte) error {
+	err := os.WriteFile(path, contents, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to write credentials file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// expiring returns true when the RFC3339 expiration time is within the expiry
+// window, credentials without an expiration never expire
+func expiring(expiration string) bool {
+	if expiration == "" {
+		return false
+	}
+
+	t, err := time.Parse(time.RFC3339, expiration)
+	if err != nil {
+		return true
+	}
+
+	return time.Now().Add(expiryWindow).After(t)
+}
+
+// encryptValues encrypts the sensitive values before they are written to the state
+func encryptValues(id string, values ...*string) error {
+	for _, v := range values {
+		if *v == "" {
+			continue
+		}
+
+		enc, err := utils.EncryptSecret(*v)
+		if err != nil {
+			return fmt.Errorf("unable to encrypt credentials %s: %w", id, err)
+		}
+
+		*v = enc
+	}
+
+	return nil
+}
+
+// decryptValues decrypts the values encrypted by encryptValues, values that
+// can not be decrypted are reset so that the state can still be loaded
+func decryptValues(id string, values ...*string) {
+	for _, v := range values {
+		*v = utils.DecryptSecretOrEmpty(id, *v)
+		utils.RegisterSensitive(*v)
+	}
+}
+
+// sensitiveEnv are the environment variables containing secrets
+var sensitiveEnv = map[string]bool{
+	"AWS_SECRET_ACCESS_KEY":      true,
+	"AWS_SESSION_TOKEN":          true,
+	"GOOGLE_OAUTH_ACCESS_TOKEN":  true,
+	"CLOUDSDK_AUTH_ACCESS_TOKEN": true,
+}
+
+// encryptEnv encrypts the sensitive values of the environment map
+func encryptEnv(id string, env map[string]string) (map[string]string, error) {
+	if env == nil {
+		return nil, nil
+	}
+
+	enc := map[string]string{}
+	for k, v := range env {
+		if sensitiveEnv[k] {
+			err := encryptValues(id, &v)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		enc[k] = v
+	}
+
+	return enc, nil
+}
+
+// decryptEnv decrypts the values of the environment map encrypted by encryptEnv
+func decryptEnv(id string, env map[string]string) map[string]string {
+	if env == nil {
+		return nil
+	}
+
+	dec := map[string]string{}
+	for k, v := range env {
+		if utils.IsEncryptedSecret(v) {
+			decryptValues(id, &v)
+		}
+
+		dec[k] = v
+	}
+
+	return dec
+}
+
+// parseINI parses an INI file such as the AWS credentials file returning
+// the keys for each section
+func parseINI(d string) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	current := ""
+
+	for _, line := range strings.Split(d, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			if sections[current] == nil {
+				sections[current] = map[string]string{}
+			}
+
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, "=")
+		if !ok || current == "" {
+			continue
+		}
+
+		sections[current][strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+
+	return sections
+}
+
+// removeCredentials removes the materialized credentials file
+func removeCredentials(file string, l sdk.Logger) error {
+	if file == "" {
+		return nil
+	}
+
+	err := os.Remove(file)
+	if err != nil && !os.IsNotExist(err) {
+		l.Debug("Unable to remove credentials file", "file", file, "error", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,135 @@
+package cloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// endpoint for the GCE metadata server, replaced in tests
+var gcpMetadataEndpoint = "http://metadata.google.internal"
+
+// gcpCredentials are the credentials resolved from the local machine
+type gcpCredentials struct {
+	Source string
+	// JSON credentials file contents, either a service account key or
+	// application default credentials
+	JSON        []byte
+	AccessToken string
+	Expiration  string
+	Project     string
+}
+
+// resolveGCPCredentials finds the Google Cloud credentials on the local machine,
+// the file referenced by GOOGLE_APPLICATION_CREDENTIALS is checked first, then
+// the application default credentials created by gcloud and finally the
+// metadata server.
+func resolveGCPCredentials(ctx context.Context, project string) (*gcpCredentials, error) {
+	creds := &gcpCredentials{}
+
+	if f := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS"); f != "" {
+		d, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read credentials file %s from GOOGLE_APPLICATION_CREDENTIALS: %w", f, err)
+		}
+
+		creds.Source = SourceEnvironment
+		creds.JSON = d
+	} else if d, err := os.ReadFile(gcloudADCPath()); err == nil {
+		creds.Source = SourceProfile
+		creds.JSON = d
+	} else {
+		err := gcpMetadataCredentials(ctx, creds)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find Google Cloud credentials in GOOGLE_APPLICATION_CREDENTIALS, application default credentials, or metadata server: %w", err)
+		}
+	}
+
+	creds.Project = project
+	if creds.Project == "" {
+		creds.Project = gcpProject(ctx, creds)
+	}
+
+	return creds, nil
+}
+
+// gcloudADCPath returns the location of the application default credentials
+// written by `gcloud auth application-default login`
+func gcloudADCPath() string {
+	if d := os.Getenv("CLOUDSDK_CONFIG"); d != "" {
+		return filepath.Join(d, "application_default_credentials.json")
+	}
+
+	if runtime.GOOS == "windows" {
+		return filepath.Join(os.Getenv("APPDATA"), "gcloud", "application_default_credentials.json")
+	}
+
+	return filepath.Join(utils.HomeFolder(), ".config", "gcloud", "application_default_credentials.json")
+}
+
+// gcpProject returns the project from the environment, the credentials file,
+// or the metadata server
+func gcpProject(ctx context.Context, creds *gcpCredentials) string {
+	for _, e := range []string{"GOOGLE_CLOUD_PROJECT", "CLOUDSDK_CORE_PROJECT", "GCLOUD_PROJECT"} {
+		if p := os.Getenv(e); p != "" {
+			return p
+		}
+	}
+
+	if creds.JSON != nil {
+		f := struct {
+			ProjectID      string `json:"project_id"`
+			QuotaProjectID string `json:"quota_project_id"`
+		}{}
+
+		json.Unmarshal(creds.JSON, &f)
+		if f.ProjectID != "" {
+			return f.ProjectID
+		}
+
+		return f.QuotaProjectID
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	p, _ := metadataRequest(ctx, http.MethodGet, gcpMetadataEndpoint+"/computeMetadata/v1/project/project-id", map[string]string{"Metadata-Flavor": "Google"})
+
+	return strings.TrimSpace(p)
+}
+
+// gcpMetadataCredentials fetches an access token for the default service
+// account from the metadata server
+func gcpMetadataCredentials(ctx context.Context, creds *gcpCredentials) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	d, err := metadataRequest(ctx, http.MethodGet, gcpMetadataEndpoint+"/computeMetadata/v1/instance/service-accounts/default/token", map[string]string{"Metadata-Flavor": "Google"})
+	if err != nil {
+		return err
+	}
+
+	t := struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}{}
+
+	err = json.Unmarshal([]byte(d), &t)
+	if err != nil {
+		return fmt.Errorf("unable to parse token from metadata server: %w", err)
+	}
+
+	creds.Source = SourceMetadata
+	creds.AccessToken = t.AccessToken
+	creds.Expiration = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second).UTC().Format(time.RFC3339)
+
+	return nil
+}
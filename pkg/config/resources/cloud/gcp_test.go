@@ -0,0 +1,89 @@
+package cloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupGCPEnv(t *testing.T) string {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("CLOUDSDK_CONFIG", filepath.Join(home, "gcloud"))
+
+	for _, e := range []string{"GOOGLE_APPLICATION_CREDENTIALS", "GOOGLE_CLOUD_PROJECT", "CLOUDSDK_CORE_PROJECT", "GCLOUD_PROJECT"} {
+		t.Setenv(e, "")
+	}
+
+	return home
+}
+
+func TestResolveGCPCredentialsFromEnvironment(t *testing.T) {
+	home := setupGCPEnv(t)
+
+	f := filepath.Join(home, "sa.json")
+	err := os.WriteFile(f, []byte(`{"type": "service_account", "project_id": "my-project"}`), 0600)
+	require.NoError(t, err)
+
+	t.Setenv("GOOGLE_APPLICATION_CREDENTIALS", f)
+
+	c, err := resolveGCPCredentials(context.Background(), "")
+	require.NoError(t, err)
+
+	require.Equal(t, SourceEnvironment, c.Source)
+	require.Equal(t, "my-project", c.Project)
+	require.Contains(t, string(c.JSON), "service_account")
+}
+
+func TestResolveGCPCredentialsFromADC(t *testing.T) {
+	home := setupGCPEnv(t)
+
+	os.MkdirAll(filepath.Join(home, "gcloud"), os.ModePerm)
+	err := os.WriteFile(filepath.Join(home, "gcloud", "application_default_credentials.json"), []byte(`{"type": "authorized_user", "quota_project_id": "quota"}`), 0600)
+	require.NoError(t, err)
+
+	c, err := resolveGCPCredentials(context.Background(), "override")
+	require.NoError(t, err)
+
+	require.Equal(t, SourceProfile, c.Source)
+	require.Equal(t, "override", c.Project)
+}
+
+func TestResolveGCPCredentialsFromMetadata(t *testing.T) {
+	setupGCPEnv(t)
+
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/computeMetadata/v1/instance/service-accounts/default/token":
+			rw.Write([]byte(`{"access_token": "gcptoken", "expires_in": 3600}`))
+		case "/computeMetadata/v1/project/project-id":
+			rw.Write([]byte("metadata-project"))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(s.Close)
+
+	old := gcpMetadataEndpoint
+	gcpMetadataEndpoint = s.URL
+	t.Cleanup(func() { gcpMetadataEndpoint = old })
+
+	c, err := resolveGCPCredentials(context.Background(), "")
+	require.NoError(t, err)
+
+	require.Equal(t, SourceMetadata, c.Source)
+	require.Equal(t, "gcptoken", c.AccessToken)
+	require.Equal(t, "metadata-project", c.Project)
+	require.False(t, expiring(c.Expiration))
+}
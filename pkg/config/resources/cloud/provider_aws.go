@@ -0,0 +1,124 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &AWSProvider{}
+
+// AWSProvider materializes local AWS credentials
+type AWSProvider struct {
+	config *AWSCredentials
+	log    sdk.Logger
+}
+
+func (p *AWSProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*AWSCredentials)
+	if !ok {
+		return fmt.Errorf("unable to initialize AWSCredentials provider, resource is not of type AWSCredentials")
+	}
+
+	p.config = c
+	p.log = l
+
+	return nil
+}
+
+func (p *AWSProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Creating AWS credentials", "ref", p.config.Meta.ID)
+
+	return p.materialize(ctx)
+}
+
+func (p *AWSProvider) Destroy(ctx context.Context, force bool) error {
+	p.log.Info("Destroy AWS credentials", "ref", p.config.Meta.ID)
+
+	return removeCredentials(p.config.File, p.log)
+}
+
+func (p *AWSProvider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+// Refresh reads the credentials again so that rotated or renewed credentials
+// are written to the credentials file
+func (p *AWSProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh AWS credentials", "ref", p.config.Meta.ID)
+
+	return p.materialize(ctx)
+}
+
+// Changed returns true when the credentials have expired or are about to expire
+func (p *AWSProvider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	if expiring(p.config.Expiration) {
+		p.log.Debug("AWS credentials have expired", "ref", p.config.Meta.ID, "expiration", p.config.Expiration)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (p *AWSProvider) materialize(ctx context.Context) error {
+	creds, err := resolveAWSCredentials(ctx, p.config.Profile, p.config.Region)
+	if err != nil {
+		return err
+	}
+
+	if expiring(creds.Expiration) {
+		p.log.Warn("AWS credentials have expired or are about to expire, refresh the local credentials", "ref", p.config.Meta.ID, "expiration", creds.Expiration)
+	}
+
+	utils.RegisterSensitive(creds.SecretAccessKey)
+	utils.RegisterSensitive(creds.SessionToken)
+
+	file := credentialsPath(p.config.Meta.ID, "credentials")
+
+	err = writeCredentials(file, creds.sharedCredentials())
+	if err != nil {
+		return err
+	}
+
+	p.log.Debug("Materialized AWS credentials", "ref", p.config.Meta.ID, "source", creds.Source, "file", file)
+
+	env := map[string]string{
+		"AWS_ACCESS_KEY_ID":           creds.AccessKeyID,
+		"AWS_SECRET_ACCESS_KEY":       creds.SecretAccessKey,
+		"AWS_SHARED_CREDENTIALS_FILE": p.config.ContainerPath,
+	}
+
+	if creds.SessionToken != "" {
+		env["AWS_SESSION_TOKEN"] = creds.SessionToken
+	}
+
+	if creds.Region != "" {
+		env["AWS_REGION"] = creds.Region
+		env["AWS_DEFAULT_REGION"] = creds.Region
+	}
+
+	p.config.Source = creds.Source
+	p.config.AccessKeyID = creds.AccessKeyID
+	p.config.SecretAccessKey = creds.SecretAccessKey
+	p.config.SessionToken = creds.SessionToken
+	p.config.Expiration = creds.Expiration
+	p.config.File = file
+	p.config.Env = env
+
+	return nil
+}
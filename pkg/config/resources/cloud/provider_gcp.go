@@ -0,0 +1,124 @@
+package cloud
+
+import (
+	"context"
+	"fmt"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &GCPProvider{}
+
+// GCPProvider materializes local Google Cloud credentials
+type GCPProvider struct {
+	config *GCPCredentials
+	log    sdk.Logger
+}
+
+func (p *GCPProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*GCPCredentials)
+	if !ok {
+		return fmt.Errorf("unable to initialize GCPCredentials provider, resource is not of type GCPCredentials")
+	}
+
+	p.config = c
+	p.log = l
+
+	return nil
+}
+
+func (p *GCPProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Creating Google Cloud credentials", "ref", p.config.Meta.ID)
+
+	return p.materialize(ctx)
+}
+
+func (p *GCPProvider) Destroy(ctx context.Context, force bool) error {
+	p.log.Info("Destroy Google Cloud credentials", "ref", p.config.Meta.ID)
+
+	return removeCredentials(p.config.File, p.log)
+}
+
+func (p *GCPProvider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+// Refresh reads the credentials again so that renewed access tokens are
+// written to the credentials file
+func (p *GCPProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Google Cloud credentials", "ref", p.config.Meta.ID)
+
+	return p.materialize(ctx)
+}
+
+// Changed returns true when the access token has expired or is about to expire
+func (p *GCPProvider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	if expiring(p.config.Expiration) {
+		p.log.Debug("Google Cloud access token has expired", "ref", p.config.Meta.ID, "expiration", p.config.Expiration)
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func (p *GCPProvider) materialize(ctx context.Context) error {
+	creds, err := resolveGCPCredentials(ctx, p.config.Project)
+	if err != nil {
+		return err
+	}
+
+	utils.RegisterSensitive(creds.AccessToken)
+
+	env := map[string]string{}
+
+	// access tokens from the metadata server are written as a plain file,
+	// other credentials are written as a JSON credentials file
+	file := credentialsPath(p.config.Meta.ID, "credentials.json")
+	contents := creds.JSON
+
+	if creds.AccessToken != "" {
+		file = credentialsPath(p.config.Meta.ID, "access_token")
+		contents = []byte(creds.AccessToken)
+
+		env["GOOGLE_OAUTH_ACCESS_TOKEN"] = creds.AccessToken
+		env["CLOUDSDK_AUTH_ACCESS_TOKEN"] = creds.AccessToken
+		env["CLOUDSDK_AUTH_ACCESS_TOKEN_FILE"] = p.config.ContainerPath
+	} else {
+		env["GOOGLE_APPLICATION_CREDENTIALS"] = p.config.ContainerPath
+		env["CLOUDSDK_AUTH_CREDENTIAL_FILE_OVERRIDE"] = p.config.ContainerPath
+	}
+
+	if creds.Project != "" {
+		env["GOOGLE_CLOUD_PROJECT"] = creds.Project
+		env["CLOUDSDK_CORE_PROJECT"] = creds.Project
+	}
+
+	err = writeCredentials(file, contents)
+	if err != nil {
+		return err
+	}
+
+	p.log.Debug("Materialized Google Cloud credentials", "ref", p.config.Meta.ID, "source", creds.Source, "file", file)
+
+	p.config.Source = creds.Source
+	p.config.AccessToken = creds.AccessToken
+	p.config.Expiration = creds.Expiration
+	p.config.File = file
+	p.config.Env = env
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package cloud
+
+import (
+	"encoding/json"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// TypeAWSCredentials is the resource string for an AWSCredentials resource
+const TypeAWSCredentials string = "aws_credentials"
+
+const defaultAWSContainerPath = "/etc/jumppad/aws/credentials"
+
+// AWSCredentials materializes the local AWS credentials so that they can be
+// passed to containers and clusters. Credentials are read from the environment,
+// the shared credentials file, or the container and instance metadata services.
+//
+// The generated credentials file can be mounted into a container at
+// container_path and the environment variables set using env. Temporary
+// credentials are refreshed on every apply and renewed when they expire.
+type AWSCredentials struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Profile to read from the shared credentials file, when set the
+	// environment is ignored
+	Profile string `hcl:"profile,optional" json:"profile,omitempty"`
+	// Region to set in the environment, defaults to the region for the profile
+	Region string `hcl:"region,optional" json:"region,omitempty"`
+	// ContainerPath is the path the credentials file will be mounted at in
+	// the container, defaults to /etc/jumppad/aws/credentials
+	ContainerPath string `hcl:"container_path,optional" json:"container_path,omitempty"`
+
+	// Output parameters
+
+	// Source of the credentials, env, profile, or metadata
+	Source          string `hcl:"source,optional" json:"source,omitempty"`
+	AccessKeyID     string `hcl:"access_key_id,optional" json:"access_key_id,omitempty"`
+	SecretAccessKey string `hcl:"secret_access_key,optional" json:"secret_access_key,omitempty"`
+	SessionToken    string `hcl:"session_token,optional" json:"session_token,omitempty"`
+	// Expiration of temporary credentials in RFC3339 format
+	Expiration string `hcl:"expiration,optional" json:"expiration,omitempty"`
+	// File is the path on the host of the generated credentials file
+	File string `hcl:"file,optional" json:"file,omitempty"`
+	// Env contains the environment variables to set in a container
+	Env map[string]string `hcl:"env,optional" json:"env,omitempty"`
+}
+
+func (a *AWSCredentials) Process() error {
+	if a.ContainerPath == "" {
+		a.ContainerPath = defaultAWSContainerPath
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(a.Meta.ID)
+		if r != nil {
+			state := r.(*AWSCredentials)
+			a.Source = state.Source
+			a.AccessKeyID = state.AccessKeyID
+			a.SecretAccessKey = state.SecretAccessKey
+			a.SessionToken = state.SessionToken
+			a.Expiration = state.Expiration
+			a.File = state.File
+			a.Env = state.Env
+		}
+	}
+
+	utils.RegisterSensitive(a.SecretAccessKey)
+	utils.RegisterSensitive(a.SessionToken)
+
+	return nil
+}
+
+type awsCredentialsJSON AWSCredentials
+
+// MarshalJSON encrypts the credentials before they are written to the state
+func (a *AWSCredentials) MarshalJSON() ([]byte, error) {
+	aj := awsCredentialsJSON(*a)
+
+	err := encryptValues(a.Meta.ID, &aj.AccessKeyID, &aj.SecretAccessKey, &aj.SessionToken)
+	if err != nil {
+		return nil, err
+	}
+
+	aj.Env, err = encryptEnv(a.Meta.ID, aj.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(aj)
+}
+
+// UnmarshalJSON decrypts the credentials encrypted by MarshalJSON
+func (a *AWSCredentials) UnmarshalJSON(d []byte) error {
+	aj := awsCredentialsJSON{}
+
+	err := json.Unmarshal(d, &aj)
+	if err != nil {
+		return err
+	}
+
+	decryptValues(aj.Meta.ID, &aj.AccessKeyID, &aj.SecretAccessKey, &aj.SessionToken)
+	aj.Env = decryptEnv(aj.Meta.ID, aj.Env)
+
+	*a = AWSCredentials(aj)
+
+	return nil
+}
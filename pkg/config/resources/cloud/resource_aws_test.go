@@ -0,0 +1,58 @@
+package cloud
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func testAWSCredentials() *AWSCredentials {
+	return &AWSCredentials{
+		ResourceBase:    types.ResourceBase{Meta: types.Meta{ID: "resource.aws_credentials.test"}},
+		AccessKeyID:     "AKIAEXAMPLE",
+		SecretAccessKey: "s3cr3t-access-key",
+		Env:             map[string]string{"AWS_SECRET_ACCESS_KEY": "s3cr3t-access-key"},
+	}
+}
+
+func TestAWSCredentialsMarshalJSONEncryptsValues(t *testing.T) {
+	testutils.SetupState(t, "")
+	t.Setenv(utils.SecretsKeyEnvVar, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+
+	d, err := json.Marshal(testAWSCredentials())
+	require.NoError(t, err)
+	require.NotContains(t, string(d), "s3cr3t-access-key")
+
+	a := &AWSCredentials{}
+	err = json.Unmarshal(d, a)
+	require.NoError(t, err)
+
+	require.Equal(t, "s3cr3t-access-key", a.SecretAccessKey)
+	require.Equal(t, "s3cr3t-access-key", a.Env["AWS_SECRET_ACCESS_KEY"])
+}
+
+func TestAWSCredentialsUnmarshalJSONWithChangedKeyResetsValues(t *testing.T) {
+	testutils.SetupState(t, "")
+	t.Setenv(utils.SecretsKeyEnvVar, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+
+	d, err := json.Marshal(testAWSCredentials())
+	require.NoError(t, err)
+
+	// rotate the key
+	key := make([]byte, 32)
+	key[0] = 1
+	t.Setenv(utils.SecretsKeyEnvVar, base64.StdEncoding.EncodeToString(key))
+
+	a := &AWSCredentials{}
+	err = json.Unmarshal(d, a)
+	require.NoError(t, err)
+
+	require.Equal(t, "", a.SecretAccessKey)
+	require.Equal(t, "", a.Env["AWS_SECRET_ACCESS_KEY"])
+	require.Equal(t, "resource.aws_credentials.test", a.Meta.ID)
+}
@@ -0,0 +1,111 @@
+package cloud
+
+import (
+	"encoding/json"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// TypeGCPCredentials is the resource string for a GCPCredentials resource
+const TypeGCPCredentials string = "gcp_credentials"
+
+const defaultGCPContainerPath = "/etc/jumppad/gcp/credentials.json"
+
+// GCPCredentials materializes the local Google Cloud credentials so that they
+// can be passed to containers and clusters. Credentials are read from the file
+// referenced by GOOGLE_APPLICATION_CREDENTIALS, the gcloud application default
+// credentials, or the metadata server.
+//
+// The generated credentials file can be mounted into a container at
+// container_path and the environment variables set using env. Access tokens
+// from the metadata server are refreshed on every apply and renewed when they
+// expire.
+type GCPCredentials struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Project to set in the environment, defaults to the project from the
+	// environment or credentials
+	Project string `hcl:"project,optional" json:"project,omitempty"`
+	// ContainerPath is the path the credentials file will be mounted at in
+	// the container, defaults to /etc/jumppad/gcp/credentials.json
+	ContainerPath string `hcl:"container_path,optional" json:"container_path,omitempty"`
+
+	// Output parameters
+
+	// Source of the credentials, env, profile, or metadata
+	Source string `hcl:"source,optional" json:"source,omitempty"`
+	// AccessToken is only set when the credentials are read from the metadata server
+	AccessToken string `hcl:"access_token,optional" json:"access_token,omitempty"`
+	// Expiration of the access token in RFC3339 format
+	Expiration string `hcl:"expiration,optional" json:"expiration,omitempty"`
+	// File is the path on the host of the generated credentials file
+	File string `hcl:"file,optional" json:"file,omitempty"`
+	// Env contains the environment variables to set in a container
+	Env map[string]string `hcl:"env,optional" json:"env,omitempty"`
+}
+
+func (g *GCPCredentials) Process() error {
+	if g.ContainerPath == "" {
+		g.ContainerPath = defaultGCPContainerPath
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(g.Meta.ID)
+		if r != nil {
+			state := r.(*GCPCredentials)
+			g.Source = state.Source
+			g.AccessToken = state.AccessToken
+			g.Expiration = state.Expiration
+			g.File = state.File
+			g.Env = state.Env
+		}
+	}
+
+	utils.RegisterSensitive(g.AccessToken)
+
+	return nil
+}
+
+type gcpCredentialsJSON GCPCredentials
+
+// MarshalJSON encrypts the credentials before they are written to the state
+func (g *GCPCredentials) MarshalJSON() ([]byte, error) {
+	gj := gcpCredentialsJSON(*g)
+
+	err := encryptValues(g.Meta.ID, &gj.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+
+	gj.Env, err = encryptEnv(g.Meta.ID, gj.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(gj)
+}
+
+// UnmarshalJSON decrypts the credentials encrypted by MarshalJSON
+func (g *GCPCredentials) UnmarshalJSON(d []byte) error {
+	gj := gcpCredentialsJSON{}
+
+	err := json.Unmarshal(d, &gj)
+	if err != nil {
+		return err
+	}
+
+	decryptValues(gj.Meta.ID, &gj.AccessToken)
+	gj.Env = decryptEnv(gj.Meta.ID, gj.Env)
+
+	*g = GCPCredentials(gj)
+
+	return nil
+}
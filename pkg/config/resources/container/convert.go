@@ -94,3 +94,24 @@ func (p PortRanges) ToClientPortRanges() []types.PortRange {
 
 	return ports
 }
+
+func (r *Resources) ToClientResources() *types.Resources {
+	if r == nil {
+		return nil
+	}
+
+	res := &types.Resources{
+		CPU:    r.CPU,
+		CPUPin: r.CPUPin,
+		Memory: r.Memory,
+	}
+
+	if r.GPU != nil {
+		res.GPU = &types.GPU{
+			Driver:    r.GPU.Driver,
+			DeviceIDs: r.GPU.DeviceIDs,
+		}
+	}
+
+	return res
+}
@@ -15,6 +15,9 @@ import (
 	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/http"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/metrics"
+	"github.com/jumppad-labs/jumppad/pkg/clients/tracing"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
@@ -163,7 +166,9 @@ func (c *Provider) internalCreate(ctx context.Context, sidecar bool) error {
 		Password: c.config.Image.Password,
 	}
 
+	_, span := tracing.Start(ctx, "image.pull", tracing.AttributeImage.String(img.Name))
 	err := c.client.PullImage(img, false)
+	tracing.End(span, err)
 	if err != nil {
 		c.log.Error("Error pulling container image", "ref", c.config.Meta.ID, "image", c.config.Image.Name)
 
@@ -186,12 +191,16 @@ func (c *Provider) internalCreate(ctx context.Context, sidecar bool) error {
 		Entrypoint:      c.config.Entrypoint,
 		Command:         c.config.Command,
 		Environment:     c.config.Environment,
-		Labels:          c.config.Labels,
+		Labels:          config.DockerLabels(c.config),
 		DNS:             c.config.DNS,
 		Privileged:      c.config.Privileged,
 		MaxRestartCount: c.config.MaxRestartCount,
 	}
 
+	if c.config.Clock != nil {
+		new.Environment = c.config.Clock.Environment(c.config.Environment)
+	}
+
 	for _, v := range c.config.Networks {
 		new.Networks = append(new.Networks, types.NetworkAttachment{
 			ID:          v.ID,
@@ -239,20 +248,7 @@ func (c *Provider) internalCreate(ctx context.Context, sidecar bool) error {
 		}
 	}
 
-	if c.config.Resources != nil {
-		new.Resources = &types.Resources{
-			CPU:    c.config.Resources.CPU,
-			CPUPin: c.config.Resources.CPUPin,
-			Memory: c.config.Resources.Memory,
-		}
-
-		if c.config.Resources.GPU != nil {
-			new.Resources.GPU = &types.GPU{
-				Driver:    c.config.Resources.GPU.Driver,
-				DeviceIDs: c.config.Resources.GPU.DeviceIDs,
-			}
-		}
-	}
+	new.Resources = c.config.Resources.ToClientResources()
 
 	if c.config.RunAs != nil {
 		new.RunAs = &types.User{
@@ -267,6 +263,13 @@ func (c *Provider) internalCreate(ctx context.Context, sidecar bool) error {
 		return err
 	}
 
+	if c.config.Clock != nil {
+		err := c.checkFakeTimeLibrary(id)
+		if err != nil {
+			return err
+		}
+	}
+
 	// get the assigned ip addresses for the container
 	dc := c.client.ListNetworks(id)
 	for _, n := range dc {
@@ -297,11 +300,13 @@ func (c *Provider) internalCreate(ctx context.Context, sidecar bool) error {
 
 	// execute tcp health checks
 	for _, hc := range c.config.HealthCheck.TCP {
+		st := time.Now()
 		err := c.httpClient.HealthCheckTCP(
 			hc.Address,
 			timeout,
 		)
 
+		metrics.Default.ObserveHealthCheck(c.config.Meta.ID, metrics.HealthCheckTCP, time.Since(st), err)
 		if err != nil {
 			return err
 		}
@@ -309,6 +314,7 @@ func (c *Provider) internalCreate(ctx context.Context, sidecar bool) error {
 
 	// execute http health checks
 	for _, hc := range c.config.HealthCheck.HTTP {
+		st := time.Now()
 		err := c.httpClient.HealthCheckHTTP(
 			hc.Address,
 			hc.Method,
@@ -318,13 +324,16 @@ func (c *Provider) internalCreate(ctx context.Context, sidecar bool) error {
 			timeout,
 		)
 
+		metrics.Default.ObserveHealthCheck(c.config.Meta.ID, metrics.HealthCheckHTTP, time.Since(st), err)
 		if err != nil {
 			return err
 		}
 	}
 
 	for _, hc := range c.config.HealthCheck.Exec {
+		st := time.Now()
 		err := c.runExecHealthCheck(ctx, id, hc.Command, hc.Script, hc.ExitCode, timeout)
+		metrics.Default.ObserveHealthCheck(c.config.Meta.ID, metrics.HealthCheckExec, time.Since(st), err)
 		if err != nil {
 			return err
 		}
@@ -386,6 +395,30 @@ func (c *Provider) runExecHealthCheck(ctx context.Context, id string, command []
 	}
 }
 
+// checkFakeTimeLibrary ensures libfaketime exists in the container, when the
+// library is missing the dynamic linker ignores LD_PRELOAD and the container
+// silently runs with the real time, the container is removed when the check
+// fails
+func (c *Provider) checkFakeTimeLibrary(id string) error {
+	ok, err := c.client.FileExists(id, c.config.Clock.Library)
+	if err == nil && !ok {
+		err = fmt.Errorf("libfaketime not found at %s, install it in the image or mount it with a volume, and set the clock library when it is installed to a different path", c.config.Clock.Library)
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	c.log.Error("Unable to use fake clock", "ref", c.config.Meta.ID, "library", c.config.Clock.Library, "error", err)
+
+	rerr := c.client.RemoveContainer(id, true)
+	if rerr != nil {
+		c.log.Error("Unable to remove container", "ref", c.config.Meta.ID, "error", rerr)
+	}
+
+	return err
+}
+
 func (c *Provider) internalDestroy(ctx context.Context, force bool) error {
 	if ctx.Err() != nil {
 		c.log.Debug("Context cancelled, skipping container destroy", "ref", c.config.Meta.ID)
@@ -398,13 +431,7 @@ func (c *Provider) internalDestroy(ctx context.Context, force bool) error {
 	}
 
 	if len(ids) > 0 {
-		for _, id := range ids {
-			err := c.client.RemoveContainer(id, force)
-
-			if err != nil {
-				return err
-			}
-		}
+		return c.client.RemoveContainers(ids, force)
 	}
 
 	return nil
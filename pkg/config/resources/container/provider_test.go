@@ -209,7 +209,7 @@ func TestContainerDestroysCorrectlyWhenContainerExists(t *testing.T) {
 	p := Provider{config: cc, client: md, httpClient: hc, log: logger.NewTestLogger(t)}
 
 	md.On("FindContainerIDs", cc.ContainerName).Return([]string{"abc"}, nil)
-	md.On("RemoveContainer", "abc", false).Return(nil)
+	md.On("RemoveContainers", []string{"abc"}, false).Return(nil)
 	md.On("DetachNetwork", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	err := p.Destroy(context.Background(), false)
@@ -225,7 +225,7 @@ func TestContainerDoesNotDestroysWhenNotExists(t *testing.T) {
 
 	err := p.Destroy(context.Background(), false)
 	assert.NoError(t, err)
-	md.AssertNotCalled(t, "RemoveContainer")
+	md.AssertNotCalled(t, "RemoveContainers")
 }
 
 func TestContainerDoesNotDestroysWhenLookupError(t *testing.T) {
@@ -237,7 +237,7 @@ func TestContainerDoesNotDestroysWhenLookupError(t *testing.T) {
 
 	err := p.Destroy(context.Background(), false)
 	assert.Error(t, err)
-	md.AssertNotCalled(t, "RemoveContainer")
+	md.AssertNotCalled(t, "RemoveContainers")
 }
 
 func TestContainerLooksupIDs(t *testing.T) {
@@ -275,3 +275,37 @@ func TestContainerAddsResources(t *testing.T) {
 	assert.Equal(t, "nvidia", ac.Resources.GPU.Driver)
 	assert.Equal(t, []string{"1"}, ac.Resources.GPU.DeviceIDs)
 }
+
+func TestContainerAddsClockEnvironment(t *testing.T) {
+	cc, md, hc := setupContainerTests(t)
+	cc.Environment = map[string]string{"FOO": "bar"}
+	cc.Clock = &Clock{Offset: "+30d", Rate: 60, Library: DefaultFakeTimeLibrary}
+
+	md.On("FileExists", "12345", DefaultFakeTimeLibrary).Once().Return(true, nil)
+
+	p := Provider{config: cc, client: md, httpClient: hc, log: logger.NewTestLogger(t)}
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	ac := testutils.GetCalls(&md.Mock, "CreateContainer")[0].Arguments[0].(*ctypes.Container)
+	assert.Equal(t, "bar", ac.Environment["FOO"])
+	assert.Equal(t, "+30d x60", ac.Environment["FAKETIME"])
+	assert.Equal(t, DefaultFakeTimeLibrary, ac.Environment["LD_PRELOAD"])
+
+	// the environment in the config is not changed
+	assert.Len(t, cc.Environment, 1)
+}
+
+func TestContainerWithClockAndMissingLibraryReturnsError(t *testing.T) {
+	cc, md, hc := setupContainerTests(t)
+	cc.Clock = &Clock{Offset: "+30d", Rate: 1, Library: DefaultFakeTimeLibrary}
+
+	md.On("FileExists", "12345", DefaultFakeTimeLibrary).Once().Return(false, nil)
+	md.On("RemoveContainer", "12345", true).Once().Return(nil)
+
+	p := Provider{config: cc, client: md, httpClient: hc, log: logger.NewTestLogger(t)}
+	err := p.Create(context.Background())
+	assert.ErrorContains(t, err, "libfaketime not found")
+
+	md.AssertCalled(t, "RemoveContainer", "12345", true)
+}
@@ -0,0 +1,152 @@
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// DefaultFakeTimeLibrary is the path of libfaketime in Alpine images
+const DefaultFakeTimeLibrary = "/usr/lib/faketime/libfaketime.so.1"
+
+var clockOffsetRegex = regexp.MustCompile(`^[+-][0-9]+(\.[0-9]+)?[smhdy]?$`)
+
+/*
+Clock runs the processes in a container with a fake clock using libfaketime,
+the clock can be offset from the real time, start at a fixed time, and run
+faster or slower than the real time. This can be used to demo certificate
+expiry, token rotation, and scheduled jobs without waiting.
+
+libfaketime is loaded with LD_PRELOAD so it must be installed in the image or
+mounted with a volume, creating the container fails when the library can not
+be found. The fake clock only changes the time for dynamically linked
+programs, statically linked programs, including most Go programs such as
+Vault and Consul, read the real time. Linux time namespaces are not used as
+they can not change the wall clock.
+
+The following example builds an Alpine image with libfaketime installed from
+a Dockerfile containing:
+
+	FROM alpine:3.20
+	RUN apk add --no-cache libfaketime
+
+```hcl
+
+	resource "build" "faketime" {
+	  container {
+	    dockerfile = "./Dockerfile"
+	    context    = "./"
+	  }
+	}
+
+	resource "container" "clock" {
+	  image {
+	    name = resource.build.faketime.image
+	  }
+
+	  command = ["sh", "-c", "while true; do date; sleep 1; done"]
+
+	  clock {
+	    offset = "+30d"
+	    rate   = 60
+	  }
+	}
+
+```
+*/
+type Clock struct {
+	// Offset from the real time e.g. +30d or -2h, the units are s, m, h, d,
+	// and y, seconds are used when no unit is given
+	Offset string `hcl:"offset,optional" json:"offset,omitempty"`
+
+	// StartAt is the RFC3339 time the clock starts at when the container
+	// starts e.g. 2030-01-01T00:00:00Z, can not be used with offset
+	StartAt string `hcl:"start_at,optional" json:"start_at,omitempty"`
+
+	// Rate the clock runs at compared to the real time, 60 makes a minute
+	// pass every second, defaults to 1
+	Rate float64 `hcl:"rate,optional" json:"rate,omitempty"`
+
+	// Library is the path to libfaketime in the container, defaults to
+	// /usr/lib/faketime/libfaketime.so.1, Debian and Ubuntu images install it
+	// to /usr/lib/x86_64-linux-gnu/faketime/libfaketime.so.1
+	Library string `hcl:"library,optional" json:"library,omitempty"`
+}
+
+// Process validates the clock and sets the defaults
+func (c *Clock) Process() error {
+	if c.Offset != "" && c.StartAt != "" {
+		return fmt.Errorf("clock can not have both offset and start_at")
+	}
+
+	if c.Offset != "" && !clockOffsetRegex.MatchString(c.Offset) {
+		return fmt.Errorf("invalid clock offset %s, offset must start with + or - followed by a number and a unit s, m, h, d, or y e.g. +30d", c.Offset)
+	}
+
+	if c.StartAt != "" {
+		if _, err := time.Parse(time.RFC3339, c.StartAt); err != nil {
+			return fmt.Errorf("invalid clock start_at %s, start_at must be an RFC3339 time e.g. 2030-01-01T00:00:00Z", c.StartAt)
+		}
+	}
+
+	if c.Rate < 0 {
+		return fmt.Errorf("invalid clock rate %v, rate must be greater than 0", c.Rate)
+	}
+
+	if c.Rate == 0 {
+		c.Rate = 1
+	}
+
+	if c.Library == "" {
+		c.Library = DefaultFakeTimeLibrary
+	}
+
+	return nil
+}
+
+// FakeTime returns the value for the libfaketime FAKETIME environment
+// variable
+func (c *Clock) FakeTime() string {
+	ft := "+0"
+
+	if c.Offset != "" {
+		ft = c.Offset
+	}
+
+	if c.StartAt != "" {
+		// validated by Process, containers use UTC unless TZ is set
+		t, _ := time.Parse(time.RFC3339, c.StartAt)
+		ft = "@" + t.UTC().Format(time.DateTime)
+	}
+
+	if c.Rate != 0 && c.Rate != 1 {
+		ft = fmt.Sprintf("%s x%s", ft, strconv.FormatFloat(c.Rate, 'f', -1, 64))
+	}
+
+	return ft
+}
+
+// Environment returns the environment variables that load libfaketime, the
+// environment of the container is used to keep any existing preloads and to
+// allow the libfaketime settings to be overridden
+func (c *Clock) Environment(env map[string]string) map[string]string {
+	out := map[string]string{
+		"FAKETIME": c.FakeTime(),
+		// start the clock when the container starts not when each process starts
+		"FAKETIME_DONT_RESET": "1",
+		// faking the monotonic clock breaks timeouts in many programs
+		"FAKETIME_DONT_FAKE_MONOTONIC": "1",
+	}
+
+	for k, v := range env {
+		out[k] = v
+	}
+
+	out["LD_PRELOAD"] = c.Library
+	if env["LD_PRELOAD"] != "" {
+		out["LD_PRELOAD"] = fmt.Sprintf("%s:%s", c.Library, env["LD_PRELOAD"])
+	}
+
+	return out
+}
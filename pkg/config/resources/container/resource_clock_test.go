@@ -0,0 +1,75 @@
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockProcessSetsDefaults(t *testing.T) {
+	c := &Clock{Offset: "+30d"}
+
+	err := c.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, float64(1), c.Rate)
+	require.Equal(t, DefaultFakeTimeLibrary, c.Library)
+}
+
+func TestClockProcessWithOffsetAndStartAtReturnsError(t *testing.T) {
+	c := &Clock{Offset: "+30d", StartAt: "2030-01-01T00:00:00Z"}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "both offset and start_at")
+}
+
+func TestClockProcessInvalidOffsetReturnsError(t *testing.T) {
+	c := &Clock{Offset: "30 days"}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid clock offset 30 days")
+}
+
+func TestClockProcessInvalidStartAtReturnsError(t *testing.T) {
+	c := &Clock{StartAt: "tomorrow"}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid clock start_at tomorrow")
+}
+
+func TestClockProcessNegativeRateReturnsError(t *testing.T) {
+	c := &Clock{Rate: -1}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid clock rate -1")
+}
+
+func TestClockFakeTimeWithOffsetAndRate(t *testing.T) {
+	c := &Clock{Offset: "-2h", Rate: 60}
+
+	require.Equal(t, "-2h x60", c.FakeTime())
+}
+
+func TestClockFakeTimeWithStartAt(t *testing.T) {
+	c := &Clock{StartAt: "2030-01-01T01:00:00+01:00", Rate: 1}
+
+	require.Equal(t, "@2030-01-01 00:00:00", c.FakeTime())
+}
+
+func TestClockFakeTimeWithRateOnly(t *testing.T) {
+	c := &Clock{Rate: 0.5}
+
+	require.Equal(t, "+0 x0.5", c.FakeTime())
+}
+
+func TestClockEnvironmentKeepsExistingPreload(t *testing.T) {
+	c := &Clock{Offset: "+1y", Library: "/lib/faketime.so"}
+
+	env := c.Environment(map[string]string{"LD_PRELOAD": "/lib/other.so", "FAKETIME_DONT_FAKE_MONOTONIC": "0", "FOO": "bar"})
+
+	require.Equal(t, "/lib/faketime.so:/lib/other.so", env["LD_PRELOAD"])
+	require.Equal(t, "+1y", env["FAKETIME"])
+	require.Equal(t, "0", env["FAKETIME_DONT_FAKE_MONOTONIC"])
+	require.Equal(t, "1", env["FAKETIME_DONT_RESET"])
+	require.Equal(t, "bar", env["FOO"])
+}
@@ -1,6 +1,8 @@
 package container
 
 import (
+	"fmt"
+	"net"
 	"strings"
 
 	"github.com/jumppad-labs/hclconfig/types"
@@ -17,6 +19,8 @@ type Container struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Networks        []NetworkAttachment `hcl:"network,block" json:"networks,omitempty"`           // Attach to the correct network // only when Image is specified
 	Image           Image               `hcl:"image,block" json:"image"`                          // Image to use for the container
 	Entrypoint      []string            `hcl:"entrypoint,optional" json:"entrypoint,omitempty"`   // Entrypoint to use when starting the container
@@ -40,6 +44,9 @@ type Container struct {
 	// User block for mapping the user id and group id inside the container
 	RunAs *User `hcl:"run_as,block" json:"run_as,omitempty"`
 
+	// Clock runs the container with a fake clock
+	Clock *Clock `hcl:"clock,block" json:"clock,omitempty"`
+
 	// Output parameters
 
 	// ContainerName is the fully qualified domain name for the container, this can be used
@@ -102,12 +109,37 @@ type Volume struct {
 
 type Volumes []Volume
 
+// GetLabels returns the labels for the container
+func (c *Container) GetLabels() map[string]string {
+	return c.Labels
+}
+
 func (c *Container) Process() error {
 	// process volumes
 	for i, v := range c.Volumes {
 		// make sure mount paths are absolute when type is bind, unless this is the docker sock
 		if v.Type == "" || v.Type == "bind" {
-			c.Volumes[i].Source = utils.EnsureAbsolute(v.Source, c.Meta.File)
+			src, err := utils.VolumeSource(v.Source, c.Meta.File)
+			if err != nil {
+				return fmt.Errorf("invalid source for volume %s: %s", v.Destination, err)
+			}
+
+			c.Volumes[i].Source = src
+		}
+	}
+
+	// static addresses are validated against the subnet when the container
+	// is created, here we only check they are valid addresses
+	for _, n := range c.Networks {
+		if n.IPAddress != "" && net.ParseIP(n.IPAddress) == nil {
+			return fmt.Errorf("invalid ip_address %s for network %s", n.IPAddress, n.ID)
+		}
+	}
+
+	if c.Clock != nil {
+		err := c.Clock.Process()
+		if err != nil {
+			return err
 		}
 	}
 
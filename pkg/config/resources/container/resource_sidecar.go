@@ -1,6 +1,8 @@
 package container
 
 import (
+	"fmt"
+
 	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck"
@@ -15,6 +17,8 @@ type Sidecar struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Target Container `hcl:"target" json:"target"`
 
 	Image       Image             `hcl:"image,block" json:"image"`                          // image to use for the container
@@ -41,12 +45,22 @@ type Sidecar struct {
 	ContainerName string `hcl:"container_name,optional" json:"container_name,omitempty"`
 }
 
+// GetLabels returns the labels for the sidecar
+func (c *Sidecar) GetLabels() map[string]string {
+	return c.Labels
+}
+
 func (c *Sidecar) Process() error {
 	// process volumes
 	for i, v := range c.Volumes {
 		// make sure mount paths are absolute when type is bind
 		if v.Type == "" || v.Type == "bind" {
-			c.Volumes[i].Source = utils.EnsureAbsolute(v.Source, c.Meta.File)
+			src, err := utils.VolumeSource(v.Source, c.Meta.File)
+			if err != nil {
+				return fmt.Errorf("invalid source for volume %s: %s", v.Destination, err)
+			}
+
+			c.Volumes[i].Source = src
 		}
 	}
 
@@ -2,9 +2,11 @@ package container
 
 import (
 	"os"
+	"runtime"
 	"testing"
 
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/stretchr/testify/require"
 )
 
@@ -26,3 +28,22 @@ func TestContainerProcessSetsAbsolute(t *testing.T) {
 
 	require.Equal(t, wd, c.Volumes[0].Source)
 }
+
+func TestContainerProcessWithWindowsPathReturnsError(t *testing.T) {
+	if runtime.GOOS == "windows" || utils.IsWSL() {
+		t.Skip("windows paths can be shared on this host")
+	}
+
+	c := &Container{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Volumes: []Volume{
+			{
+				Source:      `C:\Users\nic\files`,
+				Destination: "/files",
+			},
+		},
+	}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid source for volume /files")
+}
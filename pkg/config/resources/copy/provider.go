@@ -65,7 +65,7 @@ func (p *Provider) Create(ctx context.Context) error {
 			}
 		}()
 
-		err := p.getter.Get(p.config.Source, tempPath)
+		err := p.getter.Get(p.sourceURL(), tempPath)
 		if err != nil {
 			return fmt.Errorf("error getting source from %s: %v", p.config.Source, err)
 		}
@@ -78,6 +78,37 @@ func (p *Provider) Create(ctx context.Context) error {
 		}
 
 		srcPath = tempPath
+	} else {
+		// remote sources are verified by the getter, local files need to be
+		// checked before copying
+		if p.config.Checksum != "" {
+			err := getter.VerifyChecksum(srcPath, p.config.Checksum)
+			if err != nil {
+				return fmt.Errorf("unable to verify source for copy resource, ref=%s: %w", p.config.Meta.ID, err)
+			}
+		}
+
+		if p.config.Unpack != nil && *p.config.Unpack {
+			tempPath := filepath.Join(utils.JumppadTemp(), "copy", p.config.Meta.ID)
+			os.RemoveAll(tempPath)
+
+			defer func() {
+				// clean up temporary files
+				err := os.RemoveAll(tempPath)
+				if err != nil {
+					p.log.Warn("Error removing temporary files", "ref", p.config.Meta.Name, "path", tempPath, "error", err)
+				}
+			}()
+
+			p.log.Debug("Unpacking archive", "ref", p.config.Meta.ID, "source", srcPath, "path", tempPath)
+
+			err := getter.Unpack(srcPath, tempPath)
+			if err != nil {
+				return fmt.Errorf("unable to unpack source for copy resource, ref=%s: %w", p.config.Meta.ID, err)
+			}
+
+			srcPath = tempPath
+		}
 	}
 
 	// Check the dest exists, if so grab the existing perms
@@ -131,9 +162,56 @@ func (p *Provider) Create(ctx context.Context) error {
 		os.Chmod(p.config.Destination, originalPerms)
 	}
 
+	cs, err := p.sourceChecksum()
+	if err != nil {
+		return fmt.Errorf("unable to generate checksum for source, ref=%s: %w", p.config.Meta.Name, err)
+	}
+
+	p.config.SourceChecksum = cs
+
 	return nil
 }
 
+// sourceChecksum returns the hash of a local source file or directory, remote
+// sources are not hashed and return an empty string
+func (p *Provider) sourceChecksum() (string, error) {
+	s, err := os.Stat(p.config.Source)
+	if err != nil {
+		return "", nil
+	}
+
+	if s.IsDir() {
+		return utils.HashDir(p.config.Source)
+	}
+
+	return utils.HashFile(p.config.Source)
+}
+
+// sourceURL returns the url for remote sources with the checksum and
+// archive options appended as getter query parameters
+func (p *Provider) sourceURL() string {
+	params := []string{}
+
+	if p.config.Checksum != "" {
+		params = append(params, "checksum="+p.config.Checksum)
+	}
+
+	if p.config.Unpack != nil && !*p.config.Unpack {
+		params = append(params, "archive=false")
+	}
+
+	if len(params) == 0 {
+		return p.config.Source
+	}
+
+	sep := "?"
+	if strings.Contains(p.config.Source, "?") {
+		sep = "&"
+	}
+
+	return p.config.Source + sep + strings.Join(params, "&")
+}
+
 func (p *Provider) Destroy(ctx context.Context, force bool) error {
 	if ctx.Err() != nil {
 		p.log.Debug("Context is cacncelled, skipping destroy", "ref", p.config.Meta.ID)
@@ -163,11 +241,50 @@ func (p *Provider) Lookup() ([]string, error) {
 }
 
 func (p *Provider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Context is cancelled, skipping refresh", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	changed, err := p.Changed()
+	if err != nil {
+		return err
+	}
+
+	if !changed {
+		return nil
+	}
+
 	p.log.Debug("Refresh Copied files", "ref", p.config.Meta.Name)
-	return nil
+
+	// remove the previously copied files so that files deleted from the
+	// source are not left in the destination
+	err = p.Destroy(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return p.Create(ctx)
 }
 
 func (p *Provider) Changed() (bool, error) {
 	p.log.Debug("Checking changes", "ref", p.config.Meta.Name)
+
+	// remote sources and resources created before the checksum was recorded
+	// are not compared
+	if p.config.SourceChecksum == "" {
+		return false, nil
+	}
+
+	cs, err := p.sourceChecksum()
+	if err != nil {
+		return false, fmt.Errorf("unable to generate checksum for source, ref=%s: %w", p.config.Meta.Name, err)
+	}
+
+	if cs != p.config.SourceChecksum {
+		p.log.Debug("Source has changed, needs refresh", "ref", p.config.Meta.ID)
+		return true, nil
+	}
+
 	return false, nil
 }
@@ -1,6 +1,7 @@
 package copy
 
 import (
+	"archive/zip"
 	"context"
 	"os"
 	"path"
@@ -29,7 +30,7 @@ func setupCopy(t *testing.T) (*Copy, *Provider) {
 	cc.Source = inDir
 	cc.Destination = outDir
 
-	p := &Provider{logger.NewTestLogger(t), cc, getter.NewGetter(true)}
+	p := &Provider{logger.NewTestLogger(t), cc, getter.NewGetter(true, nil)}
 
 	return cc, p
 }
@@ -106,3 +107,119 @@ func TestFetchesRemoteFiles(t *testing.T) {
 
 	require.FileExists(t, path.Join(c.Destination, "README.md"))
 }
+
+// sha256 of the contents of file1.txt
+const file1SHA256 = "c147efcfc2d7ea666a9e4f5187b115c90903f0fc896a56df9a6ef5d8f3fc9f31"
+
+func TestCopiesASingleFileWithChecksum(t *testing.T) {
+	c, p := setupCopy(t)
+
+	dDir := c.Destination
+	c.Source = path.Join(c.Source, "file1.txt")
+	c.Destination = path.Join(c.Destination, "file1.txt")
+	c.Checksum = "sha256:" + file1SHA256
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.FileExists(t, path.Join(dDir, "file1.txt"))
+}
+
+func TestCopyReturnsErrorWhenChecksumDoesNotMatch(t *testing.T) {
+	c, p := setupCopy(t)
+
+	dDir := c.Destination
+	c.Source = path.Join(c.Source, "file2.txt")
+	c.Destination = path.Join(c.Destination, "file2.txt")
+	c.Checksum = "sha256:" + file1SHA256
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "checksum mismatch")
+
+	require.NoFileExists(t, path.Join(dDir, "file2.txt"))
+}
+
+func TestCopyUnpacksALocalArchive(t *testing.T) {
+	c, p := setupCopy(t)
+
+	archive := path.Join(t.TempDir(), "files.zip")
+	f, err := os.Create(archive)
+	require.NoError(t, err)
+
+	w := zip.NewWriter(f)
+	fw, err := w.Create("sub/file3.txt")
+	require.NoError(t, err)
+	fw.Write([]byte("file3"))
+	w.Close()
+	f.Close()
+
+	unpack := true
+	c.Source = archive
+	c.Unpack = &unpack
+
+	err = p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.FileExists(t, path.Join(c.Destination, "sub", "file3.txt"))
+	require.NoFileExists(t, path.Join(c.Destination, "files.zip"))
+}
+
+func TestCopyAppendsGetterOptionsToRemoteSource(t *testing.T) {
+	c, p := setupCopy(t)
+
+	unpack := false
+	c.Source = "git::https://github.com/jumppad-labs/jumppad?ref=v0.5.0"
+	c.Checksum = "sha256:" + file1SHA256
+	c.Unpack = &unpack
+
+	require.Equal(t, "git::https://github.com/jumppad-labs/jumppad?ref=v0.5.0&checksum=sha256:"+file1SHA256+"&archive=false", p.sourceURL())
+
+	c.Source = "https://example.com/files.tar.gz"
+	c.Unpack = nil
+
+	require.Equal(t, "https://example.com/files.tar.gz?checksum=sha256:"+file1SHA256, p.sourceURL())
+}
+
+func TestCopyRefreshCopiesChangedSource(t *testing.T) {
+	c, p := setupCopy(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+	require.NotEmpty(t, c.SourceChecksum)
+
+	os.WriteFile(path.Join(c.Source, "file1.txt"), []byte("changed"), 0755)
+	os.Remove(path.Join(c.Source, "file2.txt"))
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	err = p.Refresh(context.Background())
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(path.Join(c.Destination, "file1.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "changed", string(d))
+	require.NoFileExists(t, path.Join(c.Destination, "file2.txt"))
+}
+
+func TestCopyRefreshDoesNotCopyUnchangedSource(t *testing.T) {
+	c, p := setupCopy(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	// files edited in the destination are not overwritten
+	os.WriteFile(path.Join(c.Destination, "file1.txt"), []byte("edited"), 0755)
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	err = p.Refresh(context.Background())
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(path.Join(c.Destination, "file1.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "edited", string(d))
+}
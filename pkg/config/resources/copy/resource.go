@@ -1,9 +1,11 @@
 package copy
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/getter"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 )
@@ -17,14 +19,29 @@ type Copy struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Depends []string `hcl:"depends_on,optional" json:"depends,omitempty"`
 
 	Source      string `hcl:"source" json:"source"`                              // Source file, folder, url, git repo, etc
 	Destination string `hcl:"destination" json:"destination"`                    // Destination to write file or files to
 	Permissions string `hcl:"permissions,optional" json:"permissions,omitempty"` // Permissions 0777 to set for written file
 
+	// Checksum verifies the source file before it is copied, the value is in
+	// the format type:value where type is one of md5, sha1, sha256, or sha512
+	Checksum string `hcl:"checksum,optional" json:"checksum,omitempty"`
+
+	// Unpack controls the extraction of tar and zip archives into the
+	// destination, when not set remote archives are unpacked and local
+	// archives are copied as is
+	Unpack *bool `hcl:"unpack,optional" json:"unpack,omitempty"`
+
 	// outputs
 	CopiedFiles []string `hcl:"copied_files,optional" json:"copied_files"`
+
+	// SourceChecksum is the hash of a local source when it was copied, it is
+	// used to copy the files again when the source changes
+	SourceChecksum string `hcl:"source_checksum,optional" json:"source_checksum,omitempty"`
 }
 
 func (t *Copy) Process() error {
@@ -36,6 +53,22 @@ func (t *Copy) Process() error {
 
 	t.Destination = utils.EnsureAbsolute(t.Destination, t.Meta.File)
 
+	if t.Checksum != "" {
+		if _, _, err := getter.ParseChecksum(t.Checksum); err != nil {
+			return err
+		}
+
+		if s, err := os.Stat(t.Source); err == nil && s.IsDir() {
+			return fmt.Errorf("checksum can not be specified when the source %s is a directory", t.Source)
+		}
+	}
+
+	if t.Unpack != nil && *t.Unpack {
+		if s, err := os.Stat(t.Source); err == nil && (s.IsDir() || getter.ArchiveType(t.Source) == "") {
+			return fmt.Errorf("unable to unpack %s, source is not a tar or zip archive", t.Source)
+		}
+	}
+
 	cfg, err := config.LoadState()
 	if err == nil {
 		// try and find the resource in the state
@@ -43,6 +76,7 @@ func (t *Copy) Process() error {
 		if r != nil {
 			kstate := r.(*Copy)
 			t.CopiedFiles = kstate.CopiedFiles
+			t.SourceChecksum = kstate.SourceChecksum
 		}
 	}
 
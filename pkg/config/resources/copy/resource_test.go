@@ -77,3 +77,41 @@ func TestCopySetsOutputsFromState(t *testing.T) {
 
 	require.Equal(t, []string{"a", "b"}, c.CopiedFiles)
 }
+
+func TestCopyProcessReturnsErrorWhenChecksumInvalid(t *testing.T) {
+	c := &Copy{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Source:       "https://example.com/file.txt",
+		Destination:  "./",
+		Checksum:     "sha256",
+	}
+
+	err := c.Process()
+	require.Error(t, err)
+}
+
+func TestCopyProcessReturnsErrorWhenChecksumForDirectory(t *testing.T) {
+	c := &Copy{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Source:       "./",
+		Destination:  "./",
+		Checksum:     "md5:5d41402abc4b2a76b9719d911017c592",
+	}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "directory")
+}
+
+func TestCopyProcessReturnsErrorWhenUnpackingNonArchive(t *testing.T) {
+	unpack := true
+
+	c := &Copy{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Source:       "./resource.go",
+		Destination:  "./",
+		Unpack:       &unpack,
+	}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "not a tar or zip archive")
+}
@@ -0,0 +1,136 @@
+package custom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/plugins"
+	"github.com/jumppad-labs/jumppad/pkg/clients/wasm"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+// checks Provider implements the sdk.Provider interface
+var _ sdk.Provider = &Provider{}
+
+// Provider runs the WASM module for a Custom resource, the request and
+// response use the same format as external plugins
+type Provider struct {
+	config *Custom
+	wasm   wasm.WASM
+	log    sdk.Logger
+}
+
+func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Custom)
+	if !ok {
+		return fmt.Errorf("unable to initialize provider, resource is not of type Custom")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.wasm = cli.WASM
+	p.log = l
+
+	return nil
+}
+
+func (p *Provider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Creating Custom resource", "ref", p.config.Meta.ID, "module", p.config.Module)
+
+	resp, err := p.run(ctx, plugins.OperationCreate)
+	if err != nil {
+		return err
+	}
+
+	return p.config.setOutput(resp.Output)
+}
+
+func (p *Provider) Destroy(ctx context.Context, force bool) error {
+	p.log.Info("Destroy Custom resource", "ref", p.config.Meta.ID, "module", p.config.Module)
+
+	_, err := p.run(ctx, plugins.OperationDestroy)
+	return err
+}
+
+func (p *Provider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+func (p *Provider) Refresh(ctx context.Context) error {
+	p.log.Debug("Refresh Custom resource", "ref", p.config.Meta.ID)
+
+	return nil
+}
+
+// Changed returns false as changes to the module or config change the
+// checksum of the resource which causes it to be recreated
+func (p *Provider) Changed() (bool, error) {
+	return false, nil
+}
+
+func (p *Provider) run(ctx context.Context, operation string) (*plugins.Response, error) {
+	timeout, err := time.ParseDuration(p.config.Timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse timeout duration: %w", err)
+	}
+
+	req, err := json.Marshal(plugins.Request{
+		Type:   p.config.Meta.Type,
+		ID:     p.config.Meta.ID,
+		Name:   p.config.Meta.Name,
+		Config: p.config.ConfigValues,
+		Output: p.config.OutputValues,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode request: %w", err)
+	}
+
+	mounts := []wasm.Mount{}
+	for _, m := range p.config.Mounts {
+		mounts = append(mounts, wasm.Mount{Source: m.Source, Destination: m.Destination, ReadOnly: m.ReadOnly})
+	}
+
+	out := &bytes.Buffer{}
+
+	err = p.wasm.Run(ctx, p.config.Module, wasm.RunConfig{
+		Args:        []string{operation},
+		Environment: p.config.Environment,
+		Mounts:      mounts,
+		Timeout:     timeout,
+		Stdin:       bytes.NewReader(req),
+		Stdout:      out,
+		Stderr:      p.log.StandardWriter(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to %s %s: %w", operation, p.config.Meta.ID, err)
+	}
+
+	// modules that do not return outputs do not need to write a response
+	resp := &plugins.Response{}
+	if len(bytes.TrimSpace(out.Bytes())) > 0 {
+		err = json.Unmarshal(out.Bytes(), resp)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode response from module: %w", err)
+		}
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("unable to %s %s: %s", operation, p.config.Meta.ID, resp.Error)
+	}
+
+	return resp, nil
+}
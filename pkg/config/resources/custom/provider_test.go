@@ -0,0 +1,91 @@
+package custom
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/wasm"
+	"github.com/jumppad-labs/jumppad/pkg/clients/wasm/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func setupProvider(t *testing.T, response string, err error) (*Provider, *mocks.WASM) {
+	c := &Custom{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.custom.seed", Name: "seed", Type: TypeCustom}},
+		Module:       "/modules/seed.wasm",
+		Timeout:      "30s",
+		ConfigValues: map[string]interface{}{"rows": float64(10)},
+		Mounts:       []Mount{{Source: "/tmp/data", Destination: "/data", ReadOnly: true}},
+	}
+
+	mw := mocks.NewWASM(t)
+	mw.On("Run", mock.Anything, "/modules/seed.wasm", mock.Anything).
+		Run(func(args mock.Arguments) {
+			rc := args.Get(2).(wasm.RunConfig)
+			rc.Stdout.Write([]byte(response))
+		}).
+		Return(err)
+
+	return &Provider{config: c, wasm: mw, log: logger.NewTestLogger(t)}, mw
+}
+
+func TestCreateRunsModuleWithRequest(t *testing.T) {
+	p, mw := setupProvider(t, `{"output":{"seeded":true}}`, nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	rc := mw.Calls[0].Arguments.Get(2).(wasm.RunConfig)
+	require.Equal(t, []string{"create"}, rc.Args)
+	require.Equal(t, []wasm.Mount{{Source: "/tmp/data", Destination: "/data", ReadOnly: true}}, rc.Mounts)
+
+	req, _ := io.ReadAll(rc.Stdin)
+	require.Contains(t, string(req), `"config":{"rows":10}`)
+}
+
+func TestCreateSetsOutput(t *testing.T) {
+	p, _ := setupProvider(t, `{"output":{"seeded":true}}`, nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, true, p.config.OutputValues["seeded"])
+	require.Equal(t, cty.True, p.config.Output.GetAttr("seeded"))
+}
+
+func TestCreateWithEmptyResponseDoesNotReturnError(t *testing.T) {
+	p, _ := setupProvider(t, "", nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+}
+
+func TestCreateWithErrorResponseReturnsError(t *testing.T) {
+	p, _ := setupProvider(t, `{"error":"database not ready"}`, nil)
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "database not ready")
+}
+
+func TestCreateWithModuleErrorReturnsError(t *testing.T) {
+	p, _ := setupProvider(t, "", fmt.Errorf("module exited with code 1"))
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "module exited with code 1")
+}
+
+func TestDestroyRunsModule(t *testing.T) {
+	p, mw := setupProvider(t, "", nil)
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	rc := mw.Calls[0].Arguments.Get(2).(wasm.RunConfig)
+	require.Equal(t, []string{"destroy"}, rc.Args)
+}
@@ -0,0 +1,104 @@
+package custom
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TypeCustom is the resource string for a Custom resource
+const TypeCustom string = "custom"
+
+// Custom runs a WebAssembly module to create and destroy a resource, the
+// module is sandboxed using WASI and can only access the mounted directories.
+//
+// The module is called with the operation, create or destroy, as its only
+// argument and a JSON request containing the config on stdin, the values
+// written to stdout as JSON are set as the output of the resource.
+type Custom struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Module      string            `hcl:"module" json:"module"`                              // Path to the WASI module
+	Config      cty.Value         `hcl:"config,optional" json:"-"`                          // Values passed to the module
+	Environment map[string]string `hcl:"environment,optional" json:"environment,omitempty"` // Environment variables available to the module
+	Mounts      []Mount           `hcl:"mount,block" json:"mounts,omitempty"`               // Directories the module can access
+	Timeout     string            `hcl:"timeout,optional" json:"timeout,omitempty"`         // Maximum time the module can run for
+
+	// Output parameters
+
+	Output         cty.Value `hcl:"output,optional" json:"-"`                                  // Values returned by the module
+	ModuleChecksum string    `hcl:"module_checksum,optional" json:"module_checksum,omitempty"` // Checksum of the module
+
+	// ConfigValues and OutputValues hold the config and output as plain values
+	// so that they can be sent to the module and stored in the state
+	ConfigValues map[string]interface{} `json:"config,omitempty"`
+	OutputValues map[string]interface{} `json:"output,omitempty"`
+}
+
+// Mount makes a directory on the host available to the module
+type Mount struct {
+	Source      string `hcl:"source" json:"source"`                          // Directory on the host
+	Destination string `hcl:"destination" json:"destination"`                // Path of the directory in the module
+	ReadOnly    bool   `hcl:"read_only,optional" json:"read_only,omitempty"` // Prevent the module writing to the directory
+}
+
+func (c *Custom) Process() error {
+	c.Module = utils.EnsureAbsolute(c.Module, c.Meta.File)
+
+	for i, m := range c.Mounts {
+		c.Mounts[i].Source = utils.EnsureAbsolute(m.Source, c.Meta.File)
+	}
+
+	if c.Timeout == "" {
+		c.Timeout = "300s"
+	}
+
+	d, err := os.ReadFile(c.Module)
+	if err != nil {
+		return fmt.Errorf("unable to read module for %s: %s", c.Meta.ID, err)
+	}
+
+	// changes to the module cause the resource to be recreated
+	c.ModuleChecksum, err = utils.HashString(string(d))
+	if err != nil {
+		return fmt.Errorf("unable to generate checksum for module: %s", err)
+	}
+
+	c.ConfigValues, err = config.ValuesFromCty(c.Config)
+	if err != nil {
+		return fmt.Errorf("unable to read config for %s: %s", c.Meta.ID, err)
+	}
+
+	// restore the output from the state so that it can be used by dependent
+	// resources
+	cfg, err := config.LoadState()
+	if err == nil {
+		r, _ := cfg.FindResource(c.Meta.ID)
+		if r != nil {
+			state := r.(*Custom)
+			c.setOutput(state.OutputValues)
+		}
+	}
+
+	return nil
+}
+
+// setOutput sets the output values returned by the module
+func (c *Custom) setOutput(o map[string]interface{}) error {
+	v, err := config.CtyFromValues(o)
+	if err != nil {
+		return err
+	}
+
+	c.OutputValues = o
+	c.Output = v
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package docs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// DefaultLocale is the locale used for page content when no locale is set
+const DefaultLocale = "en"
+
+var localeRegex = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z]{2,4})?$`)
+
+var variantRegex = regexp.MustCompile(`<Variant\s+name="([^"]*)"\s+value="([^"]*)"`)
+
+// validLocale returns true when the locale is a language code with an
+// optional region e.g. fr or pt-BR
+func validLocale(l string) bool {
+	return localeRegex.MatchString(l)
+}
+
+// loadPage reads the content for a page from the source file and any
+// translations found alongside it, e.g. for the source index.md the file
+// index.fr.md is loaded as the fr translation. Translations set explicitly
+// take precedence over files.
+func loadPage(p *Page, file string) error {
+	if p.Source == "" {
+		if p.Content == "" {
+			return fmt.Errorf("page %s must specify either content or source", p.Name)
+		}
+
+		return nil
+	}
+
+	if p.Content != "" {
+		return fmt.Errorf("page %s can not specify both content and source", p.Name)
+	}
+
+	p.Source = utils.EnsureAbsolute(p.Source, file)
+
+	d, err := os.ReadFile(p.Source)
+	if err != nil {
+		return fmt.Errorf("unable to read source for page %s: %w", p.Name, err)
+	}
+
+	p.Content = string(d)
+
+	translations, err := findTranslations(p.Source)
+	if err != nil {
+		return fmt.Errorf("unable to read translations for page %s: %w", p.Name, err)
+	}
+
+	for l, c := range p.Translations {
+		translations[l] = c
+	}
+
+	p.Translations = translations
+
+	return nil
+}
+
+// findTranslations returns the content of the files next to source that
+// have a locale before the extension keyed by the locale
+func findTranslations(source string) (map[string]string, error) {
+	ext := filepath.Ext(source)
+	base := strings.TrimSuffix(filepath.Base(source), ext)
+
+	files, err := filepath.Glob(filepath.Join(filepath.Dir(source), base+".*"+ext))
+	if err != nil {
+		return nil, err
+	}
+
+	translations := map[string]string{}
+	for _, f := range files {
+		l := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(f), base+"."), ext)
+		if !validLocale(l) {
+			continue
+		}
+
+		d, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+
+		translations[l] = string(d)
+	}
+
+	return translations, nil
+}
+
+// pageLocales returns the sorted locales that a page has content for
+func pageLocales(p Page, defaultLocale string) []string {
+	locales := []string{defaultLocale}
+	for l := range p.Translations {
+		locales = append(locales, l)
+	}
+
+	slices.Sort(locales)
+
+	return slices.Compact(locales)
+}
+
+// checkVariants returns an error when the content references a variant or
+// value that is not defined
+func checkVariants(content string, variants []Variant) error {
+	for _, m := range variantRegex.FindAllStringSubmatch(content, -1) {
+		i := slices.IndexFunc(variants, func(v Variant) bool { return v.Name == m[1] })
+		if i == -1 {
+			return fmt.Errorf("variant %q is not defined", m[1])
+		}
+
+		if !slices.Contains(variants[i].Options, m[2]) {
+			return fmt.Errorf("variant %q does not have the option %q", m[1], m[2])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,69 @@
+package docs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupPageSource(t *testing.T) string {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		"index.md":       "# Welcome",
+		"index.fr.md":    "# Bienvenue",
+		"index.pt-BR.md": "# Bem-vindo",
+		"index.notes.md": "ignored",
+		"other.md":       "ignored",
+	}
+
+	for f, c := range files {
+		err := os.WriteFile(filepath.Join(dir, f), []byte(c), 0644)
+		require.NoError(t, err)
+	}
+
+	return filepath.Join(dir, "index.md")
+}
+
+func TestLoadPageReadsSourceAndTranslations(t *testing.T) {
+	p := &Page{Name: "index", Source: setupPageSource(t)}
+
+	err := loadPage(p, "")
+	require.NoError(t, err)
+
+	require.Equal(t, "# Welcome", p.Content)
+	require.Equal(t, map[string]string{"fr": "# Bienvenue", "pt-BR": "# Bem-vindo"}, p.Translations)
+}
+
+func TestLoadPageExplicitTranslationsTakePrecedence(t *testing.T) {
+	p := &Page{Name: "index", Source: setupPageSource(t), Translations: map[string]string{"fr": "# Salut"}}
+
+	err := loadPage(p, "")
+	require.NoError(t, err)
+
+	require.Equal(t, "# Salut", p.Translations["fr"])
+}
+
+func TestLoadPageReturnsErrorWithoutContent(t *testing.T) {
+	err := loadPage(&Page{Name: "index"}, "")
+	require.Error(t, err)
+
+	err = loadPage(&Page{Name: "index", Content: "# Hello", Source: "./index.md"}, "")
+	require.Error(t, err)
+}
+
+func TestPageLocalesIncludesDefault(t *testing.T) {
+	p := Page{Translations: map[string]string{"fr": "", "de": ""}}
+
+	require.Equal(t, []string{"de", "en", "fr"}, pageLocales(p, "en"))
+}
+
+func TestCheckVariantsValidatesReferences(t *testing.T) {
+	variants := []Variant{{Name: "os", Options: []string{"linux", "macos"}}}
+
+	require.NoError(t, checkVariants(`<Variant name="os" value="linux">apt install</Variant>`, variants))
+	require.Error(t, checkVariants(`<Variant name="cloud" value="aws">aws</Variant>`, variants))
+	require.Error(t, checkVariants(`<Variant name="os" value="windows">choco</Variant>`, variants))
+}
@@ -0,0 +1,41 @@
+package docs
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/progress"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRestoreProgressSetsSavedStatus(t *testing.T) {
+	tasks := []Progress{
+		{ID: "resource.task.install", Status: "unlocked", Conditions: []ProgressCondition{{ID: "installed"}}},
+		{ID: "resource.task.init", Prerequisites: []string{"resource.task.install"}, Status: "locked"},
+		{ID: "resource.task.apply", Prerequisites: []string{"resource.task.init"}, Status: "locked"},
+	}
+
+	restoreProgress(tasks, map[string]progress.Task{
+		"resource.task.install": {
+			ID:         "resource.task.install",
+			Status:     "completed",
+			Conditions: []progress.Condition{{ID: "installed", Status: "completed"}},
+		},
+	})
+
+	require.Equal(t, "completed", tasks[0].Status)
+	require.Equal(t, "completed", tasks[0].Conditions[0].Status)
+	require.Equal(t, "unlocked", tasks[1].Status)
+	require.Equal(t, "locked", tasks[2].Status)
+}
+
+func TestRestoreProgressIgnoresFailedTasks(t *testing.T) {
+	tasks := []Progress{
+		{ID: "resource.task.install", Status: "unlocked"},
+	}
+
+	restoreProgress(tasks, map[string]progress.Task{
+		"resource.task.install": {ID: "resource.task.install", Status: "failed"},
+	})
+
+	require.Equal(t, "unlocked", tasks[0].Status)
+}
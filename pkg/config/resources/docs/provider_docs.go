@@ -7,12 +7,17 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	htypes "github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/progress"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 	"github.com/mohae/deepcopy"
@@ -21,9 +26,16 @@ import (
 const docsImageName = "ghcr.io/jumppad-labs/docs"
 const docsVersion = "v0.5.1"
 
+// docsEventsPath is the path on the API server the docs UI connects to
+// for live reload events
+const docsEventsPath = "/docs/events"
+
 type DocsConfig struct {
-	DefaultPath string `json:"defaultPath"`
-	Logo        Logo   `json:"logo"`
+	DefaultPath   string    `json:"defaultPath"`
+	Logo          Logo      `json:"logo"`
+	DefaultLocale string    `json:"defaultLocale"`
+	Locales       []string  `json:"locales"`
+	Variants      []Variant `json:"variants"`
 }
 
 type State struct {
@@ -76,13 +88,19 @@ type ChapterIndex struct {
 type ChapterIndexPage struct {
 	Title string `hcl:"title" json:"title"`
 	URI   string `hcl:"uri" json:"uri"`
+
+	// Titles of the translated pages keyed by locale
+	Titles  map[string]string `hcl:"titles,optional" json:"titles,omitempty"`
+	Locales []string          `hcl:"locales,optional" json:"locales,omitempty"`
 }
 
 // Docs defines a provider for creating documentation containers
 type DocsProvider struct {
-	config *Docs
-	client container.ContainerTasks
-	log    sdk.Logger
+	config    *Docs
+	client    container.ContainerTasks
+	connector connector.Connector
+	progress  progress.Store
+	log       sdk.Logger
 }
 
 func (p *DocsProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
@@ -98,6 +116,8 @@ func (p *DocsProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
 
 	p.config = c
 	p.client = cli.ContainerTasks
+	p.connector = cli.Connector
+	p.progress = progress.NewFileStore(progress.DefaultPath())
 	p.log = l
 
 	return nil
@@ -111,7 +131,7 @@ func (p *DocsProvider) Create(ctx context.Context) error {
 	}
 
 	p.log.Info("Creating Documentation", "ref", p.config.Meta.ID)
-	err := p.generateDocs()
+	_, err := p.generateDocs()
 	if err != nil {
 		return err
 	}
@@ -172,7 +192,34 @@ func (p *DocsProvider) Refresh(ctx context.Context) error {
 		return nil
 	}
 
-	return p.generateDocs()
+	pages, err := p.generateDocs()
+	if err != nil {
+		return err
+	}
+
+	// when authoring with jumppad dev push the changes to the docs UI
+	// rather than waiting for the user to reload the page
+	if utils.IsDevMode() {
+		p.reload(pages)
+	}
+
+	return nil
+}
+
+// reload notifies the docs UI that the content has changed, failure is not
+// fatal as the content has already been written
+func (p *DocsProvider) reload(pages []string) {
+	p.log.Debug("Reloading docs", "ref", p.config.Meta.ID, "pages", pages)
+
+	err := p.connector.ReloadDocs(ctypes.DocsReload{
+		ID:       p.config.Meta.ID,
+		Checksum: p.config.ContentChecksum,
+		Pages:    pages,
+	})
+
+	if err != nil {
+		p.log.Warn("Unable to reload docs", "ref", p.config.Meta.ID, "error", err)
+	}
 }
 
 func (p *DocsProvider) Changed() (bool, error) {
@@ -231,6 +278,8 @@ func (p *DocsProvider) createDocsContainer() error {
 	cc.Networks = p.config.Networks.ToClientNetworkAttachments()
 	cc.Image = &types.Image{Name: fmt.Sprintf("%s:%s", docsImageName, docsVersion)}
 	cc.MaxRestartCount = -1
+	cc.Resources = p.config.Resources.ToClientResources()
+	cc.Labels = config.DockerLabels(p.config)
 
 	// if image is set override defaults
 	if p.config.Image != nil {
@@ -257,11 +306,13 @@ func (p *DocsProvider) createDocsContainer() error {
 	}
 
 	// add the environment variables for the
-	// ip and port of the terminal server
+	// ip and port of the terminal server, the docs UI
+	// also receives live reload events from this server
 	localIP, _ := utils.GetLocalIPAndHostname()
 	cc.Environment = map[string]string{
 		"TERMINAL_SERVER_IP":   localIP,
 		"TERMINAL_SERVER_PORT": "30003",
+		"LIVE_RELOAD_PATH":     docsEventsPath,
 	}
 
 	// ~/.jumppad/library/content
@@ -314,7 +365,9 @@ func (p *DocsProvider) createDocsContainer() error {
 	return err
 }
 
-func (p *DocsProvider) generateDocs() error {
+// generateDocs writes the content to disk and returns the URIs of the pages
+// that have changed
+func (p *DocsProvider) generateDocs() ([]string, error) {
 	p.log.Info("Refresh Docs", "ref", p.config.Meta.ID)
 
 	// refresh content on disk
@@ -326,25 +379,26 @@ func (p *DocsProvider) generateDocs() error {
 	navigationPath := filepath.Join(configPath, "navigation.jsx")
 	indexPage, err := p.writeNavigation(navigationPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// progress.jsx
 	progressPath := filepath.Join(configPath, "progress.jsx")
 	err = p.writeProgress(progressPath)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	frontendConfigPath := filepath.Join(configPath, "jumppad.config.js")
 	err = p.writeConfig(frontendConfigPath, indexPage)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// /content
 	contentPath := utils.LibraryFolder("content", 0775)
 
+	pages := []string{}
 	for _, book := range p.config.Content {
 		bookPath := filepath.Join(contentPath, book.Meta.Name)
 
@@ -354,9 +408,13 @@ func (p *DocsProvider) generateDocs() error {
 			os.Chmod(chapterPath, 0755)
 
 			for _, page := range chapter.Pages {
-				err := p.processPage(chapterPath, chapter, page)
+				changed, err := p.processPage(chapterPath, chapter, page)
 				if err != nil {
-					return err
+					return nil, err
+				}
+
+				if changed {
+					pages = append(pages, fmt.Sprintf("/docs/%s/%s/%s", book.Meta.Name, chapter.Meta.Name, page.Name))
 				}
 			}
 		}
@@ -365,36 +423,88 @@ func (p *DocsProvider) generateDocs() error {
 	// store a checksum of the content
 	cs, err := p.generateContentChecksum()
 	if err != nil {
-		return fmt.Errorf("unable to generate checksum for content: %s", err)
+		return nil, fmt.Errorf("unable to generate checksum for content: %s", err)
 	}
 
 	p.config.ContentChecksum = cs
 
 	p.log.Debug("Content written", "ref", p.config.Meta.ID, "checksum", p.config.ContentChecksum)
 
-	return nil
+	return pages, nil
 }
 
-func (p *DocsProvider) processPage(chapterPath string, chapter Chapter, page Page) error {
-	content := strings.Replace(page.Content, "\r\n", "\n", -1)
+// processPage writes the page and its translations to disk, returns true
+// when the content differs from the existing files
+func (p *DocsProvider) processPage(chapterPath string, chapter Chapter, page Page) (bool, error) {
+	files := map[string]string{fmt.Sprintf("%s.mdx", page.Name): page.Content}
 
-	// replace task ids
-	taskRegex, _ := regexp.Compile("<Task id=\"(?P<id>.*)\">")
-	taskMatch := taskRegex.FindAllStringSubmatch(page.Content, -1)
-	for _, match := range taskMatch {
-		taskID := match[1]
-		resourceID := fmt.Sprintf("<Task id=\"%s\">", chapter.Tasks[taskID].Meta.ID)
-		content = taskRegex.ReplaceAllString(page.Content, resourceID)
+	// translations are written as page.locale.mdx
+	locales := p.locales()
+	for l, c := range page.Translations {
+		if !slices.Contains(locales, l) {
+			continue
+		}
+
+		files[fmt.Sprintf("%s.%s.mdx", page.Name, l)] = c
 	}
 
-	pageFile := fmt.Sprintf("%s.mdx", page.Name)
-	pagePath := filepath.Join(chapterPath, pageFile)
-	err := os.WriteFile(pagePath, []byte(content), 0755)
-	if err != nil {
-		return fmt.Errorf("unable to write page %s to disk at %s", page.Name, pagePath)
+	changed := false
+	for pageFile, raw := range files {
+		err := checkVariants(raw, p.config.Variants)
+		if err != nil {
+			return false, fmt.Errorf("invalid content for page %s: %s", page.Name, err)
+		}
+
+		content := strings.Replace(raw, "\r\n", "\n", -1)
+
+		// replace task ids
+		taskRegex, _ := regexp.Compile("<Task id=\"(?P<id>.*)\">")
+		taskMatch := taskRegex.FindAllStringSubmatch(raw, -1)
+		for _, match := range taskMatch {
+			taskID := match[1]
+			resourceID := fmt.Sprintf("<Task id=\"%s\">", chapter.Tasks[taskID].Meta.ID)
+			content = taskRegex.ReplaceAllString(raw, resourceID)
+		}
+
+		pagePath := filepath.Join(chapterPath, pageFile)
+
+		if existing, err := os.ReadFile(pagePath); err == nil && string(existing) == content {
+			continue
+		}
+
+		err = os.WriteFile(pagePath, []byte(content), 0755)
+		if err != nil {
+			return false, fmt.Errorf("unable to write page %s to disk at %s", page.Name, pagePath)
+		}
+
+		changed = true
 	}
 
-	return nil
+	return changed, nil
+}
+
+// locales returns the locales that can be selected in the docs UI, when
+// not set these are the locales that pages have been translated to
+func (p *DocsProvider) locales() []string {
+	if len(p.config.Locales) > 0 {
+		locales := append([]string{p.config.DefaultLocale}, p.config.Locales...)
+		slices.Sort(locales)
+
+		return slices.Compact(locales)
+	}
+
+	locales := []string{p.config.DefaultLocale}
+	for _, book := range p.config.Content {
+		for _, chapter := range book.Chapters {
+			for _, page := range chapter.Pages {
+				locales = append(locales, pageLocales(page, p.config.DefaultLocale)...)
+			}
+		}
+	}
+
+	slices.Sort(locales)
+
+	return slices.Compact(locales)
 }
 
 func (p *DocsProvider) writeProgress(path string) error {
@@ -422,11 +532,29 @@ func (p *DocsProvider) writeProgress(path string) error {
 					})
 				}
 
+				// validations are graded alongside the conditions
+				for _, validation := range task.Validations {
+					p.Conditions = append(p.Conditions, ProgressCondition{
+						ID:          validation.Name,
+						Description: validation.Description,
+						Status:      "",
+					})
+				}
+
 				progress = append(progress, p)
 			}
 		}
 	}
 
+	// restore the progress from a previous run so that the learner can
+	// resume the workshop
+	saved, err := p.progress.Tasks()
+	if err != nil {
+		p.log.Warn("Unable to read saved progress", "ref", p.config.Meta.ID, "error", err)
+	}
+
+	restoreProgress(progress, saved)
+
 	progressJSON, err := json.MarshalIndent(progress, "", " ")
 	if err != nil {
 		return err
@@ -441,6 +569,50 @@ func (p *DocsProvider) writeProgress(path string) error {
 	return nil
 }
 
+// restoreProgress sets the status of tasks and conditions from the saved
+// progress, tasks that have been completed unlock the tasks that depend on
+// them
+func restoreProgress(tasks []Progress, saved map[string]progress.Task) {
+	completed := map[string]bool{}
+
+	for i, t := range tasks {
+		st, ok := saved[t.ID]
+		if !ok {
+			continue
+		}
+
+		for j, c := range t.Conditions {
+			for _, sc := range st.Conditions {
+				if sc.ID == c.ID {
+					tasks[i].Conditions[j].Status = sc.Status
+				}
+			}
+		}
+
+		if st.Status == "completed" || st.Status == "skipped" {
+			tasks[i].Status = st.Status
+			completed[t.ID] = true
+		}
+	}
+
+	for i, t := range tasks {
+		if t.Status != "locked" {
+			continue
+		}
+
+		unlocked := true
+		for _, pr := range t.Prerequisites {
+			if !completed[pr] {
+				unlocked = false
+			}
+		}
+
+		if unlocked {
+			tasks[i].Status = "unlocked"
+		}
+	}
+}
+
 // writes the navigation config and returns the index page for the config
 func (p *DocsProvider) writeNavigation(path string) (string, error) {
 	indexPage := "/"
@@ -458,7 +630,7 @@ func (p *DocsProvider) writeNavigation(path string) (string, error) {
 				Pages: []ChapterIndexPage{},
 			}
 
-			for p, page := range chapter.Pages {
+			for i, page := range chapter.Pages {
 				pageIndex := ChapterIndexPage{
 					Title: page.Name,
 					URI:   fmt.Sprintf("/docs/%s/%s/%s", book.Meta.Name, chapter.Meta.Name, page.Name),
@@ -466,7 +638,7 @@ func (p *DocsProvider) writeNavigation(path string) (string, error) {
 
 				// if this is the first book and chapter and page
 				// set the index page
-				if b == 0 && c == 0 && p == 0 {
+				if b == 0 && c == 0 && i == 0 {
 					indexPage = pageIndex.URI
 				}
 
@@ -477,6 +649,24 @@ func (p *DocsProvider) writeNavigation(path string) (string, error) {
 					pageIndex.Title = titleMatch[1]
 				}
 
+				// add the titles of any translations
+				if len(page.Translations) > 0 {
+					locales := p.locales()
+					pageIndex.Titles = map[string]string{}
+
+					for _, l := range pageLocales(page, p.config.DefaultLocale) {
+						if !slices.Contains(locales, l) {
+							continue
+						}
+
+						pageIndex.Locales = append(pageIndex.Locales, l)
+
+						if tm := titleRegex.FindStringSubmatch(page.Translations[l]); len(tm) > 0 {
+							pageIndex.Titles[l] = tm[1]
+						}
+					}
+				}
+
 				chapterIndex.Pages = append(chapterIndex.Pages, pageIndex)
 			}
 
@@ -502,8 +692,11 @@ func (p *DocsProvider) writeNavigation(path string) (string, error) {
 
 func (p *DocsProvider) writeConfig(configPath, indexPage string) error {
 	config := DocsConfig{
-		Logo:        p.config.Logo,
-		DefaultPath: indexPage,
+		Logo:          p.config.Logo,
+		DefaultPath:   indexPage,
+		DefaultLocale: p.config.DefaultLocale,
+		Locales:       p.locales(),
+		Variants:      p.config.Variants,
 	}
 
 	configJSON, err := json.MarshalIndent(config, "", " ")
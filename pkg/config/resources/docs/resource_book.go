@@ -9,6 +9,8 @@ const TypeBook string = "book"
 type Book struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Title    string    `hcl:"title" json:"title"`
 	Chapters []Chapter `hcl:"chapters" json:"chapters"`
 }
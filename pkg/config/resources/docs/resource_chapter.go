@@ -1,6 +1,8 @@
 package docs
 
 import (
+	"fmt"
+
 	"github.com/jumppad-labs/hclconfig/types"
 )
 
@@ -9,6 +11,8 @@ const TypeChapter string = "chapter"
 type Chapter struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Prerequisites []string `hcl:"prerequisites,optional" json:"prerequisites"`
 
 	Title string          `hcl:"title,optional" json:"title,omitempty"`
@@ -18,10 +22,30 @@ type Chapter struct {
 
 type Page struct {
 	Name    string `hcl:"name,label" json:"name"`
-	Content string `hcl:"content" json:"content"`
+	Content string `hcl:"content,optional" json:"content"`
+
+	// Source is the path to a markdown file containing the content, files in
+	// the same folder with a locale before the extension e.g. index.fr.md
+	// are loaded as translations
+	Source string `hcl:"source,optional" json:"source,omitempty"`
+
+	// Translations of the content keyed by locale
+	Translations map[string]string `hcl:"translations,optional" json:"translations,omitempty"`
 }
 
 func (c *Chapter) Process() error {
+	for i := range c.Pages {
+		err := loadPage(&c.Pages[i], c.Meta.File)
+		if err != nil {
+			return err
+		}
+
+		for l := range c.Pages[i].Translations {
+			if !validLocale(l) {
+				return fmt.Errorf("page %s has an invalid locale %q, locales must be a language code e.g. fr or pt-BR", c.Pages[i].Name, l)
+			}
+		}
+	}
 
 	return nil
 }
@@ -1,6 +1,9 @@
 package docs
 
 import (
+	"fmt"
+	"slices"
+
 	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
@@ -15,10 +18,14 @@ const TypeDocs string = "docs"
 type Docs struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Networks ctypes.NetworkAttachments `hcl:"network,block" json:"networks,omitempty"` // Attach to the correct network // only when Image is specified
 
 	Image *ctypes.Image `hcl:"image,block" json:"image,omitempty"` // image to use for the container
 
+	Resources *ctypes.Resources `hcl:"resources,block" json:"resources,omitempty"` // resource constraints for the container
+
 	Content []Book `hcl:"content" json:"content"`
 
 	Port          int  `hcl:"port,optional" json:"port"`
@@ -27,6 +34,16 @@ type Docs struct {
 	Logo   Logo   `hcl:"logo,optional" json:"logo,omitempty"`
 	Assets string `hcl:"assets,optional" json:"assets,omitempty"`
 
+	// DefaultLocale is the locale of the page content, defaults to en
+	DefaultLocale string `hcl:"default_locale,optional" json:"default_locale,omitempty"`
+	// Locales that can be selected in the docs UI, defaults to the locales
+	// that pages have been translated to
+	Locales []string `hcl:"locales,optional" json:"locales,omitempty"`
+
+	// Variants allow the reader to select instructions e.g. for their
+	// operating system or cloud
+	Variants []Variant `hcl:"variant,block" json:"variants,omitempty"`
+
 	// Output parameters
 
 	// ContainerName is the fully qualified resource name for the container, this can be used
@@ -38,6 +55,15 @@ type Docs struct {
 	ContentChecksum string `hcl:"content_checksum,optional" json:"content_checksum,omitempty"`
 }
 
+// Variant defines a choice that is selected in the docs UI, content wrapped
+// in <Variant name="os" value="linux"> is only shown for the selected option
+type Variant struct {
+	Name    string   `hcl:"name,label" json:"name"`
+	Title   string   `hcl:"title,optional" json:"title,omitempty"`
+	Options []string `hcl:"options" json:"options"`
+	Default string   `hcl:"default,optional" json:"default"`
+}
+
 type Logo struct {
 	URL    string `hcl:"url" json:"url"`
 	Width  int    `hcl:"width" json:"width"`
@@ -54,6 +80,30 @@ func (d *Docs) Process() error {
 		d.Assets = utils.EnsureAbsolute(d.Assets, d.Meta.File)
 	}
 
+	if d.DefaultLocale == "" {
+		d.DefaultLocale = DefaultLocale
+	}
+
+	for _, l := range append([]string{d.DefaultLocale}, d.Locales...) {
+		if !validLocale(l) {
+			return fmt.Errorf("invalid locale %q, locales must be a language code e.g. fr or pt-BR", l)
+		}
+	}
+
+	for i, v := range d.Variants {
+		if len(v.Options) == 0 {
+			return fmt.Errorf("variant %s must have at least one option", v.Name)
+		}
+
+		if v.Default == "" {
+			d.Variants[i].Default = v.Options[0]
+		}
+
+		if !slices.Contains(v.Options, d.Variants[i].Default) {
+			return fmt.Errorf("default %q for variant %s is not one of the options", v.Default, v.Name)
+		}
+	}
+
 	// do we have an existing resource in the state?
 	// if so we need to set any computed resources for dependents
 	cfg, err := config.LoadState()
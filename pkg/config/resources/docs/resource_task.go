@@ -1,6 +1,9 @@
 package docs
 
 import (
+	"fmt"
+	"net/http"
+
 	"github.com/jumppad-labs/hclconfig/types"
 )
 
@@ -9,10 +12,15 @@ const TypeTask string = "task"
 type Task struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Prerequisites []string    `hcl:"prerequisites,optional" json:"prerequisites"`
 	Config        *Config     `hcl:"config,block" json:"config,omitempty"`
 	Conditions    []Condition `hcl:"condition,block" json:"conditions"`
 	Status        string      `hcl:"status,optional" json:"status"`
+
+	// Validations grade the task by checking the state of blueprint resources
+	Validations []TaskValidation `hcl:"validation,block" json:"validations,omitempty"`
 }
 
 type Condition struct {
@@ -32,6 +40,65 @@ type Validation struct {
 	Config         `hcl:",remain"`
 }
 
+// TaskValidation checks that a learner has completed a step by running a
+// command in a container, probing a HTTP endpoint, or checking a Kubernetes
+// object, exactly one check must be specified
+type TaskValidation struct {
+	Name           string `hcl:"id,label" json:"id"`
+	Description    string `hcl:"description" json:"description"`
+	FailureMessage string `hcl:"failure_message,optional" json:"failure_message,omitempty"`
+	SuccessMessage string `hcl:"success_message,optional" json:"success_message,omitempty"`
+
+	Exec       *ExecValidation       `hcl:"exec,block" json:"exec,omitempty"`
+	HTTP       *HTTPValidation       `hcl:"http,block" json:"http,omitempty"`
+	Kubernetes *KubernetesValidation `hcl:"kubernetes,block" json:"kubernetes,omitempty"`
+}
+
+// ExecValidation runs a command or script in a container, the validation
+// passes when the exit code and output match
+type ExecValidation struct {
+	// Target is the ID of the container resource to run the command in
+	Target           string   `hcl:"target" json:"target"`
+	Command          []string `hcl:"command,optional" json:"command,omitempty"`
+	Script           string   `hcl:"script,optional" json:"script,omitempty"`
+	User             string   `hcl:"user,optional" json:"user,omitempty"`
+	Group            string   `hcl:"group,optional" json:"group,omitempty"`
+	WorkingDirectory string   `hcl:"working_directory,optional" json:"working_directory,omitempty"`
+	Timeout          int      `hcl:"timeout,optional" json:"timeout"`
+
+	ExitCode       int    `hcl:"exit_code,optional" json:"exit_code"`
+	OutputContains string `hcl:"output_contains,optional" json:"output_contains,omitempty"`
+}
+
+// HTTPValidation makes a HTTP request, the validation passes when the
+// status code and body match
+type HTTPValidation struct {
+	URL     string            `hcl:"url" json:"url"`
+	Method  string            `hcl:"method,optional" json:"method,omitempty"`
+	Headers map[string]string `hcl:"headers,optional" json:"headers,omitempty"`
+	Body    string            `hcl:"body,optional" json:"body,omitempty"`
+	Timeout int               `hcl:"timeout,optional" json:"timeout"`
+
+	StatusCodes  []int  `hcl:"status_codes,optional" json:"status_codes,omitempty"`
+	BodyContains string `hcl:"body_contains,optional" json:"body_contains,omitempty"`
+}
+
+// KubernetesValidation checks an object in a Kubernetes cluster, the
+// validation passes when the object exists and, when Ready is set, reports
+// that it is ready
+type KubernetesValidation struct {
+	// KubeConfig is the path to the kubeconfig for the cluster
+	KubeConfig string `hcl:"kube_config" json:"kube_config"`
+	APIVersion string `hcl:"api_version,optional" json:"api_version"`
+	Kind       string `hcl:"kind" json:"kind"`
+	Name       string `hcl:"name" json:"name"`
+	Namespace  string `hcl:"namespace,optional" json:"namespace,omitempty"`
+	Timeout    int    `hcl:"timeout,optional" json:"timeout"`
+
+	Ready  bool `hcl:"ready,optional" json:"ready,omitempty"`
+	Absent bool `hcl:"absent,optional" json:"absent,omitempty"`
+}
+
 type Config struct {
 	Timeout          int    `hcl:"timeout,optional" json:"timeout"`
 	Target           string `hcl:"target,optional" json:"target,omitempty"`
@@ -127,5 +194,91 @@ func (t *Task) Process() error {
 		}
 	}
 
+	for i := range t.Validations {
+		err := t.processValidation(&t.Validations[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// processValidation checks a validation is valid and sets the defaults
+func (t *Task) processValidation(v *TaskValidation) error {
+	checks := 0
+
+	if v.Exec != nil {
+		checks++
+
+		if v.Exec.Target == "" {
+			v.Exec.Target = t.Config.Target
+		}
+
+		if v.Exec.Target == "" {
+			return fmt.Errorf("validation %s: exec requires a target", v.Name)
+		}
+
+		if (len(v.Exec.Command) == 0) == (v.Exec.Script == "") {
+			return fmt.Errorf("validation %s: exec requires either a command or a script", v.Name)
+		}
+
+		if v.Exec.Timeout == 0 {
+			v.Exec.Timeout = t.Config.Timeout
+		}
+
+		if v.Exec.User == "" {
+			v.Exec.User = t.Config.User
+		}
+
+		if v.Exec.Group == "" {
+			v.Exec.Group = t.Config.Group
+		}
+
+		if v.Exec.WorkingDirectory == "" {
+			v.Exec.WorkingDirectory = t.Config.WorkingDirectory
+		}
+	}
+
+	if v.HTTP != nil {
+		checks++
+
+		if v.HTTP.Method == "" {
+			v.HTTP.Method = http.MethodGet
+		}
+
+		if v.HTTP.Timeout == 0 {
+			v.HTTP.Timeout = t.Config.Timeout
+		}
+
+		if len(v.HTTP.StatusCodes) == 0 {
+			v.HTTP.StatusCodes = []int{http.StatusOK}
+		}
+	}
+
+	if v.Kubernetes != nil {
+		checks++
+
+		if v.Kubernetes.APIVersion == "" {
+			v.Kubernetes.APIVersion = "v1"
+		}
+
+		if v.Kubernetes.Namespace == "" {
+			v.Kubernetes.Namespace = "default"
+		}
+
+		if v.Kubernetes.Timeout == 0 {
+			v.Kubernetes.Timeout = t.Config.Timeout
+		}
+
+		if v.Kubernetes.Ready && v.Kubernetes.Absent {
+			return fmt.Errorf("validation %s: kubernetes can not set both ready and absent", v.Name)
+		}
+	}
+
+	if checks != 1 {
+		return fmt.Errorf("validation %s: exactly one of exec, http or kubernetes must be specified", v.Name)
+	}
+
 	return nil
 }
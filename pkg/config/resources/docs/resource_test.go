@@ -22,6 +22,35 @@ func TestDocsProcessSetsAbsolute(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestDocsProcessSetsLocaleAndVariantDefaults(t *testing.T) {
+	h := &Docs{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Variants:     []Variant{{Name: "os", Options: []string{"linux", "macos"}}},
+	}
+
+	err := h.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, DefaultLocale, h.DefaultLocale)
+	require.Equal(t, "linux", h.Variants[0].Default)
+}
+
+func TestDocsProcessReturnsErrorForInvalidVariant(t *testing.T) {
+	h := &Docs{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Variants:     []Variant{{Name: "os", Options: []string{"linux"}, Default: "windows"}},
+	}
+
+	require.Error(t, h.Process())
+
+	h = &Docs{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Locales:      []string{"french"},
+	}
+
+	require.Error(t, h.Process())
+}
+
 func TestDocsLoadsValuesFromState(t *testing.T) {
 	testutils.SetupState(t, `
 {
@@ -52,3 +81,43 @@ func TestDocsLoadsValuesFromState(t *testing.T) {
 
 	require.Equal(t, "fqdn.mine", docs.ContainerName)
 }
+
+func TestTaskProcessSetsValidationDefaults(t *testing.T) {
+	task := &Task{
+		Config: &Config{Target: "resource.container.web", Timeout: 10},
+		Validations: []TaskValidation{
+			{Name: "exec", Exec: &ExecValidation{Command: []string{"ls"}}},
+			{Name: "http", HTTP: &HTTPValidation{URL: "http://localhost"}},
+			{Name: "k8s", Kubernetes: &KubernetesValidation{KubeConfig: "/kubeconfig", Kind: "Pod", Name: "web"}},
+		},
+	}
+
+	err := task.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "resource.container.web", task.Validations[0].Exec.Target)
+	require.Equal(t, 10, task.Validations[0].Exec.Timeout)
+	require.Equal(t, "root", task.Validations[0].Exec.User)
+	require.Equal(t, "GET", task.Validations[1].HTTP.Method)
+	require.Equal(t, []int{200}, task.Validations[1].HTTP.StatusCodes)
+	require.Equal(t, "v1", task.Validations[2].Kubernetes.APIVersion)
+	require.Equal(t, "default", task.Validations[2].Kubernetes.Namespace)
+}
+
+func TestTaskProcessReturnsErrorWhenValidationInvalid(t *testing.T) {
+	task := &Task{
+		Validations: []TaskValidation{
+			{Name: "none"},
+		},
+	}
+
+	require.Error(t, task.Process())
+
+	task = &Task{
+		Validations: []TaskValidation{
+			{Name: "both", Exec: &ExecValidation{Target: "resource.container.web", Command: []string{"ls"}, Script: "ls"}},
+		},
+	}
+
+	require.Error(t, task.Process())
+}
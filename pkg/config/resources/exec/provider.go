@@ -1,11 +1,13 @@
 package exec
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,6 +19,9 @@ import (
 	contClient "github.com/jumppad-labs/jumppad/pkg/clients/container"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	nomadClient "github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+	sshClient "github.com/jumppad-labs/jumppad/pkg/clients/ssh"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 	"github.com/zclconf/go-cty/cty"
@@ -31,6 +36,8 @@ type Provider struct {
 	config    *Exec
 	container contClient.ContainerTasks
 	command   cmdClient.Command
+	ssh       sshClient.SSH
+	nomad     nomadClient.Nomad
 	log       logger.Logger
 }
 
@@ -49,6 +56,8 @@ func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
 	p.config = c
 	p.command = cli.Command
 	p.container = cli.ContainerTasks
+	p.ssh = cli.SSH
+	p.nomad = cli.Nomad
 	p.log = l
 
 	return nil
@@ -75,7 +84,17 @@ func (p *Provider) Create(ctx context.Context) error {
 	defer os.Remove(outPath)
 
 	// check if we have a target or image specified
-	if p.config.Image != nil || p.config.Target != nil {
+	if p.config.SSH != nil {
+		err := p.createSSHExec(outPath)
+		if err != nil {
+			return fmt.Errorf("unable to create ssh exec: %w", err)
+		}
+	} else if p.config.NomadTask != nil {
+		err := p.createNomadExec(outPath)
+		if err != nil {
+			return fmt.Errorf("unable to create nomad exec: %w", err)
+		}
+	} else if p.config.Image != nil || p.config.Target != nil {
 		// remote exec
 		err := p.createRemoteExec(outPath)
 		if err != nil {
@@ -117,6 +136,11 @@ func (p *Provider) Destroy(ctx context.Context, force bool) error {
 		if err != nil {
 			p.log.Warn("error cleaning up daemonized process", "error", err)
 		}
+
+		if p.config.Restart != nil {
+			os.Remove(SupervisorStatusPath(p.config))
+			os.Remove(supervisorConfigPath(p.config))
+		}
 	}
 
 	return nil
@@ -234,6 +258,155 @@ func (p *Provider) createRemoteExec(outputPath string) error {
 	return nil
 }
 
+func (p *Provider) createSSHExec(outputPath string) error {
+	t, err := sshTarget(p.config.SSH)
+	if err != nil {
+		return err
+	}
+
+	// upload the volumes before running the script
+	for _, v := range p.config.Volumes {
+		err := p.ssh.Upload(t, v.Source, v.Destination)
+		if err != nil {
+			return fmt.Errorf("unable to upload %s to %s: %w", v.Source, v.Destination, err)
+		}
+	}
+
+	remoteOut := fmt.Sprintf("/tmp/jumppad_exec_%s.out", p.config.Meta.Name)
+
+	// build the environment variables
+	envs := []string{"EXEC_OUTPUT=" + remoteOut}
+
+	for k, v := range p.config.Environment {
+		envs = append(envs, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	timeout, err := time.ParseDuration(p.config.Timeout)
+	if err != nil {
+		p.log.Error("Unable to parse timeout duration", "ref", p.config.Meta.Name, "timeout", p.config.Timeout, "error", err)
+		return fmt.Errorf("unable to parse timeout duration: %w", err)
+	}
+
+	exitCode, err := p.ssh.ExecuteScript(t, p.config.Script, envs, p.config.WorkingDirectory, timeout, p.log.StandardWriter())
+	if err != nil {
+		p.log.Error("Unable to execute command", "ref", p.config.Meta.Name, "host", t.Host, "script", p.config.Script)
+		return fmt.Errorf("unable to execute command on %s: %w", t.Host, err)
+	}
+
+	p.config.ExitCode = exitCode
+	if exitCode != 0 {
+		return fmt.Errorf("script exited with code %d", exitCode)
+	}
+
+	// copy the output file, the script might not write any output
+	err = p.ssh.Download(t, remoteOut, outputPath)
+	if err != nil {
+		p.log.Debug("Error copying output file", "ref", p.config.Meta.Name, "output", outputPath, "host", t.Host)
+	}
+
+	// remove the output file
+	p.ssh.ExecuteScript(t, "rm -f "+remoteOut, nil, "", 30*time.Second, p.log.StandardWriter())
+
+	return nil
+}
+
+func (p *Provider) createNomadExec(outputPath string) error {
+	nt := p.config.NomadTask
+
+	p.nomad.SetConfig(fmt.Sprintf("http://%s", nt.Cluster.ExternalIP), nt.Cluster.APIPort, nt.Cluster.ClientNodes)
+
+	taskOut := "/tmp/exec.out"
+
+	timeout, err := time.ParseDuration(p.config.Timeout)
+	if err != nil {
+		p.log.Error("Unable to parse timeout duration", "ref", p.config.Meta.Name, "timeout", p.config.Timeout, "error", err)
+		return fmt.Errorf("unable to parse timeout duration: %w", err)
+	}
+
+	script := nomadScript(p.config.Script, taskOut, p.config.Environment, p.config.WorkingDirectory)
+
+	exitCode, err := p.nomad.ExecuteCommand(nt.Job, nt.Group, nt.Task, []string{"sh", "-c", script}, p.log.StandardWriter(), timeout)
+	if err != nil {
+		p.log.Error("Unable to execute command", "ref", p.config.Meta.Name, "job", nt.Job, "task", nt.Task, "script", p.config.Script)
+		return fmt.Errorf("unable to execute command in task %s: %w", nt.Task, err)
+	}
+
+	p.config.ExitCode = exitCode
+	if exitCode != 0 {
+		return fmt.Errorf("script exited with code %d", exitCode)
+	}
+
+	// the exec API has no file copy, read the output file with cat, the
+	// script might not write any output
+	out := bytes.NewBuffer(nil)
+	_, err = p.nomad.ExecuteCommand(nt.Job, nt.Group, nt.Task, []string{"sh", "-c", "cat " + taskOut + " 2>/dev/null"}, out, 30*time.Second)
+	if err != nil {
+		p.log.Debug("Error copying output file", "ref", p.config.Meta.Name, "output", outputPath, "task", nt.Task)
+	}
+
+	err = os.WriteFile(outputPath, out.Bytes(), 0755)
+	if err != nil {
+		return fmt.Errorf("unable to write output file: %w", err)
+	}
+
+	// remove the output file
+	p.nomad.ExecuteCommand(nt.Job, nt.Group, nt.Task, []string{"rm", "-f", taskOut}, p.log.StandardWriter(), 30*time.Second)
+
+	return nil
+}
+
+// nomadScript returns the script with the environment and working directory
+// set, the Nomad exec API does not accept either
+func nomadScript(script, output string, env map[string]string, workingDirectory string) string {
+	vars := []string{fmt.Sprintf("export EXEC_OUTPUT=%s", shellQuote(output))}
+	for k, v := range env {
+		vars = append(vars, fmt.Sprintf("export %s=%s", k, shellQuote(v)))
+	}
+
+	sort.Strings(vars)
+
+	if workingDirectory != "" {
+		vars = append(vars, "cd "+shellQuote(workingDirectory))
+	}
+
+	return strings.Join(vars, "\n") + "\n" + script
+}
+
+// shellQuote returns s as a single quoted shell string
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// sshTarget converts the ssh block to the target used by the ssh client
+func sshTarget(s *SSH) (sshClient.Target, error) {
+	t := sshClient.Target{
+		Host:     s.Host,
+		Port:     s.Port,
+		User:     s.User,
+		Password: s.Password,
+		HostKey:  s.HostKey,
+	}
+
+	if s.Key == "" {
+		return t, nil
+	}
+
+	// the key can be a path or the contents of the key
+	if strings.Contains(s.Key, "PRIVATE KEY") {
+		t.PrivateKey = []byte(s.Key)
+		return t, nil
+	}
+
+	k, err := os.ReadFile(s.Key)
+	if err != nil {
+		return t, fmt.Errorf("unable to read ssh key: %w", err)
+	}
+
+	t.PrivateKey = k
+
+	return t, nil
+}
+
 func (p *Provider) createRemoteExecContainer() (string, error) {
 	// generate the ID for the new container based on the clock time and a string
 	fqdn := utils.FQDN(p.config.Meta.Name, p.config.Meta.Module, p.config.Meta.Type)
@@ -242,6 +415,7 @@ func (p *Provider) createRemoteExecContainer() (string, error) {
 		Name:        fqdn,
 		Image:       &types.Image{Name: p.config.Image.Name, Username: p.config.Image.Username, Password: p.config.Image.Password},
 		Environment: p.config.Environment,
+		Labels:      config.DockerLabels(p.config),
 	}
 
 	for _, v := range p.config.Networks {
@@ -265,6 +439,8 @@ func (p *Provider) createRemoteExecContainer() (string, error) {
 		})
 	}
 
+	new.Resources = p.config.Resources.ToClientResources()
+
 	new.Entrypoint = []string{}
 	new.Command = []string{"/bin/sh"} // ensure container does not immediately exit
 
@@ -323,6 +499,15 @@ func (p *Provider) createLocalExec(outputPath string) (int, error) {
 	// inject the output file into the environment
 	envs = append(envs, fmt.Sprintf("EXEC_OUTPUT=%s", outputPath))
 
+	// supervised processes are started by the supervisor which restarts
+	// the script and rotates its log
+	if p.config.Restart != nil {
+		return p.createSupervisedExec(scriptPath, envs, logPath)
+	}
+
+	// keep stderr so that it can be shown when the script fails
+	stderr := bytes.NewBuffer(nil)
+
 	// create the config
 	cc := cmdTypes.CommandConfig{
 		Command:          scriptPath,
@@ -331,9 +516,61 @@ func (p *Provider) createLocalExec(outputPath string) (int, error) {
 		RunInBackground:  p.config.Daemon,
 		LogFilePath:      logPath,
 		Timeout:          timeout,
+		Stderr:           stderr,
+	}
+
+	pid, exitCode, err := p.command.Execute(cc)
+	if err != nil {
+		return 0, err
+	}
+
+	if !p.config.Daemon && exitCode != 0 {
+		return 0, fmt.Errorf("script exited with code %d: %s", exitCode, strings.TrimSpace(stderr.String()))
 	}
 
-	pid, err := p.command.Execute(cc)
+	return pid, nil
+}
+
+// createSupervisedExec starts the script using the jumppad supervise command,
+// the returned pid is the pid of the supervisor
+func (p *Provider) createSupervisedExec(scriptPath string, envs []string, logPath string) (int, error) {
+	delay, err := time.ParseDuration(p.config.Restart.Delay)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse restart delay: %w", err)
+	}
+
+	sc := &cmdClient.SupervisorConfig{
+		Command:          scriptPath,
+		Env:              envs,
+		WorkingDirectory: p.config.WorkingDirectory,
+		Policy:           p.config.Restart.Policy,
+		MaxRestarts:      p.config.Restart.MaxRestarts,
+		Delay:            delay,
+		LogFilePath:      logPath,
+		StatusPath:       SupervisorStatusPath(p.config),
+	}
+
+	configPath := supervisorConfigPath(p.config)
+
+	err = cmdClient.WriteSupervisorConfig(configPath, sc)
+	if err != nil {
+		return 0, err
+	}
+
+	bin, err := os.Executable()
+	if err != nil {
+		return 0, fmt.Errorf("unable to find jumppad executable: %w", err)
+	}
+
+	cc := cmdTypes.CommandConfig{
+		Command:         bin,
+		Args:            []string{"supervise", configPath},
+		InheritEnv:      true,
+		RunInBackground: true,
+		LogFilePath:     filepath.Join(utils.LogsDir(), fmt.Sprintf("exec_%s.supervisor.log", p.config.Meta.Name)),
+	}
+
+	pid, _, err := p.command.Execute(cc)
 	if err != nil {
 		return 0, err
 	}
@@ -341,6 +578,16 @@ func (p *Provider) createLocalExec(outputPath string) (int, error) {
 	return pid, nil
 }
 
+// SupervisorStatusPath returns the file that the supervisor of a daemonized
+// exec writes the status of the process to
+func SupervisorStatusPath(e *Exec) string {
+	return filepath.Join(utils.JumppadTemp(), fmt.Sprintf("exec_%s.status", e.Meta.Name))
+}
+
+func supervisorConfigPath(e *Exec) string {
+	return filepath.Join(utils.JumppadTemp(), fmt.Sprintf("exec_%s.supervisor.json", e.Meta.Name))
+}
+
 func (p *Provider) generateOutput() error {
 	outPath := fmt.Sprintf("%s/%s.out", utils.JumppadTemp(), p.config.Meta.ID)
 
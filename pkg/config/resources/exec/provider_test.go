@@ -7,11 +7,16 @@ import (
 	"testing"
 
 	"github.com/jumppad-labs/hclconfig/types"
+	cmdClient "github.com/jumppad-labs/jumppad/pkg/clients/command"
 	commandMocks "github.com/jumppad-labs/jumppad/pkg/clients/command/mocks"
 	cmdTypes "github.com/jumppad-labs/jumppad/pkg/clients/command/types"
 	containerMocks "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	nomadMocks "github.com/jumppad-labs/jumppad/pkg/clients/nomad/mocks"
+	sshClient "github.com/jumppad-labs/jumppad/pkg/clients/ssh"
+	sshMocks "github.com/jumppad-labs/jumppad/pkg/clients/ssh/mocks"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/jumppad-labs/jumppad/testutils"
 	"github.com/stretchr/testify/mock"
@@ -20,7 +25,7 @@ import (
 
 func setupProvider(t *testing.T) (*Exec, *Provider, *commandMocks.Command, *containerMocks.ContainerTasks) {
 	cm := &commandMocks.Command{}
-	cm.On("Execute", mock.Anything).Return(1, nil)
+	cm.On("Execute", mock.Anything).Return(1, 0, nil)
 
 	dm := &containerMocks.ContainerTasks{}
 	dm.On("FindContainerIDs", mock.Anything).Return([]string{"abc123"}, nil)
@@ -48,6 +53,138 @@ func TestInjectsOutputEnvIntoLocal(t *testing.T) {
 	require.Contains(t, ac.Env, fmt.Sprintf("EXEC_OUTPUT=%s/resource.exec.test.out", td))
 }
 
+func TestLocalExecNonZeroExitCodeReturnsError(t *testing.T) {
+	e, p, cm, _ := setupProvider(t)
+	e.Script = "exit 1"
+	e.Timeout = "300s"
+
+	testutils.RemoveOn(&cm.Mock, "Execute")
+	cm.On("Execute", mock.Anything).Return(1, 1, nil)
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "script exited with code 1")
+}
+
+func TestLocalExecDaemonIgnoresExitCode(t *testing.T) {
+	e, p, cm, _ := setupProvider(t)
+	e.Script = "sleep 100"
+	e.Timeout = "300s"
+	e.Daemon = true
+
+	testutils.RemoveOn(&cm.Mock, "Execute")
+	cm.On("Execute", mock.Anything).Return(1, -1, nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+}
+
+func TestLocalExecWithRestartStartsSupervisor(t *testing.T) {
+	e, p, cm, _ := setupProvider(t)
+	e.Script = "sleep 100"
+	e.Timeout = "300s"
+	e.Daemon = true
+	e.Restart = &Restart{Policy: "always", Delay: "1s"}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	ac := testutils.GetCalls(&cm.Mock, "Execute")[0].Arguments[0].(cmdTypes.CommandConfig)
+	require.Equal(t, "supervise", ac.Args[0])
+	require.True(t, ac.RunInBackground)
+
+	sc, err := cmdClient.ReadSupervisorConfig(ac.Args[1])
+	require.NoError(t, err)
+	require.Equal(t, "always", sc.Policy)
+	require.Equal(t, SupervisorStatusPath(e), sc.StatusPath)
+}
+
+func setupSSHProvider(t *testing.T, exitCode int) (*Exec, *Provider, *sshMocks.SSH) {
+	e, p, _, _ := setupProvider(t)
+	e.Script = "echo FOO=BAR >> $EXEC_OUTPUT"
+	e.Timeout = "300s"
+	e.SSH = &SSH{Host: "10.0.0.5", Port: 22, User: "ubuntu", Password: "secret"}
+	e.Volumes = []container.Volume{{Source: "/tmp/config", Destination: "/etc/app"}}
+
+	sm := &sshMocks.SSH{}
+	sm.On("Upload", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	sm.On("ExecuteScript", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(exitCode, nil)
+	sm.On("Download", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	p.ssh = sm
+
+	return e, p, sm
+}
+
+func TestSSHExecUploadsVolumesAndRunsScript(t *testing.T) {
+	_, p, sm := setupSSHProvider(t, 0)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	target := sshClient.Target{Host: "10.0.0.5", Port: 22, User: "ubuntu", Password: "secret"}
+	sm.AssertCalled(t, "Upload", target, "/tmp/config", "/etc/app")
+
+	env := testutils.GetCalls(&sm.Mock, "ExecuteScript")[0].Arguments[2].([]string)
+	require.Contains(t, env, "EXEC_OUTPUT=/tmp/jumppad_exec_test.out")
+
+	sm.AssertCalled(t, "Download", target, "/tmp/jumppad_exec_test.out", mock.Anything)
+}
+
+func TestSSHExecNonZeroExitCodeReturnsError(t *testing.T) {
+	_, p, sm := setupSSHProvider(t, 2)
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "script exited with code 2")
+
+	sm.AssertNotCalled(t, "Download", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func setupNomadProvider(t *testing.T, exitCode int) (*Exec, *Provider, *nomadMocks.Nomad) {
+	e, p, _, _ := setupProvider(t)
+	e.Script = "echo FOO=BAR >> $EXEC_OUTPUT"
+	e.Timeout = "300s"
+	e.Environment = map[string]string{"NAME": "it's"}
+	e.NomadTask = &NomadTask{
+		Cluster: nomad.NomadCluster{ExternalIP: "10.0.0.2", APIPort: 4646, ClientNodes: 1},
+		Job:     "api",
+		Group:   "api",
+		Task:    "web",
+	}
+
+	nm := &nomadMocks.Nomad{}
+	nm.On("SetConfig", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	nm.On("ExecuteCommand", "api", "api", "web", mock.Anything, mock.Anything, mock.Anything).Return(exitCode, nil)
+
+	p.nomad = nm
+
+	return e, p, nm
+}
+
+func TestNomadExecRunsScriptInTask(t *testing.T) {
+	_, p, nm := setupNomadProvider(t, 0)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	nm.AssertCalled(t, "SetConfig", "http://10.0.0.2", 4646, 1)
+
+	cmd := testutils.GetCalls(&nm.Mock, "ExecuteCommand")[0].Arguments[3].([]string)
+	require.Equal(t, "sh", cmd[0])
+	require.Contains(t, cmd[2], "export EXEC_OUTPUT='/tmp/exec.out'")
+	require.Contains(t, cmd[2], `export NAME='it'\''s'`)
+	require.Contains(t, cmd[2], "echo FOO=BAR >> $EXEC_OUTPUT")
+}
+
+func TestNomadExecNonZeroExitCodeReturnsError(t *testing.T) {
+	e, p, nm := setupNomadProvider(t, 2)
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "script exited with code 2")
+	require.Equal(t, 2, e.ExitCode)
+
+	nm.AssertNumberOfCalls(t, "ExecuteCommand", 1)
+}
+
 func TestParsesOutput(t *testing.T) {
 	e, p, _, _ := setupProvider(t)
 	e.Script = "echo FOO=BAR >> $EXEC_OUTPUT"
@@ -3,10 +3,13 @@ package exec
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/command"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/zclconf/go-cty/cty"
 )
@@ -19,20 +22,32 @@ type Exec struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Script           string            `hcl:"script" json:"script"`                                          // script to execute
 	WorkingDirectory string            `hcl:"working_directory,optional" json:"working_directory,omitempty"` // Working directory to execute commands
 	Daemon           bool              `hcl:"daemon,optional" json:"daemon,omitempty"`                       // Should the process run as a daemon
 	Timeout          string            `hcl:"timeout,optional" json:"timeout,omitempty"`                     // Set the timeout for the command
 	Environment      map[string]string `hcl:"environment,optional" json:"environment,omitempty"`             // environment variables to set
+	Restart          *Restart          `hcl:"restart,block" json:"restart,omitempty"`                        // Restart policy for daemonized local execs
 
 	// If remote, either Image or Target must be specified
 	Image  *ctypes.Image     `hcl:"image,block" json:"image,omitempty"`      // Create a new container and exec
 	Target *ctypes.Container `hcl:"target,optional" json:"target,omitempty"` // Attach to a running target and exec
 
+	// SSH runs the script on a remote machine, volumes are uploaded to the
+	// machine before the script runs
+	SSH *SSH `hcl:"ssh,block" json:"ssh,omitempty"`
+
+	// NomadTask runs the script in a task of a running Nomad allocation
+	NomadTask *NomadTask `hcl:"nomad_task,block" json:"nomad_task,omitempty"`
+
 	Networks []ctypes.NetworkAttachment `hcl:"network,block" json:"networks,omitempty"` // Attach to the correct network // only when Image is specified
 	Volumes  []ctypes.Volume            `hcl:"volume,block" json:"volumes,omitempty"`   // Volumes to mount to container
 	RunAs    *ctypes.User               `hcl:"run_as,block" json:"run_as,omitempty"`    // User block for mapping the user id and group id inside the container
 
+	Resources *ctypes.Resources `hcl:"resources,block" json:"resources,omitempty"` // resource constraints for the container // only when Image is specified
+
 	// output
 	PID      int       `hcl:"pid,optional" json:"pid,omitempty"`             // PID stores the ID of the created connector service if it is a local exec
 	ExitCode int       `hcl:"exit_code,optional" json:"exit_code,omitempty"` // Exit code of the process
@@ -40,13 +55,87 @@ type Exec struct {
 	Checksum string    `hcl:"checksum,optional" json:"checksum,omitempty"`   // Checksum of the script
 }
 
+// SSH is a remote machine that the script is run on
+type SSH struct {
+	Host string `hcl:"host" json:"host"`
+	Port int    `hcl:"port,optional" json:"port,omitempty"`
+	User string `hcl:"user" json:"user"`
+	// Key is the path to a private key or a PEM encoded private key
+	Key      string `hcl:"key,optional" json:"key,omitempty"`
+	Password string `hcl:"password,optional" json:"password,omitempty"`
+	// HostKey is the public key of the host in authorized_keys format, when
+	// not set the host key is not verified
+	HostKey string `hcl:"host_key,optional" json:"host_key,omitempty"`
+}
+
+// NomadTask is a task in a job running on a Nomad cluster, the script is run
+// in the first running allocation that contains the task
+type NomadTask struct {
+	// Cluster is the Nomad cluster that the job is running on
+	Cluster nomad.NomadCluster `hcl:"cluster" json:"cluster"`
+	Job     string             `hcl:"job" json:"job"`
+	// Group is the task group, when not set any group containing the task is
+	// used
+	Group string `hcl:"group,optional" json:"group,omitempty"`
+	Task  string `hcl:"task" json:"task"`
+}
+
+// Restart defines how a daemonized local exec is supervised, the process is
+// restarted according to the policy and its log file is rotated
+type Restart struct {
+	// Policy is one of no, on-failure or always
+	Policy string `hcl:"policy,optional" json:"policy,omitempty"`
+	// MaxRestarts limits the number of restarts, 0 is unlimited
+	MaxRestarts int `hcl:"max_restarts,optional" json:"max_restarts,omitempty"`
+	// Delay is the time to wait before restarting the process, e.g. 5s
+	Delay string `hcl:"delay,optional" json:"delay,omitempty"`
+}
+
 func (e *Exec) Process() error {
+	if e.SSH != nil {
+		if e.Image != nil || e.Target != nil {
+			return fmt.Errorf("unable to create ssh exec with image or target")
+		}
+
+		if e.Daemon || len(e.Networks) > 0 || e.RunAs != nil || e.Resources != nil {
+			return fmt.Errorf("unable to create ssh exec with daemon, networks, run_as or resources")
+		}
+
+		if e.SSH.Key == "" && e.SSH.Password == "" {
+			return fmt.Errorf("unable to create ssh exec, key or password must be set")
+		}
+
+		if e.SSH.Port == 0 {
+			e.SSH.Port = 22
+		}
+
+		// the key can be a path or the contents of the key
+		if e.SSH.Key != "" && !strings.Contains(e.SSH.Key, "PRIVATE KEY") {
+			e.SSH.Key = utils.EnsureAbsolute(e.SSH.Key, e.Meta.File)
+		}
+	}
+
+	if e.NomadTask != nil {
+		if e.Image != nil || e.Target != nil || e.SSH != nil {
+			return fmt.Errorf("unable to create nomad exec with image, target or ssh")
+		}
+
+		if e.Daemon || len(e.Networks) > 0 || len(e.Volumes) > 0 || e.RunAs != nil || e.Resources != nil {
+			return fmt.Errorf("unable to create nomad exec with daemon, networks, volumes, run_as or resources")
+		}
+	}
+
 	// check if it is a remote exec
-	if e.Image != nil || e.Target != nil {
+	if e.Image != nil || e.Target != nil || e.SSH != nil || e.NomadTask != nil {
 		// process volumes
 		// make sure mount paths are absolute
 		for i, v := range e.Volumes {
-			e.Volumes[i].Source = utils.EnsureAbsolute(v.Source, e.Meta.File)
+			src, err := utils.VolumeSource(v.Source, e.Meta.File)
+			if err != nil {
+				return fmt.Errorf("invalid source for volume %s: %s", v.Destination, err)
+			}
+
+			e.Volumes[i].Source = src
 		}
 
 		// make sure line endings are linux
@@ -55,6 +144,32 @@ func (e *Exec) Process() error {
 		if len(e.Networks) > 0 || len(e.Volumes) > 0 {
 			return fmt.Errorf("unable to create local exec with networks or volumes")
 		}
+
+		if e.Resources != nil {
+			return fmt.Errorf("unable to create local exec with resources")
+		}
+	}
+
+	if e.Restart != nil {
+		if !e.Daemon || e.Image != nil || e.Target != nil {
+			return fmt.Errorf("restart can only be set for local execs that run as a daemon")
+		}
+
+		switch e.Restart.Policy {
+		case "":
+			e.Restart.Policy = command.RestartOnFailure
+		case command.RestartNever, command.RestartOnFailure, command.RestartAlways:
+		default:
+			return fmt.Errorf("invalid restart policy %s, must be one of %s, %s, or %s", e.Restart.Policy, command.RestartNever, command.RestartOnFailure, command.RestartAlways)
+		}
+
+		if e.Restart.Delay == "" {
+			e.Restart.Delay = "1s"
+		}
+
+		if _, err := time.ParseDuration(e.Restart.Delay); err != nil {
+			return fmt.Errorf("invalid restart delay %s: %s", e.Restart.Delay, err)
+		}
 	}
 
 	if e.Timeout == "" {
@@ -94,3 +94,87 @@ func TestExecLocalWithNetworksReturnsError(t *testing.T) {
 	err := c.Process()
 	require.Error(t, err)
 }
+
+func TestExecLocalWithResourcesReturnsError(t *testing.T) {
+	c := &Exec{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Resources:    &ctypes.Resources{Memory: 512},
+	}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "unable to create local exec with resources")
+}
+
+func TestExecProcessSetsRestartDefaults(t *testing.T) {
+	c := &Exec{Daemon: true, Restart: &Restart{}}
+
+	err := c.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "on-failure", c.Restart.Policy)
+	require.Equal(t, "1s", c.Restart.Delay)
+}
+
+func TestExecProcessRestartWithoutDaemonReturnsError(t *testing.T) {
+	c := &Exec{Restart: &Restart{Policy: "always"}}
+
+	err := c.Process()
+	require.Error(t, err)
+}
+
+func TestExecProcessInvalidRestartPolicyReturnsError(t *testing.T) {
+	c := &Exec{Daemon: true, Restart: &Restart{Policy: "sometimes"}}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid restart policy sometimes")
+}
+
+func TestExecProcessSetsSSHDefaults(t *testing.T) {
+	c := &Exec{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "/tmp"}},
+		SSH:          &SSH{Host: "10.0.0.5", User: "ubuntu", Key: "./id_rsa"},
+	}
+
+	err := c.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, 22, c.SSH.Port)
+	require.Equal(t, "/tmp/id_rsa", c.SSH.Key)
+}
+
+func TestExecProcessSSHWithImageReturnsError(t *testing.T) {
+	c := &Exec{
+		SSH:   &SSH{Host: "10.0.0.5", User: "ubuntu", Password: "secret"},
+		Image: &ctypes.Image{Name: "test"},
+	}
+
+	err := c.Process()
+	require.Error(t, err)
+}
+
+func TestExecProcessSSHWithoutCredentialsReturnsError(t *testing.T) {
+	c := &Exec{SSH: &SSH{Host: "10.0.0.5", User: "ubuntu"}}
+
+	err := c.Process()
+	require.Error(t, err)
+}
+
+func TestExecProcessNomadTaskWithTargetReturnsError(t *testing.T) {
+	c := &Exec{
+		NomadTask: &NomadTask{Job: "api", Task: "web"},
+		Target:    &ctypes.Container{},
+	}
+
+	err := c.Process()
+	require.Error(t, err)
+}
+
+func TestExecProcessNomadTaskWithVolumesReturnsError(t *testing.T) {
+	c := &Exec{
+		NomadTask: &NomadTask{Job: "api", Task: "web"},
+		Volumes:   []ctypes.Volume{{Source: "/tmp", Destination: "/tmp"}},
+	}
+
+	err := c.Process()
+	require.Error(t, err)
+}
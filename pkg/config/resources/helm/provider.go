@@ -11,6 +11,7 @@ import (
 	"github.com/jumppad-labs/jumppad/pkg/clients/helm"
 	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/metrics"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
@@ -62,6 +63,13 @@ func (p *Provider) Create(ctx context.Context) error {
 		p.config.Namespace = "default"
 	}
 
+	// the checksum is generated before remote charts are downloaded and the
+	// chart is replaced with the local path
+	cs, err := p.checksum()
+	if err != nil {
+		return fmt.Errorf("unable to generate checksum for chart: %w", err)
+	}
+
 	// is this chart ot be loaded from a repository?
 	if p.config.Repository != nil {
 		p.log.Debug("Updating Helm chart repository", "name", p.config.Repository.Name, "url", p.config.Repository.URL)
@@ -89,7 +97,6 @@ func (p *Provider) Create(ctx context.Context) error {
 
 	// set the KubeConfig for the kubernetes client
 	// this is used by the health checks
-	var err error
 	p.log.Debug("Using Kubernetes config", "ref", p.config.Meta.ID, "path", p.config.Cluster.KubeConfig)
 	p.kubeClient, err = p.kubeClient.SetConfig(p.config.Cluster.KubeConfig.ConfigPath)
 	if err != nil {
@@ -132,7 +139,8 @@ func (p *Provider) Create(ctx context.Context) error {
 				p.config.Chart,
 				p.config.Version,
 				p.config.Values,
-				p.config.ValuesString)
+				p.config.ValuesString,
+				p.config.Labels)
 
 			if err == nil {
 				doneChan <- struct{}{}
@@ -159,6 +167,8 @@ func (p *Provider) Create(ctx context.Context) error {
 		p.log.Debug("Helm chart applied", "ref", p.config.Meta.Name)
 	}
 
+	p.config.Checksum = cs
+
 	// we can now health check the install
 	if p.config.HealthCheck != nil && len(p.config.HealthCheck.Pods) > 0 {
 		to, err := time.ParseDuration(p.config.HealthCheck.Timeout)
@@ -166,7 +176,9 @@ func (p *Provider) Create(ctx context.Context) error {
 			return fmt.Errorf("unable to parse health check duration: %w", err)
 		}
 
+		st := time.Now()
 		err = p.kubeClient.HealthCheckPods(ctx, p.config.HealthCheck.Pods, to)
+		metrics.Default.ObserveHealthCheck(p.config.Meta.ID, metrics.HealthCheckPods, time.Since(st), err)
 		if err != nil {
 			return fmt.Errorf("health check failed after helm chart setup: %w", err)
 		}
@@ -213,13 +225,79 @@ func (p *Provider) Refresh(ctx context.Context) error {
 		return nil
 	}
 
-	p.log.Debug("Refresh Helm Chart", "ref", p.config.Meta.Name)
+	changed, err := p.Changed()
+	if err != nil {
+		return err
+	}
 
-	return nil
+	if !changed {
+		return nil
+	}
+
+	p.log.Info("Refresh Helm chart", "ref", p.config.Meta.ID)
+
+	// charts are installed not upgraded, remove the existing release
+	err = p.Destroy(ctx, false)
+	if err != nil {
+		return err
+	}
+
+	return p.Create(ctx)
 }
 
 func (p *Provider) Changed() (bool, error) {
 	p.log.Debug("Checking changes", "ref", p.config.Meta.Name)
 
+	// charts installed before the checksum was recorded are not compared
+	if p.config.Checksum == "" {
+		return false, nil
+	}
+
+	cs, err := p.checksum()
+	if err != nil {
+		return false, fmt.Errorf("unable to generate checksum for chart: %w", err)
+	}
+
+	if cs != p.config.Checksum {
+		p.log.Debug("Helm chart changed, needs refresh", "ref", p.config.Meta.ID)
+		return true, nil
+	}
+
 	return false, nil
 }
+
+// checksum returns a hash of the chart, version, namespace and values, local
+// charts and values files are hashed using their contents
+func (p *Provider) checksum() (string, error) {
+	namespace := p.config.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	content := map[string]interface{}{
+		"chart":         p.config.Chart,
+		"version":       p.config.Version,
+		"namespace":     namespace,
+		"values_string": p.config.ValuesString,
+	}
+
+	if utils.IsLocalFolder(p.config.Chart) {
+		h, err := utils.HashDir(p.config.Chart)
+		if err != nil {
+			return "", err
+		}
+
+		content["chart_hash"] = h
+	}
+
+	if p.config.Values != "" {
+		h, err := utils.HashFile(p.config.Values)
+		if err != nil {
+			return "", err
+		}
+
+		content["values_hash"] = h
+	}
+
+	return utils.ChecksumFromInterface(content)
+}
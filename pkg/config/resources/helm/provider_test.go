@@ -0,0 +1,75 @@
+package helm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func setupHelmChecksum(t *testing.T) (*Helm, *Provider) {
+	dir := t.TempDir()
+
+	chart := filepath.Join(dir, "chart")
+	os.MkdirAll(chart, os.ModePerm)
+	os.WriteFile(filepath.Join(chart, "Chart.yaml"), []byte("name: test"), 0644)
+
+	values := filepath.Join(dir, "values.yaml")
+	os.WriteFile(values, []byte("replicas: 1"), 0644)
+
+	h := &Helm{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.helm.test"}},
+		Chart:        chart,
+		Values:       values,
+	}
+
+	p := &Provider{config: h, log: logger.NewTestLogger(t)}
+
+	cs, err := p.checksum()
+	require.NoError(t, err)
+	h.Checksum = cs
+
+	return h, p
+}
+
+func TestHelmChangedWhenValuesFileChanges(t *testing.T) {
+	h, p := setupHelmChecksum(t)
+
+	os.WriteFile(h.Values, []byte("replicas: 3"), 0644)
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, changed)
+}
+
+func TestHelmChangedWhenLocalChartChanges(t *testing.T) {
+	h, p := setupHelmChecksum(t)
+
+	os.WriteFile(filepath.Join(h.Chart, "values.yaml"), []byte("image: nginx"), 0644)
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, changed)
+}
+
+func TestHelmNotChangedWhenContentIsTheSame(t *testing.T) {
+	_, p := setupHelmChecksum(t)
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestHelmNotChangedWithoutChecksum(t *testing.T) {
+	h, p := setupHelmChecksum(t)
+	h.Checksum = ""
+
+	os.WriteFile(h.Values, []byte("replicas: 3"), 0644)
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.False(t, changed)
+}
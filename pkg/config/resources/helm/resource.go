@@ -2,6 +2,7 @@ package helm
 
 import (
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
@@ -14,6 +15,8 @@ const TypeHelm string = "helm"
 type Helm struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Depends []string `hcl:"depends_on,optional" json:"depends,omitempty"`
 
 	Cluster k8s.Cluster `hcl:"cluster" json:"cluster"`
@@ -47,6 +50,16 @@ type Helm struct {
 
 	// Define health checks for the pods deployed by the chart
 	HealthCheck *healthcheck.HealthCheckKubernetes `hcl:"health_check,block" json:"health_check,omitempty"`
+
+	// Labels to add to the release and the Kubernetes objects created by the
+	// chart
+	Labels map[string]string `hcl:"labels,optional" json:"labels,omitempty"`
+
+	// output
+
+	// Checksum is the hash of the chart, version and values when the chart
+	// was installed, it is used to install the chart again when they change
+	Checksum string `hcl:"checksum,optional" json:"checksum,omitempty"`
 }
 
 type HelmRepository struct {
@@ -54,6 +67,16 @@ type HelmRepository struct {
 	URL  string `hcl:"url" json:"url"`
 }
 
+// GetLabels returns the labels for the release
+func (h *Helm) GetLabels() map[string]string {
+	return h.Labels
+}
+
+// GetCluster returns the cluster the chart is installed in
+func (h *Helm) GetCluster() types.Resource {
+	return &h.Cluster
+}
+
 func (h *Helm) Process() error {
 	// only set absolute if is local folder
 	if h.Chart != "" && utils.IsLocalFolder(utils.EnsureAbsolute(h.Chart, h.Meta.File)) {
@@ -64,5 +87,15 @@ func (h *Helm) Process() error {
 		h.Values = utils.EnsureAbsolute(h.Values, h.Meta.File)
 	}
 
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(h.Meta.ID)
+		if r != nil {
+			kstate := r.(*Helm)
+			h.Checksum = kstate.Checksum
+		}
+	}
+
 	return nil
 }
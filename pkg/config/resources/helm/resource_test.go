@@ -6,9 +6,15 @@ import (
 	"testing"
 
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/testutils"
 	"github.com/stretchr/testify/require"
 )
 
+func init() {
+	config.RegisterResource(TypeHelm, &Helm{}, &Provider{})
+}
+
 func TestHelmProcessSetsAbsolute(t *testing.T) {
 	wd, err := os.Getwd()
 	require.NoError(t, err)
@@ -25,3 +31,30 @@ func TestHelmProcessSetsAbsolute(t *testing.T) {
 	require.Equal(t, wd, h.Chart)
 	require.Equal(t, path.Join(wd, "values.yaml"), h.Values)
 }
+
+func TestHelmSetsOutputsFromState(t *testing.T) {
+	testutils.SetupState(t, `
+{
+  "blueprint": null,
+  "resources": [
+	{
+			"meta": {
+				"id": "resource.helm.test",
+				"name": "test",
+				"type": "helm"
+			},
+			"checksum": "abc"
+	}
+	]
+}`)
+
+	h := &Helm{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.helm.test", File: "./"}},
+		Chart:        "./",
+	}
+
+	err := h.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "abc", h.Checksum)
+}
@@ -3,14 +3,19 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	sdk "github.com/jumppad-labs/plugin-sdk"
+	"github.com/zclconf/go-cty/cty"
 )
 
 type Provider struct {
@@ -49,15 +54,60 @@ func (p *Provider) Create(ctx context.Context) error {
 		p.client.Timeout = timeout
 	}
 
+	delay := time.Second
+	if p.config.RetryDelay != "" {
+		d, err := time.ParseDuration(p.config.RetryDelay)
+		if err != nil {
+			return err
+		}
+
+		delay = d
+	}
+
+	var status int
+	var body []byte
+	var err error
+
+	for attempt := 0; attempt <= p.config.Retries; attempt++ {
+		if attempt > 0 {
+			p.log.Debug("Retrying request", "ref", p.config.Meta.ID, "attempt", attempt, "error", err)
+
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		status, body, err = p.doRequest(ctx)
+		if err == nil {
+			break
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	// set the outputs
+	p.config.Status = status
+	p.config.Body = string(body)
+
+	return p.generateOutput(body)
+}
+
+// doRequest makes the request and returns the status and body, an error is
+// returned when the status is not expected
+func (p *Provider) doRequest(ctx context.Context) (int, []byte, error) {
 	var payload io.Reader
-	if p.config.Method == "POST" {
+	if p.config.Payload != "" {
 		payload = bytes.NewBuffer([]byte(p.config.Payload))
 	}
 
 	// create a http request
-	request, err := http.NewRequest(p.config.Method, p.config.URL, payload)
+	request, err := http.NewRequestWithContext(ctx, p.config.Method, p.config.URL, payload)
 	if err != nil {
-		return err
+		return 0, nil, err
 	}
 
 	// add headers
@@ -68,22 +118,90 @@ func (p *Provider) Create(ctx context.Context) error {
 	// make the request
 	response, err := p.client.Do(request)
 	if err != nil {
-		return err
+		return 0, nil, err
 	}
+	defer response.Body.Close()
 
 	// read the response body
 	body, err := io.ReadAll(response.Body)
 	if err != nil {
-		return err
+		return 0, nil, err
 	}
 
-	// set the outputs
-	p.config.Status = response.StatusCode
-	p.config.Body = string(body)
+	if len(p.config.ExpectedStatus) > 0 && !slices.Contains(p.config.ExpectedStatus, response.StatusCode) {
+		return response.StatusCode, body, fmt.Errorf("unexpected status %d, expected one of %v", response.StatusCode, p.config.ExpectedStatus)
+	}
+
+	return response.StatusCode, body, nil
+}
+
+// generateOutput sets the output from the fields of the JSON body, strings
+// are returned as they are, other values are returned as JSON
+func (p *Provider) generateOutput(body []byte) error {
+	if len(p.config.JSONOutputs) == 0 {
+		return nil
+	}
+
+	var doc interface{}
+	err := json.Unmarshal(body, &doc)
+	if err != nil {
+		return fmt.Errorf("unable to parse response body as JSON: %w", err)
+	}
+
+	values := map[string]cty.Value{}
+	for k, path := range p.config.JSONOutputs {
+		v, err := jsonField(doc, path)
+		if err != nil {
+			return fmt.Errorf("unable to get output %s: %w", k, err)
+		}
+
+		if s, ok := v.(string); ok {
+			values[k] = cty.StringVal(s)
+			continue
+		}
+
+		d, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("unable to get output %s: %w", k, err)
+		}
+
+		values[k] = cty.StringVal(string(d))
+	}
+
+	p.config.Output = cty.ObjectVal(values)
 
 	return nil
 }
 
+// jsonField returns the field at the dot separated path, array elements are
+// selected with their index, e.g. items.0.id
+func jsonField(doc interface{}, path string) (interface{}, error) {
+	v := doc
+
+	for _, part := range strings.Split(path, ".") {
+		switch t := v.(type) {
+		case map[string]interface{}:
+			f, ok := t[part]
+			if !ok {
+				return nil, fmt.Errorf("field %s not found in %s", part, path)
+			}
+
+			v = f
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, fmt.Errorf("invalid index %s in %s", part, path)
+			}
+
+			v = t[i]
+		default:
+			return nil, fmt.Errorf("field %s not found in %s", part, path)
+		}
+	}
+
+	return v, nil
+}
+
 func (p *Provider) Destroy(ctx context.Context, force bool) error {
 	return nil
 }
@@ -104,3 +104,88 @@ func TestHttpResourceTimeout(t *testing.T) {
 	err := p.Create(context.Background())
 	require.Error(t, err)
 }
+
+func TestHttpResourceRetriesUntilExpectedStatus(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	defer ts.Close()
+
+	h, p := setupHttp(t)
+	h.Method = "GET"
+	h.URL = ts.URL
+	h.Retries = 3
+	h.RetryDelay = "1ms"
+	h.ExpectedStatus = []int{http.StatusCreated}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 3, calls)
+	require.Equal(t, http.StatusCreated, h.Status)
+}
+
+func TestHttpResourceUnexpectedStatusReturnsError(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	defer ts.Close()
+
+	h, p := setupHttp(t)
+	h.Method = "GET"
+	h.URL = ts.URL
+	h.Retries = 1
+	h.RetryDelay = "1ms"
+	h.ExpectedStatus = []int{http.StatusOK}
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "unexpected status 500")
+
+	require.Equal(t, 2, calls)
+}
+
+func TestHttpResourceSetsJSONOutputs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"token": "abc", "items": [{"id": 42}]}`)
+	}))
+
+	defer ts.Close()
+
+	h, p := setupHttp(t)
+	h.Method = "GET"
+	h.URL = ts.URL
+	h.JSONOutputs = map[string]string{"token": "token", "id": "items.0.id"}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "abc", h.Output.GetAttr("token").AsString())
+	require.Equal(t, "42", h.Output.GetAttr("id").AsString())
+}
+
+func TestHttpResourceMissingJSONOutputReturnsError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": []}`)
+	}))
+
+	defer ts.Close()
+
+	h, p := setupHttp(t)
+	h.Method = "GET"
+	h.URL = ts.URL
+	h.JSONOutputs = map[string]string{"id": "items.0.id"}
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
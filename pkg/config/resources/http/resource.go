@@ -1,8 +1,12 @@
 package http
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/zclconf/go-cty/cty"
 )
 
 const TypeHTTP string = "http"
@@ -10,6 +14,8 @@ const TypeHTTP string = "http"
 type HTTP struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Method string `hcl:"method" json:"method"`
 	URL    string `hcl:"url" json:"url"`
 
@@ -17,12 +23,38 @@ type HTTP struct {
 	Payload string            `hcl:"payload,optional" json:"payload,omitempty"`
 	Timeout string            `hcl:"timeout,optional" json:"timeout,omitempty"`
 
+	// Retries is the number of times the request is retried when it fails or
+	// the status is not one of ExpectedStatus
+	Retries int `hcl:"retries,optional" json:"retries,omitempty"`
+	// RetryDelay is the time to wait between retries, defaults to 1s
+	RetryDelay string `hcl:"retry_delay,optional" json:"retry_delay,omitempty"`
+	// ExpectedStatus is the list of status codes that are considered a
+	// success, when not set any status code is accepted
+	ExpectedStatus []int `hcl:"expected_status,optional" json:"expected_status,omitempty"`
+
+	// JSONOutputs maps the name of an output to the path of a field in the JSON
+	// response body, e.g. data.items.0.id
+	JSONOutputs map[string]string `hcl:"json_outputs,optional" json:"json_outputs,omitempty"`
+
 	// Output parameters
-	Status int    `hcl:"status,optional" json:"status"`
-	Body   string `hcl:"body,optional" json:"body"`
+	Status int       `hcl:"status,optional" json:"status"`
+	Body   string    `hcl:"body,optional" json:"body"`
+	Output cty.Value `hcl:"output,optional" json:"output,omitempty"` // values of the JSONOutputs
 }
 
 func (t *HTTP) Process() error {
+	if t.Retries < 0 {
+		return fmt.Errorf("retries must not be negative")
+	}
+
+	if t.RetryDelay == "" {
+		t.RetryDelay = "1s"
+	}
+
+	if _, err := time.ParseDuration(t.RetryDelay); err != nil {
+		return fmt.Errorf("invalid retry delay %s: %s", t.RetryDelay, err)
+	}
+
 	cfg, err := config.LoadState()
 	if err == nil {
 		// try and find the resource in the state
@@ -31,6 +63,7 @@ func (t *HTTP) Process() error {
 			state := r.(*HTTP)
 			t.Status = state.Status
 			t.Body = state.Body
+			t.Output = state.Output
 		}
 	}
 
@@ -4,12 +4,19 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
+	"github.com/jumppad-labs/connector/protos/shipyard"
 	htypes "github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
 	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/system"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
@@ -23,6 +30,7 @@ type Provider struct {
 	config    *Ingress
 	client    container.ContainerTasks
 	connector connector.Connector
+	system    system.System
 	log       logger.Logger
 }
 
@@ -40,6 +48,7 @@ func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
 	p.config = c
 	p.client = cli.ContainerTasks
 	p.connector = cli.Connector
+	p.system = cli.System
 	p.log = l
 
 	return nil
@@ -53,11 +62,7 @@ func (p *Provider) Create(ctx context.Context) error {
 
 	p.log.Info("Create Ingress", "ref", p.config.Meta.ID)
 
-	if p.config.ExposeLocal {
-		return p.exposeLocal()
-	}
-
-	return p.exposeRemote()
+	return p.expose()
 }
 
 // Destroy satisfies the interface method but is not implemented by LocalExec
@@ -69,11 +74,34 @@ func (p *Provider) Destroy(ctx context.Context, force bool) error {
 
 	p.log.Info("Destroy Ingress", "ref", p.config.Meta.ID, "id", p.config.IngressID)
 
-	err := p.connector.RemoveService(p.config.IngressID)
-	if err != nil {
-		// fail silently as this should not stop us from destroying the
-		// other resources
-		p.log.Warn("Unable to remove local ingress", "ref", p.config.Meta.Name, "id", p.config.IngressID, "error", err)
+	for _, id := range p.ingressIDs() {
+		err := p.connector.RemoveService(id)
+		if err != nil {
+			// fail silently as this should not stop us from destroying the
+			// other resources
+			p.log.Warn("Unable to remove local ingress", "ref", p.config.Meta.Name, "id", id, "error", err)
+		}
+	}
+
+	if p.tlsEnabled() {
+		ports, err := p.localPorts()
+		if err != nil {
+			return err
+		}
+
+		for _, port := range ports {
+			err := p.connector.RemoveTLSTermination(port)
+			if err != nil {
+				p.log.Warn("Unable to remove TLS termination", "ref", p.config.Meta.Name, "port", port, "error", err)
+			}
+		}
+
+		if p.config.TLS.TrustCA {
+			err := p.untrustCA()
+			if err != nil {
+				p.log.Warn("Unable to remove CA from the system trust store", "ref", p.config.Meta.Name, "error", err)
+			}
+		}
 	}
 
 	return nil
@@ -94,50 +122,206 @@ func (p *Provider) Refresh(ctx context.Context) error {
 
 	p.log.Debug("Refresh Ingress", "ref", p.config.Meta.ID)
 
-	return nil
+	// when the connector has been restarted or the connection to the remote
+	// connector has failed the service needs to be exposed again
+	active, err := p.serviceActive()
+	if err != nil {
+		return err
+	}
+
+	if active {
+		return nil
+	}
+
+	p.log.Info("Reconnecting Ingress", "ref", p.config.Meta.ID, "id", p.config.IngressID)
+
+	for _, id := range p.ingressIDs() {
+		// the service may still be registered but in an error state
+		// remove it so that the port is released
+		err := p.connector.RemoveService(id)
+		if err != nil {
+			p.log.Debug("Unable to remove existing ingress", "ref", p.config.Meta.ID, "id", id, "error", err)
+		}
+	}
+
+	return p.expose()
 }
 
 func (p *Provider) Changed() (bool, error) {
 	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
 
+	// an ingress that is no longer active is treated as changed, this
+	// ensures that refresh is called and the service is reconnected
+	active, err := p.serviceActive()
+	if err != nil {
+		return false, err
+	}
+
+	if !active {
+		p.log.Debug("Ingress is not active", "ref", p.config.Meta.ID, "id", p.config.IngressID)
+		return true, nil
+	}
+
 	return false, nil
 }
 
-func (p *Provider) exposeLocal() error {
-	// validate the name
-	if p.config.Target.Config["service"] == "connector" {
-		return fmt.Errorf("unable to expose local service, Service name 'connector' is a reserved name")
+// ingressIDs returns the ids of all the connector services created
+// by the ingress
+func (p *Provider) ingressIDs() []string {
+	ids := []string{}
+
+	if p.config.IngressID != "" {
+		ids = append(ids, p.config.IngressID)
 	}
 
-	// set the namespace
-	p.config.Target.Config["namespace"] = "jumppad"
+	for _, port := range sortedKeys(p.config.IngressIDs) {
+		ids = append(ids, p.config.IngressIDs[port])
+	}
 
-	remoteAddr := ""
+	return ids
+}
 
-	port := fmt.Sprintf("%d", p.config.Target.Port)
+// serviceActive returns true when the connector has a service matching
+// every ingress id that has not errored
+func (p *Provider) serviceActive() (bool, error) {
+	ids := p.ingressIDs()
+	if len(ids) == 0 {
+		return false, nil
+	}
+
+	svcs, err := p.connector.ListServices()
+	if err != nil {
+		return false, fmt.Errorf("unable to list connector services: %w", err)
+	}
+
+	for _, id := range ids {
+		found := false
+		for _, s := range svcs {
+			if s.Id == id && s.Status != shipyard.ServiceStatus_ERROR {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// expose creates the connector services for the ingress
+func (p *Provider) expose() error {
+	if p.tlsEnabled() {
+		err := p.generateTLSCertificates()
+		if err != nil {
+			return fmt.Errorf("unable to generate TLS certificates: %w", err)
+		}
+	}
+
+	if len(p.config.Ports) > 0 {
+		return p.exposePorts()
+	}
 
+	port := fmt.Sprintf("%d", p.config.Target.Port)
 	if p.config.Target.NamedPort != "" {
 		port = p.config.Target.NamedPort
 	}
 
+	var id, localAddr, remoteAddr string
+	var err error
+
+	if p.config.ExposeLocal {
+		id, localAddr, remoteAddr, err = p.exposeLocal(p.config.Port, p.config.Target.Port, port)
+	} else {
+		id, localAddr, remoteAddr, err = p.exposeRemote(p.config.Port, port)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	p.config.IngressID = id
+	p.config.LocalAddress = localAddr
+	p.config.RemoteAddress = remoteAddr
+
+	return nil
+}
+
+// exposePorts creates a connector service for every port defined in
+// the ports list, local ports are the target port plus the local offset
+func (p *Provider) exposePorts() error {
+	ports, err := ParsePorts(p.config.Ports)
+	if err != nil {
+		return err
+	}
+
+	p.config.IngressID = ""
+	p.config.LocalAddress = ""
+	p.config.RemoteAddress = ""
+	p.config.IngressIDs = map[string]string{}
+	p.config.LocalAddresses = map[string]string{}
+	p.config.RemoteAddresses = map[string]string{}
+
+	for _, targetPort := range ports {
+		localPort := targetPort + p.config.LocalPortOffset
+
+		var id, localAddr, remoteAddr string
+		if p.config.ExposeLocal {
+			id, localAddr, remoteAddr, err = p.exposeLocal(localPort, targetPort, fmt.Sprintf("%d", targetPort))
+		} else {
+			id, localAddr, remoteAddr, err = p.exposeRemote(localPort, fmt.Sprintf("%d", targetPort))
+		}
+
+		if err != nil {
+			return err
+		}
+
+		key := fmt.Sprintf("%d", targetPort)
+		p.config.IngressIDs[key] = id
+		p.config.LocalAddresses[key] = localAddr
+		p.config.RemoteAddresses[key] = remoteAddr
+	}
+
+	return nil
+}
+
+// targetAddress returns the address of the service in the target cluster
+func (p *Provider) targetAddress(port string) (string, error) {
 	switch p.config.Target.Resource.Meta.Type {
 	case k8s.TypeK8sCluster:
-		remoteAddr = fmt.Sprintf(
+		return fmt.Sprintf(
 			"%s.%s.svc:%s",
 			p.config.Target.Config["service"],
 			p.config.Target.Config["namespace"],
 			port,
-		)
+		), nil
 	case nomad.TypeNomadCluster:
-		remoteAddr = fmt.Sprintf(
+		return fmt.Sprintf(
 			"%s.%s.%s:%s",
 			p.config.Target.Config["job"],
 			p.config.Target.Config["group"],
 			p.config.Target.Config["task"],
 			port,
-		)
-	default:
-		return fmt.Errorf("target type must be either a Kubernetes or a Nomad cluster")
+		), nil
+	}
+
+	return "", fmt.Errorf("target type must be either a Kubernetes or a Nomad cluster")
+}
+
+func (p *Provider) exposeLocal(localPort, targetPort int, port string) (string, string, string, error) {
+	// validate the name
+	if p.config.Target.Config["service"] == "connector" {
+		return "", "", "", fmt.Errorf("unable to expose local service, Service name 'connector' is a reserved name")
+	}
+
+	// set the namespace
+	p.config.Target.Config["namespace"] = "jumppad"
+
+	remoteAddr, err := p.targetAddress(port)
+	if err != nil {
+		return "", "", "", err
 	}
 
 	// address of the remote connector
@@ -147,72 +331,50 @@ func (p *Provider) exposeLocal() error {
 	p.log.Debug(
 		"Calling connector to expose local service",
 		"name", p.config.Target.Config["service"],
-		"local_port", p.config.Target.Port,
+		"local_port", targetPort,
 		"connector_addr", connectorAddress,
-		"remote_addr", fmt.Sprintf("localhost:%d", p.config.Port),
+		"remote_addr", fmt.Sprintf("localhost:%d", localPort),
 	)
 
 	id, err := p.connector.ExposeService(
 		p.config.Target.Config["service"],
-		p.config.Target.Port,
+		targetPort,
 		connectorAddress,
-		fmt.Sprintf("localhost:%d", p.config.Port),
+		fmt.Sprintf("localhost:%d", localPort),
 		"local",
 	)
 
 	if err != nil {
-		return fmt.Errorf("unable to expose remote service on cluster :%w", err)
+		return "", "", "", fmt.Errorf("unable to expose remote service on cluster :%w", err)
 	}
 
-	addr := fmt.Sprintf("%s:%d", utils.GetDockerIP(), p.config.Port)
+	addr := fmt.Sprintf("%s:%d", utils.GetDockerIP(), localPort)
 	p.log.Debug("Successfully exposed service", "id", id, "dest", remoteAddr, "addr", addr)
 
-	p.config.IngressID = id
-	p.config.LocalAddress = addr
-	p.config.RemoteAddress = remoteAddr
-
-	return nil
+	return id, addr, remoteAddr, nil
 }
 
-func (p *Provider) exposeRemote() error {
+func (p *Provider) exposeRemote(localPort int, port string) (string, string, string, error) {
 	// check if the port is in use, if so, return an immediate error
-	p.log.Debug("Checking if port is available", "port", p.config.Port)
-	tc, err := net.Dial("tcp", fmt.Sprintf("0.0.0.0:%d", p.config.Port))
-	if err == nil {
-		p.log.Debug("Port in use", "port", p.config.Port)
-		return fmt.Errorf("unable to create ingress port %d in use", p.config.Port)
+	p.log.Debug("Checking if port is available", "port", localPort)
+	if !p.portAvailable(localPort) {
+		p.log.Debug("Port in use", "port", localPort)
+		return "", "", "", fmt.Errorf("unable to create ingress port %d in use", localPort)
 	}
 
-	if tc != nil {
-		tc.Close()
-	}
-
-	destAddr := ""
-
-	port := fmt.Sprintf("%d", p.config.Target.Port)
-
-	if p.config.Target.NamedPort != "" {
-		port = p.config.Target.NamedPort
+	destAddr, err := p.targetAddress(port)
+	if err != nil {
+		return "", "", "", err
 	}
 
-	switch p.config.Target.Resource.Meta.Type {
-	case k8s.TypeK8sCluster:
-		destAddr = fmt.Sprintf(
-			"%s.%s.svc:%s",
-			p.config.Target.Config["service"],
-			p.config.Target.Config["namespace"],
-			port,
-		)
-	case nomad.TypeNomadCluster:
-		destAddr = fmt.Sprintf(
-			"%s.%s.%s:%s",
-			p.config.Target.Config["job"],
-			p.config.Target.Config["group"],
-			p.config.Target.Config["task"],
-			port,
-		)
-	default:
-		return fmt.Errorf("target type must be either a Kubernetes or a Nomad cluster")
+	// when terminating TLS the connector exposes the service on an internal
+	// port and the TLS listener on the local port forwards to it
+	servicePort := localPort
+	if p.tlsEnabled() {
+		servicePort, err = utils.RandomAvailablePort(31000, 32000)
+		if err != nil {
+			return "", "", "", err
+		}
 	}
 
 	// address of the remote connector
@@ -222,33 +384,214 @@ func (p *Provider) exposeRemote() error {
 	p.log.Debug(
 		"Calling connector to expose remote service",
 		"name", p.config.Target.Config["service"],
-		"local_port", p.config.Port,
+		"local_port", servicePort,
 		"connector_addr", connectorAddress,
 		"remote_addr", destAddr,
 	)
 
 	id, err := p.connector.ExposeService(
 		p.config.Target.Config["service"],
-		p.config.Port,
+		servicePort,
 		connectorAddress,
 		destAddr,
 		"remote",
 	)
 
 	if err != nil {
-		return fmt.Errorf("unable to expose remote service on cluster :%w", err)
+		return "", "", "", fmt.Errorf("unable to expose remote service on cluster :%w", err)
 	}
 
-	addr := fmt.Sprintf("%s:%d", utils.GetDockerIP(), p.config.Port)
+	if p.tlsEnabled() {
+		p.log.Debug("Adding TLS termination", "port", localPort, "service_port", servicePort)
+
+		err = p.connector.AddTLSTermination(localPort, fmt.Sprintf("localhost:%d", servicePort), p.config.TLS.Cert, p.config.TLS.Key)
+		if err != nil {
+			return "", "", "", fmt.Errorf("unable to create TLS termination for port %d: %w", localPort, err)
+		}
+	}
+
+	addr := fmt.Sprintf("%s:%d", utils.GetDockerIP(), localPort)
 	p.log.Debug("Successfully exposed service", "id", id, "dest", destAddr, "addr", addr)
 
-	p.config.IngressID = id
-	p.config.LocalAddress = addr
-	p.config.RemoteAddress = destAddr
+	return id, addr, destAddr, nil
+}
+
+// portAvailable checks if the given local port is free
+func (p *Provider) portAvailable(port int) bool {
+	addr := fmt.Sprintf("0.0.0.0:%d", port)
+
+	tc, err := net.Dial("tcp", addr)
+	if err == nil {
+		tc.Close()
+		return false
+	}
+
+	return true
+}
+
+// tlsEnabled returns true when the ingress terminates TLS
+func (p *Provider) tlsEnabled() bool {
+	return p.config.TLS != nil && p.config.TLS.Enabled
+}
+
+// localPorts returns all the local ports used by the ingress
+func (p *Provider) localPorts() ([]int, error) {
+	if len(p.config.Ports) == 0 {
+		return []int{p.config.Port}, nil
+	}
+
+	ports, err := ParsePorts(p.config.Ports)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range ports {
+		ports[i] = ports[i] + p.config.LocalPortOffset
+	}
+
+	return ports, nil
+}
+
+// generateTLSCertificates sets the certificate used to terminate TLS, when
+// the tls block does not reference a certificate_leaf resource a leaf
+// certificate signed by the jumppad CA is created using the certificate
+// provider
+func (p *Provider) generateTLSCertificates() error {
+	if c := p.config.TLS.Certificate; c != nil {
+		p.config.TLS.CACert = c.CACert
+		p.config.TLS.Cert = c.Cert.Path
+		p.config.TLS.Key = c.PrivateKey.Path
+	} else {
+		cb, err := p.connector.GetLocalCertBundle(utils.CertsDir(""))
+		if err != nil {
+			return fmt.Errorf("unable to find the jumppad CA: %w", err)
+		}
+
+		dnsNames := []string{"localhost", fmt.Sprintf("*.local.%s", utils.LocalTLD)}
+		dnsNames = append(dnsNames, p.config.TLS.DNSNames...)
+
+		ips := []string{"127.0.0.1"}
+		if ip := net.ParseIP(utils.GetDockerIP()); ip != nil {
+			ips = append(ips, ip.String())
+		}
+		ips = append(ips, p.config.TLS.IPAddresses...)
+
+		leaf := &cert.CertificateLeaf{
+			ResourceBase: htypes.ResourceBase{
+				Meta: htypes.Meta{
+					ID:   p.config.Meta.ID,
+					Name: strings.ReplaceAll(p.config.Meta.ID, ".", "_"),
+					Type: cert.TypeCertificateLeaf,
+				},
+			},
+			CACert:      cb.RootCertPath,
+			CAKey:       cb.RootKeyPath,
+			DNSNames:    dnsNames,
+			IPAddresses: ips,
+			Output:      utils.CertsDir("ingress"),
+		}
+
+		lp := &cert.LeafProvider{}
+		err = lp.Init(leaf, p.log)
+		if err != nil {
+			return err
+		}
+
+		err = lp.Create(context.Background())
+		if err != nil {
+			return err
+		}
+
+		p.config.TLS.CACert = cb.RootCertPath
+		p.config.TLS.Cert = leaf.Cert.Path
+		p.config.TLS.Key = leaf.PrivateKey.Path
+	}
+
+	if !p.config.TLS.TrustCA {
+		return nil
+	}
+
+	// trusting the CA requires elevated permissions, only do this when the
+	// CA has not already been trusted
+	ca, err := os.ReadFile(p.config.TLS.CACert)
+	if err != nil {
+		return fmt.Errorf("unable to read CA %s: %w", p.config.TLS.CACert, err)
+	}
+
+	trusted := filepath.Join(utils.CertsDir("ingress"), "ca.trusted")
+	if t, err := os.ReadFile(trusted); err != nil || string(t) != string(ca) {
+		p.log.Info("Adding CA to the system trust store, you may be prompted for your password", "ref", p.config.Meta.ID, "ca", p.config.TLS.CACert)
+
+		err = p.system.TrustCertificate(p.config.TLS.CACert)
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(trusted, ca, 0600)
+		if err != nil {
+			return fmt.Errorf("unable to record the trusted CA: %w", err)
+		}
+	}
+
+	// record the ingress using the trusted CA, the CA is removed from the
+	// trust store when the last ingress using it is destroyed
+	err = os.WriteFile(p.trustMarker(), []byte(p.config.Meta.ID), 0600)
+	if err != nil {
+		return fmt.Errorf("unable to record the trusted CA: %w", err)
+	}
 
 	return nil
 }
 
+// untrustCA removes the CA from the system trust store when no other ingress
+// is using it
+func (p *Provider) untrustCA() error {
+	err := os.Remove(p.trustMarker())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return fmt.Errorf("unable to remove the trusted CA record: %w", err)
+	}
+
+	users, err := filepath.Glob(filepath.Join(utils.CertsDir("ingress"), "*.trusted_by"))
+	if err != nil || len(users) > 0 {
+		return err
+	}
+
+	trusted := filepath.Join(utils.CertsDir("ingress"), "ca.trusted")
+	if _, err := os.Stat(trusted); err != nil {
+		return nil
+	}
+
+	p.log.Info("Removing CA from the system trust store, you may be prompted for your password", "ref", p.config.Meta.ID)
+
+	err = p.system.UntrustCertificate(trusted)
+	if err != nil {
+		return err
+	}
+
+	return os.Remove(trusted)
+}
+
+// trustMarker returns the path of the file that records the ingress is
+// using the trusted CA
+func (p *Provider) trustMarker() string {
+	return filepath.Join(utils.CertsDir("ingress"), fmt.Sprintf("%s.trusted_by", strings.ReplaceAll(p.config.Meta.ID, ".", "_")))
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
 // exposeK8sRemote exposes a remote kubernetes service to the local machine
 //func (c *Ingress) exposeK8sRemote() error {
 //	// get the target
@@ -0,0 +1,355 @@
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/connector/crypto"
+	"github.com/jumppad-labs/connector/protos/shipyard"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/mocks"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	smocks "github.com/jumppad-labs/jumppad/pkg/clients/system/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupIngressProvider(t *testing.T) (*Provider, *mocks.Connector) {
+	c := &Ingress{
+		ResourceBase: types.ResourceBase{
+			Meta: types.Meta{
+				ID:   "resource.ingress.test",
+				Name: "test",
+			},
+		},
+		Port:        8080,
+		ExposeLocal: true,
+		IngressID:   "123",
+		Target: TrafficTarget{
+			Resource: TargetConfig{
+				Meta:          types.Meta{Type: k8s.TypeK8sCluster},
+				ExternalIP:    "10.0.0.2",
+				ConnectorPort: 60000,
+			},
+			Port: 9090,
+			Config: map[string]string{
+				"service": "test",
+			},
+		},
+	}
+
+	mc := &mocks.Connector{}
+	mc.On("ListServices").Return([]*shipyard.Service{
+		{Id: "123", Status: shipyard.ServiceStatus_COMPLETE},
+	}, nil)
+	mc.On("RemoveService", mock.Anything).Return(nil)
+	mc.On("ExposeService", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return("456", nil)
+
+	p := &Provider{
+		config:    c,
+		connector: mc,
+		log:       logger.NewTestLogger(t),
+	}
+
+	return p, mc
+}
+
+func TestIngressChangedReturnsFalseWhenServiceActive(t *testing.T) {
+	p, _ := setupIngressProvider(t)
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestIngressChangedReturnsTrueWhenServiceMissing(t *testing.T) {
+	p, mc := setupIngressProvider(t)
+	testutils.RemoveOn(&mc.Mock, "ListServices")
+	mc.On("ListServices").Return([]*shipyard.Service{}, nil)
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, changed)
+}
+
+func TestIngressChangedReturnsTrueWhenServiceErrored(t *testing.T) {
+	p, mc := setupIngressProvider(t)
+	testutils.RemoveOn(&mc.Mock, "ListServices")
+	mc.On("ListServices").Return([]*shipyard.Service{
+		{Id: "123", Status: shipyard.ServiceStatus_ERROR},
+	}, nil)
+
+	changed, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, changed)
+}
+
+func TestIngressChangedReturnsErrorWhenUnableToListServices(t *testing.T) {
+	p, mc := setupIngressProvider(t)
+	testutils.RemoveOn(&mc.Mock, "ListServices")
+	mc.On("ListServices").Return(nil, context.DeadlineExceeded)
+
+	_, err := p.Changed()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestIngressRefreshDoesNothingWhenServiceActive(t *testing.T) {
+	p, mc := setupIngressProvider(t)
+
+	err := p.Refresh(context.Background())
+	require.NoError(t, err)
+
+	mc.AssertNotCalled(t, "ExposeService", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	require.Equal(t, "123", p.config.IngressID)
+}
+
+func TestIngressRefreshReconnectsLocalServiceWhenErrored(t *testing.T) {
+	p, mc := setupIngressProvider(t)
+	testutils.RemoveOn(&mc.Mock, "ListServices")
+	mc.On("ListServices").Return([]*shipyard.Service{
+		{Id: "123", Status: shipyard.ServiceStatus_ERROR},
+	}, nil)
+
+	err := p.Refresh(context.Background())
+	require.NoError(t, err)
+
+	mc.AssertCalled(t, "RemoveService", "123")
+	mc.AssertCalled(t, "ExposeService", "test", 9090, "10.0.0.2:60000", "localhost:8080", "local")
+	require.Equal(t, "456", p.config.IngressID)
+}
+
+func TestIngressRefreshReturnsErrorWhenUnableToListServices(t *testing.T) {
+	p, mc := setupIngressProvider(t)
+	testutils.RemoveOn(&mc.Mock, "ListServices")
+	mc.On("ListServices").Return(nil, context.DeadlineExceeded)
+
+	err := p.Refresh(context.Background())
+	require.Error(t, err)
+}
+
+func TestIngressCreateWithPortsExposesEachPort(t *testing.T) {
+	p, mc := setupIngressProvider(t)
+	p.config.IngressID = ""
+	p.config.Ports = []string{"9090-9091"}
+	p.config.LocalPortOffset = 1000
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	mc.AssertCalled(t, "ExposeService", "test", 9090, "10.0.0.2:60000", "localhost:10090", "local")
+	mc.AssertCalled(t, "ExposeService", "test", 9091, "10.0.0.2:60000", "localhost:10091", "local")
+
+	require.Len(t, p.config.IngressIDs, 2)
+	require.Equal(t, "456", p.config.IngressIDs["9090"])
+	require.Contains(t, p.config.LocalAddresses["9091"], ":10091")
+	require.Equal(t, "test.jumppad.svc:9091", p.config.RemoteAddresses["9091"])
+}
+
+func TestIngressDestroyWithPortsRemovesEachService(t *testing.T) {
+	p, mc := setupIngressProvider(t)
+	p.config.IngressID = ""
+	p.config.IngressIDs = map[string]string{"9090": "a", "9091": "b"}
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	mc.AssertCalled(t, "RemoveService", "a")
+	mc.AssertCalled(t, "RemoveService", "b")
+}
+
+// setupIngressCA creates a CA bundle like the one the connector generates
+func setupIngressCA(t *testing.T) *ctypes.CertBundle {
+	dir := t.TempDir()
+
+	k, err := crypto.GenerateKeyPair()
+	require.NoError(t, err)
+
+	ca, err := crypto.GenerateCA("Jumppad CA", k.Private)
+	require.NoError(t, err)
+
+	cb := &ctypes.CertBundle{
+		RootCertPath: filepath.Join(dir, "root.cert"),
+		RootKeyPath:  filepath.Join(dir, "root.key"),
+	}
+
+	require.NoError(t, k.Private.WriteFile(cb.RootKeyPath))
+	require.NoError(t, ca.WriteFile(cb.RootCertPath))
+
+	return cb
+}
+
+func TestIngressCreateWithTLSGeneratesCertificatesAndTerminatesTLS(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	p, mc := setupIngressProvider(t)
+	cb := setupIngressCA(t)
+	mc.On("GetLocalCertBundle", mock.Anything).Return(cb, nil)
+	mc.On("AddTLSTermination", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	p.config.ExposeLocal = false
+	p.config.IngressID = ""
+	p.config.Port = 18443
+	p.config.TLS = &TLS{Enabled: true}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, cb.RootCertPath, p.config.TLS.CACert)
+	require.FileExists(t, p.config.TLS.Cert)
+	require.FileExists(t, p.config.TLS.Key)
+
+	mc.AssertCalled(t, "AddTLSTermination", 18443, mock.Anything, p.config.TLS.Cert, p.config.TLS.Key)
+	mc.AssertNotCalled(t, "ExposeService", "test", 18443, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestIngressCreateWithTLSCertificateUsesCertificateResource(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	p, mc := setupIngressProvider(t)
+	mc.On("AddTLSTermination", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	p.config.ExposeLocal = false
+	p.config.IngressID = ""
+	p.config.Port = 18443
+	p.config.TLS = &TLS{
+		Enabled: true,
+		Certificate: &cert.CertificateLeaf{
+			CACert:     "/certs/ca.cert",
+			Cert:       cert.File{Path: "/certs/leaf.cert"},
+			PrivateKey: cert.File{Path: "/certs/leaf.key"},
+		},
+	}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "/certs/ca.cert", p.config.TLS.CACert)
+	mc.AssertNotCalled(t, "GetLocalCertBundle", mock.Anything)
+	mc.AssertCalled(t, "AddTLSTermination", 18443, mock.Anything, "/certs/leaf.cert", "/certs/leaf.key")
+}
+
+func TestIngressCreateWithTLSTrustsCAOnce(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	p, mc := setupIngressProvider(t)
+	cb := setupIngressCA(t)
+	mc.On("GetLocalCertBundle", mock.Anything).Return(cb, nil)
+	mc.On("AddTLSTermination", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	ms := &smocks.System{}
+	ms.On("TrustCertificate", mock.Anything).Return(nil)
+	p.system = ms
+
+	p.config.ExposeLocal = false
+	p.config.IngressID = ""
+	p.config.Port = 18443
+	p.config.TLS = &TLS{Enabled: true, TrustCA: true}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	err = p.Create(context.Background())
+	require.NoError(t, err)
+
+	ms.AssertNumberOfCalls(t, "TrustCertificate", 1)
+	ms.AssertCalled(t, "TrustCertificate", cb.RootCertPath)
+}
+
+func TestIngressDestroyWithTLSUntrustsCA(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	p, mc := setupIngressProvider(t)
+	cb := setupIngressCA(t)
+	mc.On("GetLocalCertBundle", mock.Anything).Return(cb, nil)
+	mc.On("AddTLSTermination", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mc.On("RemoveTLSTermination", mock.Anything).Return(nil)
+
+	ms := &smocks.System{}
+	ms.On("TrustCertificate", mock.Anything).Return(nil)
+	ms.On("UntrustCertificate", mock.Anything).Return(nil)
+	p.system = ms
+
+	p.config.ExposeLocal = false
+	p.config.IngressID = ""
+	p.config.Port = 18443
+	p.config.TLS = &TLS{Enabled: true, TrustCA: true}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	err = p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	ms.AssertNumberOfCalls(t, "UntrustCertificate", 1)
+	require.NoFileExists(t, filepath.Join(utils.CertsDir("ingress"), "ca.trusted"))
+}
+
+func TestIngressDestroyWithTLSKeepsCATrustedWhenUsedByOtherIngress(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	p, mc := setupIngressProvider(t)
+	cb := setupIngressCA(t)
+	mc.On("GetLocalCertBundle", mock.Anything).Return(cb, nil)
+	mc.On("AddTLSTermination", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mc.On("RemoveTLSTermination", mock.Anything).Return(nil)
+
+	ms := &smocks.System{}
+	ms.On("TrustCertificate", mock.Anything).Return(nil)
+	ms.On("UntrustCertificate", mock.Anything).Return(nil)
+	p.system = ms
+
+	p.config.ExposeLocal = false
+	p.config.IngressID = ""
+	p.config.Port = 18443
+	p.config.TLS = &TLS{Enabled: true, TrustCA: true}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	// a second ingress uses the same CA
+	p.config.Meta.ID = "resource.ingress.other"
+	err = p.Create(context.Background())
+	require.NoError(t, err)
+
+	err = p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	ms.AssertNumberOfCalls(t, "TrustCertificate", 1)
+	ms.AssertNotCalled(t, "UntrustCertificate", mock.Anything)
+	require.FileExists(t, filepath.Join(utils.CertsDir("ingress"), "ca.trusted"))
+}
+
+func TestIngressCreateWithTLSReturnsErrorWhenCAMissing(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	p, mc := setupIngressProvider(t)
+	mc.On("GetLocalCertBundle", mock.Anything).Return(nil, fmt.Errorf("unable to find root certificate"))
+
+	p.config.ExposeLocal = false
+	p.config.IngressID = ""
+	p.config.Port = 18443
+	p.config.TLS = &TLS{Enabled: true}
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "jumppad CA")
+}
+
+func TestIngressDestroyWithTLSRemovesTermination(t *testing.T) {
+	p, mc := setupIngressProvider(t)
+	mc.On("RemoveTLSTermination", mock.Anything).Return(nil)
+
+	p.config.ExposeLocal = false
+	p.config.TLS = &TLS{Enabled: true}
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	mc.AssertCalled(t, "RemoveTLSTermination", 8080)
+}
@@ -2,9 +2,12 @@ package ingress
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 )
 
@@ -15,8 +18,18 @@ const TypeIngress string = "ingress"
 type Ingress struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	// local port to expose the service on
-	Port int `hcl:"port" json:"port"`
+	Port int `hcl:"port,optional" json:"port"`
+
+	// Ports is a list of target ports or port ranges to expose, e.g.
+	// ["8080", "9090-9099"], when set port and target.port are ignored
+	Ports []string `hcl:"ports,optional" json:"ports,omitempty"`
+
+	// LocalPortOffset is added to each target port defined in ports
+	// to calculate the local port
+	LocalPortOffset int `hcl:"local_port_offset,optional" json:"local_port_offset,omitempty"`
 
 	// Are we exposing a local serve to the target
 	// if
@@ -28,6 +41,9 @@ type Ingress struct {
 	// path to open in the browser
 	OpenInBrowser string `hcl:"open_in_browser,optional" json:"open_in_browser,omitempty"`
 
+	// TLS enables HTTPS termination for the ingress
+	TLS *TLS `hcl:"tls,block" json:"tls,omitempty"`
+
 	// --- Output Params ----
 
 	// IngressId stores the ID of the created connector service
@@ -40,6 +56,51 @@ type Ingress struct {
 	// RemoteAddress is the fully qualified uri for accessing the resource
 	// in the remote machine
 	RemoteAddress string `hcl:"remote_address,optional" json:"remote_address,omitempty"`
+
+	// IngressIDs stores the IDs of the created connector services keyed
+	// by target port when ports is set
+	IngressIDs map[string]string `hcl:"ingress_ids,optional" json:"ingress_ids,omitempty"`
+
+	// LocalAddresses stores the local address for each target port
+	// when ports is set
+	LocalAddresses map[string]string `hcl:"local_addresses,optional" json:"local_addresses,omitempty"`
+
+	// RemoteAddresses stores the remote address for each target port
+	// when ports is set
+	RemoteAddresses map[string]string `hcl:"remote_addresses,optional" json:"remote_addresses,omitempty"`
+}
+
+// TLS defines the configuration for terminating TLS at the local connector
+type TLS struct {
+	// Enabled terminates TLS for the ingress, when no certificate is set a
+	// certificate signed by the jumppad CA is generated
+	Enabled bool `hcl:"enabled,optional" json:"enabled"`
+
+	// Certificate is an optional certificate_leaf resource used to
+	// terminate TLS instead of the generated certificate
+	Certificate *cert.CertificateLeaf `hcl:"certificate,optional" json:"certificate,omitempty"`
+
+	// TrustCA adds the CA that signed the certificate to the local system
+	// trust store, the CA is removed when the last ingress trusting it is
+	// destroyed
+	TrustCA bool `hcl:"trust_ca,optional" json:"trust_ca,omitempty"`
+
+	// Additional DNS names to add to the generated certificate
+	DNSNames []string `hcl:"dns_names,optional" json:"dns_names,omitempty"`
+
+	// Additional IP addresses to add to the generated certificate
+	IPAddresses []string `hcl:"ip_addresses,optional" json:"ip_addresses,omitempty"`
+
+	// --- Output Params ----
+
+	// CACert is the path to the CA used to sign the certificate
+	CACert string `hcl:"ca_cert,optional" json:"ca_cert,omitempty"`
+
+	// Cert is the path to the generated certificate
+	Cert string `hcl:"cert,optional" json:"cert,omitempty"`
+
+	// Key is the path to the private key for the generated certificate
+	Key string `hcl:"key,optional" json:"key,omitempty"`
 }
 
 type TargetConfig struct {
@@ -65,6 +126,10 @@ func (i *Ingress) Process() error {
 		return fmt.Errorf("ingress name 'connector' is a reserved name")
 	}
 
+	if i.Port == 0 && len(i.Ports) == 0 {
+		return fmt.Errorf("either port or ports must be specified")
+	}
+
 	// validate the remote port, can not be 60000 or 60001 as these
 	// ports are used by the connector service
 	if i.Port == 60000 || i.Port == 60001 {
@@ -72,6 +137,31 @@ func (i *Ingress) Process() error {
 			"ports 60000 and 60001 are reserved for internal use", i.Port)
 	}
 
+	if len(i.Ports) > 0 {
+		ports, err := ParsePorts(i.Ports)
+		if err != nil {
+			return err
+		}
+
+		for _, p := range ports {
+			lp := p + i.LocalPortOffset
+			if lp < 1 || lp > 65535 {
+				return fmt.Errorf("invalid local port %d for target port %d, local ports must be between 1 and 65535", lp, p)
+			}
+
+			if lp == 60000 || lp == 60001 {
+				return fmt.Errorf("unable to expose service using local port %d,"+
+					"ports 60000 and 60001 are reserved for internal use", lp)
+			}
+		}
+	}
+
+	if i.TLS != nil && i.TLS.Enabled {
+		if i.ExposeLocal {
+			return fmt.Errorf("tls is only supported when exposing remote services to the local machine")
+		}
+	}
+
 	if i.Target.Config == nil {
 		i.Target.Config = make(map[string]string)
 	}
@@ -95,8 +185,59 @@ func (i *Ingress) Process() error {
 			i.IngressID = kstate.IngressID
 			i.LocalAddress = kstate.LocalAddress
 			i.RemoteAddress = kstate.RemoteAddress
+			i.IngressIDs = kstate.IngressIDs
+			i.LocalAddresses = kstate.LocalAddresses
+			i.RemoteAddresses = kstate.RemoteAddresses
+
+			if i.TLS != nil && kstate.TLS != nil {
+				i.TLS.CACert = kstate.TLS.CACert
+				i.TLS.Cert = kstate.TLS.Cert
+				i.TLS.Key = kstate.TLS.Key
+			}
 		}
 	}
 
 	return nil
 }
+
+// ParsePorts expands a list of ports and port ranges, e.g. ["8080", "9090-9092"]
+// into a list of individual ports
+func ParsePorts(ports []string) ([]int, error) {
+	out := []int{}
+	seen := map[int]bool{}
+
+	for _, p := range ports {
+		parts := strings.Split(strings.TrimSpace(p), "-")
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("invalid port range %s, ranges must be specified as start-end", p)
+		}
+
+		start, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid port %s, ports must be a number or a range", p)
+		}
+
+		end := start
+		if len(parts) == 2 {
+			end, err = strconv.Atoi(strings.TrimSpace(parts[1]))
+			if err != nil {
+				return nil, fmt.Errorf("invalid port %s, ports must be a number or a range", p)
+			}
+		}
+
+		if start < 1 || end > 65535 || start > end {
+			return nil, fmt.Errorf("invalid port range %s, ports must be between 1 and 65535 and the start must not be greater than the end", p)
+		}
+
+		for port := start; port <= end; port++ {
+			if seen[port] {
+				return nil, fmt.Errorf("port %d is defined more than once", port)
+			}
+
+			seen[port] = true
+			out = append(out, port)
+		}
+	}
+
+	return out, nil
+}
@@ -36,10 +36,81 @@ func TestIngressSetsOutputsFromState(t *testing.T) {
 				ID: "resource.ingress.test",
 			},
 		},
+		Port: 8080,
 	}
 
-	c.Process()
+	err := c.Process()
+	require.NoError(t, err)
 
 	require.Equal(t, "42", c.IngressID)
 	require.Equal(t, "127.0.0.1", c.LocalAddress)
 }
+
+func TestIngressWithoutPortOrPortsReturnsError(t *testing.T) {
+	c := &Ingress{
+		ResourceBase: types.ResourceBase{
+			Meta: types.Meta{
+				ID:   "resource.ingress.test",
+				Name: "test",
+			},
+		},
+	}
+
+	err := c.Process()
+	require.Error(t, err)
+}
+
+func TestIngressWithReservedLocalPortInRangeReturnsError(t *testing.T) {
+	c := &Ingress{
+		ResourceBase: types.ResourceBase{
+			Meta: types.Meta{
+				ID:   "resource.ingress.test",
+				Name: "test",
+			},
+		},
+		Ports:           []string{"9000-9001"},
+		LocalPortOffset: 51000,
+	}
+
+	err := c.Process()
+	require.Error(t, err)
+}
+
+func TestParsePortsExpandsListAndRanges(t *testing.T) {
+	ports, err := ParsePorts([]string{"8080", "8081", "9090-9093"})
+	require.NoError(t, err)
+
+	require.Equal(t, []int{8080, 8081, 9090, 9091, 9092, 9093}, ports)
+}
+
+func TestParsePortsWithInvalidRangeReturnsError(t *testing.T) {
+	_, err := ParsePorts([]string{"9099-9090"})
+	require.Error(t, err)
+}
+
+func TestParsePortsWithInvalidPortReturnsError(t *testing.T) {
+	_, err := ParsePorts([]string{"abc"})
+	require.Error(t, err)
+}
+
+func TestParsePortsWithDuplicatePortReturnsError(t *testing.T) {
+	_, err := ParsePorts([]string{"8080", "8079-8081"})
+	require.Error(t, err)
+}
+
+func TestIngressWithTLSAndExposeLocalReturnsError(t *testing.T) {
+	c := &Ingress{
+		ResourceBase: types.ResourceBase{
+			Meta: types.Meta{
+				ID:   "resource.ingress.test",
+				Name: "test",
+			},
+		},
+		Port:        8443,
+		ExposeLocal: true,
+		TLS:         &TLS{Enabled: true},
+	}
+
+	err := c.Process()
+	require.Error(t, err)
+}
@@ -11,18 +11,23 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/Masterminds/semver"
 	htypes "github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/command"
 	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
 	cclient "github.com/jumppad-labs/jumppad/pkg/clients/container"
 	ctypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/http"
 	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logcollector"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/tracing"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 	"gopkg.in/yaml.v3"
@@ -44,6 +49,7 @@ type ClusterProvider struct {
 	httpClient http.HTTP
 	connector  connector.Connector
 	log        logger.Logger
+	command    command.Command
 }
 
 func (p *ClusterProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
@@ -63,6 +69,7 @@ func (p *ClusterProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
 	p.httpClient = cli.HTTP
 	p.connector = cli.Connector
 	p.log = l
+	p.command = cli.Command
 
 	return nil
 }
@@ -257,9 +264,17 @@ func (p *ClusterProvider) createK3s(ctx context.Context) error {
 		return fmt.Errorf("error, cluster exists")
 	}
 
+	// k3s runs privileged and needs to manage cgroups
+	err = p.client.EngineInfo().CheckClusterSupport()
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes cluster %s: %s", p.config.Meta.ID, err)
+	}
+
 	img := ctypes.Image{Name: p.config.Image.Name, Username: p.config.Image.Username, Password: p.config.Image.Password}
 	// pull the container image
+	_, span := tracing.Start(ctx, "image.pull", tracing.AttributeImage.String(img.Name))
 	err = p.client.PullImage(img, false)
+	tracing.End(span, err)
 	if err != nil {
 		return err
 	}
@@ -279,13 +294,18 @@ func (p *ClusterProvider) createK3s(ctx context.Context) error {
 
 	cc.Image = &img
 	cc.Privileged = true // k3s must run Privileged
+	cc.Labels = config.DockerLabels(p.config)
+
+	// the cluster name is added as an alias so that it resolves inside
+	// other containers using the same name as the connector DNS server
+	clusterName := utils.FQDN(p.config.Meta.Name, p.config.Meta.Module, p.config.Meta.Type)
 
 	for _, v := range p.config.Networks {
 		cc.Networks = append(cc.Networks, ctypes.NetworkAttachment{
 			ID:        v.ID,
 			Name:      v.Name,
 			IPAddress: v.IPAddress,
-			Aliases:   v.Aliases,
+			Aliases:   append(slices.Clone(v.Aliases), clusterName),
 		})
 	}
 
@@ -325,6 +345,17 @@ func (p *ClusterProvider) createK3s(ctx context.Context) error {
 		})
 	}
 
+	// trust the custom CA bundle so that images can be pulled from
+	// registries that use the CA of a corporate proxy
+	if ca := utils.CABundle(); ca != "" {
+		cc.Volumes = append(cc.Volumes, ctypes.Volume{
+			Source:      ca,
+			Destination: utils.CABundleContainerPath,
+			Type:        "bind",
+			ReadOnly:    true,
+		})
+	}
+
 	// Add any custom environment variables
 	cc.Environment = map[string]string{}
 
@@ -361,11 +392,18 @@ func (p *ClusterProvider) createK3s(ctx context.Context) error {
 		cc.Environment["CONTAINERD_HTTPS_PROXY"] = utils.ImageCacheAddress()
 		cc.Environment["PROXY_CA"] = string(ca)
 
-		// add the no-proxy overrides
+		// add the no-proxy overrides, hosts that are not proxied on the host
+		// are also pulled directly
+		noProxy := []string{}
 		if p.config.Config != nil &&
-			p.config.Config.DockerConfig != nil &&
-			len(p.config.Config.DockerConfig.NoProxy) > 0 {
-			cc.Environment["CONTAINERD_NO_PROXY"] = strings.Join(p.config.Config.DockerConfig.NoProxy, ",")
+			p.config.Config.DockerConfig != nil {
+			noProxy = append(noProxy, p.config.Config.DockerConfig.NoProxy...)
+		}
+
+		noProxy = append(noProxy, utils.NoProxy()...)
+
+		if len(noProxy) > 0 {
+			cc.Environment["CONTAINERD_NO_PROXY"] = strings.Join(noProxy, ",")
 		}
 	}
 
@@ -544,6 +582,15 @@ func (p *ClusterProvider) createK3s(ctx context.Context) error {
 		return err
 	}
 
+	// start collecting logs before the health checks so that the logs of pods
+	// which fail to start are available
+	if p.config.CollectLogs {
+		err = p.startLogCollector()
+		if err != nil {
+			return err
+		}
+	}
+
 	// ensure essential pods have started before announcing the resource is available
 	err = p.kubeClient.HealthCheckPods(ctx, []string{"app=local-path-provisioner", "k8s-app=kube-dns"}, startTimeout)
 	if err != nil {
@@ -752,7 +799,7 @@ func (p *ClusterProvider) deployConnector(ctx context.Context, grpcPort, httpPor
 	}
 
 	// deploy the application config
-	err = p.kubeClient.Apply(files, true)
+	err = p.kubeClient.Apply(files, true, p.config.Labels)
 	if err != nil {
 		return fmt.Errorf("unable to apply configuration: %s", err)
 	}
@@ -769,13 +816,22 @@ func (p *ClusterProvider) deployConnector(ctx context.Context, grpcPort, httpPor
 func (p *ClusterProvider) destroyK3s(force bool) error {
 	p.log.Info("Destroy Cluster", "ref", p.config.Meta.ID)
 
+	if p.config.LogCollectorPID > 0 {
+		p.log.Debug("Stopping log collector", "ref", p.config.Meta.ID, "pid", p.config.LogCollectorPID)
+
+		err := logcollector.Stop(p.command, p.config.Meta.Name, p.config.LogCollectorPID)
+		if err != nil {
+			p.log.Warn("Unable to stop log collector", "ref", p.config.Meta.ID, "error", err)
+		}
+	}
+
 	ids, err := p.Lookup()
 	if err != nil {
 		return err
 	}
 
-	for _, i := range ids {
-		err := p.client.RemoveContainer(i, force)
+	if len(ids) > 0 {
+		err = p.client.RemoveContainers(ids, force)
 		if err != nil {
 			return err
 		}
@@ -787,6 +843,27 @@ func (p *ClusterProvider) destroyK3s(force bool) error {
 	return nil
 }
 
+// startLogCollector starts a background process that writes the logs of all
+// the pods in the cluster to the logs directory
+func (p *ClusterProvider) startLogCollector() error {
+	p.config.LogsDirectory = logcollector.Directory(p.config.Meta.Name)
+
+	p.log.Debug("Starting log collector", "ref", p.config.Meta.ID, "directory", p.config.LogsDirectory)
+
+	pid, err := logcollector.Start(p.command, p.config.Meta.Name, &logcollector.Config{
+		Type:       logcollector.TypeKubernetes,
+		Directory:  p.config.LogsDirectory,
+		KubeConfig: p.config.KubeConfig.ConfigPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.config.LogCollectorPID = pid
+
+	return nil
+}
+
 // createRegistriesConfig creates the k3s mirrors config for the cluster
 func (p *ClusterProvider) createRegistriesConfig() (string, error) {
 	dir, _, _ := utils.CreateKubeConfigPath(p.config.Meta.ID)
@@ -800,19 +877,32 @@ func (p *ClusterProvider) createRegistriesConfig() (string, error) {
 		Mirrors: map[string]dockerMirror{},
 	}
 
-	// if the config is nil, do nothing
-	if p.config.Config == nil ||
-		p.config.Config.DockerConfig == nil ||
-		len(p.config.Config.DockerConfig.InsecureRegistries) < 1 {
+	insecure := []string{}
+	if p.config.Config != nil && p.config.Config.DockerConfig != nil {
+		insecure = p.config.Config.DockerConfig.InsecureRegistries
+	}
+
+	mirrors := utils.RegistryMirrors()
+
+	// if there are no registries to configure, do nothing
+	if len(insecure) < 1 && len(mirrors) < 1 {
 		return "", nil
 	}
 
-	for _, ir := range p.config.Config.DockerConfig.InsecureRegistries {
+	for _, ir := range insecure {
 		dc.Mirrors[ir] = dockerMirror{
 			Endpoints: []string{fmt.Sprintf("http://%s", ir)},
 		}
 	}
 
+	// pull images from Docker Hub using the mirrors, k3s falls back to
+	// Docker Hub when the mirrors can not be reached
+	if len(mirrors) > 0 {
+		dc.Mirrors["docker.io"] = dockerMirror{
+			Endpoints: mirrors,
+		}
+	}
+
 	// write the config to a file
 	data, err := yaml.Marshal(&dc)
 	if err != nil {
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	htypes "github.com/jumppad-labs/hclconfig/types"
+	commandMocks "github.com/jumppad-labs/jumppad/pkg/clients/command/mocks"
+	commandTypes "github.com/jumppad-labs/jumppad/pkg/clients/command/types"
 	conmocks "github.com/jumppad-labs/jumppad/pkg/clients/connector/mocks"
 	contypes "github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
 	cmocks "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
@@ -25,6 +27,7 @@ import (
 	"github.com/mohae/deepcopy"
 	"github.com/stretchr/testify/mock"
 	assert "github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
 )
 
 // setupClusterMocks sets up a happy path for mocks
@@ -46,6 +49,7 @@ func setupClusterMocks(t *testing.T) (
 	md.On("CopyLocalDockerImagesToVolume", mock.Anything, mock.Anything, mock.Anything).Return([]string{"/images/file.tar.gz"}, nil)
 	md.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	md.On("RemoveContainer", mock.Anything, mock.Anything).Return(nil)
+	md.On("RemoveContainers", mock.Anything, mock.Anything).Return(nil)
 	md.On("RemoveVolume", mock.Anything).Return(nil)
 	md.On("DetachNetwork", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	md.On("ListNetworks", mock.Anything).Return([]ctypes.NetworkAttachment{})
@@ -79,7 +83,7 @@ func setupClusterMocks(t *testing.T) (
 	mk := &k8s.MockKubernetes{}
 	mk.Mock.On("SetConfig", mock.Anything).Return(nil)
 	mk.Mock.On("HealthCheckPods", mock.Anything, mock.Anything, mock.Anything).Return(nil)
-	mk.Mock.On("Apply", mock.Anything, mock.Anything).Return(nil)
+	mk.Mock.On("Apply", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mk.Mock.On("GetPodLogs", mock.Anything, mock.Anything, mock.Anything).Return(nil, nil)
 
 	rc, err := os.CreateTemp(tmpDir, "root.cert")
@@ -134,7 +138,7 @@ func TestClusterK3ErrorsWhenUnableToLookupIDs(t *testing.T) {
 	md.On("FindContainerIDs", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("boom"))
 
 	mk := &k8s.MockKubernetes{}
-	p := ClusterProvider{clusterConfig, md, mk, nil, nil, logger.NewTestLogger(t)}
+	p := ClusterProvider{clusterConfig, md, mk, nil, nil, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.Error(t, err)
@@ -144,7 +148,7 @@ func TestClusterK3SetsEnvironment(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 	cc.Image = nil
 
-	p := ClusterProvider{clusterConfig, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{clusterConfig, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -158,11 +162,24 @@ func TestClusterK3SetsEnvironment(t *testing.T) {
 	assert.Equal(t, params.Environment["PROXY_CA"], "CA")
 }
 
+func TestClusterK3AddsClusterNameAlias(t *testing.T) {
+	cc, md, mk, mc := setupClusterMocks(t)
+
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
+
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	params := testutils.GetCalls(&md.Mock, "CreateContainer")[0].Arguments[0].(*ctypes.Container)
+
+	assert.Contains(t, params.Networks[0].Aliases, "test.k8s-cluster.local.jmpd.in")
+}
+
 func TestClusterK3DoesNotSetProxyEnvironmentWithWrongVersion(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 	cc.Image = &container.Image{Name: "jumppad.dev/k3s:v1.12.1"}
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -176,7 +193,7 @@ func TestClusterK3NoProxyIsSet(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 	cc.Config = &ClusterConfig{DockerConfig: &DockerConfig{NoProxy: []string{"test.com", "test2.com"}}}
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -185,20 +202,98 @@ func TestClusterK3NoProxyIsSet(t *testing.T) {
 	assert.Equal(t, "test.com,test2.com", params.Environment["CONTAINERD_NO_PROXY"])
 }
 
+func TestClusterK3NoProxyIncludesHostNoProxy(t *testing.T) {
+	t.Setenv("NO_PROXY", "registry.corp.com")
+
+	cc, md, mk, mc := setupClusterMocks(t)
+	cc.Config = &ClusterConfig{DockerConfig: &DockerConfig{NoProxy: []string{"test.com"}}}
+
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
+
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	params := testutils.GetCalls(&md.Mock, "CreateContainer")[0].Arguments[0].(*ctypes.Container)
+	assert.Equal(t, "test.com,registry.corp.com", params.Environment["CONTAINERD_NO_PROXY"])
+}
+
+func TestClusterK3MountsCABundle(t *testing.T) {
+	t.Setenv(utils.CABundleEnvVar, "/etc/pki/corp-ca.pem")
+
+	cc, md, mk, mc := setupClusterMocks(t)
+
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
+
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	params := testutils.GetCalls(&md.Mock, "CreateContainer")[0].Arguments[0].(*ctypes.Container)
+	assert.Contains(t, params.Volumes, ctypes.Volume{
+		Source:      "/etc/pki/corp-ca.pem",
+		Destination: utils.CABundleContainerPath,
+		Type:        "bind",
+		ReadOnly:    true,
+	})
+}
+
+func TestClusterK3ConfiguresRegistryMirrors(t *testing.T) {
+	t.Setenv(utils.RegistryMirrorsEnvVar, "https://mirror.gcr.io")
+
+	cc, md, mk, mc := setupClusterMocks(t)
+
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
+
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	params := testutils.GetCalls(&md.Mock, "CreateContainer")[0].Arguments[0].(*ctypes.Container)
+
+	var registries string
+	for _, v := range params.Volumes {
+		if v.Destination == "/etc/rancher/k3s/registries.yaml" {
+			registries = v.Source
+		}
+	}
+
+	assert.NotEmpty(t, registries)
+
+	d, err := os.ReadFile(registries)
+	assert.NoError(t, err)
+
+	dc := dockerConfig{}
+	err = yaml.Unmarshal(d, &dc)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"https://mirror.gcr.io"}, dc.Mirrors["docker.io"].Endpoints)
+}
+
 func TestClusterK3ErrorsWhenClusterExists(t *testing.T) {
 	md := &cmocks.ContainerTasks{}
 	md.On("FindContainerIDs", utils.FQDN("server."+clusterConfig.Meta.Name, "", TypeK8sCluster)).Return([]string{"abc"}, nil)
 
 	mk := &k8s.MockKubernetes{}
-	p := ClusterProvider{clusterConfig, md, mk, nil, nil, logger.NewTestLogger(t)}
+	p := ClusterProvider{clusterConfig, md, mk, nil, nil, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.Error(t, err)
 }
 
+func TestClusterK3RootlessWithCgroupV1ReturnsError(t *testing.T) {
+	cc, md, mk, mc := setupClusterMocks(t)
+	testutils.RemoveOn(&md.Mock, "EngineInfo")
+	md.On("EngineInfo").Return(&ctypes.EngineInfo{StorageDriver: "overlay2", EngineType: ctypes.EngineTypeDocker, Rootless: true, CgroupVersion: "1"})
+
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
+
+	err := p.Create(context.Background())
+	assert.ErrorContains(t, err, "clusters require cgroup v2")
+
+	md.AssertNotCalled(t, "CreateContainer", mock.Anything)
+}
+
 func TestClusterK3PullsImage(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -208,7 +303,7 @@ func TestClusterK3PullsImage(t *testing.T) {
 func TestClusterK3CreatesNewVolume(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -221,7 +316,7 @@ func TestClusterK3FailsWhenUnableToCreatesANewVolume(t *testing.T) {
 	testutils.RemoveOn(&md.Mock, "CreateVolume")
 	md.On("CreateVolume", mock.Anything, mock.Anything).Return("", fmt.Errorf("boom"))
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.Error(t, err)
@@ -231,7 +326,7 @@ func TestClusterK3FailsWhenUnableToCreatesANewVolume(t *testing.T) {
 func TestClusterK3CreatesAServer(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -285,7 +380,7 @@ func TestClusterK3CreatesAServerWithAdditionalPorts(t *testing.T) {
 	cc.Ports = []container.Port{{Local: "8080", Remote: "8080", Host: "8080"}}
 	cc.PortRanges = []container.PortRange{{Range: "8000-9000", EnableHost: true}}
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -310,7 +405,7 @@ func TestClusterK3sErrorsIfServerNOTStart(t *testing.T) {
 		nil,
 	)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 	startTimeout = 10 * time.Millisecond // reset the startTimeout, do not want to wait 120s
 
 	err := p.Create(context.Background())
@@ -321,7 +416,7 @@ func TestClusterK3sDownloadsConfig(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 	_, kubePath, _ := utils.CreateKubeConfigPath(cc.Meta.ID)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -338,7 +433,7 @@ func TestClusterK3sRaisesErrorWhenUnableToDownloadConfig(t *testing.T) {
 	testutils.RemoveOn(&md.Mock, "CopyFromContainer")
 	md.On("CopyFromContainer", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("boom"))
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.Error(t, err)
@@ -354,7 +449,7 @@ func TestClusterK3sSetsServerInConfig(t *testing.T) {
 
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -376,7 +471,7 @@ func TestClusterK3sSetsServerInConfig(t *testing.T) {
 func TestCreateSetsKubeConfig(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -390,7 +485,7 @@ func TestCreateSetsKubeConfig(t *testing.T) {
 func TestClusterK3sCreatesKubeClient(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -403,7 +498,7 @@ func TestClusterK3sErrorsWhenFailedToCreateKubeClient(t *testing.T) {
 	testutils.RemoveOn(&mk.Mock, "SetConfig")
 	mk.Mock.On("SetConfig", mock.Anything).Return(fmt.Errorf("boom"))
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.Error(t, err)
@@ -412,7 +507,7 @@ func TestClusterK3sErrorsWhenFailedToCreateKubeClient(t *testing.T) {
 func TestClusterK3sWaitsForPods(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -425,7 +520,7 @@ func TestClusterK3sErrorsWhenWaitsForPodsFail(t *testing.T) {
 	testutils.RemoveOn(&mk.Mock, "HealthCheckPods")
 	mk.On("HealthCheckPods", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("boom"))
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.Error(t, err)
@@ -435,7 +530,7 @@ func TestClusterK3sStreamsLogsWhenRunning(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
 	mk.On("GetPodLogs", mock.Anything, mock.Anything).Return(fmt.Errorf("boom"))
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -455,7 +550,7 @@ func TestClusterK3sImportDockerImagesDoesNothingWhenEmpty(t *testing.T) {
 	md.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 	md.On("FindImageInLocalRegistry", mock.Anything).Return("abc123", nil)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -482,7 +577,7 @@ func TestClusterK3sImportDockerImagesPullsImages(t *testing.T) {
 	md.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 	md.On("FindImageInLocalRegistry", mock.Anything).Return("abc123", nil)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -500,7 +595,7 @@ func TestClusterK3sImportDockerCopiesImages(t *testing.T) {
 	md.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 	md.On("FindImageInLocalRegistry", mock.Anything).Return("abc123", nil)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -518,7 +613,7 @@ func TestClusterK3sImportDockerCopyImageFailReturnsError(t *testing.T) {
 	testutils.RemoveOn(&md.Mock, "CopyLocalDockerImagesToVolume")
 	md.On("CopyLocalDockerImagesToVolume", mock.Anything, mock.Anything, mock.Anything).Return([]string{}, fmt.Errorf("boom"))
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.Error(t, err)
@@ -533,7 +628,7 @@ func TestClusterK3sImportDockerRunsExecCommand(t *testing.T) {
 	md.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
 	md.On("FindImageInLocalRegistry", mock.Anything).Return("abc123", nil)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 	err := p.Create(context.Background())
 
 	assert.NoError(t, err)
@@ -549,7 +644,7 @@ func TestClusterK3sImportDockerExecFailReturnsError(t *testing.T) {
 	md.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(1, fmt.Errorf("boom"))
 	md.On("FindImageInLocalRegistry", mock.Anything).Return("abc123", nil)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.Error(t, err)
@@ -558,7 +653,7 @@ func TestClusterK3sImportDockerExecFailReturnsError(t *testing.T) {
 func TestClusterK3sGeneratesCertsForConnector(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -577,7 +672,7 @@ func TestClusterK3sGeneratesCertsForConnector(t *testing.T) {
 func TestClusterK3sGeneratesCertsForDeployment(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -591,7 +686,7 @@ func TestClusterK3sGeneratesCertsForDeployment(t *testing.T) {
 func TestClusterK3sDeploysConnector(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -603,7 +698,7 @@ func TestClusterK3sDeploysConnector(t *testing.T) {
 		"deployment.yaml",
 	}
 
-	mk.AssertCalled(t, "Apply", mock.Anything, true)
+	mk.AssertCalled(t, "Apply", mock.Anything, true, mock.Anything)
 
 	args := testutils.GetCalls(&mk.Mock, "Apply")[0]
 
@@ -616,7 +711,7 @@ func TestClusterK3sDeploysConnector(t *testing.T) {
 func TestClusterK3sWaitsForConnectorStart(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Create(context.Background())
 	assert.NoError(t, err)
@@ -628,7 +723,7 @@ func TestClusterK3sWaitsForConnectorStart(t *testing.T) {
 func TestClusterK3sDestroyGetsIDr(t *testing.T) {
 	cc, md, mk, mc := setupClusterMocks(t)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Destroy(context.Background(), false)
 	assert.NoError(t, err)
@@ -640,7 +735,7 @@ func TestClusterK3sDestroyWithFindIDErrorReturnsError(t *testing.T) {
 	testutils.RemoveOn(&md.Mock, "FindContainerIDs")
 	md.On("FindContainerIDs", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("boom"))
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Destroy(context.Background(), false)
 	assert.Error(t, err)
@@ -651,11 +746,11 @@ func TestClusterK3sDestroyWithNoIDReturns(t *testing.T) {
 	testutils.RemoveOn(&md.Mock, "FindContainerIDs")
 	md.On("FindContainerIDs", mock.Anything, mock.Anything).Return(nil, nil)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Destroy(context.Background(), false)
 	assert.NoError(t, err)
-	md.AssertNotCalled(t, "RemoveContainer", mock.Anything, mock.Anything)
+	md.AssertNotCalled(t, "RemoveContainers", mock.Anything, mock.Anything)
 }
 
 func TestClusterK3sDestroyRemovesContainer(t *testing.T) {
@@ -663,11 +758,60 @@ func TestClusterK3sDestroyRemovesContainer(t *testing.T) {
 	testutils.RemoveOn(&md.Mock, "FindContainerIDs")
 	md.On("FindContainerIDs", mock.Anything, mock.Anything).Return([]string{"found"}, nil)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
+
+	err := p.Destroy(context.Background(), false)
+	assert.NoError(t, err)
+	md.AssertCalled(t, "RemoveContainers", []string{"found"}, false)
+}
+
+func TestClusterK3sStartsLogCollector(t *testing.T) {
+	cc, md, mk, mc := setupClusterMocks(t)
+	cc.CollectLogs = true
+
+	cm := &commandMocks.Command{}
+	cm.On("Execute", mock.Anything).Return(42, 0, nil)
+
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), cm}
+
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	params := testutils.GetCalls(&cm.Mock, "Execute")[0].Arguments[0].(commandTypes.CommandConfig)
+	assert.Equal(t, "collect-logs", params.Args[0])
+	assert.True(t, params.RunInBackground)
+
+	assert.Equal(t, 42, cc.LogCollectorPID)
+	assert.Equal(t, filepath.Join(utils.LogsDir(), cc.Meta.Name), cc.LogsDirectory)
+}
+
+func TestClusterK3sDoesNotStartLogCollectorByDefault(t *testing.T) {
+	cc, md, mk, mc := setupClusterMocks(t)
+
+	cm := &commandMocks.Command{}
+
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), cm}
+
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	cm.AssertNotCalled(t, "Execute", mock.Anything)
+	assert.Empty(t, cc.LogsDirectory)
+}
+
+func TestClusterK3sDestroyStopsLogCollector(t *testing.T) {
+	cc, md, mk, mc := setupClusterMocks(t)
+	cc.LogCollectorPID = 42
+
+	cm := &commandMocks.Command{}
+	cm.On("Kill", 42).Return(nil)
+
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), cm}
 
 	err := p.Destroy(context.Background(), false)
 	assert.NoError(t, err)
-	md.AssertCalled(t, "RemoveContainer", mock.Anything, false)
+
+	cm.AssertCalled(t, "Kill", 42)
 }
 
 func TestClusterK3sDestroyRemovesConfig(t *testing.T) {
@@ -677,11 +821,11 @@ func TestClusterK3sDestroyRemovesConfig(t *testing.T) {
 
 	dir, _, _ := utils.CreateKubeConfigPath(cc.Meta.Name)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	err := p.Destroy(context.Background(), false)
 	assert.NoError(t, err)
-	md.AssertCalled(t, "RemoveContainer", mock.Anything, false)
+	md.AssertCalled(t, "RemoveContainers", []string{"found"}, false)
 
 	assert.NoDirExists(t, dir)
 }
@@ -692,7 +836,7 @@ func TestLookupReturnsIDs(t *testing.T) {
 	testutils.RemoveOn(&md.Mock, "FindContainerIDs")
 	md.On("FindContainerIDs", mock.Anything, mock.Anything).Return([]string{"found"}, nil)
 
-	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t)}
+	p := ClusterProvider{cc, md, mk, nil, mc, logger.NewTestLogger(t), nil}
 
 	ids, err := p.Lookup()
 
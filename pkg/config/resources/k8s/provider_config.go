@@ -9,6 +9,7 @@ import (
 	htypes "github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
 	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/metrics"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
@@ -56,7 +57,7 @@ func (p *ConfigProvider) create(ctx context.Context) error {
 		return err
 	}
 
-	err = p.client.Apply(p.config.Paths, p.config.WaitUntilReady)
+	err = p.client.Apply(p.config.Paths, p.config.WaitUntilReady, p.config.Labels)
 	if err != nil {
 		return err
 	}
@@ -68,7 +69,9 @@ func (p *ConfigProvider) create(ctx context.Context) error {
 			return fmt.Errorf("unable to parse healthcheck duration: %w", err)
 		}
 
+		st := time.Now()
 		err = p.client.HealthCheckPods(ctx, p.config.HealthCheck.Pods, to)
+		metrics.Default.ObserveHealthCheck(p.config.Meta.ID, metrics.HealthCheckPods, time.Since(st), err)
 		if err != nil {
 			return fmt.Errorf("healthcheck failed after helm chart setup: %w", err)
 		}
@@ -19,7 +19,7 @@ import (
 func setupK8sConfig(t *testing.T) (*k8scli.MockKubernetes, *ConfigProvider) {
 	mk := &k8scli.MockKubernetes{}
 	mk.On("SetConfig", mock.Anything).Return(nil)
-	mk.On("Apply", mock.Anything, mock.Anything).Return(nil)
+	mk.On("Apply", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 	mk.On("Delete", mock.Anything, mock.Anything).Return(nil)
 
 	// create the test files
@@ -48,7 +48,7 @@ func TestCreatesCorrectly(t *testing.T) {
 
 	//_, destPath, _ := utils.CreateKubeConfigPath("testcluster")
 	//mk.AssertCalled(t, "SetConfig", destPath)
-	mk.AssertCalled(t, "Apply", p.config.Paths, p.config.WaitUntilReady)
+	mk.AssertCalled(t, "Apply", p.config.Paths, p.config.WaitUntilReady, p.config.Labels)
 }
 
 func TestRunsHealthChecks(t *testing.T) {
@@ -0,0 +1,103 @@
+package k8s
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ sdk.Provider = &SecretProvider{}
+
+// SecretProvider reads a secret from a Kubernetes cluster
+type SecretProvider struct {
+	config *Secret
+	client k8s.Kubernetes
+	log    sdk.Logger
+}
+
+func (p *SecretProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Secret)
+	if !ok {
+		return fmt.Errorf("unable to initialize Secret provider, resource is not of type K8sSecret")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.client = cli.Kubernetes
+	p.log = l
+
+	return nil
+}
+
+// Create reads the secret, waiting until it exists
+func (p *SecretProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Reading Kubernetes secret", "ref", p.config.Meta.ID, "name", p.config.Name, "namespace", p.config.Namespace)
+
+	return p.read(ctx)
+}
+
+// Destroy does nothing as the secret is not managed by the resource
+func (p *SecretProvider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *SecretProvider) Lookup() ([]string, error) {
+	return []string{}, nil
+}
+
+// Refresh reads the secret again so that the outputs are current
+func (p *SecretProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Kubernetes secret", "ref", p.config.Meta.ID)
+
+	return p.read(ctx)
+}
+
+func (p *SecretProvider) Changed() (bool, error) {
+	return false, nil
+}
+
+func (p *SecretProvider) read(ctx context.Context) error {
+	o, err := waitForObject(ctx, p.client, p.config.Cluster, "Secret", p.config.Namespace, p.config.Name, p.config.Timeout)
+	if err != nil {
+		return err
+	}
+
+	p.config.Type, _, _ = unstructured.NestedString(o.Object, "type")
+
+	data, _, err := unstructured.NestedStringMap(o.Object, "data")
+	if err != nil {
+		return fmt.Errorf("unable to read secret data: %w", err)
+	}
+
+	p.config.Data = map[string]string{}
+	for k, v := range data {
+		d, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			return fmt.Errorf("unable to decode secret value %s: %w", k, err)
+		}
+
+		p.config.Data[k] = string(d)
+	}
+
+	return nil
+}
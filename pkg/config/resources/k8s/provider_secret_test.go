@@ -0,0 +1,70 @@
+package k8s
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	k8scli "github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func setupK8sSecret(t *testing.T) (*k8scli.MockKubernetes, *SecretProvider) {
+	mk := &k8scli.MockKubernetes{}
+	mk.On("SetConfig", mock.Anything).Return(nil)
+
+	c := Cluster{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "testcluster"}}}
+
+	s := &Secret{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "token"}}}
+	s.Cluster = c
+	s.Name = "vault-token"
+	s.Namespace = "vault"
+	s.Timeout = "1ms"
+
+	p := &SecretProvider{s, mk, logger.NewTestLogger(t)}
+
+	return mk, p
+}
+
+func TestSecretDecodesData(t *testing.T) {
+	mk, p := setupK8sSecret(t)
+
+	o := &unstructured.Unstructured{Object: map[string]interface{}{
+		"type": "kubernetes.io/service-account-token",
+		"data": map[string]interface{}{
+			"token": base64.StdEncoding.EncodeToString([]byte("abc123")),
+		},
+	}}
+	mk.On("GetObject", mock.Anything, "v1", "Secret", "vault", "vault-token").Return(o, nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "kubernetes.io/service-account-token", p.config.Type)
+	require.Equal(t, "abc123", p.config.Data["token"])
+}
+
+func TestSecretInvalidDataReturnsError(t *testing.T) {
+	mk, p := setupK8sSecret(t)
+
+	o := &unstructured.Unstructured{Object: map[string]interface{}{
+		"data": map[string]interface{}{"token": "not base64!"},
+	}}
+	mk.On("GetObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(o, nil)
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
+
+func TestSecretNotFoundReturnsError(t *testing.T) {
+	mk, p := setupK8sSecret(t)
+	mk.On("GetObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("not found"))
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var _ sdk.Provider = &ServiceProvider{}
+
+// objectPollInterval is the time between attempts to read an object that
+// does not exist yet
+var objectPollInterval = 2 * time.Second
+
+// ServiceProvider reads a service from a Kubernetes cluster
+type ServiceProvider struct {
+	config *Service
+	client k8s.Kubernetes
+	log    sdk.Logger
+}
+
+func (p *ServiceProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Service)
+	if !ok {
+		return fmt.Errorf("unable to initialize Service provider, resource is not of type K8sService")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.client = cli.Kubernetes
+	p.log = l
+
+	return nil
+}
+
+// Create reads the service, waiting until it exists
+func (p *ServiceProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Reading Kubernetes service", "ref", p.config.Meta.ID, "name", p.config.Name, "namespace", p.config.Namespace)
+
+	return p.read(ctx)
+}
+
+// Destroy does nothing as the service is not managed by the resource
+func (p *ServiceProvider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *ServiceProvider) Lookup() ([]string, error) {
+	return []string{}, nil
+}
+
+// Refresh reads the service again so that the outputs are current
+func (p *ServiceProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Kubernetes service", "ref", p.config.Meta.ID)
+
+	return p.read(ctx)
+}
+
+func (p *ServiceProvider) Changed() (bool, error) {
+	return false, nil
+}
+
+func (p *ServiceProvider) read(ctx context.Context) error {
+	o, err := waitForObject(ctx, p.client, p.config.Cluster, "Service", p.config.Namespace, p.config.Name, p.config.Timeout)
+	if err != nil {
+		return err
+	}
+
+	p.config.Type, _, _ = unstructured.NestedString(o.Object, "spec", "type")
+	p.config.ClusterIP, _, _ = unstructured.NestedString(o.Object, "spec", "clusterIP")
+
+	p.config.ExternalIP = ""
+	ingress, _, _ := unstructured.NestedSlice(o.Object, "status", "loadBalancer", "ingress")
+	if len(ingress) > 0 {
+		if i, ok := ingress[0].(map[string]interface{}); ok {
+			p.config.ExternalIP, _, _ = unstructured.NestedString(i, "ip")
+		}
+	}
+
+	p.config.Ports = map[string]int{}
+	p.config.NodePorts = map[string]int{}
+
+	ports, _, _ := unstructured.NestedSlice(o.Object, "spec", "ports")
+	for _, port := range ports {
+		pm, ok := port.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		number, _, _ := unstructured.NestedInt64(pm, "port")
+		name, _, _ := unstructured.NestedString(pm, "name")
+		if name == "" {
+			name = strconv.FormatInt(number, 10)
+		}
+
+		p.config.Ports[name] = int(number)
+
+		if np, ok, _ := unstructured.NestedInt64(pm, "nodePort"); ok {
+			p.config.NodePorts[name] = int(np)
+		}
+	}
+
+	return nil
+}
+
+// waitForObject reads the object from the cluster, retrying until the object
+// exists or the timeout elapses
+func waitForObject(ctx context.Context, client k8s.Kubernetes, cluster Cluster, kind, namespace, name, timeout string) (*unstructured.Unstructured, error) {
+	to, err := time.ParseDuration(timeout)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse timeout: %w", err)
+	}
+
+	kc, err := client.SetConfig(cluster.KubeConfig.ConfigPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+
+	st := time.Now()
+	for {
+		o, err := kc.GetObject(ctx, "v1", kind, namespace, name)
+		if err == nil {
+			return o, nil
+		}
+
+		remaining := to - time.Since(st)
+		if remaining <= 0 {
+			return nil, fmt.Errorf("timeout waiting for %s %s/%s: %w", kind, namespace, name, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(min(objectPollInterval, remaining)):
+		}
+	}
+}
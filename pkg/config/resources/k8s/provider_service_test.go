@@ -0,0 +1,66 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	k8scli "github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func setupK8sService(t *testing.T) (*k8scli.MockKubernetes, *ServiceProvider) {
+	mk := &k8scli.MockKubernetes{}
+	mk.On("SetConfig", mock.Anything).Return(nil)
+
+	c := Cluster{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "testcluster"}}}
+	c.KubeConfig.ConfigPath = "/tmp/kubeconfig.yaml"
+
+	s := &Service{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "consul"}}}
+	s.Cluster = c
+	s.Name = "consul"
+	s.Namespace = "default"
+	s.Timeout = "1ms"
+
+	p := &ServiceProvider{s, mk, logger.NewTestLogger(t)}
+
+	return mk, p
+}
+
+func TestServiceReadsOutputs(t *testing.T) {
+	mk, p := setupK8sService(t)
+
+	o := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"type":      "NodePort",
+			"clusterIP": "10.43.0.10",
+			"ports": []interface{}{
+				map[string]interface{}{"name": "http", "port": int64(8500), "nodePort": int64(30500)},
+				map[string]interface{}{"port": int64(8600)},
+			},
+		},
+	}}
+	mk.On("GetObject", mock.Anything, "v1", "Service", "default", "consul").Return(o, nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	mk.AssertCalled(t, "SetConfig", "/tmp/kubeconfig.yaml")
+
+	require.Equal(t, "NodePort", p.config.Type)
+	require.Equal(t, "10.43.0.10", p.config.ClusterIP)
+	require.Equal(t, map[string]int{"http": 8500, "8600": 8600}, p.config.Ports)
+	require.Equal(t, map[string]int{"http": 30500}, p.config.NodePorts)
+}
+
+func TestServiceNotFoundReturnsError(t *testing.T) {
+	mk, p := setupK8sService(t)
+	mk.On("GetObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("not found"))
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
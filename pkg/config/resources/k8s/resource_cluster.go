@@ -18,6 +18,8 @@ type Cluster struct {
 	// embedded type holding name, etc.
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Networks []container.NetworkAttachment `hcl:"network,block" json:"networks,omitempty"` // Attach to the correct network // only when Image is specified
 
 	Image   *container.Image   `hcl:"image,block" json:"images,omitempty"` // optional image to use when creating the cluster
@@ -44,8 +46,16 @@ type Cluster struct {
 
 	Environment map[string]string `hcl:"environment,optional" json:"environment,omitempty"` // environment variables to set when starting the container
 
+	// Labels to set on the cluster containers and the Kubernetes objects
+	// created by jumppad
+	Labels map[string]string `hcl:"labels,optional" json:"labels,omitempty"`
+
 	Config *ClusterConfig `hcl:"config,block" json:"config,omitempty"`
 
+	// CollectLogs runs a process that writes the logs of all the pods in the
+	// cluster to logs_directory
+	CollectLogs bool `hcl:"collect_logs,optional" json:"collect_logs,omitempty"`
+
 	// output parameters
 
 	// Kubernetes config details
@@ -64,6 +74,13 @@ type Cluster struct {
 	// ExternalIP is the ip address of the cluster, this generally resolves
 	// to the docker ip
 	ExternalIP string `hcl:"external_ip,optional" json:"external_ip,omitempty"`
+
+	// LogsDirectory is the directory the pod logs are written to when
+	// collect_logs is set, $HOME/.jumppad/logs/[name]
+	LogsDirectory string `hcl:"logs_directory,optional" json:"logs_directory,omitempty"`
+
+	// LogCollectorPID is the process id of the log collector
+	LogCollectorPID int `hcl:"log_collector_pid,optional" json:"log_collector_pid,omitempty"`
 }
 
 type ClusterConfig struct {
@@ -89,6 +106,11 @@ type KubeConfig struct {
 const k3sBaseImage = "ghcr.io/jumppad-labs/kubernetes"
 const k3sBaseVersion = "v1.31.1"
 
+// GetLabels returns the labels for the cluster
+func (k *Cluster) GetLabels() map[string]string {
+	return k.Labels
+}
+
 func (k *Cluster) Process() error {
 	if k.APIPort == 0 {
 		k.APIPort = 443
@@ -99,7 +121,12 @@ func (k *Cluster) Process() error {
 	}
 
 	for i, v := range k.Volumes {
-		k.Volumes[i].Source = utils.EnsureAbsolute(v.Source, k.Meta.File)
+		src, err := utils.VolumeSource(v.Source, k.Meta.File)
+		if err != nil {
+			return fmt.Errorf("invalid source for volume %s: %s", v.Destination, err)
+		}
+
+		k.Volumes[i].Source = src
 	}
 
 	if k.Resources == nil {
@@ -124,6 +151,8 @@ func (k *Cluster) Process() error {
 			k.ExternalIP = kstate.ExternalIP
 			k.KubeConfig = kstate.KubeConfig
 			k.Resources = kstate.Resources
+			k.LogsDirectory = kstate.LogsDirectory
+			k.LogCollectorPID = kstate.LogCollectorPID
 
 			// add the network addresses
 			for _, a := range kstate.Networks {
@@ -52,6 +52,8 @@ func TestK8sClusterSetsOutputsFromState(t *testing.T) {
 				"path": "./mine.yaml"
 			},
       "container_name": "fqdn.mine.com",
+      "logs_directory": "/logs/test",
+      "log_collector_pid": 42,
       "networks": [{
         "assigned_address": "10.5.0.2",
         "name": "cloud"
@@ -78,6 +80,8 @@ func TestK8sClusterSetsOutputsFromState(t *testing.T) {
 	require.Equal(t, 124, c.ConnectorPort)
 	require.Equal(t, "./mine.yaml", c.KubeConfig.ConfigPath)
 	require.Equal(t, "fqdn.mine.com", c.ContainerName)
+	require.Equal(t, "/logs/test", c.LogsDirectory)
+	require.Equal(t, 42, c.LogCollectorPID)
 
 	// check the netwok
 	require.Equal(t, "10.5.0.2", c.Networks[0].AssignedAddress)
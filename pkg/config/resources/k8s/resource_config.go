@@ -15,6 +15,8 @@ const TypeKubernetesConfig string = "kubernetes_config"
 type Config struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Cluster Cluster `hcl:"cluster" json:"cluster"`
 
 	// Path of a file or directory of Kubernetes config files to apply
@@ -25,6 +27,9 @@ type Config struct {
 	// HealthCheck defines a health check for the resource
 	HealthCheck *healthcheck.HealthCheckKubernetes `hcl:"health_check,block" json:"health_check,omitempty"`
 
+	// Labels to add to the Kubernetes objects in the files
+	Labels map[string]string `hcl:"labels,optional" json:"labels,omitempty"`
+
 	// output
 
 	// JobChecksums store a checksum of the files or paths referenced in the Paths field
@@ -32,6 +37,16 @@ type Config struct {
 	JobChecksums map[string]string `hcl:"job_checksums,optional" json:"job_checksums,omitempty"`
 }
 
+// GetLabels returns the labels for the configuration
+func (k *Config) GetLabels() map[string]string {
+	return k.Labels
+}
+
+// GetCluster returns the cluster the configuration is applied to
+func (k *Config) GetCluster() types.Resource {
+	return &k.Cluster
+}
+
 func (k *Config) Process() error {
 	// make all the paths absolute
 	for i, p := range k.Paths {
@@ -0,0 +1,63 @@
+package k8s
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+)
+
+// TypeK8sSecret defines the string type for the Kubernetes secret resource
+const TypeK8sSecret string = "k8s_secret"
+const TypeKubernetesSecret string = "kubernetes_secret"
+
+// Secret reads a secret from a Kubernetes cluster, the secret is not created
+// or modified
+type Secret struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Cluster Cluster `hcl:"cluster" json:"cluster"`
+
+	Name      string `hcl:"name" json:"name"`
+	Namespace string `hcl:"namespace,optional" json:"namespace,omitempty"`
+
+	// Timeout to wait for the secret to exist, defaults to 60s
+	Timeout string `hcl:"timeout,optional" json:"timeout,omitempty"`
+
+	// output
+
+	// Type of the secret i.e Opaque, kubernetes.io/service-account-token
+	Type string `hcl:"type,optional" json:"type,omitempty"`
+	// Data contains the decoded values of the secret
+	Data map[string]string `hcl:"data,optional" json:"data,omitempty"`
+}
+
+func (k *Secret) Process() error {
+	if k.Namespace == "" {
+		k.Namespace = "default"
+	}
+
+	if k.Timeout == "" {
+		k.Timeout = "60s"
+	}
+
+	if _, err := time.ParseDuration(k.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout %s: %s", k.Timeout, err)
+	}
+
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(k.Meta.ID)
+		if r != nil {
+			state := r.(*Secret)
+			k.Type = state.Type
+			k.Data = state.Data
+		}
+	}
+
+	return nil
+}
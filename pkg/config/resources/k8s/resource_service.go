@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+)
+
+// TypeK8sService defines the string type for the Kubernetes service resource
+const TypeK8sService string = "k8s_service"
+const TypeKubernetesService string = "kubernetes_service"
+
+// Service reads a service from a Kubernetes cluster, the service is not
+// created or modified
+type Service struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Cluster Cluster `hcl:"cluster" json:"cluster"`
+
+	Name      string `hcl:"name" json:"name"`
+	Namespace string `hcl:"namespace,optional" json:"namespace,omitempty"`
+
+	// Timeout to wait for the service to exist, defaults to 60s
+	Timeout string `hcl:"timeout,optional" json:"timeout,omitempty"`
+
+	// output
+
+	// Type of the service i.e ClusterIP, NodePort, LoadBalancer
+	Type      string `hcl:"type,optional" json:"type,omitempty"`
+	ClusterIP string `hcl:"cluster_ip,optional" json:"cluster_ip,omitempty"`
+	// ExternalIP is the address of the load balancer for LoadBalancer services
+	ExternalIP string `hcl:"external_ip,optional" json:"external_ip,omitempty"`
+	// Ports maps the name of each port to the port number, unnamed ports use
+	// the port number as the name
+	Ports map[string]int `hcl:"ports,optional" json:"ports,omitempty"`
+	// NodePorts maps the name of each port to the node port
+	NodePorts map[string]int `hcl:"node_ports,optional" json:"node_ports,omitempty"`
+}
+
+func (k *Service) Process() error {
+	if k.Namespace == "" {
+		k.Namespace = "default"
+	}
+
+	if k.Timeout == "" {
+		k.Timeout = "60s"
+	}
+
+	if _, err := time.ParseDuration(k.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout %s: %s", k.Timeout, err)
+	}
+
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(k.Meta.ID)
+		if r != nil {
+			state := r.(*Service)
+			k.Type = state.Type
+			k.ClusterIP = state.ClusterIP
+			k.ExternalIP = state.ExternalIP
+			k.Ports = state.Ports
+			k.NodePorts = state.NodePorts
+		}
+	}
+
+	return nil
+}
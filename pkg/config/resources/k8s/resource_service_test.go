@@ -0,0 +1,24 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestK8sServiceProcessSetsDefaults(t *testing.T) {
+	s := &Service{Name: "consul"}
+
+	err := s.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "default", s.Namespace)
+	require.Equal(t, "60s", s.Timeout)
+}
+
+func TestK8sServiceProcessInvalidTimeoutReturnsError(t *testing.T) {
+	s := &Service{Name: "consul", Timeout: "sixty"}
+
+	err := s.Process()
+	require.Error(t, err)
+}
@@ -0,0 +1,183 @@
+package mesh
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// chart is a Helm chart installed for the mesh
+type chart struct {
+	release    string
+	chart      string
+	repository string
+	url        string
+	values     map[string]any
+}
+
+// charts returns the Helm charts for the mesh in the order they are installed
+func charts(m *ServiceMesh) []chart {
+	if m.Mesh == MeshIstio {
+		return []chart{
+			{
+				release:    "istio-base",
+				chart:      "istio/base",
+				repository: "istio",
+				url:        "https://istio-release.storage.googleapis.com/charts",
+				values:     map[string]any{"defaultRevision": "default"},
+			},
+			{
+				release:    "istiod",
+				chart:      "istio/istiod",
+				repository: "istio",
+				url:        "https://istio-release.storage.googleapis.com/charts",
+				values:     map[string]any{},
+			},
+		}
+	}
+
+	return []chart{
+		{
+			release:    "consul",
+			chart:      "hashicorp/consul",
+			repository: "hashicorp",
+			url:        "https://helm.releases.hashicorp.com",
+			values: map[string]any{
+				"global": map[string]any{"name": "consul"},
+				"server": map[string]any{"replicas": 1},
+				"ui":     map[string]any{"enabled": true},
+				"connectInject": map[string]any{
+					"enabled":            true,
+					"default":            true,
+					"k8sAllowNamespaces": m.InjectNamespaces,
+				},
+			},
+		},
+	}
+}
+
+// healthChecks returns the selectors for the control plane pods
+func healthChecks(m *ServiceMesh) []string {
+	if m.Mesh == MeshIstio {
+		return []string{"app=istiod"}
+	}
+
+	return []string{"app=consul,component=server", "app=consul,component=connect-injector"}
+}
+
+// injectionLabels returns the labels that enable sidecar injection for a
+// namespace, Consul selects the namespaces in the Helm values
+func injectionLabels(m *ServiceMesh) map[string]string {
+	if m.Mesh == MeshIstio {
+		return map[string]string{"istio-injection": "enabled"}
+	}
+
+	return map[string]string{}
+}
+
+// policies returns the Kubernetes manifests for the intentions, an empty
+// string is returned when there are no policies
+func policies(m *ServiceMesh) (string, error) {
+	docs := consulIntentions(m)
+	if m.Mesh == MeshIstio {
+		docs = istioPolicies(m)
+	}
+
+	buf := bytes.NewBuffer(nil)
+	for i, d := range docs {
+		if i > 0 {
+			buf.WriteString("---\n")
+		}
+
+		y, err := yaml.Marshal(d)
+		if err != nil {
+			return "", fmt.Errorf("unable to serialize policy: %s", err)
+		}
+
+		buf.Write(y)
+	}
+
+	return buf.String(), nil
+}
+
+// consulIntentions creates a ServiceIntentions for each destination
+func consulIntentions(m *ServiceMesh) []map[string]any {
+	docs := []map[string]any{}
+
+	if m.DefaultDeny {
+		docs = append(docs, serviceIntentions("deny-all", m.Namespace, "*", []map[string]any{
+			{"name": "*", "action": ActionDeny},
+		}))
+	}
+
+	// keep the order the intentions were defined in
+	keys := []string{}
+	sources := map[string][]map[string]any{}
+
+	for _, in := range m.Intentions {
+		key := in.Namespace + "/" + in.Destination
+		if _, ok := sources[key]; !ok {
+			keys = append(keys, key)
+		}
+
+		sources[key] = append(sources[key], map[string]any{"name": in.Source, "action": in.Action})
+	}
+
+	for _, k := range keys {
+		ns, dest, _ := strings.Cut(k, "/")
+		docs = append(docs, serviceIntentions(dest, ns, dest, sources[k]))
+	}
+
+	return docs
+}
+
+func serviceIntentions(name, namespace, destination string, sources []map[string]any) map[string]any {
+	return map[string]any{
+		"apiVersion": "consul.hashicorp.com/v1alpha1",
+		"kind":       "ServiceIntentions",
+		"metadata":   map[string]any{"name": name, "namespace": namespace},
+		"spec": map[string]any{
+			"destination": map[string]any{"name": destination},
+			"sources":     sources,
+		},
+	}
+}
+
+// istioPolicies creates an AuthorizationPolicy for each intention, services
+// are identified by their app label and service account
+func istioPolicies(m *ServiceMesh) []map[string]any {
+	docs := []map[string]any{}
+
+	// an empty policy in the root namespace denies all traffic in the mesh
+	if m.DefaultDeny {
+		docs = append(docs, map[string]any{
+			"apiVersion": "security.istio.io/v1",
+			"kind":       "AuthorizationPolicy",
+			"metadata":   map[string]any{"name": "deny-all", "namespace": m.Namespace},
+			"spec":       map[string]any{},
+		})
+	}
+
+	for _, in := range m.Intentions {
+		docs = append(docs, map[string]any{
+			"apiVersion": "security.istio.io/v1",
+			"kind":       "AuthorizationPolicy",
+			"metadata":   map[string]any{"name": fmt.Sprintf("%s-to-%s", in.Source, in.Destination), "namespace": in.Namespace},
+			"spec": map[string]any{
+				"selector": map[string]any{"matchLabels": map[string]any{"app": in.Destination}},
+				"action":   strings.ToUpper(in.Action),
+				"rules": []map[string]any{{
+					"from": []map[string]any{{
+						"source": map[string]any{
+							"principals": []string{fmt.Sprintf("cluster.local/ns/%s/sa/%s", in.Namespace, in.Source)},
+						},
+					}},
+				}},
+			},
+		})
+	}
+
+	return docs
+}
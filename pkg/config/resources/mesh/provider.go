@@ -0,0 +1,220 @@
+package mesh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/helm"
+	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+	"gopkg.in/yaml.v3"
+)
+
+var _ sdk.Provider = &Provider{}
+
+// Provider installs a service mesh on a Kubernetes cluster
+type Provider struct {
+	config     *ServiceMesh
+	kubeClient k8s.Kubernetes
+	helmClient helm.Helm
+	log        logger.Logger
+}
+
+func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*ServiceMesh)
+	if !ok {
+		return fmt.Errorf("unable to initialize ServiceMesh provider, resource is not of type ServiceMesh")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.kubeClient = cli.Kubernetes
+	p.helmClient = cli.Helm
+	p.log = l
+
+	return nil
+}
+
+// Create installs the control plane, enables injection for the namespaces
+// and creates the policies
+func (p *Provider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Creating Service Mesh", "ref", p.config.Meta.ID, "mesh", p.config.Mesh)
+
+	timeout, err := time.ParseDuration(p.config.Timeout)
+	if err != nil {
+		return fmt.Errorf("unable to parse timeout duration: %w", err)
+	}
+
+	p.kubeClient, err = p.kubeClient.SetConfig(p.config.Cluster.KubeConfig.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+
+	dir := p.configDir()
+
+	for _, c := range charts(p.config) {
+		p.log.Debug("Installing Helm chart", "ref", p.config.Meta.ID, "chart", c.chart)
+
+		err := p.helmClient.UpsertChartRepository(c.repository, c.url)
+		if err != nil {
+			return fmt.Errorf("unable to initialize chart repository: %w", err)
+		}
+
+		values := filepath.Join(dir, fmt.Sprintf("%s-values.yaml", c.release))
+
+		d, err := yaml.Marshal(c.values)
+		if err != nil {
+			return fmt.Errorf("unable to serialize values for chart %s: %w", c.chart, err)
+		}
+
+		err = os.WriteFile(values, d, 0644)
+		if err != nil {
+			return fmt.Errorf("unable to write values for chart %s: %w", c.chart, err)
+		}
+
+		err = p.helmClient.Create(p.config.Cluster.KubeConfig.ConfigPath, c.release, p.config.Namespace, true, false, c.chart, p.config.Version, values, nil, nil)
+		if err != nil {
+			return fmt.Errorf("unable to install chart %s: %w", c.chart, err)
+		}
+	}
+
+	// the CRDs for the policies are available once the control plane has
+	// started
+	err = p.kubeClient.HealthCheckPods(ctx, healthChecks(p.config), timeout)
+	if err != nil {
+		return fmt.Errorf("timeout waiting for service mesh control plane: %w", err)
+	}
+
+	for _, ns := range p.config.InjectNamespaces {
+		p.log.Debug("Enabling sidecar injection", "ref", p.config.Meta.ID, "namespace", ns)
+
+		err := p.kubeClient.LabelNamespace(ctx, ns, injectionLabels(p.config))
+		if err != nil {
+			return err
+		}
+	}
+
+	pol, err := policies(p.config)
+	if err != nil {
+		return err
+	}
+
+	if pol == "" {
+		return nil
+	}
+
+	path := filepath.Join(dir, "policies.yaml")
+
+	err = os.WriteFile(path, []byte(pol), 0644)
+	if err != nil {
+		return fmt.Errorf("unable to write policies: %w", err)
+	}
+
+	err = p.kubeClient.Apply([]string{path}, false, nil)
+	if err != nil {
+		return fmt.Errorf("unable to apply policies: %w", err)
+	}
+
+	return nil
+}
+
+// Destroy removes the policies and the control plane, errors are logged
+// but ignored as the cluster may have been removed
+func (p *Provider) Destroy(ctx context.Context, force bool) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping destroy, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Destroy Service Mesh", "ref", p.config.Meta.ID)
+
+	dir := p.configDir()
+	defer os.RemoveAll(dir)
+
+	var err error
+	p.kubeClient, err = p.kubeClient.SetConfig(p.config.Cluster.KubeConfig.ConfigPath)
+	if err != nil {
+		p.log.Warn("Unable to create Kubernetes client, ignoring error", "ref", p.config.Meta.ID, "error", err)
+		return nil
+	}
+
+	path := filepath.Join(dir, "policies.yaml")
+	if _, err := os.Stat(path); err == nil {
+		err := p.kubeClient.Delete([]string{path})
+		if err != nil {
+			p.log.Warn("Unable to remove policies, ignoring error", "ref", p.config.Meta.ID, "error", err)
+		}
+	}
+
+	// remove the injection labels, the namespaces are not removed as they
+	// may contain other workloads
+	labels := map[string]string{}
+	for k := range injectionLabels(p.config) {
+		labels[k] = ""
+	}
+
+	for _, ns := range p.config.InjectNamespaces {
+		if len(labels) == 0 {
+			break
+		}
+
+		err := p.kubeClient.LabelNamespace(ctx, ns, labels)
+		if err != nil {
+			p.log.Warn("Unable to disable sidecar injection, ignoring error", "ref", p.config.Meta.ID, "namespace", ns, "error", err)
+		}
+	}
+
+	// remove the charts in the reverse order to install
+	cs := charts(p.config)
+	for i := len(cs) - 1; i >= 0; i-- {
+		err := p.helmClient.Destroy(p.config.Cluster.KubeConfig.ConfigPath, cs[i].release, p.config.Namespace)
+		if err != nil {
+			p.log.Warn("Unable to remove Helm chart, ignoring error", "ref", p.config.Meta.ID, "chart", cs[i].chart, "error", err)
+		}
+	}
+
+	return nil
+}
+
+// Lookup implements the provider Lookup method
+func (p *Provider) Lookup() ([]string, error) {
+	return []string{}, nil
+}
+
+func (p *Provider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Service Mesh", "ref", p.config.Meta.ID)
+
+	return nil
+}
+
+func (p *Provider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return false, nil
+}
+
+// configDir returns the directory the values and policies are written to
+func (p *Provider) configDir() string {
+	return utils.DataFolder(fmt.Sprintf("mesh_%s", utils.FQDN(p.config.Meta.Name, p.config.Meta.Module, p.config.Meta.Type)), 0775)
+}
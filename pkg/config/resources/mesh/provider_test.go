@@ -0,0 +1,153 @@
+package mesh
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	helmMocks "github.com/jumppad-labs/jumppad/pkg/clients/helm/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	k8sResources "github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProvider(t *testing.T, mesh string) (*ServiceMesh, *Provider, *k8s.MockKubernetes, *helmMocks.Helm) {
+	home := os.Getenv(utils.HomeEnvName())
+	os.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	t.Cleanup(func() {
+		os.Setenv(utils.HomeEnvName(), home)
+	})
+
+	mk := &k8s.MockKubernetes{}
+	mk.On("SetConfig", mock.Anything).Return(nil)
+	mk.On("HealthCheckPods", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mk.On("LabelNamespace", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mk.On("Apply", mock.Anything, false, mock.Anything).Return(nil)
+	mk.On("Delete", mock.Anything).Return(nil)
+
+	mh := &helmMocks.Helm{}
+	mh.On("UpsertChartRepository", mock.Anything, mock.Anything).Return(nil)
+	mh.On("Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mh.On("Destroy", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	m := &ServiceMesh{
+		ResourceBase: htypes.ResourceBase{Meta: htypes.Meta{ID: "resource.service_mesh.test", Name: "test", Type: TypeServiceMesh}},
+		Cluster:      k8sResources.Cluster{KubeConfig: k8sResources.KubeConfig{ConfigPath: "/kubeconfig.yaml"}},
+		Mesh:         mesh,
+	}
+
+	err := m.Process()
+	require.NoError(t, err)
+
+	p := &Provider{m, mk, mh, logger.NewTestLogger(t)}
+
+	return m, p, mk, mh
+}
+
+func TestServiceMeshConsulInstallsChart(t *testing.T) {
+	_, p, _, mh := setupProvider(t, MeshConsul)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	mh.AssertCalled(t, "UpsertChartRepository", "hashicorp", "https://helm.releases.hashicorp.com")
+	mh.AssertCalled(t, "Create", "/kubeconfig.yaml", "consul", "consul", true, false, "hashicorp/consul", "", mock.Anything, mock.Anything, mock.Anything)
+
+	values := testutils.GetCalls(&mh.Mock, "Create")[0].Arguments[7].(string)
+	d, err := os.ReadFile(values)
+	require.NoError(t, err)
+	require.Contains(t, string(d), "k8sAllowNamespaces:\n        - default")
+}
+
+func TestServiceMeshIstioInstallsChartsAndLabelsNamespaces(t *testing.T) {
+	m, p, mk, mh := setupProvider(t, MeshIstio)
+	m.InjectNamespaces = []string{"payments"}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	calls := testutils.GetCalls(&mh.Mock, "Create")
+	require.Len(t, calls, 2)
+	require.Equal(t, "istio/base", calls[0].Arguments[5])
+	require.Equal(t, "istio/istiod", calls[1].Arguments[5])
+
+	mk.AssertCalled(t, "LabelNamespace", mock.Anything, "payments", map[string]string{"istio-injection": "enabled"})
+}
+
+func TestServiceMeshWithoutPoliciesDoesNotApply(t *testing.T) {
+	_, p, mk, _ := setupProvider(t, MeshConsul)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	mk.AssertNotCalled(t, "Apply", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestServiceMeshConsulAppliesIntentions(t *testing.T) {
+	m, p, mk, _ := setupProvider(t, MeshConsul)
+	m.DefaultDeny = true
+	m.Intentions = []Intention{
+		{Source: "web", Destination: "api", Namespace: "default", Action: ActionAllow},
+		{Source: "admin", Destination: "api", Namespace: "default", Action: ActionAllow},
+	}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	mk.AssertCalled(t, "Apply", mock.Anything, false, mock.Anything)
+
+	d, err := os.ReadFile(filepath.Join(p.configDir(), "policies.yaml"))
+	require.NoError(t, err)
+
+	require.Contains(t, string(d), "kind: ServiceIntentions")
+	require.Contains(t, string(d), "name: deny-all")
+	require.Contains(t, string(d), "- action: allow\n          name: web\n        - action: allow\n          name: admin")
+}
+
+func TestServiceMeshIstioAppliesAuthorizationPolicies(t *testing.T) {
+	m, p, _, _ := setupProvider(t, MeshIstio)
+	m.Intentions = []Intention{{Source: "web", Destination: "api", Namespace: "shop", Action: ActionDeny}}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(p.configDir(), "policies.yaml"))
+	require.NoError(t, err)
+
+	require.Contains(t, string(d), "kind: AuthorizationPolicy")
+	require.Contains(t, string(d), "action: DENY")
+	require.Contains(t, string(d), "cluster.local/ns/shop/sa/web")
+}
+
+func TestServiceMeshHealthCheckFailReturnsError(t *testing.T) {
+	_, p, mk, _ := setupProvider(t, MeshConsul)
+	testutils.RemoveOn(&mk.Mock, "HealthCheckPods")
+	mk.On("HealthCheckPods", mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("boom"))
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+
+	mk.AssertNotCalled(t, "LabelNamespace", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestServiceMeshDestroyRemovesCharts(t *testing.T) {
+	_, p, mk, mh := setupProvider(t, MeshIstio)
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	calls := testutils.GetCalls(&mh.Mock, "Destroy")
+	require.Len(t, calls, 2)
+	require.Equal(t, "istiod", calls[0].Arguments[1])
+	require.Equal(t, "istio-base", calls[1].Arguments[1])
+
+	mk.AssertCalled(t, "LabelNamespace", mock.Anything, "default", map[string]string{"istio-injection": ""})
+}
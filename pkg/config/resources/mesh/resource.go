@@ -0,0 +1,117 @@
+package mesh
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+)
+
+// TypeServiceMesh is the resource string for a ServiceMesh resource
+const TypeServiceMesh string = "service_mesh"
+
+// supported service meshes
+const (
+	MeshConsul = "consul"
+	MeshIstio  = "istio"
+)
+
+// supported intention actions
+const (
+	ActionAllow = "allow"
+	ActionDeny  = "deny"
+)
+
+// ServiceMesh installs Consul service mesh or Istio on a Kubernetes cluster,
+// enables sidecar injection for namespaces and creates the policies that
+// control which services can communicate
+type ServiceMesh struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Cluster k8s.Cluster `hcl:"cluster" json:"cluster"`
+
+	// Mesh is the service mesh to install, consul or istio
+	Mesh string `hcl:"mesh" json:"mesh"`
+
+	// Version of the Helm charts, defaults to the latest version
+	Version string `hcl:"version,optional" json:"version,omitempty"`
+
+	// Namespace the control plane is installed to, defaults to consul for
+	// Consul and istio-system for Istio
+	Namespace string `hcl:"namespace,optional" json:"namespace,omitempty"`
+
+	// InjectNamespaces are the namespaces where sidecars are injected into
+	// pods, namespaces that do not exist are created, defaults to default
+	InjectNamespaces []string `hcl:"inject_namespaces,optional" json:"inject_namespaces,omitempty"`
+
+	// DefaultDeny denies all traffic between services that is not allowed by
+	// an intention
+	DefaultDeny bool `hcl:"default_deny,optional" json:"default_deny,omitempty"`
+
+	// Intentions allow or deny traffic between services, for Istio these are
+	// created as AuthorizationPolicies
+	Intentions []Intention `hcl:"intention,block" json:"intentions,omitempty"`
+
+	// Timeout is the maximum time to wait for the control plane to start,
+	// default 300s
+	Timeout string `hcl:"timeout,optional" json:"timeout,omitempty"`
+}
+
+// Intention allows or denies traffic from the source service to the
+// destination service, for Istio the source is the service account and the
+// destination is the app label of the pods in the namespace
+type Intention struct {
+	Source      string `hcl:"source" json:"source"`
+	Destination string `hcl:"destination" json:"destination"`
+	// Namespace of the destination service, defaults to default
+	Namespace string `hcl:"namespace,optional" json:"namespace,omitempty"`
+	// Action is allow or deny, defaults to allow
+	Action string `hcl:"action,optional" json:"action,omitempty"`
+}
+
+func (m *ServiceMesh) Process() error {
+	switch m.Mesh {
+	case MeshConsul:
+		if m.Namespace == "" {
+			m.Namespace = "consul"
+		}
+	case MeshIstio:
+		if m.Namespace == "" {
+			m.Namespace = "istio-system"
+		}
+	default:
+		return fmt.Errorf("invalid mesh %s, must be %s or %s", m.Mesh, MeshConsul, MeshIstio)
+	}
+
+	if len(m.InjectNamespaces) == 0 {
+		m.InjectNamespaces = []string{"default"}
+	}
+
+	for i, in := range m.Intentions {
+		if in.Namespace == "" {
+			m.Intentions[i].Namespace = "default"
+		}
+
+		switch in.Action {
+		case "":
+			m.Intentions[i].Action = ActionAllow
+		case ActionAllow, ActionDeny:
+		default:
+			return fmt.Errorf("invalid action %s for intention %s to %s, must be %s or %s", in.Action, in.Source, in.Destination, ActionAllow, ActionDeny)
+		}
+	}
+
+	if m.Timeout == "" {
+		m.Timeout = "300s"
+	}
+
+	if _, err := time.ParseDuration(m.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout %s: %s", m.Timeout, err)
+	}
+
+	return nil
+}
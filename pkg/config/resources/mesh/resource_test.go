@@ -0,0 +1,49 @@
+package mesh
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestServiceMeshProcessSetsConsulDefaults(t *testing.T) {
+	m := &ServiceMesh{
+		Mesh:       MeshConsul,
+		Intentions: []Intention{{Source: "web", Destination: "api"}},
+	}
+
+	err := m.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "consul", m.Namespace)
+	require.Equal(t, []string{"default"}, m.InjectNamespaces)
+	require.Equal(t, "300s", m.Timeout)
+	require.Equal(t, "default", m.Intentions[0].Namespace)
+	require.Equal(t, ActionAllow, m.Intentions[0].Action)
+}
+
+func TestServiceMeshProcessSetsIstioNamespace(t *testing.T) {
+	m := &ServiceMesh{Mesh: MeshIstio}
+
+	err := m.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "istio-system", m.Namespace)
+}
+
+func TestServiceMeshProcessInvalidMeshReturnsError(t *testing.T) {
+	m := &ServiceMesh{Mesh: "linkerd"}
+
+	err := m.Process()
+	require.Error(t, err)
+}
+
+func TestServiceMeshProcessInvalidActionReturnsError(t *testing.T) {
+	m := &ServiceMesh{
+		Mesh:       MeshConsul,
+		Intentions: []Intention{{Source: "web", Destination: "api", Action: "block"}},
+	}
+
+	err := m.Process()
+	require.Error(t, err)
+}
@@ -0,0 +1,396 @@
+package monitoring
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// paths the generated config is mounted to in the containers
+const (
+	prometheusConfigPath = "/etc/prometheus/prometheus.yml"
+	prometheusCertsPath  = "/etc/prometheus/certs"
+	promtailConfigPath   = "/etc/promtail/config.yml"
+	clusterLogsPath      = "/var/log/jumppad"
+	dashboardsPath       = "/etc/grafana/dashboards"
+)
+
+type prometheusConfig struct {
+	Global        prometheusGlobal `yaml:"global"`
+	ScrapeConfigs []scrapeConfig   `yaml:"scrape_configs"`
+}
+
+type prometheusGlobal struct {
+	ScrapeInterval string `yaml:"scrape_interval"`
+}
+
+type scrapeConfig struct {
+	JobName         string              `yaml:"job_name"`
+	MetricsPath     string              `yaml:"metrics_path,omitempty"`
+	Scheme          string              `yaml:"scheme,omitempty"`
+	Params          map[string][]string `yaml:"params,omitempty"`
+	TLSConfig       *tlsConfig          `yaml:"tls_config,omitempty"`
+	StaticConfigs   []staticConfig      `yaml:"static_configs,omitempty"`
+	DockerSDConfigs []dockerSDConfig    `yaml:"docker_sd_configs,omitempty"`
+	RelabelConfigs  []relabelConfig     `yaml:"relabel_configs,omitempty"`
+}
+
+type tlsConfig struct {
+	CertFile           string `yaml:"cert_file,omitempty"`
+	KeyFile            string `yaml:"key_file,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+type staticConfig struct {
+	Targets []string          `yaml:"targets"`
+	Labels  map[string]string `yaml:"labels,omitempty"`
+}
+
+type dockerSDConfig struct {
+	Host string `yaml:"host"`
+}
+
+type relabelConfig struct {
+	SourceLabels []string `yaml:"source_labels,omitempty"`
+	Regex        string   `yaml:"regex,omitempty"`
+	Replacement  string   `yaml:"replacement,omitempty"`
+	TargetLabel  string   `yaml:"target_label,omitempty"`
+	Action       string   `yaml:"action,omitempty"`
+}
+
+type promtailConfig struct {
+	Server        map[string]any `yaml:"server"`
+	Positions     map[string]any `yaml:"positions"`
+	Clients       []promtailURL  `yaml:"clients"`
+	ScrapeConfigs []scrapeConfig `yaml:"scrape_configs"`
+}
+
+type promtailURL struct {
+	URL string `yaml:"url"`
+}
+
+// writePrometheusConfig writes the Prometheus config to dir, the client
+// certificates for the Kubernetes clusters are written to dir/certs
+func writePrometheusConfig(dir string, m *Monitoring, networks []string) error {
+	pc := prometheusConfig{
+		Global: prometheusGlobal{ScrapeInterval: "15s"},
+		ScrapeConfigs: []scrapeConfig{
+			{
+				JobName:       "prometheus",
+				StaticConfigs: []staticConfig{{Targets: []string{"localhost:9090"}}},
+			},
+			containersScrapeConfig(networks),
+		},
+	}
+
+	certDir := filepath.Join(dir, "certs")
+	err := os.MkdirAll(certDir, 0755)
+	if err != nil {
+		return fmt.Errorf("unable to create certificate directory: %s", err)
+	}
+
+	for _, k := range m.K8sClusters {
+		// the api server requires a client certificate
+		for _, f := range []struct{ name, data string }{
+			{k.Meta.Name + ".crt", k.KubeConfig.ClientCertificate},
+			{k.Meta.Name + ".key", k.KubeConfig.ClientKey},
+		} {
+			d, err := base64.StdEncoding.DecodeString(f.data)
+			if err != nil {
+				return fmt.Errorf("unable to decode client certificate for cluster %s: %s", k.Meta.Name, err)
+			}
+
+			err = os.WriteFile(filepath.Join(certDir, f.name), d, 0644)
+			if err != nil {
+				return fmt.Errorf("unable to write client certificate for cluster %s: %s", k.Meta.Name, err)
+			}
+		}
+
+		pc.ScrapeConfigs = append(pc.ScrapeConfigs, scrapeConfig{
+			JobName: "k8s_" + k.Meta.Name,
+			Scheme:  "https",
+			TLSConfig: &tlsConfig{
+				CertFile:           path.Join(prometheusCertsPath, k.Meta.Name+".crt"),
+				KeyFile:            path.Join(prometheusCertsPath, k.Meta.Name+".key"),
+				InsecureSkipVerify: true,
+			},
+			StaticConfigs: []staticConfig{{
+				Targets: []string{fmt.Sprintf("%s:%d", k.ContainerName, k.APIPort)},
+				Labels:  map[string]string{"cluster": k.Meta.Name},
+			}},
+		})
+	}
+
+	for _, n := range m.NomadClusters {
+		targets := []string{n.ServerContainerName + ":4646"}
+		for _, c := range n.ClientContainerName {
+			targets = append(targets, c+":4646")
+		}
+
+		pc.ScrapeConfigs = append(pc.ScrapeConfigs, scrapeConfig{
+			JobName:       "nomad_" + n.Meta.Name,
+			MetricsPath:   "/v1/metrics",
+			Params:        map[string][]string{"format": {"prometheus"}},
+			StaticConfigs: []staticConfig{{Targets: targets, Labels: map[string]string{"cluster": n.Meta.Name}}},
+		})
+	}
+
+	for _, s := range m.Scrape {
+		pc.ScrapeConfigs = append(pc.ScrapeConfigs, scrapeConfig{
+			JobName:       s.Name,
+			MetricsPath:   s.Path,
+			Scheme:        s.Scheme,
+			StaticConfigs: []staticConfig{{Targets: s.Targets}},
+		})
+	}
+
+	return writeYAML(filepath.Join(dir, "prometheus.yml"), pc)
+}
+
+// containersScrapeConfig discovers the containers in the networks that have
+// the prometheus.io/scrape label, the port and path are set with the
+// prometheus.io/port and prometheus.io/path labels
+func containersScrapeConfig(networks []string) scrapeConfig {
+	return scrapeConfig{
+		JobName:         "containers",
+		DockerSDConfigs: []dockerSDConfig{{Host: "unix:///var/run/docker.sock"}},
+		RelabelConfigs: append(networkRelabelConfigs(networks),
+			relabelConfig{
+				SourceLabels: []string{"__meta_docker_container_label_prometheus_io_scrape"},
+				Regex:        "true",
+				Action:       "keep",
+			},
+			relabelConfig{
+				SourceLabels: []string{"__meta_docker_network_ip", "__meta_docker_container_label_prometheus_io_port"},
+				Regex:        "(.+);(.+)",
+				Replacement:  "$1:$2",
+				TargetLabel:  "__address__",
+			},
+			relabelConfig{
+				SourceLabels: []string{"__meta_docker_container_label_prometheus_io_path"},
+				Regex:        "(.+)",
+				TargetLabel:  "__metrics_path__",
+			},
+			relabelConfig{
+				SourceLabels: []string{"__meta_docker_container_name"},
+				Regex:        "/(.*)",
+				TargetLabel:  "container",
+			},
+		),
+	}
+}
+
+// writePromtailConfig writes the Promtail config to dir, the logs of the
+// containers in the networks and of the clusters that collect logs are
+// sent to Loki
+func writePromtailConfig(dir string, m *Monitoring, networks []string, lokiAddress string) error {
+	pc := promtailConfig{
+		Server:    map[string]any{"http_listen_port": 9080, "grpc_listen_port": 0},
+		Positions: map[string]any{"filename": "/tmp/positions.yaml"},
+		Clients:   []promtailURL{{URL: lokiAddress + "/loki/api/v1/push"}},
+		ScrapeConfigs: []scrapeConfig{
+			{
+				JobName:         "containers",
+				DockerSDConfigs: []dockerSDConfig{{Host: "unix:///var/run/docker.sock"}},
+				RelabelConfigs: append(networkRelabelConfigs(networks),
+					relabelConfig{
+						SourceLabels: []string{"__meta_docker_container_name"},
+						Regex:        "/(.*)",
+						TargetLabel:  "container",
+					},
+					relabelConfig{
+						Replacement: "containers",
+						TargetLabel: "job",
+					},
+				),
+			},
+		},
+	}
+
+	for _, c := range logClusters(m) {
+		pc.ScrapeConfigs = append(pc.ScrapeConfigs, scrapeConfig{
+			JobName: "cluster_" + c.name,
+			StaticConfigs: []staticConfig{{
+				Targets: []string{"localhost"},
+				Labels: map[string]string{
+					"job":      "clusters",
+					"cluster":  c.name,
+					"__path__": path.Join(clusterLogsPath, c.name, "*.log"),
+				},
+			}},
+		})
+	}
+
+	return writeYAML(filepath.Join(dir, "promtail.yml"), pc)
+}
+
+// networkRelabelConfigs keeps only the targets in the given networks
+func networkRelabelConfigs(networks []string) []relabelConfig {
+	if len(networks) == 0 {
+		return []relabelConfig{}
+	}
+
+	names := []string{}
+	for _, n := range networks {
+		names = append(names, regexp.QuoteMeta(n))
+	}
+
+	return []relabelConfig{{
+		SourceLabels: []string{"__meta_docker_network_name"},
+		Regex:        strings.Join(names, "|"),
+		Action:       "keep",
+	}}
+}
+
+// writeGrafanaConfig writes the Grafana provisioning config to dir
+func writeGrafanaConfig(dir string, prometheusAddress, lokiAddress string, customDashboards bool) error {
+	for _, d := range []string{"datasources", "dashboards", "jumppad"} {
+		err := os.MkdirAll(filepath.Join(dir, d), 0755)
+		if err != nil {
+			return fmt.Errorf("unable to create Grafana config directory: %s", err)
+		}
+	}
+
+	datasources := map[string]any{
+		"apiVersion": 1,
+		"datasources": []map[string]any{
+			{"name": "Prometheus", "type": "prometheus", "uid": "prometheus", "access": "proxy", "url": prometheusAddress, "isDefault": true},
+			{"name": "Loki", "type": "loki", "uid": "loki", "access": "proxy", "url": lokiAddress},
+		},
+	}
+
+	err := writeYAML(filepath.Join(dir, "datasources", "datasources.yml"), datasources)
+	if err != nil {
+		return err
+	}
+
+	providers := []map[string]any{
+		dashboardProvider("jumppad", path.Join(dashboardsPath, "jumppad")),
+	}
+
+	if customDashboards {
+		providers = append(providers, dashboardProvider("custom", path.Join(dashboardsPath, "custom")))
+	}
+
+	err = writeYAML(filepath.Join(dir, "dashboards", "dashboards.yml"), map[string]any{"apiVersion": 1, "providers": providers})
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(filepath.Join(dir, "jumppad", "overview.json"), []byte(overviewDashboard), 0644)
+	if err != nil {
+		return fmt.Errorf("unable to write dashboard: %s", err)
+	}
+
+	return nil
+}
+
+func dashboardProvider(name, path string) map[string]any {
+	return map[string]any{
+		"name":                  name,
+		"folder":                name,
+		"type":                  "file",
+		"updateIntervalSeconds": 10,
+		"options":               map[string]any{"path": path},
+	}
+}
+
+type logCluster struct {
+	name      string
+	directory string
+}
+
+// logClusters returns the clusters that write their logs to the host
+func logClusters(m *Monitoring) []logCluster {
+	lc := []logCluster{}
+
+	for _, k := range m.K8sClusters {
+		if k.LogsDirectory != "" {
+			lc = append(lc, logCluster{k.Meta.Name, k.LogsDirectory})
+		}
+	}
+
+	for _, n := range m.NomadClusters {
+		if n.LogsDirectory != "" {
+			lc = append(lc, logCluster{n.Meta.Name, n.LogsDirectory})
+		}
+	}
+
+	return lc
+}
+
+func writeYAML(file string, v any) error {
+	d, err := yaml.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("unable to serialize %s: %s", filepath.Base(file), err)
+	}
+
+	err = os.WriteFile(file, d, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to write %s: %s", filepath.Base(file), err)
+	}
+
+	return nil
+}
+
+// overviewDashboard shows the status of the scrape targets and the logs of
+// the containers and clusters
+const overviewDashboard = `{
+  "uid": "jumppad-overview",
+  "title": "Jumppad Overview",
+  "schemaVersion": 39,
+  "time": { "from": "now-30m", "to": "now" },
+  "refresh": "10s",
+  "templating": {
+    "list": [
+      {
+        "name": "container",
+        "type": "query",
+        "datasource": { "type": "loki", "uid": "loki" },
+        "query": { "label": "container", "stream": "{job=\"containers\"}", "type": 1 },
+        "includeAll": true,
+        "multi": true,
+        "current": { "text": "All", "value": "$__all" }
+      }
+    ]
+  },
+  "panels": [
+    {
+      "id": 1,
+      "type": "table",
+      "title": "Scrape Targets",
+      "gridPos": { "h": 8, "w": 24, "x": 0, "y": 0 },
+      "datasource": { "type": "prometheus", "uid": "prometheus" },
+      "targets": [
+        { "refId": "A", "expr": "up", "instant": true, "format": "table" }
+      ]
+    },
+    {
+      "id": 2,
+      "type": "logs",
+      "title": "Container Logs",
+      "gridPos": { "h": 12, "w": 24, "x": 0, "y": 8 },
+      "datasource": { "type": "loki", "uid": "loki" },
+      "targets": [
+        { "refId": "A", "expr": "{job=\"containers\", container=~\"$container\"}" }
+      ]
+    },
+    {
+      "id": 3,
+      "type": "logs",
+      "title": "Cluster Logs",
+      "gridPos": { "h": 12, "w": 24, "x": 0, "y": 20 },
+      "datasource": { "type": "loki", "uid": "loki" },
+      "targets": [
+        { "refId": "A", "expr": "{job=\"clusters\"}" }
+      ]
+    }
+  ]
+}
+`
@@ -0,0 +1,295 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/jumppad-labs/hclconfig/resources"
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+// images used by the monitoring stack
+const (
+	prometheusImage = "prom/prometheus:v2.54.1"
+	grafanaImage    = "grafana/grafana:11.2.0"
+	lokiImage       = "grafana/loki:3.1.1"
+	promtailImage   = "grafana/promtail:3.1.1"
+)
+
+var _ sdk.Provider = &Provider{}
+
+// Provider creates the containers for a Monitoring resource
+type Provider struct {
+	config *Monitoring
+	client container.ContainerTasks
+	log    logger.Logger
+}
+
+func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Monitoring)
+	if !ok {
+		return fmt.Errorf("unable to initialize Monitoring provider, resource is not of type Monitoring")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.client = cli.ContainerTasks
+	p.log = l
+
+	return nil
+}
+
+// Create the configuration and the containers for the monitoring stack
+func (p *Provider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Creating Monitoring", "ref", p.config.Meta.ID)
+
+	p.config.PrometheusContainerName = p.containerName("prometheus")
+	p.config.LokiContainerName = p.containerName("loki")
+	p.config.GrafanaContainerName = p.containerName("grafana")
+
+	prometheusAddress := fmt.Sprintf("http://%s:9090", p.config.PrometheusContainerName)
+	lokiAddress := fmt.Sprintf("http://%s:3100", p.config.LokiContainerName)
+
+	dir := p.configDir()
+	networks := p.networkNames()
+
+	err := writePrometheusConfig(dir, p.config, networks)
+	if err != nil {
+		return err
+	}
+
+	err = writePromtailConfig(dir, p.config, networks, lokiAddress)
+	if err != nil {
+		return err
+	}
+
+	err = writeGrafanaConfig(filepath.Join(dir, "grafana"), prometheusAddress, lokiAddress, p.config.Dashboards != "")
+	if err != nil {
+		return err
+	}
+
+	// Loki uses the default config from the image
+	err = p.createContainer(&types.Container{
+		Name:  p.config.LokiContainerName,
+		Image: &types.Image{Name: lokiImage},
+		Ports: []types.Port{port(3100, p.config.LokiPort)},
+	})
+	if err != nil {
+		return err
+	}
+
+	// Prometheus and Promtail discover the containers using the Docker API
+	err = p.createContainer(&types.Container{
+		Name:    p.config.PrometheusContainerName,
+		Image:   &types.Image{Name: prometheusImage},
+		Command: []string{"--config.file=" + prometheusConfigPath, "--web.enable-lifecycle"},
+		Ports:   []types.Port{port(9090, p.config.PrometheusPort)},
+		Volumes: []types.Volume{
+			dockerSocket(),
+			{Source: filepath.Join(dir, "prometheus.yml"), Destination: prometheusConfigPath, ReadOnly: true},
+			{Source: filepath.Join(dir, "certs"), Destination: prometheusCertsPath, ReadOnly: true},
+		},
+		RunAs: &types.User{User: "0", Group: "0"},
+	})
+	if err != nil {
+		return err
+	}
+
+	promtailVolumes := []types.Volume{
+		dockerSocket(),
+		{Source: filepath.Join(dir, "promtail.yml"), Destination: promtailConfigPath, ReadOnly: true},
+	}
+
+	for _, c := range logClusters(p.config) {
+		promtailVolumes = append(promtailVolumes, types.Volume{
+			Source:      c.directory,
+			Destination: path.Join(clusterLogsPath, c.name),
+			ReadOnly:    true,
+		})
+	}
+
+	err = p.createContainer(&types.Container{
+		Name:    p.containerName("promtail"),
+		Image:   &types.Image{Name: promtailImage},
+		Command: []string{"-config.file=" + promtailConfigPath},
+		Volumes: promtailVolumes,
+	})
+	if err != nil {
+		return err
+	}
+
+	grafanaVolumes := []types.Volume{
+		{Source: filepath.Join(dir, "grafana", "datasources"), Destination: "/etc/grafana/provisioning/datasources", ReadOnly: true},
+		{Source: filepath.Join(dir, "grafana", "dashboards"), Destination: "/etc/grafana/provisioning/dashboards", ReadOnly: true},
+		{Source: filepath.Join(dir, "grafana", "jumppad"), Destination: path.Join(dashboardsPath, "jumppad"), ReadOnly: true},
+	}
+
+	if p.config.Dashboards != "" {
+		grafanaVolumes = append(grafanaVolumes, types.Volume{
+			Source:      p.config.Dashboards,
+			Destination: path.Join(dashboardsPath, "custom"),
+			ReadOnly:    true,
+		})
+	}
+
+	err = p.createContainer(&types.Container{
+		Name:  p.config.GrafanaContainerName,
+		Image: &types.Image{Name: grafanaImage},
+		Ports: []types.Port{port(3000, p.config.GrafanaPort)},
+		Environment: map[string]string{
+			"GF_AUTH_ANONYMOUS_ENABLED":                 "true",
+			"GF_AUTH_ANONYMOUS_ORG_ROLE":                "Admin",
+			"GF_AUTH_DISABLE_LOGIN_FORM":                "true",
+			"GF_DASHBOARDS_DEFAULT_HOME_DASHBOARD_PATH": path.Join(dashboardsPath, "jumppad", "overview.json"),
+		},
+		Volumes: grafanaVolumes,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.config.GrafanaAddress = fmt.Sprintf("http://localhost:%d", p.config.GrafanaPort)
+	p.config.PrometheusAddress = fmt.Sprintf("http://localhost:%d", p.config.PrometheusPort)
+	p.config.LokiAddress = fmt.Sprintf("http://localhost:%d", p.config.LokiPort)
+
+	return nil
+}
+
+// Destroy the containers and the configuration
+func (p *Provider) Destroy(ctx context.Context, force bool) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping destroy, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Destroy Monitoring", "ref", p.config.Meta.ID)
+
+	ids, err := p.Lookup()
+	if err != nil {
+		return err
+	}
+
+	err = p.client.RemoveContainers(ids, force)
+	if err != nil {
+		return err
+	}
+
+	os.RemoveAll(p.configDir())
+
+	return nil
+}
+
+// Lookup the ids of the containers
+func (p *Provider) Lookup() ([]string, error) {
+	ids := []string{}
+
+	for _, s := range []string{"grafana", "promtail", "prometheus", "loki"} {
+		id, err := p.client.FindContainerIDs(p.containerName(s))
+		if err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id...)
+	}
+
+	return ids, nil
+}
+
+func (p *Provider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Monitoring", "ref", p.config.Meta.ID)
+
+	return nil
+}
+
+func (p *Provider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return false, nil
+}
+
+func (p *Provider) createContainer(cc *types.Container) error {
+	p.log.Debug("Creating container", "ref", p.config.Meta.ID, "name", cc.Name, "image", cc.Image.Name)
+
+	cc.Networks = p.config.Networks.ToClientNetworkAttachments()
+	cc.Labels = config.DockerLabels(p.config)
+	cc.MaxRestartCount = -1
+
+	err := p.client.PullImage(*cc.Image, false)
+	if err != nil {
+		return fmt.Errorf("unable to pull image %s: %s", cc.Image.Name, err)
+	}
+
+	_, err = p.client.CreateContainer(cc)
+	if err != nil {
+		return fmt.Errorf("unable to create container %s: %s", cc.Name, err)
+	}
+
+	return nil
+}
+
+// containerName returns the fully qualified name for a service in the stack
+// e.g. grafana.[name].monitoring.local.jmpd.in
+func (p *Provider) containerName(service string) string {
+	return utils.FQDN(fmt.Sprintf("%s.%s", service, p.config.Meta.Name), p.config.Meta.Module, p.config.Meta.Type)
+}
+
+// configDir returns the directory the generated config is written to
+func (p *Provider) configDir() string {
+	return utils.DataFolder(fmt.Sprintf("monitoring_%s", utils.FQDN(p.config.Meta.Name, p.config.Meta.Module, p.config.Meta.Type)), 0775)
+}
+
+// networkNames returns the Docker network names the stack is attached to,
+// the Docker network is named after the network resource and environment
+func (p *Provider) networkNames() []string {
+	names := []string{}
+
+	for _, n := range p.config.Networks {
+		fqrn, err := resources.ParseFQRN(n.ID)
+		if err != nil {
+			continue
+		}
+
+		names = append(names, utils.EnvironmentName(fqrn.Resource))
+	}
+
+	return names
+}
+
+func port(local, host int) types.Port {
+	return types.Port{
+		Local:    fmt.Sprintf("%d", local),
+		Host:     fmt.Sprintf("%d", host),
+		Protocol: "tcp",
+	}
+}
+
+func dockerSocket() types.Volume {
+	return types.Volume{
+		Source:      utils.GetDockerHost(),
+		Destination: "/var/run/docker.sock",
+	}
+}
@@ -0,0 +1,175 @@
+package monitoring
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	cmocks "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProvider(t *testing.T) (*Monitoring, *Provider, *cmocks.ContainerTasks) {
+	home := os.Getenv(utils.HomeEnvName())
+	os.Setenv(utils.HomeEnvName(), t.TempDir())
+
+	t.Cleanup(func() {
+		os.Setenv(utils.HomeEnvName(), home)
+	})
+
+	md := &cmocks.ContainerTasks{}
+	md.On("PullImage", mock.Anything, false).Return(nil)
+	md.On("CreateContainer", mock.Anything).Return("123", nil)
+	md.On("FindContainerIDs", mock.Anything).Return([]string{"123"}, nil)
+	md.On("RemoveContainers", mock.Anything, mock.Anything).Return(nil)
+
+	m := &Monitoring{
+		ResourceBase:   htypes.ResourceBase{Meta: htypes.Meta{ID: "resource.monitoring.test", Name: "test", Type: TypeMonitoring}},
+		Networks:       ctypes.NetworkAttachments{{ID: "resource.network.cloud"}},
+		GrafanaPort:    3000,
+		PrometheusPort: 9090,
+		LokiPort:       3100,
+	}
+
+	p := &Provider{m, md, logger.NewTestLogger(t)}
+
+	return m, p, md
+}
+
+func createdContainer(t *testing.T, md *cmocks.ContainerTasks, name string) *types.Container {
+	for _, c := range testutils.GetCalls(&md.Mock, "CreateContainer") {
+		cc := c.Arguments[0].(*types.Container)
+		if cc.Name == name {
+			return cc
+		}
+	}
+
+	require.Failf(t, "container not created", "%s", name)
+	return nil
+}
+
+func TestMonitoringCreatesContainers(t *testing.T) {
+	m, p, md := setupProvider(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	md.AssertNumberOfCalls(t, "CreateContainer", 4)
+
+	require.Equal(t, "grafana.test.monitoring.local.jmpd.in", m.GrafanaContainerName)
+	require.Equal(t, "http://localhost:3000", m.GrafanaAddress)
+	require.Equal(t, "http://localhost:9090", m.PrometheusAddress)
+	require.Equal(t, "http://localhost:3100", m.LokiAddress)
+
+	grafana := createdContainer(t, md, m.GrafanaContainerName)
+	require.Equal(t, "3000", grafana.Ports[0].Host)
+	require.Equal(t, "resource.network.cloud", grafana.Networks[0].ID)
+}
+
+func TestMonitoringCreateWritesGrafanaDatasources(t *testing.T) {
+	_, p, _ := setupProvider(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(p.configDir(), "grafana", "datasources", "datasources.yml"))
+	require.NoError(t, err)
+
+	require.Contains(t, string(d), "http://prometheus.test.monitoring.local.jmpd.in:9090")
+	require.Contains(t, string(d), "http://loki.test.monitoring.local.jmpd.in:3100")
+}
+
+func TestMonitoringCreateScrapesClusters(t *testing.T) {
+	m, p, _ := setupProvider(t)
+
+	m.K8sClusters = []k8s.Cluster{{
+		ResourceBase:  htypes.ResourceBase{Meta: htypes.Meta{Name: "k3s"}},
+		ContainerName: "server.k3s.k8s-cluster.local.jmpd.in",
+		APIPort:       443,
+		KubeConfig: k8s.KubeConfig{
+			ClientCertificate: base64.StdEncoding.EncodeToString([]byte("cert")),
+			ClientKey:         base64.StdEncoding.EncodeToString([]byte("key")),
+		},
+	}}
+
+	m.NomadClusters = []nomad.NomadCluster{{
+		ResourceBase:        htypes.ResourceBase{Meta: htypes.Meta{Name: "dev"}},
+		ServerContainerName: "server.dev.nomad-cluster.local.jmpd.in",
+	}}
+
+	m.Scrape = []Scrape{{Name: "app", Targets: []string{"app:9102"}, Path: "/metrics", Scheme: "http"}}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(p.configDir(), "prometheus.yml"))
+	require.NoError(t, err)
+
+	require.Contains(t, string(d), "server.k3s.k8s-cluster.local.jmpd.in:443")
+	require.Contains(t, string(d), "server.dev.nomad-cluster.local.jmpd.in:4646")
+	require.Contains(t, string(d), "app:9102")
+	require.Contains(t, string(d), "regex: cloud")
+
+	cert, err := os.ReadFile(filepath.Join(p.configDir(), "certs", "k3s.crt"))
+	require.NoError(t, err)
+	require.Equal(t, "cert", string(cert))
+}
+
+func TestMonitoringCreateWithInvalidCertificateReturnsError(t *testing.T) {
+	m, p, md := setupProvider(t)
+
+	m.K8sClusters = []k8s.Cluster{{
+		ResourceBase: htypes.ResourceBase{Meta: htypes.Meta{Name: "k3s"}},
+		KubeConfig:   k8s.KubeConfig{ClientCertificate: "not base64"},
+	}}
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+
+	md.AssertNotCalled(t, "CreateContainer", mock.Anything)
+}
+
+func TestMonitoringCreateTailsClusterLogs(t *testing.T) {
+	m, p, md := setupProvider(t)
+
+	m.NomadClusters = []nomad.NomadCluster{{
+		ResourceBase:  htypes.ResourceBase{Meta: htypes.Meta{Name: "dev"}},
+		LogsDirectory: "/logs/dev",
+	}}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	promtail := createdContainer(t, md, p.containerName("promtail"))
+	require.Equal(t, "/logs/dev", promtail.Volumes[2].Source)
+	require.Equal(t, "/var/log/jumppad/dev", promtail.Volumes[2].Destination)
+}
+
+func TestMonitoringCreateWithPullErrorReturnsError(t *testing.T) {
+	_, p, md := setupProvider(t)
+	testutils.RemoveOn(&md.Mock, "PullImage")
+	md.On("PullImage", mock.Anything, false).Return(os.ErrNotExist)
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
+
+func TestMonitoringDestroyRemovesContainers(t *testing.T) {
+	_, p, md := setupProvider(t)
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	md.AssertCalled(t, "RemoveContainers", []string{"123", "123", "123", "123"}, false)
+}
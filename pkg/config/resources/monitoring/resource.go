@@ -0,0 +1,130 @@
+package monitoring
+
+import (
+	"fmt"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// TypeMonitoring is the resource string for a Monitoring resource
+const TypeMonitoring string = "monitoring"
+
+// Monitoring runs Prometheus, Grafana and Loki containers that are
+// configured to collect the metrics and logs of the containers and clusters
+// in the environment
+type Monitoring struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Networks ctypes.NetworkAttachments `hcl:"network,block" json:"networks,omitempty"` // Attach to the correct network
+
+	// K8sClusters are scraped by Prometheus, the logs of clusters that set
+	// collect_logs are sent to Loki
+	K8sClusters []k8s.Cluster `hcl:"k8s_clusters,optional" json:"k8s_clusters,omitempty"`
+
+	// NomadClusters are scraped by Prometheus, the logs of clusters that set
+	// collect_logs are sent to Loki
+	NomadClusters []nomad.NomadCluster `hcl:"nomad_clusters,optional" json:"nomad_clusters,omitempty"`
+
+	// Scrape defines additional Prometheus scrape jobs
+	Scrape []Scrape `hcl:"scrape,block" json:"scrape,omitempty"`
+
+	// Dashboards is a directory of Grafana dashboard JSON files which are
+	// provisioned along with the default dashboards
+	Dashboards string `hcl:"dashboards,optional" json:"dashboards,omitempty"`
+
+	// Host ports for the UIs and APIs
+	GrafanaPort    int `hcl:"grafana_port,optional" json:"grafana_port,omitempty"`       // defaults to 3000
+	PrometheusPort int `hcl:"prometheus_port,optional" json:"prometheus_port,omitempty"` // defaults to 9090
+	LokiPort       int `hcl:"loki_port,optional" json:"loki_port,omitempty"`             // defaults to 3100
+
+	// Output parameters
+
+	// GrafanaAddress is the address of the Grafana UI on the host
+	GrafanaAddress string `hcl:"grafana_address,optional" json:"grafana_address,omitempty"`
+
+	// PrometheusAddress is the address of the Prometheus API on the host
+	PrometheusAddress string `hcl:"prometheus_address,optional" json:"prometheus_address,omitempty"`
+
+	// LokiAddress is the address of the Loki API on the host
+	LokiAddress string `hcl:"loki_address,optional" json:"loki_address,omitempty"`
+
+	// Fully qualified domain names of the containers, these can be used to
+	// reference the services from other containers
+	GrafanaContainerName    string `hcl:"grafana_container_name,optional" json:"grafana_container_name,omitempty"`
+	PrometheusContainerName string `hcl:"prometheus_container_name,optional" json:"prometheus_container_name,omitempty"`
+	LokiContainerName       string `hcl:"loki_container_name,optional" json:"loki_container_name,omitempty"`
+}
+
+// Scrape is a Prometheus scrape job
+type Scrape struct {
+	Name string `hcl:"name,label" json:"name"`
+	// Targets are the host:port addresses to scrape
+	Targets []string `hcl:"targets" json:"targets"`
+	// Path defaults to /metrics
+	Path string `hcl:"path,optional" json:"path,omitempty"`
+	// Scheme is http or https, defaults to http
+	Scheme string `hcl:"scheme,optional" json:"scheme,omitempty"`
+}
+
+func (m *Monitoring) Process() error {
+	if m.GrafanaPort == 0 {
+		m.GrafanaPort = 3000
+	}
+
+	if m.PrometheusPort == 0 {
+		m.PrometheusPort = 9090
+	}
+
+	if m.LokiPort == 0 {
+		m.LokiPort = 3100
+	}
+
+	if m.Dashboards != "" {
+		m.Dashboards = utils.EnsureAbsolute(m.Dashboards, m.Meta.File)
+	}
+
+	for i, s := range m.Scrape {
+		if len(s.Targets) == 0 {
+			return fmt.Errorf("scrape %s must have at least one target", s.Name)
+		}
+
+		if s.Path == "" {
+			m.Scrape[i].Path = "/metrics"
+		}
+
+		switch s.Scheme {
+		case "":
+			m.Scrape[i].Scheme = "http"
+		case "http", "https":
+		default:
+			return fmt.Errorf("invalid scheme %s for scrape %s, must be http or https", s.Scheme, s.Name)
+		}
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(m.Meta.ID)
+		if r != nil {
+			kstate := r.(*Monitoring)
+			m.GrafanaAddress = kstate.GrafanaAddress
+			m.PrometheusAddress = kstate.PrometheusAddress
+			m.LokiAddress = kstate.LokiAddress
+			m.GrafanaContainerName = kstate.GrafanaContainerName
+			m.PrometheusContainerName = kstate.PrometheusContainerName
+			m.LokiContainerName = kstate.LokiContainerName
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package monitoring
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	config.RegisterResource(TypeMonitoring, &Monitoring{}, &Provider{})
+}
+
+func TestMonitoringProcessSetsDefaults(t *testing.T) {
+	m := &Monitoring{Scrape: []Scrape{{Name: "app", Targets: []string{"app:9102"}}}}
+
+	err := m.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, 3000, m.GrafanaPort)
+	require.Equal(t, 9090, m.PrometheusPort)
+	require.Equal(t, 3100, m.LokiPort)
+	require.Equal(t, "/metrics", m.Scrape[0].Path)
+	require.Equal(t, "http", m.Scrape[0].Scheme)
+}
+
+func TestMonitoringProcessScrapeWithoutTargetsReturnsError(t *testing.T) {
+	m := &Monitoring{Scrape: []Scrape{{Name: "app"}}}
+
+	err := m.Process()
+	require.Error(t, err)
+}
+
+func TestMonitoringProcessInvalidSchemeReturnsError(t *testing.T) {
+	m := &Monitoring{Scrape: []Scrape{{Name: "app", Targets: []string{"app:9102"}, Scheme: "tcp"}}}
+
+	err := m.Process()
+	require.Error(t, err)
+}
+
+func TestMonitoringSetsOutputsFromState(t *testing.T) {
+	testutils.SetupState(t, `
+{
+  "blueprint": null,
+  "resources": [
+  {
+      "meta": {
+        "id": "resource.monitoring.test",
+        "name": "test",
+        "type": "monitoring"
+      },
+      "grafana_address": "http://localhost:3000",
+      "prometheus_address": "http://localhost:9090",
+      "loki_address": "http://localhost:3100",
+      "grafana_container_name": "grafana.test.monitoring.local.jmpd.in"
+  }]
+}`)
+
+	m := &Monitoring{
+		ResourceBase: types.ResourceBase{
+			Meta: types.Meta{
+				ID: "resource.monitoring.test",
+			},
+		},
+	}
+
+	err := m.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "http://localhost:3000", m.GrafanaAddress)
+	require.Equal(t, "http://localhost:9090", m.PrometheusAddress)
+	require.Equal(t, "http://localhost:3100", m.LokiAddress)
+	require.Equal(t, "grafana.test.monitoring.local.jmpd.in", m.GrafanaContainerName)
+}
@@ -0,0 +1,43 @@
+package network
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+)
+
+// defaultHelperImage is the image used to configure the network namespace of
+// a container when no image is specified, it contains both tc and iptables
+const defaultHelperImage = "nicolaka/netshoot:v0.13"
+
+// execInNetworkNamespace creates a temporary container that shares the network
+// namespace of the target, executes the command, and removes the container
+func execInNetworkNamespace(client container.ContainerTasks, name string, image types.Image, targetID string, command []string) (string, error) {
+	helper := types.Container{
+		Name:         name,
+		Image:        &image,
+		Entrypoint:   []string{},
+		Command:      []string{"tail", "-f", "/dev/null"}, // ensure container does not immediately exit
+		Networks:     []types.NetworkAttachment{{ID: targetID, IsContainer: true}},
+		Capabilities: &types.Capabilities{Add: []string{"NET_ADMIN"}},
+	}
+
+	id, err := client.CreateContainer(&helper)
+	if err != nil {
+		return "", fmt.Errorf("unable to create helper container: %w", err)
+	}
+
+	defer client.RemoveContainer(id, true)
+
+	out := bytes.NewBufferString("")
+
+	_, err = client.ExecuteCommand(id, command, nil, "", "", "", 30, out)
+	if err != nil {
+		return "", fmt.Errorf("unable to execute %s: %w, output: %s", strings.Join(command, " "), err, out.String())
+	}
+
+	return out.String(), nil
+}
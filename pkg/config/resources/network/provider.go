@@ -4,12 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"regexp"
 
 	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 	htypes "github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
 
@@ -75,11 +78,21 @@ func (p *Provider) Create(ctx context.Context) error {
 
 	// is the network name and subnet equal to one which already exists
 	for _, ne := range nets {
-		if ne.Name == p.config.Meta.Name {
+		if ne.Name == p.dockerName() {
 			return fmt.Errorf("a Network already exists with the name: %s ref:%s", p.config.Meta.Name, p.config.Meta.ID)
 		}
 	}
 
+	subnets := []*net.IPNet{cidr}
+	if p.config.SubnetIPv6 != "" {
+		_, cidr6, err := net.ParseCIDR(p.config.SubnetIPv6)
+		if err != nil {
+			return fmt.Errorf("unable to create network %s, invalid IPv6 subnet %s", p.config.Meta.Name, p.config.SubnetIPv6)
+		}
+
+		subnets = append(subnets, cidr6)
+	}
+
 	// check for overlapping subnets
 	for _, ne := range nets {
 		for _, ci := range ne.IPAM.Config {
@@ -89,8 +102,10 @@ func (p *Provider) Create(ctx context.Context) error {
 				return err
 			}
 
-			if cidr.Contains(cidr2.IP) || cidr2.Contains(cidr.IP) {
-				return fmt.Errorf("unable to create network %s, Network %s already exists with an overlapping subnet %s. Either remove the network '%s' or change the subnet for your network", p.config.Meta.Name, ne.Name, ci.Subnet, ne.Name)
+			for _, sn := range subnets {
+				if sn.Contains(cidr2.IP) || cidr2.Contains(sn.IP) {
+					return fmt.Errorf("unable to create network %s, Network %s already exists with an overlapping subnet %s. Either remove the network '%s' or change the subnet for your network", p.config.Meta.Name, ne.Name, ci.Subnet, ne.Name)
+				}
 			}
 		}
 	}
@@ -126,7 +141,7 @@ func (p *Provider) Destroy(ctx context.Context, force bool) error {
 	}
 
 	if len(ids) == 1 {
-		return p.client.NetworkRemove(context.Background(), p.config.Meta.Name)
+		return p.client.NetworkRemove(context.Background(), p.dockerName())
 	}
 
 	return nil
@@ -134,7 +149,7 @@ func (p *Provider) Destroy(ctx context.Context, force bool) error {
 
 // Lookup the ID for a network
 func (p *Provider) Lookup() ([]string, error) {
-	nets, err := p.getNetworks(p.config.Meta.Name)
+	nets, err := p.getNetworks(p.dockerName())
 
 	if err != nil {
 		return nil, err
@@ -166,33 +181,52 @@ func (p *Provider) Changed() (bool, error) {
 }
 
 func (p *Provider) createWithDriver(driver string) error {
+	ipam := []network.IPAMConfig{
+		{
+			Subnet:  p.config.Subnet,
+			Gateway: p.config.Gateway,
+		},
+	}
+
+	if p.config.EnableIPv6 && p.config.SubnetIPv6 != "" {
+		ipam = append(ipam, network.IPAMConfig{
+			Subnet:  p.config.SubnetIPv6,
+			Gateway: p.config.GatewayIPv6,
+		})
+	}
+
 	opts := network.CreateOptions{
 		// CheckDuplicate: true,
 		Driver:     driver,
 		EnableIPv6: &p.config.EnableIPv6,
 		IPAM: &network.IPAM{
 			Driver: "default",
-			Config: []network.IPAMConfig{
-				{
-					Subnet: p.config.Subnet,
-				},
-			},
-		},
-		Labels: map[string]string{
-			"created_by": "jumppad",
-			"id":         p.config.Meta.ID,
+			Config: ipam,
 		},
+		// the id label is used to find the network
+		Labels:     config.DockerLabels(p.config),
 		Attachable: true,
 	}
 
-	_, err := p.client.NetworkCreate(context.Background(), p.config.Meta.Name, opts)
+	_, err := p.client.NetworkCreate(context.Background(), p.dockerName(), opts)
 
 	return err
 }
 
+// dockerName returns the name of the Docker network, networks are named
+// after the resource and qualified with the environment
+func (p *Provider) dockerName() string {
+	return utils.EnvironmentName(p.config.Meta.Name)
+}
+
 func (p *Provider) getNetworks(name string) ([]network.Summary, error) {
 	args := filters.NewArgs()
-	args.Add("name", name)
+	if name != "" {
+		// the name filter matches partial names, anchor it so that networks
+		// from other environments are not returned
+		args.Add("name", fmt.Sprintf("^%s$", regexp.QuoteMeta(name)))
+	}
+
 	return p.client.NetworkList(context.Background(), network.ListOptions{Filters: args})
 }
 
@@ -0,0 +1,278 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/network"
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &ConditionProvider{}
+
+// defaultInterface is used when no interface or network is specified
+const defaultInterface = "eth0"
+
+var interfaceRegex = regexp.MustCompile(`^[a-zA-Z0-9_.-]+$`)
+
+// ConditionProvider applies network conditions to containers by running
+// tc in a helper container that shares the network namespace of the target
+type ConditionProvider struct {
+	config *NetworkCondition
+	client container.ContainerTasks
+	docker container.Docker
+	log    sdk.Logger
+}
+
+// conditionTarget is a container that the conditions are applied to
+type conditionTarget struct {
+	id      string
+	name    string
+	address string
+}
+
+func (p *ConditionProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*NetworkCondition)
+	if !ok {
+		return fmt.Errorf("unable to initialize NetworkCondition provider, resource is not of type NetworkCondition")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.client = cli.ContainerTasks
+	p.docker = cli.Docker
+	p.log = l
+
+	return nil
+}
+
+// Create applies the network conditions to the targets
+func (p *ConditionProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Applying network conditions", "ref", p.config.Meta.ID, "latency", p.config.Latency, "jitter", p.config.Jitter, "loss", p.config.Loss, "bandwidth", p.config.Bandwidth)
+
+	targets, err := p.findTargets()
+	if err != nil {
+		return err
+	}
+
+	err = p.client.PullImage(p.image(), false)
+	if err != nil {
+		return fmt.Errorf("unable to pull image %s: %w", p.config.Image.Name, err)
+	}
+
+	p.config.Interfaces = map[string]string{}
+
+	for _, t := range targets {
+		iface, err := p.applyConditions(t)
+		if err != nil {
+			return fmt.Errorf("unable to apply network conditions to %s: %w", t.name, err)
+		}
+
+		p.config.Interfaces[t.name] = iface
+	}
+
+	return nil
+}
+
+// Destroy removes the network conditions from the targets
+func (p *ConditionProvider) Destroy(ctx context.Context, force bool) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping destroy, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Removing network conditions", "ref", p.config.Meta.ID)
+
+	for name, iface := range p.config.Interfaces {
+		ids, err := p.client.FindContainerIDs(name)
+		if err != nil || len(ids) == 0 {
+			// the container has been removed so there is nothing to clean up
+			p.log.Debug("Container not found, skipping", "ref", p.config.Meta.ID, "container", name)
+			continue
+		}
+
+		cmd := []string{"tc", "qdisc", "del", "dev", iface, "root"}
+
+		_, err = p.runInHelper(ids[0], cmd)
+		if err != nil {
+			p.log.Warn("Unable to remove network conditions", "ref", p.config.Meta.ID, "container", name, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *ConditionProvider) Lookup() ([]string, error) {
+	return []string{}, nil
+}
+
+func (p *ConditionProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Network Condition", "ref", p.config.Meta.ID)
+
+	return nil
+}
+
+func (p *ConditionProvider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return false, nil
+}
+
+// findTargets returns the containers to apply the conditions to along with
+// their address on the network when a network has been specified
+func (p *ConditionProvider) findTargets() ([]conditionTarget, error) {
+	endpoints := map[string]network.EndpointResource{}
+
+	if p.config.Network != nil {
+		n, err := p.docker.NetworkInspect(context.Background(), utils.EnvironmentName(p.config.Network.Meta.Name), network.InspectOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("unable to find network %s: %w", p.config.Network.Meta.Name, err)
+		}
+
+		endpoints = n.Containers
+	}
+
+	targets := []conditionTarget{}
+
+	// no targets apply the conditions to every container on the network
+	if len(p.config.Targets) == 0 {
+		for id, e := range endpoints {
+			targets = append(targets, conditionTarget{id: id, name: e.Name, address: e.IPv4Address})
+		}
+
+		return targets, nil
+	}
+
+	for _, c := range p.config.Targets {
+		ids, err := p.client.FindContainerIDs(c.ContainerName)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find target %s: %w", c.ContainerName, err)
+		}
+
+		if len(ids) != 1 {
+			return nil, fmt.Errorf("unable to find target %s", c.ContainerName)
+		}
+
+		t := conditionTarget{id: ids[0], name: c.ContainerName}
+
+		if p.config.Network != nil {
+			e, ok := endpoints[ids[0]]
+			if !ok {
+				return nil, fmt.Errorf("target %s is not attached to the network %s", c.ContainerName, p.config.Network.Meta.Name)
+			}
+
+			t.address = e.IPv4Address
+		}
+
+		targets = append(targets, t)
+	}
+
+	return targets, nil
+}
+
+// applyConditions runs tc in the network namespace of the target and
+// returns the interface the conditions were applied to
+func (p *ConditionProvider) applyConditions(t conditionTarget) (string, error) {
+	iface := p.config.Interface
+	if iface == "" {
+		iface = defaultInterface
+	}
+
+	// find the interface for the network using the address of the container
+	if p.config.Interface == "" && t.address != "" {
+		ip, _, _ := strings.Cut(t.address, "/")
+		script := fmt.Sprintf(`ip -o -4 addr show | awk '$4 ~ /^%s\// {print $2}'`, strings.ReplaceAll(ip, ".", `\.`))
+
+		out, err := p.runInHelper(t.id, []string{"sh", "-c", script})
+		if err != nil {
+			return "", fmt.Errorf("unable to find interface for address %s: %w", t.address, err)
+		}
+
+		found, _, _ := strings.Cut(strings.TrimSpace(out), "@")
+		if !interfaceRegex.MatchString(found) {
+			return "", fmt.Errorf("unable to find interface for address %s", t.address)
+		}
+
+		iface = found
+	}
+
+	p.log.Debug("Applying network conditions", "ref", p.config.Meta.ID, "container", t.name, "interface", iface)
+
+	cmd, err := p.netemCommand(iface)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = p.runInHelper(t.id, cmd)
+	if err != nil {
+		return "", err
+	}
+
+	return iface, nil
+}
+
+// netemCommand builds the tc command that applies the conditions, replace
+// is used so that existing conditions are updated
+func (p *ConditionProvider) netemCommand(iface string) ([]string, error) {
+	cmd := []string{"tc", "qdisc", "replace", "dev", iface, "root", "netem"}
+
+	if p.config.Latency != "" {
+		d, err := time.ParseDuration(p.config.Latency)
+		if err != nil {
+			return nil, fmt.Errorf("invalid latency %s: %w", p.config.Latency, err)
+		}
+
+		cmd = append(cmd, "delay", fmt.Sprintf("%dus", d.Microseconds()))
+
+		if p.config.Jitter != "" {
+			j, err := time.ParseDuration(p.config.Jitter)
+			if err != nil {
+				return nil, fmt.Errorf("invalid jitter %s: %w", p.config.Jitter, err)
+			}
+
+			cmd = append(cmd, fmt.Sprintf("%dus", j.Microseconds()))
+		}
+	}
+
+	if p.config.Loss > 0 {
+		cmd = append(cmd, "loss", fmt.Sprintf("%v%%", p.config.Loss))
+	}
+
+	if p.config.Bandwidth != "" {
+		cmd = append(cmd, "rate", p.config.Bandwidth)
+	}
+
+	return cmd, nil
+}
+
+// runInHelper executes the command in the network namespace of the target
+func (p *ConditionProvider) runInHelper(targetID string, command []string) (string, error) {
+	name := utils.FQDN(p.config.Meta.Name, p.config.Meta.Module, p.config.Meta.Type)
+	return execInNetworkNamespace(p.client, name, p.image(), targetID, command)
+}
+
+func (p *ConditionProvider) image() types.Image {
+	return types.Image{Name: p.config.Image.Name, Username: p.config.Image.Username, Password: p.config.Image.Password}
+}
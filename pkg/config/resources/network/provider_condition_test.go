@@ -0,0 +1,124 @@
+package network
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/docker/docker/api/types/network"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupConditionProvider(t *testing.T) (*ConditionProvider, *mocks.ContainerTasks, *mocks.Docker) {
+	testutils.SetupState(t, "")
+
+	c := testNetworkCondition()
+	c.Process()
+
+	mc := &mocks.ContainerTasks{}
+	mc.On("FindContainerIDs", "web.container.local.jmpd.in").Return([]string{"abc"}, nil)
+	mc.On("PullImage", mock.Anything, false).Return(nil)
+	mc.On("CreateContainer", mock.Anything).Return("helper", nil)
+	mc.On("RemoveContainer", "helper", true).Return(nil)
+	mc.On("ExecuteCommand", "helper", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+
+	md := &mocks.Docker{}
+	md.On("NetworkInspect", mock.Anything, "main", mock.Anything).Return(network.Inspect{
+		Containers: map[string]network.EndpointResource{
+			"abc": {Name: "web.container.local.jmpd.in", IPv4Address: "10.5.0.10/16"},
+			"def": {Name: "db.container.local.jmpd.in", IPv4Address: "10.5.0.11/16"},
+		},
+	}, nil)
+
+	p := &ConditionProvider{
+		config: c,
+		client: mc,
+		docker: md,
+		log:    logger.NewTestLogger(t),
+	}
+
+	return p, mc, md
+}
+
+func TestNetworkConditionCreateAppliesNetemInHelperContainer(t *testing.T) {
+	p, mc, _ := setupConditionProvider(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	helper := testutils.GetCalls(&mc.Mock, "CreateContainer")[0].Arguments[0].(*ctypes.Container)
+	require.Equal(t, "abc", helper.Networks[0].ID)
+	require.True(t, helper.Networks[0].IsContainer)
+	require.Contains(t, helper.Capabilities.Add, "NET_ADMIN")
+
+	mc.AssertCalled(t, "ExecuteCommand",
+		"helper",
+		[]string{"tc", "qdisc", "replace", "dev", "eth0", "root", "netem", "delay", "100000us", "10000us", "loss", "1.5%", "rate", "1mbit"},
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	)
+
+	mc.AssertCalled(t, "RemoveContainer", "helper", true)
+	require.Equal(t, "eth0", p.config.Interfaces["web.container.local.jmpd.in"])
+}
+
+func TestNetworkConditionCreateWithNetworkFindsInterface(t *testing.T) {
+	p, mc, _ := setupConditionProvider(t)
+	p.config.Network = &Network{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "main"}}}
+
+	testutils.RemoveOn(&mc.Mock, "ExecuteCommand")
+	mc.On("ExecuteCommand", "helper", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			args.Get(7).(io.Writer).Write([]byte("eth1\n"))
+		}).
+		Return(0, nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "eth1", p.config.Interfaces["web.container.local.jmpd.in"])
+	require.Len(t, p.config.Interfaces, 1)
+}
+
+func TestNetworkConditionCreateWithNetworkAndNoTargetsAppliesToAllContainers(t *testing.T) {
+	p, _, _ := setupConditionProvider(t)
+	p.config.Targets = nil
+	p.config.Interface = "eth0"
+	p.config.Network = &Network{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "main"}}}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, p.config.Interfaces, 2)
+	require.Equal(t, "eth0", p.config.Interfaces["db.container.local.jmpd.in"])
+}
+
+func TestNetworkConditionCreateWithTargetNotOnNetworkReturnsError(t *testing.T) {
+	p, mc, _ := setupConditionProvider(t)
+	p.config.Network = &Network{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "main"}}}
+
+	testutils.RemoveOn(&mc.Mock, "FindContainerIDs")
+	mc.On("FindContainerIDs", mock.Anything).Return([]string{"xyz"}, nil)
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "not attached")
+}
+
+func TestNetworkConditionDestroyRemovesQdisc(t *testing.T) {
+	p, mc, _ := setupConditionProvider(t)
+	p.config.Interfaces = map[string]string{"web.container.local.jmpd.in": "eth1"}
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	mc.AssertCalled(t, "ExecuteCommand",
+		"helper",
+		[]string{"tc", "qdisc", "del", "dev", "eth1", "root"},
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+	)
+}
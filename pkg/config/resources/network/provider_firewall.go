@@ -0,0 +1,292 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &FirewallProvider{}
+
+// establishedRule allows replies to connections initiated by the target so
+// that deny rules only affect new inbound connections
+const establishedRule = "INPUT -m conntrack --ctstate ESTABLISHED,RELATED -j ACCEPT"
+
+// FirewallProvider applies firewall rules to containers by running iptables
+// in a helper container that shares the network namespace of the target
+type FirewallProvider struct {
+	config *FirewallRule
+	client container.ContainerTasks
+	log    sdk.Logger
+}
+
+func (p *FirewallProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*FirewallRule)
+	if !ok {
+		return fmt.Errorf("unable to initialize FirewallRule provider, resource is not of type FirewallRule")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.client = cli.ContainerTasks
+	p.log = l
+
+	return nil
+}
+
+// Create applies the firewall rules to the target
+func (p *FirewallProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Creating Firewall Rule", "ref", p.config.Meta.ID, "target", p.config.Target.ContainerName, "action", p.config.Action)
+
+	return p.apply()
+}
+
+// Destroy removes the firewall rules from the target
+func (p *FirewallProvider) Destroy(ctx context.Context, force bool) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping destroy, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Destroy Firewall Rule", "ref", p.config.Meta.ID)
+
+	if len(p.config.Rules) == 0 {
+		return nil
+	}
+
+	id, err := p.findTarget()
+	if err != nil {
+		// the container has been removed so there is nothing to clean up
+		p.log.Debug("Target not found, skipping", "ref", p.config.Meta.ID, "error", err)
+		return nil
+	}
+
+	cmds := []string{}
+	for _, r := range p.config.Rules {
+		command, spec := splitRule(r)
+		cmds = append(cmds, fmt.Sprintf("(%s -D %s || true)", command, spec))
+	}
+
+	_, err = p.runInHelper(id, []string{"sh", "-c", strings.Join(cmds, "; ")})
+	if err != nil {
+		p.log.Warn("Unable to remove firewall rules", "ref", p.config.Meta.ID, "error", err)
+	}
+
+	return nil
+}
+
+func (p *FirewallProvider) Lookup() ([]string, error) {
+	return []string{}, nil
+}
+
+func (p *FirewallProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Firewall Rule", "ref", p.config.Meta.ID)
+
+	// rules are lost when the target container is restarted, applying the
+	// rules is idempotent so they are always applied again
+	return p.apply()
+}
+
+func (p *FirewallProvider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return false, nil
+}
+
+// apply adds the firewall rules to the target, existing rules are removed
+// first so that applying the rules is idempotent
+func (p *FirewallProvider) apply() error {
+	id, err := p.findTarget()
+	if err != nil {
+		return err
+	}
+
+	sources, err := p.sourceAddresses()
+	if err != nil {
+		return err
+	}
+
+	rules := p.buildRules(sources)
+
+	// allow rules are inserted at the top of the chain and deny rules appended
+	// so that allow rules always take precedence
+	cmds := []string{}
+	if p.config.Action == FirewallActionDeny {
+		for _, command := range ruleCommands(rules) {
+			cmds = append(cmds, fmt.Sprintf("(%[1]s -C %[2]s 2>/dev/null || %[1]s -I %[2]s)", command, establishedRule))
+		}
+	}
+
+	for _, r := range rules {
+		op := "-A"
+		if p.config.Action == FirewallActionAllow {
+			op = "-I"
+		}
+
+		command, spec := splitRule(r)
+		cmds = append(cmds, fmt.Sprintf("(%s -D %s 2>/dev/null || true)", command, spec), fmt.Sprintf("%s %s %s", command, op, spec))
+	}
+
+	err = p.client.PullImage(p.image(), false)
+	if err != nil {
+		return fmt.Errorf("unable to pull image %s: %w", p.config.Image.Name, err)
+	}
+
+	_, err = p.runInHelper(id, []string{"sh", "-c", strings.Join(cmds, " && ")})
+	if err != nil {
+		return fmt.Errorf("unable to apply firewall rules to %s: %w", p.config.Target.ContainerName, err)
+	}
+
+	p.config.Rules = rules
+
+	return nil
+}
+
+func (p *FirewallProvider) findTarget() (string, error) {
+	ids, err := p.client.FindContainerIDs(p.config.Target.ContainerName)
+	if err != nil {
+		return "", fmt.Errorf("unable to find target %s: %w", p.config.Target.ContainerName, err)
+	}
+
+	if len(ids) != 1 {
+		return "", fmt.Errorf("unable to find target %s", p.config.Target.ContainerName)
+	}
+
+	return ids[0], nil
+}
+
+// sourceAddresses returns the addresses or address ranges for all the sources
+func (p *FirewallProvider) sourceAddresses() ([]string, error) {
+	sources := []string{}
+
+	for _, c := range p.config.Sources {
+		found := false
+		for _, n := range c.Networks {
+			if n.AssignedAddress != "" {
+				prefix := "/32"
+				if isIPv6(n.AssignedAddress) {
+					prefix = "/128"
+				}
+
+				sources = append(sources, n.AssignedAddress+prefix)
+				found = true
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("source %s does not have an assigned ip address", c.Meta.ID)
+		}
+	}
+
+	for _, n := range p.config.SourceNetworks {
+		sources = append(sources, n.Subnet)
+
+		if n.SubnetIPv6 != "" {
+			sources = append(sources, n.SubnetIPv6)
+		}
+	}
+
+	sources = append(sources, p.config.SourceCIDRs...)
+
+	return sources, nil
+}
+
+// buildRules returns the rule specifications for the sources prefixed with
+// the command that manages them, ip6tables for IPv6 sources and iptables for
+// IPv4. Each rule is tagged with the resource id so it can be identified in
+// the chain
+func (p *FirewallProvider) buildRules(sources []string) []string {
+	target := "DROP"
+	if p.config.Action == FirewallActionAllow {
+		target = "ACCEPT"
+	}
+
+	rules := []string{}
+
+	for _, s := range sources {
+		command := "iptables"
+		if isIPv6(s) {
+			command = "ip6tables"
+		}
+
+		base := fmt.Sprintf("%s INPUT -s %s -p %s", command, s, p.config.Protocol)
+
+		if len(p.config.Ports) == 0 {
+			rules = append(rules, fmt.Sprintf("%s -m comment --comment %s -j %s", base, p.config.Meta.ID, target))
+			continue
+		}
+
+		for _, port := range p.config.Ports {
+			rules = append(rules, fmt.Sprintf("%s --dport %d -m comment --comment %s -j %s", base, port, p.config.Meta.ID, target))
+		}
+	}
+
+	return rules
+}
+
+// splitRule returns the command and the specification for a rule, rules
+// created before IPv6 support do not have a command and use iptables
+func splitRule(rule string) (string, string) {
+	command, spec, _ := strings.Cut(rule, " ")
+	if command != "iptables" && command != "ip6tables" {
+		return "iptables", rule
+	}
+
+	return command, spec
+}
+
+// ruleCommands returns the distinct commands used by the rules
+func ruleCommands(rules []string) []string {
+	commands := []string{}
+	for _, r := range rules {
+		command, _ := splitRule(r)
+		if !slices.Contains(commands, command) {
+			commands = append(commands, command)
+		}
+	}
+
+	return commands
+}
+
+// isIPv6 returns true when the address or CIDR is an IPv6 address
+func isIPv6(address string) bool {
+	ip, _, err := net.ParseCIDR(address)
+	if err != nil {
+		ip = net.ParseIP(address)
+	}
+
+	return ip != nil && ip.To4() == nil
+}
+
+// runInHelper executes the command in the network namespace of the target
+func (p *FirewallProvider) runInHelper(targetID string, command []string) (string, error) {
+	name := utils.FQDN(p.config.Meta.Name, p.config.Meta.Module, p.config.Meta.Type)
+	return execInNetworkNamespace(p.client, name, p.image(), targetID, command)
+}
+
+func (p *FirewallProvider) image() types.Image {
+	return types.Image{Name: p.config.Image.Name, Username: p.config.Image.Username, Password: p.config.Image.Password}
+}
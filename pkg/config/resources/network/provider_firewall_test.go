@@ -0,0 +1,136 @@
+package network
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFirewallProvider(t *testing.T) (*FirewallProvider, *mocks.ContainerTasks) {
+	testutils.SetupState(t, "")
+
+	f := testFirewallRule()
+	f.Ports = []int{5432}
+	f.SourceNetworks = []Network{{ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "main"}}, Subnet: "10.6.0.0/16"}}
+	f.Process()
+
+	mc := &mocks.ContainerTasks{}
+	mc.On("FindContainerIDs", "db.container.local.jmpd.in").Return([]string{"abc"}, nil)
+	mc.On("PullImage", mock.Anything, false).Return(nil)
+	mc.On("CreateContainer", mock.Anything).Return("helper", nil)
+	mc.On("RemoveContainer", "helper", true).Return(nil)
+	mc.On("ExecuteCommand", "helper", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+
+	p := &FirewallProvider{
+		config: f,
+		client: mc,
+		log:    logger.NewTestLogger(t),
+	}
+
+	return p, mc
+}
+
+func TestFirewallRuleCreateAppendsDenyRules(t *testing.T) {
+	p, mc := setupFirewallProvider(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"iptables INPUT -s 10.5.0.10/32 -p tcp --dport 5432 -m comment --comment resource.firewall_rule.test -j DROP",
+		"iptables INPUT -s 10.6.0.0/16 -p tcp --dport 5432 -m comment --comment resource.firewall_rule.test -j DROP",
+	}, p.config.Rules)
+
+	cmd := testutils.GetCalls(&mc.Mock, "ExecuteCommand")[0].Arguments[1].([]string)
+	require.Contains(t, cmd[2], establishedRule)
+	require.Contains(t, cmd[2], "iptables -A INPUT -s 10.5.0.10/32")
+}
+
+func TestFirewallRuleCreateWithIPv6SourceUsesIP6Tables(t *testing.T) {
+	p, mc := setupFirewallProvider(t)
+	p.config.SourceNetworks[0].SubnetIPv6 = "fd00:6::/64"
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Contains(t, p.config.Rules, "ip6tables INPUT -s fd00:6::/64 -p tcp --dport 5432 -m comment --comment resource.firewall_rule.test -j DROP")
+
+	cmd := testutils.GetCalls(&mc.Mock, "ExecuteCommand")[0].Arguments[1].([]string)
+	require.Contains(t, cmd[2], "ip6tables -C "+establishedRule)
+	require.Contains(t, cmd[2], "ip6tables -A INPUT -s fd00:6::/64")
+	require.Contains(t, cmd[2], "iptables -A INPUT -s 10.6.0.0/16")
+}
+
+func TestFirewallRuleRefreshReappliesRules(t *testing.T) {
+	p, mc := setupFirewallProvider(t)
+
+	err := p.Refresh(context.Background())
+	require.NoError(t, err)
+
+	cmd := testutils.GetCalls(&mc.Mock, "ExecuteCommand")[0].Arguments[1].([]string)
+	require.Contains(t, cmd[2], "iptables -A INPUT -s 10.5.0.10/32")
+	require.Len(t, p.config.Rules, 2)
+}
+
+func TestFirewallRuleCreateInsertsAllowRules(t *testing.T) {
+	p, mc := setupFirewallProvider(t)
+	p.config.Action = FirewallActionAllow
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	cmd := testutils.GetCalls(&mc.Mock, "ExecuteCommand")[0].Arguments[1].([]string)
+	require.NotContains(t, cmd[2], establishedRule)
+	require.Contains(t, cmd[2], "iptables -I INPUT -s 10.5.0.10/32 -p tcp --dport 5432 -m comment --comment resource.firewall_rule.test -j ACCEPT")
+}
+
+func TestFirewallRuleCreateWithSourceWithoutAddressReturnsError(t *testing.T) {
+	p, mc := setupFirewallProvider(t)
+	p.config.Sources[0].Networks[0].AssignedAddress = ""
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+
+	mc.AssertNotCalled(t, "CreateContainer", mock.Anything)
+}
+
+func TestFirewallRuleDestroyRemovesRules(t *testing.T) {
+	p, mc := setupFirewallProvider(t)
+	p.config.Rules = []string{"INPUT -s 10.5.0.10/32 -p all -m comment --comment resource.firewall_rule.test -j DROP"}
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	cmd := testutils.GetCalls(&mc.Mock, "ExecuteCommand")[0].Arguments[1].([]string)
+	require.Equal(t, "(iptables -D INPUT -s 10.5.0.10/32 -p all -m comment --comment resource.firewall_rule.test -j DROP || true)", cmd[2])
+}
+
+func TestFirewallRuleDestroyRemovesIPv6Rules(t *testing.T) {
+	p, mc := setupFirewallProvider(t)
+	p.config.Rules = []string{"ip6tables INPUT -s fd00:6::/64 -p all -m comment --comment resource.firewall_rule.test -j DROP"}
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	cmd := testutils.GetCalls(&mc.Mock, "ExecuteCommand")[0].Arguments[1].([]string)
+	require.Equal(t, "(ip6tables -D INPUT -s fd00:6::/64 -p all -m comment --comment resource.firewall_rule.test -j DROP || true)", cmd[2])
+}
+
+func TestFirewallRuleDestroyWithMissingTargetDoesNothing(t *testing.T) {
+	p, mc := setupFirewallProvider(t)
+	p.config.Rules = []string{"INPUT -s 10.5.0.10/32 -p all -j DROP"}
+
+	testutils.RemoveOn(&mc.Mock, "FindContainerIDs")
+	mc.On("FindContainerIDs", mock.Anything).Return([]string{}, nil)
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	mc.AssertNotCalled(t, "CreateContainer", mock.Anything)
+}
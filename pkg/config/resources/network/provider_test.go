@@ -9,6 +9,7 @@ import (
 	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/jumppad-labs/jumppad/testutils"
 	"github.com/stretchr/testify/mock"
 	assert "github.com/stretchr/testify/require"
@@ -95,6 +96,60 @@ func TestNetworkCreatesCorrectly(t *testing.T) {
 	assert.Equal(t, c.Subnet, nco.IPAM.Config[0].Subnet)
 }
 
+func TestNetworkCreatesWithEnvironmentName(t *testing.T) {
+	t.Setenv(utils.EnvironmentEnvVar, "staging")
+
+	c := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "testnetwork"}},
+	}
+	c.Subnet = "10.1.2.0/24"
+
+	md, p := setupNetworkTests(t, c)
+
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	params := md.Calls[1].Arguments
+	nco := params[2].(network.CreateOptions)
+
+	assert.Equal(t, "testnetwork.staging", params[1])
+	assert.Equal(t, "staging", nco.Labels[utils.EnvironmentLabel])
+}
+
+func TestLookupFiltersByExactName(t *testing.T) {
+	t.Setenv(utils.EnvironmentEnvVar, "staging")
+
+	c := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "testnetwork"}},
+	}
+
+	md, p := setupNetworkTests(t, c)
+
+	_, err := p.Lookup()
+	assert.NoError(t, err)
+
+	args := testutils.GetCalls(&md.Mock, "NetworkList")[0].Arguments[1].(network.ListOptions)
+	assert.Equal(t, `^testnetwork\.staging$`, args.Filters.Get("name")[0])
+}
+
+func TestNetworkCreatesWithLabels(t *testing.T) {
+	c := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.network.testnetwork", Name: "testnetwork"}},
+	}
+	c.Subnet = "10.1.2.0/24"
+	c.Labels = map[string]string{"team": "platform", "id": "other"}
+
+	md, p := setupNetworkTests(t, c)
+
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	nco := md.Calls[1].Arguments[2].(network.CreateOptions)
+
+	assert.Equal(t, "platform", nco.Labels["team"])
+	assert.Equal(t, "resource.network.testnetwork", nco.Labels["id"])
+}
+
 func TestNetworkCreatesNatWhenNoBridge(t *testing.T) {
 	c := &Network{
 		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "testnetwork"}},
@@ -181,3 +236,52 @@ func TestCreateWithOverlappingSubnetReturnsError(t *testing.T) {
 	err := p.Create(context.Background())
 	assert.Error(t, err)
 }
+
+func TestNetworkCreatesWithGatewayAndIPv6Subnet(t *testing.T) {
+	c := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "testnetwork"}},
+	}
+	c.Subnet = "10.1.2.0/24"
+	c.Gateway = "10.1.2.254"
+	c.EnableIPv6 = true
+	c.SubnetIPv6 = "fd00:1:2::/64"
+	c.GatewayIPv6 = "fd00:1:2::1"
+
+	md, p := setupNetworkTests(t, c)
+
+	err := p.Create(context.Background())
+	assert.NoError(t, err)
+
+	params := md.Calls[1].Arguments
+	nco := params[2].(network.CreateOptions)
+
+	assert.True(t, *nco.EnableIPv6)
+	assert.Len(t, nco.IPAM.Config, 2)
+	assert.Equal(t, c.Gateway, nco.IPAM.Config[0].Gateway)
+	assert.Equal(t, c.SubnetIPv6, nco.IPAM.Config[1].Subnet)
+	assert.Equal(t, c.GatewayIPv6, nco.IPAM.Config[1].Gateway)
+}
+
+func TestCreateWithOverlappingIPv6SubnetReturnsError(t *testing.T) {
+	c := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "testnetwork"}},
+	}
+	c.Subnet = "10.1.2.0/24"
+	c.EnableIPv6 = true
+	c.SubnetIPv6 = "fd00:1:2::/64"
+
+	md, p := setupNetworkTests(t, c)
+	testutils.RemoveOn(&md.Mock, "NetworkList")
+	md.On("NetworkList", mock.Anything, mock.Anything).Return([]network.Summary{
+		{
+			ID:   "abc",
+			Name: "abc",
+			IPAM: network.IPAM{
+				Config: []network.IPAMConfig{{Subnet: "10.8.0.0/24"}, {Subnet: "fd00:1:2::/48"}},
+			},
+		}, bridgeNetwork,
+	}, nil)
+
+	err := p.Create(context.Background())
+	assert.Error(t, err)
+}
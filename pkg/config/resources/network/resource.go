@@ -1,7 +1,11 @@
 package network
 
 import (
+	"fmt"
+	"net"
+
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 )
 
 // TypeNetwork is the string resource type for Network resources
@@ -12,6 +16,66 @@ type Network struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Subnet     string `hcl:"subnet" json:"subnet"`
 	EnableIPv6 bool   `hcl:"enable_ipv6,optional" json:"enable_ipv6"`
+
+	// Gateway for the IPv4 subnet, when not set Docker uses the first
+	// address in the subnet
+	Gateway string `hcl:"gateway,optional" json:"gateway,omitempty"`
+
+	// SubnetIPv6 is the IPv6 subnet for the network, requires enable_ipv6
+	SubnetIPv6 string `hcl:"subnet_ipv6,optional" json:"subnet_ipv6,omitempty"`
+
+	// GatewayIPv6 is the gateway for the IPv6 subnet
+	GatewayIPv6 string `hcl:"gateway_ipv6,optional" json:"gateway_ipv6,omitempty"`
+
+	// Labels to set on the Docker network
+	Labels map[string]string `hcl:"labels,optional" json:"labels,omitempty"`
+}
+
+// GetLabels returns the labels for the network
+func (n *Network) GetLabels() map[string]string {
+	return n.Labels
+}
+
+func (n *Network) Process() error {
+	_, cidr, err := net.ParseCIDR(n.Subnet)
+	if err != nil || cidr.IP.To4() == nil {
+		return fmt.Errorf("invalid subnet %s, subnet must be a valid IPv4 CIDR", n.Subnet)
+	}
+
+	if n.Gateway != "" {
+		err := utils.ValidateSubnetIP(n.Gateway, n.Subnet)
+		if err != nil {
+			return fmt.Errorf("invalid gateway: %s", err)
+		}
+	}
+
+	if n.SubnetIPv6 == "" {
+		if n.GatewayIPv6 != "" {
+			return fmt.Errorf("gateway_ipv6 can only be set when subnet_ipv6 is specified")
+		}
+
+		return nil
+	}
+
+	if !n.EnableIPv6 {
+		return fmt.Errorf("subnet_ipv6 can only be set when enable_ipv6 is true")
+	}
+
+	_, cidr, err = net.ParseCIDR(n.SubnetIPv6)
+	if err != nil || cidr.IP.To4() != nil {
+		return fmt.Errorf("invalid subnet_ipv6 %s, subnet must be a valid IPv6 CIDR", n.SubnetIPv6)
+	}
+
+	if n.GatewayIPv6 != "" {
+		err := utils.ValidateSubnetIP(n.GatewayIPv6, n.SubnetIPv6)
+		if err != nil {
+			return fmt.Errorf("invalid gateway_ipv6: %s", err)
+		}
+	}
+
+	return nil
 }
@@ -0,0 +1,103 @@
+package network
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+)
+
+// TypeNetworkCondition is the string resource type for NetworkCondition resources
+const TypeNetworkCondition string = "network_condition"
+
+var bandwidthRegex = regexp.MustCompile(`^[0-9]+(bit|kbit|mbit|gbit|tbit|bps|kbps|mbps|gbps|tbps)$`)
+
+// NetworkCondition applies latency, packet loss, and bandwidth shaping
+// to the network interfaces of containers using tc netem
+type NetworkCondition struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Targets are the containers to apply the conditions to, when not set
+	// the conditions are applied to all containers attached to Network
+	Targets []ctypes.Container `hcl:"targets,optional" json:"targets,omitempty"`
+
+	// Network restricts the conditions to the interface attached to this
+	// network
+	Network *Network `hcl:"network,optional" json:"network,omitempty"`
+
+	// Interface is the name of the interface to apply the conditions to,
+	// when not set the interface for Network is used, or eth0
+	Interface string `hcl:"interface,optional" json:"interface,omitempty"`
+
+	Latency   string  `hcl:"latency,optional" json:"latency,omitempty"`     // delay added to outbound packets e.g. 100ms
+	Jitter    string  `hcl:"jitter,optional" json:"jitter,omitempty"`       // random variation of the latency e.g. 10ms
+	Loss      float64 `hcl:"loss,optional" json:"loss,omitempty"`           // percentage of packets to drop
+	Bandwidth string  `hcl:"bandwidth,optional" json:"bandwidth,omitempty"` // maximum rate for outbound traffic e.g. 1mbit
+
+	// Image used to apply the conditions, must contain the tc command
+	Image *ctypes.Image `hcl:"image,block" json:"image,omitempty"`
+
+	// output
+
+	// Interfaces is a map of container name to the interface that the
+	// conditions have been applied to
+	Interfaces map[string]string `hcl:"interfaces,optional" json:"interfaces,omitempty"`
+}
+
+func (n *NetworkCondition) Process() error {
+	if len(n.Targets) == 0 && n.Network == nil {
+		return fmt.Errorf("at least one target or a network must be specified")
+	}
+
+	if n.Latency == "" && n.Loss == 0 && n.Bandwidth == "" {
+		return fmt.Errorf("at least one of latency, loss, or bandwidth must be specified")
+	}
+
+	if n.Latency != "" {
+		if _, err := time.ParseDuration(n.Latency); err != nil {
+			return fmt.Errorf("invalid latency %s: %s", n.Latency, err)
+		}
+	}
+
+	if n.Jitter != "" {
+		if n.Latency == "" {
+			return fmt.Errorf("jitter can only be specified with latency")
+		}
+
+		if _, err := time.ParseDuration(n.Jitter); err != nil {
+			return fmt.Errorf("invalid jitter %s: %s", n.Jitter, err)
+		}
+	}
+
+	if n.Loss < 0 || n.Loss > 100 {
+		return fmt.Errorf("invalid loss %v, loss must be a percentage between 0 and 100", n.Loss)
+	}
+
+	if n.Bandwidth != "" && !bandwidthRegex.MatchString(n.Bandwidth) {
+		return fmt.Errorf("invalid bandwidth %s, bandwidth must be a number followed by a unit e.g. 1mbit", n.Bandwidth)
+	}
+
+	if n.Image == nil {
+		n.Image = &ctypes.Image{Name: defaultHelperImage}
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(n.Meta.ID)
+		if r != nil {
+			kstate := r.(*NetworkCondition)
+			n.Interfaces = kstate.Interfaces
+		}
+	}
+
+	return nil
+}
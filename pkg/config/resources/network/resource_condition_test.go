@@ -0,0 +1,112 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	config.RegisterResource(TypeNetworkCondition, &NetworkCondition{}, &ConditionProvider{})
+}
+
+func testNetworkCondition() *NetworkCondition {
+	return &NetworkCondition{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.network_condition.test", Name: "test"}},
+		Targets:      []ctypes.Container{{ContainerName: "web.container.local.jmpd.in"}},
+		Latency:      "100ms",
+		Jitter:       "10ms",
+		Loss:         1.5,
+		Bandwidth:    "1mbit",
+	}
+}
+
+func TestNetworkConditionProcessSetsDefaultImage(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	n := testNetworkCondition()
+
+	err := n.Process()
+	require.NoError(t, err)
+	require.Equal(t, defaultHelperImage, n.Image.Name)
+}
+
+func TestNetworkConditionProcessWithNoTargetsOrNetworkReturnsError(t *testing.T) {
+	n := testNetworkCondition()
+	n.Targets = nil
+
+	err := n.Process()
+	require.ErrorContains(t, err, "target")
+}
+
+func TestNetworkConditionProcessWithNoConditionsReturnsError(t *testing.T) {
+	n := testNetworkCondition()
+	n.Latency = ""
+	n.Jitter = ""
+	n.Loss = 0
+	n.Bandwidth = ""
+
+	err := n.Process()
+	require.Error(t, err)
+}
+
+func TestNetworkConditionProcessWithInvalidLatencyReturnsError(t *testing.T) {
+	n := testNetworkCondition()
+	n.Latency = "100"
+
+	err := n.Process()
+	require.ErrorContains(t, err, "latency")
+}
+
+func TestNetworkConditionProcessWithJitterAndNoLatencyReturnsError(t *testing.T) {
+	n := testNetworkCondition()
+	n.Latency = ""
+
+	err := n.Process()
+	require.ErrorContains(t, err, "jitter")
+}
+
+func TestNetworkConditionProcessWithInvalidLossReturnsError(t *testing.T) {
+	n := testNetworkCondition()
+	n.Loss = 101
+
+	err := n.Process()
+	require.ErrorContains(t, err, "loss")
+}
+
+func TestNetworkConditionProcessWithInvalidBandwidthReturnsError(t *testing.T) {
+	n := testNetworkCondition()
+	n.Bandwidth = "fast"
+
+	err := n.Process()
+	require.ErrorContains(t, err, "bandwidth")
+}
+
+func TestNetworkConditionSetsOutputsFromState(t *testing.T) {
+	testutils.SetupState(t, `
+{
+  "blueprint": null,
+  "resources": [
+	{
+		"meta": {
+			"id": "resource.network_condition.test",
+			"name": "test",
+			"type": "network_condition"
+		},
+		"interfaces": {
+			"web.container.local.jmpd.in": "eth1"
+		}
+	}
+  ]
+}`)
+
+	n := testNetworkCondition()
+
+	err := n.Process()
+	require.NoError(t, err)
+	require.Equal(t, "eth1", n.Interfaces["web.container.local.jmpd.in"])
+}
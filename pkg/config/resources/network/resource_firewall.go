@@ -0,0 +1,123 @@
+package network
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+)
+
+// TypeFirewallRule is the string resource type for FirewallRule resources
+const TypeFirewallRule string = "firewall_rule"
+
+const (
+	FirewallActionAllow = "allow"
+	FirewallActionDeny  = "deny"
+
+	FirewallProtocolTCP = "tcp"
+	FirewallProtocolUDP = "udp"
+	FirewallProtocolAll = "all"
+)
+
+// FirewallRule controls the inbound traffic to a container from other
+// containers, networks, or CIDR ranges. Rules are applied using iptables and
+// ip6tables in the network namespace of the target container, allow rules
+// always take precedence over deny rules.
+type FirewallRule struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Target is the container that the rule controls inbound traffic for
+	Target ctypes.Container `hcl:"target" json:"target"`
+
+	// Action to take for matching traffic, either allow or deny, defaults to deny
+	Action string `hcl:"action,optional" json:"action,omitempty"`
+
+	Sources        []ctypes.Container `hcl:"sources,optional" json:"sources,omitempty"`                 // containers the traffic originates from
+	SourceNetworks []Network          `hcl:"source_networks,optional" json:"source_networks,omitempty"` // networks the traffic originates from
+	SourceCIDRs    []string           `hcl:"source_cidrs,optional" json:"source_cidrs,omitempty"`       // address ranges the traffic originates from
+
+	// Ports restricts the rule to the given destination ports, when not set
+	// the rule applies to all ports
+	Ports []int `hcl:"ports,optional" json:"ports,omitempty"`
+
+	// Protocol for the rule, either tcp, udp, or all, defaults to all
+	// or tcp when ports are specified
+	Protocol string `hcl:"protocol,optional" json:"protocol,omitempty"`
+
+	// Image used to apply the rules, must contain the iptables and ip6tables
+	// commands
+	Image *ctypes.Image `hcl:"image,block" json:"image,omitempty"`
+
+	// output
+
+	// Rules are the rule specifications that have been applied to the
+	// target, prefixed with either iptables or ip6tables
+	Rules []string `hcl:"rules,optional" json:"rules,omitempty"`
+}
+
+func (f *FirewallRule) Process() error {
+	if f.Action == "" {
+		f.Action = FirewallActionDeny
+	}
+
+	if f.Action != FirewallActionAllow && f.Action != FirewallActionDeny {
+		return fmt.Errorf("invalid action %s, action must be either %s or %s", f.Action, FirewallActionAllow, FirewallActionDeny)
+	}
+
+	if len(f.Sources) == 0 && len(f.SourceNetworks) == 0 && len(f.SourceCIDRs) == 0 {
+		return fmt.Errorf("at least one of sources, source_networks, or source_cidrs must be specified")
+	}
+
+	for _, c := range f.SourceCIDRs {
+		if _, _, err := net.ParseCIDR(c); err != nil {
+			return fmt.Errorf("invalid source_cidr %s: %s", c, err)
+		}
+	}
+
+	if f.Protocol == "" {
+		f.Protocol = FirewallProtocolAll
+
+		if len(f.Ports) > 0 {
+			f.Protocol = FirewallProtocolTCP
+		}
+	}
+
+	switch f.Protocol {
+	case FirewallProtocolTCP, FirewallProtocolUDP:
+	case FirewallProtocolAll:
+		if len(f.Ports) > 0 {
+			return fmt.Errorf("protocol must be either %s or %s when ports are specified", FirewallProtocolTCP, FirewallProtocolUDP)
+		}
+	default:
+		return fmt.Errorf("invalid protocol %s, protocol must be one of %s, %s, or %s", f.Protocol, FirewallProtocolTCP, FirewallProtocolUDP, FirewallProtocolAll)
+	}
+
+	for _, p := range f.Ports {
+		if p < 1 || p > 65535 {
+			return fmt.Errorf("invalid port %d, ports must be between 1 and 65535", p)
+		}
+	}
+
+	if f.Image == nil {
+		f.Image = &ctypes.Image{Name: defaultHelperImage}
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(f.Meta.ID)
+		if r != nil {
+			kstate := r.(*FirewallRule)
+			f.Rules = kstate.Rules
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func testFirewallRule() *FirewallRule {
+	return &FirewallRule{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.firewall_rule.test", Name: "test"}},
+		Target:       ctypes.Container{ContainerName: "db.container.local.jmpd.in"},
+		Sources: []ctypes.Container{
+			{
+				ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.container.web"}},
+				Networks:     []ctypes.NetworkAttachment{{AssignedAddress: "10.5.0.10"}},
+			},
+		},
+	}
+}
+
+func TestFirewallRuleProcessSetsDefaults(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	f := testFirewallRule()
+
+	err := f.Process()
+	require.NoError(t, err)
+	require.Equal(t, FirewallActionDeny, f.Action)
+	require.Equal(t, FirewallProtocolAll, f.Protocol)
+	require.Equal(t, defaultHelperImage, f.Image.Name)
+}
+
+func TestFirewallRuleProcessWithPortsDefaultsToTCP(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	f := testFirewallRule()
+	f.Ports = []int{5432}
+
+	err := f.Process()
+	require.NoError(t, err)
+	require.Equal(t, FirewallProtocolTCP, f.Protocol)
+}
+
+func TestFirewallRuleProcessWithInvalidActionReturnsError(t *testing.T) {
+	f := testFirewallRule()
+	f.Action = "reject"
+
+	err := f.Process()
+	require.ErrorContains(t, err, "action")
+}
+
+func TestFirewallRuleProcessWithNoSourcesReturnsError(t *testing.T) {
+	f := testFirewallRule()
+	f.Sources = nil
+
+	err := f.Process()
+	require.ErrorContains(t, err, "sources")
+}
+
+func TestFirewallRuleProcessWithInvalidCIDRReturnsError(t *testing.T) {
+	f := testFirewallRule()
+	f.SourceCIDRs = []string{"10.5.0.1"}
+
+	err := f.Process()
+	require.ErrorContains(t, err, "source_cidr")
+}
+
+func TestFirewallRuleProcessWithPortsAndAllProtocolReturnsError(t *testing.T) {
+	f := testFirewallRule()
+	f.Ports = []int{5432}
+	f.Protocol = FirewallProtocolAll
+
+	err := f.Process()
+	require.ErrorContains(t, err, "protocol")
+}
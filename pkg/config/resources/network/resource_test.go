@@ -0,0 +1,66 @@
+package network
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNetworkProcessWithValidConfigReturnsNoError(t *testing.T) {
+	n := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "test"}},
+		Subnet:       "10.5.0.0/16",
+		Gateway:      "10.5.0.254",
+		EnableIPv6:   true,
+		SubnetIPv6:   "fd00:5::/64",
+		GatewayIPv6:  "fd00:5::1",
+	}
+
+	err := n.Process()
+	require.NoError(t, err)
+}
+
+func TestNetworkProcessWithInvalidSubnetReturnsError(t *testing.T) {
+	n := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "test"}},
+		Subnet:       "10.5.0.0",
+	}
+
+	err := n.Process()
+	require.Error(t, err)
+}
+
+func TestNetworkProcessWithGatewayOutsideSubnetReturnsError(t *testing.T) {
+	n := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "test"}},
+		Subnet:       "10.5.0.0/16",
+		Gateway:      "10.6.0.1",
+	}
+
+	err := n.Process()
+	require.ErrorContains(t, err, "not in the subnet")
+}
+
+func TestNetworkProcessWithIPv6SubnetAndIPv6DisabledReturnsError(t *testing.T) {
+	n := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "test"}},
+		Subnet:       "10.5.0.0/16",
+		SubnetIPv6:   "fd00:5::/64",
+	}
+
+	err := n.Process()
+	require.ErrorContains(t, err, "enable_ipv6")
+}
+
+func TestNetworkProcessWithIPv4SubnetForIPv6ReturnsError(t *testing.T) {
+	n := &Network{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{Name: "test"}},
+		Subnet:       "10.5.0.0/16",
+		EnableIPv6:   true,
+		SubnetIPv6:   "10.6.0.0/16",
+	}
+
+	err := n.Process()
+	require.Error(t, err)
+}
@@ -0,0 +1,152 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &AllocationProvider{}
+
+// pollInterval is the time between queries for allocations and services that
+// are not running yet
+var pollInterval = 2 * time.Second
+
+// AllocationProvider reads the running allocations for a Nomad task
+type AllocationProvider struct {
+	config *NomadAllocation
+	client nomad.Nomad
+	log    sdk.Logger
+}
+
+func (p *AllocationProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	c, ok := cfg.(*NomadAllocation)
+	if !ok {
+		return fmt.Errorf("unable to initialize NomadAllocation provider, resource is not of type NomadAllocation")
+	}
+
+	p.config = c
+	p.client = cli.Nomad
+	p.log = l
+
+	return nil
+}
+
+// Create reads the allocations, waiting until at least one is running
+func (p *AllocationProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Reading Nomad allocations", "ref", p.config.Meta.ID, "job", p.config.Job, "group", p.config.Group, "task", p.config.Task)
+
+	return p.read(ctx)
+}
+
+// Destroy does nothing as the job is not managed by the resource
+func (p *AllocationProvider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *AllocationProvider) Lookup() ([]string, error) {
+	return []string{}, nil
+}
+
+// Refresh reads the allocations again as the ports change when the job is
+// rescheduled
+func (p *AllocationProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Nomad allocations", "ref", p.config.Meta.ID)
+
+	return p.read(ctx)
+}
+
+func (p *AllocationProvider) Changed() (bool, error) {
+	return false, nil
+}
+
+func (p *AllocationProvider) read(ctx context.Context) error {
+	nomadCluster := p.config.Cluster
+
+	// load the config
+	p.client.SetConfig(fmt.Sprintf("http://%s", nomadCluster.ExternalIP), nomadCluster.APIPort, nomadCluster.ClientNodes)
+
+	var endpoints []map[string]string
+	err := waitFor(ctx, p.config.Timeout, func() error {
+		var err error
+		endpoints, err = p.client.Endpoints(p.config.Job, p.config.Group, p.config.Task)
+		if err == nil && len(endpoints) == 0 {
+			err = fmt.Errorf("no running allocations for task %s in job %s", p.config.Task, p.config.Job)
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	p.config.Endpoints = endpoints
+	p.config.IP = ""
+	p.config.Ports = map[string]int{}
+
+	for label, addr := range endpoints[0] {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return fmt.Errorf("invalid address %s for port %s: %w", addr, label, err)
+		}
+
+		pn, err := strconv.Atoi(port)
+		if err != nil {
+			return fmt.Errorf("invalid address %s for port %s: %w", addr, label, err)
+		}
+
+		p.config.IP = host
+		p.config.Ports[label] = pn
+	}
+
+	return nil
+}
+
+// waitFor calls f until it does not return an error or the timeout elapses
+func waitFor(ctx context.Context, timeout string, f func() error) error {
+	to, err := time.ParseDuration(timeout)
+	if err != nil {
+		return fmt.Errorf("unable to parse timeout: %w", err)
+	}
+
+	st := time.Now()
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+
+		remaining := to - time.Since(st)
+		if remaining <= 0 {
+			return fmt.Errorf("timeout after %s: %w", timeout, err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(min(pollInterval, remaining)):
+		}
+	}
+}
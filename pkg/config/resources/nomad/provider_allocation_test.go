@@ -0,0 +1,89 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad/mocks"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAllocationProvider(t *testing.T) (*AllocationProvider, *mocks.Nomad) {
+	nm := &mocks.Nomad{}
+	nm.On("SetConfig", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	nm.On("Endpoints", "example_1", "fake_service", "fake_service").Return([]map[string]string{
+		{"http": "10.5.0.2:28862"},
+		{"http": "10.5.0.3:19090"},
+	}, nil)
+
+	c := &NomadAllocation{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.nomad_allocation.test"}}}
+	c.Cluster = NomadCluster{ExternalIP: "10.5.0.1", APIPort: 4646, ClientNodes: 1}
+	c.Job = "example_1"
+	c.Group = "fake_service"
+	c.Task = "fake_service"
+	c.Timeout = "1ms"
+
+	return &AllocationProvider{c, nm, logger.NewTestLogger(t)}, nm
+}
+
+func TestAllocationSetsOutputs(t *testing.T) {
+	p, nm := setupAllocationProvider(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	nm.AssertCalled(t, "SetConfig", "http://10.5.0.1", 4646, 1)
+
+	require.Len(t, p.config.Endpoints, 2)
+	require.Equal(t, "10.5.0.2", p.config.IP)
+	require.Equal(t, map[string]int{"http": 28862}, p.config.Ports)
+}
+
+func TestAllocationWithoutRunningAllocationsReturnsError(t *testing.T) {
+	p, nm := setupAllocationProvider(t)
+
+	testutils.RemoveOn(&nm.Mock, "Endpoints")
+	nm.On("Endpoints", mock.Anything, mock.Anything, mock.Anything).Return([]map[string]string{}, nil)
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
+
+func TestServiceSetsOutputs(t *testing.T) {
+	nm := &mocks.Nomad{}
+	nm.On("SetConfig", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	nm.On("ServiceAddresses", "api").Return([]string{"10.5.0.2:28862"}, nil)
+
+	c := &NomadService{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.nomad_service.api"}}}
+	c.Name = "api"
+	c.Timeout = "1ms"
+
+	p := &ServiceProvider{c, nm, logger.NewTestLogger(t)}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"10.5.0.2:28862"}, c.Addresses)
+	require.Equal(t, "10.5.0.2", c.IP)
+	require.Equal(t, 28862, c.Port)
+}
+
+func TestServiceQueryErrorReturnsError(t *testing.T) {
+	nm := &mocks.Nomad{}
+	nm.On("SetConfig", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	nm.On("ServiceAddresses", "api").Return(nil, fmt.Errorf("boom"))
+
+	c := &NomadService{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.nomad_service.api"}}}
+	c.Name = "api"
+	c.Timeout = "1ms"
+
+	p := &ServiceProvider{c, nm, logger.NewTestLogger(t)}
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
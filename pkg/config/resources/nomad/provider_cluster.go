@@ -10,6 +10,9 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -17,11 +20,15 @@ import (
 	"github.com/google/uuid"
 	htypes "github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/command"
 	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
 	cclients "github.com/jumppad-labs/jumppad/pkg/clients/container"
 	ctypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logcollector"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/clients/tracing"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
@@ -35,6 +42,7 @@ type ClusterProvider struct {
 	nomadClient nomad.Nomad
 	connector   connector.Connector
 	log         logger.Logger
+	command     command.Command
 }
 
 var startTimeout = (300 * time.Second)
@@ -55,6 +63,7 @@ func (p *ClusterProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
 	p.nomadClient = cli.Nomad
 	p.connector = cli.Connector
 	p.log = l
+	p.command = cli.Command
 
 	return nil
 }
@@ -267,6 +276,8 @@ func (p *ClusterProvider) ImportLocalDockerImages(images []ctypes.Image, force b
 		return err
 	}
 
+	// the archives are copied to the shared images volume once, each node
+	// loads the archives from the volume in parallel
 	clWait := sync.WaitGroup{}
 	clWait.Add(len(ids))
 
@@ -276,7 +287,7 @@ func (p *ClusterProvider) ImportLocalDockerImages(images []ctypes.Image, force b
 			// write any command output to the logger
 			for _, i := range images {
 				p.log.Debug("Importing docker images", "ref", p.config.Meta.ID, "id", id, "image", i)
-				_, err = p.client.ExecuteCommand(id, []string{"docker", "load", "-i", i}, nil, "/", "", "", 300, p.log.StandardWriter())
+				_, err := p.client.ExecuteCommand(id, []string{"docker", "load", "-i", i}, nil, "/", "", "", 300, p.log.StandardWriter())
 				if err != nil {
 					p.log.Error("Unable to import docker images", "error", err)
 				}
@@ -343,8 +354,17 @@ func (p *ClusterProvider) createNomad(ctx context.Context) error {
 		return fmt.Errorf("unable to lookup cluster id: %w", err)
 	}
 
+	// nomad and the docker engine inside the nodes need to manage cgroups
+	err = p.client.EngineInfo().CheckClusterSupport()
+	if err != nil {
+		return fmt.Errorf("unable to create Nomad cluster %s: %s", p.config.Meta.ID, err)
+	}
+
 	// pull the container image
-	err = p.client.PullImage(p.config.Image.ToClientImage(), false)
+	img := p.config.Image.ToClientImage()
+	_, span := tracing.Start(ctx, "image.pull", tracing.AttributeImage.String(img.Name))
+	err = p.client.PullImage(img, false)
+	tracing.End(span, err)
 	if err != nil {
 		return err
 	}
@@ -431,6 +451,16 @@ func (p *ClusterProvider) createNomad(ctx context.Context) error {
 
 	// ensure all client nodes are up
 	p.nomadClient.SetConfig(fmt.Sprintf("http://%s", p.config.ExternalIP), p.config.APIPort, clientNodes)
+
+	// start collecting logs before the health check so that the logs of
+	// allocations which fail to start are available
+	if p.config.CollectLogs {
+		err = p.startLogCollector()
+		if err != nil {
+			return err
+		}
+	}
+
 	err = p.nomadClient.HealthCheckAPI(ctx, startTimeout)
 	if err != nil {
 		return err
@@ -464,7 +494,7 @@ func (p *ClusterProvider) createServerNode(img ctypes.Image, volumeID string, is
 	}
 
 	// generate the server config
-	sc := dataDir + "\n" + fmt.Sprintf(serverConfig, p.config.Datacenter, cpu)
+	sc := dataDir + "\n" + fmt.Sprintf(serverConfig, p.config.Datacenter, nodeMeta(p.config.Labels, map[string]string{"node_type": "server"}), cpu)
 
 	// write the nomad config to a file
 	os.MkdirAll(p.config.ConfigDir, os.ModePerm)
@@ -484,6 +514,15 @@ func (p *ClusterProvider) createServerNode(img ctypes.Image, volumeID string, is
 	cc.Networks = p.config.Networks.ToClientNetworkAttachments()
 	cc.Privileged = true // nomad must run Privileged as Docker needs to manipulate ip tables and stuff
 
+	// the cluster name is added as an alias so that it resolves inside
+	// other containers using the same name as the connector DNS server
+	clusterName := utils.FQDN(p.config.Meta.Name, p.config.Meta.Module, p.config.Meta.Type)
+	for i := range cc.Networks {
+		cc.Networks[i].Aliases = append(slices.Clone(cc.Networks[i].Aliases), clusterName)
+	}
+	cc.Labels = config.DockerLabels(p.config)
+	cc.Resources = p.config.Resources.ToClientResources()
+
 	// Add Consul DNS
 	//cc.DNS = []string{"127.0.0.1"}
 
@@ -544,6 +583,17 @@ func (p *ClusterProvider) createServerNode(img ctypes.Image, volumeID string, is
 		cc.Volumes = append(cc.Volumes, v.ToClientVolume())
 	}
 
+	// trust the custom CA bundle so that images can be pulled from
+	// registries that use the CA of a corporate proxy
+	if ca := utils.CABundle(); ca != "" {
+		cc.Volumes = append(cc.Volumes, ctypes.Volume{
+			Source:      ca,
+			Destination: utils.CABundleContainerPath,
+			Type:        "bind",
+			ReadOnly:    true,
+		})
+	}
+
 	// expose the API server port
 	cc.Ports = []ctypes.Port{
 		{
@@ -590,7 +640,7 @@ func (p *ClusterProvider) createClientNode(id string, image, volumeID, serverID
 	cpu := fmt.Sprintf("cpu_total_compute = %d", info.CPU*1000)
 
 	// generate the client config
-	sc := dataDir + "\n" + fmt.Sprintf(clientConfig, p.config.Datacenter, serverID, cpu)
+	sc := dataDir + "\n" + fmt.Sprintf(clientConfig, p.config.Datacenter, serverID, nodeMeta(p.config.Labels, nil), cpu)
 
 	// write the default config to a file
 	clientConfigPath := path.Join(p.config.ConfigDir, "client_config.hcl")
@@ -607,6 +657,8 @@ func (p *ClusterProvider) createClientNode(id string, image, volumeID, serverID
 	cc.Image = &ctypes.Image{Name: image}
 	cc.Networks = p.config.Networks.ToClientNetworkAttachments()
 	cc.Privileged = true // nomad must run Privileged as Docker needs to manipulate ip tables and stuff
+	cc.Labels = config.DockerLabels(p.config)
+	cc.Resources = p.config.Resources.ToClientResources()
 
 	//cc.DNS = []string{"127.0.0.1"}
 
@@ -654,6 +706,17 @@ func (p *ClusterProvider) createClientNode(id string, image, volumeID, serverID
 	// if there are any custom volumes to mount
 	cc.Volumes = append(cc.Volumes, p.config.Volumes.ToClientVolumes()...)
 
+	// trust the custom CA bundle so that images can be pulled from
+	// registries that use the CA of a corporate proxy
+	if ca := utils.CABundle(); ca != "" {
+		cc.Volumes = append(cc.Volumes, ctypes.Volume{
+			Source:      ca,
+			Destination: utils.CABundleContainerPath,
+			Type:        "bind",
+			ReadOnly:    true,
+		})
+	}
+
 	cc.Environment = p.config.Environment
 	if cc.Environment == nil {
 		cc.Environment = map[string]string{}
@@ -680,6 +743,7 @@ func (p *ClusterProvider) createClientNode(id string, image, volumeID, serverID
 type dockerConfig struct {
 	Proxies            dockerProxies `json:"proxies,omitempty"`
 	InsecureRegistries []string      `json:"insecure-registries,omitempty"`
+	RegistryMirrors    []string      `json:"registry-mirrors,omitempty"`
 }
 
 type dockerProxies struct {
@@ -710,11 +774,23 @@ func (p *ClusterProvider) createDockerConfig() (string, error) {
 		dc.InsecureRegistries = p.config.Config.DockerConfig.InsecureRegistries
 	}
 
-	// set the no proxy
+	// pull images from Docker Hub using the mirrors
+	if mirrors := utils.RegistryMirrors(); len(mirrors) > 0 {
+		dc.RegistryMirrors = mirrors
+	}
+
+	// set the no proxy, hosts that are not proxied on the host are also
+	// pulled directly
+	noProxy := []string{}
 	if p.config.Config != nil &&
-		p.config.Config.DockerConfig != nil &&
-		len(p.config.Config.DockerConfig.NoProxy) > 0 {
-		dc.Proxies.NOPROXY = strings.TrimSuffix(strings.Join(p.config.Config.DockerConfig.NoProxy, ","), ",")
+		p.config.Config.DockerConfig != nil {
+		noProxy = append(noProxy, p.config.Config.DockerConfig.NoProxy...)
+	}
+
+	noProxy = append(noProxy, utils.NoProxy()...)
+
+	if len(noProxy) > 0 {
+		dc.Proxies.NOPROXY = strings.TrimSuffix(strings.Join(noProxy, ","), ",")
 	}
 
 	// set the cache details
@@ -804,7 +880,7 @@ func (p *ClusterProvider) deployConnector() error {
 	os.WriteFile(connectorDeployment, []byte(config), os.ModePerm)
 
 	// deploy the file
-	err = p.nomadClient.Create([]string{connectorDeployment})
+	err = p.nomadClient.Create([]string{connectorDeployment}, p.config.Labels)
 	if err != nil {
 		return fmt.Errorf("unable to run Connector deployment: %s", err)
 	}
@@ -845,6 +921,15 @@ func (p *ClusterProvider) deployConnector() error {
 func (p *ClusterProvider) destroyNomad(force bool) error {
 	p.log.Info("Destroy Nomad Cluster", "ref", p.config.Meta.ID)
 
+	if p.config.LogCollectorPID > 0 {
+		p.log.Debug("Stopping log collector", "ref", p.config.Meta.ID, "pid", p.config.LogCollectorPID)
+
+		err := logcollector.Stop(p.command, p.config.Meta.Name, p.config.LogCollectorPID)
+		if err != nil {
+			p.log.Warn("Unable to stop log collector", "ref", p.config.Meta.ID, "error", err)
+		}
+	}
+
 	// destroy the clients
 	wg := sync.WaitGroup{}
 	wg.Add(len(p.config.ClientContainerName))
@@ -874,6 +959,28 @@ func (p *ClusterProvider) destroyNomad(force bool) error {
 	return nil
 }
 
+// startLogCollector starts a background process that writes the logs of all
+// the running allocations in the cluster to the logs directory
+func (p *ClusterProvider) startLogCollector() error {
+	p.config.LogsDirectory = logcollector.Directory(p.config.Meta.Name)
+
+	p.log.Debug("Starting log collector", "ref", p.config.Meta.ID, "directory", p.config.LogsDirectory)
+
+	pid, err := logcollector.Start(p.command, p.config.Meta.Name, &logcollector.Config{
+		Type:         logcollector.TypeNomad,
+		Directory:    p.config.LogsDirectory,
+		NomadAddress: fmt.Sprintf("http://%s", p.config.ExternalIP),
+		NomadPort:    p.config.APIPort,
+	})
+	if err != nil {
+		return err
+	}
+
+	p.config.LogCollectorPID = pid
+
+	return nil
+}
+
 func (p *ClusterProvider) destroyNode(id string, force bool) error {
 	// FindContainerIDs works on absolute addresses, we need to append the server
 	ids, _ := p.client.FindContainerIDs(id)
@@ -1082,6 +1189,33 @@ job "connector" {
 }
 `
 
+// nodeMeta returns the meta block for the Nomad client config containing the
+// labels and the meta, an empty string is returned when there is no meta
+func nodeMeta(labels, meta map[string]string) string {
+	m := config.MergeLabels(labels, meta)
+	if len(m) == 0 {
+		return ""
+	}
+
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	sb := strings.Builder{}
+	sb.WriteString("meta {\n")
+
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("\t\t%s = %s\n", strconv.Quote(k), strconv.Quote(m[k])))
+	}
+
+	sb.WriteString("\t}")
+
+	return sb.String()
+}
+
 const dataDir = `
 data_dir = "/var/lib/nomad"
 `
@@ -1096,9 +1230,7 @@ server {
 
 client {
 	enabled = true
-	meta {
-		node_type = "server"
-	}
+	%s
 	%s
 }
 
@@ -1107,6 +1239,12 @@ plugin "raw_exec" {
 		enabled = true
   }
 }
+
+telemetry {
+  publish_allocation_metrics = true
+  publish_node_metrics       = true
+  prometheus_metrics         = true
+}
 `
 
 const clientConfig = `
@@ -1120,6 +1258,7 @@ client {
 	}
 
 	%s
+	%s
 }
 
 plugin "raw_exec" {
@@ -1127,4 +1266,10 @@ plugin "raw_exec" {
 		enabled = true
   }
 }
+
+telemetry {
+  publish_allocation_metrics = true
+  publish_node_metrics       = true
+  prometheus_metrics         = true
+}
 `
@@ -8,6 +8,7 @@ import (
 
 	htypes "github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/metrics"
 	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
@@ -55,7 +56,7 @@ func (p *JobProvider) Create(ctx context.Context) error {
 	// load the config
 	p.client.SetConfig(fmt.Sprintf("http://%s", nomadCluster.ExternalIP), nomadCluster.APIPort, nomadCluster.ClientNodes)
 
-	err := p.client.Create(p.config.Paths)
+	err := p.client.Create(p.config.Paths, p.config.Labels)
 	if err != nil {
 		return fmt.Errorf("unable to create Nomad jobs: %w", err)
 	}
@@ -68,28 +69,11 @@ func (p *JobProvider) Create(ctx context.Context) error {
 			return err
 		}
 
-		for _, j := range p.config.HealthCheck.Jobs {
-			for {
-				if ctx.Err() != nil {
-					return fmt.Errorf("context cancelled, unable to wait for job health")
-				}
-
-				if time.Since(st) >= dur {
-					return fmt.Errorf("timeout waiting for job '%s' to start", j)
-				}
-
-				p.log.Debug("Checking health for", "ref", p.config.Meta.ID, "job", j)
-
-				s, err := p.client.JobRunning(j)
-				if err == nil && s {
-					p.log.Debug("Health passed for", "ref", p.config.Meta.ID, "job", j)
-					break
-				}
-
-				time.Sleep(1 * time.Second)
-			}
+		err = p.waitForJobs(ctx, st, dur)
+		metrics.Default.ObserveHealthCheck(p.config.Meta.ID, metrics.HealthCheckJobs, time.Since(st), err)
+		if err != nil {
+			return err
 		}
-
 	}
 
 	// set the checksums
@@ -103,6 +87,34 @@ func (p *JobProvider) Create(ctx context.Context) error {
 	return nil
 }
 
+// waitForJobs waits until all the jobs in the health check are running or
+// the timeout since st elapses
+func (p *JobProvider) waitForJobs(ctx context.Context, st time.Time, dur time.Duration) error {
+	for _, j := range p.config.HealthCheck.Jobs {
+		for {
+			if ctx.Err() != nil {
+				return fmt.Errorf("context cancelled, unable to wait for job health")
+			}
+
+			if time.Since(st) >= dur {
+				return fmt.Errorf("timeout waiting for job '%s' to start", j)
+			}
+
+			p.log.Debug("Checking health for", "ref", p.config.Meta.ID, "job", j)
+
+			s, err := p.client.JobRunning(j)
+			if err == nil && s {
+				p.log.Debug("Health passed for", "ref", p.config.Meta.ID, "job", j)
+				break
+			}
+
+			time.Sleep(1 * time.Second)
+		}
+	}
+
+	return nil
+}
+
 // Destroy the Nomad jobs defined by the config
 func (p *JobProvider) Destroy(ctx context.Context, force bool) error {
 	if ctx.Err() != nil {
@@ -0,0 +1,115 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &ServiceProvider{}
+
+// ServiceProvider reads a service from the Nomad service discovery
+type ServiceProvider struct {
+	config *NomadService
+	client nomad.Nomad
+	log    sdk.Logger
+}
+
+func (p *ServiceProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	c, ok := cfg.(*NomadService)
+	if !ok {
+		return fmt.Errorf("unable to initialize NomadService provider, resource is not of type NomadService")
+	}
+
+	p.config = c
+	p.client = cli.Nomad
+	p.log = l
+
+	return nil
+}
+
+// Create reads the service, waiting until it is registered
+func (p *ServiceProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Reading Nomad service", "ref", p.config.Meta.ID, "service", p.config.Name)
+
+	return p.read(ctx)
+}
+
+// Destroy does nothing as the service is not managed by the resource
+func (p *ServiceProvider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *ServiceProvider) Lookup() ([]string, error) {
+	return []string{}, nil
+}
+
+// Refresh reads the service again as the addresses change when the job is
+// rescheduled
+func (p *ServiceProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Nomad service", "ref", p.config.Meta.ID)
+
+	return p.read(ctx)
+}
+
+func (p *ServiceProvider) Changed() (bool, error) {
+	return false, nil
+}
+
+func (p *ServiceProvider) read(ctx context.Context) error {
+	nomadCluster := p.config.Cluster
+
+	// load the config
+	p.client.SetConfig(fmt.Sprintf("http://%s", nomadCluster.ExternalIP), nomadCluster.APIPort, nomadCluster.ClientNodes)
+
+	var addresses []string
+	err := waitFor(ctx, p.config.Timeout, func() error {
+		var err error
+		addresses, err = p.client.ServiceAddresses(p.config.Name)
+		if err == nil && len(addresses) == 0 {
+			err = fmt.Errorf("service %s is not registered", p.config.Name)
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	host, port, err := net.SplitHostPort(addresses[0])
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %w", addresses[0], err)
+	}
+
+	pn, err := strconv.Atoi(port)
+	if err != nil {
+		return fmt.Errorf("invalid address %s: %w", addresses[0], err)
+	}
+
+	p.config.Addresses = addresses
+	p.config.IP = host
+	p.config.Port = pn
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package nomad
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	dcontainer "github.com/docker/docker/api/types/container"
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &VolumeProvider{}
+
+// nomadConfigDir is the folder in the nodes the Nomad agent loads its config
+// from
+const nomadConfigDir = "/etc/nomad.d"
+
+// VolumeProvider adds host volumes to the nodes of a Nomad cluster and
+// registers CSI volumes
+type VolumeProvider struct {
+	config *NomadVolume
+	client nomad.Nomad
+	tasks  container.ContainerTasks
+	docker container.Docker
+	log    sdk.Logger
+}
+
+func (p *VolumeProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	c, ok := cfg.(*NomadVolume)
+	if !ok {
+		return fmt.Errorf("unable to initialize NomadVolume provider, resource is not of type NomadVolume")
+	}
+
+	p.config = c
+	p.client = cli.Nomad
+	p.tasks = cli.ContainerTasks
+	p.docker = cli.Docker
+	p.log = l
+
+	return nil
+}
+
+// Create adds the host volume to the nodes or registers the CSI volume
+func (p *VolumeProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Create Nomad Volume", "ref", p.config.Meta.ID, "type", p.config.Type, "name", p.config.Name)
+
+	nomadCluster := p.config.Cluster
+
+	// load the config
+	p.client.SetConfig(fmt.Sprintf("http://%s", nomadCluster.ExternalIP), nomadCluster.APIPort, len(p.nodes()))
+
+	if p.config.Type == VolumeTypeCSI {
+		return p.createCSIVolume(ctx)
+	}
+
+	return p.createHostVolume(ctx)
+}
+
+// Destroy removes the host volume from the nodes or deregisters the CSI
+// volume, CSI volumes created by the plugin are deleted
+func (p *VolumeProvider) Destroy(ctx context.Context, force bool) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping destroy, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Destroy Nomad Volume", "ref", p.config.Meta.ID, "type", p.config.Type, "name", p.config.Name)
+
+	nomadCluster := p.config.Cluster
+
+	// load the config
+	p.client.SetConfig(fmt.Sprintf("http://%s", nomadCluster.ExternalIP), nomadCluster.APIPort, len(p.nodes()))
+
+	if p.config.Type == VolumeTypeCSI {
+		err := p.client.DeregisterCSIVolume(p.config.Name, p.config.ExternalID == "")
+		if err != nil {
+			p.log.Error("Unable to deregister Nomad volume", "ref", p.config.Meta.ID, "error", err)
+		}
+
+		return nil
+	}
+
+	// the data in the volume is not removed as the path may be mounted from
+	// the host
+	for _, n := range p.nodes() {
+		_, err := p.tasks.ExecuteCommand(n, []string{"rm", "-f", p.hostVolumeConfigPath()}, nil, "", "", "", 30, nil)
+		if err != nil {
+			p.log.Warn("Unable to remove host volume config", "ref", p.config.Meta.ID, "node", n, "error", err)
+			continue
+		}
+
+		err = p.restartNode(n)
+		if err != nil {
+			p.log.Warn("Unable to restart node", "ref", p.config.Meta.ID, "node", n, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func (p *VolumeProvider) Lookup() ([]string, error) {
+	return []string{}, nil
+}
+
+func (p *VolumeProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Nomad Volume", "ref", p.config.Meta.ID)
+
+	return nil
+}
+
+func (p *VolumeProvider) Changed() (bool, error) {
+	return false, nil
+}
+
+// createHostVolume writes the host volume config to every node and restarts
+// the nodes, Nomad only loads host volumes when the agent starts
+func (p *VolumeProvider) createHostVolume(ctx context.Context) error {
+	nodes := p.nodes()
+	conf := p.hostVolumeConfig()
+
+	for _, n := range nodes {
+		p.log.Debug("Adding host volume to node", "ref", p.config.Meta.ID, "node", n, "path", p.config.Path)
+
+		_, err := p.tasks.ExecuteCommand(n, []string{"mkdir", "-p", p.config.Path}, nil, "", "", "", 30, nil)
+		if err != nil {
+			return fmt.Errorf("unable to create path %s for host volume on node %s: %w", p.config.Path, n, err)
+		}
+
+		err = p.tasks.CreateFileInContainer(n, conf, p.hostVolumeConfigFile(), nomadConfigDir)
+		if err != nil {
+			return fmt.Errorf("unable to add host volume config to node %s: %w", n, err)
+		}
+
+		err = p.restartNode(n)
+		if err != nil {
+			return fmt.Errorf("unable to restart node %s: %w", n, err)
+		}
+	}
+
+	// wait for all the nodes to fingerprint the volume
+	err := waitFor(ctx, p.config.Timeout, func() error {
+		count, err := p.client.HostVolumeNodes(p.config.Name)
+		if err == nil && count < len(nodes) {
+			err = fmt.Errorf("host volume %s loaded by %d of %d nodes", p.config.Name, count, len(nodes))
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// the drivers are restarted with the nodes
+	to, _ := time.ParseDuration(p.config.Timeout)
+
+	return p.client.HealthCheckAPI(ctx, to)
+}
+
+// createCSIVolume waits for the plugin to be healthy and registers the volume
+func (p *VolumeProvider) createCSIVolume(ctx context.Context) error {
+	err := waitFor(ctx, p.config.Timeout, func() error {
+		ok, err := p.client.CSIPluginHealthy(p.config.PluginID)
+		if err == nil && !ok {
+			err = fmt.Errorf("csi plugin %s is not healthy", p.config.PluginID)
+		}
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	// validated by Process
+	capMin, _ := capacityBytes(p.config.CapacityMin)
+	capMax, _ := capacityBytes(p.config.CapacityMax)
+
+	err = p.client.RegisterCSIVolume(nomad.CSIVolume{
+		ID:         p.config.Name,
+		Name:       p.config.Name,
+		PluginID:   p.config.PluginID,
+		ExternalID: p.config.ExternalID,
+		RequestedCapabilities: []nomad.CSIVolumeCapability{
+			{AccessMode: p.config.AccessMode, AttachmentMode: p.config.AttachmentMode},
+		},
+		RequestedCapacityMin: capMin,
+		RequestedCapacityMax: capMax,
+		Parameters:           p.config.Parameters,
+		Secrets:              p.config.Secrets,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to register Nomad volume: %w", err)
+	}
+
+	return nil
+}
+
+// nodes returns the containers for the Nomad clients, the server is the only
+// client when the cluster does not have client nodes
+func (p *VolumeProvider) nodes() []string {
+	if p.config.Cluster.ClientNodes > 0 {
+		return p.config.Cluster.ClientContainerName
+	}
+
+	return []string{p.config.Cluster.ServerContainerName}
+}
+
+func (p *VolumeProvider) restartNode(n string) error {
+	err := p.docker.ContainerStop(context.Background(), n, dcontainer.StopOptions{})
+	if err != nil {
+		return err
+	}
+
+	return p.docker.ContainerStart(context.Background(), n, dcontainer.StartOptions{})
+}
+
+func (p *VolumeProvider) hostVolumeConfigFile() string {
+	return fmt.Sprintf("jumppad_volume_%s.hcl", p.config.Name)
+}
+
+func (p *VolumeProvider) hostVolumeConfigPath() string {
+	return fmt.Sprintf("%s/%s", nomadConfigDir, p.hostVolumeConfigFile())
+}
+
+func (p *VolumeProvider) hostVolumeConfig() string {
+	return fmt.Sprintf(hostVolumeConfig, p.config.Name, strconv.Quote(p.config.Path), p.config.ReadOnly)
+}
+
+const hostVolumeConfig = `
+client {
+  host_volume "%s" {
+    path      = %s
+    read_only = %t
+  }
+}
+`
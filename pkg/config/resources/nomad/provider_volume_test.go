@@ -0,0 +1,141 @@
+package nomad
+
+import (
+	"context"
+	"testing"
+
+	cmocks "github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad"
+	"github.com/jumppad-labs/jumppad/pkg/clients/nomad/mocks"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupVolumeProvider(t *testing.T) (*VolumeProvider, *mocks.Nomad, *cmocks.ContainerTasks, *cmocks.Docker) {
+	nm := &mocks.Nomad{}
+	nm.On("SetConfig", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	nm.On("HostVolumeNodes", "postgres").Return(2, nil)
+	nm.On("HealthCheckAPI", mock.Anything, mock.Anything).Return(nil)
+	nm.On("CSIPluginHealthy", "hostpath").Return(true, nil)
+	nm.On("RegisterCSIVolume", mock.Anything).Return(nil)
+	nm.On("DeregisterCSIVolume", mock.Anything, mock.Anything).Return(nil)
+
+	ct := &cmocks.ContainerTasks{}
+	ct.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+	ct.On("CreateFileInContainer", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	dc := &cmocks.Docker{}
+	dc.On("ContainerStop", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	dc.On("ContainerStart", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	v := testVolume()
+	v.Type = VolumeTypeHost
+	v.Name = "postgres"
+	v.Path = "/opt/jumppad/volumes/postgres"
+	v.Cluster = NomadCluster{
+		ExternalIP:          "10.5.0.1",
+		APIPort:             4646,
+		ClientNodes:         2,
+		ServerContainerName: "server.dev.nomad-cluster.local.jmpd.in",
+		ClientContainerName: []string{"1.client.dev.nomad-cluster.local.jmpd.in", "2.client.dev.nomad-cluster.local.jmpd.in"},
+	}
+	v.Timeout = "1ms"
+
+	return &VolumeProvider{v, nm, ct, dc, logger.NewTestLogger(t)}, nm, ct, dc
+}
+
+func TestVolumeCreateAddsHostVolumeToClients(t *testing.T) {
+	p, nm, ct, dc := setupVolumeProvider(t)
+	p.config.Path = "/opt/postgres"
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	nm.AssertCalled(t, "SetConfig", "http://10.5.0.1", 4646, 2)
+
+	for _, n := range p.config.Cluster.ClientContainerName {
+		ct.AssertCalled(t, "ExecuteCommand", n, []string{"mkdir", "-p", "/opt/postgres"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		ct.AssertCalled(t, "CreateFileInContainer", n, mock.Anything, "jumppad_volume_postgres.hcl", "/etc/nomad.d")
+		dc.AssertCalled(t, "ContainerStart", mock.Anything, n, mock.Anything)
+	}
+
+	conf := testutils.GetCalls(&ct.Mock, "CreateFileInContainer")[0].Arguments[1].(string)
+	require.Contains(t, conf, `host_volume "postgres"`)
+	require.Contains(t, conf, `path      = "/opt/postgres"`)
+}
+
+func TestVolumeCreateAddsHostVolumeToServerWithoutClients(t *testing.T) {
+	p, _, ct, _ := setupVolumeProvider(t)
+	p.config.Cluster.ClientNodes = 0
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	ct.AssertCalled(t, "CreateFileInContainer", "server.dev.nomad-cluster.local.jmpd.in", mock.Anything, mock.Anything, mock.Anything)
+	ct.AssertNumberOfCalls(t, "CreateFileInContainer", 1)
+}
+
+func TestVolumeCreateHostVolumeNotLoadedReturnsError(t *testing.T) {
+	p, nm, _, _ := setupVolumeProvider(t)
+
+	testutils.RemoveOn(&nm.Mock, "HostVolumeNodes")
+	nm.On("HostVolumeNodes", "postgres").Return(1, nil)
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "loaded by 1 of 2 nodes")
+}
+
+func TestVolumeCreateRegistersCSIVolume(t *testing.T) {
+	p, nm, _, _ := setupVolumeProvider(t)
+	p.config.Type = VolumeTypeCSI
+	p.config.PluginID = "hostpath"
+	p.config.AccessMode = "single-node-writer"
+	p.config.AttachmentMode = "file-system"
+	p.config.CapacityMin = "1GiB"
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	v := testutils.GetCalls(&nm.Mock, "RegisterCSIVolume")[0].Arguments[0].(nomad.CSIVolume)
+	require.Equal(t, "postgres", v.ID)
+	require.Equal(t, "hostpath", v.PluginID)
+	require.Equal(t, int64(1024*1024*1024), v.RequestedCapacityMin)
+	require.Equal(t, "single-node-writer", v.RequestedCapabilities[0].AccessMode)
+}
+
+func TestVolumeCreateCSIPluginNotHealthyReturnsError(t *testing.T) {
+	p, nm, _, _ := setupVolumeProvider(t)
+	p.config.Type = VolumeTypeCSI
+	p.config.PluginID = "hostpath"
+
+	testutils.RemoveOn(&nm.Mock, "CSIPluginHealthy")
+	nm.On("CSIPluginHealthy", "hostpath").Return(false, nil)
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "csi plugin hostpath is not healthy")
+
+	nm.AssertNotCalled(t, "RegisterCSIVolume", mock.Anything)
+}
+
+func TestVolumeDestroyDeletesCreatedCSIVolume(t *testing.T) {
+	p, nm, _, _ := setupVolumeProvider(t)
+	p.config.Type = VolumeTypeCSI
+	p.config.PluginID = "hostpath"
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	nm.AssertCalled(t, "DeregisterCSIVolume", "postgres", true)
+}
+
+func TestVolumeDestroyRemovesHostVolumeConfig(t *testing.T) {
+	p, _, ct, dc := setupVolumeProvider(t)
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	ct.AssertCalled(t, "ExecuteCommand", "1.client.dev.nomad-cluster.local.jmpd.in", []string{"rm", "-f", "/etc/nomad.d/jumppad_volume_postgres.hcl"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	dc.AssertNumberOfCalls(t, "ContainerStart", 2)
+}
@@ -0,0 +1,67 @@
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+)
+
+// TypeNomadAllocation defines the string type for the Nomad allocation resource
+const TypeNomadAllocation string = "nomad_allocation"
+
+// NomadAllocation reads the addresses of the running allocations for a task,
+// the job is not created or modified
+type NomadAllocation struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Cluster is the cluster the job is running on
+	Cluster NomadCluster `hcl:"cluster" json:"cluster"`
+
+	Job   string `hcl:"job" json:"job"`
+	Group string `hcl:"group" json:"group"`
+	Task  string `hcl:"task" json:"task"`
+
+	// Timeout to wait for a running allocation, defaults to 60s
+	Timeout string `hcl:"timeout,optional" json:"timeout,omitempty"`
+
+	// output
+
+	// Endpoints contains the ports of each running allocation, the port label
+	// is mapped to the address in the form ip:port
+	Endpoints []map[string]string `hcl:"endpoints,optional" json:"endpoints,omitempty"`
+
+	// IP is the address of the first running allocation
+	IP string `hcl:"ip,optional" json:"ip,omitempty"`
+
+	// Ports maps the port labels of the first running allocation to the port
+	Ports map[string]int `hcl:"ports,optional" json:"ports,omitempty"`
+}
+
+func (n *NomadAllocation) Process() error {
+	if n.Timeout == "" {
+		n.Timeout = "60s"
+	}
+
+	if _, err := time.ParseDuration(n.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout %s: %s", n.Timeout, err)
+	}
+
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(n.Meta.ID)
+		if r != nil {
+			state := r.(*NomadAllocation)
+			n.Endpoints = state.Endpoints
+			n.IP = state.IP
+			n.Ports = state.Ports
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package nomad
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNomadAllocationProcessSetsDefaultTimeout(t *testing.T) {
+	c := &NomadAllocation{Job: "example_1", Group: "fake_service", Task: "fake_service"}
+
+	err := c.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "60s", c.Timeout)
+}
+
+func TestNomadServiceProcessInvalidTimeoutReturnsError(t *testing.T) {
+	c := &NomadService{Name: "api", Timeout: "sixty"}
+
+	err := c.Process()
+	require.Error(t, err)
+}
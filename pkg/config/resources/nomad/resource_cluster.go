@@ -17,6 +17,8 @@ type NomadCluster struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Networks      ctypes.NetworkAttachments `hcl:"network,block" json:"networks,omitempty"` // Attach to the correct network // only when Image is specified
 	Image         *ctypes.Image             `hcl:"image,block" json:"images,omitempty"`     // optional image to use for the cluster
 	ClientNodes   int                       `hcl:"client_nodes,optional" json:"client_nodes,omitempty"`
@@ -26,6 +28,7 @@ type NomadCluster struct {
 	ConsulConfig  string                    `hcl:"consul_config,optional" json:"consul_config,omitempty"`
 	Volumes       ctypes.Volumes            `hcl:"volume,block" json:"volumes,omitempty"`                     // volumes to attach to the cluster
 	OpenInBrowser bool                      `hcl:"open_in_browser,optional" json:"open_in_browser,omitempty"` // open the UI in the browser after creation
+	Resources     *ctypes.Resources         `hcl:"resources,block" json:"resources,omitempty"`                // resource constraints for each node in the cluster
 
 	Datacenter string `hcl:"datacenter,optional" json:"datacenter"` // Nomad datacenter, defaults dc1
 
@@ -39,6 +42,14 @@ type NomadCluster struct {
 	// Configuration for the drivers
 	Config *Config `hcl:"config,block" json:"config,omitempty"`
 
+	// CollectLogs runs a process that writes the logs of all the allocations
+	// in the cluster to logs_directory
+	CollectLogs bool `hcl:"collect_logs,optional" json:"collect_logs,omitempty"`
+
+	// Labels to set on the cluster containers, the labels are also added to
+	// the meta of the client nodes
+	Labels map[string]string `hcl:"labels,optional" json:"labels,omitempty"`
+
 	// Output Parameters
 
 	// The APIPort the server is running on
@@ -59,6 +70,13 @@ type NomadCluster struct {
 	// ExternalIP is the ip address of the cluster, this generally resolves
 	// to the docker ip
 	ExternalIP string `hcl:"external_ip,optional" json:"external_ip,omitempty"`
+
+	// LogsDirectory is the directory the allocation logs are written to when
+	// collect_logs is set, $HOME/.jumppad/logs/[name]
+	LogsDirectory string `hcl:"logs_directory,optional" json:"logs_directory,omitempty"`
+
+	// LogCollectorPID is the process id of the log collector
+	LogCollectorPID int `hcl:"log_collector_pid,optional" json:"log_collector_pid,omitempty"`
 }
 
 const nomadBaseImage = "ghcr.io/jumppad-labs/nomad"
@@ -77,6 +95,11 @@ type DockerConfig struct {
 	InsecureRegistries []string `hcl:"insecure_registries,optional" json:"insecure-registries,omitempty"`
 }
 
+// GetLabels returns the labels for the cluster
+func (n *NomadCluster) GetLabels() map[string]string {
+	return n.Labels
+}
+
 func (n *NomadCluster) Process() error {
 	if n.Image == nil {
 		n.Image = &ctypes.Image{Name: fmt.Sprintf("%s:%s", nomadBaseImage, nomadBaseVersion)}
@@ -103,7 +126,12 @@ func (n *NomadCluster) Process() error {
 	for i, v := range n.Volumes {
 		if v.Type == "" || v.Type == "bind" {
 			// only change path for bind mounts
-			n.Volumes[i].Source = utils.EnsureAbsolute(v.Source, n.Meta.File)
+			src, err := utils.VolumeSource(v.Source, n.Meta.File)
+			if err != nil {
+				return fmt.Errorf("invalid source for volume %s: %s", v.Destination, err)
+			}
+
+			n.Volumes[i].Source = src
 		}
 	}
 
@@ -121,6 +149,8 @@ func (n *NomadCluster) Process() error {
 			n.ClientContainerName = state.ClientContainerName
 			n.APIPort = state.APIPort
 			n.ConnectorPort = state.ConnectorPort
+			n.LogsDirectory = state.LogsDirectory
+			n.LogCollectorPID = state.LogCollectorPID
 
 			// add the image ids from the state, this allows the tracking of
 			// pushed images so that they can be automatically updated
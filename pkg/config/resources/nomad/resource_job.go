@@ -15,6 +15,8 @@ type NomadJob struct {
 	// embedded type holding name, etc
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	// Cluster is the name of the cluster to apply configuration to
 	Cluster NomadCluster `hcl:"cluster" json:"cluster"`
 
@@ -24,12 +26,25 @@ type NomadJob struct {
 	// HealthCheck defines a health check for the resource
 	HealthCheck *healthcheck.HealthCheckNomad `hcl:"health_check,block" json:"health_check,omitempty"`
 
+	// Labels to add to the meta of the jobs
+	Labels map[string]string `hcl:"labels,optional" json:"labels,omitempty"`
+
 	// output
 
 	// JobChecksums stores a checksum of the files or paths
 	JobChecksums []string `hcl:"job_checksums,optional" json:"job_checksums,omitempty"`
 }
 
+// GetLabels returns the labels for the jobs
+func (n *NomadJob) GetLabels() map[string]string {
+	return n.Labels
+}
+
+// GetCluster returns the cluster the jobs are run in
+func (n *NomadJob) GetCluster() types.Resource {
+	return &n.Cluster
+}
+
 func (n *NomadJob) Process() error {
 	// make all the paths absolute
 	for i, p := range n.Paths {
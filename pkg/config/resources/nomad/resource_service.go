@@ -0,0 +1,63 @@
+package nomad
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+)
+
+// TypeNomadService defines the string type for the Nomad service resource
+const TypeNomadService string = "nomad_service"
+
+// NomadService reads the addresses of a service registered with the Nomad
+// service discovery
+type NomadService struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Cluster is the cluster the service is registered with
+	Cluster NomadCluster `hcl:"cluster" json:"cluster"`
+
+	// Name of the service
+	Name string `hcl:"name" json:"name"`
+
+	// Timeout to wait for the service to be registered, defaults to 60s
+	Timeout string `hcl:"timeout,optional" json:"timeout,omitempty"`
+
+	// output
+
+	// Addresses of the service instances in the form ip:port
+	Addresses []string `hcl:"addresses,optional" json:"addresses,omitempty"`
+
+	// IP and Port of the first service instance
+	IP   string `hcl:"ip,optional" json:"ip,omitempty"`
+	Port int    `hcl:"port,optional" json:"port,omitempty"`
+}
+
+func (n *NomadService) Process() error {
+	if n.Timeout == "" {
+		n.Timeout = "60s"
+	}
+
+	if _, err := time.ParseDuration(n.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout %s: %s", n.Timeout, err)
+	}
+
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(n.Meta.ID)
+		if r != nil {
+			state := r.(*NomadService)
+			n.Addresses = state.Addresses
+			n.IP = state.IP
+			n.Port = state.Port
+		}
+	}
+
+	return nil
+}
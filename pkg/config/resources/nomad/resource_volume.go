@@ -0,0 +1,225 @@
+package nomad
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+)
+
+// TypeNomadVolume defines the string type for the Nomad volume resource
+const TypeNomadVolume string = "nomad_volume"
+
+// volume types
+const (
+	// VolumeTypeHost adds a host volume to the client config of every node
+	VolumeTypeHost = "host"
+	// VolumeTypeCSI registers or creates a volume with a CSI plugin
+	VolumeTypeCSI = "csi"
+)
+
+// hostVolumesDir is the folder on the nodes host volumes are created in when
+// no path is set
+const hostVolumesDir = "/opt/jumppad/volumes"
+
+var volumeAccessModes = []string{
+	"single-node-reader-only",
+	"single-node-writer",
+	"multi-node-reader-only",
+	"multi-node-single-writer",
+	"multi-node-multi-writer",
+}
+
+var volumeAttachmentModes = []string{"file-system", "block-device"}
+
+var volumeNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+var volumeCapacityRegex = regexp.MustCompile(`^([0-9]+)(MiB|GiB|TiB)$`)
+
+/*
+NomadVolume makes a volume available to the jobs in a Nomad cluster.
+
+Host volumes are added to the client config of every node and the nodes are
+restarted so that Nomad loads the volume, the folder for the volume is created
+on each node. Data in host volumes is stored in the node containers and is
+removed with the cluster unless the path is a volume mounted into the cluster.
+
+CSI volumes are registered with a CSI plugin, the plugin must be running in
+the cluster e.g. deployed with a nomad_job resource. When external_id is not
+set the plugin creates the volume and the volume is deleted when the resource
+is destroyed.
+
+```hcl
+
+	resource "nomad_volume" "postgres" {
+	  cluster = resource.nomad_cluster.dev
+	}
+
+	resource "nomad_job" "postgres" {
+	  depends_on = ["resource.nomad_volume.postgres"]
+
+	  cluster = resource.nomad_cluster.dev
+	  paths   = ["./jobs/postgres.nomad"]
+	}
+
+```
+
+The job uses the name of the volume as the source of the volume block.
+*/
+type NomadVolume struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Cluster is the cluster to add the volume to
+	Cluster NomadCluster `hcl:"cluster" json:"cluster"`
+
+	// Type of the volume host or csi, defaults to host
+	Type string `hcl:"type,optional" json:"type,omitempty"`
+
+	// Name of the volume in Nomad, defaults to the name of the resource
+	Name string `hcl:"name,optional" json:"name,omitempty"`
+
+	// Path of the host volume on the nodes, defaults to
+	// /opt/jumppad/volumes/[name]
+	Path string `hcl:"path,optional" json:"path,omitempty"`
+
+	// ReadOnly makes the host volume read only
+	ReadOnly bool `hcl:"read_only,optional" json:"read_only,omitempty"`
+
+	// PluginID is the id of the CSI plugin for the volume
+	PluginID string `hcl:"plugin_id,optional" json:"plugin_id,omitempty"`
+
+	// ExternalID is the id of an existing volume in the storage provider,
+	// when not set the plugin creates the volume
+	ExternalID string `hcl:"external_id,optional" json:"external_id,omitempty"`
+
+	// AccessMode of the CSI volume, defaults to single-node-writer
+	AccessMode string `hcl:"access_mode,optional" json:"access_mode,omitempty"`
+
+	// AttachmentMode of the CSI volume, defaults to file-system
+	AttachmentMode string `hcl:"attachment_mode,optional" json:"attachment_mode,omitempty"`
+
+	// CapacityMin and CapacityMax are the size of a created CSI volume
+	// e.g. 10GiB
+	CapacityMin string `hcl:"capacity_min,optional" json:"capacity_min,omitempty"`
+	CapacityMax string `hcl:"capacity_max,optional" json:"capacity_max,omitempty"`
+
+	// Parameters and Secrets are passed to the CSI plugin
+	Parameters map[string]string `hcl:"parameters,optional" json:"parameters,omitempty"`
+	Secrets    map[string]string `hcl:"secrets,optional" json:"secrets,omitempty"`
+
+	// Timeout to wait for the nodes or the CSI plugin to be ready, defaults
+	// to 120s
+	Timeout string `hcl:"timeout,optional" json:"timeout,omitempty"`
+}
+
+func (n *NomadVolume) Process() error {
+	if n.Type == "" {
+		n.Type = VolumeTypeHost
+	}
+
+	if n.Name == "" {
+		n.Name = n.Meta.Name
+	}
+
+	if !volumeNameRegex.MatchString(n.Name) {
+		return fmt.Errorf("invalid name %s, name can only contain letters, numbers, - and _", n.Name)
+	}
+
+	if n.Timeout == "" {
+		n.Timeout = "120s"
+	}
+
+	if _, err := time.ParseDuration(n.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout %s: %s", n.Timeout, err)
+	}
+
+	switch n.Type {
+	case VolumeTypeHost:
+		return n.processHost()
+	case VolumeTypeCSI:
+		return n.processCSI()
+	}
+
+	return fmt.Errorf("invalid type %s, type must be %s or %s", n.Type, VolumeTypeHost, VolumeTypeCSI)
+}
+
+func (n *NomadVolume) processHost() error {
+	if n.PluginID != "" || n.ExternalID != "" {
+		return fmt.Errorf("plugin_id and external_id can only be set for csi volumes")
+	}
+
+	if n.Path == "" {
+		n.Path = path.Join(hostVolumesDir, n.Name)
+	}
+
+	if !path.IsAbs(n.Path) {
+		return fmt.Errorf("invalid path %s, path must be absolute", n.Path)
+	}
+
+	return nil
+}
+
+func (n *NomadVolume) processCSI() error {
+	if n.PluginID == "" {
+		return fmt.Errorf("plugin_id must be set for csi volumes")
+	}
+
+	if n.AccessMode == "" {
+		n.AccessMode = "single-node-writer"
+	}
+
+	if !slices.Contains(volumeAccessModes, n.AccessMode) {
+		return fmt.Errorf("invalid access_mode %s, access_mode must be one of %s", n.AccessMode, strings.Join(volumeAccessModes, ", "))
+	}
+
+	if n.AttachmentMode == "" {
+		n.AttachmentMode = "file-system"
+	}
+
+	if !slices.Contains(volumeAttachmentModes, n.AttachmentMode) {
+		return fmt.Errorf("invalid attachment_mode %s, attachment_mode must be one of %s", n.AttachmentMode, strings.Join(volumeAttachmentModes, ", "))
+	}
+
+	if _, err := capacityBytes(n.CapacityMin); err != nil {
+		return fmt.Errorf("invalid capacity_min: %s", err)
+	}
+
+	if _, err := capacityBytes(n.CapacityMax); err != nil {
+		return fmt.Errorf("invalid capacity_max: %s", err)
+	}
+
+	return nil
+}
+
+// capacityBytes converts a capacity e.g. 10GiB to bytes, an empty capacity
+// is 0
+func capacityBytes(c string) (int64, error) {
+	if c == "" {
+		return 0, nil
+	}
+
+	m := volumeCapacityRegex.FindStringSubmatch(c)
+	if m == nil {
+		return 0, fmt.Errorf("%s must be a number followed by MiB, GiB, or TiB e.g. 10GiB", c)
+	}
+
+	b, _ := strconv.ParseInt(m[1], 10, 64)
+
+	switch m[2] {
+	case "TiB":
+		b *= 1024 * 1024 * 1024 * 1024
+	case "GiB":
+		b *= 1024 * 1024 * 1024
+	default:
+		b *= 1024 * 1024
+	}
+
+	return b, nil
+}
@@ -0,0 +1,96 @@
+package nomad
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/stretchr/testify/require"
+)
+
+func testVolume() *NomadVolume {
+	return &NomadVolume{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.nomad_volume.postgres", Name: "postgres"}}}
+}
+
+func TestVolumeProcessSetsHostDefaults(t *testing.T) {
+	v := testVolume()
+
+	err := v.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, VolumeTypeHost, v.Type)
+	require.Equal(t, "postgres", v.Name)
+	require.Equal(t, "/opt/jumppad/volumes/postgres", v.Path)
+	require.Equal(t, "120s", v.Timeout)
+}
+
+func TestVolumeProcessSetsCSIDefaults(t *testing.T) {
+	v := testVolume()
+	v.Type = VolumeTypeCSI
+	v.PluginID = "hostpath"
+
+	err := v.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "single-node-writer", v.AccessMode)
+	require.Equal(t, "file-system", v.AttachmentMode)
+}
+
+func TestVolumeProcessInvalidTypeReturnsError(t *testing.T) {
+	v := testVolume()
+	v.Type = "nfs"
+
+	err := v.Process()
+	require.ErrorContains(t, err, "invalid type nfs")
+}
+
+func TestVolumeProcessInvalidNameReturnsError(t *testing.T) {
+	v := testVolume()
+	v.Name = "my volume"
+
+	err := v.Process()
+	require.ErrorContains(t, err, "invalid name my volume")
+}
+
+func TestVolumeProcessRelativeHostPathReturnsError(t *testing.T) {
+	v := testVolume()
+	v.Path = "./data"
+
+	err := v.Process()
+	require.ErrorContains(t, err, "path must be absolute")
+}
+
+func TestVolumeProcessHostWithPluginReturnsError(t *testing.T) {
+	v := testVolume()
+	v.PluginID = "hostpath"
+
+	err := v.Process()
+	require.ErrorContains(t, err, "can only be set for csi volumes")
+}
+
+func TestVolumeProcessCSIWithoutPluginReturnsError(t *testing.T) {
+	v := testVolume()
+	v.Type = VolumeTypeCSI
+
+	err := v.Process()
+	require.ErrorContains(t, err, "plugin_id must be set")
+}
+
+func TestVolumeProcessInvalidAccessModeReturnsError(t *testing.T) {
+	v := testVolume()
+	v.Type = VolumeTypeCSI
+	v.PluginID = "hostpath"
+	v.AccessMode = "everyone"
+
+	err := v.Process()
+	require.ErrorContains(t, err, "invalid access_mode everyone")
+}
+
+func TestVolumeProcessInvalidCapacityReturnsError(t *testing.T) {
+	v := testVolume()
+	v.Type = VolumeTypeCSI
+	v.PluginID = "hostpath"
+	v.CapacityMin = "10GB"
+
+	err := v.Process()
+	require.ErrorContains(t, err, "invalid capacity_min")
+}
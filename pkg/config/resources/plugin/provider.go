@@ -0,0 +1,98 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/plugins"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &Provider{}
+
+// Provider proxies the lifecycle of a resource to the plugin that provides
+// its type
+type Provider struct {
+	config *Resource
+	plugin *plugins.Plugin
+	log    sdk.Logger
+}
+
+func (p *Provider) Init(cfg types.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Resource)
+	if !ok {
+		return fmt.Errorf("unable to initialize provider, resource is not of type plugin.Resource")
+	}
+
+	pl, ok := plugins.Lookup(c.Meta.Type)
+	if !ok {
+		return fmt.Errorf("unable to initialize provider, no plugin provides the resource type %s", c.Meta.Type)
+	}
+
+	p.config = c
+	p.plugin = pl
+	p.log = l
+
+	return nil
+}
+
+func (p *Provider) Create(ctx context.Context) error {
+	p.log.Info(fmt.Sprintf("Creating %s", p.config.Meta.Type), "ref", p.config.Meta.ID, "plugin", p.plugin.Name)
+
+	resp, err := p.call(ctx, plugins.OperationCreate, false)
+	if err != nil {
+		return err
+	}
+
+	return p.config.setOutput(resp.Output)
+}
+
+func (p *Provider) Destroy(ctx context.Context, force bool) error {
+	p.log.Info(fmt.Sprintf("Destroy %s", p.config.Meta.Type), "ref", p.config.Meta.ID, "plugin", p.plugin.Name)
+
+	_, err := p.call(ctx, plugins.OperationDestroy, force)
+	return err
+}
+
+func (p *Provider) Lookup() ([]string, error) {
+	resp, err := p.call(context.Background(), plugins.OperationLookup, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.IDs, nil
+}
+
+func (p *Provider) Refresh(ctx context.Context) error {
+	p.log.Debug(fmt.Sprintf("Refresh %s", p.config.Meta.Type), "ref", p.config.Meta.ID, "plugin", p.plugin.Name)
+
+	resp, err := p.call(ctx, plugins.OperationRefresh, false)
+	if err != nil {
+		return err
+	}
+
+	return p.config.setOutput(resp.Output)
+}
+
+func (p *Provider) Changed() (bool, error) {
+	resp, err := p.call(context.Background(), plugins.OperationChanged, false)
+	if err != nil {
+		return false, err
+	}
+
+	return resp.Changed, nil
+}
+
+func (p *Provider) call(ctx context.Context, operation string, force bool) (*plugins.Response, error) {
+	req := plugins.Request{
+		Type:   p.config.Meta.Type,
+		ID:     p.config.Meta.ID,
+		Name:   p.config.Meta.Name,
+		Config: p.config.ConfigValues,
+		Output: p.config.OutputValues,
+		Force:  force,
+	}
+
+	return p.plugin.Call(ctx, operation, req, p.log.StandardWriter())
+}
@@ -0,0 +1,81 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/plugins"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Resource is a resource whose type is provided by an external plugin, the
+// attributes declared in the plugin schema are set using the config
+// attribute and the values returned by the plugin are available as output
+//
+//	resource "kafka_cluster" "main" {
+//	  config = {
+//	    brokers = 3
+//	  }
+//	}
+type Resource struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Config cty.Value `hcl:"config,optional" json:"-"` // attributes defined by the plugin schema
+
+	// Output parameters
+
+	Output cty.Value `hcl:"output,optional" json:"-"` // values returned by the plugin
+
+	// ConfigValues and OutputValues hold the config and output as plain values
+	// so that they can be sent to the plugin and stored in the state
+	ConfigValues map[string]interface{} `json:"config,omitempty"`
+	OutputValues map[string]interface{} `json:"output,omitempty"`
+}
+
+func (r *Resource) Process() error {
+	p, ok := plugins.Lookup(r.Meta.Type)
+	if !ok {
+		return fmt.Errorf("no plugin provides the resource type %s, check the plugin is installed in the plugins directory", r.Meta.Type)
+	}
+
+	cv, err := config.ValuesFromCty(r.Config)
+	if err != nil {
+		return fmt.Errorf("unable to read config for %s: %s", r.Meta.ID, err)
+	}
+
+	err = p.Types[r.Meta.Type].Validate(cv)
+	if err != nil {
+		return fmt.Errorf("invalid config for %s: %s", r.Meta.ID, err)
+	}
+
+	r.ConfigValues = cv
+
+	// restore the output from the state so that it can be used by dependent
+	// resources
+	cfg, err := config.LoadState()
+	if err == nil {
+		sr, _ := cfg.FindResource(r.Meta.ID)
+		if sr != nil {
+			state := sr.(*Resource)
+			r.setOutput(state.OutputValues)
+		}
+	}
+
+	return nil
+}
+
+// setOutput sets the output values returned by the plugin
+func (r *Resource) setOutput(o map[string]interface{}) error {
+	v, err := config.CtyFromValues(o)
+	if err != nil {
+		return err
+	}
+
+	r.OutputValues = o
+	r.Output = v
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package plugin
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/plugins"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func init() {
+	config.RegisterResource("kafka_cluster", &Resource{}, &Provider{})
+
+	plugins.Register(&plugins.Plugin{
+		Name: "kafka",
+		Types: map[string]plugins.Schema{
+			"kafka_cluster": {
+				Attributes: map[string]plugins.Attribute{
+					"brokers": {Type: plugins.AttributeNumber, Required: true},
+					"version": {Type: plugins.AttributeString},
+				},
+			},
+		},
+	})
+}
+
+func newTestResource(c cty.Value) *Resource {
+	return &Resource{
+		ResourceBase: types.ResourceBase{
+			Meta: types.Meta{
+				ID:   "resource.kafka_cluster.main",
+				Type: "kafka_cluster",
+			},
+		},
+		Config: c,
+	}
+}
+
+func TestProcessSetsConfigValues(t *testing.T) {
+	r := newTestResource(cty.ObjectVal(map[string]cty.Value{
+		"brokers": cty.NumberIntVal(3),
+		"version": cty.StringVal("3.7"),
+	}))
+
+	err := r.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, float64(3), r.ConfigValues["brokers"])
+	require.Equal(t, "3.7", r.ConfigValues["version"])
+}
+
+func TestProcessWithInvalidConfigReturnsError(t *testing.T) {
+	r := newTestResource(cty.ObjectVal(map[string]cty.Value{
+		"version": cty.StringVal("3.7"),
+	}))
+
+	err := r.Process()
+	require.ErrorContains(t, err, "attribute brokers is required")
+}
+
+func TestProcessWithUnknownTypeReturnsError(t *testing.T) {
+	r := newTestResource(cty.NilVal)
+	r.Meta.Type = "redis_cluster"
+
+	err := r.Process()
+	require.ErrorContains(t, err, "no plugin provides the resource type redis_cluster")
+}
+
+func TestProcessSetsOutputFromState(t *testing.T) {
+	testutils.SetupState(t, `
+{
+  "blueprint": null,
+  "resources": [
+	{
+			"meta": {
+      	"id": "resource.kafka_cluster.main",
+      	"name": "main",
+      	"type": "kafka_cluster"
+			},
+			"output": {
+				"bootstrap": "localhost:9092"
+			}
+	}
+	]
+}`)
+
+	r := newTestResource(cty.ObjectVal(map[string]cty.Value{
+		"brokers": cty.NumberIntVal(3),
+	}))
+
+	err := r.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "localhost:9092", r.OutputValues["bootstrap"])
+	require.Equal(t, cty.StringVal("localhost:9092"), r.Output.GetAttr("bootstrap"))
+}
@@ -0,0 +1,35 @@
+package random
+
+import (
+	"maps"
+
+	"github.com/jumppad-labs/jumppad/pkg/config"
+)
+
+// keepersResource is implemented by random resources that support keepers
+type keepersResource interface {
+	getKeepers() map[string]string
+}
+
+// keepersChanged returns true when the keepers for the resource differ from
+// the keepers stored in the state. Keepers are arbitrary values, normally
+// referencing other resources, that force a new random value to be generated
+// when they change.
+func keepersChanged(id string, keepers map[string]string) bool {
+	cfg, err := config.LoadState()
+	if err != nil {
+		return false
+	}
+
+	r, err := cfg.FindResource(id)
+	if err != nil {
+		return false
+	}
+
+	k, ok := r.(keepersResource)
+	if !ok {
+		return false
+	}
+
+	return !maps.Equal(keepers, k.getKeepers())
+}
@@ -0,0 +1,105 @@
+package random
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	config.RegisterResource(TypeRandomInteger, &RandomInteger{}, &RandomIntegerProvider{})
+}
+
+var keepersState = `
+{
+  "blueprint": null,
+  "resources": [
+	{
+		"meta": {
+			"id": "resource.random_integer.test",
+			"name": "test",
+			"type": "random_integer"
+		},
+		"min": 10,
+		"max": 10,
+		"keepers": {
+			"image": "nginx:1.0"
+		},
+		"value": 10
+	}
+  ]
+}`
+
+func setupRandomInteger(t *testing.T, keepers map[string]string) (*RandomInteger, *RandomIntegerProvider) {
+	testutils.SetupState(t, keepersState)
+
+	r := &RandomInteger{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.random_integer.test"}},
+		Min:          1,
+		Max:          5,
+		Keepers:      keepers,
+	}
+
+	err := r.Process()
+	require.NoError(t, err)
+
+	p := &RandomIntegerProvider{}
+	err = p.Init(r, logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	return r, p
+}
+
+func TestRandomIntegerGeneratesValueInRange(t *testing.T) {
+	r, p := setupRandomInteger(t, nil)
+
+	for range 100 {
+		err := p.Create(context.Background())
+		require.NoError(t, err)
+
+		require.GreaterOrEqual(t, r.Value, 1)
+		require.LessOrEqual(t, r.Value, 5)
+	}
+}
+
+func TestRandomIntegerProcessReturnsErrorWhenMaxLessThanMin(t *testing.T) {
+	r := &RandomInteger{Min: 5, Max: 1}
+
+	err := r.Process()
+	require.Error(t, err)
+}
+
+func TestChangedReturnsFalseWhenKeepersUnchanged(t *testing.T) {
+	r, p := setupRandomInteger(t, map[string]string{"image": "nginx:1.0"})
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.False(t, c)
+
+	err = p.Refresh(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 10, r.Value)
+}
+
+func TestChangedReturnsTrueWhenKeepersChange(t *testing.T) {
+	_, p := setupRandomInteger(t, map[string]string{"image": "nginx:2.0"})
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, c)
+}
+
+func TestRefreshGeneratesNewValueWhenKeepersChange(t *testing.T) {
+	r, p := setupRandomInteger(t, map[string]string{"image": "nginx:2.0"})
+	require.Equal(t, 10, r.Value)
+
+	err := p.Refresh(context.Background())
+	require.NoError(t, err)
+
+	require.LessOrEqual(t, r.Value, 5)
+}
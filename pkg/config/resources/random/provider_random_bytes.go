@@ -0,0 +1,75 @@
+package random
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &RandomBytesProvider{}
+
+// RandomBytesProvider is a provider for generating random bytes
+type RandomBytesProvider struct {
+	config *RandomBytes
+	log    sdk.Logger
+}
+
+func (p *RandomBytesProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*RandomBytes)
+	if !ok {
+		return fmt.Errorf("unable to initialize RandomBytes provider, resource is not of type RandomBytes")
+	}
+
+	p.config = c
+	p.log = l
+
+	return nil
+}
+
+func (p *RandomBytesProvider) Create(ctx context.Context) error {
+	p.log.Info("Creating random bytes", "ref", p.config.Meta.ID)
+
+	bytes := make([]byte, p.config.Length)
+
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return fmt.Errorf("unable generate random bytes: %w", err)
+	}
+
+	p.config.Base64 = base64.StdEncoding.EncodeToString(bytes)
+	p.config.Hex = hex.EncodeToString(bytes)
+
+	utils.RegisterSensitive(p.config.Base64)
+	utils.RegisterSensitive(p.config.Hex)
+
+	return nil
+}
+
+func (p *RandomBytesProvider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *RandomBytesProvider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+func (p *RandomBytesProvider) Refresh(ctx context.Context) error {
+	if keepersChanged(p.config.Meta.ID, p.config.Keepers) {
+		p.log.Debug("Keepers changed, generating new value", "ref", p.config.Meta.ID)
+		return p.Create(ctx)
+	}
+
+	return nil
+}
+
+func (p *RandomBytesProvider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return keepersChanged(p.config.Meta.ID, p.config.Keepers), nil
+}
@@ -253,11 +253,16 @@ func (p *RandomCreatureProvider) Lookup() ([]string, error) {
 }
 
 func (p *RandomCreatureProvider) Refresh(ctx context.Context) error {
+	if keepersChanged(p.config.Meta.ID, p.config.Keepers) {
+		p.log.Debug("Keepers changed, generating new value", "ref", p.config.Meta.ID)
+		return p.Create(ctx)
+	}
+
 	return nil
 }
 
 func (p *RandomCreatureProvider) Changed() (bool, error) {
 	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
 
-	return false, nil
+	return keepersChanged(p.config.Meta.ID, p.config.Keepers), nil
 }
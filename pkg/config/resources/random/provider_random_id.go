@@ -64,11 +64,16 @@ func (p *RandomIDProvider) Lookup() ([]string, error) {
 }
 
 func (p *RandomIDProvider) Refresh(ctx context.Context) error {
+	if keepersChanged(p.config.Meta.ID, p.config.Keepers) {
+		p.log.Debug("Keepers changed, generating new value", "ref", p.config.Meta.ID)
+		return p.Create(ctx)
+	}
+
 	return nil
 }
 
 func (p *RandomIDProvider) Changed() (bool, error) {
 	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
 
-	return false, nil
+	return keepersChanged(p.config.Meta.ID, p.config.Keepers), nil
 }
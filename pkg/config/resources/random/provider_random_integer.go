@@ -0,0 +1,68 @@
+package random
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &RandomIntegerProvider{}
+
+// RandomIntegerProvider is a provider for generating random integers
+type RandomIntegerProvider struct {
+	config *RandomInteger
+	log    sdk.Logger
+}
+
+func (p *RandomIntegerProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*RandomInteger)
+	if !ok {
+		return fmt.Errorf("unable to initialize RandomInteger provider, resource is not of type RandomInteger")
+	}
+
+	p.config = c
+	p.log = l
+
+	return nil
+}
+
+func (p *RandomIntegerProvider) Create(ctx context.Context) error {
+	p.log.Info("Creating random integer", "ref", p.config.Meta.ID)
+
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(p.config.Max-p.config.Min)+1))
+	if err != nil {
+		return fmt.Errorf("unable to generate random integer: %w", err)
+	}
+
+	p.config.Value = int(n.Int64()) + p.config.Min
+	p.log.Debug("Generated random integer", "ref", p.config.Meta.ID, "value", p.config.Value)
+
+	return nil
+}
+
+func (p *RandomIntegerProvider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *RandomIntegerProvider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+func (p *RandomIntegerProvider) Refresh(ctx context.Context) error {
+	if keepersChanged(p.config.Meta.ID, p.config.Keepers) {
+		p.log.Debug("Keepers changed, generating new value", "ref", p.config.Meta.ID)
+		return p.Create(ctx)
+	}
+
+	return nil
+}
+
+func (p *RandomIntegerProvider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return keepersChanged(p.config.Meta.ID, p.config.Keepers), nil
+}
@@ -49,11 +49,16 @@ func (p *RandomNumberProvider) Lookup() ([]string, error) {
 }
 
 func (p *RandomNumberProvider) Refresh(ctx context.Context) error {
+	if keepersChanged(p.config.Meta.ID, p.config.Keepers) {
+		p.log.Debug("Keepers changed, generating new value", "ref", p.config.Meta.ID)
+		return p.Create(ctx)
+	}
+
 	return nil
 }
 
 func (p *RandomNumberProvider) Changed() (bool, error) {
 	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
 
-	return false, nil
+	return keepersChanged(p.config.Meta.ID, p.config.Keepers), nil
 }
@@ -7,6 +7,7 @@ import (
 	"sort"
 
 	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
 
@@ -92,6 +93,7 @@ func (p *RandomPasswordProvider) Create(ctx context.Context) error {
 	})
 
 	p.config.Value = string(result)
+	utils.RegisterSensitive(p.config.Value)
 
 	return nil
 }
@@ -105,11 +107,16 @@ func (p *RandomPasswordProvider) Lookup() ([]string, error) {
 }
 
 func (p *RandomPasswordProvider) Refresh(ctx context.Context) error {
+	if keepersChanged(p.config.Meta.ID, p.config.Keepers) {
+		p.log.Debug("Keepers changed, generating new value", "ref", p.config.Meta.ID)
+		return p.Create(ctx)
+	}
+
 	return nil
 }
 
 func (p *RandomPasswordProvider) Changed() (bool, error) {
 	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
 
-	return false, nil
+	return keepersChanged(p.config.Meta.ID, p.config.Keepers), nil
 }
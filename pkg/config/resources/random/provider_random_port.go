@@ -0,0 +1,167 @@
+package random
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &RandomPortProvider{}
+
+// number of ports checked before giving up
+const portAttempts = 100
+
+// allocatedPorts contains the ports selected by the random_port resources in
+// this run, keyed by protocol and port with the id of the resource as the
+// value. Resources are created concurrently and the state is only saved when
+// the run completes, the ports are reserved here so that two resources can
+// not select the same port
+var allocatedPorts = map[string]string{}
+var allocatedPortsMutex = sync.Mutex{}
+
+// RandomPortProvider is a provider for selecting free local ports
+type RandomPortProvider struct {
+	config *RandomPort
+	log    sdk.Logger
+}
+
+func (p *RandomPortProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*RandomPort)
+	if !ok {
+		return fmt.Errorf("unable to initialize RandomPort provider, resource is not of type RandomPort")
+	}
+
+	p.config = c
+	p.log = l
+
+	return nil
+}
+
+func (p *RandomPortProvider) Create(ctx context.Context) error {
+	p.log.Info("Creating random port", "ref", p.config.Meta.ID)
+
+	// hold the lock until the port is reserved so that resources created
+	// concurrently do not select the same port
+	allocatedPortsMutex.Lock()
+	defer allocatedPortsMutex.Unlock()
+
+	// the port previously selected by this resource is released
+	p.releasePort()
+
+	// ports held by other random_port resources may not be bound yet,
+	// exclude them so that each resource gets a unique port
+	reserved := p.reservedPorts()
+
+	for range portAttempts {
+		port, err := p.candidate()
+		if err != nil {
+			return err
+		}
+
+		if reserved[port] || p.allocated(port) {
+			continue
+		}
+
+		if p.config.Min == 0 || utils.PortAvailable(p.config.Protocol, port) {
+			p.config.Value = port
+			allocatedPorts[portKey(p.config.Protocol, port)] = p.config.Meta.ID
+			p.log.Debug("Selected random port", "ref", p.config.Meta.ID, "port", port, "protocol", p.config.Protocol)
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unable to find a free %s port in the range %d-%d", p.config.Protocol, p.config.Min, p.config.Max)
+}
+
+func (p *RandomPortProvider) Destroy(ctx context.Context, force bool) error {
+	allocatedPortsMutex.Lock()
+	defer allocatedPortsMutex.Unlock()
+
+	p.releasePort()
+
+	return nil
+}
+
+func (p *RandomPortProvider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+func (p *RandomPortProvider) Refresh(ctx context.Context) error {
+	if p.config.Value == 0 || keepersChanged(p.config.Meta.ID, p.config.Keepers) {
+		p.log.Debug("Port not set or keepers changed, selecting new port", "ref", p.config.Meta.ID)
+		return p.Create(ctx)
+	}
+
+	return nil
+}
+
+func (p *RandomPortProvider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return keepersChanged(p.config.Meta.ID, p.config.Keepers), nil
+}
+
+// candidate returns a port to check, when no range is set the operating
+// system selects a free port
+func (p *RandomPortProvider) candidate() (int, error) {
+	if p.config.Min == 0 {
+		return utils.FreePort(p.config.Protocol)
+	}
+
+	return rand.Intn(p.config.Max-p.config.Min+1) + p.config.Min, nil
+}
+
+// allocated returns true when the port has been selected by another
+// random_port resource in this run, allocatedPortsMutex must be held
+func (p *RandomPortProvider) allocated(port int) bool {
+	id, ok := allocatedPorts[portKey(p.config.Protocol, port)]
+	return ok && id != p.config.Meta.ID
+}
+
+// releasePort removes the ports selected by this resource from the allocated
+// ports, allocatedPortsMutex must be held
+func (p *RandomPortProvider) releasePort() {
+	for k, id := range allocatedPorts {
+		if id == p.config.Meta.ID {
+			delete(allocatedPorts, k)
+		}
+	}
+}
+
+func portKey(protocol string, port int) string {
+	return fmt.Sprintf("%s/%d", protocol, port)
+}
+
+// reservedPorts returns the ports allocated to other random_port resources
+// in the state
+func (p *RandomPortProvider) reservedPorts() map[int]bool {
+	reserved := map[int]bool{}
+
+	cfg, err := config.LoadState()
+	if err != nil {
+		return reserved
+	}
+
+	res, err := cfg.FindResourcesByType(TypeRandomPort)
+	if err != nil {
+		return reserved
+	}
+
+	for _, r := range res {
+		rp, ok := r.(*RandomPort)
+		if !ok || rp.Meta.ID == p.config.Meta.ID || rp.Protocol != p.config.Protocol {
+			continue
+		}
+
+		reserved[rp.Value] = true
+	}
+
+	return reserved
+}
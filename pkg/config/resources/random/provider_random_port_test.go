@@ -0,0 +1,128 @@
+package random
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	config.RegisterResource(TypeRandomPort, &RandomPort{}, &RandomPortProvider{})
+}
+
+var portState = `
+{
+  "blueprint": null,
+  "resources": [
+	{
+		"meta": {
+			"id": "resource.random_port.other",
+			"name": "other",
+			"type": "random_port"
+		},
+		"protocol": "tcp",
+		"min": 40000,
+		"max": 40001,
+		"value": 40000
+	},
+	{
+		"meta": {
+			"id": "resource.random_port.test",
+			"name": "test",
+			"type": "random_port"
+		},
+		"protocol": "tcp",
+		"min": 40000,
+		"max": 40001,
+		"value": 40001
+	}
+  ]
+}`
+
+func setupRandomPort(t *testing.T, state string, r *RandomPort) *RandomPortProvider {
+	testutils.SetupState(t, state)
+
+	allocatedPorts = map[string]string{}
+	t.Cleanup(func() { allocatedPorts = map[string]string{} })
+
+	err := r.Process()
+	require.NoError(t, err)
+
+	p := &RandomPortProvider{}
+	err = p.Init(r, logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestRandomPortSelectsFreePort(t *testing.T) {
+	r := &RandomPort{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.random_port.new"}}}
+	p := setupRandomPort(t, "", r)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "tcp", r.Protocol)
+	require.Greater(t, r.Value, 0)
+}
+
+func TestRandomPortSkipsPortsReservedByOtherResources(t *testing.T) {
+	r := &RandomPort{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.random_port.new"}}, Min: 40000, Max: 40001}
+	p := setupRandomPort(t, portState, r)
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
+
+func TestRandomPortKeepsPortFromState(t *testing.T) {
+	r := &RandomPort{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.random_port.test"}}, Min: 40000, Max: 40001}
+	p := setupRandomPort(t, portState, r)
+
+	err := p.Refresh(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, 40001, r.Value)
+}
+
+func TestRandomPortProcessReturnsErrorWhenProtocolInvalid(t *testing.T) {
+	r := &RandomPort{Protocol: "icmp"}
+
+	err := r.Process()
+	require.Error(t, err)
+}
+
+func TestRandomPortSkipsPortsAllocatedInThisRun(t *testing.T) {
+	r := &RandomPort{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.random_port.one"}}, Min: 40000, Max: 40000}
+	p := setupRandomPort(t, "", r)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 40000, r.Value)
+
+	r2 := &RandomPort{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.random_port.two"}}, Min: 40000, Max: 40000}
+	require.NoError(t, r2.Process())
+
+	p2 := &RandomPortProvider{}
+	require.NoError(t, p2.Init(r2, logger.NewTestLogger(t)))
+
+	err = p2.Create(context.Background())
+	require.Error(t, err)
+}
+
+func TestRandomPortDestroyReleasesPort(t *testing.T) {
+	r := &RandomPort{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.random_port.one"}}, Min: 40000, Max: 40000}
+	p := setupRandomPort(t, "", r)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	err = p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	require.Empty(t, allocatedPorts)
+}
@@ -52,13 +52,18 @@ func (p *RandomUUIDProvider) Lookup() ([]string, error) {
 }
 
 func (p *RandomUUIDProvider) Refresh(ctx context.Context) error {
+	if keepersChanged(p.config.Meta.ID, p.config.Keepers) {
+		p.log.Debug("Keepers changed, generating new value", "ref", p.config.Meta.ID)
+		return p.Create(ctx)
+	}
+
 	return nil
 }
 
 func (p *RandomUUIDProvider) Changed() (bool, error) {
 	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
 
-	return false, nil
+	return keepersChanged(p.config.Meta.ID, p.config.Keepers), nil
 }
 
 func generateRandomBytes(charSet *string, length int64) ([]byte, error) {
@@ -0,0 +1,102 @@
+package random
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// TypeRandomBytes is the resource for generating random bytes
+const TypeRandomBytes string = "random_bytes"
+
+// allows the generation of random bytes that can be used as keys, the
+// generated bytes are treated as sensitive
+type RandomBytes struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Length int64 `hcl:"length" json:"length"`
+
+	// Keepers force a new value to be generated when any of the values change
+	Keepers map[string]string `hcl:"keepers,optional" json:"keepers,omitempty"`
+
+	// Output parameters
+	Base64 string `hcl:"base64,optional" json:"base64"`
+	Hex    string `hcl:"hex,optional" json:"hex"`
+}
+
+func (c *RandomBytes) Process() error {
+	if c.Length < 1 {
+		return fmt.Errorf("length must be greater than 0")
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(c.Meta.ID)
+		if r != nil {
+			state := r.(*RandomBytes)
+			c.Base64 = state.Base64
+			c.Hex = state.Hex
+		}
+	}
+
+	utils.RegisterSensitive(c.Base64)
+	utils.RegisterSensitive(c.Hex)
+
+	return nil
+}
+
+func (c *RandomBytes) getKeepers() map[string]string {
+	return c.Keepers
+}
+
+// randomBytesJSON is used to serialize the bytes without recursing into
+// MarshalJSON
+type randomBytesJSON RandomBytes
+
+// MarshalJSON encrypts the generated bytes so that they are not written to
+// the state in plain text
+func (c *RandomBytes) MarshalJSON() ([]byte, error) {
+	rj := randomBytesJSON(*c)
+
+	for _, v := range []*string{&rj.Base64, &rj.Hex} {
+		if *v == "" {
+			continue
+		}
+
+		enc, err := utils.EncryptSecret(*v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encrypt bytes %s: %w", c.Meta.ID, err)
+		}
+
+		*v = enc
+	}
+
+	return json.Marshal(rj)
+}
+
+// UnmarshalJSON decrypts the bytes encrypted by MarshalJSON
+func (c *RandomBytes) UnmarshalJSON(d []byte) error {
+	rj := randomBytesJSON{}
+
+	err := json.Unmarshal(d, &rj)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range []*string{&rj.Base64, &rj.Hex} {
+		*v = utils.DecryptSecretOrEmpty(rj.Meta.ID, *v)
+		utils.RegisterSensitive(*v)
+	}
+
+	*c = RandomBytes(rj)
+
+	return nil
+}
@@ -12,6 +12,11 @@ const TypeRandomCreature string = "random_creature"
 type RandomCreature struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Keepers force a new value to be generated when any of the values change
+	Keepers map[string]string `hcl:"keepers,optional" json:"keepers,omitempty"`
+
 	// Output parameters
 	Value string `hcl:"value,optional" json:"value"`
 }
@@ -35,3 +40,7 @@ func (c *RandomCreature) Process() error {
 func boolPointer(value bool) *bool {
 	return &value
 }
+
+func (c *RandomCreature) getKeepers() map[string]string {
+	return c.Keepers
+}
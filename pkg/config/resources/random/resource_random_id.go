@@ -12,8 +12,13 @@ const TypeRandomID string = "random_id"
 type RandomID struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	ByteLength int64 `hcl:"byte_length" json:"byte_length"`
 
+	// Keepers force a new value to be generated when any of the values change
+	Keepers map[string]string `hcl:"keepers,optional" json:"keepers,omitempty"`
+
 	// Output parameters
 	Base64 string `hcl:"base64,optional" json:"base64"`
 	Hex    string `hcl:"hex,optional" json:"hex"`
@@ -37,3 +42,7 @@ func (c *RandomID) Process() error {
 
 	return nil
 }
+
+func (c *RandomID) getKeepers() map[string]string {
+	return c.Keepers
+}
@@ -0,0 +1,51 @@
+package random
+
+import (
+	"fmt"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+)
+
+// TypeRandomInteger is the resource for generating random integers in a range
+const TypeRandomInteger string = "random_integer"
+
+// allows the generation of random integers between min and max inclusive
+type RandomInteger struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Min int `hcl:"min" json:"min"`
+	Max int `hcl:"max" json:"max"`
+
+	// Keepers force a new value to be generated when any of the values change
+	Keepers map[string]string `hcl:"keepers,optional" json:"keepers,omitempty"`
+
+	// Output parameters
+	Value int `hcl:"value,optional" json:"value"`
+}
+
+func (c *RandomInteger) Process() error {
+	if c.Max < c.Min {
+		return fmt.Errorf("max %d must be greater than or equal to min %d", c.Max, c.Min)
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(c.Meta.ID)
+		if r != nil {
+			state := r.(*RandomInteger)
+			c.Value = state.Value
+		}
+	}
+
+	return nil
+}
+
+func (c *RandomInteger) getKeepers() map[string]string {
+	return c.Keepers
+}
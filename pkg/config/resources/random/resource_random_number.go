@@ -12,9 +12,14 @@ const TypeRandomNumber string = "random_number"
 type RandomNumber struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Minimum int `hcl:"minimum" json:"minimum"`
 	Maximum int `hcl:"maximum" json:"maximum"`
 
+	// Keepers force a new value to be generated when any of the values change
+	Keepers map[string]string `hcl:"keepers,optional" json:"keepers,omitempty"`
+
 	// Output parameters
 	Value int `hcl:"value,optional" json:"value"`
 }
@@ -34,3 +39,7 @@ func (c *RandomNumber) Process() error {
 
 	return nil
 }
+
+func (c *RandomNumber) getKeepers() map[string]string {
+	return c.Keepers
+}
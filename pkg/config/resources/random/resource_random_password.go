@@ -1,8 +1,12 @@
 package random
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
 )
 
 // TypeRandomPassword is the resource for generating random passwords
@@ -12,6 +16,8 @@ const TypeRandomPassword string = "random_password"
 type RandomPassword struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Length int64 `hcl:"length" json:"lenght"`
 
 	OverrideSpecial string `hcl:"override_special,optional" json:"override_special"`
@@ -25,6 +31,9 @@ type RandomPassword struct {
 	MinLower   int64 `hcl:"min_lower,optional" json:"min_lower"`
 	MinUpper   int64 `hcl:"min_upper,optional" json:"min_upper"`
 
+	// Keepers force a new value to be generated when any of the values change
+	Keepers map[string]string `hcl:"keepers,optional" json:"keepers,omitempty"`
+
 	// Output parameters
 	Value string `hcl:"value,optional" json:"value"`
 }
@@ -58,5 +67,49 @@ func (c *RandomPassword) Process() error {
 		}
 	}
 
+	utils.RegisterSensitive(c.Value)
+
+	return nil
+}
+
+// randomPasswordJSON is used to serialize the password without recursing
+// into MarshalJSON
+type randomPasswordJSON RandomPassword
+
+// MarshalJSON encrypts the generated password so that it is not written to
+// the state in plain text
+func (c *RandomPassword) MarshalJSON() ([]byte, error) {
+	rj := randomPasswordJSON(*c)
+
+	if rj.Value != "" {
+		enc, err := utils.EncryptSecret(rj.Value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encrypt password %s: %w", c.Meta.ID, err)
+		}
+
+		rj.Value = enc
+	}
+
+	return json.Marshal(rj)
+}
+
+// UnmarshalJSON decrypts the password encrypted by MarshalJSON
+func (c *RandomPassword) UnmarshalJSON(d []byte) error {
+	rj := randomPasswordJSON{}
+
+	err := json.Unmarshal(d, &rj)
+	if err != nil {
+		return err
+	}
+
+	rj.Value = utils.DecryptSecretOrEmpty(rj.Meta.ID, rj.Value)
+
+	*c = RandomPassword(rj)
+	utils.RegisterSensitive(c.Value)
+
 	return nil
 }
+
+func (c *RandomPassword) getKeepers() map[string]string {
+	return c.Keepers
+}
@@ -0,0 +1,75 @@
+package random
+
+import (
+	"fmt"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+)
+
+// TypeRandomPort is the resource for reserving free local ports
+const TypeRandomPort string = "random_port"
+
+// allows the selection of a free local port, once selected the port is
+// stored in the state and remains stable across runs
+type RandomPort struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Protocol of the port, tcp or udp, defaults to tcp
+	Protocol string `hcl:"protocol,optional" json:"protocol"`
+
+	// Min and Max optionally restrict the range the port is chosen from,
+	// when not set the operating system selects a free port
+	Min int `hcl:"min,optional" json:"min,omitempty"`
+	Max int `hcl:"max,optional" json:"max,omitempty"`
+
+	// Keepers force a new value to be generated when any of the values change
+	Keepers map[string]string `hcl:"keepers,optional" json:"keepers,omitempty"`
+
+	// Output parameters
+	Value int `hcl:"value,optional" json:"value"`
+}
+
+func (c *RandomPort) Process() error {
+	if c.Protocol == "" {
+		c.Protocol = "tcp"
+	}
+
+	if c.Protocol != "tcp" && c.Protocol != "udp" {
+		return fmt.Errorf("invalid protocol %q, protocol must be tcp or udp", c.Protocol)
+	}
+
+	if c.Min != 0 || c.Max != 0 {
+		if c.Min == 0 {
+			c.Min = 1024
+		}
+
+		if c.Max == 0 {
+			c.Max = 65535
+		}
+
+		if c.Min < 1 || c.Max > 65535 || c.Min > c.Max {
+			return fmt.Errorf("invalid port range %d-%d, min and max must be between 1 and 65535 and min less than or equal to max", c.Min, c.Max)
+		}
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(c.Meta.ID)
+		if r != nil {
+			state := r.(*RandomPort)
+			c.Value = state.Value
+		}
+	}
+
+	return nil
+}
+
+func (c *RandomPort) getKeepers() map[string]string {
+	return c.Keepers
+}
@@ -12,6 +12,11 @@ const TypeRandomUUID string = "random_uuid"
 type RandomUUID struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Keepers force a new value to be generated when any of the values change
+	Keepers map[string]string `hcl:"keepers,optional" json:"keepers,omitempty"`
+
 	// Output parameters
 	Value string `hcl:"value,optional" json:"value"`
 }
@@ -31,3 +36,7 @@ func (c *RandomUUID) Process() error {
 
 	return nil
 }
+
+func (c *RandomUUID) getKeepers() map[string]string {
+	return c.Keepers
+}
@@ -0,0 +1,130 @@
+package requirements
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Masterminds/semver"
+	"github.com/jumppad-labs/hclconfig/types"
+)
+
+// TypeJumppad is the resource string for a Jumppad resource
+const TypeJumppad string = "jumppad"
+
+// Version is the version of the running jumppad CLI, it is set when the CLI
+// starts, development builds use v0.0.0 or the git commit
+var Version = "v0.0.0"
+
+// releaseVersion matches the versions of jumppad releases e.g. 0.13.0 or
+// v0.13.0-beta.1
+var releaseVersion = regexp.MustCompile(`^v?[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?$`)
+
+// Features are the optional features of this version of jumppad that a
+// blueprint can require
+var Features = []string{
+	"ci",
+	"collect_logs",
+	"monitoring",
+	"oci_blueprints",
+	"rollback",
+	"self_update",
+	"service_mesh",
+}
+
+// Jumppad defines the version of jumppad and the features needed to run a
+// blueprint, the requirements are checked when the configuration is parsed
+// before any resources are created
+type Jumppad struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// RequiredVersion is the version of jumppad e.g. 0.13.0 or a constraint
+	// e.g. >= 0.9, < 0.14
+	RequiredVersion string `hcl:"required_version,optional" json:"required_version,omitempty"`
+
+	// RequiredFeatures are the features of jumppad the blueprint uses
+	RequiredFeatures []string `hcl:"required_features,optional" json:"required_features,omitempty"`
+}
+
+// Parse checks the requirements against the running version of jumppad
+func (j *Jumppad) Parse(conf types.Findable) error {
+	return j.Check(Version, Features)
+}
+
+// Check returns an error when the version does not match the required version
+// or a required feature is not in features, development builds are not
+// checked against the required version
+func (j *Jumppad) Check(current string, features []string) error {
+	if !development(current) {
+		err := CheckVersion(j.RequiredVersion, current)
+		if err != nil {
+			return err
+		}
+	}
+
+	missing := []string{}
+	for _, f := range j.RequiredFeatures {
+		if !contains(features, f) {
+			missing = append(missing, f)
+		}
+	}
+
+	if len(missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(missing)
+
+	return fmt.Errorf("the blueprint requires the features %s which are not supported by jumppad version %s, run 'jumppad self-update' to install the latest version", strings.Join(missing, ", "), current)
+}
+
+// CheckVersion returns an error when the current version of jumppad does not
+// match the constraint, the error contains the command to install a
+// compatible version
+func CheckVersion(constraint, current string) error {
+	if constraint == "" {
+		return nil
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return fmt.Errorf("invalid jumppad version %s: %s", constraint, err)
+	}
+
+	v, err := semver.NewVersion(current)
+	if err != nil {
+		return fmt.Errorf("unable to parse jumppad version %s: %s", current, err)
+	}
+
+	if c.Check(v) {
+		return nil
+	}
+
+	// suggest the pinned version when the blueprint does not use a constraint
+	update := "jumppad self-update"
+	if _, err := semver.NewVersion(constraint); err == nil {
+		update = fmt.Sprintf("jumppad self-update %s", constraint)
+	}
+
+	return fmt.Errorf("the blueprint requires jumppad version %s but the current version is %s, run '%s' to install a compatible version", constraint, current, update)
+}
+
+// development returns true for builds that are not a release, development
+// builds use v0.0.0 or the git commit as the version
+func development(version string) bool {
+	return !releaseVersion.MatchString(version) || strings.TrimPrefix(version, "v") == "0.0.0"
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
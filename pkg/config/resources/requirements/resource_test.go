@@ -0,0 +1,79 @@
+package requirements
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckWithoutRequirementsReturnsNil(t *testing.T) {
+	j := &Jumppad{}
+
+	err := j.Check("0.13.0", Features)
+	require.NoError(t, err)
+}
+
+func TestCheckMatchingVersionAndFeaturesReturnsNil(t *testing.T) {
+	j := &Jumppad{RequiredVersion: ">= 0.9", RequiredFeatures: []string{"rollback"}}
+
+	err := j.Check("v0.13.0", Features)
+	require.NoError(t, err)
+}
+
+func TestCheckOldVersionReturnsUpgradeInstructions(t *testing.T) {
+	j := &Jumppad{RequiredVersion: ">= 0.9"}
+
+	err := j.Check("v0.8.2", Features)
+	require.ErrorContains(t, err, "requires jumppad version >= 0.9 but the current version is v0.8.2")
+	require.ErrorContains(t, err, "run 'jumppad self-update' to install a compatible version")
+}
+
+func TestCheckDevelopmentBuildIgnoresVersion(t *testing.T) {
+	j := &Jumppad{RequiredVersion: ">= 0.9"}
+
+	err := j.Check("v0.0.0", Features)
+	require.NoError(t, err)
+}
+
+func TestCheckCommitBuildIgnoresVersion(t *testing.T) {
+	j := &Jumppad{RequiredVersion: ">= 0.9"}
+
+	err := j.Check("4d5e8f1", Features)
+	require.NoError(t, err)
+
+	err = j.Check("1234567", Features)
+	require.NoError(t, err)
+}
+
+func TestCheckPreReleaseVersionIsChecked(t *testing.T) {
+	j := &Jumppad{RequiredVersion: ">= 0.9"}
+
+	err := j.Check("v0.8.0-beta.1", Features)
+	require.ErrorContains(t, err, "the current version is v0.8.0-beta.1")
+}
+
+func TestCheckMissingFeaturesReturnsUpgradeInstructions(t *testing.T) {
+	j := &Jumppad{RequiredFeatures: []string{"wasm", "rollback", "gpu"}}
+
+	err := j.Check("v0.13.0", []string{"rollback"})
+	require.ErrorContains(t, err, "requires the features gpu, wasm which are not supported by jumppad version v0.13.0")
+	require.ErrorContains(t, err, "run 'jumppad self-update'")
+}
+
+func TestCheckInvalidVersionReturnsError(t *testing.T) {
+	j := &Jumppad{RequiredVersion: "latest"}
+
+	err := j.Check("v0.13.0", Features)
+	require.ErrorContains(t, err, "invalid jumppad version latest")
+}
+
+func TestParseChecksRunningVersion(t *testing.T) {
+	current := Version
+	Version = "v0.8.0"
+	t.Cleanup(func() { Version = current })
+
+	j := &Jumppad{RequiredVersion: ">= 0.9"}
+
+	err := j.Parse(nil)
+	require.ErrorContains(t, err, "the current version is v0.8.0")
+}
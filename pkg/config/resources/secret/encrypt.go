@@ -0,0 +1,64 @@
+package secret
+
+import (
+	"fmt"
+
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// encrypt encrypts a sensitive value before it is written to the state
+func encrypt(id, value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	enc, err := utils.EncryptSecret(value)
+	if err != nil {
+		return "", fmt.Errorf("unable to encrypt secret %s: %w", id, err)
+	}
+
+	return enc, nil
+}
+
+// decrypt decrypts a value read from the state and registers it as sensitive
+// so that it is redacted from logs and output, values that can not be
+// decrypted are reset to an empty value
+func decrypt(id, value string) string {
+	dec := utils.DecryptSecretOrEmpty(id, value)
+	utils.RegisterSensitive(dec)
+
+	return dec
+}
+
+// encryptMap encrypts all the values in the map
+func encryptMap(id string, values map[string]string) (map[string]string, error) {
+	if values == nil {
+		return nil, nil
+	}
+
+	enc := map[string]string{}
+	for k, v := range values {
+		ev, err := encrypt(id, v)
+		if err != nil {
+			return nil, err
+		}
+
+		enc[k] = ev
+	}
+
+	return enc, nil
+}
+
+// decryptMap decrypts all the values in the map
+func decryptMap(id string, values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+
+	dec := map[string]string{}
+	for k, v := range values {
+		dec[k] = decrypt(id, v)
+	}
+
+	return dec
+}
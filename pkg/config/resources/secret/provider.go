@@ -0,0 +1,96 @@
+package secret
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &Provider{}
+
+const secretChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Provider generates values for Secret resources
+type Provider struct {
+	config *Secret
+	log    sdk.Logger
+}
+
+func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Secret)
+	if !ok {
+		return fmt.Errorf("unable to initialize Secret provider, resource is not of type Secret")
+	}
+
+	p.config = c
+	p.log = l
+
+	return nil
+}
+
+// Create generates a random value when a value has not been set
+func (p *Provider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Creating Secret", "ref", p.config.Meta.ID)
+
+	return p.generate()
+}
+
+// generate creates a random value when a value has not been set
+func (p *Provider) generate() error {
+	if p.config.Value != "" {
+		return nil
+	}
+
+	value := make([]byte, p.config.Length)
+	for i := range value {
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(secretChars))))
+		if err != nil {
+			return fmt.Errorf("unable to generate secret: %w", err)
+		}
+
+		value[i] = secretChars[n.Int64()]
+	}
+
+	p.config.Value = string(value)
+	utils.RegisterSensitive(p.config.Value)
+
+	return nil
+}
+
+func (p *Provider) Destroy(ctx context.Context, force bool) error {
+	p.log.Info("Destroy Secret", "ref", p.config.Meta.ID)
+
+	return nil
+}
+
+func (p *Provider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+func (p *Provider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Secret", "ref", p.config.Meta.ID)
+
+	// the value may have been removed from the state
+	return p.generate()
+}
+
+func (p *Provider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return false, nil
+}
@@ -0,0 +1,78 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &EnvProvider{}
+
+// EnvProvider reads secrets from environment variables
+type EnvProvider struct {
+	config *EnvSecret
+	log    sdk.Logger
+}
+
+func (p *EnvProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*EnvSecret)
+	if !ok {
+		return fmt.Errorf("unable to initialize EnvSecret provider, resource is not of type EnvSecret")
+	}
+
+	p.config = c
+	p.log = l
+
+	return nil
+}
+
+func (p *EnvProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Reading secret from environment", "ref", p.config.Meta.ID, "variable", p.config.Variable)
+
+	return p.read()
+}
+
+func (p *EnvProvider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *EnvProvider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+// Refresh reads the secret again so that changes to the environment are
+// picked up on every apply
+func (p *EnvProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh secret from environment", "ref", p.config.Meta.ID)
+
+	return p.read()
+}
+
+func (p *EnvProvider) Changed() (bool, error) {
+	return false, nil
+}
+
+func (p *EnvProvider) read() error {
+	v, err := p.config.lookup()
+	if err != nil {
+		return err
+	}
+
+	utils.RegisterSensitive(v)
+	p.config.Value = v
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func setupEnvProvider(t *testing.T, e *EnvSecret) *EnvProvider {
+	p := &EnvProvider{}
+	err := p.Init(e, logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestEnvCreateReadsVariable(t *testing.T) {
+	t.Setenv("JUMPPAD_TEST_SECRET", "s3cr3t")
+
+	e := &EnvSecret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret_env.test"}}, Variable: "JUMPPAD_TEST_SECRET"}
+	p := setupEnvProvider(t, e)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "s3cr3t", e.Value)
+}
+
+func TestEnvCreateUsesDefault(t *testing.T) {
+	e := &EnvSecret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret_env.test"}}, Variable: "JUMPPAD_TEST_SECRET_UNSET", Default: "default"}
+	p := setupEnvProvider(t, e)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "default", e.Value)
+}
+
+func TestEnvCreateReturnsErrorWhenNotSet(t *testing.T) {
+	e := &EnvSecret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret_env.test"}}, Variable: "JUMPPAD_TEST_SECRET_UNSET"}
+	p := setupEnvProvider(t, e)
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,104 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &OnePasswordProvider{}
+
+// runOp executes the 1Password CLI and returns stdout, replaced in tests
+var runOp = func(ctx context.Context, args ...string) ([]byte, error) {
+	stdout := bytes.NewBuffer(nil)
+	stderr := bytes.NewBuffer(nil)
+
+	cmd := exec.CommandContext(ctx, "op", args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err := cmd.Run()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// OnePasswordProvider reads secrets using the 1Password CLI
+type OnePasswordProvider struct {
+	config *OnePasswordSecret
+	log    sdk.Logger
+}
+
+func (p *OnePasswordProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*OnePasswordSecret)
+	if !ok {
+		return fmt.Errorf("unable to initialize OnePasswordSecret provider, resource is not of type OnePasswordSecret")
+	}
+
+	p.config = c
+	p.log = l
+
+	return nil
+}
+
+func (p *OnePasswordProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Reading secret from 1Password", "ref", p.config.Meta.ID, "reference", p.config.Reference)
+
+	return p.read(ctx)
+}
+
+func (p *OnePasswordProvider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *OnePasswordProvider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+// Refresh reads the secret again so that rotated secrets are picked up on
+// every apply
+func (p *OnePasswordProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh secret from 1Password", "ref", p.config.Meta.ID)
+
+	return p.read(ctx)
+}
+
+func (p *OnePasswordProvider) Changed() (bool, error) {
+	return false, nil
+}
+
+func (p *OnePasswordProvider) read(ctx context.Context) error {
+	args := []string{"read", "--no-newline", p.config.Reference}
+	if p.config.Account != "" {
+		args = append(args, "--account", p.config.Account)
+	}
+
+	out, err := runOp(ctx, args...)
+	if err != nil {
+		return fmt.Errorf("unable to read secret %s from 1Password: %w", p.config.Reference, err)
+	}
+
+	v := string(out)
+	utils.RegisterSensitive(v)
+	p.config.Value = v
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package secret
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOnePasswordProvider(t *testing.T, o *OnePasswordSecret, out string, err error) (*OnePasswordProvider, *[]string) {
+	args := []string{}
+
+	oldRun := runOp
+	runOp = func(ctx context.Context, a ...string) ([]byte, error) {
+		args = append(args, a...)
+		return []byte(out), err
+	}
+
+	t.Cleanup(func() {
+		runOp = oldRun
+	})
+
+	p := &OnePasswordProvider{}
+	perr := p.Init(o, logger.NewTestLogger(t))
+	require.NoError(t, perr)
+
+	return p, &args
+}
+
+func TestOnePasswordCreateReadsSecret(t *testing.T) {
+	o := &OnePasswordSecret{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret_op.test"}},
+		Reference:    "op://dev/db/password",
+		Account:      "my.1password.com",
+	}
+	p, args := setupOnePasswordProvider(t, o, "s3cr3t", nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "s3cr3t", o.Value)
+	require.Equal(t, []string{"read", "--no-newline", "op://dev/db/password", "--account", "my.1password.com"}, *args)
+}
+
+func TestOnePasswordCreateReturnsErrorOnFailure(t *testing.T) {
+	o := &OnePasswordSecret{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret_op.test"}},
+		Reference:    "op://dev/db/password",
+	}
+	p, _ := setupOnePasswordProvider(t, o, "", fmt.Errorf("not signed in"))
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "not signed in")
+}
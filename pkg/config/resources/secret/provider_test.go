@@ -0,0 +1,38 @@
+package secret
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func setupProvider(t *testing.T, s *Secret) *Provider {
+	p := &Provider{}
+	err := p.Init(s, logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestCreateGeneratesValue(t *testing.T) {
+	s := &Secret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret.test"}}, Length: 16}
+	p := setupProvider(t, s)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Len(t, s.Value, 16)
+}
+
+func TestCreateKeepsExistingValue(t *testing.T) {
+	s := &Secret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret.test"}}, Value: "abc", Length: 16}
+	p := setupProvider(t, s)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "abc", s.Value)
+}
@@ -0,0 +1,157 @@
+package secret
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &VaultProvider{}
+
+// VaultProvider reads secrets from HashiCorp Vault using the HTTP API
+type VaultProvider struct {
+	config *VaultSecret
+	log    sdk.Logger
+	client http.Client
+}
+
+func (p *VaultProvider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*VaultSecret)
+	if !ok {
+		return fmt.Errorf("unable to initialize VaultSecret provider, resource is not of type VaultSecret")
+	}
+
+	p.config = c
+	p.log = l
+	p.client = http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	return nil
+}
+
+func (p *VaultProvider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Reading secret from Vault", "ref", p.config.Meta.ID, "path", p.config.Path)
+
+	return p.read(ctx)
+}
+
+func (p *VaultProvider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *VaultProvider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+// Refresh reads the secret again so that rotated secrets are picked up on
+// every apply
+func (p *VaultProvider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh secret from Vault", "ref", p.config.Meta.ID)
+
+	return p.read(ctx)
+}
+
+func (p *VaultProvider) Changed() (bool, error) {
+	return false, nil
+}
+
+type vaultResponse struct {
+	Data map[string]interface{} `json:"data"`
+}
+
+func (p *VaultProvider) read(ctx context.Context) error {
+	addr := fmt.Sprintf("%s/v1/%s", strings.TrimSuffix(p.config.Address, "/"), strings.TrimPrefix(p.config.Path, "/"))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create request for Vault: %w", err)
+	}
+
+	if p.config.Token != "" {
+		req.Header.Set("X-Vault-Token", p.config.Token)
+	}
+
+	if p.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.config.Namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to read secret %s from Vault: %w", p.config.Path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to read secret %s from Vault: %w", p.config.Path, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to read secret %s from Vault, status code %d: %s", p.config.Path, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	vr := vaultResponse{}
+	err = json.Unmarshal(body, &vr)
+	if err != nil {
+		return fmt.Errorf("unable to parse response from Vault: %w", err)
+	}
+
+	data := vr.Data
+
+	// KV version 2 secrets nest the values in a data field alongside the metadata
+	if d, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = d
+		}
+	}
+
+	values := map[string]string{}
+	for k, v := range data {
+		values[k] = vaultValue(v)
+		utils.RegisterSensitive(values[k])
+	}
+
+	p.config.Values = values
+	p.config.Value = ""
+
+	if p.config.Key != "" {
+		v, ok := values[p.config.Key]
+		if !ok {
+			return fmt.Errorf("key %s not found in secret %s", p.config.Key, p.config.Path)
+		}
+
+		p.config.Value = v
+	}
+
+	return nil
+}
+
+// vaultValue converts a value from the Vault response to a string, values
+// that are not strings are returned as JSON
+func vaultValue(v interface{}) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+
+	d, _ := json.Marshal(v)
+	return string(d)
+}
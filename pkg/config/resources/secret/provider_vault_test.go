@@ -0,0 +1,73 @@
+package secret
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func setupVaultProvider(t *testing.T, path, body string) (*VaultSecret, *VaultProvider) {
+	s := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/"+path || r.Header.Get("X-Vault-Token") != "root" {
+			rw.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		rw.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+
+	v := &VaultSecret{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret_vault.test"}},
+		Address:      s.URL,
+		Token:        "root",
+		Path:         path,
+	}
+
+	p := &VaultProvider{}
+	err := p.Init(v, logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	return v, p
+}
+
+func TestVaultCreateReadsKVV2Secret(t *testing.T) {
+	v, p := setupVaultProvider(t, "secret/data/app", `{"data": {"data": {"password": "s3cr3t", "port": 5432}, "metadata": {"version": 1}}}`)
+	v.Key = "password"
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, "s3cr3t", v.Value)
+	require.Equal(t, map[string]string{"password": "s3cr3t", "port": "5432"}, v.Values)
+}
+
+func TestVaultCreateReadsKVV1Secret(t *testing.T) {
+	v, p := setupVaultProvider(t, "kv/app", `{"data": {"password": "s3cr3t"}}`)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{"password": "s3cr3t"}, v.Values)
+}
+
+func TestVaultCreateReturnsErrorWhenKeyMissing(t *testing.T) {
+	v, p := setupVaultProvider(t, "kv/app", `{"data": {"password": "s3cr3t"}}`)
+	v.Key = "username"
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
+
+func TestVaultCreateReturnsErrorOnForbidden(t *testing.T) {
+	v, p := setupVaultProvider(t, "kv/app", `{}`)
+	v.Token = "bad"
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,102 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// TypeSecret is the resource string for a Secret resource
+const TypeSecret string = "secret"
+
+// defaultLength is the length of generated secrets
+const defaultLength = 32
+
+// Secret holds a sensitive value such as a token or password. The value is
+// encrypted when written to the state using the local secrets key and is
+// redacted from logs and the output of `jumppad output`. Any attribute that
+// interpolates the value, such as the environment variables of a container,
+// is also encrypted in the state.
+// When the secrets key is removed or rotated the value can not be decrypted,
+// a warning is shown and generated values are created again.
+type Secret struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Value of the secret, when not set a random value is generated
+	Value string `hcl:"value,optional" json:"value,omitempty"`
+
+	// Length of the generated value, defaults to 32
+	Length int `hcl:"length,optional" json:"length,omitempty"`
+}
+
+func (s *Secret) Process() error {
+	if s.Length == 0 {
+		s.Length = defaultLength
+	}
+
+	if s.Length < 0 {
+		return fmt.Errorf("invalid length %d, length must be greater than 0", s.Length)
+	}
+
+	// generated values are stored in the state
+	if s.Value == "" {
+		r, err := findState(s.Meta.ID)
+		if err == nil {
+			s.Value = r.(*Secret).Value
+		}
+	}
+
+	utils.RegisterSensitive(s.Value)
+
+	return nil
+}
+
+// findState returns the resource with the given id from the state
+func findState(id string) (types.Resource, error) {
+	cfg, err := config.LoadState()
+	if err != nil {
+		return nil, err
+	}
+
+	return cfg.FindResource(id)
+}
+
+// secretJSON is used to serialize the secret without recursing into
+// MarshalJSON
+type secretJSON Secret
+
+// MarshalJSON encrypts the value so that it is not written to the state in
+// plain text
+func (s *Secret) MarshalJSON() ([]byte, error) {
+	sj := secretJSON(*s)
+
+	var err error
+	sj.Value, err = encrypt(s.Meta.ID, sj.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(sj)
+}
+
+// UnmarshalJSON decrypts the value encrypted by MarshalJSON, when the value
+// can not be decrypted it is reset to an empty value
+func (s *Secret) UnmarshalJSON(d []byte) error {
+	sj := secretJSON{}
+
+	err := json.Unmarshal(d, &sj)
+	if err != nil {
+		return err
+	}
+
+	sj.Value = decrypt(sj.Meta.ID, sj.Value)
+
+	*s = Secret(sj)
+
+	return nil
+}
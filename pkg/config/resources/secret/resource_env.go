@@ -0,0 +1,100 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// TypeEnvSecret is the resource string for a EnvSecret resource
+const TypeEnvSecret string = "secret_env"
+
+// EnvSecret reads a sensitive value from an environment variable when the
+// blueprint is applied.
+type EnvSecret struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Variable is the name of the environment variable to read
+	Variable string `hcl:"variable" json:"variable"`
+	// Default value used when the environment variable is not set, when not
+	// specified an unset variable is an error
+	Default string `hcl:"default,optional" json:"default,omitempty"`
+
+	// Output parameters
+
+	// Value of the environment variable
+	Value string `hcl:"value,optional" json:"value,omitempty"`
+}
+
+func (e *EnvSecret) Process() error {
+	if e.Variable == "" {
+		return fmt.Errorf("variable must be specified")
+	}
+
+	utils.RegisterSensitive(e.Default)
+
+	// the value is read on apply, set the last known value from the state
+	// so that it is available to other commands
+	r, err := findState(e.Meta.ID)
+	if err == nil {
+		e.Value = r.(*EnvSecret).Value
+	}
+
+	return nil
+}
+
+// lookup returns the value of the environment variable
+func (e *EnvSecret) lookup() (string, error) {
+	v, ok := os.LookupEnv(e.Variable)
+	if ok {
+		return v, nil
+	}
+
+	if e.Default != "" {
+		return e.Default, nil
+	}
+
+	return "", fmt.Errorf("environment variable %s is not set", e.Variable)
+}
+
+type envSecretJSON EnvSecret
+
+// MarshalJSON encrypts the sensitive values before they are written to the state
+func (e *EnvSecret) MarshalJSON() ([]byte, error) {
+	ej := envSecretJSON(*e)
+
+	var err error
+	ej.Value, err = encrypt(e.Meta.ID, ej.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	ej.Default, err = encrypt(e.Meta.ID, ej.Default)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(ej)
+}
+
+// UnmarshalJSON decrypts the values encrypted by MarshalJSON
+func (e *EnvSecret) UnmarshalJSON(d []byte) error {
+	ej := envSecretJSON{}
+
+	err := json.Unmarshal(d, &ej)
+	if err != nil {
+		return err
+	}
+
+	ej.Value = decrypt(ej.Meta.ID, ej.Value)
+	ej.Default = decrypt(ej.Meta.ID, ej.Default)
+
+	*e = EnvSecret(ej)
+
+	return nil
+}
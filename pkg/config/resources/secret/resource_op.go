@@ -0,0 +1,76 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig/types"
+)
+
+// TypeOnePasswordSecret is the resource string for a OnePasswordSecret resource
+const TypeOnePasswordSecret string = "secret_op"
+
+// OnePasswordSecret reads a secret using the 1Password CLI `op` when the
+// blueprint is applied. The CLI must be installed and signed in.
+type OnePasswordSecret struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Reference to the secret, e.g. op://vault/item/field
+	Reference string `hcl:"reference" json:"reference"`
+	// Account to use when signed in to multiple accounts, optional
+	Account string `hcl:"account,optional" json:"account,omitempty"`
+
+	// Output parameters
+
+	// Value of the secret
+	Value string `hcl:"value,optional" json:"value,omitempty"`
+}
+
+func (o *OnePasswordSecret) Process() error {
+	if !strings.HasPrefix(o.Reference, "op://") {
+		return fmt.Errorf("invalid reference %q, reference must be in the format op://vault/item/field", o.Reference)
+	}
+
+	// the value is read on apply, set the last known value from the state
+	// so that it is available to other commands
+	r, err := findState(o.Meta.ID)
+	if err == nil {
+		o.Value = r.(*OnePasswordSecret).Value
+	}
+
+	return nil
+}
+
+type onePasswordSecretJSON OnePasswordSecret
+
+// MarshalJSON encrypts the sensitive values before they are written to the state
+func (o *OnePasswordSecret) MarshalJSON() ([]byte, error) {
+	oj := onePasswordSecretJSON(*o)
+
+	var err error
+	oj.Value, err = encrypt(o.Meta.ID, oj.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(oj)
+}
+
+// UnmarshalJSON decrypts the values encrypted by MarshalJSON
+func (o *OnePasswordSecret) UnmarshalJSON(d []byte) error {
+	oj := onePasswordSecretJSON{}
+
+	err := json.Unmarshal(d, &oj)
+	if err != nil {
+		return err
+	}
+
+	oj.Value = decrypt(oj.Meta.ID, oj.Value)
+
+	*o = OnePasswordSecret(oj)
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package secret
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	config.RegisterResource(TypeSecret, &Secret{}, &Provider{})
+}
+
+func TestSecretProcessSetsDefaults(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	s := &Secret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret.test"}}}
+
+	err := s.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, defaultLength, s.Length)
+}
+
+func TestSecretProcessReturnsErrorWhenLengthInvalid(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	s := &Secret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret.test"}}, Length: -1}
+
+	err := s.Process()
+	require.Error(t, err)
+}
+
+func TestSecretMarshalJSONEncryptsValue(t *testing.T) {
+	testutils.SetupState(t, "")
+
+	s := &Secret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret.test"}}, Value: "t0k3n"}
+
+	d, err := json.Marshal(s)
+	require.NoError(t, err)
+	require.NotContains(t, string(d), "t0k3n")
+
+	s2 := &Secret{}
+	err = json.Unmarshal(d, s2)
+	require.NoError(t, err)
+
+	require.Equal(t, "t0k3n", s2.Value)
+	require.Equal(t, "resource.secret.test", s2.Meta.ID)
+}
+
+func TestSecretUnmarshalJSONWithChangedKeyResetsValue(t *testing.T) {
+	testutils.SetupState(t, "")
+	t.Setenv(utils.SecretsKeyEnvVar, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+
+	s := &Secret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret.test"}}, Value: "t0k3n"}
+
+	d, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	// rotate the key
+	key := make([]byte, 32)
+	key[0] = 1
+	t.Setenv(utils.SecretsKeyEnvVar, base64.StdEncoding.EncodeToString(key))
+
+	s2 := &Secret{}
+	err = json.Unmarshal(d, s2)
+	require.NoError(t, err)
+
+	require.Equal(t, "", s2.Value)
+	require.Equal(t, "resource.secret.test", s2.Meta.ID)
+}
+
+func TestSecretLoadsValueFromState(t *testing.T) {
+	t.Setenv(utils.SecretsKeyEnvVar, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+
+	enc, err := utils.EncryptSecret("from-state")
+	require.NoError(t, err)
+
+	testutils.SetupState(t, fmt.Sprintf(`
+{
+  "blueprint": null,
+  "resources": [
+	{
+		"meta": {
+			"id": "resource.secret.test",
+			"name": "test",
+			"type": "secret"
+		},
+		"value": "%s",
+		"length": 32
+	}
+  ]
+}`, enc))
+
+	s := &Secret{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.secret.test"}}}
+
+	err = s.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "from-state", s.Value)
+}
@@ -0,0 +1,117 @@
+package secret
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// TypeVaultSecret is the resource string for a VaultSecret resource
+const TypeVaultSecret string = "secret_vault"
+
+// VaultSecret reads a secret from HashiCorp Vault when the blueprint is
+// applied. Both KV version 1 and version 2 secrets are supported.
+type VaultSecret struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Address of the Vault server, defaults to the environment variable VAULT_ADDR
+	Address string `hcl:"address,optional" json:"address,omitempty"`
+	// Token used to authenticate, defaults to the environment variable VAULT_TOKEN
+	Token string `hcl:"token,optional" json:"token,omitempty"`
+	// Namespace for Vault Enterprise, defaults to the environment variable VAULT_NAMESPACE
+	Namespace string `hcl:"namespace,optional" json:"namespace,omitempty"`
+	// Path of the secret including the mount, e.g. secret/data/myapp
+	Path string `hcl:"path" json:"path"`
+	// Key in the secret to set as value, optional
+	Key string `hcl:"key,optional" json:"key,omitempty"`
+
+	// Output parameters
+
+	// Value of the key in the secret, only set when key is specified
+	Value string `hcl:"value,optional" json:"value,omitempty"`
+	// Values contains all the keys in the secret
+	Values map[string]string `hcl:"values,optional" json:"values,omitempty"`
+}
+
+func (v *VaultSecret) Process() error {
+	if v.Path == "" {
+		return fmt.Errorf("path must be specified")
+	}
+
+	if v.Address == "" {
+		v.Address = os.Getenv("VAULT_ADDR")
+	}
+
+	if v.Address == "" {
+		return fmt.Errorf("address must be specified or the environment variable VAULT_ADDR set")
+	}
+
+	if v.Token == "" {
+		v.Token = os.Getenv("VAULT_TOKEN")
+	}
+
+	if v.Namespace == "" {
+		v.Namespace = os.Getenv("VAULT_NAMESPACE")
+	}
+
+	utils.RegisterSensitive(v.Token)
+
+	// the value is read on apply, set the last known values from the state
+	// so that they are available to other commands
+	r, err := findState(v.Meta.ID)
+	if err == nil {
+		kstate := r.(*VaultSecret)
+		v.Value = kstate.Value
+		v.Values = kstate.Values
+	}
+
+	return nil
+}
+
+type vaultSecretJSON VaultSecret
+
+// MarshalJSON encrypts the sensitive values before they are written to the state
+func (v *VaultSecret) MarshalJSON() ([]byte, error) {
+	vj := vaultSecretJSON(*v)
+
+	var err error
+	vj.Token, err = encrypt(v.Meta.ID, vj.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	vj.Value, err = encrypt(v.Meta.ID, vj.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	vj.Values, err = encryptMap(v.Meta.ID, vj.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(vj)
+}
+
+// UnmarshalJSON decrypts the values encrypted by MarshalJSON
+func (v *VaultSecret) UnmarshalJSON(d []byte) error {
+	vj := vaultSecretJSON{}
+
+	err := json.Unmarshal(d, &vj)
+	if err != nil {
+		return err
+	}
+
+	vj.Token = decrypt(vj.Meta.ID, vj.Token)
+	vj.Value = decrypt(vj.Meta.ID, vj.Value)
+	vj.Values = decryptMap(vj.Meta.ID, vj.Values)
+
+	*v = VaultSecret(vj)
+
+	return nil
+}
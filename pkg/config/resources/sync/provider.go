@@ -0,0 +1,164 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &Provider{}
+
+// helperImage is used to mount volumes when syncing to a volume
+const helperImage = "alpine:latest"
+
+// Provider performs the initial sync for Sync resources, for volumes a
+// helper container that mounts the volume is created
+type Provider struct {
+	config *Sync
+	client container.ContainerTasks
+	log    sdk.Logger
+}
+
+func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Sync)
+	if !ok {
+		return fmt.Errorf("unable to initialize Sync provider, resource is not of type Sync")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.client = cli.ContainerTasks
+	p.log = l
+
+	return nil
+}
+
+// Create syncs the source to the destination
+func (p *Provider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping create, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Creating Sync", "ref", p.config.Meta.ID, "source", p.config.Source, "destination", p.config.Destination)
+
+	if p.config.Volume != "" {
+		err := p.createHelper()
+		if err != nil {
+			return err
+		}
+	}
+
+	return p.sync()
+}
+
+// Destroy removes the helper container, synced files are not removed
+func (p *Provider) Destroy(ctx context.Context, force bool) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping destroy, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Destroy Sync", "ref", p.config.Meta.ID)
+
+	if p.config.Volume == "" {
+		return nil
+	}
+
+	ids, err := p.client.FindContainerIDs(p.config.containerName())
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ids {
+		err := p.client.RemoveContainer(id, force)
+		if err != nil {
+			return fmt.Errorf("unable to remove helper container %s: %w", p.config.containerName(), err)
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) Lookup() ([]string, error) {
+	if p.config.Volume == "" {
+		return []string{}, nil
+	}
+
+	return p.client.FindContainerIDs(p.config.containerName())
+}
+
+// Refresh syncs the source so that changes made while `jumppad dev` was not
+// running are applied
+func (p *Provider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Skipping refresh, context cancelled", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Sync", "ref", p.config.Meta.ID)
+
+	return p.sync()
+}
+
+func (p *Provider) Changed() (bool, error) {
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	return false, nil
+}
+
+func (p *Provider) sync() error {
+	count, err := NewSyncer(p.config, p.client, p.log).Sync()
+	if err != nil {
+		return err
+	}
+
+	p.log.Debug("Synced files", "ref", p.config.Meta.ID, "count", count)
+
+	return nil
+}
+
+// createHelper creates a container that mounts the volume so that files can
+// be copied to it
+func (p *Provider) createHelper() error {
+	ids, err := p.client.FindContainerIDs(p.config.containerName())
+	if err == nil && len(ids) > 0 {
+		return nil
+	}
+
+	err = p.client.PullImage(types.Image{Name: helperImage}, false)
+	if err != nil {
+		return fmt.Errorf("unable to pull image %s: %w", helperImage, err)
+	}
+
+	c := &types.Container{
+		Name:    p.config.containerName(),
+		Image:   &types.Image{Name: helperImage},
+		Command: []string{"tail", "-f", "/dev/null"},
+		Labels:  config.DockerLabels(p.config),
+		Volumes: []types.Volume{
+			{
+				Source:      p.config.Volume,
+				Destination: volumeMountPath,
+				Type:        "volume",
+			},
+		},
+	}
+
+	_, err = p.client.CreateContainer(c)
+	if err != nil {
+		return fmt.Errorf("unable to create helper container for volume %s: %w", p.config.Volume, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package sync
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSyncProvider(t *testing.T) (*Provider, *mocks.ContainerTasks) {
+	testutils.SetupState(t, "")
+
+	s := testSync(t)
+	err := s.Process()
+	require.NoError(t, err)
+
+	mc := &mocks.ContainerTasks{}
+	mc.On("FindContainerIDs", "app.container.local.jmpd.in").Return([]string{"abc"}, nil)
+	mc.On("FindContainerIDs", "test.sync.local.jmpd.in").Return([]string{}, nil)
+	mc.On("PullImage", mock.Anything, false).Return(nil)
+	mc.On("CreateContainer", mock.Anything).Return("helper", nil)
+	mc.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(0, nil)
+	mc.On("CopyFileToContainer", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	p := &Provider{
+		config: s,
+		client: mc,
+		log:    logger.NewTestLogger(t),
+	}
+
+	return p, mc
+}
+
+func copiedFiles(mc *mocks.ContainerTasks) []string {
+	files := []string{}
+	for _, c := range testutils.GetCalls(&mc.Mock, "CopyFileToContainer") {
+		files = append(files, filepath.Base(c.Arguments.String(1))+":"+c.Arguments.String(2))
+	}
+
+	sort.Strings(files)
+
+	return files
+}
+
+func TestSyncCreateCopiesFilesToContainer(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"app.log:/app/sub", "main.go:/app"}, copiedFiles(mc))
+	mc.AssertCalled(t, "ExecuteCommand", "abc", []string{"mkdir", "-p", "/app/sub"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mc.AssertNotCalled(t, "CreateContainer", mock.Anything)
+}
+
+func TestSyncCreateSkipsIgnoredFiles(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	p.config.Ignore = []string{"*.log"}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"main.go:/app"}, copiedFiles(mc))
+}
+
+func TestSyncCreateWithVolumeCreatesHelper(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	p.config.Target = nil
+	p.config.Volume = "data"
+
+	testutils.RemoveOn(&mc.Mock, "FindContainerIDs")
+	mc.On("FindContainerIDs", "test.sync.local.jmpd.in").Once().Return([]string{}, nil)
+	mc.On("FindContainerIDs", "test.sync.local.jmpd.in").Return([]string{"helper"}, nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	c := testutils.GetCalls(&mc.Mock, "CreateContainer")[0].Arguments[0].(*types.Container)
+	require.Equal(t, "data", c.Volumes[0].Source)
+	require.Equal(t, volumeMountPath, c.Volumes[0].Destination)
+
+	require.Equal(t, []string{"app.log:/sync/app/sub", "main.go:/sync/app"}, copiedFiles(mc))
+}
+
+func TestSyncCreateReturnsErrorWhenTargetNotFound(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+
+	testutils.RemoveOn(&mc.Mock, "FindContainerIDs")
+	mc.On("FindContainerIDs", mock.Anything).Return([]string{}, nil)
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
+
+func TestSyncDestroyRemovesHelper(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	p.config.Target = nil
+	p.config.Volume = "data"
+
+	testutils.RemoveOn(&mc.Mock, "FindContainerIDs")
+	mc.On("FindContainerIDs", "test.sync.local.jmpd.in").Return([]string{"helper"}, nil)
+	mc.On("RemoveContainer", "helper", false).Return(nil)
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	mc.AssertCalled(t, "RemoveContainer", "helper", false)
+}
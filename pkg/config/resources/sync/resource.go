@@ -0,0 +1,93 @@
+package sync
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// TypeSync is the string resource type for Sync resources
+const TypeSync string = "sync"
+
+// volumeMountPath is the path the volume is mounted to in the helper container
+const volumeMountPath = "/sync"
+
+// Sync mirrors a local directory into a running container or a volume. The
+// initial sync happens when the resource is created, `jumppad dev` keeps the
+// destination up to date by watching the source for changes. When
+// bidirectional is set, changes made in the destination are also synced back
+// to the source.
+type Sync struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Source      string `hcl:"source" json:"source"`           // Local directory to sync
+	Destination string `hcl:"destination" json:"destination"` // Absolute path in the container or volume to sync to
+
+	Target *ctypes.Container `hcl:"target,optional" json:"target,omitempty"` // Container to sync the files to
+	Volume string            `hcl:"volume,optional" json:"volume,omitempty"` // Volume to sync the files to
+
+	// Ignore is a list of glob patterns for files and directories that should
+	// not be synced, patterns are matched against the path relative to the
+	// source and the file name
+	Ignore []string `hcl:"ignore,optional" json:"ignore,omitempty"`
+
+	// Bidirectional syncs files that are created, changed or removed in the
+	// destination back to the source while `jumppad dev` is running
+	Bidirectional bool `hcl:"bidirectional,optional" json:"bidirectional,omitempty"`
+}
+
+func (s *Sync) Process() error {
+	s.Source = utils.EnsureAbsolute(s.Source, s.Meta.File)
+
+	fi, err := os.Stat(s.Source)
+	if err != nil {
+		return fmt.Errorf("source %s does not exist", s.Source)
+	}
+
+	if !fi.IsDir() {
+		return fmt.Errorf("source %s must be a directory", s.Source)
+	}
+
+	if !path.IsAbs(s.Destination) {
+		return fmt.Errorf("destination %s must be an absolute path", s.Destination)
+	}
+
+	if (s.Target == nil) == (s.Volume == "") {
+		return fmt.Errorf("exactly one of target or volume must be specified")
+	}
+
+	for _, i := range s.Ignore {
+		if _, err := filepath.Match(i, ""); err != nil {
+			return fmt.Errorf("invalid ignore pattern %s: %s", i, err)
+		}
+	}
+
+	return nil
+}
+
+// containerName returns the name of the container that the files are
+// synced to, for volumes this is a helper container that mounts the volume
+func (s *Sync) containerName() string {
+	if s.Target != nil {
+		return s.Target.ContainerName
+	}
+
+	return utils.FQDN(s.Meta.Name, s.Meta.Module, s.Meta.Type)
+}
+
+// destinationPath returns the path in the container the files are synced to
+func (s *Sync) destinationPath() string {
+	if s.Target != nil {
+		return s.Destination
+	}
+
+	return path.Join(volumeMountPath, s.Destination)
+}
@@ -0,0 +1,80 @@
+package sync
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	config.RegisterResource(TypeSync, &Sync{}, &Provider{})
+}
+
+func testSync(t *testing.T) *Sync {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "src", "sub"), 0755)
+	os.WriteFile(filepath.Join(dir, "src", "main.go"), []byte("package main"), 0644)
+	os.WriteFile(filepath.Join(dir, "src", "sub", "app.log"), []byte("log"), 0644)
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(""), 0644)
+
+	return &Sync{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.sync.test", Name: "test", Type: TypeSync, File: filepath.Join(dir, "main.hcl")}},
+		Source:       "./src",
+		Destination:  "/app",
+		Target:       &ctypes.Container{ContainerName: "app.container.local.jmpd.in"},
+	}
+}
+
+func TestSyncProcessSetsAbsoluteSource(t *testing.T) {
+	s := testSync(t)
+
+	err := s.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, filepath.Join(filepath.Dir(s.Meta.File), "src"), s.Source)
+}
+
+func TestSyncProcessReturnsErrorWhenSourceNotDirectory(t *testing.T) {
+	s := testSync(t)
+	s.Source = "./src/main.go"
+
+	err := s.Process()
+	require.ErrorContains(t, err, "must be a directory")
+}
+
+func TestSyncProcessReturnsErrorWhenDestinationRelative(t *testing.T) {
+	s := testSync(t)
+	s.Destination = "app"
+
+	err := s.Process()
+	require.ErrorContains(t, err, "absolute path")
+}
+
+func TestSyncProcessReturnsErrorWhenTargetAndVolume(t *testing.T) {
+	s := testSync(t)
+	s.Volume = "data"
+
+	err := s.Process()
+	require.ErrorContains(t, err, "exactly one of target or volume")
+}
+
+func TestSyncProcessReturnsErrorWhenNoTargetOrVolume(t *testing.T) {
+	s := testSync(t)
+	s.Target = nil
+
+	err := s.Process()
+	require.ErrorContains(t, err, "exactly one of target or volume")
+}
+
+func TestSyncProcessReturnsErrorWhenIgnoreInvalid(t *testing.T) {
+	s := testSync(t)
+	s.Ignore = []string{"[a-"}
+
+	err := s.Process()
+	require.ErrorContains(t, err, "invalid ignore pattern")
+}
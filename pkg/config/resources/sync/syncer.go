@@ -0,0 +1,445 @@
+package sync
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+// debounce is the time to wait for further changes before syncing, editors
+// often write a file multiple times when saving
+const debounce = 200 * time.Millisecond
+
+// pollInterval is how often the destination is checked for changes when
+// syncing bidirectionally, files in a container can not be watched
+const pollInterval = time.Second
+
+// Syncer mirrors the files in the source directory of a Sync resource to the
+// destination in a container
+type Syncer struct {
+	config *Sync
+	client container.ContainerTasks
+	log    sdk.Logger
+
+	// hashes holds the checksum of the files as they were last synced in
+	// either direction, keyed by the path relative to the source. This stops
+	// a file that was copied from one side being copied straight back.
+	hashes map[string]string
+}
+
+// NewSyncer creates a new Syncer for the given resource
+func NewSyncer(c *Sync, client container.ContainerTasks, l sdk.Logger) *Syncer {
+	return &Syncer{config: c, client: client, log: l, hashes: map[string]string{}}
+}
+
+// Sync copies all the files in the source to the destination, the number of
+// files copied is returned
+func (s *Syncer) Sync() (int, error) {
+	id, err := s.findContainer()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = filepath.WalkDir(s.config.Source, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if s.ignored(p) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		count++
+		return s.copyFile(id, p)
+	})
+
+	if err != nil {
+		return count, fmt.Errorf("unable to sync %s: %w", s.config.Source, err)
+	}
+
+	return count, nil
+}
+
+// Watch watches the source for changes and syncs them to the destination
+// until the context is cancelled. When the resource is bidirectional the
+// destination is polled for changes which are synced back to the source.
+func (s *Syncer) Watch(ctx context.Context) error {
+	id, err := s.findContainer()
+	if err != nil {
+		return err
+	}
+
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to create file watcher: %w", err)
+	}
+	defer w.Close()
+
+	err = s.watchDir(w, s.config.Source)
+	if err != nil {
+		return err
+	}
+
+	s.log.Debug("Watching for changes", "ref", s.config.Meta.ID, "source", s.config.Source)
+
+	pending := map[string]bool{}
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+
+	// a nil channel blocks forever, the destination is only polled when
+	// syncing bidirectionally
+	var poll <-chan time.Time
+	if s.config.Bidirectional {
+		t := time.NewTicker(pollInterval)
+		defer t.Stop()
+
+		poll = t.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case e, ok := <-w.Events:
+			if !ok {
+				return nil
+			}
+
+			if s.ignored(e.Name) {
+				continue
+			}
+
+			// fsnotify does not watch recursively, new directories need to be
+			// added to the watcher
+			if e.Has(fsnotify.Create) {
+				if fi, err := os.Stat(e.Name); err == nil && fi.IsDir() {
+					if err := s.watchDir(w, e.Name); err != nil {
+						s.log.Warn("Unable to watch directory", "ref", s.config.Meta.ID, "path", e.Name, "error", err)
+					}
+				}
+			}
+
+			pending[e.Name] = true
+			timer.Reset(debounce)
+
+		case err, ok := <-w.Errors:
+			if !ok {
+				return nil
+			}
+
+			s.log.Warn("Error watching for changes", "ref", s.config.Meta.ID, "error", err)
+
+		case <-timer.C:
+			for p := range pending {
+				err := s.syncPath(id, p)
+				if err != nil {
+					s.log.Warn("Unable to sync file", "ref", s.config.Meta.ID, "path", p, "error", err)
+				}
+			}
+
+			pending = map[string]bool{}
+
+		case <-poll:
+			err := s.pull(id)
+			if err != nil {
+				s.log.Warn("Unable to sync changes from destination", "ref", s.config.Meta.ID, "error", err)
+			}
+		}
+	}
+}
+
+// syncPath syncs a single changed path, paths that no longer exist are
+// removed from the destination
+func (s *Syncer) syncPath(id, p string) error {
+	fi, err := os.Stat(p)
+	if err != nil {
+		dest, err := s.destination(p)
+		if err != nil {
+			return err
+		}
+
+		s.log.Debug("Removing file", "ref", s.config.Meta.ID, "path", dest)
+
+		_, err = s.client.ExecuteCommand(id, []string{"rm", "-rf", dest}, nil, "/", "", "", 30, nil)
+		if err != nil {
+			return err
+		}
+
+		s.forget(p)
+		return nil
+	}
+
+	if !fi.IsDir() {
+		return s.copyFile(id, p)
+	}
+
+	return filepath.WalkDir(p, func(f string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if s.ignored(f) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if d.IsDir() {
+			return nil
+		}
+
+		return s.copyFile(id, f)
+	})
+}
+
+// copyFile copies the file at p to the matching path in the destination
+func (s *Syncer) copyFile(id, p string) error {
+	dest, err := s.destination(p)
+	if err != nil {
+		return err
+	}
+
+	hash, err := fileHash(p)
+	if err != nil {
+		return err
+	}
+
+	rel := s.relative(p)
+	if s.hashes[rel] == hash {
+		// the file has not changed since it was last synced
+		return nil
+	}
+
+	dir := path.Dir(dest)
+
+	s.log.Debug("Syncing file", "ref", s.config.Meta.ID, "source", p, "destination", dest)
+
+	_, err = s.client.ExecuteCommand(id, []string{"mkdir", "-p", dir}, nil, "/", "", "", 30, nil)
+	if err != nil {
+		return fmt.Errorf("unable to create directory %s: %w", dir, err)
+	}
+
+	err = s.client.CopyFileToContainer(id, p, dir)
+	if err != nil {
+		return err
+	}
+
+	s.hashes[rel] = hash
+	return nil
+}
+
+// pull syncs the files that were created or changed in the destination back
+// to the source, files that were removed from the destination since they were
+// last synced are removed from the source. When a file changes on both sides
+// between polls the destination wins.
+func (s *Syncer) pull(id string) error {
+	dest := path.Clean(s.config.destinationPath())
+	out := bytes.NewBuffer(nil)
+
+	// a failed listing must not be treated as an empty destination, that
+	// would remove every file from the source
+	_, err := s.client.ExecuteCommand(id, []string{"find", dest, "-type", "f", "-exec", "sha256sum", "{}", "+"}, nil, "/", "", "", 30, out)
+	if err != nil {
+		return fmt.Errorf("unable to list files in %s: %w", dest, err)
+	}
+
+	found := map[string]bool{}
+	for _, l := range strings.Split(out.String(), "\n") {
+		hash, file, ok := strings.Cut(strings.TrimSpace(l), "  ")
+		if !ok || !strings.HasPrefix(file, dest+"/") {
+			continue
+		}
+
+		rel := filepath.FromSlash(strings.TrimPrefix(file, dest+"/"))
+		local := filepath.Join(s.config.Source, rel)
+
+		if s.ignoredTree(local) {
+			continue
+		}
+
+		found[rel] = true
+
+		if s.hashes[rel] == hash {
+			continue
+		}
+
+		// the file may already be the same as the source, for example on
+		// the first poll after the initial sync
+		if h, err := fileHash(local); err == nil && h == hash {
+			s.hashes[rel] = hash
+			continue
+		}
+
+		s.log.Debug("Syncing file from destination", "ref", s.config.Meta.ID, "source", file, "destination", local)
+
+		err := os.MkdirAll(filepath.Dir(local), os.ModePerm)
+		if err != nil {
+			return fmt.Errorf("unable to create directory %s: %w", filepath.Dir(local), err)
+		}
+
+		err = s.client.CopyFromContainer(id, file, local)
+		if err != nil {
+			return fmt.Errorf("unable to copy %s from destination: %w", file, err)
+		}
+
+		s.hashes[rel] = hash
+	}
+
+	for rel := range s.hashes {
+		if found[rel] {
+			continue
+		}
+
+		local := filepath.Join(s.config.Source, rel)
+
+		s.log.Debug("Removing file removed from destination", "ref", s.config.Meta.ID, "path", local)
+
+		err := os.Remove(local)
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to remove %s: %w", local, err)
+		}
+
+		delete(s.hashes, rel)
+	}
+
+	return nil
+}
+
+// forget removes the checksums for the path p and any files below it
+func (s *Syncer) forget(p string) {
+	rel := s.relative(p)
+
+	for k := range s.hashes {
+		if k == rel || strings.HasPrefix(k, rel+string(filepath.Separator)) {
+			delete(s.hashes, k)
+		}
+	}
+}
+
+// relative returns the path of p relative to the source
+func (s *Syncer) relative(p string) string {
+	rel, err := filepath.Rel(s.config.Source, p)
+	if err != nil {
+		return p
+	}
+
+	return rel
+}
+
+// destination returns the path in the container for the local path p
+func (s *Syncer) destination(p string) (string, error) {
+	rel, err := filepath.Rel(s.config.Source, p)
+	if err != nil {
+		return "", fmt.Errorf("unable to find relative path for %s: %w", p, err)
+	}
+
+	return path.Join(s.config.destinationPath(), filepath.ToSlash(rel)), nil
+}
+
+// ignored returns true when the path matches one of the ignore patterns
+func (s *Syncer) ignored(p string) bool {
+	rel, err := filepath.Rel(s.config.Source, p)
+	if err != nil || rel == "." {
+		return false
+	}
+
+	for _, i := range s.config.Ignore {
+		if m, _ := filepath.Match(i, rel); m {
+			return true
+		}
+
+		if m, _ := filepath.Match(i, filepath.Base(p)); m {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ignoredTree returns true when the path or any of its parent directories
+// below the source match one of the ignore patterns
+func (s *Syncer) ignoredTree(p string) bool {
+	for ; p != s.config.Source && p != filepath.Dir(p); p = filepath.Dir(p) {
+		if s.ignored(p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// watchDir adds the directory and all sub directories to the watcher
+func (s *Syncer) watchDir(w *fsnotify.Watcher, dir string) error {
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		if s.ignored(p) {
+			return filepath.SkipDir
+		}
+
+		return w.Add(p)
+	})
+}
+
+func (s *Syncer) findContainer() (string, error) {
+	name := s.config.containerName()
+
+	ids, err := s.client.FindContainerIDs(name)
+	if err != nil {
+		return "", fmt.Errorf("unable to find container %s: %w", name, err)
+	}
+
+	if len(ids) != 1 {
+		return "", fmt.Errorf("unable to find container %s", name)
+	}
+
+	return ids[0], nil
+}
+
+// fileHash returns the hex encoded sha256 checksum of the file at p
+func fileHash(p string) (string, error) {
+	f, err := os.Open(p)
+	if err != nil {
+		return "", fmt.Errorf("unable to open file %s: %w", p, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	_, err = io.Copy(h, f)
+	if err != nil {
+		return "", fmt.Errorf("unable to read file %s: %w", p, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
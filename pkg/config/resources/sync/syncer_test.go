@@ -0,0 +1,164 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/container/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncerWatchSyncsChangedFiles(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	s := NewSyncer(p.config, mc, logger.NewTestLogger(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go s.Watch(ctx)
+
+	require.Eventually(t, func() bool {
+		os.WriteFile(filepath.Join(p.config.Source, "main.go"), []byte("package main\n"), 0644)
+		return len(testutils.GetCalls(&mc.Mock, "CopyFileToContainer")) > 0
+	}, 5*time.Second, 300*time.Millisecond)
+
+	require.Contains(t, copiedFiles(mc), "main.go:/app")
+}
+
+func TestSyncerWatchRemovesDeletedFiles(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	s := NewSyncer(p.config, mc, logger.NewTestLogger(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go s.Watch(ctx)
+
+	// give the watcher time to start
+	time.Sleep(100 * time.Millisecond)
+	os.Remove(filepath.Join(p.config.Source, "main.go"))
+
+	require.Eventually(t, func() bool {
+		for _, c := range testutils.GetCalls(&mc.Mock, "ExecuteCommand") {
+			if cmd, ok := c.Arguments[1].([]string); ok && cmd[0] == "rm" {
+				return true
+			}
+		}
+
+		return false
+	}, 5*time.Second, 100*time.Millisecond)
+
+	mc.AssertCalled(t, "ExecuteCommand", "abc", []string{"rm", "-rf", "/app/main.go"}, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+// setupDestinationFiles replaces the ExecuteCommand mock so that listing the
+// destination returns the given files and checksums
+func setupDestinationFiles(mc *mocks.ContainerTasks, files map[string]string, err error) {
+	testutils.RemoveOn(&mc.Mock, "ExecuteCommand")
+	mc.On("ExecuteCommand", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(
+		func(id string, cmd []string, env []string, wd string, user string, group string, timeout int, w io.Writer) (int, error) {
+			if cmd[0] != "find" {
+				return 0, nil
+			}
+
+			if err != nil {
+				return 1, err
+			}
+
+			for f, h := range files {
+				fmt.Fprintf(w, "%s  %s\n", h, f)
+			}
+
+			return 0, nil
+		})
+
+	mc.On("CopyFromContainer", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+}
+
+func TestSyncerPullCopiesChangedFilesFromDestination(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	setupDestinationFiles(mc, map[string]string{"/app/sub/new.txt": "abc123"}, nil)
+
+	s := NewSyncer(p.config, mc, logger.NewTestLogger(t))
+
+	err := s.pull("abc")
+	require.NoError(t, err)
+
+	mc.AssertCalled(t, "CopyFromContainer", "abc", "/app/sub/new.txt", filepath.Join(p.config.Source, "sub", "new.txt"))
+}
+
+func TestSyncerPullSkipsFilesMatchingSource(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+
+	h, err := fileHash(filepath.Join(p.config.Source, "main.go"))
+	require.NoError(t, err)
+
+	setupDestinationFiles(mc, map[string]string{"/app/main.go": h}, nil)
+
+	s := NewSyncer(p.config, mc, logger.NewTestLogger(t))
+
+	err = s.pull("abc")
+	require.NoError(t, err)
+
+	mc.AssertNotCalled(t, "CopyFromContainer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSyncerPullSkipsIgnoredFiles(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	p.config.Ignore = []string{"node_modules"}
+	setupDestinationFiles(mc, map[string]string{"/app/node_modules/lib/index.js": "abc123"}, nil)
+
+	s := NewSyncer(p.config, mc, logger.NewTestLogger(t))
+
+	err := s.pull("abc")
+	require.NoError(t, err)
+
+	mc.AssertNotCalled(t, "CopyFromContainer", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestSyncerPullRemovesFilesRemovedFromDestination(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	setupDestinationFiles(mc, map[string]string{}, nil)
+
+	s := NewSyncer(p.config, mc, logger.NewTestLogger(t))
+	s.hashes["main.go"] = "abc123"
+
+	err := s.pull("abc")
+	require.NoError(t, err)
+
+	require.NoFileExists(t, filepath.Join(p.config.Source, "main.go"))
+}
+
+func TestSyncerPullWithListErrorDoesNotRemoveFiles(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	setupDestinationFiles(mc, nil, fmt.Errorf("boom"))
+
+	s := NewSyncer(p.config, mc, logger.NewTestLogger(t))
+	s.hashes["main.go"] = "abc123"
+
+	err := s.pull("abc")
+	require.Error(t, err)
+
+	require.FileExists(t, filepath.Join(p.config.Source, "main.go"))
+}
+
+func TestSyncerCopyFileSkipsUnchangedFiles(t *testing.T) {
+	p, mc := setupSyncProvider(t)
+	s := NewSyncer(p.config, mc, logger.NewTestLogger(t))
+
+	err := s.copyFile("abc", filepath.Join(p.config.Source, "main.go"))
+	require.NoError(t, err)
+
+	err = s.copyFile("abc", filepath.Join(p.config.Source, "main.go"))
+	require.NoError(t, err)
+
+	require.Len(t, testutils.GetCalls(&mc.Mock, "CopyFileToContainer"), 1)
+}
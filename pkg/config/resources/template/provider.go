@@ -1,12 +1,17 @@
 package template
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
 
+	"github.com/Masterminds/sprig/v3"
 	"github.com/infinytum/raymond/v2"
 	htypes "github.com/jumppad-labs/hclconfig/types"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
@@ -41,42 +46,13 @@ func (p *TemplateProvider) Create(ctx context.Context) error {
 		return nil
 	}
 
-	// check the template is valid
-	if p.config.Source == "" {
-		return fmt.Errorf("template source empty")
-	}
-
-	output := p.config.Source
-	if p.config.Variables != nil {
-
-		vars := parseVars(p.config.Variables)
-
-		tmpl, err := raymond.Parse(p.config.Source)
-		if err != nil {
-			return fmt.Errorf("error parsing template: %s", err)
-		}
-
-		tmpl.RegisterHelpers(map[string]interface{}{
-			"quote": func(in string) string {
-				return fmt.Sprintf(`"%s"`, in)
-			},
-			"trim": func(in string) string {
-				return strings.TrimSpace(in)
-			},
-		})
-
-		result, err := tmpl.Exec(vars)
-		if err != nil {
-			return fmt.Errorf("error processing template: %s", err)
-		}
-
-		output = result
+	if p.config.SourceDir != "" {
+		return p.createDir()
 	}
 
-	// gemerate a checksum from the result
-	cs, err := utils.ChecksumFromInterface(output)
+	output, perms, cs, err := p.renderFile()
 	if err != nil {
-		return fmt.Errorf("unable to generate checksum for template: %s", err)
+		return err
 	}
 
 	outputExists := false
@@ -110,18 +86,288 @@ func (p *TemplateProvider) Create(ctx context.Context) error {
 		}
 		defer f.Close()
 
-		f.WriteString(output)
+		_, err = f.WriteString(output)
+		if err != nil {
+			return fmt.Errorf("unable to write template to destination file: %s", err)
+		}
+
+		// preserve the permissions of template files
+		if perms != 0 {
+			err = os.Chmod(p.config.Destination, perms)
+			if err != nil {
+				return fmt.Errorf("unable to set permissions for destination file: %s", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// renderFile processes the source template and returns the output, the
+// permissions of the template file, and a checksum of the output
+func (p *TemplateProvider) renderFile() (string, os.FileMode, string, error) {
+	// check the template is valid
+	if p.config.Source == "" {
+		return "", 0, "", fmt.Errorf("template source empty")
+	}
+
+	// source can be a file containing the template or the template itself
+	source := p.config.Source
+	perms := os.FileMode(0)
+	if fi, err := os.Stat(source); err == nil && !fi.IsDir() {
+		d, err := os.ReadFile(source)
+		if err != nil {
+			return "", 0, "", fmt.Errorf("unable to read template file %s: %s", source, err)
+		}
+
+		source = string(d)
+		perms = fi.Mode().Perm()
+	}
+
+	output, err := p.render(p.config.Meta.Name, source)
+	if err != nil {
+		return "", 0, "", err
+	}
+
+	// gemerate a checksum from the result
+	cs, err := utils.ChecksumFromInterface(output)
+	if err != nil {
+		return "", 0, "", fmt.Errorf("unable to generate checksum for template: %s", err)
+	}
+
+	return output, perms, cs, nil
+}
+
+// templateFile is a processed template in a directory tree
+type templateFile struct {
+	output string
+	mode   os.FileMode
+}
+
+// renderDir processes every file in the source directory and returns the
+// processed files and directories keyed by the path relative to the source
+// along with a checksum of the output
+func (p *TemplateProvider) renderDir() (map[string]templateFile, map[string]os.FileMode, string, error) {
+	files := map[string]templateFile{}
+	dirs := map[string]os.FileMode{}
+
+	err := filepath.WalkDir(p.config.SourceDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(p.config.SourceDir, path)
+		if err != nil {
+			return err
+		}
+
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			dirs[rel] = fi.Mode().Perm()
+			return nil
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read template file %s: %s", path, err)
+		}
+
+		output, err := p.render(rel, string(data))
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		files[rel] = templateFile{output, fi.Mode().Perm()}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, "", err
+	}
+
+	outputs := map[string]string{}
+	for k, v := range files {
+		outputs[k] = fmt.Sprintf("%s:%s", v.mode, v.output)
+	}
+
+	cs, err := utils.ChecksumFromInterface(outputs)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("unable to generate checksum for template: %s", err)
+	}
+
+	return files, dirs, cs, nil
+}
+
+// createDir writes the processed templates in the source directory to the
+// destination directory
+func (p *TemplateProvider) createDir() error {
+	files, dirs, cs, err := p.renderDir()
+	if err != nil {
+		return err
+	}
+
+	// regenerate the templates if they have changed or any file is missing
+	if p.config.Checksum == cs && p.filesExist() {
+		return nil
+	}
+
+	p.log.Info("Generating templates", "ref", p.config.Meta.ID, "checksum", p.config.Checksum, "source", p.config.SourceDir, "output", p.config.DestinationDir)
+
+	// remove the files from the previous run
+	p.removeFiles()
+
+	// create the directories first so that the permissions of the source
+	// directories are preserved
+	dirNames := []string{}
+	for k := range dirs {
+		dirNames = append(dirNames, k)
+	}
+	sort.Strings(dirNames)
+
+	for _, d := range dirNames {
+		dst := filepath.Join(p.config.DestinationDir, d)
+
+		err := os.MkdirAll(dst, dirs[d])
+		if err != nil {
+			return fmt.Errorf("unable to create destination directory %s: %s", dst, err)
+		}
+	}
+
+	written := []string{}
+	for k, v := range files {
+		dst := filepath.Join(p.config.DestinationDir, k)
+
+		err := os.WriteFile(dst, []byte(v.output), v.mode)
+		if err != nil {
+			return fmt.Errorf("unable to write template %s: %s", dst, err)
+		}
+
+		// WriteFile does not change the permissions of an existing file
+		err = os.Chmod(dst, v.mode)
+		if err != nil {
+			return fmt.Errorf("unable to set permissions for template %s: %s", dst, err)
+		}
+
+		written = append(written, dst)
 	}
 
+	sort.Strings(written)
+
+	p.config.Files = written
+	p.config.Checksum = cs
+
 	return nil
 }
 
+// render processes the template using the configured engine
+func (p *TemplateProvider) render(name, source string) (string, error) {
+	if p.config.Engine == EngineGo {
+		tmpl, err := template.New(name).Funcs(sprig.TxtFuncMap()).Parse(source)
+		if err != nil {
+			return "", fmt.Errorf("error parsing template: %s", err)
+		}
+
+		out := bytes.NewBufferString("")
+		err = tmpl.Execute(out, parseVars(p.config.Variables))
+		if err != nil {
+			return "", fmt.Errorf("error processing template: %s", err)
+		}
+
+		return out.String(), nil
+	}
+
+	// handlebars templates are only processed when variables are set
+	if p.config.Variables == nil {
+		return source, nil
+	}
+
+	vars := parseVars(p.config.Variables)
+
+	tmpl, err := raymond.Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("error parsing template: %s", err)
+	}
+
+	tmpl.RegisterHelpers(map[string]interface{}{
+		"quote": func(in string) string {
+			return fmt.Sprintf(`"%s"`, in)
+		},
+		"trim": func(in string) string {
+			return strings.TrimSpace(in)
+		},
+	})
+
+	result, err := tmpl.Exec(vars)
+	if err != nil {
+		return "", fmt.Errorf("error processing template: %s", err)
+	}
+
+	return result, nil
+}
+
+// filesExist returns true when all the files written by a previous run exist
+func (p *TemplateProvider) filesExist() bool {
+	if len(p.config.Files) == 0 {
+		return false
+	}
+
+	for _, f := range p.config.Files {
+		if _, err := os.Stat(f); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// removeFiles removes the files written by a previous run along with any
+// directories that are left empty
+func (p *TemplateProvider) removeFiles() {
+	dirs := map[string]bool{}
+
+	for _, f := range p.config.Files {
+		err := os.Remove(f)
+		if err != nil && !os.IsNotExist(err) {
+			p.log.Warn("Unable to delete template file", "ref", p.config.Meta.Name, "file", f, "error", err)
+		}
+
+		// track the parent directories up to the destination
+		for d := filepath.Dir(f); strings.HasPrefix(d, p.config.DestinationDir); d = filepath.Dir(d) {
+			dirs[d] = true
+		}
+	}
+
+	// remove the deepest directories first, Remove fails for directories
+	// that are not empty
+	dirNames := []string{}
+	for d := range dirs {
+		dirNames = append(dirNames, d)
+	}
+	sort.Slice(dirNames, func(i, j int) bool { return len(dirNames[i]) > len(dirNames[j]) })
+
+	for _, d := range dirNames {
+		os.Remove(d)
+	}
+
+	p.config.Files = nil
+}
+
 func (p *TemplateProvider) Destroy(ctx context.Context, force bool) error {
 	if ctx.Err() != nil {
 		p.log.Debug("Context cancelled, skipping destroy", "ref", p.config.Meta.ID)
 		return nil
 	}
 
+	if p.config.SourceDir != "" {
+		p.removeFiles()
+		return nil
+	}
+
 	if _, err := os.Stat(p.config.Destination); !os.IsNotExist(err) {
 		err := os.RemoveAll(p.config.Destination)
 		if err != nil {
@@ -152,8 +398,49 @@ func (p *TemplateProvider) Refresh(ctx context.Context) error {
 	return p.Create(ctx)
 }
 
+// Changed processes the template with the current values for the variables
+// and compares the output with the last generated output. Variables that
+// reference the outputs of other resources are resolved when the template is
+// processed, the template is changed when the outputs of the referenced
+// resources or the template files have changed.
 func (p *TemplateProvider) Changed() (bool, error) {
-	return false, nil
+	p.log.Debug("Checking changes", "ref", p.config.Meta.ID)
+
+	// the template has not been generated or the referenced resources have
+	// not been created, the template is processed after they are created
+	if p.config.Checksum == "" || !isKnown(p.config.Variables) {
+		return false, nil
+	}
+
+	var cs string
+	var err error
+
+	if p.config.SourceDir != "" {
+		_, _, cs, err = p.renderDir()
+	} else {
+		_, _, cs, err = p.renderFile()
+	}
+
+	if err != nil {
+		// errors are returned when the template is created
+		p.log.Debug("Unable to process template", "ref", p.config.Meta.ID, "error", err)
+		return true, nil
+	}
+
+	return cs != p.config.Checksum, nil
+}
+
+// isKnown returns false when any of the variables contain values that are
+// not known, this happens when a variable references the output of a
+// resource that has not been created
+func isKnown(value map[string]cty.Value) bool {
+	for _, v := range value {
+		if !v.IsWhollyKnown() {
+			return false
+		}
+	}
+
+	return true
 }
 
 // parseVars converts a map[string]cty.Value into map[string]interface
@@ -171,7 +458,7 @@ func parseVars(value map[string]cty.Value) map[string]interface{} {
 }
 
 func castVar(v cty.Value) interface{} {
-	if v.IsNull() {
+	if v.IsNull() || !v.IsKnown() {
 		return nil
 	}
 
@@ -0,0 +1,199 @@
+package template
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func setupTemplateProvider(t *testing.T, tmpl *Template) *TemplateProvider {
+	testutils.SetupState(t, "")
+
+	file := filepath.Join(t.TempDir(), "main.hcl")
+	os.WriteFile(file, []byte(""), 0644)
+
+	tmpl.ResourceBase = types.ResourceBase{Meta: types.Meta{ID: "resource.template.test", Name: "test", File: file}}
+
+	err := tmpl.Process()
+	require.NoError(t, err)
+
+	return &TemplateProvider{config: tmpl, log: logger.NewTestLogger(t)}
+}
+
+func TestTemplateCreateProcessesHandlebars(t *testing.T) {
+	p := setupTemplateProvider(t, &Template{
+		Source:      "name = {{{quote name}}}",
+		Destination: "./out.hcl",
+		Variables:   map[string]cty.Value{"name": cty.StringVal("consul")},
+	})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(p.config.Destination)
+	require.NoError(t, err)
+	require.Equal(t, `name = "consul"`, string(d))
+}
+
+func TestTemplateCreateProcessesGoWithSprig(t *testing.T) {
+	p := setupTemplateProvider(t, &Template{
+		Source:      `name = {{ .name | upper | quote }}`,
+		Destination: "./out.hcl",
+		Engine:      EngineGo,
+		Variables:   map[string]cty.Value{"name": cty.StringVal("consul")},
+	})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(p.config.Destination)
+	require.NoError(t, err)
+	require.Equal(t, `name = "CONSUL"`, string(d))
+}
+
+func TestTemplateCreateReturnsErrorWhenGoTemplateInvalid(t *testing.T) {
+	p := setupTemplateProvider(t, &Template{
+		Source:      `name = {{ .name `,
+		Destination: "./out.hcl",
+		Engine:      EngineGo,
+	})
+
+	err := p.Create(context.Background())
+	require.ErrorContains(t, err, "error parsing template")
+}
+
+func setupTemplateDir(t *testing.T) string {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "config", "scripts"), 0755)
+	os.WriteFile(filepath.Join(dir, "config", "app.hcl"), []byte(`name = "{{ .name }}"`), 0644)
+	os.WriteFile(filepath.Join(dir, "config", "scripts", "run.sh"), []byte(`echo {{ .name | title }}`), 0755)
+
+	return filepath.Join(dir, "config")
+}
+
+func TestTemplateCreateProcessesDirectory(t *testing.T) {
+	src := setupTemplateDir(t)
+	dst := filepath.Join(t.TempDir(), "out")
+
+	p := setupTemplateProvider(t, &Template{
+		SourceDir:      src,
+		DestinationDir: dst,
+		Engine:         EngineGo,
+		Variables:      map[string]cty.Value{"name": cty.StringVal("consul")},
+	})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(filepath.Join(dst, "app.hcl"))
+	require.NoError(t, err)
+	require.Equal(t, `name = "consul"`, string(d))
+
+	d, err = os.ReadFile(filepath.Join(dst, "scripts", "run.sh"))
+	require.NoError(t, err)
+	require.Equal(t, `echo Consul`, string(d))
+
+	fi, err := os.Stat(filepath.Join(dst, "scripts", "run.sh"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0755), fi.Mode().Perm())
+
+	require.Equal(t, []string{filepath.Join(dst, "app.hcl"), filepath.Join(dst, "scripts", "run.sh")}, p.config.Files)
+}
+
+func TestTemplateDestroyRemovesDirectoryFiles(t *testing.T) {
+	src := setupTemplateDir(t)
+	dst := filepath.Join(t.TempDir(), "out")
+
+	p := setupTemplateProvider(t, &Template{
+		SourceDir:      src,
+		DestinationDir: dst,
+		Engine:         EngineGo,
+		Variables:      map[string]cty.Value{"name": cty.StringVal("consul")},
+	})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	err = p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	require.NoFileExists(t, filepath.Join(dst, "app.hcl"))
+	require.NoDirExists(t, filepath.Join(dst, "scripts"))
+}
+
+func TestTemplateChangedReturnsFalseWhenOutputSame(t *testing.T) {
+	p := setupTemplateProvider(t, &Template{
+		Source:      "ip = {{ip}}",
+		Destination: "./out.hcl",
+		Variables:   map[string]cty.Value{"ip": cty.StringVal("10.0.0.2")},
+	})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.False(t, c)
+}
+
+func TestTemplateChangedReturnsTrueWhenReferencedOutputChanges(t *testing.T) {
+	p := setupTemplateProvider(t, &Template{
+		Source:      "ip = {{ip}}",
+		Destination: "./out.hcl",
+		Variables:   map[string]cty.Value{"ip": cty.StringVal("10.0.0.2")},
+	})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	// the referenced container has been recreated with a new ip
+	p.config.Variables["ip"] = cty.StringVal("10.0.0.3")
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, c)
+}
+
+func TestTemplateChangedReturnsTrueWhenTemplateFileChanges(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "tmpl.hcl")
+	os.WriteFile(src, []byte("ip = {{ip}}"), 0644)
+
+	p := setupTemplateProvider(t, &Template{
+		Source:      src,
+		Destination: "./out.hcl",
+		Variables:   map[string]cty.Value{"ip": cty.StringVal("10.0.0.2")},
+	})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	os.WriteFile(src, []byte("address = {{ip}}"), 0644)
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.True(t, c)
+}
+
+func TestTemplateChangedReturnsFalseWhenReferencedOutputUnknown(t *testing.T) {
+	p := setupTemplateProvider(t, &Template{
+		Source:      "ip = {{ip}}",
+		Destination: "./out.hcl",
+		Variables:   map[string]cty.Value{"ip": cty.StringVal("10.0.0.2")},
+	})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	p.config.Variables["ip"] = cty.UnknownVal(cty.String)
+
+	c, err := p.Changed()
+	require.NoError(t, err)
+	require.False(t, c)
+}
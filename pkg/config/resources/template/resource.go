@@ -1,6 +1,7 @@
 package template
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -13,18 +14,78 @@ import (
 // TypeTemplate is the resource string for a Template resource
 const TypeTemplate string = "template"
 
+const (
+	// EngineHandlebars processes templates using Handlebars syntax
+	EngineHandlebars = "handlebars"
+	// EngineGo processes templates using Go text/template syntax with the
+	// sprig function library
+	EngineGo = "go"
+)
+
 // Template allows the process of user defined templates
 type Template struct {
 	types.ResourceBase `hcl:",remain"`
 
-	Source      string               `hcl:"source" json:"source"`                          // Source template to be processed as string
-	Destination string               `hcl:"destination" json:"destination"`                // Destination filename to write
-	Variables   map[string]cty.Value `hcl:"variables,optional" json:"variables,omitempty"` // Variables to be processed in the template
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	Source      string `hcl:"source,optional" json:"source,omitempty"`           // Source template to be processed as string or file
+	Destination string `hcl:"destination,optional" json:"destination,omitempty"` // Destination filename to write
+
+	// Variables to be processed in the template, variables can reference the
+	// outputs of other resources such as container ips or random values, the
+	// template is processed after the referenced resources have been created
+	// and is processed again when their outputs change
+	Variables map[string]cty.Value `hcl:"variables,optional" json:"variables,omitempty"`
 
-	Checksum string `hcl:"checksum,optional" json:"checksum,omitempty"` // Checksum of the parsed template
+	// SourceDir is a directory of templates, every file in the directory
+	// tree is processed and written to DestinationDir preserving the
+	// directory structure and file permissions
+	SourceDir      string `hcl:"source_dir,optional" json:"source_dir,omitempty"`
+	DestinationDir string `hcl:"destination_dir,optional" json:"destination_dir,omitempty"`
+
+	// Engine used to process the template, either handlebars or go,
+	// defaults to handlebars
+	Engine string `hcl:"engine,optional" json:"engine,omitempty"`
+
+	Checksum string   `hcl:"checksum,optional" json:"checksum,omitempty"` // Checksum of the parsed template
+	Files    []string `hcl:"files,optional" json:"files,omitempty"`       // Files written by the template
 }
 
 func (t *Template) Process() error {
+	if t.Engine == "" {
+		t.Engine = EngineHandlebars
+	}
+
+	if t.Engine != EngineHandlebars && t.Engine != EngineGo {
+		return fmt.Errorf("invalid engine %s, engine must be either %s or %s", t.Engine, EngineHandlebars, EngineGo)
+	}
+
+	if t.SourceDir != "" || t.DestinationDir != "" {
+		if t.Source != "" || t.Destination != "" {
+			return fmt.Errorf("source and destination can not be specified with source_dir and destination_dir")
+		}
+
+		if t.SourceDir == "" || t.DestinationDir == "" {
+			return fmt.Errorf("both source_dir and destination_dir must be specified")
+		}
+
+		t.SourceDir = utils.EnsureAbsolute(t.SourceDir, t.Meta.File)
+		t.DestinationDir = utils.EnsureAbsolute(t.DestinationDir, t.Meta.File)
+
+		fi, err := os.Stat(t.SourceDir)
+		if err != nil || !fi.IsDir() {
+			return fmt.Errorf("source_dir %s must be a directory", t.SourceDir)
+		}
+
+		t.loadState()
+
+		return nil
+	}
+
+	if t.Destination == "" {
+		return fmt.Errorf("destination must be specified")
+	}
+
 	t.Destination = utils.EnsureAbsolute(t.Destination, t.Meta.File)
 
 	// Source can be a file or a template as a string
@@ -40,6 +101,13 @@ func (t *Template) Process() error {
 		t.Source = strings.Replace(t.Source, "\r\n", "\n", -1)
 	}
 
+	t.loadState()
+
+	return nil
+}
+
+// loadState sets the computed outputs from the state
+func (t *Template) loadState() {
 	cfg, err := config.LoadState()
 	if err == nil {
 		// try and find the resource in the state
@@ -47,8 +115,7 @@ func (t *Template) Process() error {
 		if r != nil {
 			kstate := r.(*Template)
 			t.Checksum = kstate.Checksum
+			t.Files = kstate.Files
 		}
 	}
-
-	return nil
 }
@@ -40,3 +40,54 @@ func TestTemplateProcessSetsAbsoluteWhenSourceString(t *testing.T) {
 	require.Equal(t, path.Join(wd, "output.hcl"), c.Destination)
 	require.Equal(t, "foobar", c.Source)
 }
+
+func TestTemplateProcessReturnsErrorWhenEngineInvalid(t *testing.T) {
+	c := &Template{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Source:       "foobar",
+		Destination:  "./output.hcl",
+		Engine:       "jinja",
+	}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "invalid engine")
+}
+
+func TestTemplateProcessReturnsErrorWhenSourceAndSourceDir(t *testing.T) {
+	c := &Template{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Source:       "foobar",
+		SourceDir:    "./",
+	}
+
+	err := c.Process()
+	require.Error(t, err)
+}
+
+func TestTemplateProcessReturnsErrorWhenDestinationDirMissing(t *testing.T) {
+	c := &Template{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		SourceDir:    "./",
+	}
+
+	err := c.Process()
+	require.ErrorContains(t, err, "destination_dir")
+}
+
+func TestTemplateProcessSetsAbsoluteDirectories(t *testing.T) {
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+
+	c := &Template{
+		ResourceBase:   types.ResourceBase{Meta: types.Meta{File: "./"}},
+		SourceDir:      "./",
+		DestinationDir: "./out",
+	}
+
+	err = c.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, wd, c.SourceDir)
+	require.Equal(t, path.Join(wd, "out"), c.DestinationDir)
+	require.Equal(t, EngineHandlebars, c.Engine)
+}
@@ -0,0 +1,152 @@
+package terminal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+var _ sdk.Provider = &Provider{}
+
+// Provider serves browser based terminals using the connector
+type Provider struct {
+	config    *Terminal
+	connector connector.Connector
+	log       sdk.Logger
+}
+
+func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Terminal)
+	if !ok {
+		return fmt.Errorf("unable to initialize Terminal provider, resource is not of type Terminal")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.connector = cli.Connector
+	p.log = l
+
+	return nil
+}
+
+func (p *Provider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Context is cancelled, skipping create", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Creating Terminal", "ref", p.config.Meta.ID)
+
+	if p.config.Port == 0 {
+		port, err := utils.FreePort("tcp")
+		if err != nil {
+			return fmt.Errorf("unable to find a free port for terminal: %w", err)
+		}
+
+		p.config.Port = port
+	}
+
+	if p.config.Token == "" {
+		token, err := generateToken()
+		if err != nil {
+			return fmt.Errorf("unable to generate token for terminal: %w", err)
+		}
+
+		p.config.Token = token
+		utils.RegisterSensitive(token)
+	}
+
+	return p.addTerminal()
+}
+
+func (p *Provider) Destroy(ctx context.Context, force bool) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Context is cancelled, skipping destroy", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Destroy Terminal", "ref", p.config.Meta.ID)
+
+	if p.config.Port == 0 {
+		return nil
+	}
+
+	err := p.connector.RemoveTerminal(p.config.Port)
+	if err != nil {
+		p.log.Warn("Unable to remove terminal", "ref", p.config.Meta.ID, "port", p.config.Port, "error", err)
+	}
+
+	return nil
+}
+
+func (p *Provider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+// Refresh registers the terminal again, the connector may have been
+// restarted or the configuration changed
+func (p *Provider) Refresh(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Context is cancelled, skipping refresh", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Debug("Refresh Terminal", "ref", p.config.Meta.ID)
+
+	return p.Create(ctx)
+}
+
+func (p *Provider) Changed() (bool, error) {
+	return false, nil
+}
+
+func (p *Provider) addTerminal() error {
+	target := types.TerminalLocal
+	if p.config.Target != nil {
+		target = p.config.Target.ContainerName
+	}
+
+	err := p.connector.AddTerminal(types.Terminal{
+		Port:             p.config.Port,
+		Target:           target,
+		Shell:            p.config.Shell,
+		User:             p.config.User,
+		WorkingDirectory: p.config.WorkingDirectory,
+		Token:            p.config.Token,
+		Title:            p.config.Title,
+	})
+
+	if err != nil {
+		return fmt.Errorf("unable to create terminal: %w", err)
+	}
+
+	p.config.URL = fmt.Sprintf("http://localhost:%d/?token=%s", p.config.Port, p.config.Token)
+
+	p.log.Debug("Terminal available", "ref", p.config.Meta.ID, "port", p.config.Port, "target", target)
+
+	return nil
+}
+
+// generateToken returns a random hex encoded token
+func generateToken() (string, error) {
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,72 @@
+package terminal
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/mocks"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTerminalProvider(t *testing.T, c *Terminal) (*Provider, *mocks.Connector) {
+	c.ResourceBase = types.ResourceBase{Meta: types.Meta{ID: "resource.terminal.test", Name: "test"}}
+
+	mc := &mocks.Connector{}
+	mc.On("AddTerminal", mock.Anything).Return(nil)
+	mc.On("RemoveTerminal", mock.Anything).Return(nil)
+
+	p := &Provider{
+		config:    c,
+		connector: mc,
+		log:       logger.NewTestLogger(t),
+	}
+
+	return p, mc
+}
+
+func TestTerminalCreateGeneratesPortAndToken(t *testing.T) {
+	p, mc := setupTerminalProvider(t, &Terminal{WorkingDirectory: "/tmp"})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	require.NotZero(t, p.config.Port)
+	require.Len(t, p.config.Token, 32)
+	require.Contains(t, p.config.URL, p.config.Token)
+
+	req := mc.Calls[0].Arguments[0].(ctypes.Terminal)
+	require.Equal(t, ctypes.TerminalLocal, req.Target)
+	require.Equal(t, p.config.Token, req.Token)
+	require.Equal(t, "/tmp", req.WorkingDirectory)
+}
+
+func TestTerminalCreateUsesContainerAndToken(t *testing.T) {
+	p, mc := setupTerminalProvider(t, &Terminal{
+		Target: &container.Container{ContainerName: "ubuntu.container.local.jmpd.in"},
+		Port:   8080,
+		Token:  "mytoken",
+	})
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	req := mc.Calls[0].Arguments[0].(ctypes.Terminal)
+	require.Equal(t, "ubuntu.container.local.jmpd.in", req.Target)
+	require.Equal(t, 8080, req.Port)
+	require.Equal(t, "mytoken", req.Token)
+	require.Equal(t, "http://localhost:8080/?token=mytoken", p.config.URL)
+}
+
+func TestTerminalDestroyRemovesTerminal(t *testing.T) {
+	p, mc := setupTerminalProvider(t, &Terminal{Port: 8080})
+
+	err := p.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	mc.AssertCalled(t, "RemoveTerminal", 8080)
+}
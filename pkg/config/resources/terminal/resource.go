@@ -0,0 +1,139 @@
+package terminal
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	ctypes "github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// TypeTerminal is the resource string for a Terminal resource
+const TypeTerminal string = "terminal"
+
+// Terminal serves a browser based shell attached to a container or the
+// local machine, access to the terminal requires the token
+type Terminal struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Target is the container to attach to, when not set the shell runs on
+	// the local machine
+	Target *ctypes.Container `hcl:"target,optional" json:"target,omitempty"`
+
+	Shell            string `hcl:"shell,optional" json:"shell,omitempty"`
+	User             string `hcl:"user,optional" json:"user,omitempty"`
+	WorkingDirectory string `hcl:"working_directory,optional" json:"working_directory,omitempty"`
+	Title            string `hcl:"title,optional" json:"title,omitempty"`
+
+	// Port to serve the terminal on, a free port is selected when not set
+	Port int `hcl:"port,optional" json:"port,omitempty"`
+
+	// Token used to access the terminal, a random token is generated when
+	// not set
+	Token string `hcl:"token,optional" json:"token,omitempty"`
+
+	// Output parameters
+
+	// URL of the terminal including the token
+	URL string `hcl:"url,optional" json:"url,omitempty"`
+}
+
+func (t *Terminal) Process() error {
+	if t.Target != nil {
+		if t.Shell == "" {
+			t.Shell = "/bin/sh"
+		}
+
+		if t.User == "" {
+			t.User = "root"
+		}
+
+		if t.WorkingDirectory == "" {
+			t.WorkingDirectory = "/"
+		}
+	} else {
+		if t.WorkingDirectory == "" {
+			t.WorkingDirectory = utils.HomeFolder()
+		}
+
+		t.WorkingDirectory = utils.EnsureAbsolute(t.WorkingDirectory, t.Meta.File)
+	}
+
+	if t.Port < 0 || t.Port > 65535 {
+		return fmt.Errorf("invalid port %d, port must be between 1 and 65535", t.Port)
+	}
+
+	// do we have an existing resource in the state?
+	// if so we need to set any computed resources for dependents
+	cfg, err := config.LoadState()
+	if err == nil {
+		// try and find the resource in the state
+		r, _ := cfg.FindResource(t.Meta.ID)
+		if r != nil {
+			state := r.(*Terminal)
+
+			if t.Port == 0 {
+				t.Port = state.Port
+			}
+
+			if t.Token == "" {
+				t.Token = state.Token
+			}
+
+			t.URL = state.URL
+		}
+	}
+
+	utils.RegisterSensitive(t.Token)
+
+	return nil
+}
+
+// terminalJSON is used to serialize the terminal without recursing into
+// MarshalJSON
+type terminalJSON Terminal
+
+// MarshalJSON encrypts the token and url so that they are not written to
+// the state in plain text
+func (t *Terminal) MarshalJSON() ([]byte, error) {
+	tj := terminalJSON(*t)
+
+	for _, v := range []*string{&tj.Token, &tj.URL} {
+		if *v == "" {
+			continue
+		}
+
+		enc, err := utils.EncryptSecret(*v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encrypt token for %s: %w", t.Meta.ID, err)
+		}
+
+		*v = enc
+	}
+
+	return json.Marshal(tj)
+}
+
+// UnmarshalJSON decrypts the token and url encrypted by MarshalJSON
+func (t *Terminal) UnmarshalJSON(d []byte) error {
+	tj := terminalJSON{}
+
+	err := json.Unmarshal(d, &tj)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range []*string{&tj.Token, &tj.URL} {
+		*v = utils.DecryptSecretOrEmpty(tj.Meta.ID, *v)
+	}
+
+	utils.RegisterSensitive(tj.Token)
+
+	*t = Terminal(tj)
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package terminal
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func init() {
+	config.RegisterResource(TypeTerminal, &Terminal{}, &Provider{})
+}
+
+func TestTerminalProcessSetsContainerDefaults(t *testing.T) {
+	term := &Terminal{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Target:       &container.Container{},
+	}
+
+	err := term.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "/bin/sh", term.Shell)
+	require.Equal(t, "root", term.User)
+	require.Equal(t, "/", term.WorkingDirectory)
+}
+
+func TestTerminalProcessReturnsErrorForInvalidPort(t *testing.T) {
+	term := &Terminal{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{File: "./"}},
+		Port:         70000,
+	}
+
+	require.Error(t, term.Process())
+}
+
+func TestTerminalLoadsValuesFromState(t *testing.T) {
+	testutils.SetupState(t, `
+{
+  "blueprint": null,
+  "resources": [
+	{
+			"meta": {
+				"id": "resource.terminal.test",
+  	    "name": "test",
+  	    "type": "terminal"
+			},
+			"port": 31000,
+			"token": "abc",
+			"url": "http://localhost:31000/?token=abc"
+	}
+	]
+}`)
+
+	term := &Terminal{
+		ResourceBase: types.ResourceBase{
+			Meta: types.Meta{
+				File: "./",
+				ID:   "resource.terminal.test",
+			},
+		},
+	}
+
+	err := term.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, 31000, term.Port)
+	require.Equal(t, "abc", term.Token)
+	require.Equal(t, "http://localhost:31000/?token=abc", term.URL)
+}
+
+func TestTerminalMarshalEncryptsToken(t *testing.T) {
+	t.Setenv(utils.SecretsKeyEnvVar, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+
+	term := &Terminal{Token: "mytoken", URL: "http://localhost:8080/?token=mytoken"}
+
+	d, err := json.Marshal(term)
+	require.NoError(t, err)
+	require.NotContains(t, string(d), "mytoken")
+
+	out := &Terminal{}
+	err = json.Unmarshal(d, out)
+	require.NoError(t, err)
+	require.Equal(t, "mytoken", out.Token)
+	require.Equal(t, term.URL, out.URL)
+}
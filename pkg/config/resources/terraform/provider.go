@@ -18,6 +18,7 @@ import (
 	"github.com/jumppad-labs/jumppad/pkg/clients"
 	cclient "github.com/jumppad-labs/jumppad/pkg/clients/container"
 	ctypes "github.com/jumppad-labs/jumppad/pkg/clients/container/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 	"github.com/kennygrant/sanitize"
@@ -224,6 +225,7 @@ func (p *TerraformProvider) createContainer() (string, error) {
 		Name:        fqdn,
 		Image:       &ctypes.Image{Name: image},
 		Environment: p.config.Environment,
+		Labels:      config.DockerLabels(p.config),
 	}
 
 	for _, v := range p.config.Networks {
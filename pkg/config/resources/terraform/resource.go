@@ -18,6 +18,8 @@ const TypeTerraform string = "terraform"
 type Terraform struct {
 	types.ResourceBase `hcl:",remain"`
 
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
 	Networks []ctypes.NetworkAttachment `hcl:"network,block" json:"networks,omitempty"` // Attach to the correct network // only when Image is specified
 
 	Source           string            `hcl:"source" json:"source"`                                          // Source directory containing Terraform config
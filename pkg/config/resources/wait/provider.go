@@ -0,0 +1,155 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	htypes "github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	cmdClient "github.com/jumppad-labs/jumppad/pkg/clients/command"
+	cmdTypes "github.com/jumppad-labs/jumppad/pkg/clients/command/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/http"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+)
+
+// checks Provider implements the sdk.Provider interface
+var _ sdk.Provider = &Provider{}
+
+// Provider waits for the duration and conditions of a Wait resource
+type Provider struct {
+	config  *Wait
+	http    http.HTTP
+	command cmdClient.Command
+	log     sdk.Logger
+	backoff time.Duration
+}
+
+func (p *Provider) Init(cfg htypes.Resource, l sdk.Logger) error {
+	c, ok := cfg.(*Wait)
+	if !ok {
+		return fmt.Errorf("unable to initialize Wait provider, resource is not an instance of Wait")
+	}
+
+	cli, err := clients.GenerateClients(l)
+	if err != nil {
+		return err
+	}
+
+	p.config = c
+	p.http = cli.HTTP
+	p.command = cli.Command
+	p.log = l
+	p.backoff = time.Second
+
+	return nil
+}
+
+func (p *Provider) Create(ctx context.Context) error {
+	if ctx.Err() != nil {
+		p.log.Debug("Context is cancelled, skipping create", "ref", p.config.Meta.ID)
+		return nil
+	}
+
+	p.log.Info("Waiting", "ref", p.config.Meta.ID, "duration", p.config.Duration)
+
+	if p.config.Duration != "" {
+		d, err := time.ParseDuration(p.config.Duration)
+		if err != nil {
+			return fmt.Errorf("unable to parse duration: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(d):
+		}
+	}
+
+	timeout, err := time.ParseDuration(p.config.Timeout)
+	if err != nil {
+		return fmt.Errorf("unable to parse timeout: %w", err)
+	}
+
+	// all conditions share the timeout
+	st := time.Now()
+	remaining := func() time.Duration {
+		return timeout - time.Since(st)
+	}
+
+	for _, c := range p.config.TCP {
+		err := p.http.HealthCheckTCP(c.Address, remaining())
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range p.config.HTTP {
+		err := p.http.HealthCheckHTTP(c.Address, c.Method, c.Headers, c.Body, c.SuccessCodes, remaining())
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, c := range p.config.Command {
+		err := p.waitForCommand(ctx, c, remaining())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *Provider) Destroy(ctx context.Context, force bool) error {
+	return nil
+}
+
+func (p *Provider) Lookup() ([]string, error) {
+	return nil, nil
+}
+
+func (p *Provider) Refresh(ctx context.Context) error {
+	return nil
+}
+
+func (p *Provider) Changed() (bool, error) {
+	return false, nil
+}
+
+// waitForCommand runs the command until it returns the expected exit code
+func (p *Provider) waitForCommand(ctx context.Context, c Command, timeout time.Duration) error {
+	cc := cmdTypes.CommandConfig{InheritEnv: true}
+
+	if c.Script != "" {
+		cc.Command = "sh"
+		cc.Args = []string{"-c", c.Script}
+	} else {
+		cc.Command = c.Command[0]
+		cc.Args = c.Command[1:]
+	}
+
+	st := time.Now()
+
+	for {
+		if ctx.Err() != nil {
+			p.log.Debug("Context cancelled, skipping command condition", "ref", p.config.Meta.ID)
+			return nil
+		}
+
+		if time.Since(st) > timeout {
+			return fmt.Errorf("timeout waiting for command %s %v", cc.Command, cc.Args)
+		}
+
+		cc.Timeout = timeout - time.Since(st)
+
+		_, code, err := p.command.Execute(cc)
+		if err == nil && code == c.ExitCode {
+			p.log.Debug("Command condition met", "ref", p.config.Meta.ID, "command", cc.Command)
+			return nil
+		}
+
+		p.log.Debug("Command condition not met, retrying", "ref", p.config.Meta.ID, "command", cc.Command, "exit_code", code, "error", err)
+		time.Sleep(p.backoff)
+	}
+}
@@ -0,0 +1,89 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	commandMocks "github.com/jumppad-labs/jumppad/pkg/clients/command/mocks"
+	cmdTypes "github.com/jumppad-labs/jumppad/pkg/clients/command/types"
+	httpMocks "github.com/jumppad-labs/jumppad/pkg/clients/http/mocks"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupWait(t *testing.T) (*Wait, *Provider, *httpMocks.HTTP, *commandMocks.Command) {
+	hm := &httpMocks.HTTP{}
+	hm.On("HealthCheckTCP", mock.Anything, mock.Anything).Return(nil)
+	hm.On("HealthCheckHTTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	cm := &commandMocks.Command{}
+	cm.On("Execute", mock.Anything).Return(1, 0, nil)
+
+	w := &Wait{ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "resource.wait.test"}}}
+	w.Timeout = "10s"
+
+	p := &Provider{config: w, http: hm, command: cm, log: logger.NewTestLogger(t), backoff: time.Millisecond}
+
+	return w, p, hm, cm
+}
+
+func TestWaitChecksAllConditions(t *testing.T) {
+	w, p, hm, cm := setupWait(t)
+	w.Duration = "1ms"
+	w.TCP = []healthcheck.HealthCheckTCP{{Address: "localhost:8500"}}
+	w.HTTP = []healthcheck.HealthCheckHTTP{{Address: "http://localhost:8500", SuccessCodes: []int{200}}}
+	w.Command = []Command{{Script: "test -f /tmp/ready"}}
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	hm.AssertCalled(t, "HealthCheckTCP", "localhost:8500", mock.Anything)
+	hm.AssertCalled(t, "HealthCheckHTTP", "http://localhost:8500", "", mock.Anything, "", []int{200}, mock.Anything)
+
+	cc := testutils.GetCalls(&cm.Mock, "Execute")[0].Arguments[0].(cmdTypes.CommandConfig)
+	require.Equal(t, "sh", cc.Command)
+	require.Equal(t, []string{"-c", "test -f /tmp/ready"}, cc.Args)
+}
+
+func TestWaitRetriesCommandUntilExitCode(t *testing.T) {
+	w, p, _, cm := setupWait(t)
+	w.Command = []Command{{Command: []string{"curl", "localhost"}, ExitCode: 0}}
+
+	testutils.RemoveOn(&cm.Mock, "Execute")
+	cm.On("Execute", mock.Anything).Return(1, 7, nil).Twice()
+	cm.On("Execute", mock.Anything).Return(1, 0, nil)
+
+	err := p.Create(context.Background())
+	require.NoError(t, err)
+
+	cm.AssertNumberOfCalls(t, "Execute", 3)
+}
+
+func TestWaitCommandTimeoutReturnsError(t *testing.T) {
+	w, p, _, cm := setupWait(t)
+	w.Timeout = "10ms"
+	w.Command = []Command{{Command: []string{"false"}}}
+
+	testutils.RemoveOn(&cm.Mock, "Execute")
+	cm.On("Execute", mock.Anything).Return(1, 1, nil)
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
+
+func TestWaitHTTPErrorReturnsError(t *testing.T) {
+	w, p, hm, _ := setupWait(t)
+	w.HTTP = []healthcheck.HealthCheckHTTP{{Address: "http://localhost:8500"}}
+
+	testutils.RemoveOn(&hm.Mock, "HealthCheckHTTP")
+	hm.On("HealthCheckHTTP", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(fmt.Errorf("boom"))
+
+	err := p.Create(context.Background())
+	require.Error(t, err)
+}
@@ -0,0 +1,66 @@
+package wait
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck"
+)
+
+// TypeWait is the resource string for a Wait resource
+const TypeWait string = "wait"
+
+// Wait blocks until the duration has elapsed and all the conditions are met,
+// resources that depend on a Wait are not created until it completes
+type Wait struct {
+	// embedded type holding name, etc
+	types.ResourceBase `hcl:",remain"`
+
+	Enabled *bool `hcl:"enabled,optional" json:"enabled,omitempty"`
+
+	// Duration to wait before the conditions are checked i.e 10s
+	Duration string `hcl:"duration,optional" json:"duration,omitempty"`
+
+	// Timeout for all the conditions to be met, defaults to 300s
+	Timeout string `hcl:"timeout,optional" json:"timeout,omitempty"`
+
+	HTTP    []healthcheck.HealthCheckHTTP `hcl:"http,block" json:"http,omitempty"`
+	TCP     []healthcheck.HealthCheckTCP  `hcl:"tcp,block" json:"tcp,omitempty"`
+	Command []Command                     `hcl:"command,block" json:"command,omitempty"`
+}
+
+// Command is a condition that is met when the command returns the exit code,
+// the command is run on the local machine
+type Command struct {
+	// Command to execute
+	Command []string `hcl:"command,optional" json:"command,omitempty"`
+	// Script to execute with sh
+	Script string `hcl:"script,optional" json:"script,omitempty"`
+	// ExitCode to mark the condition as met, default 0
+	ExitCode int `hcl:"exit_code,optional" json:"exit_code,omitempty"`
+}
+
+func (w *Wait) Process() error {
+	if w.Duration != "" {
+		if _, err := time.ParseDuration(w.Duration); err != nil {
+			return fmt.Errorf("invalid duration %s: %s", w.Duration, err)
+		}
+	}
+
+	if w.Timeout == "" {
+		w.Timeout = "300s"
+	}
+
+	if _, err := time.ParseDuration(w.Timeout); err != nil {
+		return fmt.Errorf("invalid timeout %s: %s", w.Timeout, err)
+	}
+
+	for _, c := range w.Command {
+		if (len(c.Command) == 0) == (c.Script == "") {
+			return fmt.Errorf("command conditions must set either command or script")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package wait
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitProcessSetsDefaultTimeout(t *testing.T) {
+	w := &Wait{Duration: "10s"}
+
+	err := w.Process()
+	require.NoError(t, err)
+
+	require.Equal(t, "300s", w.Timeout)
+}
+
+func TestWaitProcessInvalidDurationReturnsError(t *testing.T) {
+	w := &Wait{Duration: "ten seconds"}
+
+	err := w.Process()
+	require.Error(t, err)
+}
+
+func TestWaitProcessCommandWithoutScriptReturnsError(t *testing.T) {
+	w := &Wait{Command: []Command{{ExitCode: 1}}}
+
+	err := w.Process()
+	require.Error(t, err)
+}
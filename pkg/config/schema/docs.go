@@ -0,0 +1,117 @@
+package schema
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ParseDocs reads the doc comments of the structs in the Go packages below
+// dir and returns them keyed by package path, type, and field name. Only
+// fields with a hcl tag are returned. root is the folder containing the
+// module and module its import path.
+func ParseDocs(root, module, dir string) (map[string]string, error) {
+	docs := map[string]string{}
+	fset := token.NewFileSet()
+
+	err := filepath.WalkDir(filepath.Join(root, dir), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || filepath.Ext(path) != ".go" || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+
+		pkg := module + "/" + filepath.ToSlash(rel)
+
+		f, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+
+				st, ok := ts.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				// the comment is on the declaration when it has a single type
+				doc := ts.Doc
+				if doc == nil && len(gd.Specs) == 1 {
+					doc = gd.Doc
+				}
+
+				hcl := false
+				for _, field := range st.Fields.List {
+					if !hasHCLTag(field) {
+						continue
+					}
+
+					hcl = true
+
+					doc := field.Doc
+					if doc == nil {
+						doc = field.Comment
+					}
+
+					for _, n := range field.Names {
+						addDoc(docs, pkg+"."+ts.Name.Name+"."+n.Name, doc)
+					}
+				}
+
+				// only structs that can be decoded from hcl are documented
+				if hcl {
+					addDoc(docs, pkg+"."+ts.Name.Name, doc)
+				}
+			}
+		}
+
+		return nil
+	})
+
+	return docs, err
+}
+
+func hasHCLTag(f *ast.Field) bool {
+	if f.Tag == nil {
+		return false
+	}
+
+	tag, err := strconv.Unquote(f.Tag.Value)
+	if err != nil {
+		return false
+	}
+
+	_, ok := reflect.StructTag(tag).Lookup("hcl")
+	return ok
+}
+
+func addDoc(docs map[string]string, key string, cg *ast.CommentGroup) {
+	if cg == nil {
+		return
+	}
+
+	text := strings.Join(strings.Fields(cg.Text()), " ")
+	if text != "" {
+		docs[key] = text
+	}
+}
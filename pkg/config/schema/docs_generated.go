@@ -0,0 +1,529 @@
+// Code generated by gen.go. DO NOT EDIT.
+
+package schema
+
+// fieldDocs contains the doc comments of the resource structs
+var fieldDocs = map[string]string{
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/blueprint.Blueprint":                          "Blueprint defines a stack blueprint for defining yard configs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.Build.BuildChecksum":                    "Checksum is calculated from the Context files",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.Build.Image":                            "Image is the full local reference of the built image",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.Build.Outputs":                          "Outputs allow files or directories to be copied from the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.Build.Registries":                       "Optional registry to push the image to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.BuildContainer.Args":                    "Build args to pass to the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.BuildContainer.Context":                 "Path to build context",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.BuildContainer.DockerFile":              "Location of build file inside build context defaults to ./Dockerfile",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.BuildContainer.Ignore":                  "Files to ignore in the build context, this is the same as .dockerignore",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.Output.Destination":                     "Destination for copied file or directory",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/build.Output.Source":                          "Source file or directory in container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.ImageCache":                             "ImageCache defines a structure for creating ImageCache containers",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.ImageCache.Networks":                    "Attach to the correct network // only when Image is specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.ImageCache.Resources":                   "resource constraints for the cache container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.Registry":                               "Registry defines a structure for registering additional registries for the image cache",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.Registry.Auth":                          "auth to authenticate against registry",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.Registry.Hostname":                      "Hostname of the registry",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.RegistryAuth":                           "RegistryAuth defines a structure for authenticating against a docker registry",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.RegistryAuth.Hostname":                  "Hostname for authentication, can be different from registry hostname",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.RegistryAuth.Password":                  "Password for authentication",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache.RegistryAuth.Username":                  "Username for authentication",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA":                           "CertificateCA allows the generate of CA certificates",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.Bundle":                    "Bundle contains the certificate followed by the certificates of the issuing CAs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.CAKey":                     "CAKey and CACert are optional, when set an intermediate CA signed by the given CA is created. To include the full chain in the bundle set CACert to the bundle of the issuing CA.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.Cert":                      "Cert is the value related to the certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.KeySize":                   "KeySize is the size of the key in bits, defaults to 4096 for rsa and 256 for ecdsa",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.KeyType":                   "KeyType is the type of key to generate, rsa or ecdsa, defaults to rsa",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.Output":                    "Output directory to write the certificate and key too",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.PKCS12":                    "PKCS12 contains the private key, certificate and chain as a PKCS#12 archive",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.PKCS12Password":            "PKCS12Password is the password used to encrypt the PKCS#12 file",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.PrivateKey":                "Key is the value related to the certificate key",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.PublicKeyPEM":              "Key is the value related to the certificate key",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.RenewBefore":               "RenewBefore renews the certificate when it expires within the given duration, by default the certificate is only renewed once it has expired",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateCA.Validity":                  "Validity is the duration the certificate is valid for, defaults to 87600h",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf":                         "CertificateCA allows the generate of CA certificates",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.Bundle":                  "Bundle contains the certificate followed by the certificates of the issuing CAs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.CACert":                  "Path to the root CA",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.CAKey":                   "Path to the primary key for the root CA",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.Cert":                    "Cert is the value related to the certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.DNSNames":                "DNS names to add to the cert",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.IPAddresses":             "ip addresses to add to the cert",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.KeySize":                 "KeySize is the size of the key in bits, defaults to 4096 for rsa and 256 for ecdsa",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.KeyType":                 "KeyType is the type of key to generate, rsa or ecdsa, defaults to rsa",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.Output":                  "output location for the certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.PKCS12":                  "PKCS12 contains the private key, certificate and chain as a PKCS#12 archive",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.PKCS12Password":          "PKCS12Password is the password used to encrypt the PKCS#12 file",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.PrivateKey":              "Key is the value related to the certificate key",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.PublicKeyPEM":            "Key is the value related to the certificate key",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.RenewBefore":             "RenewBefore renews the certificate when it expires within the given duration, by default the certificate is only renewed once it has expired",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert.CertificateLeaf.Validity":                "Validity is the duration the certificate is valid for, defaults to 8760h",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Chaos":                                  "Chaos injects faults into containers and pods on a schedule for the lifetime of the environment, faults can be disabled and enabled again with the jumppad chaos command. ```hcl resource \"chaos\" \"api\" { targets = [resource.container.api] interval = \"1m\" jitter = \"30s\" mode = \"random\" fault \"kill\" { duration = \"20s\" } fault \"cpu_stress\" { duration = \"30s\" workers = 2 } } ```",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Chaos.Faults":                           "Faults to inject into the targets",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Chaos.Interval":                         "Interval is the time between faults e.g. 1m",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Chaos.Jitter":                           "Jitter is the maximum random delay added to the interval",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Chaos.Mode":                             "Mode is all to inject the faults in order into every target, or random to inject a random fault into a random target, defaults to all",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Chaos.PID":                              "PID is the process id of the fault injector",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Chaos.Paused":                           "Paused can be set to true to create the resource without injecting faults until it is enabled with jumppad chaos enable",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Chaos.Pods":                             "Pods select the pods in Kubernetes clusters to inject faults into",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Chaos.Targets":                          "Targets are the containers to inject faults into",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Fault":                                  "Fault is a kill, pause, cpu_stress, or disk_fill fault",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Fault.Duration":                         "Duration the fault lasts before the target is recovered, killed containers are started after the duration, defaults to 10s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Fault.Path":                             "Path of the folder disk_fill writes to, defaults to /tmp",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Fault.Size":                             "Size of the file written by disk_fill e.g. 1GB, defaults to 512MB",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.Fault.Workers":                          "Workers is the number of CPU cores cpu_stress keeps busy, defaults to 1",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.PodSelector":                            "PodSelector selects pods in a Kubernetes cluster using a label selector",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.PodSelector.Namespace":                  "Namespace of the pods, defaults to default",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos.PodSelector.Selector":                   "Selector is a label selector e.g. app=api",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.AWSCredentials":                         "AWSCredentials materializes the local AWS credentials so that they can be passed to containers and clusters. Credentials are read from the environment, the shared credentials file, or the container and instance metadata services. The generated credentials file can be mounted into a container at container_path and the environment variables set using env. Temporary credentials are refreshed on every apply and renewed when they expire.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.AWSCredentials.ContainerPath":           "ContainerPath is the path the credentials file will be mounted at in the container, defaults to /etc/jumppad/aws/credentials",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.AWSCredentials.Env":                     "Env contains the environment variables to set in a container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.AWSCredentials.Expiration":              "Expiration of temporary credentials in RFC3339 format",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.AWSCredentials.File":                    "File is the path on the host of the generated credentials file",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.AWSCredentials.Profile":                 "Profile to read from the shared credentials file, when set the environment is ignored",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.AWSCredentials.Region":                  "Region to set in the environment, defaults to the region for the profile",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.AWSCredentials.Source":                  "Source of the credentials, env, profile, or metadata",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.GCPCredentials":                         "GCPCredentials materializes the local Google Cloud credentials so that they can be passed to containers and clusters. Credentials are read from the file referenced by GOOGLE_APPLICATION_CREDENTIALS, the gcloud application default credentials, or the metadata server. The generated credentials file can be mounted into a container at container_path and the environment variables set using env. Access tokens from the metadata server are refreshed on every apply and renewed when they expire.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.GCPCredentials.AccessToken":             "AccessToken is only set when the credentials are read from the metadata server",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.GCPCredentials.ContainerPath":           "ContainerPath is the path the credentials file will be mounted at in the container, defaults to /etc/jumppad/gcp/credentials.json",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.GCPCredentials.Env":                     "Env contains the environment variables to set in a container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.GCPCredentials.Expiration":              "Expiration of the access token in RFC3339 format",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.GCPCredentials.File":                    "File is the path on the host of the generated credentials file",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.GCPCredentials.Project":                 "Project to set in the environment, defaults to the project from the environment or credentials",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud.GCPCredentials.Source":                  "Source of the credentials, env, profile, or metadata",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Capabilities.Add":                   "CapAdd is a list of kernel capabilities to add to the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Capabilities.Drop":                  "CapDrop is a list of kernel capabilities to remove from the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Clock":                              "Clock runs the processes in a container with a fake clock using libfaketime, the clock can be offset from the real time, start at a fixed time, and run faster or slower than the real time. This can be used to demo certificate expiry, token rotation, and scheduled jobs without waiting. libfaketime is loaded with LD_PRELOAD so it must be installed in the image or mounted with a volume, creating the container fails when the library can not be found. The fake clock only changes the time for dynamically linked programs, statically linked programs, including most Go programs such as Vault and Consul, read the real time. Linux time namespaces are not used as they can not change the wall clock. The following example builds an Alpine image with libfaketime installed from a Dockerfile containing: FROM alpine:3.20 RUN apk add --no-cache libfaketime ```hcl resource \"build\" \"faketime\" { container { dockerfile = \"./Dockerfile\" context = \"./\" } } resource \"container\" \"clock\" { image { name = resource.build.faketime.image } command = [\"sh\", \"-c\", \"while true; do date; sleep 1; done\"] clock { offset = \"+30d\" rate = 60 } } ```",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Clock.Library":                      "Library is the path to libfaketime in the container, defaults to /usr/lib/faketime/libfaketime.so.1, Debian and Ubuntu images install it to /usr/lib/x86_64-linux-gnu/faketime/libfaketime.so.1",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Clock.Offset":                       "Offset from the real time e.g. +30d or -2h, the units are s, m, h, d, and y, seconds are used when no unit is given",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Clock.Rate":                         "Rate the clock runs at compared to the real time, 60 makes a minute pass every second, defaults to 1",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Clock.StartAt":                      "StartAt is the RFC3339 time the clock starts at when the container starts e.g. 2030-01-01T00:00:00Z, can not be used with offset",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container":                          "Container defines a structure for creating Docker containers",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Capabilities":             "Capabilities to add or drop from the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Clock":                    "Clock runs the container with a fake clock",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Command":                  "Command to use when starting the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.ContainerName":            "ContainerName is the fully qualified domain name for the container, this can be used to access the container from other sources",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.DNS":                      "Add custom DNS servers to the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Entrypoint":               "Entrypoint to use when starting the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Environment":              "Environment variables to set when starting the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.HealthCheck":              "health checks for the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Image":                    "Image to use for the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Labels":                   "Labels to set on the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Networks":                 "Attach to the correct network // only when Image is specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.PortRanges":               "Range of ports to expose",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Ports":                    "Ports to expose",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Privileged":               "Run the container in privileged mode?",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Resources":                "resource constraints",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.RunAs":                    "User block for mapping the user id and group id inside the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Container.Volumes":                  "Volumes to attach to the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.GPU.DeviceIDs":                      "device ids to use for the GPU",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.GPU.Driver":                         "driver to use for the GPU",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Image":                              "Image defines a docker image which will be pushed to the clusters Docker registry",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Image.ID":                           "ID is the unique identifier for the image, this is independent of tag and changes each time the image is built. An image that has been tagged multiple times also shares the same ID.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Image.Password":                     "Password is the Docker registry password to use for private repositories",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Image.Username":                     "Username is the Docker registry user to use for private repositories",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.NetworkAttachment.Aliases":          "Network aliases for the resource",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.NetworkAttachment.AssignedAddress":  "AssignedAddress will equal if IPAddress is set, else it will be the value automatically assigned from the network",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.NetworkAttachment.IPAddress":        "Optional address to assign",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.NetworkAttachment.Name":             "Name will equal the name of the network as created by jumppad",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Port":                               "Port is a port mapping",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Port.Host":                          "Host port",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Port.Local":                         "Local port in the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Port.OpenInBrowser":                 "When a host port is defined open this port with the given path in a browser",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Port.Protocol":                      "Protocol tcp, udp",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Port.Remote":                        "Remote port of the service",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.PortRange":                          "PortRange allows a range of ports to be mapped",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.PortRange.EnableHost":               "Host port",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.PortRange.Protocol":                 "Protocol tcp, udp",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.PortRange.Range":                    "Local port in the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Resources":                          "Resources allows the setting of resource constraints for the Container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Resources.CPU":                      "cpu limit for the container where 1 CPU = 1000",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Resources.CPUPin":                   "pin the container to one or more cpu cores",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Resources.GPU":                      "GPU resource constraints",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Resources.Memory":                   "max memory the container can consume in MB",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar":                            "Sidecar defines a structure for creating Docker containers",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.Command":                    "command to use when starting the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.ContainerName":              "ContainerName is the fully qualified domain name for the container the sidecar is linked to, this can be used to access the sidecar from other sources",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.Entrypoint":                 "entrypoint to use when starting the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.Environment":                "environment variables to set when starting the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.HealthCheck":                "health checks for the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.Image":                      "image to use for the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.Labels":                     "labels to set on the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.Privileged":                 "run the container in privileged mode?",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.Resources":                  "resource constraints",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Sidecar.Volumes":                    "volumes to attach to the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.User.Group":                         "Group is the GroupID of the user to run the container as",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.User.User":                          "Username or UserID of the user to run the container as",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Volume":                             "Volume defines a folder, Docker volume, or temp folder to mount to the Container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Volume.BindPropagation":             "propagation mode for bind mounts [shared, private, slave, rslave, rprivate]",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Volume.BindPropagationNonRecursive": "recursive bind mount, default true",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Volume.Destination":                 "path to mount the volume inside the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Volume.ReadOnly":                    "specify that the volume is mounted read only",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Volume.SelinuxRelabel":              "selinux_relabeling [\"\", shared, private]",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Volume.Source":                      "source path on the local machine for the volume",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container.Volume.Type":                        "type of the volume to mount [bind, volume, tmpfs]",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/copy.Copy":                                    "Docs allows the running of a Docusaurus container which can be used for online tutorials or documentation",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/copy.Copy.Checksum":                           "Checksum verifies the source file before it is copied, the value is in the format type:value where type is one of md5, sha1, sha256, or sha512",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/copy.Copy.CopiedFiles":                        "outputs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/copy.Copy.Destination":                        "Destination to write file or files to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/copy.Copy.Permissions":                        "Permissions 0777 to set for written file",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/copy.Copy.Source":                             "Source file, folder, url, git repo, etc",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/copy.Copy.SourceChecksum":                     "SourceChecksum is the hash of a local source when it was copied, it is used to copy the files again when the source changes",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/copy.Copy.Unpack":                             "Unpack controls the extraction of tar and zip archives into the destination, when not set remote archives are unpacked and local archives are copied as is",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Custom":                                "Custom runs a WebAssembly module to create and destroy a resource, the module is sandboxed using WASI and can only access the mounted directories. The module is called with the operation, create or destroy, as its only argument and a JSON request containing the config on stdin, the values written to stdout as JSON are set as the output of the resource.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Custom.Config":                         "Values passed to the module",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Custom.Environment":                    "Environment variables available to the module",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Custom.Module":                         "Path to the WASI module",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Custom.ModuleChecksum":                 "Checksum of the module",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Custom.Mounts":                         "Directories the module can access",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Custom.Output":                         "Values returned by the module",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Custom.Timeout":                        "Maximum time the module can run for",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Mount":                                 "Mount makes a directory on the host available to the module",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Mount.Destination":                     "Path of the directory in the module",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Mount.ReadOnly":                        "Prevent the module writing to the directory",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom.Mount.Source":                          "Directory on the host",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.ChapterIndexPage.Titles":                 "Titles of the translated pages keyed by locale",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs":                                    "Docs allows the running of a Docusaurus container which can be used for online tutorials or documentation",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs.ContainerName":                      "ContainerName is the fully qualified resource name for the container, this can be used to access the container from other sources",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs.ContentChecksum":                    "ContentChecksum is the checksum of the content directory, this is used to determine if the docs need to be recreated",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs.DefaultLocale":                      "DefaultLocale is the locale of the page content, defaults to en",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs.Image":                              "image to use for the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs.Locales":                            "Locales that can be selected in the docs UI, defaults to the locales that pages have been translated to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs.Networks":                           "Attach to the correct network // only when Image is specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs.OpenInBrowser":                      "When a host port is defined open the location in a browser",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs.Resources":                          "resource constraints for the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Docs.Variants":                           "Variants allow the reader to select instructions e.g. for their operating system or cloud",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.ExecValidation":                          "ExecValidation runs a command or script in a container, the validation passes when the exit code and output match",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.ExecValidation.Target":                   "Target is the ID of the container resource to run the command in",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.HTTPValidation":                          "HTTPValidation makes a HTTP request, the validation passes when the status code and body match",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.KubernetesValidation":                    "KubernetesValidation checks an object in a Kubernetes cluster, the validation passes when the object exists and, when Ready is set, reports that it is ready",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.KubernetesValidation.KubeConfig":         "KubeConfig is the path to the kubeconfig for the cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Page.Source":                             "Source is the path to a markdown file containing the content, files in the same folder with a locale before the extension e.g. index.fr.md are loaded as translations",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Page.Translations":                       "Translations of the content keyed by locale",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Task.Validations":                        "Validations grade the task by checking the state of blueprint resources",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.TaskValidation":                          "TaskValidation checks that a learner has completed a step by running a command in a container, probing a HTTP endpoint, or checking a Kubernetes object, exactly one check must be specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs.Variant":                                 "Variant defines a choice that is selected in the docs UI, content wrapped in <Variant name=\"os\" value=\"linux\"> is only shown for the selected option",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec":                                    "Exec allows commands to be executed either locally or remotely",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Checksum":                           "Checksum of the script",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Daemon":                             "Should the process run as a daemon",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Environment":                        "environment variables to set",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.ExitCode":                           "Exit code of the process",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Image":                              "If remote, either Image or Target must be specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Networks":                           "Attach to the correct network // only when Image is specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.NomadTask":                          "NomadTask runs the script in a task of a running Nomad allocation",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Output":                             "output values returned from exec",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.PID":                                "output",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Resources":                          "resource constraints for the container // only when Image is specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Restart":                            "Restart policy for daemonized local execs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.RunAs":                              "User block for mapping the user id and group id inside the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.SSH":                                "SSH runs the script on a remote machine, volumes are uploaded to the machine before the script runs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Script":                             "script to execute",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Target":                             "Attach to a running target and exec",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Timeout":                            "Set the timeout for the command",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.Volumes":                            "Volumes to mount to container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Exec.WorkingDirectory":                   "Working directory to execute commands",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.NomadTask":                               "NomadTask is a task in a job running on a Nomad cluster, the script is run in the first running allocation that contains the task",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.NomadTask.Cluster":                       "Cluster is the Nomad cluster that the job is running on",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.NomadTask.Group":                         "Group is the task group, when not set any group containing the task is used",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Restart":                                 "Restart defines how a daemonized local exec is supervised, the process is restarted according to the policy and its log file is rotated",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Restart.Delay":                           "Delay is the time to wait before restarting the process, e.g. 5s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Restart.MaxRestarts":                     "MaxRestarts limits the number of restarts, 0 is unlimited",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.Restart.Policy":                          "Policy is one of no, on-failure or always",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.SSH":                                     "SSH is a remote machine that the script is run on",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.SSH.HostKey":                             "HostKey is the public key of the host in authorized_keys format, when not set the host key is not verified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec.SSH.Key":                                 "Key is the path to a private key or a PEM encoded private key",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckContainer":             "HealthCheckContainer is an internal block for configuration which allows the user to define the criteria for successful creation",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckContainer.Timeout":     "Timeout expressed as a go duration i.e 10s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckExec.Command":          "Command to execute, the command is run in the target container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckExec.ExitCode":         "ExitCode to mark a successful check, default 0",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckExec.Script":           "Script specified as a string to execute, the script can be a bash or a sh script scripts are copied to the container /tmp directory, marked as executable and run",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckHTTP":                  "HealthCheckHTTP defines a HTTP based health check",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckHTTP.Address":          "HTTP endpoint to check",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckHTTP.Body":             "Payload to send with check",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckHTTP.Headers":          "HTTP headers to send with request",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckHTTP.Method":           "HTTP method to use, default GET",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckHTTP.SuccessCodes":     "HTTP status codes that signal the health of the endpoint, default 200",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckKubernetes.Pods":       "pods = [\"component=server,app=consul\", \"component=client,app=consul\"] // is the pod running and healthy",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckKubernetes.Timeout":    "Timeout expressed as a go duration i.e 10s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckNomad.Jobs":            "jobs = [\"redis\"] // are the Nomad jobs running and healthy",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckNomad.Timeout":         "Timeout expressed as a go duration i.e 10s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/healthcheck.HealthCheckTCP.Address":           "address = \"consul-consul:8500\" // can a TCP connection be made",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm":                                    "Helm defines configuration for running Helm charts",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.Chart":                              "name of the chart within the repository or Go Getter reference to download chart from",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.Checksum":                           "Checksum is the hash of the chart, version and values when the chart was installed, it is used to install the chart again when they change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.CreateNamespace":                    "CreateNamespace when set to true Helm will create the namespace before installing",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.HealthCheck":                        "Define health checks for the pods deployed by the chart",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.Labels":                             "Labels to add to the release and the Kubernetes objects created by the chart",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.Namespace":                          "Namespace is the Kubernetes namespace",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.Repository":                         "Optional HelmRepository, if specified will try to download the chart from the give repository",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.Retry":                              "Retry the install n number of times",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.SkipCRDs":                           "Skip the install of any CRDs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.Timeout":                            "Timeout specifies the maximum time a chart can run, default 300s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm.Helm.Version":                            "semver of the chart to install",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/http.HTTP.ExpectedStatus":                     "ExpectedStatus is the list of status codes that are considered a success, when not set any status code is accepted",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/http.HTTP.JSONOutputs":                        "JSONOutputs maps the name of an output to the path of a field in the JSON response body, e.g. data.items.0.id",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/http.HTTP.Output":                             "values of the JSONOutputs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/http.HTTP.Retries":                            "Retries is the number of times the request is retried when it fails or the status is not one of ExpectedStatus",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/http.HTTP.RetryDelay":                         "RetryDelay is the time to wait between retries, defaults to 1s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/http.HTTP.Status":                             "Output parameters",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress":                              "Ingress defines an ingress service mapping ports between local host and resources like containers and kube cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.ExposeLocal":                  "Are we exposing a local serve to the target if",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.IngressID":                    "IngressId stores the ID of the created connector service",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.IngressIDs":                   "IngressIDs stores the IDs of the created connector services keyed by target port when ports is set",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.LocalAddress":                 "LocalAddress is the fully qualified uri for accessing the resource from the local machine",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.LocalAddresses":               "LocalAddresses stores the local address for each target port when ports is set",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.LocalPortOffset":              "LocalPortOffset is added to each target port defined in ports to calculate the local port",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.OpenInBrowser":                "path to open in the browser",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.Port":                         "local port to expose the service on",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.Ports":                        "Ports is a list of target ports or port ranges to expose, e.g. [\"8080\", \"9090-9099\"], when set port and target.port are ignored",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.RemoteAddress":                "RemoteAddress is the fully qualified uri for accessing the resource in the remote machine",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.RemoteAddresses":              "RemoteAddresses stores the remote address for each target port when ports is set",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.TLS":                          "TLS enables HTTPS termination for the ingress",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.Ingress.Target":                       "details for the destination service",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TLS":                                  "TLS defines the configuration for terminating TLS at the local connector",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TLS.CACert":                           "CACert is the path to the CA used to sign the certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TLS.Cert":                             "Cert is the path to the generated certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TLS.Certificate":                      "Certificate is an optional certificate_leaf resource used to terminate TLS instead of the generated certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TLS.DNSNames":                         "Additional DNS names to add to the generated certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TLS.Enabled":                          "Enabled terminates TLS for the ingress, when no certificate is set a certificate signed by the jumppad CA is generated",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TLS.IPAddresses":                      "Additional IP addresses to add to the generated certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TLS.Key":                              "Key is the path to the private key for the generated certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TLS.TrustCA":                          "TrustCA adds the CA that signed the certificate to the local system trust store, the CA is removed when the last ingress trusting it is destroyed",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TrafficTarget":                        "Traffic defines either a source or a destination block for ingress traffic",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress.TrafficTarget.Config":                 "Config is an collection which has driver specific content",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster":                                  "Cluster is a config stanza which defines a Kubernetes or a Nomad cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.APIPort":                          "Port the API server is running on",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.CollectLogs":                      "CollectLogs runs a process that writes the logs of all the pods in the cluster to logs_directory",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.ConnectorPort":                    "Port the connector is running on",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.ContainerName":                    "Fully qualified domain name for the container, this address can be used to reference the container within docker and from other containers",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.CopyImages":                       "Images that will be copied from the local docker cache to the cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.Environment":                      "environment variables to set when starting the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.ExternalIP":                       "ExternalIP is the ip address of the cluster, this generally resolves to the docker ip",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.Image":                            "optional image to use when creating the cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.KubeConfig":                       "Kubernetes config details",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.Labels":                           "Labels to set on the cluster containers and the Kubernetes objects created by jumppad",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.LogCollectorPID":                  "LogCollectorPID is the process id of the log collector",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.LogsDirectory":                    "LogsDirectory is the directory the pod logs are written to when collect_logs is set, $HOME/.jumppad/logs/[name]",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.Networks":                         "Attach to the correct network // only when Image is specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.PortRanges":                       "range of ports to expose",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.Ports":                            "ports to expose",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.Resources":                        "Define resource constraints for the cluster ```hcl resources { cpu = 100 memory = 1024 } ```",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Cluster.Volumes":                          "volumes to attach to the cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.ClusterConfig.DockerConfig":               "Specifies configuration for the Docker driver.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Config":                                   "K8sConfig applies and deletes and deletes Kubernetes configuration",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Config.HealthCheck":                       "HealthCheck defines a health check for the resource",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Config.JobChecksums":                      "JobChecksums store a checksum of the files or paths referenced in the Paths field this is used to detect when a file changes so that it can be re-applied",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Config.Labels":                            "Labels to add to the Kubernetes objects in the files",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Config.Paths":                             "Path of a file or directory of Kubernetes config files to apply",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Config.WaitUntilReady":                    "WaitUntilReady when set to true waits until all resources have been created and are in a \"Running\" state",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.DockerConfig.InsecureRegistries":          "InsecureRegistries is a list of docker registries that should be treated as insecure",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.DockerConfig.NoProxy":                     "NoProxy is a list of docker registires that should be excluded from the image cache",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.KubeConfig.CA":                            "base64 encoded ca certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.KubeConfig.ClientCertificate":             "base64 encoded client certificate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.KubeConfig.ClientKey":                     "base64 encoded client key",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.KubeConfig.ConfigPath":                    "path to the kubeconfig file",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Secret":                                   "Secret reads a secret from a Kubernetes cluster, the secret is not created or modified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Secret.Data":                              "Data contains the decoded values of the secret",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Secret.Timeout":                           "Timeout to wait for the secret to exist, defaults to 60s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Secret.Type":                              "Type of the secret i.e Opaque, kubernetes.io/service-account-token",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Service":                                  "Service reads a service from a Kubernetes cluster, the service is not created or modified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Service.ExternalIP":                       "ExternalIP is the address of the load balancer for LoadBalancer services",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Service.NodePorts":                        "NodePorts maps the name of each port to the node port",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Service.Ports":                            "Ports maps the name of each port to the port number, unnamed ports use the port number as the name",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Service.Timeout":                          "Timeout to wait for the service to exist, defaults to 60s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s.Service.Type":                             "Type of the service i.e ClusterIP, NodePort, LoadBalancer",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.Intention":                               "Intention allows or denies traffic from the source service to the destination service, for Istio the source is the service account and the destination is the app label of the pods in the namespace",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.Intention.Action":                        "Action is allow or deny, defaults to allow",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.Intention.Namespace":                     "Namespace of the destination service, defaults to default",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.ServiceMesh":                             "ServiceMesh installs Consul service mesh or Istio on a Kubernetes cluster, enables sidecar injection for namespaces and creates the policies that control which services can communicate",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.ServiceMesh.DefaultDeny":                 "DefaultDeny denies all traffic between services that is not allowed by an intention",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.ServiceMesh.InjectNamespaces":            "InjectNamespaces are the namespaces where sidecars are injected into pods, namespaces that do not exist are created, defaults to default",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.ServiceMesh.Intentions":                  "Intentions allow or deny traffic between services, for Istio these are created as AuthorizationPolicies",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.ServiceMesh.Mesh":                        "Mesh is the service mesh to install, consul or istio",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.ServiceMesh.Namespace":                   "Namespace the control plane is installed to, defaults to consul for Consul and istio-system for Istio",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.ServiceMesh.Timeout":                     "Timeout is the maximum time to wait for the control plane to start, default 300s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh.ServiceMesh.Version":                     "Version of the Helm charts, defaults to the latest version",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring":                        "Monitoring runs Prometheus, Grafana and Loki containers that are configured to collect the metrics and logs of the containers and clusters in the environment",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.Dashboards":             "Dashboards is a directory of Grafana dashboard JSON files which are provisioned along with the default dashboards",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.GrafanaAddress":         "GrafanaAddress is the address of the Grafana UI on the host",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.GrafanaContainerName":   "Fully qualified domain names of the containers, these can be used to reference the services from other containers",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.GrafanaPort":            "Host ports for the UIs and APIs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.K8sClusters":            "K8sClusters are scraped by Prometheus, the logs of clusters that set collect_logs are sent to Loki",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.LokiAddress":            "LokiAddress is the address of the Loki API on the host",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.LokiPort":               "defaults to 3100",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.Networks":               "Attach to the correct network",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.NomadClusters":          "NomadClusters are scraped by Prometheus, the logs of clusters that set collect_logs are sent to Loki",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.PrometheusAddress":      "PrometheusAddress is the address of the Prometheus API on the host",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.PrometheusPort":         "defaults to 9090",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Monitoring.Scrape":                 "Scrape defines additional Prometheus scrape jobs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Scrape":                            "Scrape is a Prometheus scrape job",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Scrape.Path":                       "Path defaults to /metrics",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Scrape.Scheme":                     "Scheme is http or https, defaults to http",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring.Scrape.Targets":                    "Targets are the host:port addresses to scrape",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule":                         "FirewallRule controls the inbound traffic to a container from other containers, networks, or CIDR ranges. Rules are applied using iptables and ip6tables in the network namespace of the target container, allow rules always take precedence over deny rules.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule.Action":                  "Action to take for matching traffic, either allow or deny, defaults to deny",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule.Image":                   "Image used to apply the rules, must contain the iptables and ip6tables commands",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule.Ports":                   "Ports restricts the rule to the given destination ports, when not set the rule applies to all ports",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule.Protocol":                "Protocol for the rule, either tcp, udp, or all, defaults to all or tcp when ports are specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule.Rules":                   "Rules are the rule specifications that have been applied to the target, prefixed with either iptables or ip6tables",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule.SourceCIDRs":             "address ranges the traffic originates from",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule.SourceNetworks":          "networks the traffic originates from",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule.Sources":                 "containers the traffic originates from",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.FirewallRule.Target":                  "Target is the container that the rule controls inbound traffic for",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.Network":                              "Network defines a Docker network",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.Network.Gateway":                      "Gateway for the IPv4 subnet, when not set Docker uses the first address in the subnet",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.Network.GatewayIPv6":                  "GatewayIPv6 is the gateway for the IPv6 subnet",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.Network.Labels":                       "Labels to set on the Docker network",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.Network.SubnetIPv6":                   "SubnetIPv6 is the IPv6 subnet for the network, requires enable_ipv6",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition":                     "NetworkCondition applies latency, packet loss, and bandwidth shaping to the network interfaces of containers using tc netem",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition.Bandwidth":           "maximum rate for outbound traffic e.g. 1mbit",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition.Image":               "Image used to apply the conditions, must contain the tc command",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition.Interface":           "Interface is the name of the interface to apply the conditions to, when not set the interface for Network is used, or eth0",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition.Interfaces":          "Interfaces is a map of container name to the interface that the conditions have been applied to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition.Jitter":              "random variation of the latency e.g. 10ms",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition.Latency":             "delay added to outbound packets e.g. 100ms",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition.Loss":                "percentage of packets to drop",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition.Network":             "Network restricts the conditions to the interface attached to this network",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network.NetworkCondition.Targets":             "Targets are the containers to apply the conditions to, when not set the conditions are applied to all containers attached to Network",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.Config.DockerConfig":                    "Specifies configuration for the Docker driver.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.DockerConfig.InsecureRegistries":        "InsecureRegistries is a list of docker registries that should be treated as insecure",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.DockerConfig.NoProxy":                   "NoProxy is a list of docker registires that should be excluded from the image cache",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadAllocation":                        "NomadAllocation reads the addresses of the running allocations for a task, the job is not created or modified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadAllocation.Cluster":                "Cluster is the cluster the job is running on",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadAllocation.Endpoints":              "Endpoints contains the ports of each running allocation, the port label is mapped to the address in the form ip:port",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadAllocation.IP":                     "IP is the address of the first running allocation",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadAllocation.Ports":                  "Ports maps the port labels of the first running allocation to the port",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadAllocation.Timeout":                "Timeout to wait for a running allocation, defaults to 60s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster":                           "Cluster is a config stanza which defines a Kubernetes or a Nomad cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.APIPort":                   "The APIPort the server is running on",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.ClientContainerName":       "The fully qualified docker address for the client nodes",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.CollectLogs":               "CollectLogs runs a process that writes the logs of all the allocations in the cluster to logs_directory",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.Config":                    "Configuration for the drivers",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.ConfigDir":                 "The directory where the server and client config is written to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.ConnectorPort":             "The Port where the connector is running",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.CopyImages":                "Images that will be copied from the local docker cache to the cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.Datacenter":                "Nomad datacenter, defaults dc1",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.ExternalIP":                "ExternalIP is the ip address of the cluster, this generally resolves to the docker ip",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.Image":                     "optional image to use for the cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.Labels":                    "Labels to set on the cluster containers, the labels are also added to the meta of the client nodes",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.LogCollectorPID":           "LogCollectorPID is the process id of the log collector",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.LogsDirectory":             "LogsDirectory is the directory the allocation logs are written to when collect_logs is set, $HOME/.jumppad/logs/[name]",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.Networks":                  "Attach to the correct network // only when Image is specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.OpenInBrowser":             "open the UI in the browser after creation",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.PortRanges":                "range of ports to expose",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.Ports":                     "Additional ports to expose on the nomad sever node",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.Resources":                 "resource constraints for each node in the cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.ServerContainerName":       "The fully qualified docker address for the server",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadCluster.Volumes":                   "volumes to attach to the cluster",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadJob":                               "NomadJob applies and deletes and deletes Nomad cluster jobs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadJob.Cluster":                       "Cluster is the name of the cluster to apply configuration to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadJob.HealthCheck":                   "HealthCheck defines a health check for the resource",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadJob.JobChecksums":                  "JobChecksums stores a checksum of the files or paths",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadJob.Labels":                        "Labels to add to the meta of the jobs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadJob.Paths":                         "Path of a file or directory of Job files to apply",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadService":                           "NomadService reads the addresses of a service registered with the Nomad service discovery",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadService.Addresses":                 "Addresses of the service instances in the form ip:port",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadService.Cluster":                   "Cluster is the cluster the service is registered with",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadService.IP":                        "IP and Port of the first service instance",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadService.Name":                      "Name of the service",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadService.Timeout":                   "Timeout to wait for the service to be registered, defaults to 60s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume":                            "NomadVolume makes a volume available to the jobs in a Nomad cluster. Host volumes are added to the client config of every node and the nodes are restarted so that Nomad loads the volume, the folder for the volume is created on each node. Data in host volumes is stored in the node containers and is removed with the cluster unless the path is a volume mounted into the cluster. CSI volumes are registered with a CSI plugin, the plugin must be running in the cluster e.g. deployed with a nomad_job resource. When external_id is not set the plugin creates the volume and the volume is deleted when the resource is destroyed. ```hcl resource \"nomad_volume\" \"postgres\" { cluster = resource.nomad_cluster.dev } resource \"nomad_job\" \"postgres\" { depends_on = [\"resource.nomad_volume.postgres\"] cluster = resource.nomad_cluster.dev paths = [\"./jobs/postgres.nomad\"] } ``` The job uses the name of the volume as the source of the volume block.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.AccessMode":                 "AccessMode of the CSI volume, defaults to single-node-writer",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.AttachmentMode":             "AttachmentMode of the CSI volume, defaults to file-system",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.CapacityMin":                "CapacityMin and CapacityMax are the size of a created CSI volume e.g. 10GiB",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.Cluster":                    "Cluster is the cluster to add the volume to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.ExternalID":                 "ExternalID is the id of an existing volume in the storage provider, when not set the plugin creates the volume",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.Name":                       "Name of the volume in Nomad, defaults to the name of the resource",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.Parameters":                 "Parameters and Secrets are passed to the CSI plugin",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.Path":                       "Path of the host volume on the nodes, defaults to /opt/jumppad/volumes/[name]",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.PluginID":                   "PluginID is the id of the CSI plugin for the volume",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.ReadOnly":                   "ReadOnly makes the host volume read only",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.Timeout":                    "Timeout to wait for the nodes or the CSI plugin to be ready, defaults to 120s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad.NomadVolume.Type":                       "Type of the volume host or csi, defaults to host",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ollama.OllamaModel.Digest":                    "output fields",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/plugin.Resource":                              "Resource is a resource whose type is provided by an external plugin, the attributes declared in the plugin schema are set using the config attribute and the values returned by the plugin are available as output resource \"kafka_cluster\" \"main\" { config = { brokers = 3 } }",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/plugin.Resource.Config":                       "attributes defined by the plugin schema",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/plugin.Resource.Output":                       "values returned by the plugin",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomBytes":                           "allows the generation of random bytes that can be used as keys, the generated bytes are treated as sensitive",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomBytes.Base64":                    "Output parameters",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomBytes.Keepers":                   "Keepers force a new value to be generated when any of the values change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomCreature":                        "allows the generation of random creatures",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomCreature.Keepers":                "Keepers force a new value to be generated when any of the values change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomCreature.Value":                  "Output parameters",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomID":                              "allows the generation of random IDs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomID.Base64":                       "Output parameters",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomID.Keepers":                      "Keepers force a new value to be generated when any of the values change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomInteger":                         "allows the generation of random integers between min and max inclusive",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomInteger.Keepers":                 "Keepers force a new value to be generated when any of the values change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomInteger.Value":                   "Output parameters",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomNumber":                          "allows the generation of random numbers",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomNumber.Keepers":                  "Keepers force a new value to be generated when any of the values change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomNumber.Value":                    "Output parameters",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomPassword":                        "allows the generation of random Passwords",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomPassword.Keepers":                "Keepers force a new value to be generated when any of the values change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomPassword.Value":                  "Output parameters",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomPort":                            "allows the selection of a free local port, once selected the port is stored in the state and remains stable across runs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomPort.Keepers":                    "Keepers force a new value to be generated when any of the values change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomPort.Min":                        "Min and Max optionally restrict the range the port is chosen from, when not set the operating system selects a free port",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomPort.Protocol":                   "Protocol of the port, tcp or udp, defaults to tcp",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomPort.Value":                      "Output parameters",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomUUID":                            "allows the generation of random UUIDs",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomUUID.Keepers":                    "Keepers force a new value to be generated when any of the values change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/random.RandomUUID.Value":                      "Output parameters",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/requirements.Jumppad":                         "Jumppad defines the version of jumppad and the features needed to run a blueprint, the requirements are checked when the configuration is parsed before any resources are created",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/requirements.Jumppad.RequiredFeatures":        "RequiredFeatures are the features of jumppad the blueprint uses",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/requirements.Jumppad.RequiredVersion":         "RequiredVersion is the version of jumppad e.g. 0.13.0 or a constraint e.g. >= 0.9, < 0.14",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.EnvSecret":                             "EnvSecret reads a sensitive value from an environment variable when the blueprint is applied.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.EnvSecret.Default":                     "Default value used when the environment variable is not set, when not specified an unset variable is an error",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.EnvSecret.Value":                       "Value of the environment variable",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.EnvSecret.Variable":                    "Variable is the name of the environment variable to read",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.OnePasswordSecret":                     "OnePasswordSecret reads a secret using the 1Password CLI `op` when the blueprint is applied. The CLI must be installed and signed in.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.OnePasswordSecret.Account":             "Account to use when signed in to multiple accounts, optional",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.OnePasswordSecret.Reference":           "Reference to the secret, e.g. op://vault/item/field",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.OnePasswordSecret.Value":               "Value of the secret",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.Secret":                                "Secret holds a sensitive value such as a token or password. The value is encrypted when written to the state using the local secrets key and is redacted from logs and the output of `jumppad output`. Any attribute that interpolates the value, such as the environment variables of a container, is also encrypted in the state. When the secrets key is removed or rotated the value can not be decrypted, a warning is shown and generated values are created again.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.Secret.Length":                         "Length of the generated value, defaults to 32",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.Secret.Value":                          "Value of the secret, when not set a random value is generated",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.VaultSecret":                           "VaultSecret reads a secret from HashiCorp Vault when the blueprint is applied. Both KV version 1 and version 2 secrets are supported.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.VaultSecret.Address":                   "Address of the Vault server, defaults to the environment variable VAULT_ADDR",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.VaultSecret.Key":                       "Key in the secret to set as value, optional",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.VaultSecret.Namespace":                 "Namespace for Vault Enterprise, defaults to the environment variable VAULT_NAMESPACE",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.VaultSecret.Path":                      "Path of the secret including the mount, e.g. secret/data/myapp",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.VaultSecret.Token":                     "Token used to authenticate, defaults to the environment variable VAULT_TOKEN",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.VaultSecret.Value":                     "Value of the key in the secret, only set when key is specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret.VaultSecret.Values":                    "Values contains all the keys in the secret",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/sync.Sync":                                    "Sync mirrors a local directory into a running container or a volume. The initial sync happens when the resource is created, `jumppad dev` keeps the destination up to date by watching the source for changes. When bidirectional is set, changes made in the destination are also synced back to the source.",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/sync.Sync.Bidirectional":                      "Bidirectional syncs files that are created, changed or removed in the destination back to the source while `jumppad dev` is running",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/sync.Sync.Destination":                        "Absolute path in the container or volume to sync to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/sync.Sync.Ignore":                             "Ignore is a list of glob patterns for files and directories that should not be synced, patterns are matched against the path relative to the source and the file name",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/sync.Sync.Source":                             "Local directory to sync",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/sync.Sync.Target":                             "Container to sync the files to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/sync.Sync.Volume":                             "Volume to sync the files to",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/template.Template":                            "Template allows the process of user defined templates",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/template.Template.Checksum":                   "Checksum of the parsed template",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/template.Template.Destination":                "Destination filename to write",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/template.Template.Engine":                     "Engine used to process the template, either handlebars or go, defaults to handlebars",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/template.Template.Files":                      "Files written by the template",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/template.Template.Source":                     "Source template to be processed as string or file",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/template.Template.SourceDir":                  "SourceDir is a directory of templates, every file in the directory tree is processed and written to DestinationDir preserving the directory structure and file permissions",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/template.Template.Variables":                  "Variables to be processed in the template, variables can reference the outputs of other resources such as container ips or random values, the template is processed after the referenced resources have been created and is processed again when their outputs change",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terminal.Terminal":                            "Terminal serves a browser based shell attached to a container or the local machine, access to the terminal requires the token",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terminal.Terminal.Port":                       "Port to serve the terminal on, a free port is selected when not set",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terminal.Terminal.Target":                     "Target is the container to attach to, when not set the shell runs on the local machine",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terminal.Terminal.Token":                      "Token used to access the terminal, a random token is generated when not set",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terminal.Terminal.URL":                        "URL of the terminal including the token",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform":                          "ExecRemote allows commands to be executed in remote containers",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.ApplyOutput":              "output from the terraform apply",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.Environment":              "environment variables to set when starting the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.Networks":                 "Attach to the correct network // only when Image is specified",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.Output":                   "output values returned from Terraform",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.Source":                   "Source directory containing Terraform config",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.SourceChecksum":           "checksum of the source directory",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.Variables":                "variables to pass to terraform",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.Version":                  "Version of terraform to use",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.Volumes":                  "Volumes to attach to the container",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform.Terraform.WorkingDirectory":         "Working directory to run terraform commands",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/wait.Command":                                 "Command is a condition that is met when the command returns the exit code, the command is run on the local machine",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/wait.Command.Command":                         "Command to execute",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/wait.Command.ExitCode":                        "ExitCode to mark the condition as met, default 0",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/wait.Command.Script":                          "Script to execute with sh",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/wait.Wait":                                    "Wait blocks until the duration has elapsed and all the conditions are met, resources that depend on a Wait are not created until it completes",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/wait.Wait.Duration":                           "Duration to wait before the conditions are checked i.e 10s",
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/wait.Wait.Timeout":                            "Timeout for all the conditions to be met, defaults to 300s",
+}
@@ -0,0 +1,53 @@
+//go:build ignore
+
+// gen writes the doc comments of the resource structs to docs_generated.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+
+	"github.com/jumppad-labs/jumppad/pkg/config/schema"
+)
+
+func main() {
+	docs, err := schema.ParseDocs("../../..", "github.com/jumppad-labs/jumppad", "pkg/config/resources")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	keys := []string{}
+	for k := range docs {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	buf := &bytes.Buffer{}
+	fmt.Fprintln(buf, "// Code generated by gen.go. DO NOT EDIT.")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "package schema")
+	fmt.Fprintln(buf)
+	fmt.Fprintln(buf, "// fieldDocs contains the doc comments of the resource structs")
+	fmt.Fprintln(buf, "var fieldDocs = map[string]string{")
+	for _, k := range keys {
+		fmt.Fprintf(buf, "%q: %q,\n", k, docs[k])
+	}
+	fmt.Fprintln(buf, "}")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	err = os.WriteFile("docs_generated.go", src, 0644)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
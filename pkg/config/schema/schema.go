@@ -0,0 +1,300 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+)
+
+//go:generate go run gen.go
+
+// jsonSchemaDraft is the version of JSON Schema used by the exported schema
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// BlockResource is the top level block used to define registered resources,
+// e.g. resource "container" "consul" {}
+const BlockResource = "resource"
+
+const (
+	// KindAttribute marks a property that is set with an argument
+	KindAttribute = "attribute"
+	// KindBlock marks a property that is set with a nested block
+	KindBlock = "block"
+)
+
+// Schema is a JSON Schema document describing the body of a block. The
+// x-hcl extensions describe how the properties map to HCL so that editors
+// can complete attributes and blocks.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+
+	// AdditionalProperties is false for blocks or the schema for the values
+	// of a map
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+
+	// Deprecated is set for resource types and attributes that have been
+	// renamed, they continue to work but should not be suggested
+	Deprecated bool `json:"deprecated,omitempty"`
+
+	// Kind is attribute or block for the properties of a block
+	Kind string `json:"x-hcl-kind,omitempty"`
+
+	// Block is the top level block keyword for the definitions in $defs,
+	// resource for registered types or the type for builtin types such as
+	// variable and output
+	Block string `json:"x-hcl-block,omitempty"`
+
+	// Labels are the names of the labels for a block
+	Labels []string `json:"x-hcl-labels,omitempty"`
+}
+
+// New returns a JSON Schema with a definition in $defs for each of the given
+// resource types and the builtin variable, output, local, and module blocks.
+// The schema is generated from the Go structs so it does not drift from the
+// parser, descriptions are taken from the doc comments on the structs.
+func New(registered map[string]types.Resource) *Schema {
+	docs := map[string]string{}
+	for k, v := range fieldDocs {
+		docs[k] = v
+	}
+
+	for k, v := range builtinDocs {
+		docs[k] = v
+	}
+
+	b := newBuilder(docs)
+	b.deprecated = config.DeprecatedResources()
+	b.aliases = config.AttributeAliases
+
+	return b.build(registered)
+}
+
+// builtinDocs documents the hclconfig types, they are not generated as the
+// source is not part of this module
+var builtinDocs = map[string]string{
+	"github.com/jumppad-labs/hclconfig/types.ResourceBase.DependsOn":   "Resources that must be created before this resource",
+	"github.com/jumppad-labs/hclconfig/types.ResourceBase.Disabled":    "Disabled resources are not created, when set on a created resource the resource is destroyed",
+	"github.com/jumppad-labs/hclconfig/resources.Variable":             "Variable defines an input that can be set from the command line, a vars file, or a module",
+	"github.com/jumppad-labs/hclconfig/resources.Variable.Default":     "Default value for the variable",
+	"github.com/jumppad-labs/hclconfig/resources.Variable.Description": "Description of the variable",
+	"github.com/jumppad-labs/hclconfig/resources.Output":               "Output defines a value that is returned by the configuration or module",
+	"github.com/jumppad-labs/hclconfig/resources.Output.CtyValue":      "Value of the output",
+	"github.com/jumppad-labs/hclconfig/resources.Output.Description":   "Description of the output",
+	"github.com/jumppad-labs/hclconfig/resources.Local":                "Local defines a value that can be referenced in the configuration",
+	"github.com/jumppad-labs/hclconfig/resources.Local.CtyValue":       "Value of the local",
+	"github.com/jumppad-labs/hclconfig/resources.Module":               "Module imports configuration from a folder or a remote source such as GitHub",
+	"github.com/jumppad-labs/hclconfig/resources.Module.Source":        "Source of the module, a local folder or a go-getter URL",
+	"github.com/jumppad-labs/hclconfig/resources.Module.Version":       "Version of the module to use",
+	"github.com/jumppad-labs/hclconfig/resources.Module.Variables":     "Variables to set in the module",
+}
+
+type builder struct {
+	docs map[string]string
+
+	// deprecated maps deprecated resource types to their replacement and
+	// aliases returns the deprecated attributes for a resource type
+	deprecated map[string]string
+	aliases    func(resourceType string) map[string]string
+
+	// visiting contains the types being converted, recursive types are
+	// returned as an object without properties
+	visiting map[reflect.Type]bool
+}
+
+func newBuilder(docs map[string]string) *builder {
+	return &builder{
+		docs:       docs,
+		deprecated: map[string]string{},
+		aliases:    func(string) map[string]string { return nil },
+		visiting:   map[reflect.Type]bool{},
+	}
+}
+
+func (b *builder) build(registered map[string]types.Resource) *Schema {
+	s := &Schema{
+		Schema:      jsonSchemaDraft,
+		Title:       "Jumppad configuration",
+		Description: "Resources and builtin blocks that can be used in jumppad configuration",
+		Defs:        map[string]*Schema{},
+	}
+
+	for name, r := range registered {
+		d := b.block(reflect.TypeOf(r))
+		d.Block = BlockResource
+		d.Labels = []string{"type", "name"}
+
+		if replacement, ok := b.deprecated[name]; ok {
+			d.Deprecated = true
+			d.Description = strings.TrimSpace(fmt.Sprintf("Deprecated: use %s. %s", replacement, d.Description))
+		}
+
+		for previous, attribute := range b.aliases(name) {
+			if p, ok := d.Properties[previous]; ok {
+				p.Deprecated = true
+				p.Description = strings.TrimSpace(fmt.Sprintf("Deprecated: use %s. %s", attribute, p.Description))
+			}
+		}
+
+		s.Defs[name] = d
+	}
+
+	for name, r := range resources.DefaultResources() {
+		// root is the implicit module for the configuration
+		if name == "root" {
+			continue
+		}
+
+		d := b.block(reflect.TypeOf(r))
+		d.Block = name
+		d.Labels = []string{"name"}
+
+		s.Defs[name] = d
+	}
+
+	return s
+}
+
+// block converts a struct to the schema for a block body
+func (b *builder) block(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	s := &Schema{
+		Type:                 "object",
+		Description:          b.docs[docKey(t, "")],
+		Properties:           map[string]*Schema{},
+		AdditionalProperties: false,
+	}
+
+	if b.visiting[t] {
+		return s
+	}
+
+	b.visiting[t] = true
+	defer delete(b.visiting, t)
+
+	b.fields(t, s)
+
+	return s
+}
+
+func (b *builder) fields(t reflect.Type, s *Schema) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag, ok := f.Tag.Lookup("hcl")
+		if !ok {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		name := parts[0]
+		kind := ""
+		if len(parts) > 1 {
+			kind = parts[1]
+		}
+
+		switch kind {
+		case "remain":
+			// embedded structs add their fields to the block
+			if f.Anonymous {
+				b.fields(f.Type, s)
+			}
+
+			continue
+		case "label":
+			s.Labels = append(s.Labels, name)
+			continue
+		}
+
+		// meta is set by the parser
+		if name == "" || name == "meta" {
+			continue
+		}
+
+		var p *Schema
+		if kind == "block" {
+			p = b.nestedBlock(f.Type)
+
+			// a block that is not a list or pointer must be set
+			if f.Type.Kind() == reflect.Struct {
+				s.Required = append(s.Required, name)
+			}
+		} else {
+			p = b.attribute(f.Type)
+			p.Kind = KindAttribute
+
+			if kind != "optional" {
+				s.Required = append(s.Required, name)
+			}
+		}
+
+		p.Description = b.docs[docKey(t, f.Name)]
+		s.Properties[name] = p
+	}
+}
+
+func (b *builder) nestedBlock(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Slice {
+		return &Schema{Type: "array", Kind: KindBlock, Items: b.block(t.Elem())}
+	}
+
+	s := b.block(t)
+	s.Kind = KindBlock
+
+	return s
+}
+
+// attribute converts the type of an attribute, types that can hold any
+// value such as cty.Value return an empty schema
+func (b *builder) attribute(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: b.attribute(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: b.attribute(t.Elem())}
+	case reflect.Struct:
+		// structs other than blocks are values such as cty.Value
+		return &Schema{}
+	}
+
+	return &Schema{}
+}
+
+// docKey returns the key for the doc comment of a field, or of the type
+// when field is empty
+func docKey(t reflect.Type, field string) string {
+	k := t.PkgPath() + "." + t.Name()
+	if field != "" {
+		k += "." + field
+	}
+
+	return k
+}
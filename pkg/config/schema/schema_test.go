@@ -0,0 +1,140 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/stretchr/testify/require"
+)
+
+// testResource covers the field types used by the resources
+type testResource struct {
+	types.ResourceBase `hcl:",remain"`
+
+	Image    string            `hcl:"image"`
+	Command  []string          `hcl:"command,optional"`
+	Env      map[string]string `hcl:"environment,optional"`
+	Port     int               `hcl:"port,optional"`
+	Ratio    float64           `hcl:"ratio,optional"`
+	Value    any               `hcl:"value,optional"`
+	Volumes  []testVolume      `hcl:"volume,block"`
+	Health   *testHealth       `hcl:"health_check,block"`
+	Build    testBuild         `hcl:"build,block"`
+	Checksum string            `json:"checksum"`
+}
+
+type testVolume struct {
+	Source string `hcl:"source"`
+}
+
+type testHealth struct {
+	Timeout string `hcl:"timeout,optional"`
+}
+
+type testBuild struct {
+	Name string `hcl:"name,label"`
+	File string `hcl:"file"`
+}
+
+func setupSchema(t *testing.T, docs map[string]string) *Schema {
+	s := newBuilder(docs).build(map[string]types.Resource{"test": &testResource{}})
+
+	// the schema must be encodable
+	_, err := json.Marshal(s)
+	require.NoError(t, err)
+
+	require.Contains(t, s.Defs, "test")
+
+	return s.Defs["test"]
+}
+
+func TestSchemaConvertsAttributes(t *testing.T) {
+	d := setupSchema(t, nil)
+
+	require.Equal(t, BlockResource, d.Block)
+	require.Equal(t, []string{"type", "name"}, d.Labels)
+
+	require.Equal(t, "string", d.Properties["image"].Type)
+	require.Equal(t, KindAttribute, d.Properties["image"].Kind)
+	require.Equal(t, "array", d.Properties["command"].Type)
+	require.Equal(t, "string", d.Properties["command"].Items.Type)
+	require.Equal(t, "object", d.Properties["environment"].Type)
+	require.Equal(t, &Schema{Type: "string"}, d.Properties["environment"].AdditionalProperties)
+	require.Equal(t, "integer", d.Properties["port"].Type)
+	require.Equal(t, "number", d.Properties["ratio"].Type)
+	require.Equal(t, "", d.Properties["value"].Type)
+}
+
+func TestSchemaConvertsBlocks(t *testing.T) {
+	d := setupSchema(t, nil)
+
+	require.Equal(t, KindBlock, d.Properties["volume"].Kind)
+	require.Equal(t, "array", d.Properties["volume"].Type)
+	require.Contains(t, d.Properties["volume"].Items.Properties, "source")
+
+	require.Equal(t, KindBlock, d.Properties["health_check"].Kind)
+	require.Contains(t, d.Properties["health_check"].Properties, "timeout")
+
+	require.Equal(t, []string{"name"}, d.Properties["build"].Labels)
+	require.NotContains(t, d.Properties["build"].Properties, "name")
+}
+
+func TestSchemaAddsResourceBaseFields(t *testing.T) {
+	d := setupSchema(t, nil)
+
+	require.Contains(t, d.Properties, "depends_on")
+	require.Contains(t, d.Properties, "disabled")
+	require.NotContains(t, d.Properties, "meta")
+	require.NotContains(t, d.Properties, "checksum")
+}
+
+func TestSchemaSetsRequiredFields(t *testing.T) {
+	d := setupSchema(t, nil)
+
+	require.Equal(t, []string{"image", "build"}, d.Required)
+}
+
+func TestSchemaSetsDescriptions(t *testing.T) {
+	d := setupSchema(t, map[string]string{
+		"github.com/jumppad-labs/jumppad/pkg/config/schema.testResource":       "test resource",
+		"github.com/jumppad-labs/jumppad/pkg/config/schema.testResource.Image": "image to run",
+		"github.com/jumppad-labs/jumppad/pkg/config/schema.testVolume.Source":  "source of the volume",
+	})
+
+	require.Equal(t, "test resource", d.Description)
+	require.Equal(t, "image to run", d.Properties["image"].Description)
+	require.Equal(t, "source of the volume", d.Properties["volume"].Items.Properties["source"].Description)
+}
+
+func TestSchemaAddsBuiltinBlocks(t *testing.T) {
+	s := New(nil)
+
+	require.Equal(t, "variable", s.Defs["variable"].Block)
+	require.Equal(t, []string{"name"}, s.Defs["variable"].Labels)
+	require.Contains(t, s.Defs, "output")
+	require.Contains(t, s.Defs, "local")
+	require.Contains(t, s.Defs, "module")
+	require.NotContains(t, s.Defs, "root")
+}
+
+func TestGeneratedDocsAreUpToDate(t *testing.T) {
+	docs, err := ParseDocs("../../..", "github.com/jumppad-labs/jumppad", "pkg/config/resources")
+	require.NoError(t, err)
+
+	require.Equal(t, docs, fieldDocs, "the resource docs have changed, run go generate ./pkg/config/schema")
+}
+
+func TestSchemaMarksDeprecatedTypesAndAttributes(t *testing.T) {
+	b := newBuilder(nil)
+	b.deprecated = map[string]string{"old_test": "test"}
+	b.aliases = func(string) map[string]string { return map[string]string{"command": "entrypoint"} }
+
+	s := b.build(map[string]types.Resource{"test": &testResource{}, "old_test": &testResource{}})
+
+	require.False(t, s.Defs["test"].Deprecated)
+	require.True(t, s.Defs["old_test"].Deprecated)
+	require.Equal(t, "Deprecated: use test.", s.Defs["old_test"].Description)
+	require.True(t, s.Defs["test"].Properties["command"].Deprecated)
+	require.False(t, s.Defs["test"].Properties["image"].Deprecated)
+}
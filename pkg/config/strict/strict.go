@@ -0,0 +1,325 @@
+package strict
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hclerrors "github.com/jumppad-labs/hclconfig/errors"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/schema"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// stanzas are the top level blocks that can be used in a blueprint
+var stanzas = []string{"resource", "variable", "output", "local", "module"}
+
+// Check reads the blueprint at path and returns an error for mistakes that
+// the parser ignores or reports without a suggestion:
+//
+//   - unknown blocks and attributes, with the closest valid name
+//   - variables that are not referenced
+//   - variables passed to a local module that the module does not define
+//   - outputs of a local module that are not referenced by the parent
+//
+// The error is a *hclerrors.ConfigError containing a ParserError for each
+// problem. Syntax errors are left for the parser to report. Modules that are
+// not on the local machine are not checked.
+func Check(path string) error {
+	c := &checker{
+		schema:  schema.New(config.RegisteredResources()),
+		errs:    hclerrors.NewConfigError(),
+		visited: map[string]bool{},
+	}
+
+	c.checkModule(path)
+
+	if len(c.errs.Errors) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(c.errs.Errors, func(i, j int) bool {
+		a := c.errs.Errors[i].(*hclerrors.ParserError)
+		b := c.errs.Errors[j].(*hclerrors.ParserError)
+
+		if a.Filename != b.Filename {
+			return a.Filename < b.Filename
+		}
+
+		return a.Line < b.Line
+	})
+
+	return c.errs
+}
+
+type checker struct {
+	schema  *schema.Schema
+	errs    *hclerrors.ConfigError
+	visited map[string]bool
+}
+
+// module contains the blocks and references in the files of a module
+type module struct {
+	variables map[string]*hclsyntax.Block
+	outputs   map[string]*hclsyntax.Block
+	modules   []moduleCall
+
+	// references are the variables and module outputs used by expressions
+	// e.g. variable.version or module.consul.output.address
+	references map[string]bool
+}
+
+// moduleCall is a module block and the folder containing the file
+type moduleCall struct {
+	block *hclsyntax.Block
+	dir   string
+}
+
+func (c *checker) checkModule(path string) *module {
+	m := &module{
+		variables:  map[string]*hclsyntax.Block{},
+		outputs:    map[string]*hclsyntax.Block{},
+		references: map[string]bool{},
+	}
+
+	for _, f := range blueprintFiles(path) {
+		c.checkFile(f, m)
+	}
+
+	for name, b := range m.variables {
+		if !m.references["variable."+name] {
+			c.addError(b.DefRange(), fmt.Sprintf(`variable "%s" is not used`, name))
+		}
+	}
+
+	for _, mc := range m.modules {
+		c.checkModuleCall(mc, m)
+	}
+
+	return m
+}
+
+func (c *checker) checkFile(path string, m *module) {
+	f, diags := hclparse.NewParser().ParseHCLFile(path)
+	if diags.HasErrors() {
+		return
+	}
+
+	body, ok := f.Body.(*hclsyntax.Body)
+	if !ok {
+		return
+	}
+
+	for _, b := range body.Blocks {
+		switch b.Type {
+		case "resource":
+			if len(b.Labels) != 2 {
+				continue
+			}
+
+			d, ok := c.schema.Defs[b.Labels[0]]
+			if !ok || d.Block != schema.BlockResource {
+				c.addError(b.LabelRanges[0], fmt.Sprintf(`unknown resource type "%s"%s`, b.Labels[0], suggest(b.Labels[0], c.resourceTypes())))
+				continue
+			}
+
+			c.checkBody(b.Body, d, fmt.Sprintf(`resource type "%s"`, b.Labels[0]))
+		case "variable", "output", "local", "module":
+			c.checkBody(b.Body, c.schema.Defs[b.Type], b.Type)
+
+			if len(b.Labels) != 1 {
+				continue
+			}
+
+			switch b.Type {
+			case "variable":
+				m.variables[b.Labels[0]] = b
+			case "output":
+				m.outputs[b.Labels[0]] = b
+			case "module":
+				m.modules = append(m.modules, moduleCall{block: b, dir: filepath.Dir(path)})
+			}
+		default:
+			c.addError(b.TypeRange, fmt.Sprintf(`unknown block "%s"%s`, b.Type, suggest(b.Type, stanzas)))
+		}
+	}
+
+	addReferences(body, m.references)
+}
+
+// checkBody reports the attributes and blocks that are not in the schema
+func (c *checker) checkBody(body *hclsyntax.Body, s *schema.Schema, in string) {
+	if s == nil {
+		return
+	}
+
+	attributes := []string{}
+	blocks := []string{}
+	for name, p := range s.Properties {
+		if p.Kind == schema.KindBlock {
+			blocks = append(blocks, name)
+		} else {
+			attributes = append(attributes, name)
+		}
+	}
+
+	for name, a := range body.Attributes {
+		p, ok := s.Properties[name]
+		if !ok || p.Kind == schema.KindBlock {
+			c.addError(a.NameRange, fmt.Sprintf(`unknown attribute "%s" for %s%s`, name, in, suggest(name, attributes)))
+		}
+	}
+
+	for _, b := range body.Blocks {
+		p, ok := s.Properties[b.Type]
+		if !ok || p.Kind != schema.KindBlock {
+			c.addError(b.TypeRange, fmt.Sprintf(`unknown block "%s" for %s%s`, b.Type, in, suggest(b.Type, blocks)))
+			continue
+		}
+
+		if p.Items != nil {
+			p = p.Items
+		}
+
+		c.checkBody(b.Body, p, fmt.Sprintf(`block "%s"`, b.Type))
+	}
+}
+
+// checkModuleCall checks the module when the source is a local folder, the
+// variables set by the module block must be defined by the module and the
+// outputs of the module must be used by the parent
+func (c *checker) checkModuleCall(mc moduleCall, parent *module) {
+	name := mc.block.Labels[0]
+
+	a, ok := mc.block.Body.Attributes["source"]
+	if !ok {
+		return
+	}
+
+	v, diags := a.Expr.Value(nil)
+	if diags.HasErrors() || !v.Type().Equals(cty.String) {
+		return
+	}
+
+	source := v.AsString()
+	if !filepath.IsAbs(source) {
+		source = filepath.Join(mc.dir, source)
+	}
+
+	if !utils.IsLocalFolder(source) {
+		return
+	}
+
+	abs, _ := filepath.Abs(source)
+	if c.visited[abs] {
+		return
+	}
+
+	c.visited[abs] = true
+
+	m := c.checkModule(source)
+
+	if vars, ok := mc.block.Body.Attributes["variables"]; ok {
+		if obj, ok := vars.Expr.(*hclsyntax.ObjectConsExpr); ok {
+			names := []string{}
+			for n := range m.variables {
+				names = append(names, n)
+			}
+
+			for _, item := range obj.Items {
+				key := hcl.ExprAsKeyword(item.KeyExpr)
+				if key == "" {
+					continue
+				}
+
+				if _, ok := m.variables[key]; !ok {
+					c.addError(item.KeyExpr.Range(), fmt.Sprintf(`module "%s" does not have a variable "%s"%s`, name, key, suggest(key, names)))
+				}
+			}
+		}
+	}
+
+	for out, b := range m.outputs {
+		if !parent.references[fmt.Sprintf("module.%s.output.%s", name, out)] {
+			c.addError(b.DefRange(), fmt.Sprintf(`output "%s" of module "%s" is not used`, out, name))
+		}
+	}
+}
+
+func (c *checker) resourceTypes() []string {
+	types := []string{}
+	for name, d := range c.schema.Defs {
+		if d.Block == schema.BlockResource {
+			types = append(types, name)
+		}
+	}
+
+	return types
+}
+
+func (c *checker) addError(r hcl.Range, msg string) {
+	c.errs.AppendError(&hclerrors.ParserError{
+		Filename: r.Filename,
+		Line:     r.Start.Line,
+		Column:   r.Start.Column,
+		Message:  msg,
+		Level:    hclerrors.ParserErrorLevelError,
+	})
+}
+
+// addReferences adds the variables and module outputs referenced by the
+// expressions in the body
+func addReferences(body *hclsyntax.Body, refs map[string]bool) {
+	for _, a := range body.Attributes {
+		for _, t := range a.Expr.Variables() {
+			parts := []string{t.RootName()}
+
+			for _, step := range t[1:] {
+				s, ok := step.(hcl.TraverseAttr)
+				if !ok {
+					break
+				}
+
+				parts = append(parts, s.Name)
+			}
+
+			switch {
+			case parts[0] == "variable" && len(parts) >= 2:
+				refs[strings.Join(parts[:2], ".")] = true
+			case parts[0] == "module" && len(parts) >= 4 && parts[2] == "output":
+				refs[strings.Join(parts[:4], ".")] = true
+			}
+		}
+	}
+
+	for _, b := range body.Blocks {
+		addReferences(b.Body, refs)
+	}
+}
+
+// blueprintFiles returns the files the parser reads for the path
+func blueprintFiles(path string) []string {
+	if utils.IsHCLFile(path) {
+		return []string{path}
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil
+	}
+
+	files := []string{}
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".hcl") {
+			files = append(files, filepath.Join(path, e.Name()))
+		}
+	}
+
+	return files
+}
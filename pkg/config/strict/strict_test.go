@@ -0,0 +1,196 @@
+package strict
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	hclerrors "github.com/jumppad-labs/hclconfig/errors"
+	"github.com/stretchr/testify/require"
+
+	// register the jumppad resources
+	_ "github.com/jumppad-labs/jumppad/pkg/jumppad"
+)
+
+var validConfig = `
+variable "image" {
+  default = "consul:1.16.1"
+}
+
+resource "container" "consul" {
+  image {
+    name = variable.image
+  }
+
+  port {
+    local = 8500
+  }
+}
+
+module "vault" {
+  source = "./vault"
+
+  variables = {
+    version = "1.15.0"
+  }
+}
+
+output "vault_addr" {
+  value = module.vault.output.addr
+}
+`
+
+var validModule = `
+variable "version" {
+  default = "1.14.0"
+}
+
+resource "container" "vault" {
+  image {
+    name = "hashicorp/vault:${variable.version}"
+  }
+}
+
+output "addr" {
+  value = "http://localhost:8200"
+}
+`
+
+var invalidConfig = `
+variable "image" {
+  default = "consul:1.16.1"
+}
+
+variable "unused" {
+  default = "nothing"
+}
+
+resource "container" "consul" {
+  imag {
+    name = variable.image
+  }
+
+  enviroment = {
+    CONSUL_HTTP_ADDR = "http://localhost:8500"
+  }
+}
+
+resource "contaner" "vault" {
+}
+
+modul "vault" {
+}
+
+module "vault" {
+  source = "./vault"
+
+  variables = {
+    verison = "1.15.0"
+  }
+}
+`
+
+func setupBlueprint(t *testing.T, main, module string) string {
+	dir := t.TempDir()
+
+	os.WriteFile(filepath.Join(dir, "main.hcl"), []byte(main), 0644)
+	os.MkdirAll(filepath.Join(dir, "vault"), os.ModePerm)
+	os.WriteFile(filepath.Join(dir, "vault", "main.hcl"), []byte(module), 0644)
+
+	return dir
+}
+
+func messages(t *testing.T, err error) []string {
+	ce, ok := err.(*hclerrors.ConfigError)
+	require.True(t, ok)
+
+	msgs := []string{}
+	for _, e := range ce.Errors {
+		msgs = append(msgs, e.(*hclerrors.ParserError).Message)
+	}
+
+	return msgs
+}
+
+func TestCheckValidBlueprintReturnsNoError(t *testing.T) {
+	dir := setupBlueprint(t, validConfig, validModule)
+
+	err := Check(dir)
+	require.NoError(t, err)
+}
+
+func TestCheckSingleFileReturnsNoError(t *testing.T) {
+	dir := setupBlueprint(t, validConfig, validModule)
+
+	err := Check(filepath.Join(dir, "main.hcl"))
+	require.NoError(t, err)
+}
+
+func TestCheckInvalidBlueprintReturnsErrors(t *testing.T) {
+	dir := setupBlueprint(t, invalidConfig, validModule)
+
+	err := Check(dir)
+	require.Error(t, err)
+
+	require.Equal(t, []string{
+		`variable "unused" is not used`,
+		`unknown block "imag" for resource type "container", did you mean "image"?`,
+		`unknown attribute "enviroment" for resource type "container", did you mean "environment"?`,
+		`unknown resource type "contaner", did you mean "container"?`,
+		`unknown block "modul", did you mean "module"?`,
+		`module "vault" does not have a variable "verison", did you mean "version"?`,
+		`output "addr" of module "vault" is not used`,
+	}, messages(t, err))
+}
+
+func TestCheckInvalidBlueprintReturnsErrorLocation(t *testing.T) {
+	dir := setupBlueprint(t, invalidConfig, validModule)
+
+	err := Check(dir)
+	require.Error(t, err)
+
+	pe := err.(*hclerrors.ConfigError).Errors[0].(*hclerrors.ParserError)
+	require.Equal(t, filepath.Join(dir, "main.hcl"), pe.Filename)
+	require.Equal(t, 6, pe.Line)
+	require.Equal(t, hclerrors.ParserErrorLevelError, pe.Level)
+}
+
+func TestCheckUnknownNestedAttributeReturnsError(t *testing.T) {
+	dir := setupBlueprint(t, `
+resource "container" "consul" {
+  image {
+    nme = "consul:1.16.1"
+  }
+}
+`, "")
+
+	err := Check(dir)
+	require.Error(t, err)
+
+	require.Equal(t, []string{`unknown attribute "nme" for block "image", did you mean "name"?`}, messages(t, err))
+}
+
+func TestCheckUnknownAttributeWithoutSuggestionReturnsError(t *testing.T) {
+	dir := setupBlueprint(t, `
+resource "container" "consul" {
+  image {
+    name = "consul:1.16.1"
+  }
+
+  something_else = true
+}
+`, "")
+
+	err := Check(dir)
+	require.Error(t, err)
+
+	require.Equal(t, []string{`unknown attribute "something_else" for resource type "container"`}, messages(t, err))
+}
+
+func TestSuggestReturnsClosestCandidate(t *testing.T) {
+	require.Equal(t, `, did you mean "environment"?`, suggest("enviroment", []string{"entrypoint", "environment", "image"}))
+}
+
+func TestSuggestWithNoCloseCandidateReturnsEmpty(t *testing.T) {
+	require.Equal(t, "", suggest("network", []string{"image", "command"}))
+}
@@ -0,0 +1,56 @@
+package strict
+
+import (
+	"fmt"
+	"sort"
+)
+
+// suggest returns a did you mean suggestion for the candidate closest to
+// name, an empty string is returned when no candidate is close enough
+func suggest(name string, candidates []string) string {
+	sorted := append([]string{}, candidates...)
+	sort.Strings(sorted)
+
+	best := ""
+	bestDistance := len(name)/3 + 1
+
+	for _, c := range sorted {
+		d := distance(name, c)
+		if d <= bestDistance && (best == "" || d < distance(name, best)) {
+			best = c
+			bestDistance = d
+		}
+	}
+
+	if best == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`, did you mean "%s"?`, best)
+}
+
+// distance returns the Levenshtein distance between a and b
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+
+		prev = cur
+	}
+
+	return prev[len(b)]
+}
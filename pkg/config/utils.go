@@ -1,10 +1,13 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
 	"testing"
 
 	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
 )
 
 // ParseVars converts a map[string]cty.Value into map[string]interface
@@ -47,6 +50,45 @@ func castVar(v cty.Value) interface{} {
 	return nil
 }
 
+// ValuesFromCty converts an object or map into map[string]interface where
+// the values are the types produced by encoding/json, a null value returns
+// an empty map
+func ValuesFromCty(v cty.Value) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	if v.IsNull() {
+		return values, nil
+	}
+
+	if !v.Type().IsObjectType() && !v.Type().IsMapType() {
+		return nil, fmt.Errorf("value must be a map or object")
+	}
+
+	d, err := ctyjson.SimpleJSONValue{Value: v}.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(d, &values)
+	return values, err
+}
+
+// CtyFromValues converts a map of values produced by encoding/json into an
+// object, an empty map returns an empty object
+func CtyFromValues(values map[string]interface{}) (cty.Value, error) {
+	if len(values) == 0 {
+		return cty.EmptyObjectVal, nil
+	}
+
+	d, err := json.Marshal(values)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	v := ctyjson.SimpleJSONValue{}
+	err = v.UnmarshalJSON(d)
+	return v.Value, err
+}
+
 // createsTestFiles creates a temporary directory and
 // stores temp files into it
 // returns directory containing files
@@ -37,3 +37,32 @@ func TestProcessesTypes(t *testing.T) {
 
 	require.Equal(t, "abc", output["map"].(map[string]interface{})["foo"])
 }
+
+func TestValuesFromCtyConvertsObject(t *testing.T) {
+	v, err := ValuesFromCty(cty.ObjectVal(map[string]cty.Value{
+		"brokers": cty.NumberIntVal(3),
+		"tags":    cty.ListVal([]cty.Value{cty.StringVal("a")}),
+	}))
+	require.NoError(t, err)
+
+	require.Equal(t, float64(3), v["brokers"])
+	require.Equal(t, []interface{}{"a"}, v["tags"])
+}
+
+func TestValuesFromCtyWithNullReturnsEmptyMap(t *testing.T) {
+	v, err := ValuesFromCty(cty.NilVal)
+	require.NoError(t, err)
+	require.Empty(t, v)
+}
+
+func TestValuesFromCtyWithStringReturnsError(t *testing.T) {
+	_, err := ValuesFromCty(cty.StringVal("abc"))
+	require.Error(t, err)
+}
+
+func TestCtyFromValuesConvertsMap(t *testing.T) {
+	v, err := CtyFromValues(map[string]interface{}{"bootstrap": "localhost:9092"})
+	require.NoError(t, err)
+
+	require.Equal(t, cty.StringVal("localhost:9092"), v.GetAttr("bootstrap"))
+}
@@ -1,7 +1,7 @@
 package config
 
 import (
-	"path"
+	"sync"
 
 	"github.com/jumppad-labs/hclconfig"
 	"github.com/jumppad-labs/hclconfig/types"
@@ -17,11 +17,32 @@ var registeredTypes map[string]types.Resource
 // it is the responsibility of the type to register itself with the parser
 var registeredProviders map[string]Provider
 
+// typeLoaders register resource types that are expensive to discover, such
+// as the types provided by plugins, they are run once when the registered
+// types are first used
+var typeLoaders []func()
+var loadTypesOnce sync.Once
+
 func init() {
 	registeredTypes = map[string]types.Resource{}
 	registeredProviders = map[string]Provider{}
 }
 
+// RegisterTypeLoader adds a function that registers resource types, the
+// function is not called until a blueprint or the state is parsed
+func RegisterTypeLoader(fn func()) {
+	typeLoaders = append(typeLoaders, fn)
+}
+
+// loadTypes runs the type loaders the first time it is called
+func loadTypes() {
+	loadTypesOnce.Do(func() {
+		for _, fn := range typeLoaders {
+			fn()
+		}
+	})
+}
+
 // RegisterResource allows a resource to register itself with the parser
 func RegisterResource(name string, r types.Resource, p sdk.Provider) {
 	if r != nil {
@@ -33,19 +54,57 @@ func RegisterResource(name string, r types.Resource, p sdk.Provider) {
 	}
 }
 
+// ResourceRegistered returns true when a resource type with the given name
+// has been registered
+func ResourceRegistered(name string) bool {
+	_, ok := registeredTypes[name]
+	return ok
+}
+
+// RegisteredResources returns the resource types that have been registered
+// with the parser
+func RegisteredResources() map[string]types.Resource {
+	loadTypes()
+
+	r := map[string]types.Resource{}
+	for k, v := range registeredTypes {
+		r[k] = v
+	}
+
+	return r
+}
+
 // setupHCLConfig configures the HCLConfig package and registers the custom types
 func NewParser(callback hclconfig.WalkCallback, variables map[string]string, variablesFiles []string) *hclconfig.Parser {
 	cfg := hclconfig.DefaultOptions()
 
-	cfg.Callback = callback
+	// deprecated attributes are mapped before the callback so that
+	// providers only need to handle the current attributes, resources that
+	// are not enabled are handled in the same way as disabled resources,
+	// they are not created and are destroyed if they exist in the state
+	cfg.Callback = func(r types.Resource) error {
+		resolveAttributeAliases(r)
+
+		if !ResourceEnabled(r) {
+			r.SetDisabled(true)
+			return nil
+		}
+
+		if callback == nil {
+			return nil
+		}
+
+		return callback(r)
+	}
 	cfg.VariableEnvPrefix = "JUMPPAD_VAR_"
 	cfg.Variables = variables
 	cfg.VariablesFiles = variablesFiles
-	cfg.ModuleCache = path.Join(utils.JumppadHome(), "modules")
+	cfg.ModuleCache = utils.ModulesDir()
 
 	p := hclconfig.NewParser(cfg)
 
 	// Register the types
+	loadTypes()
 	for k, v := range registeredTypes {
 		p.RegisterType(k, v)
 	}
@@ -58,6 +117,7 @@ func NewParser(callback hclconfig.WalkCallback, variables map[string]string, var
 	p.RegisterFunction("data_with_permissions", customHCLFuncDataFolderWithPermissions)
 	p.RegisterFunction("system", customHCLFuncSystem)
 	p.RegisterFunction("exists", customHCLFuncExists)
+	p.RegisterFunction("sensitive", customHCLFuncSensitive)
 
 	return p
 }
@@ -0,0 +1,39 @@
+package config
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupTypeLoader(t *testing.T) *int {
+	loaders := typeLoaders
+	t.Cleanup(func() {
+		typeLoaders = loaders
+		loadTypesOnce = sync.Once{}
+	})
+
+	typeLoaders = nil
+	loadTypesOnce = sync.Once{}
+
+	calls := 0
+	RegisterTypeLoader(func() { calls++ })
+
+	return &calls
+}
+
+func TestRegisterTypeLoaderDoesNotLoadTypes(t *testing.T) {
+	calls := setupTypeLoader(t)
+
+	require.Equal(t, 0, *calls)
+}
+
+func TestNewParserRunsTypeLoadersOnce(t *testing.T) {
+	calls := setupTypeLoader(t)
+
+	NewParser(nil, nil, nil)
+	NewParser(nil, nil, nil)
+
+	require.Equal(t, 1, *calls)
+}
@@ -1,6 +1,8 @@
 package config
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"os"
 
@@ -14,6 +16,11 @@ func LoadState() (*hclconfig.Config, error) {
 		return hclconfig.NewConfig(), fmt.Errorf("unable to read state file: %s", err)
 	}
 
+	d, err = decryptState(d)
+	if err != nil {
+		return hclconfig.NewConfig(), fmt.Errorf("unable to unmarshal state file: %s", err)
+	}
+
 	p := NewParser(nil, nil, nil)
 	c, err := p.UnmarshalJSON(d)
 	if err != nil {
@@ -24,12 +31,16 @@ func LoadState() (*hclconfig.Config, error) {
 }
 
 func SaveState(c *hclconfig.Config) error {
-	// save the state regardless of error
 	d, err := c.ToJSON()
 	if err != nil {
 		return fmt.Errorf("unable to serialize config to JSON: %s", err)
 	}
 
+	d, err = encryptState(d)
+	if err != nil {
+		return err
+	}
+
 	err = os.MkdirAll(utils.StateDir(), os.ModePerm)
 	if err != nil {
 		return fmt.Errorf("unable to create directory for state file '%s', error: %s", utils.StateDir(), err)
@@ -42,3 +53,128 @@ func SaveState(c *hclconfig.Config) error {
 
 	return nil
 }
+
+// stateJSON is the serialized state, resources are decoded as generic values
+// so that the strings in every resource type can be encrypted
+type stateJSON struct {
+	Resources []map[string]any `json:"resources"`
+}
+
+// encryptState encrypts every string in the serialized state that contains a
+// sensitive value, so that secrets interpolated into any attribute, such as
+// container environment variables or exec scripts, are not written to the
+// state in plain text. The meta data is not encrypted as it is used to find
+// the resources.
+func encryptState(d []byte) ([]byte, error) {
+	if !utils.HasSensitive() {
+		return d, nil
+	}
+
+	return mapState(d, func(id, s string) (string, error) {
+		if utils.IsEncryptedSecret(s) || !utils.ContainsSensitive(s) {
+			return s, nil
+		}
+
+		enc, err := utils.EncryptSecret(s)
+		if err != nil {
+			return "", fmt.Errorf("unable to encrypt sensitive values for %s: %s", id, err)
+		}
+
+		return enc, nil
+	})
+}
+
+// decryptState decrypts the strings encrypted by encryptState, the decrypted
+// values are registered as sensitive so that they are redacted from output
+// and encrypted again when the state is saved
+func decryptState(d []byte) ([]byte, error) {
+	return mapState(d, func(id, s string) (string, error) {
+		if !utils.IsEncryptedSecret(s) {
+			return s, nil
+		}
+
+		dec := utils.DecryptSecretOrEmpty(id, s)
+		utils.RegisterSensitive(dec)
+
+		return dec, nil
+	})
+}
+
+// mapState applies fn to every string in the resources of the serialized
+// state except the meta data, the id of the resource is passed to fn
+func mapState(d []byte, fn func(id, s string) (string, error)) ([]byte, error) {
+	st := stateJSON{}
+
+	dec := json.NewDecoder(bytes.NewReader(d))
+	dec.UseNumber()
+
+	err := dec.Decode(&st)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, r := range st.Resources {
+		id := ""
+		if m, ok := r["meta"].(map[string]any); ok {
+			id, _ = m["id"].(string)
+		}
+
+		for k, v := range r {
+			if k == "meta" {
+				continue
+			}
+
+			m, err := mapStrings(v, func(s string) (string, error) { return fn(id, s) })
+			if err != nil {
+				return nil, err
+			}
+
+			st.Resources[i][k] = m
+		}
+	}
+
+	buf := bytes.NewBuffer(nil)
+	enc := json.NewEncoder(buf)
+	enc.SetIndent("", " ")
+
+	err = enc.Encode(st)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// mapStrings returns a copy of v with fn applied to every string it contains
+func mapStrings(v any, fn func(string) (string, error)) (any, error) {
+	switch val := v.(type) {
+	case string:
+		return fn(val)
+	case []any:
+		out := make([]any, len(val))
+		for i, e := range val {
+			m, err := mapStrings(e, fn)
+			if err != nil {
+				return nil, err
+			}
+
+			out[i] = m
+		}
+
+		return out, nil
+	case map[string]any:
+		out := map[string]any{}
+		for k, e := range val {
+			m, err := mapStrings(e, fn)
+			if err != nil {
+				return nil, err
+			}
+
+			out[k] = m
+		}
+
+		return out, nil
+	}
+
+	return v, nil
+}
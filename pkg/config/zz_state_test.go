@@ -0,0 +1,98 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func setupStateWithOutput(t *testing.T, value any) (*hclconfig.Config, *resources.Output) {
+	testutils.SetupState(t, "")
+	t.Setenv(utils.SecretsKeyEnvVar, "")
+
+	o := &resources.Output{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "output.db", Name: "db", Type: resources.TypeOutput}},
+		Value:        value,
+	}
+
+	c := hclconfig.NewConfig()
+	err := c.AppendResource(o)
+	require.NoError(t, err)
+
+	return c, o
+}
+
+func TestSaveStateEncryptsOutputsContainingSensitiveValues(t *testing.T) {
+	utils.RegisterSensitive("st4te-s3cret")
+	c, o := setupStateWithOutput(t, map[string]any{"addr": "postgres://admin:st4te-s3cret@db"})
+
+	err := SaveState(c)
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(utils.StatePath())
+	require.NoError(t, err)
+	require.NotContains(t, string(d), "st4te-s3cret")
+
+	// the value in memory is not changed
+	require.Equal(t, "postgres://admin:st4te-s3cret@db", o.Value.(map[string]any)["addr"])
+}
+
+func TestSaveStateDoesNotEncryptOtherOutputs(t *testing.T) {
+	c, _ := setupStateWithOutput(t, "http://localhost:8080")
+
+	err := SaveState(c)
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(utils.StatePath())
+	require.NoError(t, err)
+	require.Contains(t, string(d), "http://localhost:8080")
+}
+
+func TestLoadStateDecryptsOutputs(t *testing.T) {
+	utils.RegisterSensitive("st4te-s3cret")
+	c, _ := setupStateWithOutput(t, []any{"postgres://admin:st4te-s3cret@db"})
+
+	err := SaveState(c)
+	require.NoError(t, err)
+
+	c, err = LoadState()
+	require.NoError(t, err)
+
+	r, err := c.FindResource("output.db")
+	require.NoError(t, err)
+	require.Equal(t, []any{"postgres://admin:st4te-s3cret@db"}, r.(*resources.Output).Value)
+}
+
+func TestSaveStateEncryptsSensitiveValuesInAnyResource(t *testing.T) {
+	utils.RegisterSensitive("l0cal-s3cret")
+	c, _ := setupStateWithOutput(t, "http://localhost:8080")
+
+	l := &resources.Local{
+		ResourceBase: types.ResourceBase{Meta: types.Meta{ID: "local.env", Name: "env", Type: resources.TypeLocal}},
+		Value:        map[string]any{"TOKEN": "l0cal-s3cret"},
+	}
+
+	err := c.AppendResource(l)
+	require.NoError(t, err)
+
+	err = SaveState(c)
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(utils.StatePath())
+	require.NoError(t, err)
+	require.NotContains(t, string(d), "l0cal-s3cret")
+	require.Contains(t, string(d), "local.env")
+
+	c, err = LoadState()
+	require.NoError(t, err)
+
+	r, err := c.FindResource("local.env")
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"TOKEN": "l0cal-s3cret"}, r.(*resources.Local).Value)
+}
@@ -17,4 +17,8 @@ const (
 	// StatusDisabled indicates that the resources has been disabled and no
 	// resources have been created
 	StatusDisabled = "disabled"
+
+	// StatusDestroyed is reported when a resource has been destroyed, destroyed
+	// resources are removed from the state so this status is never persisted
+	StatusDestroyed = "destroyed"
 )
@@ -0,0 +1,23 @@
+// Package jumppad contains the engine that creates and destroys the
+// resources in a blueprint. The engine can be embedded in other Go programs
+// to create environments from tools and tests.
+//
+//	e, err := jumppad.New(
+//		jumppad.WithVariables(map[string]string{"version": "1.16.2"}),
+//		jumppad.WithEventHandler(func(ev events.Event) {
+//			fmt.Println(ev.Text)
+//		}),
+//	)
+//	if err != nil {
+//		return err
+//	}
+//
+//	_, err = e.Up(ctx, "./blueprint")
+//	defer e.Down(ctx)
+//
+// Up returns a *ParseError when the blueprint is invalid and a
+// *ResourceError when resources could not be created, the outcome of each
+// resource is returned by Engine.Results. When no providers are set with
+// WithProviders the engine uses the local container runtime configured by
+// DOCKER_HOST or the jumppad settings.
+package jumppad
@@ -5,22 +5,29 @@ import (
 	// "fmt"
 
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/jumppad-labs/hclconfig"
 	hclerrors "github.com/jumppad-labs/hclconfig/errors"
 	"github.com/jumppad-labs/hclconfig/resources"
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients"
+	"github.com/jumppad-labs/jumppad/pkg/clients/events"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/tracing"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
 	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Clients contains clients which are responsible for creating and destroying resources
@@ -29,17 +36,71 @@ import (
 //
 //go:generate mockery --name Engine --filename engine.go
 type Engine interface {
+	// Up creates the resources in the blueprint at path using the variables
+	// and options the engine was created with. A *ParseError is returned when
+	// the blueprint is invalid and a *ResourceError when resources fail.
+	Up(ctx context.Context, path string) (*hclconfig.Config, error)
+
+	// Down destroys the resources in the current environment, it does nothing
+	// when there is no environment. A *ResourceError is returned when
+	// resources fail to be destroyed.
+	Down(ctx context.Context) error
+
 	Apply(context.Context, string) (*hclconfig.Config, error)
 
 	// ApplyWithVariables applies a configuration file or directory containing
 	// configuration. Optionally the user can provide a map of variables which the configuration
 	// uses and / or a file containing variables.
 	ApplyWithVariables(ctx context.Context, path string, variables map[string]string, variablesFile string) (*hclconfig.Config, error)
+
+	// ApplyTargets applies the configuration in the same way as
+	// ApplyWithVariables but only refreshes the created resources whose ids
+	// are in targets, other created resources are left unchanged. New and
+	// failed resources are always created and removed resources destroyed.
+	ApplyTargets(ctx context.Context, path string, variables map[string]string, variablesFile string, targets []string) (*hclconfig.Config, error)
 	ParseConfig(string) (*hclconfig.Config, error)
 	ParseConfigWithVariables(string, map[string]string, string) (*hclconfig.Config, error)
 	Destroy(ctx context.Context, force bool) error
+
+	// Rollback destroys the resources in the state that are not in previous,
+	// previous is the state before Apply was called
+	Rollback(ctx context.Context, previous *hclconfig.Config) error
 	Config() *hclconfig.Config
 	Diff(path string, variables map[string]string, variablesFile string) (new []types.Resource, changed []types.Resource, removed []types.Resource, cfg *hclconfig.Config, err error)
+
+	// Results returns the outcome of each resource processed by the last call
+	// to Apply or Destroy
+	Results() []ResourceResult
+
+	// SetProgressHandler sets a function that is called when the engine starts
+	// and finishes processing each resource, setting nil removes the handler
+	SetProgressHandler(h ProgressHandler)
+
+	// SetEventBus sets the bus that resource and engine lifecycle events are
+	// published to, setting nil disables events
+	SetEventBus(b *events.Bus)
+}
+
+const (
+	// PhaseCreating is reported when a resource starts being created
+	PhaseCreating = "creating"
+	// PhaseRefreshing is reported when a resource that exists starts being refreshed
+	PhaseRefreshing = "refreshing"
+	// PhaseDestroying is reported when a resource starts being destroyed
+	PhaseDestroying = "destroying"
+)
+
+// ProgressHandler is called with the current phase when the engine starts
+// processing a resource and with the result when it finishes
+type ProgressHandler func(r ResourceResult)
+
+// ResourceResult is the outcome of creating or destroying a single resource
+type ResourceResult struct {
+	ID       string
+	Type     string
+	Status   string
+	Duration time.Duration
+	Error    error
 }
 
 // EngineImpl is responsible for creating and destroying resources
@@ -50,17 +111,71 @@ type EngineImpl struct {
 	ctx        context.Context
 	force      bool
 	cacheMutex sync.Mutex
+
+	// destroying contains the clusters removed by Destroy, resources created
+	// inside these clusters are removed with the cluster
+	destroying map[string]bool
+	// withCluster contains the resources waiting to be removed from the state
+	// when their cluster has been destroyed
+	withCluster      map[string][]types.Resource
+	withClusterMutex sync.Mutex
+
+	results      []ResourceResult
+	resultsMutex sync.Mutex
+	progress     ProgressHandler
+	eventBus     *events.Bus
+
+	// deprecations contains the deprecation warnings that have been logged,
+	// the config is parsed more than once so each warning is logged once
+	deprecations      map[string]bool
+	deprecationsMutex sync.Mutex
+
+	// targets contains the ids of the resources refreshed by ApplyTargets,
+	// all resources are refreshed when nil
+	targets map[string]bool
+
+	// variables and force are set by options and used by Up and Down
+	variables     map[string]string
+	variablesFile string
+	forceDestroy  bool
 }
 
-// New creates a new Jumppad engine
-func New(p config.Providers, l logger.Logger) (Engine, error) {
+// New creates a new Jumppad engine, when no providers are set the engine
+// uses the clients for the local container runtime
+func New(opts ...Option) (Engine, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if o.log == nil {
+		o.log = logger.NewLogger(io.Discard, logger.LogLevelInfo)
+	}
+
+	if o.providers == nil {
+		c, err := clients.GenerateClients(o.log)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create clients: %w", err)
+		}
+
+		o.providers = config.NewProviders(c)
+	}
+
 	e := &EngineImpl{}
-	e.log = l
-	e.providers = p
+	e.log = o.log
+	e.providers = o.providers
+	e.progress = o.progress
+	e.variables = o.variables
+	e.variablesFile = o.variablesFile
+	e.forceDestroy = o.force
 	e.cacheMutex = sync.Mutex{}
 
+	if len(o.sinks) > 0 {
+		e.eventBus = events.NewBus(o.log, o.sinks, o.eventTypes)
+	}
+
 	// Set the standard writer to our logger as the DAG uses the standard library log.
-	log.SetOutput(l.StandardWriter())
+	log.SetOutput(o.log.StandardWriter())
 
 	return e, nil
 }
@@ -90,6 +205,12 @@ func (e *EngineImpl) ParseConfigWithVariables(path string, vars map[string]strin
 
 	e.log.Debug("Parsing configuration", "path", path)
 
+	// parsing the config also builds and walks the dependency graph
+	_, span := tracing.Start(e.traceContext(), "jumppad.parse", tracing.AttributePath.String(path))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	if variablesFile != "" {
 		variablesFile, err = filepath.Abs(variablesFile)
 		if err != nil {
@@ -203,6 +324,41 @@ func (e *EngineImpl) Diff(path string, variables map[string]string, variablesFil
 	return new, changed, removed, res, nil
 }
 
+// Up creates the resources in the blueprint at path, resources that are no
+// longer in the blueprint are destroyed
+func (e *EngineImpl) Up(ctx context.Context, path string) (*hclconfig.Config, error) {
+	c, err := e.ApplyWithVariables(ctx, path, e.variables, e.variablesFile)
+	if err == nil {
+		return c, nil
+	}
+
+	if failed := e.failedResults(); len(failed) > 0 {
+		return c, &ResourceError{Failed: failed, Err: err}
+	}
+
+	var ce *hclerrors.ConfigError
+	if errors.As(err, &ce) {
+		return c, &ParseError{Path: path, Err: err}
+	}
+
+	return c, &ResourceError{Err: err}
+}
+
+// Down destroys the resources in the current environment
+func (e *EngineImpl) Down(ctx context.Context) error {
+	if _, err := os.Stat(utils.StatePath()); os.IsNotExist(err) {
+		e.log.Debug("No environment to destroy")
+		return nil
+	}
+
+	err := e.Destroy(ctx, e.forceDestroy)
+	if err != nil {
+		return &ResourceError{Failed: e.failedResults(), Err: err}
+	}
+
+	return nil
+}
+
 // Apply the configuration and create or destroy the resources
 func (e *EngineImpl) Apply(ctx context.Context, path string) (*hclconfig.Config, error) {
 	return e.ApplyWithVariables(ctx, path, nil, "")
@@ -210,17 +366,65 @@ func (e *EngineImpl) Apply(ctx context.Context, path string) (*hclconfig.Config,
 
 // ApplyWithVariables applies the current config creating the resources
 func (e *EngineImpl) ApplyWithVariables(ctx context.Context, path string, vars map[string]string, variablesFile string) (*hclconfig.Config, error) {
-	e.ctx = ctx
-
 	// abs paths
-	var err error
-	path, err = filepath.Abs(path)
+	path, err := filepath.Abs(path)
 	if err != nil {
 		return nil, err
 	}
 
+	ctx, span := tracing.Start(ctx, "jumppad.apply", tracing.AttributePath.String(path))
+	e.publish(events.Event{
+		Type: events.TypeEngineUpStart,
+		Text: fmt.Sprintf("Creating resources from %s", path),
+		Path: path,
+	})
+
+	start := time.Now()
+	c, err := e.applyWithVariables(ctx, path, vars, variablesFile)
+	tracing.End(span, err)
+
+	if err != nil {
+		e.publish(events.Event{
+			Type:     events.TypeEngineUpFail,
+			Text:     fmt.Sprintf("Failed to create resources from %s: %s", path, err),
+			Path:     path,
+			Duration: time.Since(start).Seconds(),
+			Error:    err.Error(),
+		})
+	} else {
+		e.publish(events.Event{
+			Type:     events.TypeEngineUpComplete,
+			Text:     fmt.Sprintf("Created resources from %s in %s", path, time.Since(start).Round(time.Second)),
+			Path:     path,
+			Duration: time.Since(start).Seconds(),
+		})
+	}
+
+	return c, err
+}
+
+// ApplyTargets applies the current config only refreshing the created
+// resources in targets
+func (e *EngineImpl) ApplyTargets(ctx context.Context, path string, vars map[string]string, variablesFile string, targets []string) (*hclconfig.Config, error) {
+	e.targets = map[string]bool{}
+	for _, t := range targets {
+		e.targets[t] = true
+	}
+
+	defer func() {
+		e.targets = nil
+	}()
+
+	return e.ApplyWithVariables(ctx, path, vars, variablesFile)
+}
+
+func (e *EngineImpl) applyWithVariables(ctx context.Context, path string, vars map[string]string, variablesFile string) (*hclconfig.Config, error) {
+	e.ctx = ctx
+
 	e.log.Info("Creating resources from configuration", "path", path)
+	e.resetResults()
 
+	var err error
 	if variablesFile != "" {
 		variablesFile, err = filepath.Abs(variablesFile)
 		if err != nil {
@@ -266,7 +470,12 @@ func (e *EngineImpl) ApplyWithVariables(ctx context.Context, path string, vars m
 		}
 
 		// create the cache
-		err := p.Create(ctx)
+		e.startPhase(ca, PhaseCreating)
+		spanCtx, span := startResourceSpan(ctx, "resource.create", ca)
+		start := time.Now()
+		err := p.Create(spanCtx)
+		e.addResult(ca, constants.StatusCreated, start, err)
+		tracing.End(span, err)
 		if err != nil {
 			ca.Meta.Properties[constants.PropertyStatus] = constants.StatusFailed
 		} else {
@@ -300,7 +509,12 @@ func (e *EngineImpl) ApplyWithVariables(ctx context.Context, path string, vars m
 		}
 
 		// call destroy
-		err := p.Destroy(e.ctx, e.force)
+		e.startPhase(r, PhaseDestroying)
+		spanCtx, span := startResourceSpan(e.ctx, "resource.destroy", r)
+		start := time.Now()
+		err := p.Destroy(spanCtx, e.force)
+		e.addResult(r, constants.StatusDestroyed, start, err)
+		tracing.End(span, err)
 		if err != nil {
 			processErr = fmt.Errorf("unable to destroy resource Name: %s, Type: %s", r.Metadata().Name, r.Metadata().Type)
 			continue
@@ -319,10 +533,40 @@ func (e *EngineImpl) ApplyWithVariables(ctx context.Context, path string, vars m
 }
 
 // Destroy the resources defined by the state
-func (e *EngineImpl) Destroy(ctx context.Context, force bool) error {
+func (e *EngineImpl) Destroy(ctx context.Context, force bool) (err error) {
 	e.log.Info("Destroying resources", "force", force)
+
+	ctx, span := tracing.Start(ctx, "jumppad.destroy")
+	e.publish(events.Event{
+		Type: events.TypeEngineDownStart,
+		Text: "Destroying resources",
+	})
+
+	start := time.Now()
+	defer func() {
+		tracing.End(span, err)
+
+		if err != nil {
+			e.publish(events.Event{
+				Type:     events.TypeEngineDownFail,
+				Text:     fmt.Sprintf("Failed to destroy resources: %s", err),
+				Duration: time.Since(start).Seconds(),
+				Error:    err.Error(),
+			})
+
+			return
+		}
+
+		e.publish(events.Event{
+			Type:     events.TypeEngineDownComplete,
+			Text:     fmt.Sprintf("Destroyed resources in %s", time.Since(start).Round(time.Second)),
+			Duration: time.Since(start).Seconds(),
+		})
+	}()
+
 	e.force = force
 	e.ctx = ctx
+	e.resetResults()
 
 	// load the state
 	c, err := config.LoadState()
@@ -332,14 +576,19 @@ func (e *EngineImpl) Destroy(ctx context.Context, force bool) error {
 
 	e.config = c
 
-	// run through the graph and call the destroy callback
+	e.destroying = destroyedClusters(c)
+	e.withCluster = map[string][]types.Resource{}
+	defer func() { e.destroying, e.withCluster = nil, nil }()
+
+	// run through the graph and call the destroy callback, the graph is
+	// walked in reverse dependency order and independent resources are
+	// destroyed concurrently
 	// disabled resources are not included in this callback
 	// image cache which is manually added by Apply process
 	// should have the correct dependency graph to be
 	// destroyed last
 	err = e.config.Walk(e.destroyCallback, true)
 	if err != nil {
-
 		// return the process error
 		return fmt.Errorf("error trying to call Destroy on provider: %s", err)
 	}
@@ -348,6 +597,61 @@ func (e *EngineImpl) Destroy(ctx context.Context, force bool) error {
 	return os.Remove(utils.StatePath())
 }
 
+// Rollback destroys the resources created by a failed Apply, resources that
+// existed in the previous state are left in place even when they were changed
+func (e *EngineImpl) Rollback(ctx context.Context, previous *hclconfig.Config) error {
+	e.log.Info("Rolling back resources created by apply")
+
+	e.ctx = ctx
+	e.force = false
+
+	c, err := config.LoadState()
+	if err != nil {
+		return fmt.Errorf("unable to load state: %s", err)
+	}
+
+	e.config = c
+
+	existing := map[string]bool{}
+	if previous != nil {
+		for _, r := range previous.Resources {
+			existing[resources.FQRNFromResource(r).String()] = true
+		}
+	}
+
+	// only clusters created by the apply are destroyed
+	e.destroying = map[string]bool{}
+	for cl := range destroyedClusters(c) {
+		if !existing[cl] {
+			e.destroying[cl] = true
+		}
+	}
+	e.withCluster = map[string][]types.Resource{}
+	defer func() { e.destroying, e.withCluster = nil, nil }()
+
+	err = c.Walk(func(r types.Resource) error {
+		if existing[resources.FQRNFromResource(r).String()] {
+			return nil
+		}
+
+		return e.destroyCallback(r)
+	}, true)
+
+	// save the state regardless of error so that it only contains the
+	// resources that have not been destroyed
+	if len(e.config.Resources) == 0 {
+		os.Remove(utils.StatePath())
+	} else if stateErr := config.SaveState(e.config); stateErr != nil {
+		e.log.Info("Unable to save state", "error", stateErr)
+	}
+
+	if err != nil {
+		return fmt.Errorf("unable to roll back resources: %s", err)
+	}
+
+	return nil
+}
+
 // ResourceCount defines the number of resources in a plan
 func (e *EngineImpl) ResourceCount() int {
 	return e.config.ResourceCount()
@@ -376,7 +680,10 @@ func (e *EngineImpl) readAndProcessConfig(path string, variables map[string]stri
 		variablesFiles = append(variablesFiles, variablesFile)
 	}
 
-	hclParser := config.NewParser(callback, variables, variablesFiles)
+	hclParser := config.NewParser(func(r types.Resource) error {
+		e.warnDeprecations(r)
+		return callback(r)
+	}, variables, variablesFiles)
 
 	if utils.IsHCLFile(path) {
 		// ParseFile processes the HCL, builds a graph of resources then calls
@@ -413,6 +720,26 @@ func (e *EngineImpl) readAndProcessConfig(path string, variables map[string]stri
 	return parseError
 }
 
+// warnDeprecations logs a warning for each deprecated resource type or
+// attribute used by the resource
+func (e *EngineImpl) warnDeprecations(r types.Resource) {
+	e.deprecationsMutex.Lock()
+	defer e.deprecationsMutex.Unlock()
+
+	if e.deprecations == nil {
+		e.deprecations = map[string]bool{}
+	}
+
+	for _, d := range config.Deprecations(r) {
+		if e.deprecations[d.String()] {
+			continue
+		}
+
+		e.deprecations[d.String()] = true
+		e.log.Warn("Deprecated configuration", "resource", d.ResourceID, "file", d.File, "line", d.Line, "message", d.Message)
+	}
+}
+
 // destroyDisabledResources destroys any resrouces that were created but
 // have subsequently been set to disabled
 func (e *EngineImpl) destroyDisabledResources(ctx context.Context, force bool) error {
@@ -470,12 +797,205 @@ func (e *EngineImpl) appendDisabledResources(c *hclconfig.Config) error {
 	return nil
 }
 
+// Results returns the outcome of each resource processed by the last call
+// to Apply or Destroy
+func (e *EngineImpl) Results() []ResourceResult {
+	e.resultsMutex.Lock()
+	defer e.resultsMutex.Unlock()
+
+	res := make([]ResourceResult, len(e.results))
+	copy(res, e.results)
+
+	return res
+}
+
+func (e *EngineImpl) failedResults() []ResourceResult {
+	failed := []ResourceResult{}
+	for _, r := range e.Results() {
+		if r.Status == constants.StatusFailed {
+			failed = append(failed, r)
+		}
+	}
+
+	return failed
+}
+
+func (e *EngineImpl) resetResults() {
+	e.resultsMutex.Lock()
+	defer e.resultsMutex.Unlock()
+
+	e.results = []ResourceResult{}
+}
+
+// SetProgressHandler sets a function that is called when the engine starts
+// and finishes processing each resource, setting nil removes the handler
+func (e *EngineImpl) SetProgressHandler(h ProgressHandler) {
+	e.resultsMutex.Lock()
+	defer e.resultsMutex.Unlock()
+
+	e.progress = h
+}
+
+// SetEventBus sets the bus that resource and engine lifecycle events are
+// published to, setting nil disables events
+func (e *EngineImpl) SetEventBus(b *events.Bus) {
+	e.resultsMutex.Lock()
+	defer e.resultsMutex.Unlock()
+
+	e.eventBus = b
+}
+
+// publish sends an event to the event bus when one is set
+func (e *EngineImpl) publish(ev events.Event) {
+	e.resultsMutex.Lock()
+	b := e.eventBus
+	e.resultsMutex.Unlock()
+
+	b.Publish(ev)
+}
+
+// startPhase notifies the progress handler that processing of the resource
+// has started
+func (e *EngineImpl) startPhase(r types.Resource, phase string) {
+	e.resultsMutex.Lock()
+	h := e.progress
+	e.resultsMutex.Unlock()
+
+	id := resources.FQRNFromResource(r).String()
+
+	if h != nil {
+		h(ResourceResult{
+			ID:     id,
+			Type:   r.Metadata().Type,
+			Status: phase,
+		})
+	}
+
+	// refreshing is part of creating a resource
+	t := events.TypeResourceCreateStart
+	if phase == PhaseDestroying {
+		t = events.TypeResourceDestroyStart
+	}
+
+	e.publish(events.Event{
+		Type:         t,
+		Text:         fmt.Sprintf("%s %s", phaseText[phase], id),
+		ResourceID:   id,
+		ResourceType: r.Metadata().Type,
+		Status:       phase,
+	})
+}
+
+var phaseText = map[string]string{
+	PhaseCreating:   "Creating",
+	PhaseRefreshing: "Refreshing",
+	PhaseDestroying: "Destroying",
+}
+
+// addResult records the outcome of processing a resource, callbacks are
+// called concurrently so access is guarded by a mutex
+func (e *EngineImpl) addResult(r types.Resource, status string, start time.Time, err error) {
+	destroy := status == constants.StatusDestroyed
+
+	if err != nil {
+		status = constants.StatusFailed
+	}
+
+	res := ResourceResult{
+		ID:       resources.FQRNFromResource(r).String(),
+		Type:     r.Metadata().Type,
+		Status:   status,
+		Duration: time.Since(start),
+		Error:    err,
+	}
+
+	e.resultsMutex.Lock()
+	e.results = append(e.results, res)
+	h := e.progress
+	e.resultsMutex.Unlock()
+
+	if h != nil {
+		h(res)
+	}
+
+	e.publish(resultEvent(res, destroy))
+}
+
+// resultEvent creates the success or failure event for the result of
+// creating or destroying a resource
+func resultEvent(res ResourceResult, destroy bool) events.Event {
+	ev := events.Event{
+		ResourceID:   res.ID,
+		ResourceType: res.Type,
+		Status:       res.Status,
+		Duration:     res.Duration.Seconds(),
+	}
+
+	d := res.Duration.Round(time.Millisecond)
+
+	switch {
+	case destroy && res.Error != nil:
+		ev.Type = events.TypeResourceDestroyFail
+		ev.Text = fmt.Sprintf("Failed to destroy %s: %s", res.ID, res.Error)
+	case destroy:
+		ev.Type = events.TypeResourceDestroySuccess
+		ev.Text = fmt.Sprintf("Destroyed %s in %s", res.ID, d)
+	case res.Error != nil:
+		ev.Type = events.TypeResourceCreateFail
+		ev.Text = fmt.Sprintf("Failed to create %s: %s", res.ID, res.Error)
+	default:
+		ev.Type = events.TypeResourceCreateSuccess
+		ev.Text = fmt.Sprintf("Created %s in %s", res.ID, d)
+	}
+
+	if res.Error != nil {
+		ev.Error = res.Error.Error()
+	}
+
+	return ev
+}
+
+// traceContext returns the context of the current operation so that spans
+// created outside of Apply or Destroy are still nested when called from them
+func (e *EngineImpl) traceContext() context.Context {
+	if e.ctx != nil {
+		return e.ctx
+	}
+
+	return context.Background()
+}
+
+// startResourceSpan starts a span for an operation on a single resource
+func startResourceSpan(ctx context.Context, name string, r types.Resource) (context.Context, trace.Span) {
+	return tracing.Start(ctx, name,
+		tracing.AttributeResourceID.String(resources.FQRNFromResource(r).String()),
+		tracing.AttributeResourceType.String(r.Metadata().Type),
+	)
+}
+
 func (e *EngineImpl) createCallback(r types.Resource) error {
 	// if the context is cancelled skip
 	if e.ctx.Err() != nil {
 		return nil
 	}
 
+	ctx, span := startResourceSpan(e.ctx, "resource.create", r)
+
+	start := time.Now()
+	err := e.createResource(ctx, r)
+
+	status, _ := r.Metadata().Properties[constants.PropertyStatus].(string)
+	e.addResult(r, status, start, err)
+
+	span.SetAttributes(tracing.AttributeResourceStatus.String(status))
+	tracing.End(span, err)
+
+	return err
+}
+
+// createResource creates or refreshes the resource, ctx is passed to the
+// provider so that any spans it creates are nested under the resource
+func (e *EngineImpl) createResource(ctx context.Context, r types.Resource) error {
 	p := e.providers.GetProvider(r)
 	if p == nil {
 		r.Metadata().Properties[constants.PropertyStatus] = constants.StatusFailed
@@ -496,10 +1016,23 @@ func (e *EngineImpl) createCallback(r types.Resource) error {
 		}
 	}
 
+	// created resources that are not targeted keep their current state
+	if r.Metadata().Properties[constants.PropertyStatus] == constants.StatusCreated && !e.targeted(r) {
+		e.log.Debug("Skipping refresh for resource that is not targeted", "ref", r.Metadata().ID)
+
+		err = e.config.AppendResource(r)
+		if err != nil {
+			return fmt.Errorf(`unable add resource "%s" to state, %s`, r.Metadata().ID, err)
+		}
+
+		return nil
+	}
+
 	var providerError error
 	switch r.Metadata().Properties[constants.PropertyStatus] {
 	case constants.StatusCreated:
-		providerError = p.Refresh(e.ctx)
+		e.startPhase(r, PhaseRefreshing)
+		providerError = p.Refresh(ctx)
 		if providerError != nil {
 			r.Metadata().Properties[constants.PropertyStatus] = constants.StatusFailed
 		}
@@ -512,7 +1045,8 @@ func (e *EngineImpl) createCallback(r types.Resource) error {
 
 	// Always attempt to destroy and re-create failed resources
 	case constants.StatusFailed:
-		providerError = p.Destroy(e.ctx, false)
+		e.startPhase(r, PhaseDestroying)
+		providerError = p.Destroy(ctx, false)
 		if providerError != nil {
 			r.Metadata().Properties[constants.PropertyStatus] = constants.StatusFailed
 		}
@@ -520,8 +1054,9 @@ func (e *EngineImpl) createCallback(r types.Resource) error {
 		fallthrough // failed resources should always attempt recreation
 
 	default:
+		e.startPhase(r, PhaseCreating)
 		r.Metadata().Properties[constants.PropertyStatus] = constants.StatusCreated
-		providerError = p.Create(e.ctx)
+		providerError = p.Create(ctx)
 		if providerError != nil {
 			r.Metadata().Properties[constants.PropertyStatus] = constants.StatusFailed
 		}
@@ -545,7 +1080,7 @@ func (e *EngineImpl) createCallback(r types.Resource) error {
 
 			// reload the networks
 			np := e.providers.GetProvider(ic)
-			np.Refresh(e.ctx)
+			np.Refresh(ctx)
 		} else {
 			e.log.Error("Unable to find Image Cache", "error", err)
 		}
@@ -583,12 +1118,12 @@ func (e *EngineImpl) createCallback(r types.Resource) error {
 
 			e.cacheMutex.Unlock()
 
-			err := np.Destroy(e.ctx, e.force)
+			err := np.Destroy(ctx, e.force)
 			if err != nil {
 				e.log.Error("Unable to destroy Image Cache", "error", err)
 			}
 
-			err = np.Create(e.ctx)
+			err = np.Create(ctx)
 			if err != nil {
 				e.log.Error("Unable to create Image Cache", "error", err)
 			}
@@ -600,6 +1135,12 @@ func (e *EngineImpl) createCallback(r types.Resource) error {
 	return providerError
 }
 
+// targeted returns true when all resources are applied or the resource is
+// one of the targets set by ApplyTargets
+func (e *EngineImpl) targeted(r types.Resource) bool {
+	return e.targets == nil || e.targets[resources.FQRNFromResource(r).String()]
+}
+
 func (e *EngineImpl) destroyCallback(r types.Resource) error {
 	// if the context is cancelled skip
 	if e.ctx.Err() != nil {
@@ -616,6 +1157,20 @@ func (e *EngineImpl) destroyCallback(r types.Resource) error {
 		return nil
 	}
 
+	// resources inside a cluster that is also being destroyed are removed
+	// with the cluster, skipping them avoids waiting for the cluster to
+	// uninstall them. They stay in the state until the cluster is removed
+	if cl := config.ResourceCluster(r); cl != nil && e.destroying[resources.FQRNFromResource(cl).String()] {
+		cluster := resources.FQRNFromResource(cl).String()
+		e.log.Debug("Skipping resource removed with cluster", "fqdn", fqrn.String(), "cluster", cluster)
+
+		e.withClusterMutex.Lock()
+		e.withCluster[cluster] = append(e.withCluster[cluster], r)
+		e.withClusterMutex.Unlock()
+
+		return nil
+	}
+
 	p := e.providers.GetProvider(r)
 
 	if p == nil {
@@ -623,14 +1178,72 @@ func (e *EngineImpl) destroyCallback(r types.Resource) error {
 		return fmt.Errorf("unable to create provider for resource Name: %s, Type: %s", r.Metadata().Name, r.Metadata().Type)
 	}
 
-	err := p.Destroy(e.ctx, e.force)
+	// the workloads in a cluster are discarded with the cluster, the nodes
+	// are removed without waiting for them to stop gracefully
+	force := e.force || e.destroying[fqrn.String()]
+
+	e.startPhase(r, PhaseDestroying)
+	ctx, span := startResourceSpan(e.ctx, "resource.destroy", r)
+	start := time.Now()
+	err := p.Destroy(ctx, force)
 	if err != nil && !e.force {
 		r.Metadata().Properties[constants.PropertyStatus] = constants.StatusFailed
+		e.addResult(r, constants.StatusDestroyed, start, err)
+		tracing.End(span, err)
+
 		return fmt.Errorf("unable to destroy resource Name: %s, Type: %s, Error: %s", r.Metadata().Name, r.Metadata().Type, err)
 	}
 
+	e.addResult(r, constants.StatusDestroyed, start, nil)
+	tracing.End(span, nil)
+
 	// remove from the state
 	e.config.RemoveResource(r)
+	e.removeWithCluster(fqrn.String(), start)
 
 	return nil
 }
+
+// removeWithCluster removes the resources that were destroyed with the
+// cluster from the state
+func (e *EngineImpl) removeWithCluster(cluster string, start time.Time) {
+	e.withClusterMutex.Lock()
+	removed := e.withCluster[cluster]
+	delete(e.withCluster, cluster)
+	e.withClusterMutex.Unlock()
+
+	for _, r := range removed {
+		e.addResult(r, constants.StatusDestroyed, start, nil)
+		e.config.RemoveResource(r)
+	}
+}
+
+// destroyedClusters returns the fully qualified names of the clusters in the
+// config that are not disabled and contain resources, the resources created
+// in these clusters are removed with the cluster
+func destroyedClusters(c *hclconfig.Config) map[string]bool {
+	clusters := map[string]bool{}
+	if c == nil {
+		return clusters
+	}
+
+	enabled := map[string]bool{}
+	for _, r := range c.Resources {
+		if !r.GetDisabled() {
+			enabled[resources.FQRNFromResource(r).String()] = true
+		}
+	}
+
+	for _, r := range c.Resources {
+		cl := config.ResourceCluster(r)
+		if cl == nil {
+			continue
+		}
+
+		if fqrn := resources.FQRNFromResource(cl).String(); enabled[fqrn] {
+			clusters[fqrn] = true
+		}
+	}
+
+	return clusters
+}
@@ -6,21 +6,28 @@ import (
 	"log"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/jumppad-labs/hclconfig"
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/events"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/config/mocks"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
 	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
 	"github.com/jumppad-labs/jumppad/pkg/utils"
 	"github.com/jumppad-labs/jumppad/testutils"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func setupTests(t *testing.T, returnVals map[string]error) (*EngineImpl, *mocks.Providers) {
@@ -175,9 +182,9 @@ func TestApplyCallsProviderCreateForEachProvider(t *testing.T) {
 	testAssertMethodCalled(t, mp, "Create", rc)
 	testAssertMethodCalled(t, mp, "Refresh", 1)
 
-	// the state should also contain 11 resources
+	// the state should also contain 14 resources
 	sf := testLoadState(t)
-	require.Equal(t, 11, sf.ResourceCount())
+	require.Equal(t, 14, sf.ResourceCount())
 }
 
 func TestApplyDoesNotCallsProviderCreateWhenInState(t *testing.T) {
@@ -312,6 +319,122 @@ func TestApplyCallsProviderGenerateErrorStopsExecution(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestApplyRecordsResultForEachResource(t *testing.T) {
+	e, _ := setupTests(t, nil)
+
+	_, err := e.Apply(context.Background(), "../../examples/single_file/container.hcl")
+	require.NoError(t, err)
+
+	res := e.Results()
+	require.Len(t, res, 7) // 6 resources in the file plus the image cache
+
+	for _, r := range res {
+		require.Equal(t, constants.StatusCreated, r.Status, r.ID)
+		require.NoError(t, r.Error)
+	}
+}
+
+func TestApplyRecordsErrorInResults(t *testing.T) {
+	e, _ := setupTests(t, map[string]error{"onprem": fmt.Errorf("boom")})
+
+	_, err := e.Apply(context.Background(), "../../examples/single_file/container.hcl")
+	require.Error(t, err)
+
+	res := map[string]ResourceResult{}
+	for _, r := range e.Results() {
+		res[r.ID] = r
+	}
+
+	require.Contains(t, res, "resource.network.onprem")
+	require.Equal(t, constants.StatusFailed, res["resource.network.onprem"].Status)
+	require.ErrorContains(t, res["resource.network.onprem"].Error, "boom")
+}
+
+func TestApplyCallsProgressHandlerWhenResourcesStartAndFinish(t *testing.T) {
+	e, _ := setupTests(t, nil)
+
+	m := sync.Mutex{}
+	events := map[string][]string{}
+
+	e.SetProgressHandler(func(r ResourceResult) {
+		m.Lock()
+		defer m.Unlock()
+
+		events[r.ID] = append(events[r.ID], r.Status)
+	})
+
+	_, err := e.Apply(context.Background(), "../../examples/single_file/container.hcl")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{PhaseCreating, constants.StatusCreated}, events["resource.container.consul"])
+	require.Equal(t, []string{PhaseCreating, constants.StatusCreated}, events["resource.network.onprem"])
+}
+
+func TestApplyCreatesSpanForEachResource(t *testing.T) {
+	e, _ := setupTests(t, nil)
+
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+	})
+
+	_, err := e.Apply(context.Background(), "../../examples/single_file/container.hcl")
+	require.NoError(t, err)
+
+	var apply sdktrace.ReadOnlySpan
+	creates := []sdktrace.ReadOnlySpan{}
+
+	for _, s := range sr.Ended() {
+		switch s.Name() {
+		case "jumppad.apply":
+			apply = s
+		case "resource.create":
+			creates = append(creates, s)
+		}
+	}
+
+	require.NotNil(t, apply)
+	require.Len(t, creates, 7) // 6 resources in the file plus the image cache
+
+	for _, s := range creates {
+		require.Equal(t, apply.SpanContext().SpanID(), s.Parent().SpanID())
+	}
+}
+
+type recordingSink struct {
+	events []events.Event
+	mutex  sync.Mutex
+}
+
+func (r *recordingSink) Send(e events.Event) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.events = append(r.events, e)
+	return nil
+}
+
+func TestApplyPublishesEventsForEachResource(t *testing.T) {
+	e, _ := setupTests(t, nil)
+
+	rs := &recordingSink{}
+	e.SetEventBus(events.NewBus(logger.NewTestLogger(t), []events.Sink{rs}, nil))
+
+	_, err := e.Apply(context.Background(), "../../examples/single_file/container.hcl")
+	require.NoError(t, err)
+
+	counts := map[string]int{}
+	for _, ev := range rs.events {
+		counts[ev.Type]++
+	}
+
+	require.Equal(t, events.TypeEngineUpStart, rs.events[0].Type)
+	require.Equal(t, events.TypeEngineUpComplete, rs.events[len(rs.events)-1].Type)
+	require.Equal(t, 7, counts[events.TypeResourceCreateStart]) // 6 resources in the file plus the image cache
+	require.Equal(t, 7, counts[events.TypeResourceCreateSuccess])
+}
+
 func TestApplyCallsProviderDestroyAndCreateForFailedResources(t *testing.T) {
 	e, mp := setupTestsWithState(t, nil, failedState)
 
@@ -355,6 +478,49 @@ func TestApplyCallsProviderDestroyForDisabledResources(t *testing.T) {
 	testAssertMethodCalled(t, mp, "Create", 0) // ImageCache are always created
 }
 
+func TestApplyNotCallsProviderCreateForResourcesNotEnabled(t *testing.T) {
+	e, _ := setupTests(t, nil)
+
+	_, err := e.Apply(context.Background(), "../../examples/enabled")
+	require.NoError(t, err)
+
+	// resources that are not enabled are added to the state like disabled
+	// resources
+	sf := testLoadState(t)
+
+	r, err := sf.FindResource("resource.container.prometheus")
+	require.NoError(t, err)
+	require.True(t, r.GetDisabled())
+	require.Nil(t, r.Metadata().Properties[constants.PropertyStatus])
+}
+
+func TestApplyCallsProviderCreateWhenEnabledByVariable(t *testing.T) {
+	e, _ := setupTests(t, nil)
+
+	_, err := e.ApplyWithVariables(context.Background(), "../../examples/enabled", map[string]string{"monitoring": "true"}, "")
+	require.NoError(t, err)
+
+	sf := testLoadState(t)
+
+	r, err := sf.FindResource("resource.container.prometheus")
+	require.NoError(t, err)
+	require.False(t, r.GetDisabled())
+	require.Equal(t, constants.StatusCreated, r.Metadata().Properties[constants.PropertyStatus])
+}
+
+func TestApplyCallsProviderDestroyForResourcesNoLongerEnabled(t *testing.T) {
+	e, mp := setupTestsWithState(t, nil, enabledAndCreatedState)
+
+	_, err := e.Apply(context.Background(), "../../examples/enabled")
+	require.NoError(t, err)
+
+	r, err := e.config.FindResource("resource.container.prometheus")
+	require.NoError(t, err)
+	require.Equal(t, constants.StatusDisabled, r.Metadata().Properties[constants.PropertyStatus])
+
+	testAssertMethodCalled(t, mp, "Destroy", 1, r)
+}
+
 func TestApplyCallsProviderRefreshForCreatedResources(t *testing.T) {
 	e, mp := setupTestsWithState(t, nil, existingState)
 
@@ -367,6 +533,33 @@ func TestApplyCallsProviderRefreshForCreatedResources(t *testing.T) {
 	testAssertMethodCalled(t, mp, "Refresh", 2)
 }
 
+func TestApplyTargetsCallsProviderRefreshOnlyForTargets(t *testing.T) {
+	e, mp := setupTestsWithState(t, nil, singleFileState)
+
+	_, err := e.ApplyTargets(context.Background(), "../../examples/single_file/container.hcl", nil, "", []string{"resource.template.consul_config"})
+	require.NoError(t, err)
+
+	r, err := e.config.FindResource("resource.template.consul_config")
+	require.NoError(t, err)
+
+	testAssertMethodCalled(t, mp, "Refresh", 1, r)
+	testAssertMethodCalled(t, mp, "Refresh", 1)
+}
+
+func TestApplyTargetsKeepsResourcesThatAreNotTargetsInState(t *testing.T) {
+	e, mp := setupTestsWithState(t, nil, singleFileState)
+
+	_, err := e.ApplyTargets(context.Background(), "../../examples/single_file/container.hcl", nil, "", []string{})
+	require.NoError(t, err)
+
+	testAssertMethodCalled(t, mp, "Refresh", 0)
+
+	sf := testLoadState(t)
+	r, err := sf.FindResource("resource.container.consul")
+	require.NoError(t, err)
+	require.Equal(t, constants.StatusCreated, r.Metadata().Properties[constants.PropertyStatus])
+}
+
 func TestApplyCallsProviderRefreshWithErrorHaltsExecution(t *testing.T) {
 	e, mp := setupTestsWithState(t, map[string]error{"consul_config": fmt.Errorf("boom")}, singleFileState)
 
@@ -391,6 +584,20 @@ func TestDestroyCallsProviderDestroyForEachProvider(t *testing.T) {
 	require.NoFileExists(t, utils.StatePath())
 }
 
+func TestDestroyRecordsResultForEachResource(t *testing.T) {
+	e, _ := setupTestsWithState(t, nil, existingState)
+
+	err := e.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	res := e.Results()
+	require.Len(t, res, 4)
+
+	for _, r := range res {
+		require.Equal(t, constants.StatusDestroyed, r.Status, r.ID)
+	}
+}
+
 func TestDestroyNotCallsProviderDestroyForResourcesDisabled(t *testing.T) {
 	e, mp := setupTestsWithState(t, nil, disabledState)
 
@@ -411,7 +618,8 @@ func TestDestroyCallsProviderGenerateErrorStopsExecution(t *testing.T) {
 	err := e.Destroy(context.Background(), false)
 	require.Error(t, err)
 
-	// should have call destroy for each provider
+	// the image cache depends on the container so it is destroyed first,
+	// the error from the container stops the remaining resources
 	testAssertMethodCalled(t, mp, "Destroy", 2)
 
 	// state should not be removed
@@ -428,6 +636,81 @@ func TestDestroyFailSetsStatus(t *testing.T) {
 	require.Equal(t, constants.StatusFailed, r.Metadata().Properties[constants.PropertyStatus])
 }
 
+func TestDestroySkipsResourcesInDestroyedCluster(t *testing.T) {
+	e, mp := setupTestsWithState(t, nil, clusterState)
+
+	err := e.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	// the helm chart is removed with the cluster
+	testAssertMethodCalled(t, mp, "Destroy", 1)
+	require.Equal(t, "k3s", getMetaFromMock(mp, 0).Name)
+
+	res := e.Results()
+	require.Len(t, res, 2)
+
+	for _, r := range res {
+		require.Equal(t, constants.StatusDestroyed, r.Status, r.ID)
+	}
+}
+
+func TestRollbackDestroysResourcesNotInPreviousState(t *testing.T) {
+	e, mp := setupTestsWithState(t, nil, existingState)
+
+	previous := hclconfig.NewConfig()
+
+	n := &network.Network{}
+	n.Meta = types.Meta{Name: "cloud", Type: network.TypeNetwork, Properties: map[string]any{}}
+	previous.AppendResource(n)
+
+	ca := &cache.ImageCache{}
+	ca.Meta = types.Meta{Name: "default", Type: cache.TypeImageCache, Properties: map[string]any{}}
+	previous.AppendResource(ca)
+
+	err := e.Rollback(context.Background(), previous)
+	require.NoError(t, err)
+
+	testAssertMethodCalled(t, mp, "Destroy", 2)
+
+	s := testLoadState(t)
+	require.Len(t, s.Resources, 2)
+
+	_, err = s.FindResource("resource.network.cloud")
+	require.NoError(t, err)
+
+	_, err = s.FindResource("resource.container.container")
+	require.Error(t, err)
+}
+
+func TestRollbackWithoutPreviousStateRemovesState(t *testing.T) {
+	e, mp := setupTestsWithState(t, nil, existingState)
+
+	err := e.Rollback(context.Background(), hclconfig.NewConfig())
+	require.NoError(t, err)
+
+	testAssertMethodCalled(t, mp, "Destroy", 4)
+	require.NoFileExists(t, utils.StatePath())
+}
+
+func TestDestroyRemovesClusterWithoutWaiting(t *testing.T) {
+	e, mp := setupTestsWithState(t, nil, clusterState)
+
+	err := e.Destroy(context.Background(), false)
+	require.NoError(t, err)
+
+	mp.Providers[0].AssertCalled(t, "Destroy", mock.Anything, true)
+}
+
+func TestDestroyKeepsResourcesInClusterWhenClusterFails(t *testing.T) {
+	e, _ := setupTestsWithState(t, map[string]error{"k3s": fmt.Errorf("boom")}, clusterState)
+
+	err := e.Destroy(context.Background(), false)
+	require.Error(t, err)
+
+	_, err = e.config.FindResource("resource.helm.vault")
+	require.NoError(t, err)
+}
+
 func TestParseConfig(t *testing.T) {
 	e, mp := setupTests(t, nil)
 
@@ -674,7 +957,7 @@ var complexState = `
           "status": "created"
         }
       },
-      "depends_on": ["resource.network.cloud"]
+      "depends_on": ["resource.network.cloud", "resource.container.mycontainer"]
   },
   {
       "meta": {
@@ -785,3 +1068,134 @@ var disabledAndCreatedState = `
   ]
 }
 `
+
+var enabledAndCreatedState = `
+{
+  "blueprint": null,
+  "resources": [
+  {
+      "meta": {
+        "name": "main",
+        "properties": {
+          "status": "created"
+        },
+        "type": "network"
+      },
+      "subnet": "10.16.0.0/16"
+  },
+  {
+     "meta": {
+        "name": "prometheus",
+        "properties": {
+          "status": "created"
+        },
+        "type": "container"
+      },
+      "image": {
+        "name": "prom/prometheus:v2.53.0"
+      }
+  }
+  ]
+}
+`
+
+var clusterState = `
+{
+  "resources": [
+  {
+      "meta": {
+        "name": "k3s",
+        "properties": {
+          "status": "created"
+        },
+        "type": "k8s_cluster"
+      }
+  },
+  {
+      "meta": {
+        "name": "vault",
+        "properties": {
+          "status": "created"
+        },
+        "type": "helm"
+      },
+      "cluster": {
+        "meta": {
+          "name": "k3s",
+          "type": "k8s_cluster"
+        }
+      },
+      "depends_on": ["resource.k8s_cluster.k3s"]
+  }
+  ]
+}
+`
+
+func TestUpWithInvalidBlueprintReturnsParseError(t *testing.T) {
+	e, mp := setupTests(t, nil)
+
+	dir := t.TempDir()
+	err := os.WriteFile(dir+"/main.hcl", []byte(`resource "container" "consul" {`), 0644)
+	require.NoError(t, err)
+
+	_, err = e.Up(context.Background(), dir)
+
+	pe := &ParseError{}
+	require.ErrorAs(t, err, &pe)
+	require.Equal(t, dir, pe.Path)
+	testAssertMethodCalled(t, mp, "Create", 0)
+}
+
+func TestUpWithFailedResourceReturnsResourceError(t *testing.T) {
+	e, _ := setupTests(t, map[string]error{"onprem": fmt.Errorf("boom")})
+
+	_, err := e.Up(context.Background(), "../../examples/single_file/container.hcl")
+
+	re := &ResourceError{}
+	require.ErrorAs(t, err, &re)
+	require.Len(t, re.Failed, 1)
+	require.Equal(t, "resource.network.onprem", re.Failed[0].ID)
+	require.ErrorContains(t, re.Failed[0].Error, "boom")
+}
+
+func TestUpUsesVariablesFromOptions(t *testing.T) {
+	e, mp := setupTests(t, nil)
+	e.variablesFile = "../../examples/single_file/default.vars"
+
+	_, err := e.Up(context.Background(), "../../examples/single_file/container.hcl")
+	require.NoError(t, err)
+
+	cont := getResourceFromMock(mp, 6).(*container.Container)
+	require.Equal(t, "consul:1.8.1", cont.Image.Name)
+}
+
+func TestDownDestroysResourcesInState(t *testing.T) {
+	e, mp := setupTestsWithState(t, nil, existingState)
+
+	err := e.Down(context.Background())
+	require.NoError(t, err)
+
+	testAssertMethodCalled(t, mp, "Destroy", 4)
+	require.NoFileExists(t, utils.StatePath())
+}
+
+func TestDownWithoutStateDoesNothing(t *testing.T) {
+	e, mp := setupTests(t, nil)
+	os.Remove(utils.StatePath())
+
+	err := e.Down(context.Background())
+	require.NoError(t, err)
+
+	testAssertMethodCalled(t, mp, "Destroy", 0)
+}
+
+func TestDownWithFailedResourceReturnsResourceError(t *testing.T) {
+	e, _ := setupTestsWithState(t, map[string]error{"mycontainer": fmt.Errorf("boom")}, complexState)
+
+	err := e.Down(context.Background())
+
+	re := &ResourceError{}
+	require.ErrorAs(t, err, &re)
+	require.NotEmpty(t, re.Failed)
+	require.Equal(t, constants.StatusFailed, re.Failed[0].Status)
+}
@@ -0,0 +1,48 @@
+package jumppad
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseError is returned by Up when the blueprint can not be parsed, no
+// resources are changed when the blueprint is invalid
+type ParseError struct {
+	Path string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("unable to parse blueprint %s: %s", e.Path, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// ResourceError is returned by Up and Down when one or more resources could
+// not be created or destroyed, the resources that succeeded are left in
+// place and recorded in the state
+type ResourceError struct {
+	// Failed contains the result for each resource that failed, the result
+	// for every resource is returned by Engine.Results
+	Failed []ResourceResult
+	Err    error
+}
+
+func (e *ResourceError) Error() string {
+	if len(e.Failed) == 0 {
+		return e.Err.Error()
+	}
+
+	ids := []string{}
+	for _, r := range e.Failed {
+		ids = append(ids, r.ID)
+	}
+
+	return fmt.Sprintf("%d resources failed (%s): %s", len(e.Failed), strings.Join(ids, ", "), e.Err)
+}
+
+func (e *ResourceError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,256 @@
+package jumppad
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+)
+
+const (
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+)
+
+// graphEdge is a dependency between two resources, To depends on From
+type graphEdge struct {
+	From string
+	To   string
+}
+
+var mermaidID = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// Graph renders the dependency graph for the resources in the config as DOT
+// or Mermaid. When state is not nil the status of each resource in the state
+// is added to the nodes in the graph.
+func Graph(c *hclconfig.Config, state *hclconfig.Config, format string) (string, error) {
+	nodes, edges := dependencyGraph(c)
+
+	status := map[string]string{}
+	if state != nil {
+		for _, r := range state.Resources {
+			if s, ok := r.Metadata().Properties[constants.PropertyStatus].(string); ok {
+				status[resources.FQRNFromResource(r).String()] = s
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		if n.GetDisabled() {
+			status[resources.FQRNFromResource(n).String()] = constants.StatusDisabled
+		}
+	}
+
+	switch format {
+	case GraphFormatDOT:
+		return renderDOT(nodes, edges, status), nil
+	case GraphFormatMermaid:
+		return renderMermaid(nodes, edges, status), nil
+	}
+
+	return "", fmt.Errorf("unknown graph format %s, must be one of %s, %s", format, GraphFormatDOT, GraphFormatMermaid)
+}
+
+// dependencyGraph returns the resources and the dependencies between them
+// using the same rules as the parser uses to determine the order resources
+// are created
+func dependencyGraph(c *hclconfig.Config) ([]types.Resource, []graphEdge) {
+	nodes := []types.Resource{}
+	edges := map[graphEdge]bool{}
+
+	for _, r := range c.Resources {
+		// variables are not part of the graph
+		if r.Metadata().Type == resources.TypeVariable {
+			continue
+		}
+
+		nodes = append(nodes, r)
+		id := resources.FQRNFromResource(r).String()
+
+		deps := []types.Resource{}
+
+		for _, d := range append(r.GetDependencies(), r.Metadata().Links...) {
+			fqrn, err := resources.ParseFQRN(d)
+			if err != nil {
+				continue
+			}
+
+			// references are relative to the module the resource is defined in
+			rel := fqrn.AppendParentModule(r.Metadata().Module)
+
+			// when the dependency is a module, depend on all resources in the module
+			if fqrn.Type == resources.TypeModule {
+				mr, _ := c.FindModuleResources(rel.String(), true)
+				deps = append(deps, mr...)
+				continue
+			}
+
+			dr, err := c.FindResource(rel.String())
+			if err == nil {
+				deps = append(deps, dr)
+			}
+		}
+
+		// resources in a module depend on the module
+		if r.Metadata().Module != "" {
+			mr, err := c.FindResource(fmt.Sprintf("module.%s", r.Metadata().Module))
+			if err == nil {
+				deps = append(deps, mr)
+			}
+		}
+
+		for _, d := range deps {
+			if d.Metadata().Type == resources.TypeVariable {
+				continue
+			}
+
+			edges[graphEdge{From: resources.FQRNFromResource(d).String(), To: id}] = true
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		return resources.FQRNFromResource(nodes[i]).String() < resources.FQRNFromResource(nodes[j]).String()
+	})
+
+	sorted := []graphEdge{}
+	for e := range edges {
+		sorted = append(sorted, e)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].From == sorted[j].From {
+			return sorted[i].To < sorted[j].To
+		}
+
+		return sorted[i].From < sorted[j].From
+	})
+
+	return nodes, sorted
+}
+
+func renderDOT(nodes []types.Resource, edges []graphEdge, status map[string]string) string {
+	sb := strings.Builder{}
+	sb.WriteString("digraph jumppad {\n")
+	sb.WriteString("  rankdir = \"LR\";\n")
+	sb.WriteString("  node [shape = \"box\"];\n")
+
+	for _, n := range nodes {
+		id := resources.FQRNFromResource(n).String()
+		label := id
+
+		attrs := []string{}
+		if s, ok := status[id]; ok {
+			label = fmt.Sprintf("%s\\n%s", id, s)
+			attrs = append(attrs, fmt.Sprintf("color = \"%s\"", dotColor(s)))
+		}
+
+		if n.GetDisabled() {
+			attrs = append(attrs, "style = \"dashed\"")
+		}
+
+		attrs = append([]string{fmt.Sprintf("label = \"%s\"", label)}, attrs...)
+		sb.WriteString(fmt.Sprintf("  \"%s\" [%s];\n", id, strings.Join(attrs, ", ")))
+	}
+
+	for _, e := range edges {
+		sb.WriteString(fmt.Sprintf("  \"%s\" -> \"%s\";\n", e.From, e.To))
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+func renderMermaid(nodes []types.Resource, edges []graphEdge, status map[string]string) string {
+	sb := strings.Builder{}
+	sb.WriteString("graph LR\n")
+
+	classes := map[string][]string{}
+
+	for _, n := range nodes {
+		id := resources.FQRNFromResource(n).String()
+		label := id
+
+		if s, ok := status[id]; ok {
+			label = fmt.Sprintf("%s<br/>%s", id, s)
+			classes[s] = append(classes[s], mermaidID.ReplaceAllString(id, "_"))
+		}
+
+		sb.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", mermaidID.ReplaceAllString(id, "_"), label))
+	}
+
+	for _, e := range edges {
+		sb.WriteString(fmt.Sprintf("  %s --> %s\n", mermaidID.ReplaceAllString(e.From, "_"), mermaidID.ReplaceAllString(e.To, "_")))
+	}
+
+	keys := []string{}
+	for k := range classes {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, s := range keys {
+		sb.WriteString(fmt.Sprintf("  classDef %s stroke:%s\n", s, dotColor(s)))
+		sb.WriteString(fmt.Sprintf("  class %s %s\n", strings.Join(classes[s], ","), s))
+	}
+
+	return sb.String()
+}
+
+// dotColor returns the color used for a resource with the given status
+func dotColor(status string) string {
+	switch status {
+	case constants.StatusCreated:
+		return "green"
+	case constants.StatusFailed:
+		return "red"
+	case constants.StatusTainted:
+		return "orange"
+	case constants.StatusDisabled:
+		return "gray"
+	}
+
+	return "black"
+}
+
+// Dependents returns the resource with the given id and all the resources
+// that depend on it directly or through other resources, sorted by name
+func Dependents(c *hclconfig.Config, id string) ([]string, error) {
+	r, err := c.FindResource(id)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find resource %s: %s", id, err)
+	}
+
+	_, edges := dependencyGraph(c)
+
+	start := resources.FQRNFromResource(r).String()
+	found := map[string]bool{start: true}
+	queue := []string{start}
+
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+
+		for _, e := range edges {
+			if e.From == n && !found[e.To] {
+				found[e.To] = true
+				queue = append(queue, e.To)
+			}
+		}
+	}
+
+	ids := []string{}
+	for k := range found {
+		ids = append(ids, k)
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
@@ -0,0 +1,94 @@
+package jumppad
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
+	"github.com/jumppad-labs/jumppad/pkg/jumppad/constants"
+	"github.com/stretchr/testify/require"
+)
+
+func setupGraphConfig() *hclconfig.Config {
+	c := hclconfig.NewConfig()
+
+	n := &network.Network{}
+	n.Meta = types.Meta{Name: "main", Type: network.TypeNetwork, Properties: map[string]any{}}
+
+	ct := &container.Container{}
+	ct.Meta = types.Meta{Name: "consul", Type: container.TypeContainer, Links: []string{"resource.network.main.meta.id"}, Properties: map[string]any{}}
+
+	m := &resources.Module{}
+	m.Meta = types.Meta{Name: "vault", Type: resources.TypeModule, Properties: map[string]any{}}
+	m.DependsOn = []string{"resource.container.consul"}
+
+	mc := &container.Container{}
+	mc.Meta = types.Meta{Name: "vault", Type: container.TypeContainer, Module: "vault", Links: []string{"resource.network.main.meta.id"}, Properties: map[string]any{}}
+
+	v := &resources.Variable{}
+	v.Meta = types.Meta{Name: "version", Type: resources.TypeVariable, Properties: map[string]any{}}
+
+	c.AppendResource(n)
+	c.AppendResource(ct)
+	c.AppendResource(m)
+	c.AppendResource(mc)
+	c.AppendResource(v)
+
+	return c
+}
+
+func TestGraphRendersDOT(t *testing.T) {
+	g, err := Graph(setupGraphConfig(), nil, GraphFormatDOT)
+	require.NoError(t, err)
+
+	require.Contains(t, g, "digraph jumppad {")
+	require.Contains(t, g, `"resource.network.main" -> "resource.container.consul";`)
+	require.Contains(t, g, `"resource.container.consul" -> "module.vault";`)
+	require.Contains(t, g, `"module.vault" -> "module.vault.resource.container.vault";`)
+	require.NotContains(t, g, "variable.version")
+}
+
+func TestGraphRendersMermaid(t *testing.T) {
+	g, err := Graph(setupGraphConfig(), nil, GraphFormatMermaid)
+	require.NoError(t, err)
+
+	require.Contains(t, g, "graph LR")
+	require.Contains(t, g, `resource_container_consul["resource.container.consul"]`)
+	require.Contains(t, g, "resource_network_main --> resource_container_consul")
+}
+
+func TestGraphAnnotatesStatusFromState(t *testing.T) {
+	state := setupGraphConfig()
+	r, _ := state.FindResource("resource.container.consul")
+	r.Metadata().Properties[constants.PropertyStatus] = constants.StatusFailed
+
+	g, err := Graph(setupGraphConfig(), state, GraphFormatDOT)
+	require.NoError(t, err)
+
+	require.Contains(t, g, `"resource.container.consul" [label = "resource.container.consul\nfailed", color = "red"];`)
+	require.Contains(t, g, `"resource.network.main" [label = "resource.network.main"];`)
+}
+
+func TestGraphUnknownFormatReturnsError(t *testing.T) {
+	_, err := Graph(setupGraphConfig(), nil, "svg")
+	require.ErrorContains(t, err, "unknown graph format svg")
+}
+
+func TestDependentsReturnsResourceAndDependents(t *testing.T) {
+	ids, err := Dependents(setupGraphConfig(), "resource.container.consul")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"module.vault",
+		"module.vault.resource.container.vault",
+		"resource.container.consul",
+	}, ids)
+}
+
+func TestDependentsMissingResourceReturnsError(t *testing.T) {
+	_, err := Dependents(setupGraphConfig(), "resource.container.vault")
+	require.Error(t, err)
+}
@@ -1,28 +1,44 @@
 package jumppad
 
 import (
+	"context"
+	"strings"
+
 	"github.com/jumppad-labs/hclconfig/resources"
 	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/plugins"
 	"github.com/jumppad-labs/jumppad/pkg/config"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/blueprint"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/build"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/cache"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/cert"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/chaos"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/cloud"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/copy"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/custom"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/exec"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/helm"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/http"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/mesh"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/monitoring"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/network"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/null"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/ollama"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/plugin"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/random"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/requirements"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/secret"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/sync"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/template"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/terminal"
 	"github.com/jumppad-labs/jumppad/pkg/config/resources/terraform"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/wait"
 	sdk "github.com/jumppad-labs/plugin-sdk"
 )
 
@@ -32,9 +48,13 @@ func init() {
 	config.RegisterResource(cache.TypeImageCache, &cache.ImageCache{}, &cache.Provider{})
 	config.RegisterResource(cert.TypeCertificateCA, &cert.CertificateCA{}, &cert.CAProvider{})
 	config.RegisterResource(cert.TypeCertificateLeaf, &cert.CertificateLeaf{}, &cert.LeafProvider{})
+	config.RegisterResource(chaos.TypeChaos, &chaos.Chaos{}, &chaos.Provider{})
+	config.RegisterResource(cloud.TypeAWSCredentials, &cloud.AWSCredentials{}, &cloud.AWSProvider{})
+	config.RegisterResource(cloud.TypeGCPCredentials, &cloud.GCPCredentials{}, &cloud.GCPProvider{})
 	config.RegisterResource(container.TypeContainer, &container.Container{}, &container.Provider{})
 	config.RegisterResource(container.TypeSidecar, &container.Sidecar{}, &container.Provider{})
 	config.RegisterResource(copy.TypeCopy, &copy.Copy{}, &copy.Provider{})
+	config.RegisterResource(custom.TypeCustom, &custom.Custom{}, &custom.Provider{})
 	config.RegisterResource(docs.TypeDocs, &docs.Docs{}, &docs.DocsProvider{})
 	config.RegisterResource(docs.TypeChapter, &docs.Chapter{}, &null.Provider{})
 	config.RegisterResource(docs.TypeTask, &docs.Task{}, &null.Provider{})
@@ -45,22 +65,45 @@ func init() {
 	config.RegisterResource(ingress.TypeIngress, &ingress.Ingress{}, &ingress.Provider{})
 	config.RegisterResource(k8s.TypeK8sCluster, &k8s.Cluster{}, &k8s.ClusterProvider{})
 	config.RegisterResource(k8s.TypeK8sConfig, &k8s.Config{}, &k8s.ConfigProvider{})
+	config.RegisterResource(k8s.TypeK8sService, &k8s.Service{}, &k8s.ServiceProvider{})
+	config.RegisterResource(k8s.TypeK8sSecret, &k8s.Secret{}, &k8s.SecretProvider{})
 	// add alias for k8s
-	config.RegisterResource(k8s.TypeKubernetesCluster, &k8s.Cluster{}, &k8s.ClusterProvider{})
-	config.RegisterResource(k8s.TypeKubernetesConfig, &k8s.Config{}, &k8s.ConfigProvider{})
+	config.RegisterResourceAlias(k8s.TypeKubernetesCluster, k8s.TypeK8sCluster)
+	config.RegisterResourceAlias(k8s.TypeKubernetesConfig, k8s.TypeK8sConfig)
+	config.RegisterResourceAlias(k8s.TypeKubernetesService, k8s.TypeK8sService)
+	config.RegisterResourceAlias(k8s.TypeKubernetesSecret, k8s.TypeK8sSecret)
+
+	config.RegisterResource(mesh.TypeServiceMesh, &mesh.ServiceMesh{}, &mesh.Provider{})
+	config.RegisterResource(monitoring.TypeMonitoring, &monitoring.Monitoring{}, &monitoring.Provider{})
 
 	config.RegisterResource(network.TypeNetwork, &network.Network{}, &network.Provider{})
+	config.RegisterResource(network.TypeFirewallRule, &network.FirewallRule{}, &network.FirewallProvider{})
+	config.RegisterResource(network.TypeNetworkCondition, &network.NetworkCondition{}, &network.ConditionProvider{})
 	config.RegisterResource(nomad.TypeNomadCluster, &nomad.NomadCluster{}, &nomad.ClusterProvider{})
 	config.RegisterResource(nomad.TypeNomadJob, &nomad.NomadJob{}, &nomad.JobProvider{})
+	config.RegisterResource(nomad.TypeNomadAllocation, &nomad.NomadAllocation{}, &nomad.AllocationProvider{})
+	config.RegisterResource(nomad.TypeNomadService, &nomad.NomadService{}, &nomad.ServiceProvider{})
+	config.RegisterResource(nomad.TypeNomadVolume, &nomad.NomadVolume{}, &nomad.VolumeProvider{})
 	config.RegisterResource(ollama.TypeOllamaModel, &ollama.OllamaModel{}, &ollama.ModelProvider{})
 	config.RegisterResource(random.TypeRandomNumber, &random.RandomNumber{}, &random.RandomNumberProvider{})
 	config.RegisterResource(random.TypeRandomID, &random.RandomID{}, &random.RandomIDProvider{})
 	config.RegisterResource(random.TypeRandomUUID, &random.RandomUUID{}, &random.RandomUUIDProvider{})
 	config.RegisterResource(random.TypeRandomPassword, &random.RandomPassword{}, &random.RandomPasswordProvider{})
 	config.RegisterResource(random.TypeRandomCreature, &random.RandomCreature{}, &random.RandomCreatureProvider{})
+	config.RegisterResource(random.TypeRandomInteger, &random.RandomInteger{}, &random.RandomIntegerProvider{})
+	config.RegisterResource(random.TypeRandomBytes, &random.RandomBytes{}, &random.RandomBytesProvider{})
+	config.RegisterResource(random.TypeRandomPort, &random.RandomPort{}, &random.RandomPortProvider{})
 	config.RegisterResource(cache.TypeRegistry, &cache.Registry{}, &null.Provider{})
+	config.RegisterResource(requirements.TypeJumppad, &requirements.Jumppad{}, &null.Provider{})
+	config.RegisterResource(secret.TypeSecret, &secret.Secret{}, &secret.Provider{})
+	config.RegisterResource(secret.TypeEnvSecret, &secret.EnvSecret{}, &secret.EnvProvider{})
+	config.RegisterResource(secret.TypeOnePasswordSecret, &secret.OnePasswordSecret{}, &secret.OnePasswordProvider{})
+	config.RegisterResource(secret.TypeVaultSecret, &secret.VaultSecret{}, &secret.VaultProvider{})
+	config.RegisterResource(sync.TypeSync, &sync.Sync{}, &sync.Provider{})
 	config.RegisterResource(template.TypeTemplate, &template.Template{}, &template.TemplateProvider{})
+	config.RegisterResource(terminal.TypeTerminal, &terminal.Terminal{}, &terminal.Provider{})
 	config.RegisterResource(terraform.TypeTerraform, &terraform.Terraform{}, &terraform.TerraformProvider{})
+	config.RegisterResource(wait.TypeWait, &wait.Wait{}, &wait.Provider{})
 
 	// register providers for the default types
 	config.RegisterResource(resources.TypeModule, &resources.Module{}, &null.Provider{})
@@ -80,3 +123,53 @@ func PluginRegisterResource(name string, r types.Resource, p sdk.Provider) {
 func PluginLoadState() (sdk.Config, error) {
 	return config.LoadState()
 }
+
+// LoadPluginsOnUse defers LoadPlugins until a blueprint or the state is first
+// parsed, commands that do not parse configuration do not run the plugins
+func LoadPluginsOnUse(dir string, l logger.Logger) {
+	config.RegisterTypeLoader(func() {
+		err := LoadPlugins(dir, l)
+		if err != nil {
+			l.Error("Unable to load plugins", "error", err)
+		}
+	})
+}
+
+// LoadPlugins discovers the plugin executables in dir and registers the
+// resource types that they provide, plugins can not replace the built in
+// resource types or the types of another plugin, a plugin that provides a
+// type that is already registered is skipped
+func LoadPlugins(dir string, l logger.Logger) error {
+	ps, err := plugins.Discover(context.Background(), dir, l.StandardWriter())
+	if err != nil {
+		return err
+	}
+
+	for _, p := range ps {
+		names := []string{}
+		conflict := ""
+		for t := range p.Types {
+			if config.ResourceRegistered(t) {
+				conflict = t
+				break
+			}
+
+			names = append(names, t)
+		}
+
+		if conflict != "" {
+			l.Warn("Skipping plugin, the resource type is already registered", "name", p.Name, "path", p.Path, "type", conflict)
+			continue
+		}
+
+		plugins.Register(p)
+
+		for _, t := range names {
+			config.RegisterResource(t, &plugin.Resource{}, &plugin.Provider{})
+		}
+
+		l.Debug("Loaded plugin", "name", p.Name, "path", p.Path, "types", strings.Join(names, ","))
+	}
+
+	return nil
+}
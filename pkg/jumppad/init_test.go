@@ -0,0 +1,50 @@
+package jumppad
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/plugins"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTypePlugin(t *testing.T, resourceType string) string {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin tests require a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	script := fmt.Sprintf(`#!/bin/sh
+echo '{"types":{"%s":{"attributes":{"brokers":{"type":"number"}}}}}'
+`, resourceType)
+
+	err := os.WriteFile(filepath.Join(dir, plugins.Prefix+"test"), []byte(script), 0755)
+	require.NoError(t, err)
+
+	return dir
+}
+
+func TestLoadPluginsRegistersPluginTypes(t *testing.T) {
+	dir := setupTypePlugin(t, "init_test_cluster")
+
+	err := LoadPlugins(dir, logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	require.True(t, config.ResourceRegistered("init_test_cluster"))
+}
+
+func TestLoadPluginsSkipsPluginWithRegisteredType(t *testing.T) {
+	dir := setupTypePlugin(t, container.TypeContainer)
+
+	err := LoadPlugins(dir, logger.NewTestLogger(t))
+	require.NoError(t, err)
+
+	_, ok := plugins.Lookup(container.TypeContainer)
+	require.False(t, ok)
+}
@@ -0,0 +1,202 @@
+package jumppad
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+)
+
+// LockFileName is the name of the file that records the hashes of remote
+// blueprints and modules
+const LockFileName = ".jumppad.lock"
+
+const (
+	LockTypeBlueprint = "blueprint"
+	LockTypeModule    = "module"
+)
+
+const lockFileHeader = `# This file is generated by jumppad and records the hashes of the remote
+# blueprints and modules used by this configuration, do not edit it manually.
+`
+
+// lockIgnore are the files that are not included in the hash of a remote
+// source, go-getter keeps the .git folder for git sources
+var lockIgnore = []string{"*/.git"}
+
+// LockMismatchError is returned when the files for a remote source do not
+// match the hash recorded in the lock file
+type LockMismatchError struct {
+	Type     string
+	Source   string
+	Expected string
+	Got      string
+}
+
+func (e *LockMismatchError) Error() string {
+	return fmt.Sprintf("hash for %s %s does not match the lock file, expected %s, got %s", e.Type, e.Source, e.Expected, e.Got)
+}
+
+// LockFile records the dirhash of remote blueprints and modules so that
+// subsequent runs use exactly the same files
+type LockFile struct {
+	path    string
+	hashes  map[string]string
+	changed bool
+}
+
+// LoadLockFile loads the lock file at the given path, if the file does not
+// exist an empty lock file is returned
+func LoadLockFile(path string) (*LockFile, error) {
+	lf := &LockFile{path: path, hashes: map[string]string{}}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return lf, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to open lock file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.Fields(line)
+		if len(parts) != 3 || (parts[0] != LockTypeBlueprint && parts[0] != LockTypeModule) {
+			return nil, fmt.Errorf("invalid entry in lock file %s on line %d", path, n)
+		}
+
+		lf.hashes[parts[0]+" "+parts[1]] = parts[2]
+	}
+
+	if err := s.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read lock file %s: %w", path, err)
+	}
+
+	return lf, nil
+}
+
+// Verify hashes the folder dir containing the files for the remote source and
+// compares it to the hash in the lock file. If the source is not in the lock
+// file the hash is added.
+//
+// When the hashes do not match a LockMismatchError is returned, if update is
+// true the lock file is also updated with the new hash
+func (l *LockFile) Verify(lockType, source, dir string, update bool) error {
+	h, err := utils.HashDir(dir, lockIgnore...)
+	if err != nil {
+		return fmt.Errorf("unable to hash %s %s: %w", lockType, source, err)
+	}
+
+	key := lockType + " " + source
+
+	expected, ok := l.hashes[key]
+	if ok && expected == h {
+		return nil
+	}
+
+	if !ok || update {
+		l.hashes[key] = h
+		l.changed = true
+	}
+
+	if !ok {
+		return nil
+	}
+
+	return &LockMismatchError{Type: lockType, Source: source, Expected: expected, Got: h}
+}
+
+// Hash returns the recorded hash for the source
+func (l *LockFile) Hash(lockType, source string) string {
+	return l.hashes[lockType+" "+source]
+}
+
+// Save writes the lock file when entries have been added or updated
+func (l *LockFile) Save() error {
+	if !l.changed {
+		return nil
+	}
+
+	keys := []string{}
+	for k := range l.hashes {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	sb := strings.Builder{}
+	sb.WriteString(lockFileHeader)
+	sb.WriteString("\n")
+
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf("%s %s\n", k, l.hashes[k]))
+	}
+
+	err := os.WriteFile(l.path, []byte(sb.String()), 0644)
+	if err != nil {
+		return fmt.Errorf("unable to write lock file %s: %w", l.path, err)
+	}
+
+	l.changed = false
+
+	return nil
+}
+
+// RemoteModules returns a map of the source and download folder for every
+// module in the config that was fetched into the module cache at cacheDir
+func RemoteModules(c *hclconfig.Config, cacheDir string) map[string]string {
+	modules := map[string]string{}
+
+	if c == nil {
+		return modules
+	}
+
+	for _, r := range c.Resources {
+		m, ok := r.(*resources.Module)
+		if !ok {
+			continue
+		}
+
+		// resources defined in the module have the full module name
+		name := m.Meta.Name
+		if m.Meta.Module != "" {
+			name = m.Meta.Module + "." + name
+		}
+
+		for _, cr := range c.Resources {
+			if cr.Metadata().Module != name {
+				continue
+			}
+
+			rel, err := filepath.Rel(cacheDir, cr.Metadata().File)
+			if err != nil || strings.HasPrefix(rel, "..") {
+				continue
+			}
+
+			source := m.Source
+			if m.Version != "" && m.Version != "latest" {
+				source = fmt.Sprintf("%s@%s", source, m.Version)
+			}
+
+			// the module is downloaded to a sub folder of the cache
+			modules[source] = filepath.Join(cacheDir, strings.Split(filepath.ToSlash(rel), "/")[0])
+			break
+		}
+	}
+
+	return modules
+}
@@ -0,0 +1,153 @@
+package jumppad
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	"github.com/jumppad-labs/hclconfig/resources"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLockFile(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "module")
+
+	os.MkdirAll(filepath.Join(src, ".git"), os.ModePerm)
+	os.WriteFile(filepath.Join(src, "main.hcl"), []byte(`resource "network" "main" {}`), 0644)
+	os.WriteFile(filepath.Join(src, ".git", "HEAD"), []byte(`ref: refs/heads/main`), 0644)
+
+	return filepath.Join(dir, LockFileName), src
+}
+
+func TestLoadLockFileMissingReturnsEmpty(t *testing.T) {
+	lf, err := LoadLockFile(filepath.Join(t.TempDir(), LockFileName))
+	require.NoError(t, err)
+	require.Empty(t, lf.hashes)
+}
+
+func TestLoadLockFileInvalidReturnsError(t *testing.T) {
+	p := filepath.Join(t.TempDir(), LockFileName)
+	os.WriteFile(p, []byte("container github.com/org/module h1:abc="), 0644)
+
+	_, err := LoadLockFile(p)
+	require.ErrorContains(t, err, "line 1")
+}
+
+func TestVerifyAddsNewSourceAndSaves(t *testing.T) {
+	p, src := setupLockFile(t)
+
+	lf, _ := LoadLockFile(p)
+	err := lf.Verify(LockTypeModule, "github.com/org/module", src, false)
+	require.NoError(t, err)
+
+	err = lf.Save()
+	require.NoError(t, err)
+
+	lf2, err := LoadLockFile(p)
+	require.NoError(t, err)
+	require.Equal(t, lf.Hash(LockTypeModule, "github.com/org/module"), lf2.Hash(LockTypeModule, "github.com/org/module"))
+	require.Contains(t, lf2.Hash(LockTypeModule, "github.com/org/module"), "h1:")
+}
+
+func TestVerifyIgnoresGitFolder(t *testing.T) {
+	p, src := setupLockFile(t)
+
+	lf, _ := LoadLockFile(p)
+	lf.Verify(LockTypeModule, "github.com/org/module", src, false)
+
+	os.WriteFile(filepath.Join(src, ".git", "HEAD"), []byte(`ref: refs/heads/other`), 0644)
+
+	err := lf.Verify(LockTypeModule, "github.com/org/module", src, false)
+	require.NoError(t, err)
+}
+
+func TestVerifyChangedSourceReturnsMismatch(t *testing.T) {
+	p, src := setupLockFile(t)
+
+	lf, _ := LoadLockFile(p)
+	lf.Verify(LockTypeModule, "github.com/org/module", src, false)
+	lf.Save()
+
+	os.WriteFile(filepath.Join(src, "main.hcl"), []byte(`resource "network" "changed" {}`), 0644)
+
+	lf, _ = LoadLockFile(p)
+	expected := lf.Hash(LockTypeModule, "github.com/org/module")
+
+	err := lf.Verify(LockTypeModule, "github.com/org/module", src, false)
+
+	me := &LockMismatchError{}
+	require.ErrorAs(t, err, &me)
+	require.Equal(t, expected, me.Expected)
+	require.Equal(t, expected, lf.Hash(LockTypeModule, "github.com/org/module"))
+	require.False(t, lf.changed)
+}
+
+func TestVerifyChangedSourceInIgnoreFileReturnsMismatch(t *testing.T) {
+	p, src := setupLockFile(t)
+	os.WriteFile(filepath.Join(src, ".jumppadignore"), []byte("*\n"), 0644)
+
+	lf, _ := LoadLockFile(p)
+	lf.Verify(LockTypeModule, "github.com/org/module", src, false)
+
+	os.WriteFile(filepath.Join(src, "main.hcl"), []byte(`resource "network" "changed" {}`), 0644)
+
+	err := lf.Verify(LockTypeModule, "github.com/org/module", src, false)
+
+	me := &LockMismatchError{}
+	require.ErrorAs(t, err, &me)
+}
+
+func TestVerifyChangedSourceWithUpdateReplacesHash(t *testing.T) {
+	p, src := setupLockFile(t)
+
+	lf, _ := LoadLockFile(p)
+	lf.Verify(LockTypeModule, "github.com/org/module", src, false)
+	lf.Save()
+
+	os.WriteFile(filepath.Join(src, "main.hcl"), []byte(`resource "network" "changed" {}`), 0644)
+
+	err := lf.Verify(LockTypeModule, "github.com/org/module", src, true)
+
+	me := &LockMismatchError{}
+	require.ErrorAs(t, err, &me)
+	require.Equal(t, me.Got, lf.Hash(LockTypeModule, "github.com/org/module"))
+	require.True(t, lf.changed)
+}
+
+func TestRemoteModulesReturnsCachedModules(t *testing.T) {
+	cache := t.TempDir()
+
+	c := hclconfig.NewConfig()
+
+	remote := &resources.Module{Source: "github.com/org/modules//consul", Version: "latest"}
+	remote.Meta = types.Meta{Name: "consul", Type: resources.TypeModule}
+
+	nested := &resources.Module{Source: "registry.jumppad.dev/org/vault", Version: "0.1.0"}
+	nested.Meta = types.Meta{Name: "vault", Type: resources.TypeModule, Module: "consul", File: filepath.Join(cache, "consul_src", "main.hcl")}
+
+	local := &resources.Module{Source: "./modules/local"}
+	local.Meta = types.Meta{Name: "local", Type: resources.TypeModule}
+
+	c1 := &container.Container{}
+	c1.Meta = types.Meta{Name: "consul", Type: container.TypeContainer, Module: "consul", File: filepath.Join(cache, "consul_src", "main.hcl")}
+
+	c2 := &container.Container{}
+	c2.Meta = types.Meta{Name: "vault", Type: container.TypeContainer, Module: "consul.vault", File: filepath.Join(cache, "vault_src", "sub", "main.hcl")}
+
+	c3 := &container.Container{}
+	c3.Meta = types.Meta{Name: "local", Type: container.TypeContainer, Module: "local", File: "/blueprint/modules/local/main.hcl"}
+
+	for _, r := range []types.Resource{remote, nested, local, c1, c2, c3} {
+		c.Resources = append(c.Resources, r)
+	}
+
+	m := RemoteModules(c, cache)
+	require.Equal(t, map[string]string{
+		"github.com/org/modules//consul":       filepath.Join(cache, "consul_src"),
+		"registry.jumppad.dev/org/vault@0.1.0": filepath.Join(cache, "vault_src"),
+	}, m)
+}
@@ -5,8 +5,12 @@ package mocks
 import (
 	context "context"
 
+	events "github.com/jumppad-labs/jumppad/pkg/clients/events"
+
 	hclconfig "github.com/jumppad-labs/hclconfig"
 
+	jumppad "github.com/jumppad-labs/jumppad/pkg/jumppad"
+
 	mock "github.com/stretchr/testify/mock"
 
 	types "github.com/jumppad-labs/hclconfig/types"
@@ -43,6 +47,32 @@ func (_m *Engine) Apply(_a0 context.Context, _a1 string) (*hclconfig.Config, err
 	return r0, r1
 }
 
+// ApplyTargets provides a mock function with given fields: ctx, path, variables, variablesFile, targets
+func (_m *Engine) ApplyTargets(ctx context.Context, path string, variables map[string]string, variablesFile string, targets []string) (*hclconfig.Config, error) {
+	ret := _m.Called(ctx, path, variables, variablesFile, targets)
+
+	var r0 *hclconfig.Config
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, string, []string) (*hclconfig.Config, error)); ok {
+		return rf(ctx, path, variables, variablesFile, targets)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]string, string, []string) *hclconfig.Config); ok {
+		r0 = rf(ctx, path, variables, variablesFile, targets)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*hclconfig.Config)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, map[string]string, string, []string) error); ok {
+		r1 = rf(ctx, path, variables, variablesFile, targets)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ApplyWithVariables provides a mock function with given fields: ctx, path, variables, variablesFile
 func (_m *Engine) ApplyWithVariables(ctx context.Context, path string, variables map[string]string, variablesFile string) (*hclconfig.Config, error) {
 	ret := _m.Called(ctx, path, variables, variablesFile)
@@ -99,6 +129,20 @@ func (_m *Engine) Destroy(ctx context.Context, force bool) error {
 	return r0
 }
 
+// Down provides a mock function with given fields: ctx
+func (_m *Engine) Down(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Diff provides a mock function with given fields: path, variables, variablesFile
 func (_m *Engine) Diff(path string, variables map[string]string, variablesFile string) ([]types.Resource, []types.Resource, []types.Resource, *hclconfig.Config, error) {
 	ret := _m.Called(path, variables, variablesFile)
@@ -204,6 +248,72 @@ func (_m *Engine) ParseConfigWithVariables(_a0 string, _a1 map[string]string, _a
 	return r0, r1
 }
 
+// Results provides a mock function with given fields:
+func (_m *Engine) Results() []jumppad.ResourceResult {
+	ret := _m.Called()
+
+	var r0 []jumppad.ResourceResult
+	if rf, ok := ret.Get(0).(func() []jumppad.ResourceResult); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]jumppad.ResourceResult)
+		}
+	}
+
+	return r0
+}
+
+// Rollback provides a mock function with given fields: ctx, previous
+func (_m *Engine) Rollback(ctx context.Context, previous *hclconfig.Config) error {
+	ret := _m.Called(ctx, previous)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *hclconfig.Config) error); ok {
+		r0 = rf(ctx, previous)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetEventBus provides a mock function with given fields: b
+func (_m *Engine) SetEventBus(b *events.Bus) {
+	_m.Called(b)
+}
+
+// SetProgressHandler provides a mock function with given fields: h
+func (_m *Engine) SetProgressHandler(h jumppad.ProgressHandler) {
+	_m.Called(h)
+}
+
+// Up provides a mock function with given fields: ctx, path
+func (_m *Engine) Up(ctx context.Context, path string) (*hclconfig.Config, error) {
+	ret := _m.Called(ctx, path)
+
+	var r0 *hclconfig.Config
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*hclconfig.Config, error)); ok {
+		return rf(ctx, path)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *hclconfig.Config); ok {
+		r0 = rf(ctx, path)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*hclconfig.Config)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, path)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewEngine interface {
 	mock.TestingT
 	Cleanup(func())
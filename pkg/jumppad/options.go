@@ -0,0 +1,102 @@
+package jumppad
+
+import (
+	"github.com/jumppad-labs/jumppad/pkg/clients/events"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config"
+)
+
+// Option configures an engine created with New
+type Option func(*options)
+
+type options struct {
+	providers     config.Providers
+	log           logger.Logger
+	progress      ProgressHandler
+	sinks         []events.Sink
+	eventTypes    []string
+	variables     map[string]string
+	variablesFile string
+	force         bool
+}
+
+// WithProviders sets the providers used to create and destroy resources,
+// when not set the providers use the clients for the local container runtime
+func WithProviders(p config.Providers) Option {
+	return func(o *options) {
+		o.providers = p
+	}
+}
+
+// WithLogger sets the logger for the engine and its providers, when not set
+// log messages are discarded
+func WithLogger(l logger.Logger) Option {
+	return func(o *options) {
+		o.log = l
+	}
+}
+
+// WithProgressHandler sets a function that is called when the engine starts
+// and finishes processing each resource
+func WithProgressHandler(h ProgressHandler) Option {
+	return func(o *options) {
+		o.progress = h
+	}
+}
+
+// WithEventHandler adds a function that is called with each resource and
+// engine lifecycle event, the handler is called synchronously so it should
+// return quickly
+func WithEventHandler(h func(events.Event)) Option {
+	return func(o *options) {
+		o.sinks = append(o.sinks, eventHandler(h))
+	}
+}
+
+// WithEventSinks adds sinks that resource and engine lifecycle events are
+// published to
+func WithEventSinks(s ...events.Sink) Option {
+	return func(o *options) {
+		o.sinks = append(o.sinks, s...)
+	}
+}
+
+// WithEventTypes limits the events sent to the event handlers and sinks, a
+// type ending in * matches any event with that prefix e.g. resource.create.*
+func WithEventTypes(t ...string) Option {
+	return func(o *options) {
+		o.eventTypes = append(o.eventTypes, t...)
+	}
+}
+
+// WithVariables sets the variables used by Up, the variables override the
+// default values in the blueprint
+func WithVariables(v map[string]string) Option {
+	return func(o *options) {
+		o.variables = v
+	}
+}
+
+// WithVariablesFile sets the path of a file containing the variables used by
+// Up
+func WithVariablesFile(path string) Option {
+	return func(o *options) {
+		o.variablesFile = path
+	}
+}
+
+// WithForce destroys containers without waiting for a graceful shutdown
+// when Down is called
+func WithForce(force bool) Option {
+	return func(o *options) {
+		o.force = force
+	}
+}
+
+// eventHandler adapts a function to the events.Sink interface
+type eventHandler func(events.Event)
+
+func (h eventHandler) Send(e events.Event) error {
+	h(e)
+	return nil
+}
@@ -0,0 +1,79 @@
+package jumppad
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/events"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/config/mocks"
+	"github.com/jumppad-labs/jumppad/testutils"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupOptionsTests(t *testing.T, opts ...Option) (*EngineImpl, *mocks.Providers) {
+	pm := mocks.NewProviders(nil)
+	pm.On("GetProvider", mock.Anything)
+
+	testutils.SetupState(t, "")
+
+	opts = append([]Option{WithProviders(pm), WithLogger(logger.NewTestLogger(t))}, opts...)
+
+	e, err := New(opts...)
+	require.NoError(t, err)
+
+	return e.(*EngineImpl), pm
+}
+
+func TestNewSetsOptions(t *testing.T) {
+	e, pm := setupOptionsTests(t,
+		WithVariables(map[string]string{"version": "1.8.1"}),
+		WithVariablesFile("./default.vars"),
+		WithForce(true),
+	)
+
+	require.Equal(t, pm, e.providers)
+	require.Equal(t, map[string]string{"version": "1.8.1"}, e.variables)
+	require.Equal(t, "./default.vars", e.variablesFile)
+	require.True(t, e.forceDestroy)
+	require.Nil(t, e.eventBus)
+}
+
+func TestNewWithProgressHandlerCallsHandler(t *testing.T) {
+	m := sync.Mutex{}
+	phases := []string{}
+
+	e, _ := setupOptionsTests(t, WithProgressHandler(func(r ResourceResult) {
+		m.Lock()
+		defer m.Unlock()
+
+		phases = append(phases, r.Status)
+	}))
+
+	_, err := e.Up(context.Background(), "../../examples/single_file/container.hcl")
+	require.NoError(t, err)
+
+	require.Contains(t, phases, PhaseCreating)
+}
+
+func TestNewWithEventHandlerCallsHandler(t *testing.T) {
+	m := sync.Mutex{}
+	received := []string{}
+
+	e, _ := setupOptionsTests(t,
+		WithEventHandler(func(ev events.Event) {
+			m.Lock()
+			defer m.Unlock()
+
+			received = append(received, ev.Type)
+		}),
+		WithEventTypes("engine.*"),
+	)
+
+	_, err := e.Up(context.Background(), "../../examples/single_file/container.hcl")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{events.TypeEngineUpStart, events.TypeEngineUpComplete}, received)
+}
@@ -0,0 +1,205 @@
+package jumppad
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/jumppad-labs/hclconfig"
+	hclerrors "github.com/jumppad-labs/hclconfig/errors"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/nomad"
+)
+
+// hostPort is a port on the local machine that is used by a resource
+type hostPort struct {
+	port     string
+	protocol string
+}
+
+// Validate checks a parsed configuration for problems that the parser does
+// not detect, such as host ports that are used by more than one resource and
+// invalid image names.
+//
+// Problems are returned as a ConfigError containing a ParserError for each
+// problem so that they can be reported with the file and line of the resource.
+// Values that are set from the outputs of other resources are not known until
+// the resources are created and are not checked.
+func Validate(c *hclconfig.Config) error {
+	ce := hclerrors.NewConfigError()
+
+	used := map[hostPort][]types.Resource{}
+
+	for _, r := range c.Resources {
+		if r.GetDisabled() {
+			continue
+		}
+
+		for _, i := range resourceImages(r) {
+			if i == "" {
+				continue
+			}
+
+			if _, err := reference.ParseNormalizedNamed(i); err != nil {
+				ce.AppendError(validationError(r, fmt.Sprintf(`invalid image name "%s", %s`, i, err)))
+			}
+		}
+
+		ports, err := resourceHostPorts(r)
+		if err != nil {
+			ce.AppendError(validationError(r, err.Error()))
+			continue
+		}
+
+		for _, p := range ports {
+			used[p] = append(used[p], r)
+		}
+	}
+
+	// sort the ports so that the errors are always returned in the same order
+	keys := []hostPort{}
+	for k := range used {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].port+keys[i].protocol < keys[j].port+keys[j].protocol
+	})
+
+	for _, k := range keys {
+		rs := used[k]
+		if len(rs) < 2 {
+			continue
+		}
+
+		ids := []string{}
+		for _, r := range rs {
+			ids = append(ids, r.Metadata().ID)
+		}
+
+		for _, r := range rs {
+			ce.AppendError(validationError(r, fmt.Sprintf("host port %s/%s is used by more than one resource: %s", k.port, k.protocol, strings.Join(ids, ", "))))
+		}
+	}
+
+	if len(ce.Errors) > 0 {
+		return ce
+	}
+
+	return nil
+}
+
+func validationError(r types.Resource, message string) *hclerrors.ParserError {
+	pe := &hclerrors.ParserError{}
+	pe.Filename = r.Metadata().File
+	pe.Line = r.Metadata().Line
+	pe.Column = r.Metadata().Column
+	pe.Message = message
+	pe.Level = hclerrors.ParserErrorLevelError
+
+	return pe
+}
+
+// resourceImages returns the names of the images used by the resource
+func resourceImages(r types.Resource) []string {
+	switch v := r.(type) {
+	case *container.Container:
+		return []string{v.Image.Name}
+	case *container.Sidecar:
+		return []string{v.Image.Name}
+	case *k8s.Cluster:
+		if v.Image != nil {
+			return []string{v.Image.Name}
+		}
+	case *nomad.NomadCluster:
+		if v.Image != nil {
+			return []string{v.Image.Name}
+		}
+	}
+
+	return nil
+}
+
+// resourceHostPorts returns the ports on the local machine that are used by
+// the resource, ports that are not set are ignored
+func resourceHostPorts(r types.Resource) ([]hostPort, error) {
+	switch v := r.(type) {
+	case *container.Container:
+		return containerHostPorts(v.Ports, v.PortRanges)
+	case *k8s.Cluster:
+		return containerHostPorts(v.Ports, nil)
+	case *nomad.NomadCluster:
+		return containerHostPorts(v.Ports, v.PortRanges)
+	case *ingress.Ingress:
+		if len(v.Ports) == 0 {
+			return withPorts(nil, []int{v.Port}, "tcp"), nil
+		}
+
+		ports, err := ingress.ParsePorts(v.Ports)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range ports {
+			ports[i] = ports[i] + v.LocalPortOffset
+		}
+
+		return withPorts(nil, ports, "tcp"), nil
+	case *docs.Docs:
+		return withPorts(nil, []int{v.Port}, "tcp"), nil
+	}
+
+	return nil, nil
+}
+
+func containerHostPorts(ports []container.Port, ranges []container.PortRange) ([]hostPort, error) {
+	out := []hostPort{}
+
+	for _, p := range ports {
+		if p.Host == "" || p.Host == "0" {
+			continue
+		}
+
+		out = append(out, hostPort{port: p.Host, protocol: portProtocol(p.Protocol)})
+	}
+
+	for _, pr := range ranges {
+		if !pr.EnableHost {
+			continue
+		}
+
+		ports, err := ingress.ParsePorts([]string{pr.Range})
+		if err != nil {
+			return nil, err
+		}
+
+		out = withPorts(out, ports, portProtocol(pr.Protocol))
+	}
+
+	return out, nil
+}
+
+func withPorts(out []hostPort, ports []int, protocol string) []hostPort {
+	for _, p := range ports {
+		if p == 0 {
+			continue
+		}
+
+		out = append(out, hostPort{port: fmt.Sprintf("%d", p), protocol: protocol})
+	}
+
+	return out
+}
+
+func portProtocol(p string) string {
+	if p == "" {
+		return "tcp"
+	}
+
+	return strings.ToLower(p)
+}
@@ -0,0 +1,103 @@
+package jumppad
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/hclconfig"
+	hclerrors "github.com/jumppad-labs/hclconfig/errors"
+	"github.com/jumppad-labs/hclconfig/types"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/container"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/ingress"
+	"github.com/stretchr/testify/require"
+)
+
+func setupValidateContainer(name, image string, ports ...container.Port) *container.Container {
+	c := &container.Container{Image: container.Image{Name: image}, Ports: ports}
+	c.Meta = types.Meta{ID: "resource.container." + name, Name: name, Type: container.TypeContainer, File: "/blueprint/main.hcl", Line: 10, Column: 1}
+
+	return c
+}
+
+func TestValidateValidConfigReturnsNoError(t *testing.T) {
+	c := hclconfig.NewConfig()
+	c.Resources = append(c.Resources,
+		setupValidateContainer("one", "consul:1.10.6", container.Port{Local: "8500", Host: "8500"}),
+		setupValidateContainer("two", "ghcr.io/org/app@sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a", container.Port{Local: "8500", Host: "8501"}),
+	)
+
+	err := Validate(c)
+	require.NoError(t, err)
+}
+
+func TestValidateInvalidImageReturnsError(t *testing.T) {
+	c := hclconfig.NewConfig()
+	c.Resources = append(c.Resources, setupValidateContainer("one", "Consul:latest"))
+
+	err := Validate(c)
+	require.Error(t, err)
+
+	ce := err.(*hclerrors.ConfigError)
+	require.Len(t, ce.Errors, 1)
+
+	pe := ce.Errors[0].(*hclerrors.ParserError)
+	require.Equal(t, "/blueprint/main.hcl", pe.Filename)
+	require.Equal(t, 10, pe.Line)
+	require.Contains(t, pe.Message, `invalid image name "Consul:latest"`)
+}
+
+func TestValidateHostPortCollisionReturnsErrorForEachResource(t *testing.T) {
+	i := &ingress.Ingress{Port: 8500}
+	i.Meta = types.Meta{ID: "resource.ingress.consul", Name: "consul", Type: ingress.TypeIngress}
+
+	c := hclconfig.NewConfig()
+	c.Resources = append(c.Resources,
+		setupValidateContainer("one", "consul:1.10.6", container.Port{Local: "8500", Host: "8500"}),
+		i,
+	)
+
+	err := Validate(c)
+	require.Error(t, err)
+
+	ce := err.(*hclerrors.ConfigError)
+	require.Len(t, ce.Errors, 2)
+	require.Contains(t, ce.Errors[0].(*hclerrors.ParserError).Message, "host port 8500/tcp is used by more than one resource: resource.container.one, resource.ingress.consul")
+}
+
+func TestValidateSamePortDifferentProtocolReturnsNoError(t *testing.T) {
+	c := hclconfig.NewConfig()
+	c.Resources = append(c.Resources,
+		setupValidateContainer("one", "consul:1.10.6", container.Port{Local: "53", Host: "53", Protocol: "tcp"}),
+		setupValidateContainer("two", "consul:1.10.6", container.Port{Local: "53", Host: "53", Protocol: "udp"}),
+	)
+
+	err := Validate(c)
+	require.NoError(t, err)
+}
+
+func TestValidateIgnoresDisabledResources(t *testing.T) {
+	d := setupValidateContainer("two", "Invalid", container.Port{Local: "8500", Host: "8500"})
+	d.Disabled = true
+
+	c := hclconfig.NewConfig()
+	c.Resources = append(c.Resources,
+		setupValidateContainer("one", "consul:1.10.6", container.Port{Local: "8500", Host: "8500"}),
+		d,
+	)
+
+	err := Validate(c)
+	require.NoError(t, err)
+}
+
+func TestValidatePortRangeCollisionReturnsError(t *testing.T) {
+	one := setupValidateContainer("one", "consul:1.10.6")
+	one.PortRanges = []container.PortRange{{Range: "8500-8502", EnableHost: true}}
+
+	c := hclconfig.NewConfig()
+	c.Resources = append(c.Resources,
+		one,
+		setupValidateContainer("two", "consul:1.10.6", container.Port{Local: "8500", Host: "8502"}),
+	)
+
+	err := Validate(c)
+	require.ErrorContains(t, err, "host port 8502/tcp")
+}
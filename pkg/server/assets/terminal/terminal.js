@@ -0,0 +1,641 @@
+// Terminal renders the output of a shell in the browser. It implements the
+// control sequences used by shells and most command line tools for an xterm
+// compatible terminal, it is served with the connector so that the page does
+// not load any code from a third party.
+(function () {
+  "use strict";
+
+  const palette = [
+    "#000000", "#cd0000", "#00cd00", "#cdcd00", "#0000ee", "#cd00cd", "#00cdcd", "#e5e5e5",
+    "#7f7f7f", "#ff0000", "#00ff00", "#ffff00", "#5c5cff", "#ff00ff", "#00ffff", "#ffffff",
+  ];
+
+  // color returns the css color for an entry in the 256 color palette
+  const color = (n) => {
+    if (n < 16) return palette[n];
+
+    if (n < 232) {
+      const v = [0, 95, 135, 175, 215, 255];
+      n -= 16;
+      return `rgb(${v[Math.floor(n / 36)]},${v[Math.floor(n / 6) % 6]},${v[n % 6]})`;
+    }
+
+    const g = 8 + (n - 232) * 10;
+    return `rgb(${g},${g},${g})`;
+  };
+
+  const defaultStyle = () => ({ fg: null, bg: null, bold: false, dim: false, italic: false, underline: false, inverse: false });
+
+  const escapeHTML = (s) => s.replace(/&/g, "&amp;").replace(/</g, "&lt;").replace(/>/g, "&gt;");
+
+  const maxScrollback = 1000;
+
+  class Terminal {
+    constructor(el) {
+      this.el = el;
+      this.rows = 24;
+      this.cols = 80;
+      this.onData = () => {};
+      this.onResize = () => {};
+      this.decoder = new TextDecoder();
+      this.scrollback = [];
+      this.pending = false;
+
+      this.reset();
+      this.listen();
+    }
+
+    reset() {
+      this.style = defaultStyle();
+      this.x = 0;
+      this.y = 0;
+      this.wrap = false;
+      this.top = 0;
+      this.bottom = this.rows - 1;
+      this.saved = { x: 0, y: 0, style: defaultStyle() };
+      this.cursorVisible = true;
+      this.appCursor = false;
+      this.autowrap = true;
+      this.alt = null;
+      this.state = "ground";
+      this.params = "";
+      this.lines = [];
+
+      for (let i = 0; i < this.rows; i++) this.lines.push(this.blankLine());
+    }
+
+    blankCell() {
+      return { ch: " ", style: Object.assign({}, this.style) };
+    }
+
+    blankLine() {
+      const l = [];
+      for (let i = 0; i < this.cols; i++) l.push(this.blankCell());
+      return l;
+    }
+
+    // fit resizes the terminal to the size of the element
+    fit() {
+      const m = document.createElement("span");
+      m.className = "measure";
+      m.textContent = "W".repeat(10);
+      this.el.appendChild(m);
+      const r = m.getBoundingClientRect();
+      this.el.removeChild(m);
+
+      const cs = getComputedStyle(this.el);
+      const width = this.el.clientWidth - parseFloat(cs.paddingLeft) - parseFloat(cs.paddingRight);
+      const height = this.el.clientHeight - parseFloat(cs.paddingTop) - parseFloat(cs.paddingBottom);
+      const lineHeight = parseFloat(cs.lineHeight) || r.height;
+
+      this.resize(Math.max(Math.floor(height / lineHeight), 1), Math.max(Math.floor(width / (r.width / 10)), 1));
+    }
+
+    resize(rows, cols) {
+      if (rows === this.rows && cols === this.cols) return;
+
+      const resizeLines = (lines) => {
+        for (const l of lines) {
+          while (l.length < cols) l.push(this.blankCell());
+          l.length = cols;
+        }
+      };
+
+      this.cols = cols;
+      resizeLines(this.lines);
+
+      // rows removed from the top of the screen are kept in the scrollback
+      while (this.lines.length > rows) {
+        const l = this.lines.shift();
+        if (!this.alt) this.scrollback.push(l);
+        this.y = Math.max(this.y - 1, 0);
+      }
+
+      while (this.lines.length < rows) this.lines.push(this.blankLine());
+
+      if (this.alt) {
+        resizeLines(this.alt.lines);
+        while (this.alt.lines.length > rows) this.alt.lines.shift();
+        while (this.alt.lines.length < rows) this.alt.lines.push(this.blankLine());
+      }
+
+      this.rows = rows;
+      this.top = 0;
+      this.bottom = rows - 1;
+      this.x = Math.min(this.x, cols - 1);
+      this.y = Math.min(this.y, rows - 1);
+      this.wrap = false;
+
+      this.onResize(rows, cols);
+      this.render();
+    }
+
+    // write parses data received from the shell
+    write(data) {
+      const s = typeof data === "string" ? data : this.decoder.decode(data, { stream: true });
+
+      for (const ch of s) this.parse(ch);
+
+      this.render();
+    }
+
+    parse(ch) {
+      const c = ch.codePointAt(0);
+
+      switch (this.state) {
+        case "escape":
+          this.escape(ch);
+          return;
+        case "csi":
+          if (c >= 0x40 && c <= 0x7e) {
+            this.state = "ground";
+            this.csi(ch, this.params);
+          } else {
+            this.params += ch;
+          }
+          return;
+        case "osc":
+          if (ch === "\x07") {
+            this.osc(this.params);
+            this.state = "ground";
+          } else if (ch === "\x1b") {
+            this.osc(this.params);
+            this.state = "escape";
+          } else {
+            this.params += ch;
+          }
+          return;
+        case "charset":
+          this.state = "ground";
+          return;
+      }
+
+      switch (ch) {
+        case "\x1b":
+          this.state = "escape";
+          return;
+        case "\r":
+          this.x = 0;
+          this.wrap = false;
+          return;
+        case "\n":
+        case "\x0b":
+        case "\x0c":
+          this.lineFeed();
+          return;
+        case "\b":
+          if (this.x > 0) this.x--;
+          this.wrap = false;
+          return;
+        case "\t":
+          this.x = Math.min((Math.floor(this.x / 8) + 1) * 8, this.cols - 1);
+          return;
+        case "\x07":
+        case "\x00":
+        case "\x0e":
+        case "\x0f":
+          return;
+      }
+
+      if (c < 0x20 || c === 0x7f) return;
+
+      this.print(ch);
+    }
+
+    print(ch) {
+      if (this.wrap) {
+        this.x = 0;
+        this.lineFeed();
+      }
+
+      this.lines[this.y][this.x] = { ch: ch, style: Object.assign({}, this.style) };
+
+      if (this.x < this.cols - 1) {
+        this.x++;
+      } else if (this.autowrap) {
+        this.wrap = true;
+      }
+    }
+
+    lineFeed() {
+      this.wrap = false;
+
+      if (this.y === this.bottom) {
+        this.scrollUp(1);
+      } else if (this.y < this.rows - 1) {
+        this.y++;
+      }
+    }
+
+    scrollUp(n) {
+      for (let i = 0; i < n; i++) {
+        const l = this.lines.splice(this.top, 1)[0];
+        if (!this.alt && this.top === 0) {
+          this.scrollback.push(l);
+          if (this.scrollback.length > maxScrollback) this.scrollback.shift();
+        }
+
+        this.lines.splice(this.bottom, 0, this.blankLine());
+      }
+    }
+
+    scrollDown(n) {
+      for (let i = 0; i < n; i++) {
+        this.lines.splice(this.bottom, 1);
+        this.lines.splice(this.top, 0, this.blankLine());
+      }
+    }
+
+    escape(ch) {
+      this.state = "ground";
+
+      switch (ch) {
+        case "[":
+          this.state = "csi";
+          this.params = "";
+          break;
+        case "]":
+          this.state = "osc";
+          this.params = "";
+          break;
+        case "(":
+        case ")":
+          this.state = "charset";
+          break;
+        case "7":
+          this.saveCursor();
+          break;
+        case "8":
+          this.restoreCursor();
+          break;
+        case "D":
+          this.lineFeed();
+          break;
+        case "E":
+          this.x = 0;
+          this.lineFeed();
+          break;
+        case "M":
+          if (this.y === this.top) {
+            this.scrollDown(1);
+          } else if (this.y > 0) {
+            this.y--;
+          }
+          break;
+        case "c":
+          this.reset();
+          break;
+      }
+    }
+
+    // osc sets the title of the page
+    osc(params) {
+      const i = params.indexOf(";");
+      const code = params.substring(0, i);
+
+      if (code === "0" || code === "2") document.title = params.substring(i + 1);
+    }
+
+    saveCursor() {
+      this.saved = { x: this.x, y: this.y, style: Object.assign({}, this.style) };
+    }
+
+    restoreCursor() {
+      this.x = Math.min(this.saved.x, this.cols - 1);
+      this.y = Math.min(this.saved.y, this.rows - 1);
+      this.style = Object.assign({}, this.saved.style);
+      this.wrap = false;
+    }
+
+    csi(final, raw) {
+      const priv = raw.startsWith("?");
+      const p = (priv ? raw.substring(1) : raw).split(";").map((v) => parseInt(v, 10));
+      const n = (i, d) => (isNaN(p[i]) || p[i] === 0 ? d : p[i]);
+      const clampX = (x) => Math.max(0, Math.min(x, this.cols - 1));
+      const clampY = (y) => Math.max(0, Math.min(y, this.rows - 1));
+
+      this.wrap = false;
+
+      switch (final) {
+        case "A":
+          this.y = Math.max(this.y - n(0, 1), this.y >= this.top ? this.top : 0);
+          break;
+        case "B":
+          this.y = Math.min(this.y + n(0, 1), this.y <= this.bottom ? this.bottom : this.rows - 1);
+          break;
+        case "C":
+          this.x = clampX(this.x + n(0, 1));
+          break;
+        case "D":
+          this.x = clampX(this.x - n(0, 1));
+          break;
+        case "E":
+          this.x = 0;
+          this.y = clampY(this.y + n(0, 1));
+          break;
+        case "F":
+          this.x = 0;
+          this.y = clampY(this.y - n(0, 1));
+          break;
+        case "G":
+        case "`":
+          this.x = clampX(n(0, 1) - 1);
+          break;
+        case "H":
+        case "f":
+          this.y = clampY(n(0, 1) - 1);
+          this.x = clampX(n(1, 1) - 1);
+          break;
+        case "d":
+          this.y = clampY(n(0, 1) - 1);
+          break;
+        case "J":
+          this.eraseDisplay(isNaN(p[0]) ? 0 : p[0]);
+          break;
+        case "K":
+          this.eraseLine(isNaN(p[0]) ? 0 : p[0]);
+          break;
+        case "L":
+          if (this.y >= this.top && this.y <= this.bottom) {
+            for (let i = 0; i < n(0, 1); i++) {
+              this.lines.splice(this.bottom, 1);
+              this.lines.splice(this.y, 0, this.blankLine());
+            }
+          }
+          break;
+        case "M":
+          if (this.y >= this.top && this.y <= this.bottom) {
+            for (let i = 0; i < n(0, 1); i++) {
+              this.lines.splice(this.y, 1);
+              this.lines.splice(this.bottom, 0, this.blankLine());
+            }
+          }
+          break;
+        case "P": {
+          const l = this.lines[this.y];
+          l.splice(this.x, Math.min(n(0, 1), this.cols - this.x));
+          while (l.length < this.cols) l.push(this.blankCell());
+          break;
+        }
+        case "@": {
+          const l = this.lines[this.y];
+          for (let i = 0; i < n(0, 1); i++) l.splice(this.x, 0, this.blankCell());
+          l.length = this.cols;
+          break;
+        }
+        case "X":
+          for (let i = this.x; i < Math.min(this.x + n(0, 1), this.cols); i++) this.lines[this.y][i] = this.blankCell();
+          break;
+        case "S":
+          this.scrollUp(n(0, 1));
+          break;
+        case "T":
+          this.scrollDown(n(0, 1));
+          break;
+        case "r":
+          this.top = clampY(n(0, 1) - 1);
+          this.bottom = clampY(n(1, this.rows) - 1);
+          if (this.top >= this.bottom) {
+            this.top = 0;
+            this.bottom = this.rows - 1;
+          }
+          this.x = 0;
+          this.y = 0;
+          break;
+        case "s":
+          this.saveCursor();
+          break;
+        case "u":
+          this.restoreCursor();
+          break;
+        case "m":
+          this.sgr(raw === "" ? [0] : p.map((v) => (isNaN(v) ? 0 : v)));
+          break;
+        case "h":
+        case "l":
+          if (priv) p.forEach((m) => this.mode(m, final === "h"));
+          break;
+        case "n":
+          if (p[0] === 6) this.onData(`\x1b[${this.y + 1};${this.x + 1}R`);
+          if (p[0] === 5) this.onData("\x1b[0n");
+          break;
+        case "c":
+          if (!priv && !raw.startsWith(">")) this.onData("\x1b[?1;2c");
+          break;
+      }
+    }
+
+    mode(m, set) {
+      switch (m) {
+        case 1:
+          this.appCursor = set;
+          break;
+        case 7:
+          this.autowrap = set;
+          break;
+        case 25:
+          this.cursorVisible = set;
+          break;
+        case 47:
+        case 1047:
+        case 1049:
+          if (set && !this.alt) {
+            if (m === 1049) this.saveCursor();
+            this.alt = { lines: this.lines };
+            this.lines = [];
+            for (let i = 0; i < this.rows; i++) this.lines.push(this.blankLine());
+          } else if (!set && this.alt) {
+            this.lines = this.alt.lines;
+            this.alt = null;
+            if (m === 1049) this.restoreCursor();
+          }
+          break;
+      }
+    }
+
+    sgr(p) {
+      for (let i = 0; i < p.length; i++) {
+        const v = p[i];
+
+        if (v === 0) this.style = defaultStyle();
+        else if (v === 1) this.style.bold = true;
+        else if (v === 2) this.style.dim = true;
+        else if (v === 3) this.style.italic = true;
+        else if (v === 4) this.style.underline = true;
+        else if (v === 7) this.style.inverse = true;
+        else if (v === 22) this.style.bold = this.style.dim = false;
+        else if (v === 23) this.style.italic = false;
+        else if (v === 24) this.style.underline = false;
+        else if (v === 27) this.style.inverse = false;
+        else if (v >= 30 && v <= 37) this.style.fg = palette[v - 30];
+        else if (v === 39) this.style.fg = null;
+        else if (v >= 40 && v <= 47) this.style.bg = palette[v - 40];
+        else if (v === 49) this.style.bg = null;
+        else if (v >= 90 && v <= 97) this.style.fg = palette[v - 82];
+        else if (v >= 100 && v <= 107) this.style.bg = palette[v - 92];
+        else if (v === 38 || v === 48) {
+          let c = null;
+          if (p[i + 1] === 5) {
+            c = color(p[i + 2] || 0);
+            i += 2;
+          } else if (p[i + 1] === 2) {
+            c = `rgb(${p[i + 2] || 0},${p[i + 3] || 0},${p[i + 4] || 0})`;
+            i += 4;
+          }
+
+          if (v === 38) this.style.fg = c;
+          else this.style.bg = c;
+        }
+      }
+    }
+
+    eraseDisplay(mode) {
+      if (mode === 0) {
+        this.eraseLine(0);
+        for (let y = this.y + 1; y < this.rows; y++) this.lines[y] = this.blankLine();
+      } else if (mode === 1) {
+        this.eraseLine(1);
+        for (let y = 0; y < this.y; y++) this.lines[y] = this.blankLine();
+      } else if (mode === 2) {
+        for (let y = 0; y < this.rows; y++) this.lines[y] = this.blankLine();
+      } else if (mode === 3) {
+        this.scrollback = [];
+      }
+    }
+
+    eraseLine(mode) {
+      const l = this.lines[this.y];
+      const start = mode === 0 ? this.x : 0;
+      const end = mode === 1 ? this.x + 1 : this.cols;
+
+      for (let x = start; x < end; x++) l[x] = this.blankCell();
+    }
+
+    css(style) {
+      let fg = style.fg;
+      let bg = style.bg;
+
+      if (style.inverse) {
+        [fg, bg] = [bg || "#000000", fg || "#e5e5e5"];
+      }
+
+      let css = "";
+      if (fg) css += `color:${fg};`;
+      if (bg) css += `background:${bg};`;
+      if (style.bold) css += "font-weight:bold;";
+      if (style.dim) css += "opacity:0.7;";
+      if (style.italic) css += "font-style:italic;";
+      if (style.underline) css += "text-decoration:underline;";
+
+      return css;
+    }
+
+    renderLine(l, cursor) {
+      let html = "";
+      let run = "";
+      let css = null;
+
+      const flush = () => {
+        if (run === "") return;
+        html += css ? `<span style="${css}">${escapeHTML(run)}</span>` : escapeHTML(run);
+        run = "";
+      };
+
+      for (let x = 0; x < l.length; x++) {
+        const c = this.css(l[x].style);
+
+        if (x === cursor) {
+          flush();
+          html += `<span class="cursor" style="${c}">${escapeHTML(l[x].ch)}</span>`;
+          css = null;
+          continue;
+        }
+
+        if (c !== css) {
+          flush();
+          css = c;
+        }
+
+        run += l[x].ch;
+      }
+
+      flush();
+
+      return `<div class="row">${html}</div>`;
+    }
+
+    // render updates the page on the next animation frame
+    render() {
+      if (this.pending) return;
+      this.pending = true;
+
+      requestAnimationFrame(() => {
+        this.pending = false;
+
+        const lines = this.alt ? [] : this.scrollback.map((l) => this.renderLine(l, -1));
+        this.lines.forEach((l, y) => lines.push(this.renderLine(l, this.cursorVisible && y === this.y ? this.x : -1)));
+
+        this.el.innerHTML = lines.join("");
+        this.el.scrollTop = this.el.scrollHeight;
+      });
+    }
+
+    key(e) {
+      const cursor = (c) => (this.appCursor ? "\x1bO" : "\x1b[") + c;
+      const keys = {
+        Enter: "\r",
+        Backspace: "\x7f",
+        Tab: "\t",
+        Escape: "\x1b",
+        ArrowUp: cursor("A"),
+        ArrowDown: cursor("B"),
+        ArrowRight: cursor("C"),
+        ArrowLeft: cursor("D"),
+        Home: cursor("H"),
+        End: cursor("F"),
+        Insert: "\x1b[2~",
+        Delete: "\x1b[3~",
+        PageUp: "\x1b[5~",
+        PageDown: "\x1b[6~",
+      };
+
+      // allow the browser shortcuts for copy and paste
+      if ((e.ctrlKey || e.metaKey) && (e.shiftKey || e.metaKey)) return null;
+
+      if (e.key === "Tab" && e.shiftKey) return "\x1b[Z";
+      if (keys[e.key]) return keys[e.key];
+
+      if (e.key.length !== 1) return null;
+
+      if (e.ctrlKey && !e.altKey) {
+        const c = e.key.toUpperCase().charCodeAt(0);
+        if (c >= 64 && c <= 95) return String.fromCharCode(c - 64);
+        if (e.key === " ") return "\x00";
+        return null;
+      }
+
+      return e.altKey ? "\x1b" + e.key : e.key;
+    }
+
+    listen() {
+      this.el.addEventListener("keydown", (e) => {
+        const d = this.key(e);
+        if (d === null) return;
+
+        e.preventDefault();
+        this.onData(d);
+      });
+
+      this.el.addEventListener("paste", (e) => {
+        e.preventDefault();
+        this.onData(e.clipboardData.getData("text").replace(/\r?\n/g, "\r"));
+      });
+    }
+
+    focus() {
+      this.el.focus();
+    }
+  }
+
+  window.JumppadTerminal = Terminal;
+})();
@@ -0,0 +1,168 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	sdk "github.com/jumppad-labs/plugin-sdk"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// dnsTTL is the time to live for records returned by the DNS server, this is
+// kept short as addresses change whenever resources are recreated
+const dnsTTL = 5
+
+// RecordsFunc returns a map of fully qualified domain names and the
+// IP address they resolve to
+type RecordsFunc func() map[string]string
+
+// DNS is a small DNS server that resolves the names of jumppad resources
+// such as web.container.local.jmpd.in to their IP addresses
+type DNS struct {
+	addr    string
+	log     sdk.Logger
+	records RecordsFunc
+	conn    net.PacketConn
+
+	cacheMutex sync.Mutex
+	cache      map[string]string
+	cacheTime  time.Time
+}
+
+// NewDNS creates a new DNS server that resolves the records returned by
+// records, records is called at most once per TTL
+func NewDNS(addr string, records RecordsFunc, l logger.Logger) *DNS {
+	return &DNS{addr: addr, log: l, records: records}
+}
+
+// Start the DNS server, this function blocks until the server is stopped
+func (d *DNS) Start() error {
+	d.log.Debug("Starting DNS server", "bind_addr", d.addr)
+
+	conn, err := net.ListenPacket("udp", d.addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", d.addr, err)
+	}
+
+	d.conn = conn
+
+	buf := make([]byte, 512)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			d.log.Debug("DNS server exit", "error", err)
+			return nil
+		}
+
+		resp, err := d.handle(buf[:n])
+		if err != nil {
+			d.log.Debug("Unable to handle DNS query", "error", err)
+			continue
+		}
+
+		conn.WriteTo(resp, addr)
+	}
+}
+
+// Stop the DNS server
+func (d *DNS) Stop() {
+	d.log.Info("Shutdown DNS server")
+
+	if d.conn != nil {
+		d.conn.Close()
+	}
+}
+
+// handle parses the DNS query and builds the response
+func (d *DNS) handle(query []byte) ([]byte, error) {
+	var p dnsmessage.Parser
+
+	h, err := p.Start(query)
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := p.Question()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(q.Name.String(), "."))
+	ip, found := d.lookup(name)
+
+	rh := dnsmessage.Header{
+		ID:                 h.ID,
+		Response:           true,
+		Authoritative:      true,
+		RecursionDesired:   h.RecursionDesired,
+		RecursionAvailable: false,
+		RCode:              dnsmessage.RCodeSuccess,
+	}
+
+	if !found {
+		rh.RCode = dnsmessage.RCodeNameError
+	}
+
+	b := dnsmessage.NewBuilder(make([]byte, 0, 512), rh)
+	b.EnableCompression()
+
+	err = b.StartQuestions()
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Question(q)
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.StartAnswers()
+	if err != nil {
+		return nil, err
+	}
+
+	if found && q.Type == dnsmessage.TypeA {
+		if v4 := net.ParseIP(ip).To4(); v4 != nil {
+			a := dnsmessage.AResource{}
+			copy(a.A[:], v4)
+
+			err = b.AResource(dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: dnsTTL}, a)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if found && q.Type == dnsmessage.TypeAAAA {
+		if parsed := net.ParseIP(ip); parsed != nil && parsed.To4() == nil {
+			a := dnsmessage.AAAAResource{}
+			copy(a.AAAA[:], parsed.To16())
+
+			err = b.AAAAResource(dnsmessage.ResourceHeader{Name: q.Name, Class: dnsmessage.ClassINET, TTL: dnsTTL}, a)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return b.Finish()
+}
+
+// lookup returns the ip address for the given name, records are cached
+// for the lifetime of the TTL to avoid reading the state on every query
+func (d *DNS) lookup(name string) (string, bool) {
+	d.cacheMutex.Lock()
+	defer d.cacheMutex.Unlock()
+
+	if d.cache == nil || time.Since(d.cacheTime) > dnsTTL*time.Second {
+		d.cache = d.records()
+		d.cacheTime = time.Now()
+	}
+
+	ip, ok := d.cache[name]
+	return ip, ok
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func setupDNS(t *testing.T) *DNS {
+	records := func() map[string]string {
+		return map[string]string{
+			"web.container.local.jmpd.in": "10.6.0.2",
+		}
+	}
+
+	return NewDNS("127.0.0.1:0", records, logger.NewTestLogger(t))
+}
+
+func buildQuery(t *testing.T, name string, qtype dnsmessage.Type) []byte {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: 42, RecursionDesired: true})
+	require.NoError(t, b.StartQuestions())
+	require.NoError(t, b.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(name),
+		Type:  qtype,
+		Class: dnsmessage.ClassINET,
+	}))
+
+	q, err := b.Finish()
+	require.NoError(t, err)
+
+	return q
+}
+
+func TestDNSResolvesKnownRecord(t *testing.T) {
+	d := setupDNS(t)
+
+	resp, err := d.handle(buildQuery(t, "web.container.local.jmpd.in.", dnsmessage.TypeA))
+	require.NoError(t, err)
+
+	m := dnsmessage.Message{}
+	require.NoError(t, m.Unpack(resp))
+
+	require.Equal(t, uint16(42), m.Header.ID)
+	require.Equal(t, dnsmessage.RCodeSuccess, m.Header.RCode)
+	require.Len(t, m.Answers, 1)
+	require.Equal(t, [4]byte{10, 6, 0, 2}, m.Answers[0].Body.(*dnsmessage.AResource).A)
+}
+
+func TestDNSReturnsNameErrorForUnknownRecord(t *testing.T) {
+	d := setupDNS(t)
+
+	resp, err := d.handle(buildQuery(t, "db.container.local.jmpd.in.", dnsmessage.TypeA))
+	require.NoError(t, err)
+
+	m := dnsmessage.Message{}
+	require.NoError(t, m.Unpack(resp))
+
+	require.Equal(t, dnsmessage.RCodeNameError, m.Header.RCode)
+	require.Len(t, m.Answers, 0)
+}
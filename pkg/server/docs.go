@@ -0,0 +1,80 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+)
+
+// docsClients holds the websocket connections for the docs UI that receive
+// reload events
+type docsClients struct {
+	sync.Mutex
+	clients map[*websocket.Conn]bool
+}
+
+// docsEvents upgrades the connection to a websocket and sends reload events
+// to the docs UI until the connection is closed
+func (a *API) docsEvents(w http.ResponseWriter, r *http.Request) {
+	connection, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		a.log.Error("Unable to upgrade docs connection", "error", err)
+		return
+	}
+
+	a.docs.Lock()
+	a.docs.clients[connection] = true
+	a.docs.Unlock()
+
+	a.log.Debug("Docs client connected", "remote", r.RemoteAddr)
+
+	// the docs UI does not send messages, read until the connection closes
+	for {
+		if _, _, err := connection.NextReader(); err != nil {
+			break
+		}
+	}
+
+	a.docs.Lock()
+	delete(a.docs.clients, connection)
+	a.docs.Unlock()
+
+	connection.Close()
+	a.log.Debug("Docs client disconnected", "remote", r.RemoteAddr)
+}
+
+// docsReload broadcasts a reload event to all connected docs clients
+func (a *API) docsReload(w http.ResponseWriter, r *http.Request) {
+	ev := types.DocsReload{}
+	err := json.NewDecoder(r.Body).Decode(&ev)
+	if err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if ev.ID == "" {
+		http.Error(w, "id is required", http.StatusBadRequest)
+		return
+	}
+
+	d, _ := json.Marshal(ev)
+
+	a.docs.Lock()
+	defer a.docs.Unlock()
+
+	for c := range a.docs.clients {
+		err := c.WriteMessage(websocket.TextMessage, d)
+		if err != nil {
+			a.log.Debug("Unable to send reload event, removing client", "error", err)
+			c.Close()
+			delete(a.docs.clients, c)
+		}
+	}
+
+	a.log.Debug("Sent docs reload event", "ref", ev.ID, "clients", len(a.docs.clients))
+
+	w.WriteHeader(http.StatusAccepted)
+}
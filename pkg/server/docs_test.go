@@ -0,0 +1,87 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDocsAPI(t *testing.T) (*API, *httptest.Server) {
+	api := New(":0", logger.NewTestLogger(t))
+
+	ts := httptest.NewServer(api.server.Handler)
+	t.Cleanup(ts.Close)
+
+	return api, ts
+}
+
+func connectDocsClient(t *testing.T, api *API, ts *httptest.Server) *websocket.Conn {
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/docs/events"
+
+	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+
+	// wait for the client to be registered
+	require.Eventually(t, func() bool {
+		api.docs.Lock()
+		defer api.docs.Unlock()
+
+		return len(api.docs.clients) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	return c
+}
+
+func TestDocsReloadSendsEventToClients(t *testing.T) {
+	api, ts := setupDocsAPI(t)
+	c := connectDocsClient(t, api, ts)
+
+	ev := types.DocsReload{ID: "resource.docs.test", Checksum: "abc", Pages: []string{"/docs/book/chapter/page"}}
+	d, _ := json.Marshal(ev)
+
+	resp, err := http.Post(ts.URL+"/docs/reload", "application/json", bytes.NewReader(d))
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+	c.SetReadDeadline(time.Now().Add(time.Second))
+	_, msg, err := c.ReadMessage()
+	require.NoError(t, err)
+
+	got := types.DocsReload{}
+	require.NoError(t, json.Unmarshal(msg, &got))
+	require.Equal(t, ev, got)
+}
+
+func TestDocsReloadWithoutIDReturnsBadRequest(t *testing.T) {
+	api, _ := setupDocsAPI(t)
+
+	rr := httptest.NewRecorder()
+	api.docsReload(rr, httptest.NewRequest(http.MethodPost, "/docs/reload", strings.NewReader(`{}`)))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestDocsEventsRemovesClientOnClose(t *testing.T) {
+	api, ts := setupDocsAPI(t)
+	c := connectDocsClient(t, api, ts)
+
+	c.Close()
+
+	require.Eventually(t, func() bool {
+		api.docs.Lock()
+		defer api.docs.Unlock()
+
+		return len(api.docs.clients) == 0
+	}, time.Second, 10*time.Millisecond)
+}
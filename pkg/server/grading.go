@@ -0,0 +1,280 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/jumppad-labs/jumppad/pkg/clients/container"
+	"github.com/jumppad-labs/jumppad/pkg/clients/images"
+	"github.com/jumppad-labs/jumppad/pkg/clients/k8s"
+	"github.com/jumppad-labs/jumppad/pkg/clients/progress"
+	"github.com/jumppad-labs/jumppad/pkg/clients/tar"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// SetProgressStore sets the store used to persist the progress of a learner
+// so that the docs UI can restore it
+func (a *API) SetProgressStore(s progress.Store) {
+	a.progress = s
+}
+
+func (a *API) listProgress(w http.ResponseWriter, r *http.Request) {
+	tasks, err := a.progress.Tasks()
+	if err != nil {
+		a.log.Error("Unable to read progress", "error", err)
+		http.Error(w, "unable to read progress", http.StatusInternalServerError)
+		return
+	}
+
+	resp := []progress.Task{}
+	for _, t := range tasks {
+		resp = append(resp, t)
+	}
+
+	slices.SortFunc(resp, func(a, b progress.Task) int { return strings.Compare(a.ID, b.ID) })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (a *API) getProgress(w http.ResponseWriter, r *http.Request) {
+	taskID := chi.URLParam(r, "task")
+
+	tasks, err := a.progress.Tasks()
+	if err != nil {
+		a.log.Error("Unable to read progress", "error", err)
+		http.Error(w, "unable to read progress", http.StatusInternalServerError)
+		return
+	}
+
+	resp, ok := tasks[taskID]
+	if !ok {
+		http.Error(w, fmt.Sprintf("no progress for task %s", taskID), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// setProgress records the result of validating a task
+func (a *API) setProgress(resp validationResponse) {
+	t := progress.Task{ID: resp.ID, Status: resp.Status}
+	for _, c := range resp.Conditions {
+		t.Conditions = append(t.Conditions, progress.Condition{ID: c.ID, Messages: c.Messages, Status: c.Status})
+	}
+
+	err := a.progress.SetTask(t)
+	if err != nil {
+		a.log.Error("Unable to save progress", "ref", resp.ID, "error", err)
+	}
+}
+
+// runTaskValidation grades a task validation, returns the resulting
+// condition for the progress API
+func (a *API) runTaskValidation(ctx context.Context, v docs.TaskValidation) validationCondition {
+	var err error
+
+	switch {
+	case v.Exec != nil:
+		err = a.validateExec(v.Exec)
+	case v.HTTP != nil:
+		err = validateHTTP(ctx, v.HTTP)
+	case v.Kubernetes != nil:
+		err = a.validateKubernetes(ctx, v.Kubernetes)
+	default:
+		err = fmt.Errorf("validation does not define a check")
+	}
+
+	if err != nil {
+		a.log.Debug("Validation failed", "id", v.Name, "error", err)
+
+		message := err.Error()
+		if v.FailureMessage != "" {
+			message = v.FailureMessage
+		}
+
+		return validationCondition{ID: v.Name, Messages: []string{message}, Status: "failed"}
+	}
+
+	messages := []string{}
+	if v.SuccessMessage != "" {
+		messages = append(messages, v.SuccessMessage)
+	}
+
+	return validationCondition{ID: v.Name, Messages: messages, Status: "completed"}
+}
+
+// validateExec runs a command or script in the target container and checks
+// the exit code and output
+func (a *API) validateExec(v *docs.ExecValidation) error {
+	dc, err := container.NewDocker()
+	if err != nil {
+		return err
+	}
+
+	ct, err := container.NewDockerTasks(dc, images.NewImageFileLog(utils.ImageCacheLog()), &tar.TarGz{}, a.log)
+	if err != nil {
+		return err
+	}
+
+	fqdn, err := getTarget(v.Target)
+	if err != nil {
+		return err
+	}
+
+	ids, err := ct.FindContainerIDs(fqdn)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return fmt.Errorf("container %s is not running", fqdn)
+	}
+
+	env := append(os.Environ(), "TERM=xterm")
+	output := bytes.NewBufferString("")
+
+	var exitCode int
+	if v.Script != "" {
+		exitCode, err = ct.ExecuteScript(ids[0], v.Script, env, v.WorkingDirectory, v.User, v.Group, v.Timeout, output)
+	} else {
+		exitCode, err = ct.ExecuteCommand(ids[0], v.Command, env, v.WorkingDirectory, v.User, v.Group, v.Timeout, output)
+	}
+
+	// a non zero exit code is returned as an error, only fail when
+	// the command could not be run
+	if err != nil && exitCode == 0 {
+		return err
+	}
+
+	return checkExecResult(v, exitCode, output.String())
+}
+
+func checkExecResult(v *docs.ExecValidation, exitCode int, output string) error {
+	if exitCode != v.ExitCode {
+		return fmt.Errorf("expected exit code %d, got %d", v.ExitCode, exitCode)
+	}
+
+	if v.OutputContains != "" && !strings.Contains(output, v.OutputContains) {
+		return fmt.Errorf("expected output to contain %q", v.OutputContains)
+	}
+
+	return nil
+}
+
+// validateHTTP makes a request and checks the status code and body
+func validateHTTP(ctx context.Context, v *docs.HTTPValidation) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(v.Timeout)*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, v.Method, v.URL, strings.NewReader(v.Body))
+	if err != nil {
+		return fmt.Errorf("unable to create request: %w", err)
+	}
+
+	for k, h := range v.Headers {
+		req.Header.Set(k, h)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to contact %s: %w", v.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(v.StatusCodes, resp.StatusCode) {
+		return fmt.Errorf("expected status code %v, got %d", v.StatusCodes, resp.StatusCode)
+	}
+
+	if v.BodyContains != "" {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("unable to read response body: %w", err)
+		}
+
+		if !strings.Contains(string(body), v.BodyContains) {
+			return fmt.Errorf("expected body to contain %q", v.BodyContains)
+		}
+	}
+
+	return nil
+}
+
+// validateKubernetes checks the existence and readiness of an object
+func (a *API) validateKubernetes(ctx context.Context, v *docs.KubernetesValidation) error {
+	timeout := time.Duration(v.Timeout) * time.Second
+
+	kc, err := k8s.NewKubernetes(timeout, a.log).SetConfig(v.KubeConfig)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	o, err := kc.GetObject(ctx, v.APIVersion, v.Kind, v.Namespace, v.Name)
+	if errors.IsNotFound(err) {
+		o = nil
+	} else if err != nil {
+		return err
+	}
+
+	return checkKubernetesObject(v, o)
+}
+
+func checkKubernetesObject(v *docs.KubernetesValidation, o *unstructured.Unstructured) error {
+	if v.Absent {
+		if o != nil {
+			return fmt.Errorf("%s %s/%s exists", v.Kind, v.Namespace, v.Name)
+		}
+
+		return nil
+	}
+
+	if o == nil {
+		return fmt.Errorf("%s %s/%s does not exist", v.Kind, v.Namespace, v.Name)
+	}
+
+	if v.Ready && !kubernetesObjectReady(o) {
+		return fmt.Errorf("%s %s/%s is not ready", v.Kind, v.Namespace, v.Name)
+	}
+
+	return nil
+}
+
+// kubernetesObjectReady returns true when the Ready or Available condition
+// of an object is true, or for workloads when all replicas are ready
+func kubernetesObjectReady(o *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(o.Object, "status", "conditions")
+	for _, c := range conditions {
+		cm, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if (cm["type"] == "Ready" || cm["type"] == "Available") && cm["status"] == "True" {
+			return true
+		}
+	}
+
+	replicas, found, _ := unstructured.NestedInt64(o.Object, "spec", "replicas")
+	if found {
+		ready, _, _ := unstructured.NestedInt64(o.Object, "status", "readyReplicas")
+		return ready >= replicas
+	}
+
+	return false
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/clients/progress"
+	"github.com/jumppad-labs/jumppad/pkg/config/resources/docs"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func setupHTTPValidation(t *testing.T, status int, body string) *docs.HTTPValidation {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(ts.Close)
+
+	return &docs.HTTPValidation{
+		URL:         ts.URL,
+		Method:      http.MethodGet,
+		Timeout:     5,
+		StatusCodes: []int{http.StatusOK},
+	}
+}
+
+func TestValidateHTTPPassesWhenStatusAndBodyMatch(t *testing.T) {
+	v := setupHTTPValidation(t, http.StatusOK, "hello world")
+	v.BodyContains = "world"
+
+	require.NoError(t, validateHTTP(context.Background(), v))
+}
+
+func TestValidateHTTPFailsWhenStatusDoesNotMatch(t *testing.T) {
+	v := setupHTTPValidation(t, http.StatusNotFound, "")
+
+	require.ErrorContains(t, validateHTTP(context.Background(), v), "expected status code")
+}
+
+func TestValidateHTTPFailsWhenBodyDoesNotMatch(t *testing.T) {
+	v := setupHTTPValidation(t, http.StatusOK, "hello")
+	v.BodyContains = "world"
+
+	require.ErrorContains(t, validateHTTP(context.Background(), v), "expected body")
+}
+
+func TestCheckExecResultComparesExitCodeAndOutput(t *testing.T) {
+	v := &docs.ExecValidation{ExitCode: 0, OutputContains: "ready"}
+
+	require.NoError(t, checkExecResult(v, 0, "server is ready"))
+	require.Error(t, checkExecResult(v, 1, "server is ready"))
+	require.Error(t, checkExecResult(v, 0, "starting"))
+}
+
+func TestCheckKubernetesObject(t *testing.T) {
+	ready := &unstructured.Unstructured{Object: map[string]interface{}{
+		"status": map[string]interface{}{
+			"conditions": []interface{}{
+				map[string]interface{}{"type": "Available", "status": "True"},
+			},
+		},
+	}}
+
+	notReady := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec":   map[string]interface{}{"replicas": int64(2)},
+		"status": map[string]interface{}{"readyReplicas": int64(1)},
+	}}
+
+	require.NoError(t, checkKubernetesObject(&docs.KubernetesValidation{}, notReady))
+	require.NoError(t, checkKubernetesObject(&docs.KubernetesValidation{Ready: true}, ready))
+	require.Error(t, checkKubernetesObject(&docs.KubernetesValidation{Ready: true}, notReady))
+	require.Error(t, checkKubernetesObject(&docs.KubernetesValidation{}, nil))
+	require.NoError(t, checkKubernetesObject(&docs.KubernetesValidation{Absent: true}, nil))
+	require.Error(t, checkKubernetesObject(&docs.KubernetesValidation{Absent: true}, ready))
+}
+
+func TestRunTaskValidationUsesMessages(t *testing.T) {
+	api := New(":0", logger.NewTestLogger(t))
+
+	v := docs.TaskValidation{
+		Name:           "web",
+		HTTP:           setupHTTPValidation(t, http.StatusOK, ""),
+		SuccessMessage: "web is running",
+		FailureMessage: "web is not running",
+	}
+
+	c := api.runTaskValidation(context.Background(), v)
+	require.Equal(t, "completed", c.Status)
+	require.Equal(t, []string{"web is running"}, c.Messages)
+
+	v.HTTP = setupHTTPValidation(t, http.StatusInternalServerError, "")
+
+	c = api.runTaskValidation(context.Background(), v)
+	require.Equal(t, "failed", c.Status)
+	require.Equal(t, []string{"web is not running"}, c.Messages)
+}
+
+func TestProgressReturnsStoredResults(t *testing.T) {
+	api := New(":0", logger.NewTestLogger(t))
+	api.setProgress(validationResponse{ID: "resource.task.b", Status: "failed"})
+	api.setProgress(validationResponse{ID: "resource.task.a", Status: "completed"})
+
+	rr := httptest.NewRecorder()
+	api.listProgress(rr, httptest.NewRequest(http.MethodGet, "/progress", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	resp := []progress.Task{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Len(t, resp, 2)
+	require.Equal(t, "resource.task.a", resp[0].ID)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("task", "resource.task.missing")
+	req := httptest.NewRequest(http.MethodGet, "/progress/resource.task.missing", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rr = httptest.NewRecorder()
+	api.getProgress(rr, req)
+	require.Equal(t, http.StatusNotFound, rr.Code)
+}
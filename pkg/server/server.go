@@ -9,13 +9,20 @@ import (
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/go-chi/cors"
+	"github.com/gorilla/websocket"
 	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
-	sdk "github.com/jumppad-labs/plugin-sdk"
+	"github.com/jumppad-labs/jumppad/pkg/clients/progress"
 )
 
 type API struct {
-	server *http.Server
-	log    sdk.Logger
+	server    *http.Server
+	log       logger.Logger
+	tls       *tlsTerminations
+	docs      *docsClients
+	progress  progress.Store
+	terminals *webTerminals
+
+	services ServiceLister
 }
 
 // New creates a new server
@@ -43,13 +50,32 @@ func New(addr string, l logger.Logger) *API {
 	}
 
 	api := &API{
-		server: server,
-		log:    l,
+		server:    server,
+		log:       l,
+		tls:       &tlsTerminations{listeners: map[int]*tlsTermination{}},
+		docs:      &docsClients{clients: map[*websocket.Conn]bool{}},
+		progress:  progress.NewMemoryStore(),
+		terminals: &webTerminals{servers: map[int]*webTerminal{}},
 	}
 
 	router.Get("/terminal", api.terminal)
 	router.Post("/validate/{task}/{action}", api.validation)
 
+	router.Get("/progress", api.listProgress)
+	router.Get("/progress/{task}", api.getProgress)
+
+	router.Get("/status", api.status)
+
+	router.Get("/docs/events", api.docsEvents)
+	router.Post("/docs/reload", api.docsReload)
+
+	router.Get("/tls", api.listTLSTerminations)
+	router.Post("/tls", api.createTLSTermination)
+	router.Delete("/tls/{port}", api.deleteTLSTermination)
+
+	router.Post("/terminals", api.createTerminal)
+	router.Delete("/terminals/{port}", api.deleteTerminal)
+
 	return api
 }
 
@@ -72,5 +98,7 @@ func (s *API) Stop() {
 	defer cancel()
 
 	s.log.Info("Shutdown API server")
+	s.closeTLSTerminations()
+	s.closeTerminals()
 	s.server.Shutdown(ctx)
 }
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"github.com/jumppad-labs/connector/protos/shipyard"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+)
+
+// ServiceLister returns the services that are tunnelled by the connector
+type ServiceLister func() ([]*shipyard.Service, error)
+
+// SetServiceLister sets the function used to list the connector services
+// for the status endpoint
+func (a *API) SetServiceLister(sl ServiceLister) {
+	a.services = sl
+}
+
+func (a *API) status(w http.ResponseWriter, r *http.Request) {
+	resp := types.Status{
+		Services:        []types.ServiceStatus{},
+		TLSTerminations: []types.TLSTerminationStatus{},
+	}
+
+	if a.services != nil {
+		svcs, err := a.services()
+		if err != nil {
+			a.log.Error("Unable to list services", "error", err)
+			http.Error(w, "unable to list services", http.StatusInternalServerError)
+			return
+		}
+
+		for _, s := range svcs {
+			resp.Services = append(resp.Services, types.ServiceStatus{
+				ID:                  s.Id,
+				Name:                s.Name,
+				Type:                strings.ToLower(s.Type.String()),
+				SourcePort:          int(s.SourcePort),
+				RemoteConnectorAddr: s.RemoteConnectorAddr,
+				DestinationAddr:     s.DestinationAddr,
+				Status:              strings.ToLower(s.Status.String()),
+				Healthy:             s.Status == shipyard.ServiceStatus_COMPLETE,
+			})
+		}
+	}
+
+	a.tls.Lock()
+	for _, t := range a.tls.listeners {
+		resp.TLSTerminations = append(resp.TLSTerminations, types.TLSTerminationStatus{
+			TLSTermination: t.request,
+			Connections:    atomic.LoadInt64(&t.connections),
+			BytesIn:        atomic.LoadInt64(&t.bytesIn),
+			BytesOut:       atomic.LoadInt64(&t.bytesOut),
+		})
+	}
+	a.tls.Unlock()
+
+	sort.Slice(resp.Services, func(i, j int) bool { return resp.Services[i].Name < resp.Services[j].Name })
+	sort.Slice(resp.TLSTerminations, func(i, j int) bool { return resp.TLSTerminations[i].Port < resp.TLSTerminations[j].Port })
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jumppad-labs/connector/protos/shipyard"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/stretchr/testify/require"
+)
+
+func setupStatusAPI(t *testing.T) *API {
+	api := New(":0", logger.NewTestLogger(t))
+	api.SetServiceLister(func() ([]*shipyard.Service, error) {
+		return []*shipyard.Service{
+			{Id: "2", Name: "web", Type: shipyard.ServiceType_REMOTE, SourcePort: 8080, Status: shipyard.ServiceStatus_COMPLETE},
+			{Id: "1", Name: "api", Type: shipyard.ServiceType_LOCAL, SourcePort: 9090, Status: shipyard.ServiceStatus_ERROR},
+		}, nil
+	})
+
+	api.tls.listeners[8443] = &tlsTermination{
+		request:     types.TLSTermination{Port: 8443, Destination: "localhost:31001"},
+		connections: 2,
+		bytesIn:     100,
+		bytesOut:    200,
+	}
+
+	return api
+}
+
+func TestStatusReturnsServicesAndTLSTerminations(t *testing.T) {
+	api := setupStatusAPI(t)
+
+	rr := httptest.NewRecorder()
+	api.status(rr, httptest.NewRequest(http.MethodGet, "/status", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	s := types.Status{}
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &s))
+
+	require.Len(t, s.Services, 2)
+	require.Equal(t, "api", s.Services[0].Name)
+	require.Equal(t, "local", s.Services[0].Type)
+	require.Equal(t, "error", s.Services[0].Status)
+	require.False(t, s.Services[0].Healthy)
+	require.True(t, s.Services[1].Healthy)
+
+	require.Len(t, s.TLSTerminations, 1)
+	require.Equal(t, int64(2), s.TLSTerminations[0].Connections)
+	require.Equal(t, int64(100), s.TLSTerminations[0].BytesIn)
+	require.Equal(t, int64(200), s.TLSTerminations[0].BytesOut)
+}
+
+func TestStatusReturnsErrorWhenUnableToListServices(t *testing.T) {
+	api := setupStatusAPI(t)
+	api.SetServiceLister(func() ([]*shipyard.Service, error) {
+		return nil, fmt.Errorf("boom")
+	})
+
+	rr := httptest.NewRecorder()
+	api.status(rr, httptest.NewRequest(http.MethodGet, "/status", nil))
+	require.Equal(t, http.StatusInternalServerError, rr.Code)
+}
@@ -49,6 +49,12 @@ func (a *API) terminal(w http.ResponseWriter, r *http.Request) {
 	// Upgrade to websockets
 	connection, _ := upgrader.Upgrade(w, r, nil)
 
+	a.runTerminal(connection, target, user, workdir, shell)
+}
+
+// runTerminal starts a shell for the target, either the local machine or a
+// container, and connects it to the websocket until either side closes
+func (a *API) runTerminal(connection *websocket.Conn, target, user, workdir, shell string) {
 	var cmd *exec.Cmd
 	if target == "local" {
 		defaultShell := "bash"
@@ -73,7 +79,30 @@ func (a *API) terminal(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// replay the output from the previous session so the learner can
+	// resume where they left off
+	history, err := newTerminalHistory(terminalKey(target, user, workdir), a.progress)
+	if err != nil {
+		a.log.Error("Unable to read terminal history", "error", err)
+	}
+
+	done := make(chan struct{})
+
+	if history != nil {
+		if d := history.Bytes(); len(d) > 0 {
+			_ = connection.WriteMessage(websocket.BinaryMessage, d)
+		}
+
+		go func() {
+			err := history.FlushEvery(historyFlushInterval, done)
+			if err != nil {
+				a.log.Error("Unable to save terminal history", "error", err)
+			}
+		}()
+	}
+
 	defer func() {
+		close(done)
 		cmd.Process.Kill()
 		cmd.Process.Wait()
 		tty.Close()
@@ -97,6 +126,10 @@ func (a *API) terminal(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			_ = connection.WriteMessage(websocket.BinaryMessage, buf[:read])
+
+			if history != nil {
+				history.Write(buf[:read])
+			}
 		}
 	}()
 
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/progress"
+)
+
+// historyFlushInterval is how often terminal output is saved to the store
+const historyFlushInterval = 5 * time.Second
+
+// terminalHistory records the output of a terminal so that it can be
+// replayed when the learner reconnects
+type terminalHistory struct {
+	key   string
+	store progress.Store
+	data  []byte
+	dirty bool
+	mutex sync.Mutex
+}
+
+// terminalKey returns the key for a terminal, terminals for the same target,
+// user and working directory share history
+func terminalKey(target, user, workdir string) string {
+	return fmt.Sprintf("%s:%s:%s", target, user, workdir)
+}
+
+func newTerminalHistory(key string, store progress.Store) (*terminalHistory, error) {
+	d, err := store.History(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &terminalHistory{key: key, store: store, data: d}, nil
+}
+
+// Bytes returns the recorded output
+func (h *terminalHistory) Bytes() []byte {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return append([]byte{}, h.data...)
+}
+
+// Write records the output, only the last progress.MaxHistory bytes are kept
+func (h *terminalHistory) Write(p []byte) (int, error) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.data = append(h.data, p...)
+	if len(h.data) > progress.MaxHistory {
+		h.data = h.data[len(h.data)-progress.MaxHistory:]
+	}
+
+	h.dirty = true
+
+	return len(p), nil
+}
+
+// Flush saves the output to the store when it has changed
+func (h *terminalHistory) Flush() error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if !h.dirty {
+		return nil
+	}
+
+	h.dirty = false
+
+	return h.store.SetHistory(h.key, h.data)
+}
+
+// FlushEvery saves the output at the given interval until done is closed
+func (h *terminalHistory) FlushEvery(interval time.Duration, done <-chan struct{}) error {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+
+	for {
+		select {
+		case <-done:
+			return h.Flush()
+		case <-t.C:
+			err := h.Flush()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
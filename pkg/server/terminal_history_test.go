@@ -0,0 +1,52 @@
+package server
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jumppad-labs/jumppad/pkg/clients/progress"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTerminalHistoryRestoresSavedOutput(t *testing.T) {
+	s := progress.NewMemoryStore()
+	key := terminalKey("resource.container.vscode", "root", "/")
+
+	h, err := newTerminalHistory(key, s)
+	require.NoError(t, err)
+
+	h.Write([]byte("$ terraform init\n"))
+	require.NoError(t, h.Flush())
+
+	h, err = newTerminalHistory(key, s)
+	require.NoError(t, err)
+	require.Equal(t, []byte("$ terraform init\n"), h.Bytes())
+}
+
+func TestTerminalHistoryKeepsLastBytes(t *testing.T) {
+	h, err := newTerminalHistory("local", progress.NewMemoryStore())
+	require.NoError(t, err)
+
+	h.Write(bytes.Repeat([]byte("a"), progress.MaxHistory))
+	h.Write([]byte("end"))
+
+	require.Len(t, h.Bytes(), progress.MaxHistory)
+	require.True(t, bytes.HasSuffix(h.Bytes(), []byte("end")))
+}
+
+func TestTerminalHistoryFlushOnlySavesChanges(t *testing.T) {
+	s := progress.NewMemoryStore()
+
+	h, err := newTerminalHistory("local", s)
+	require.NoError(t, err)
+	require.NoError(t, h.Flush())
+
+	d, _ := s.History("local")
+	require.Nil(t, d)
+
+	h.Write([]byte("ls"))
+	require.NoError(t, h.Flush())
+
+	d, _ = s.History("local")
+	require.Equal(t, []byte("ls"), d)
+}
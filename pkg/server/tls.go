@@ -0,0 +1,181 @@
+package server
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-chi/chi"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+)
+
+type tlsTermination struct {
+	request  types.TLSTermination
+	listener net.Listener
+
+	// traffic counters, updated atomically
+	connections int64
+	bytesIn     int64
+	bytesOut    int64
+}
+
+// tlsTerminations holds the active TLS listeners keyed by port
+type tlsTerminations struct {
+	sync.Mutex
+	listeners map[int]*tlsTermination
+}
+
+func (a *API) createTLSTermination(w http.ResponseWriter, r *http.Request) {
+	req := types.TLSTermination{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to decode request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if req.Port == 0 || req.Destination == "" || req.CertPath == "" || req.KeyPath == "" {
+		http.Error(w, "port, destination, cert_path, and key_path are required", http.StatusBadRequest)
+		return
+	}
+
+	cert, err := tls.LoadX509KeyPair(req.CertPath, req.KeyPath)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to load certificate: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	a.tls.Lock()
+	defer a.tls.Unlock()
+
+	// replace any existing listener on the same port
+	if t, ok := a.tls.listeners[req.Port]; ok {
+		t.listener.Close()
+		delete(a.tls.listeners, req.Port)
+	}
+
+	l, err := tls.Listen("tcp", fmt.Sprintf(":%d", req.Port), &tls.Config{Certificates: []tls.Certificate{cert}})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to listen on port %d: %s", req.Port, err), http.StatusInternalServerError)
+		return
+	}
+
+	t := &tlsTermination{request: req, listener: l}
+	a.tls.listeners[req.Port] = t
+
+	a.log.Info("Started TLS termination", "port", req.Port, "destination", req.Destination)
+
+	go a.serveTLSTermination(t)
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (a *API) deleteTLSTermination(w http.ResponseWriter, r *http.Request) {
+	port, err := strconv.Atoi(chi.URLParam(r, "port"))
+	if err != nil {
+		http.Error(w, "invalid port", http.StatusBadRequest)
+		return
+	}
+
+	a.tls.Lock()
+	defer a.tls.Unlock()
+
+	t, ok := a.tls.listeners[port]
+	if !ok {
+		http.Error(w, fmt.Sprintf("no TLS termination for port %d", port), http.StatusNotFound)
+		return
+	}
+
+	t.listener.Close()
+	delete(a.tls.listeners, port)
+
+	a.log.Info("Stopped TLS termination", "port", port)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (a *API) listTLSTerminations(w http.ResponseWriter, r *http.Request) {
+	a.tls.Lock()
+	defer a.tls.Unlock()
+
+	resp := []types.TLSTermination{}
+	for _, t := range a.tls.listeners {
+		resp = append(resp, t.request)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// serveTLSTermination accepts connections until the listener is closed
+// and proxies them to the destination
+func (a *API) serveTLSTermination(t *tlsTermination) {
+	for {
+		conn, err := t.listener.Accept()
+		if err != nil {
+			a.log.Debug("TLS listener closed", "port", t.request.Port, "error", err)
+			return
+		}
+
+		atomic.AddInt64(&t.connections, 1)
+
+		go a.proxyTLSConnection(conn, t)
+	}
+}
+
+func (a *API) proxyTLSConnection(src net.Conn, t *tlsTermination) {
+	defer src.Close()
+
+	dst, err := net.Dial("tcp", t.request.Destination)
+	if err != nil {
+		a.log.Error("Unable to connect to TLS destination", "destination", t.request.Destination, "error", err)
+		return
+	}
+	defer dst.Close()
+
+	done := make(chan struct{}, 2)
+
+	go func() {
+		io.Copy(&countingWriter{w: dst, n: &t.bytesIn}, src)
+		done <- struct{}{}
+	}()
+
+	go func() {
+		io.Copy(&countingWriter{w: src, n: &t.bytesOut}, dst)
+		done <- struct{}{}
+	}()
+
+	// wait for either side to close
+	<-done
+}
+
+// countingWriter records the number of bytes written so that traffic
+// is visible in the status while a connection is open
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	atomic.AddInt64(c.n, int64(n))
+
+	return n, err
+}
+
+// closeTLSTerminations stops all the running TLS listeners
+func (a *API) closeTLSTerminations() {
+	a.tls.Lock()
+	defer a.tls.Unlock()
+
+	for p, t := range a.tls.listeners {
+		t.listener.Close()
+		delete(a.tls.listeners, p)
+	}
+}
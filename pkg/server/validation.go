@@ -196,6 +196,18 @@ func (a *API) validation(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
+	// validations grade the task against the blueprint resources
+	if action == "check" {
+		for _, v := range task.Validations {
+			c := a.runTaskValidation(r.Context(), v)
+			if c.Status == "completed" {
+				completed++
+			}
+
+			conditions = append(conditions, c)
+		}
+	}
+
 	if len(conditions) == completed {
 		if action == "solve" {
 			status = "skipped"
@@ -204,10 +216,17 @@ func (a *API) validation(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(validationResponse{
+	resp := validationResponse{
 		ID:         task.Meta.ID,
 		Conditions: conditions,
 		Status:     status,
-	})
+	}
+
+	// write the result back so the progress can be restored by the docs UI
+	if action == "check" {
+		a.setProgress(resp)
+	}
+
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(resp)
 }
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"crypto/subtle"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"github.com/go-chi/chi"
+	"github.com/gorilla/websocket"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+)
+
+// webTerminal is a browser based terminal served on a local port
+type webTerminal struct {
+	request types.Terminal
+	server  *http.Server
+}
+
+// webTerminals holds the running terminals keyed by port
+type webTerminals struct {
+	sync.Mutex
+	servers map[int]*webTerminal
+}
+
+// terminalAssets are the script and styles for the terminal page, they are
+// served by the terminal so that the page does not load code from a CDN
+//
+//go:embed assets/terminal
+var terminalAssets embed.FS
+
+// terminalCookie is the name of the cookie that holds the token for the
+// terminal on the port, cookies are shared by all the ports on a host
+const terminalCookie = "jumppad_terminal_%d"
+
+var terminalPage = template.Must(template.New("terminal").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <meta charset="utf-8">
+  <meta name="referrer" content="no-referrer">
+  <title>{{ .Title }}</title>
+  <link rel="stylesheet" href="/assets/terminal/terminal.css">
+  <script src="/assets/terminal/terminal.js"></script>
+</head>
+<body>
+  <div id="terminal" tabindex="0"></div>
+  <script>
+    const term = new JumppadTerminal(document.getElementById("terminal"));
+
+    const proto = window.location.protocol === "https:" ? "wss:" : "ws:";
+    const ws = new WebSocket(proto + "//" + window.location.host + "/ws");
+    ws.binaryType = "arraybuffer";
+
+    const encoder = new TextEncoder();
+    const send = (type, data) => {
+      if (ws.readyState !== WebSocket.OPEN) return;
+
+      const d = encoder.encode(data);
+      const msg = new Uint8Array(d.length + 1);
+      msg[0] = type;
+      msg.set(d, 1);
+      ws.send(msg);
+    };
+
+    term.onData = (d) => send(0, d);
+    term.onResize = (rows, cols) => send(1, JSON.stringify({ rows: rows, cols: cols }));
+
+    ws.onopen = () => {
+      term.fit();
+      send(1, JSON.stringify({ rows: term.rows, cols: term.cols }));
+      term.focus();
+    };
+    ws.onmessage = (e) => term.write(typeof e.data === "string" ? e.data : new Uint8Array(e.data));
+    ws.onclose = () => term.write("\r\n[connection closed]\r\n");
+    window.addEventListener("resize", () => term.fit());
+  </script>
+</body>
+</html>
+`))
+
+// webTerminalUpgrader only accepts websockets opened by the terminal page,
+// the cookie is sent by the browser for pages on any port of the host
+var webTerminalUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		u, err := url.Parse(r.Header.Get("Origin"))
+		return err == nil && u.Host == r.Host
+	},
+}
+
+func (a *API) createTerminal(w http.ResponseWriter, r *http.Request) {
+	req := types.Terminal{}
+
+	err := json.NewDecoder(r.Body).Decode(&req)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to decode request: %s", err), http.StatusBadRequest)
+		return
+	}
+
+	if req.Port == 0 || req.Target == "" || req.Token == "" {
+		http.Error(w, "port, target, and token are required", http.StatusBadRequest)
+		return
+	}
+
+	a.terminals.Lock()
+	defer a.terminals.Unlock()
+
+	// replace any existing terminal on the same port
+	if t, ok := a.terminals.servers[req.Port]; ok {
+		t.server.Close()
+		delete(a.terminals.servers, req.Port)
+	}
+
+	// the terminal runs a shell on the local machine, it must not be
+	// reachable from the network
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", req.Port))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("unable to listen on port %d: %s", req.Port, err), http.StatusInternalServerError)
+		return
+	}
+
+	t := &webTerminal{request: req}
+	t.server = &http.Server{Handler: a.webTerminalHandler(t)}
+	a.terminals.servers[req.Port] = t
+
+	a.log.Info("Started terminal", "port", req.Port, "target", req.Target)
+
+	go func() {
+		err := t.server.Serve(l)
+		if err != nil && err != http.ErrServerClosed {
+			a.log.Error("Terminal server exited", "port", req.Port, "error", err)
+		}
+	}()
+
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (a *API) deleteTerminal(w http.ResponseWriter, r *http.Request) {
+	port, err := strconv.Atoi(chi.URLParam(r, "port"))
+	if err != nil {
+		http.Error(w, "invalid port", http.StatusBadRequest)
+		return
+	}
+
+	a.terminals.Lock()
+	defer a.terminals.Unlock()
+
+	t, ok := a.terminals.servers[port]
+	if !ok {
+		http.Error(w, fmt.Sprintf("no terminal for port %d", port), http.StatusNotFound)
+		return
+	}
+
+	t.server.Close()
+	delete(a.terminals.servers, port)
+
+	a.log.Info("Stopped terminal", "port", port)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// webTerminalHandler serves the terminal page and the websocket for the
+// shell. The token is only accepted in the url of the first request, it is
+// moved to a cookie and the page is reloaded without it so that the token is
+// not kept in the browser history or sent in the Referer header.
+func (a *API) webTerminalHandler(t *webTerminal) http.Handler {
+	mux := http.NewServeMux()
+
+	assets, _ := fs.Sub(terminalAssets, "assets")
+	mux.Handle("/assets/", http.StripPrefix("/assets/", http.FileServer(http.FS(assets))))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Referrer-Policy", "no-referrer")
+
+		if r.URL.Query().Has("token") {
+			if !t.validToken(r.URL.Query().Get("token")) {
+				http.Error(w, "invalid token", http.StatusUnauthorized)
+				return
+			}
+
+			http.SetCookie(w, &http.Cookie{
+				Name:     fmt.Sprintf(terminalCookie, t.request.Port),
+				Value:    t.request.Token,
+				Path:     "/",
+				HttpOnly: true,
+				SameSite: http.SameSiteStrictMode,
+			})
+
+			http.Redirect(w, r, "/", http.StatusSeeOther)
+			return
+		}
+
+		if !t.authorized(r) {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		title := t.request.Title
+		if title == "" {
+			title = t.request.Target
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		terminalPage.Execute(w, struct{ Title string }{title})
+	})
+
+	mux.HandleFunc("/ws", func(w http.ResponseWriter, r *http.Request) {
+		if !t.authorized(r) {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+
+		connection, err := webTerminalUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			a.log.Error("Unable to upgrade terminal connection", "port", t.request.Port, "error", err)
+			return
+		}
+
+		a.runTerminal(connection, t.request.Target, t.request.User, t.request.WorkingDirectory, t.request.Shell)
+	})
+
+	return mux
+}
+
+// authorized returns true when the request contains the cookie with the
+// terminal token
+func (t *webTerminal) authorized(r *http.Request) bool {
+	c, err := r.Cookie(fmt.Sprintf(terminalCookie, t.request.Port))
+	if err != nil {
+		return false
+	}
+
+	return t.validToken(c.Value)
+}
+
+// validToken returns true when token is the terminal token
+func (t *webTerminal) validToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(t.request.Token)) == 1
+}
+
+// closeTerminals stops all the running terminals
+func (a *API) closeTerminals() {
+	a.terminals.Lock()
+	defer a.terminals.Unlock()
+
+	for p, t := range a.terminals.servers {
+		t.server.Close()
+		delete(a.terminals.servers, p)
+	}
+}
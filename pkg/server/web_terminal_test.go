@@ -0,0 +1,148 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/gorilla/websocket"
+	"github.com/jumppad-labs/jumppad/pkg/clients/connector/types"
+	"github.com/jumppad-labs/jumppad/pkg/clients/logger"
+	"github.com/jumppad-labs/jumppad/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func setupWebTerminal(t *testing.T) (*API, types.Terminal) {
+	api := New(":0", logger.NewTestLogger(t))
+	t.Cleanup(api.closeTerminals)
+
+	port, err := utils.FreePort("tcp")
+	require.NoError(t, err)
+
+	req := types.Terminal{Port: port, Target: types.TerminalLocal, Token: "secret", Title: "Workshop"}
+	d, _ := json.Marshal(req)
+
+	rr := httptest.NewRecorder()
+	api.createTerminal(rr, httptest.NewRequest(http.MethodPost, "/terminals", bytes.NewReader(d)))
+	require.Equal(t, http.StatusCreated, rr.Code)
+
+	return api, req
+}
+
+func terminalClient(t *testing.T) *http.Client {
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	return &http.Client{Jar: jar}
+}
+
+func TestCreateTerminalServesPageWithToken(t *testing.T) {
+	_, req := setupWebTerminal(t)
+
+	resp, err := terminalClient(t).Get(fmt.Sprintf("http://127.0.0.1:%d/?token=secret", req.Port))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Contains(t, resp.Header.Get("Content-Type"), "text/html")
+
+	// the token is moved to a cookie and removed from the url
+	require.Equal(t, "/", resp.Request.URL.RequestURI())
+
+	d, _ := io.ReadAll(resp.Body)
+	require.NotContains(t, string(d), "secret")
+	require.NotContains(t, string(d), "cdn.jsdelivr.net")
+}
+
+func TestCreateTerminalServesAssets(t *testing.T) {
+	_, req := setupWebTerminal(t)
+
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/assets/terminal/terminal.js", req.Port))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestCreateTerminalRejectsInvalidToken(t *testing.T) {
+	_, req := setupWebTerminal(t)
+
+	for _, path := range []string{"/", "/?token=wrong", "/ws", "/ws?token=secret"} {
+		resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d%s", req.Port, path))
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		require.Equal(t, http.StatusUnauthorized, resp.StatusCode, path)
+	}
+}
+
+func TestCreateTerminalRejectsWebsocketFromOtherOrigin(t *testing.T) {
+	_, req := setupWebTerminal(t)
+
+	c := terminalClient(t)
+	resp, err := c.Get(fmt.Sprintf("http://127.0.0.1:%d/?token=secret", req.Port))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	u, _ := url.Parse(fmt.Sprintf("http://127.0.0.1:%d/", req.Port))
+	d := websocket.Dialer{Jar: c.Jar}
+
+	_, resp, err = d.Dial(fmt.Sprintf("ws://127.0.0.1:%d/ws", req.Port), http.Header{"Origin": []string{"http://localhost:9999"}})
+	require.Error(t, err)
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+	require.NotEmpty(t, c.Jar.Cookies(u))
+}
+
+func TestCreateTerminalIsNotReachableFromTheNetwork(t *testing.T) {
+	_, req := setupWebTerminal(t)
+
+	ip := ""
+	addrs, _ := net.InterfaceAddrs()
+	for _, a := range addrs {
+		if n, ok := a.(*net.IPNet); ok && !n.IP.IsLoopback() && n.IP.To4() != nil {
+			ip = n.IP.String()
+			break
+		}
+	}
+
+	if ip == "" {
+		t.Skip("no network interface")
+	}
+
+	_, err := net.Dial("tcp", net.JoinHostPort(ip, fmt.Sprint(req.Port)))
+	require.Error(t, err)
+}
+
+func TestCreateTerminalWithoutTokenReturnsBadRequest(t *testing.T) {
+	api := New(":0", logger.NewTestLogger(t))
+
+	rr := httptest.NewRecorder()
+	api.createTerminal(rr, httptest.NewRequest(http.MethodPost, "/terminals", bytes.NewReader([]byte(`{"port": 8080, "target": "local"}`))))
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestDeleteTerminalStopsServer(t *testing.T) {
+	api, req := setupWebTerminal(t)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("port", fmt.Sprintf("%d", req.Port))
+	r := httptest.NewRequest(http.MethodDelete, "/terminals", nil)
+	r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+	rr := httptest.NewRecorder()
+	api.deleteTerminal(rr, r)
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	_, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/?token=secret", req.Port))
+	require.Error(t, err)
+}
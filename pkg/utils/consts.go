@@ -15,8 +15,8 @@ const BuildImagePrefix = "jumppad.dev/localcache"
 // Name of the Cache resource
 const CacheName string = "docker-cache"
 
-// Address of the proxy used for caching docker images
-const jumppadProxyAddress string = "http://default.image-cache.local.jmpd.in:3128"
+// Port of the proxy used for caching docker images
+const imageCachePort = 3128
 
 // Addresses to bypass when using a HTTP Proxy
 const ProxyBypass string = "localhost,127.0.0.1,cluster.local,jumppad.dev,jumpd.in,svc,consul"
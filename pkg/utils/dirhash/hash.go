@@ -24,9 +24,14 @@ import (
 	"strings"
 
 	"github.com/facebookgo/symwalk"
+	gitignore "github.com/monochromegane/go-gitignore"
 	"github.com/ryanuber/go-glob"
 )
 
+// IgnoreFile is the name of the file at the root of a directory that lists
+// the files that are not included in the hash using gitignore syntax
+const IgnoreFile = ".jumppadignore"
+
 // DefaultHash is the default hash function used in new go.sum entries.
 var DefaultHash Hash = Hash1
 
@@ -89,16 +94,45 @@ func HashDir(dir, prefix string, hash Hash, ignore ...string) (string, error) {
 // DirFiles returns the list of files in the tree rooted at dir,
 // replacing the directory name dir with prefix in each name.
 // The resulting names always use forward slashes.
-// A globbed list of files to ignore can be provided as a variadic argument
+// A globbed list of files to ignore can be provided as a variadic argument.
 func DirFiles(dir, prefix string, ignore ...string) ([]string, error) {
+	return dirFiles(dir, prefix, gitignore.DummyIgnoreMatcher(false), ignore...)
+}
+
+// DirFilesWithIgnoreFile returns the list of files in the tree rooted at dir
+// like DirFiles, files matching the patterns in a .jumppadignore file at the
+// root of dir are also ignored.
+//
+// The ignore file is part of the content so it must only be used when
+// publishing content, hashes used to verify content must include every file.
+func DirFilesWithIgnoreFile(dir, prefix string, ignore ...string) ([]string, error) {
+	matcher, err := ignoreMatcher(filepath.Clean(dir))
+	if err != nil {
+		return nil, err
+	}
+
+	return dirFiles(dir, prefix, matcher, ignore...)
+}
+
+func dirFiles(dir, prefix string, matcher gitignore.IgnoreMatcher, ignore ...string) ([]string, error) {
 	var ignoredDirectories []string
 	var files []string
 	dir = filepath.Clean(dir)
+
 	err := symwalk.Walk(dir, func(file string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
+		// check that the file is not ignored by the .jumppadignore file
+		if file != dir && matcher.Match(file, info.IsDir()) {
+			if info.IsDir() {
+				ignoredDirectories = append(ignoredDirectories, file+string(filepath.Separator))
+			}
+
+			return nil
+		}
+
 		// check that the result is not in the ignore list
 		for _, i := range ignore {
 			ignore := glob.Glob(i, file)
@@ -140,6 +174,24 @@ func DirFiles(dir, prefix string, ignore ...string) ([]string, error) {
 	return files, nil
 }
 
+// ignoreMatcher returns a matcher for the patterns in the .jumppadignore file
+// at the root of dir, the matcher does not match any files when the file
+// does not exist
+func ignoreMatcher(dir string) (gitignore.IgnoreMatcher, error) {
+	path := filepath.Join(dir, IgnoreFile)
+
+	if _, err := os.Stat(path); err != nil {
+		return gitignore.DummyIgnoreMatcher(false), nil
+	}
+
+	m, err := gitignore.NewGitIgnore(path, dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	return m, nil
+}
+
 // HashZip returns the hash of the file content in the named zip file.
 // Only the file names and their contents are included in the hash:
 // the exact zip file format encoding, compression method,
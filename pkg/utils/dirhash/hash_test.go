@@ -1,6 +1,8 @@
 package dirhash
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -27,3 +29,55 @@ func TestGeneratesTheCorrectHashFromADirectoryContainingSymlinks(t *testing.T) {
 	require.Equal(t, "h1:rVNDC8+SpMX32ggfqjNmud8EFVMphVqVLs0x+LcWLTA=", h)
 
 }
+
+func setupIgnoreDir(t *testing.T) string {
+	dir := t.TempDir()
+
+	files := map[string]string{
+		IgnoreFile:                     "# dependencies\nnode_modules/\n*.log\n!keep.log\n",
+		"main.hcl":                     "resource \"network\" \"main\" {}",
+		"debug.log":                    "debug",
+		"keep.log":                     "keep",
+		"node_modules/module/index.js": "module",
+		"node_modules_docs/README.md":  "docs",
+	}
+
+	for f, c := range files {
+		os.MkdirAll(filepath.Join(dir, filepath.Dir(f)), os.ModePerm)
+		os.WriteFile(filepath.Join(dir, f), []byte(c), 0644)
+	}
+
+	return dir
+}
+
+func TestDirFilesWithIgnoreFileExcludesFilesInIgnoreFile(t *testing.T) {
+	dir := setupIgnoreDir(t)
+
+	files, err := DirFilesWithIgnoreFile(dir, "")
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []string{IgnoreFile, "main.hcl", "keep.log", "node_modules_docs/README.md"}, files)
+}
+
+func TestDirFilesIncludesFilesInIgnoreFile(t *testing.T) {
+	dir := setupIgnoreDir(t)
+
+	files, err := DirFiles(dir, "")
+	require.NoError(t, err)
+
+	require.ElementsMatch(t, []string{IgnoreFile, "main.hcl", "debug.log", "keep.log", "node_modules/module/index.js", "node_modules_docs/README.md"}, files)
+}
+
+func TestHashDirIsChangedByFilesInIgnoreFile(t *testing.T) {
+	dir := setupIgnoreDir(t)
+
+	h, err := HashDir(dir, "", DefaultHash)
+	require.NoError(t, err)
+
+	os.WriteFile(filepath.Join(dir, "node_modules", "module", "index.js"), []byte("changed"), 0644)
+
+	h2, err := HashDir(dir, "", DefaultHash)
+	require.NoError(t, err)
+
+	require.NotEqual(t, h, h2)
+}
@@ -0,0 +1,180 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// EnvironmentEnvVar overrides the selected environment, it is set by the
+// --env flag
+const EnvironmentEnvVar = "JUMPPAD_ENV"
+
+// DefaultEnvironment is the environment used when no environment has been
+// selected, it stores its state in the root of the jumppad folder so that
+// existing state is not affected
+const DefaultEnvironment = "default"
+
+// EnvironmentLabel is added to the Docker objects created by jumppad, it
+// contains the name of the environment that created the object. The key is
+// namespaced so that it does not clash with the labels set by users
+const EnvironmentLabel = "dev.jumppad.environment"
+
+// Environment returns the name of the current environment, the environment
+// set in JUMPPAD_ENV takes precedence over the environment selected with
+// SelectEnvironment
+func Environment() string {
+	if e := os.Getenv(EnvironmentEnvVar); e != "" {
+		return e
+	}
+
+	d, err := os.ReadFile(selectedEnvironmentPath())
+	if err != nil {
+		return DefaultEnvironment
+	}
+
+	if e := strings.TrimSpace(string(d)); e != "" {
+		return e
+	}
+
+	return DefaultEnvironment
+}
+
+// EnvironmentName qualifies name with the current environment so that
+// Docker objects created by different environments do not clash, names in
+// the default environment are not changed
+func EnvironmentName(name string) string {
+	if e := Environment(); e != DefaultEnvironment {
+		return fmt.Sprintf("%s.%s", name, e)
+	}
+
+	return name
+}
+
+// EnvironmentsDir returns the location where the named environments are
+// stored, usually $HOME/.jumppad/environments
+func EnvironmentsDir() string {
+	return filepath.Join(JumppadHome(), "/environments")
+}
+
+// EnvironmentHome returns the folder that contains the state, data and logs
+// for the current environment
+func EnvironmentHome() string {
+	return environmentHome(Environment())
+}
+
+func environmentHome(name string) string {
+	if name == DefaultEnvironment {
+		return JumppadHome()
+	}
+
+	return filepath.Join(EnvironmentsDir(), name)
+}
+
+// ValidateEnvironment checks that name can be used as an environment name
+func ValidateEnvironment(name string) error {
+	if _, err := ValidateName(name); err != nil {
+		return fmt.Errorf("invalid environment name %s: %s", name, err)
+	}
+
+	return nil
+}
+
+// SelectEnvironment sets the environment used by future commands
+func SelectEnvironment(name string) error {
+	err := ValidateEnvironment(name)
+	if err != nil {
+		return err
+	}
+
+	if name == DefaultEnvironment {
+		err := os.Remove(selectedEnvironmentPath())
+		if err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("unable to select environment: %s", err)
+		}
+
+		return nil
+	}
+
+	err = os.MkdirAll(environmentHome(name), os.ModePerm)
+	if err != nil {
+		return fmt.Errorf("unable to create environment: %s", err)
+	}
+
+	err = os.WriteFile(selectedEnvironmentPath(), []byte(name), 0644)
+	if err != nil {
+		return fmt.Errorf("unable to select environment: %s", err)
+	}
+
+	return nil
+}
+
+// ListEnvironments returns the names of the environments, the default
+// environment is always returned
+func ListEnvironments() ([]string, error) {
+	envs := []string{DefaultEnvironment}
+
+	entries, err := os.ReadDir(EnvironmentsDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("unable to read environments: %s", err)
+	}
+
+	for _, e := range entries {
+		if e.IsDir() && e.Name() != DefaultEnvironment {
+			envs = append(envs, e.Name())
+		}
+	}
+
+	sort.Strings(envs[1:])
+
+	return envs, nil
+}
+
+// EnvironmentHasState returns true when the environment has a state file,
+// i.e. it has resources that have not been destroyed
+func EnvironmentHasState(name string) bool {
+	_, err := os.Stat(EnvironmentStatePath(name))
+	return err == nil
+}
+
+// EnvironmentStatePath returns the path of the state file for the named
+// environment
+func EnvironmentStatePath(name string) string {
+	return filepath.Join(environmentHome(name), "/state", "/state.json")
+}
+
+// DeleteEnvironment removes the state, data and logs for the environment,
+// when the environment is selected the default environment is selected
+func DeleteEnvironment(name string) error {
+	if name == DefaultEnvironment {
+		return fmt.Errorf("the default environment can not be deleted")
+	}
+
+	err := ValidateEnvironment(name)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(environmentHome(name)); err != nil {
+		return fmt.Errorf("environment %s does not exist", name)
+	}
+
+	err = os.RemoveAll(environmentHome(name))
+	if err != nil {
+		return fmt.Errorf("unable to delete environment %s: %s", name, err)
+	}
+
+	d, _ := os.ReadFile(selectedEnvironmentPath())
+	if strings.TrimSpace(string(d)) == name {
+		return SelectEnvironment(DefaultEnvironment)
+	}
+
+	return nil
+}
+
+// selectedEnvironmentPath is the file that stores the selected environment
+func selectedEnvironmentPath() string {
+	return filepath.Join(JumppadHome(), "/environment")
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func setupEnvironmentHome(t *testing.T) string {
+	tmp := t.TempDir()
+	t.Setenv(HomeEnvName(), tmp)
+	t.Setenv(EnvironmentEnvVar, "")
+
+	return tmp
+}
+
+func TestEnvironmentReturnsDefault(t *testing.T) {
+	tmp := setupEnvironmentHome(t)
+
+	require.Equal(t, DefaultEnvironment, Environment())
+	require.Equal(t, filepath.Join(tmp, ".jumppad"), EnvironmentHome())
+	require.Equal(t, filepath.Join(tmp, ".jumppad", "state", "state.json"), StatePath())
+}
+
+func TestEnvironmentReturnsEnvVar(t *testing.T) {
+	tmp := setupEnvironmentHome(t)
+	t.Setenv(EnvironmentEnvVar, "staging")
+
+	require.Equal(t, "staging", Environment())
+	require.Equal(t, filepath.Join(tmp, ".jumppad", "environments", "staging", "state", "state.json"), StatePath())
+	require.Equal(t, filepath.Join(tmp, ".jumppad", "environments", "staging", "data", "test"), DataFolder("test", 0775))
+}
+
+func TestSelectEnvironmentSetsEnvironment(t *testing.T) {
+	tmp := setupEnvironmentHome(t)
+
+	err := SelectEnvironment("demo")
+	require.NoError(t, err)
+
+	require.Equal(t, "demo", Environment())
+	require.DirExists(t, filepath.Join(tmp, ".jumppad", "environments", "demo"))
+
+	err = SelectEnvironment(DefaultEnvironment)
+	require.NoError(t, err)
+
+	require.Equal(t, DefaultEnvironment, Environment())
+}
+
+func TestSelectEnvironmentWithInvalidNameReturnsError(t *testing.T) {
+	setupEnvironmentHome(t)
+
+	err := SelectEnvironment("../demo")
+	require.ErrorContains(t, err, "invalid environment name")
+}
+
+func TestListEnvironmentsReturnsDefaultFirst(t *testing.T) {
+	setupEnvironmentHome(t)
+
+	require.NoError(t, SelectEnvironment("zeta"))
+	require.NoError(t, SelectEnvironment("alpha"))
+
+	envs, err := ListEnvironments()
+	require.NoError(t, err)
+	require.Equal(t, []string{DefaultEnvironment, "alpha", "zeta"}, envs)
+}
+
+func TestDeleteEnvironmentRemovesEnvironmentAndSelectsDefault(t *testing.T) {
+	tmp := setupEnvironmentHome(t)
+
+	require.NoError(t, SelectEnvironment("demo"))
+	os.MkdirAll(StateDir(), os.ModePerm)
+	os.WriteFile(StatePath(), []byte("{}"), 0644)
+	require.True(t, EnvironmentHasState("demo"))
+
+	err := DeleteEnvironment("demo")
+	require.NoError(t, err)
+
+	require.NoDirExists(t, filepath.Join(tmp, ".jumppad", "environments", "demo"))
+	require.Equal(t, DefaultEnvironment, Environment())
+}
+
+func TestDeleteEnvironmentWithDefaultReturnsError(t *testing.T) {
+	setupEnvironmentHome(t)
+
+	err := DeleteEnvironment(DefaultEnvironment)
+	require.ErrorContains(t, err, "can not be deleted")
+}
+
+func TestDeleteEnvironmentWithMissingEnvironmentReturnsError(t *testing.T) {
+	setupEnvironmentHome(t)
+
+	err := DeleteEnvironment("missing")
+	require.ErrorContains(t, err, "environment missing does not exist")
+}
+
+func TestEnvironmentNamesAreNotChangedForDefault(t *testing.T) {
+	setupEnvironmentHome(t)
+	t.Setenv("IMAGE_CACHE_ADDR", "")
+
+	require.Equal(t, "onprem", EnvironmentName("onprem"))
+	require.Equal(t, "web.container.local.jmpd.in", FQDN("web", "", "container"))
+	require.Equal(t, "images.volume.jmpd.in", FQDNVolumeName("images"))
+	require.Equal(t, "http://default.image-cache.local.jmpd.in:3128", ImageCacheAddress())
+}
+
+func TestEnvironmentNamesContainTheEnvironment(t *testing.T) {
+	setupEnvironmentHome(t)
+	t.Setenv(EnvironmentEnvVar, "staging")
+	t.Setenv("IMAGE_CACHE_ADDR", "")
+
+	require.Equal(t, "onprem.staging", EnvironmentName("onprem"))
+	require.Equal(t, "web.container.staging.local.jmpd.in", FQDN("web", "", "container"))
+	require.Equal(t, "web.db.container.staging.local.jmpd.in", FQDN("web", "db", "container"))
+	require.Equal(t, "images.staging.volume.jmpd.in", FQDNVolumeName("images"))
+	require.Equal(t, "http://default.image-cache.staging.local.jmpd.in:3128", ImageCacheAddress())
+}
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// windowsDrivePath matches paths such as C:\Users\nic or C:/Users/nic
+var windowsDrivePath = regexp.MustCompile(`^([A-Za-z]):(?:[\\/](.*))?$`)
+
+// unixDrivePath matches the paths used by WSL (/mnt/c/Users/nic) and Git Bash
+// (/c/Users/nic) for files on a Windows drive
+var unixDrivePath = regexp.MustCompile(`^/(?:mnt/)?([A-Za-z])(?:/(.*))?$`)
+
+// wslSharePath matches the paths Windows uses for the files inside a WSL
+// distribution, e.g. \\wsl$\Ubuntu\home\nic
+var wslSharePath = regexp.MustCompile(`(?i)^//(?:wsl\$|wsl\.localhost)/([^/]+)(/.*)?$`)
+
+// hostPlatform describes the host jumppad is running on
+type hostPlatform struct {
+	os string
+	// wslDistro is the name of the WSL distribution when jumppad runs
+	// inside WSL, it is empty otherwise
+	wslDistro string
+}
+
+// IsWSL returns true when jumppad is running inside Windows Subsystem for
+// Linux
+func IsWSL() bool {
+	return wslDistro() != ""
+}
+
+func wslDistro() string {
+	if runtime.GOOS != "linux" {
+		return ""
+	}
+
+	if d := os.Getenv("WSL_DISTRO_NAME"); d != "" {
+		return d
+	}
+
+	r, err := os.ReadFile("/proc/sys/kernel/osrelease")
+	if err == nil && strings.Contains(strings.ToLower(string(r)), "microsoft") {
+		return "wsl"
+	}
+
+	return ""
+}
+
+// TranslateHostPath converts a path on the host to a path that Docker
+// Desktop can share with a container. Windows drive paths are converted to
+// /mnt/[drive] paths inside WSL, WSL and Git Bash drive paths are converted to
+// Windows paths on Windows. An error is returned when the path can not be
+// shared, e.g. a Windows path on Linux or a network share.
+func TranslateHostPath(path string) (string, error) {
+	return hostPlatform{os: runtime.GOOS, wslDistro: wslDistro()}.translate(path)
+}
+
+// VolumeSource returns the absolute path for the source of a bind mount,
+// relative paths are resolved from the directory of file and the path is
+// translated so that it can be shared with Docker
+func VolumeSource(path, file string) (string, error) {
+	p, err := TranslateHostPath(path)
+	if err != nil {
+		return "", err
+	}
+
+	return EnsureAbsolute(p, file), nil
+}
+
+func (h hostPlatform) translate(path string) (string, error) {
+	// the docker socket is not a path on the host
+	if path == "" || path == GetDockerHost() {
+		return path, nil
+	}
+
+	slashed := strings.ReplaceAll(path, `\`, "/")
+
+	if strings.HasPrefix(slashed, "//") {
+		return h.translateShare(path, slashed)
+	}
+
+	drive, rest, isDrive := "", "", false
+	if m := windowsDrivePath.FindStringSubmatch(slashed); m != nil {
+		drive, rest, isDrive = m[1], m[2], true
+	}
+
+	switch {
+	case h.os == "windows":
+		if !isDrive {
+			// paths written for WSL or Git Bash
+			m := unixDrivePath.FindStringSubmatch(slashed)
+			if m == nil {
+				return path, nil
+			}
+
+			drive, rest = m[1], m[2]
+		}
+
+		return strings.ToUpper(drive) + `:\` + strings.ReplaceAll(rest, "/", `\`), nil
+	case h.wslDistro != "":
+		if !isDrive {
+			return path, nil
+		}
+
+		return strings.TrimSuffix("/mnt/"+strings.ToLower(drive)+"/"+rest, "/"), nil
+	case isDrive:
+		return "", fmt.Errorf("path %s is a Windows path and can not be shared with Docker on %s", path, h.os)
+	}
+
+	return path, nil
+}
+
+// translateShare converts the paths of Windows network shares, only the
+// files of the WSL distribution jumppad is running in can be shared
+func (h hostPlatform) translateShare(path, slashed string) (string, error) {
+	m := wslSharePath.FindStringSubmatch(slashed)
+	if m == nil {
+		return "", fmt.Errorf("path %s is a network share, only paths on a local drive can be shared with Docker", path)
+	}
+
+	if h.wslDistro != "" && strings.EqualFold(m[1], h.wslDistro) {
+		if m[2] == "" {
+			return "/", nil
+		}
+
+		return m[2], nil
+	}
+
+	return "", fmt.Errorf("path %s is inside the WSL distribution %s and can only be shared with Docker when jumppad is run inside the distribution", path, m[1])
+}
@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var windowsHost = hostPlatform{os: "windows"}
+var wslHost = hostPlatform{os: "linux", wslDistro: "Ubuntu"}
+var linuxHost = hostPlatform{os: "linux"}
+
+func TestTranslateOnWindowsNormalizesDrivePaths(t *testing.T) {
+	p, err := windowsHost.translate(`c:/Users/nic/files`)
+	require.NoError(t, err)
+
+	require.Equal(t, `C:\Users\nic\files`, p)
+}
+
+func TestTranslateOnWindowsConvertsWSLPaths(t *testing.T) {
+	p, err := windowsHost.translate(`/mnt/d/projects/app`)
+	require.NoError(t, err)
+
+	require.Equal(t, `D:\projects\app`, p)
+}
+
+func TestTranslateOnWindowsConvertsGitBashPaths(t *testing.T) {
+	p, err := windowsHost.translate(`\c\Users\nic`)
+	require.NoError(t, err)
+
+	require.Equal(t, `C:\Users\nic`, p)
+}
+
+func TestTranslateOnWindowsDoesNotChangeRelativePaths(t *testing.T) {
+	p, err := windowsHost.translate(`./files`)
+	require.NoError(t, err)
+
+	require.Equal(t, `./files`, p)
+}
+
+func TestTranslateOnWindowsWithNetworkShareReturnsError(t *testing.T) {
+	_, err := windowsHost.translate(`\\fileserver\share\files`)
+	require.ErrorContains(t, err, "is a network share")
+}
+
+func TestTranslateOnWindowsWithWSLShareReturnsError(t *testing.T) {
+	_, err := windowsHost.translate(`\\wsl$\Ubuntu\home\nic`)
+	require.ErrorContains(t, err, "inside the WSL distribution Ubuntu")
+}
+
+func TestTranslateInWSLConvertsWindowsPaths(t *testing.T) {
+	p, err := wslHost.translate(`C:\Users\nic\files`)
+	require.NoError(t, err)
+
+	require.Equal(t, `/mnt/c/Users/nic/files`, p)
+}
+
+func TestTranslateInWSLConvertsDrive(t *testing.T) {
+	p, err := wslHost.translate(`D:\`)
+	require.NoError(t, err)
+
+	require.Equal(t, `/mnt/d`, p)
+}
+
+func TestTranslateInWSLConvertsShareForCurrentDistribution(t *testing.T) {
+	p, err := wslHost.translate(`\\wsl.localhost\ubuntu\home\nic`)
+	require.NoError(t, err)
+
+	require.Equal(t, `/home/nic`, p)
+}
+
+func TestTranslateInWSLWithShareForOtherDistributionReturnsError(t *testing.T) {
+	_, err := wslHost.translate(`\\wsl$\Debian\home\nic`)
+	require.ErrorContains(t, err, "inside the WSL distribution Debian")
+}
+
+func TestTranslateInWSLDoesNotChangeLinuxPaths(t *testing.T) {
+	p, err := wslHost.translate(`/home/nic/files`)
+	require.NoError(t, err)
+
+	require.Equal(t, `/home/nic/files`, p)
+}
+
+func TestTranslateOnLinuxDoesNotChangePaths(t *testing.T) {
+	p, err := linuxHost.translate(`/c/files`)
+	require.NoError(t, err)
+
+	require.Equal(t, `/c/files`, p)
+}
+
+func TestTranslateOnLinuxWithWindowsPathReturnsError(t *testing.T) {
+	_, err := linuxHost.translate(`C:\Users\nic`)
+	require.ErrorContains(t, err, "is a Windows path and can not be shared with Docker on linux")
+}
+
+func TestTranslateDoesNotChangeDockerHost(t *testing.T) {
+	p, err := windowsHost.translate(GetDockerHost())
+	require.NoError(t, err)
+
+	require.Equal(t, GetDockerHost(), p)
+}
+
+func TestVolumeSourceReturnsAbsolutePath(t *testing.T) {
+	dir := t.TempDir()
+
+	p, err := VolumeSource("./files", dir)
+	require.NoError(t, err)
+
+	require.Equal(t, filepath.Join(dir, "files"), p)
+}
@@ -0,0 +1,136 @@
+package utils
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// CABundleEnvVar is the environment variable containing the path of a PEM
+// encoded bundle of CA certificates that are trusted in addition to the
+// system roots, e.g. the CA of a corporate proxy
+const CABundleEnvVar = "JUMPPAD_CA_BUNDLE"
+
+// CABundleContainerPath is the location the CA bundle is mounted in cluster
+// nodes, Go programs such as containerd and Docker load the certificates in
+// /etc/ssl/certs in addition to the system bundle
+const CABundleContainerPath = "/etc/ssl/certs/jumppad-ca-bundle.pem"
+
+// RegistryMirrorsEnvVar is the environment variable containing a comma
+// separated list of registry mirrors that clusters use to pull images from
+// Docker Hub, e.g. the local image cache or a corporate Artifactory
+const RegistryMirrorsEnvVar = "JUMPPAD_REGISTRY_MIRRORS"
+
+// proxyEnvVars are the standard environment variables used to configure a
+// proxy
+var proxyEnvVars = []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY"}
+
+// CABundle returns the path of the custom CA bundle, an empty string is
+// returned when no bundle has been configured
+func CABundle() string {
+	return os.Getenv(CABundleEnvVar)
+}
+
+// CertPool returns the system certificate pool with the certificates from
+// the CA bundle appended
+func CertPool() (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	bundle := CABundle()
+	if bundle == "" {
+		return pool, nil
+	}
+
+	d, err := os.ReadFile(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read CA bundle %s: %s", bundle, err)
+	}
+
+	if !pool.AppendCertsFromPEM(d) {
+		return nil, fmt.Errorf("unable to read CA bundle %s: no PEM encoded certificates found", bundle)
+	}
+
+	return pool, nil
+}
+
+// HTTPTransport returns a transport that uses the proxy set in the
+// environment and trusts the certificates in the CA bundle, when the bundle
+// can not be read the system roots are used
+func HTTPTransport() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.Proxy = http.ProxyFromEnvironment
+
+	if CABundle() == "" {
+		return t
+	}
+
+	pool, err := CertPool()
+	if err != nil {
+		return t
+	}
+
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+
+	t.TLSClientConfig.RootCAs = pool
+
+	return t
+}
+
+// ProxyEnvironment returns the proxy environment variables that are set, the
+// variables are returned in upper and lower case as not all programs read
+// the upper case variables
+func ProxyEnvironment() map[string]string {
+	env := map[string]string{}
+
+	for _, k := range proxyEnvVars {
+		v := os.Getenv(k)
+		if v == "" {
+			v = os.Getenv(strings.ToLower(k))
+		}
+
+		if v == "" {
+			continue
+		}
+
+		env[k] = v
+		env[strings.ToLower(k)] = v
+	}
+
+	return env
+}
+
+// NoProxy returns the hosts in the NO_PROXY environment variable
+func NoProxy() []string {
+	np := os.Getenv("NO_PROXY")
+	if np == "" {
+		np = os.Getenv("no_proxy")
+	}
+
+	return splitList(np)
+}
+
+// RegistryMirrors returns the registry mirrors for Docker Hub, an empty slice
+// is returned when no mirrors have been configured
+func RegistryMirrors() []string {
+	return splitList(os.Getenv(RegistryMirrorsEnvVar))
+}
+
+// splitList returns the non empty items in a comma separated list
+func splitList(l string) []string {
+	items := []string{}
+	for _, i := range strings.Split(l, ",") {
+		if i = strings.TrimSpace(i); i != "" {
+			items = append(items, i)
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeTestCA(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Corporate Proxy CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644)
+
+	return path
+}
+
+func TestCertPoolAppendsCABundle(t *testing.T) {
+	t.Setenv(CABundleEnvVar, writeTestCA(t))
+
+	pool, err := CertPool()
+	require.NoError(t, err)
+
+	system, _ := x509.SystemCertPool()
+	require.True(t, len(pool.Subjects()) > len(system.Subjects()))
+}
+
+func TestCertPoolWithInvalidBundleReturnsError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	os.WriteFile(path, []byte("not a certificate"), 0644)
+	t.Setenv(CABundleEnvVar, path)
+
+	_, err := CertPool()
+	require.ErrorContains(t, err, "no PEM encoded certificates found")
+}
+
+func TestHTTPTransportTrustsCABundle(t *testing.T) {
+	t.Setenv(CABundleEnvVar, writeTestCA(t))
+
+	tr := HTTPTransport()
+
+	require.NotNil(t, tr.Proxy)
+	require.NotNil(t, tr.TLSClientConfig.RootCAs)
+}
+
+func TestHTTPTransportWithoutCABundleUsesSystemRoots(t *testing.T) {
+	t.Setenv(CABundleEnvVar, "")
+
+	tr := HTTPTransport()
+
+	if tr.TLSClientConfig != nil {
+		require.Nil(t, tr.TLSClientConfig.RootCAs)
+	}
+}
+
+func TestProxyEnvironmentReturnsUpperAndLowerCase(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "")
+	t.Setenv("http_proxy", "http://proxy:3128")
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("https_proxy", "")
+	t.Setenv("NO_PROXY", "localhost")
+
+	env := ProxyEnvironment()
+
+	require.Equal(t, map[string]string{
+		"HTTP_PROXY": "http://proxy:3128",
+		"http_proxy": "http://proxy:3128",
+		"NO_PROXY":   "localhost",
+		"no_proxy":   "localhost",
+	}, env)
+}
+
+func TestNoProxyReturnsHosts(t *testing.T) {
+	t.Setenv("NO_PROXY", "localhost, .corp.com,,10.0.0.0/8")
+
+	require.Equal(t, []string{"localhost", ".corp.com", "10.0.0.0/8"}, NoProxy())
+}
+
+func TestRegistryMirrorsReturnsMirrors(t *testing.T) {
+	t.Setenv(RegistryMirrorsEnvVar, "https://mirror.gcr.io, http://10.5.0.2:5000")
+
+	require.Equal(t, []string{"https://mirror.gcr.io", "http://10.5.0.2:5000"}, RegistryMirrors())
+}
+
+func TestRegistryMirrorsWithoutMirrorsReturnsEmpty(t *testing.T) {
+	t.Setenv(RegistryMirrorsEnvVar, "")
+
+	require.Empty(t, RegistryMirrors())
+}
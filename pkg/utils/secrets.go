@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SecretsKeyEnvVar allows the key used to encrypt secrets to be set
+// from the environment, the value is a base64 encoded 32 byte key
+const SecretsKeyEnvVar = "JUMPPAD_SECRETS_KEY"
+
+// encryptedPrefix identifies values that have been encrypted by EncryptSecret
+const encryptedPrefix = "jumppad:enc:v1:"
+
+// Redacted replaces sensitive values in output
+const Redacted = "(sensitive)"
+
+var sensitiveValues = map[string]bool{}
+var sensitiveMutex = sync.RWMutex{}
+
+// secretWarnings receives the warnings for values that can not be decrypted
+var secretWarnings io.Writer = os.Stderr
+
+// secretsKeyMutex guards the generation of the secrets key so that
+// concurrent callers do not generate different keys
+var secretsKeyMutex = sync.Mutex{}
+
+// secretsKey returns the key used to encrypt secrets, if the key does not
+// exist a new key is generated and written to SecretsKeyPath
+func secretsKey() ([]byte, error) {
+	if k := os.Getenv(SecretsKeyEnvVar); k != "" {
+		key, err := base64.StdEncoding.DecodeString(k)
+		if err != nil || len(key) != 32 {
+			return nil, fmt.Errorf("%s must be a base64 encoded 32 byte key", SecretsKeyEnvVar)
+		}
+
+		return key, nil
+	}
+
+	secretsKeyMutex.Lock()
+	defer secretsKeyMutex.Unlock()
+
+	key, err := readSecretsKey()
+	if err == nil {
+		return key, nil
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	// generate a new key
+	key = make([]byte, 32)
+	_, err = rand.Read(key)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate secrets key: %s", err)
+	}
+
+	err = os.MkdirAll(filepath.Dir(SecretsKeyPath()), os.ModePerm)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create directory for secrets key: %s", err)
+	}
+
+	// the key is written to a temporary file and linked into place so that
+	// the key file is never empty or partially written. Another jumppad
+	// process may be generating the key at the same time, only one link
+	// succeeds, the others use its key
+	f, err := os.CreateTemp(filepath.Dir(SecretsKeyPath()), ".secrets-key-*")
+	if err != nil {
+		return nil, fmt.Errorf("unable to write secrets key %s: %s", SecretsKeyPath(), err)
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.WriteString(base64.StdEncoding.EncodeToString(key))
+	if err == nil {
+		err = f.Sync()
+	}
+
+	f.Close()
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to write secrets key %s: %s", SecretsKeyPath(), err)
+	}
+
+	err = os.Link(f.Name(), SecretsKeyPath())
+	if os.IsExist(err) {
+		return readSecretsKey()
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to write secrets key %s: %s", SecretsKeyPath(), err)
+	}
+
+	return key, nil
+}
+
+// readSecretsKey reads the key from SecretsKeyPath, an error satisfying
+// os.IsNotExist is returned when the key has not been generated
+func readSecretsKey() ([]byte, error) {
+	d, err := os.ReadFile(SecretsKeyPath())
+	if os.IsNotExist(err) {
+		return nil, err
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to read secrets key %s: %s", SecretsKeyPath(), err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(d)))
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("invalid secrets key %s", SecretsKeyPath())
+	}
+
+	return key, nil
+}
+
+// EncryptSecret encrypts the value with AES-GCM using the local secrets key
+func EncryptSecret(value string) (string, error) {
+	key, err := secretsKey()
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return "", fmt.Errorf("unable to generate nonce: %s", err)
+	}
+
+	data := gcm.Seal(nonce, nonce, []byte(value), nil)
+
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// DecryptSecret decrypts a value encrypted by EncryptSecret, values that
+// are not encrypted are returned unchanged
+func DecryptSecret(value string) (string, error) {
+	if !IsEncryptedSecret(value) {
+		return value, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(value, encryptedPrefix))
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted value: %s", err)
+	}
+
+	key, err := secretsKey()
+	if err != nil {
+		return "", err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return "", err
+	}
+
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted value")
+	}
+
+	d, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt value, the secrets key may have changed: %s", err)
+	}
+
+	return string(d), nil
+}
+
+// DecryptSecretOrEmpty decrypts a value encrypted by EncryptSecret. When the
+// value can not be decrypted, because the secrets key has been removed or
+// rotated, a warning is written and an empty value is returned so that the
+// state can still be loaded.
+func DecryptSecretOrEmpty(id, value string) string {
+	dec, err := DecryptSecret(value)
+	if err != nil {
+		fmt.Fprintf(secretWarnings, "Warning: unable to decrypt the sensitive values for %s, the values have been reset: %s\n", id, err)
+		return ""
+	}
+
+	return dec
+}
+
+// IsEncryptedSecret returns true when the value has been encrypted by
+// EncryptSecret
+func IsEncryptedSecret(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix)
+}
+
+// RegisterSensitive registers a value that should be redacted from output
+// and encrypted in the state, empty values are ignored
+func RegisterSensitive(value string) {
+	if value == "" {
+		return
+	}
+
+	sensitiveMutex.Lock()
+	defer sensitiveMutex.Unlock()
+
+	sensitiveValues[value] = true
+}
+
+// Redact replaces any registered sensitive values in s
+func Redact(s string) string {
+	sensitiveMutex.RLock()
+	defer sensitiveMutex.RUnlock()
+
+	if len(sensitiveValues) == 0 {
+		return s
+	}
+
+	// replace the longest values first so that values containing other
+	// values are fully redacted
+	values := make([]string, 0, len(sensitiveValues))
+	for v := range sensitiveValues {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return len(values[i]) > len(values[j]) })
+
+	for _, v := range values {
+		s = strings.ReplaceAll(s, v, Redacted)
+	}
+
+	return s
+}
+
+// HasSensitive returns true when any sensitive values have been registered
+func HasSensitive() bool {
+	sensitiveMutex.RLock()
+	defer sensitiveMutex.RUnlock()
+
+	return len(sensitiveValues) > 0
+}
+
+// ContainsSensitive returns true when s contains a registered sensitive value
+func ContainsSensitive(s string) bool {
+	return Redact(s) != s
+}
@@ -0,0 +1,170 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptSecretGeneratesKey(t *testing.T) {
+	t.Setenv(HomeEnvName(), t.TempDir())
+	t.Setenv(SecretsKeyEnvVar, "")
+
+	enc, err := EncryptSecret("abc123")
+	require.NoError(t, err)
+	require.True(t, IsEncryptedSecret(enc))
+	require.NotContains(t, enc, "abc123")
+
+	fi, err := os.Stat(SecretsKeyPath())
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+
+	dec, err := DecryptSecret(enc)
+	require.NoError(t, err)
+	require.Equal(t, "abc123", dec)
+}
+
+func TestEncryptSecretConcurrentlyGeneratesOneKey(t *testing.T) {
+	t.Setenv(HomeEnvName(), t.TempDir())
+	t.Setenv(SecretsKeyEnvVar, "")
+
+	encs := make([]string, 10)
+	wg := sync.WaitGroup{}
+	for i := range encs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			encs[i], _ = EncryptSecret("abc123")
+		}()
+	}
+	wg.Wait()
+
+	// every value must be encrypted with the key that was written
+	for _, enc := range encs {
+		dec, err := DecryptSecret(enc)
+		require.NoError(t, err)
+		require.Equal(t, "abc123", dec)
+	}
+}
+
+func TestEncryptSecretDoesNotLeaveTemporaryKeyFiles(t *testing.T) {
+	t.Setenv(HomeEnvName(), t.TempDir())
+	t.Setenv(SecretsKeyEnvVar, "")
+
+	_, err := EncryptSecret("abc123")
+	require.NoError(t, err)
+
+	files, err := os.ReadDir(filepath.Dir(SecretsKeyPath()))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, filepath.Base(SecretsKeyPath()), files[0].Name())
+}
+
+func TestEncryptSecretUsesExistingKeyFile(t *testing.T) {
+	t.Setenv(HomeEnvName(), t.TempDir())
+	t.Setenv(SecretsKeyEnvVar, "")
+
+	key := base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{1}, 32))
+	require.NoError(t, os.MkdirAll(filepath.Dir(SecretsKeyPath()), os.ModePerm))
+	require.NoError(t, os.WriteFile(SecretsKeyPath(), []byte(key), 0600))
+
+	_, err := EncryptSecret("abc123")
+	require.NoError(t, err)
+
+	d, err := os.ReadFile(SecretsKeyPath())
+	require.NoError(t, err)
+	require.Equal(t, key, string(d))
+}
+
+func TestEncryptSecretUsesKeyFromEnvironment(t *testing.T) {
+	t.Setenv(HomeEnvName(), t.TempDir())
+	t.Setenv(SecretsKeyEnvVar, base64.StdEncoding.EncodeToString(make([]byte, 32)))
+
+	enc, err := EncryptSecret("abc123")
+	require.NoError(t, err)
+
+	require.NoFileExists(t, SecretsKeyPath())
+
+	dec, err := DecryptSecret(enc)
+	require.NoError(t, err)
+	require.Equal(t, "abc123", dec)
+}
+
+func TestEncryptSecretReturnsErrorWhenKeyInvalid(t *testing.T) {
+	t.Setenv(SecretsKeyEnvVar, "abc")
+
+	_, err := EncryptSecret("abc123")
+	require.Error(t, err)
+}
+
+func TestDecryptSecretReturnsPlainValues(t *testing.T) {
+	dec, err := DecryptSecret("abc123")
+	require.NoError(t, err)
+	require.Equal(t, "abc123", dec)
+}
+
+func TestDecryptSecretReturnsErrorWhenKeyChanged(t *testing.T) {
+	t.Setenv(HomeEnvName(), t.TempDir())
+	t.Setenv(SecretsKeyEnvVar, "")
+
+	enc, err := EncryptSecret("abc123")
+	require.NoError(t, err)
+
+	os.Remove(SecretsKeyPath())
+
+	_, err = DecryptSecret(enc)
+	require.Error(t, err)
+}
+
+func TestRedactReplacesSensitiveValues(t *testing.T) {
+	RegisterSensitive("s3cr3t")
+	RegisterSensitive("s3cr3t-token")
+
+	require.Equal(t, "token=(sensitive) pass=(sensitive)", Redact("token=s3cr3t-token pass=s3cr3t"))
+	require.True(t, ContainsSensitive("s3cr3t"))
+	require.False(t, ContainsSensitive("public"))
+}
+
+func TestDecryptSecretOrEmptyReturnsEmptyWhenKeyChanged(t *testing.T) {
+	t.Setenv(HomeEnvName(), t.TempDir())
+	t.Setenv(SecretsKeyEnvVar, "")
+
+	w := bytes.NewBuffer(nil)
+	secretWarnings = w
+	t.Cleanup(func() { secretWarnings = os.Stderr })
+
+	enc, err := EncryptSecret("abc123")
+	require.NoError(t, err)
+
+	os.Remove(SecretsKeyPath())
+
+	require.Equal(t, "", DecryptSecretOrEmpty("resource.secret.db", enc))
+	require.Contains(t, w.String(), "resource.secret.db")
+}
+
+func TestDecryptSecretOrEmptyReturnsDecryptedValue(t *testing.T) {
+	t.Setenv(HomeEnvName(), t.TempDir())
+	t.Setenv(SecretsKeyEnvVar, "")
+
+	enc, err := EncryptSecret("abc123")
+	require.NoError(t, err)
+
+	require.Equal(t, "abc123", DecryptSecretOrEmpty("resource.secret.db", enc))
+}
+
+func TestRegisterSensitiveRegistersShortValues(t *testing.T) {
+	RegisterSensitive("Zq")
+
+	require.Equal(t, "pin=(sensitive)", Redact("pin=Zq"))
+}
+
+func TestRegisterSensitiveIgnoresEmptyValues(t *testing.T) {
+	RegisterSensitive("")
+
+	require.Equal(t, "name=", Redact("name="))
+}
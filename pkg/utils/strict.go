@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"os"
+	"strconv"
+)
+
+// StrictEnvVar is the environment variable that enables strict checking of
+// blueprints by default, it is set from the strict setting
+const StrictEnvVar = "JUMPPAD_STRICT"
+
+// StrictMode returns true when strict checking has been enabled with the
+// strict setting or the JUMPPAD_STRICT environment variable
+func StrictMode() bool {
+	b, _ := strconv.ParseBool(os.Getenv(StrictEnvVar))
+	return b
+}
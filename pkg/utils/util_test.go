@@ -3,6 +3,7 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"path/filepath"
 	"testing"
@@ -254,10 +255,25 @@ func TestGetLocalIPAndHostnameReturnsCorrectly(t *testing.T) {
 	require.NotEqual(t, host, "localhost")
 }
 
+func TestValidateSubnetIPWithValidAddressReturnsNoError(t *testing.T) {
+	require.NoError(t, ValidateSubnetIP("10.0.0.10", "10.0.0.0/24"))
+	require.NoError(t, ValidateSubnetIP("fd00::10", "fd00::/64"))
+}
+
+func TestValidateSubnetIPWithAddressOutsideSubnetReturnsError(t *testing.T) {
+	err := ValidateSubnetIP("10.0.1.10", "10.0.0.0/24")
+	require.ErrorContains(t, err, "not in the subnet")
+}
+
+func TestValidateSubnetIPWithNetworkOrBroadcastAddressReturnsError(t *testing.T) {
+	require.ErrorContains(t, ValidateSubnetIP("10.0.0.0", "10.0.0.0/24"), "network address")
+	require.ErrorContains(t, ValidateSubnetIP("10.0.0.255", "10.0.0.0/24"), "broadcast address")
+}
+
 func TestImageCacheAddressReturnsDefaultWhenEnvNotSet(t *testing.T) {
 	proxy := ImageCacheAddress()
 
-	require.Equal(t, jumppadProxyAddress, proxy)
+	require.Equal(t, "http://default.image-cache.local.jmpd.in:3128", proxy)
 }
 
 func TestImageCacheAddressReturnsEnvWhenEnvSet(t *testing.T) {
@@ -292,3 +308,31 @@ func TestChecksumInterface(t *testing.T) {
 
 	require.Equal(t, "h1:kpp5xuYieKQMhbtP0+Y6N+dUzx9p9pGq9+WXkgbK6fs=", c)
 }
+
+func TestFreePortReturnsAvailablePort(t *testing.T) {
+	for _, proto := range []string{"tcp", "udp"} {
+		p, err := FreePort(proto)
+		require.NoError(t, err)
+
+		require.Greater(t, p, 0)
+		require.True(t, PortAvailable(proto, p))
+	}
+}
+
+func TestPortAvailableReturnsFalseWhenInUse(t *testing.T) {
+	ln, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	require.False(t, PortAvailable("tcp", ln.Addr().(*net.TCPAddr).Port))
+}
+
+func TestIsDevModeReturnsTrueWhenSet(t *testing.T) {
+	t.Setenv(DevModeEnvVar, "true")
+	require.True(t, IsDevMode())
+}
+
+func TestIsDevModeReturnsFalseWhenNotSet(t *testing.T) {
+	t.Setenv(DevModeEnvVar, "")
+	require.False(t, IsDevMode())
+}
@@ -96,9 +96,14 @@ func ReplaceNonURIChars(s string) (string, error) {
 
 // FQDN generates the full qualified name for a container
 func FQDN(name, module, typeName string) string {
-	fqdn := fmt.Sprintf("%s.%s.local.%s", name, typeName, LocalTLD)
+	domain := fmt.Sprintf("local.%s", LocalTLD)
+	if e := Environment(); e != DefaultEnvironment {
+		domain = fmt.Sprintf("%s.%s", e, domain)
+	}
+
+	fqdn := fmt.Sprintf("%s.%s.%s", name, typeName, domain)
 	if module != "" {
-		fqdn = fmt.Sprintf("%s.%s.%s.local.%s", name, module, typeName, LocalTLD)
+		fqdn = fmt.Sprintf("%s.%s.%s.%s", name, module, typeName, domain)
 	}
 
 	// ensure that the name is valid for URI schema
@@ -113,7 +118,7 @@ func FQDN(name, module, typeName string) string {
 // FQDNVolumeName creates a full qualified volume name
 func FQDNVolumeName(name string) string {
 	// ensure that the name is valid for URI schema
-	cleanName, err := ReplaceNonURIChars(name)
+	cleanName, err := ReplaceNonURIChars(EnvironmentName(name))
 	if err != nil {
 		panic(err)
 	}
@@ -153,15 +158,24 @@ func HomeEnvName() string {
 	return "HOME"
 }
 
+// DevModeEnvVar is set by `jumppad dev` so that providers can enable
+// behaviour that is only used while authoring a blueprint
+const DevModeEnvVar = "JUMPPAD_DEV"
+
+// IsDevMode returns true when jumppad is running under `jumppad dev`
+func IsDevMode() bool {
+	return os.Getenv(DevModeEnvVar) == "true"
+}
+
 // JumppadHome returns the location of the jumppad
 // folder, usually $HOME/.jumppad
 func JumppadHome() string {
 	return filepath.Join(HomeFolder(), "/.jumppad")
 }
 
-// JumppadTemp returns a temporary folder
+// JumppadTemp returns a temporary folder for the current environment
 func JumppadTemp() string {
-	dir := filepath.Join(JumppadHome(), "/tmp")
+	dir := filepath.Join(EnvironmentHome(), "/tmp")
 	err := os.MkdirAll(dir, 0755)
 	if err != nil {
 		panic(err)
@@ -171,9 +185,16 @@ func JumppadTemp() string {
 }
 
 // StateDir returns the location of the jumppad
-// state, usually $HOME/.jumppad/state
+// state, usually $HOME/.jumppad/state, named environments store their state
+// in $HOME/.jumppad/environments/<name>/state
 func StateDir() string {
-	return filepath.Join(JumppadHome(), "/state")
+	return filepath.Join(EnvironmentHome(), "/state")
+}
+
+// ModulesDir returns the location where remote modules are cached,
+// usually $HOME/.jumppad/modules
+func ModulesDir() string {
+	return filepath.Join(JumppadHome(), "/modules")
 }
 
 // PluginsDir returns the location of the plugins
@@ -198,22 +219,61 @@ func CertsDir(name string) string {
 // LogsDir returns the location of the logs
 // used to secure the Jumppad ingress, usually $HOME/.jumppad/logs
 func LogsDir() string {
-	logs := filepath.Join(JumppadHome(), "/logs")
+	logs := filepath.Join(EnvironmentHome(), "/logs")
 
 	os.MkdirAll(logs, os.ModePerm)
 	return logs
 }
 
+// SnapshotsDir returns the location where environment snapshots are stored,
+// usually $HOME/.jumppad/snapshots
+func SnapshotsDir() string {
+	snapshots := filepath.Join(JumppadHome(), "/snapshots")
+
+	os.MkdirAll(snapshots, os.ModePerm)
+	return snapshots
+}
+
+// ArtifactsDir returns the location of the store for downloaded blueprints,
+// modules, Helm charts and files, usually $HOME/.jumppad/artifacts
+func ArtifactsDir() string {
+	return filepath.Join(JumppadHome(), "/artifacts")
+}
+
 // StatePath returns the full path for the state file
 func StatePath() string {
 	return filepath.Join(StateDir(), "/state.json")
 }
 
+// SecretsKeyPath returns the full path for the key used to encrypt
+// secrets in the state
+func SecretsKeyPath() string {
+	return filepath.Join(JumppadHome(), "/secrets.key")
+}
+
+// APITokenPath returns the full path for the token used to authenticate
+// requests to the local API, usually $HOME/.jumppad/api.token
+func APITokenPath() string {
+	return filepath.Join(JumppadHome(), "/api.token")
+}
+
+// SettingsPath returns the location of the per user settings file,
+// usually $HOME/.jumppad/config.hcl
+func SettingsPath() string {
+	return filepath.Join(JumppadHome(), "/config.hcl")
+}
+
 // ImageCacheLog returns the location of the image cache log
 func ImageCacheLog() string {
 	return fmt.Sprintf("%s/images.log", JumppadHome())
 }
 
+// ImageSetsLog returns the location of the file that records the images
+// used by each blueprint
+func ImageSetsLog() string {
+	return filepath.Join(JumppadHome(), "/image_sets.json")
+}
+
 // IsLocalFolder tests if the given path is a localfolder and can
 // exist in the current filesystem
 // TODO make more robust with error messages
@@ -299,7 +359,7 @@ func ReleasesFolder() string {
 
 // DataFolder creates the data directory used by the application
 func DataFolder(p string, perms os.FileMode) string {
-	data := filepath.Join(JumppadHome(), "data", p)
+	data := filepath.Join(EnvironmentHome(), "data", p)
 
 	// create the folder if it does not exist
 	os.MkdirAll(data, perms)
@@ -323,7 +383,7 @@ func CacheFolder(p string, perms os.FileMode) string {
 // LibraryFolder creates the library directory used by the application
 func LibraryFolder(p string, perms os.FileMode) string {
 	p = sanitize.Path(p)
-	data := filepath.Join(JumppadHome(), "library", p)
+	data := filepath.Join(EnvironmentHome(), "library", p)
 
 	// create the folder if it does not exist
 	os.MkdirAll(data, perms)
@@ -371,6 +431,12 @@ func GetConnectorLogFile() string {
 	return filepath.Join(LogsDir(), "connector.log")
 }
 
+// ResourceLogFile returns the log file that the provider logs for the
+// resource with the given id are written to
+func ResourceLogFile(id string) string {
+	return filepath.Join(LogsDir(), fmt.Sprintf("%s.log", id))
+}
+
 // GetJumppadBinaryPath returns the path to the running Jumppad binary
 func GetJumppadBinaryPath() string {
 	exe, _ := os.Executable()
@@ -432,7 +498,7 @@ func ImageCacheAddress() string {
 		return p
 	}
 
-	return jumppadProxyAddress
+	return fmt.Sprintf("http://%s:%d", FQDN("default", "", "image_cache"), imageCachePort)
 }
 
 // get all ipaddresses in a subnet
@@ -448,6 +514,42 @@ func SubnetIPs(subnet string) ([]string, error) {
 	return ipList, nil
 }
 
+// ValidateSubnetIP checks that the given ip address is a valid host address
+// within the subnet, the network and broadcast addresses are not allowed
+func ValidateSubnetIP(ip, subnet string) error {
+	_, ipnet, err := net.ParseCIDR(subnet)
+	if err != nil {
+		return fmt.Errorf("invalid subnet %s: %w", subnet, err)
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return fmt.Errorf("%s is not a valid ip address", ip)
+	}
+
+	if !ipnet.Contains(addr) {
+		return fmt.Errorf("ip address %s is not in the subnet %s", ip, subnet)
+	}
+
+	if addr.Equal(ipnet.IP) {
+		return fmt.Errorf("ip address %s is the network address for the subnet %s", ip, subnet)
+	}
+
+	// IPv6 networks do not have a broadcast address
+	if v4 := ipnet.IP.To4(); v4 != nil {
+		broadcast := make(net.IP, len(v4))
+		for i := range v4 {
+			broadcast[i] = v4[i] | ^ipnet.Mask[i]
+		}
+
+		if addr.Equal(broadcast) {
+			return fmt.Errorf("ip address %s is the broadcast address for the subnet %s", ip, subnet)
+		}
+	}
+
+	return nil
+}
+
 // HashDir generates a hash of the given directory
 // optionally a list of arguments to be ignored can be passed
 // these arguments are expresed as a glob pattern
@@ -507,10 +609,7 @@ func RandomAvailablePort(from, to int) (int, error) {
 		port := rand.Intn(to-from) + from
 
 		// check if the port is available
-		ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
-		if err == nil {
-			ln.Close()
-
+		if PortAvailable("tcp", port) {
 			return port, nil
 		}
 	}
@@ -518,6 +617,52 @@ func RandomAvailablePort(from, to int) (int, error) {
 	return 0, fmt.Errorf("unable to find a free port in the range %d-%d", from, to)
 }
 
+// PortAvailable returns true when the given tcp or udp port can be bound
+// on the local machine
+func PortAvailable(protocol string, port int) bool {
+	addr := fmt.Sprintf(":%d", port)
+
+	if protocol == "udp" {
+		pc, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return false
+		}
+
+		pc.Close()
+		return true
+	}
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return false
+	}
+
+	ln.Close()
+	return true
+}
+
+// FreePort returns a tcp or udp port chosen by the operating system that is
+// currently free on the local machine
+func FreePort(protocol string) (int, error) {
+	if protocol == "udp" {
+		pc, err := net.ListenPacket("udp", ":0")
+		if err != nil {
+			return 0, fmt.Errorf("unable to find a free udp port: %w", err)
+		}
+		defer pc.Close()
+
+		return pc.LocalAddr().(*net.UDPAddr).Port, nil
+	}
+
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		return 0, fmt.Errorf("unable to find a free tcp port: %w", err)
+	}
+	defer ln.Close()
+
+	return ln.Addr().(*net.TCPAddr).Port, nil
+}
+
 func incIP(ip net.IP) net.IP {
 	// allocate a new IP
 	newIp := make(net.IP, len(ip))